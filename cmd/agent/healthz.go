@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+)
+
+// serveHealthz serves the supervisor's /healthz and Prometheus /metrics on HEALTHZ_ADDR,
+// if set, until ctx is done.
+// HEALTHZ_MAX_CONTACT_AGE optionally reports the supervisor unhealthy once the OpAMP
+// server has not been reached for longer than the given duration.
+func serveHealthz(ctx context.Context, logger *slog.Logger, sup *supervisor.Supervisor) error {
+	addr := os.Getenv("HEALTHZ_ADDR")
+	if addr == "" {
+		return nil
+	}
+	var maxContactAge time.Duration
+	if v := os.Getenv("HEALTHZ_MAX_CONTACT_AGE"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid HEALTHZ_MAX_CONTACT_AGE: %w", err)
+		}
+		maxContactAge = d
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /healthz", sup.HealthHandler(maxContactAge))
+	mux.Handle("GET /metrics", sup.MetricsHandler())
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		logger.With("addr", addr).Info("serving supervisor health")
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.With("err", err).Error("health server stopped")
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	return nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	bootstrapclient "github.com/otelfleet/otelfleet/pkg/bootstrap/client"
+	"github.com/otelfleet/otelfleet/pkg/keyring"
+)
+
+// keyringStore returns the store of the keys negotiated at bootstrap, kept in DATA_DIR or the
+// otelfleet directory of the user's config dir. KEYRING_KEY_FILE keeps the key encrypting
+// them outside of the data directory.
+func keyringStore() (*keyring.Store, error) {
+	dir := os.Getenv("DATA_DIR")
+	if dir == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf("no DATA_DIR set: %w", err)
+		}
+		dir = filepath.Join(configDir, "otelfleet")
+	}
+	store := keyring.NewStore(dir)
+	store.SetKeyFile(os.Getenv("KEYRING_KEY_FILE"))
+	return store, nil
+}
+
+// restoreTLSConfig returns the TLS configuration of a previous bootstrap, or false if the agent
+// has to bootstrap, because it never did or its client certificate expired
+func restoreTLSConfig(logger *slog.Logger, store *keyring.Store) (*tls.Config, bool, error) {
+	kr, err := store.Get()
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, fmt.Errorf("failed to load keyring: %w", err)
+	}
+	tlsConfig, err := bootstrapclient.TLSConfig(kr, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, cert := range tlsConfig.Certificates {
+		if time.Now().After(cert.Leaf.NotAfter) {
+			logger.With("expiry", cert.Leaf.NotAfter).Warn("client certificate expired, bootstrapping again")
+			return nil, false, nil
+		}
+	}
+	return tlsConfig, true, nil
+}
@@ -2,12 +2,21 @@ package main
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
+	"github.com/otelfleet/otelfleet/pkg/bootstrap"
 	bootstrapclient "github.com/otelfleet/otelfleet/pkg/bootstrap/client"
 	"github.com/otelfleet/otelfleet/pkg/ident"
+	"github.com/otelfleet/otelfleet/pkg/keyring"
 	_ "github.com/otelfleet/otelfleet/pkg/logutil"
 	"github.com/otelfleet/otelfleet/pkg/supervisor"
 	"github.com/otelfleet/otelfleet/pkg/util/contextutil"
@@ -15,42 +24,189 @@ import (
 
 const (
 	gatewayAddr = "http://127.0.0.1:16587"
-	opAmpAddr   = "ws://127.0.0.1:4320/v1/opamp"
+	// defaultOpAmpAddr is the OpAMP endpoint of a server in single-listener mode,
+	// OPAMP_ADDR overrides it for servers with a dedicated OpAMP listener
+	defaultOpAmpAddr = "ws://127.0.0.1:16587/v1/opamp"
 )
 
 func main() {
 	logger := slog.Default()
+	// subcommands manage the agent's registration with the host's service manager
+	if len(os.Args) > 1 {
+		if err := runCommand(logger, os.Args[1], os.Args[2:]); err != nil && !errors.Is(err, flag.ErrHelp) {
+			logger.With("err", err).Error("command failed", "command", os.Args[1])
+			os.Exit(1)
+		}
+		return
+	}
+
+	if ok, err := runAsService(runAgent); ok || err != nil {
+		if err != nil {
+			logger.With("err", err).Error("otelfleet agent service failed")
+			os.Exit(1)
+		}
+		return
+	}
+
 	ctx := contextutil.SetupSignals(context.Background())
+	if err := runAgent(ctx, logger); err != nil {
+		logger.With("err", err).Error("otelfleet agent failed")
+		os.Exit(1)
+	}
+}
 
+// runAgent enrolls the agent and runs the supervisor until ctx is done
+func runAgent(ctx context.Context, logger *slog.Logger) error {
 	bootstrapToken := os.Getenv("BOOTSTRAP_TOKEN")
 	agentName := os.Getenv("AGENT_NAME")
+	serverURL := gatewayAddr
+	if v := os.Getenv("SERVER_URL"); v != "" {
+		serverURL = v
+	}
+	// pre-signed enrollment URLs carry both the server address and a single-use token
+	if v := os.Getenv("ENROLLMENT_URL"); v != "" {
+		baseURL, signed, err := bootstrap.ParseEnrollmentURL(v)
+		if err != nil {
+			return fmt.Errorf("invalid ENROLLMENT_URL: %w", err)
+		}
+		serverURL, bootstrapToken = baseURL, bootstrap.EnrollmentAuthorization(signed)
+	}
+
+	var (
+		agentID   ident.Identity
+		tlsConfig *tls.Config
+		err       error
+	)
+	if os.Getenv("SPIFFE_SVID_CERT") != "" {
+		agentID, tlsConfig, err = enrollWithSVID(ctx, logger, agentName)
+		if err != nil {
+			return fmt.Errorf("failed to enroll agent with SVID: %w", err)
+		}
+	} else {
+		store, err := keyringStore()
+		if err != nil {
+			return err
+		}
+		var restored bool
+		tlsConfig, restored, err = restoreTLSConfig(logger, store)
+		if err != nil {
+			return err
+		}
+		if restored {
+			logger.Info("restored credentials of a previous bootstrap")
+			agentID, err = agentIdentity(agentName)
+		} else {
+			agentID, tlsConfig, err = bootstrapWithToken(ctx, logger, store, serverURL, agentName, bootstrapToken)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	opAmpAddr := defaultOpAmpAddr
+	if serverURL != gatewayAddr {
+		opAmpAddr, err = bootstrap.OpAMPURL(serverURL)
+		if err != nil {
+			return fmt.Errorf("invalid SERVER_URL: %w", err)
+		}
+	}
+	if v := os.Getenv("OPAMP_ADDR"); v != "" {
+		opAmpAddr = v
+	}
+	// labels are reported as non-identifying attributes, e.g. AGENT_LABELS=env=prod,region=eu
+	labels, err := bootstrap.ParseLabels(os.Getenv("AGENT_LABELS"))
+	if err != nil {
+		return fmt.Errorf("invalid AGENT_LABELS: %w", err)
+	}
+	watchdogThreshold := supervisor.DefaultWatchdogThreshold
+	if v := os.Getenv("WATCHDOG_THRESHOLD"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid WATCHDOG_THRESHOLD: %w", err)
+		}
+		watchdogThreshold = d
+	}
+	// collector upgrades offered by the server are kept in PACKAGE_DIR, and must be signed with
+	// the ed25519 key whose base64 encoded public key is PACKAGE_VERIFY_KEY, if set
+	var packages *supervisor.PackageStore
+	if dir := os.Getenv("PACKAGE_DIR"); dir != "" {
+		var verifyKey ed25519.PublicKey
+		if v := os.Getenv("PACKAGE_VERIFY_KEY"); v != "" {
+			key, err := base64.StdEncoding.DecodeString(v)
+			if err != nil || len(key) != ed25519.PublicKeySize {
+				return errors.New("invalid PACKAGE_VERIFY_KEY: expected a base64 encoded ed25519 public key")
+			}
+			verifyKey = key
+		}
+		packages, err = supervisor.NewPackageStore(dir, verifyKey)
+		if err != nil {
+			return fmt.Errorf("failed to open package store: %w", err)
+		}
+	}
+
+	supervisor := supervisor.NewSupervisorWithProcManager(
+		logger.With("component", "supervisor"),
+		tlsConfig,
+		opAmpAddr,
+		agentID,
+		supervisor.ExtraAttributes{NonIdentifying: labels},
+	)
+	supervisor.SetWatchdogThreshold(watchdogThreshold)
+	if packages != nil {
+		supervisor.SetPackageStore(packages)
+	}
+	logger.With("agentID", agentID.UniqueIdentifier().UUID).Info("otelfleet agent starting...")
+	if err := supervisor.Start(); err != nil {
+		return fmt.Errorf("failed to start supervisor: %w", err)
+	}
+	if err := serveHealthz(ctx, logger.With("component", "healthz"), supervisor); err != nil {
+		return fmt.Errorf("failed to serve supervisor health: %w", err)
+	}
+	notifyServiceReady(logger)
+
+	<-ctx.Done()
+	logger.Info("shutting down otelfleet agent...")
+	notifyServiceStopping(logger)
+	if err := supervisor.Shutdown(); err != nil {
+		return fmt.Errorf("failed to shutdown supervisor: %w", err)
+	}
+	return nil
+}
 
+// agentIdentity returns the identity of the agent on this host
+func agentIdentity(agentName string) (ident.Identity, error) {
+	// FIXME: this only accounts for baremetal environments.
+	// I need to extend this for container / kubernetes
+	// but I think I'd rather keep imports on those things in a separate repo
+	// since they're more of a known compile time thing, and can come with dependency hell and
+	// binary bloat.
+	// Perhaps the API to construct agents can live here, but agent builds and capabilities
+	// are registered in an out-of-scope repo?
+	return ident.IdFromMac(sha256.New(), agentName)
+}
+
+// bootstrapWithToken bootstraps the agent and persists the negotiated keys in store, so that
+// the agent doesn't need to bootstrap again when it restarts
+func bootstrapWithToken(ctx context.Context, logger *slog.Logger, store *keyring.Store, serverURL, agentName, bootstrapToken string) (ident.Identity, *tls.Config, error) {
 	// Create bootstrap client using shared package
 	// isSecureMode() is defined in insecure.go or secure.go based on build tags
 	client := bootstrapclient.New(
 		bootstrapclient.Config{
 			Logger:    logger.With("component", "bootstrapper").With("agent-name", agentName).With("token", bootstrapToken),
-			ServerURL: gatewayAddr,
+			ServerURL: serverURL,
 		},
 		isSecureMode(),
 	)
 
 	if err := client.VerifyToken(ctx, bootstrapToken); err != nil {
 		logger.With("err", err).Error("failed to verify bootstrap token")
-		os.Exit(1)
+		return nil, nil, err
 	}
 
-	// FIXME: this only accounts for baremetal environments.
-	// I need to extend this for container / kubernetes
-	// but I think I'd rather keep imports on those things in a separate repo
-	// since they're more of a known compile time thing, and can come with dependency hell and
-	// binary bloat.
-	// Perhaps the API to construct agents can live here, but agent builds and capabilities
-	// are registered in an out-of-scope repo?
-	agentID, err := ident.IdFromMac(sha256.New(), agentName)
+	agentID, err := agentIdentity(agentName)
 	if err != nil {
 		logger.With("err", err).Error("failed to get agent identity")
-		os.Exit(1)
+		return nil, nil, err
 	}
 
 	// FIXME: backoff retry
@@ -58,26 +214,12 @@ func main() {
 	result, err := client.BootstrapAgent(ctx, agentID, agentName, bootstrapToken)
 	if err != nil {
 		logger.With("err", err).Error("failed to bootstrap agent")
-		os.Exit(1)
+		return nil, nil, err
 	}
-
-	supervisor := supervisor.NewSupervisorWithProcManager(
-		slog.Default().With("component", "supervisor"),
-		result.TLSConfig,
-		opAmpAddr,
-		agentID,
-		supervisor.ExtraAttributes{},
-	)
-	logger.With("agentID", agentID.UniqueIdentifier().UUID).Info("otelfleet agent starting...")
-	if err := supervisor.Start(); err != nil {
-		logger.With("err", err.Error()).Error("failed to start supervisor")
-		os.Exit(1)
-	}
-
-	<-ctx.Done()
-	logger.Info("shutting down otelfleet agent...")
-	if err := supervisor.Shutdown(); err != nil {
-		logger.With("err", err.Error()).Error("failed to shutdown supervisor")
-		os.Exit(1)
+	if result.Keyring != nil {
+		if err := store.Put(result.Keyring); err != nil {
+			return nil, nil, fmt.Errorf("failed to persist keyring: %w", err)
+		}
 	}
+	return agentID, result.TLSConfig, nil
 }
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const defaultServiceName = "otelfleet-agent"
+
+// serviceNameEnv is set in the environment of installed services, so the agent knows
+// the name it was registered under
+const serviceNameEnv = "OTELFLEET_SERVICE_NAME"
+
+// agentEnvVars configure the agent, and are recorded in the service environment
+// when set at install time
+var agentEnvVars = []string{
+	"BOOTSTRAP_TOKEN",
+	"AGENT_NAME",
+	"AGENT_LABELS",
+	"SERVER_URL",
+	"ENROLLMENT_URL",
+	"OPAMP_ADDR",
+	"WATCHDOG_THRESHOLD",
+	"HEALTHZ_ADDR",
+	"HEALTHZ_MAX_CONTACT_AGE",
+	"PACKAGE_DIR",
+	"PACKAGE_VERIFY_KEY",
+	"DATA_DIR",
+	"KEYRING_KEY_FILE",
+	"SPIFFE_SVID_CERT",
+	"SPIFFE_SVID_KEY",
+	"SPIFFE_BUNDLE_PATH",
+}
+
+// serviceConfig describes the service registered with the host's service manager
+type serviceConfig struct {
+	Name       string
+	Executable string
+	// User the service runs as, the service manager's default when empty
+	User string
+	// Env is the environment of the agent, sorted by key
+	Env [][2]string
+	// Start starts the service once registered
+	Start bool
+}
+
+// runCommand runs a subcommand of the agent binary
+func runCommand(logger *slog.Logger, command string, args []string) error {
+	switch command {
+	case "install":
+		cfg, err := parseInstallArgs(args, os.LookupEnv)
+		if err != nil {
+			return err
+		}
+		if err := installService(cfg); err != nil {
+			return fmt.Errorf("failed to install service %s: %w", cfg.Name, err)
+		}
+		logger.With("service", cfg.Name, "executable", cfg.Executable).Info("installed otelfleet agent service")
+	case "uninstall":
+		fs := flag.NewFlagSet("uninstall", flag.ContinueOnError)
+		name := fs.String("name", defaultServiceName, "name of the service to remove")
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+		if err := uninstallService(*name); err != nil {
+			return fmt.Errorf("failed to uninstall service %s: %w", *name, err)
+		}
+		logger.With("service", *name).Info("uninstalled otelfleet agent service")
+	default:
+		return fmt.Errorf("unknown command %q, expected install or uninstall", command)
+	}
+	return nil
+}
+
+// parseInstallArgs parses the flags of the install command. The agent variables set in
+// the environment are recorded, -env flags add to or override them.
+func parseInstallArgs(args []string, lookupEnv func(string) (string, bool)) (serviceConfig, error) {
+	fs := flag.NewFlagSet("install", flag.ContinueOnError)
+	cfg := serviceConfig{}
+	fs.StringVar(&cfg.Name, "name", defaultServiceName, "name of the service")
+	fs.StringVar(&cfg.Executable, "executable", "", "path of the agent binary, defaults to the running one")
+	fs.StringVar(&cfg.User, "user", "", "account to run the service as, the service manager's default when empty")
+	noStart := fs.Bool("no-start", false, "register the service without starting it")
+	env := map[string]string{}
+	fs.Func("env", "KEY=VALUE set in the agent environment, may be repeated", func(v string) error {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("expected KEY=VALUE, got %q", v)
+		}
+		env[key] = value
+		return nil
+	})
+	for _, key := range agentEnvVars {
+		if v, ok := lookupEnv(key); ok && v != "" {
+			env[key] = v
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return serviceConfig{}, err
+	}
+	if fs.NArg() > 0 {
+		return serviceConfig{}, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if cfg.Name == "" || strings.ContainsAny(cfg.Name, `/\ `) {
+		return serviceConfig{}, fmt.Errorf("invalid service name %q", cfg.Name)
+	}
+	if cfg.Executable == "" {
+		exe, err := os.Executable()
+		if err != nil {
+			return serviceConfig{}, fmt.Errorf("failed to find the agent binary: %w", err)
+		}
+		cfg.Executable = exe
+	}
+	exe, err := filepath.Abs(cfg.Executable)
+	if err != nil {
+		return serviceConfig{}, err
+	}
+	cfg.Executable = exe
+	cfg.Start = !*noStart
+
+	env[serviceNameEnv] = cfg.Name
+	for key, value := range env {
+		cfg.Env = append(cfg.Env, [2]string{key, value})
+	}
+	slices.SortFunc(cfg.Env, func(a, b [2]string) int {
+		return strings.Compare(a[0], b[0])
+	})
+	return cfg, nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/coreos/go-systemd/v22/daemon"
+)
+
+const (
+	systemdUnitDir = "/etc/systemd/system"
+	agentEnvDir    = "/etc/otelfleet"
+)
+
+// systemdUnitTemplate is the unit of the agent service. Only the supervisor receives
+// SIGTERM and stops the collector itself, the remaining processes are only killed once
+// TimeoutStopSec expires. The agent keeps its identity and configs under
+// XDG_CONFIG_HOME, kept in the state directory managed by systemd.
+var systemdUnitTemplate = template.Must(template.New("unit").Parse(`[Unit]
+Description=otelfleet agent
+Documentation=https://github.com/otelfleet/otelfleet
+Wants=network-online.target
+After=network-online.target
+StartLimitIntervalSec=300
+StartLimitBurst=5
+
+[Service]
+Type=notify
+ExecStart={{ printf "%q" .Executable }}
+EnvironmentFile={{ .EnvFile }}
+Environment=XDG_CONFIG_HOME=/var/lib/{{ .Name }}
+StateDirectory={{ .Name }}
+{{- if .User }}
+User={{ .User }}
+{{- end }}
+Restart=on-failure
+RestartSec=5s
+KillMode=mixed
+KillSignal=SIGTERM
+TimeoutStopSec=30s
+StandardOutput=journal
+StandardError=journal
+SyslogIdentifier={{ .Name }}
+
+[Install]
+WantedBy=multi-user.target
+`))
+
+func systemdUnitPath(name string) string {
+	return filepath.Join(systemdUnitDir, name+".service")
+}
+
+func agentEnvPath(name string) string {
+	return filepath.Join(agentEnvDir, name+".env")
+}
+
+// systemdUnit renders the unit of the agent service
+func systemdUnit(cfg serviceConfig) ([]byte, error) {
+	var buf bytes.Buffer
+	err := systemdUnitTemplate.Execute(&buf, struct {
+		serviceConfig
+		EnvFile string
+	}{cfg, agentEnvPath(cfg.Name)})
+	return buf.Bytes(), err
+}
+
+// systemdEnvFile renders the environment of the agent as a systemd EnvironmentFile
+func systemdEnvFile(env [][2]string) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, kv := range env {
+		if strings.ContainsAny(kv[1], "\r\n") {
+			return nil, fmt.Errorf("value of %s must be a single line", kv[0])
+		}
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(kv[1])
+		fmt.Fprintf(&buf, "%s=\"%s\"\n", kv[0], value)
+	}
+	return buf.Bytes(), nil
+}
+
+func installService(cfg serviceConfig) error {
+	unit, err := systemdUnit(cfg)
+	if err != nil {
+		return err
+	}
+	envFile, err := systemdEnvFile(cfg.Env)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(systemdUnitPath(cfg.Name)); err == nil {
+		return fmt.Errorf("%s already exists, uninstall the service first", systemdUnitPath(cfg.Name))
+	}
+	if err := os.MkdirAll(agentEnvDir, 0o755); err != nil {
+		return err
+	}
+	// the environment may hold the bootstrap token
+	if err := os.WriteFile(agentEnvPath(cfg.Name), envFile, 0o600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(systemdUnitPath(cfg.Name), unit, 0o644); err != nil {
+		return err
+	}
+	if err := systemctl("daemon-reload"); err != nil {
+		return err
+	}
+	args := []string{"enable", cfg.Name + ".service"}
+	if cfg.Start {
+		args = append(args, "--now")
+	}
+	return systemctl(args...)
+}
+
+func uninstallService(name string) error {
+	if _, err := os.Stat(systemdUnitPath(name)); err != nil {
+		return fmt.Errorf("service is not installed: %w", err)
+	}
+	if err := systemctl("disable", "--now", name+".service"); err != nil {
+		return err
+	}
+	for _, path := range []string{systemdUnitPath(name), agentEnvPath(name)} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return systemctl("daemon-reload")
+}
+
+func systemctl(args ...string) error {
+	out, err := exec.Command("systemctl", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("systemctl %s: %w: %s", strings.Join(args, " "), err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// runAsService returns false, systemd services run the agent like any other process
+func runAsService(func(context.Context, *slog.Logger) error) (bool, error) {
+	return false, nil
+}
+
+// notifyServiceReady tells systemd the agent started, when run as a notify service
+func notifyServiceReady(logger *slog.Logger) {
+	sdNotify(logger, daemon.SdNotifyReady)
+}
+
+// notifyServiceStopping tells systemd the agent is shutting down
+func notifyServiceStopping(logger *slog.Logger) {
+	sdNotify(logger, daemon.SdNotifyStopping)
+}
+
+func sdNotify(logger *slog.Logger, state string) {
+	if _, err := daemon.SdNotify(false, state); err != nil {
+		logger.With("err", err, "state", state).Warn("failed to notify systemd")
+	}
+}
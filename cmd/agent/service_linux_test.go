@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemdUnit(t *testing.T) {
+	cfg := serviceConfig{
+		Name:       "otelfleet-agent",
+		Executable: "/opt/otelfleet/bin/otelfleet agent",
+		User:       "otelfleet",
+	}
+	unit, err := systemdUnit(cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(unit), "Type=notify\n")
+	assert.Contains(t, string(unit), `ExecStart="/opt/otelfleet/bin/otelfleet agent"`+"\n")
+	assert.Contains(t, string(unit), "EnvironmentFile=/etc/otelfleet/otelfleet-agent.env\n")
+	assert.Contains(t, string(unit), "StateDirectory=otelfleet-agent\n")
+	assert.Contains(t, string(unit), "User=otelfleet\nRestart=on-failure\n")
+	assert.Contains(t, string(unit), "SyslogIdentifier=otelfleet-agent\n")
+
+	cfg.User = ""
+	unit, err = systemdUnit(cfg)
+	require.NoError(t, err)
+	assert.NotContains(t, string(unit), "User=")
+	assert.Contains(t, string(unit), "StateDirectory=otelfleet-agent\nRestart=on-failure\n")
+}
+
+func TestSystemdEnvFile(t *testing.T) {
+	envFile, err := systemdEnvFile([][2]string{
+		{"AGENT_LABELS", "env=prod"},
+		{"AGENT_NAME", `say "hi" \o/`},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "AGENT_LABELS=\"env=prod\"\nAGENT_NAME=\"say \\\"hi\\\" \\\\o/\"\n", string(envFile))
+
+	_, err = systemdEnvFile([][2]string{{"AGENT_NAME", "two\nlines"}})
+	assert.Error(t, err)
+}
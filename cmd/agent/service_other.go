@@ -0,0 +1,26 @@
+//go:build !linux && !windows
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+func installService(serviceConfig) error {
+	return errors.ErrUnsupported
+}
+
+func uninstallService(string) error {
+	return errors.ErrUnsupported
+}
+
+// runAsService returns false, the agent only runs as a service on linux and windows
+func runAsService(func(context.Context, *slog.Logger) error) (bool, error) {
+	return false, nil
+}
+
+func notifyServiceReady(*slog.Logger) {}
+
+func notifyServiceStopping(*slog.Logger) {}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInstallArgs(t *testing.T) {
+	env := map[string]string{
+		"BOOTSTRAP_TOKEN": "token",
+		"AGENT_LABELS":    "env=prod",
+		"HOME":            "/root",
+	}
+	lookupEnv := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+
+	cfg, err := parseInstallArgs([]string{
+		"-executable", "/usr/local/bin/otelfleet-agent",
+		"-env", "AGENT_LABELS=env=dev",
+		"-env", "SERVER_URL=https://otelfleet:16587",
+	}, lookupEnv)
+	require.NoError(t, err)
+	assert.Equal(t, serviceConfig{
+		Name:       defaultServiceName,
+		Executable: "/usr/local/bin/otelfleet-agent",
+		Env: [][2]string{
+			{"AGENT_LABELS", "env=dev"},
+			{"BOOTSTRAP_TOKEN", "token"},
+			{serviceNameEnv, defaultServiceName},
+			{"SERVER_URL", "https://otelfleet:16587"},
+		},
+		Start: true,
+	}, cfg)
+
+	cfg, err = parseInstallArgs([]string{"-name", "collector", "-no-start"}, lookupEnv)
+	require.NoError(t, err)
+	assert.Equal(t, "collector", cfg.Name)
+	assert.False(t, cfg.Start)
+	assert.NotEmpty(t, cfg.Executable)
+	assert.Contains(t, cfg.Env, [2]string{serviceNameEnv, "collector"})
+
+	for _, args := range [][]string{
+		{"-env", "NOVALUE"},
+		{"-name", "a/b"},
+		{"extra"},
+	} {
+		_, err := parseInstallArgs(args, lookupEnv)
+		assert.Error(t, err, args)
+	}
+}
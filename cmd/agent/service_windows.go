@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/windows/registry"
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/eventlog"
+	"golang.org/x/sys/windows/svc/mgr"
+)
+
+// the agent is restarted after failures, the failure count resets after a day
+var serviceRecoveryActions = []mgr.RecoveryAction{
+	{Type: mgr.ServiceRestart, Delay: 5 * time.Second},
+	{Type: mgr.ServiceRestart, Delay: 30 * time.Second},
+	{Type: mgr.ServiceRestart, Delay: time.Minute},
+}
+
+const serviceRecoveryResetPeriod = 24 * 60 * 60
+
+func installService(cfg serviceConfig) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	if s, err := m.OpenService(cfg.Name); err == nil {
+		s.Close()
+		return fmt.Errorf("service %s already exists, uninstall it first", cfg.Name)
+	}
+	s, err := m.CreateService(cfg.Name, cfg.Executable, mgr.Config{
+		DisplayName:      "otelfleet agent",
+		Description:      "Supervises the OpenTelemetry collector managed by otelfleet",
+		StartType:        mgr.StartAutomatic,
+		DelayedAutoStart: true,
+		ServiceStartName: cfg.User,
+	})
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	if err := s.SetRecoveryActions(serviceRecoveryActions, serviceRecoveryResetPeriod); err != nil {
+		return errors.Join(err, s.Delete())
+	}
+	// the agent exits with an error, rather than crashing, when it cannot run
+	if err := s.SetRecoveryActionsOnNonCrashFailures(true); err != nil {
+		return errors.Join(err, s.Delete())
+	}
+	if err := setServiceEnvironment(cfg.Name, cfg.Env); err != nil {
+		return errors.Join(err, s.Delete())
+	}
+	err = eventlog.InstallAsEventCreate(cfg.Name, eventlog.Error|eventlog.Warning|eventlog.Info)
+	if err != nil && !strings.Contains(err.Error(), "registry key already exists") {
+		return errors.Join(fmt.Errorf("failed to register event log source: %w", err), s.Delete())
+	}
+	if cfg.Start {
+		return s.Start()
+	}
+	return nil
+}
+
+// setServiceEnvironment sets the environment the service control manager starts the
+// agent with
+func setServiceEnvironment(name string, env [][2]string) error {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Services\`+name, registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+	values := make([]string, 0, len(env))
+	for _, kv := range env {
+		values = append(values, kv[0]+"="+kv[1])
+	}
+	return k.SetStringsValue("Environment", values)
+}
+
+func uninstallService(name string) error {
+	m, err := mgr.Connect()
+	if err != nil {
+		return err
+	}
+	defer m.Disconnect()
+
+	s, err := m.OpenService(name)
+	if err != nil {
+		return fmt.Errorf("service is not installed: %w", err)
+	}
+	defer s.Close()
+	if status, err := s.Query(); err == nil && status.State != svc.Stopped {
+		if _, err := s.Control(svc.Stop); err != nil {
+			return fmt.Errorf("failed to stop service: %w", err)
+		}
+	}
+	if err := s.Delete(); err != nil {
+		return err
+	}
+	return eventlog.Remove(name)
+}
+
+// runAsService runs the agent under the service control manager if it started the
+// process, reporting to the Windows Event Log
+func runAsService(run func(context.Context, *slog.Logger) error) (bool, error) {
+	isService, err := svc.IsWindowsService()
+	if err != nil || !isService {
+		return false, err
+	}
+	name := os.Getenv(serviceNameEnv)
+	if name == "" {
+		name = defaultServiceName
+	}
+	elog, err := eventlog.Open(name)
+	if err != nil {
+		return true, err
+	}
+	defer elog.Close()
+
+	logger := slog.New(newEventLogHandler(elog))
+	slog.SetDefault(logger)
+	return true, svc.Run(name, &agentService{run: run, logger: logger})
+}
+
+// agentService runs the agent until the service control manager stops it
+type agentService struct {
+	run    func(context.Context, *slog.Logger) error
+	logger *slog.Logger
+}
+
+func (a *agentService) Execute(_ []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	changes <- svc.Status{State: svc.StartPending}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- a.run(ctx, a.logger)
+	}()
+	status := svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+	changes <- status
+
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				a.logger.With("err", err).Error("otelfleet agent failed")
+				// a non-zero exit code triggers the recovery actions
+				return false, 1
+			}
+			return false, 0
+		case req := <-requests:
+			switch req.Cmd {
+			case svc.Interrogate:
+				changes <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				changes <- svc.Status{State: svc.StopPending}
+				cancel()
+			}
+		}
+	}
+}
+
+// eventLogHandler formats records as text and reports them to the Windows Event Log
+type eventLogHandler struct {
+	handler slog.Handler
+	log     *eventlog.Log
+	mu      *sync.Mutex
+	buf     *bytes.Buffer
+}
+
+func newEventLogHandler(log *eventlog.Log) *eventLogHandler {
+	buf := &bytes.Buffer{}
+	return &eventLogHandler{
+		handler: slog.NewTextHandler(buf, nil),
+		log:     log,
+		mu:      &sync.Mutex{},
+		buf:     buf,
+	}
+}
+
+func (h *eventLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *eventLogHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.buf.Reset()
+	if err := h.handler.Handle(ctx, r); err != nil {
+		return err
+	}
+	msg := strings.TrimSpace(h.buf.String())
+	switch {
+	case r.Level >= slog.LevelError:
+		return h.log.Error(1, msg)
+	case r.Level >= slog.LevelWarn:
+		return h.log.Warning(1, msg)
+	default:
+		return h.log.Info(1, msg)
+	}
+}
+
+func (h *eventLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &eventLogHandler{handler: h.handler.WithAttrs(attrs), log: h.log, mu: h.mu, buf: h.buf}
+}
+
+func (h *eventLogHandler) WithGroup(name string) slog.Handler {
+	return &eventLogHandler{handler: h.handler.WithGroup(name), log: h.log, mu: h.mu, buf: h.buf}
+}
+
+// notifyServiceReady does nothing, the service status is reported by agentService
+func notifyServiceReady(*slog.Logger) {}
+
+// notifyServiceStopping does nothing, the service status is reported by agentService
+func notifyServiceStopping(*slog.Logger) {}
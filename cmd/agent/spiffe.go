@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+
+	bootstrapclient "github.com/otelfleet/otelfleet/pkg/bootstrap/client"
+	"github.com/otelfleet/otelfleet/pkg/ident"
+	"github.com/otelfleet/otelfleet/pkg/spiffe"
+)
+
+const secureGatewayAddr = "https://127.0.0.1:16587"
+
+// enrollWithSVID enrolls the agent using the X.509 SVID at SPIFFE_SVID_CERT / SPIFFE_SVID_KEY,
+// as issued by the SPIRE agent, instead of a bootstrap token.
+func enrollWithSVID(ctx context.Context, logger *slog.Logger, agentName string) (ident.Identity, *tls.Config, error) {
+	tlsConfig, err := spiffe.ClientTLSConfig(
+		os.Getenv("SPIFFE_SVID_CERT"),
+		os.Getenv("SPIFFE_SVID_KEY"),
+		os.Getenv("SPIFFE_BUNDLE_PATH"),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	id, err := spiffe.IDFromTLSConfig(tlsConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	agentID := spiffe.AgentID(id)
+
+	client := bootstrapclient.New(
+		bootstrapclient.Config{
+			Logger:    logger.With("component", "bootstrapper").With("agent-name", agentName).With("spiffe-id", id.String()),
+			ServerURL: secureGatewayAddr,
+			HTTPClient: &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: tlsConfig,
+				},
+			},
+		},
+		isSecureMode(),
+	)
+	result, err := client.Enroll(ctx, agentName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if result.AgentID != agentID.UniqueIdentifier().UUID {
+		return nil, nil, fmt.Errorf("server derived agent id %s does not match local agent id %s", result.AgentID, agentID.UniqueIdentifier().UUID)
+	}
+	return agentID, tlsConfig, nil
+}
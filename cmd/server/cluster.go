@@ -0,0 +1,12 @@
+package main
+
+import (
+	"github.com/otelfleet/otelfleet/pkg/config"
+)
+
+// clusterConfigFromEnv runs the server as the replica CLUSTER_REPLICA_ID of the replicas sharing
+// its storage, whose leader renews its lease within CLUSTER_LEASE_TTL
+func clusterConfigFromEnv(cfg *config.ClusterConfig) error {
+	stringFromEnv("CLUSTER_REPLICA_ID", &cfg.ReplicaID)
+	return durationFromEnv("CLUSTER_LEASE_TTL", &cfg.LeaseTTL)
+}
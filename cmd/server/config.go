@@ -0,0 +1,150 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/config"
+	"github.com/otelfleet/otelfleet/pkg/features"
+	"github.com/otelfleet/otelfleet/pkg/services/otelconfig"
+)
+
+// defaultStoragePath is the embedded database used unless a storage path is configured
+const defaultStoragePath = "./otelfleet.kv"
+
+// loadConfig reads the config of the server from the YAML file set by -config.file, overridden
+// by the environment, itself overridden by the flags set in args
+func loadConfig(name string, args []string) (config.Config, error) {
+	var path string
+	// find the config file first, the flags are parsed again once it is loaded
+	pre := flag.NewFlagSet(name, flag.ContinueOnError)
+	pre.SetOutput(io.Discard)
+	pre.StringVar(&path, "config.file", "", "")
+	(&config.Config{}).RegisterFlags(pre)
+	_ = pre.Parse(args)
+
+	cfg := config.Config{StoragePath: defaultStoragePath}
+	if path != "" {
+		if err := config.LoadFile(path, &cfg); err != nil {
+			return cfg, err
+		}
+	}
+	if err := configFromEnv(&cfg); err != nil {
+		return cfg, err
+	}
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.StringVar(&path, "config.file", path, "YAML config file, overridden by the environment and the flags")
+	cfg.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// configFromEnv overrides cfg with the environment variables that are set
+func configFromEnv(cfg *config.Config) error {
+	// STORAGE_PATH selects the storage backend, see NewStorageService of pkg/services/storage
+	stringFromEnv("STORAGE_PATH", &cfg.StoragePath)
+	stringFromEnv("HTTP_TLS_CERT_PATH", &cfg.HTTPTLSCertPath)
+	stringFromEnv("HTTP_TLS_KEY_PATH", &cfg.HTTPTLSKeyPath)
+	// DEV_TLS=true serves TLS with a generated development certificate, kept in DEV_TLS_DIR
+	if os.Getenv("DEV_TLS") == "true" && cfg.DevTLSDir == "" {
+		cfg.DevTLSDir = "./otelfleet.tls"
+	}
+	stringFromEnv("DEV_TLS_DIR", &cfg.DevTLSDir)
+	stringFromEnv("AGENT_MTLS_CA_CERT_PATH", &cfg.AgentMTLS.CACertPath)
+	stringFromEnv("AGENT_MTLS_CA_KEY_PATH", &cfg.AgentMTLS.CAKeyPath)
+	boolFromEnv("AGENT_MTLS_REQUIRE", &cfg.AgentMTLS.Require)
+	stringFromEnv("AGENT_METRICS_ENDPOINT", &cfg.AgentMetrics.Endpoint)
+	boolFromEnv("AGENT_METRICS_RECEIVE", &cfg.AgentMetrics.Receive)
+	stringFromEnv("EXTERNAL_URL", &cfg.ExternalURL)
+	stringFromEnv("UI_PATH", &cfg.UIPath)
+	stringFromEnv("SPIFFE_TRUST_DOMAIN", &cfg.SPIFFE.TrustDomain)
+	stringFromEnv("SPIFFE_BUNDLE_PATH", &cfg.SPIFFE.BundlePath)
+	stringFromEnv("VAULT_ADDR", &cfg.Vault.Address)
+	stringFromEnv("VAULT_TOKEN", &cfg.Vault.Token)
+	stringFromEnv("VAULT_NAMESPACE", &cfg.Vault.Namespace)
+	boolFromEnv("TRUST_PROXY_HEADERS", &cfg.Auth.TrustProxyHeaders)
+	boolFromEnv("AUTH_RBAC", &cfg.Auth.RBAC)
+	if v := os.Getenv("AUTH_ADMINS"); v != "" {
+		cfg.Auth.Admins = strings.Split(v, ",")
+	}
+	stringFromEnv("OIDC_ISSUER_URL", &cfg.Auth.OIDC.IssuerURL)
+	stringFromEnv("OIDC_AUDIENCE", &cfg.Auth.OIDC.Audience)
+	stringFromEnv("OIDC_SUBJECT_CLAIM", &cfg.Auth.OIDC.SubjectClaim)
+	stringFromEnv("OIDC_ROLES_CLAIM", &cfg.Auth.OIDC.RolesClaim)
+	stringFromEnv("DESIRED_STATE_TOKEN", &cfg.DesiredStateToken)
+	stringFromEnv("REPLICATION_TOKEN", &cfg.ReplicationToken)
+	stringFromEnv("NOTIFY_TRANSPORT", &cfg.Notify.Transport)
+	// the OTLP exporters read the other OTEL_EXPORTER_OTLP_ variables themselves, e.g. headers
+	stringFromEnv("OTEL_EXPORTER_OTLP_ENDPOINT", &cfg.Telemetry.Endpoint)
+	stringFromEnv("OTEL_EXPORTER_OTLP_PROTOCOL", &cfg.Telemetry.Protocol)
+	boolFromEnv("TELEMETRY_LOGS", &cfg.Telemetry.Logs)
+
+	for env, d := range map[string]*time.Duration{
+		"EVENT_RETENTION":             &cfg.EventRetention,
+		"AGENT_TIMELINE_RETENTION":    &cfg.AgentTimelineRetention,
+		"AUDIT_RETENTION":             &cfg.AuditRetention,
+		"SNAPSHOT_RETENTION":          &cfg.SnapshotRetention,
+		"AGENT_DELETION_GRACE_PERIOD": &cfg.AgentDeletionGracePeriod,
+		"DEPLOYMENT_RETENTION":        &cfg.DeploymentRetention,
+		"JOB_RETENTION":               &cfg.JobRetention,
+		"NOTIFY_POLL_INTERVAL":        &cfg.Notify.PollInterval,
+		"AGENT_MTLS_CERT_VALIDITY":    &cfg.AgentMTLS.CertValidity,
+	} {
+		if err := durationFromEnv(env, d); err != nil {
+			return err
+		}
+	}
+	for env, n := range map[string]*int{
+		"DEPLOYMENT_RETENTION_COUNT": &cfg.DeploymentRetentionCount,
+		"JOB_WORKERS":                &cfg.JobWorkers,
+		"BATCH_CONCURRENCY":          &cfg.BatchConcurrency,
+	} {
+		if err := intFromEnv(env, n); err != nil {
+			return err
+		}
+	}
+
+	featureFlags, err := features.Parse(os.Getenv("FEATURE_FLAGS"))
+	if err != nil {
+		return fmt.Errorf("invalid FEATURE_FLAGS: %w", err)
+	}
+	if len(featureFlags) > 0 && cfg.Features == nil {
+		cfg.Features = map[string]bool{}
+	}
+	maps.Copy(cfg.Features, featureFlags)
+	if v := os.Getenv("ASSIGNMENT_PRECEDENCE"); v != "" {
+		if cfg.AssignmentPrecedence, err = otelconfig.ParsePrecedence(v); err != nil {
+			return fmt.Errorf("invalid ASSIGNMENT_PRECEDENCE: %w", err)
+		}
+	}
+
+	if err := rpcTimeoutsFromEnv(&cfg.RPCTimeout, &cfg.RPCTimeouts); err != nil {
+		return fmt.Errorf("invalid RPC timeouts: %w", err)
+	}
+	if err := storageBudgetFromEnv(&cfg.Storage); err != nil {
+		return fmt.Errorf("invalid storage budget: %w", err)
+	}
+	if err := opampConfigFromEnv(&cfg.OpAMP); err != nil {
+		return fmt.Errorf("invalid OpAMP listener configuration: %w", err)
+	}
+	if err := gatewayConfigFromEnv(&cfg.Gateway); err != nil {
+		return fmt.Errorf("invalid gateway configuration: %w", err)
+	}
+	if err := followerConfigFromEnv(&cfg.Follower); err != nil {
+		return fmt.Errorf("invalid follower configuration: %w", err)
+	}
+	if err := clusterConfigFromEnv(&cfg.Cluster); err != nil {
+		return fmt.Errorf("invalid cluster configuration: %w", err)
+	}
+	if err := labelRulesFromEnv(&cfg.LabelRules); err != nil {
+		return fmt.Errorf("invalid label rules: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// stringFromEnv sets *s to the value of env if it is set
+func stringFromEnv(env string, s *string) {
+	if v := os.Getenv(env); v != "" {
+		*s = v
+	}
+}
+
+// boolFromEnv sets *b to whether env is "true" if it is set
+func boolFromEnv(env string, b *bool) {
+	if v := os.Getenv(env); v != "" {
+		*b = v == "true"
+	}
+}
+
+// intFromEnv sets *n to the integer env if it is set
+func intFromEnv(env string, n *int) error {
+	v := os.Getenv(env)
+	if v == "" {
+		return nil
+	}
+	parsed, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", env, err)
+	}
+	*n = parsed
+	return nil
+}
+
+// durationFromEnv sets *d to the duration env if it is set
+func durationFromEnv(env string, d *time.Duration) error {
+	v := os.Getenv(env)
+	if v == "" {
+		return nil
+	}
+	parsed, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", env, err)
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"github.com/otelfleet/otelfleet/pkg/config"
+)
+
+// followerConfigFromEnv runs the server as a read replica of FOLLOWER_PRIMARY_URL, copying its
+// snapshots every FOLLOWER_SYNC_INTERVAL
+func followerConfigFromEnv(cfg *config.FollowerConfig) error {
+	stringFromEnv("FOLLOWER_PRIMARY_URL", &cfg.PrimaryURL)
+	stringFromEnv("FOLLOWER_PRIMARY_TOKEN", &cfg.PrimaryToken)
+	return durationFromEnv("FOLLOWER_SYNC_INTERVAL", &cfg.SyncInterval)
+}
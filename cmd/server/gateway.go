@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/otelfleet/otelfleet/pkg/config"
+)
+
+// gatewayConfigFromEnv runs the server as a gateway relaying its agents to GATEWAY_UPSTREAM_URL.
+// The gateway is identified upstream by GATEWAY_ID, defaulting to the hostname.
+func gatewayConfigFromEnv(cfg *config.GatewayConfig) error {
+	stringFromEnv("GATEWAY_ID", &cfg.ID)
+	stringFromEnv("GATEWAY_UPSTREAM_URL", &cfg.UpstreamURL)
+	stringFromEnv("GATEWAY_UPSTREAM_TOKEN", &cfg.UpstreamToken)
+	if !cfg.Enabled() {
+		return nil
+	}
+	if cfg.ID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("GATEWAY_ID is not set and the hostname is unknown: %w", err)
+		}
+		cfg.ID = hostname
+	}
+	return durationFromEnv("GATEWAY_RELAY_TIMEOUT", &cfg.RelayTimeout)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"os"
+	"strings"
+
+	"github.com/otelfleet/otelfleet/pkg/labels"
+)
+
+// labelRulesFromEnv reads the label rules from LABEL_ALLOWED_PREFIXES and
+// LABEL_RESERVED_PREFIXES, comma separated key prefixes, and LABEL_MAX_KEY_LENGTH and
+// LABEL_MAX_VALUE_LENGTH
+func labelRulesFromEnv(rules *labels.Rules) error {
+	if v := os.Getenv("LABEL_ALLOWED_PREFIXES"); v != "" {
+		rules.AllowedPrefixes = strings.Split(v, ",")
+	}
+	if v := os.Getenv("LABEL_RESERVED_PREFIXES"); v != "" {
+		rules.ReservedPrefixes = strings.Split(v, ",")
+	}
+	for env, n := range map[string]*int{
+		"LABEL_MAX_KEY_LENGTH":   &rules.MaxKeyLength,
+		"LABEL_MAX_VALUE_LENGTH": &rules.MaxValueLength,
+	} {
+		if err := intFromEnv(env, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
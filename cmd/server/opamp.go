@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/otelfleet/otelfleet/pkg/config"
+)
+
+// opampConfigFromEnv configures a dedicated OpAMP listener on OPAMP_LISTEN_ADDR (host:port).
+// OpAMP is served on the HTTP API listener when no listener is configured.
+func opampConfigFromEnv(cfg *config.OpAMPConfig) error {
+	stringFromEnv("OPAMP_TLS_CERT_PATH", &cfg.TLSCertPath)
+	stringFromEnv("OPAMP_TLS_KEY_PATH", &cfg.TLSKeyPath)
+	stringFromEnv("OPAMP_TLS_CLIENT_AUTH", &cfg.ClientAuth)
+	stringFromEnv("OPAMP_TLS_CLIENT_CA_PATH", &cfg.ClientCAPath)
+	if addr := os.Getenv("OPAMP_LISTEN_ADDR"); addr != "" {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return fmt.Errorf("invalid OPAMP_LISTEN_ADDR: %w", err)
+		}
+		cfg.ListenAddress = host
+		if cfg.ListenPort, err = strconv.Atoi(port); err != nil {
+			return fmt.Errorf("invalid OPAMP_LISTEN_ADDR port: %w", err)
+		}
+	}
+	if v := os.Getenv("OPAMP_RATE_LIMIT"); v != "" {
+		limit, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid OPAMP_RATE_LIMIT: %w", err)
+		}
+		cfg.RateLimit = limit
+	}
+	for env, n := range map[string]*int{
+		"OPAMP_RATE_LIMIT_BURST":   &cfg.RateLimitBurst,
+		"OPAMP_PERSIST_WORKERS":    &cfg.PersistWorkers,
+		"OPAMP_PERSIST_QUEUE_SIZE": &cfg.PersistQueueSize,
+	} {
+		if err := intFromEnv(env, n); err != nil {
+			return err
+		}
+	}
+	return durationFromEnv("OPAMP_HEARTBEAT_TIMEOUT", &cfg.HeartbeatTimeout)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/server/util"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+)
+
+// rpcTimeoutsFromEnv reads the default timeout of unary RPCs from RPC_TIMEOUT, and per
+// procedure or service overrides from RPC_TIMEOUTS, e.g. /config.v1alpha1.ConfigService/=1m
+func rpcTimeoutsFromEnv(timeout *time.Duration, timeouts *map[string]time.Duration) error {
+	if err := durationFromEnv("RPC_TIMEOUT", timeout); err != nil {
+		return err
+	}
+	overrides, err := util.ParseTimeouts(os.Getenv("RPC_TIMEOUTS"))
+	if err != nil {
+		return fmt.Errorf("invalid RPC_TIMEOUTS: %w", err)
+	}
+	if len(overrides) > 0 && *timeouts == nil {
+		*timeouts = map[string]time.Duration{}
+	}
+	maps.Copy(*timeouts, overrides)
+	return nil
+}
+
+// storageBudgetFromEnv reads the timeout of storage operations run without a deadline from
+// STORAGE_TIMEOUT, and the slow operation thresholds from STORAGE_SLOW_READ_THRESHOLD and
+// STORAGE_SLOW_SCAN_THRESHOLD
+func storageBudgetFromEnv(budget *storage.Budget) error {
+	for env, d := range map[string]*time.Duration{
+		"STORAGE_TIMEOUT":             &budget.Timeout,
+		"STORAGE_SLOW_READ_THRESHOLD": &budget.SlowReadThreshold,
+		"STORAGE_SLOW_SCAN_THRESHOLD": &budget.SlowScanThreshold,
+	} {
+		if err := durationFromEnv(env, d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
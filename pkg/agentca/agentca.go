@@ -0,0 +1,130 @@
+// Package agentca issues the client certificates agents authenticate their OpAMP connections
+// with, from a CA provisioned by the operator. The certificates identify the agent they were
+// issued to, so that agents may only speak for themselves once bootstrapped.
+package agentca
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultCertValidity is how long issued client certificates are valid unless configured
+// otherwise
+const DefaultCertValidity = 90 * 24 * time.Hour
+
+// uriPrefix prefixes the agent ID in the URI SAN of issued certificates
+const uriPrefix = "otelfleet:agent:"
+
+// Authority issues agent client certificates signed by a CA
+type Authority struct {
+	cert     *x509.Certificate
+	key      crypto.Signer
+	validity time.Duration
+	now      func() time.Time
+}
+
+// New returns an authority issuing certificates signed by the CA cert, whose private key is key
+func New(cert *x509.Certificate, key crypto.Signer) (*Authority, error) {
+	if !cert.IsCA || cert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return nil, errors.New("the agent CA certificate can't sign certificates")
+	}
+	return &Authority{
+		cert:     cert,
+		key:      key,
+		validity: DefaultCertValidity,
+		now:      time.Now,
+	}, nil
+}
+
+// Load returns an authority issuing certificates signed by the PEM encoded CA at certPath,
+// whose private key is at keyPath
+func Load(certPath, keyPath string) (*Authority, error) {
+	pair, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the agent CA: %w", err)
+	}
+	key, ok := pair.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported agent CA key type")
+	}
+	return New(pair.Leaf, key)
+}
+
+// SetCertValidity sets how long issued certificates are valid, 0 keeps DefaultCertValidity
+func (a *Authority) SetCertValidity(validity time.Duration) {
+	if validity > 0 {
+		a.validity = validity
+	}
+}
+
+// Certificate returns the CA certificate
+func (a *Authority) Certificate() *x509.Certificate {
+	return a.cert
+}
+
+// Pool returns the pool of the CA certificate, which issued certificates are verified against
+func (a *Authority) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(a.cert)
+	return pool
+}
+
+// Issue returns the DER encoded client certificate of the agent, for its public key pub
+func (a *Authority) Issue(agentID string, pub crypto.PublicKey) ([]byte, error) {
+	if agentID == "" {
+		return nil, errors.New("agent ID is required")
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := a.now()
+	notAfter := now.Add(a.validity)
+	if notAfter.After(a.cert.NotAfter) {
+		notAfter = a.cert.NotAfter
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: agentID},
+		URIs:         []*url.URL{AgentURI(agentID)},
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	return x509.CreateCertificate(rand.Reader, template, a.cert, pub, a.key)
+}
+
+// AgentURI returns the URI identifying the agent in its client certificate
+func AgentURI(agentID string) *url.URL {
+	return &url.URL{Scheme: "urn", Opaque: uriPrefix + agentID}
+}
+
+// AgentID returns the agent a client certificate was issued to, or "" if it wasn't issued
+// to an agent
+func AgentID(cert *x509.Certificate) string {
+	for _, uri := range cert.URIs {
+		if uri.Scheme == "urn" && strings.HasPrefix(uri.Opaque, uriPrefix) {
+			return strings.TrimPrefix(uri.Opaque, uriPrefix)
+		}
+	}
+	return ""
+}
+
+// VerifiedAgentID returns the agent the verified client certificate of a TLS connection was
+// issued to, or "" if the client presented no verified agent certificate
+func VerifiedAgentID(state *tls.ConnectionState) string {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return ""
+	}
+	return AgentID(state.VerifiedChains[0][0])
+}
@@ -0,0 +1,79 @@
+package agentca
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCA writes a self-signed CA to dir and returns the paths of its certificate and key
+func newCA(t *testing.T, dir string, isCA bool) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "otelfleet agents"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(30 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certPath, keyPath := filepath.Join(dir, "ca.pem"), filepath.Join(dir, "ca-key.pem")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certPath, keyPath
+}
+
+func TestAuthority(t *testing.T) {
+	ca, err := Load(newCA(t, t.TempDir(), true))
+	require.NoError(t, err)
+	ca.SetCertValidity(time.Hour)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := ca.Issue("agent-1", &key.PublicKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	chains, err := cert.Verify(x509.VerifyOptions{
+		Roots:     ca.Pool(),
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "agent-1", AgentID(cert))
+	assert.Equal(t, "agent-1", VerifiedAgentID(&tls.ConnectionState{VerifiedChains: chains}))
+	assert.WithinDuration(t, time.Now().Add(time.Hour), cert.NotAfter, time.Minute)
+
+	assert.Empty(t, AgentID(ca.Certificate()), "certificates not issued to agents identify no agent")
+	assert.Empty(t, VerifiedAgentID(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}), "unverified certificates identify no agent")
+	assert.Empty(t, VerifiedAgentID(nil))
+
+	_, err = ca.Issue("", &key.PublicKey)
+	assert.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	_, err := Load(newCA(t, t.TempDir(), false))
+	assert.ErrorContains(t, err, "can't sign certificates")
+	_, err = Load(filepath.Join(t.TempDir(), "ca.pem"), filepath.Join(t.TempDir(), "ca-key.pem"))
+	assert.ErrorContains(t, err, "failed to load the agent CA")
+}
@@ -0,0 +1,692 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: pkg/api/admin/v1alpha1/admin.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ModuleState int32
+
+const (
+	// the module has no service, e.g. it only registers routes, or it is not initialized
+	ModuleState_MODULE_STATE_UNSPECIFIED ModuleState = 0
+	ModuleState_MODULE_STATE_NEW         ModuleState = 1
+	ModuleState_MODULE_STATE_STARTING    ModuleState = 2
+	ModuleState_MODULE_STATE_RUNNING     ModuleState = 3
+	ModuleState_MODULE_STATE_STOPPING    ModuleState = 4
+	ModuleState_MODULE_STATE_TERMINATED  ModuleState = 5
+	ModuleState_MODULE_STATE_FAILED      ModuleState = 6
+)
+
+// Enum value maps for ModuleState.
+var (
+	ModuleState_name = map[int32]string{
+		0: "MODULE_STATE_UNSPECIFIED",
+		1: "MODULE_STATE_NEW",
+		2: "MODULE_STATE_STARTING",
+		3: "MODULE_STATE_RUNNING",
+		4: "MODULE_STATE_STOPPING",
+		5: "MODULE_STATE_TERMINATED",
+		6: "MODULE_STATE_FAILED",
+	}
+	ModuleState_value = map[string]int32{
+		"MODULE_STATE_UNSPECIFIED": 0,
+		"MODULE_STATE_NEW":         1,
+		"MODULE_STATE_STARTING":    2,
+		"MODULE_STATE_RUNNING":     3,
+		"MODULE_STATE_STOPPING":    4,
+		"MODULE_STATE_TERMINATED":  5,
+		"MODULE_STATE_FAILED":      6,
+	}
+)
+
+func (x ModuleState) Enum() *ModuleState {
+	p := new(ModuleState)
+	*p = x
+	return p
+}
+
+func (x ModuleState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModuleState) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_admin_v1alpha1_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (ModuleState) Type() protoreflect.EnumType {
+	return &file_pkg_api_admin_v1alpha1_admin_proto_enumTypes[0]
+}
+
+func (x ModuleState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModuleState.Descriptor instead.
+func (ModuleState) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_admin_v1alpha1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type Module struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// user visible modules can be targeted
+	UserVisible bool `protobuf:"varint,2,opt,name=user_visible,json=userVisible,proto3" json:"user_visible,omitempty"`
+	// included modules are run by the server
+	Included bool `protobuf:"varint,3,opt,name=included,proto3" json:"included,omitempty"`
+	// the modules this module directly depends on
+	Dependencies []string    `protobuf:"bytes,4,rep,name=dependencies,proto3" json:"dependencies,omitempty"`
+	State        ModuleState `protobuf:"varint,5,opt,name=state,proto3,enum=admin.v1alpha1.ModuleState" json:"state,omitempty"`
+	// why the module failed, if it did
+	Failure       string `protobuf:"bytes,6,opt,name=failure,proto3" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Module) Reset() {
+	*x = Module{}
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Module) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Module) ProtoMessage() {}
+
+func (x *Module) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Module.ProtoReflect.Descriptor instead.
+func (*Module) Descriptor() ([]byte, []int) {
+	return file_pkg_api_admin_v1alpha1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Module) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Module) GetUserVisible() bool {
+	if x != nil {
+		return x.UserVisible
+	}
+	return false
+}
+
+func (x *Module) GetIncluded() bool {
+	if x != nil {
+		return x.Included
+	}
+	return false
+}
+
+func (x *Module) GetDependencies() []string {
+	if x != nil {
+		return x.Dependencies
+	}
+	return nil
+}
+
+func (x *Module) GetState() ModuleState {
+	if x != nil {
+		return x.State
+	}
+	return ModuleState_MODULE_STATE_UNSPECIFIED
+}
+
+func (x *Module) GetFailure() string {
+	if x != nil {
+		return x.Failure
+	}
+	return ""
+}
+
+type GetModulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetModulesRequest) Reset() {
+	*x = GetModulesRequest{}
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetModulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetModulesRequest) ProtoMessage() {}
+
+func (x *GetModulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetModulesRequest.ProtoReflect.Descriptor instead.
+func (*GetModulesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_admin_v1alpha1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type GetModulesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the module run by the server, including its dependencies
+	Target string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	// modules sorted by name
+	Modules       []*Module `protobuf:"bytes,2,rep,name=modules,proto3" json:"modules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetModulesResponse) Reset() {
+	*x = GetModulesResponse{}
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetModulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetModulesResponse) ProtoMessage() {}
+
+func (x *GetModulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetModulesResponse.ProtoReflect.Descriptor instead.
+func (*GetModulesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_admin_v1alpha1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetModulesResponse) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *GetModulesResponse) GetModules() []*Module {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+// OpAMPSession is a live OpAMP connection of an agent
+type OpAMPSession struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the agent identified on the session, empty until it identifies itself
+	AgentId string `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// "websocket", "http" or "gateway"
+	Transport string `protobuf:"bytes,3,opt,name=transport,proto3" json:"transport,omitempty"`
+	// address the session was opened from, as seen by the server
+	RemoteAddr string `protobuf:"bytes,4,opt,name=remote_addr,json=remoteAddr,proto3" json:"remote_addr,omitempty"`
+	// X-Forwarded-For header of the request opening the session, if any
+	ForwardedFor string `protobuf:"bytes,5,opt,name=forwarded_for,json=forwardedFor,proto3" json:"forwarded_for,omitempty"`
+	UserAgent    string `protobuf:"bytes,6,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	// unset for sessions not opened over TLS
+	Tls *SessionTLS `protobuf:"bytes,7,opt,name=tls,proto3" json:"tls,omitempty"`
+	// the gateway relaying the session, for the gateway transport
+	GatewayId string `protobuf:"bytes,8,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// capabilities the agent declared on the session, e.g. "AcceptsRemoteConfig"
+	Capabilities  []string               `protobuf:"bytes,9,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpAMPSession) Reset() {
+	*x = OpAMPSession{}
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpAMPSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpAMPSession) ProtoMessage() {}
+
+func (x *OpAMPSession) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpAMPSession.ProtoReflect.Descriptor instead.
+func (*OpAMPSession) Descriptor() ([]byte, []int) {
+	return file_pkg_api_admin_v1alpha1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OpAMPSession) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *OpAMPSession) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *OpAMPSession) GetTransport() string {
+	if x != nil {
+		return x.Transport
+	}
+	return ""
+}
+
+func (x *OpAMPSession) GetRemoteAddr() string {
+	if x != nil {
+		return x.RemoteAddr
+	}
+	return ""
+}
+
+func (x *OpAMPSession) GetForwardedFor() string {
+	if x != nil {
+		return x.ForwardedFor
+	}
+	return ""
+}
+
+func (x *OpAMPSession) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *OpAMPSession) GetTls() *SessionTLS {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+func (x *OpAMPSession) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+func (x *OpAMPSession) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *OpAMPSession) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+type SessionTLS struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// e.g. "TLS 1.3"
+	Version     string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	CipherSuite string `protobuf:"bytes,2,opt,name=cipher_suite,json=cipherSuite,proto3" json:"cipher_suite,omitempty"`
+	// the server name the agent requested
+	ServerName string `protobuf:"bytes,3,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`
+	// the ALPN protocol, if one was negotiated
+	NegotiatedProtocol string `protobuf:"bytes,4,opt,name=negotiated_protocol,json=negotiatedProtocol,proto3" json:"negotiated_protocol,omitempty"`
+	// subject of the client certificate, empty unless the agent presented one
+	ClientSubject string `protobuf:"bytes,5,opt,name=client_subject,json=clientSubject,proto3" json:"client_subject,omitempty"`
+	// URI SANs of the client certificate, e.g. SPIFFE IDs
+	ClientUris    []string `protobuf:"bytes,6,rep,name=client_uris,json=clientUris,proto3" json:"client_uris,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionTLS) Reset() {
+	*x = SessionTLS{}
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionTLS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionTLS) ProtoMessage() {}
+
+func (x *SessionTLS) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionTLS.ProtoReflect.Descriptor instead.
+func (*SessionTLS) Descriptor() ([]byte, []int) {
+	return file_pkg_api_admin_v1alpha1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SessionTLS) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SessionTLS) GetCipherSuite() string {
+	if x != nil {
+		return x.CipherSuite
+	}
+	return ""
+}
+
+func (x *SessionTLS) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+func (x *SessionTLS) GetNegotiatedProtocol() string {
+	if x != nil {
+		return x.NegotiatedProtocol
+	}
+	return ""
+}
+
+func (x *SessionTLS) GetClientSubject() string {
+	if x != nil {
+		return x.ClientSubject
+	}
+	return ""
+}
+
+func (x *SessionTLS) GetClientUris() []string {
+	if x != nil {
+		return x.ClientUris
+	}
+	return nil
+}
+
+type ListSessionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// only list the sessions of this agent
+	AgentId       string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_admin_v1alpha1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListSessionsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type ListSessionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sessions sorted by start time
+	Sessions      []*OpAMPSession `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_admin_v1alpha1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_admin_v1alpha1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*OpAMPSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+var File_pkg_api_admin_v1alpha1_admin_proto protoreflect.FileDescriptor
+
+const file_pkg_api_admin_v1alpha1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\"pkg/api/admin/v1alpha1/admin.proto\x12\x0eadmin.v1alpha1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcc\x01\n" +
+	"\x06Module\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fuser_visible\x18\x02 \x01(\bR\vuserVisible\x12\x1a\n" +
+	"\bincluded\x18\x03 \x01(\bR\bincluded\x12\"\n" +
+	"\fdependencies\x18\x04 \x03(\tR\fdependencies\x121\n" +
+	"\x05state\x18\x05 \x01(\x0e2\x1b.admin.v1alpha1.ModuleStateR\x05state\x12\x18\n" +
+	"\afailure\x18\x06 \x01(\tR\afailure\"\x13\n" +
+	"\x11GetModulesRequest\"^\n" +
+	"\x12GetModulesResponse\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x120\n" +
+	"\amodules\x18\x02 \x03(\v2\x16.admin.v1alpha1.ModuleR\amodules\"\xe8\x02\n" +
+	"\fOpAMPSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x1c\n" +
+	"\ttransport\x18\x03 \x01(\tR\ttransport\x12\x1f\n" +
+	"\vremote_addr\x18\x04 \x01(\tR\n" +
+	"remoteAddr\x12#\n" +
+	"\rforwarded_for\x18\x05 \x01(\tR\fforwardedFor\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x06 \x01(\tR\tuserAgent\x12,\n" +
+	"\x03tls\x18\a \x01(\v2\x1a.admin.v1alpha1.SessionTLSR\x03tls\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\b \x01(\tR\tgatewayId\x12\"\n" +
+	"\fcapabilities\x18\t \x03(\tR\fcapabilities\x129\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\"\xe3\x01\n" +
+	"\n" +
+	"SessionTLS\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12!\n" +
+	"\fcipher_suite\x18\x02 \x01(\tR\vcipherSuite\x12\x1f\n" +
+	"\vserver_name\x18\x03 \x01(\tR\n" +
+	"serverName\x12/\n" +
+	"\x13negotiated_protocol\x18\x04 \x01(\tR\x12negotiatedProtocol\x12%\n" +
+	"\x0eclient_subject\x18\x05 \x01(\tR\rclientSubject\x12\x1f\n" +
+	"\vclient_uris\x18\x06 \x03(\tR\n" +
+	"clientUris\"0\n" +
+	"\x13ListSessionsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"P\n" +
+	"\x14ListSessionsResponse\x128\n" +
+	"\bsessions\x18\x01 \x03(\v2\x1c.admin.v1alpha1.OpAMPSessionR\bsessions*\xc7\x01\n" +
+	"\vModuleState\x12\x1c\n" +
+	"\x18MODULE_STATE_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10MODULE_STATE_NEW\x10\x01\x12\x19\n" +
+	"\x15MODULE_STATE_STARTING\x10\x02\x12\x18\n" +
+	"\x14MODULE_STATE_RUNNING\x10\x03\x12\x19\n" +
+	"\x15MODULE_STATE_STOPPING\x10\x04\x12\x1b\n" +
+	"\x17MODULE_STATE_TERMINATED\x10\x05\x12\x17\n" +
+	"\x13MODULE_STATE_FAILED\x10\x062\xbe\x01\n" +
+	"\fAdminService\x12S\n" +
+	"\n" +
+	"GetModules\x12!.admin.v1alpha1.GetModulesRequest\x1a\".admin.v1alpha1.GetModulesResponse\x12Y\n" +
+	"\fListSessions\x12#.admin.v1alpha1.ListSessionsRequest\x1a$.admin.v1alpha1.ListSessionsResponseB7Z5github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1b\x06proto3"
+
+var (
+	file_pkg_api_admin_v1alpha1_admin_proto_rawDescOnce sync.Once
+	file_pkg_api_admin_v1alpha1_admin_proto_rawDescData []byte
+)
+
+func file_pkg_api_admin_v1alpha1_admin_proto_rawDescGZIP() []byte {
+	file_pkg_api_admin_v1alpha1_admin_proto_rawDescOnce.Do(func() {
+		file_pkg_api_admin_v1alpha1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_api_admin_v1alpha1_admin_proto_rawDesc), len(file_pkg_api_admin_v1alpha1_admin_proto_rawDesc)))
+	})
+	return file_pkg_api_admin_v1alpha1_admin_proto_rawDescData
+}
+
+var file_pkg_api_admin_v1alpha1_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_api_admin_v1alpha1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_pkg_api_admin_v1alpha1_admin_proto_goTypes = []any{
+	(ModuleState)(0),              // 0: admin.v1alpha1.ModuleState
+	(*Module)(nil),                // 1: admin.v1alpha1.Module
+	(*GetModulesRequest)(nil),     // 2: admin.v1alpha1.GetModulesRequest
+	(*GetModulesResponse)(nil),    // 3: admin.v1alpha1.GetModulesResponse
+	(*OpAMPSession)(nil),          // 4: admin.v1alpha1.OpAMPSession
+	(*SessionTLS)(nil),            // 5: admin.v1alpha1.SessionTLS
+	(*ListSessionsRequest)(nil),   // 6: admin.v1alpha1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),  // 7: admin.v1alpha1.ListSessionsResponse
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+}
+var file_pkg_api_admin_v1alpha1_admin_proto_depIdxs = []int32{
+	0, // 0: admin.v1alpha1.Module.state:type_name -> admin.v1alpha1.ModuleState
+	1, // 1: admin.v1alpha1.GetModulesResponse.modules:type_name -> admin.v1alpha1.Module
+	5, // 2: admin.v1alpha1.OpAMPSession.tls:type_name -> admin.v1alpha1.SessionTLS
+	8, // 3: admin.v1alpha1.OpAMPSession.started_at:type_name -> google.protobuf.Timestamp
+	4, // 4: admin.v1alpha1.ListSessionsResponse.sessions:type_name -> admin.v1alpha1.OpAMPSession
+	2, // 5: admin.v1alpha1.AdminService.GetModules:input_type -> admin.v1alpha1.GetModulesRequest
+	6, // 6: admin.v1alpha1.AdminService.ListSessions:input_type -> admin.v1alpha1.ListSessionsRequest
+	3, // 7: admin.v1alpha1.AdminService.GetModules:output_type -> admin.v1alpha1.GetModulesResponse
+	7, // 8: admin.v1alpha1.AdminService.ListSessions:output_type -> admin.v1alpha1.ListSessionsResponse
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_admin_v1alpha1_admin_proto_init() }
+func file_pkg_api_admin_v1alpha1_admin_proto_init() {
+	if File_pkg_api_admin_v1alpha1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_admin_v1alpha1_admin_proto_rawDesc), len(file_pkg_api_admin_v1alpha1_admin_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_admin_v1alpha1_admin_proto_goTypes,
+		DependencyIndexes: file_pkg_api_admin_v1alpha1_admin_proto_depIdxs,
+		EnumInfos:         file_pkg_api_admin_v1alpha1_admin_proto_enumTypes,
+		MessageInfos:      file_pkg_api_admin_v1alpha1_admin_proto_msgTypes,
+	}.Build()
+	File_pkg_api_admin_v1alpha1_admin_proto = out.File
+	file_pkg_api_admin_v1alpha1_admin_proto_goTypes = nil
+	file_pkg_api_admin_v1alpha1_admin_proto_depIdxs = nil
+}
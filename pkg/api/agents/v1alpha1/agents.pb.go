@@ -7,8 +7,10 @@
 package v1alpha1
 
 import (
+	v1alpha1 "github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
@@ -23,6 +25,277 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type AgentSortField int32
+
+const (
+	// by agent ID
+	AgentSortField_AGENT_SORT_FIELD_UNSPECIFIED AgentSortField = 0
+	AgentSortField_AGENT_SORT_FIELD_NAME        AgentSortField = 1
+	// agents that were never seen first
+	AgentSortField_AGENT_SORT_FIELD_LAST_SEEN AgentSortField = 2
+	AgentSortField_AGENT_SORT_FIELD_STATE     AgentSortField = 3
+)
+
+// Enum value maps for AgentSortField.
+var (
+	AgentSortField_name = map[int32]string{
+		0: "AGENT_SORT_FIELD_UNSPECIFIED",
+		1: "AGENT_SORT_FIELD_NAME",
+		2: "AGENT_SORT_FIELD_LAST_SEEN",
+		3: "AGENT_SORT_FIELD_STATE",
+	}
+	AgentSortField_value = map[string]int32{
+		"AGENT_SORT_FIELD_UNSPECIFIED": 0,
+		"AGENT_SORT_FIELD_NAME":        1,
+		"AGENT_SORT_FIELD_LAST_SEEN":   2,
+		"AGENT_SORT_FIELD_STATE":       3,
+	}
+)
+
+func (x AgentSortField) Enum() *AgentSortField {
+	p := new(AgentSortField)
+	*p = x
+	return p
+}
+
+func (x AgentSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AgentSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[0].Descriptor()
+}
+
+func (AgentSortField) Type() protoreflect.EnumType {
+	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[0]
+}
+
+func (x AgentSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AgentSortField.Descriptor instead.
+func (AgentSortField) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{0}
+}
+
+type AgentHealthState int32
+
+const (
+	// the agent hasn't reported its health
+	AgentHealthState_AGENT_HEALTH_STATE_UNKNOWN   AgentHealthState = 0
+	AgentHealthState_AGENT_HEALTH_STATE_HEALTHY   AgentHealthState = 1
+	AgentHealthState_AGENT_HEALTH_STATE_UNHEALTHY AgentHealthState = 2
+)
+
+// Enum value maps for AgentHealthState.
+var (
+	AgentHealthState_name = map[int32]string{
+		0: "AGENT_HEALTH_STATE_UNKNOWN",
+		1: "AGENT_HEALTH_STATE_HEALTHY",
+		2: "AGENT_HEALTH_STATE_UNHEALTHY",
+	}
+	AgentHealthState_value = map[string]int32{
+		"AGENT_HEALTH_STATE_UNKNOWN":   0,
+		"AGENT_HEALTH_STATE_HEALTHY":   1,
+		"AGENT_HEALTH_STATE_UNHEALTHY": 2,
+	}
+)
+
+func (x AgentHealthState) Enum() *AgentHealthState {
+	p := new(AgentHealthState)
+	*p = x
+	return p
+}
+
+func (x AgentHealthState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AgentHealthState) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[1].Descriptor()
+}
+
+func (AgentHealthState) Type() protoreflect.EnumType {
+	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[1]
+}
+
+func (x AgentHealthState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AgentHealthState.Descriptor instead.
+func (AgentHealthState) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{1}
+}
+
+// AgentStatusView selects the parts of an agent's status to assemble, cheaper views skip
+// reading the stores holding the omitted parts
+type AgentStatusView int32
+
+const (
+	// same as AGENT_STATUS_VIEW_FULL
+	AgentStatusView_AGENT_STATUS_VIEW_UNSPECIFIED AgentStatusView = 0
+	// state, connection times, instance history and config sync status
+	AgentStatusView_AGENT_STATUS_VIEW_BASIC AgentStatusView = 1
+	// the basic view plus component health and the remote config status
+	AgentStatusView_AGENT_STATUS_VIEW_HEALTH AgentStatusView = 2
+	// everything, including the effective config bodies
+	AgentStatusView_AGENT_STATUS_VIEW_FULL AgentStatusView = 3
+)
+
+// Enum value maps for AgentStatusView.
+var (
+	AgentStatusView_name = map[int32]string{
+		0: "AGENT_STATUS_VIEW_UNSPECIFIED",
+		1: "AGENT_STATUS_VIEW_BASIC",
+		2: "AGENT_STATUS_VIEW_HEALTH",
+		3: "AGENT_STATUS_VIEW_FULL",
+	}
+	AgentStatusView_value = map[string]int32{
+		"AGENT_STATUS_VIEW_UNSPECIFIED": 0,
+		"AGENT_STATUS_VIEW_BASIC":       1,
+		"AGENT_STATUS_VIEW_HEALTH":      2,
+		"AGENT_STATUS_VIEW_FULL":        3,
+	}
+)
+
+func (x AgentStatusView) Enum() *AgentStatusView {
+	p := new(AgentStatusView)
+	*p = x
+	return p
+}
+
+func (x AgentStatusView) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AgentStatusView) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[2].Descriptor()
+}
+
+func (AgentStatusView) Type() protoreflect.EnumType {
+	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[2]
+}
+
+func (x AgentStatusView) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AgentStatusView.Descriptor instead.
+func (AgentStatusView) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{2}
+}
+
+type AgentCondition int32
+
+const (
+	AgentCondition_AGENT_CONDITION_UNSPECIFIED AgentCondition = 0
+	AgentCondition_AGENT_CONDITION_CONNECTED   AgentCondition = 1
+	// the agent applied its assigned config, the built-in config when nothing is assigned, or
+	// the config with config_hash if set
+	AgentCondition_AGENT_CONDITION_CONFIG_APPLIED AgentCondition = 2
+	AgentCondition_AGENT_CONDITION_HEALTHY        AgentCondition = 3
+	AgentCondition_AGENT_CONDITION_DISCONNECTED   AgentCondition = 4
+)
+
+// Enum value maps for AgentCondition.
+var (
+	AgentCondition_name = map[int32]string{
+		0: "AGENT_CONDITION_UNSPECIFIED",
+		1: "AGENT_CONDITION_CONNECTED",
+		2: "AGENT_CONDITION_CONFIG_APPLIED",
+		3: "AGENT_CONDITION_HEALTHY",
+		4: "AGENT_CONDITION_DISCONNECTED",
+	}
+	AgentCondition_value = map[string]int32{
+		"AGENT_CONDITION_UNSPECIFIED":    0,
+		"AGENT_CONDITION_CONNECTED":      1,
+		"AGENT_CONDITION_CONFIG_APPLIED": 2,
+		"AGENT_CONDITION_HEALTHY":        3,
+		"AGENT_CONDITION_DISCONNECTED":   4,
+	}
+)
+
+func (x AgentCondition) Enum() *AgentCondition {
+	p := new(AgentCondition)
+	*p = x
+	return p
+}
+
+func (x AgentCondition) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AgentCondition) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[3].Descriptor()
+}
+
+func (AgentCondition) Type() protoreflect.EnumType {
+	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[3]
+}
+
+func (x AgentCondition) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AgentCondition.Descriptor instead.
+func (AgentCondition) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{3}
+}
+
+type AgentSnapshotState int32
+
+const (
+	AgentSnapshotState_AGENT_SNAPSHOT_STATE_UNSPECIFIED AgentSnapshotState = 0
+	AgentSnapshotState_AGENT_SNAPSHOT_STATE_PENDING     AgentSnapshotState = 1
+	AgentSnapshotState_AGENT_SNAPSHOT_STATE_READY       AgentSnapshotState = 2
+	AgentSnapshotState_AGENT_SNAPSHOT_STATE_FAILED      AgentSnapshotState = 3
+)
+
+// Enum value maps for AgentSnapshotState.
+var (
+	AgentSnapshotState_name = map[int32]string{
+		0: "AGENT_SNAPSHOT_STATE_UNSPECIFIED",
+		1: "AGENT_SNAPSHOT_STATE_PENDING",
+		2: "AGENT_SNAPSHOT_STATE_READY",
+		3: "AGENT_SNAPSHOT_STATE_FAILED",
+	}
+	AgentSnapshotState_value = map[string]int32{
+		"AGENT_SNAPSHOT_STATE_UNSPECIFIED": 0,
+		"AGENT_SNAPSHOT_STATE_PENDING":     1,
+		"AGENT_SNAPSHOT_STATE_READY":       2,
+		"AGENT_SNAPSHOT_STATE_FAILED":      3,
+	}
+)
+
+func (x AgentSnapshotState) Enum() *AgentSnapshotState {
+	p := new(AgentSnapshotState)
+	*p = x
+	return p
+}
+
+func (x AgentSnapshotState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AgentSnapshotState) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[4].Descriptor()
+}
+
+func (AgentSnapshotState) Type() protoreflect.EnumType {
+	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[4]
+}
+
+func (x AgentSnapshotState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AgentSnapshotState.Descriptor instead.
+func (AgentSnapshotState) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{4}
+}
+
 type AgentState int32
 
 const (
@@ -56,11 +329,11 @@ func (x AgentState) String() string {
 }
 
 func (AgentState) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[0].Descriptor()
+	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[5].Descriptor()
 }
 
 func (AgentState) Type() protoreflect.EnumType {
-	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[0]
+	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[5]
 }
 
 func (x AgentState) Number() protoreflect.EnumNumber {
@@ -69,7 +342,7 @@ func (x AgentState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AgentState.Descriptor instead.
 func (AgentState) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{0}
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{5}
 }
 
 // ConfigSyncStatus represents the unified config synchronization status.
@@ -82,6 +355,7 @@ const (
 	ConfigSyncStatus_CONFIG_SYNC_STATUS_OUT_OF_SYNC ConfigSyncStatus = 2 // Hash mismatch or no status reported
 	ConfigSyncStatus_CONFIG_SYNC_STATUS_APPLYING    ConfigSyncStatus = 3 // Agent is currently applying the config
 	ConfigSyncStatus_CONFIG_SYNC_STATUS_ERROR       ConfigSyncStatus = 4 // Agent reported failure applying config
+	ConfigSyncStatus_CONFIG_SYNC_STATUS_UNSUPPORTED ConfigSyncStatus = 5 // Config assigned but the agent does not accept remote config
 )
 
 // Enum value maps for ConfigSyncStatus.
@@ -92,6 +366,7 @@ var (
 		2: "CONFIG_SYNC_STATUS_OUT_OF_SYNC",
 		3: "CONFIG_SYNC_STATUS_APPLYING",
 		4: "CONFIG_SYNC_STATUS_ERROR",
+		5: "CONFIG_SYNC_STATUS_UNSUPPORTED",
 	}
 	ConfigSyncStatus_value = map[string]int32{
 		"CONFIG_SYNC_STATUS_UNKNOWN":     0,
@@ -99,6 +374,7 @@ var (
 		"CONFIG_SYNC_STATUS_OUT_OF_SYNC": 2,
 		"CONFIG_SYNC_STATUS_APPLYING":    3,
 		"CONFIG_SYNC_STATUS_ERROR":       4,
+		"CONFIG_SYNC_STATUS_UNSUPPORTED": 5,
 	}
 )
 
@@ -113,11 +389,11 @@ func (x ConfigSyncStatus) String() string {
 }
 
 func (ConfigSyncStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[1].Descriptor()
+	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[6].Descriptor()
 }
 
 func (ConfigSyncStatus) Type() protoreflect.EnumType {
-	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[1]
+	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[6]
 }
 
 func (x ConfigSyncStatus) Number() protoreflect.EnumNumber {
@@ -126,7 +402,7 @@ func (x ConfigSyncStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ConfigSyncStatus.Descriptor instead.
 func (ConfigSyncStatus) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{1}
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{6}
 }
 
 type RemoteConfigStatuses int32
@@ -165,11 +441,11 @@ func (x RemoteConfigStatuses) String() string {
 }
 
 func (RemoteConfigStatuses) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[2].Descriptor()
+	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[7].Descriptor()
 }
 
 func (RemoteConfigStatuses) Type() protoreflect.EnumType {
-	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[2]
+	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[7]
 }
 
 func (x RemoteConfigStatuses) Number() protoreflect.EnumNumber {
@@ -178,75 +454,202 @@ func (x RemoteConfigStatuses) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RemoteConfigStatuses.Descriptor instead.
 func (RemoteConfigStatuses) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{2}
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{7}
 }
 
-type ListAgentsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WithStatus    bool                   `protobuf:"varint,1,opt,name=with_status,json=withStatus,proto3" json:"with_status,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ConfigPushState int32
+
+const (
+	ConfigPushState_CONFIG_PUSH_STATE_UNSPECIFIED ConfigPushState = 0
+	// the config was sent to the agent
+	ConfigPushState_CONFIG_PUSH_STATE_OFFERED ConfigPushState = 1
+	// the agent reported receiving the config
+	ConfigPushState_CONFIG_PUSH_STATE_ACKNOWLEDGED ConfigPushState = 2
+	ConfigPushState_CONFIG_PUSH_STATE_APPLIED      ConfigPushState = 3
+	ConfigPushState_CONFIG_PUSH_STATE_FAILED       ConfigPushState = 4
+)
+
+// Enum value maps for ConfigPushState.
+var (
+	ConfigPushState_name = map[int32]string{
+		0: "CONFIG_PUSH_STATE_UNSPECIFIED",
+		1: "CONFIG_PUSH_STATE_OFFERED",
+		2: "CONFIG_PUSH_STATE_ACKNOWLEDGED",
+		3: "CONFIG_PUSH_STATE_APPLIED",
+		4: "CONFIG_PUSH_STATE_FAILED",
+	}
+	ConfigPushState_value = map[string]int32{
+		"CONFIG_PUSH_STATE_UNSPECIFIED":  0,
+		"CONFIG_PUSH_STATE_OFFERED":      1,
+		"CONFIG_PUSH_STATE_ACKNOWLEDGED": 2,
+		"CONFIG_PUSH_STATE_APPLIED":      3,
+		"CONFIG_PUSH_STATE_FAILED":       4,
+	}
+)
+
+func (x ConfigPushState) Enum() *ConfigPushState {
+	p := new(ConfigPushState)
+	*p = x
+	return p
 }
 
-func (x *ListAgentsRequest) Reset() {
-	*x = ListAgentsRequest{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[0]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x ConfigPushState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *ListAgentsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (ConfigPushState) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[8].Descriptor()
 }
 
-func (*ListAgentsRequest) ProtoMessage() {}
+func (ConfigPushState) Type() protoreflect.EnumType {
+	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[8]
+}
 
-func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[0]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x ConfigPushState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigPushState.Descriptor instead.
+func (ConfigPushState) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{8}
+}
+
+type AgentCommandState int32
+
+const (
+	AgentCommandState_AGENT_COMMAND_STATE_UNSPECIFIED AgentCommandState = 0
+	// the command was sent, the agent hasn't reported its result yet
+	AgentCommandState_AGENT_COMMAND_STATE_PENDING   AgentCommandState = 1
+	AgentCommandState_AGENT_COMMAND_STATE_SUCCEEDED AgentCommandState = 2
+	AgentCommandState_AGENT_COMMAND_STATE_FAILED    AgentCommandState = 3
+)
+
+// Enum value maps for AgentCommandState.
+var (
+	AgentCommandState_name = map[int32]string{
+		0: "AGENT_COMMAND_STATE_UNSPECIFIED",
+		1: "AGENT_COMMAND_STATE_PENDING",
+		2: "AGENT_COMMAND_STATE_SUCCEEDED",
+		3: "AGENT_COMMAND_STATE_FAILED",
 	}
-	return mi.MessageOf(x)
+	AgentCommandState_value = map[string]int32{
+		"AGENT_COMMAND_STATE_UNSPECIFIED": 0,
+		"AGENT_COMMAND_STATE_PENDING":     1,
+		"AGENT_COMMAND_STATE_SUCCEEDED":   2,
+		"AGENT_COMMAND_STATE_FAILED":      3,
+	}
+)
+
+func (x AgentCommandState) Enum() *AgentCommandState {
+	p := new(AgentCommandState)
+	*p = x
+	return p
 }
 
-// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
-func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{0}
+func (x AgentCommandState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *ListAgentsRequest) GetWithStatus() bool {
-	if x != nil {
-		return x.WithStatus
+func (AgentCommandState) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[9].Descriptor()
+}
+
+func (AgentCommandState) Type() protoreflect.EnumType {
+	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[9]
+}
+
+func (x AgentCommandState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AgentCommandState.Descriptor instead.
+func (AgentCommandState) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{9}
+}
+
+type AgentProblemType int32
+
+const (
+	AgentProblemType_AGENT_PROBLEM_TYPE_UNSPECIFIED AgentProblemType = 0
+	// the agent failed to apply its remote config several times in a row
+	AgentProblemType_AGENT_PROBLEM_TYPE_CONFIG_APPLY_FAILING AgentProblemType = 1
+	// the collector keeps exiting shortly after being started
+	AgentProblemType_AGENT_PROBLEM_TYPE_COLLECTOR_CRASH_LOOP AgentProblemType = 2
+	// the filesystem of the collector's config is nearly full
+	AgentProblemType_AGENT_PROBLEM_TYPE_DISK_NEARLY_FULL AgentProblemType = 3
+)
+
+// Enum value maps for AgentProblemType.
+var (
+	AgentProblemType_name = map[int32]string{
+		0: "AGENT_PROBLEM_TYPE_UNSPECIFIED",
+		1: "AGENT_PROBLEM_TYPE_CONFIG_APPLY_FAILING",
+		2: "AGENT_PROBLEM_TYPE_COLLECTOR_CRASH_LOOP",
+		3: "AGENT_PROBLEM_TYPE_DISK_NEARLY_FULL",
 	}
-	return false
+	AgentProblemType_value = map[string]int32{
+		"AGENT_PROBLEM_TYPE_UNSPECIFIED":          0,
+		"AGENT_PROBLEM_TYPE_CONFIG_APPLY_FAILING": 1,
+		"AGENT_PROBLEM_TYPE_COLLECTOR_CRASH_LOOP": 2,
+		"AGENT_PROBLEM_TYPE_DISK_NEARLY_FULL":     3,
+	}
+)
+
+func (x AgentProblemType) Enum() *AgentProblemType {
+	p := new(AgentProblemType)
+	*p = x
+	return p
 }
 
-type ListAgentsResponse struct {
-	state         protoimpl.MessageState       `protogen:"open.v1"`
-	Agents        []*AgentDescriptionAndStatus `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+func (x AgentProblemType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AgentProblemType) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[10].Descriptor()
+}
+
+func (AgentProblemType) Type() protoreflect.EnumType {
+	return &file_pkg_api_agents_v1alpha1_agents_proto_enumTypes[10]
+}
+
+func (x AgentProblemType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AgentProblemType.Descriptor instead.
+func (AgentProblemType) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{10}
+}
+
+type RelayRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	GatewayId string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	// identifies the agent connection on the gateway
+	ConnectionId string `protobuf:"bytes,2,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	// the agent identified on the connection, if the gateway knows it
+	AgentId string `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// serialized opamp AgentToServer message
+	AgentToServer []byte `protobuf:"bytes,4,opt,name=agent_to_server,json=agentToServer,proto3" json:"agent_to_server,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAgentsResponse) Reset() {
-	*x = ListAgentsResponse{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[1]
+func (x *RelayRequest) Reset() {
+	*x = RelayRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAgentsResponse) String() string {
+func (x *RelayRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAgentsResponse) ProtoMessage() {}
+func (*RelayRequest) ProtoMessage() {}
 
-func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[1]
+func (x *RelayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -257,43 +660,62 @@ func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
-func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use RelayRequest.ProtoReflect.Descriptor instead.
+func (*RelayRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *ListAgentsResponse) GetAgents() []*AgentDescriptionAndStatus {
+func (x *RelayRequest) GetGatewayId() string {
 	if x != nil {
-		return x.Agents
+		return x.GatewayId
 	}
-	return nil
+	return ""
 }
 
-// AgentView combines registration and status for list/get responses.
-// This is the preferred type name for combined agent data.
-type AgentView struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Registration  *AgentRegistration     `protobuf:"bytes,1,opt,name=registration,proto3" json:"registration,omitempty"`
-	Status        *AgentStatus           `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+func (x *RelayRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *RelayRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *RelayRequest) GetAgentToServer() []byte {
+	if x != nil {
+		return x.AgentToServer
+	}
+	return nil
+}
+
+type RelayResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// serialized opamp ServerToAgent messages
+	ServerToAgent [][]byte `protobuf:"bytes,1,rep,name=server_to_agent,json=serverToAgent,proto3" json:"server_to_agent,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentView) Reset() {
-	*x = AgentView{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[2]
+func (x *RelayResponse) Reset() {
+	*x = RelayResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentView) String() string {
+func (x *RelayResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentView) ProtoMessage() {}
+func (*RelayResponse) ProtoMessage() {}
 
-func (x *AgentView) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[2]
+func (x *RelayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -304,49 +726,86 @@ func (x *AgentView) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentView.ProtoReflect.Descriptor instead.
-func (*AgentView) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use RelayResponse.ProtoReflect.Descriptor instead.
+func (*RelayResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *AgentView) GetRegistration() *AgentRegistration {
+func (x *RelayResponse) GetServerToAgent() [][]byte {
 	if x != nil {
-		return x.Registration
+		return x.ServerToAgent
 	}
 	return nil
 }
 
-func (x *AgentView) GetStatus() *AgentStatus {
+type WatchGatewayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GatewayId     string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchGatewayRequest) Reset() {
+	*x = WatchGatewayRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchGatewayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchGatewayRequest) ProtoMessage() {}
+
+func (x *WatchGatewayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[2]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-// AgentDescriptionAndStatus is kept for backward compatibility.
-// Use AgentView for new code.
-type AgentDescriptionAndStatus struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Agent         *AgentDescription      `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
-	Status        *AgentStatus           `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+// Deprecated: Use WatchGatewayRequest.ProtoReflect.Descriptor instead.
+func (*WatchGatewayRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WatchGatewayRequest) GetGatewayId() string {
+	if x != nil {
+		return x.GatewayId
+	}
+	return ""
+}
+
+type RelayedMessage struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
+	AgentId      string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// serialized opamp ServerToAgent message
+	ServerToAgent []byte `protobuf:"bytes,3,opt,name=server_to_agent,json=serverToAgent,proto3" json:"server_to_agent,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentDescriptionAndStatus) Reset() {
-	*x = AgentDescriptionAndStatus{}
+func (x *RelayedMessage) Reset() {
+	*x = RelayedMessage{}
 	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentDescriptionAndStatus) String() string {
+func (x *RelayedMessage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentDescriptionAndStatus) ProtoMessage() {}
+func (*RelayedMessage) ProtoMessage() {}
 
-func (x *AgentDescriptionAndStatus) ProtoReflect() protoreflect.Message {
+func (x *RelayedMessage) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -358,46 +817,54 @@ func (x *AgentDescriptionAndStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentDescriptionAndStatus.ProtoReflect.Descriptor instead.
-func (*AgentDescriptionAndStatus) Descriptor() ([]byte, []int) {
+// Deprecated: Use RelayedMessage.ProtoReflect.Descriptor instead.
+func (*RelayedMessage) Descriptor() ([]byte, []int) {
 	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *AgentDescriptionAndStatus) GetAgent() *AgentDescription {
+func (x *RelayedMessage) GetConnectionId() string {
 	if x != nil {
-		return x.Agent
+		return x.ConnectionId
 	}
-	return nil
+	return ""
 }
 
-func (x *AgentDescriptionAndStatus) GetStatus() *AgentStatus {
+func (x *RelayedMessage) GetAgentId() string {
 	if x != nil {
-		return x.Status
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *RelayedMessage) GetServerToAgent() []byte {
+	if x != nil {
+		return x.ServerToAgent
 	}
 	return nil
 }
 
-type GetAgentRequest struct {
+type DisconnectRelayedAgentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	GatewayId     string                 `protobuf:"bytes,1,opt,name=gateway_id,json=gatewayId,proto3" json:"gateway_id,omitempty"`
+	ConnectionId  string                 `protobuf:"bytes,2,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAgentRequest) Reset() {
-	*x = GetAgentRequest{}
+func (x *DisconnectRelayedAgentRequest) Reset() {
+	*x = DisconnectRelayedAgentRequest{}
 	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAgentRequest) String() string {
+func (x *DisconnectRelayedAgentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAgentRequest) ProtoMessage() {}
+func (*DisconnectRelayedAgentRequest) ProtoMessage() {}
 
-func (x *GetAgentRequest) ProtoReflect() protoreflect.Message {
+func (x *DisconnectRelayedAgentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -409,39 +876,67 @@ func (x *GetAgentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAgentRequest.ProtoReflect.Descriptor instead.
-func (*GetAgentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DisconnectRelayedAgentRequest.ProtoReflect.Descriptor instead.
+func (*DisconnectRelayedAgentRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetAgentRequest) GetAgentId() string {
+func (x *DisconnectRelayedAgentRequest) GetGatewayId() string {
 	if x != nil {
-		return x.AgentId
+		return x.GatewayId
 	}
 	return ""
 }
 
-type GetAgentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Agent         *AgentDescription      `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+func (x *DisconnectRelayedAgentRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+type ListAgentsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	WithStatus bool                   `protobuf:"varint,1,opt,name=with_status,json=withStatus,proto3" json:"with_status,omitempty"`
+	// parts of the status to include when with_status is set
+	View AgentStatusView `protobuf:"varint,2,opt,name=view,proto3,enum=config.v1alpha1.AgentStatusView" json:"view,omitempty"`
+	// only return agents in one of these config sync statuses, all agents when empty
+	ConfigSyncStatuses []ConfigSyncStatus `protobuf:"varint,3,rep,packed,name=config_sync_statuses,json=configSyncStatuses,proto3,enum=config.v1alpha1.ConfigSyncStatus" json:"config_sync_statuses,omitempty"`
+	// also return the agents deleted within the grace period
+	IncludeDeleted bool `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// maximum number of agents returned, all the matching agents when 0. The next page is
+	// requested with the next_page_token of the response, and the same filters and order.
+	PageSize  int32  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// order of the agents, ties are broken by agent ID
+	SortBy     AgentSortField `protobuf:"varint,7,opt,name=sort_by,json=sortBy,proto3,enum=config.v1alpha1.AgentSortField" json:"sort_by,omitempty"`
+	Descending bool           `protobuf:"varint,8,opt,name=descending,proto3" json:"descending,omitempty"`
+	// only return agents in one of these connection states, all agents when empty
+	States []AgentState `protobuf:"varint,9,rep,packed,name=states,proto3,enum=config.v1alpha1.AgentState" json:"states,omitempty"`
+	// only return agents whose labels include all of these
+	LabelSelector map[string]string `protobuf:"bytes,10,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// only return agents assigned one of these configs, all agents when empty
+	ConfigIds []string `protobuf:"bytes,11,rep,name=config_ids,json=configIds,proto3" json:"config_ids,omitempty"`
+	// only return agents in one of these health states, all agents when empty
+	HealthStates  []AgentHealthState `protobuf:"varint,12,rep,packed,name=health_states,json=healthStates,proto3,enum=config.v1alpha1.AgentHealthState" json:"health_states,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAgentResponse) Reset() {
-	*x = GetAgentResponse{}
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
 	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAgentResponse) String() string {
+func (x *ListAgentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAgentResponse) ProtoMessage() {}
+func (*ListAgentsRequest) ProtoMessage() {}
 
-func (x *GetAgentResponse) ProtoReflect() protoreflect.Message {
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -453,84 +948,125 @@ func (x *GetAgentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAgentResponse.ProtoReflect.Descriptor instead.
-func (*GetAgentResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *GetAgentResponse) GetAgent() *AgentDescription {
+func (x *ListAgentsRequest) GetWithStatus() bool {
 	if x != nil {
-		return x.Agent
+		return x.WithStatus
+	}
+	return false
+}
+
+func (x *ListAgentsRequest) GetView() AgentStatusView {
+	if x != nil {
+		return x.View
+	}
+	return AgentStatusView_AGENT_STATUS_VIEW_UNSPECIFIED
+}
+
+func (x *ListAgentsRequest) GetConfigSyncStatuses() []ConfigSyncStatus {
+	if x != nil {
+		return x.ConfigSyncStatuses
 	}
 	return nil
 }
 
-type GetAgentStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *ListAgentsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
 }
 
-func (x *GetAgentStatusRequest) Reset() {
-	*x = GetAgentStatusRequest{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *ListAgentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
 }
 
-func (x *GetAgentStatusRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ListAgentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
 }
 
-func (*GetAgentStatusRequest) ProtoMessage() {}
+func (x *ListAgentsRequest) GetSortBy() AgentSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return AgentSortField_AGENT_SORT_FIELD_UNSPECIFIED
+}
 
-func (x *GetAgentStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[6]
+func (x *ListAgentsRequest) GetDescending() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Descending
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use GetAgentStatusRequest.ProtoReflect.Descriptor instead.
-func (*GetAgentStatusRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{6}
+func (x *ListAgentsRequest) GetStates() []AgentState {
+	if x != nil {
+		return x.States
+	}
+	return nil
 }
 
-func (x *GetAgentStatusRequest) GetAgentId() string {
+func (x *ListAgentsRequest) GetLabelSelector() map[string]string {
 	if x != nil {
-		return x.AgentId
+		return x.LabelSelector
 	}
-	return ""
+	return nil
 }
 
-type GetAgentStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        *AgentStatus           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+func (x *ListAgentsRequest) GetConfigIds() []string {
+	if x != nil {
+		return x.ConfigIds
+	}
+	return nil
+}
+
+func (x *ListAgentsRequest) GetHealthStates() []AgentHealthState {
+	if x != nil {
+		return x.HealthStates
+	}
+	return nil
+}
+
+type ListAgentsResponse struct {
+	state  protoimpl.MessageState       `protogen:"open.v1"`
+	Agents []*AgentDescriptionAndStatus `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	// agents that are registered but could not be loaded, and are missing from agents
+	Errors []*AgentLoadError `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	// number of registered agents, before filtering
+	TotalCount int32 `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// token of the next page, empty on the last page
+	NextPageToken string `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// number of agents matching the filters, across all pages
+	MatchedCount  int32 `protobuf:"varint,5,opt,name=matched_count,json=matchedCount,proto3" json:"matched_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAgentStatusResponse) Reset() {
-	*x = GetAgentStatusResponse{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[7]
+func (x *ListAgentsResponse) Reset() {
+	*x = ListAgentsResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAgentStatusResponse) String() string {
+func (x *ListAgentsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAgentStatusResponse) ProtoMessage() {}
+func (*ListAgentsResponse) ProtoMessage() {}
 
-func (x *GetAgentStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[7]
+func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,40 +1077,69 @@ func (x *GetAgentStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAgentStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetAgentStatusResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetAgentStatusResponse) GetStatus() *AgentStatus {
+func (x *ListAgentsResponse) GetAgents() []*AgentDescriptionAndStatus {
 	if x != nil {
-		return x.Status
+		return x.Agents
 	}
 	return nil
 }
 
-type DeleteAgentRequest struct {
+func (x *ListAgentsResponse) GetErrors() []*AgentLoadError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ListAgentsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListAgentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListAgentsResponse) GetMatchedCount() int32 {
+	if x != nil {
+		return x.MatchedCount
+	}
+	return 0
+}
+
+type AgentLoadError struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAgentRequest) Reset() {
-	*x = DeleteAgentRequest{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[8]
+func (x *AgentLoadError) Reset() {
+	*x = AgentLoadError{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAgentRequest) String() string {
+func (x *AgentLoadError) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAgentRequest) ProtoMessage() {}
+func (*AgentLoadError) ProtoMessage() {}
 
-func (x *DeleteAgentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[8]
+func (x *AgentLoadError) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -585,49 +1150,3267 @@ func (x *DeleteAgentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAgentRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAgentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AgentLoadError.ProtoReflect.Descriptor instead.
+func (*AgentLoadError) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AgentLoadError) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentLoadError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// AgentView combines registration and status for list/get responses.
+// This is the preferred type name for combined agent data.
+type AgentView struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Registration  *AgentRegistration     `protobuf:"bytes,1,opt,name=registration,proto3" json:"registration,omitempty"`
+	Status        *AgentStatus           `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentView) Reset() {
+	*x = AgentView{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentView) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentView) ProtoMessage() {}
+
+func (x *AgentView) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentView.ProtoReflect.Descriptor instead.
+func (*AgentView) Descriptor() ([]byte, []int) {
 	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *DeleteAgentRequest) GetAgentId() string {
+func (x *AgentView) GetRegistration() *AgentRegistration {
+	if x != nil {
+		return x.Registration
+	}
+	return nil
+}
+
+func (x *AgentView) GetStatus() *AgentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+// AgentDescriptionAndStatus is kept for backward compatibility.
+// Use AgentView for new code.
+type AgentDescriptionAndStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         *AgentDescription      `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	Status        *AgentStatus           `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentDescriptionAndStatus) Reset() {
+	*x = AgentDescriptionAndStatus{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentDescriptionAndStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentDescriptionAndStatus) ProtoMessage() {}
+
+func (x *AgentDescriptionAndStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentDescriptionAndStatus.ProtoReflect.Descriptor instead.
+func (*AgentDescriptionAndStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AgentDescriptionAndStatus) GetAgent() *AgentDescription {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+func (x *AgentDescriptionAndStatus) GetStatus() *AgentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type GetAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentRequest) Reset() {
+	*x = GetAgentRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentRequest) ProtoMessage() {}
+
+func (x *GetAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAgentRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type GetAgentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         *AgentDescription      `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentResponse) Reset() {
+	*x = GetAgentResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentResponse) ProtoMessage() {}
+
+func (x *GetAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetAgentResponse) GetAgent() *AgentDescription {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+type GetAgentStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	View          AgentStatusView        `protobuf:"varint,2,opt,name=view,proto3,enum=config.v1alpha1.AgentStatusView" json:"view,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentStatusRequest) Reset() {
+	*x = GetAgentStatusRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentStatusRequest) ProtoMessage() {}
+
+func (x *GetAgentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetAgentStatusRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *GetAgentStatusRequest) GetView() AgentStatusView {
+	if x != nil {
+		return x.View
+	}
+	return AgentStatusView_AGENT_STATUS_VIEW_UNSPECIFIED
+}
+
+type GetAgentStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *AgentStatus           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentStatusResponse) Reset() {
+	*x = GetAgentStatusResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentStatusResponse) ProtoMessage() {}
+
+func (x *GetAgentStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentStatusResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetAgentStatusResponse) GetStatus() *AgentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type WaitForAgentConditionRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AgentId   string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Condition AgentCondition         `protobuf:"varint,2,opt,name=condition,proto3,enum=config.v1alpha1.AgentCondition" json:"condition,omitempty"`
+	// for AGENT_CONDITION_CONFIG_APPLIED, the hash of the config that must be applied, as
+	// reported in the remote config status
+	ConfigHash []byte `protobuf:"bytes,3,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+	// how long to wait, defaults to 30s and is capped at 5m
+	Timeout       *durationpb.Duration `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitForAgentConditionRequest) Reset() {
+	*x = WaitForAgentConditionRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitForAgentConditionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitForAgentConditionRequest) ProtoMessage() {}
+
+func (x *WaitForAgentConditionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitForAgentConditionRequest.ProtoReflect.Descriptor instead.
+func (*WaitForAgentConditionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WaitForAgentConditionRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *WaitForAgentConditionRequest) GetCondition() AgentCondition {
+	if x != nil {
+		return x.Condition
+	}
+	return AgentCondition_AGENT_CONDITION_UNSPECIFIED
+}
+
+func (x *WaitForAgentConditionRequest) GetConfigHash() []byte {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return nil
+}
+
+func (x *WaitForAgentConditionRequest) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type WaitForAgentConditionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// false if the timeout expired first
+	Satisfied     bool         `protobuf:"varint,1,opt,name=satisfied,proto3" json:"satisfied,omitempty"`
+	Status        *AgentStatus `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WaitForAgentConditionResponse) Reset() {
+	*x = WaitForAgentConditionResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WaitForAgentConditionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitForAgentConditionResponse) ProtoMessage() {}
+
+func (x *WaitForAgentConditionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitForAgentConditionResponse.ProtoReflect.Descriptor instead.
+func (*WaitForAgentConditionResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WaitForAgentConditionResponse) GetSatisfied() bool {
+	if x != nil {
+		return x.Satisfied
+	}
+	return false
+}
+
+func (x *WaitForAgentConditionResponse) GetStatus() *AgentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type DeleteAgentRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// delete the agent permanently, without a grace period
+	Purge         bool `protobuf:"varint,2,opt,name=purge,proto3" json:"purge,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAgentRequest) Reset() {
+	*x = DeleteAgentRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAgentRequest) ProtoMessage() {}
+
+func (x *DeleteAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAgentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAgentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteAgentRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *DeleteAgentRequest) GetPurge() bool {
+	if x != nil {
+		return x.Purge
+	}
+	return false
+}
+
+type CaptureAgentSnapshotRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// max_bytes caps the size of the snapshot archive, defaults to 4MiB
+	MaxBytes      int64 `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureAgentSnapshotRequest) Reset() {
+	*x = CaptureAgentSnapshotRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureAgentSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureAgentSnapshotRequest) ProtoMessage() {}
+
+func (x *CaptureAgentSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureAgentSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CaptureAgentSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CaptureAgentSnapshotRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *CaptureAgentSnapshotRequest) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+type CaptureAgentSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshot      *AgentSnapshot         `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureAgentSnapshotResponse) Reset() {
+	*x = CaptureAgentSnapshotResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureAgentSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureAgentSnapshotResponse) ProtoMessage() {}
+
+func (x *CaptureAgentSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureAgentSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*CaptureAgentSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CaptureAgentSnapshotResponse) GetSnapshot() *AgentSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type GetAgentSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SnapshotId    string                 `protobuf:"bytes,1,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentSnapshotRequest) Reset() {
+	*x = GetAgentSnapshotRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentSnapshotRequest) ProtoMessage() {}
+
+func (x *GetAgentSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetAgentSnapshotRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+type GetAgentSnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Snapshot      *AgentSnapshot         `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentSnapshotResponse) Reset() {
+	*x = GetAgentSnapshotResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentSnapshotResponse) ProtoMessage() {}
+
+func (x *GetAgentSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetAgentSnapshotResponse) GetSnapshot() *AgentSnapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type ListAgentSnapshotsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// agent_id optionally restricts the snapshots to a single agent
+	AgentId       string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentSnapshotsRequest) Reset() {
+	*x = ListAgentSnapshotsRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentSnapshotsRequest) ProtoMessage() {}
+
+func (x *ListAgentSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListAgentSnapshotsRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type ListAgentSnapshotsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// snapshots are listed without their archive
+	Snapshots     []*AgentSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentSnapshotsResponse) Reset() {
+	*x = ListAgentSnapshotsResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListAgentSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListAgentSnapshotsResponse) GetSnapshots() []*AgentSnapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+type ListConfigPushesRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// push_id optionally restricts the response to a single push
+	PushId        string `protobuf:"bytes,2,opt,name=push_id,json=pushId,proto3" json:"push_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigPushesRequest) Reset() {
+	*x = ListConfigPushesRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigPushesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigPushesRequest) ProtoMessage() {}
+
+func (x *ListConfigPushesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigPushesRequest.ProtoReflect.Descriptor instead.
+func (*ListConfigPushesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListConfigPushesRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ListConfigPushesRequest) GetPushId() string {
+	if x != nil {
+		return x.PushId
+	}
+	return ""
+}
+
+type ListConfigPushesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// pushes are listed oldest first
+	Pushes        []*ConfigPush `protobuf:"bytes,1,rep,name=pushes,proto3" json:"pushes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigPushesResponse) Reset() {
+	*x = ListConfigPushesResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigPushesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigPushesResponse) ProtoMessage() {}
+
+func (x *ListConfigPushesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigPushesResponse.ProtoReflect.Descriptor instead.
+func (*ListConfigPushesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListConfigPushesResponse) GetPushes() []*ConfigPush {
+	if x != nil {
+		return x.Pushes
+	}
+	return nil
+}
+
+type CaptureFleetSnapshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureFleetSnapshotRequest) Reset() {
+	*x = CaptureFleetSnapshotRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureFleetSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureFleetSnapshotRequest) ProtoMessage() {}
+
+func (x *CaptureFleetSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureFleetSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CaptureFleetSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{25}
+}
+
+type ListFleetSnapshotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFleetSnapshotsRequest) Reset() {
+	*x = ListFleetSnapshotsRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFleetSnapshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFleetSnapshotsRequest) ProtoMessage() {}
+
+func (x *ListFleetSnapshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFleetSnapshotsRequest.ProtoReflect.Descriptor instead.
+func (*ListFleetSnapshotsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{26}
+}
+
+type ListFleetSnapshotsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// snapshots are listed oldest first, without their agents
+	Snapshots     []*FleetSnapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFleetSnapshotsResponse) Reset() {
+	*x = ListFleetSnapshotsResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFleetSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFleetSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListFleetSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFleetSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListFleetSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListFleetSnapshotsResponse) GetSnapshots() []*FleetSnapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+type DiffFleetStateRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	FromSnapshotId string                 `protobuf:"bytes,1,opt,name=from_snapshot_id,json=fromSnapshotId,proto3" json:"from_snapshot_id,omitempty"`
+	// to_snapshot_id defaults to the current state of the fleet
+	ToSnapshotId  string `protobuf:"bytes,2,opt,name=to_snapshot_id,json=toSnapshotId,proto3" json:"to_snapshot_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffFleetStateRequest) Reset() {
+	*x = DiffFleetStateRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffFleetStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffFleetStateRequest) ProtoMessage() {}
+
+func (x *DiffFleetStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffFleetStateRequest.ProtoReflect.Descriptor instead.
+func (*DiffFleetStateRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DiffFleetStateRequest) GetFromSnapshotId() string {
+	if x != nil {
+		return x.FromSnapshotId
+	}
+	return ""
+}
+
+func (x *DiffFleetStateRequest) GetToSnapshotId() string {
+	if x != nil {
+		return x.ToSnapshotId
+	}
+	return ""
+}
+
+// FleetSnapshot records the state of every agent at a point in time
+type FleetSnapshot struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CapturedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"`
+	AgentCount int32                  `protobuf:"varint,3,opt,name=agent_count,json=agentCount,proto3" json:"agent_count,omitempty"`
+	// agents are sorted by ID
+	Agents        []*FleetSnapshotAgent `protobuf:"bytes,4,rep,name=agents,proto3" json:"agents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FleetSnapshot) Reset() {
+	*x = FleetSnapshot{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FleetSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FleetSnapshot) ProtoMessage() {}
+
+func (x *FleetSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FleetSnapshot.ProtoReflect.Descriptor instead.
+func (*FleetSnapshot) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FleetSnapshot) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FleetSnapshot) GetCapturedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return nil
+}
+
+func (x *FleetSnapshot) GetAgentCount() int32 {
+	if x != nil {
+		return x.AgentCount
+	}
+	return 0
+}
+
+func (x *FleetSnapshot) GetAgents() []*FleetSnapshotAgent {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+type FleetSnapshotAgent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Name    string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// version is the agent's reported service.version
+	Version  string `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	ConfigId string `protobuf:"bytes,4,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	// config_hash is the hex encoded hash of the remote config last reported by the agent
+	ConfigHash    string            `protobuf:"bytes,5,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+	State         string            `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	Labels        map[string]string `protobuf:"bytes,7,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FleetSnapshotAgent) Reset() {
+	*x = FleetSnapshotAgent{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FleetSnapshotAgent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FleetSnapshotAgent) ProtoMessage() {}
+
+func (x *FleetSnapshotAgent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FleetSnapshotAgent.ProtoReflect.Descriptor instead.
+func (*FleetSnapshotAgent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FleetSnapshotAgent) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *FleetSnapshotAgent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FleetSnapshotAgent) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *FleetSnapshotAgent) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *FleetSnapshotAgent) GetConfigHash() string {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return ""
+}
+
+func (x *FleetSnapshotAgent) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *FleetSnapshotAgent) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type FleetStateDiff struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// from and to are the compared snapshots, without their agents.
+	// to has no ID when compared against the current state of the fleet.
+	From          *FleetSnapshot        `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *FleetSnapshot        `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Added         []*FleetSnapshotAgent `protobuf:"bytes,3,rep,name=added,proto3" json:"added,omitempty"`
+	Removed       []*FleetSnapshotAgent `protobuf:"bytes,4,rep,name=removed,proto3" json:"removed,omitempty"`
+	Changed       []*AgentStateChange   `protobuf:"bytes,5,rep,name=changed,proto3" json:"changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FleetStateDiff) Reset() {
+	*x = FleetStateDiff{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FleetStateDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FleetStateDiff) ProtoMessage() {}
+
+func (x *FleetStateDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FleetStateDiff.ProtoReflect.Descriptor instead.
+func (*FleetStateDiff) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *FleetStateDiff) GetFrom() *FleetSnapshot {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *FleetStateDiff) GetTo() *FleetSnapshot {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *FleetStateDiff) GetAdded() []*FleetSnapshotAgent {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *FleetStateDiff) GetRemoved() []*FleetSnapshotAgent {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *FleetStateDiff) GetChanged() []*AgentStateChange {
+	if x != nil {
+		return x.Changed
+	}
+	return nil
+}
+
+// AgentStateChange lists the fields of an agent that changed between two fleet snapshots
+type AgentStateChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentStateChange) Reset() {
+	*x = AgentStateChange{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentStateChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentStateChange) ProtoMessage() {}
+
+func (x *AgentStateChange) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentStateChange.ProtoReflect.Descriptor instead.
+func (*AgentStateChange) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AgentStateChange) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentStateChange) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type FieldChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field is config_id, config_hash, version, name, state or label.<key>
+	Field         string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	From          string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *FieldChange) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// AgentSnapshot is a support bundle captured by an agent's supervisor, containing
+// its config directory, applied config hash, recent supervisor logs and process list.
+type AgentSnapshot struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AgentId     string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	State       AgentSnapshotState     `protobuf:"varint,3,opt,name=state,proto3,enum=config.v1alpha1.AgentSnapshotState" json:"state,omitempty"`
+	RequestedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// expires_at is when the snapshot is deleted from the server
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	MaxBytes  int64                  `protobuf:"varint,7,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	SizeBytes int64                  `protobuf:"varint,8,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// truncated is set if content was left out to respect max_bytes
+	Truncated bool   `protobuf:"varint,9,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	Error     string `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	// archive is a gzipped tarball of the snapshot contents
+	Archive       []byte `protobuf:"bytes,11,opt,name=archive,proto3" json:"archive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentSnapshot) Reset() {
+	*x = AgentSnapshot{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentSnapshot) ProtoMessage() {}
+
+func (x *AgentSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentSnapshot.ProtoReflect.Descriptor instead.
+func (*AgentSnapshot) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AgentSnapshot) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AgentSnapshot) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentSnapshot) GetState() AgentSnapshotState {
+	if x != nil {
+		return x.State
+	}
+	return AgentSnapshotState_AGENT_SNAPSHOT_STATE_UNSPECIFIED
+}
+
+func (x *AgentSnapshot) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+func (x *AgentSnapshot) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *AgentSnapshot) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *AgentSnapshot) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *AgentSnapshot) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *AgentSnapshot) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *AgentSnapshot) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AgentSnapshot) GetArchive() []byte {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+// SnapshotRequest is sent to supervisors in an OpAMP custom message to request a snapshot.
+type SnapshotRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	SnapshotId string                 `protobuf:"bytes,1,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	// server_pub_key is the server's ephemeral x25519 public key used to encrypt the upload
+	ServerPubKey  []byte `protobuf:"bytes,2,opt,name=server_pub_key,json=serverPubKey,proto3" json:"server_pub_key,omitempty"`
+	MaxBytes      int64  `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest) ProtoMessage() {}
+
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SnapshotRequest) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *SnapshotRequest) GetServerPubKey() []byte {
+	if x != nil {
+		return x.ServerPubKey
+	}
+	return nil
+}
+
+func (x *SnapshotRequest) GetMaxBytes() int64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+// SnapshotUpload is sent by supervisors in an OpAMP custom message in reply to a SnapshotRequest.
+type SnapshotUpload struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	SnapshotId string                 `protobuf:"bytes,1,opt,name=snapshot_id,json=snapshotId,proto3" json:"snapshot_id,omitempty"`
+	// client_pub_key is the supervisor's ephemeral x25519 public key
+	ClientPubKey []byte `protobuf:"bytes,2,opt,name=client_pub_key,json=clientPubKey,proto3" json:"client_pub_key,omitempty"`
+	// ciphertext is the AES-GCM sealed archive, prefixed with its nonce
+	Ciphertext []byte `protobuf:"bytes,3,opt,name=ciphertext,proto3" json:"ciphertext,omitempty"`
+	Truncated  bool   `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// error is set if the supervisor failed to capture the snapshot
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotUpload) Reset() {
+	*x = SnapshotUpload{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotUpload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotUpload) ProtoMessage() {}
+
+func (x *SnapshotUpload) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotUpload.ProtoReflect.Descriptor instead.
+func (*SnapshotUpload) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SnapshotUpload) GetSnapshotId() string {
+	if x != nil {
+		return x.SnapshotId
+	}
+	return ""
+}
+
+func (x *SnapshotUpload) GetClientPubKey() []byte {
+	if x != nil {
+		return x.ClientPubKey
+	}
+	return nil
+}
+
+func (x *SnapshotUpload) GetCiphertext() []byte {
+	if x != nil {
+		return x.Ciphertext
+	}
+	return nil
+}
+
+func (x *SnapshotUpload) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *SnapshotUpload) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type AgentStatus struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	State              AgentState             `protobuf:"varint,1,opt,name=state,proto3,enum=config.v1alpha1.AgentState" json:"state,omitempty"`
+	Health             *ComponentHealth       `protobuf:"bytes,2,opt,name=health,proto3" json:"health,omitempty"`
+	EffectiveConfig    *EffectiveConfig       `protobuf:"bytes,3,opt,name=effective_config,json=effectiveConfig,proto3" json:"effective_config,omitempty"`
+	RemoteConfigStatus *RemoteConfigStatus    `protobuf:"bytes,4,opt,name=remote_config_status,json=remoteConfigStatus,proto3" json:"remote_config_status,omitempty"`
+	// New fields for unified status
+	LastSeen         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	ConfigSyncStatus ConfigSyncStatus       `protobuf:"varint,6,opt,name=config_sync_status,json=configSyncStatus,proto3,enum=config.v1alpha1.ConfigSyncStatus" json:"config_sync_status,omitempty"`
+	ConfigSyncReason string                 `protobuf:"bytes,7,opt,name=config_sync_reason,json=configSyncReason,proto3" json:"config_sync_reason,omitempty"`
+	ConnectedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	DisconnectedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=disconnected_at,json=disconnectedAt,proto3" json:"disconnected_at,omitempty"`
+	// instance_uid is the OpAMP instance UID of the agent's current process
+	InstanceUid []byte `protobuf:"bytes,10,opt,name=instance_uid,json=instanceUid,proto3" json:"instance_uid,omitempty"`
+	// instance_history lists the instance UIDs the agent has connected with, oldest first
+	InstanceHistory []*AgentInstance `protobuf:"bytes,11,rep,name=instance_history,json=instanceHistory,proto3" json:"instance_history,omitempty"`
+	// the last command sent to the agent, unset if it was never sent one
+	LastCommand *AgentCommandStatus `protobuf:"bytes,12,opt,name=last_command,json=lastCommand,proto3" json:"last_command,omitempty"`
+	// the latest own metrics of the agent's collector, in the health and full views. Unset
+	// unless the server receives the own metrics of agents.
+	Metrics       *AgentMetrics `protobuf:"bytes,13,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentStatus) Reset() {
+	*x = AgentStatus{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentStatus) ProtoMessage() {}
+
+func (x *AgentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentStatus.ProtoReflect.Descriptor instead.
+func (*AgentStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AgentStatus) GetState() AgentState {
+	if x != nil {
+		return x.State
+	}
+	return AgentState_AGENT_STATE_UNKNOWN
+}
+
+func (x *AgentStatus) GetHealth() *ComponentHealth {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetEffectiveConfig() *EffectiveConfig {
+	if x != nil {
+		return x.EffectiveConfig
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetRemoteConfigStatus() *RemoteConfigStatus {
+	if x != nil {
+		return x.RemoteConfigStatus
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetConfigSyncStatus() ConfigSyncStatus {
+	if x != nil {
+		return x.ConfigSyncStatus
+	}
+	return ConfigSyncStatus_CONFIG_SYNC_STATUS_UNKNOWN
+}
+
+func (x *AgentStatus) GetConfigSyncReason() string {
+	if x != nil {
+		return x.ConfigSyncReason
+	}
+	return ""
+}
+
+func (x *AgentStatus) GetConnectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetDisconnectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisconnectedAt
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetInstanceUid() []byte {
+	if x != nil {
+		return x.InstanceUid
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetInstanceHistory() []*AgentInstance {
+	if x != nil {
+		return x.InstanceHistory
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetLastCommand() *AgentCommandStatus {
+	if x != nil {
+		return x.LastCommand
+	}
+	return nil
+}
+
+func (x *AgentStatus) GetMetrics() *AgentMetrics {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+// AgentMetrics are the own metrics of an agent's collector, as last reported to the server
+type AgentMetrics struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ReceivedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	// CPU time used by the collector process since it started
+	CpuSeconds float64 `protobuf:"fixed64,2,opt,name=cpu_seconds,json=cpuSeconds,proto3" json:"cpu_seconds,omitempty"`
+	// CPUs used by the collector process since the previous report, e.g. 0.5 for half a CPU
+	CpuUtilization float64 `protobuf:"fixed64,3,opt,name=cpu_utilization,json=cpuUtilization,proto3" json:"cpu_utilization,omitempty"`
+	// resident memory of the collector process
+	MemoryRssBytes int64 `protobuf:"varint,4,opt,name=memory_rss_bytes,json=memoryRssBytes,proto3" json:"memory_rss_bytes,omitempty"`
+	// bytes of heap objects allocated by the collector
+	HeapAllocBytes int64                `protobuf:"varint,5,opt,name=heap_alloc_bytes,json=heapAllocBytes,proto3" json:"heap_alloc_bytes,omitempty"`
+	Uptime         *durationpb.Duration `protobuf:"bytes,6,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	// the sending queues of the exporters of the collector
+	ExporterQueues []*ExporterQueue `protobuf:"bytes,7,rep,name=exporter_queues,json=exporterQueues,proto3" json:"exporter_queues,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AgentMetrics) Reset() {
+	*x = AgentMetrics{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentMetrics) ProtoMessage() {}
+
+func (x *AgentMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentMetrics.ProtoReflect.Descriptor instead.
+func (*AgentMetrics) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AgentMetrics) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
+}
+
+func (x *AgentMetrics) GetCpuSeconds() float64 {
+	if x != nil {
+		return x.CpuSeconds
+	}
+	return 0
+}
+
+func (x *AgentMetrics) GetCpuUtilization() float64 {
+	if x != nil {
+		return x.CpuUtilization
+	}
+	return 0
+}
+
+func (x *AgentMetrics) GetMemoryRssBytes() int64 {
+	if x != nil {
+		return x.MemoryRssBytes
+	}
+	return 0
+}
+
+func (x *AgentMetrics) GetHeapAllocBytes() int64 {
+	if x != nil {
+		return x.HeapAllocBytes
+	}
+	return 0
+}
+
+func (x *AgentMetrics) GetUptime() *durationpb.Duration {
+	if x != nil {
+		return x.Uptime
+	}
+	return nil
+}
+
+func (x *AgentMetrics) GetExporterQueues() []*ExporterQueue {
+	if x != nil {
+		return x.ExporterQueues
+	}
+	return nil
+}
+
+type ExporterQueue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the exporter, e.g. otlp/backend
+	Exporter string `protobuf:"bytes,1,opt,name=exporter,proto3" json:"exporter,omitempty"`
+	// number of batches in the queue
+	Size          int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Capacity      int64 `protobuf:"varint,3,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExporterQueue) Reset() {
+	*x = ExporterQueue{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExporterQueue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExporterQueue) ProtoMessage() {}
+
+func (x *ExporterQueue) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExporterQueue.ProtoReflect.Descriptor instead.
+func (*ExporterQueue) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ExporterQueue) GetExporter() string {
+	if x != nil {
+		return x.Exporter
+	}
+	return ""
+}
+
+func (x *ExporterQueue) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ExporterQueue) GetCapacity() int64 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+// AgentRegistration represents the core agent identity and attributes.
+// This is the preferred type name for agent registration data.
+type AgentRegistration struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FriendlyName string                 `protobuf:"bytes,2,opt,name=friendly_name,json=friendlyName,proto3" json:"friendly_name,omitempty"`
+	// Attributes that identify the Agent (e.g., service.name, service.version, service.instance.id).
+	IdentifyingAttributes []*KeyValue `protobuf:"bytes,3,rep,name=identifying_attributes,json=identifyingAttributes,proto3" json:"identifying_attributes,omitempty"`
+	// Attributes that do not necessarily identify the Agent but help describe where it runs.
+	NonIdentifyingAttributes []*KeyValue `protobuf:"bytes,4,rep,name=non_identifying_attributes,json=nonIdentifyingAttributes,proto3" json:"non_identifying_attributes,omitempty"`
+	Capabilities             []string    `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *AgentRegistration) Reset() {
+	*x = AgentRegistration{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentRegistration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentRegistration) ProtoMessage() {}
+
+func (x *AgentRegistration) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentRegistration.ProtoReflect.Descriptor instead.
+func (*AgentRegistration) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AgentRegistration) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AgentRegistration) GetFriendlyName() string {
+	if x != nil {
+		return x.FriendlyName
+	}
+	return ""
+}
+
+func (x *AgentRegistration) GetIdentifyingAttributes() []*KeyValue {
+	if x != nil {
+		return x.IdentifyingAttributes
+	}
+	return nil
+}
+
+func (x *AgentRegistration) GetNonIdentifyingAttributes() []*KeyValue {
+	if x != nil {
+		return x.NonIdentifyingAttributes
+	}
+	return nil
+}
+
+func (x *AgentRegistration) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+// AgentDescription is kept for backward compatibility.
+// Use AgentRegistration for new code.
+type AgentDescription struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FriendlyName string                 `protobuf:"bytes,2,opt,name=friendly_name,json=friendlyName,proto3" json:"friendly_name,omitempty"`
+	// Attributes that identify the Agent (e.g., service.name, service.version, service.instance.id).
+	// These attributes uniquely identify the agent and should be used for its own telemetry.
+	IdentifyingAttributes []*KeyValue `protobuf:"bytes,3,rep,name=identifying_attributes,json=identifyingAttributes,proto3" json:"identifying_attributes,omitempty"`
+	// Attributes that do not necessarily identify the Agent but help describe where it runs
+	// (e.g., os.type, os.version, host.*, cloud.*).
+	NonIdentifyingAttributes []*KeyValue `protobuf:"bytes,4,rep,name=non_identifying_attributes,json=nonIdentifyingAttributes,proto3" json:"non_identifying_attributes,omitempty"`
+	Capabilities             []string    `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// when the agent was deleted, it is purged once the deletion grace period expires
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// when the agent was revoked, its OpAMP connections are rejected
+	RevokedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	// tenant the agent joined when it bootstrapped, empty for the default tenant
+	Tenant        string `protobuf:"bytes,8,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentDescription) Reset() {
+	*x = AgentDescription{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentDescription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentDescription) ProtoMessage() {}
+
+func (x *AgentDescription) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentDescription.ProtoReflect.Descriptor instead.
+func (*AgentDescription) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AgentDescription) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AgentDescription) GetFriendlyName() string {
+	if x != nil {
+		return x.FriendlyName
+	}
+	return ""
+}
+
+func (x *AgentDescription) GetIdentifyingAttributes() []*KeyValue {
+	if x != nil {
+		return x.IdentifyingAttributes
+	}
+	return nil
+}
+
+func (x *AgentDescription) GetNonIdentifyingAttributes() []*KeyValue {
+	if x != nil {
+		return x.NonIdentifyingAttributes
+	}
+	return nil
+}
+
+func (x *AgentDescription) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *AgentDescription) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *AgentDescription) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *AgentDescription) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+// KeyValue represents a key-value pair with support for various value types.
+type KeyValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         *AnyValue              `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyValue) Reset() {
+	*x = KeyValue{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyValue) ProtoMessage() {}
+
+func (x *KeyValue) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyValue.ProtoReflect.Descriptor instead.
+func (*KeyValue) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *KeyValue) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyValue) GetValue() *AnyValue {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// AnyValue represents a value that can be one of several types.
+type AnyValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*AnyValue_StringValue
+	//	*AnyValue_BoolValue
+	//	*AnyValue_IntValue
+	//	*AnyValue_DoubleValue
+	//	*AnyValue_BytesValue
+	//	*AnyValue_ArrayValue
+	//	*AnyValue_KvlistValue
+	Value         isAnyValue_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnyValue) Reset() {
+	*x = AnyValue{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnyValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnyValue) ProtoMessage() {}
+
+func (x *AnyValue) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnyValue.ProtoReflect.Descriptor instead.
+func (*AnyValue) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *AnyValue) GetValue() isAnyValue_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *AnyValue) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *AnyValue) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *AnyValue) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *AnyValue) GetDoubleValue() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_DoubleValue); ok {
+			return x.DoubleValue
+		}
+	}
+	return 0
+}
+
+func (x *AnyValue) GetBytesValue() []byte {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_BytesValue); ok {
+			return x.BytesValue
+		}
+	}
+	return nil
+}
+
+func (x *AnyValue) GetArrayValue() *ArrayValue {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_ArrayValue); ok {
+			return x.ArrayValue
+		}
+	}
+	return nil
+}
+
+func (x *AnyValue) GetKvlistValue() *KeyValueList {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_KvlistValue); ok {
+			return x.KvlistValue
+		}
+	}
+	return nil
+}
+
+type isAnyValue_Value interface {
+	isAnyValue_Value()
+}
+
+type AnyValue_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type AnyValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type AnyValue_IntValue struct {
+	IntValue int64 `protobuf:"varint,3,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type AnyValue_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,4,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type AnyValue_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,5,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+type AnyValue_ArrayValue struct {
+	ArrayValue *ArrayValue `protobuf:"bytes,6,opt,name=array_value,json=arrayValue,proto3,oneof"`
+}
+
+type AnyValue_KvlistValue struct {
+	KvlistValue *KeyValueList `protobuf:"bytes,7,opt,name=kvlist_value,json=kvlistValue,proto3,oneof"`
+}
+
+func (*AnyValue_StringValue) isAnyValue_Value() {}
+
+func (*AnyValue_BoolValue) isAnyValue_Value() {}
+
+func (*AnyValue_IntValue) isAnyValue_Value() {}
+
+func (*AnyValue_DoubleValue) isAnyValue_Value() {}
+
+func (*AnyValue_BytesValue) isAnyValue_Value() {}
+
+func (*AnyValue_ArrayValue) isAnyValue_Value() {}
+
+func (*AnyValue_KvlistValue) isAnyValue_Value() {}
+
+// ArrayValue holds an array of AnyValue.
+type ArrayValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*AnyValue            `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArrayValue) Reset() {
+	*x = ArrayValue{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArrayValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArrayValue) ProtoMessage() {}
+
+func (x *ArrayValue) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArrayValue.ProtoReflect.Descriptor instead.
+func (*ArrayValue) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ArrayValue) GetValues() []*AnyValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// KeyValueList holds a list of KeyValue pairs.
+type KeyValueList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*KeyValue            `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyValueList) Reset() {
+	*x = KeyValueList{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyValueList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyValueList) ProtoMessage() {}
+
+func (x *KeyValueList) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyValueList.ProtoReflect.Descriptor instead.
+func (*KeyValueList) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *KeyValueList) GetValues() []*KeyValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// AgentConnectionState represents the persisted connection state of an agent.
+// This replaces the in-memory AgentTracker state.
+type AgentConnectionState struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AgentId        string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	State          AgentState             `protobuf:"varint,2,opt,name=state,proto3,enum=config.v1alpha1.AgentState" json:"state,omitempty"`
+	LastSeen       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	ConnectedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	DisconnectedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=disconnected_at,json=disconnectedAt,proto3" json:"disconnected_at,omitempty"`
+	InstanceUid    []byte                 `protobuf:"bytes,6,opt,name=instance_uid,json=instanceUid,proto3" json:"instance_uid,omitempty"`
+	Capabilities   uint64                 `protobuf:"varint,7,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	SequenceNum    uint64                 `protobuf:"varint,8,opt,name=sequence_num,json=sequenceNum,proto3" json:"sequence_num,omitempty"`
+	// instance_history lists the instance UIDs the agent has connected with, oldest first.
+	// The last entry is the current instance.
+	InstanceHistory []*AgentInstance `protobuf:"bytes,9,rep,name=instance_history,json=instanceHistory,proto3" json:"instance_history,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AgentConnectionState) Reset() {
+	*x = AgentConnectionState{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentConnectionState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentConnectionState) ProtoMessage() {}
+
+func (x *AgentConnectionState) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentConnectionState.ProtoReflect.Descriptor instead.
+func (*AgentConnectionState) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *AgentConnectionState) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentConnectionState) GetState() AgentState {
+	if x != nil {
+		return x.State
+	}
+	return AgentState_AGENT_STATE_UNKNOWN
+}
+
+func (x *AgentConnectionState) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+func (x *AgentConnectionState) GetConnectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return nil
+}
+
+func (x *AgentConnectionState) GetDisconnectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisconnectedAt
+	}
+	return nil
+}
+
+func (x *AgentConnectionState) GetInstanceUid() []byte {
+	if x != nil {
+		return x.InstanceUid
+	}
+	return nil
+}
+
+func (x *AgentConnectionState) GetCapabilities() uint64 {
+	if x != nil {
+		return x.Capabilities
+	}
+	return 0
+}
+
+func (x *AgentConnectionState) GetSequenceNum() uint64 {
+	if x != nil {
+		return x.SequenceNum
+	}
+	return 0
+}
+
+func (x *AgentConnectionState) GetInstanceHistory() []*AgentInstance {
+	if x != nil {
+		return x.InstanceHistory
+	}
+	return nil
+}
+
+// AgentInstance records an OpAMP instance UID an agent has connected with.
+// An agent's instance UID changes when its supervisor regenerates it, e.g. on reinstall.
+type AgentInstance struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	InstanceUid []byte                 `protobuf:"bytes,1,opt,name=instance_uid,json=instanceUid,proto3" json:"instance_uid,omitempty"`
+	FirstSeen   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	// last_seen is unset for the current instance
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentInstance) Reset() {
+	*x = AgentInstance{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentInstance) ProtoMessage() {}
+
+func (x *AgentInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentInstance.ProtoReflect.Descriptor instead.
+func (*AgentInstance) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AgentInstance) GetInstanceUid() []byte {
+	if x != nil {
+		return x.InstanceUid
+	}
+	return nil
+}
+
+func (x *AgentInstance) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *AgentInstance) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+// ComponentHealth represents the health status of an agent and its components.
+type ComponentHealth struct {
+	state              protoimpl.MessageState      `protogen:"open.v1"`
+	Healthy            bool                        `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	StartTimeUnixNano  uint64                      `protobuf:"varint,2,opt,name=start_time_unix_nano,json=startTimeUnixNano,proto3" json:"start_time_unix_nano,omitempty"`
+	LastError          string                      `protobuf:"bytes,3,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	Status             string                      `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	StatusTimeUnixNano uint64                      `protobuf:"varint,5,opt,name=status_time_unix_nano,json=statusTimeUnixNano,proto3" json:"status_time_unix_nano,omitempty"`
+	ComponentHealthMap map[string]*ComponentHealth `protobuf:"bytes,6,rep,name=component_health_map,json=componentHealthMap,proto3" json:"component_health_map,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ComponentHealth) Reset() {
+	*x = ComponentHealth{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentHealth) ProtoMessage() {}
+
+func (x *ComponentHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentHealth.ProtoReflect.Descriptor instead.
+func (*ComponentHealth) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ComponentHealth) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *ComponentHealth) GetStartTimeUnixNano() uint64 {
+	if x != nil {
+		return x.StartTimeUnixNano
+	}
+	return 0
+}
+
+func (x *ComponentHealth) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ComponentHealth) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ComponentHealth) GetStatusTimeUnixNano() uint64 {
+	if x != nil {
+		return x.StatusTimeUnixNano
+	}
+	return 0
+}
+
+func (x *ComponentHealth) GetComponentHealthMap() map[string]*ComponentHealth {
+	if x != nil {
+		return x.ComponentHealthMap
+	}
+	return nil
+}
+
+// EffectiveConfig represents the current effective configuration of an agent.
+type EffectiveConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConfigMap     *AgentConfigMap        `protobuf:"bytes,1,opt,name=config_map,json=configMap,proto3" json:"config_map,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EffectiveConfig) Reset() {
+	*x = EffectiveConfig{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EffectiveConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EffectiveConfig) ProtoMessage() {}
+
+func (x *EffectiveConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EffectiveConfig.ProtoReflect.Descriptor instead.
+func (*EffectiveConfig) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *EffectiveConfig) GetConfigMap() *AgentConfigMap {
+	if x != nil {
+		return x.ConfigMap
+	}
+	return nil
+}
+
+// AgentConfigMap holds a map of config file names to their content.
+type AgentConfigMap struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	ConfigMap     map[string]*AgentConfigFile `protobuf:"bytes,1,rep,name=config_map,json=configMap,proto3" json:"config_map,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentConfigMap) Reset() {
+	*x = AgentConfigMap{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentConfigMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentConfigMap) ProtoMessage() {}
+
+func (x *AgentConfigMap) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentConfigMap.ProtoReflect.Descriptor instead.
+func (*AgentConfigMap) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *AgentConfigMap) GetConfigMap() map[string]*AgentConfigFile {
+	if x != nil {
+		return x.ConfigMap
+	}
+	return nil
+}
+
+// AgentConfigFile represents a single configuration file.
+type AgentConfigFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Body          []byte                 `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentConfigFile) Reset() {
+	*x = AgentConfigFile{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentConfigFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentConfigFile) ProtoMessage() {}
+
+func (x *AgentConfigFile) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentConfigFile.ProtoReflect.Descriptor instead.
+func (*AgentConfigFile) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *AgentConfigFile) GetBody() []byte {
+	if x != nil {
+		return x.Body
+	}
+	return nil
+}
+
+func (x *AgentConfigFile) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+// RemoteConfigStatus represents the status of a remote configuration on an agent.
+type RemoteConfigStatus struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	LastRemoteConfigHash []byte                 `protobuf:"bytes,1,opt,name=last_remote_config_hash,json=lastRemoteConfigHash,proto3" json:"last_remote_config_hash,omitempty"`
+	Status               RemoteConfigStatuses   `protobuf:"varint,2,opt,name=status,proto3,enum=config.v1alpha1.RemoteConfigStatuses" json:"status,omitempty"`
+	ErrorMessage         string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RemoteConfigStatus) Reset() {
+	*x = RemoteConfigStatus{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoteConfigStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteConfigStatus) ProtoMessage() {}
+
+func (x *RemoteConfigStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteConfigStatus.ProtoReflect.Descriptor instead.
+func (*RemoteConfigStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *RemoteConfigStatus) GetLastRemoteConfigHash() []byte {
+	if x != nil {
+		return x.LastRemoteConfigHash
+	}
+	return nil
+}
+
+func (x *RemoteConfigStatus) GetStatus() RemoteConfigStatuses {
+	if x != nil {
+		return x.Status
+	}
+	return RemoteConfigStatuses_REMOTE_CONFIG_STATUSES_UNSET
+}
+
+func (x *RemoteConfigStatus) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// ConfigPush tracks the delivery of a single remote config offer to an agent.
+type ConfigPush struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PushId         string                 `protobuf:"bytes,1,opt,name=push_id,json=pushId,proto3" json:"push_id,omitempty"`
+	AgentId        string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ConfigHash     []byte                 `protobuf:"bytes,3,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+	State          ConfigPushState        `protobuf:"varint,4,opt,name=state,proto3,enum=config.v1alpha1.ConfigPushState" json:"state,omitempty"`
+	OfferedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=offered_at,json=offeredAt,proto3" json:"offered_at,omitempty"`
+	AcknowledgedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=acknowledged_at,json=acknowledgedAt,proto3" json:"acknowledged_at,omitempty"`
+	AppliedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"`
+	ErrorMessage   string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// attempt counts consecutive pushes of the same config hash, starting at 1,
+	// so that retries are visible
+	Attempt       int32 `protobuf:"varint,9,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigPush) Reset() {
+	*x = ConfigPush{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigPush) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigPush) ProtoMessage() {}
+
+func (x *ConfigPush) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigPush.ProtoReflect.Descriptor instead.
+func (*ConfigPush) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ConfigPush) GetPushId() string {
+	if x != nil {
+		return x.PushId
+	}
+	return ""
+}
+
+func (x *ConfigPush) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ConfigPush) GetConfigHash() []byte {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return nil
+}
+
+func (x *ConfigPush) GetState() ConfigPushState {
+	if x != nil {
+		return x.State
+	}
+	return ConfigPushState_CONFIG_PUSH_STATE_UNSPECIFIED
+}
+
+func (x *ConfigPush) GetOfferedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OfferedAt
+	}
+	return nil
+}
+
+func (x *ConfigPush) GetAcknowledgedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcknowledgedAt
+	}
+	return nil
+}
+
+func (x *ConfigPush) GetAppliedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return nil
+}
+
+func (x *ConfigPush) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ConfigPush) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+// ConfigPushHistory holds the most recent pushes to an agent, oldest first.
+type ConfigPushHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pushes        []*ConfigPush          `protobuf:"bytes,1,rep,name=pushes,proto3" json:"pushes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigPushHistory) Reset() {
+	*x = ConfigPushHistory{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigPushHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigPushHistory) ProtoMessage() {}
+
+func (x *ConfigPushHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigPushHistory.ProtoReflect.Descriptor instead.
+func (*ConfigPushHistory) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ConfigPushHistory) GetPushes() []*ConfigPush {
+	if x != nil {
+		return x.Pushes
+	}
+	return nil
+}
+
+// ConfigPushOffer is sent to supervisors in an OpAMP custom message alongside a remote config.
+type ConfigPushOffer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PushId        string                 `protobuf:"bytes,1,opt,name=push_id,json=pushId,proto3" json:"push_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigPushOffer) Reset() {
+	*x = ConfigPushOffer{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigPushOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigPushOffer) ProtoMessage() {}
+
+func (x *ConfigPushOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigPushOffer.ProtoReflect.Descriptor instead.
+func (*ConfigPushOffer) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ConfigPushOffer) GetPushId() string {
+	if x != nil {
+		return x.PushId
+	}
+	return ""
+}
+
+// ConfigPushReceipt is sent by supervisors in an OpAMP custom message once they
+// processed the remote config of a ConfigPushOffer.
+type ConfigPushReceipt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PushId        string                 `protobuf:"bytes,1,opt,name=push_id,json=pushId,proto3" json:"push_id,omitempty"`
+	Applied       bool                   `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigPushReceipt) Reset() {
+	*x = ConfigPushReceipt{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigPushReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigPushReceipt) ProtoMessage() {}
+
+func (x *ConfigPushReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigPushReceipt.ProtoReflect.Descriptor instead.
+func (*ConfigPushReceipt) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ConfigPushReceipt) GetPushId() string {
+	if x != nil {
+		return x.PushId
+	}
+	return ""
+}
+
+func (x *ConfigPushReceipt) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+func (x *ConfigPushReceipt) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// AvailabilityHistory records the periods an agent was heard from, oldest first
+type AvailabilityHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Periods       []*AvailabilityPeriod  `protobuf:"bytes,1,rep,name=periods,proto3" json:"periods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvailabilityHistory) Reset() {
+	*x = AvailabilityHistory{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvailabilityHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailabilityHistory) ProtoMessage() {}
+
+func (x *AvailabilityHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailabilityHistory.ProtoReflect.Descriptor instead.
+func (*AvailabilityHistory) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *AvailabilityHistory) GetPeriods() []*AvailabilityPeriod {
 	if x != nil {
-		return x.AgentId
+		return x.Periods
 	}
-	return ""
+	return nil
 }
 
-type AgentStatus struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	State              AgentState             `protobuf:"varint,1,opt,name=state,proto3,enum=config.v1alpha1.AgentState" json:"state,omitempty"`
-	Health             *ComponentHealth       `protobuf:"bytes,2,opt,name=health,proto3" json:"health,omitempty"`
-	EffectiveConfig    *EffectiveConfig       `protobuf:"bytes,3,opt,name=effective_config,json=effectiveConfig,proto3" json:"effective_config,omitempty"`
-	RemoteConfigStatus *RemoteConfigStatus    `protobuf:"bytes,4,opt,name=remote_config_status,json=remoteConfigStatus,proto3" json:"remote_config_status,omitempty"`
-	// New fields for unified status
-	LastSeen         *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
-	ConfigSyncStatus ConfigSyncStatus       `protobuf:"varint,6,opt,name=config_sync_status,json=configSyncStatus,proto3,enum=config.v1alpha1.ConfigSyncStatus" json:"config_sync_status,omitempty"`
-	ConfigSyncReason string                 `protobuf:"bytes,7,opt,name=config_sync_reason,json=configSyncReason,proto3" json:"config_sync_reason,omitempty"`
-	ConnectedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
-	DisconnectedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=disconnected_at,json=disconnectedAt,proto3" json:"disconnected_at,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+// AvailabilityPeriod is a stretch of time the agent sent heartbeats no further apart than
+// the heartbeat timeout, with the same health
+type AvailabilityPeriod struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// time of the last heartbeat of the period
+	End     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	Healthy bool                   `protobuf:"varint,3,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	// set when the period ended with the agent disconnecting, open periods are assumed to last
+	// until the heartbeat timeout expires
+	Closed        bool `protobuf:"varint,4,opt,name=closed,proto3" json:"closed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentStatus) Reset() {
-	*x = AgentStatus{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[9]
+func (x *AvailabilityPeriod) Reset() {
+	*x = AvailabilityPeriod{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentStatus) String() string {
+func (x *AvailabilityPeriod) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentStatus) ProtoMessage() {}
+func (*AvailabilityPeriod) ProtoMessage() {}
 
-func (x *AgentStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[9]
+func (x *AvailabilityPeriod) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -638,104 +4421,179 @@ func (x *AgentStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentStatus.ProtoReflect.Descriptor instead.
-func (*AgentStatus) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use AvailabilityPeriod.ProtoReflect.Descriptor instead.
+func (*AvailabilityPeriod) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *AgentStatus) GetState() AgentState {
+func (x *AvailabilityPeriod) GetStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.State
+		return x.Start
 	}
-	return AgentState_AGENT_STATE_UNKNOWN
+	return nil
 }
 
-func (x *AgentStatus) GetHealth() *ComponentHealth {
+func (x *AvailabilityPeriod) GetEnd() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Health
+		return x.End
 	}
 	return nil
 }
 
-func (x *AgentStatus) GetEffectiveConfig() *EffectiveConfig {
+func (x *AvailabilityPeriod) GetHealthy() bool {
 	if x != nil {
-		return x.EffectiveConfig
+		return x.Healthy
 	}
-	return nil
+	return false
 }
 
-func (x *AgentStatus) GetRemoteConfigStatus() *RemoteConfigStatus {
+func (x *AvailabilityPeriod) GetClosed() bool {
 	if x != nil {
-		return x.RemoteConfigStatus
+		return x.Closed
 	}
-	return nil
+	return false
 }
 
-func (x *AgentStatus) GetLastSeen() *timestamppb.Timestamp {
-	if x != nil {
-		return x.LastSeen
-	}
-	return nil
+type GetAgentAvailabilityRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// defaults to 1h, 24h, 7d and 30d, windows longer than 30d are not supported
+	Windows       []*durationpb.Duration `protobuf:"bytes,2,rep,name=windows,proto3" json:"windows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentStatus) GetConfigSyncStatus() ConfigSyncStatus {
+func (x *GetAgentAvailabilityRequest) Reset() {
+	*x = GetAgentAvailabilityRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentAvailabilityRequest) ProtoMessage() {}
+
+func (x *GetAgentAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[59]
 	if x != nil {
-		return x.ConfigSyncStatus
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ConfigSyncStatus_CONFIG_SYNC_STATUS_UNKNOWN
+	return mi.MessageOf(x)
 }
 
-func (x *AgentStatus) GetConfigSyncReason() string {
+// Deprecated: Use GetAgentAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetAgentAvailabilityRequest) GetAgentId() string {
 	if x != nil {
-		return x.ConfigSyncReason
+		return x.AgentId
 	}
 	return ""
 }
 
-func (x *AgentStatus) GetConnectedAt() *timestamppb.Timestamp {
+func (x *GetAgentAvailabilityRequest) GetWindows() []*durationpb.Duration {
 	if x != nil {
-		return x.ConnectedAt
+		return x.Windows
 	}
 	return nil
 }
 
-func (x *AgentStatus) GetDisconnectedAt() *timestamppb.Timestamp {
+// WindowAvailability is the availability over the window ending now. Windows start no
+// earlier than the first heartbeat of the agent.
+type WindowAvailability struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Window *durationpb.Duration   `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"`
+	// share of the window the agent was connected, between 0 and 1
+	Connected float64 `protobuf:"fixed64,2,opt,name=connected,proto3" json:"connected,omitempty"`
+	// share of the window the agent was connected and healthy, between 0 and 1
+	Healthy       float64 `protobuf:"fixed64,3,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WindowAvailability) Reset() {
+	*x = WindowAvailability{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WindowAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WindowAvailability) ProtoMessage() {}
+
+func (x *WindowAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[60]
 	if x != nil {
-		return x.DisconnectedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WindowAvailability.ProtoReflect.Descriptor instead.
+func (*WindowAvailability) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *WindowAvailability) GetWindow() *durationpb.Duration {
+	if x != nil {
+		return x.Window
 	}
 	return nil
 }
 
-// AgentRegistration represents the core agent identity and attributes.
-// This is the preferred type name for agent registration data.
-type AgentRegistration struct {
-	state        protoimpl.MessageState `protogen:"open.v1"`
-	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FriendlyName string                 `protobuf:"bytes,2,opt,name=friendly_name,json=friendlyName,proto3" json:"friendly_name,omitempty"`
-	// Attributes that identify the Agent (e.g., service.name, service.version, service.instance.id).
-	IdentifyingAttributes []*KeyValue `protobuf:"bytes,3,rep,name=identifying_attributes,json=identifyingAttributes,proto3" json:"identifying_attributes,omitempty"`
-	// Attributes that do not necessarily identify the Agent but help describe where it runs.
-	NonIdentifyingAttributes []*KeyValue `protobuf:"bytes,4,rep,name=non_identifying_attributes,json=nonIdentifyingAttributes,proto3" json:"non_identifying_attributes,omitempty"`
-	Capabilities             []string    `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
-	unknownFields            protoimpl.UnknownFields
-	sizeCache                protoimpl.SizeCache
+func (x *WindowAvailability) GetConnected() float64 {
+	if x != nil {
+		return x.Connected
+	}
+	return 0
 }
 
-func (x *AgentRegistration) Reset() {
-	*x = AgentRegistration{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[10]
+func (x *WindowAvailability) GetHealthy() float64 {
+	if x != nil {
+		return x.Healthy
+	}
+	return 0
+}
+
+type AgentAvailability struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Windows       []*WindowAvailability  `protobuf:"bytes,2,rep,name=windows,proto3" json:"windows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentAvailability) Reset() {
+	*x = AgentAvailability{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentRegistration) String() string {
+func (x *AgentAvailability) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentRegistration) ProtoMessage() {}
+func (*AgentAvailability) ProtoMessage() {}
 
-func (x *AgentRegistration) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[10]
+func (x *AgentAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -746,78 +4604,226 @@ func (x *AgentRegistration) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentRegistration.ProtoReflect.Descriptor instead.
-func (*AgentRegistration) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use AgentAvailability.ProtoReflect.Descriptor instead.
+func (*AgentAvailability) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *AgentRegistration) GetId() string {
+func (x *AgentAvailability) GetAgentId() string {
 	if x != nil {
-		return x.Id
+		return x.AgentId
 	}
 	return ""
 }
 
-func (x *AgentRegistration) GetFriendlyName() string {
+func (x *AgentAvailability) GetWindows() []*WindowAvailability {
 	if x != nil {
-		return x.FriendlyName
+		return x.Windows
+	}
+	return nil
+}
+
+type GetFleetAvailabilityRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// label to group agents by, all agents are in a single group if empty
+	GroupBy string `protobuf:"bytes,1,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	// only agents matching every label are included
+	Selector      map[string]string      `protobuf:"bytes,2,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Windows       []*durationpb.Duration `protobuf:"bytes,3,rep,name=windows,proto3" json:"windows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFleetAvailabilityRequest) Reset() {
+	*x = GetFleetAvailabilityRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFleetAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFleetAvailabilityRequest) ProtoMessage() {}
+
+func (x *GetFleetAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFleetAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*GetFleetAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetFleetAvailabilityRequest) GetGroupBy() string {
+	if x != nil {
+		return x.GroupBy
 	}
 	return ""
 }
 
-func (x *AgentRegistration) GetIdentifyingAttributes() []*KeyValue {
+func (x *GetFleetAvailabilityRequest) GetSelector() map[string]string {
 	if x != nil {
-		return x.IdentifyingAttributes
+		return x.Selector
 	}
 	return nil
 }
 
-func (x *AgentRegistration) GetNonIdentifyingAttributes() []*KeyValue {
+func (x *GetFleetAvailabilityRequest) GetWindows() []*durationpb.Duration {
 	if x != nil {
-		return x.NonIdentifyingAttributes
+		return x.Windows
 	}
 	return nil
 }
 
-func (x *AgentRegistration) GetCapabilities() []string {
+// AvailabilityGroup is the mean availability of the agents sharing a label value
+type AvailabilityGroup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// empty for agents without the label
+	LabelValue    string                `protobuf:"bytes,1,opt,name=label_value,json=labelValue,proto3" json:"label_value,omitempty"`
+	AgentCount    int32                 `protobuf:"varint,2,opt,name=agent_count,json=agentCount,proto3" json:"agent_count,omitempty"`
+	Windows       []*WindowAvailability `protobuf:"bytes,3,rep,name=windows,proto3" json:"windows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvailabilityGroup) Reset() {
+	*x = AvailabilityGroup{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvailabilityGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailabilityGroup) ProtoMessage() {}
+
+func (x *AvailabilityGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[63]
 	if x != nil {
-		return x.Capabilities
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailabilityGroup.ProtoReflect.Descriptor instead.
+func (*AvailabilityGroup) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *AvailabilityGroup) GetLabelValue() string {
+	if x != nil {
+		return x.LabelValue
+	}
+	return ""
+}
+
+func (x *AvailabilityGroup) GetAgentCount() int32 {
+	if x != nil {
+		return x.AgentCount
+	}
+	return 0
+}
+
+func (x *AvailabilityGroup) GetWindows() []*WindowAvailability {
+	if x != nil {
+		return x.Windows
 	}
 	return nil
 }
 
-// AgentDescription is kept for backward compatibility.
-// Use AgentRegistration for new code.
-type AgentDescription struct {
-	state        protoimpl.MessageState `protogen:"open.v1"`
-	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FriendlyName string                 `protobuf:"bytes,2,opt,name=friendly_name,json=friendlyName,proto3" json:"friendly_name,omitempty"`
-	// Attributes that identify the Agent (e.g., service.name, service.version, service.instance.id).
-	// These attributes uniquely identify the agent and should be used for its own telemetry.
-	IdentifyingAttributes []*KeyValue `protobuf:"bytes,3,rep,name=identifying_attributes,json=identifyingAttributes,proto3" json:"identifying_attributes,omitempty"`
-	// Attributes that do not necessarily identify the Agent but help describe where it runs
-	// (e.g., os.type, os.version, host.*, cloud.*).
-	NonIdentifyingAttributes []*KeyValue `protobuf:"bytes,4,rep,name=non_identifying_attributes,json=nonIdentifyingAttributes,proto3" json:"non_identifying_attributes,omitempty"`
-	Capabilities             []string    `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
-	unknownFields            protoimpl.UnknownFields
-	sizeCache                protoimpl.SizeCache
+type FleetAvailability struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sorted by label value
+	Groups        []*AvailabilityGroup `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FleetAvailability) Reset() {
+	*x = FleetAvailability{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FleetAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FleetAvailability) ProtoMessage() {}
+
+func (x *FleetAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FleetAvailability.ProtoReflect.Descriptor instead.
+func (*FleetAvailability) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *AgentDescription) Reset() {
-	*x = AgentDescription{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[11]
+func (x *FleetAvailability) GetGroups() []*AvailabilityGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// AgentCommandStatus tracks a command sent to an agent
+type AgentCommandStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the command, e.g. "restart"
+	Type  string            `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	State AgentCommandState `protobuf:"varint,3,opt,name=state,proto3,enum=config.v1alpha1.AgentCommandState" json:"state,omitempty"`
+	// principal that requested the command, empty for anonymous callers
+	RequestedBy string                 `protobuf:"bytes,4,opt,name=requested_by,json=requestedBy,proto3" json:"requested_by,omitempty"`
+	RequestedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	// when the agent reported the result
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,7,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentCommandStatus) Reset() {
+	*x = AgentCommandStatus{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentDescription) String() string {
+func (x *AgentCommandStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentDescription) ProtoMessage() {}
+func (*AgentCommandStatus) ProtoMessage() {}
 
-func (x *AgentDescription) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[11]
+func (x *AgentCommandStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -828,70 +4834,86 @@ func (x *AgentDescription) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentDescription.ProtoReflect.Descriptor instead.
-func (*AgentDescription) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use AgentCommandStatus.ProtoReflect.Descriptor instead.
+func (*AgentCommandStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *AgentDescription) GetId() string {
+func (x *AgentCommandStatus) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *AgentDescription) GetFriendlyName() string {
+func (x *AgentCommandStatus) GetType() string {
 	if x != nil {
-		return x.FriendlyName
+		return x.Type
 	}
 	return ""
 }
 
-func (x *AgentDescription) GetIdentifyingAttributes() []*KeyValue {
+func (x *AgentCommandStatus) GetState() AgentCommandState {
 	if x != nil {
-		return x.IdentifyingAttributes
+		return x.State
 	}
-	return nil
+	return AgentCommandState_AGENT_COMMAND_STATE_UNSPECIFIED
 }
 
-func (x *AgentDescription) GetNonIdentifyingAttributes() []*KeyValue {
+func (x *AgentCommandStatus) GetRequestedBy() string {
 	if x != nil {
-		return x.NonIdentifyingAttributes
+		return x.RequestedBy
+	}
+	return ""
+}
+
+func (x *AgentCommandStatus) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
 	}
 	return nil
 }
 
-func (x *AgentDescription) GetCapabilities() []string {
+func (x *AgentCommandStatus) GetCompletedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Capabilities
+		return x.CompletedAt
 	}
 	return nil
 }
 
-// KeyValue represents a key-value pair with support for various value types.
-type KeyValue struct {
+func (x *AgentCommandStatus) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+// AgentCommandResult is sent by supervisors once they carried out a command. OpAMP commands
+// carry no ID, the result is for the agent's last command of the same type.
+type AgentCommandResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value         *AnyValue              `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Succeeded     bool                   `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyValue) Reset() {
-	*x = KeyValue{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[12]
+func (x *AgentCommandResult) Reset() {
+	*x = AgentCommandResult{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyValue) String() string {
+func (x *AgentCommandResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyValue) ProtoMessage() {}
+func (*AgentCommandResult) ProtoMessage() {}
 
-func (x *KeyValue) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[12]
+func (x *AgentCommandResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -902,57 +4924,54 @@ func (x *KeyValue) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyValue.ProtoReflect.Descriptor instead.
-func (*KeyValue) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use AgentCommandResult.ProtoReflect.Descriptor instead.
+func (*AgentCommandResult) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *KeyValue) GetKey() string {
+func (x *AgentCommandResult) GetType() string {
 	if x != nil {
-		return x.Key
+		return x.Type
 	}
 	return ""
 }
 
-func (x *KeyValue) GetValue() *AnyValue {
+func (x *AgentCommandResult) GetSucceeded() bool {
 	if x != nil {
-		return x.Value
+		return x.Succeeded
 	}
-	return nil
+	return false
 }
 
-// AnyValue represents a value that can be one of several types.
-type AnyValue struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Value:
-	//
-	//	*AnyValue_StringValue
-	//	*AnyValue_BoolValue
-	//	*AnyValue_IntValue
-	//	*AnyValue_DoubleValue
-	//	*AnyValue_BytesValue
-	//	*AnyValue_ArrayValue
-	//	*AnyValue_KvlistValue
-	Value         isAnyValue_Value `protobuf_oneof:"value"`
+func (x *AgentCommandResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type RestartAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AnyValue) Reset() {
-	*x = AnyValue{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[13]
+func (x *RestartAgentRequest) Reset() {
+	*x = RestartAgentRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AnyValue) String() string {
+func (x *RestartAgentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AnyValue) ProtoMessage() {}
+func (*RestartAgentRequest) ProtoMessage() {}
 
-func (x *AnyValue) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[13]
+func (x *RestartAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -963,150 +4982,84 @@ func (x *AnyValue) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AnyValue.ProtoReflect.Descriptor instead.
-func (*AnyValue) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *AnyValue) GetValue() isAnyValue_Value {
-	if x != nil {
-		return x.Value
-	}
-	return nil
+// Deprecated: Use RestartAgentRequest.ProtoReflect.Descriptor instead.
+func (*RestartAgentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *AnyValue) GetStringValue() string {
+func (x *RestartAgentRequest) GetAgentId() string {
 	if x != nil {
-		if x, ok := x.Value.(*AnyValue_StringValue); ok {
-			return x.StringValue
-		}
+		return x.AgentId
 	}
 	return ""
 }
 
-func (x *AnyValue) GetBoolValue() bool {
-	if x != nil {
-		if x, ok := x.Value.(*AnyValue_BoolValue); ok {
-			return x.BoolValue
-		}
-	}
-	return false
+type RestartAgentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Command       *AgentCommandStatus    `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AnyValue) GetIntValue() int64 {
-	if x != nil {
-		if x, ok := x.Value.(*AnyValue_IntValue); ok {
-			return x.IntValue
-		}
-	}
-	return 0
+func (x *RestartAgentResponse) Reset() {
+	*x = RestartAgentResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *AnyValue) GetDoubleValue() float64 {
-	if x != nil {
-		if x, ok := x.Value.(*AnyValue_DoubleValue); ok {
-			return x.DoubleValue
-		}
-	}
-	return 0
+func (x *RestartAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *AnyValue) GetBytesValue() []byte {
+func (*RestartAgentResponse) ProtoMessage() {}
+
+func (x *RestartAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[68]
 	if x != nil {
-		if x, ok := x.Value.(*AnyValue_BytesValue); ok {
-			return x.BytesValue
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *AnyValue) GetArrayValue() *ArrayValue {
-	if x != nil {
-		if x, ok := x.Value.(*AnyValue_ArrayValue); ok {
-			return x.ArrayValue
-		}
-	}
-	return nil
+// Deprecated: Use RestartAgentResponse.ProtoReflect.Descriptor instead.
+func (*RestartAgentResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *AnyValue) GetKvlistValue() *KeyValueList {
+func (x *RestartAgentResponse) GetCommand() *AgentCommandStatus {
 	if x != nil {
-		if x, ok := x.Value.(*AnyValue_KvlistValue); ok {
-			return x.KvlistValue
-		}
+		return x.Command
 	}
 	return nil
 }
 
-type isAnyValue_Value interface {
-	isAnyValue_Value()
-}
-
-type AnyValue_StringValue struct {
-	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
-}
-
-type AnyValue_BoolValue struct {
-	BoolValue bool `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3,oneof"`
-}
-
-type AnyValue_IntValue struct {
-	IntValue int64 `protobuf:"varint,3,opt,name=int_value,json=intValue,proto3,oneof"`
-}
-
-type AnyValue_DoubleValue struct {
-	DoubleValue float64 `protobuf:"fixed64,4,opt,name=double_value,json=doubleValue,proto3,oneof"`
-}
-
-type AnyValue_BytesValue struct {
-	BytesValue []byte `protobuf:"bytes,5,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
-}
-
-type AnyValue_ArrayValue struct {
-	ArrayValue *ArrayValue `protobuf:"bytes,6,opt,name=array_value,json=arrayValue,proto3,oneof"`
-}
-
-type AnyValue_KvlistValue struct {
-	KvlistValue *KeyValueList `protobuf:"bytes,7,opt,name=kvlist_value,json=kvlistValue,proto3,oneof"`
-}
-
-func (*AnyValue_StringValue) isAnyValue_Value() {}
-
-func (*AnyValue_BoolValue) isAnyValue_Value() {}
-
-func (*AnyValue_IntValue) isAnyValue_Value() {}
-
-func (*AnyValue_DoubleValue) isAnyValue_Value() {}
-
-func (*AnyValue_BytesValue) isAnyValue_Value() {}
-
-func (*AnyValue_ArrayValue) isAnyValue_Value() {}
-
-func (*AnyValue_KvlistValue) isAnyValue_Value() {}
-
-// ArrayValue holds an array of AnyValue.
-type ArrayValue struct {
+type UndeleteAgentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Values        []*AnyValue            `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ArrayValue) Reset() {
-	*x = ArrayValue{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[14]
+func (x *UndeleteAgentRequest) Reset() {
+	*x = UndeleteAgentRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[69]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ArrayValue) String() string {
+func (x *UndeleteAgentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ArrayValue) ProtoMessage() {}
+func (*UndeleteAgentRequest) ProtoMessage() {}
 
-func (x *ArrayValue) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[14]
+func (x *UndeleteAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[69]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1117,41 +5070,40 @@ func (x *ArrayValue) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ArrayValue.ProtoReflect.Descriptor instead.
-func (*ArrayValue) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use UndeleteAgentRequest.ProtoReflect.Descriptor instead.
+func (*UndeleteAgentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *ArrayValue) GetValues() []*AnyValue {
+func (x *UndeleteAgentRequest) GetAgentId() string {
 	if x != nil {
-		return x.Values
+		return x.AgentId
 	}
-	return nil
+	return ""
 }
 
-// KeyValueList holds a list of KeyValue pairs.
-type KeyValueList struct {
+type RevokeAgentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Values        []*KeyValue            `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *KeyValueList) Reset() {
-	*x = KeyValueList{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[15]
+func (x *RevokeAgentRequest) Reset() {
+	*x = RevokeAgentRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *KeyValueList) String() string {
+func (x *RevokeAgentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyValueList) ProtoMessage() {}
+func (*RevokeAgentRequest) ProtoMessage() {}
 
-func (x *KeyValueList) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[15]
+func (x *RevokeAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1162,49 +5114,50 @@ func (x *KeyValueList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyValueList.ProtoReflect.Descriptor instead.
-func (*KeyValueList) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use RevokeAgentRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAgentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *KeyValueList) GetValues() []*KeyValue {
+func (x *RevokeAgentRequest) GetAgentId() string {
 	if x != nil {
-		return x.Values
+		return x.AgentId
 	}
-	return nil
+	return ""
 }
 
-// AgentConnectionState represents the persisted connection state of an agent.
-// This replaces the in-memory AgentTracker state.
-type AgentConnectionState struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	AgentId        string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	State          AgentState             `protobuf:"varint,2,opt,name=state,proto3,enum=config.v1alpha1.AgentState" json:"state,omitempty"`
-	LastSeen       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
-	ConnectedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
-	DisconnectedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=disconnected_at,json=disconnectedAt,proto3" json:"disconnected_at,omitempty"`
-	InstanceUid    []byte                 `protobuf:"bytes,6,opt,name=instance_uid,json=instanceUid,proto3" json:"instance_uid,omitempty"`
-	Capabilities   uint64                 `protobuf:"varint,7,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
-	SequenceNum    uint64                 `protobuf:"varint,8,opt,name=sequence_num,json=sequenceNum,proto3" json:"sequence_num,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type GetAgentEventsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// inclusive lower bound on the event time
+	Start *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	// exclusive upper bound on the event time
+	End *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	// event types to return, all if empty
+	Types []string `protobuf:"bytes,4,rep,name=types,proto3" json:"types,omitempty"`
+	// maximum number of events to return, 0 for the server default
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_page_token from a previous response
+	PageToken     string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentConnectionState) Reset() {
-	*x = AgentConnectionState{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[16]
+func (x *GetAgentEventsRequest) Reset() {
+	*x = GetAgentEventsRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentConnectionState) String() string {
+func (x *GetAgentEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentConnectionState) ProtoMessage() {}
+func (*GetAgentEventsRequest) ProtoMessage() {}
 
-func (x *AgentConnectionState) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[16]
+func (x *GetAgentEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1215,95 +5168,76 @@ func (x *AgentConnectionState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentConnectionState.ProtoReflect.Descriptor instead.
-func (*AgentConnectionState) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use GetAgentEventsRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentEventsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *AgentConnectionState) GetAgentId() string {
+func (x *GetAgentEventsRequest) GetAgentId() string {
 	if x != nil {
 		return x.AgentId
 	}
 	return ""
 }
 
-func (x *AgentConnectionState) GetState() AgentState {
-	if x != nil {
-		return x.State
-	}
-	return AgentState_AGENT_STATE_UNKNOWN
-}
-
-func (x *AgentConnectionState) GetLastSeen() *timestamppb.Timestamp {
-	if x != nil {
-		return x.LastSeen
-	}
-	return nil
-}
-
-func (x *AgentConnectionState) GetConnectedAt() *timestamppb.Timestamp {
+func (x *GetAgentEventsRequest) GetStart() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ConnectedAt
+		return x.Start
 	}
 	return nil
 }
 
-func (x *AgentConnectionState) GetDisconnectedAt() *timestamppb.Timestamp {
+func (x *GetAgentEventsRequest) GetEnd() *timestamppb.Timestamp {
 	if x != nil {
-		return x.DisconnectedAt
+		return x.End
 	}
 	return nil
 }
 
-func (x *AgentConnectionState) GetInstanceUid() []byte {
+func (x *GetAgentEventsRequest) GetTypes() []string {
 	if x != nil {
-		return x.InstanceUid
+		return x.Types
 	}
 	return nil
 }
 
-func (x *AgentConnectionState) GetCapabilities() uint64 {
+func (x *GetAgentEventsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Capabilities
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *AgentConnectionState) GetSequenceNum() uint64 {
+func (x *GetAgentEventsRequest) GetPageToken() string {
 	if x != nil {
-		return x.SequenceNum
+		return x.PageToken
 	}
-	return 0
+	return ""
 }
 
-// ComponentHealth represents the health status of an agent and its components.
-type ComponentHealth struct {
-	state              protoimpl.MessageState      `protogen:"open.v1"`
-	Healthy            bool                        `protobuf:"varint,1,opt,name=healthy,proto3" json:"healthy,omitempty"`
-	StartTimeUnixNano  uint64                      `protobuf:"varint,2,opt,name=start_time_unix_nano,json=startTimeUnixNano,proto3" json:"start_time_unix_nano,omitempty"`
-	LastError          string                      `protobuf:"bytes,3,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
-	Status             string                      `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	StatusTimeUnixNano uint64                      `protobuf:"varint,5,opt,name=status_time_unix_nano,json=statusTimeUnixNano,proto3" json:"status_time_unix_nano,omitempty"`
-	ComponentHealthMap map[string]*ComponentHealth `protobuf:"bytes,6,rep,name=component_health_map,json=componentHealthMap,proto3" json:"component_health_map,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type GetAgentEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*v1alpha1.Event      `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ComponentHealth) Reset() {
-	*x = ComponentHealth{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[17]
+func (x *GetAgentEventsResponse) Reset() {
+	*x = GetAgentEventsResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ComponentHealth) String() string {
+func (x *GetAgentEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ComponentHealth) ProtoMessage() {}
+func (*GetAgentEventsResponse) ProtoMessage() {}
 
-func (x *ComponentHealth) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[17]
+func (x *GetAgentEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1314,76 +5248,129 @@ func (x *ComponentHealth) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ComponentHealth.ProtoReflect.Descriptor instead.
-func (*ComponentHealth) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use GetAgentEventsResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentEventsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *ComponentHealth) GetHealthy() bool {
+func (x *GetAgentEventsResponse) GetEvents() []*v1alpha1.Event {
 	if x != nil {
-		return x.Healthy
+		return x.Events
 	}
-	return false
+	return nil
 }
 
-func (x *ComponentHealth) GetStartTimeUnixNano() uint64 {
+func (x *GetAgentEventsResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.StartTimeUnixNano
+		return x.NextPageToken
 	}
-	return 0
+	return ""
 }
 
-func (x *ComponentHealth) GetLastError() string {
+// AgentProblemReport is sent by supervisors when they detect a problem they can't recover
+// from by themselves. A problem is reported once when it starts, and again if it recurs
+// after it cleared.
+type AgentProblemReport struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Type    AgentProblemType       `protobuf:"varint,1,opt,name=type,proto3,enum=config.v1alpha1.AgentProblemType" json:"type,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// how many times the problem occurred, e.g. the consecutive failed config applies
+	Occurrences   int32             `protobuf:"varint,3,opt,name=occurrences,proto3" json:"occurrences,omitempty"`
+	Details       map[string]string `protobuf:"bytes,4,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentProblemReport) Reset() {
+	*x = AgentProblemReport{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentProblemReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentProblemReport) ProtoMessage() {}
+
+func (x *AgentProblemReport) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[73]
 	if x != nil {
-		return x.LastError
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ComponentHealth) GetStatus() string {
+// Deprecated: Use AgentProblemReport.ProtoReflect.Descriptor instead.
+func (*AgentProblemReport) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *AgentProblemReport) GetType() AgentProblemType {
 	if x != nil {
-		return x.Status
+		return x.Type
+	}
+	return AgentProblemType_AGENT_PROBLEM_TYPE_UNSPECIFIED
+}
+
+func (x *AgentProblemReport) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *ComponentHealth) GetStatusTimeUnixNano() uint64 {
+func (x *AgentProblemReport) GetOccurrences() int32 {
 	if x != nil {
-		return x.StatusTimeUnixNano
+		return x.Occurrences
 	}
 	return 0
 }
 
-func (x *ComponentHealth) GetComponentHealthMap() map[string]*ComponentHealth {
+func (x *AgentProblemReport) GetDetails() map[string]string {
 	if x != nil {
-		return x.ComponentHealthMap
+		return x.Details
 	}
 	return nil
 }
 
-// EffectiveConfig represents the current effective configuration of an agent.
-type EffectiveConfig struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ConfigMap     *AgentConfigMap        `protobuf:"bytes,1,opt,name=config_map,json=configMap,proto3" json:"config_map,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *EffectiveConfig) Reset() {
-	*x = EffectiveConfig{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[18]
+// AgentProblem is a problem reported by an agent, open until it is acknowledged
+type AgentProblem struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Type    AgentProblemType       `protobuf:"varint,2,opt,name=type,proto3,enum=config.v1alpha1.AgentProblemType" json:"type,omitempty"`
+	// from the last report of the problem
+	Message     string            `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Occurrences int32             `protobuf:"varint,4,opt,name=occurrences,proto3" json:"occurrences,omitempty"`
+	Details     map[string]string `protobuf:"bytes,5,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// reports received since the problem was opened
+	Reports         int32                  `protobuf:"varint,6,opt,name=reports,proto3" json:"reports,omitempty"`
+	FirstReportedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=first_reported_at,json=firstReportedAt,proto3" json:"first_reported_at,omitempty"`
+	LastReportedAt  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_reported_at,json=lastReportedAt,proto3" json:"last_reported_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AgentProblem) Reset() {
+	*x = AgentProblem{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EffectiveConfig) String() string {
+func (x *AgentProblem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EffectiveConfig) ProtoMessage() {}
+func (*AgentProblem) ProtoMessage() {}
 
-func (x *EffectiveConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[18]
+func (x *AgentProblem) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1394,41 +5381,92 @@ func (x *EffectiveConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EffectiveConfig.ProtoReflect.Descriptor instead.
-func (*EffectiveConfig) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use AgentProblem.ProtoReflect.Descriptor instead.
+func (*AgentProblem) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *EffectiveConfig) GetConfigMap() *AgentConfigMap {
+func (x *AgentProblem) GetAgentId() string {
 	if x != nil {
-		return x.ConfigMap
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentProblem) GetType() AgentProblemType {
+	if x != nil {
+		return x.Type
+	}
+	return AgentProblemType_AGENT_PROBLEM_TYPE_UNSPECIFIED
+}
+
+func (x *AgentProblem) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AgentProblem) GetOccurrences() int32 {
+	if x != nil {
+		return x.Occurrences
+	}
+	return 0
+}
+
+func (x *AgentProblem) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
 	}
 	return nil
 }
 
-// AgentConfigMap holds a map of config file names to their content.
-type AgentConfigMap struct {
-	state         protoimpl.MessageState      `protogen:"open.v1"`
-	ConfigMap     map[string]*AgentConfigFile `protobuf:"bytes,1,rep,name=config_map,json=configMap,proto3" json:"config_map,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+func (x *AgentProblem) GetReports() int32 {
+	if x != nil {
+		return x.Reports
+	}
+	return 0
+}
+
+func (x *AgentProblem) GetFirstReportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstReportedAt
+	}
+	return nil
+}
+
+func (x *AgentProblem) GetLastReportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastReportedAt
+	}
+	return nil
+}
+
+type GetProblemsReportRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// agent whose problems to return, all agents if empty
+	AgentId string `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// problem types to return, all if empty
+	Types         []AgentProblemType `protobuf:"varint,2,rep,packed,name=types,proto3,enum=config.v1alpha1.AgentProblemType" json:"types,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentConfigMap) Reset() {
-	*x = AgentConfigMap{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[19]
+func (x *GetProblemsReportRequest) Reset() {
+	*x = GetProblemsReportRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentConfigMap) String() string {
+func (x *GetProblemsReportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentConfigMap) ProtoMessage() {}
+func (*GetProblemsReportRequest) ProtoMessage() {}
 
-func (x *AgentConfigMap) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[19]
+func (x *GetProblemsReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1439,42 +5477,48 @@ func (x *AgentConfigMap) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentConfigMap.ProtoReflect.Descriptor instead.
-func (*AgentConfigMap) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use GetProblemsReportRequest.ProtoReflect.Descriptor instead.
+func (*GetProblemsReportRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *AgentConfigMap) GetConfigMap() map[string]*AgentConfigFile {
+func (x *GetProblemsReportRequest) GetAgentId() string {
 	if x != nil {
-		return x.ConfigMap
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *GetProblemsReportRequest) GetTypes() []AgentProblemType {
+	if x != nil {
+		return x.Types
 	}
 	return nil
 }
 
-// AgentConfigFile represents a single configuration file.
-type AgentConfigFile struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Body          []byte                 `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
-	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+type GetProblemsReportResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ordered by agent ID, then problem type
+	Problems      []*AgentProblem `protobuf:"bytes,1,rep,name=problems,proto3" json:"problems,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentConfigFile) Reset() {
-	*x = AgentConfigFile{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[20]
+func (x *GetProblemsReportResponse) Reset() {
+	*x = GetProblemsReportResponse{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentConfigFile) String() string {
+func (x *GetProblemsReportResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentConfigFile) ProtoMessage() {}
+func (*GetProblemsReportResponse) ProtoMessage() {}
 
-func (x *AgentConfigFile) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[20]
+func (x *GetProblemsReportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1485,50 +5529,41 @@ func (x *AgentConfigFile) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentConfigFile.ProtoReflect.Descriptor instead.
-func (*AgentConfigFile) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use GetProblemsReportResponse.ProtoReflect.Descriptor instead.
+func (*GetProblemsReportResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *AgentConfigFile) GetBody() []byte {
+func (x *GetProblemsReportResponse) GetProblems() []*AgentProblem {
 	if x != nil {
-		return x.Body
+		return x.Problems
 	}
 	return nil
 }
 
-func (x *AgentConfigFile) GetContentType() string {
-	if x != nil {
-		return x.ContentType
-	}
-	return ""
-}
-
-// RemoteConfigStatus represents the status of a remote configuration on an agent.
-type RemoteConfigStatus struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	LastRemoteConfigHash []byte                 `protobuf:"bytes,1,opt,name=last_remote_config_hash,json=lastRemoteConfigHash,proto3" json:"last_remote_config_hash,omitempty"`
-	Status               RemoteConfigStatuses   `protobuf:"varint,2,opt,name=status,proto3,enum=config.v1alpha1.RemoteConfigStatuses" json:"status,omitempty"`
-	ErrorMessage         string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+type AcknowledgeProblemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Type          AgentProblemType       `protobuf:"varint,2,opt,name=type,proto3,enum=config.v1alpha1.AgentProblemType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoteConfigStatus) Reset() {
-	*x = RemoteConfigStatus{}
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[21]
+func (x *AcknowledgeProblemRequest) Reset() {
+	*x = AcknowledgeProblemRequest{}
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoteConfigStatus) String() string {
+func (x *AcknowledgeProblemRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoteConfigStatus) ProtoMessage() {}
+func (*AcknowledgeProblemRequest) ProtoMessage() {}
 
-func (x *RemoteConfigStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[21]
+func (x *AcknowledgeProblemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1539,42 +5574,81 @@ func (x *RemoteConfigStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoteConfigStatus.ProtoReflect.Descriptor instead.
-func (*RemoteConfigStatus) Descriptor() ([]byte, []int) {
-	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{21}
-}
-
-func (x *RemoteConfigStatus) GetLastRemoteConfigHash() []byte {
-	if x != nil {
-		return x.LastRemoteConfigHash
-	}
-	return nil
+// Deprecated: Use AcknowledgeProblemRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgeProblemRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *RemoteConfigStatus) GetStatus() RemoteConfigStatuses {
+func (x *AcknowledgeProblemRequest) GetAgentId() string {
 	if x != nil {
-		return x.Status
+		return x.AgentId
 	}
-	return RemoteConfigStatuses_REMOTE_CONFIG_STATUSES_UNSET
+	return ""
 }
 
-func (x *RemoteConfigStatus) GetErrorMessage() string {
+func (x *AcknowledgeProblemRequest) GetType() AgentProblemType {
 	if x != nil {
-		return x.ErrorMessage
+		return x.Type
 	}
-	return ""
+	return AgentProblemType_AGENT_PROBLEM_TYPE_UNSPECIFIED
 }
 
 var File_pkg_api_agents_v1alpha1_agents_proto protoreflect.FileDescriptor
 
 const file_pkg_api_agents_v1alpha1_agents_proto_rawDesc = "" +
 	"\n" +
-	"$pkg/api/agents/v1alpha1/agents.proto\x12\x0fconfig.v1alpha1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"4\n" +
+	"$pkg/api/agents/v1alpha1/agents.proto\x12\x0fconfig.v1alpha1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a$pkg/api/events/v1alpha1/events.proto\"\x95\x01\n" +
+	"\fRelayRequest\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x12#\n" +
+	"\rconnection_id\x18\x02 \x01(\tR\fconnectionId\x12\x19\n" +
+	"\bagent_id\x18\x03 \x01(\tR\aagentId\x12&\n" +
+	"\x0fagent_to_server\x18\x04 \x01(\fR\ragentToServer\"7\n" +
+	"\rRelayResponse\x12&\n" +
+	"\x0fserver_to_agent\x18\x01 \x03(\fR\rserverToAgent\"4\n" +
+	"\x13WatchGatewayRequest\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\"x\n" +
+	"\x0eRelayedMessage\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12&\n" +
+	"\x0fserver_to_agent\x18\x03 \x01(\fR\rserverToAgent\"c\n" +
+	"\x1dDisconnectRelayedAgentRequest\x12\x1d\n" +
+	"\n" +
+	"gateway_id\x18\x01 \x01(\tR\tgatewayId\x12#\n" +
+	"\rconnection_id\x18\x02 \x01(\tR\fconnectionId\"\xba\x05\n" +
 	"\x11ListAgentsRequest\x12\x1f\n" +
 	"\vwith_status\x18\x01 \x01(\bR\n" +
-	"withStatus\"X\n" +
+	"withStatus\x124\n" +
+	"\x04view\x18\x02 \x01(\x0e2 .config.v1alpha1.AgentStatusViewR\x04view\x12S\n" +
+	"\x14config_sync_statuses\x18\x03 \x03(\x0e2!.config.v1alpha1.ConfigSyncStatusR\x12configSyncStatuses\x12'\n" +
+	"\x0finclude_deleted\x18\x04 \x01(\bR\x0eincludeDeleted\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x128\n" +
+	"\asort_by\x18\a \x01(\x0e2\x1f.config.v1alpha1.AgentSortFieldR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\b \x01(\bR\n" +
+	"descending\x123\n" +
+	"\x06states\x18\t \x03(\x0e2\x1b.config.v1alpha1.AgentStateR\x06states\x12\\\n" +
+	"\x0elabel_selector\x18\n" +
+	" \x03(\v25.config.v1alpha1.ListAgentsRequest.LabelSelectorEntryR\rlabelSelector\x12\x1d\n" +
+	"\n" +
+	"config_ids\x18\v \x03(\tR\tconfigIds\x12F\n" +
+	"\rhealth_states\x18\f \x03(\x0e2!.config.v1alpha1.AgentHealthStateR\fhealthStates\x1a@\n" +
+	"\x12LabelSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xff\x01\n" +
 	"\x12ListAgentsResponse\x12B\n" +
-	"\x06agents\x18\x01 \x03(\v2*.config.v1alpha1.AgentDescriptionAndStatusR\x06agents\"\x89\x01\n" +
+	"\x06agents\x18\x01 \x03(\v2*.config.v1alpha1.AgentDescriptionAndStatusR\x06agents\x127\n" +
+	"\x06errors\x18\x02 \x03(\v2\x1f.config.v1alpha1.AgentLoadErrorR\x06errors\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\x12#\n" +
+	"\rmatched_count\x18\x05 \x01(\x05R\fmatchedCount\"E\n" +
+	"\x0eAgentLoadError\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x89\x01\n" +
 	"\tAgentView\x12F\n" +
 	"\fregistration\x18\x01 \x01(\v2\".config.v1alpha1.AgentRegistrationR\fregistration\x124\n" +
 	"\x06status\x18\x02 \x01(\v2\x1c.config.v1alpha1.AgentStatusR\x06status\"\x8a\x01\n" +
@@ -1584,13 +5658,111 @@ const file_pkg_api_agents_v1alpha1_agents_proto_rawDesc = "" +
 	"\x0fGetAgentRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\"K\n" +
 	"\x10GetAgentResponse\x127\n" +
-	"\x05agent\x18\x01 \x01(\v2!.config.v1alpha1.AgentDescriptionR\x05agent\"2\n" +
+	"\x05agent\x18\x01 \x01(\v2!.config.v1alpha1.AgentDescriptionR\x05agent\"h\n" +
 	"\x15GetAgentStatusRequest\x12\x19\n" +
-	"\bagent_id\x18\x01 \x01(\tR\aagentId\"N\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x124\n" +
+	"\x04view\x18\x02 \x01(\x0e2 .config.v1alpha1.AgentStatusViewR\x04view\"N\n" +
 	"\x16GetAgentStatusResponse\x124\n" +
-	"\x06status\x18\x01 \x01(\v2\x1c.config.v1alpha1.AgentStatusR\x06status\"/\n" +
+	"\x06status\x18\x01 \x01(\v2\x1c.config.v1alpha1.AgentStatusR\x06status\"\xce\x01\n" +
+	"\x1cWaitForAgentConditionRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12=\n" +
+	"\tcondition\x18\x02 \x01(\x0e2\x1f.config.v1alpha1.AgentConditionR\tcondition\x12\x1f\n" +
+	"\vconfig_hash\x18\x03 \x01(\fR\n" +
+	"configHash\x123\n" +
+	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"s\n" +
+	"\x1dWaitForAgentConditionResponse\x12\x1c\n" +
+	"\tsatisfied\x18\x01 \x01(\bR\tsatisfied\x124\n" +
+	"\x06status\x18\x02 \x01(\v2\x1c.config.v1alpha1.AgentStatusR\x06status\"E\n" +
 	"\x12DeleteAgentRequest\x12\x19\n" +
-	"\bagent_id\x18\x01 \x01(\tR\aagentId\"\xda\x04\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x14\n" +
+	"\x05purge\x18\x02 \x01(\bR\x05purge\"U\n" +
+	"\x1bCaptureAgentSnapshotRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
+	"\tmax_bytes\x18\x02 \x01(\x03R\bmaxBytes\"Z\n" +
+	"\x1cCaptureAgentSnapshotResponse\x12:\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x1e.config.v1alpha1.AgentSnapshotR\bsnapshot\":\n" +
+	"\x17GetAgentSnapshotRequest\x12\x1f\n" +
+	"\vsnapshot_id\x18\x01 \x01(\tR\n" +
+	"snapshotId\"V\n" +
+	"\x18GetAgentSnapshotResponse\x12:\n" +
+	"\bsnapshot\x18\x01 \x01(\v2\x1e.config.v1alpha1.AgentSnapshotR\bsnapshot\"6\n" +
+	"\x19ListAgentSnapshotsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"Z\n" +
+	"\x1aListAgentSnapshotsResponse\x12<\n" +
+	"\tsnapshots\x18\x01 \x03(\v2\x1e.config.v1alpha1.AgentSnapshotR\tsnapshots\"M\n" +
+	"\x17ListConfigPushesRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x17\n" +
+	"\apush_id\x18\x02 \x01(\tR\x06pushId\"O\n" +
+	"\x18ListConfigPushesResponse\x123\n" +
+	"\x06pushes\x18\x01 \x03(\v2\x1b.config.v1alpha1.ConfigPushR\x06pushes\"\x1d\n" +
+	"\x1bCaptureFleetSnapshotRequest\"\x1b\n" +
+	"\x19ListFleetSnapshotsRequest\"Z\n" +
+	"\x1aListFleetSnapshotsResponse\x12<\n" +
+	"\tsnapshots\x18\x01 \x03(\v2\x1e.config.v1alpha1.FleetSnapshotR\tsnapshots\"g\n" +
+	"\x15DiffFleetStateRequest\x12(\n" +
+	"\x10from_snapshot_id\x18\x01 \x01(\tR\x0efromSnapshotId\x12$\n" +
+	"\x0eto_snapshot_id\x18\x02 \x01(\tR\ftoSnapshotId\"\xba\x01\n" +
+	"\rFleetSnapshot\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12;\n" +
+	"\vcaptured_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"capturedAt\x12\x1f\n" +
+	"\vagent_count\x18\x03 \x01(\x05R\n" +
+	"agentCount\x12;\n" +
+	"\x06agents\x18\x04 \x03(\v2#.config.v1alpha1.FleetSnapshotAgentR\x06agents\"\xb5\x02\n" +
+	"\x12FleetSnapshotAgent\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x1b\n" +
+	"\tconfig_id\x18\x04 \x01(\tR\bconfigId\x12\x1f\n" +
+	"\vconfig_hash\x18\x05 \x01(\tR\n" +
+	"configHash\x12\x14\n" +
+	"\x05state\x18\x06 \x01(\tR\x05state\x12G\n" +
+	"\x06labels\x18\a \x03(\v2/.config.v1alpha1.FleetSnapshotAgent.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xab\x02\n" +
+	"\x0eFleetStateDiff\x122\n" +
+	"\x04from\x18\x01 \x01(\v2\x1e.config.v1alpha1.FleetSnapshotR\x04from\x12.\n" +
+	"\x02to\x18\x02 \x01(\v2\x1e.config.v1alpha1.FleetSnapshotR\x02to\x129\n" +
+	"\x05added\x18\x03 \x03(\v2#.config.v1alpha1.FleetSnapshotAgentR\x05added\x12=\n" +
+	"\aremoved\x18\x04 \x03(\v2#.config.v1alpha1.FleetSnapshotAgentR\aremoved\x12;\n" +
+	"\achanged\x18\x05 \x03(\v2!.config.v1alpha1.AgentStateChangeR\achanged\"e\n" +
+	"\x10AgentStateChange\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x126\n" +
+	"\achanges\x18\x02 \x03(\v2\x1c.config.v1alpha1.FieldChangeR\achanges\"G\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\"\xb8\x03\n" +
+	"\rAgentSnapshot\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x129\n" +
+	"\x05state\x18\x03 \x01(\x0e2#.config.v1alpha1.AgentSnapshotStateR\x05state\x12=\n" +
+	"\frequested_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x12=\n" +
+	"\fcompleted_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1b\n" +
+	"\tmax_bytes\x18\a \x01(\x03R\bmaxBytes\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\b \x01(\x03R\tsizeBytes\x12\x1c\n" +
+	"\ttruncated\x18\t \x01(\bR\ttruncated\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\x12\x18\n" +
+	"\aarchive\x18\v \x01(\fR\aarchive\"u\n" +
+	"\x0fSnapshotRequest\x12\x1f\n" +
+	"\vsnapshot_id\x18\x01 \x01(\tR\n" +
+	"snapshotId\x12$\n" +
+	"\x0eserver_pub_key\x18\x02 \x01(\fR\fserverPubKey\x12\x1b\n" +
+	"\tmax_bytes\x18\x03 \x01(\x03R\bmaxBytes\"\xab\x01\n" +
+	"\x0eSnapshotUpload\x12\x1f\n" +
+	"\vsnapshot_id\x18\x01 \x01(\tR\n" +
+	"snapshotId\x12$\n" +
+	"\x0eclient_pub_key\x18\x02 \x01(\fR\fclientPubKey\x12\x1e\n" +
+	"\n" +
+	"ciphertext\x18\x03 \x01(\fR\n" +
+	"ciphertext\x12\x1c\n" +
+	"\ttruncated\x18\x04 \x01(\bR\ttruncated\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xc9\x06\n" +
 	"\vAgentStatus\x121\n" +
 	"\x05state\x18\x01 \x01(\x0e2\x1b.config.v1alpha1.AgentStateR\x05state\x128\n" +
 	"\x06health\x18\x02 \x01(\v2 .config.v1alpha1.ComponentHealthR\x06health\x12K\n" +
@@ -1600,19 +5772,43 @@ const file_pkg_api_agents_v1alpha1_agents_proto_rawDesc = "" +
 	"\x12config_sync_status\x18\x06 \x01(\x0e2!.config.v1alpha1.ConfigSyncStatusR\x10configSyncStatus\x12,\n" +
 	"\x12config_sync_reason\x18\a \x01(\tR\x10configSyncReason\x12=\n" +
 	"\fconnected_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vconnectedAt\x12C\n" +
-	"\x0fdisconnected_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0edisconnectedAt\"\x97\x02\n" +
+	"\x0fdisconnected_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0edisconnectedAt\x12!\n" +
+	"\finstance_uid\x18\n" +
+	" \x01(\fR\vinstanceUid\x12I\n" +
+	"\x10instance_history\x18\v \x03(\v2\x1e.config.v1alpha1.AgentInstanceR\x0finstanceHistory\x12F\n" +
+	"\flast_command\x18\f \x01(\v2#.config.v1alpha1.AgentCommandStatusR\vlastCommand\x127\n" +
+	"\ametrics\x18\r \x01(\v2\x1d.config.v1alpha1.AgentMetricsR\ametrics\"\xe5\x02\n" +
+	"\fAgentMetrics\x12;\n" +
+	"\vreceived_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"receivedAt\x12\x1f\n" +
+	"\vcpu_seconds\x18\x02 \x01(\x01R\n" +
+	"cpuSeconds\x12'\n" +
+	"\x0fcpu_utilization\x18\x03 \x01(\x01R\x0ecpuUtilization\x12(\n" +
+	"\x10memory_rss_bytes\x18\x04 \x01(\x03R\x0ememoryRssBytes\x12(\n" +
+	"\x10heap_alloc_bytes\x18\x05 \x01(\x03R\x0eheapAllocBytes\x121\n" +
+	"\x06uptime\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x06uptime\x12G\n" +
+	"\x0fexporter_queues\x18\a \x03(\v2\x1e.config.v1alpha1.ExporterQueueR\x0eexporterQueues\"[\n" +
+	"\rExporterQueue\x12\x1a\n" +
+	"\bexporter\x18\x01 \x01(\tR\bexporter\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x1a\n" +
+	"\bcapacity\x18\x03 \x01(\x03R\bcapacity\"\x97\x02\n" +
 	"\x11AgentRegistration\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
 	"\rfriendly_name\x18\x02 \x01(\tR\ffriendlyName\x12P\n" +
 	"\x16identifying_attributes\x18\x03 \x03(\v2\x19.config.v1alpha1.KeyValueR\x15identifyingAttributes\x12W\n" +
 	"\x1anon_identifying_attributes\x18\x04 \x03(\v2\x19.config.v1alpha1.KeyValueR\x18nonIdentifyingAttributes\x12\"\n" +
-	"\fcapabilities\x18\x05 \x03(\tR\fcapabilities\"\x96\x02\n" +
+	"\fcapabilities\x18\x05 \x03(\tR\fcapabilities\"\xa4\x03\n" +
 	"\x10AgentDescription\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
 	"\rfriendly_name\x18\x02 \x01(\tR\ffriendlyName\x12P\n" +
 	"\x16identifying_attributes\x18\x03 \x03(\v2\x19.config.v1alpha1.KeyValueR\x15identifyingAttributes\x12W\n" +
 	"\x1anon_identifying_attributes\x18\x04 \x03(\v2\x19.config.v1alpha1.KeyValueR\x18nonIdentifyingAttributes\x12\"\n" +
-	"\fcapabilities\x18\x05 \x03(\tR\fcapabilities\"M\n" +
+	"\fcapabilities\x18\x05 \x03(\tR\fcapabilities\x129\n" +
+	"\n" +
+	"deleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x12\x16\n" +
+	"\x06tenant\x18\b \x01(\tR\x06tenant\"M\n" +
 	"\bKeyValue\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12/\n" +
 	"\x05value\x18\x02 \x01(\v2\x19.config.v1alpha1.AnyValueR\x05value\"\xc4\x02\n" +
@@ -1632,7 +5828,7 @@ const file_pkg_api_agents_v1alpha1_agents_proto_rawDesc = "" +
 	"ArrayValue\x121\n" +
 	"\x06values\x18\x01 \x03(\v2\x19.config.v1alpha1.AnyValueR\x06values\"A\n" +
 	"\fKeyValueList\x121\n" +
-	"\x06values\x18\x01 \x03(\v2\x19.config.v1alpha1.KeyValueR\x06values\"\x8b\x03\n" +
+	"\x06values\x18\x01 \x03(\v2\x19.config.v1alpha1.KeyValueR\x06values\"\xd6\x03\n" +
 	"\x14AgentConnectionState\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x121\n" +
 	"\x05state\x18\x02 \x01(\x0e2\x1b.config.v1alpha1.AgentStateR\x05state\x127\n" +
@@ -1641,7 +5837,13 @@ const file_pkg_api_agents_v1alpha1_agents_proto_rawDesc = "" +
 	"\x0fdisconnected_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0edisconnectedAt\x12!\n" +
 	"\finstance_uid\x18\x06 \x01(\fR\vinstanceUid\x12\"\n" +
 	"\fcapabilities\x18\a \x01(\x04R\fcapabilities\x12!\n" +
-	"\fsequence_num\x18\b \x01(\x04R\vsequenceNum\"\x9b\x03\n" +
+	"\fsequence_num\x18\b \x01(\x04R\vsequenceNum\x12I\n" +
+	"\x10instance_history\x18\t \x03(\v2\x1e.config.v1alpha1.AgentInstanceR\x0finstanceHistory\"\xa6\x01\n" +
+	"\rAgentInstance\x12!\n" +
+	"\finstance_uid\x18\x01 \x01(\fR\vinstanceUid\x129\n" +
+	"\n" +
+	"first_seen\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tfirstSeen\x127\n" +
+	"\tlast_seen\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\blastSeen\"\x9b\x03\n" +
 	"\x0fComponentHealth\x12\x18\n" +
 	"\ahealthy\x18\x01 \x01(\bR\ahealthy\x12/\n" +
 	"\x14start_time_unix_nano\x18\x02 \x01(\x04R\x11startTimeUnixNano\x12\x1d\n" +
@@ -1668,29 +5870,205 @@ const file_pkg_api_agents_v1alpha1_agents_proto_rawDesc = "" +
 	"\x12RemoteConfigStatus\x125\n" +
 	"\x17last_remote_config_hash\x18\x01 \x01(\fR\x14lastRemoteConfigHash\x12=\n" +
 	"\x06status\x18\x02 \x01(\x0e2%.config.v1alpha1.RemoteConfigStatusesR\x06status\x12#\n" +
-	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage*^\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"\x93\x03\n" +
+	"\n" +
+	"ConfigPush\x12\x17\n" +
+	"\apush_id\x18\x01 \x01(\tR\x06pushId\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x1f\n" +
+	"\vconfig_hash\x18\x03 \x01(\fR\n" +
+	"configHash\x126\n" +
+	"\x05state\x18\x04 \x01(\x0e2 .config.v1alpha1.ConfigPushStateR\x05state\x129\n" +
+	"\n" +
+	"offered_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tofferedAt\x12C\n" +
+	"\x0facknowledged_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0eacknowledgedAt\x129\n" +
+	"\n" +
+	"applied_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tappliedAt\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x12\x18\n" +
+	"\aattempt\x18\t \x01(\x05R\aattempt\"H\n" +
+	"\x11ConfigPushHistory\x123\n" +
+	"\x06pushes\x18\x01 \x03(\v2\x1b.config.v1alpha1.ConfigPushR\x06pushes\"*\n" +
+	"\x0fConfigPushOffer\x12\x17\n" +
+	"\apush_id\x18\x01 \x01(\tR\x06pushId\"k\n" +
+	"\x11ConfigPushReceipt\x12\x17\n" +
+	"\apush_id\x18\x01 \x01(\tR\x06pushId\x12\x18\n" +
+	"\aapplied\x18\x02 \x01(\bR\aapplied\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"T\n" +
+	"\x13AvailabilityHistory\x12=\n" +
+	"\aperiods\x18\x01 \x03(\v2#.config.v1alpha1.AvailabilityPeriodR\aperiods\"\xa6\x01\n" +
+	"\x12AvailabilityPeriod\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x18\n" +
+	"\ahealthy\x18\x03 \x01(\bR\ahealthy\x12\x16\n" +
+	"\x06closed\x18\x04 \x01(\bR\x06closed\"m\n" +
+	"\x1bGetAgentAvailabilityRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x123\n" +
+	"\awindows\x18\x02 \x03(\v2\x19.google.protobuf.DurationR\awindows\"\x7f\n" +
+	"\x12WindowAvailability\x121\n" +
+	"\x06window\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x06window\x12\x1c\n" +
+	"\tconnected\x18\x02 \x01(\x01R\tconnected\x12\x18\n" +
+	"\ahealthy\x18\x03 \x01(\x01R\ahealthy\"m\n" +
+	"\x11AgentAvailability\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12=\n" +
+	"\awindows\x18\x02 \x03(\v2#.config.v1alpha1.WindowAvailabilityR\awindows\"\x82\x02\n" +
+	"\x1bGetFleetAvailabilityRequest\x12\x19\n" +
+	"\bgroup_by\x18\x01 \x01(\tR\agroupBy\x12V\n" +
+	"\bselector\x18\x02 \x03(\v2:.config.v1alpha1.GetFleetAvailabilityRequest.SelectorEntryR\bselector\x123\n" +
+	"\awindows\x18\x03 \x03(\v2\x19.google.protobuf.DurationR\awindows\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x94\x01\n" +
+	"\x11AvailabilityGroup\x12\x1f\n" +
+	"\vlabel_value\x18\x01 \x01(\tR\n" +
+	"labelValue\x12\x1f\n" +
+	"\vagent_count\x18\x02 \x01(\x05R\n" +
+	"agentCount\x12=\n" +
+	"\awindows\x18\x03 \x03(\v2#.config.v1alpha1.WindowAvailabilityR\awindows\"O\n" +
+	"\x11FleetAvailability\x12:\n" +
+	"\x06groups\x18\x01 \x03(\v2\".config.v1alpha1.AvailabilityGroupR\x06groups\"\xb8\x02\n" +
+	"\x12AgentCommandStatus\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x128\n" +
+	"\x05state\x18\x03 \x01(\x0e2\".config.v1alpha1.AgentCommandStateR\x05state\x12!\n" +
+	"\frequested_by\x18\x04 \x01(\tR\vrequestedBy\x12=\n" +
+	"\frequested_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x12=\n" +
+	"\fcompleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12#\n" +
+	"\rerror_message\x18\a \x01(\tR\ferrorMessage\"k\n" +
+	"\x12AgentCommandResult\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1c\n" +
+	"\tsucceeded\x18\x02 \x01(\bR\tsucceeded\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\"0\n" +
+	"\x13RestartAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"U\n" +
+	"\x14RestartAgentResponse\x12=\n" +
+	"\acommand\x18\x01 \x01(\v2#.config.v1alpha1.AgentCommandStatusR\acommand\"1\n" +
+	"\x14UndeleteAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"/\n" +
+	"\x12RevokeAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"\xdd\x01\n" +
+	"\x15GetAgentEventsRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x120\n" +
+	"\x05start\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12\x14\n" +
+	"\x05types\x18\x04 \x03(\tR\x05types\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\"p\n" +
+	"\x16GetAgentEventsResponse\x12.\n" +
+	"\x06events\x18\x01 \x03(\v2\x16.events.v1alpha1.EventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x8f\x02\n" +
+	"\x12AgentProblemReport\x125\n" +
+	"\x04type\x18\x01 \x01(\x0e2!.config.v1alpha1.AgentProblemTypeR\x04type\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12 \n" +
+	"\voccurrences\x18\x03 \x01(\x05R\voccurrences\x12J\n" +
+	"\adetails\x18\x04 \x03(\v20.config.v1alpha1.AgentProblemReport.DetailsEntryR\adetails\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc6\x03\n" +
+	"\fAgentProblem\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x125\n" +
+	"\x04type\x18\x02 \x01(\x0e2!.config.v1alpha1.AgentProblemTypeR\x04type\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12 \n" +
+	"\voccurrences\x18\x04 \x01(\x05R\voccurrences\x12D\n" +
+	"\adetails\x18\x05 \x03(\v2*.config.v1alpha1.AgentProblem.DetailsEntryR\adetails\x12\x18\n" +
+	"\areports\x18\x06 \x01(\x05R\areports\x12F\n" +
+	"\x11first_reported_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x0ffirstReportedAt\x12D\n" +
+	"\x10last_reported_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0elastReportedAt\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"n\n" +
+	"\x18GetProblemsReportRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x127\n" +
+	"\x05types\x18\x02 \x03(\x0e2!.config.v1alpha1.AgentProblemTypeR\x05types\"V\n" +
+	"\x19GetProblemsReportResponse\x129\n" +
+	"\bproblems\x18\x01 \x03(\v2\x1d.config.v1alpha1.AgentProblemR\bproblems\"m\n" +
+	"\x19AcknowledgeProblemRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x125\n" +
+	"\x04type\x18\x02 \x01(\x0e2!.config.v1alpha1.AgentProblemTypeR\x04type*\x89\x01\n" +
+	"\x0eAgentSortField\x12 \n" +
+	"\x1cAGENT_SORT_FIELD_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15AGENT_SORT_FIELD_NAME\x10\x01\x12\x1e\n" +
+	"\x1aAGENT_SORT_FIELD_LAST_SEEN\x10\x02\x12\x1a\n" +
+	"\x16AGENT_SORT_FIELD_STATE\x10\x03*t\n" +
+	"\x10AgentHealthState\x12\x1e\n" +
+	"\x1aAGENT_HEALTH_STATE_UNKNOWN\x10\x00\x12\x1e\n" +
+	"\x1aAGENT_HEALTH_STATE_HEALTHY\x10\x01\x12 \n" +
+	"\x1cAGENT_HEALTH_STATE_UNHEALTHY\x10\x02*\x8b\x01\n" +
+	"\x0fAgentStatusView\x12!\n" +
+	"\x1dAGENT_STATUS_VIEW_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17AGENT_STATUS_VIEW_BASIC\x10\x01\x12\x1c\n" +
+	"\x18AGENT_STATUS_VIEW_HEALTH\x10\x02\x12\x1a\n" +
+	"\x16AGENT_STATUS_VIEW_FULL\x10\x03*\xb3\x01\n" +
+	"\x0eAgentCondition\x12\x1f\n" +
+	"\x1bAGENT_CONDITION_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19AGENT_CONDITION_CONNECTED\x10\x01\x12\"\n" +
+	"\x1eAGENT_CONDITION_CONFIG_APPLIED\x10\x02\x12\x1b\n" +
+	"\x17AGENT_CONDITION_HEALTHY\x10\x03\x12 \n" +
+	"\x1cAGENT_CONDITION_DISCONNECTED\x10\x04*\x9d\x01\n" +
+	"\x12AgentSnapshotState\x12$\n" +
+	" AGENT_SNAPSHOT_STATE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cAGENT_SNAPSHOT_STATE_PENDING\x10\x01\x12\x1e\n" +
+	"\x1aAGENT_SNAPSHOT_STATE_READY\x10\x02\x12\x1f\n" +
+	"\x1bAGENT_SNAPSHOT_STATE_FAILED\x10\x03*^\n" +
 	"\n" +
 	"AgentState\x12\x17\n" +
 	"\x13AGENT_STATE_UNKNOWN\x10\x00\x12\x19\n" +
 	"\x15AGENT_STATE_CONNECTED\x10\x01\x12\x1c\n" +
-	"\x18AGENT_STATE_DISCONNECTED\x10\x02*\xb5\x01\n" +
+	"\x18AGENT_STATE_DISCONNECTED\x10\x02*\xd9\x01\n" +
 	"\x10ConfigSyncStatus\x12\x1e\n" +
 	"\x1aCONFIG_SYNC_STATUS_UNKNOWN\x10\x00\x12\x1e\n" +
 	"\x1aCONFIG_SYNC_STATUS_IN_SYNC\x10\x01\x12\"\n" +
 	"\x1eCONFIG_SYNC_STATUS_OUT_OF_SYNC\x10\x02\x12\x1f\n" +
 	"\x1bCONFIG_SYNC_STATUS_APPLYING\x10\x03\x12\x1c\n" +
-	"\x18CONFIG_SYNC_STATUS_ERROR\x10\x04*\xa4\x01\n" +
+	"\x18CONFIG_SYNC_STATUS_ERROR\x10\x04\x12\"\n" +
+	"\x1eCONFIG_SYNC_STATUS_UNSUPPORTED\x10\x05*\xa4\x01\n" +
 	"\x14RemoteConfigStatuses\x12 \n" +
 	"\x1cREMOTE_CONFIG_STATUSES_UNSET\x10\x00\x12\"\n" +
 	"\x1eREMOTE_CONFIG_STATUSES_APPLIED\x10\x01\x12#\n" +
 	"\x1fREMOTE_CONFIG_STATUSES_APPLYING\x10\x02\x12!\n" +
-	"\x1dREMOTE_CONFIG_STATUSES_FAILED\x10\x032\xdd\x02\n" +
+	"\x1dREMOTE_CONFIG_STATUSES_FAILED\x10\x03*\xb4\x01\n" +
+	"\x0fConfigPushState\x12!\n" +
+	"\x1dCONFIG_PUSH_STATE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19CONFIG_PUSH_STATE_OFFERED\x10\x01\x12\"\n" +
+	"\x1eCONFIG_PUSH_STATE_ACKNOWLEDGED\x10\x02\x12\x1d\n" +
+	"\x19CONFIG_PUSH_STATE_APPLIED\x10\x03\x12\x1c\n" +
+	"\x18CONFIG_PUSH_STATE_FAILED\x10\x04*\x9c\x01\n" +
+	"\x11AgentCommandState\x12#\n" +
+	"\x1fAGENT_COMMAND_STATE_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bAGENT_COMMAND_STATE_PENDING\x10\x01\x12!\n" +
+	"\x1dAGENT_COMMAND_STATE_SUCCEEDED\x10\x02\x12\x1e\n" +
+	"\x1aAGENT_COMMAND_STATE_FAILED\x10\x03*\xb9\x01\n" +
+	"\x10AgentProblemType\x12\"\n" +
+	"\x1eAGENT_PROBLEM_TYPE_UNSPECIFIED\x10\x00\x12+\n" +
+	"'AGENT_PROBLEM_TYPE_CONFIG_APPLY_FAILING\x10\x01\x12+\n" +
+	"'AGENT_PROBLEM_TYPE_COLLECTOR_CRASH_LOOP\x10\x02\x12'\n" +
+	"#AGENT_PROBLEM_TYPE_DISK_NEARLY_FULL\x10\x032\xb1\x0f\n" +
 	"\fAgentService\x12U\n" +
 	"\n" +
 	"ListAgents\x12\".config.v1alpha1.ListAgentsRequest\x1a#.config.v1alpha1.ListAgentsResponse\x12O\n" +
 	"\bGetAgent\x12 .config.v1alpha1.GetAgentRequest\x1a!.config.v1alpha1.GetAgentResponse\x12Y\n" +
 	"\x06Status\x12&.config.v1alpha1.GetAgentStatusRequest\x1a'.config.v1alpha1.GetAgentStatusResponse\x12J\n" +
-	"\vDeleteAgent\x12#.config.v1alpha1.DeleteAgentRequest\x1a\x16.google.protobuf.EmptyB8Z6github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1b\x06proto3"
+	"\vDeleteAgent\x12#.config.v1alpha1.DeleteAgentRequest\x1a\x16.google.protobuf.Empty\x12N\n" +
+	"\rUndeleteAgent\x12%.config.v1alpha1.UndeleteAgentRequest\x1a\x16.google.protobuf.Empty\x12J\n" +
+	"\vRevokeAgent\x12#.config.v1alpha1.RevokeAgentRequest\x1a\x16.google.protobuf.Empty\x12s\n" +
+	"\x14CaptureAgentSnapshot\x12,.config.v1alpha1.CaptureAgentSnapshotRequest\x1a-.config.v1alpha1.CaptureAgentSnapshotResponse\x12g\n" +
+	"\x10GetAgentSnapshot\x12(.config.v1alpha1.GetAgentSnapshotRequest\x1a).config.v1alpha1.GetAgentSnapshotResponse\x12m\n" +
+	"\x12ListAgentSnapshots\x12*.config.v1alpha1.ListAgentSnapshotsRequest\x1a+.config.v1alpha1.ListAgentSnapshotsResponse\x12g\n" +
+	"\x10ListConfigPushes\x12(.config.v1alpha1.ListConfigPushesRequest\x1a).config.v1alpha1.ListConfigPushesResponse\x12d\n" +
+	"\x14CaptureFleetSnapshot\x12,.config.v1alpha1.CaptureFleetSnapshotRequest\x1a\x1e.config.v1alpha1.FleetSnapshot\x12m\n" +
+	"\x12ListFleetSnapshots\x12*.config.v1alpha1.ListFleetSnapshotsRequest\x1a+.config.v1alpha1.ListFleetSnapshotsResponse\x12Y\n" +
+	"\x0eDiffFleetState\x12&.config.v1alpha1.DiffFleetStateRequest\x1a\x1f.config.v1alpha1.FleetStateDiff\x12h\n" +
+	"\x14GetAgentAvailability\x12,.config.v1alpha1.GetAgentAvailabilityRequest\x1a\".config.v1alpha1.AgentAvailability\x12h\n" +
+	"\x14GetFleetAvailability\x12,.config.v1alpha1.GetFleetAvailabilityRequest\x1a\".config.v1alpha1.FleetAvailability\x12v\n" +
+	"\x15WaitForAgentCondition\x12-.config.v1alpha1.WaitForAgentConditionRequest\x1a..config.v1alpha1.WaitForAgentConditionResponse\x12[\n" +
+	"\fRestartAgent\x12$.config.v1alpha1.RestartAgentRequest\x1a%.config.v1alpha1.RestartAgentResponse\x12a\n" +
+	"\x0eGetAgentEvents\x12&.config.v1alpha1.GetAgentEventsRequest\x1a'.config.v1alpha1.GetAgentEventsResponse\x12j\n" +
+	"\x11GetProblemsReport\x12).config.v1alpha1.GetProblemsReportRequest\x1a*.config.v1alpha1.GetProblemsReportResponse\x12X\n" +
+	"\x12AcknowledgeProblem\x12*.config.v1alpha1.AcknowledgeProblemRequest\x1a\x16.google.protobuf.Empty2\x80\x02\n" +
+	"\x0eGatewayService\x12F\n" +
+	"\x05Relay\x12\x1d.config.v1alpha1.RelayRequest\x1a\x1e.config.v1alpha1.RelayResponse\x12P\n" +
+	"\x05Watch\x12$.config.v1alpha1.WatchGatewayRequest\x1a\x1f.config.v1alpha1.RelayedMessage0\x01\x12T\n" +
+	"\n" +
+	"Disconnect\x12..config.v1alpha1.DisconnectRelayedAgentRequest\x1a\x16.google.protobuf.EmptyB8Z6github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1b\x06proto3"
 
 var (
 	file_pkg_api_agents_v1alpha1_agents_proto_rawDescOnce sync.Once
@@ -1704,87 +6082,267 @@ func file_pkg_api_agents_v1alpha1_agents_proto_rawDescGZIP() []byte {
 	return file_pkg_api_agents_v1alpha1_agents_proto_rawDescData
 }
 
-var file_pkg_api_agents_v1alpha1_agents_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_pkg_api_agents_v1alpha1_agents_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_pkg_api_agents_v1alpha1_agents_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_pkg_api_agents_v1alpha1_agents_proto_msgTypes = make([]protoimpl.MessageInfo, 85)
 var file_pkg_api_agents_v1alpha1_agents_proto_goTypes = []any{
-	(AgentState)(0),                   // 0: config.v1alpha1.AgentState
-	(ConfigSyncStatus)(0),             // 1: config.v1alpha1.ConfigSyncStatus
-	(RemoteConfigStatuses)(0),         // 2: config.v1alpha1.RemoteConfigStatuses
-	(*ListAgentsRequest)(nil),         // 3: config.v1alpha1.ListAgentsRequest
-	(*ListAgentsResponse)(nil),        // 4: config.v1alpha1.ListAgentsResponse
-	(*AgentView)(nil),                 // 5: config.v1alpha1.AgentView
-	(*AgentDescriptionAndStatus)(nil), // 6: config.v1alpha1.AgentDescriptionAndStatus
-	(*GetAgentRequest)(nil),           // 7: config.v1alpha1.GetAgentRequest
-	(*GetAgentResponse)(nil),          // 8: config.v1alpha1.GetAgentResponse
-	(*GetAgentStatusRequest)(nil),     // 9: config.v1alpha1.GetAgentStatusRequest
-	(*GetAgentStatusResponse)(nil),    // 10: config.v1alpha1.GetAgentStatusResponse
-	(*DeleteAgentRequest)(nil),        // 11: config.v1alpha1.DeleteAgentRequest
-	(*AgentStatus)(nil),               // 12: config.v1alpha1.AgentStatus
-	(*AgentRegistration)(nil),         // 13: config.v1alpha1.AgentRegistration
-	(*AgentDescription)(nil),          // 14: config.v1alpha1.AgentDescription
-	(*KeyValue)(nil),                  // 15: config.v1alpha1.KeyValue
-	(*AnyValue)(nil),                  // 16: config.v1alpha1.AnyValue
-	(*ArrayValue)(nil),                // 17: config.v1alpha1.ArrayValue
-	(*KeyValueList)(nil),              // 18: config.v1alpha1.KeyValueList
-	(*AgentConnectionState)(nil),      // 19: config.v1alpha1.AgentConnectionState
-	(*ComponentHealth)(nil),           // 20: config.v1alpha1.ComponentHealth
-	(*EffectiveConfig)(nil),           // 21: config.v1alpha1.EffectiveConfig
-	(*AgentConfigMap)(nil),            // 22: config.v1alpha1.AgentConfigMap
-	(*AgentConfigFile)(nil),           // 23: config.v1alpha1.AgentConfigFile
-	(*RemoteConfigStatus)(nil),        // 24: config.v1alpha1.RemoteConfigStatus
-	nil,                               // 25: config.v1alpha1.ComponentHealth.ComponentHealthMapEntry
-	nil,                               // 26: config.v1alpha1.AgentConfigMap.ConfigMapEntry
-	(*timestamppb.Timestamp)(nil),     // 27: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),             // 28: google.protobuf.Empty
+	(AgentSortField)(0),                   // 0: config.v1alpha1.AgentSortField
+	(AgentHealthState)(0),                 // 1: config.v1alpha1.AgentHealthState
+	(AgentStatusView)(0),                  // 2: config.v1alpha1.AgentStatusView
+	(AgentCondition)(0),                   // 3: config.v1alpha1.AgentCondition
+	(AgentSnapshotState)(0),               // 4: config.v1alpha1.AgentSnapshotState
+	(AgentState)(0),                       // 5: config.v1alpha1.AgentState
+	(ConfigSyncStatus)(0),                 // 6: config.v1alpha1.ConfigSyncStatus
+	(RemoteConfigStatuses)(0),             // 7: config.v1alpha1.RemoteConfigStatuses
+	(ConfigPushState)(0),                  // 8: config.v1alpha1.ConfigPushState
+	(AgentCommandState)(0),                // 9: config.v1alpha1.AgentCommandState
+	(AgentProblemType)(0),                 // 10: config.v1alpha1.AgentProblemType
+	(*RelayRequest)(nil),                  // 11: config.v1alpha1.RelayRequest
+	(*RelayResponse)(nil),                 // 12: config.v1alpha1.RelayResponse
+	(*WatchGatewayRequest)(nil),           // 13: config.v1alpha1.WatchGatewayRequest
+	(*RelayedMessage)(nil),                // 14: config.v1alpha1.RelayedMessage
+	(*DisconnectRelayedAgentRequest)(nil), // 15: config.v1alpha1.DisconnectRelayedAgentRequest
+	(*ListAgentsRequest)(nil),             // 16: config.v1alpha1.ListAgentsRequest
+	(*ListAgentsResponse)(nil),            // 17: config.v1alpha1.ListAgentsResponse
+	(*AgentLoadError)(nil),                // 18: config.v1alpha1.AgentLoadError
+	(*AgentView)(nil),                     // 19: config.v1alpha1.AgentView
+	(*AgentDescriptionAndStatus)(nil),     // 20: config.v1alpha1.AgentDescriptionAndStatus
+	(*GetAgentRequest)(nil),               // 21: config.v1alpha1.GetAgentRequest
+	(*GetAgentResponse)(nil),              // 22: config.v1alpha1.GetAgentResponse
+	(*GetAgentStatusRequest)(nil),         // 23: config.v1alpha1.GetAgentStatusRequest
+	(*GetAgentStatusResponse)(nil),        // 24: config.v1alpha1.GetAgentStatusResponse
+	(*WaitForAgentConditionRequest)(nil),  // 25: config.v1alpha1.WaitForAgentConditionRequest
+	(*WaitForAgentConditionResponse)(nil), // 26: config.v1alpha1.WaitForAgentConditionResponse
+	(*DeleteAgentRequest)(nil),            // 27: config.v1alpha1.DeleteAgentRequest
+	(*CaptureAgentSnapshotRequest)(nil),   // 28: config.v1alpha1.CaptureAgentSnapshotRequest
+	(*CaptureAgentSnapshotResponse)(nil),  // 29: config.v1alpha1.CaptureAgentSnapshotResponse
+	(*GetAgentSnapshotRequest)(nil),       // 30: config.v1alpha1.GetAgentSnapshotRequest
+	(*GetAgentSnapshotResponse)(nil),      // 31: config.v1alpha1.GetAgentSnapshotResponse
+	(*ListAgentSnapshotsRequest)(nil),     // 32: config.v1alpha1.ListAgentSnapshotsRequest
+	(*ListAgentSnapshotsResponse)(nil),    // 33: config.v1alpha1.ListAgentSnapshotsResponse
+	(*ListConfigPushesRequest)(nil),       // 34: config.v1alpha1.ListConfigPushesRequest
+	(*ListConfigPushesResponse)(nil),      // 35: config.v1alpha1.ListConfigPushesResponse
+	(*CaptureFleetSnapshotRequest)(nil),   // 36: config.v1alpha1.CaptureFleetSnapshotRequest
+	(*ListFleetSnapshotsRequest)(nil),     // 37: config.v1alpha1.ListFleetSnapshotsRequest
+	(*ListFleetSnapshotsResponse)(nil),    // 38: config.v1alpha1.ListFleetSnapshotsResponse
+	(*DiffFleetStateRequest)(nil),         // 39: config.v1alpha1.DiffFleetStateRequest
+	(*FleetSnapshot)(nil),                 // 40: config.v1alpha1.FleetSnapshot
+	(*FleetSnapshotAgent)(nil),            // 41: config.v1alpha1.FleetSnapshotAgent
+	(*FleetStateDiff)(nil),                // 42: config.v1alpha1.FleetStateDiff
+	(*AgentStateChange)(nil),              // 43: config.v1alpha1.AgentStateChange
+	(*FieldChange)(nil),                   // 44: config.v1alpha1.FieldChange
+	(*AgentSnapshot)(nil),                 // 45: config.v1alpha1.AgentSnapshot
+	(*SnapshotRequest)(nil),               // 46: config.v1alpha1.SnapshotRequest
+	(*SnapshotUpload)(nil),                // 47: config.v1alpha1.SnapshotUpload
+	(*AgentStatus)(nil),                   // 48: config.v1alpha1.AgentStatus
+	(*AgentMetrics)(nil),                  // 49: config.v1alpha1.AgentMetrics
+	(*ExporterQueue)(nil),                 // 50: config.v1alpha1.ExporterQueue
+	(*AgentRegistration)(nil),             // 51: config.v1alpha1.AgentRegistration
+	(*AgentDescription)(nil),              // 52: config.v1alpha1.AgentDescription
+	(*KeyValue)(nil),                      // 53: config.v1alpha1.KeyValue
+	(*AnyValue)(nil),                      // 54: config.v1alpha1.AnyValue
+	(*ArrayValue)(nil),                    // 55: config.v1alpha1.ArrayValue
+	(*KeyValueList)(nil),                  // 56: config.v1alpha1.KeyValueList
+	(*AgentConnectionState)(nil),          // 57: config.v1alpha1.AgentConnectionState
+	(*AgentInstance)(nil),                 // 58: config.v1alpha1.AgentInstance
+	(*ComponentHealth)(nil),               // 59: config.v1alpha1.ComponentHealth
+	(*EffectiveConfig)(nil),               // 60: config.v1alpha1.EffectiveConfig
+	(*AgentConfigMap)(nil),                // 61: config.v1alpha1.AgentConfigMap
+	(*AgentConfigFile)(nil),               // 62: config.v1alpha1.AgentConfigFile
+	(*RemoteConfigStatus)(nil),            // 63: config.v1alpha1.RemoteConfigStatus
+	(*ConfigPush)(nil),                    // 64: config.v1alpha1.ConfigPush
+	(*ConfigPushHistory)(nil),             // 65: config.v1alpha1.ConfigPushHistory
+	(*ConfigPushOffer)(nil),               // 66: config.v1alpha1.ConfigPushOffer
+	(*ConfigPushReceipt)(nil),             // 67: config.v1alpha1.ConfigPushReceipt
+	(*AvailabilityHistory)(nil),           // 68: config.v1alpha1.AvailabilityHistory
+	(*AvailabilityPeriod)(nil),            // 69: config.v1alpha1.AvailabilityPeriod
+	(*GetAgentAvailabilityRequest)(nil),   // 70: config.v1alpha1.GetAgentAvailabilityRequest
+	(*WindowAvailability)(nil),            // 71: config.v1alpha1.WindowAvailability
+	(*AgentAvailability)(nil),             // 72: config.v1alpha1.AgentAvailability
+	(*GetFleetAvailabilityRequest)(nil),   // 73: config.v1alpha1.GetFleetAvailabilityRequest
+	(*AvailabilityGroup)(nil),             // 74: config.v1alpha1.AvailabilityGroup
+	(*FleetAvailability)(nil),             // 75: config.v1alpha1.FleetAvailability
+	(*AgentCommandStatus)(nil),            // 76: config.v1alpha1.AgentCommandStatus
+	(*AgentCommandResult)(nil),            // 77: config.v1alpha1.AgentCommandResult
+	(*RestartAgentRequest)(nil),           // 78: config.v1alpha1.RestartAgentRequest
+	(*RestartAgentResponse)(nil),          // 79: config.v1alpha1.RestartAgentResponse
+	(*UndeleteAgentRequest)(nil),          // 80: config.v1alpha1.UndeleteAgentRequest
+	(*RevokeAgentRequest)(nil),            // 81: config.v1alpha1.RevokeAgentRequest
+	(*GetAgentEventsRequest)(nil),         // 82: config.v1alpha1.GetAgentEventsRequest
+	(*GetAgentEventsResponse)(nil),        // 83: config.v1alpha1.GetAgentEventsResponse
+	(*AgentProblemReport)(nil),            // 84: config.v1alpha1.AgentProblemReport
+	(*AgentProblem)(nil),                  // 85: config.v1alpha1.AgentProblem
+	(*GetProblemsReportRequest)(nil),      // 86: config.v1alpha1.GetProblemsReportRequest
+	(*GetProblemsReportResponse)(nil),     // 87: config.v1alpha1.GetProblemsReportResponse
+	(*AcknowledgeProblemRequest)(nil),     // 88: config.v1alpha1.AcknowledgeProblemRequest
+	nil,                                   // 89: config.v1alpha1.ListAgentsRequest.LabelSelectorEntry
+	nil,                                   // 90: config.v1alpha1.FleetSnapshotAgent.LabelsEntry
+	nil,                                   // 91: config.v1alpha1.ComponentHealth.ComponentHealthMapEntry
+	nil,                                   // 92: config.v1alpha1.AgentConfigMap.ConfigMapEntry
+	nil,                                   // 93: config.v1alpha1.GetFleetAvailabilityRequest.SelectorEntry
+	nil,                                   // 94: config.v1alpha1.AgentProblemReport.DetailsEntry
+	nil,                                   // 95: config.v1alpha1.AgentProblem.DetailsEntry
+	(*durationpb.Duration)(nil),           // 96: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),         // 97: google.protobuf.Timestamp
+	(*v1alpha1.Event)(nil),                // 98: events.v1alpha1.Event
+	(*emptypb.Empty)(nil),                 // 99: google.protobuf.Empty
 }
 var file_pkg_api_agents_v1alpha1_agents_proto_depIdxs = []int32{
-	6,  // 0: config.v1alpha1.ListAgentsResponse.agents:type_name -> config.v1alpha1.AgentDescriptionAndStatus
-	13, // 1: config.v1alpha1.AgentView.registration:type_name -> config.v1alpha1.AgentRegistration
-	12, // 2: config.v1alpha1.AgentView.status:type_name -> config.v1alpha1.AgentStatus
-	14, // 3: config.v1alpha1.AgentDescriptionAndStatus.agent:type_name -> config.v1alpha1.AgentDescription
-	12, // 4: config.v1alpha1.AgentDescriptionAndStatus.status:type_name -> config.v1alpha1.AgentStatus
-	14, // 5: config.v1alpha1.GetAgentResponse.agent:type_name -> config.v1alpha1.AgentDescription
-	12, // 6: config.v1alpha1.GetAgentStatusResponse.status:type_name -> config.v1alpha1.AgentStatus
-	0,  // 7: config.v1alpha1.AgentStatus.state:type_name -> config.v1alpha1.AgentState
-	20, // 8: config.v1alpha1.AgentStatus.health:type_name -> config.v1alpha1.ComponentHealth
-	21, // 9: config.v1alpha1.AgentStatus.effective_config:type_name -> config.v1alpha1.EffectiveConfig
-	24, // 10: config.v1alpha1.AgentStatus.remote_config_status:type_name -> config.v1alpha1.RemoteConfigStatus
-	27, // 11: config.v1alpha1.AgentStatus.last_seen:type_name -> google.protobuf.Timestamp
-	1,  // 12: config.v1alpha1.AgentStatus.config_sync_status:type_name -> config.v1alpha1.ConfigSyncStatus
-	27, // 13: config.v1alpha1.AgentStatus.connected_at:type_name -> google.protobuf.Timestamp
-	27, // 14: config.v1alpha1.AgentStatus.disconnected_at:type_name -> google.protobuf.Timestamp
-	15, // 15: config.v1alpha1.AgentRegistration.identifying_attributes:type_name -> config.v1alpha1.KeyValue
-	15, // 16: config.v1alpha1.AgentRegistration.non_identifying_attributes:type_name -> config.v1alpha1.KeyValue
-	15, // 17: config.v1alpha1.AgentDescription.identifying_attributes:type_name -> config.v1alpha1.KeyValue
-	15, // 18: config.v1alpha1.AgentDescription.non_identifying_attributes:type_name -> config.v1alpha1.KeyValue
-	16, // 19: config.v1alpha1.KeyValue.value:type_name -> config.v1alpha1.AnyValue
-	17, // 20: config.v1alpha1.AnyValue.array_value:type_name -> config.v1alpha1.ArrayValue
-	18, // 21: config.v1alpha1.AnyValue.kvlist_value:type_name -> config.v1alpha1.KeyValueList
-	16, // 22: config.v1alpha1.ArrayValue.values:type_name -> config.v1alpha1.AnyValue
-	15, // 23: config.v1alpha1.KeyValueList.values:type_name -> config.v1alpha1.KeyValue
-	0,  // 24: config.v1alpha1.AgentConnectionState.state:type_name -> config.v1alpha1.AgentState
-	27, // 25: config.v1alpha1.AgentConnectionState.last_seen:type_name -> google.protobuf.Timestamp
-	27, // 26: config.v1alpha1.AgentConnectionState.connected_at:type_name -> google.protobuf.Timestamp
-	27, // 27: config.v1alpha1.AgentConnectionState.disconnected_at:type_name -> google.protobuf.Timestamp
-	25, // 28: config.v1alpha1.ComponentHealth.component_health_map:type_name -> config.v1alpha1.ComponentHealth.ComponentHealthMapEntry
-	22, // 29: config.v1alpha1.EffectiveConfig.config_map:type_name -> config.v1alpha1.AgentConfigMap
-	26, // 30: config.v1alpha1.AgentConfigMap.config_map:type_name -> config.v1alpha1.AgentConfigMap.ConfigMapEntry
-	2,  // 31: config.v1alpha1.RemoteConfigStatus.status:type_name -> config.v1alpha1.RemoteConfigStatuses
-	20, // 32: config.v1alpha1.ComponentHealth.ComponentHealthMapEntry.value:type_name -> config.v1alpha1.ComponentHealth
-	23, // 33: config.v1alpha1.AgentConfigMap.ConfigMapEntry.value:type_name -> config.v1alpha1.AgentConfigFile
-	3,  // 34: config.v1alpha1.AgentService.ListAgents:input_type -> config.v1alpha1.ListAgentsRequest
-	7,  // 35: config.v1alpha1.AgentService.GetAgent:input_type -> config.v1alpha1.GetAgentRequest
-	9,  // 36: config.v1alpha1.AgentService.Status:input_type -> config.v1alpha1.GetAgentStatusRequest
-	11, // 37: config.v1alpha1.AgentService.DeleteAgent:input_type -> config.v1alpha1.DeleteAgentRequest
-	4,  // 38: config.v1alpha1.AgentService.ListAgents:output_type -> config.v1alpha1.ListAgentsResponse
-	8,  // 39: config.v1alpha1.AgentService.GetAgent:output_type -> config.v1alpha1.GetAgentResponse
-	10, // 40: config.v1alpha1.AgentService.Status:output_type -> config.v1alpha1.GetAgentStatusResponse
-	28, // 41: config.v1alpha1.AgentService.DeleteAgent:output_type -> google.protobuf.Empty
-	38, // [38:42] is the sub-list for method output_type
-	34, // [34:38] is the sub-list for method input_type
-	34, // [34:34] is the sub-list for extension type_name
-	34, // [34:34] is the sub-list for extension extendee
-	0,  // [0:34] is the sub-list for field type_name
+	2,   // 0: config.v1alpha1.ListAgentsRequest.view:type_name -> config.v1alpha1.AgentStatusView
+	6,   // 1: config.v1alpha1.ListAgentsRequest.config_sync_statuses:type_name -> config.v1alpha1.ConfigSyncStatus
+	0,   // 2: config.v1alpha1.ListAgentsRequest.sort_by:type_name -> config.v1alpha1.AgentSortField
+	5,   // 3: config.v1alpha1.ListAgentsRequest.states:type_name -> config.v1alpha1.AgentState
+	89,  // 4: config.v1alpha1.ListAgentsRequest.label_selector:type_name -> config.v1alpha1.ListAgentsRequest.LabelSelectorEntry
+	1,   // 5: config.v1alpha1.ListAgentsRequest.health_states:type_name -> config.v1alpha1.AgentHealthState
+	20,  // 6: config.v1alpha1.ListAgentsResponse.agents:type_name -> config.v1alpha1.AgentDescriptionAndStatus
+	18,  // 7: config.v1alpha1.ListAgentsResponse.errors:type_name -> config.v1alpha1.AgentLoadError
+	51,  // 8: config.v1alpha1.AgentView.registration:type_name -> config.v1alpha1.AgentRegistration
+	48,  // 9: config.v1alpha1.AgentView.status:type_name -> config.v1alpha1.AgentStatus
+	52,  // 10: config.v1alpha1.AgentDescriptionAndStatus.agent:type_name -> config.v1alpha1.AgentDescription
+	48,  // 11: config.v1alpha1.AgentDescriptionAndStatus.status:type_name -> config.v1alpha1.AgentStatus
+	52,  // 12: config.v1alpha1.GetAgentResponse.agent:type_name -> config.v1alpha1.AgentDescription
+	2,   // 13: config.v1alpha1.GetAgentStatusRequest.view:type_name -> config.v1alpha1.AgentStatusView
+	48,  // 14: config.v1alpha1.GetAgentStatusResponse.status:type_name -> config.v1alpha1.AgentStatus
+	3,   // 15: config.v1alpha1.WaitForAgentConditionRequest.condition:type_name -> config.v1alpha1.AgentCondition
+	96,  // 16: config.v1alpha1.WaitForAgentConditionRequest.timeout:type_name -> google.protobuf.Duration
+	48,  // 17: config.v1alpha1.WaitForAgentConditionResponse.status:type_name -> config.v1alpha1.AgentStatus
+	45,  // 18: config.v1alpha1.CaptureAgentSnapshotResponse.snapshot:type_name -> config.v1alpha1.AgentSnapshot
+	45,  // 19: config.v1alpha1.GetAgentSnapshotResponse.snapshot:type_name -> config.v1alpha1.AgentSnapshot
+	45,  // 20: config.v1alpha1.ListAgentSnapshotsResponse.snapshots:type_name -> config.v1alpha1.AgentSnapshot
+	64,  // 21: config.v1alpha1.ListConfigPushesResponse.pushes:type_name -> config.v1alpha1.ConfigPush
+	40,  // 22: config.v1alpha1.ListFleetSnapshotsResponse.snapshots:type_name -> config.v1alpha1.FleetSnapshot
+	97,  // 23: config.v1alpha1.FleetSnapshot.captured_at:type_name -> google.protobuf.Timestamp
+	41,  // 24: config.v1alpha1.FleetSnapshot.agents:type_name -> config.v1alpha1.FleetSnapshotAgent
+	90,  // 25: config.v1alpha1.FleetSnapshotAgent.labels:type_name -> config.v1alpha1.FleetSnapshotAgent.LabelsEntry
+	40,  // 26: config.v1alpha1.FleetStateDiff.from:type_name -> config.v1alpha1.FleetSnapshot
+	40,  // 27: config.v1alpha1.FleetStateDiff.to:type_name -> config.v1alpha1.FleetSnapshot
+	41,  // 28: config.v1alpha1.FleetStateDiff.added:type_name -> config.v1alpha1.FleetSnapshotAgent
+	41,  // 29: config.v1alpha1.FleetStateDiff.removed:type_name -> config.v1alpha1.FleetSnapshotAgent
+	43,  // 30: config.v1alpha1.FleetStateDiff.changed:type_name -> config.v1alpha1.AgentStateChange
+	44,  // 31: config.v1alpha1.AgentStateChange.changes:type_name -> config.v1alpha1.FieldChange
+	4,   // 32: config.v1alpha1.AgentSnapshot.state:type_name -> config.v1alpha1.AgentSnapshotState
+	97,  // 33: config.v1alpha1.AgentSnapshot.requested_at:type_name -> google.protobuf.Timestamp
+	97,  // 34: config.v1alpha1.AgentSnapshot.completed_at:type_name -> google.protobuf.Timestamp
+	97,  // 35: config.v1alpha1.AgentSnapshot.expires_at:type_name -> google.protobuf.Timestamp
+	5,   // 36: config.v1alpha1.AgentStatus.state:type_name -> config.v1alpha1.AgentState
+	59,  // 37: config.v1alpha1.AgentStatus.health:type_name -> config.v1alpha1.ComponentHealth
+	60,  // 38: config.v1alpha1.AgentStatus.effective_config:type_name -> config.v1alpha1.EffectiveConfig
+	63,  // 39: config.v1alpha1.AgentStatus.remote_config_status:type_name -> config.v1alpha1.RemoteConfigStatus
+	97,  // 40: config.v1alpha1.AgentStatus.last_seen:type_name -> google.protobuf.Timestamp
+	6,   // 41: config.v1alpha1.AgentStatus.config_sync_status:type_name -> config.v1alpha1.ConfigSyncStatus
+	97,  // 42: config.v1alpha1.AgentStatus.connected_at:type_name -> google.protobuf.Timestamp
+	97,  // 43: config.v1alpha1.AgentStatus.disconnected_at:type_name -> google.protobuf.Timestamp
+	58,  // 44: config.v1alpha1.AgentStatus.instance_history:type_name -> config.v1alpha1.AgentInstance
+	76,  // 45: config.v1alpha1.AgentStatus.last_command:type_name -> config.v1alpha1.AgentCommandStatus
+	49,  // 46: config.v1alpha1.AgentStatus.metrics:type_name -> config.v1alpha1.AgentMetrics
+	97,  // 47: config.v1alpha1.AgentMetrics.received_at:type_name -> google.protobuf.Timestamp
+	96,  // 48: config.v1alpha1.AgentMetrics.uptime:type_name -> google.protobuf.Duration
+	50,  // 49: config.v1alpha1.AgentMetrics.exporter_queues:type_name -> config.v1alpha1.ExporterQueue
+	53,  // 50: config.v1alpha1.AgentRegistration.identifying_attributes:type_name -> config.v1alpha1.KeyValue
+	53,  // 51: config.v1alpha1.AgentRegistration.non_identifying_attributes:type_name -> config.v1alpha1.KeyValue
+	53,  // 52: config.v1alpha1.AgentDescription.identifying_attributes:type_name -> config.v1alpha1.KeyValue
+	53,  // 53: config.v1alpha1.AgentDescription.non_identifying_attributes:type_name -> config.v1alpha1.KeyValue
+	97,  // 54: config.v1alpha1.AgentDescription.deleted_at:type_name -> google.protobuf.Timestamp
+	97,  // 55: config.v1alpha1.AgentDescription.revoked_at:type_name -> google.protobuf.Timestamp
+	54,  // 56: config.v1alpha1.KeyValue.value:type_name -> config.v1alpha1.AnyValue
+	55,  // 57: config.v1alpha1.AnyValue.array_value:type_name -> config.v1alpha1.ArrayValue
+	56,  // 58: config.v1alpha1.AnyValue.kvlist_value:type_name -> config.v1alpha1.KeyValueList
+	54,  // 59: config.v1alpha1.ArrayValue.values:type_name -> config.v1alpha1.AnyValue
+	53,  // 60: config.v1alpha1.KeyValueList.values:type_name -> config.v1alpha1.KeyValue
+	5,   // 61: config.v1alpha1.AgentConnectionState.state:type_name -> config.v1alpha1.AgentState
+	97,  // 62: config.v1alpha1.AgentConnectionState.last_seen:type_name -> google.protobuf.Timestamp
+	97,  // 63: config.v1alpha1.AgentConnectionState.connected_at:type_name -> google.protobuf.Timestamp
+	97,  // 64: config.v1alpha1.AgentConnectionState.disconnected_at:type_name -> google.protobuf.Timestamp
+	58,  // 65: config.v1alpha1.AgentConnectionState.instance_history:type_name -> config.v1alpha1.AgentInstance
+	97,  // 66: config.v1alpha1.AgentInstance.first_seen:type_name -> google.protobuf.Timestamp
+	97,  // 67: config.v1alpha1.AgentInstance.last_seen:type_name -> google.protobuf.Timestamp
+	91,  // 68: config.v1alpha1.ComponentHealth.component_health_map:type_name -> config.v1alpha1.ComponentHealth.ComponentHealthMapEntry
+	61,  // 69: config.v1alpha1.EffectiveConfig.config_map:type_name -> config.v1alpha1.AgentConfigMap
+	92,  // 70: config.v1alpha1.AgentConfigMap.config_map:type_name -> config.v1alpha1.AgentConfigMap.ConfigMapEntry
+	7,   // 71: config.v1alpha1.RemoteConfigStatus.status:type_name -> config.v1alpha1.RemoteConfigStatuses
+	8,   // 72: config.v1alpha1.ConfigPush.state:type_name -> config.v1alpha1.ConfigPushState
+	97,  // 73: config.v1alpha1.ConfigPush.offered_at:type_name -> google.protobuf.Timestamp
+	97,  // 74: config.v1alpha1.ConfigPush.acknowledged_at:type_name -> google.protobuf.Timestamp
+	97,  // 75: config.v1alpha1.ConfigPush.applied_at:type_name -> google.protobuf.Timestamp
+	64,  // 76: config.v1alpha1.ConfigPushHistory.pushes:type_name -> config.v1alpha1.ConfigPush
+	69,  // 77: config.v1alpha1.AvailabilityHistory.periods:type_name -> config.v1alpha1.AvailabilityPeriod
+	97,  // 78: config.v1alpha1.AvailabilityPeriod.start:type_name -> google.protobuf.Timestamp
+	97,  // 79: config.v1alpha1.AvailabilityPeriod.end:type_name -> google.protobuf.Timestamp
+	96,  // 80: config.v1alpha1.GetAgentAvailabilityRequest.windows:type_name -> google.protobuf.Duration
+	96,  // 81: config.v1alpha1.WindowAvailability.window:type_name -> google.protobuf.Duration
+	71,  // 82: config.v1alpha1.AgentAvailability.windows:type_name -> config.v1alpha1.WindowAvailability
+	93,  // 83: config.v1alpha1.GetFleetAvailabilityRequest.selector:type_name -> config.v1alpha1.GetFleetAvailabilityRequest.SelectorEntry
+	96,  // 84: config.v1alpha1.GetFleetAvailabilityRequest.windows:type_name -> google.protobuf.Duration
+	71,  // 85: config.v1alpha1.AvailabilityGroup.windows:type_name -> config.v1alpha1.WindowAvailability
+	74,  // 86: config.v1alpha1.FleetAvailability.groups:type_name -> config.v1alpha1.AvailabilityGroup
+	9,   // 87: config.v1alpha1.AgentCommandStatus.state:type_name -> config.v1alpha1.AgentCommandState
+	97,  // 88: config.v1alpha1.AgentCommandStatus.requested_at:type_name -> google.protobuf.Timestamp
+	97,  // 89: config.v1alpha1.AgentCommandStatus.completed_at:type_name -> google.protobuf.Timestamp
+	76,  // 90: config.v1alpha1.RestartAgentResponse.command:type_name -> config.v1alpha1.AgentCommandStatus
+	97,  // 91: config.v1alpha1.GetAgentEventsRequest.start:type_name -> google.protobuf.Timestamp
+	97,  // 92: config.v1alpha1.GetAgentEventsRequest.end:type_name -> google.protobuf.Timestamp
+	98,  // 93: config.v1alpha1.GetAgentEventsResponse.events:type_name -> events.v1alpha1.Event
+	10,  // 94: config.v1alpha1.AgentProblemReport.type:type_name -> config.v1alpha1.AgentProblemType
+	94,  // 95: config.v1alpha1.AgentProblemReport.details:type_name -> config.v1alpha1.AgentProblemReport.DetailsEntry
+	10,  // 96: config.v1alpha1.AgentProblem.type:type_name -> config.v1alpha1.AgentProblemType
+	95,  // 97: config.v1alpha1.AgentProblem.details:type_name -> config.v1alpha1.AgentProblem.DetailsEntry
+	97,  // 98: config.v1alpha1.AgentProblem.first_reported_at:type_name -> google.protobuf.Timestamp
+	97,  // 99: config.v1alpha1.AgentProblem.last_reported_at:type_name -> google.protobuf.Timestamp
+	10,  // 100: config.v1alpha1.GetProblemsReportRequest.types:type_name -> config.v1alpha1.AgentProblemType
+	85,  // 101: config.v1alpha1.GetProblemsReportResponse.problems:type_name -> config.v1alpha1.AgentProblem
+	10,  // 102: config.v1alpha1.AcknowledgeProblemRequest.type:type_name -> config.v1alpha1.AgentProblemType
+	59,  // 103: config.v1alpha1.ComponentHealth.ComponentHealthMapEntry.value:type_name -> config.v1alpha1.ComponentHealth
+	62,  // 104: config.v1alpha1.AgentConfigMap.ConfigMapEntry.value:type_name -> config.v1alpha1.AgentConfigFile
+	16,  // 105: config.v1alpha1.AgentService.ListAgents:input_type -> config.v1alpha1.ListAgentsRequest
+	21,  // 106: config.v1alpha1.AgentService.GetAgent:input_type -> config.v1alpha1.GetAgentRequest
+	23,  // 107: config.v1alpha1.AgentService.Status:input_type -> config.v1alpha1.GetAgentStatusRequest
+	27,  // 108: config.v1alpha1.AgentService.DeleteAgent:input_type -> config.v1alpha1.DeleteAgentRequest
+	80,  // 109: config.v1alpha1.AgentService.UndeleteAgent:input_type -> config.v1alpha1.UndeleteAgentRequest
+	81,  // 110: config.v1alpha1.AgentService.RevokeAgent:input_type -> config.v1alpha1.RevokeAgentRequest
+	28,  // 111: config.v1alpha1.AgentService.CaptureAgentSnapshot:input_type -> config.v1alpha1.CaptureAgentSnapshotRequest
+	30,  // 112: config.v1alpha1.AgentService.GetAgentSnapshot:input_type -> config.v1alpha1.GetAgentSnapshotRequest
+	32,  // 113: config.v1alpha1.AgentService.ListAgentSnapshots:input_type -> config.v1alpha1.ListAgentSnapshotsRequest
+	34,  // 114: config.v1alpha1.AgentService.ListConfigPushes:input_type -> config.v1alpha1.ListConfigPushesRequest
+	36,  // 115: config.v1alpha1.AgentService.CaptureFleetSnapshot:input_type -> config.v1alpha1.CaptureFleetSnapshotRequest
+	37,  // 116: config.v1alpha1.AgentService.ListFleetSnapshots:input_type -> config.v1alpha1.ListFleetSnapshotsRequest
+	39,  // 117: config.v1alpha1.AgentService.DiffFleetState:input_type -> config.v1alpha1.DiffFleetStateRequest
+	70,  // 118: config.v1alpha1.AgentService.GetAgentAvailability:input_type -> config.v1alpha1.GetAgentAvailabilityRequest
+	73,  // 119: config.v1alpha1.AgentService.GetFleetAvailability:input_type -> config.v1alpha1.GetFleetAvailabilityRequest
+	25,  // 120: config.v1alpha1.AgentService.WaitForAgentCondition:input_type -> config.v1alpha1.WaitForAgentConditionRequest
+	78,  // 121: config.v1alpha1.AgentService.RestartAgent:input_type -> config.v1alpha1.RestartAgentRequest
+	82,  // 122: config.v1alpha1.AgentService.GetAgentEvents:input_type -> config.v1alpha1.GetAgentEventsRequest
+	86,  // 123: config.v1alpha1.AgentService.GetProblemsReport:input_type -> config.v1alpha1.GetProblemsReportRequest
+	88,  // 124: config.v1alpha1.AgentService.AcknowledgeProblem:input_type -> config.v1alpha1.AcknowledgeProblemRequest
+	11,  // 125: config.v1alpha1.GatewayService.Relay:input_type -> config.v1alpha1.RelayRequest
+	13,  // 126: config.v1alpha1.GatewayService.Watch:input_type -> config.v1alpha1.WatchGatewayRequest
+	15,  // 127: config.v1alpha1.GatewayService.Disconnect:input_type -> config.v1alpha1.DisconnectRelayedAgentRequest
+	17,  // 128: config.v1alpha1.AgentService.ListAgents:output_type -> config.v1alpha1.ListAgentsResponse
+	22,  // 129: config.v1alpha1.AgentService.GetAgent:output_type -> config.v1alpha1.GetAgentResponse
+	24,  // 130: config.v1alpha1.AgentService.Status:output_type -> config.v1alpha1.GetAgentStatusResponse
+	99,  // 131: config.v1alpha1.AgentService.DeleteAgent:output_type -> google.protobuf.Empty
+	99,  // 132: config.v1alpha1.AgentService.UndeleteAgent:output_type -> google.protobuf.Empty
+	99,  // 133: config.v1alpha1.AgentService.RevokeAgent:output_type -> google.protobuf.Empty
+	29,  // 134: config.v1alpha1.AgentService.CaptureAgentSnapshot:output_type -> config.v1alpha1.CaptureAgentSnapshotResponse
+	31,  // 135: config.v1alpha1.AgentService.GetAgentSnapshot:output_type -> config.v1alpha1.GetAgentSnapshotResponse
+	33,  // 136: config.v1alpha1.AgentService.ListAgentSnapshots:output_type -> config.v1alpha1.ListAgentSnapshotsResponse
+	35,  // 137: config.v1alpha1.AgentService.ListConfigPushes:output_type -> config.v1alpha1.ListConfigPushesResponse
+	40,  // 138: config.v1alpha1.AgentService.CaptureFleetSnapshot:output_type -> config.v1alpha1.FleetSnapshot
+	38,  // 139: config.v1alpha1.AgentService.ListFleetSnapshots:output_type -> config.v1alpha1.ListFleetSnapshotsResponse
+	42,  // 140: config.v1alpha1.AgentService.DiffFleetState:output_type -> config.v1alpha1.FleetStateDiff
+	72,  // 141: config.v1alpha1.AgentService.GetAgentAvailability:output_type -> config.v1alpha1.AgentAvailability
+	75,  // 142: config.v1alpha1.AgentService.GetFleetAvailability:output_type -> config.v1alpha1.FleetAvailability
+	26,  // 143: config.v1alpha1.AgentService.WaitForAgentCondition:output_type -> config.v1alpha1.WaitForAgentConditionResponse
+	79,  // 144: config.v1alpha1.AgentService.RestartAgent:output_type -> config.v1alpha1.RestartAgentResponse
+	83,  // 145: config.v1alpha1.AgentService.GetAgentEvents:output_type -> config.v1alpha1.GetAgentEventsResponse
+	87,  // 146: config.v1alpha1.AgentService.GetProblemsReport:output_type -> config.v1alpha1.GetProblemsReportResponse
+	99,  // 147: config.v1alpha1.AgentService.AcknowledgeProblem:output_type -> google.protobuf.Empty
+	12,  // 148: config.v1alpha1.GatewayService.Relay:output_type -> config.v1alpha1.RelayResponse
+	14,  // 149: config.v1alpha1.GatewayService.Watch:output_type -> config.v1alpha1.RelayedMessage
+	99,  // 150: config.v1alpha1.GatewayService.Disconnect:output_type -> google.protobuf.Empty
+	128, // [128:151] is the sub-list for method output_type
+	105, // [105:128] is the sub-list for method input_type
+	105, // [105:105] is the sub-list for extension type_name
+	105, // [105:105] is the sub-list for extension extendee
+	0,   // [0:105] is the sub-list for field type_name
 }
 
 func init() { file_pkg_api_agents_v1alpha1_agents_proto_init() }
@@ -1792,7 +6350,7 @@ func file_pkg_api_agents_v1alpha1_agents_proto_init() {
 	if File_pkg_api_agents_v1alpha1_agents_proto != nil {
 		return
 	}
-	file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[13].OneofWrappers = []any{
+	file_pkg_api_agents_v1alpha1_agents_proto_msgTypes[43].OneofWrappers = []any{
 		(*AnyValue_StringValue)(nil),
 		(*AnyValue_BoolValue)(nil),
 		(*AnyValue_IntValue)(nil),
@@ -1806,10 +6364,10 @@ func file_pkg_api_agents_v1alpha1_agents_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_agents_v1alpha1_agents_proto_rawDesc), len(file_pkg_api_agents_v1alpha1_agents_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   24,
+			NumEnums:      11,
+			NumMessages:   85,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_pkg_api_agents_v1alpha1_agents_proto_goTypes,
 		DependencyIndexes: file_pkg_api_agents_v1alpha1_agents_proto_depIdxs,
@@ -24,6 +24,8 @@ const _ = connect.IsAtLeastVersion1_13_0
 const (
 	// AgentServiceName is the fully-qualified name of the AgentService service.
 	AgentServiceName = "config.v1alpha1.AgentService"
+	// GatewayServiceName is the fully-qualified name of the GatewayService service.
+	GatewayServiceName = "config.v1alpha1.GatewayService"
 )
 
 // These constants are the fully-qualified names of the RPCs defined in this package. They're
@@ -43,6 +45,61 @@ const (
 	// AgentServiceDeleteAgentProcedure is the fully-qualified name of the AgentService's DeleteAgent
 	// RPC.
 	AgentServiceDeleteAgentProcedure = "/config.v1alpha1.AgentService/DeleteAgent"
+	// AgentServiceUndeleteAgentProcedure is the fully-qualified name of the AgentService's
+	// UndeleteAgent RPC.
+	AgentServiceUndeleteAgentProcedure = "/config.v1alpha1.AgentService/UndeleteAgent"
+	// AgentServiceRevokeAgentProcedure is the fully-qualified name of the AgentService's RevokeAgent
+	// RPC.
+	AgentServiceRevokeAgentProcedure = "/config.v1alpha1.AgentService/RevokeAgent"
+	// AgentServiceCaptureAgentSnapshotProcedure is the fully-qualified name of the AgentService's
+	// CaptureAgentSnapshot RPC.
+	AgentServiceCaptureAgentSnapshotProcedure = "/config.v1alpha1.AgentService/CaptureAgentSnapshot"
+	// AgentServiceGetAgentSnapshotProcedure is the fully-qualified name of the AgentService's
+	// GetAgentSnapshot RPC.
+	AgentServiceGetAgentSnapshotProcedure = "/config.v1alpha1.AgentService/GetAgentSnapshot"
+	// AgentServiceListAgentSnapshotsProcedure is the fully-qualified name of the AgentService's
+	// ListAgentSnapshots RPC.
+	AgentServiceListAgentSnapshotsProcedure = "/config.v1alpha1.AgentService/ListAgentSnapshots"
+	// AgentServiceListConfigPushesProcedure is the fully-qualified name of the AgentService's
+	// ListConfigPushes RPC.
+	AgentServiceListConfigPushesProcedure = "/config.v1alpha1.AgentService/ListConfigPushes"
+	// AgentServiceCaptureFleetSnapshotProcedure is the fully-qualified name of the AgentService's
+	// CaptureFleetSnapshot RPC.
+	AgentServiceCaptureFleetSnapshotProcedure = "/config.v1alpha1.AgentService/CaptureFleetSnapshot"
+	// AgentServiceListFleetSnapshotsProcedure is the fully-qualified name of the AgentService's
+	// ListFleetSnapshots RPC.
+	AgentServiceListFleetSnapshotsProcedure = "/config.v1alpha1.AgentService/ListFleetSnapshots"
+	// AgentServiceDiffFleetStateProcedure is the fully-qualified name of the AgentService's
+	// DiffFleetState RPC.
+	AgentServiceDiffFleetStateProcedure = "/config.v1alpha1.AgentService/DiffFleetState"
+	// AgentServiceGetAgentAvailabilityProcedure is the fully-qualified name of the AgentService's
+	// GetAgentAvailability RPC.
+	AgentServiceGetAgentAvailabilityProcedure = "/config.v1alpha1.AgentService/GetAgentAvailability"
+	// AgentServiceGetFleetAvailabilityProcedure is the fully-qualified name of the AgentService's
+	// GetFleetAvailability RPC.
+	AgentServiceGetFleetAvailabilityProcedure = "/config.v1alpha1.AgentService/GetFleetAvailability"
+	// AgentServiceWaitForAgentConditionProcedure is the fully-qualified name of the AgentService's
+	// WaitForAgentCondition RPC.
+	AgentServiceWaitForAgentConditionProcedure = "/config.v1alpha1.AgentService/WaitForAgentCondition"
+	// AgentServiceRestartAgentProcedure is the fully-qualified name of the AgentService's RestartAgent
+	// RPC.
+	AgentServiceRestartAgentProcedure = "/config.v1alpha1.AgentService/RestartAgent"
+	// AgentServiceGetAgentEventsProcedure is the fully-qualified name of the AgentService's
+	// GetAgentEvents RPC.
+	AgentServiceGetAgentEventsProcedure = "/config.v1alpha1.AgentService/GetAgentEvents"
+	// AgentServiceGetProblemsReportProcedure is the fully-qualified name of the AgentService's
+	// GetProblemsReport RPC.
+	AgentServiceGetProblemsReportProcedure = "/config.v1alpha1.AgentService/GetProblemsReport"
+	// AgentServiceAcknowledgeProblemProcedure is the fully-qualified name of the AgentService's
+	// AcknowledgeProblem RPC.
+	AgentServiceAcknowledgeProblemProcedure = "/config.v1alpha1.AgentService/AcknowledgeProblem"
+	// GatewayServiceRelayProcedure is the fully-qualified name of the GatewayService's Relay RPC.
+	GatewayServiceRelayProcedure = "/config.v1alpha1.GatewayService/Relay"
+	// GatewayServiceWatchProcedure is the fully-qualified name of the GatewayService's Watch RPC.
+	GatewayServiceWatchProcedure = "/config.v1alpha1.GatewayService/Watch"
+	// GatewayServiceDisconnectProcedure is the fully-qualified name of the GatewayService's Disconnect
+	// RPC.
+	GatewayServiceDisconnectProcedure = "/config.v1alpha1.GatewayService/Disconnect"
 )
 
 // AgentServiceClient is a client for the config.v1alpha1.AgentService service.
@@ -50,7 +107,52 @@ type AgentServiceClient interface {
 	ListAgents(context.Context, *connect.Request[v1alpha1.ListAgentsRequest]) (*connect.Response[v1alpha1.ListAgentsResponse], error)
 	GetAgent(context.Context, *connect.Request[v1alpha1.GetAgentRequest]) (*connect.Response[v1alpha1.GetAgentResponse], error)
 	Status(context.Context, *connect.Request[v1alpha1.GetAgentStatusRequest]) (*connect.Response[v1alpha1.GetAgentStatusResponse], error)
+	// DeleteAgent hides the agent and withholds its config. It is purged once the deletion
+	// grace period expires, unless it reconnects or is undeleted in the meantime.
 	DeleteAgent(context.Context, *connect.Request[v1alpha1.DeleteAgentRequest]) (*connect.Response[emptypb.Empty], error)
+	// UndeleteAgent restores an agent deleted within the grace period
+	UndeleteAgent(context.Context, *connect.Request[v1alpha1.UndeleteAgentRequest]) (*connect.Response[emptypb.Empty], error)
+	// RevokeAgent disconnects the agent and rejects its OpAMP connections from then on, e.g. once
+	// its host is decommissioned or compromised. Revoked agents can't bootstrap again under the
+	// same ID, they stay listed until they are deleted.
+	RevokeAgent(context.Context, *connect.Request[v1alpha1.RevokeAgentRequest]) (*connect.Response[emptypb.Empty], error)
+	// CaptureAgentSnapshot asks the agent's supervisor to upload a support snapshot.
+	// The snapshot is captured asynchronously, poll GetAgentSnapshot until it is ready.
+	CaptureAgentSnapshot(context.Context, *connect.Request[v1alpha1.CaptureAgentSnapshotRequest]) (*connect.Response[v1alpha1.CaptureAgentSnapshotResponse], error)
+	GetAgentSnapshot(context.Context, *connect.Request[v1alpha1.GetAgentSnapshotRequest]) (*connect.Response[v1alpha1.GetAgentSnapshotResponse], error)
+	ListAgentSnapshots(context.Context, *connect.Request[v1alpha1.ListAgentSnapshotsRequest]) (*connect.Response[v1alpha1.ListAgentSnapshotsResponse], error)
+	// ListConfigPushes lists the remote configs recently pushed to an agent and how far
+	// each got, from being offered to being applied.
+	ListConfigPushes(context.Context, *connect.Request[v1alpha1.ListConfigPushesRequest]) (*connect.Response[v1alpha1.ListConfigPushesResponse], error)
+	// Fleet snapshots record the state of every agent, they are captured daily and on demand.
+	CaptureFleetSnapshot(context.Context, *connect.Request[v1alpha1.CaptureFleetSnapshotRequest]) (*connect.Response[v1alpha1.FleetSnapshot], error)
+	ListFleetSnapshots(context.Context, *connect.Request[v1alpha1.ListFleetSnapshotsRequest]) (*connect.Response[v1alpha1.ListFleetSnapshotsResponse], error)
+	// DiffFleetState reports the agents added, removed and changed between two fleet snapshots,
+	// or between a snapshot and the current state of the fleet.
+	DiffFleetState(context.Context, *connect.Request[v1alpha1.DiffFleetStateRequest]) (*connect.Response[v1alpha1.FleetStateDiff], error)
+	// Availability is the share of time agents were connected and healthy over rolling windows,
+	// computed from their heartbeats. An agent missing heartbeats for longer than the configured
+	// timeout counts as unavailable until it is heard from again.
+	GetAgentAvailability(context.Context, *connect.Request[v1alpha1.GetAgentAvailabilityRequest]) (*connect.Response[v1alpha1.AgentAvailability], error)
+	// GetFleetAvailability averages the availability of agents, grouped by the value of a label
+	GetFleetAvailability(context.Context, *connect.Request[v1alpha1.GetFleetAvailabilityRequest]) (*connect.Response[v1alpha1.FleetAvailability], error)
+	// WaitForAgentCondition returns as soon as the agent satisfies the condition, or once the
+	// timeout expires, with the status of the agent either way
+	WaitForAgentCondition(context.Context, *connect.Request[v1alpha1.WaitForAgentConditionRequest]) (*connect.Response[v1alpha1.WaitForAgentConditionResponse], error)
+	// RestartAgent asks a connected agent to restart its collector. The agent reports the
+	// result asynchronously, it is the last_command of the agent's status.
+	RestartAgent(context.Context, *connect.Request[v1alpha1.RestartAgentRequest]) (*connect.Response[v1alpha1.RestartAgentResponse], error)
+	// GetAgentEvents returns the timeline of an agent, oldest first: the events recorded about
+	// it, e.g. connections, config assignments and outcomes, bootstrap and deletion. Timelines
+	// are kept longer than fleet events, and outlive purged agents until they expire.
+	GetAgentEvents(context.Context, *connect.Request[v1alpha1.GetAgentEventsRequest]) (*connect.Response[v1alpha1.GetAgentEventsResponse], error)
+	// GetProblemsReport returns the open problems reported by agents' supervisors, e.g. a
+	// config failing to apply or a crash-looping collector. Problems stay open until they are
+	// acknowledged, even if the agent recovered in the meantime.
+	GetProblemsReport(context.Context, *connect.Request[v1alpha1.GetProblemsReportRequest]) (*connect.Response[v1alpha1.GetProblemsReportResponse], error)
+	// AcknowledgeProblem closes an open problem of an agent. It is opened again if the agent
+	// reports it again.
+	AcknowledgeProblem(context.Context, *connect.Request[v1alpha1.AcknowledgeProblemRequest]) (*connect.Response[emptypb.Empty], error)
 }
 
 // NewAgentServiceClient constructs a client for the config.v1alpha1.AgentService service. By
@@ -88,15 +190,127 @@ func NewAgentServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(agentServiceMethods.ByName("DeleteAgent")),
 			connect.WithClientOptions(opts...),
 		),
+		undeleteAgent: connect.NewClient[v1alpha1.UndeleteAgentRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AgentServiceUndeleteAgentProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("UndeleteAgent")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeAgent: connect.NewClient[v1alpha1.RevokeAgentRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AgentServiceRevokeAgentProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("RevokeAgent")),
+			connect.WithClientOptions(opts...),
+		),
+		captureAgentSnapshot: connect.NewClient[v1alpha1.CaptureAgentSnapshotRequest, v1alpha1.CaptureAgentSnapshotResponse](
+			httpClient,
+			baseURL+AgentServiceCaptureAgentSnapshotProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("CaptureAgentSnapshot")),
+			connect.WithClientOptions(opts...),
+		),
+		getAgentSnapshot: connect.NewClient[v1alpha1.GetAgentSnapshotRequest, v1alpha1.GetAgentSnapshotResponse](
+			httpClient,
+			baseURL+AgentServiceGetAgentSnapshotProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("GetAgentSnapshot")),
+			connect.WithClientOptions(opts...),
+		),
+		listAgentSnapshots: connect.NewClient[v1alpha1.ListAgentSnapshotsRequest, v1alpha1.ListAgentSnapshotsResponse](
+			httpClient,
+			baseURL+AgentServiceListAgentSnapshotsProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("ListAgentSnapshots")),
+			connect.WithClientOptions(opts...),
+		),
+		listConfigPushes: connect.NewClient[v1alpha1.ListConfigPushesRequest, v1alpha1.ListConfigPushesResponse](
+			httpClient,
+			baseURL+AgentServiceListConfigPushesProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("ListConfigPushes")),
+			connect.WithClientOptions(opts...),
+		),
+		captureFleetSnapshot: connect.NewClient[v1alpha1.CaptureFleetSnapshotRequest, v1alpha1.FleetSnapshot](
+			httpClient,
+			baseURL+AgentServiceCaptureFleetSnapshotProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("CaptureFleetSnapshot")),
+			connect.WithClientOptions(opts...),
+		),
+		listFleetSnapshots: connect.NewClient[v1alpha1.ListFleetSnapshotsRequest, v1alpha1.ListFleetSnapshotsResponse](
+			httpClient,
+			baseURL+AgentServiceListFleetSnapshotsProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("ListFleetSnapshots")),
+			connect.WithClientOptions(opts...),
+		),
+		diffFleetState: connect.NewClient[v1alpha1.DiffFleetStateRequest, v1alpha1.FleetStateDiff](
+			httpClient,
+			baseURL+AgentServiceDiffFleetStateProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("DiffFleetState")),
+			connect.WithClientOptions(opts...),
+		),
+		getAgentAvailability: connect.NewClient[v1alpha1.GetAgentAvailabilityRequest, v1alpha1.AgentAvailability](
+			httpClient,
+			baseURL+AgentServiceGetAgentAvailabilityProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("GetAgentAvailability")),
+			connect.WithClientOptions(opts...),
+		),
+		getFleetAvailability: connect.NewClient[v1alpha1.GetFleetAvailabilityRequest, v1alpha1.FleetAvailability](
+			httpClient,
+			baseURL+AgentServiceGetFleetAvailabilityProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("GetFleetAvailability")),
+			connect.WithClientOptions(opts...),
+		),
+		waitForAgentCondition: connect.NewClient[v1alpha1.WaitForAgentConditionRequest, v1alpha1.WaitForAgentConditionResponse](
+			httpClient,
+			baseURL+AgentServiceWaitForAgentConditionProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("WaitForAgentCondition")),
+			connect.WithClientOptions(opts...),
+		),
+		restartAgent: connect.NewClient[v1alpha1.RestartAgentRequest, v1alpha1.RestartAgentResponse](
+			httpClient,
+			baseURL+AgentServiceRestartAgentProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("RestartAgent")),
+			connect.WithClientOptions(opts...),
+		),
+		getAgentEvents: connect.NewClient[v1alpha1.GetAgentEventsRequest, v1alpha1.GetAgentEventsResponse](
+			httpClient,
+			baseURL+AgentServiceGetAgentEventsProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("GetAgentEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		getProblemsReport: connect.NewClient[v1alpha1.GetProblemsReportRequest, v1alpha1.GetProblemsReportResponse](
+			httpClient,
+			baseURL+AgentServiceGetProblemsReportProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("GetProblemsReport")),
+			connect.WithClientOptions(opts...),
+		),
+		acknowledgeProblem: connect.NewClient[v1alpha1.AcknowledgeProblemRequest, emptypb.Empty](
+			httpClient,
+			baseURL+AgentServiceAcknowledgeProblemProcedure,
+			connect.WithSchema(agentServiceMethods.ByName("AcknowledgeProblem")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // agentServiceClient implements AgentServiceClient.
 type agentServiceClient struct {
-	listAgents  *connect.Client[v1alpha1.ListAgentsRequest, v1alpha1.ListAgentsResponse]
-	getAgent    *connect.Client[v1alpha1.GetAgentRequest, v1alpha1.GetAgentResponse]
-	status      *connect.Client[v1alpha1.GetAgentStatusRequest, v1alpha1.GetAgentStatusResponse]
-	deleteAgent *connect.Client[v1alpha1.DeleteAgentRequest, emptypb.Empty]
+	listAgents            *connect.Client[v1alpha1.ListAgentsRequest, v1alpha1.ListAgentsResponse]
+	getAgent              *connect.Client[v1alpha1.GetAgentRequest, v1alpha1.GetAgentResponse]
+	status                *connect.Client[v1alpha1.GetAgentStatusRequest, v1alpha1.GetAgentStatusResponse]
+	deleteAgent           *connect.Client[v1alpha1.DeleteAgentRequest, emptypb.Empty]
+	undeleteAgent         *connect.Client[v1alpha1.UndeleteAgentRequest, emptypb.Empty]
+	revokeAgent           *connect.Client[v1alpha1.RevokeAgentRequest, emptypb.Empty]
+	captureAgentSnapshot  *connect.Client[v1alpha1.CaptureAgentSnapshotRequest, v1alpha1.CaptureAgentSnapshotResponse]
+	getAgentSnapshot      *connect.Client[v1alpha1.GetAgentSnapshotRequest, v1alpha1.GetAgentSnapshotResponse]
+	listAgentSnapshots    *connect.Client[v1alpha1.ListAgentSnapshotsRequest, v1alpha1.ListAgentSnapshotsResponse]
+	listConfigPushes      *connect.Client[v1alpha1.ListConfigPushesRequest, v1alpha1.ListConfigPushesResponse]
+	captureFleetSnapshot  *connect.Client[v1alpha1.CaptureFleetSnapshotRequest, v1alpha1.FleetSnapshot]
+	listFleetSnapshots    *connect.Client[v1alpha1.ListFleetSnapshotsRequest, v1alpha1.ListFleetSnapshotsResponse]
+	diffFleetState        *connect.Client[v1alpha1.DiffFleetStateRequest, v1alpha1.FleetStateDiff]
+	getAgentAvailability  *connect.Client[v1alpha1.GetAgentAvailabilityRequest, v1alpha1.AgentAvailability]
+	getFleetAvailability  *connect.Client[v1alpha1.GetFleetAvailabilityRequest, v1alpha1.FleetAvailability]
+	waitForAgentCondition *connect.Client[v1alpha1.WaitForAgentConditionRequest, v1alpha1.WaitForAgentConditionResponse]
+	restartAgent          *connect.Client[v1alpha1.RestartAgentRequest, v1alpha1.RestartAgentResponse]
+	getAgentEvents        *connect.Client[v1alpha1.GetAgentEventsRequest, v1alpha1.GetAgentEventsResponse]
+	getProblemsReport     *connect.Client[v1alpha1.GetProblemsReportRequest, v1alpha1.GetProblemsReportResponse]
+	acknowledgeProblem    *connect.Client[v1alpha1.AcknowledgeProblemRequest, emptypb.Empty]
 }
 
 // ListAgents calls config.v1alpha1.AgentService.ListAgents.
@@ -119,12 +333,137 @@ func (c *agentServiceClient) DeleteAgent(ctx context.Context, req *connect.Reque
 	return c.deleteAgent.CallUnary(ctx, req)
 }
 
+// UndeleteAgent calls config.v1alpha1.AgentService.UndeleteAgent.
+func (c *agentServiceClient) UndeleteAgent(ctx context.Context, req *connect.Request[v1alpha1.UndeleteAgentRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.undeleteAgent.CallUnary(ctx, req)
+}
+
+// RevokeAgent calls config.v1alpha1.AgentService.RevokeAgent.
+func (c *agentServiceClient) RevokeAgent(ctx context.Context, req *connect.Request[v1alpha1.RevokeAgentRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.revokeAgent.CallUnary(ctx, req)
+}
+
+// CaptureAgentSnapshot calls config.v1alpha1.AgentService.CaptureAgentSnapshot.
+func (c *agentServiceClient) CaptureAgentSnapshot(ctx context.Context, req *connect.Request[v1alpha1.CaptureAgentSnapshotRequest]) (*connect.Response[v1alpha1.CaptureAgentSnapshotResponse], error) {
+	return c.captureAgentSnapshot.CallUnary(ctx, req)
+}
+
+// GetAgentSnapshot calls config.v1alpha1.AgentService.GetAgentSnapshot.
+func (c *agentServiceClient) GetAgentSnapshot(ctx context.Context, req *connect.Request[v1alpha1.GetAgentSnapshotRequest]) (*connect.Response[v1alpha1.GetAgentSnapshotResponse], error) {
+	return c.getAgentSnapshot.CallUnary(ctx, req)
+}
+
+// ListAgentSnapshots calls config.v1alpha1.AgentService.ListAgentSnapshots.
+func (c *agentServiceClient) ListAgentSnapshots(ctx context.Context, req *connect.Request[v1alpha1.ListAgentSnapshotsRequest]) (*connect.Response[v1alpha1.ListAgentSnapshotsResponse], error) {
+	return c.listAgentSnapshots.CallUnary(ctx, req)
+}
+
+// ListConfigPushes calls config.v1alpha1.AgentService.ListConfigPushes.
+func (c *agentServiceClient) ListConfigPushes(ctx context.Context, req *connect.Request[v1alpha1.ListConfigPushesRequest]) (*connect.Response[v1alpha1.ListConfigPushesResponse], error) {
+	return c.listConfigPushes.CallUnary(ctx, req)
+}
+
+// CaptureFleetSnapshot calls config.v1alpha1.AgentService.CaptureFleetSnapshot.
+func (c *agentServiceClient) CaptureFleetSnapshot(ctx context.Context, req *connect.Request[v1alpha1.CaptureFleetSnapshotRequest]) (*connect.Response[v1alpha1.FleetSnapshot], error) {
+	return c.captureFleetSnapshot.CallUnary(ctx, req)
+}
+
+// ListFleetSnapshots calls config.v1alpha1.AgentService.ListFleetSnapshots.
+func (c *agentServiceClient) ListFleetSnapshots(ctx context.Context, req *connect.Request[v1alpha1.ListFleetSnapshotsRequest]) (*connect.Response[v1alpha1.ListFleetSnapshotsResponse], error) {
+	return c.listFleetSnapshots.CallUnary(ctx, req)
+}
+
+// DiffFleetState calls config.v1alpha1.AgentService.DiffFleetState.
+func (c *agentServiceClient) DiffFleetState(ctx context.Context, req *connect.Request[v1alpha1.DiffFleetStateRequest]) (*connect.Response[v1alpha1.FleetStateDiff], error) {
+	return c.diffFleetState.CallUnary(ctx, req)
+}
+
+// GetAgentAvailability calls config.v1alpha1.AgentService.GetAgentAvailability.
+func (c *agentServiceClient) GetAgentAvailability(ctx context.Context, req *connect.Request[v1alpha1.GetAgentAvailabilityRequest]) (*connect.Response[v1alpha1.AgentAvailability], error) {
+	return c.getAgentAvailability.CallUnary(ctx, req)
+}
+
+// GetFleetAvailability calls config.v1alpha1.AgentService.GetFleetAvailability.
+func (c *agentServiceClient) GetFleetAvailability(ctx context.Context, req *connect.Request[v1alpha1.GetFleetAvailabilityRequest]) (*connect.Response[v1alpha1.FleetAvailability], error) {
+	return c.getFleetAvailability.CallUnary(ctx, req)
+}
+
+// WaitForAgentCondition calls config.v1alpha1.AgentService.WaitForAgentCondition.
+func (c *agentServiceClient) WaitForAgentCondition(ctx context.Context, req *connect.Request[v1alpha1.WaitForAgentConditionRequest]) (*connect.Response[v1alpha1.WaitForAgentConditionResponse], error) {
+	return c.waitForAgentCondition.CallUnary(ctx, req)
+}
+
+// RestartAgent calls config.v1alpha1.AgentService.RestartAgent.
+func (c *agentServiceClient) RestartAgent(ctx context.Context, req *connect.Request[v1alpha1.RestartAgentRequest]) (*connect.Response[v1alpha1.RestartAgentResponse], error) {
+	return c.restartAgent.CallUnary(ctx, req)
+}
+
+// GetAgentEvents calls config.v1alpha1.AgentService.GetAgentEvents.
+func (c *agentServiceClient) GetAgentEvents(ctx context.Context, req *connect.Request[v1alpha1.GetAgentEventsRequest]) (*connect.Response[v1alpha1.GetAgentEventsResponse], error) {
+	return c.getAgentEvents.CallUnary(ctx, req)
+}
+
+// GetProblemsReport calls config.v1alpha1.AgentService.GetProblemsReport.
+func (c *agentServiceClient) GetProblemsReport(ctx context.Context, req *connect.Request[v1alpha1.GetProblemsReportRequest]) (*connect.Response[v1alpha1.GetProblemsReportResponse], error) {
+	return c.getProblemsReport.CallUnary(ctx, req)
+}
+
+// AcknowledgeProblem calls config.v1alpha1.AgentService.AcknowledgeProblem.
+func (c *agentServiceClient) AcknowledgeProblem(ctx context.Context, req *connect.Request[v1alpha1.AcknowledgeProblemRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.acknowledgeProblem.CallUnary(ctx, req)
+}
+
 // AgentServiceHandler is an implementation of the config.v1alpha1.AgentService service.
 type AgentServiceHandler interface {
 	ListAgents(context.Context, *connect.Request[v1alpha1.ListAgentsRequest]) (*connect.Response[v1alpha1.ListAgentsResponse], error)
 	GetAgent(context.Context, *connect.Request[v1alpha1.GetAgentRequest]) (*connect.Response[v1alpha1.GetAgentResponse], error)
 	Status(context.Context, *connect.Request[v1alpha1.GetAgentStatusRequest]) (*connect.Response[v1alpha1.GetAgentStatusResponse], error)
+	// DeleteAgent hides the agent and withholds its config. It is purged once the deletion
+	// grace period expires, unless it reconnects or is undeleted in the meantime.
 	DeleteAgent(context.Context, *connect.Request[v1alpha1.DeleteAgentRequest]) (*connect.Response[emptypb.Empty], error)
+	// UndeleteAgent restores an agent deleted within the grace period
+	UndeleteAgent(context.Context, *connect.Request[v1alpha1.UndeleteAgentRequest]) (*connect.Response[emptypb.Empty], error)
+	// RevokeAgent disconnects the agent and rejects its OpAMP connections from then on, e.g. once
+	// its host is decommissioned or compromised. Revoked agents can't bootstrap again under the
+	// same ID, they stay listed until they are deleted.
+	RevokeAgent(context.Context, *connect.Request[v1alpha1.RevokeAgentRequest]) (*connect.Response[emptypb.Empty], error)
+	// CaptureAgentSnapshot asks the agent's supervisor to upload a support snapshot.
+	// The snapshot is captured asynchronously, poll GetAgentSnapshot until it is ready.
+	CaptureAgentSnapshot(context.Context, *connect.Request[v1alpha1.CaptureAgentSnapshotRequest]) (*connect.Response[v1alpha1.CaptureAgentSnapshotResponse], error)
+	GetAgentSnapshot(context.Context, *connect.Request[v1alpha1.GetAgentSnapshotRequest]) (*connect.Response[v1alpha1.GetAgentSnapshotResponse], error)
+	ListAgentSnapshots(context.Context, *connect.Request[v1alpha1.ListAgentSnapshotsRequest]) (*connect.Response[v1alpha1.ListAgentSnapshotsResponse], error)
+	// ListConfigPushes lists the remote configs recently pushed to an agent and how far
+	// each got, from being offered to being applied.
+	ListConfigPushes(context.Context, *connect.Request[v1alpha1.ListConfigPushesRequest]) (*connect.Response[v1alpha1.ListConfigPushesResponse], error)
+	// Fleet snapshots record the state of every agent, they are captured daily and on demand.
+	CaptureFleetSnapshot(context.Context, *connect.Request[v1alpha1.CaptureFleetSnapshotRequest]) (*connect.Response[v1alpha1.FleetSnapshot], error)
+	ListFleetSnapshots(context.Context, *connect.Request[v1alpha1.ListFleetSnapshotsRequest]) (*connect.Response[v1alpha1.ListFleetSnapshotsResponse], error)
+	// DiffFleetState reports the agents added, removed and changed between two fleet snapshots,
+	// or between a snapshot and the current state of the fleet.
+	DiffFleetState(context.Context, *connect.Request[v1alpha1.DiffFleetStateRequest]) (*connect.Response[v1alpha1.FleetStateDiff], error)
+	// Availability is the share of time agents were connected and healthy over rolling windows,
+	// computed from their heartbeats. An agent missing heartbeats for longer than the configured
+	// timeout counts as unavailable until it is heard from again.
+	GetAgentAvailability(context.Context, *connect.Request[v1alpha1.GetAgentAvailabilityRequest]) (*connect.Response[v1alpha1.AgentAvailability], error)
+	// GetFleetAvailability averages the availability of agents, grouped by the value of a label
+	GetFleetAvailability(context.Context, *connect.Request[v1alpha1.GetFleetAvailabilityRequest]) (*connect.Response[v1alpha1.FleetAvailability], error)
+	// WaitForAgentCondition returns as soon as the agent satisfies the condition, or once the
+	// timeout expires, with the status of the agent either way
+	WaitForAgentCondition(context.Context, *connect.Request[v1alpha1.WaitForAgentConditionRequest]) (*connect.Response[v1alpha1.WaitForAgentConditionResponse], error)
+	// RestartAgent asks a connected agent to restart its collector. The agent reports the
+	// result asynchronously, it is the last_command of the agent's status.
+	RestartAgent(context.Context, *connect.Request[v1alpha1.RestartAgentRequest]) (*connect.Response[v1alpha1.RestartAgentResponse], error)
+	// GetAgentEvents returns the timeline of an agent, oldest first: the events recorded about
+	// it, e.g. connections, config assignments and outcomes, bootstrap and deletion. Timelines
+	// are kept longer than fleet events, and outlive purged agents until they expire.
+	GetAgentEvents(context.Context, *connect.Request[v1alpha1.GetAgentEventsRequest]) (*connect.Response[v1alpha1.GetAgentEventsResponse], error)
+	// GetProblemsReport returns the open problems reported by agents' supervisors, e.g. a
+	// config failing to apply or a crash-looping collector. Problems stay open until they are
+	// acknowledged, even if the agent recovered in the meantime.
+	GetProblemsReport(context.Context, *connect.Request[v1alpha1.GetProblemsReportRequest]) (*connect.Response[v1alpha1.GetProblemsReportResponse], error)
+	// AcknowledgeProblem closes an open problem of an agent. It is opened again if the agent
+	// reports it again.
+	AcknowledgeProblem(context.Context, *connect.Request[v1alpha1.AcknowledgeProblemRequest]) (*connect.Response[emptypb.Empty], error)
 }
 
 // NewAgentServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -158,6 +497,102 @@ func NewAgentServiceHandler(svc AgentServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(agentServiceMethods.ByName("DeleteAgent")),
 		connect.WithHandlerOptions(opts...),
 	)
+	agentServiceUndeleteAgentHandler := connect.NewUnaryHandler(
+		AgentServiceUndeleteAgentProcedure,
+		svc.UndeleteAgent,
+		connect.WithSchema(agentServiceMethods.ByName("UndeleteAgent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceRevokeAgentHandler := connect.NewUnaryHandler(
+		AgentServiceRevokeAgentProcedure,
+		svc.RevokeAgent,
+		connect.WithSchema(agentServiceMethods.ByName("RevokeAgent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceCaptureAgentSnapshotHandler := connect.NewUnaryHandler(
+		AgentServiceCaptureAgentSnapshotProcedure,
+		svc.CaptureAgentSnapshot,
+		connect.WithSchema(agentServiceMethods.ByName("CaptureAgentSnapshot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceGetAgentSnapshotHandler := connect.NewUnaryHandler(
+		AgentServiceGetAgentSnapshotProcedure,
+		svc.GetAgentSnapshot,
+		connect.WithSchema(agentServiceMethods.ByName("GetAgentSnapshot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceListAgentSnapshotsHandler := connect.NewUnaryHandler(
+		AgentServiceListAgentSnapshotsProcedure,
+		svc.ListAgentSnapshots,
+		connect.WithSchema(agentServiceMethods.ByName("ListAgentSnapshots")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceListConfigPushesHandler := connect.NewUnaryHandler(
+		AgentServiceListConfigPushesProcedure,
+		svc.ListConfigPushes,
+		connect.WithSchema(agentServiceMethods.ByName("ListConfigPushes")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceCaptureFleetSnapshotHandler := connect.NewUnaryHandler(
+		AgentServiceCaptureFleetSnapshotProcedure,
+		svc.CaptureFleetSnapshot,
+		connect.WithSchema(agentServiceMethods.ByName("CaptureFleetSnapshot")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceListFleetSnapshotsHandler := connect.NewUnaryHandler(
+		AgentServiceListFleetSnapshotsProcedure,
+		svc.ListFleetSnapshots,
+		connect.WithSchema(agentServiceMethods.ByName("ListFleetSnapshots")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceDiffFleetStateHandler := connect.NewUnaryHandler(
+		AgentServiceDiffFleetStateProcedure,
+		svc.DiffFleetState,
+		connect.WithSchema(agentServiceMethods.ByName("DiffFleetState")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceGetAgentAvailabilityHandler := connect.NewUnaryHandler(
+		AgentServiceGetAgentAvailabilityProcedure,
+		svc.GetAgentAvailability,
+		connect.WithSchema(agentServiceMethods.ByName("GetAgentAvailability")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceGetFleetAvailabilityHandler := connect.NewUnaryHandler(
+		AgentServiceGetFleetAvailabilityProcedure,
+		svc.GetFleetAvailability,
+		connect.WithSchema(agentServiceMethods.ByName("GetFleetAvailability")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceWaitForAgentConditionHandler := connect.NewUnaryHandler(
+		AgentServiceWaitForAgentConditionProcedure,
+		svc.WaitForAgentCondition,
+		connect.WithSchema(agentServiceMethods.ByName("WaitForAgentCondition")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceRestartAgentHandler := connect.NewUnaryHandler(
+		AgentServiceRestartAgentProcedure,
+		svc.RestartAgent,
+		connect.WithSchema(agentServiceMethods.ByName("RestartAgent")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceGetAgentEventsHandler := connect.NewUnaryHandler(
+		AgentServiceGetAgentEventsProcedure,
+		svc.GetAgentEvents,
+		connect.WithSchema(agentServiceMethods.ByName("GetAgentEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceGetProblemsReportHandler := connect.NewUnaryHandler(
+		AgentServiceGetProblemsReportProcedure,
+		svc.GetProblemsReport,
+		connect.WithSchema(agentServiceMethods.ByName("GetProblemsReport")),
+		connect.WithHandlerOptions(opts...),
+	)
+	agentServiceAcknowledgeProblemHandler := connect.NewUnaryHandler(
+		AgentServiceAcknowledgeProblemProcedure,
+		svc.AcknowledgeProblem,
+		connect.WithSchema(agentServiceMethods.ByName("AcknowledgeProblem")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/config.v1alpha1.AgentService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AgentServiceListAgentsProcedure:
@@ -168,6 +603,38 @@ func NewAgentServiceHandler(svc AgentServiceHandler, opts ...connect.HandlerOpti
 			agentServiceStatusHandler.ServeHTTP(w, r)
 		case AgentServiceDeleteAgentProcedure:
 			agentServiceDeleteAgentHandler.ServeHTTP(w, r)
+		case AgentServiceUndeleteAgentProcedure:
+			agentServiceUndeleteAgentHandler.ServeHTTP(w, r)
+		case AgentServiceRevokeAgentProcedure:
+			agentServiceRevokeAgentHandler.ServeHTTP(w, r)
+		case AgentServiceCaptureAgentSnapshotProcedure:
+			agentServiceCaptureAgentSnapshotHandler.ServeHTTP(w, r)
+		case AgentServiceGetAgentSnapshotProcedure:
+			agentServiceGetAgentSnapshotHandler.ServeHTTP(w, r)
+		case AgentServiceListAgentSnapshotsProcedure:
+			agentServiceListAgentSnapshotsHandler.ServeHTTP(w, r)
+		case AgentServiceListConfigPushesProcedure:
+			agentServiceListConfigPushesHandler.ServeHTTP(w, r)
+		case AgentServiceCaptureFleetSnapshotProcedure:
+			agentServiceCaptureFleetSnapshotHandler.ServeHTTP(w, r)
+		case AgentServiceListFleetSnapshotsProcedure:
+			agentServiceListFleetSnapshotsHandler.ServeHTTP(w, r)
+		case AgentServiceDiffFleetStateProcedure:
+			agentServiceDiffFleetStateHandler.ServeHTTP(w, r)
+		case AgentServiceGetAgentAvailabilityProcedure:
+			agentServiceGetAgentAvailabilityHandler.ServeHTTP(w, r)
+		case AgentServiceGetFleetAvailabilityProcedure:
+			agentServiceGetFleetAvailabilityHandler.ServeHTTP(w, r)
+		case AgentServiceWaitForAgentConditionProcedure:
+			agentServiceWaitForAgentConditionHandler.ServeHTTP(w, r)
+		case AgentServiceRestartAgentProcedure:
+			agentServiceRestartAgentHandler.ServeHTTP(w, r)
+		case AgentServiceGetAgentEventsProcedure:
+			agentServiceGetAgentEventsHandler.ServeHTTP(w, r)
+		case AgentServiceGetProblemsReportProcedure:
+			agentServiceGetProblemsReportHandler.ServeHTTP(w, r)
+		case AgentServiceAcknowledgeProblemProcedure:
+			agentServiceAcknowledgeProblemHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -192,3 +659,199 @@ func (UnimplementedAgentServiceHandler) Status(context.Context, *connect.Request
 func (UnimplementedAgentServiceHandler) DeleteAgent(context.Context, *connect.Request[v1alpha1.DeleteAgentRequest]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.DeleteAgent is not implemented"))
 }
+
+func (UnimplementedAgentServiceHandler) UndeleteAgent(context.Context, *connect.Request[v1alpha1.UndeleteAgentRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.UndeleteAgent is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) RevokeAgent(context.Context, *connect.Request[v1alpha1.RevokeAgentRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.RevokeAgent is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) CaptureAgentSnapshot(context.Context, *connect.Request[v1alpha1.CaptureAgentSnapshotRequest]) (*connect.Response[v1alpha1.CaptureAgentSnapshotResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.CaptureAgentSnapshot is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) GetAgentSnapshot(context.Context, *connect.Request[v1alpha1.GetAgentSnapshotRequest]) (*connect.Response[v1alpha1.GetAgentSnapshotResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.GetAgentSnapshot is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) ListAgentSnapshots(context.Context, *connect.Request[v1alpha1.ListAgentSnapshotsRequest]) (*connect.Response[v1alpha1.ListAgentSnapshotsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.ListAgentSnapshots is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) ListConfigPushes(context.Context, *connect.Request[v1alpha1.ListConfigPushesRequest]) (*connect.Response[v1alpha1.ListConfigPushesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.ListConfigPushes is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) CaptureFleetSnapshot(context.Context, *connect.Request[v1alpha1.CaptureFleetSnapshotRequest]) (*connect.Response[v1alpha1.FleetSnapshot], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.CaptureFleetSnapshot is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) ListFleetSnapshots(context.Context, *connect.Request[v1alpha1.ListFleetSnapshotsRequest]) (*connect.Response[v1alpha1.ListFleetSnapshotsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.ListFleetSnapshots is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) DiffFleetState(context.Context, *connect.Request[v1alpha1.DiffFleetStateRequest]) (*connect.Response[v1alpha1.FleetStateDiff], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.DiffFleetState is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) GetAgentAvailability(context.Context, *connect.Request[v1alpha1.GetAgentAvailabilityRequest]) (*connect.Response[v1alpha1.AgentAvailability], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.GetAgentAvailability is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) GetFleetAvailability(context.Context, *connect.Request[v1alpha1.GetFleetAvailabilityRequest]) (*connect.Response[v1alpha1.FleetAvailability], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.GetFleetAvailability is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) WaitForAgentCondition(context.Context, *connect.Request[v1alpha1.WaitForAgentConditionRequest]) (*connect.Response[v1alpha1.WaitForAgentConditionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.WaitForAgentCondition is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) RestartAgent(context.Context, *connect.Request[v1alpha1.RestartAgentRequest]) (*connect.Response[v1alpha1.RestartAgentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.RestartAgent is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) GetAgentEvents(context.Context, *connect.Request[v1alpha1.GetAgentEventsRequest]) (*connect.Response[v1alpha1.GetAgentEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.GetAgentEvents is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) GetProblemsReport(context.Context, *connect.Request[v1alpha1.GetProblemsReportRequest]) (*connect.Response[v1alpha1.GetProblemsReportResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.GetProblemsReport is not implemented"))
+}
+
+func (UnimplementedAgentServiceHandler) AcknowledgeProblem(context.Context, *connect.Request[v1alpha1.AcknowledgeProblemRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.AgentService.AcknowledgeProblem is not implemented"))
+}
+
+// GatewayServiceClient is a client for the config.v1alpha1.GatewayService service.
+type GatewayServiceClient interface {
+	// Relay handles a message an agent sent to the gateway and returns the messages to send
+	// back to it, the reply last
+	Relay(context.Context, *connect.Request[v1alpha1.RelayRequest]) (*connect.Response[v1alpha1.RelayResponse], error)
+	// Watch streams the messages sent to the agents of the gateway outside of replies, such as
+	// config pushes. Agents relayed by a gateway are disconnected when its watch ends.
+	Watch(context.Context, *connect.Request[v1alpha1.WatchGatewayRequest]) (*connect.ServerStreamForClient[v1alpha1.RelayedMessage], error)
+	// Disconnect reports that an agent connection to the gateway closed
+	Disconnect(context.Context, *connect.Request[v1alpha1.DisconnectRelayedAgentRequest]) (*connect.Response[emptypb.Empty], error)
+}
+
+// NewGatewayServiceClient constructs a client for the config.v1alpha1.GatewayService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewGatewayServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) GatewayServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	gatewayServiceMethods := v1alpha1.File_pkg_api_agents_v1alpha1_agents_proto.Services().ByName("GatewayService").Methods()
+	return &gatewayServiceClient{
+		relay: connect.NewClient[v1alpha1.RelayRequest, v1alpha1.RelayResponse](
+			httpClient,
+			baseURL+GatewayServiceRelayProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("Relay")),
+			connect.WithClientOptions(opts...),
+		),
+		watch: connect.NewClient[v1alpha1.WatchGatewayRequest, v1alpha1.RelayedMessage](
+			httpClient,
+			baseURL+GatewayServiceWatchProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("Watch")),
+			connect.WithClientOptions(opts...),
+		),
+		disconnect: connect.NewClient[v1alpha1.DisconnectRelayedAgentRequest, emptypb.Empty](
+			httpClient,
+			baseURL+GatewayServiceDisconnectProcedure,
+			connect.WithSchema(gatewayServiceMethods.ByName("Disconnect")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// gatewayServiceClient implements GatewayServiceClient.
+type gatewayServiceClient struct {
+	relay      *connect.Client[v1alpha1.RelayRequest, v1alpha1.RelayResponse]
+	watch      *connect.Client[v1alpha1.WatchGatewayRequest, v1alpha1.RelayedMessage]
+	disconnect *connect.Client[v1alpha1.DisconnectRelayedAgentRequest, emptypb.Empty]
+}
+
+// Relay calls config.v1alpha1.GatewayService.Relay.
+func (c *gatewayServiceClient) Relay(ctx context.Context, req *connect.Request[v1alpha1.RelayRequest]) (*connect.Response[v1alpha1.RelayResponse], error) {
+	return c.relay.CallUnary(ctx, req)
+}
+
+// Watch calls config.v1alpha1.GatewayService.Watch.
+func (c *gatewayServiceClient) Watch(ctx context.Context, req *connect.Request[v1alpha1.WatchGatewayRequest]) (*connect.ServerStreamForClient[v1alpha1.RelayedMessage], error) {
+	return c.watch.CallServerStream(ctx, req)
+}
+
+// Disconnect calls config.v1alpha1.GatewayService.Disconnect.
+func (c *gatewayServiceClient) Disconnect(ctx context.Context, req *connect.Request[v1alpha1.DisconnectRelayedAgentRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.disconnect.CallUnary(ctx, req)
+}
+
+// GatewayServiceHandler is an implementation of the config.v1alpha1.GatewayService service.
+type GatewayServiceHandler interface {
+	// Relay handles a message an agent sent to the gateway and returns the messages to send
+	// back to it, the reply last
+	Relay(context.Context, *connect.Request[v1alpha1.RelayRequest]) (*connect.Response[v1alpha1.RelayResponse], error)
+	// Watch streams the messages sent to the agents of the gateway outside of replies, such as
+	// config pushes. Agents relayed by a gateway are disconnected when its watch ends.
+	Watch(context.Context, *connect.Request[v1alpha1.WatchGatewayRequest], *connect.ServerStream[v1alpha1.RelayedMessage]) error
+	// Disconnect reports that an agent connection to the gateway closed
+	Disconnect(context.Context, *connect.Request[v1alpha1.DisconnectRelayedAgentRequest]) (*connect.Response[emptypb.Empty], error)
+}
+
+// NewGatewayServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewGatewayServiceHandler(svc GatewayServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	gatewayServiceMethods := v1alpha1.File_pkg_api_agents_v1alpha1_agents_proto.Services().ByName("GatewayService").Methods()
+	gatewayServiceRelayHandler := connect.NewUnaryHandler(
+		GatewayServiceRelayProcedure,
+		svc.Relay,
+		connect.WithSchema(gatewayServiceMethods.ByName("Relay")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceWatchHandler := connect.NewServerStreamHandler(
+		GatewayServiceWatchProcedure,
+		svc.Watch,
+		connect.WithSchema(gatewayServiceMethods.ByName("Watch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	gatewayServiceDisconnectHandler := connect.NewUnaryHandler(
+		GatewayServiceDisconnectProcedure,
+		svc.Disconnect,
+		connect.WithSchema(gatewayServiceMethods.ByName("Disconnect")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/config.v1alpha1.GatewayService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case GatewayServiceRelayProcedure:
+			gatewayServiceRelayHandler.ServeHTTP(w, r)
+		case GatewayServiceWatchProcedure:
+			gatewayServiceWatchHandler.ServeHTTP(w, r)
+		case GatewayServiceDisconnectProcedure:
+			gatewayServiceDisconnectHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedGatewayServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedGatewayServiceHandler struct{}
+
+func (UnimplementedGatewayServiceHandler) Relay(context.Context, *connect.Request[v1alpha1.RelayRequest]) (*connect.Response[v1alpha1.RelayResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.GatewayService.Relay is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) Watch(context.Context, *connect.Request[v1alpha1.WatchGatewayRequest], *connect.ServerStream[v1alpha1.RelayedMessage]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.GatewayService.Watch is not implemented"))
+}
+
+func (UnimplementedGatewayServiceHandler) Disconnect(context.Context, *connect.Request[v1alpha1.DisconnectRelayedAgentRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.GatewayService.Disconnect is not implemented"))
+}
@@ -39,4 +39,104 @@ func RegisterAgentServiceHandler(mux *mux.Router, svc AgentServiceHandler, opts
 		svc.DeleteAgent,
 		opts...,
 	))
+	mux.Handle("/config.v1alpha1.AgentService/UndeleteAgent", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/UndeleteAgent",
+		svc.UndeleteAgent,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/RevokeAgent", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/RevokeAgent",
+		svc.RevokeAgent,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/CaptureAgentSnapshot", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/CaptureAgentSnapshot",
+		svc.CaptureAgentSnapshot,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/GetAgentSnapshot", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/GetAgentSnapshot",
+		svc.GetAgentSnapshot,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/ListAgentSnapshots", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/ListAgentSnapshots",
+		svc.ListAgentSnapshots,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/ListConfigPushes", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/ListConfigPushes",
+		svc.ListConfigPushes,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/CaptureFleetSnapshot", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/CaptureFleetSnapshot",
+		svc.CaptureFleetSnapshot,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/ListFleetSnapshots", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/ListFleetSnapshots",
+		svc.ListFleetSnapshots,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/DiffFleetState", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/DiffFleetState",
+		svc.DiffFleetState,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/GetAgentAvailability", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/GetAgentAvailability",
+		svc.GetAgentAvailability,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/GetFleetAvailability", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/GetFleetAvailability",
+		svc.GetFleetAvailability,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/WaitForAgentCondition", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/WaitForAgentCondition",
+		svc.WaitForAgentCondition,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/RestartAgent", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/RestartAgent",
+		svc.RestartAgent,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/GetAgentEvents", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/GetAgentEvents",
+		svc.GetAgentEvents,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/GetProblemsReport", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/GetProblemsReport",
+		svc.GetProblemsReport,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.AgentService/AcknowledgeProblem", connect.NewUnaryHandler(
+		"/config.v1alpha1.AgentService/AcknowledgeProblem",
+		svc.AcknowledgeProblem,
+		opts...,
+	))
+}
+
+// RegisterGatewayServiceHandler register an HTTP handler to a mux.Router from the service
+// implementation.
+func RegisterGatewayServiceHandler(mux *mux.Router, svc GatewayServiceHandler, opts ...connect.HandlerOption) {
+	mux.Handle("/config.v1alpha1.GatewayService/Relay", connect.NewUnaryHandler(
+		"/config.v1alpha1.GatewayService/Relay",
+		svc.Relay,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.GatewayService/Watch", connect.NewServerStreamHandler(
+		"/config.v1alpha1.GatewayService/Watch",
+		svc.Watch,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.GatewayService/Disconnect", connect.NewUnaryHandler(
+		"/config.v1alpha1.GatewayService/Disconnect",
+		svc.Disconnect,
+		opts...,
+	))
 }
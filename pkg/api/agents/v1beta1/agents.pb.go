@@ -0,0 +1,1043 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: pkg/api/agents/v1beta1/agents.proto
+
+package v1beta1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AgentSortField int32
+
+const (
+	// by agent ID
+	AgentSortField_AGENT_SORT_FIELD_UNSPECIFIED AgentSortField = 0
+	AgentSortField_AGENT_SORT_FIELD_NAME        AgentSortField = 1
+	// agents that were never seen first
+	AgentSortField_AGENT_SORT_FIELD_LAST_SEEN AgentSortField = 2
+	AgentSortField_AGENT_SORT_FIELD_STATE     AgentSortField = 3
+)
+
+// Enum value maps for AgentSortField.
+var (
+	AgentSortField_name = map[int32]string{
+		0: "AGENT_SORT_FIELD_UNSPECIFIED",
+		1: "AGENT_SORT_FIELD_NAME",
+		2: "AGENT_SORT_FIELD_LAST_SEEN",
+		3: "AGENT_SORT_FIELD_STATE",
+	}
+	AgentSortField_value = map[string]int32{
+		"AGENT_SORT_FIELD_UNSPECIFIED": 0,
+		"AGENT_SORT_FIELD_NAME":        1,
+		"AGENT_SORT_FIELD_LAST_SEEN":   2,
+		"AGENT_SORT_FIELD_STATE":       3,
+	}
+)
+
+func (x AgentSortField) Enum() *AgentSortField {
+	p := new(AgentSortField)
+	*p = x
+	return p
+}
+
+func (x AgentSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AgentSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_agents_v1beta1_agents_proto_enumTypes[0].Descriptor()
+}
+
+func (AgentSortField) Type() protoreflect.EnumType {
+	return &file_pkg_api_agents_v1beta1_agents_proto_enumTypes[0]
+}
+
+func (x AgentSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AgentSortField.Descriptor instead.
+func (AgentSortField) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{0}
+}
+
+type ListAgentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// also return the agents deleted within the grace period
+	IncludeDeleted bool `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// maximum number of agents returned, all the matching agents when 0. The next page is
+	// requested with the next_page_token of the response, and the same filters and order.
+	PageSize  int32  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// order of the agents, ties are broken by agent ID
+	SortBy     AgentSortField `protobuf:"varint,7,opt,name=sort_by,json=sortBy,proto3,enum=agents.v1beta1.AgentSortField" json:"sort_by,omitempty"`
+	Descending bool           `protobuf:"varint,8,opt,name=descending,proto3" json:"descending,omitempty"`
+	// only return agents whose labels include all of these
+	LabelSelector map[string]string `protobuf:"bytes,10,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// only return agents assigned one of these configs, all agents when empty
+	ConfigIds     []string `protobuf:"bytes,11,rep,name=config_ids,json=configIds,proto3" json:"config_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsRequest) Reset() {
+	*x = ListAgentsRequest{}
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsRequest) ProtoMessage() {}
+
+func (x *ListAgentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListAgentsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListAgentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAgentsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListAgentsRequest) GetSortBy() AgentSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return AgentSortField_AGENT_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *ListAgentsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *ListAgentsRequest) GetLabelSelector() map[string]string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return nil
+}
+
+func (x *ListAgentsRequest) GetConfigIds() []string {
+	if x != nil {
+		return x.ConfigIds
+	}
+	return nil
+}
+
+type ListAgentsResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Agents []*AgentListEntry      `protobuf:"bytes,1,rep,name=agents,proto3" json:"agents,omitempty"`
+	// number of registered agents, before filtering
+	TotalCount int32 `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// token of the next page, empty on the last page
+	NextPageToken string `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// number of agents matching the filters, across all pages
+	MatchedCount  int32 `protobuf:"varint,5,opt,name=matched_count,json=matchedCount,proto3" json:"matched_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAgentsResponse) Reset() {
+	*x = ListAgentsResponse{}
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAgentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAgentsResponse) ProtoMessage() {}
+
+func (x *ListAgentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAgentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListAgentsResponse) GetAgents() []*AgentListEntry {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *ListAgentsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListAgentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListAgentsResponse) GetMatchedCount() int32 {
+	if x != nil {
+		return x.MatchedCount
+	}
+	return 0
+}
+
+type AgentListEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         *Agent                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentListEntry) Reset() {
+	*x = AgentListEntry{}
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentListEntry) ProtoMessage() {}
+
+func (x *AgentListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentListEntry.ProtoReflect.Descriptor instead.
+func (*AgentListEntry) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AgentListEntry) GetAgent() *Agent {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+type GetAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentRequest) Reset() {
+	*x = GetAgentRequest{}
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentRequest) ProtoMessage() {}
+
+func (x *GetAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAgentRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type GetAgentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Agent         *Agent                 `protobuf:"bytes,1,opt,name=agent,proto3" json:"agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentResponse) Reset() {
+	*x = GetAgentResponse{}
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentResponse) ProtoMessage() {}
+
+func (x *GetAgentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetAgentResponse) GetAgent() *Agent {
+	if x != nil {
+		return x.Agent
+	}
+	return nil
+}
+
+type DeleteAgentRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// delete the agent permanently, without a grace period
+	Purge         bool `protobuf:"varint,2,opt,name=purge,proto3" json:"purge,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAgentRequest) Reset() {
+	*x = DeleteAgentRequest{}
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAgentRequest) ProtoMessage() {}
+
+func (x *DeleteAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAgentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAgentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteAgentRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *DeleteAgentRequest) GetPurge() bool {
+	if x != nil {
+		return x.Purge
+	}
+	return false
+}
+
+type UndeleteAgentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeleteAgentRequest) Reset() {
+	*x = UndeleteAgentRequest{}
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeleteAgentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteAgentRequest) ProtoMessage() {}
+
+func (x *UndeleteAgentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteAgentRequest.ProtoReflect.Descriptor instead.
+func (*UndeleteAgentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UndeleteAgentRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type Agent struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FriendlyName string                 `protobuf:"bytes,2,opt,name=friendly_name,json=friendlyName,proto3" json:"friendly_name,omitempty"`
+	// Attributes that identify the Agent (e.g., service.name, service.version, service.instance.id).
+	IdentifyingAttributes []*KeyValue `protobuf:"bytes,3,rep,name=identifying_attributes,json=identifyingAttributes,proto3" json:"identifying_attributes,omitempty"`
+	// Attributes that do not necessarily identify the Agent but help describe where it runs
+	// (e.g., os.type, os.version, host.*, cloud.*).
+	NonIdentifyingAttributes []*KeyValue `protobuf:"bytes,4,rep,name=non_identifying_attributes,json=nonIdentifyingAttributes,proto3" json:"non_identifying_attributes,omitempty"`
+	Capabilities             []string    `protobuf:"bytes,5,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	// when the agent was deleted, it is purged once the deletion grace period expires
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Agent) Reset() {
+	*x = Agent{}
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Agent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Agent) ProtoMessage() {}
+
+func (x *Agent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Agent.ProtoReflect.Descriptor instead.
+func (*Agent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Agent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Agent) GetFriendlyName() string {
+	if x != nil {
+		return x.FriendlyName
+	}
+	return ""
+}
+
+func (x *Agent) GetIdentifyingAttributes() []*KeyValue {
+	if x != nil {
+		return x.IdentifyingAttributes
+	}
+	return nil
+}
+
+func (x *Agent) GetNonIdentifyingAttributes() []*KeyValue {
+	if x != nil {
+		return x.NonIdentifyingAttributes
+	}
+	return nil
+}
+
+func (x *Agent) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *Agent) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+// KeyValue represents a key-value pair with support for various value types.
+type KeyValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         *AnyValue              `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyValue) Reset() {
+	*x = KeyValue{}
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyValue) ProtoMessage() {}
+
+func (x *KeyValue) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyValue.ProtoReflect.Descriptor instead.
+func (*KeyValue) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *KeyValue) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *KeyValue) GetValue() *AnyValue {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// AnyValue represents a value that can be one of several types.
+type AnyValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Value:
+	//
+	//	*AnyValue_StringValue
+	//	*AnyValue_BoolValue
+	//	*AnyValue_IntValue
+	//	*AnyValue_DoubleValue
+	//	*AnyValue_BytesValue
+	//	*AnyValue_ArrayValue
+	//	*AnyValue_KvlistValue
+	Value         isAnyValue_Value `protobuf_oneof:"value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnyValue) Reset() {
+	*x = AnyValue{}
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnyValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnyValue) ProtoMessage() {}
+
+func (x *AnyValue) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnyValue.ProtoReflect.Descriptor instead.
+func (*AnyValue) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AnyValue) GetValue() isAnyValue_Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *AnyValue) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *AnyValue) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *AnyValue) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *AnyValue) GetDoubleValue() float64 {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_DoubleValue); ok {
+			return x.DoubleValue
+		}
+	}
+	return 0
+}
+
+func (x *AnyValue) GetBytesValue() []byte {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_BytesValue); ok {
+			return x.BytesValue
+		}
+	}
+	return nil
+}
+
+func (x *AnyValue) GetArrayValue() *ArrayValue {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_ArrayValue); ok {
+			return x.ArrayValue
+		}
+	}
+	return nil
+}
+
+func (x *AnyValue) GetKvlistValue() *KeyValueList {
+	if x != nil {
+		if x, ok := x.Value.(*AnyValue_KvlistValue); ok {
+			return x.KvlistValue
+		}
+	}
+	return nil
+}
+
+type isAnyValue_Value interface {
+	isAnyValue_Value()
+}
+
+type AnyValue_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type AnyValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,2,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type AnyValue_IntValue struct {
+	IntValue int64 `protobuf:"varint,3,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type AnyValue_DoubleValue struct {
+	DoubleValue float64 `protobuf:"fixed64,4,opt,name=double_value,json=doubleValue,proto3,oneof"`
+}
+
+type AnyValue_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,5,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+type AnyValue_ArrayValue struct {
+	ArrayValue *ArrayValue `protobuf:"bytes,6,opt,name=array_value,json=arrayValue,proto3,oneof"`
+}
+
+type AnyValue_KvlistValue struct {
+	KvlistValue *KeyValueList `protobuf:"bytes,7,opt,name=kvlist_value,json=kvlistValue,proto3,oneof"`
+}
+
+func (*AnyValue_StringValue) isAnyValue_Value() {}
+
+func (*AnyValue_BoolValue) isAnyValue_Value() {}
+
+func (*AnyValue_IntValue) isAnyValue_Value() {}
+
+func (*AnyValue_DoubleValue) isAnyValue_Value() {}
+
+func (*AnyValue_BytesValue) isAnyValue_Value() {}
+
+func (*AnyValue_ArrayValue) isAnyValue_Value() {}
+
+func (*AnyValue_KvlistValue) isAnyValue_Value() {}
+
+// ArrayValue holds an array of AnyValue.
+type ArrayValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*AnyValue            `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArrayValue) Reset() {
+	*x = ArrayValue{}
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArrayValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArrayValue) ProtoMessage() {}
+
+func (x *ArrayValue) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArrayValue.ProtoReflect.Descriptor instead.
+func (*ArrayValue) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ArrayValue) GetValues() []*AnyValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// KeyValueList holds a list of KeyValue pairs.
+type KeyValueList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Values        []*KeyValue            `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyValueList) Reset() {
+	*x = KeyValueList{}
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyValueList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyValueList) ProtoMessage() {}
+
+func (x *KeyValueList) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_agents_v1beta1_agents_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyValueList.ProtoReflect.Descriptor instead.
+func (*KeyValueList) Descriptor() ([]byte, []int) {
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *KeyValueList) GetValues() []*KeyValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+var File_pkg_api_agents_v1beta1_agents_proto protoreflect.FileDescriptor
+
+const file_pkg_api_agents_v1beta1_agents_proto_rawDesc = "" +
+	"\n" +
+	"#pkg/api/agents/v1beta1/agents.proto\x12\x0eagents.v1beta1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x8f\x03\n" +
+	"\x11ListAgentsRequest\x12'\n" +
+	"\x0finclude_deleted\x18\x04 \x01(\bR\x0eincludeDeleted\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x127\n" +
+	"\asort_by\x18\a \x01(\x0e2\x1e.agents.v1beta1.AgentSortFieldR\x06sortBy\x12\x1e\n" +
+	"\n" +
+	"descending\x18\b \x01(\bR\n" +
+	"descending\x12[\n" +
+	"\x0elabel_selector\x18\n" +
+	" \x03(\v24.agents.v1beta1.ListAgentsRequest.LabelSelectorEntryR\rlabelSelector\x12\x1d\n" +
+	"\n" +
+	"config_ids\x18\v \x03(\tR\tconfigIds\x1a@\n" +
+	"\x12LabelSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xba\x01\n" +
+	"\x12ListAgentsResponse\x126\n" +
+	"\x06agents\x18\x01 \x03(\v2\x1e.agents.v1beta1.AgentListEntryR\x06agents\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\x12#\n" +
+	"\rmatched_count\x18\x05 \x01(\x05R\fmatchedCount\"=\n" +
+	"\x0eAgentListEntry\x12+\n" +
+	"\x05agent\x18\x01 \x01(\v2\x15.agents.v1beta1.AgentR\x05agent\",\n" +
+	"\x0fGetAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"?\n" +
+	"\x10GetAgentResponse\x12+\n" +
+	"\x05agent\x18\x01 \x01(\v2\x15.agents.v1beta1.AgentR\x05agent\"E\n" +
+	"\x12DeleteAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x14\n" +
+	"\x05purge\x18\x02 \x01(\bR\x05purge\"1\n" +
+	"\x14UndeleteAgentRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"\xc4\x02\n" +
+	"\x05Agent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rfriendly_name\x18\x02 \x01(\tR\ffriendlyName\x12O\n" +
+	"\x16identifying_attributes\x18\x03 \x03(\v2\x18.agents.v1beta1.KeyValueR\x15identifyingAttributes\x12V\n" +
+	"\x1anon_identifying_attributes\x18\x04 \x03(\v2\x18.agents.v1beta1.KeyValueR\x18nonIdentifyingAttributes\x12\"\n" +
+	"\fcapabilities\x18\x05 \x03(\tR\fcapabilities\x129\n" +
+	"\n" +
+	"deleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"L\n" +
+	"\bKeyValue\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12.\n" +
+	"\x05value\x18\x02 \x01(\v2\x18.agents.v1beta1.AnyValueR\x05value\"\xc2\x02\n" +
+	"\bAnyValue\x12#\n" +
+	"\fstring_value\x18\x01 \x01(\tH\x00R\vstringValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x02 \x01(\bH\x00R\tboolValue\x12\x1d\n" +
+	"\tint_value\x18\x03 \x01(\x03H\x00R\bintValue\x12#\n" +
+	"\fdouble_value\x18\x04 \x01(\x01H\x00R\vdoubleValue\x12!\n" +
+	"\vbytes_value\x18\x05 \x01(\fH\x00R\n" +
+	"bytesValue\x12=\n" +
+	"\varray_value\x18\x06 \x01(\v2\x1a.agents.v1beta1.ArrayValueH\x00R\n" +
+	"arrayValue\x12A\n" +
+	"\fkvlist_value\x18\a \x01(\v2\x1c.agents.v1beta1.KeyValueListH\x00R\vkvlistValueB\a\n" +
+	"\x05value\">\n" +
+	"\n" +
+	"ArrayValue\x120\n" +
+	"\x06values\x18\x01 \x03(\v2\x18.agents.v1beta1.AnyValueR\x06values\"@\n" +
+	"\fKeyValueList\x120\n" +
+	"\x06values\x18\x01 \x03(\v2\x18.agents.v1beta1.KeyValueR\x06values*\x89\x01\n" +
+	"\x0eAgentSortField\x12 \n" +
+	"\x1cAGENT_SORT_FIELD_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15AGENT_SORT_FIELD_NAME\x10\x01\x12\x1e\n" +
+	"\x1aAGENT_SORT_FIELD_LAST_SEEN\x10\x02\x12\x1a\n" +
+	"\x16AGENT_SORT_FIELD_STATE\x10\x032\xcc\x02\n" +
+	"\fAgentService\x12S\n" +
+	"\n" +
+	"ListAgents\x12!.agents.v1beta1.ListAgentsRequest\x1a\".agents.v1beta1.ListAgentsResponse\x12M\n" +
+	"\bGetAgent\x12\x1f.agents.v1beta1.GetAgentRequest\x1a .agents.v1beta1.GetAgentResponse\x12I\n" +
+	"\vDeleteAgent\x12\".agents.v1beta1.DeleteAgentRequest\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\rUndeleteAgent\x12$.agents.v1beta1.UndeleteAgentRequest\x1a\x16.google.protobuf.EmptyB7Z5github.com/otelfleet/otelfleet/pkg/api/agents/v1beta1b\x06proto3"
+
+var (
+	file_pkg_api_agents_v1beta1_agents_proto_rawDescOnce sync.Once
+	file_pkg_api_agents_v1beta1_agents_proto_rawDescData []byte
+)
+
+func file_pkg_api_agents_v1beta1_agents_proto_rawDescGZIP() []byte {
+	file_pkg_api_agents_v1beta1_agents_proto_rawDescOnce.Do(func() {
+		file_pkg_api_agents_v1beta1_agents_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_api_agents_v1beta1_agents_proto_rawDesc), len(file_pkg_api_agents_v1beta1_agents_proto_rawDesc)))
+	})
+	return file_pkg_api_agents_v1beta1_agents_proto_rawDescData
+}
+
+var file_pkg_api_agents_v1beta1_agents_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_api_agents_v1beta1_agents_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_pkg_api_agents_v1beta1_agents_proto_goTypes = []any{
+	(AgentSortField)(0),           // 0: agents.v1beta1.AgentSortField
+	(*ListAgentsRequest)(nil),     // 1: agents.v1beta1.ListAgentsRequest
+	(*ListAgentsResponse)(nil),    // 2: agents.v1beta1.ListAgentsResponse
+	(*AgentListEntry)(nil),        // 3: agents.v1beta1.AgentListEntry
+	(*GetAgentRequest)(nil),       // 4: agents.v1beta1.GetAgentRequest
+	(*GetAgentResponse)(nil),      // 5: agents.v1beta1.GetAgentResponse
+	(*DeleteAgentRequest)(nil),    // 6: agents.v1beta1.DeleteAgentRequest
+	(*UndeleteAgentRequest)(nil),  // 7: agents.v1beta1.UndeleteAgentRequest
+	(*Agent)(nil),                 // 8: agents.v1beta1.Agent
+	(*KeyValue)(nil),              // 9: agents.v1beta1.KeyValue
+	(*AnyValue)(nil),              // 10: agents.v1beta1.AnyValue
+	(*ArrayValue)(nil),            // 11: agents.v1beta1.ArrayValue
+	(*KeyValueList)(nil),          // 12: agents.v1beta1.KeyValueList
+	nil,                           // 13: agents.v1beta1.ListAgentsRequest.LabelSelectorEntry
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 15: google.protobuf.Empty
+}
+var file_pkg_api_agents_v1beta1_agents_proto_depIdxs = []int32{
+	0,  // 0: agents.v1beta1.ListAgentsRequest.sort_by:type_name -> agents.v1beta1.AgentSortField
+	13, // 1: agents.v1beta1.ListAgentsRequest.label_selector:type_name -> agents.v1beta1.ListAgentsRequest.LabelSelectorEntry
+	3,  // 2: agents.v1beta1.ListAgentsResponse.agents:type_name -> agents.v1beta1.AgentListEntry
+	8,  // 3: agents.v1beta1.AgentListEntry.agent:type_name -> agents.v1beta1.Agent
+	8,  // 4: agents.v1beta1.GetAgentResponse.agent:type_name -> agents.v1beta1.Agent
+	9,  // 5: agents.v1beta1.Agent.identifying_attributes:type_name -> agents.v1beta1.KeyValue
+	9,  // 6: agents.v1beta1.Agent.non_identifying_attributes:type_name -> agents.v1beta1.KeyValue
+	14, // 7: agents.v1beta1.Agent.deleted_at:type_name -> google.protobuf.Timestamp
+	10, // 8: agents.v1beta1.KeyValue.value:type_name -> agents.v1beta1.AnyValue
+	11, // 9: agents.v1beta1.AnyValue.array_value:type_name -> agents.v1beta1.ArrayValue
+	12, // 10: agents.v1beta1.AnyValue.kvlist_value:type_name -> agents.v1beta1.KeyValueList
+	10, // 11: agents.v1beta1.ArrayValue.values:type_name -> agents.v1beta1.AnyValue
+	9,  // 12: agents.v1beta1.KeyValueList.values:type_name -> agents.v1beta1.KeyValue
+	1,  // 13: agents.v1beta1.AgentService.ListAgents:input_type -> agents.v1beta1.ListAgentsRequest
+	4,  // 14: agents.v1beta1.AgentService.GetAgent:input_type -> agents.v1beta1.GetAgentRequest
+	6,  // 15: agents.v1beta1.AgentService.DeleteAgent:input_type -> agents.v1beta1.DeleteAgentRequest
+	7,  // 16: agents.v1beta1.AgentService.UndeleteAgent:input_type -> agents.v1beta1.UndeleteAgentRequest
+	2,  // 17: agents.v1beta1.AgentService.ListAgents:output_type -> agents.v1beta1.ListAgentsResponse
+	5,  // 18: agents.v1beta1.AgentService.GetAgent:output_type -> agents.v1beta1.GetAgentResponse
+	15, // 19: agents.v1beta1.AgentService.DeleteAgent:output_type -> google.protobuf.Empty
+	15, // 20: agents.v1beta1.AgentService.UndeleteAgent:output_type -> google.protobuf.Empty
+	17, // [17:21] is the sub-list for method output_type
+	13, // [13:17] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_agents_v1beta1_agents_proto_init() }
+func file_pkg_api_agents_v1beta1_agents_proto_init() {
+	if File_pkg_api_agents_v1beta1_agents_proto != nil {
+		return
+	}
+	file_pkg_api_agents_v1beta1_agents_proto_msgTypes[9].OneofWrappers = []any{
+		(*AnyValue_StringValue)(nil),
+		(*AnyValue_BoolValue)(nil),
+		(*AnyValue_IntValue)(nil),
+		(*AnyValue_DoubleValue)(nil),
+		(*AnyValue_BytesValue)(nil),
+		(*AnyValue_ArrayValue)(nil),
+		(*AnyValue_KvlistValue)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_agents_v1beta1_agents_proto_rawDesc), len(file_pkg_api_agents_v1beta1_agents_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_agents_v1beta1_agents_proto_goTypes,
+		DependencyIndexes: file_pkg_api_agents_v1beta1_agents_proto_depIdxs,
+		EnumInfos:         file_pkg_api_agents_v1beta1_agents_proto_enumTypes,
+		MessageInfos:      file_pkg_api_agents_v1beta1_agents_proto_msgTypes,
+	}.Build()
+	File_pkg_api_agents_v1beta1_agents_proto = out.File
+	file_pkg_api_agents_v1beta1_agents_proto_goTypes = nil
+	file_pkg_api_agents_v1beta1_agents_proto_depIdxs = nil
+}
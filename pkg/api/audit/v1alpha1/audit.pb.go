@@ -0,0 +1,530 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: pkg/api/audit/v1alpha1/audit.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// AuditEntry records a call to a management API mutation, whether it succeeded or not
+type AuditEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// monotonically increasing sequence number
+	Sequence uint64                 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Time     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// authenticated principal that made the call, empty for anonymous callers
+	Principal string `protobuf:"bytes,3,opt,name=principal,proto3" json:"principal,omitempty"`
+	// full name of the procedure called, e.g. /config.v1alpha1.ConfigService/PutConfig
+	Procedure string `protobuf:"bytes,4,opt,name=procedure,proto3" json:"procedure,omitempty"`
+	// snake cased type of the resource the procedure changes, e.g. "config" or "agent"
+	ResourceType string `protobuf:"bytes,5,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	// ID of the resource, if the request names one
+	ResourceId string `protobuf:"bytes,6,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	// ID of the API request, see the X-Request-ID header
+	RequestId string `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// address of the caller
+	PeerAddr string `protobuf:"bytes,8,opt,name=peer_addr,json=peerAddr,proto3" json:"peer_addr,omitempty"`
+	// connect code of the failure, e.g. "permission_denied", empty if the call succeeded
+	Code string `protobuf:"bytes,9,opt,name=code,proto3" json:"code,omitempty"`
+	// error message of the failure
+	Error string `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`
+	// config changed by config-affecting operations, such as PutConfig or AssignConfig
+	ConfigChange  *ConfigChange `protobuf:"bytes,11,opt,name=config_change,json=configChange,proto3" json:"config_change,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_pkg_api_audit_v1alpha1_audit_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_audit_v1alpha1_audit_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_pkg_api_audit_v1alpha1_audit_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AuditEntry) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetProcedure() string {
+	if x != nil {
+		return x.Procedure
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPeerAddr() string {
+	if x != nil {
+		return x.PeerAddr
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetConfigChange() *ConfigChange {
+	if x != nil {
+		return x.ConfigChange
+	}
+	return nil
+}
+
+// ConfigChange describes the change of a config made by an operation
+type ConfigChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// what the config is of, e.g. "config/logs", "agent/agent-1" or "default_config"
+	Subject string `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// hex encoded SHA-256 of the config before and after the operation, empty if there was none
+	OldHash string `protobuf:"bytes,2,opt,name=old_hash,json=oldHash,proto3" json:"old_hash,omitempty"`
+	NewHash string `protobuf:"bytes,3,opt,name=new_hash,json=newHash,proto3" json:"new_hash,omitempty"`
+	// unified diff of the config, with secret references redacted. It is empty for rollouts,
+	// whose previous config varies per agent.
+	Diff string `protobuf:"bytes,4,opt,name=diff,proto3" json:"diff,omitempty"`
+	// the diff was truncated to keep entries compact
+	DiffTruncated bool `protobuf:"varint,5,opt,name=diff_truncated,json=diffTruncated,proto3" json:"diff_truncated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigChange) Reset() {
+	*x = ConfigChange{}
+	mi := &file_pkg_api_audit_v1alpha1_audit_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigChange) ProtoMessage() {}
+
+func (x *ConfigChange) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_audit_v1alpha1_audit_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigChange.ProtoReflect.Descriptor instead.
+func (*ConfigChange) Descriptor() ([]byte, []int) {
+	return file_pkg_api_audit_v1alpha1_audit_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConfigChange) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetOldHash() string {
+	if x != nil {
+		return x.OldHash
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetNewHash() string {
+	if x != nil {
+		return x.NewHash
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+func (x *ConfigChange) GetDiffTruncated() bool {
+	if x != nil {
+		return x.DiffTruncated
+	}
+	return false
+}
+
+type EntryFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// inclusive lower bound on the entry time
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	// exclusive upper bound on the entry time
+	End           *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end,proto3" json:"end,omitempty"`
+	ResourceTypes []string               `protobuf:"bytes,3,rep,name=resource_types,json=resourceTypes,proto3" json:"resource_types,omitempty"`
+	Principals    []string               `protobuf:"bytes,4,rep,name=principals,proto3" json:"principals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntryFilter) Reset() {
+	*x = EntryFilter{}
+	mi := &file_pkg_api_audit_v1alpha1_audit_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntryFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntryFilter) ProtoMessage() {}
+
+func (x *EntryFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_audit_v1alpha1_audit_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntryFilter.ProtoReflect.Descriptor instead.
+func (*EntryFilter) Descriptor() ([]byte, []int) {
+	return file_pkg_api_audit_v1alpha1_audit_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EntryFilter) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *EntryFilter) GetEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.End
+	}
+	return nil
+}
+
+func (x *EntryFilter) GetResourceTypes() []string {
+	if x != nil {
+		return x.ResourceTypes
+	}
+	return nil
+}
+
+func (x *EntryFilter) GetPrincipals() []string {
+	if x != nil {
+		return x.Principals
+	}
+	return nil
+}
+
+type ListEntriesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *EntryFilter           `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// maximum number of entries to return, 0 for the server default
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_page_token from a previous response
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntriesRequest) Reset() {
+	*x = ListEntriesRequest{}
+	mi := &file_pkg_api_audit_v1alpha1_audit_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntriesRequest) ProtoMessage() {}
+
+func (x *ListEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_audit_v1alpha1_audit_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_audit_v1alpha1_audit_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListEntriesRequest) GetFilter() *EntryFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListEntriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListEntriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntriesResponse) Reset() {
+	*x = ListEntriesResponse{}
+	mi := &file_pkg_api_audit_v1alpha1_audit_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntriesResponse) ProtoMessage() {}
+
+func (x *ListEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_audit_v1alpha1_audit_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_audit_v1alpha1_audit_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListEntriesResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListEntriesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+var File_pkg_api_audit_v1alpha1_audit_proto protoreflect.FileDescriptor
+
+const file_pkg_api_audit_v1alpha1_audit_proto_rawDesc = "" +
+	"\n" +
+	"\"pkg/api/audit/v1alpha1/audit.proto\x12\x0eaudit.v1alpha1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x83\x03\n" +
+	"\n" +
+	"AuditEntry\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x04R\bsequence\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1c\n" +
+	"\tprincipal\x18\x03 \x01(\tR\tprincipal\x12\x1c\n" +
+	"\tprocedure\x18\x04 \x01(\tR\tprocedure\x12#\n" +
+	"\rresource_type\x18\x05 \x01(\tR\fresourceType\x12\x1f\n" +
+	"\vresource_id\x18\x06 \x01(\tR\n" +
+	"resourceId\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tR\trequestId\x12\x1b\n" +
+	"\tpeer_addr\x18\b \x01(\tR\bpeerAddr\x12\x12\n" +
+	"\x04code\x18\t \x01(\tR\x04code\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\x12A\n" +
+	"\rconfig_change\x18\v \x01(\v2\x1c.audit.v1alpha1.ConfigChangeR\fconfigChange\"\x99\x01\n" +
+	"\fConfigChange\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x19\n" +
+	"\bold_hash\x18\x02 \x01(\tR\aoldHash\x12\x19\n" +
+	"\bnew_hash\x18\x03 \x01(\tR\anewHash\x12\x12\n" +
+	"\x04diff\x18\x04 \x01(\tR\x04diff\x12%\n" +
+	"\x0ediff_truncated\x18\x05 \x01(\bR\rdiffTruncated\"\xb4\x01\n" +
+	"\vEntryFilter\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12,\n" +
+	"\x03end\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x03end\x12%\n" +
+	"\x0eresource_types\x18\x03 \x03(\tR\rresourceTypes\x12\x1e\n" +
+	"\n" +
+	"principals\x18\x04 \x03(\tR\n" +
+	"principals\"~\n" +
+	"\x12ListEntriesRequest\x123\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1b.audit.v1alpha1.EntryFilterR\x06filter\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"s\n" +
+	"\x13ListEntriesResponse\x124\n" +
+	"\aentries\x18\x01 \x03(\v2\x1a.audit.v1alpha1.AuditEntryR\aentries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2f\n" +
+	"\fAuditService\x12V\n" +
+	"\vListEntries\x12\".audit.v1alpha1.ListEntriesRequest\x1a#.audit.v1alpha1.ListEntriesResponseB7Z5github.com/otelfleet/otelfleet/pkg/api/audit/v1alpha1b\x06proto3"
+
+var (
+	file_pkg_api_audit_v1alpha1_audit_proto_rawDescOnce sync.Once
+	file_pkg_api_audit_v1alpha1_audit_proto_rawDescData []byte
+)
+
+func file_pkg_api_audit_v1alpha1_audit_proto_rawDescGZIP() []byte {
+	file_pkg_api_audit_v1alpha1_audit_proto_rawDescOnce.Do(func() {
+		file_pkg_api_audit_v1alpha1_audit_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_api_audit_v1alpha1_audit_proto_rawDesc), len(file_pkg_api_audit_v1alpha1_audit_proto_rawDesc)))
+	})
+	return file_pkg_api_audit_v1alpha1_audit_proto_rawDescData
+}
+
+var file_pkg_api_audit_v1alpha1_audit_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_pkg_api_audit_v1alpha1_audit_proto_goTypes = []any{
+	(*AuditEntry)(nil),            // 0: audit.v1alpha1.AuditEntry
+	(*ConfigChange)(nil),          // 1: audit.v1alpha1.ConfigChange
+	(*EntryFilter)(nil),           // 2: audit.v1alpha1.EntryFilter
+	(*ListEntriesRequest)(nil),    // 3: audit.v1alpha1.ListEntriesRequest
+	(*ListEntriesResponse)(nil),   // 4: audit.v1alpha1.ListEntriesResponse
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_pkg_api_audit_v1alpha1_audit_proto_depIdxs = []int32{
+	5, // 0: audit.v1alpha1.AuditEntry.time:type_name -> google.protobuf.Timestamp
+	1, // 1: audit.v1alpha1.AuditEntry.config_change:type_name -> audit.v1alpha1.ConfigChange
+	5, // 2: audit.v1alpha1.EntryFilter.start:type_name -> google.protobuf.Timestamp
+	5, // 3: audit.v1alpha1.EntryFilter.end:type_name -> google.protobuf.Timestamp
+	2, // 4: audit.v1alpha1.ListEntriesRequest.filter:type_name -> audit.v1alpha1.EntryFilter
+	0, // 5: audit.v1alpha1.ListEntriesResponse.entries:type_name -> audit.v1alpha1.AuditEntry
+	3, // 6: audit.v1alpha1.AuditService.ListEntries:input_type -> audit.v1alpha1.ListEntriesRequest
+	4, // 7: audit.v1alpha1.AuditService.ListEntries:output_type -> audit.v1alpha1.ListEntriesResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_audit_v1alpha1_audit_proto_init() }
+func file_pkg_api_audit_v1alpha1_audit_proto_init() {
+	if File_pkg_api_audit_v1alpha1_audit_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_audit_v1alpha1_audit_proto_rawDesc), len(file_pkg_api_audit_v1alpha1_audit_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_audit_v1alpha1_audit_proto_goTypes,
+		DependencyIndexes: file_pkg_api_audit_v1alpha1_audit_proto_depIdxs,
+		MessageInfos:      file_pkg_api_audit_v1alpha1_audit_proto_msgTypes,
+	}.Build()
+	File_pkg_api_audit_v1alpha1_audit_proto = out.File
+	file_pkg_api_audit_v1alpha1_audit_proto_goTypes = nil
+	file_pkg_api_audit_v1alpha1_audit_proto_depIdxs = nil
+}
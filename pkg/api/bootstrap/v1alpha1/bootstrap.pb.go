@@ -25,6 +25,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type InstallPlatform int32
+
+const (
+	InstallPlatform_INSTALL_PLATFORM_UNSPECIFIED InstallPlatform = 0
+	InstallPlatform_INSTALL_PLATFORM_SHELL       InstallPlatform = 1
+	InstallPlatform_INSTALL_PLATFORM_CLOUD_INIT  InstallPlatform = 2
+	InstallPlatform_INSTALL_PLATFORM_POWERSHELL  InstallPlatform = 3
+)
+
+// Enum value maps for InstallPlatform.
+var (
+	InstallPlatform_name = map[int32]string{
+		0: "INSTALL_PLATFORM_UNSPECIFIED",
+		1: "INSTALL_PLATFORM_SHELL",
+		2: "INSTALL_PLATFORM_CLOUD_INIT",
+		3: "INSTALL_PLATFORM_POWERSHELL",
+	}
+	InstallPlatform_value = map[string]int32{
+		"INSTALL_PLATFORM_UNSPECIFIED": 0,
+		"INSTALL_PLATFORM_SHELL":       1,
+		"INSTALL_PLATFORM_CLOUD_INIT":  2,
+		"INSTALL_PLATFORM_POWERSHELL":  3,
+	}
+)
+
+func (x InstallPlatform) Enum() *InstallPlatform {
+	p := new(InstallPlatform)
+	*p = x
+	return p
+}
+
+func (x InstallPlatform) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InstallPlatform) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_enumTypes[0].Descriptor()
+}
+
+func (InstallPlatform) Type() protoreflect.EnumType {
+	return &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_enumTypes[0]
+}
+
+func (x InstallPlatform) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InstallPlatform.Descriptor instead.
+func (InstallPlatform) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{0}
+}
+
 type GetConfigRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TokenID       string                 `protobuf:"bytes,1,opt,name=tokenID,proto3" json:"tokenID,omitempty"`
@@ -43,10 +95,640 @@ func (x *GetConfigRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConfigRequest) ProtoMessage() {}
+func (*GetConfigRequest) ProtoMessage() {}
+
+func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetConfigRequest) GetTokenID() string {
+	if x != nil {
+		return x.TokenID
+	}
+	return ""
+}
+
+type GetConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *v1alpha1.Config       `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigResponse) Reset() {
+	*x = GetConfigResponse{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigResponse) ProtoMessage() {}
+
+func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetConfigResponse) GetConfig() *v1alpha1.Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type CheckTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckTokenRequest) Reset() {
+	*x = CheckTokenRequest{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckTokenRequest) ProtoMessage() {}
+
+func (x *CheckTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckTokenRequest.ProtoReflect.Descriptor instead.
+func (*CheckTokenRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{2}
+}
+
+type CheckTokenResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Valid bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// why agents can't bootstrap with the token, for invalid tokens
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// the remaining fields are only set for valid tokens
+	TokenID string `protobuf:"bytes,3,opt,name=tokenID,proto3" json:"tokenID,omitempty"`
+	// bootstraps left before the token is consumed, unset for tokens usable until they expire
+	RemainingUses   *int32                 `protobuf:"varint,4,opt,name=remainingUses,proto3,oneof" json:"remainingUses,omitempty"`
+	Expiry          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	RemainingTTL    *durationpb.Duration   `protobuf:"bytes,6,opt,name=remainingTTL,proto3" json:"remainingTTL,omitempty"`
+	ConfigReference *string                `protobuf:"bytes,7,opt,name=configReference,proto3,oneof" json:"configReference,omitempty"`
+	Labels          map[string]string      `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CheckTokenResponse) Reset() {
+	*x = CheckTokenResponse{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckTokenResponse) ProtoMessage() {}
+
+func (x *CheckTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckTokenResponse.ProtoReflect.Descriptor instead.
+func (*CheckTokenResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckTokenResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *CheckTokenResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CheckTokenResponse) GetTokenID() string {
+	if x != nil {
+		return x.TokenID
+	}
+	return ""
+}
+
+func (x *CheckTokenResponse) GetRemainingUses() int32 {
+	if x != nil && x.RemainingUses != nil {
+		return *x.RemainingUses
+	}
+	return 0
+}
+
+func (x *CheckTokenResponse) GetExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+func (x *CheckTokenResponse) GetRemainingTTL() *durationpb.Duration {
+	if x != nil {
+		return x.RemainingTTL
+	}
+	return nil
+}
+
+func (x *CheckTokenResponse) GetConfigReference() string {
+	if x != nil && x.ConfigReference != nil {
+		return *x.ConfigReference
+	}
+	return ""
+}
+
+func (x *CheckTokenResponse) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type BootstrapAuthRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ClientId     string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ClientPubKey []byte                 `protobuf:"bytes,3,opt,name=clientPubKey,proto3" json:"clientPubKey,omitempty"`
+	// optional DER encoded certificate request of the key the agent authenticates its
+	// OpAMP connection with, signed by the agent CA if the server has one
+	CertificateRequest []byte `protobuf:"bytes,4,opt,name=certificateRequest,proto3" json:"certificateRequest,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BootstrapAuthRequest) Reset() {
+	*x = BootstrapAuthRequest{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapAuthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapAuthRequest) ProtoMessage() {}
+
+func (x *BootstrapAuthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapAuthRequest.ProtoReflect.Descriptor instead.
+func (*BootstrapAuthRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BootstrapAuthRequest) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *BootstrapAuthRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BootstrapAuthRequest) GetClientPubKey() []byte {
+	if x != nil {
+		return x.ClientPubKey
+	}
+	return nil
+}
+
+func (x *BootstrapAuthRequest) GetCertificateRequest() []byte {
+	if x != nil {
+		return x.CertificateRequest
+	}
+	return nil
+}
+
+type BootstrapAuthResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ServerPubKey []byte                 `protobuf:"bytes,1,opt,name=serverPubKey,proto3" json:"serverPubKey,omitempty"`
+	// BootstrapCredentials sealed with the shared secret of the key agreement, set if a
+	// client certificate was issued
+	Credentials   []byte `protobuf:"bytes,2,opt,name=credentials,proto3" json:"credentials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BootstrapAuthResponse) Reset() {
+	*x = BootstrapAuthResponse{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapAuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapAuthResponse) ProtoMessage() {}
+
+func (x *BootstrapAuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapAuthResponse.ProtoReflect.Descriptor instead.
+func (*BootstrapAuthResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BootstrapAuthResponse) GetServerPubKey() []byte {
+	if x != nil {
+		return x.ServerPubKey
+	}
+	return nil
+}
+
+func (x *BootstrapAuthResponse) GetCredentials() []byte {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+type BootstrapCredentials struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// DER encoded client certificate issued to the agent
+	Certificate []byte `protobuf:"bytes,1,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// DER encoded certificate of the agent CA
+	CaCertificate []byte `protobuf:"bytes,2,opt,name=caCertificate,proto3" json:"caCertificate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BootstrapCredentials) Reset() {
+	*x = BootstrapCredentials{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapCredentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapCredentials) ProtoMessage() {}
+
+func (x *BootstrapCredentials) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapCredentials.ProtoReflect.Descriptor instead.
+func (*BootstrapCredentials) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BootstrapCredentials) GetCertificate() []byte {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+func (x *BootstrapCredentials) GetCaCertificate() []byte {
+	if x != nil {
+		return x.CaCertificate
+	}
+	return nil
+}
+
+type EnrollRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// optional friendly name, defaults to the SPIFFE ID of the agent
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ClientPubKey  []byte `protobuf:"bytes,2,opt,name=clientPubKey,proto3" json:"clientPubKey,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollRequest) Reset() {
+	*x = EnrollRequest{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollRequest) ProtoMessage() {}
+
+func (x *EnrollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollRequest.ProtoReflect.Descriptor instead.
+func (*EnrollRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EnrollRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EnrollRequest) GetClientPubKey() []byte {
+	if x != nil {
+		return x.ClientPubKey
+	}
+	return nil
+}
+
+type EnrollResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// agent ID derived from the SPIFFE ID of the presented SVID
+	AgentId       string `protobuf:"bytes,1,opt,name=agentId,proto3" json:"agentId,omitempty"`
+	SpiffeId      string `protobuf:"bytes,2,opt,name=spiffeId,proto3" json:"spiffeId,omitempty"`
+	ServerPubKey  []byte `protobuf:"bytes,3,opt,name=serverPubKey,proto3" json:"serverPubKey,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollResponse) Reset() {
+	*x = EnrollResponse{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollResponse) ProtoMessage() {}
+
+func (x *EnrollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollResponse.ProtoReflect.Descriptor instead.
+func (*EnrollResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *EnrollResponse) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *EnrollResponse) GetSpiffeId() string {
+	if x != nil {
+		return x.SpiffeId
+	}
+	return ""
+}
+
+func (x *EnrollResponse) GetServerPubKey() []byte {
+	if x != nil {
+		return x.ServerPubKey
+	}
+	return nil
+}
+
+type BootstrapToken struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	ID     string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Secret string                 `protobuf:"bytes,2,opt,name=Secret,proto3" json:"Secret,omitempty"`
+	TTL    *durationpb.Duration   `protobuf:"bytes,3,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	Expiry *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=Expiry,proto3,oneof" json:"Expiry,omitempty"`
+	// TODO: eventually this will be insufficient, should refactor to a message ConfigReference in config.proto
+	ConfigReference *string             `protobuf:"bytes,5,opt,name=configReference,proto3,oneof" json:"configReference,omitempty"`
+	Labels          map[string]string   `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Audit           *v1alpha1.AuditInfo `protobuf:"bytes,7,opt,name=audit,proto3" json:"audit,omitempty"`
+	// single-use tokens are deleted once an agent bootstrapped with them
+	SingleUse bool `protobuf:"varint,8,opt,name=singleUse,proto3" json:"singleUse,omitempty"`
+	// tenant the token was created in, agents bootstrapped with it join it
+	Tenant        string `protobuf:"bytes,9,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BootstrapToken) Reset() {
+	*x = BootstrapToken{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapToken) ProtoMessage() {}
+
+func (x *BootstrapToken) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapToken.ProtoReflect.Descriptor instead.
+func (*BootstrapToken) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BootstrapToken) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *BootstrapToken) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *BootstrapToken) GetTTL() *durationpb.Duration {
+	if x != nil {
+		return x.TTL
+	}
+	return nil
+}
+
+func (x *BootstrapToken) GetExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+func (x *BootstrapToken) GetConfigReference() string {
+	if x != nil && x.ConfigReference != nil {
+		return *x.ConfigReference
+	}
+	return ""
+}
+
+func (x *BootstrapToken) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *BootstrapToken) GetAudit() *v1alpha1.AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+func (x *BootstrapToken) GetSingleUse() bool {
+	if x != nil {
+		return x.SingleUse
+	}
+	return false
+}
+
+func (x *BootstrapToken) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type ListTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *v1alpha1.AuditFilter  `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokensRequest) Reset() {
+	*x = ListTokensRequest{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokensRequest) ProtoMessage() {}
 
-func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[0]
+func (x *ListTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,40 +739,40 @@ func (x *GetConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConfigRequest.ProtoReflect.Descriptor instead.
-func (*GetConfigRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use ListTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListTokensRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *GetConfigRequest) GetTokenID() string {
+func (x *ListTokensRequest) GetFilter() *v1alpha1.AuditFilter {
 	if x != nil {
-		return x.TokenID
+		return x.Filter
 	}
-	return ""
+	return nil
 }
 
-type GetConfigResponse struct {
+type ListTokenReponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Config        *v1alpha1.Config       `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Tokens        []*BootstrapToken      `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConfigResponse) Reset() {
-	*x = GetConfigResponse{}
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[1]
+func (x *ListTokenReponse) Reset() {
+	*x = ListTokenReponse{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConfigResponse) String() string {
+func (x *ListTokenReponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConfigResponse) ProtoMessage() {}
+func (*ListTokenReponse) ProtoMessage() {}
 
-func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[1]
+func (x *ListTokenReponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -101,42 +783,42 @@ func (x *GetConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConfigResponse.ProtoReflect.Descriptor instead.
-func (*GetConfigResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ListTokenReponse.ProtoReflect.Descriptor instead.
+func (*ListTokenReponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetConfigResponse) GetConfig() *v1alpha1.Config {
+func (x *ListTokenReponse) GetTokens() []*BootstrapToken {
 	if x != nil {
-		return x.Config
+		return x.Tokens
 	}
 	return nil
 }
 
-type BootstrapAuthRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ClientId      string                 `protobuf:"bytes,1,opt,name=clientId,proto3" json:"clientId,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ClientPubKey  []byte                 `protobuf:"bytes,3,opt,name=clientPubKey,proto3" json:"clientPubKey,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type CreateTokenRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TTL             *durationpb.Duration   `protobuf:"bytes,1,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	ConfigReference *string                `protobuf:"bytes,2,opt,name=configReference,proto3,oneof" json:"configReference,omitempty"`
+	Labels          map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *BootstrapAuthRequest) Reset() {
-	*x = BootstrapAuthRequest{}
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[2]
+func (x *CreateTokenRequest) Reset() {
+	*x = CreateTokenRequest{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BootstrapAuthRequest) String() string {
+func (x *CreateTokenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BootstrapAuthRequest) ProtoMessage() {}
+func (*CreateTokenRequest) ProtoMessage() {}
 
-func (x *BootstrapAuthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[2]
+func (x *CreateTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -147,54 +829,59 @@ func (x *BootstrapAuthRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BootstrapAuthRequest.ProtoReflect.Descriptor instead.
-func (*BootstrapAuthRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use CreateTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateTokenRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *BootstrapAuthRequest) GetClientId() string {
+func (x *CreateTokenRequest) GetTTL() *durationpb.Duration {
 	if x != nil {
-		return x.ClientId
+		return x.TTL
 	}
-	return ""
+	return nil
 }
 
-func (x *BootstrapAuthRequest) GetName() string {
-	if x != nil {
-		return x.Name
+func (x *CreateTokenRequest) GetConfigReference() string {
+	if x != nil && x.ConfigReference != nil {
+		return *x.ConfigReference
 	}
 	return ""
 }
 
-func (x *BootstrapAuthRequest) GetClientPubKey() []byte {
+func (x *CreateTokenRequest) GetLabels() map[string]string {
 	if x != nil {
-		return x.ClientPubKey
+		return x.Labels
 	}
 	return nil
 }
 
-type BootstrapAuthResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServerPubKey  []byte                 `protobuf:"bytes,1,opt,name=serverPubKey,proto3" json:"serverPubKey,omitempty"`
+type CreateEnrollmentURLRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// how long the URL is valid, defaults to an hour
+	TTL             *durationpb.Duration `protobuf:"bytes,1,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	ConfigReference *string              `protobuf:"bytes,2,opt,name=configReference,proto3,oneof" json:"configReference,omitempty"`
+	Labels          map[string]string    `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// base URL of the server agents bootstrap against, defaults to the server's external URL
+	BaseURL       string `protobuf:"bytes,4,opt,name=baseURL,proto3" json:"baseURL,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BootstrapAuthResponse) Reset() {
-	*x = BootstrapAuthResponse{}
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[3]
+func (x *CreateEnrollmentURLRequest) Reset() {
+	*x = CreateEnrollmentURLRequest{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BootstrapAuthResponse) String() string {
+func (x *CreateEnrollmentURLRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BootstrapAuthResponse) ProtoMessage() {}
+func (*CreateEnrollmentURLRequest) ProtoMessage() {}
 
-func (x *BootstrapAuthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[3]
+func (x *CreateEnrollmentURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -205,46 +892,63 @@ func (x *BootstrapAuthResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BootstrapAuthResponse.ProtoReflect.Descriptor instead.
-func (*BootstrapAuthResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use CreateEnrollmentURLRequest.ProtoReflect.Descriptor instead.
+func (*CreateEnrollmentURLRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *BootstrapAuthResponse) GetServerPubKey() []byte {
+func (x *CreateEnrollmentURLRequest) GetTTL() *durationpb.Duration {
 	if x != nil {
-		return x.ServerPubKey
+		return x.TTL
 	}
 	return nil
 }
 
-type BootstrapToken struct {
-	state  protoimpl.MessageState `protogen:"open.v1"`
-	ID     string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Secret string                 `protobuf:"bytes,2,opt,name=Secret,proto3" json:"Secret,omitempty"`
-	TTL    *durationpb.Duration   `protobuf:"bytes,3,opt,name=TTL,proto3" json:"TTL,omitempty"`
-	Expiry *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=Expiry,proto3,oneof" json:"Expiry,omitempty"`
-	// TODO: eventually this will be insufficient, should refactor to a message ConfigReference in config.proto
-	ConfigReference *string           `protobuf:"bytes,5,opt,name=configReference,proto3,oneof" json:"configReference,omitempty"`
-	Labels          map[string]string `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *CreateEnrollmentURLRequest) GetConfigReference() string {
+	if x != nil && x.ConfigReference != nil {
+		return *x.ConfigReference
+	}
+	return ""
 }
 
-func (x *BootstrapToken) Reset() {
-	*x = BootstrapToken{}
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[4]
+func (x *CreateEnrollmentURLRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateEnrollmentURLRequest) GetBaseURL() string {
+	if x != nil {
+		return x.BaseURL
+	}
+	return ""
+}
+
+type EnrollmentURL struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	TokenID       string                 `protobuf:"bytes,2,opt,name=tokenID,proto3" json:"tokenID,omitempty"`
+	Expiry        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollmentURL) Reset() {
+	*x = EnrollmentURL{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BootstrapToken) String() string {
+func (x *EnrollmentURL) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BootstrapToken) ProtoMessage() {}
+func (*EnrollmentURL) ProtoMessage() {}
 
-func (x *BootstrapToken) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[4]
+func (x *EnrollmentURL) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -255,75 +959,61 @@ func (x *BootstrapToken) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BootstrapToken.ProtoReflect.Descriptor instead.
-func (*BootstrapToken) Descriptor() ([]byte, []int) {
-	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use EnrollmentURL.ProtoReflect.Descriptor instead.
+func (*EnrollmentURL) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *BootstrapToken) GetID() string {
+func (x *EnrollmentURL) GetUrl() string {
 	if x != nil {
-		return x.ID
+		return x.Url
 	}
 	return ""
 }
 
-func (x *BootstrapToken) GetSecret() string {
+func (x *EnrollmentURL) GetTokenID() string {
 	if x != nil {
-		return x.Secret
+		return x.TokenID
 	}
 	return ""
 }
 
-func (x *BootstrapToken) GetTTL() *durationpb.Duration {
-	if x != nil {
-		return x.TTL
-	}
-	return nil
-}
-
-func (x *BootstrapToken) GetExpiry() *timestamppb.Timestamp {
+func (x *EnrollmentURL) GetExpiry() *timestamppb.Timestamp {
 	if x != nil {
 		return x.Expiry
 	}
 	return nil
 }
 
-func (x *BootstrapToken) GetConfigReference() string {
-	if x != nil && x.ConfigReference != nil {
-		return *x.ConfigReference
-	}
-	return ""
-}
-
-func (x *BootstrapToken) GetLabels() map[string]string {
-	if x != nil {
-		return x.Labels
-	}
-	return nil
-}
-
-type ListTokenReponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tokens        []*BootstrapToken      `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+type GenerateInstallScriptRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TokenID  string                 `protobuf:"bytes,1,opt,name=tokenID,proto3" json:"tokenID,omitempty"`
+	Platform InstallPlatform        `protobuf:"varint,2,opt,name=platform,proto3,enum=bootstrap.v1alpha1.InstallPlatform" json:"platform,omitempty"`
+	// base URL of the server agents bootstrap against, defaults to the server's external URL
+	BaseURL string `protobuf:"bytes,3,opt,name=baseURL,proto3" json:"baseURL,omitempty"`
+	// friendly name of the agent, defaults to the host name
+	AgentName string `protobuf:"bytes,4,opt,name=agentName,proto3" json:"agentName,omitempty"`
+	// additional agent settings, e.g. WATCHDOG_THRESHOLD or HEALTHZ_ADDR
+	Settings      map[string]string `protobuf:"bytes,5,rep,name=settings,proto3" json:"settings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTokenReponse) Reset() {
-	*x = ListTokenReponse{}
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[5]
+func (x *GenerateInstallScriptRequest) Reset() {
+	*x = GenerateInstallScriptRequest{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTokenReponse) String() string {
+func (x *GenerateInstallScriptRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTokenReponse) ProtoMessage() {}
+func (*GenerateInstallScriptRequest) ProtoMessage() {}
 
-func (x *ListTokenReponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[5]
+func (x *GenerateInstallScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -334,42 +1024,72 @@ func (x *ListTokenReponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTokenReponse.ProtoReflect.Descriptor instead.
-func (*ListTokenReponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use GenerateInstallScriptRequest.ProtoReflect.Descriptor instead.
+func (*GenerateInstallScriptRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListTokenReponse) GetTokens() []*BootstrapToken {
+func (x *GenerateInstallScriptRequest) GetTokenID() string {
 	if x != nil {
-		return x.Tokens
+		return x.TokenID
+	}
+	return ""
+}
+
+func (x *GenerateInstallScriptRequest) GetPlatform() InstallPlatform {
+	if x != nil {
+		return x.Platform
+	}
+	return InstallPlatform_INSTALL_PLATFORM_UNSPECIFIED
+}
+
+func (x *GenerateInstallScriptRequest) GetBaseURL() string {
+	if x != nil {
+		return x.BaseURL
+	}
+	return ""
+}
+
+func (x *GenerateInstallScriptRequest) GetAgentName() string {
+	if x != nil {
+		return x.AgentName
+	}
+	return ""
+}
+
+func (x *GenerateInstallScriptRequest) GetSettings() map[string]string {
+	if x != nil {
+		return x.Settings
 	}
 	return nil
 }
 
-type CreateTokenRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	TTL             *durationpb.Duration   `protobuf:"bytes,1,opt,name=TTL,proto3" json:"TTL,omitempty"`
-	ConfigReference *string                `protobuf:"bytes,2,opt,name=configReference,proto3,oneof" json:"configReference,omitempty"`
-	Labels          map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type InstallScript struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Script string                 `protobuf:"bytes,1,opt,name=script,proto3" json:"script,omitempty"`
+	// suggested file name of the script, e.g. install-agent.sh
+	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// the script stops working once the token expires
+	Expiry        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTokenRequest) Reset() {
-	*x = CreateTokenRequest{}
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[6]
+func (x *InstallScript) Reset() {
+	*x = InstallScript{}
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTokenRequest) String() string {
+func (x *InstallScript) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTokenRequest) ProtoMessage() {}
+func (*InstallScript) ProtoMessage() {}
 
-func (x *CreateTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[6]
+func (x *InstallScript) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -380,42 +1100,47 @@ func (x *CreateTokenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTokenRequest.ProtoReflect.Descriptor instead.
-func (*CreateTokenRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use InstallScript.ProtoReflect.Descriptor instead.
+func (*InstallScript) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *CreateTokenRequest) GetTTL() *durationpb.Duration {
+func (x *InstallScript) GetScript() string {
 	if x != nil {
-		return x.TTL
+		return x.Script
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateTokenRequest) GetConfigReference() string {
-	if x != nil && x.ConfigReference != nil {
-		return *x.ConfigReference
+func (x *InstallScript) GetFilename() string {
+	if x != nil {
+		return x.Filename
 	}
 	return ""
 }
 
-func (x *CreateTokenRequest) GetLabels() map[string]string {
+func (x *InstallScript) GetExpiry() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Labels
+		return x.Expiry
 	}
 	return nil
 }
 
 type DeleteTokenRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	ID    string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	// delete the token even if it was recently used by bootstrap attempts
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// optionally wait up to this long for recent bootstrap attempts to settle
+	// before deciding whether the token can be deleted
+	Wait          *durationpb.Duration `protobuf:"bytes,3,opt,name=wait,proto3" json:"wait,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeleteTokenRequest) Reset() {
 	*x = DeleteTokenRequest{}
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[7]
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -427,7 +1152,7 @@ func (x *DeleteTokenRequest) String() string {
 func (*DeleteTokenRequest) ProtoMessage() {}
 
 func (x *DeleteTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[7]
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -440,7 +1165,7 @@ func (x *DeleteTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteTokenRequest.ProtoReflect.Descriptor instead.
 func (*DeleteTokenRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{7}
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *DeleteTokenRequest) GetID() string {
@@ -450,6 +1175,20 @@ func (x *DeleteTokenRequest) GetID() string {
 	return ""
 }
 
+func (x *DeleteTokenRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *DeleteTokenRequest) GetWait() *durationpb.Duration {
+	if x != nil {
+		return x.Wait
+	}
+	return nil
+}
+
 type SignatureResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Signatures    map[string][]byte      `protobuf:"bytes,1,rep,name=signatures,proto3" json:"signatures,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -459,7 +1198,7 @@ type SignatureResponse struct {
 
 func (x *SignatureResponse) Reset() {
 	*x = SignatureResponse{}
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[8]
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -471,7 +1210,7 @@ func (x *SignatureResponse) String() string {
 func (*SignatureResponse) ProtoMessage() {}
 
 func (x *SignatureResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[8]
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -484,7 +1223,7 @@ func (x *SignatureResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignatureResponse.ProtoReflect.Descriptor instead.
 func (*SignatureResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{8}
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *SignatureResponse) GetSignatures() map[string][]byte {
@@ -505,7 +1244,7 @@ type BootstrapRequest struct {
 
 func (x *BootstrapRequest) Reset() {
 	*x = BootstrapRequest{}
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[9]
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -517,7 +1256,7 @@ func (x *BootstrapRequest) String() string {
 func (*BootstrapRequest) ProtoMessage() {}
 
 func (x *BootstrapRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[9]
+	mi := &file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -530,7 +1269,7 @@ func (x *BootstrapRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BootstrapRequest.ProtoReflect.Descriptor instead.
 func (*BootstrapRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{9}
+	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *BootstrapRequest) GetID() string {
@@ -562,25 +1301,57 @@ const file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDesc = "" +
 	"\x10GetConfigRequest\x12\x18\n" +
 	"\atokenID\x18\x01 \x01(\tR\atokenID\"D\n" +
 	"\x11GetConfigResponse\x12/\n" +
-	"\x06config\x18\x01 \x01(\v2\x17.config.v1alpha1.ConfigR\x06config\"j\n" +
+	"\x06config\x18\x01 \x01(\v2\x17.config.v1alpha1.ConfigR\x06config\"\x13\n" +
+	"\x11CheckTokenRequest\"\xd6\x03\n" +
+	"\x12CheckTokenResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x18\n" +
+	"\atokenID\x18\x03 \x01(\tR\atokenID\x12)\n" +
+	"\rremainingUses\x18\x04 \x01(\x05H\x00R\rremainingUses\x88\x01\x01\x122\n" +
+	"\x06expiry\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06expiry\x12=\n" +
+	"\fremainingTTL\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\fremainingTTL\x12-\n" +
+	"\x0fconfigReference\x18\a \x01(\tH\x01R\x0fconfigReference\x88\x01\x01\x12J\n" +
+	"\x06labels\x18\b \x03(\v22.bootstrap.v1alpha1.CheckTokenResponse.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x10\n" +
+	"\x0e_remainingUsesB\x12\n" +
+	"\x10_configReference\"\x9a\x01\n" +
 	"\x14BootstrapAuthRequest\x12\x1a\n" +
 	"\bclientId\x18\x01 \x01(\tR\bclientId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
-	"\fclientPubKey\x18\x03 \x01(\fR\fclientPubKey\";\n" +
+	"\fclientPubKey\x18\x03 \x01(\fR\fclientPubKey\x12.\n" +
+	"\x12certificateRequest\x18\x04 \x01(\fR\x12certificateRequest\"]\n" +
 	"\x15BootstrapAuthResponse\x12\"\n" +
-	"\fserverPubKey\x18\x01 \x01(\fR\fserverPubKey\"\xef\x02\n" +
+	"\fserverPubKey\x18\x01 \x01(\fR\fserverPubKey\x12 \n" +
+	"\vcredentials\x18\x02 \x01(\fR\vcredentials\"^\n" +
+	"\x14BootstrapCredentials\x12 \n" +
+	"\vcertificate\x18\x01 \x01(\fR\vcertificate\x12$\n" +
+	"\rcaCertificate\x18\x02 \x01(\fR\rcaCertificate\"G\n" +
+	"\rEnrollRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\"\n" +
+	"\fclientPubKey\x18\x02 \x01(\fR\fclientPubKey\"j\n" +
+	"\x0eEnrollResponse\x12\x18\n" +
+	"\aagentId\x18\x01 \x01(\tR\aagentId\x12\x1a\n" +
+	"\bspiffeId\x18\x02 \x01(\tR\bspiffeId\x12\"\n" +
+	"\fserverPubKey\x18\x03 \x01(\fR\fserverPubKey\"\xd7\x03\n" +
 	"\x0eBootstrapToken\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x16\n" +
 	"\x06Secret\x18\x02 \x01(\tR\x06Secret\x12+\n" +
 	"\x03TTL\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03TTL\x127\n" +
 	"\x06Expiry\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x06Expiry\x88\x01\x01\x12-\n" +
 	"\x0fconfigReference\x18\x05 \x01(\tH\x01R\x0fconfigReference\x88\x01\x01\x12F\n" +
-	"\x06labels\x18\x06 \x03(\v2..bootstrap.v1alpha1.BootstrapToken.LabelsEntryR\x06labels\x1a9\n" +
+	"\x06labels\x18\x06 \x03(\v2..bootstrap.v1alpha1.BootstrapToken.LabelsEntryR\x06labels\x120\n" +
+	"\x05audit\x18\a \x01(\v2\x1a.config.v1alpha1.AuditInfoR\x05audit\x12\x1c\n" +
+	"\tsingleUse\x18\b \x01(\bR\tsingleUse\x12\x16\n" +
+	"\x06tenant\x18\t \x01(\tR\x06tenant\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\t\n" +
 	"\a_ExpiryB\x12\n" +
-	"\x10_configReference\"N\n" +
+	"\x10_configReference\"I\n" +
+	"\x11ListTokensRequest\x124\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1c.config.v1alpha1.AuditFilterR\x06filter\"N\n" +
 	"\x10ListTokenReponse\x12:\n" +
 	"\x06tokens\x18\x01 \x03(\v2\".bootstrap.v1alpha1.BootstrapTokenR\x06tokens\"\x8b\x02\n" +
 	"\x12CreateTokenRequest\x12+\n" +
@@ -590,9 +1361,37 @@ const file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDesc = "" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x12\n" +
-	"\x10_configReference\"$\n" +
+	"\x10_configReference\"\xb5\x02\n" +
+	"\x1aCreateEnrollmentURLRequest\x12+\n" +
+	"\x03TTL\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x03TTL\x12-\n" +
+	"\x0fconfigReference\x18\x02 \x01(\tH\x00R\x0fconfigReference\x88\x01\x01\x12R\n" +
+	"\x06labels\x18\x03 \x03(\v2:.bootstrap.v1alpha1.CreateEnrollmentURLRequest.LabelsEntryR\x06labels\x12\x18\n" +
+	"\abaseURL\x18\x04 \x01(\tR\abaseURL\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x12\n" +
+	"\x10_configReference\"o\n" +
+	"\rEnrollmentURL\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x18\n" +
+	"\atokenID\x18\x02 \x01(\tR\atokenID\x122\n" +
+	"\x06expiry\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06expiry\"\xca\x02\n" +
+	"\x1cGenerateInstallScriptRequest\x12\x18\n" +
+	"\atokenID\x18\x01 \x01(\tR\atokenID\x12?\n" +
+	"\bplatform\x18\x02 \x01(\x0e2#.bootstrap.v1alpha1.InstallPlatformR\bplatform\x12\x18\n" +
+	"\abaseURL\x18\x03 \x01(\tR\abaseURL\x12\x1c\n" +
+	"\tagentName\x18\x04 \x01(\tR\tagentName\x12Z\n" +
+	"\bsettings\x18\x05 \x03(\v2>.bootstrap.v1alpha1.GenerateInstallScriptRequest.SettingsEntryR\bsettings\x1a;\n" +
+	"\rSettingsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"w\n" +
+	"\rInstallScript\x12\x16\n" +
+	"\x06script\x18\x01 \x01(\tR\x06script\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x122\n" +
+	"\x06expiry\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06expiry\"i\n" +
 	"\x12DeleteTokenRequest\x12\x0e\n" +
-	"\x02ID\x18\x01 \x01(\tR\x02ID\"\xa9\x01\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\x12-\n" +
+	"\x04wait\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x04wait\"\xa9\x01\n" +
 	"\x11SignatureResponse\x12U\n" +
 	"\n" +
 	"signatures\x18\x01 \x03(\v25.bootstrap.v1alpha1.SignatureResponse.SignaturesEntryR\n" +
@@ -603,17 +1402,27 @@ const file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDesc = "" +
 	"\x10BootstrapRequest\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\"\n" +
-	"\fclientPubKey\x18\x03 \x01(\fR\fclientPubKey2\xb4\x03\n" +
+	"\fclientPubKey\x18\x03 \x01(\fR\fclientPubKey*\x91\x01\n" +
+	"\x0fInstallPlatform\x12 \n" +
+	"\x1cINSTALL_PLATFORM_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16INSTALL_PLATFORM_SHELL\x10\x01\x12\x1f\n" +
+	"\x1bINSTALL_PLATFORM_CLOUD_INIT\x10\x02\x12\x1f\n" +
+	"\x1bINSTALL_PLATFORM_POWERSHELL\x10\x032\x9b\x05\n" +
 	"\fTokenService\x12Y\n" +
-	"\vCreateToken\x12&.bootstrap.v1alpha1.CreateTokenRequest\x1a\".bootstrap.v1alpha1.BootstrapToken\x12J\n" +
+	"\vCreateToken\x12&.bootstrap.v1alpha1.CreateTokenRequest\x1a\".bootstrap.v1alpha1.BootstrapToken\x12Y\n" +
 	"\n" +
-	"ListTokens\x12\x16.google.protobuf.Empty\x1a$.bootstrap.v1alpha1.ListTokenReponse\x12M\n" +
+	"ListTokens\x12%.bootstrap.v1alpha1.ListTokensRequest\x1a$.bootstrap.v1alpha1.ListTokenReponse\x12M\n" +
 	"\vDeleteToken\x12&.bootstrap.v1alpha1.DeleteTokenRequest\x1a\x16.google.protobuf.Empty\x12K\n" +
 	"\n" +
-	"Signatures\x12\x16.google.protobuf.Empty\x1a%.bootstrap.v1alpha1.SignatureResponse\x12a\n" +
-	"\x12GetBootstrapConfig\x12$.bootstrap.v1alpha1.GetConfigRequest\x1a%.bootstrap.v1alpha1.GetConfigResponse2t\n" +
+	"Signatures\x12\x16.google.protobuf.Empty\x1a%.bootstrap.v1alpha1.SignatureResponse\x12h\n" +
+	"\x13CreateEnrollmentURL\x12..bootstrap.v1alpha1.CreateEnrollmentURLRequest\x1a!.bootstrap.v1alpha1.EnrollmentURL\x12l\n" +
+	"\x15GenerateInstallScript\x120.bootstrap.v1alpha1.GenerateInstallScriptRequest\x1a!.bootstrap.v1alpha1.InstallScript\x12a\n" +
+	"\x12GetBootstrapConfig\x12$.bootstrap.v1alpha1.GetConfigRequest\x1a%.bootstrap.v1alpha1.GetConfigResponse2\xa2\x02\n" +
 	"\x10BootstrapService\x12`\n" +
-	"\tBootstrap\x12(.bootstrap.v1alpha1.BootstrapAuthRequest\x1a).bootstrap.v1alpha1.BootstrapAuthResponseBDZBgithub.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1;v1alpha1b\x06proto3"
+	"\tBootstrap\x12(.bootstrap.v1alpha1.BootstrapAuthRequest\x1a).bootstrap.v1alpha1.BootstrapAuthResponse\x12O\n" +
+	"\x06Enroll\x12!.bootstrap.v1alpha1.EnrollRequest\x1a\".bootstrap.v1alpha1.EnrollResponse\x12[\n" +
+	"\n" +
+	"CheckToken\x12%.bootstrap.v1alpha1.CheckTokenRequest\x1a&.bootstrap.v1alpha1.CheckTokenResponseBDZBgithub.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1;v1alpha1b\x06proto3"
 
 var (
 	file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescOnce sync.Once
@@ -627,52 +1436,89 @@ func file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescGZIP() []byte {
 	return file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDescData
 }
 
-var file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_goTypes = []any{
-	(*GetConfigRequest)(nil),      // 0: bootstrap.v1alpha1.GetConfigRequest
-	(*GetConfigResponse)(nil),     // 1: bootstrap.v1alpha1.GetConfigResponse
-	(*BootstrapAuthRequest)(nil),  // 2: bootstrap.v1alpha1.BootstrapAuthRequest
-	(*BootstrapAuthResponse)(nil), // 3: bootstrap.v1alpha1.BootstrapAuthResponse
-	(*BootstrapToken)(nil),        // 4: bootstrap.v1alpha1.BootstrapToken
-	(*ListTokenReponse)(nil),      // 5: bootstrap.v1alpha1.ListTokenReponse
-	(*CreateTokenRequest)(nil),    // 6: bootstrap.v1alpha1.CreateTokenRequest
-	(*DeleteTokenRequest)(nil),    // 7: bootstrap.v1alpha1.DeleteTokenRequest
-	(*SignatureResponse)(nil),     // 8: bootstrap.v1alpha1.SignatureResponse
-	(*BootstrapRequest)(nil),      // 9: bootstrap.v1alpha1.BootstrapRequest
-	nil,                           // 10: bootstrap.v1alpha1.BootstrapToken.LabelsEntry
-	nil,                           // 11: bootstrap.v1alpha1.CreateTokenRequest.LabelsEntry
-	nil,                           // 12: bootstrap.v1alpha1.SignatureResponse.SignaturesEntry
-	(*v1alpha1.Config)(nil),       // 13: config.v1alpha1.Config
-	(*durationpb.Duration)(nil),   // 14: google.protobuf.Duration
-	(*timestamppb.Timestamp)(nil), // 15: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 16: google.protobuf.Empty
+	(InstallPlatform)(0),                 // 0: bootstrap.v1alpha1.InstallPlatform
+	(*GetConfigRequest)(nil),             // 1: bootstrap.v1alpha1.GetConfigRequest
+	(*GetConfigResponse)(nil),            // 2: bootstrap.v1alpha1.GetConfigResponse
+	(*CheckTokenRequest)(nil),            // 3: bootstrap.v1alpha1.CheckTokenRequest
+	(*CheckTokenResponse)(nil),           // 4: bootstrap.v1alpha1.CheckTokenResponse
+	(*BootstrapAuthRequest)(nil),         // 5: bootstrap.v1alpha1.BootstrapAuthRequest
+	(*BootstrapAuthResponse)(nil),        // 6: bootstrap.v1alpha1.BootstrapAuthResponse
+	(*BootstrapCredentials)(nil),         // 7: bootstrap.v1alpha1.BootstrapCredentials
+	(*EnrollRequest)(nil),                // 8: bootstrap.v1alpha1.EnrollRequest
+	(*EnrollResponse)(nil),               // 9: bootstrap.v1alpha1.EnrollResponse
+	(*BootstrapToken)(nil),               // 10: bootstrap.v1alpha1.BootstrapToken
+	(*ListTokensRequest)(nil),            // 11: bootstrap.v1alpha1.ListTokensRequest
+	(*ListTokenReponse)(nil),             // 12: bootstrap.v1alpha1.ListTokenReponse
+	(*CreateTokenRequest)(nil),           // 13: bootstrap.v1alpha1.CreateTokenRequest
+	(*CreateEnrollmentURLRequest)(nil),   // 14: bootstrap.v1alpha1.CreateEnrollmentURLRequest
+	(*EnrollmentURL)(nil),                // 15: bootstrap.v1alpha1.EnrollmentURL
+	(*GenerateInstallScriptRequest)(nil), // 16: bootstrap.v1alpha1.GenerateInstallScriptRequest
+	(*InstallScript)(nil),                // 17: bootstrap.v1alpha1.InstallScript
+	(*DeleteTokenRequest)(nil),           // 18: bootstrap.v1alpha1.DeleteTokenRequest
+	(*SignatureResponse)(nil),            // 19: bootstrap.v1alpha1.SignatureResponse
+	(*BootstrapRequest)(nil),             // 20: bootstrap.v1alpha1.BootstrapRequest
+	nil,                                  // 21: bootstrap.v1alpha1.CheckTokenResponse.LabelsEntry
+	nil,                                  // 22: bootstrap.v1alpha1.BootstrapToken.LabelsEntry
+	nil,                                  // 23: bootstrap.v1alpha1.CreateTokenRequest.LabelsEntry
+	nil,                                  // 24: bootstrap.v1alpha1.CreateEnrollmentURLRequest.LabelsEntry
+	nil,                                  // 25: bootstrap.v1alpha1.GenerateInstallScriptRequest.SettingsEntry
+	nil,                                  // 26: bootstrap.v1alpha1.SignatureResponse.SignaturesEntry
+	(*v1alpha1.Config)(nil),              // 27: config.v1alpha1.Config
+	(*timestamppb.Timestamp)(nil),        // 28: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),          // 29: google.protobuf.Duration
+	(*v1alpha1.AuditInfo)(nil),           // 30: config.v1alpha1.AuditInfo
+	(*v1alpha1.AuditFilter)(nil),         // 31: config.v1alpha1.AuditFilter
+	(*emptypb.Empty)(nil),                // 32: google.protobuf.Empty
 }
 var file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_depIdxs = []int32{
-	13, // 0: bootstrap.v1alpha1.GetConfigResponse.config:type_name -> config.v1alpha1.Config
-	14, // 1: bootstrap.v1alpha1.BootstrapToken.TTL:type_name -> google.protobuf.Duration
-	15, // 2: bootstrap.v1alpha1.BootstrapToken.Expiry:type_name -> google.protobuf.Timestamp
-	10, // 3: bootstrap.v1alpha1.BootstrapToken.labels:type_name -> bootstrap.v1alpha1.BootstrapToken.LabelsEntry
-	4,  // 4: bootstrap.v1alpha1.ListTokenReponse.tokens:type_name -> bootstrap.v1alpha1.BootstrapToken
-	14, // 5: bootstrap.v1alpha1.CreateTokenRequest.TTL:type_name -> google.protobuf.Duration
-	11, // 6: bootstrap.v1alpha1.CreateTokenRequest.labels:type_name -> bootstrap.v1alpha1.CreateTokenRequest.LabelsEntry
-	12, // 7: bootstrap.v1alpha1.SignatureResponse.signatures:type_name -> bootstrap.v1alpha1.SignatureResponse.SignaturesEntry
-	6,  // 8: bootstrap.v1alpha1.TokenService.CreateToken:input_type -> bootstrap.v1alpha1.CreateTokenRequest
-	16, // 9: bootstrap.v1alpha1.TokenService.ListTokens:input_type -> google.protobuf.Empty
-	7,  // 10: bootstrap.v1alpha1.TokenService.DeleteToken:input_type -> bootstrap.v1alpha1.DeleteTokenRequest
-	16, // 11: bootstrap.v1alpha1.TokenService.Signatures:input_type -> google.protobuf.Empty
-	0,  // 12: bootstrap.v1alpha1.TokenService.GetBootstrapConfig:input_type -> bootstrap.v1alpha1.GetConfigRequest
-	2,  // 13: bootstrap.v1alpha1.BootstrapService.Bootstrap:input_type -> bootstrap.v1alpha1.BootstrapAuthRequest
-	4,  // 14: bootstrap.v1alpha1.TokenService.CreateToken:output_type -> bootstrap.v1alpha1.BootstrapToken
-	5,  // 15: bootstrap.v1alpha1.TokenService.ListTokens:output_type -> bootstrap.v1alpha1.ListTokenReponse
-	16, // 16: bootstrap.v1alpha1.TokenService.DeleteToken:output_type -> google.protobuf.Empty
-	8,  // 17: bootstrap.v1alpha1.TokenService.Signatures:output_type -> bootstrap.v1alpha1.SignatureResponse
-	1,  // 18: bootstrap.v1alpha1.TokenService.GetBootstrapConfig:output_type -> bootstrap.v1alpha1.GetConfigResponse
-	3,  // 19: bootstrap.v1alpha1.BootstrapService.Bootstrap:output_type -> bootstrap.v1alpha1.BootstrapAuthResponse
-	14, // [14:20] is the sub-list for method output_type
-	8,  // [8:14] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	27, // 0: bootstrap.v1alpha1.GetConfigResponse.config:type_name -> config.v1alpha1.Config
+	28, // 1: bootstrap.v1alpha1.CheckTokenResponse.expiry:type_name -> google.protobuf.Timestamp
+	29, // 2: bootstrap.v1alpha1.CheckTokenResponse.remainingTTL:type_name -> google.protobuf.Duration
+	21, // 3: bootstrap.v1alpha1.CheckTokenResponse.labels:type_name -> bootstrap.v1alpha1.CheckTokenResponse.LabelsEntry
+	29, // 4: bootstrap.v1alpha1.BootstrapToken.TTL:type_name -> google.protobuf.Duration
+	28, // 5: bootstrap.v1alpha1.BootstrapToken.Expiry:type_name -> google.protobuf.Timestamp
+	22, // 6: bootstrap.v1alpha1.BootstrapToken.labels:type_name -> bootstrap.v1alpha1.BootstrapToken.LabelsEntry
+	30, // 7: bootstrap.v1alpha1.BootstrapToken.audit:type_name -> config.v1alpha1.AuditInfo
+	31, // 8: bootstrap.v1alpha1.ListTokensRequest.filter:type_name -> config.v1alpha1.AuditFilter
+	10, // 9: bootstrap.v1alpha1.ListTokenReponse.tokens:type_name -> bootstrap.v1alpha1.BootstrapToken
+	29, // 10: bootstrap.v1alpha1.CreateTokenRequest.TTL:type_name -> google.protobuf.Duration
+	23, // 11: bootstrap.v1alpha1.CreateTokenRequest.labels:type_name -> bootstrap.v1alpha1.CreateTokenRequest.LabelsEntry
+	29, // 12: bootstrap.v1alpha1.CreateEnrollmentURLRequest.TTL:type_name -> google.protobuf.Duration
+	24, // 13: bootstrap.v1alpha1.CreateEnrollmentURLRequest.labels:type_name -> bootstrap.v1alpha1.CreateEnrollmentURLRequest.LabelsEntry
+	28, // 14: bootstrap.v1alpha1.EnrollmentURL.expiry:type_name -> google.protobuf.Timestamp
+	0,  // 15: bootstrap.v1alpha1.GenerateInstallScriptRequest.platform:type_name -> bootstrap.v1alpha1.InstallPlatform
+	25, // 16: bootstrap.v1alpha1.GenerateInstallScriptRequest.settings:type_name -> bootstrap.v1alpha1.GenerateInstallScriptRequest.SettingsEntry
+	28, // 17: bootstrap.v1alpha1.InstallScript.expiry:type_name -> google.protobuf.Timestamp
+	29, // 18: bootstrap.v1alpha1.DeleteTokenRequest.wait:type_name -> google.protobuf.Duration
+	26, // 19: bootstrap.v1alpha1.SignatureResponse.signatures:type_name -> bootstrap.v1alpha1.SignatureResponse.SignaturesEntry
+	13, // 20: bootstrap.v1alpha1.TokenService.CreateToken:input_type -> bootstrap.v1alpha1.CreateTokenRequest
+	11, // 21: bootstrap.v1alpha1.TokenService.ListTokens:input_type -> bootstrap.v1alpha1.ListTokensRequest
+	18, // 22: bootstrap.v1alpha1.TokenService.DeleteToken:input_type -> bootstrap.v1alpha1.DeleteTokenRequest
+	32, // 23: bootstrap.v1alpha1.TokenService.Signatures:input_type -> google.protobuf.Empty
+	14, // 24: bootstrap.v1alpha1.TokenService.CreateEnrollmentURL:input_type -> bootstrap.v1alpha1.CreateEnrollmentURLRequest
+	16, // 25: bootstrap.v1alpha1.TokenService.GenerateInstallScript:input_type -> bootstrap.v1alpha1.GenerateInstallScriptRequest
+	1,  // 26: bootstrap.v1alpha1.TokenService.GetBootstrapConfig:input_type -> bootstrap.v1alpha1.GetConfigRequest
+	5,  // 27: bootstrap.v1alpha1.BootstrapService.Bootstrap:input_type -> bootstrap.v1alpha1.BootstrapAuthRequest
+	8,  // 28: bootstrap.v1alpha1.BootstrapService.Enroll:input_type -> bootstrap.v1alpha1.EnrollRequest
+	3,  // 29: bootstrap.v1alpha1.BootstrapService.CheckToken:input_type -> bootstrap.v1alpha1.CheckTokenRequest
+	10, // 30: bootstrap.v1alpha1.TokenService.CreateToken:output_type -> bootstrap.v1alpha1.BootstrapToken
+	12, // 31: bootstrap.v1alpha1.TokenService.ListTokens:output_type -> bootstrap.v1alpha1.ListTokenReponse
+	32, // 32: bootstrap.v1alpha1.TokenService.DeleteToken:output_type -> google.protobuf.Empty
+	19, // 33: bootstrap.v1alpha1.TokenService.Signatures:output_type -> bootstrap.v1alpha1.SignatureResponse
+	15, // 34: bootstrap.v1alpha1.TokenService.CreateEnrollmentURL:output_type -> bootstrap.v1alpha1.EnrollmentURL
+	17, // 35: bootstrap.v1alpha1.TokenService.GenerateInstallScript:output_type -> bootstrap.v1alpha1.InstallScript
+	2,  // 36: bootstrap.v1alpha1.TokenService.GetBootstrapConfig:output_type -> bootstrap.v1alpha1.GetConfigResponse
+	6,  // 37: bootstrap.v1alpha1.BootstrapService.Bootstrap:output_type -> bootstrap.v1alpha1.BootstrapAuthResponse
+	9,  // 38: bootstrap.v1alpha1.BootstrapService.Enroll:output_type -> bootstrap.v1alpha1.EnrollResponse
+	4,  // 39: bootstrap.v1alpha1.BootstrapService.CheckToken:output_type -> bootstrap.v1alpha1.CheckTokenResponse
+	30, // [30:40] is the sub-list for method output_type
+	20, // [20:30] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_init() }
@@ -680,20 +1526,23 @@ func file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_init() {
 	if File_pkg_api_bootstrap_v1alpha1_bootstrap_proto != nil {
 		return
 	}
-	file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[4].OneofWrappers = []any{}
-	file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[6].OneofWrappers = []any{}
+	file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[3].OneofWrappers = []any{}
+	file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[9].OneofWrappers = []any{}
+	file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[12].OneofWrappers = []any{}
+	file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes[13].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDesc), len(file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   13,
+			NumEnums:      1,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
 		GoTypes:           file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_goTypes,
 		DependencyIndexes: file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_depIdxs,
+		EnumInfos:         file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_enumTypes,
 		MessageInfos:      file_pkg_api_bootstrap_v1alpha1_bootstrap_proto_msgTypes,
 	}.Build()
 	File_pkg_api_bootstrap_v1alpha1_bootstrap_proto = out.File
@@ -46,20 +46,37 @@ const (
 	TokenServiceDeleteTokenProcedure = "/bootstrap.v1alpha1.TokenService/DeleteToken"
 	// TokenServiceSignaturesProcedure is the fully-qualified name of the TokenService's Signatures RPC.
 	TokenServiceSignaturesProcedure = "/bootstrap.v1alpha1.TokenService/Signatures"
+	// TokenServiceCreateEnrollmentURLProcedure is the fully-qualified name of the TokenService's
+	// CreateEnrollmentURL RPC.
+	TokenServiceCreateEnrollmentURLProcedure = "/bootstrap.v1alpha1.TokenService/CreateEnrollmentURL"
+	// TokenServiceGenerateInstallScriptProcedure is the fully-qualified name of the TokenService's
+	// GenerateInstallScript RPC.
+	TokenServiceGenerateInstallScriptProcedure = "/bootstrap.v1alpha1.TokenService/GenerateInstallScript"
 	// TokenServiceGetBootstrapConfigProcedure is the fully-qualified name of the TokenService's
 	// GetBootstrapConfig RPC.
 	TokenServiceGetBootstrapConfigProcedure = "/bootstrap.v1alpha1.TokenService/GetBootstrapConfig"
 	// BootstrapServiceBootstrapProcedure is the fully-qualified name of the BootstrapService's
 	// Bootstrap RPC.
 	BootstrapServiceBootstrapProcedure = "/bootstrap.v1alpha1.BootstrapService/Bootstrap"
+	// BootstrapServiceEnrollProcedure is the fully-qualified name of the BootstrapService's Enroll RPC.
+	BootstrapServiceEnrollProcedure = "/bootstrap.v1alpha1.BootstrapService/Enroll"
+	// BootstrapServiceCheckTokenProcedure is the fully-qualified name of the BootstrapService's
+	// CheckToken RPC.
+	BootstrapServiceCheckTokenProcedure = "/bootstrap.v1alpha1.BootstrapService/CheckToken"
 )
 
 // TokenServiceClient is a client for the bootstrap.v1alpha1.TokenService service.
 type TokenServiceClient interface {
 	CreateToken(context.Context, *connect.Request[v1alpha1.CreateTokenRequest]) (*connect.Response[v1alpha1.BootstrapToken], error)
-	ListTokens(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListTokenReponse], error)
+	ListTokens(context.Context, *connect.Request[v1alpha1.ListTokensRequest]) (*connect.Response[v1alpha1.ListTokenReponse], error)
 	DeleteToken(context.Context, *connect.Request[v1alpha1.DeleteTokenRequest]) (*connect.Response[emptypb.Empty], error)
 	Signatures(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.SignatureResponse], error)
+	// CreateEnrollmentURL creates a single-use token and returns a pre-signed URL
+	// agents can bootstrap with, for provisioning tools that can't pass bearer tokens
+	CreateEnrollmentURL(context.Context, *connect.Request[v1alpha1.CreateEnrollmentURLRequest]) (*connect.Response[v1alpha1.EnrollmentURL], error)
+	// GenerateInstallScript returns a snippet installing an agent bootstrapped with the token,
+	// embedding the server URL, the token, its labels and the agent settings
+	GenerateInstallScript(context.Context, *connect.Request[v1alpha1.GenerateInstallScriptRequest]) (*connect.Response[v1alpha1.InstallScript], error)
 	GetBootstrapConfig(context.Context, *connect.Request[v1alpha1.GetConfigRequest]) (*connect.Response[v1alpha1.GetConfigResponse], error)
 }
 
@@ -80,7 +97,7 @@ func NewTokenServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(tokenServiceMethods.ByName("CreateToken")),
 			connect.WithClientOptions(opts...),
 		),
-		listTokens: connect.NewClient[emptypb.Empty, v1alpha1.ListTokenReponse](
+		listTokens: connect.NewClient[v1alpha1.ListTokensRequest, v1alpha1.ListTokenReponse](
 			httpClient,
 			baseURL+TokenServiceListTokensProcedure,
 			connect.WithSchema(tokenServiceMethods.ByName("ListTokens")),
@@ -98,6 +115,18 @@ func NewTokenServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(tokenServiceMethods.ByName("Signatures")),
 			connect.WithClientOptions(opts...),
 		),
+		createEnrollmentURL: connect.NewClient[v1alpha1.CreateEnrollmentURLRequest, v1alpha1.EnrollmentURL](
+			httpClient,
+			baseURL+TokenServiceCreateEnrollmentURLProcedure,
+			connect.WithSchema(tokenServiceMethods.ByName("CreateEnrollmentURL")),
+			connect.WithClientOptions(opts...),
+		),
+		generateInstallScript: connect.NewClient[v1alpha1.GenerateInstallScriptRequest, v1alpha1.InstallScript](
+			httpClient,
+			baseURL+TokenServiceGenerateInstallScriptProcedure,
+			connect.WithSchema(tokenServiceMethods.ByName("GenerateInstallScript")),
+			connect.WithClientOptions(opts...),
+		),
 		getBootstrapConfig: connect.NewClient[v1alpha1.GetConfigRequest, v1alpha1.GetConfigResponse](
 			httpClient,
 			baseURL+TokenServiceGetBootstrapConfigProcedure,
@@ -109,11 +138,13 @@ func NewTokenServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 
 // tokenServiceClient implements TokenServiceClient.
 type tokenServiceClient struct {
-	createToken        *connect.Client[v1alpha1.CreateTokenRequest, v1alpha1.BootstrapToken]
-	listTokens         *connect.Client[emptypb.Empty, v1alpha1.ListTokenReponse]
-	deleteToken        *connect.Client[v1alpha1.DeleteTokenRequest, emptypb.Empty]
-	signatures         *connect.Client[emptypb.Empty, v1alpha1.SignatureResponse]
-	getBootstrapConfig *connect.Client[v1alpha1.GetConfigRequest, v1alpha1.GetConfigResponse]
+	createToken           *connect.Client[v1alpha1.CreateTokenRequest, v1alpha1.BootstrapToken]
+	listTokens            *connect.Client[v1alpha1.ListTokensRequest, v1alpha1.ListTokenReponse]
+	deleteToken           *connect.Client[v1alpha1.DeleteTokenRequest, emptypb.Empty]
+	signatures            *connect.Client[emptypb.Empty, v1alpha1.SignatureResponse]
+	createEnrollmentURL   *connect.Client[v1alpha1.CreateEnrollmentURLRequest, v1alpha1.EnrollmentURL]
+	generateInstallScript *connect.Client[v1alpha1.GenerateInstallScriptRequest, v1alpha1.InstallScript]
+	getBootstrapConfig    *connect.Client[v1alpha1.GetConfigRequest, v1alpha1.GetConfigResponse]
 }
 
 // CreateToken calls bootstrap.v1alpha1.TokenService.CreateToken.
@@ -122,7 +153,7 @@ func (c *tokenServiceClient) CreateToken(ctx context.Context, req *connect.Reque
 }
 
 // ListTokens calls bootstrap.v1alpha1.TokenService.ListTokens.
-func (c *tokenServiceClient) ListTokens(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListTokenReponse], error) {
+func (c *tokenServiceClient) ListTokens(ctx context.Context, req *connect.Request[v1alpha1.ListTokensRequest]) (*connect.Response[v1alpha1.ListTokenReponse], error) {
 	return c.listTokens.CallUnary(ctx, req)
 }
 
@@ -136,6 +167,16 @@ func (c *tokenServiceClient) Signatures(ctx context.Context, req *connect.Reques
 	return c.signatures.CallUnary(ctx, req)
 }
 
+// CreateEnrollmentURL calls bootstrap.v1alpha1.TokenService.CreateEnrollmentURL.
+func (c *tokenServiceClient) CreateEnrollmentURL(ctx context.Context, req *connect.Request[v1alpha1.CreateEnrollmentURLRequest]) (*connect.Response[v1alpha1.EnrollmentURL], error) {
+	return c.createEnrollmentURL.CallUnary(ctx, req)
+}
+
+// GenerateInstallScript calls bootstrap.v1alpha1.TokenService.GenerateInstallScript.
+func (c *tokenServiceClient) GenerateInstallScript(ctx context.Context, req *connect.Request[v1alpha1.GenerateInstallScriptRequest]) (*connect.Response[v1alpha1.InstallScript], error) {
+	return c.generateInstallScript.CallUnary(ctx, req)
+}
+
 // GetBootstrapConfig calls bootstrap.v1alpha1.TokenService.GetBootstrapConfig.
 func (c *tokenServiceClient) GetBootstrapConfig(ctx context.Context, req *connect.Request[v1alpha1.GetConfigRequest]) (*connect.Response[v1alpha1.GetConfigResponse], error) {
 	return c.getBootstrapConfig.CallUnary(ctx, req)
@@ -144,9 +185,15 @@ func (c *tokenServiceClient) GetBootstrapConfig(ctx context.Context, req *connec
 // TokenServiceHandler is an implementation of the bootstrap.v1alpha1.TokenService service.
 type TokenServiceHandler interface {
 	CreateToken(context.Context, *connect.Request[v1alpha1.CreateTokenRequest]) (*connect.Response[v1alpha1.BootstrapToken], error)
-	ListTokens(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListTokenReponse], error)
+	ListTokens(context.Context, *connect.Request[v1alpha1.ListTokensRequest]) (*connect.Response[v1alpha1.ListTokenReponse], error)
 	DeleteToken(context.Context, *connect.Request[v1alpha1.DeleteTokenRequest]) (*connect.Response[emptypb.Empty], error)
 	Signatures(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.SignatureResponse], error)
+	// CreateEnrollmentURL creates a single-use token and returns a pre-signed URL
+	// agents can bootstrap with, for provisioning tools that can't pass bearer tokens
+	CreateEnrollmentURL(context.Context, *connect.Request[v1alpha1.CreateEnrollmentURLRequest]) (*connect.Response[v1alpha1.EnrollmentURL], error)
+	// GenerateInstallScript returns a snippet installing an agent bootstrapped with the token,
+	// embedding the server URL, the token, its labels and the agent settings
+	GenerateInstallScript(context.Context, *connect.Request[v1alpha1.GenerateInstallScriptRequest]) (*connect.Response[v1alpha1.InstallScript], error)
 	GetBootstrapConfig(context.Context, *connect.Request[v1alpha1.GetConfigRequest]) (*connect.Response[v1alpha1.GetConfigResponse], error)
 }
 
@@ -181,6 +228,18 @@ func NewTokenServiceHandler(svc TokenServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(tokenServiceMethods.ByName("Signatures")),
 		connect.WithHandlerOptions(opts...),
 	)
+	tokenServiceCreateEnrollmentURLHandler := connect.NewUnaryHandler(
+		TokenServiceCreateEnrollmentURLProcedure,
+		svc.CreateEnrollmentURL,
+		connect.WithSchema(tokenServiceMethods.ByName("CreateEnrollmentURL")),
+		connect.WithHandlerOptions(opts...),
+	)
+	tokenServiceGenerateInstallScriptHandler := connect.NewUnaryHandler(
+		TokenServiceGenerateInstallScriptProcedure,
+		svc.GenerateInstallScript,
+		connect.WithSchema(tokenServiceMethods.ByName("GenerateInstallScript")),
+		connect.WithHandlerOptions(opts...),
+	)
 	tokenServiceGetBootstrapConfigHandler := connect.NewUnaryHandler(
 		TokenServiceGetBootstrapConfigProcedure,
 		svc.GetBootstrapConfig,
@@ -197,6 +256,10 @@ func NewTokenServiceHandler(svc TokenServiceHandler, opts ...connect.HandlerOpti
 			tokenServiceDeleteTokenHandler.ServeHTTP(w, r)
 		case TokenServiceSignaturesProcedure:
 			tokenServiceSignaturesHandler.ServeHTTP(w, r)
+		case TokenServiceCreateEnrollmentURLProcedure:
+			tokenServiceCreateEnrollmentURLHandler.ServeHTTP(w, r)
+		case TokenServiceGenerateInstallScriptProcedure:
+			tokenServiceGenerateInstallScriptHandler.ServeHTTP(w, r)
 		case TokenServiceGetBootstrapConfigProcedure:
 			tokenServiceGetBootstrapConfigHandler.ServeHTTP(w, r)
 		default:
@@ -212,7 +275,7 @@ func (UnimplementedTokenServiceHandler) CreateToken(context.Context, *connect.Re
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bootstrap.v1alpha1.TokenService.CreateToken is not implemented"))
 }
 
-func (UnimplementedTokenServiceHandler) ListTokens(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListTokenReponse], error) {
+func (UnimplementedTokenServiceHandler) ListTokens(context.Context, *connect.Request[v1alpha1.ListTokensRequest]) (*connect.Response[v1alpha1.ListTokenReponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bootstrap.v1alpha1.TokenService.ListTokens is not implemented"))
 }
 
@@ -224,6 +287,14 @@ func (UnimplementedTokenServiceHandler) Signatures(context.Context, *connect.Req
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bootstrap.v1alpha1.TokenService.Signatures is not implemented"))
 }
 
+func (UnimplementedTokenServiceHandler) CreateEnrollmentURL(context.Context, *connect.Request[v1alpha1.CreateEnrollmentURLRequest]) (*connect.Response[v1alpha1.EnrollmentURL], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bootstrap.v1alpha1.TokenService.CreateEnrollmentURL is not implemented"))
+}
+
+func (UnimplementedTokenServiceHandler) GenerateInstallScript(context.Context, *connect.Request[v1alpha1.GenerateInstallScriptRequest]) (*connect.Response[v1alpha1.InstallScript], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bootstrap.v1alpha1.TokenService.GenerateInstallScript is not implemented"))
+}
+
 func (UnimplementedTokenServiceHandler) GetBootstrapConfig(context.Context, *connect.Request[v1alpha1.GetConfigRequest]) (*connect.Response[v1alpha1.GetConfigResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bootstrap.v1alpha1.TokenService.GetBootstrapConfig is not implemented"))
 }
@@ -231,6 +302,13 @@ func (UnimplementedTokenServiceHandler) GetBootstrapConfig(context.Context, *con
 // BootstrapServiceClient is a client for the bootstrap.v1alpha1.BootstrapService service.
 type BootstrapServiceClient interface {
 	Bootstrap(context.Context, *connect.Request[v1alpha1.BootstrapAuthRequest]) (*connect.Response[v1alpha1.BootstrapAuthResponse], error)
+	// Enroll registers an agent authenticated by an X.509 SVID presented
+	// on the TLS connection, skipping token bootstrap entirely.
+	Enroll(context.Context, *connect.Request[v1alpha1.EnrollRequest]) (*connect.Response[v1alpha1.EnrollResponse], error)
+	// CheckToken verifies the token presented in the Authorization header like Bootstrap,
+	// without consuming a use or registering an agent, so that provisioning pipelines can
+	// validate tokens before installing agents
+	CheckToken(context.Context, *connect.Request[v1alpha1.CheckTokenRequest]) (*connect.Response[v1alpha1.CheckTokenResponse], error)
 }
 
 // NewBootstrapServiceClient constructs a client for the bootstrap.v1alpha1.BootstrapService
@@ -250,12 +328,26 @@ func NewBootstrapServiceClient(httpClient connect.HTTPClient, baseURL string, op
 			connect.WithSchema(bootstrapServiceMethods.ByName("Bootstrap")),
 			connect.WithClientOptions(opts...),
 		),
+		enroll: connect.NewClient[v1alpha1.EnrollRequest, v1alpha1.EnrollResponse](
+			httpClient,
+			baseURL+BootstrapServiceEnrollProcedure,
+			connect.WithSchema(bootstrapServiceMethods.ByName("Enroll")),
+			connect.WithClientOptions(opts...),
+		),
+		checkToken: connect.NewClient[v1alpha1.CheckTokenRequest, v1alpha1.CheckTokenResponse](
+			httpClient,
+			baseURL+BootstrapServiceCheckTokenProcedure,
+			connect.WithSchema(bootstrapServiceMethods.ByName("CheckToken")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // bootstrapServiceClient implements BootstrapServiceClient.
 type bootstrapServiceClient struct {
-	bootstrap *connect.Client[v1alpha1.BootstrapAuthRequest, v1alpha1.BootstrapAuthResponse]
+	bootstrap  *connect.Client[v1alpha1.BootstrapAuthRequest, v1alpha1.BootstrapAuthResponse]
+	enroll     *connect.Client[v1alpha1.EnrollRequest, v1alpha1.EnrollResponse]
+	checkToken *connect.Client[v1alpha1.CheckTokenRequest, v1alpha1.CheckTokenResponse]
 }
 
 // Bootstrap calls bootstrap.v1alpha1.BootstrapService.Bootstrap.
@@ -263,9 +355,26 @@ func (c *bootstrapServiceClient) Bootstrap(ctx context.Context, req *connect.Req
 	return c.bootstrap.CallUnary(ctx, req)
 }
 
+// Enroll calls bootstrap.v1alpha1.BootstrapService.Enroll.
+func (c *bootstrapServiceClient) Enroll(ctx context.Context, req *connect.Request[v1alpha1.EnrollRequest]) (*connect.Response[v1alpha1.EnrollResponse], error) {
+	return c.enroll.CallUnary(ctx, req)
+}
+
+// CheckToken calls bootstrap.v1alpha1.BootstrapService.CheckToken.
+func (c *bootstrapServiceClient) CheckToken(ctx context.Context, req *connect.Request[v1alpha1.CheckTokenRequest]) (*connect.Response[v1alpha1.CheckTokenResponse], error) {
+	return c.checkToken.CallUnary(ctx, req)
+}
+
 // BootstrapServiceHandler is an implementation of the bootstrap.v1alpha1.BootstrapService service.
 type BootstrapServiceHandler interface {
 	Bootstrap(context.Context, *connect.Request[v1alpha1.BootstrapAuthRequest]) (*connect.Response[v1alpha1.BootstrapAuthResponse], error)
+	// Enroll registers an agent authenticated by an X.509 SVID presented
+	// on the TLS connection, skipping token bootstrap entirely.
+	Enroll(context.Context, *connect.Request[v1alpha1.EnrollRequest]) (*connect.Response[v1alpha1.EnrollResponse], error)
+	// CheckToken verifies the token presented in the Authorization header like Bootstrap,
+	// without consuming a use or registering an agent, so that provisioning pipelines can
+	// validate tokens before installing agents
+	CheckToken(context.Context, *connect.Request[v1alpha1.CheckTokenRequest]) (*connect.Response[v1alpha1.CheckTokenResponse], error)
 }
 
 // NewBootstrapServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -281,10 +390,26 @@ func NewBootstrapServiceHandler(svc BootstrapServiceHandler, opts ...connect.Han
 		connect.WithSchema(bootstrapServiceMethods.ByName("Bootstrap")),
 		connect.WithHandlerOptions(opts...),
 	)
+	bootstrapServiceEnrollHandler := connect.NewUnaryHandler(
+		BootstrapServiceEnrollProcedure,
+		svc.Enroll,
+		connect.WithSchema(bootstrapServiceMethods.ByName("Enroll")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bootstrapServiceCheckTokenHandler := connect.NewUnaryHandler(
+		BootstrapServiceCheckTokenProcedure,
+		svc.CheckToken,
+		connect.WithSchema(bootstrapServiceMethods.ByName("CheckToken")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/bootstrap.v1alpha1.BootstrapService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case BootstrapServiceBootstrapProcedure:
 			bootstrapServiceBootstrapHandler.ServeHTTP(w, r)
+		case BootstrapServiceEnrollProcedure:
+			bootstrapServiceEnrollHandler.ServeHTTP(w, r)
+		case BootstrapServiceCheckTokenProcedure:
+			bootstrapServiceCheckTokenHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -297,3 +422,11 @@ type UnimplementedBootstrapServiceHandler struct{}
 func (UnimplementedBootstrapServiceHandler) Bootstrap(context.Context, *connect.Request[v1alpha1.BootstrapAuthRequest]) (*connect.Response[v1alpha1.BootstrapAuthResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bootstrap.v1alpha1.BootstrapService.Bootstrap is not implemented"))
 }
+
+func (UnimplementedBootstrapServiceHandler) Enroll(context.Context, *connect.Request[v1alpha1.EnrollRequest]) (*connect.Response[v1alpha1.EnrollResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bootstrap.v1alpha1.BootstrapService.Enroll is not implemented"))
+}
+
+func (UnimplementedBootstrapServiceHandler) CheckToken(context.Context, *connect.Request[v1alpha1.CheckTokenRequest]) (*connect.Response[v1alpha1.CheckTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("bootstrap.v1alpha1.BootstrapService.CheckToken is not implemented"))
+}
@@ -39,6 +39,16 @@ func RegisterTokenServiceHandler(mux *mux.Router, svc TokenServiceHandler, opts
 		svc.Signatures,
 		opts...,
 	))
+	mux.Handle("/bootstrap.v1alpha1.TokenService/CreateEnrollmentURL", connect.NewUnaryHandler(
+		"/bootstrap.v1alpha1.TokenService/CreateEnrollmentURL",
+		svc.CreateEnrollmentURL,
+		opts...,
+	))
+	mux.Handle("/bootstrap.v1alpha1.TokenService/GenerateInstallScript", connect.NewUnaryHandler(
+		"/bootstrap.v1alpha1.TokenService/GenerateInstallScript",
+		svc.GenerateInstallScript,
+		opts...,
+	))
 	mux.Handle("/bootstrap.v1alpha1.TokenService/GetBootstrapConfig", connect.NewUnaryHandler(
 		"/bootstrap.v1alpha1.TokenService/GetBootstrapConfig",
 		svc.GetBootstrapConfig,
@@ -54,4 +64,14 @@ func RegisterBootstrapServiceHandler(mux *mux.Router, svc BootstrapServiceHandle
 		svc.Bootstrap,
 		opts...,
 	))
+	mux.Handle("/bootstrap.v1alpha1.BootstrapService/Enroll", connect.NewUnaryHandler(
+		"/bootstrap.v1alpha1.BootstrapService/Enroll",
+		svc.Enroll,
+		opts...,
+	))
+	mux.Handle("/bootstrap.v1alpha1.BootstrapService/CheckToken", connect.NewUnaryHandler(
+		"/bootstrap.v1alpha1.BootstrapService/CheckToken",
+		svc.CheckToken,
+		opts...,
+	))
 }
@@ -6,7 +6,8 @@ import (
 )
 
 func (c *CreateTokenRequest) Validate() error {
-	if c.TTL.AsDuration() == 0 {
+	// tokens created without a TTL are valid for the server's default TTL
+	if c.TTL.AsDuration() < 0 {
 		return errors.New("invalid duration")
 	}
 	return nil
@@ -0,0 +1,445 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: pkg/api/bootstrap/v1beta1/bootstrap.proto
+
+package v1beta1
+
+import (
+	v1beta1 "github.com/otelfleet/otelfleet/pkg/api/config/v1beta1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateTokenRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Ttl             *durationpb.Duration   `protobuf:"bytes,1,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	ConfigReference *string                `protobuf:"bytes,2,opt,name=config_reference,json=configReference,proto3,oneof" json:"config_reference,omitempty"`
+	Labels          map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateTokenRequest) Reset() {
+	*x = CreateTokenRequest{}
+	mi := &file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTokenRequest) ProtoMessage() {}
+
+func (x *CreateTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateTokenRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateTokenRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *CreateTokenRequest) GetConfigReference() string {
+	if x != nil && x.ConfigReference != nil {
+		return *x.ConfigReference
+	}
+	return ""
+}
+
+func (x *CreateTokenRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type BootstrapToken struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Secret          string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	Ttl             *durationpb.Duration   `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Expiry          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expiry,proto3,oneof" json:"expiry,omitempty"`
+	ConfigReference *string                `protobuf:"bytes,5,opt,name=config_reference,json=configReference,proto3,oneof" json:"config_reference,omitempty"`
+	Labels          map[string]string      `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Audit           *v1beta1.AuditInfo     `protobuf:"bytes,7,opt,name=audit,proto3" json:"audit,omitempty"`
+	// single-use tokens are deleted once an agent bootstrapped with them
+	SingleUse     bool `protobuf:"varint,8,opt,name=single_use,json=singleUse,proto3" json:"single_use,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BootstrapToken) Reset() {
+	*x = BootstrapToken{}
+	mi := &file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BootstrapToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BootstrapToken) ProtoMessage() {}
+
+func (x *BootstrapToken) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BootstrapToken.ProtoReflect.Descriptor instead.
+func (*BootstrapToken) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BootstrapToken) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BootstrapToken) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *BootstrapToken) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+func (x *BootstrapToken) GetExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+func (x *BootstrapToken) GetConfigReference() string {
+	if x != nil && x.ConfigReference != nil {
+		return *x.ConfigReference
+	}
+	return ""
+}
+
+func (x *BootstrapToken) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *BootstrapToken) GetAudit() *v1beta1.AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+func (x *BootstrapToken) GetSingleUse() bool {
+	if x != nil {
+		return x.SingleUse
+	}
+	return false
+}
+
+type ListTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokensRequest) Reset() {
+	*x = ListTokensRequest{}
+	mi := &file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokensRequest) ProtoMessage() {}
+
+func (x *ListTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListTokensRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDescGZIP(), []int{2}
+}
+
+type ListTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*BootstrapToken      `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokensResponse) Reset() {
+	*x = ListTokensResponse{}
+	mi := &file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokensResponse) ProtoMessage() {}
+
+func (x *ListTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListTokensResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTokensResponse) GetTokens() []*BootstrapToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type DeleteTokenRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// delete the token even if it was recently used by bootstrap attempts
+	Force bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	// optionally wait up to this long for recent bootstrap attempts to settle
+	// before deciding whether the token can be deleted
+	Wait          *durationpb.Duration `protobuf:"bytes,3,opt,name=wait,proto3" json:"wait,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTokenRequest) Reset() {
+	*x = DeleteTokenRequest{}
+	mi := &file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTokenRequest) ProtoMessage() {}
+
+func (x *DeleteTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTokenRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTokenRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteTokenRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteTokenRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *DeleteTokenRequest) GetWait() *durationpb.Duration {
+	if x != nil {
+		return x.Wait
+	}
+	return nil
+}
+
+var File_pkg_api_bootstrap_v1beta1_bootstrap_proto protoreflect.FileDescriptor
+
+const file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDesc = "" +
+	"\n" +
+	")pkg/api/bootstrap/v1beta1/bootstrap.proto\x12\x11bootstrap.v1beta1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a#pkg/api/config/v1beta1/config.proto\"\x8c\x02\n" +
+	"\x12CreateTokenRequest\x12+\n" +
+	"\x03ttl\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x12.\n" +
+	"\x10config_reference\x18\x02 \x01(\tH\x00R\x0fconfigReference\x88\x01\x01\x12I\n" +
+	"\x06labels\x18\x03 \x03(\v21.bootstrap.v1beta1.CreateTokenRequest.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x13\n" +
+	"\x11_config_reference\"\xc0\x03\n" +
+	"\x0eBootstrapToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\x127\n" +
+	"\x06expiry\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x06expiry\x88\x01\x01\x12.\n" +
+	"\x10config_reference\x18\x05 \x01(\tH\x01R\x0fconfigReference\x88\x01\x01\x12E\n" +
+	"\x06labels\x18\x06 \x03(\v2-.bootstrap.v1beta1.BootstrapToken.LabelsEntryR\x06labels\x12/\n" +
+	"\x05audit\x18\a \x01(\v2\x19.config.v1beta1.AuditInfoR\x05audit\x12\x1d\n" +
+	"\n" +
+	"single_use\x18\b \x01(\bR\tsingleUse\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\t\n" +
+	"\a_expiryB\x13\n" +
+	"\x11_config_reference\"\x13\n" +
+	"\x11ListTokensRequest\"O\n" +
+	"\x12ListTokensResponse\x129\n" +
+	"\x06tokens\x18\x01 \x03(\v2!.bootstrap.v1beta1.BootstrapTokenR\x06tokens\"i\n" +
+	"\x12DeleteTokenRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\x12-\n" +
+	"\x04wait\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x04wait2\x90\x02\n" +
+	"\fTokenService\x12W\n" +
+	"\vCreateToken\x12%.bootstrap.v1beta1.CreateTokenRequest\x1a!.bootstrap.v1beta1.BootstrapToken\x12Y\n" +
+	"\n" +
+	"ListTokens\x12$.bootstrap.v1beta1.ListTokensRequest\x1a%.bootstrap.v1beta1.ListTokensResponse\x12L\n" +
+	"\vDeleteToken\x12%.bootstrap.v1beta1.DeleteTokenRequest\x1a\x16.google.protobuf.EmptyB:Z8github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1beta1b\x06proto3"
+
+var (
+	file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDescOnce sync.Once
+	file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDescData []byte
+)
+
+func file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDescGZIP() []byte {
+	file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDescOnce.Do(func() {
+		file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDesc), len(file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDesc)))
+	})
+	return file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDescData
+}
+
+var file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_pkg_api_bootstrap_v1beta1_bootstrap_proto_goTypes = []any{
+	(*CreateTokenRequest)(nil),    // 0: bootstrap.v1beta1.CreateTokenRequest
+	(*BootstrapToken)(nil),        // 1: bootstrap.v1beta1.BootstrapToken
+	(*ListTokensRequest)(nil),     // 2: bootstrap.v1beta1.ListTokensRequest
+	(*ListTokensResponse)(nil),    // 3: bootstrap.v1beta1.ListTokensResponse
+	(*DeleteTokenRequest)(nil),    // 4: bootstrap.v1beta1.DeleteTokenRequest
+	nil,                           // 5: bootstrap.v1beta1.CreateTokenRequest.LabelsEntry
+	nil,                           // 6: bootstrap.v1beta1.BootstrapToken.LabelsEntry
+	(*durationpb.Duration)(nil),   // 7: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(*v1beta1.AuditInfo)(nil),     // 9: config.v1beta1.AuditInfo
+	(*emptypb.Empty)(nil),         // 10: google.protobuf.Empty
+}
+var file_pkg_api_bootstrap_v1beta1_bootstrap_proto_depIdxs = []int32{
+	7,  // 0: bootstrap.v1beta1.CreateTokenRequest.ttl:type_name -> google.protobuf.Duration
+	5,  // 1: bootstrap.v1beta1.CreateTokenRequest.labels:type_name -> bootstrap.v1beta1.CreateTokenRequest.LabelsEntry
+	7,  // 2: bootstrap.v1beta1.BootstrapToken.ttl:type_name -> google.protobuf.Duration
+	8,  // 3: bootstrap.v1beta1.BootstrapToken.expiry:type_name -> google.protobuf.Timestamp
+	6,  // 4: bootstrap.v1beta1.BootstrapToken.labels:type_name -> bootstrap.v1beta1.BootstrapToken.LabelsEntry
+	9,  // 5: bootstrap.v1beta1.BootstrapToken.audit:type_name -> config.v1beta1.AuditInfo
+	1,  // 6: bootstrap.v1beta1.ListTokensResponse.tokens:type_name -> bootstrap.v1beta1.BootstrapToken
+	7,  // 7: bootstrap.v1beta1.DeleteTokenRequest.wait:type_name -> google.protobuf.Duration
+	0,  // 8: bootstrap.v1beta1.TokenService.CreateToken:input_type -> bootstrap.v1beta1.CreateTokenRequest
+	2,  // 9: bootstrap.v1beta1.TokenService.ListTokens:input_type -> bootstrap.v1beta1.ListTokensRequest
+	4,  // 10: bootstrap.v1beta1.TokenService.DeleteToken:input_type -> bootstrap.v1beta1.DeleteTokenRequest
+	1,  // 11: bootstrap.v1beta1.TokenService.CreateToken:output_type -> bootstrap.v1beta1.BootstrapToken
+	3,  // 12: bootstrap.v1beta1.TokenService.ListTokens:output_type -> bootstrap.v1beta1.ListTokensResponse
+	10, // 13: bootstrap.v1beta1.TokenService.DeleteToken:output_type -> google.protobuf.Empty
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_bootstrap_v1beta1_bootstrap_proto_init() }
+func file_pkg_api_bootstrap_v1beta1_bootstrap_proto_init() {
+	if File_pkg_api_bootstrap_v1beta1_bootstrap_proto != nil {
+		return
+	}
+	file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes[0].OneofWrappers = []any{}
+	file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDesc), len(file_pkg_api_bootstrap_v1beta1_bootstrap_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_bootstrap_v1beta1_bootstrap_proto_goTypes,
+		DependencyIndexes: file_pkg_api_bootstrap_v1beta1_bootstrap_proto_depIdxs,
+		MessageInfos:      file_pkg_api_bootstrap_v1beta1_bootstrap_proto_msgTypes,
+	}.Build()
+	File_pkg_api_bootstrap_v1beta1_bootstrap_proto = out.File
+	file_pkg_api_bootstrap_v1beta1_bootstrap_proto_goTypes = nil
+	file_pkg_api_bootstrap_v1beta1_bootstrap_proto_depIdxs = nil
+}
@@ -0,0 +1,59 @@
+package v1alpha1
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewAuditInfo returns the audit info of a resource created by principal at now.
+func NewAuditInfo(principal string, now time.Time) *AuditInfo {
+	ts := timestamppb.New(now)
+	return &AuditInfo{
+		CreatedBy:  principal,
+		CreatedAt:  ts,
+		ModifiedBy: principal,
+		ModifiedAt: ts,
+	}
+}
+
+// Touched returns the audit info of the resource after principal modified it at now.
+// A nil receiver is treated as a resource that is being created.
+func (a *AuditInfo) Touched(principal string, now time.Time) *AuditInfo {
+	if a == nil || a.GetCreatedAt() == nil {
+		return NewAuditInfo(principal, now)
+	}
+	return &AuditInfo{
+		CreatedBy:  a.GetCreatedBy(),
+		CreatedAt:  a.GetCreatedAt(),
+		ModifiedBy: principal,
+		ModifiedAt: timestamppb.New(now),
+	}
+}
+
+// Matches returns true if a resource with the given audit info is selected by the filter.
+// A nil filter matches everything.
+func (f *AuditFilter) Matches(info *AuditInfo) bool {
+	if f == nil {
+		return true
+	}
+	if f.GetCreatedBy() != "" && info.GetCreatedBy() != f.GetCreatedBy() {
+		return false
+	}
+	if f.GetModifiedBy() != "" && info.GetModifiedBy() != f.GetModifiedBy() {
+		return false
+	}
+	if f.ModifiedAfter != nil || f.ModifiedBefore != nil {
+		if info.GetModifiedAt() == nil {
+			return false
+		}
+		t := info.GetModifiedAt().AsTime()
+		if f.ModifiedAfter != nil && t.Before(f.GetModifiedAfter().AsTime()) {
+			return false
+		}
+		if f.ModifiedBefore != nil && !t.Before(f.GetModifiedBefore().AsTime()) {
+			return false
+		}
+	}
+	return true
+}
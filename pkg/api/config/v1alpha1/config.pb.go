@@ -9,6 +9,7 @@ package v1alpha1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
@@ -23,6 +24,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type FindingSeverity int32
+
+const (
+	FindingSeverity_FINDING_SEVERITY_UNSPECIFIED FindingSeverity = 0
+	FindingSeverity_FINDING_SEVERITY_ERROR       FindingSeverity = 1
+	FindingSeverity_FINDING_SEVERITY_WARNING     FindingSeverity = 2
+)
+
+// Enum value maps for FindingSeverity.
+var (
+	FindingSeverity_name = map[int32]string{
+		0: "FINDING_SEVERITY_UNSPECIFIED",
+		1: "FINDING_SEVERITY_ERROR",
+		2: "FINDING_SEVERITY_WARNING",
+	}
+	FindingSeverity_value = map[string]int32{
+		"FINDING_SEVERITY_UNSPECIFIED": 0,
+		"FINDING_SEVERITY_ERROR":       1,
+		"FINDING_SEVERITY_WARNING":     2,
+	}
+)
+
+func (x FindingSeverity) Enum() *FindingSeverity {
+	p := new(FindingSeverity)
+	*p = x
+	return p
+}
+
+func (x FindingSeverity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FindingSeverity) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_config_v1alpha1_config_proto_enumTypes[0].Descriptor()
+}
+
+func (FindingSeverity) Type() protoreflect.EnumType {
+	return &file_pkg_api_config_v1alpha1_config_proto_enumTypes[0]
+}
+
+func (x FindingSeverity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FindingSeverity.Descriptor instead.
+func (FindingSeverity) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{0}
+}
+
 // ConfigSource indicates how a config was assigned to an agent
 type ConfigSource int32
 
@@ -31,6 +81,10 @@ const (
 	ConfigSource_CONFIG_SOURCE_DEFAULT     ConfigSource = 1
 	ConfigSource_CONFIG_SOURCE_BOOTSTRAP   ConfigSource = 2
 	ConfigSource_CONFIG_SOURCE_MANUAL      ConfigSource = 3
+	// assigned by an AssignmentPolicy
+	ConfigSource_CONFIG_SOURCE_POLICY ConfigSource = 4
+	// the built-in config sent to agents with nothing assigned, only used in explanations
+	ConfigSource_CONFIG_SOURCE_FALLBACK ConfigSource = 5
 )
 
 // Enum value maps for ConfigSource.
@@ -40,12 +94,16 @@ var (
 		1: "CONFIG_SOURCE_DEFAULT",
 		2: "CONFIG_SOURCE_BOOTSTRAP",
 		3: "CONFIG_SOURCE_MANUAL",
+		4: "CONFIG_SOURCE_POLICY",
+		5: "CONFIG_SOURCE_FALLBACK",
 	}
 	ConfigSource_value = map[string]int32{
 		"CONFIG_SOURCE_UNSPECIFIED": 0,
 		"CONFIG_SOURCE_DEFAULT":     1,
 		"CONFIG_SOURCE_BOOTSTRAP":   2,
 		"CONFIG_SOURCE_MANUAL":      3,
+		"CONFIG_SOURCE_POLICY":      4,
+		"CONFIG_SOURCE_FALLBACK":    5,
 	}
 )
 
@@ -60,11 +118,11 @@ func (x ConfigSource) String() string {
 }
 
 func (ConfigSource) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_api_config_v1alpha1_config_proto_enumTypes[0].Descriptor()
+	return file_pkg_api_config_v1alpha1_config_proto_enumTypes[1].Descriptor()
 }
 
 func (ConfigSource) Type() protoreflect.EnumType {
-	return &file_pkg_api_config_v1alpha1_config_proto_enumTypes[0]
+	return &file_pkg_api_config_v1alpha1_config_proto_enumTypes[1]
 }
 
 func (x ConfigSource) Number() protoreflect.EnumNumber {
@@ -73,7 +131,7 @@ func (x ConfigSource) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ConfigSource.Descriptor instead.
 func (ConfigSource) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{0}
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{1}
 }
 
 // ConfigApplicationStatus indicates whether the agent has applied the config
@@ -84,6 +142,8 @@ const (
 	ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_PENDING     ConfigApplicationStatus = 1
 	ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_APPLIED     ConfigApplicationStatus = 2
 	ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_FAILED      ConfigApplicationStatus = 3
+	// the agent does not accept remote config, so the assignment is never applied
+	ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_UNSUPPORTED ConfigApplicationStatus = 4
 )
 
 // Enum value maps for ConfigApplicationStatus.
@@ -93,12 +153,14 @@ var (
 		1: "CONFIG_APPLICATION_STATUS_PENDING",
 		2: "CONFIG_APPLICATION_STATUS_APPLIED",
 		3: "CONFIG_APPLICATION_STATUS_FAILED",
+		4: "CONFIG_APPLICATION_STATUS_UNSUPPORTED",
 	}
 	ConfigApplicationStatus_value = map[string]int32{
 		"CONFIG_APPLICATION_STATUS_UNSPECIFIED": 0,
 		"CONFIG_APPLICATION_STATUS_PENDING":     1,
 		"CONFIG_APPLICATION_STATUS_APPLIED":     2,
 		"CONFIG_APPLICATION_STATUS_FAILED":      3,
+		"CONFIG_APPLICATION_STATUS_UNSUPPORTED": 4,
 	}
 )
 
@@ -113,11 +175,11 @@ func (x ConfigApplicationStatus) String() string {
 }
 
 func (ConfigApplicationStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_api_config_v1alpha1_config_proto_enumTypes[1].Descriptor()
+	return file_pkg_api_config_v1alpha1_config_proto_enumTypes[2].Descriptor()
 }
 
 func (ConfigApplicationStatus) Type() protoreflect.EnumType {
-	return &file_pkg_api_config_v1alpha1_config_proto_enumTypes[1]
+	return &file_pkg_api_config_v1alpha1_config_proto_enumTypes[2]
 }
 
 func (x ConfigApplicationStatus) Number() protoreflect.EnumNumber {
@@ -126,7 +188,7 @@ func (x ConfigApplicationStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ConfigApplicationStatus.Descriptor instead.
 func (ConfigApplicationStatus) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{1}
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{2}
 }
 
 // DeploymentState represents the overall state of a deployment
@@ -175,11 +237,11 @@ func (x DeploymentState) String() string {
 }
 
 func (DeploymentState) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_api_config_v1alpha1_config_proto_enumTypes[2].Descriptor()
+	return file_pkg_api_config_v1alpha1_config_proto_enumTypes[3].Descriptor()
 }
 
 func (DeploymentState) Type() protoreflect.EnumType {
-	return &file_pkg_api_config_v1alpha1_config_proto_enumTypes[2]
+	return &file_pkg_api_config_v1alpha1_config_proto_enumTypes[3]
 }
 
 func (x DeploymentState) Number() protoreflect.EnumNumber {
@@ -188,7 +250,7 @@ func (x DeploymentState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DeploymentState.Descriptor instead.
 func (DeploymentState) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{2}
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{3}
 }
 
 // AgentDeploymentState represents the state of deployment for a single agent
@@ -200,6 +262,9 @@ const (
 	AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLYING    AgentDeploymentState = 2
 	AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLIED     AgentDeploymentState = 3
 	AgentDeploymentState_AGENT_DEPLOYMENT_STATE_FAILED      AgentDeploymentState = 4
+	// the agent was left as is, e.g. by a rollback because its assignment changed since the
+	// rolled back deployment
+	AgentDeploymentState_AGENT_DEPLOYMENT_STATE_SKIPPED AgentDeploymentState = 5
 )
 
 // Enum value maps for AgentDeploymentState.
@@ -210,6 +275,7 @@ var (
 		2: "AGENT_DEPLOYMENT_STATE_APPLYING",
 		3: "AGENT_DEPLOYMENT_STATE_APPLIED",
 		4: "AGENT_DEPLOYMENT_STATE_FAILED",
+		5: "AGENT_DEPLOYMENT_STATE_SKIPPED",
 	}
 	AgentDeploymentState_value = map[string]int32{
 		"AGENT_DEPLOYMENT_STATE_UNSPECIFIED": 0,
@@ -217,6 +283,7 @@ var (
 		"AGENT_DEPLOYMENT_STATE_APPLYING":    2,
 		"AGENT_DEPLOYMENT_STATE_APPLIED":     3,
 		"AGENT_DEPLOYMENT_STATE_FAILED":      4,
+		"AGENT_DEPLOYMENT_STATE_SKIPPED":     5,
 	}
 )
 
@@ -231,11 +298,11 @@ func (x AgentDeploymentState) String() string {
 }
 
 func (AgentDeploymentState) Descriptor() protoreflect.EnumDescriptor {
-	return file_pkg_api_config_v1alpha1_config_proto_enumTypes[3].Descriptor()
+	return file_pkg_api_config_v1alpha1_config_proto_enumTypes[4].Descriptor()
 }
 
 func (AgentDeploymentState) Type() protoreflect.EnumType {
-	return &file_pkg_api_config_v1alpha1_config_proto_enumTypes[3]
+	return &file_pkg_api_config_v1alpha1_config_proto_enumTypes[4]
 }
 
 func (x AgentDeploymentState) Number() protoreflect.EnumNumber {
@@ -244,13 +311,189 @@ func (x AgentDeploymentState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use AgentDeploymentState.Descriptor instead.
 func (AgentDeploymentState) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{3}
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{4}
+}
+
+// DeploymentSkipReason explains why an agent targeted by a deployment would not apply the config
+type DeploymentSkipReason int32
+
+const (
+	DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_UNSPECIFIED DeploymentSkipReason = 0
+	// The agent is not registered, assigning the config to it fails
+	DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_NOT_FOUND DeploymentSkipReason = 1
+	// The agent is not connected, it applies the config once it reconnects
+	DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_OFFLINE DeploymentSkipReason = 2
+	// The agent does not accept remote config, or its collector distribution lacks
+	// components used by the config
+	DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_INCOMPATIBLE DeploymentSkipReason = 3
+)
+
+// Enum value maps for DeploymentSkipReason.
+var (
+	DeploymentSkipReason_name = map[int32]string{
+		0: "DEPLOYMENT_SKIP_REASON_UNSPECIFIED",
+		1: "DEPLOYMENT_SKIP_REASON_NOT_FOUND",
+		2: "DEPLOYMENT_SKIP_REASON_OFFLINE",
+		3: "DEPLOYMENT_SKIP_REASON_INCOMPATIBLE",
+	}
+	DeploymentSkipReason_value = map[string]int32{
+		"DEPLOYMENT_SKIP_REASON_UNSPECIFIED":  0,
+		"DEPLOYMENT_SKIP_REASON_NOT_FOUND":    1,
+		"DEPLOYMENT_SKIP_REASON_OFFLINE":      2,
+		"DEPLOYMENT_SKIP_REASON_INCOMPATIBLE": 3,
+	}
+)
+
+func (x DeploymentSkipReason) Enum() *DeploymentSkipReason {
+	p := new(DeploymentSkipReason)
+	*p = x
+	return p
+}
+
+func (x DeploymentSkipReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeploymentSkipReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_config_v1alpha1_config_proto_enumTypes[5].Descriptor()
+}
+
+func (DeploymentSkipReason) Type() protoreflect.EnumType {
+	return &file_pkg_api_config_v1alpha1_config_proto_enumTypes[5]
+}
+
+func (x DeploymentSkipReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeploymentSkipReason.Descriptor instead.
+func (DeploymentSkipReason) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{5}
+}
+
+type AgentConfigChange int32
+
+const (
+	AgentConfigChange_AGENT_CONFIG_CHANGE_UNSPECIFIED AgentConfigChange = 0
+	// a config was assigned to the agent
+	AgentConfigChange_AGENT_CONFIG_CHANGE_ASSIGNED AgentConfigChange = 1
+	// the agent's assignment was removed
+	AgentConfigChange_AGENT_CONFIG_CHANGE_UNASSIGNED AgentConfigChange = 2
+	// the agent reported applying a config
+	AgentConfigChange_AGENT_CONFIG_CHANGE_APPLIED AgentConfigChange = 3
+	// the agent reported failing to apply a config
+	AgentConfigChange_AGENT_CONFIG_CHANGE_FAILED AgentConfigChange = 4
+)
+
+// Enum value maps for AgentConfigChange.
+var (
+	AgentConfigChange_name = map[int32]string{
+		0: "AGENT_CONFIG_CHANGE_UNSPECIFIED",
+		1: "AGENT_CONFIG_CHANGE_ASSIGNED",
+		2: "AGENT_CONFIG_CHANGE_UNASSIGNED",
+		3: "AGENT_CONFIG_CHANGE_APPLIED",
+		4: "AGENT_CONFIG_CHANGE_FAILED",
+	}
+	AgentConfigChange_value = map[string]int32{
+		"AGENT_CONFIG_CHANGE_UNSPECIFIED": 0,
+		"AGENT_CONFIG_CHANGE_ASSIGNED":    1,
+		"AGENT_CONFIG_CHANGE_UNASSIGNED":  2,
+		"AGENT_CONFIG_CHANGE_APPLIED":     3,
+		"AGENT_CONFIG_CHANGE_FAILED":      4,
+	}
+)
+
+func (x AgentConfigChange) Enum() *AgentConfigChange {
+	p := new(AgentConfigChange)
+	*p = x
+	return p
+}
+
+func (x AgentConfigChange) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AgentConfigChange) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_config_v1alpha1_config_proto_enumTypes[6].Descriptor()
+}
+
+func (AgentConfigChange) Type() protoreflect.EnumType {
+	return &file_pkg_api_config_v1alpha1_config_proto_enumTypes[6]
+}
+
+func (x AgentConfigChange) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AgentConfigChange.Descriptor instead.
+func (AgentConfigChange) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{6}
+}
+
+type PackageInstallState int32
+
+const (
+	PackageInstallState_PACKAGE_INSTALL_STATE_UNSPECIFIED     PackageInstallState = 0
+	PackageInstallState_PACKAGE_INSTALL_STATE_INSTALLED       PackageInstallState = 1
+	PackageInstallState_PACKAGE_INSTALL_STATE_INSTALL_PENDING PackageInstallState = 2
+	PackageInstallState_PACKAGE_INSTALL_STATE_INSTALLING      PackageInstallState = 3
+	PackageInstallState_PACKAGE_INSTALL_STATE_INSTALL_FAILED  PackageInstallState = 4
+	PackageInstallState_PACKAGE_INSTALL_STATE_DOWNLOADING     PackageInstallState = 5
+)
+
+// Enum value maps for PackageInstallState.
+var (
+	PackageInstallState_name = map[int32]string{
+		0: "PACKAGE_INSTALL_STATE_UNSPECIFIED",
+		1: "PACKAGE_INSTALL_STATE_INSTALLED",
+		2: "PACKAGE_INSTALL_STATE_INSTALL_PENDING",
+		3: "PACKAGE_INSTALL_STATE_INSTALLING",
+		4: "PACKAGE_INSTALL_STATE_INSTALL_FAILED",
+		5: "PACKAGE_INSTALL_STATE_DOWNLOADING",
+	}
+	PackageInstallState_value = map[string]int32{
+		"PACKAGE_INSTALL_STATE_UNSPECIFIED":     0,
+		"PACKAGE_INSTALL_STATE_INSTALLED":       1,
+		"PACKAGE_INSTALL_STATE_INSTALL_PENDING": 2,
+		"PACKAGE_INSTALL_STATE_INSTALLING":      3,
+		"PACKAGE_INSTALL_STATE_INSTALL_FAILED":  4,
+		"PACKAGE_INSTALL_STATE_DOWNLOADING":     5,
+	}
+)
+
+func (x PackageInstallState) Enum() *PackageInstallState {
+	p := new(PackageInstallState)
+	*p = x
+	return p
+}
+
+func (x PackageInstallState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PackageInstallState) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_config_v1alpha1_config_proto_enumTypes[7].Descriptor()
+}
+
+func (PackageInstallState) Type() protoreflect.EnumType {
+	return &file_pkg_api_config_v1alpha1_config_proto_enumTypes[7]
+}
+
+func (x PackageInstallState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PackageInstallState.Descriptor instead.
+func (PackageInstallState) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{7}
 }
 
 type PutConfigRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ref           *ConfigReference       `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
-	Config        *Config                `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Ref    *ConfigReference       `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	Config *Config                `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// reject the config if it is invalid, with the findings of ValidConfig
+	Validate      bool `protobuf:"varint,3,opt,name=validate,proto3" json:"validate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -299,6 +542,13 @@ func (x *PutConfigRequest) GetConfig() *Config {
 	return nil
 }
 
+func (x *PutConfigRequest) GetValidate() bool {
+	if x != nil {
+		return x.Validate
+	}
+	return false
+}
+
 type ValidateConfigRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Config        *Config                `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
@@ -343,27 +593,29 @@ func (x *ValidateConfigRequest) GetConfig() *Config {
 	return nil
 }
 
-type ListConfigReponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Configs       []*ConfigReference     `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty"`
+type ValidateConfigDetailedRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Config *Config                `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	// also check the component policies applying to this agent, only fleet-wide ones when empty
+	AgentId       string `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListConfigReponse) Reset() {
-	*x = ListConfigReponse{}
+func (x *ValidateConfigDetailedRequest) Reset() {
+	*x = ValidateConfigDetailedRequest{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListConfigReponse) String() string {
+func (x *ValidateConfigDetailedRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListConfigReponse) ProtoMessage() {}
+func (*ValidateConfigDetailedRequest) ProtoMessage() {}
 
-func (x *ListConfigReponse) ProtoReflect() protoreflect.Message {
+func (x *ValidateConfigDetailedRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -375,39 +627,53 @@ func (x *ListConfigReponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListConfigReponse.ProtoReflect.Descriptor instead.
-func (*ListConfigReponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ValidateConfigDetailedRequest.ProtoReflect.Descriptor instead.
+func (*ValidateConfigDetailedRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ListConfigReponse) GetConfigs() []*ConfigReference {
+func (x *ValidateConfigDetailedRequest) GetConfig() *Config {
 	if x != nil {
-		return x.Configs
+		return x.Config
 	}
 	return nil
 }
 
-type ConfigReference struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *ValidateConfigDetailedRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+// ConfigFinding is a problem found in a config
+type ConfigFinding struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Severity FindingSeverity        `protobuf:"varint,1,opt,name=severity,proto3,enum=config.v1alpha1.FindingSeverity" json:"severity,omitempty"`
+	// stable identifier of the check producing the finding, e.g. yaml-syntax
+	RuleId  string `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// 1-based position in the config, 0 when unknown
+	Line          uint32 `protobuf:"varint,4,opt,name=line,proto3" json:"line,omitempty"`
+	Column        uint32 `protobuf:"varint,5,opt,name=column,proto3" json:"column,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConfigReference) Reset() {
-	*x = ConfigReference{}
+func (x *ConfigFinding) Reset() {
+	*x = ConfigFinding{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfigReference) String() string {
+func (x *ConfigFinding) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfigReference) ProtoMessage() {}
+func (*ConfigFinding) ProtoMessage() {}
 
-func (x *ConfigReference) ProtoReflect() protoreflect.Message {
+func (x *ConfigFinding) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -419,39 +685,70 @@ func (x *ConfigReference) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfigReference.ProtoReflect.Descriptor instead.
-func (*ConfigReference) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigFinding.ProtoReflect.Descriptor instead.
+func (*ConfigFinding) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *ConfigReference) GetId() string {
+func (x *ConfigFinding) GetSeverity() FindingSeverity {
 	if x != nil {
-		return x.Id
+		return x.Severity
+	}
+	return FindingSeverity_FINDING_SEVERITY_UNSPECIFIED
+}
+
+func (x *ConfigFinding) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
 	}
 	return ""
 }
 
-type Config struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Config        []byte                 `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+func (x *ConfigFinding) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ConfigFinding) GetLine() uint32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ConfigFinding) GetColumn() uint32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+type ConfigValidationResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// false if any finding is an error
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// findings ordered by position
+	Findings      []*ConfigFinding `protobuf:"bytes,2,rep,name=findings,proto3" json:"findings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Config) Reset() {
-	*x = Config{}
+func (x *ConfigValidationResult) Reset() {
+	*x = ConfigValidationResult{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Config) String() string {
+func (x *ConfigValidationResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Config) ProtoMessage() {}
+func (*ConfigValidationResult) ProtoMessage() {}
 
-func (x *Config) ProtoReflect() protoreflect.Message {
+func (x *ConfigValidationResult) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -463,40 +760,46 @@ func (x *Config) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Config.ProtoReflect.Descriptor instead.
-func (*Config) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigValidationResult.ProtoReflect.Descriptor instead.
+func (*ConfigValidationResult) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Config) GetConfig() []byte {
+func (x *ConfigValidationResult) GetValid() bool {
 	if x != nil {
-		return x.Config
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ConfigValidationResult) GetFindings() []*ConfigFinding {
+	if x != nil {
+		return x.Findings
 	}
 	return nil
 }
 
-type ConfigRange struct {
+type ListConfigsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	StartVersion  string                 `protobuf:"bytes,1,opt,name=startVersion,proto3" json:"startVersion,omitempty"`
-	EndVersion    string                 `protobuf:"bytes,2,opt,name=endVersion,proto3" json:"endVersion,omitempty"`
+	Filter        *AuditFilter           `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConfigRange) Reset() {
-	*x = ConfigRange{}
+func (x *ListConfigsRequest) Reset() {
+	*x = ListConfigsRequest{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfigRange) String() string {
+func (x *ListConfigsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfigRange) ProtoMessage() {}
+func (*ListConfigsRequest) ProtoMessage() {}
 
-func (x *ConfigRange) ProtoReflect() protoreflect.Message {
+func (x *ListConfigsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -508,46 +811,41 @@ func (x *ConfigRange) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfigRange.ProtoReflect.Descriptor instead.
-func (*ConfigRange) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListConfigsRequest.ProtoReflect.Descriptor instead.
+func (*ListConfigsRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ConfigRange) GetStartVersion() string {
-	if x != nil {
-		return x.StartVersion
-	}
-	return ""
-}
-
-func (x *ConfigRange) GetEndVersion() string {
+func (x *ListConfigsRequest) GetFilter() *AuditFilter {
 	if x != nil {
-		return x.EndVersion
+		return x.Filter
 	}
-	return ""
+	return nil
 }
 
-type Labels struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Labels        map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+type ListConfigReponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Configs []*ConfigReference     `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty"`
+	// metadata of the listed configs, by config ID
+	Metadata      map[string]*ConfigMetadata `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Labels) Reset() {
-	*x = Labels{}
+func (x *ListConfigReponse) Reset() {
+	*x = ListConfigReponse{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Labels) String() string {
+func (x *ListConfigReponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Labels) ProtoMessage() {}
+func (*ListConfigReponse) ProtoMessage() {}
 
-func (x *Labels) ProtoReflect() protoreflect.Message {
+func (x *ListConfigReponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -559,38 +857,46 @@ func (x *Labels) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Labels.ProtoReflect.Descriptor instead.
-func (*Labels) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListConfigReponse.ProtoReflect.Descriptor instead.
+func (*ListConfigReponse) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Labels) GetLabels() map[string]string {
+func (x *ListConfigReponse) GetConfigs() []*ConfigReference {
 	if x != nil {
-		return x.Labels
+		return x.Configs
 	}
 	return nil
 }
 
-type Matcher struct {
+func (x *ListConfigReponse) GetMetadata() map[string]*ConfigMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ConfigReference struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Matcher) Reset() {
-	*x = Matcher{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[7]
+func (x *ConfigReference) Reset() {
+	*x = ConfigReference{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Matcher) String() string {
+func (x *ConfigReference) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Matcher) ProtoMessage() {}
+func (*ConfigReference) ProtoMessage() {}
 
-func (x *Matcher) ProtoReflect() protoreflect.Message {
+func (x *ConfigReference) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -602,37 +908,40 @@ func (x *Matcher) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Matcher.ProtoReflect.Descriptor instead.
-func (*Matcher) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigReference.ProtoReflect.Descriptor instead.
+func (*ConfigReference) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{7}
 }
 
-// ConfigAssignment tracks metadata about a config assignment to an agent
-type ConfigAssignment struct {
+func (x *ConfigReference) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Config struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	ConfigId      string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
-	Source        ConfigSource           `protobuf:"varint,3,opt,name=source,proto3,enum=config.v1alpha1.ConfigSource" json:"source,omitempty"`
-	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
-	ConfigHash    []byte                 `protobuf:"bytes,5,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+	Config        []byte                 `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Metadata      *ConfigMetadata        `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConfigAssignment) Reset() {
-	*x = ConfigAssignment{}
+func (x *Config) Reset() {
+	*x = Config{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfigAssignment) String() string {
+func (x *Config) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfigAssignment) ProtoMessage() {}
+func (*Config) ProtoMessage() {}
 
-func (x *ConfigAssignment) ProtoReflect() protoreflect.Message {
+func (x *Config) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -644,68 +953,57 @@ func (x *ConfigAssignment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfigAssignment.ProtoReflect.Descriptor instead.
-func (*ConfigAssignment) Descriptor() ([]byte, []int) {
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ConfigAssignment) GetAgentId() string {
-	if x != nil {
-		return x.AgentId
-	}
-	return ""
-}
-
-func (x *ConfigAssignment) GetConfigId() string {
-	if x != nil {
-		return x.ConfigId
-	}
-	return ""
-}
-
-func (x *ConfigAssignment) GetSource() ConfigSource {
-	if x != nil {
-		return x.Source
-	}
-	return ConfigSource_CONFIG_SOURCE_UNSPECIFIED
-}
-
-func (x *ConfigAssignment) GetAssignedAt() *timestamppb.Timestamp {
+func (x *Config) GetConfig() []byte {
 	if x != nil {
-		return x.AssignedAt
+		return x.Config
 	}
 	return nil
 }
 
-func (x *ConfigAssignment) GetConfigHash() []byte {
+func (x *Config) GetMetadata() *ConfigMetadata {
 	if x != nil {
-		return x.ConfigHash
+		return x.Metadata
 	}
 	return nil
 }
 
-type AssignConfigRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	ConfigId      string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+// ConfigMetadata holds ownership information used to scope config management
+type ConfigMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// principal that created the config
+	Owner string   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Tags  []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// set by the server on every change
+	Audit *AuditInfo `protobuf:"bytes,3,opt,name=audit,proto3" json:"audit,omitempty"`
+	// free-form annotations, e.g. the lineage of configs copied from another server
+	Annotations map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// resources the config needs on the agent's host, checked against the capacity agents report
+	Resources *ResourceRequirements `protobuf:"bytes,5,opt,name=resources,proto3" json:"resources,omitempty"`
+	// tenant the config was created in, set by the server
+	Tenant        string `protobuf:"bytes,6,opt,name=tenant,proto3" json:"tenant,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssignConfigRequest) Reset() {
-	*x = AssignConfigRequest{}
+func (x *ConfigMetadata) Reset() {
+	*x = ConfigMetadata{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssignConfigRequest) String() string {
+func (x *ConfigMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssignConfigRequest) ProtoMessage() {}
+func (*ConfigMetadata) ProtoMessage() {}
 
-func (x *AssignConfigRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfigMetadata) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -717,47 +1015,76 @@ func (x *AssignConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssignConfigRequest.ProtoReflect.Descriptor instead.
-func (*AssignConfigRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigMetadata.ProtoReflect.Descriptor instead.
+func (*ConfigMetadata) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *AssignConfigRequest) GetAgentId() string {
+func (x *ConfigMetadata) GetOwner() string {
 	if x != nil {
-		return x.AgentId
+		return x.Owner
 	}
 	return ""
 }
 
-func (x *AssignConfigRequest) GetConfigId() string {
+func (x *ConfigMetadata) GetTags() []string {
 	if x != nil {
-		return x.ConfigId
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ConfigMetadata) GetAudit() *AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+func (x *ConfigMetadata) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+func (x *ConfigMetadata) GetResources() *ResourceRequirements {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *ConfigMetadata) GetTenant() string {
+	if x != nil {
+		return x.Tenant
 	}
 	return ""
 }
 
-type AssignConfigResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// ResourceRequirements are resource hints of a config. Unset fields are not checked.
+type ResourceRequirements struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MinMemoryBytes   uint64                 `protobuf:"varint,1,opt,name=min_memory_bytes,json=minMemoryBytes,proto3" json:"min_memory_bytes,omitempty"`
+	ExpectedCpuCores float64                `protobuf:"fixed64,2,opt,name=expected_cpu_cores,json=expectedCpuCores,proto3" json:"expected_cpu_cores,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *AssignConfigResponse) Reset() {
-	*x = AssignConfigResponse{}
+func (x *ResourceRequirements) Reset() {
+	*x = ResourceRequirements{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssignConfigResponse) String() string {
+func (x *ResourceRequirements) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssignConfigResponse) ProtoMessage() {}
+func (*ResourceRequirements) ProtoMessage() {}
 
-func (x *AssignConfigResponse) ProtoReflect() protoreflect.Message {
+func (x *ResourceRequirements) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -769,46 +1096,51 @@ func (x *AssignConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssignConfigResponse.ProtoReflect.Descriptor instead.
-func (*AssignConfigResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResourceRequirements.ProtoReflect.Descriptor instead.
+func (*ResourceRequirements) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *AssignConfigResponse) GetSuccess() bool {
+func (x *ResourceRequirements) GetMinMemoryBytes() uint64 {
 	if x != nil {
-		return x.Success
+		return x.MinMemoryBytes
 	}
-	return false
+	return 0
 }
 
-func (x *AssignConfigResponse) GetMessage() string {
+func (x *ResourceRequirements) GetExpectedCpuCores() float64 {
 	if x != nil {
-		return x.Message
+		return x.ExpectedCpuCores
 	}
-	return ""
+	return 0
 }
 
-type GetAgentConfigRequest struct {
+// AuditInfo records which principals created and last modified a resource.
+// Principals are empty for changes made by anonymous callers.
+type AuditInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,1,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ModifiedBy    string                 `protobuf:"bytes,3,opt,name=modified_by,json=modifiedBy,proto3" json:"modified_by,omitempty"`
+	ModifiedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAgentConfigRequest) Reset() {
-	*x = GetAgentConfigRequest{}
+func (x *AuditInfo) Reset() {
+	*x = AuditInfo{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAgentConfigRequest) String() string {
+func (x *AuditInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAgentConfigRequest) ProtoMessage() {}
+func (*AuditInfo) ProtoMessage() {}
 
-func (x *GetAgentConfigRequest) ProtoReflect() protoreflect.Message {
+func (x *AuditInfo) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -820,41 +1152,66 @@ func (x *GetAgentConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAgentConfigRequest.ProtoReflect.Descriptor instead.
-func (*GetAgentConfigRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AuditInfo.ProtoReflect.Descriptor instead.
+func (*AuditInfo) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetAgentConfigRequest) GetAgentId() string {
+func (x *AuditInfo) GetCreatedBy() string {
 	if x != nil {
-		return x.AgentId
+		return x.CreatedBy
 	}
 	return ""
 }
 
-type GetAgentConfigResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ConfigId      string                 `protobuf:"bytes,1,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
-	Source        ConfigSource           `protobuf:"varint,2,opt,name=source,proto3,enum=config.v1alpha1.ConfigSource" json:"source,omitempty"`
-	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AuditInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *GetAgentConfigResponse) Reset() {
-	*x = GetAgentConfigResponse{}
+func (x *AuditInfo) GetModifiedBy() string {
+	if x != nil {
+		return x.ModifiedBy
+	}
+	return ""
+}
+
+func (x *AuditInfo) GetModifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModifiedAt
+	}
+	return nil
+}
+
+// AuditFilter selects resources by their AuditInfo. Unset fields match everything.
+type AuditFilter struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CreatedBy  string                 `protobuf:"bytes,1,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	ModifiedBy string                 `protobuf:"bytes,2,opt,name=modified_by,json=modifiedBy,proto3" json:"modified_by,omitempty"`
+	// inclusive lower bound on the modification time
+	ModifiedAfter *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=modified_after,json=modifiedAfter,proto3" json:"modified_after,omitempty"`
+	// exclusive upper bound on the modification time
+	ModifiedBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=modified_before,json=modifiedBefore,proto3" json:"modified_before,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AuditFilter) Reset() {
+	*x = AuditFilter{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAgentConfigResponse) String() string {
+func (x *AuditFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAgentConfigResponse) ProtoMessage() {}
+func (*AuditFilter) ProtoMessage() {}
 
-func (x *GetAgentConfigResponse) ProtoReflect() protoreflect.Message {
+func (x *AuditFilter) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -866,53 +1223,65 @@ func (x *GetAgentConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAgentConfigResponse.ProtoReflect.Descriptor instead.
-func (*GetAgentConfigResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AuditFilter.ProtoReflect.Descriptor instead.
+func (*AuditFilter) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetAgentConfigResponse) GetConfigId() string {
+func (x *AuditFilter) GetCreatedBy() string {
 	if x != nil {
-		return x.ConfigId
+		return x.CreatedBy
 	}
 	return ""
 }
 
-func (x *GetAgentConfigResponse) GetSource() ConfigSource {
+func (x *AuditFilter) GetModifiedBy() string {
 	if x != nil {
-		return x.Source
+		return x.ModifiedBy
 	}
-	return ConfigSource_CONFIG_SOURCE_UNSPECIFIED
+	return ""
 }
 
-func (x *GetAgentConfigResponse) GetAssignedAt() *timestamppb.Timestamp {
+func (x *AuditFilter) GetModifiedAfter() *timestamppb.Timestamp {
 	if x != nil {
-		return x.AssignedAt
+		return x.ModifiedAfter
 	}
 	return nil
 }
 
-type UnassignConfigRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+func (x *AuditFilter) GetModifiedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModifiedBefore
+	}
+	return nil
+}
+
+// ConfigEditSession records the revision of a config an editor started from
+type ConfigEditSession struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ConfigId string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	// the config when the session began or was last saved, empty for new configs
+	Base          *Config                `protobuf:"bytes,3,opt,name=base,proto3" json:"base,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UnassignConfigRequest) Reset() {
-	*x = UnassignConfigRequest{}
+func (x *ConfigEditSession) Reset() {
+	*x = ConfigEditSession{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UnassignConfigRequest) String() string {
+func (x *ConfigEditSession) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnassignConfigRequest) ProtoMessage() {}
+func (*ConfigEditSession) ProtoMessage() {}
 
-func (x *UnassignConfigRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfigEditSession) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -924,39 +1293,63 @@ func (x *UnassignConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UnassignConfigRequest.ProtoReflect.Descriptor instead.
-func (*UnassignConfigRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigEditSession.ProtoReflect.Descriptor instead.
+func (*ConfigEditSession) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *UnassignConfigRequest) GetAgentId() string {
+func (x *ConfigEditSession) GetId() string {
 	if x != nil {
-		return x.AgentId
+		return x.Id
 	}
 	return ""
 }
 
-type UnassignConfigResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+func (x *ConfigEditSession) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *ConfigEditSession) GetBase() *Config {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ConfigEditSession) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type SaveConfigEditRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Ref       *ConfigReference       `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	SessionId string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// the edited config, its metadata replaces the current one when set
+	Config        *Config `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UnassignConfigResponse) Reset() {
-	*x = UnassignConfigResponse{}
+func (x *SaveConfigEditRequest) Reset() {
+	*x = SaveConfigEditRequest{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UnassignConfigResponse) String() string {
+func (x *SaveConfigEditRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnassignConfigResponse) ProtoMessage() {}
+func (*SaveConfigEditRequest) ProtoMessage() {}
 
-func (x *UnassignConfigResponse) ProtoReflect() protoreflect.Message {
+func (x *SaveConfigEditRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -968,39 +1361,59 @@ func (x *UnassignConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UnassignConfigResponse.ProtoReflect.Descriptor instead.
-func (*UnassignConfigResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SaveConfigEditRequest.ProtoReflect.Descriptor instead.
+func (*SaveConfigEditRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *UnassignConfigResponse) GetSuccess() bool {
+func (x *SaveConfigEditRequest) GetRef() *ConfigReference {
 	if x != nil {
-		return x.Success
+		return x.Ref
 	}
-	return false
+	return nil
 }
 
-type ListConfigAssignmentsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ConfigId      *string                `protobuf:"bytes,1,opt,name=config_id,json=configId,proto3,oneof" json:"config_id,omitempty"` // Filter by config
+func (x *SaveConfigEditRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SaveConfigEditRequest) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// ConfigMergeConflict is a value changed differently by the editor and concurrently by
+// someone else. Values are YAML, empty when absent.
+type ConfigMergeConflict struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// dot separated path of the value, e.g. exporters.otlp.endpoint
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Base          string `protobuf:"bytes,2,opt,name=base,proto3" json:"base,omitempty"`
+	Ours          string `protobuf:"bytes,3,opt,name=ours,proto3" json:"ours,omitempty"`
+	Theirs        string `protobuf:"bytes,4,opt,name=theirs,proto3" json:"theirs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListConfigAssignmentsRequest) Reset() {
-	*x = ListConfigAssignmentsRequest{}
+func (x *ConfigMergeConflict) Reset() {
+	*x = ConfigMergeConflict{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListConfigAssignmentsRequest) String() string {
+func (x *ConfigMergeConflict) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListConfigAssignmentsRequest) ProtoMessage() {}
+func (*ConfigMergeConflict) ProtoMessage() {}
 
-func (x *ListConfigAssignmentsRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfigMergeConflict) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1012,44 +1425,67 @@ func (x *ListConfigAssignmentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListConfigAssignmentsRequest.ProtoReflect.Descriptor instead.
-func (*ListConfigAssignmentsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigMergeConflict.ProtoReflect.Descriptor instead.
+func (*ConfigMergeConflict) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListConfigAssignmentsRequest) GetConfigId() string {
-	if x != nil && x.ConfigId != nil {
-		return *x.ConfigId
+func (x *ConfigMergeConflict) GetPath() string {
+	if x != nil {
+		return x.Path
 	}
 	return ""
 }
 
-type ConfigAssignmentInfo struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	AgentId       string                  `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	ConfigId      string                  `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
-	Source        ConfigSource            `protobuf:"varint,3,opt,name=source,proto3,enum=config.v1alpha1.ConfigSource" json:"source,omitempty"`
-	AssignedAt    *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
-	Status        ConfigApplicationStatus `protobuf:"varint,5,opt,name=status,proto3,enum=config.v1alpha1.ConfigApplicationStatus" json:"status,omitempty"`
-	ErrorMessage  string                  `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
+func (x *ConfigMergeConflict) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
 
-func (x *ConfigAssignmentInfo) Reset() {
-	*x = ConfigAssignmentInfo{}
+func (x *ConfigMergeConflict) GetOurs() string {
+	if x != nil {
+		return x.Ours
+	}
+	return ""
+}
+
+func (x *ConfigMergeConflict) GetTheirs() string {
+	if x != nil {
+		return x.Theirs
+	}
+	return ""
+}
+
+type SaveConfigEditResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// false if the changes conflict with concurrent ones, in which case nothing was saved
+	Saved bool `protobuf:"varint,1,opt,name=saved,proto3" json:"saved,omitempty"`
+	// true if concurrent changes were merged into the saved config
+	Merged bool `protobuf:"varint,2,opt,name=merged,proto3" json:"merged,omitempty"`
+	// the saved config, or the current one when not saved. The session continues from it,
+	// so that a resolution of the conflicts can be saved.
+	Config        *Config                `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	Conflicts     []*ConfigMergeConflict `protobuf:"bytes,4,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveConfigEditResult) Reset() {
+	*x = SaveConfigEditResult{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConfigAssignmentInfo) String() string {
+func (x *SaveConfigEditResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConfigAssignmentInfo) ProtoMessage() {}
+func (*SaveConfigEditResult) ProtoMessage() {}
 
-func (x *ConfigAssignmentInfo) ProtoReflect() protoreflect.Message {
+func (x *SaveConfigEditResult) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1061,74 +1497,61 @@ func (x *ConfigAssignmentInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConfigAssignmentInfo.ProtoReflect.Descriptor instead.
-func (*ConfigAssignmentInfo) Descriptor() ([]byte, []int) {
+// Deprecated: Use SaveConfigEditResult.ProtoReflect.Descriptor instead.
+func (*SaveConfigEditResult) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ConfigAssignmentInfo) GetAgentId() string {
-	if x != nil {
-		return x.AgentId
-	}
-	return ""
-}
-
-func (x *ConfigAssignmentInfo) GetConfigId() string {
+func (x *SaveConfigEditResult) GetSaved() bool {
 	if x != nil {
-		return x.ConfigId
+		return x.Saved
 	}
-	return ""
+	return false
 }
 
-func (x *ConfigAssignmentInfo) GetSource() ConfigSource {
+func (x *SaveConfigEditResult) GetMerged() bool {
 	if x != nil {
-		return x.Source
+		return x.Merged
 	}
-	return ConfigSource_CONFIG_SOURCE_UNSPECIFIED
+	return false
 }
 
-func (x *ConfigAssignmentInfo) GetAssignedAt() *timestamppb.Timestamp {
+func (x *SaveConfigEditResult) GetConfig() *Config {
 	if x != nil {
-		return x.AssignedAt
+		return x.Config
 	}
 	return nil
 }
 
-func (x *ConfigAssignmentInfo) GetStatus() ConfigApplicationStatus {
-	if x != nil {
-		return x.Status
-	}
-	return ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_UNSPECIFIED
-}
-
-func (x *ConfigAssignmentInfo) GetErrorMessage() string {
+func (x *SaveConfigEditResult) GetConflicts() []*ConfigMergeConflict {
 	if x != nil {
-		return x.ErrorMessage
+		return x.Conflicts
 	}
-	return ""
+	return nil
 }
 
-type ListConfigAssignmentsResponse struct {
-	state         protoimpl.MessageState  `protogen:"open.v1"`
-	Assignments   []*ConfigAssignmentInfo `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
+type ConfigRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartVersion  string                 `protobuf:"bytes,1,opt,name=startVersion,proto3" json:"startVersion,omitempty"`
+	EndVersion    string                 `protobuf:"bytes,2,opt,name=endVersion,proto3" json:"endVersion,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListConfigAssignmentsResponse) Reset() {
-	*x = ListConfigAssignmentsResponse{}
+func (x *ConfigRange) Reset() {
+	*x = ConfigRange{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListConfigAssignmentsResponse) String() string {
+func (x *ConfigRange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListConfigAssignmentsResponse) ProtoMessage() {}
+func (*ConfigRange) ProtoMessage() {}
 
-func (x *ListConfigAssignmentsResponse) ProtoReflect() protoreflect.Message {
+func (x *ConfigRange) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1140,39 +1563,46 @@ func (x *ListConfigAssignmentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListConfigAssignmentsResponse.ProtoReflect.Descriptor instead.
-func (*ListConfigAssignmentsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigRange.ProtoReflect.Descriptor instead.
+func (*ConfigRange) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ListConfigAssignmentsResponse) GetAssignments() []*ConfigAssignmentInfo {
+func (x *ConfigRange) GetStartVersion() string {
 	if x != nil {
-		return x.Assignments
+		return x.StartVersion
 	}
-	return nil
+	return ""
 }
 
-type GetConfigStatusRequest struct {
+func (x *ConfigRange) GetEndVersion() string {
+	if x != nil {
+		return x.EndVersion
+	}
+	return ""
+}
+
+type Labels struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConfigStatusRequest) Reset() {
-	*x = GetConfigStatusRequest{}
+func (x *Labels) Reset() {
+	*x = Labels{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConfigStatusRequest) String() string {
+func (x *Labels) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConfigStatusRequest) ProtoMessage() {}
+func (*Labels) ProtoMessage() {}
 
-func (x *GetConfigStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *Labels) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1184,42 +1614,38 @@ func (x *GetConfigStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConfigStatusRequest.ProtoReflect.Descriptor instead.
-func (*GetConfigStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Labels.ProtoReflect.Descriptor instead.
+func (*Labels) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *GetConfigStatusRequest) GetAgentId() string {
+func (x *Labels) GetLabels() map[string]string {
 	if x != nil {
-		return x.AgentId
+		return x.Labels
 	}
-	return ""
+	return nil
 }
 
-type GetConfigStatusResponse struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	Assignment          *ConfigAssignmentInfo  `protobuf:"bytes,1,opt,name=assignment,proto3" json:"assignment,omitempty"`
-	EffectiveConfigHash []byte                 `protobuf:"bytes,2,opt,name=effective_config_hash,json=effectiveConfigHash,proto3" json:"effective_config_hash,omitempty"` // What agent reports
-	AssignedConfigHash  []byte                 `protobuf:"bytes,3,opt,name=assigned_config_hash,json=assignedConfigHash,proto3" json:"assigned_config_hash,omitempty"`    // What we assigned
-	InSync              bool                   `protobuf:"varint,4,opt,name=in_sync,json=inSync,proto3" json:"in_sync,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+type Matcher struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetConfigStatusResponse) Reset() {
-	*x = GetConfigStatusResponse{}
+func (x *Matcher) Reset() {
+	*x = Matcher{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetConfigStatusResponse) String() string {
+func (x *Matcher) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetConfigStatusResponse) ProtoMessage() {}
+func (*Matcher) ProtoMessage() {}
 
-func (x *GetConfigStatusResponse) ProtoReflect() protoreflect.Message {
+func (x *Matcher) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1231,61 +1657,44 @@ func (x *GetConfigStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetConfigStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetConfigStatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Matcher.ProtoReflect.Descriptor instead.
+func (*Matcher) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetConfigStatusResponse) GetAssignment() *ConfigAssignmentInfo {
-	if x != nil {
-		return x.Assignment
-	}
-	return nil
-}
-
-func (x *GetConfigStatusResponse) GetEffectiveConfigHash() []byte {
-	if x != nil {
-		return x.EffectiveConfigHash
-	}
-	return nil
-}
-
-func (x *GetConfigStatusResponse) GetAssignedConfigHash() []byte {
-	if x != nil {
-		return x.AssignedConfigHash
-	}
-	return nil
-}
-
-func (x *GetConfigStatusResponse) GetInSync() bool {
-	if x != nil {
-		return x.InSync
-	}
-	return false
-}
-
-type BatchAssignConfigRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentIds      []string               `protobuf:"bytes,1,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
-	ConfigId      string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+// ConfigAssignment tracks metadata about a config assignment to an agent
+type ConfigAssignment struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	AgentId    string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ConfigId   string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	Source     ConfigSource           `protobuf:"varint,3,opt,name=source,proto3,enum=config.v1alpha1.ConfigSource" json:"source,omitempty"`
+	AssignedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	ConfigHash []byte                 `protobuf:"bytes,5,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+	Audit      *AuditInfo             `protobuf:"bytes,6,opt,name=audit,proto3" json:"audit,omitempty"`
+	// the policy that made the assignment, for CONFIG_SOURCE_POLICY
+	PolicyId string `protobuf:"bytes,7,opt,name=policy_id,json=policyId,proto3" json:"policy_id,omitempty"`
+	// roll the agent back to its last known-good config if it fails to apply this one
+	AutoRollback bool `protobuf:"varint,8,opt,name=auto_rollback,json=autoRollback,proto3" json:"auto_rollback,omitempty"`
+	// set when the assignment was restored by a rollback
+	Rollback      *ConfigRollback `protobuf:"bytes,9,opt,name=rollback,proto3" json:"rollback,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchAssignConfigRequest) Reset() {
-	*x = BatchAssignConfigRequest{}
+func (x *ConfigAssignment) Reset() {
+	*x = ConfigAssignment{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchAssignConfigRequest) String() string {
+func (x *ConfigAssignment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchAssignConfigRequest) ProtoMessage() {}
+func (*ConfigAssignment) ProtoMessage() {}
 
-func (x *BatchAssignConfigRequest) ProtoReflect() protoreflect.Message {
+func (x *ConfigAssignment) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1297,49 +1706,101 @@ func (x *BatchAssignConfigRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchAssignConfigRequest.ProtoReflect.Descriptor instead.
-func (*BatchAssignConfigRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigAssignment.ProtoReflect.Descriptor instead.
+func (*ConfigAssignment) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *BatchAssignConfigRequest) GetAgentIds() []string {
+func (x *ConfigAssignment) GetAgentId() string {
 	if x != nil {
-		return x.AgentIds
+		return x.AgentId
 	}
-	return nil
+	return ""
 }
 
-func (x *BatchAssignConfigRequest) GetConfigId() string {
+func (x *ConfigAssignment) GetConfigId() string {
 	if x != nil {
 		return x.ConfigId
 	}
 	return ""
 }
 
-type BatchAssignConfigResponse struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Successful     int32                  `protobuf:"varint,1,opt,name=successful,proto3" json:"successful,omitempty"`
-	Failed         int32                  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
-	FailedAgentIds []string               `protobuf:"bytes,3,rep,name=failed_agent_ids,json=failedAgentIds,proto3" json:"failed_agent_ids,omitempty"`
-	ErrorMessages  []string               `protobuf:"bytes,4,rep,name=error_messages,json=errorMessages,proto3" json:"error_messages,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *ConfigAssignment) GetSource() ConfigSource {
+	if x != nil {
+		return x.Source
+	}
+	return ConfigSource_CONFIG_SOURCE_UNSPECIFIED
 }
 
-func (x *BatchAssignConfigResponse) Reset() {
-	*x = BatchAssignConfigResponse{}
+func (x *ConfigAssignment) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *ConfigAssignment) GetConfigHash() []byte {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return nil
+}
+
+func (x *ConfigAssignment) GetAudit() *AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+func (x *ConfigAssignment) GetPolicyId() string {
+	if x != nil {
+		return x.PolicyId
+	}
+	return ""
+}
+
+func (x *ConfigAssignment) GetAutoRollback() bool {
+	if x != nil {
+		return x.AutoRollback
+	}
+	return false
+}
+
+func (x *ConfigAssignment) GetRollback() *ConfigRollback {
+	if x != nil {
+		return x.Rollback
+	}
+	return nil
+}
+
+// ConfigRollback records that an agent was rolled back to its last known-good config
+// after failing to apply its assigned config
+type ConfigRollback struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the config the agent failed to apply
+	FailedConfigId   string                 `protobuf:"bytes,1,opt,name=failed_config_id,json=failedConfigId,proto3" json:"failed_config_id,omitempty"`
+	FailedConfigHash []byte                 `protobuf:"bytes,2,opt,name=failed_config_hash,json=failedConfigHash,proto3" json:"failed_config_hash,omitempty"`
+	ErrorMessage     string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	RolledBackAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=rolled_back_at,json=rolledBackAt,proto3" json:"rolled_back_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ConfigRollback) Reset() {
+	*x = ConfigRollback{}
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchAssignConfigResponse) String() string {
+func (x *ConfigRollback) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchAssignConfigResponse) ProtoMessage() {}
+func (*ConfigRollback) ProtoMessage() {}
 
-func (x *BatchAssignConfigResponse) ProtoReflect() protoreflect.Message {
+func (x *ConfigRollback) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1351,62 +1812,4024 @@ func (x *BatchAssignConfigResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchAssignConfigResponse.ProtoReflect.Descriptor instead.
-func (*BatchAssignConfigResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ConfigRollback.ProtoReflect.Descriptor instead.
+func (*ConfigRollback) Descriptor() ([]byte, []int) {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *BatchAssignConfigResponse) GetSuccessful() int32 {
+func (x *ConfigRollback) GetFailedConfigId() string {
 	if x != nil {
-		return x.Successful
+		return x.FailedConfigId
 	}
-	return 0
+	return ""
+}
+
+func (x *ConfigRollback) GetFailedConfigHash() []byte {
+	if x != nil {
+		return x.FailedConfigHash
+	}
+	return nil
+}
+
+func (x *ConfigRollback) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ConfigRollback) GetRolledBackAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RolledBackAt
+	}
+	return nil
+}
+
+// KnownGoodConfig is the last assigned config an agent reported applying
+type KnownGoodConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignment    *ConfigAssignment      `protobuf:"bytes,1,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	Config        *Config                `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	AppliedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KnownGoodConfig) Reset() {
+	*x = KnownGoodConfig{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KnownGoodConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KnownGoodConfig) ProtoMessage() {}
+
+func (x *KnownGoodConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KnownGoodConfig.ProtoReflect.Descriptor instead.
+func (*KnownGoodConfig) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *KnownGoodConfig) GetAssignment() *ConfigAssignment {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+func (x *KnownGoodConfig) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *KnownGoodConfig) GetAppliedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return nil
+}
+
+type AssignConfigRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	AgentId  string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ConfigId string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	// source is MANUAL (the default) to assign config_id, or DEFAULT to have the agent
+	// intentionally follow the global default config, in which case config_id must be empty
+	Source ConfigSource `protobuf:"varint,3,opt,name=source,proto3,enum=config.v1alpha1.ConfigSource" json:"source,omitempty"`
+	// roll the agent back to its last known-good config if it fails to apply the config
+	AutoRollback  bool `protobuf:"varint,4,opt,name=auto_rollback,json=autoRollback,proto3" json:"auto_rollback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignConfigRequest) Reset() {
+	*x = AssignConfigRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignConfigRequest) ProtoMessage() {}
+
+func (x *AssignConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignConfigRequest.ProtoReflect.Descriptor instead.
+func (*AssignConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AssignConfigRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AssignConfigRequest) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *AssignConfigRequest) GetSource() ConfigSource {
+	if x != nil {
+		return x.Source
+	}
+	return ConfigSource_CONFIG_SOURCE_UNSPECIFIED
+}
+
+func (x *AssignConfigRequest) GetAutoRollback() bool {
+	if x != nil {
+		return x.AutoRollback
+	}
+	return false
+}
+
+type AssignConfigResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// the config's resource requirements exceeding the capacity reported by the agent
+	Warnings      []string `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignConfigResponse) Reset() {
+	*x = AssignConfigResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignConfigResponse) ProtoMessage() {}
+
+func (x *AssignConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignConfigResponse.ProtoReflect.Descriptor instead.
+func (*AssignConfigResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AssignConfigResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AssignConfigResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AssignConfigResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type GetAgentConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentConfigRequest) Reset() {
+	*x = GetAgentConfigRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentConfigRequest) ProtoMessage() {}
+
+func (x *GetAgentConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetAgentConfigRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type GetAgentConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConfigId      string                 `protobuf:"bytes,1,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	Source        ConfigSource           `protobuf:"varint,2,opt,name=source,proto3,enum=config.v1alpha1.ConfigSource" json:"source,omitempty"`
+	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentConfigResponse) Reset() {
+	*x = GetAgentConfigResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentConfigResponse) ProtoMessage() {}
+
+func (x *GetAgentConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentConfigResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetAgentConfigResponse) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *GetAgentConfigResponse) GetSource() ConfigSource {
+	if x != nil {
+		return x.Source
+	}
+	return ConfigSource_CONFIG_SOURCE_UNSPECIFIED
+}
+
+func (x *GetAgentConfigResponse) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+type UnassignConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignConfigRequest) Reset() {
+	*x = UnassignConfigRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignConfigRequest) ProtoMessage() {}
+
+func (x *UnassignConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignConfigRequest.ProtoReflect.Descriptor instead.
+func (*UnassignConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UnassignConfigRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type UnassignConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignConfigResponse) Reset() {
+	*x = UnassignConfigResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignConfigResponse) ProtoMessage() {}
+
+func (x *UnassignConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignConfigResponse.ProtoReflect.Descriptor instead.
+func (*UnassignConfigResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UnassignConfigResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListConfigAssignmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConfigId      *string                `protobuf:"bytes,1,opt,name=config_id,json=configId,proto3,oneof" json:"config_id,omitempty"` // Filter by config
+	AuditFilter   *AuditFilter           `protobuf:"bytes,2,opt,name=audit_filter,json=auditFilter,proto3" json:"audit_filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigAssignmentsRequest) Reset() {
+	*x = ListConfigAssignmentsRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigAssignmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigAssignmentsRequest) ProtoMessage() {}
+
+func (x *ListConfigAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListConfigAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListConfigAssignmentsRequest) GetConfigId() string {
+	if x != nil && x.ConfigId != nil {
+		return *x.ConfigId
+	}
+	return ""
+}
+
+func (x *ListConfigAssignmentsRequest) GetAuditFilter() *AuditFilter {
+	if x != nil {
+		return x.AuditFilter
+	}
+	return nil
+}
+
+type ConfigAssignmentInfo struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	AgentId       string                  `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ConfigId      string                  `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	Source        ConfigSource            `protobuf:"varint,3,opt,name=source,proto3,enum=config.v1alpha1.ConfigSource" json:"source,omitempty"`
+	AssignedAt    *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	Status        ConfigApplicationStatus `protobuf:"varint,5,opt,name=status,proto3,enum=config.v1alpha1.ConfigApplicationStatus" json:"status,omitempty"`
+	ErrorMessage  string                  `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Audit         *AuditInfo              `protobuf:"bytes,7,opt,name=audit,proto3" json:"audit,omitempty"`
+	Rollback      *ConfigRollback         `protobuf:"bytes,8,opt,name=rollback,proto3" json:"rollback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigAssignmentInfo) Reset() {
+	*x = ConfigAssignmentInfo{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigAssignmentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigAssignmentInfo) ProtoMessage() {}
+
+func (x *ConfigAssignmentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigAssignmentInfo.ProtoReflect.Descriptor instead.
+func (*ConfigAssignmentInfo) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ConfigAssignmentInfo) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ConfigAssignmentInfo) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *ConfigAssignmentInfo) GetSource() ConfigSource {
+	if x != nil {
+		return x.Source
+	}
+	return ConfigSource_CONFIG_SOURCE_UNSPECIFIED
+}
+
+func (x *ConfigAssignmentInfo) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *ConfigAssignmentInfo) GetStatus() ConfigApplicationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_UNSPECIFIED
+}
+
+func (x *ConfigAssignmentInfo) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ConfigAssignmentInfo) GetAudit() *AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+func (x *ConfigAssignmentInfo) GetRollback() *ConfigRollback {
+	if x != nil {
+		return x.Rollback
+	}
+	return nil
+}
+
+type ListConfigAssignmentsResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Assignments   []*ConfigAssignmentInfo `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigAssignmentsResponse) Reset() {
+	*x = ListConfigAssignmentsResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigAssignmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigAssignmentsResponse) ProtoMessage() {}
+
+func (x *ListConfigAssignmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigAssignmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListConfigAssignmentsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListConfigAssignmentsResponse) GetAssignments() []*ConfigAssignmentInfo {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+type GetConfigStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigStatusRequest) Reset() {
+	*x = GetConfigStatusRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigStatusRequest) ProtoMessage() {}
+
+func (x *GetConfigStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigStatusRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetConfigStatusRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type GetConfigStatusResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Assignment          *ConfigAssignmentInfo  `protobuf:"bytes,1,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	EffectiveConfigHash []byte                 `protobuf:"bytes,2,opt,name=effective_config_hash,json=effectiveConfigHash,proto3" json:"effective_config_hash,omitempty"` // What agent reports
+	AssignedConfigHash  []byte                 `protobuf:"bytes,3,opt,name=assigned_config_hash,json=assignedConfigHash,proto3" json:"assigned_config_hash,omitempty"`    // What we assigned
+	InSync              bool                   `protobuf:"varint,4,opt,name=in_sync,json=inSync,proto3" json:"in_sync,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetConfigStatusResponse) Reset() {
+	*x = GetConfigStatusResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConfigStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigStatusResponse) ProtoMessage() {}
+
+func (x *GetConfigStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigStatusResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetConfigStatusResponse) GetAssignment() *ConfigAssignmentInfo {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+func (x *GetConfigStatusResponse) GetEffectiveConfigHash() []byte {
+	if x != nil {
+		return x.EffectiveConfigHash
+	}
+	return nil
+}
+
+func (x *GetConfigStatusResponse) GetAssignedConfigHash() []byte {
+	if x != nil {
+		return x.AssignedConfigHash
+	}
+	return nil
+}
+
+func (x *GetConfigStatusResponse) GetInSync() bool {
+	if x != nil {
+		return x.InSync
+	}
+	return false
+}
+
+type BatchAssignConfigRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	AgentIds []string               `protobuf:"bytes,1,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	ConfigId string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	// Runs the assignment as a background job: the response only carries the job ID,
+	// and the job result is the BatchAssignConfigResponse
+	Async         bool `protobuf:"varint,3,opt,name=async,proto3" json:"async,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchAssignConfigRequest) Reset() {
+	*x = BatchAssignConfigRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchAssignConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchAssignConfigRequest) ProtoMessage() {}
+
+func (x *BatchAssignConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchAssignConfigRequest.ProtoReflect.Descriptor instead.
+func (*BatchAssignConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *BatchAssignConfigRequest) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *BatchAssignConfigRequest) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *BatchAssignConfigRequest) GetAsync() bool {
+	if x != nil {
+		return x.Async
+	}
+	return false
+}
+
+type BatchAssignConfigResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Successful     int32                  `protobuf:"varint,1,opt,name=successful,proto3" json:"successful,omitempty"`
+	Failed         int32                  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	FailedAgentIds []string               `protobuf:"bytes,3,rep,name=failed_agent_ids,json=failedAgentIds,proto3" json:"failed_agent_ids,omitempty"`
+	ErrorMessages  []string               `protobuf:"bytes,4,rep,name=error_messages,json=errorMessages,proto3" json:"error_messages,omitempty"`
+	// ID of the background job, for async requests
+	JobId         string `protobuf:"bytes,5,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchAssignConfigResponse) Reset() {
+	*x = BatchAssignConfigResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchAssignConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchAssignConfigResponse) ProtoMessage() {}
+
+func (x *BatchAssignConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchAssignConfigResponse.ProtoReflect.Descriptor instead.
+func (*BatchAssignConfigResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *BatchAssignConfigResponse) GetSuccessful() int32 {
+	if x != nil {
+		return x.Successful
+	}
+	return 0
+}
+
+func (x *BatchAssignConfigResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BatchAssignConfigResponse) GetFailedAgentIds() []string {
+	if x != nil {
+		return x.FailedAgentIds
+	}
+	return nil
+}
+
+func (x *BatchAssignConfigResponse) GetErrorMessages() []string {
+	if x != nil {
+		return x.ErrorMessages
+	}
+	return nil
+}
+
+func (x *BatchAssignConfigResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type AssignConfigByLabelsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Labels   map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Agent labels to match
+	ConfigId string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	// label selector expression agents must also match, e.g. "env in (prod,staging),!legacy".
+	// Groups of comma separated requirements are separated by ||, agents matching any group
+	// are selected. labels or selector_expression must be non-empty.
+	SelectorExpression string `protobuf:"bytes,3,opt,name=selector_expression,json=selectorExpression,proto3" json:"selector_expression,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AssignConfigByLabelsRequest) Reset() {
+	*x = AssignConfigByLabelsRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignConfigByLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignConfigByLabelsRequest) ProtoMessage() {}
+
+func (x *AssignConfigByLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignConfigByLabelsRequest.ProtoReflect.Descriptor instead.
+func (*AssignConfigByLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AssignConfigByLabelsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *AssignConfigByLabelsRequest) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *AssignConfigByLabelsRequest) GetSelectorExpression() string {
+	if x != nil {
+		return x.SelectorExpression
+	}
+	return ""
+}
+
+type AssignConfigByLabelsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MatchedAgentIds []string               `protobuf:"bytes,1,rep,name=matched_agent_ids,json=matchedAgentIds,proto3" json:"matched_agent_ids,omitempty"`
+	Successful      int32                  `protobuf:"varint,2,opt,name=successful,proto3" json:"successful,omitempty"`
+	Failed          int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AssignConfigByLabelsResponse) Reset() {
+	*x = AssignConfigByLabelsResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignConfigByLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignConfigByLabelsResponse) ProtoMessage() {}
+
+func (x *AssignConfigByLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignConfigByLabelsResponse.ProtoReflect.Descriptor instead.
+func (*AssignConfigByLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AssignConfigByLabelsResponse) GetMatchedAgentIds() []string {
+	if x != nil {
+		return x.MatchedAgentIds
+	}
+	return nil
+}
+
+func (x *AssignConfigByLabelsResponse) GetSuccessful() int32 {
+	if x != nil {
+		return x.Successful
+	}
+	return 0
+}
+
+func (x *AssignConfigByLabelsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+// AssignmentPolicy assigns config_id to every agent matching selector, unless the agent
+// has a manual or explicit default assignment. When several policies match an agent the
+// one with the highest priority applies, ties are broken by policy ID.
+type AssignmentPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// agent labels that must all match. One of selector, selector_expression and agent_ids
+	// must be non-empty.
+	Selector map[string]string `protobuf:"bytes,2,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ConfigId string            `protobuf:"bytes,3,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	Priority int32             `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	// set by the server on every change
+	Audit *AuditInfo `protobuf:"bytes,5,opt,name=audit,proto3" json:"audit,omitempty"`
+	// agents the policy applies to regardless of their labels
+	AgentIds []string `protobuf:"bytes,6,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	// label selector expression agents must also match, see AssignConfigByLabelsRequest
+	SelectorExpression string `protobuf:"bytes,7,opt,name=selector_expression,json=selectorExpression,proto3" json:"selector_expression,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AssignmentPolicy) Reset() {
+	*x = AssignmentPolicy{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentPolicy) ProtoMessage() {}
+
+func (x *AssignmentPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentPolicy.ProtoReflect.Descriptor instead.
+func (*AssignmentPolicy) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *AssignmentPolicy) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AssignmentPolicy) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *AssignmentPolicy) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *AssignmentPolicy) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *AssignmentPolicy) GetAudit() *AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+func (x *AssignmentPolicy) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *AssignmentPolicy) GetSelectorExpression() string {
+	if x != nil {
+		return x.SelectorExpression
+	}
+	return ""
+}
+
+type PutAssignmentPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *AssignmentPolicy      `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutAssignmentPolicyRequest) Reset() {
+	*x = PutAssignmentPolicyRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutAssignmentPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutAssignmentPolicyRequest) ProtoMessage() {}
+
+func (x *PutAssignmentPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutAssignmentPolicyRequest.ProtoReflect.Descriptor instead.
+func (*PutAssignmentPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PutAssignmentPolicyRequest) GetPolicy() *AssignmentPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type AssignmentPolicyReference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentPolicyReference) Reset() {
+	*x = AssignmentPolicyReference{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentPolicyReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentPolicyReference) ProtoMessage() {}
+
+func (x *AssignmentPolicyReference) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentPolicyReference.ProtoReflect.Descriptor instead.
+func (*AssignmentPolicyReference) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AssignmentPolicyReference) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListAssignmentPoliciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignmentPoliciesRequest) Reset() {
+	*x = ListAssignmentPoliciesRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignmentPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignmentPoliciesRequest) ProtoMessage() {}
+
+func (x *ListAssignmentPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignmentPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListAssignmentPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{41}
+}
+
+// AgentGroup is a named set of agents : the agents matching its selector, and its explicit
+// members. The config of a group is assigned to its agents like the config of an assignment
+// policy with the group's priority, including to the agents joining the group later.
+type AgentGroup struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// agent labels that must all match
+	Selector map[string]string `protobuf:"bytes,3,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// agents in the group regardless of their labels
+	AgentIds []string `protobuf:"bytes,4,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	// optional, the config assigned to the agents of the group
+	ConfigId string `protobuf:"bytes,5,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	Priority int32  `protobuf:"varint,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	// set by the server on every change
+	Audit *AuditInfo `protobuf:"bytes,7,opt,name=audit,proto3" json:"audit,omitempty"`
+	// label selector expression agents must also match, see AssignConfigByLabelsRequest
+	SelectorExpression string `protobuf:"bytes,8,opt,name=selector_expression,json=selectorExpression,proto3" json:"selector_expression,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AgentGroup) Reset() {
+	*x = AgentGroup{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentGroup) ProtoMessage() {}
+
+func (x *AgentGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentGroup.ProtoReflect.Descriptor instead.
+func (*AgentGroup) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *AgentGroup) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AgentGroup) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AgentGroup) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *AgentGroup) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *AgentGroup) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *AgentGroup) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *AgentGroup) GetAudit() *AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+func (x *AgentGroup) GetSelectorExpression() string {
+	if x != nil {
+		return x.SelectorExpression
+	}
+	return ""
+}
+
+type CreateGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *AgentGroup            `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGroupRequest) Reset() {
+	*x = CreateGroupRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupRequest) ProtoMessage() {}
+
+func (x *CreateGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateGroupRequest) GetGroup() *AgentGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+type UpdateGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *AgentGroup            `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGroupRequest) Reset() {
+	*x = UpdateGroupRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGroupRequest) ProtoMessage() {}
+
+func (x *UpdateGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGroupRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGroupRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UpdateGroupRequest) GetGroup() *AgentGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+type AgentGroupReference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AgentGroupReference) Reset() {
+	*x = AgentGroupReference{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentGroupReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentGroupReference) ProtoMessage() {}
+
+func (x *AgentGroupReference) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentGroupReference.ProtoReflect.Descriptor instead.
+func (*AgentGroupReference) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *AgentGroupReference) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListGroupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupsRequest) Reset() {
+	*x = ListGroupsRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsRequest) ProtoMessage() {}
+
+func (x *ListGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{46}
+}
+
+type ListGroupsResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Groups []*AgentGroup          `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	// number of agents in each group, by group ID
+	AgentCounts   map[string]int32 `protobuf:"bytes,2,rep,name=agent_counts,json=agentCounts,proto3" json:"agent_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupsResponse) Reset() {
+	*x = ListGroupsResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsResponse) ProtoMessage() {}
+
+func (x *ListGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListGroupsResponse) GetGroups() []*AgentGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *ListGroupsResponse) GetAgentCounts() map[string]int32 {
+	if x != nil {
+		return x.AgentCounts
+	}
+	return nil
+}
+
+// AssignmentPolicyConflict reports an agent matched by policies assigning different configs
+type AssignmentPolicyConflict struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// the matching policies, in order of precedence
+	PolicyIds []string `protobuf:"bytes,2,rep,name=policy_ids,json=policyIds,proto3" json:"policy_ids,omitempty"`
+	// the policy that applies, empty if the agent is manually overridden
+	AppliedPolicyId string `protobuf:"bytes,3,opt,name=applied_policy_id,json=appliedPolicyId,proto3" json:"applied_policy_id,omitempty"`
+	// set when several of policy_ids share the highest priority, which is usually unintended
+	Ambiguous     bool `protobuf:"varint,4,opt,name=ambiguous,proto3" json:"ambiguous,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentPolicyConflict) Reset() {
+	*x = AssignmentPolicyConflict{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentPolicyConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentPolicyConflict) ProtoMessage() {}
+
+func (x *AssignmentPolicyConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentPolicyConflict.ProtoReflect.Descriptor instead.
+func (*AssignmentPolicyConflict) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *AssignmentPolicyConflict) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AssignmentPolicyConflict) GetPolicyIds() []string {
+	if x != nil {
+		return x.PolicyIds
+	}
+	return nil
+}
+
+func (x *AssignmentPolicyConflict) GetAppliedPolicyId() string {
+	if x != nil {
+		return x.AppliedPolicyId
+	}
+	return ""
+}
+
+func (x *AssignmentPolicyConflict) GetAmbiguous() bool {
+	if x != nil {
+		return x.Ambiguous
+	}
+	return false
+}
+
+type ListAssignmentPoliciesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// in order of precedence
+	Policies  []*AssignmentPolicy         `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	Conflicts []*AssignmentPolicyConflict `protobuf:"bytes,2,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	// number of agents each policy currently applies to, by policy ID
+	AppliedAgents map[string]int32 `protobuf:"bytes,3,rep,name=applied_agents,json=appliedAgents,proto3" json:"applied_agents,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignmentPoliciesResponse) Reset() {
+	*x = ListAssignmentPoliciesResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignmentPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignmentPoliciesResponse) ProtoMessage() {}
+
+func (x *ListAssignmentPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignmentPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListAssignmentPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListAssignmentPoliciesResponse) GetPolicies() []*AssignmentPolicy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *ListAssignmentPoliciesResponse) GetConflicts() []*AssignmentPolicyConflict {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+func (x *ListAssignmentPoliciesResponse) GetAppliedAgents() map[string]int32 {
+	if x != nil {
+		return x.AppliedAgents
+	}
+	return nil
+}
+
+type GetAssignmentExplanationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssignmentExplanationRequest) Reset() {
+	*x = GetAssignmentExplanationRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssignmentExplanationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssignmentExplanationRequest) ProtoMessage() {}
+
+func (x *GetAssignmentExplanationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssignmentExplanationRequest.ProtoReflect.Descriptor instead.
+func (*GetAssignmentExplanationRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetAssignmentExplanationRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+// AssignmentCandidate is a source that could assign a config to an agent
+type AssignmentCandidate struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Source ConfigSource           `protobuf:"varint,1,opt,name=source,proto3,enum=config.v1alpha1.ConfigSource" json:"source,omitempty"`
+	// empty for the DEFAULT and FALLBACK sources
+	ConfigId string `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	// for CONFIG_SOURCE_POLICY
+	PolicyId string `protobuf:"bytes,3,opt,name=policy_id,json=policyId,proto3" json:"policy_id,omitempty"`
+	// set on the candidate whose config is assigned to the agent
+	Effective bool `protobuf:"varint,4,opt,name=effective,proto3" json:"effective,omitempty"`
+	// why the candidate applies or is overridden
+	Reason        string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentCandidate) Reset() {
+	*x = AssignmentCandidate{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentCandidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentCandidate) ProtoMessage() {}
+
+func (x *AssignmentCandidate) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentCandidate.ProtoReflect.Descriptor instead.
+func (*AssignmentCandidate) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *AssignmentCandidate) GetSource() ConfigSource {
+	if x != nil {
+		return x.Source
+	}
+	return ConfigSource_CONFIG_SOURCE_UNSPECIFIED
+}
+
+func (x *AssignmentCandidate) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *AssignmentCandidate) GetPolicyId() string {
+	if x != nil {
+		return x.PolicyId
+	}
+	return ""
+}
+
+func (x *AssignmentCandidate) GetEffective() bool {
+	if x != nil {
+		return x.Effective
+	}
+	return false
+}
+
+func (x *AssignmentCandidate) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// AssignmentExplanation lists the candidate config sources of an agent in order of
+// precedence, by default manual assignments, then assignment policies, then the config of
+// the bootstrap token, then the built-in fallback config.
+type AssignmentExplanation struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AgentId           string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	EffectiveSource   ConfigSource           `protobuf:"varint,2,opt,name=effective_source,json=effectiveSource,proto3,enum=config.v1alpha1.ConfigSource" json:"effective_source,omitempty"`
+	EffectiveConfigId string                 `protobuf:"bytes,3,opt,name=effective_config_id,json=effectiveConfigId,proto3" json:"effective_config_id,omitempty"`
+	Candidates        []*AssignmentCandidate `protobuf:"bytes,4,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	// the precedence of the MANUAL, POLICY and BOOTSTRAP sources configured on the server,
+	// highest first. DEFAULT ranks as MANUAL, and FALLBACK always comes last.
+	Precedence    []ConfigSource `protobuf:"varint,5,rep,packed,name=precedence,proto3,enum=config.v1alpha1.ConfigSource" json:"precedence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentExplanation) Reset() {
+	*x = AssignmentExplanation{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentExplanation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentExplanation) ProtoMessage() {}
+
+func (x *AssignmentExplanation) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentExplanation.ProtoReflect.Descriptor instead.
+func (*AssignmentExplanation) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *AssignmentExplanation) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AssignmentExplanation) GetEffectiveSource() ConfigSource {
+	if x != nil {
+		return x.EffectiveSource
+	}
+	return ConfigSource_CONFIG_SOURCE_UNSPECIFIED
+}
+
+func (x *AssignmentExplanation) GetEffectiveConfigId() string {
+	if x != nil {
+		return x.EffectiveConfigId
+	}
+	return ""
+}
+
+func (x *AssignmentExplanation) GetCandidates() []*AssignmentCandidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *AssignmentExplanation) GetPrecedence() []ConfigSource {
+	if x != nil {
+		return x.Precedence
+	}
+	return nil
+}
+
+// ComponentPolicy restricts the collector components that may be used in the configs of the
+// agents matching selector. Components are given as kind/type, e.g. exporters/debug, or as a
+// bare type matching components of any kind. Component names after the type are ignored.
+// Policies without a selector apply fleet-wide and are also enforced when configs are saved,
+// other policies are enforced when configs are assigned.
+type ComponentPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// agent labels that must all match, empty applies to every agent
+	Selector map[string]string `protobuf:"bytes,2,rep,name=selector,proto3" json:"selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// components that must not be used
+	Denied []string `protobuf:"bytes,3,rep,name=denied,proto3" json:"denied,omitempty"`
+	// if set, the only components that may be used
+	Allowed []string `protobuf:"bytes,4,rep,name=allowed,proto3" json:"allowed,omitempty"`
+	// set by the server on every change
+	Audit         *AuditInfo `protobuf:"bytes,5,opt,name=audit,proto3" json:"audit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentPolicy) Reset() {
+	*x = ComponentPolicy{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentPolicy) ProtoMessage() {}
+
+func (x *ComponentPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentPolicy.ProtoReflect.Descriptor instead.
+func (*ComponentPolicy) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ComponentPolicy) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ComponentPolicy) GetSelector() map[string]string {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *ComponentPolicy) GetDenied() []string {
+	if x != nil {
+		return x.Denied
+	}
+	return nil
+}
+
+func (x *ComponentPolicy) GetAllowed() []string {
+	if x != nil {
+		return x.Allowed
+	}
+	return nil
+}
+
+func (x *ComponentPolicy) GetAudit() *AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+type PutComponentPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *ComponentPolicy       `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutComponentPolicyRequest) Reset() {
+	*x = PutComponentPolicyRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutComponentPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutComponentPolicyRequest) ProtoMessage() {}
+
+func (x *PutComponentPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutComponentPolicyRequest.ProtoReflect.Descriptor instead.
+func (*PutComponentPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *PutComponentPolicyRequest) GetPolicy() *ComponentPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type ComponentPolicyReference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentPolicyReference) Reset() {
+	*x = ComponentPolicyReference{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentPolicyReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentPolicyReference) ProtoMessage() {}
+
+func (x *ComponentPolicyReference) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentPolicyReference.ProtoReflect.Descriptor instead.
+func (*ComponentPolicyReference) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ComponentPolicyReference) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListComponentPoliciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListComponentPoliciesRequest) Reset() {
+	*x = ListComponentPoliciesRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListComponentPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListComponentPoliciesRequest) ProtoMessage() {}
+
+func (x *ListComponentPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListComponentPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListComponentPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{56}
+}
+
+// ComponentPolicyViolation reports a component of an agent's assigned config forbidden by a policy
+type ComponentPolicyViolation struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PolicyId string                 `protobuf:"bytes,1,opt,name=policy_id,json=policyId,proto3" json:"policy_id,omitempty"`
+	AgentId  string                 `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// empty if the agent follows the default config
+	ConfigId string `protobuf:"bytes,3,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	// the offending component ID, e.g. exporters/debug
+	Component     string `protobuf:"bytes,4,opt,name=component,proto3" json:"component,omitempty"`
+	Reason        string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentPolicyViolation) Reset() {
+	*x = ComponentPolicyViolation{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentPolicyViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentPolicyViolation) ProtoMessage() {}
+
+func (x *ComponentPolicyViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentPolicyViolation.ProtoReflect.Descriptor instead.
+func (*ComponentPolicyViolation) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ComponentPolicyViolation) GetPolicyId() string {
+	if x != nil {
+		return x.PolicyId
+	}
+	return ""
+}
+
+func (x *ComponentPolicyViolation) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ComponentPolicyViolation) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *ComponentPolicyViolation) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *ComponentPolicyViolation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListComponentPoliciesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sorted by ID
+	Policies []*ComponentPolicy `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	// violations of configs assigned before the policies were put in place, sorted by agent ID
+	Violations    []*ComponentPolicyViolation `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListComponentPoliciesResponse) Reset() {
+	*x = ListComponentPoliciesResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListComponentPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListComponentPoliciesResponse) ProtoMessage() {}
+
+func (x *ListComponentPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListComponentPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListComponentPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListComponentPoliciesResponse) GetPolicies() []*ComponentPolicy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+func (x *ListComponentPoliciesResponse) GetViolations() []*ComponentPolicyViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+// CollectorDistribution describes a collector build: the components it includes and where to
+// download it. Agents run the distribution whose name and version match the service.name and
+// service.version they report.
+type CollectorDistribution struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// e.g. otelcol-contrib
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// components included in the distribution as kind/type, e.g. receivers/otlp
+	Components []string                `protobuf:"bytes,3,rep,name=components,proto3" json:"components,omitempty"`
+	Artifacts  []*DistributionArtifact `protobuf:"bytes,4,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	// set by the server on every change
+	Audit         *AuditInfo `protobuf:"bytes,5,opt,name=audit,proto3" json:"audit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectorDistribution) Reset() {
+	*x = CollectorDistribution{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectorDistribution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectorDistribution) ProtoMessage() {}
+
+func (x *CollectorDistribution) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectorDistribution.ProtoReflect.Descriptor instead.
+func (*CollectorDistribution) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CollectorDistribution) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CollectorDistribution) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *CollectorDistribution) GetComponents() []string {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *CollectorDistribution) GetArtifacts() []*DistributionArtifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *CollectorDistribution) GetAudit() *AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+// DistributionArtifact is a downloadable build of a distribution for a platform
+type DistributionArtifact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// os/arch, e.g. linux/amd64
+	Platform string `protobuf:"bytes,1,opt,name=platform,proto3" json:"platform,omitempty"`
+	Url      string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// hex encoded SHA-256 of the artifact
+	Sha256 string `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// optional, ed25519 signature of the SHA-256 digest of the artifact, verified by supervisors
+	// configured with a package verification key
+	Signature     []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DistributionArtifact) Reset() {
+	*x = DistributionArtifact{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DistributionArtifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DistributionArtifact) ProtoMessage() {}
+
+func (x *DistributionArtifact) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DistributionArtifact.ProtoReflect.Descriptor instead.
+func (*DistributionArtifact) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DistributionArtifact) GetPlatform() string {
+	if x != nil {
+		return x.Platform
+	}
+	return ""
+}
+
+func (x *DistributionArtifact) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DistributionArtifact) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *DistributionArtifact) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type PutCollectorDistributionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Distribution  *CollectorDistribution `protobuf:"bytes,1,opt,name=distribution,proto3" json:"distribution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutCollectorDistributionRequest) Reset() {
+	*x = PutCollectorDistributionRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutCollectorDistributionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutCollectorDistributionRequest) ProtoMessage() {}
+
+func (x *PutCollectorDistributionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutCollectorDistributionRequest.ProtoReflect.Descriptor instead.
+func (*PutCollectorDistributionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *PutCollectorDistributionRequest) GetDistribution() *CollectorDistribution {
+	if x != nil {
+		return x.Distribution
+	}
+	return nil
+}
+
+type CollectorDistributionReference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectorDistributionReference) Reset() {
+	*x = CollectorDistributionReference{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectorDistributionReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectorDistributionReference) ProtoMessage() {}
+
+func (x *CollectorDistributionReference) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectorDistributionReference.ProtoReflect.Descriptor instead.
+func (*CollectorDistributionReference) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CollectorDistributionReference) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CollectorDistributionReference) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type ListCollectorDistributionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// if set, only lists the versions of the named distribution
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectorDistributionsRequest) Reset() {
+	*x = ListCollectorDistributionsRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectorDistributionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectorDistributionsRequest) ProtoMessage() {}
+
+func (x *ListCollectorDistributionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectorDistributionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCollectorDistributionsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListCollectorDistributionsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListCollectorDistributionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sorted by name, then version
+	Distributions []*CollectorDistribution `protobuf:"bytes,1,rep,name=distributions,proto3" json:"distributions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCollectorDistributionsResponse) Reset() {
+	*x = ListCollectorDistributionsResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCollectorDistributionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCollectorDistributionsResponse) ProtoMessage() {}
+
+func (x *ListCollectorDistributionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCollectorDistributionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCollectorDistributionsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListCollectorDistributionsResponse) GetDistributions() []*CollectorDistribution {
+	if x != nil {
+		return x.Distributions
+	}
+	return nil
+}
+
+type CheckConfigCompatibilityRequest struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	ConfigId      string                          `protobuf:"bytes,1,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	Distribution  *CollectorDistributionReference `protobuf:"bytes,2,opt,name=distribution,proto3" json:"distribution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckConfigCompatibilityRequest) Reset() {
+	*x = CheckConfigCompatibilityRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckConfigCompatibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckConfigCompatibilityRequest) ProtoMessage() {}
+
+func (x *CheckConfigCompatibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckConfigCompatibilityRequest.ProtoReflect.Descriptor instead.
+func (*CheckConfigCompatibilityRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *CheckConfigCompatibilityRequest) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *CheckConfigCompatibilityRequest) GetDistribution() *CollectorDistributionReference {
+	if x != nil {
+		return x.Distribution
+	}
+	return nil
+}
+
+type ConfigCompatibility struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Compatible bool                   `protobuf:"varint,1,opt,name=compatible,proto3" json:"compatible,omitempty"`
+	// components used by the config the distribution doesn't include, as kind/type
+	MissingComponents []string `protobuf:"bytes,2,rep,name=missing_components,json=missingComponents,proto3" json:"missing_components,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ConfigCompatibility) Reset() {
+	*x = ConfigCompatibility{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigCompatibility) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigCompatibility) ProtoMessage() {}
+
+func (x *ConfigCompatibility) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigCompatibility.ProtoReflect.Descriptor instead.
+func (*ConfigCompatibility) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ConfigCompatibility) GetCompatible() bool {
+	if x != nil {
+		return x.Compatible
+	}
+	return false
+}
+
+func (x *ConfigCompatibility) GetMissingComponents() []string {
+	if x != nil {
+		return x.MissingComponents
+	}
+	return nil
+}
+
+type RollingDeploymentRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ConfigId          string                 `protobuf:"bytes,1,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	AgentIds          []string               `protobuf:"bytes,2,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	AgentLabels       map[string]string      `protobuf:"bytes,3,rep,name=agent_labels,json=agentLabels,proto3" json:"agent_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Alternative to agent_ids
+	BatchSize         int32                  `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`                                                                                // Agents per batch (default: 1)
+	BatchDelaySeconds int32                  `protobuf:"varint,5,opt,name=batch_delay_seconds,json=batchDelaySeconds,proto3" json:"batch_delay_seconds,omitempty"`                                                      // Delay between batches (default: 0)
+	MaxFailures       int32                  `protobuf:"varint,6,opt,name=max_failures,json=maxFailures,proto3" json:"max_failures,omitempty"`                                                                          // Stop after N failures (default: 0 = no limit)
+	// Smears config application within each batch: every agent is assigned the config
+	// after a per-agent delay of up to this many seconds (default: 0 = no jitter)
+	MaxApplyJitterSeconds int32 `protobuf:"varint,7,opt,name=max_apply_jitter_seconds,json=maxApplyJitterSeconds,proto3" json:"max_apply_jitter_seconds,omitempty"`
+	// roll agents back to their last known-good config if they fail to apply the config
+	AutoRollback bool `protobuf:"varint,8,opt,name=auto_rollback,json=autoRollback,proto3" json:"auto_rollback,omitempty"`
+	// Waits up to this many seconds for each agent of a batch to report applying the config
+	// before moving to the next batch, agents failing to apply it or not applying it in time
+	// count as failed. Agents not reporting remote config statuses are waited on to report
+	// being healthy instead. (default: 0 = agents count as applied once they are assigned)
+	ApplyTimeoutSeconds int32 `protobuf:"varint,9,opt,name=apply_timeout_seconds,json=applyTimeoutSeconds,proto3" json:"apply_timeout_seconds,omitempty"`
+	// label selector expression agents must also match, see AssignConfigByLabelsRequest.
+	// Alternative to agent_ids, along with agent_labels.
+	AgentSelectorExpression string `protobuf:"bytes,10,opt,name=agent_selector_expression,json=agentSelectorExpression,proto3" json:"agent_selector_expression,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *RollingDeploymentRequest) Reset() {
+	*x = RollingDeploymentRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollingDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollingDeploymentRequest) ProtoMessage() {}
+
+func (x *RollingDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollingDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*RollingDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *RollingDeploymentRequest) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *RollingDeploymentRequest) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *RollingDeploymentRequest) GetAgentLabels() map[string]string {
+	if x != nil {
+		return x.AgentLabels
+	}
+	return nil
+}
+
+func (x *RollingDeploymentRequest) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *RollingDeploymentRequest) GetBatchDelaySeconds() int32 {
+	if x != nil {
+		return x.BatchDelaySeconds
+	}
+	return 0
+}
+
+func (x *RollingDeploymentRequest) GetMaxFailures() int32 {
+	if x != nil {
+		return x.MaxFailures
+	}
+	return 0
+}
+
+func (x *RollingDeploymentRequest) GetMaxApplyJitterSeconds() int32 {
+	if x != nil {
+		return x.MaxApplyJitterSeconds
+	}
+	return 0
+}
+
+func (x *RollingDeploymentRequest) GetAutoRollback() bool {
+	if x != nil {
+		return x.AutoRollback
+	}
+	return false
+}
+
+func (x *RollingDeploymentRequest) GetApplyTimeoutSeconds() int32 {
+	if x != nil {
+		return x.ApplyTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *RollingDeploymentRequest) GetAgentSelectorExpression() string {
+	if x != nil {
+		return x.AgentSelectorExpression
+	}
+	return ""
+}
+
+// DeploymentJob is the payload of the background job executing a rolling deployment
+type DeploymentJob struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	// agents targeted by the deployment, in rollout order
+	AgentIds []string                  `protobuf:"bytes,2,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	Request  *RollingDeploymentRequest `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	// the deployment rolled back, for rollbacks
+	RollbackOf string `protobuf:"bytes,4,opt,name=rollback_of,json=rollbackOf,proto3" json:"rollback_of,omitempty"`
+	// sizes of the batches agent_ids are split in, if they differ from request.batch_size
+	BatchSizes    []int32 `protobuf:"varint,5,rep,packed,name=batch_sizes,json=batchSizes,proto3" json:"batch_sizes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeploymentJob) Reset() {
+	*x = DeploymentJob{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentJob) ProtoMessage() {}
+
+func (x *DeploymentJob) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentJob.ProtoReflect.Descriptor instead.
+func (*DeploymentJob) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DeploymentJob) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *DeploymentJob) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *DeploymentJob) GetRequest() *RollingDeploymentRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *DeploymentJob) GetRollbackOf() string {
+	if x != nil {
+		return x.RollbackOf
+	}
+	return ""
+}
+
+func (x *DeploymentJob) GetBatchSizes() []int32 {
+	if x != nil {
+		return x.BatchSizes
+	}
+	return nil
+}
+
+type RollingDeploymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollingDeploymentResponse) Reset() {
+	*x = RollingDeploymentResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollingDeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollingDeploymentResponse) ProtoMessage() {}
+
+func (x *RollingDeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollingDeploymentResponse.ProtoReflect.Descriptor instead.
+func (*RollingDeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *RollingDeploymentResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type AgentDeploymentStatus struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AgentId      string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	State        AgentDeploymentState   `protobuf:"varint,2,opt,name=state,proto3,enum=config.v1alpha1.AgentDeploymentState" json:"state,omitempty"`
+	ErrorMessage string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	AppliedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"`
+	// When the agent started applying the config, used with applied_at to
+	// estimate apply latency for future deployments
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// the batch the agent is part of, starting at 1
+	Batch int32 `protobuf:"varint,6,opt,name=batch,proto3" json:"batch,omitempty"`
+	// the assignment of the agent when the deployment started, restored by rolling the
+	// deployment back; unset if the agent had none
+	PreviousAssignment *ConfigAssignment `protobuf:"bytes,7,opt,name=previous_assignment,json=previousAssignment,proto3" json:"previous_assignment,omitempty"`
+	// the version of the previously assigned config
+	PreviousConfig *Config `protobuf:"bytes,8,opt,name=previous_config,json=previousConfig,proto3" json:"previous_config,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AgentDeploymentStatus) Reset() {
+	*x = AgentDeploymentStatus{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AgentDeploymentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentDeploymentStatus) ProtoMessage() {}
+
+func (x *AgentDeploymentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AgentDeploymentStatus.ProtoReflect.Descriptor instead.
+func (*AgentDeploymentStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *AgentDeploymentStatus) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentDeploymentStatus) GetState() AgentDeploymentState {
+	if x != nil {
+		return x.State
+	}
+	return AgentDeploymentState_AGENT_DEPLOYMENT_STATE_UNSPECIFIED
+}
+
+func (x *AgentDeploymentStatus) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *AgentDeploymentStatus) GetAppliedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return nil
+}
+
+func (x *AgentDeploymentStatus) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *AgentDeploymentStatus) GetBatch() int32 {
+	if x != nil {
+		return x.Batch
+	}
+	return 0
+}
+
+func (x *AgentDeploymentStatus) GetPreviousAssignment() *ConfigAssignment {
+	if x != nil {
+		return x.PreviousAssignment
+	}
+	return nil
+}
+
+func (x *AgentDeploymentStatus) GetPreviousConfig() *Config {
+	if x != nil {
+		return x.PreviousConfig
+	}
+	return nil
+}
+
+type DeploymentStatus struct {
+	state           protoimpl.MessageState   `protogen:"open.v1"`
+	DeploymentId    string                   `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	ConfigId        string                   `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	State           DeploymentState          `protobuf:"varint,3,opt,name=state,proto3,enum=config.v1alpha1.DeploymentState" json:"state,omitempty"`
+	TotalAgents     int32                    `protobuf:"varint,4,opt,name=total_agents,json=totalAgents,proto3" json:"total_agents,omitempty"`
+	CompletedAgents int32                    `protobuf:"varint,5,opt,name=completed_agents,json=completedAgents,proto3" json:"completed_agents,omitempty"`
+	FailedAgents    int32                    `protobuf:"varint,6,opt,name=failed_agents,json=failedAgents,proto3" json:"failed_agents,omitempty"`
+	PendingAgents   int32                    `protobuf:"varint,7,opt,name=pending_agents,json=pendingAgents,proto3" json:"pending_agents,omitempty"`
+	CurrentBatch    int32                    `protobuf:"varint,8,opt,name=current_batch,json=currentBatch,proto3" json:"current_batch,omitempty"`
+	AgentStatuses   []*AgentDeploymentStatus `protobuf:"bytes,9,rep,name=agent_statuses,json=agentStatuses,proto3" json:"agent_statuses,omitempty"`
+	StartedAt       *timestamppb.Timestamp   `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt     *timestamppb.Timestamp   `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// Estimated completion time, accounting for batch delays and apply jitter
+	ProjectedCompletionAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=projected_completion_at,json=projectedCompletionAt,proto3" json:"projected_completion_at,omitempty"`
+	Audit                 *AuditInfo             `protobuf:"bytes,13,opt,name=audit,proto3" json:"audit,omitempty"`
+	// the deployment this one rolls back, restoring the previous config of each agent
+	// rather than assigning config_id
+	RollbackOf string `protobuf:"bytes,14,opt,name=rollback_of,json=rollbackOf,proto3" json:"rollback_of,omitempty"`
+	// the deployment that rolled this one back
+	RolledBackBy string `protobuf:"bytes,15,opt,name=rolled_back_by,json=rolledBackBy,proto3" json:"rolled_back_by,omitempty"`
+	// tenant the deployment was started in
+	Tenant        string `protobuf:"bytes,16,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeploymentStatus) Reset() {
+	*x = DeploymentStatus{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentStatus) ProtoMessage() {}
+
+func (x *DeploymentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentStatus.ProtoReflect.Descriptor instead.
+func (*DeploymentStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *DeploymentStatus) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *DeploymentStatus) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *DeploymentStatus) GetState() DeploymentState {
+	if x != nil {
+		return x.State
+	}
+	return DeploymentState_DEPLOYMENT_STATE_UNSPECIFIED
+}
+
+func (x *DeploymentStatus) GetTotalAgents() int32 {
+	if x != nil {
+		return x.TotalAgents
+	}
+	return 0
+}
+
+func (x *DeploymentStatus) GetCompletedAgents() int32 {
+	if x != nil {
+		return x.CompletedAgents
+	}
+	return 0
+}
+
+func (x *DeploymentStatus) GetFailedAgents() int32 {
+	if x != nil {
+		return x.FailedAgents
+	}
+	return 0
+}
+
+func (x *DeploymentStatus) GetPendingAgents() int32 {
+	if x != nil {
+		return x.PendingAgents
+	}
+	return 0
+}
+
+func (x *DeploymentStatus) GetCurrentBatch() int32 {
+	if x != nil {
+		return x.CurrentBatch
+	}
+	return 0
+}
+
+func (x *DeploymentStatus) GetAgentStatuses() []*AgentDeploymentStatus {
+	if x != nil {
+		return x.AgentStatuses
+	}
+	return nil
+}
+
+func (x *DeploymentStatus) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *DeploymentStatus) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *DeploymentStatus) GetProjectedCompletionAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ProjectedCompletionAt
+	}
+	return nil
+}
+
+func (x *DeploymentStatus) GetAudit() *AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+func (x *DeploymentStatus) GetRollbackOf() string {
+	if x != nil {
+		return x.RollbackOf
+	}
+	return ""
+}
+
+func (x *DeploymentStatus) GetRolledBackBy() string {
+	if x != nil {
+		return x.RolledBackBy
+	}
+	return ""
+}
+
+func (x *DeploymentStatus) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type GetDeploymentStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeploymentStatusRequest) Reset() {
+	*x = GetDeploymentStatusRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeploymentStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeploymentStatusRequest) ProtoMessage() {}
+
+func (x *GetDeploymentStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeploymentStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetDeploymentStatusRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *GetDeploymentStatusRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type GetDeploymentStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *DeploymentStatus      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeploymentStatusResponse) Reset() {
+	*x = GetDeploymentStatusResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeploymentStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeploymentStatusResponse) ProtoMessage() {}
+
+func (x *GetDeploymentStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeploymentStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetDeploymentStatusResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetDeploymentStatusResponse) GetStatus() *DeploymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type PauseDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseDeploymentRequest) Reset() {
+	*x = PauseDeploymentRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseDeploymentRequest) ProtoMessage() {}
+
+func (x *PauseDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*PauseDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *PauseDeploymentRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type ResumeDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeDeploymentRequest) Reset() {
+	*x = ResumeDeploymentRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeDeploymentRequest) ProtoMessage() {}
+
+func (x *ResumeDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*ResumeDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ResumeDeploymentRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type CancelDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelDeploymentRequest) Reset() {
+	*x = CancelDeploymentRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelDeploymentRequest) ProtoMessage() {}
+
+func (x *CancelDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*CancelDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *CancelDeploymentRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type PurgeDeploymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeploymentRequest) Reset() {
+	*x = PurgeDeploymentRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeploymentRequest) ProtoMessage() {}
+
+func (x *PurgeDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *PurgeDeploymentRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type RollbackDeploymentRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	// Delay between batches (default: 0)
+	BatchDelaySeconds int32 `protobuf:"varint,2,opt,name=batch_delay_seconds,json=batchDelaySeconds,proto3" json:"batch_delay_seconds,omitempty"`
+	// Waits up to this many seconds for each agent to report applying its restored config
+	// before moving to the next batch (default: 0 = agents count as restored once assigned)
+	ApplyTimeoutSeconds int32 `protobuf:"varint,3,opt,name=apply_timeout_seconds,json=applyTimeoutSeconds,proto3" json:"apply_timeout_seconds,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *RollbackDeploymentRequest) Reset() {
+	*x = RollbackDeploymentRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackDeploymentRequest) ProtoMessage() {}
+
+func (x *RollbackDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*RollbackDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *RollbackDeploymentRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *RollbackDeploymentRequest) GetBatchDelaySeconds() int32 {
+	if x != nil {
+		return x.BatchDelaySeconds
+	}
+	return 0
+}
+
+func (x *RollbackDeploymentRequest) GetApplyTimeoutSeconds() int32 {
+	if x != nil {
+		return x.ApplyTimeoutSeconds
+	}
+	return 0
+}
+
+type DeploymentActionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeploymentActionResponse) Reset() {
+	*x = DeploymentActionResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentActionResponse) ProtoMessage() {}
+
+func (x *DeploymentActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentActionResponse.ProtoReflect.Descriptor instead.
+func (*DeploymentActionResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *DeploymentActionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeploymentActionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListDeploymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StateFilter   *DeploymentState       `protobuf:"varint,1,opt,name=state_filter,json=stateFilter,proto3,enum=config.v1alpha1.DeploymentState,oneof" json:"state_filter,omitempty"`
+	AuditFilter   *AuditFilter           `protobuf:"bytes,2,opt,name=audit_filter,json=auditFilter,proto3" json:"audit_filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeploymentsRequest) Reset() {
+	*x = ListDeploymentsRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeploymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeploymentsRequest) ProtoMessage() {}
+
+func (x *ListDeploymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeploymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeploymentsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ListDeploymentsRequest) GetStateFilter() DeploymentState {
+	if x != nil && x.StateFilter != nil {
+		return *x.StateFilter
+	}
+	return DeploymentState_DEPLOYMENT_STATE_UNSPECIFIED
+}
+
+func (x *ListDeploymentsRequest) GetAuditFilter() *AuditFilter {
+	if x != nil {
+		return x.AuditFilter
+	}
+	return nil
+}
+
+type ListDeploymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deployments   []*DeploymentStatus    `protobuf:"bytes,1,rep,name=deployments,proto3" json:"deployments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeploymentsResponse) Reset() {
+	*x = ListDeploymentsResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeploymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeploymentsResponse) ProtoMessage() {}
+
+func (x *ListDeploymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeploymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeploymentsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ListDeploymentsResponse) GetDeployments() []*DeploymentStatus {
+	if x != nil {
+		return x.Deployments
+	}
+	return nil
+}
+
+type SkippedAgent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Reason  DeploymentSkipReason   `protobuf:"varint,2,opt,name=reason,proto3,enum=config.v1alpha1.DeploymentSkipReason" json:"reason,omitempty"`
+	// details on the reason, e.g. the components missing from the agent's distribution
+	Detail        string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SkippedAgent) Reset() {
+	*x = SkippedAgent{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SkippedAgent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkippedAgent) ProtoMessage() {}
+
+func (x *SkippedAgent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkippedAgent.ProtoReflect.Descriptor instead.
+func (*SkippedAgent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *SkippedAgent) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *SkippedAgent) GetReason() DeploymentSkipReason {
+	if x != nil {
+		return x.Reason
+	}
+	return DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_UNSPECIFIED
+}
+
+func (x *SkippedAgent) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+// CapacityWarning reports a targeted agent whose host lacks resources the config requires
+type CapacityWarning struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Warnings      []string               `protobuf:"bytes,2,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapacityWarning) Reset() {
+	*x = CapacityWarning{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapacityWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapacityWarning) ProtoMessage() {}
+
+func (x *CapacityWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapacityWarning.ProtoReflect.Descriptor instead.
+func (*CapacityWarning) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *CapacityWarning) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *CapacityWarning) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type DeploymentPlanBatch struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Number   int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	AgentIds []string               `protobuf:"bytes,2,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	// Offset from the start of the deployment the batch is expected to start at
+	ExpectedStart    *durationpb.Duration `protobuf:"bytes,3,opt,name=expected_start,json=expectedStart,proto3" json:"expected_start,omitempty"`
+	ExpectedDuration *durationpb.Duration `protobuf:"bytes,4,opt,name=expected_duration,json=expectedDuration,proto3" json:"expected_duration,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeploymentPlanBatch) Reset() {
+	*x = DeploymentPlanBatch{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentPlanBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentPlanBatch) ProtoMessage() {}
+
+func (x *DeploymentPlanBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentPlanBatch.ProtoReflect.Descriptor instead.
+func (*DeploymentPlanBatch) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *DeploymentPlanBatch) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *DeploymentPlanBatch) GetAgentIds() []string {
+	if x != nil {
+		return x.AgentIds
+	}
+	return nil
+}
+
+func (x *DeploymentPlanBatch) GetExpectedStart() *durationpb.Duration {
+	if x != nil {
+		return x.ExpectedStart
+	}
+	return nil
+}
+
+func (x *DeploymentPlanBatch) GetExpectedDuration() *durationpb.Duration {
+	if x != nil {
+		return x.ExpectedDuration
+	}
+	return nil
+}
+
+// DeploymentPlan describes what a rolling deployment would do if it was started
+type DeploymentPlan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConfigId      string                 `protobuf:"bytes,1,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	TotalAgents   int32                  `protobuf:"varint,2,opt,name=total_agents,json=totalAgents,proto3" json:"total_agents,omitempty"`
+	Batches       []*DeploymentPlanBatch `protobuf:"bytes,3,rep,name=batches,proto3" json:"batches,omitempty"`
+	SkippedAgents []*SkippedAgent        `protobuf:"bytes,4,rep,name=skipped_agents,json=skippedAgents,proto3" json:"skipped_agents,omitempty"`
+	// Reasons the deployment would be rejected or is unlikely to succeed
+	PolicyViolations []string             `protobuf:"bytes,5,rep,name=policy_violations,json=policyViolations,proto3" json:"policy_violations,omitempty"`
+	ExpectedDuration *durationpb.Duration `protobuf:"bytes,6,opt,name=expected_duration,json=expectedDuration,proto3" json:"expected_duration,omitempty"`
+	// Number of recorded apply latencies the duration estimates are based on
+	LatencySamples   int32              `protobuf:"varint,7,opt,name=latency_samples,json=latencySamples,proto3" json:"latency_samples,omitempty"`
+	CapacityWarnings []*CapacityWarning `protobuf:"bytes,8,rep,name=capacity_warnings,json=capacityWarnings,proto3" json:"capacity_warnings,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeploymentPlan) Reset() {
+	*x = DeploymentPlan{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentPlan) ProtoMessage() {}
+
+func (x *DeploymentPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentPlan.ProtoReflect.Descriptor instead.
+func (*DeploymentPlan) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *DeploymentPlan) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *DeploymentPlan) GetTotalAgents() int32 {
+	if x != nil {
+		return x.TotalAgents
+	}
+	return 0
+}
+
+func (x *DeploymentPlan) GetBatches() []*DeploymentPlanBatch {
+	if x != nil {
+		return x.Batches
+	}
+	return nil
+}
+
+func (x *DeploymentPlan) GetSkippedAgents() []*SkippedAgent {
+	if x != nil {
+		return x.SkippedAgents
+	}
+	return nil
+}
+
+func (x *DeploymentPlan) GetPolicyViolations() []string {
+	if x != nil {
+		return x.PolicyViolations
+	}
+	return nil
+}
+
+func (x *DeploymentPlan) GetExpectedDuration() *durationpb.Duration {
+	if x != nil {
+		return x.ExpectedDuration
+	}
+	return nil
+}
+
+func (x *DeploymentPlan) GetLatencySamples() int32 {
+	if x != nil {
+		return x.LatencySamples
+	}
+	return 0
+}
+
+func (x *DeploymentPlan) GetCapacityWarnings() []*CapacityWarning {
+	if x != nil {
+		return x.CapacityWarnings
+	}
+	return nil
+}
+
+type GetConfigCoverageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConfigCoverageRequest) Reset() {
+	*x = GetConfigCoverageRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchAssignConfigResponse) GetFailed() int32 {
-	if x != nil {
-		return x.Failed
-	}
-	return 0
+func (x *GetConfigCoverageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *BatchAssignConfigResponse) GetFailedAgentIds() []string {
+func (*GetConfigCoverageRequest) ProtoMessage() {}
+
+func (x *GetConfigCoverageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[86]
 	if x != nil {
-		return x.FailedAgentIds
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *BatchAssignConfigResponse) GetErrorMessages() []string {
-	if x != nil {
-		return x.ErrorMessages
-	}
-	return nil
+// Deprecated: Use GetConfigCoverageRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigCoverageRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{86}
 }
 
-type AssignConfigByLabelsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Labels        map[string]string      `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Agent labels to match
-	ConfigId      string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+// SignalCoverage counts the agents running pipelines for a telemetry signal
+type SignalCoverage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// traces, metrics, logs or profiles
+	Signal string `protobuf:"bytes,1,opt,name=signal,proto3" json:"signal,omitempty"`
+	// number of agents with at least one pipeline for the signal
+	Agents int32 `protobuf:"varint,2,opt,name=agents,proto3" json:"agents,omitempty"`
+	// number of pipelines for the signal across the fleet
+	Pipelines     int32 `protobuf:"varint,3,opt,name=pipelines,proto3" json:"pipelines,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssignConfigByLabelsRequest) Reset() {
-	*x = AssignConfigByLabelsRequest{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[22]
+func (x *SignalCoverage) Reset() {
+	*x = SignalCoverage{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssignConfigByLabelsRequest) String() string {
+func (x *SignalCoverage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssignConfigByLabelsRequest) ProtoMessage() {}
+func (*SignalCoverage) ProtoMessage() {}
 
-func (x *AssignConfigByLabelsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[22]
+func (x *SignalCoverage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1417,49 +5840,57 @@ func (x *AssignConfigByLabelsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssignConfigByLabelsRequest.ProtoReflect.Descriptor instead.
-func (*AssignConfigByLabelsRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use SignalCoverage.ProtoReflect.Descriptor instead.
+func (*SignalCoverage) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *AssignConfigByLabelsRequest) GetLabels() map[string]string {
+func (x *SignalCoverage) GetSignal() string {
 	if x != nil {
-		return x.Labels
+		return x.Signal
 	}
-	return nil
+	return ""
 }
 
-func (x *AssignConfigByLabelsRequest) GetConfigId() string {
+func (x *SignalCoverage) GetAgents() int32 {
 	if x != nil {
-		return x.ConfigId
+		return x.Agents
 	}
-	return ""
+	return 0
 }
 
-type AssignConfigByLabelsResponse struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	MatchedAgentIds []string               `protobuf:"bytes,1,rep,name=matched_agent_ids,json=matchedAgentIds,proto3" json:"matched_agent_ids,omitempty"`
-	Successful      int32                  `protobuf:"varint,2,opt,name=successful,proto3" json:"successful,omitempty"`
-	Failed          int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *SignalCoverage) GetPipelines() int32 {
+	if x != nil {
+		return x.Pipelines
+	}
+	return 0
 }
 
-func (x *AssignConfigByLabelsResponse) Reset() {
-	*x = AssignConfigByLabelsResponse{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[23]
+// ComponentUsage counts the agents using a component type in their pipelines
+type ComponentUsage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// component type, without the optional "/name" suffix
+	Type          string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Agents        int32  `protobuf:"varint,2,opt,name=agents,proto3" json:"agents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentUsage) Reset() {
+	*x = ComponentUsage{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssignConfigByLabelsResponse) String() string {
+func (x *ComponentUsage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssignConfigByLabelsResponse) ProtoMessage() {}
+func (*ComponentUsage) ProtoMessage() {}
 
-func (x *AssignConfigByLabelsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[23]
+func (x *ComponentUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1470,59 +5901,58 @@ func (x *AssignConfigByLabelsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssignConfigByLabelsResponse.ProtoReflect.Descriptor instead.
-func (*AssignConfigByLabelsResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *AssignConfigByLabelsResponse) GetMatchedAgentIds() []string {
-	if x != nil {
-		return x.MatchedAgentIds
-	}
-	return nil
+// Deprecated: Use ComponentUsage.ProtoReflect.Descriptor instead.
+func (*ComponentUsage) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{88}
 }
 
-func (x *AssignConfigByLabelsResponse) GetSuccessful() int32 {
+func (x *ComponentUsage) GetType() string {
 	if x != nil {
-		return x.Successful
+		return x.Type
 	}
-	return 0
+	return ""
 }
 
-func (x *AssignConfigByLabelsResponse) GetFailed() int32 {
+func (x *ComponentUsage) GetAgents() int32 {
 	if x != nil {
-		return x.Failed
+		return x.Agents
 	}
 	return 0
 }
 
-type RollingDeploymentRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	ConfigId          string                 `protobuf:"bytes,1,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
-	AgentIds          []string               `protobuf:"bytes,2,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
-	AgentLabels       map[string]string      `protobuf:"bytes,3,rep,name=agent_labels,json=agentLabels,proto3" json:"agent_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Alternative to agent_ids
-	BatchSize         int32                  `protobuf:"varint,4,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`                                                                                // Agents per batch (default: 1)
-	BatchDelaySeconds int32                  `protobuf:"varint,5,opt,name=batch_delay_seconds,json=batchDelaySeconds,proto3" json:"batch_delay_seconds,omitempty"`                                                      // Delay between batches (default: 0)
-	MaxFailures       int32                  `protobuf:"varint,6,opt,name=max_failures,json=maxFailures,proto3" json:"max_failures,omitempty"`                                                                          // Stop after N failures (default: 0 = no limit)
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
-}
-
-func (x *RollingDeploymentRequest) Reset() {
-	*x = RollingDeploymentRequest{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[24]
+// ConfigCoverageReport summarizes the pipelines in the effective configs reported by agents
+type ConfigCoverageReport struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TotalAgents int32                  `protobuf:"varint,1,opt,name=total_agents,json=totalAgents,proto3" json:"total_agents,omitempty"`
+	// agents that have reported an effective config
+	ReportingAgents int32 `protobuf:"varint,2,opt,name=reporting_agents,json=reportingAgents,proto3" json:"reporting_agents,omitempty"`
+	// sorted by signal
+	Signals []*SignalCoverage `protobuf:"bytes,3,rep,name=signals,proto3" json:"signals,omitempty"`
+	// exporters used in pipelines, most used first
+	Exporters []*ComponentUsage `protobuf:"bytes,4,rep,name=exporters,proto3" json:"exporters,omitempty"`
+	// reporting agents whose effective config has no pipelines
+	AgentsWithoutPipelines []string `protobuf:"bytes,5,rep,name=agents_without_pipelines,json=agentsWithoutPipelines,proto3" json:"agents_without_pipelines,omitempty"`
+	// agents that have not reported an effective config yet
+	AgentsNotReporting []string `protobuf:"bytes,6,rep,name=agents_not_reporting,json=agentsNotReporting,proto3" json:"agents_not_reporting,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ConfigCoverageReport) Reset() {
+	*x = ConfigCoverageReport{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RollingDeploymentRequest) String() string {
+func (x *ConfigCoverageReport) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RollingDeploymentRequest) ProtoMessage() {}
+func (*ConfigCoverageReport) ProtoMessage() {}
 
-func (x *RollingDeploymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[24]
+func (x *ConfigCoverageReport) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1533,75 +5963,78 @@ func (x *RollingDeploymentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RollingDeploymentRequest.ProtoReflect.Descriptor instead.
-func (*RollingDeploymentRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use ConfigCoverageReport.ProtoReflect.Descriptor instead.
+func (*ConfigCoverageReport) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{89}
 }
 
-func (x *RollingDeploymentRequest) GetConfigId() string {
+func (x *ConfigCoverageReport) GetTotalAgents() int32 {
 	if x != nil {
-		return x.ConfigId
+		return x.TotalAgents
 	}
-	return ""
+	return 0
 }
 
-func (x *RollingDeploymentRequest) GetAgentIds() []string {
+func (x *ConfigCoverageReport) GetReportingAgents() int32 {
 	if x != nil {
-		return x.AgentIds
+		return x.ReportingAgents
 	}
-	return nil
+	return 0
 }
 
-func (x *RollingDeploymentRequest) GetAgentLabels() map[string]string {
+func (x *ConfigCoverageReport) GetSignals() []*SignalCoverage {
 	if x != nil {
-		return x.AgentLabels
+		return x.Signals
 	}
 	return nil
 }
 
-func (x *RollingDeploymentRequest) GetBatchSize() int32 {
+func (x *ConfigCoverageReport) GetExporters() []*ComponentUsage {
 	if x != nil {
-		return x.BatchSize
+		return x.Exporters
 	}
-	return 0
+	return nil
 }
 
-func (x *RollingDeploymentRequest) GetBatchDelaySeconds() int32 {
+func (x *ConfigCoverageReport) GetAgentsWithoutPipelines() []string {
 	if x != nil {
-		return x.BatchDelaySeconds
+		return x.AgentsWithoutPipelines
 	}
-	return 0
+	return nil
 }
 
-func (x *RollingDeploymentRequest) GetMaxFailures() int32 {
+func (x *ConfigCoverageReport) GetAgentsNotReporting() []string {
 	if x != nil {
-		return x.MaxFailures
+		return x.AgentsNotReporting
 	}
-	return 0
+	return nil
 }
 
-type RollingDeploymentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+// AgentConfigHistory records the config changes of an agent, oldest first
+type AgentConfigHistory struct {
+	state   protoimpl.MessageState     `protogen:"open.v1"`
+	Entries []*AgentConfigHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// set once the oldest entries were dropped to bound the history
+	Truncated     bool `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RollingDeploymentResponse) Reset() {
-	*x = RollingDeploymentResponse{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[25]
+func (x *AgentConfigHistory) Reset() {
+	*x = AgentConfigHistory{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RollingDeploymentResponse) String() string {
+func (x *AgentConfigHistory) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RollingDeploymentResponse) ProtoMessage() {}
+func (*AgentConfigHistory) ProtoMessage() {}
 
-func (x *RollingDeploymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[25]
+func (x *AgentConfigHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1612,43 +6045,55 @@ func (x *RollingDeploymentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RollingDeploymentResponse.ProtoReflect.Descriptor instead.
-func (*RollingDeploymentResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use AgentConfigHistory.ProtoReflect.Descriptor instead.
+func (*AgentConfigHistory) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *RollingDeploymentResponse) GetDeploymentId() string {
+func (x *AgentConfigHistory) GetEntries() []*AgentConfigHistoryEntry {
 	if x != nil {
-		return x.DeploymentId
+		return x.Entries
 	}
-	return ""
+	return nil
 }
 
-type AgentDeploymentStatus struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
-	State         AgentDeploymentState   `protobuf:"varint,2,opt,name=state,proto3,enum=config.v1alpha1.AgentDeploymentState" json:"state,omitempty"`
-	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	AppliedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"`
+func (x *AgentConfigHistory) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type AgentConfigHistoryEntry struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Time   *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Change AgentConfigChange      `protobuf:"varint,2,opt,name=change,proto3,enum=config.v1alpha1.AgentConfigChange" json:"change,omitempty"`
+	// the assignment, for ASSIGNED
+	Assignment *ConfigAssignment `protobuf:"bytes,3,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	// the version of the config that was assigned, for ASSIGNED
+	Config *Config `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	// the config hash the agent reported, for APPLIED and FAILED
+	ConfigHash    []byte `protobuf:"bytes,5,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+	ErrorMessage  string `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AgentDeploymentStatus) Reset() {
-	*x = AgentDeploymentStatus{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[26]
+func (x *AgentConfigHistoryEntry) Reset() {
+	*x = AgentConfigHistoryEntry{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AgentDeploymentStatus) String() string {
+func (x *AgentConfigHistoryEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AgentDeploymentStatus) ProtoMessage() {}
+func (*AgentConfigHistoryEntry) ProtoMessage() {}
 
-func (x *AgentDeploymentStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[26]
+func (x *AgentConfigHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1659,71 +6104,76 @@ func (x *AgentDeploymentStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AgentDeploymentStatus.ProtoReflect.Descriptor instead.
-func (*AgentDeploymentStatus) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use AgentConfigHistoryEntry.ProtoReflect.Descriptor instead.
+func (*AgentConfigHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *AgentDeploymentStatus) GetAgentId() string {
+func (x *AgentConfigHistoryEntry) GetTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.AgentId
+		return x.Time
 	}
-	return ""
+	return nil
 }
 
-func (x *AgentDeploymentStatus) GetState() AgentDeploymentState {
+func (x *AgentConfigHistoryEntry) GetChange() AgentConfigChange {
 	if x != nil {
-		return x.State
+		return x.Change
 	}
-	return AgentDeploymentState_AGENT_DEPLOYMENT_STATE_UNSPECIFIED
+	return AgentConfigChange_AGENT_CONFIG_CHANGE_UNSPECIFIED
 }
 
-func (x *AgentDeploymentStatus) GetErrorMessage() string {
+func (x *AgentConfigHistoryEntry) GetAssignment() *ConfigAssignment {
 	if x != nil {
-		return x.ErrorMessage
+		return x.Assignment
 	}
-	return ""
+	return nil
 }
 
-func (x *AgentDeploymentStatus) GetAppliedAt() *timestamppb.Timestamp {
+func (x *AgentConfigHistoryEntry) GetConfig() *Config {
 	if x != nil {
-		return x.AppliedAt
+		return x.Config
 	}
 	return nil
 }
 
-type DeploymentStatus struct {
-	state           protoimpl.MessageState   `protogen:"open.v1"`
-	DeploymentId    string                   `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
-	ConfigId        string                   `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
-	State           DeploymentState          `protobuf:"varint,3,opt,name=state,proto3,enum=config.v1alpha1.DeploymentState" json:"state,omitempty"`
-	TotalAgents     int32                    `protobuf:"varint,4,opt,name=total_agents,json=totalAgents,proto3" json:"total_agents,omitempty"`
-	CompletedAgents int32                    `protobuf:"varint,5,opt,name=completed_agents,json=completedAgents,proto3" json:"completed_agents,omitempty"`
-	FailedAgents    int32                    `protobuf:"varint,6,opt,name=failed_agents,json=failedAgents,proto3" json:"failed_agents,omitempty"`
-	PendingAgents   int32                    `protobuf:"varint,7,opt,name=pending_agents,json=pendingAgents,proto3" json:"pending_agents,omitempty"`
-	CurrentBatch    int32                    `protobuf:"varint,8,opt,name=current_batch,json=currentBatch,proto3" json:"current_batch,omitempty"`
-	AgentStatuses   []*AgentDeploymentStatus `protobuf:"bytes,9,rep,name=agent_statuses,json=agentStatuses,proto3" json:"agent_statuses,omitempty"`
-	StartedAt       *timestamppb.Timestamp   `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
-	CompletedAt     *timestamppb.Timestamp   `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *AgentConfigHistoryEntry) GetConfigHash() []byte {
+	if x != nil {
+		return x.ConfigHash
+	}
+	return nil
 }
 
-func (x *DeploymentStatus) Reset() {
-	*x = DeploymentStatus{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[27]
+func (x *AgentConfigHistoryEntry) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type GetAgentConfigAtTimeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentConfigAtTimeRequest) Reset() {
+	*x = GetAgentConfigAtTimeRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeploymentStatus) String() string {
+func (x *GetAgentConfigAtTimeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeploymentStatus) ProtoMessage() {}
+func (*GetAgentConfigAtTimeRequest) ProtoMessage() {}
 
-func (x *DeploymentStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[27]
+func (x *GetAgentConfigAtTimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1734,110 +6184,140 @@ func (x *DeploymentStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeploymentStatus.ProtoReflect.Descriptor instead.
-func (*DeploymentStatus) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use GetAgentConfigAtTimeRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentConfigAtTimeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *DeploymentStatus) GetDeploymentId() string {
+func (x *GetAgentConfigAtTimeRequest) GetAgentId() string {
 	if x != nil {
-		return x.DeploymentId
+		return x.AgentId
 	}
 	return ""
 }
 
-func (x *DeploymentStatus) GetConfigId() string {
+func (x *GetAgentConfigAtTimeRequest) GetTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ConfigId
+		return x.Time
 	}
-	return ""
+	return nil
 }
 
-func (x *DeploymentStatus) GetState() DeploymentState {
-	if x != nil {
-		return x.State
-	}
-	return DeploymentState_DEPLOYMENT_STATE_UNSPECIFIED
+// AgentConfigAtTime is the config of an agent at a past time, reconstructed from its config
+// history
+type AgentConfigAtTime struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	Time    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// the assignment in effect at time, unset if the agent had none
+	Assignment *ConfigAssignment `protobuf:"bytes,3,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	// the version of the assigned config in effect at time
+	Config *Config `protobuf:"bytes,4,opt,name=config,proto3" json:"config,omitempty"`
+	// the last config the agent reported applying before time, unset if unknown
+	Applied *AppliedAgentConfig `protobuf:"bytes,5,opt,name=applied,proto3" json:"applied,omitempty"`
+	// false if the history does not reach back to time, in which case the assignment is unknown
+	Complete      bool `protobuf:"varint,6,opt,name=complete,proto3" json:"complete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeploymentStatus) GetTotalAgents() int32 {
-	if x != nil {
-		return x.TotalAgents
-	}
-	return 0
+func (x *AgentConfigAtTime) Reset() {
+	*x = AgentConfigAtTime{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeploymentStatus) GetCompletedAgents() int32 {
+func (x *AgentConfigAtTime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AgentConfigAtTime) ProtoMessage() {}
+
+func (x *AgentConfigAtTime) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[93]
 	if x != nil {
-		return x.CompletedAgents
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *DeploymentStatus) GetFailedAgents() int32 {
+// Deprecated: Use AgentConfigAtTime.ProtoReflect.Descriptor instead.
+func (*AgentConfigAtTime) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *AgentConfigAtTime) GetAgentId() string {
 	if x != nil {
-		return x.FailedAgents
+		return x.AgentId
 	}
-	return 0
+	return ""
 }
 
-func (x *DeploymentStatus) GetPendingAgents() int32 {
+func (x *AgentConfigAtTime) GetTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.PendingAgents
+		return x.Time
 	}
-	return 0
+	return nil
 }
 
-func (x *DeploymentStatus) GetCurrentBatch() int32 {
+func (x *AgentConfigAtTime) GetAssignment() *ConfigAssignment {
 	if x != nil {
-		return x.CurrentBatch
+		return x.Assignment
 	}
-	return 0
+	return nil
 }
 
-func (x *DeploymentStatus) GetAgentStatuses() []*AgentDeploymentStatus {
+func (x *AgentConfigAtTime) GetConfig() *Config {
 	if x != nil {
-		return x.AgentStatuses
+		return x.Config
 	}
 	return nil
 }
 
-func (x *DeploymentStatus) GetStartedAt() *timestamppb.Timestamp {
+func (x *AgentConfigAtTime) GetApplied() *AppliedAgentConfig {
 	if x != nil {
-		return x.StartedAt
+		return x.Applied
 	}
 	return nil
 }
 
-func (x *DeploymentStatus) GetCompletedAt() *timestamppb.Timestamp {
+func (x *AgentConfigAtTime) GetComplete() bool {
 	if x != nil {
-		return x.CompletedAt
+		return x.Complete
 	}
-	return nil
+	return false
 }
 
-type GetDeploymentStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+type AppliedAgentConfig struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ConfigHash []byte                 `protobuf:"bytes,1,opt,name=config_hash,json=configHash,proto3" json:"config_hash,omitempty"`
+	AppliedAt  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"`
+	// the config assigned with this hash, empty if the history has no such assignment
+	ConfigId      string `protobuf:"bytes,3,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDeploymentStatusRequest) Reset() {
-	*x = GetDeploymentStatusRequest{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[28]
+func (x *AppliedAgentConfig) Reset() {
+	*x = AppliedAgentConfig{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDeploymentStatusRequest) String() string {
+func (x *AppliedAgentConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDeploymentStatusRequest) ProtoMessage() {}
+func (*AppliedAgentConfig) ProtoMessage() {}
 
-func (x *GetDeploymentStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[28]
+func (x *AppliedAgentConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1848,40 +6328,60 @@ func (x *GetDeploymentStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDeploymentStatusRequest.ProtoReflect.Descriptor instead.
-func (*GetDeploymentStatusRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use AppliedAgentConfig.ProtoReflect.Descriptor instead.
+func (*AppliedAgentConfig) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *GetDeploymentStatusRequest) GetDeploymentId() string {
+func (x *AppliedAgentConfig) GetConfigHash() []byte {
 	if x != nil {
-		return x.DeploymentId
+		return x.ConfigHash
+	}
+	return nil
+}
+
+func (x *AppliedAgentConfig) GetAppliedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return nil
+}
+
+func (x *AppliedAgentConfig) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
 	}
 	return ""
 }
 
-type GetDeploymentStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        *DeploymentStatus      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+// PackageTarget is the distribution installed on an agent, or on the agents of a group.
+// Exactly one of agent_id and group_id is set.
+type PackageTarget struct {
+	state        protoimpl.MessageState          `protogen:"open.v1"`
+	AgentId      string                          `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	GroupId      string                          `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Distribution *CollectorDistributionReference `protobuf:"bytes,3,opt,name=distribution,proto3" json:"distribution,omitempty"`
+	// set by the server on every change
+	Audit         *AuditInfo `protobuf:"bytes,4,opt,name=audit,proto3" json:"audit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetDeploymentStatusResponse) Reset() {
-	*x = GetDeploymentStatusResponse{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[29]
+func (x *PackageTarget) Reset() {
+	*x = PackageTarget{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetDeploymentStatusResponse) String() string {
+func (x *PackageTarget) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetDeploymentStatusResponse) ProtoMessage() {}
+func (*PackageTarget) ProtoMessage() {}
 
-func (x *GetDeploymentStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[29]
+func (x *PackageTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1892,40 +6392,61 @@ func (x *GetDeploymentStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetDeploymentStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetDeploymentStatusResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use PackageTarget.ProtoReflect.Descriptor instead.
+func (*PackageTarget) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *GetDeploymentStatusResponse) GetStatus() *DeploymentStatus {
+func (x *PackageTarget) GetAgentId() string {
 	if x != nil {
-		return x.Status
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *PackageTarget) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *PackageTarget) GetDistribution() *CollectorDistributionReference {
+	if x != nil {
+		return x.Distribution
 	}
 	return nil
 }
 
-type PauseDeploymentRequest struct {
+func (x *PackageTarget) GetAudit() *AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+type SetPackageTargetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Target        *PackageTarget         `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PauseDeploymentRequest) Reset() {
-	*x = PauseDeploymentRequest{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[30]
+func (x *SetPackageTargetRequest) Reset() {
+	*x = SetPackageTargetRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PauseDeploymentRequest) String() string {
+func (x *SetPackageTargetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PauseDeploymentRequest) ProtoMessage() {}
+func (*SetPackageTargetRequest) ProtoMessage() {}
 
-func (x *PauseDeploymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[30]
+func (x *SetPackageTargetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1936,40 +6457,41 @@ func (x *PauseDeploymentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PauseDeploymentRequest.ProtoReflect.Descriptor instead.
-func (*PauseDeploymentRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use SetPackageTargetRequest.ProtoReflect.Descriptor instead.
+func (*SetPackageTargetRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *PauseDeploymentRequest) GetDeploymentId() string {
+func (x *SetPackageTargetRequest) GetTarget() *PackageTarget {
 	if x != nil {
-		return x.DeploymentId
+		return x.Target
 	}
-	return ""
+	return nil
 }
 
-type ResumeDeploymentRequest struct {
+type PackageTargetReference struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResumeDeploymentRequest) Reset() {
-	*x = ResumeDeploymentRequest{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[31]
+func (x *PackageTargetReference) Reset() {
+	*x = PackageTargetReference{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResumeDeploymentRequest) String() string {
+func (x *PackageTargetReference) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResumeDeploymentRequest) ProtoMessage() {}
+func (*PackageTargetReference) ProtoMessage() {}
 
-func (x *ResumeDeploymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[31]
+func (x *PackageTargetReference) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1980,40 +6502,46 @@ func (x *ResumeDeploymentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResumeDeploymentRequest.ProtoReflect.Descriptor instead.
-func (*ResumeDeploymentRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use PackageTargetReference.ProtoReflect.Descriptor instead.
+func (*PackageTargetReference) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *ResumeDeploymentRequest) GetDeploymentId() string {
+func (x *PackageTargetReference) GetAgentId() string {
 	if x != nil {
-		return x.DeploymentId
+		return x.AgentId
 	}
 	return ""
 }
 
-type CancelDeploymentRequest struct {
+func (x *PackageTargetReference) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+type ListPackageTargetsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelDeploymentRequest) Reset() {
-	*x = CancelDeploymentRequest{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[32]
+func (x *ListPackageTargetsRequest) Reset() {
+	*x = ListPackageTargetsRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelDeploymentRequest) String() string {
+func (x *ListPackageTargetsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelDeploymentRequest) ProtoMessage() {}
+func (*ListPackageTargetsRequest) ProtoMessage() {}
 
-func (x *CancelDeploymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[32]
+func (x *ListPackageTargetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2024,41 +6552,34 @@ func (x *CancelDeploymentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelDeploymentRequest.ProtoReflect.Descriptor instead.
-func (*CancelDeploymentRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{32}
-}
-
-func (x *CancelDeploymentRequest) GetDeploymentId() string {
-	if x != nil {
-		return x.DeploymentId
-	}
-	return ""
+// Deprecated: Use ListPackageTargetsRequest.ProtoReflect.Descriptor instead.
+func (*ListPackageTargetsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{98}
 }
 
-type DeploymentActionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+type ListPackageTargetsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// agent targets sorted by agent ID, then group targets sorted by group ID
+	Targets       []*PackageTarget `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeploymentActionResponse) Reset() {
-	*x = DeploymentActionResponse{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[33]
+func (x *ListPackageTargetsResponse) Reset() {
+	*x = ListPackageTargetsResponse{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeploymentActionResponse) String() string {
+func (x *ListPackageTargetsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeploymentActionResponse) ProtoMessage() {}
+func (*ListPackageTargetsResponse) ProtoMessage() {}
 
-func (x *DeploymentActionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[33]
+func (x *ListPackageTargetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2069,47 +6590,40 @@ func (x *DeploymentActionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeploymentActionResponse.ProtoReflect.Descriptor instead.
-func (*DeploymentActionResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{33}
-}
-
-func (x *DeploymentActionResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use ListPackageTargetsResponse.ProtoReflect.Descriptor instead.
+func (*ListPackageTargetsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{99}
 }
 
-func (x *DeploymentActionResponse) GetMessage() string {
+func (x *ListPackageTargetsResponse) GetTargets() []*PackageTarget {
 	if x != nil {
-		return x.Message
+		return x.Targets
 	}
-	return ""
+	return nil
 }
 
-type ListDeploymentsRequest struct {
+type GetAgentPackageStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	StateFilter   *DeploymentState       `protobuf:"varint,1,opt,name=state_filter,json=stateFilter,proto3,enum=config.v1alpha1.DeploymentState,oneof" json:"state_filter,omitempty"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListDeploymentsRequest) Reset() {
-	*x = ListDeploymentsRequest{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[34]
+func (x *GetAgentPackageStatusRequest) Reset() {
+	*x = GetAgentPackageStatusRequest{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDeploymentsRequest) String() string {
+func (x *GetAgentPackageStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDeploymentsRequest) ProtoMessage() {}
+func (*GetAgentPackageStatusRequest) ProtoMessage() {}
 
-func (x *ListDeploymentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[34]
+func (x *GetAgentPackageStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2120,40 +6634,51 @@ func (x *ListDeploymentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDeploymentsRequest.ProtoReflect.Descriptor instead.
-func (*ListDeploymentsRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use GetAgentPackageStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentPackageStatusRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{100}
 }
 
-func (x *ListDeploymentsRequest) GetStateFilter() DeploymentState {
-	if x != nil && x.StateFilter != nil {
-		return *x.StateFilter
+func (x *GetAgentPackageStatusRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
 	}
-	return DeploymentState_DEPLOYMENT_STATE_UNSPECIFIED
+	return ""
 }
 
-type ListDeploymentsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Deployments   []*DeploymentStatus    `protobuf:"bytes,1,rep,name=deployments,proto3" json:"deployments,omitempty"`
+type AgentPackageStatus struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AgentId string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// the target applying to the agent, unset if none does
+	Target *PackageTarget `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// the artifact of the target distribution offered to the agent, unset if the distribution
+	// has no artifact for the agent's platform
+	Artifact *DistributionArtifact `protobuf:"bytes,3,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	// the version of the collector package the agent reported installing, empty if unknown
+	InstalledVersion string              `protobuf:"bytes,4,opt,name=installed_version,json=installedVersion,proto3" json:"installed_version,omitempty"`
+	State            PackageInstallState `protobuf:"varint,5,opt,name=state,proto3,enum=config.v1alpha1.PackageInstallState" json:"state,omitempty"`
+	ErrorMessage     string              `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// when the agent last reported its package statuses
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListDeploymentsResponse) Reset() {
-	*x = ListDeploymentsResponse{}
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[35]
+func (x *AgentPackageStatus) Reset() {
+	*x = AgentPackageStatus{}
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListDeploymentsResponse) String() string {
+func (x *AgentPackageStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDeploymentsResponse) ProtoMessage() {}
+func (*AgentPackageStatus) ProtoMessage() {}
 
-func (x *ListDeploymentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[35]
+func (x *AgentPackageStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1alpha1_config_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2164,14 +6689,56 @@ func (x *ListDeploymentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDeploymentsResponse.ProtoReflect.Descriptor instead.
-func (*ListDeploymentsResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use AgentPackageStatus.ProtoReflect.Descriptor instead.
+func (*AgentPackageStatus) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP(), []int{101}
 }
 
-func (x *ListDeploymentsResponse) GetDeployments() []*DeploymentStatus {
+func (x *AgentPackageStatus) GetAgentId() string {
 	if x != nil {
-		return x.Deployments
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AgentPackageStatus) GetTarget() *PackageTarget {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
+func (x *AgentPackageStatus) GetArtifact() *DistributionArtifact {
+	if x != nil {
+		return x.Artifact
+	}
+	return nil
+}
+
+func (x *AgentPackageStatus) GetInstalledVersion() string {
+	if x != nil {
+		return x.InstalledVersion
+	}
+	return ""
+}
+
+func (x *AgentPackageStatus) GetState() PackageInstallState {
+	if x != nil {
+		return x.State
+	}
+	return PackageInstallState_PACKAGE_INSTALL_STATE_UNSPECIFIED
+}
+
+func (x *AgentPackageStatus) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *AgentPackageStatus) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
 	}
 	return nil
 }
@@ -2180,18 +6747,88 @@ var File_pkg_api_config_v1alpha1_config_proto protoreflect.FileDescriptor
 
 const file_pkg_api_config_v1alpha1_config_proto_rawDesc = "" +
 	"\n" +
-	"$pkg/api/config/v1alpha1/config.proto\x12\x0fconfig.v1alpha1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"w\n" +
+	"$pkg/api/config/v1alpha1/config.proto\x12\x0fconfig.v1alpha1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x93\x01\n" +
 	"\x10PutConfigRequest\x122\n" +
 	"\x03ref\x18\x01 \x01(\v2 .config.v1alpha1.ConfigReferenceR\x03ref\x12/\n" +
-	"\x06config\x18\x02 \x01(\v2\x17.config.v1alpha1.ConfigR\x06config\"H\n" +
+	"\x06config\x18\x02 \x01(\v2\x17.config.v1alpha1.ConfigR\x06config\x12\x1a\n" +
+	"\bvalidate\x18\x03 \x01(\bR\bvalidate\"H\n" +
 	"\x15ValidateConfigRequest\x12/\n" +
-	"\x06config\x18\x01 \x01(\v2\x17.config.v1alpha1.ConfigR\x06config\"O\n" +
+	"\x06config\x18\x01 \x01(\v2\x17.config.v1alpha1.ConfigR\x06config\"k\n" +
+	"\x1dValidateConfigDetailedRequest\x12/\n" +
+	"\x06config\x18\x01 \x01(\v2\x17.config.v1alpha1.ConfigR\x06config\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\"\xac\x01\n" +
+	"\rConfigFinding\x12<\n" +
+	"\bseverity\x18\x01 \x01(\x0e2 .config.v1alpha1.FindingSeverityR\bseverity\x12\x17\n" +
+	"\arule_id\x18\x02 \x01(\tR\x06ruleId\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x12\n" +
+	"\x04line\x18\x04 \x01(\rR\x04line\x12\x16\n" +
+	"\x06column\x18\x05 \x01(\rR\x06column\"j\n" +
+	"\x16ConfigValidationResult\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12:\n" +
+	"\bfindings\x18\x02 \x03(\v2\x1e.config.v1alpha1.ConfigFindingR\bfindings\"J\n" +
+	"\x12ListConfigsRequest\x124\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1c.config.v1alpha1.AuditFilterR\x06filter\"\xfb\x01\n" +
 	"\x11ListConfigReponse\x12:\n" +
-	"\aconfigs\x18\x01 \x03(\v2 .config.v1alpha1.ConfigReferenceR\aconfigs\"!\n" +
+	"\aconfigs\x18\x01 \x03(\v2 .config.v1alpha1.ConfigReferenceR\aconfigs\x12L\n" +
+	"\bmetadata\x18\x02 \x03(\v20.config.v1alpha1.ListConfigReponse.MetadataEntryR\bmetadata\x1a\\\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x125\n" +
+	"\x05value\x18\x02 \x01(\v2\x1f.config.v1alpha1.ConfigMetadataR\x05value:\x028\x01\"!\n" +
 	"\x0fConfigReference\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\" \n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"]\n" +
 	"\x06Config\x12\x16\n" +
-	"\x06config\x18\x01 \x01(\fR\x06config\"Q\n" +
+	"\x06config\x18\x01 \x01(\fR\x06config\x12;\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x1f.config.v1alpha1.ConfigMetadataR\bmetadata\"\xdd\x02\n" +
+	"\x0eConfigMetadata\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x120\n" +
+	"\x05audit\x18\x03 \x01(\v2\x1a.config.v1alpha1.AuditInfoR\x05audit\x12R\n" +
+	"\vannotations\x18\x04 \x03(\v20.config.v1alpha1.ConfigMetadata.AnnotationsEntryR\vannotations\x12C\n" +
+	"\tresources\x18\x05 \x01(\v2%.config.v1alpha1.ResourceRequirementsR\tresources\x12\x16\n" +
+	"\x06tenant\x18\x06 \x01(\tR\x06tenant\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"n\n" +
+	"\x14ResourceRequirements\x12(\n" +
+	"\x10min_memory_bytes\x18\x01 \x01(\x04R\x0eminMemoryBytes\x12,\n" +
+	"\x12expected_cpu_cores\x18\x02 \x01(\x01R\x10expectedCpuCores\"\xc3\x01\n" +
+	"\tAuditInfo\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x01 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1f\n" +
+	"\vmodified_by\x18\x03 \x01(\tR\n" +
+	"modifiedBy\x12;\n" +
+	"\vmodified_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"modifiedAt\"\xd5\x01\n" +
+	"\vAuditFilter\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x01 \x01(\tR\tcreatedBy\x12\x1f\n" +
+	"\vmodified_by\x18\x02 \x01(\tR\n" +
+	"modifiedBy\x12A\n" +
+	"\x0emodified_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rmodifiedAfter\x12C\n" +
+	"\x0fmodified_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0emodifiedBefore\"\xa8\x01\n" +
+	"\x11ConfigEditSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x12+\n" +
+	"\x04base\x18\x03 \x01(\v2\x17.config.v1alpha1.ConfigR\x04base\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\x9b\x01\n" +
+	"\x15SaveConfigEditRequest\x122\n" +
+	"\x03ref\x18\x01 \x01(\v2 .config.v1alpha1.ConfigReferenceR\x03ref\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12/\n" +
+	"\x06config\x18\x03 \x01(\v2\x17.config.v1alpha1.ConfigR\x06config\"i\n" +
+	"\x13ConfigMergeConflict\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04base\x18\x02 \x01(\tR\x04base\x12\x12\n" +
+	"\x04ours\x18\x03 \x01(\tR\x04ours\x12\x16\n" +
+	"\x06theirs\x18\x04 \x01(\tR\x06theirs\"\xb9\x01\n" +
+	"\x14SaveConfigEditResult\x12\x14\n" +
+	"\x05saved\x18\x01 \x01(\bR\x05saved\x12\x16\n" +
+	"\x06merged\x18\x02 \x01(\bR\x06merged\x12/\n" +
+	"\x06config\x18\x03 \x01(\v2\x17.config.v1alpha1.ConfigR\x06config\x12B\n" +
+	"\tconflicts\x18\x04 \x03(\v2$.config.v1alpha1.ConfigMergeConflictR\tconflicts\"Q\n" +
 	"\vConfigRange\x12\"\n" +
 	"\fstartVersion\x18\x01 \x01(\tR\fstartVersion\x12\x1e\n" +
 	"\n" +
@@ -2202,7 +6839,7 @@ const file_pkg_api_config_v1alpha1_config_proto_rawDesc = "" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\t\n" +
-	"\aMatcher\"\xdf\x01\n" +
+	"\aMatcher\"\x90\x03\n" +
 	"\x10ConfigAssignment\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
 	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x125\n" +
@@ -2210,13 +6847,32 @@ const file_pkg_api_config_v1alpha1_config_proto_rawDesc = "" +
 	"\vassigned_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"assignedAt\x12\x1f\n" +
 	"\vconfig_hash\x18\x05 \x01(\fR\n" +
-	"configHash\"M\n" +
+	"configHash\x120\n" +
+	"\x05audit\x18\x06 \x01(\v2\x1a.config.v1alpha1.AuditInfoR\x05audit\x12\x1b\n" +
+	"\tpolicy_id\x18\a \x01(\tR\bpolicyId\x12#\n" +
+	"\rauto_rollback\x18\b \x01(\bR\fautoRollback\x12;\n" +
+	"\brollback\x18\t \x01(\v2\x1f.config.v1alpha1.ConfigRollbackR\brollback\"\xcf\x01\n" +
+	"\x0eConfigRollback\x12(\n" +
+	"\x10failed_config_id\x18\x01 \x01(\tR\x0efailedConfigId\x12,\n" +
+	"\x12failed_config_hash\x18\x02 \x01(\fR\x10failedConfigHash\x12#\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x12@\n" +
+	"\x0erolled_back_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\frolledBackAt\"\xc0\x01\n" +
+	"\x0fKnownGoodConfig\x12A\n" +
+	"\n" +
+	"assignment\x18\x01 \x01(\v2!.config.v1alpha1.ConfigAssignmentR\n" +
+	"assignment\x12/\n" +
+	"\x06config\x18\x02 \x01(\v2\x17.config.v1alpha1.ConfigR\x06config\x129\n" +
+	"\n" +
+	"applied_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tappliedAt\"\xa9\x01\n" +
 	"\x13AssignConfigRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
-	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\"J\n" +
+	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x125\n" +
+	"\x06source\x18\x03 \x01(\x0e2\x1d.config.v1alpha1.ConfigSourceR\x06source\x12#\n" +
+	"\rauto_rollback\x18\x04 \x01(\bR\fautoRollback\"f\n" +
 	"\x14AssignConfigResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"2\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\bwarnings\x18\x03 \x03(\tR\bwarnings\"2\n" +
 	"\x15GetAgentConfigRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\"\xa9\x01\n" +
 	"\x16GetAgentConfigResponse\x12\x1b\n" +
@@ -2227,11 +6883,12 @@ const file_pkg_api_config_v1alpha1_config_proto_rawDesc = "" +
 	"\x15UnassignConfigRequest\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\"2\n" +
 	"\x16UnassignConfigResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"N\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x8f\x01\n" +
 	"\x1cListConfigAssignmentsRequest\x12 \n" +
-	"\tconfig_id\x18\x01 \x01(\tH\x00R\bconfigId\x88\x01\x01B\f\n" +
+	"\tconfig_id\x18\x01 \x01(\tH\x00R\bconfigId\x88\x01\x01\x12?\n" +
+	"\faudit_filter\x18\x02 \x01(\v2\x1c.config.v1alpha1.AuditFilterR\vauditFilterB\f\n" +
 	"\n" +
-	"_config_id\"\xa9\x02\n" +
+	"_config_id\"\x98\x03\n" +
 	"\x14ConfigAssignmentInfo\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
 	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x125\n" +
@@ -2239,7 +6896,9 @@ const file_pkg_api_config_v1alpha1_config_proto_rawDesc = "" +
 	"\vassigned_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"assignedAt\x12@\n" +
 	"\x06status\x18\x05 \x01(\x0e2(.config.v1alpha1.ConfigApplicationStatusR\x06status\x12#\n" +
-	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\"h\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x120\n" +
+	"\x05audit\x18\a \x01(\v2\x1a.config.v1alpha1.AuditInfoR\x05audit\x12;\n" +
+	"\brollback\x18\b \x01(\v2\x1f.config.v1alpha1.ConfigRollbackR\brollback\"h\n" +
 	"\x1dListConfigAssignmentsResponse\x12G\n" +
 	"\vassignments\x18\x01 \x03(\v2%.config.v1alpha1.ConfigAssignmentInfoR\vassignments\"3\n" +
 	"\x16GetConfigStatusRequest\x12\x19\n" +
@@ -2250,20 +6909,23 @@ const file_pkg_api_config_v1alpha1_config_proto_rawDesc = "" +
 	"assignment\x122\n" +
 	"\x15effective_config_hash\x18\x02 \x01(\fR\x13effectiveConfigHash\x120\n" +
 	"\x14assigned_config_hash\x18\x03 \x01(\fR\x12assignedConfigHash\x12\x17\n" +
-	"\ain_sync\x18\x04 \x01(\bR\x06inSync\"T\n" +
+	"\ain_sync\x18\x04 \x01(\bR\x06inSync\"j\n" +
 	"\x18BatchAssignConfigRequest\x12\x1b\n" +
 	"\tagent_ids\x18\x01 \x03(\tR\bagentIds\x12\x1b\n" +
-	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\"\xa4\x01\n" +
+	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x12\x14\n" +
+	"\x05async\x18\x03 \x01(\bR\x05async\"\xbb\x01\n" +
 	"\x19BatchAssignConfigResponse\x12\x1e\n" +
 	"\n" +
 	"successful\x18\x01 \x01(\x05R\n" +
 	"successful\x12\x16\n" +
 	"\x06failed\x18\x02 \x01(\x05R\x06failed\x12(\n" +
 	"\x10failed_agent_ids\x18\x03 \x03(\tR\x0efailedAgentIds\x12%\n" +
-	"\x0eerror_messages\x18\x04 \x03(\tR\rerrorMessages\"\xc7\x01\n" +
+	"\x0eerror_messages\x18\x04 \x03(\tR\rerrorMessages\x12\x15\n" +
+	"\x06job_id\x18\x05 \x01(\tR\x05jobId\"\xf8\x01\n" +
 	"\x1bAssignConfigByLabelsRequest\x12P\n" +
 	"\x06labels\x18\x01 \x03(\v28.config.v1alpha1.AssignConfigByLabelsRequest.LabelsEntryR\x06labels\x12\x1b\n" +
-	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x1a9\n" +
+	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x12/\n" +
+	"\x13selector_expression\x18\x03 \x01(\tR\x12selectorExpression\x1a9\n" +
 	"\vLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x82\x01\n" +
@@ -2273,6 +6935,134 @@ const file_pkg_api_config_v1alpha1_config_proto_rawDesc = "" +
 	"successful\x18\x02 \x01(\x05R\n" +
 	"successful\x12\x16\n" +
 	"\x06failed\x18\x03 \x01(\x05R\x06failed\"\xe5\x02\n" +
+	"\x10AssignmentPolicy\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12K\n" +
+	"\bselector\x18\x02 \x03(\v2/.config.v1alpha1.AssignmentPolicy.SelectorEntryR\bselector\x12\x1b\n" +
+	"\tconfig_id\x18\x03 \x01(\tR\bconfigId\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x120\n" +
+	"\x05audit\x18\x05 \x01(\v2\x1a.config.v1alpha1.AuditInfoR\x05audit\x12\x1b\n" +
+	"\tagent_ids\x18\x06 \x03(\tR\bagentIds\x12/\n" +
+	"\x13selector_expression\x18\a \x01(\tR\x12selectorExpression\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"W\n" +
+	"\x1aPutAssignmentPolicyRequest\x129\n" +
+	"\x06policy\x18\x01 \x01(\v2!.config.v1alpha1.AssignmentPolicyR\x06policy\"+\n" +
+	"\x19AssignmentPolicyReference\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1f\n" +
+	"\x1dListAssignmentPoliciesRequest\"\xfb\x02\n" +
+	"\n" +
+	"AgentGroup\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12E\n" +
+	"\bselector\x18\x03 \x03(\v2).config.v1alpha1.AgentGroup.SelectorEntryR\bselector\x12\x1b\n" +
+	"\tagent_ids\x18\x04 \x03(\tR\bagentIds\x12\x1b\n" +
+	"\tconfig_id\x18\x05 \x01(\tR\bconfigId\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\x05R\bpriority\x120\n" +
+	"\x05audit\x18\a \x01(\v2\x1a.config.v1alpha1.AuditInfoR\x05audit\x12/\n" +
+	"\x13selector_expression\x18\b \x01(\tR\x12selectorExpression\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"G\n" +
+	"\x12CreateGroupRequest\x121\n" +
+	"\x05group\x18\x01 \x01(\v2\x1b.config.v1alpha1.AgentGroupR\x05group\"G\n" +
+	"\x12UpdateGroupRequest\x121\n" +
+	"\x05group\x18\x01 \x01(\v2\x1b.config.v1alpha1.AgentGroupR\x05group\"%\n" +
+	"\x13AgentGroupReference\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x13\n" +
+	"\x11ListGroupsRequest\"\xe2\x01\n" +
+	"\x12ListGroupsResponse\x123\n" +
+	"\x06groups\x18\x01 \x03(\v2\x1b.config.v1alpha1.AgentGroupR\x06groups\x12W\n" +
+	"\fagent_counts\x18\x02 \x03(\v24.config.v1alpha1.ListGroupsResponse.AgentCountsEntryR\vagentCounts\x1a>\n" +
+	"\x10AgentCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x9e\x01\n" +
+	"\x18AssignmentPolicyConflict\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1d\n" +
+	"\n" +
+	"policy_ids\x18\x02 \x03(\tR\tpolicyIds\x12*\n" +
+	"\x11applied_policy_id\x18\x03 \x01(\tR\x0fappliedPolicyId\x12\x1c\n" +
+	"\tambiguous\x18\x04 \x01(\bR\tambiguous\"\xd5\x02\n" +
+	"\x1eListAssignmentPoliciesResponse\x12=\n" +
+	"\bpolicies\x18\x01 \x03(\v2!.config.v1alpha1.AssignmentPolicyR\bpolicies\x12G\n" +
+	"\tconflicts\x18\x02 \x03(\v2).config.v1alpha1.AssignmentPolicyConflictR\tconflicts\x12i\n" +
+	"\x0eapplied_agents\x18\x03 \x03(\v2B.config.v1alpha1.ListAssignmentPoliciesResponse.AppliedAgentsEntryR\rappliedAgents\x1a@\n" +
+	"\x12AppliedAgentsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"<\n" +
+	"\x1fGetAssignmentExplanationRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"\xbc\x01\n" +
+	"\x13AssignmentCandidate\x125\n" +
+	"\x06source\x18\x01 \x01(\x0e2\x1d.config.v1alpha1.ConfigSourceR\x06source\x12\x1b\n" +
+	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x12\x1b\n" +
+	"\tpolicy_id\x18\x03 \x01(\tR\bpolicyId\x12\x1c\n" +
+	"\teffective\x18\x04 \x01(\bR\teffective\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\xb1\x02\n" +
+	"\x15AssignmentExplanation\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12H\n" +
+	"\x10effective_source\x18\x02 \x01(\x0e2\x1d.config.v1alpha1.ConfigSourceR\x0feffectiveSource\x12.\n" +
+	"\x13effective_config_id\x18\x03 \x01(\tR\x11effectiveConfigId\x12D\n" +
+	"\n" +
+	"candidates\x18\x04 \x03(\v2$.config.v1alpha1.AssignmentCandidateR\n" +
+	"candidates\x12=\n" +
+	"\n" +
+	"precedence\x18\x05 \x03(\x0e2\x1d.config.v1alpha1.ConfigSourceR\n" +
+	"precedence\"\x8e\x02\n" +
+	"\x0fComponentPolicy\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12J\n" +
+	"\bselector\x18\x02 \x03(\v2..config.v1alpha1.ComponentPolicy.SelectorEntryR\bselector\x12\x16\n" +
+	"\x06denied\x18\x03 \x03(\tR\x06denied\x12\x18\n" +
+	"\aallowed\x18\x04 \x03(\tR\aallowed\x120\n" +
+	"\x05audit\x18\x05 \x01(\v2\x1a.config.v1alpha1.AuditInfoR\x05audit\x1a;\n" +
+	"\rSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"U\n" +
+	"\x19PutComponentPolicyRequest\x128\n" +
+	"\x06policy\x18\x01 \x01(\v2 .config.v1alpha1.ComponentPolicyR\x06policy\"*\n" +
+	"\x18ComponentPolicyReference\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x1e\n" +
+	"\x1cListComponentPoliciesRequest\"\xa5\x01\n" +
+	"\x18ComponentPolicyViolation\x12\x1b\n" +
+	"\tpolicy_id\x18\x01 \x01(\tR\bpolicyId\x12\x19\n" +
+	"\bagent_id\x18\x02 \x01(\tR\aagentId\x12\x1b\n" +
+	"\tconfig_id\x18\x03 \x01(\tR\bconfigId\x12\x1c\n" +
+	"\tcomponent\x18\x04 \x01(\tR\tcomponent\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\xa8\x01\n" +
+	"\x1dListComponentPoliciesResponse\x12<\n" +
+	"\bpolicies\x18\x01 \x03(\v2 .config.v1alpha1.ComponentPolicyR\bpolicies\x12I\n" +
+	"\n" +
+	"violations\x18\x02 \x03(\v2).config.v1alpha1.ComponentPolicyViolationR\n" +
+	"violations\"\xdc\x01\n" +
+	"\x15CollectorDistribution\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1e\n" +
+	"\n" +
+	"components\x18\x03 \x03(\tR\n" +
+	"components\x12C\n" +
+	"\tartifacts\x18\x04 \x03(\v2%.config.v1alpha1.DistributionArtifactR\tartifacts\x120\n" +
+	"\x05audit\x18\x05 \x01(\v2\x1a.config.v1alpha1.AuditInfoR\x05audit\"z\n" +
+	"\x14DistributionArtifact\x12\x1a\n" +
+	"\bplatform\x18\x01 \x01(\tR\bplatform\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\fR\tsignature\"m\n" +
+	"\x1fPutCollectorDistributionRequest\x12J\n" +
+	"\fdistribution\x18\x01 \x01(\v2&.config.v1alpha1.CollectorDistributionR\fdistribution\"N\n" +
+	"\x1eCollectorDistributionReference\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"7\n" +
+	"!ListCollectorDistributionsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"r\n" +
+	"\"ListCollectorDistributionsResponse\x12L\n" +
+	"\rdistributions\x18\x01 \x03(\v2&.config.v1alpha1.CollectorDistributionR\rdistributions\"\x93\x01\n" +
+	"\x1fCheckConfigCompatibilityRequest\x12\x1b\n" +
+	"\tconfig_id\x18\x01 \x01(\tR\bconfigId\x12S\n" +
+	"\fdistribution\x18\x02 \x01(\v2/.config.v1alpha1.CollectorDistributionReferenceR\fdistribution\"d\n" +
+	"\x13ConfigCompatibility\x12\x1e\n" +
+	"\n" +
+	"compatible\x18\x01 \x01(\bR\n" +
+	"compatible\x12-\n" +
+	"\x12missing_components\x18\x02 \x03(\tR\x11missingComponents\"\xb3\x04\n" +
 	"\x18RollingDeploymentRequest\x12\x1b\n" +
 	"\tconfig_id\x18\x01 \x01(\tR\bconfigId\x12\x1b\n" +
 	"\tagent_ids\x18\x02 \x03(\tR\bagentIds\x12]\n" +
@@ -2280,18 +7070,36 @@ const file_pkg_api_config_v1alpha1_config_proto_rawDesc = "" +
 	"\n" +
 	"batch_size\x18\x04 \x01(\x05R\tbatchSize\x12.\n" +
 	"\x13batch_delay_seconds\x18\x05 \x01(\x05R\x11batchDelaySeconds\x12!\n" +
-	"\fmax_failures\x18\x06 \x01(\x05R\vmaxFailures\x1a>\n" +
+	"\fmax_failures\x18\x06 \x01(\x05R\vmaxFailures\x127\n" +
+	"\x18max_apply_jitter_seconds\x18\a \x01(\x05R\x15maxApplyJitterSeconds\x12#\n" +
+	"\rauto_rollback\x18\b \x01(\bR\fautoRollback\x122\n" +
+	"\x15apply_timeout_seconds\x18\t \x01(\x05R\x13applyTimeoutSeconds\x12:\n" +
+	"\x19agent_selector_expression\x18\n" +
+	" \x01(\tR\x17agentSelectorExpression\x1a>\n" +
 	"\x10AgentLabelsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"@\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd8\x01\n" +
+	"\rDeploymentJob\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1b\n" +
+	"\tagent_ids\x18\x02 \x03(\tR\bagentIds\x12C\n" +
+	"\arequest\x18\x03 \x01(\v2).config.v1alpha1.RollingDeploymentRequestR\arequest\x12\x1f\n" +
+	"\vrollback_of\x18\x04 \x01(\tR\n" +
+	"rollbackOf\x12\x1f\n" +
+	"\vbatch_sizes\x18\x05 \x03(\x05R\n" +
+	"batchSizes\"@\n" +
 	"\x19RollingDeploymentResponse\x12#\n" +
-	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"\xcf\x01\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"\xb6\x03\n" +
 	"\x15AgentDeploymentStatus\x12\x19\n" +
 	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12;\n" +
 	"\x05state\x18\x02 \x01(\x0e2%.config.v1alpha1.AgentDeploymentStateR\x05state\x12#\n" +
 	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x129\n" +
 	"\n" +
-	"applied_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tappliedAt\"\x94\x04\n" +
+	"applied_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tappliedAt\x129\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12\x14\n" +
+	"\x05batch\x18\x06 \x01(\x05R\x05batch\x12R\n" +
+	"\x13previous_assignment\x18\a \x01(\v2!.config.v1alpha1.ConfigAssignmentR\x12previousAssignment\x12@\n" +
+	"\x0fprevious_config\x18\b \x01(\v2\x17.config.v1alpha1.ConfigR\x0epreviousConfig\"\xf9\x05\n" +
 	"\x10DeploymentStatus\x12#\n" +
 	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1b\n" +
 	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x126\n" +
@@ -2305,7 +7113,13 @@ const file_pkg_api_config_v1alpha1_config_proto_rawDesc = "" +
 	"\n" +
 	"started_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
-	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"A\n" +
+	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12R\n" +
+	"\x17projected_completion_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x15projectedCompletionAt\x120\n" +
+	"\x05audit\x18\r \x01(\v2\x1a.config.v1alpha1.AuditInfoR\x05audit\x12\x1f\n" +
+	"\vrollback_of\x18\x0e \x01(\tR\n" +
+	"rollbackOf\x12$\n" +
+	"\x0erolled_back_by\x18\x0f \x01(\tR\frolledBackBy\x12\x16\n" +
+	"\x06tenant\x18\x10 \x01(\tR\x06tenant\"A\n" +
 	"\x1aGetDeploymentStatusRequest\x12#\n" +
 	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"X\n" +
 	"\x1bGetDeploymentStatusResponse\x129\n" +
@@ -2315,25 +7129,130 @@ const file_pkg_api_config_v1alpha1_config_proto_rawDesc = "" +
 	"\x17ResumeDeploymentRequest\x12#\n" +
 	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\">\n" +
 	"\x17CancelDeploymentRequest\x12#\n" +
-	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"N\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"=\n" +
+	"\x16PurgeDeploymentRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"\xa4\x01\n" +
+	"\x19RollbackDeploymentRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12.\n" +
+	"\x13batch_delay_seconds\x18\x02 \x01(\x05R\x11batchDelaySeconds\x122\n" +
+	"\x15apply_timeout_seconds\x18\x03 \x01(\x05R\x13applyTimeoutSeconds\"N\n" +
 	"\x18DeploymentActionResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"s\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xb4\x01\n" +
 	"\x16ListDeploymentsRequest\x12H\n" +
-	"\fstate_filter\x18\x01 \x01(\x0e2 .config.v1alpha1.DeploymentStateH\x00R\vstateFilter\x88\x01\x01B\x0f\n" +
+	"\fstate_filter\x18\x01 \x01(\x0e2 .config.v1alpha1.DeploymentStateH\x00R\vstateFilter\x88\x01\x01\x12?\n" +
+	"\faudit_filter\x18\x02 \x01(\v2\x1c.config.v1alpha1.AuditFilterR\vauditFilterB\x0f\n" +
 	"\r_state_filter\"^\n" +
 	"\x17ListDeploymentsResponse\x12C\n" +
-	"\vdeployments\x18\x01 \x03(\v2!.config.v1alpha1.DeploymentStatusR\vdeployments*\x7f\n" +
+	"\vdeployments\x18\x01 \x03(\v2!.config.v1alpha1.DeploymentStatusR\vdeployments\"\x80\x01\n" +
+	"\fSkippedAgent\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12=\n" +
+	"\x06reason\x18\x02 \x01(\x0e2%.config.v1alpha1.DeploymentSkipReasonR\x06reason\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\"H\n" +
+	"\x0fCapacityWarning\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1a\n" +
+	"\bwarnings\x18\x02 \x03(\tR\bwarnings\"\xd4\x01\n" +
+	"\x13DeploymentPlanBatch\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x1b\n" +
+	"\tagent_ids\x18\x02 \x03(\tR\bagentIds\x12@\n" +
+	"\x0eexpected_start\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\rexpectedStart\x12F\n" +
+	"\x11expected_duration\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x10expectedDuration\"\xc3\x03\n" +
+	"\x0eDeploymentPlan\x12\x1b\n" +
+	"\tconfig_id\x18\x01 \x01(\tR\bconfigId\x12!\n" +
+	"\ftotal_agents\x18\x02 \x01(\x05R\vtotalAgents\x12>\n" +
+	"\abatches\x18\x03 \x03(\v2$.config.v1alpha1.DeploymentPlanBatchR\abatches\x12D\n" +
+	"\x0eskipped_agents\x18\x04 \x03(\v2\x1d.config.v1alpha1.SkippedAgentR\rskippedAgents\x12+\n" +
+	"\x11policy_violations\x18\x05 \x03(\tR\x10policyViolations\x12F\n" +
+	"\x11expected_duration\x18\x06 \x01(\v2\x19.google.protobuf.DurationR\x10expectedDuration\x12'\n" +
+	"\x0flatency_samples\x18\a \x01(\x05R\x0elatencySamples\x12M\n" +
+	"\x11capacity_warnings\x18\b \x03(\v2 .config.v1alpha1.CapacityWarningR\x10capacityWarnings\"\x1a\n" +
+	"\x18GetConfigCoverageRequest\"^\n" +
+	"\x0eSignalCoverage\x12\x16\n" +
+	"\x06signal\x18\x01 \x01(\tR\x06signal\x12\x16\n" +
+	"\x06agents\x18\x02 \x01(\x05R\x06agents\x12\x1c\n" +
+	"\tpipelines\x18\x03 \x01(\x05R\tpipelines\"<\n" +
+	"\x0eComponentUsage\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x16\n" +
+	"\x06agents\x18\x02 \x01(\x05R\x06agents\"\xca\x02\n" +
+	"\x14ConfigCoverageReport\x12!\n" +
+	"\ftotal_agents\x18\x01 \x01(\x05R\vtotalAgents\x12)\n" +
+	"\x10reporting_agents\x18\x02 \x01(\x05R\x0freportingAgents\x129\n" +
+	"\asignals\x18\x03 \x03(\v2\x1f.config.v1alpha1.SignalCoverageR\asignals\x12=\n" +
+	"\texporters\x18\x04 \x03(\v2\x1f.config.v1alpha1.ComponentUsageR\texporters\x128\n" +
+	"\x18agents_without_pipelines\x18\x05 \x03(\tR\x16agentsWithoutPipelines\x120\n" +
+	"\x14agents_not_reporting\x18\x06 \x03(\tR\x12agentsNotReporting\"v\n" +
+	"\x12AgentConfigHistory\x12B\n" +
+	"\aentries\x18\x01 \x03(\v2(.config.v1alpha1.AgentConfigHistoryEntryR\aentries\x12\x1c\n" +
+	"\ttruncated\x18\x02 \x01(\bR\ttruncated\"\xbf\x02\n" +
+	"\x17AgentConfigHistoryEntry\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12:\n" +
+	"\x06change\x18\x02 \x01(\x0e2\".config.v1alpha1.AgentConfigChangeR\x06change\x12A\n" +
+	"\n" +
+	"assignment\x18\x03 \x01(\v2!.config.v1alpha1.ConfigAssignmentR\n" +
+	"assignment\x12/\n" +
+	"\x06config\x18\x04 \x01(\v2\x17.config.v1alpha1.ConfigR\x06config\x12\x1f\n" +
+	"\vconfig_hash\x18\x05 \x01(\fR\n" +
+	"configHash\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\"h\n" +
+	"\x1bGetAgentConfigAtTimeRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"\xad\x02\n" +
+	"\x11AgentConfigAtTime\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12A\n" +
+	"\n" +
+	"assignment\x18\x03 \x01(\v2!.config.v1alpha1.ConfigAssignmentR\n" +
+	"assignment\x12/\n" +
+	"\x06config\x18\x04 \x01(\v2\x17.config.v1alpha1.ConfigR\x06config\x12=\n" +
+	"\aapplied\x18\x05 \x01(\v2#.config.v1alpha1.AppliedAgentConfigR\aapplied\x12\x1a\n" +
+	"\bcomplete\x18\x06 \x01(\bR\bcomplete\"\x8d\x01\n" +
+	"\x12AppliedAgentConfig\x12\x1f\n" +
+	"\vconfig_hash\x18\x01 \x01(\fR\n" +
+	"configHash\x129\n" +
+	"\n" +
+	"applied_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tappliedAt\x12\x1b\n" +
+	"\tconfig_id\x18\x03 \x01(\tR\bconfigId\"\xcc\x01\n" +
+	"\rPackageTarget\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x12S\n" +
+	"\fdistribution\x18\x03 \x01(\v2/.config.v1alpha1.CollectorDistributionReferenceR\fdistribution\x120\n" +
+	"\x05audit\x18\x04 \x01(\v2\x1a.config.v1alpha1.AuditInfoR\x05audit\"Q\n" +
+	"\x17SetPackageTargetRequest\x126\n" +
+	"\x06target\x18\x01 \x01(\v2\x1e.config.v1alpha1.PackageTargetR\x06target\"N\n" +
+	"\x16PackageTargetReference\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\"\x1b\n" +
+	"\x19ListPackageTargetsRequest\"V\n" +
+	"\x1aListPackageTargetsResponse\x128\n" +
+	"\atargets\x18\x01 \x03(\v2\x1e.config.v1alpha1.PackageTargetR\atargets\"9\n" +
+	"\x1cGetAgentPackageStatusRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"\xf3\x02\n" +
+	"\x12AgentPackageStatus\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x126\n" +
+	"\x06target\x18\x02 \x01(\v2\x1e.config.v1alpha1.PackageTargetR\x06target\x12A\n" +
+	"\bartifact\x18\x03 \x01(\v2%.config.v1alpha1.DistributionArtifactR\bartifact\x12+\n" +
+	"\x11installed_version\x18\x04 \x01(\tR\x10installedVersion\x12:\n" +
+	"\x05state\x18\x05 \x01(\x0e2$.config.v1alpha1.PackageInstallStateR\x05state\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt*m\n" +
+	"\x0fFindingSeverity\x12 \n" +
+	"\x1cFINDING_SEVERITY_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16FINDING_SEVERITY_ERROR\x10\x01\x12\x1c\n" +
+	"\x18FINDING_SEVERITY_WARNING\x10\x02*\xb5\x01\n" +
 	"\fConfigSource\x12\x1d\n" +
 	"\x19CONFIG_SOURCE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15CONFIG_SOURCE_DEFAULT\x10\x01\x12\x1b\n" +
 	"\x17CONFIG_SOURCE_BOOTSTRAP\x10\x02\x12\x18\n" +
-	"\x14CONFIG_SOURCE_MANUAL\x10\x03*\xb8\x01\n" +
+	"\x14CONFIG_SOURCE_MANUAL\x10\x03\x12\x18\n" +
+	"\x14CONFIG_SOURCE_POLICY\x10\x04\x12\x1a\n" +
+	"\x16CONFIG_SOURCE_FALLBACK\x10\x05*\xe3\x01\n" +
 	"\x17ConfigApplicationStatus\x12)\n" +
 	"%CONFIG_APPLICATION_STATUS_UNSPECIFIED\x10\x00\x12%\n" +
 	"!CONFIG_APPLICATION_STATUS_PENDING\x10\x01\x12%\n" +
 	"!CONFIG_APPLICATION_STATUS_APPLIED\x10\x02\x12$\n" +
-	" CONFIG_APPLICATION_STATUS_FAILED\x10\x03*\xed\x01\n" +
+	" CONFIG_APPLICATION_STATUS_FAILED\x10\x03\x12)\n" +
+	"%CONFIG_APPLICATION_STATUS_UNSUPPORTED\x10\x04*\xed\x01\n" +
 	"\x0fDeploymentState\x12 \n" +
 	"\x1cDEPLOYMENT_STATE_UNSPECIFIED\x10\x00\x12\x1c\n" +
 	"\x18DEPLOYMENT_STATE_PENDING\x10\x01\x12 \n" +
@@ -2341,26 +7260,49 @@ const file_pkg_api_config_v1alpha1_config_proto_rawDesc = "" +
 	"\x17DEPLOYMENT_STATE_PAUSED\x10\x03\x12\x1e\n" +
 	"\x1aDEPLOYMENT_STATE_COMPLETED\x10\x04\x12\x1b\n" +
 	"\x17DEPLOYMENT_STATE_FAILED\x10\x05\x12\x1e\n" +
-	"\x1aDEPLOYMENT_STATE_CANCELLED\x10\x06*\xce\x01\n" +
+	"\x1aDEPLOYMENT_STATE_CANCELLED\x10\x06*\xf2\x01\n" +
 	"\x14AgentDeploymentState\x12&\n" +
 	"\"AGENT_DEPLOYMENT_STATE_UNSPECIFIED\x10\x00\x12\"\n" +
 	"\x1eAGENT_DEPLOYMENT_STATE_PENDING\x10\x01\x12#\n" +
 	"\x1fAGENT_DEPLOYMENT_STATE_APPLYING\x10\x02\x12\"\n" +
 	"\x1eAGENT_DEPLOYMENT_STATE_APPLIED\x10\x03\x12!\n" +
-	"\x1dAGENT_DEPLOYMENT_STATE_FAILED\x10\x042\xfb\x0e\n" +
+	"\x1dAGENT_DEPLOYMENT_STATE_FAILED\x10\x04\x12\"\n" +
+	"\x1eAGENT_DEPLOYMENT_STATE_SKIPPED\x10\x05*\xb1\x01\n" +
+	"\x14DeploymentSkipReason\x12&\n" +
+	"\"DEPLOYMENT_SKIP_REASON_UNSPECIFIED\x10\x00\x12$\n" +
+	" DEPLOYMENT_SKIP_REASON_NOT_FOUND\x10\x01\x12\"\n" +
+	"\x1eDEPLOYMENT_SKIP_REASON_OFFLINE\x10\x02\x12'\n" +
+	"#DEPLOYMENT_SKIP_REASON_INCOMPATIBLE\x10\x03*\xbf\x01\n" +
+	"\x11AgentConfigChange\x12#\n" +
+	"\x1fAGENT_CONFIG_CHANGE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cAGENT_CONFIG_CHANGE_ASSIGNED\x10\x01\x12\"\n" +
+	"\x1eAGENT_CONFIG_CHANGE_UNASSIGNED\x10\x02\x12\x1f\n" +
+	"\x1bAGENT_CONFIG_CHANGE_APPLIED\x10\x03\x12\x1e\n" +
+	"\x1aAGENT_CONFIG_CHANGE_FAILED\x10\x04*\x83\x02\n" +
+	"\x13PackageInstallState\x12%\n" +
+	"!PACKAGE_INSTALL_STATE_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fPACKAGE_INSTALL_STATE_INSTALLED\x10\x01\x12)\n" +
+	"%PACKAGE_INSTALL_STATE_INSTALL_PENDING\x10\x02\x12$\n" +
+	" PACKAGE_INSTALL_STATE_INSTALLING\x10\x03\x12(\n" +
+	"$PACKAGE_INSTALL_STATE_INSTALL_FAILED\x10\x04\x12%\n" +
+	"!PACKAGE_INSTALL_STATE_DOWNLOADING\x10\x052\xd8$\n" +
 	"\rConfigService\x12M\n" +
-	"\vValidConfig\x12&.config.v1alpha1.ValidateConfigRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\vValidConfig\x12&.config.v1alpha1.ValidateConfigRequest\x1a\x16.google.protobuf.Empty\x12q\n" +
+	"\x16ValidateConfigDetailed\x12..config.v1alpha1.ValidateConfigDetailedRequest\x1a'.config.v1alpha1.ConfigValidationResult\x12F\n" +
 	"\tPutConfig\x12!.config.v1alpha1.PutConfigRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
 	"\tGetConfig\x12 .config.v1alpha1.ConfigReference\x1a\x17.config.v1alpha1.Config\x12H\n" +
-	"\fDeleteConfig\x12 .config.v1alpha1.ConfigReference\x1a\x16.google.protobuf.Empty\x12I\n" +
-	"\vListConfigs\x12\x16.google.protobuf.Empty\x1a\".config.v1alpha1.ListConfigReponse\x12C\n" +
-	"\x10GetDefaultConfig\x12\x16.google.protobuf.Empty\x1a\x17.config.v1alpha1.Config\x12M\n" +
+	"\fDeleteConfig\x12 .config.v1alpha1.ConfigReference\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\vListConfigs\x12#.config.v1alpha1.ListConfigsRequest\x1a\".config.v1alpha1.ListConfigReponse\x12C\n" +
+	"\x10GetDefaultConfig\x12\x16.google.protobuf.Empty\x1a\x17.config.v1alpha1.Config\x12W\n" +
+	"\x0fBeginConfigEdit\x12 .config.v1alpha1.ConfigReference\x1a\".config.v1alpha1.ConfigEditSession\x12_\n" +
+	"\x0eSaveConfigEdit\x12&.config.v1alpha1.SaveConfigEditRequest\x1a%.config.v1alpha1.SaveConfigEditResult\x12M\n" +
 	"\x10SetDefaultConfig\x12!.config.v1alpha1.PutConfigRequest\x1a\x16.google.protobuf.Empty\x12[\n" +
 	"\fAssignConfig\x12$.config.v1alpha1.AssignConfigRequest\x1a%.config.v1alpha1.AssignConfigResponse\x12a\n" +
 	"\x0eGetAgentConfig\x12&.config.v1alpha1.GetAgentConfigRequest\x1a'.config.v1alpha1.GetAgentConfigResponse\x12a\n" +
 	"\x0eUnassignConfig\x12&.config.v1alpha1.UnassignConfigRequest\x1a'.config.v1alpha1.UnassignConfigResponse\x12v\n" +
 	"\x15ListConfigAssignments\x12-.config.v1alpha1.ListConfigAssignmentsRequest\x1a..config.v1alpha1.ListConfigAssignmentsResponse\x12d\n" +
-	"\x0fGetConfigStatus\x12'.config.v1alpha1.GetConfigStatusRequest\x1a(.config.v1alpha1.GetConfigStatusResponse\x12j\n" +
+	"\x0fGetConfigStatus\x12'.config.v1alpha1.GetConfigStatusRequest\x1a(.config.v1alpha1.GetConfigStatusResponse\x12h\n" +
+	"\x14GetAgentConfigAtTime\x12,.config.v1alpha1.GetAgentConfigAtTimeRequest\x1a\".config.v1alpha1.AgentConfigAtTime\x12j\n" +
 	"\x11BatchAssignConfig\x12).config.v1alpha1.BatchAssignConfigRequest\x1a*.config.v1alpha1.BatchAssignConfigResponse\x12s\n" +
 	"\x14AssignConfigByLabels\x12,.config.v1alpha1.AssignConfigByLabelsRequest\x1a-.config.v1alpha1.AssignConfigByLabelsResponse\x12o\n" +
 	"\x16StartRollingDeployment\x12).config.v1alpha1.RollingDeploymentRequest\x1a*.config.v1alpha1.RollingDeploymentResponse\x12p\n" +
@@ -2368,7 +7310,36 @@ const file_pkg_api_config_v1alpha1_config_proto_rawDesc = "" +
 	"\x0fPauseDeployment\x12'.config.v1alpha1.PauseDeploymentRequest\x1a).config.v1alpha1.DeploymentActionResponse\x12g\n" +
 	"\x10ResumeDeployment\x12(.config.v1alpha1.ResumeDeploymentRequest\x1a).config.v1alpha1.DeploymentActionResponse\x12g\n" +
 	"\x10CancelDeployment\x12(.config.v1alpha1.CancelDeploymentRequest\x1a).config.v1alpha1.DeploymentActionResponse\x12d\n" +
-	"\x0fListDeployments\x12'.config.v1alpha1.ListDeploymentsRequest\x1a(.config.v1alpha1.ListDeploymentsResponseB8Z6github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1b\x06proto3"
+	"\x0fListDeployments\x12'.config.v1alpha1.ListDeploymentsRequest\x1a(.config.v1alpha1.ListDeploymentsResponse\x12e\n" +
+	"\x0fPurgeDeployment\x12'.config.v1alpha1.PurgeDeploymentRequest\x1a).config.v1alpha1.DeploymentActionResponse\x12l\n" +
+	"\x12RollbackDeployment\x12*.config.v1alpha1.RollbackDeploymentRequest\x1a*.config.v1alpha1.RollingDeploymentResponse\x12`\n" +
+	"\x12SimulateDeployment\x12).config.v1alpha1.RollingDeploymentRequest\x1a\x1f.config.v1alpha1.DeploymentPlan\x12e\n" +
+	"\x13PutAssignmentPolicy\x12+.config.v1alpha1.PutAssignmentPolicyRequest\x1a!.config.v1alpha1.AssignmentPolicy\x12d\n" +
+	"\x13GetAssignmentPolicy\x12*.config.v1alpha1.AssignmentPolicyReference\x1a!.config.v1alpha1.AssignmentPolicy\x12\\\n" +
+	"\x16DeleteAssignmentPolicy\x12*.config.v1alpha1.AssignmentPolicyReference\x1a\x16.google.protobuf.Empty\x12y\n" +
+	"\x16ListAssignmentPolicies\x12..config.v1alpha1.ListAssignmentPoliciesRequest\x1a/.config.v1alpha1.ListAssignmentPoliciesResponse\x12t\n" +
+	"\x18GetAssignmentExplanation\x120.config.v1alpha1.GetAssignmentExplanationRequest\x1a&.config.v1alpha1.AssignmentExplanation\x12O\n" +
+	"\vCreateGroup\x12#.config.v1alpha1.CreateGroupRequest\x1a\x1b.config.v1alpha1.AgentGroup\x12O\n" +
+	"\vUpdateGroup\x12#.config.v1alpha1.UpdateGroupRequest\x1a\x1b.config.v1alpha1.AgentGroup\x12M\n" +
+	"\bGetGroup\x12$.config.v1alpha1.AgentGroupReference\x1a\x1b.config.v1alpha1.AgentGroup\x12K\n" +
+	"\vDeleteGroup\x12$.config.v1alpha1.AgentGroupReference\x1a\x16.google.protobuf.Empty\x12U\n" +
+	"\n" +
+	"ListGroups\x12\".config.v1alpha1.ListGroupsRequest\x1a#.config.v1alpha1.ListGroupsResponse\x12b\n" +
+	"\x12PutComponentPolicy\x12*.config.v1alpha1.PutComponentPolicyRequest\x1a .config.v1alpha1.ComponentPolicy\x12a\n" +
+	"\x12GetComponentPolicy\x12).config.v1alpha1.ComponentPolicyReference\x1a .config.v1alpha1.ComponentPolicy\x12Z\n" +
+	"\x15DeleteComponentPolicy\x12).config.v1alpha1.ComponentPolicyReference\x1a\x16.google.protobuf.Empty\x12v\n" +
+	"\x15ListComponentPolicies\x12-.config.v1alpha1.ListComponentPoliciesRequest\x1a..config.v1alpha1.ListComponentPoliciesResponse\x12t\n" +
+	"\x18PutCollectorDistribution\x120.config.v1alpha1.PutCollectorDistributionRequest\x1a&.config.v1alpha1.CollectorDistribution\x12s\n" +
+	"\x18GetCollectorDistribution\x12/.config.v1alpha1.CollectorDistributionReference\x1a&.config.v1alpha1.CollectorDistribution\x12f\n" +
+	"\x1bDeleteCollectorDistribution\x12/.config.v1alpha1.CollectorDistributionReference\x1a\x16.google.protobuf.Empty\x12\x85\x01\n" +
+	"\x1aListCollectorDistributions\x122.config.v1alpha1.ListCollectorDistributionsRequest\x1a3.config.v1alpha1.ListCollectorDistributionsResponse\x12r\n" +
+	"\x18CheckConfigCompatibility\x120.config.v1alpha1.CheckConfigCompatibilityRequest\x1a$.config.v1alpha1.ConfigCompatibility\x12e\n" +
+	"\x11GetConfigCoverage\x12).config.v1alpha1.GetConfigCoverageRequest\x1a%.config.v1alpha1.ConfigCoverageReport2\xa2\x03\n" +
+	"\x0ePackageService\x12\\\n" +
+	"\x10SetPackageTarget\x12(.config.v1alpha1.SetPackageTargetRequest\x1a\x1e.config.v1alpha1.PackageTarget\x12V\n" +
+	"\x13DeletePackageTarget\x12'.config.v1alpha1.PackageTargetReference\x1a\x16.google.protobuf.Empty\x12m\n" +
+	"\x12ListPackageTargets\x12*.config.v1alpha1.ListPackageTargetsRequest\x1a+.config.v1alpha1.ListPackageTargetsResponse\x12k\n" +
+	"\x15GetAgentPackageStatus\x12-.config.v1alpha1.GetAgentPackageStatusRequest\x1a#.config.v1alpha1.AgentPackageStatusB8Z6github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1b\x06proto3"
 
 var (
 	file_pkg_api_config_v1alpha1_config_proto_rawDescOnce sync.Once
@@ -2382,126 +7353,357 @@ func file_pkg_api_config_v1alpha1_config_proto_rawDescGZIP() []byte {
 	return file_pkg_api_config_v1alpha1_config_proto_rawDescData
 }
 
-var file_pkg_api_config_v1alpha1_config_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_pkg_api_config_v1alpha1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_pkg_api_config_v1alpha1_config_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_pkg_api_config_v1alpha1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 112)
 var file_pkg_api_config_v1alpha1_config_proto_goTypes = []any{
-	(ConfigSource)(0),                     // 0: config.v1alpha1.ConfigSource
-	(ConfigApplicationStatus)(0),          // 1: config.v1alpha1.ConfigApplicationStatus
-	(DeploymentState)(0),                  // 2: config.v1alpha1.DeploymentState
-	(AgentDeploymentState)(0),             // 3: config.v1alpha1.AgentDeploymentState
-	(*PutConfigRequest)(nil),              // 4: config.v1alpha1.PutConfigRequest
-	(*ValidateConfigRequest)(nil),         // 5: config.v1alpha1.ValidateConfigRequest
-	(*ListConfigReponse)(nil),             // 6: config.v1alpha1.ListConfigReponse
-	(*ConfigReference)(nil),               // 7: config.v1alpha1.ConfigReference
-	(*Config)(nil),                        // 8: config.v1alpha1.Config
-	(*ConfigRange)(nil),                   // 9: config.v1alpha1.ConfigRange
-	(*Labels)(nil),                        // 10: config.v1alpha1.Labels
-	(*Matcher)(nil),                       // 11: config.v1alpha1.Matcher
-	(*ConfigAssignment)(nil),              // 12: config.v1alpha1.ConfigAssignment
-	(*AssignConfigRequest)(nil),           // 13: config.v1alpha1.AssignConfigRequest
-	(*AssignConfigResponse)(nil),          // 14: config.v1alpha1.AssignConfigResponse
-	(*GetAgentConfigRequest)(nil),         // 15: config.v1alpha1.GetAgentConfigRequest
-	(*GetAgentConfigResponse)(nil),        // 16: config.v1alpha1.GetAgentConfigResponse
-	(*UnassignConfigRequest)(nil),         // 17: config.v1alpha1.UnassignConfigRequest
-	(*UnassignConfigResponse)(nil),        // 18: config.v1alpha1.UnassignConfigResponse
-	(*ListConfigAssignmentsRequest)(nil),  // 19: config.v1alpha1.ListConfigAssignmentsRequest
-	(*ConfigAssignmentInfo)(nil),          // 20: config.v1alpha1.ConfigAssignmentInfo
-	(*ListConfigAssignmentsResponse)(nil), // 21: config.v1alpha1.ListConfigAssignmentsResponse
-	(*GetConfigStatusRequest)(nil),        // 22: config.v1alpha1.GetConfigStatusRequest
-	(*GetConfigStatusResponse)(nil),       // 23: config.v1alpha1.GetConfigStatusResponse
-	(*BatchAssignConfigRequest)(nil),      // 24: config.v1alpha1.BatchAssignConfigRequest
-	(*BatchAssignConfigResponse)(nil),     // 25: config.v1alpha1.BatchAssignConfigResponse
-	(*AssignConfigByLabelsRequest)(nil),   // 26: config.v1alpha1.AssignConfigByLabelsRequest
-	(*AssignConfigByLabelsResponse)(nil),  // 27: config.v1alpha1.AssignConfigByLabelsResponse
-	(*RollingDeploymentRequest)(nil),      // 28: config.v1alpha1.RollingDeploymentRequest
-	(*RollingDeploymentResponse)(nil),     // 29: config.v1alpha1.RollingDeploymentResponse
-	(*AgentDeploymentStatus)(nil),         // 30: config.v1alpha1.AgentDeploymentStatus
-	(*DeploymentStatus)(nil),              // 31: config.v1alpha1.DeploymentStatus
-	(*GetDeploymentStatusRequest)(nil),    // 32: config.v1alpha1.GetDeploymentStatusRequest
-	(*GetDeploymentStatusResponse)(nil),   // 33: config.v1alpha1.GetDeploymentStatusResponse
-	(*PauseDeploymentRequest)(nil),        // 34: config.v1alpha1.PauseDeploymentRequest
-	(*ResumeDeploymentRequest)(nil),       // 35: config.v1alpha1.ResumeDeploymentRequest
-	(*CancelDeploymentRequest)(nil),       // 36: config.v1alpha1.CancelDeploymentRequest
-	(*DeploymentActionResponse)(nil),      // 37: config.v1alpha1.DeploymentActionResponse
-	(*ListDeploymentsRequest)(nil),        // 38: config.v1alpha1.ListDeploymentsRequest
-	(*ListDeploymentsResponse)(nil),       // 39: config.v1alpha1.ListDeploymentsResponse
-	nil,                                   // 40: config.v1alpha1.Labels.LabelsEntry
-	nil,                                   // 41: config.v1alpha1.AssignConfigByLabelsRequest.LabelsEntry
-	nil,                                   // 42: config.v1alpha1.RollingDeploymentRequest.AgentLabelsEntry
-	(*timestamppb.Timestamp)(nil),         // 43: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                 // 44: google.protobuf.Empty
+	(FindingSeverity)(0),                       // 0: config.v1alpha1.FindingSeverity
+	(ConfigSource)(0),                          // 1: config.v1alpha1.ConfigSource
+	(ConfigApplicationStatus)(0),               // 2: config.v1alpha1.ConfigApplicationStatus
+	(DeploymentState)(0),                       // 3: config.v1alpha1.DeploymentState
+	(AgentDeploymentState)(0),                  // 4: config.v1alpha1.AgentDeploymentState
+	(DeploymentSkipReason)(0),                  // 5: config.v1alpha1.DeploymentSkipReason
+	(AgentConfigChange)(0),                     // 6: config.v1alpha1.AgentConfigChange
+	(PackageInstallState)(0),                   // 7: config.v1alpha1.PackageInstallState
+	(*PutConfigRequest)(nil),                   // 8: config.v1alpha1.PutConfigRequest
+	(*ValidateConfigRequest)(nil),              // 9: config.v1alpha1.ValidateConfigRequest
+	(*ValidateConfigDetailedRequest)(nil),      // 10: config.v1alpha1.ValidateConfigDetailedRequest
+	(*ConfigFinding)(nil),                      // 11: config.v1alpha1.ConfigFinding
+	(*ConfigValidationResult)(nil),             // 12: config.v1alpha1.ConfigValidationResult
+	(*ListConfigsRequest)(nil),                 // 13: config.v1alpha1.ListConfigsRequest
+	(*ListConfigReponse)(nil),                  // 14: config.v1alpha1.ListConfigReponse
+	(*ConfigReference)(nil),                    // 15: config.v1alpha1.ConfigReference
+	(*Config)(nil),                             // 16: config.v1alpha1.Config
+	(*ConfigMetadata)(nil),                     // 17: config.v1alpha1.ConfigMetadata
+	(*ResourceRequirements)(nil),               // 18: config.v1alpha1.ResourceRequirements
+	(*AuditInfo)(nil),                          // 19: config.v1alpha1.AuditInfo
+	(*AuditFilter)(nil),                        // 20: config.v1alpha1.AuditFilter
+	(*ConfigEditSession)(nil),                  // 21: config.v1alpha1.ConfigEditSession
+	(*SaveConfigEditRequest)(nil),              // 22: config.v1alpha1.SaveConfigEditRequest
+	(*ConfigMergeConflict)(nil),                // 23: config.v1alpha1.ConfigMergeConflict
+	(*SaveConfigEditResult)(nil),               // 24: config.v1alpha1.SaveConfigEditResult
+	(*ConfigRange)(nil),                        // 25: config.v1alpha1.ConfigRange
+	(*Labels)(nil),                             // 26: config.v1alpha1.Labels
+	(*Matcher)(nil),                            // 27: config.v1alpha1.Matcher
+	(*ConfigAssignment)(nil),                   // 28: config.v1alpha1.ConfigAssignment
+	(*ConfigRollback)(nil),                     // 29: config.v1alpha1.ConfigRollback
+	(*KnownGoodConfig)(nil),                    // 30: config.v1alpha1.KnownGoodConfig
+	(*AssignConfigRequest)(nil),                // 31: config.v1alpha1.AssignConfigRequest
+	(*AssignConfigResponse)(nil),               // 32: config.v1alpha1.AssignConfigResponse
+	(*GetAgentConfigRequest)(nil),              // 33: config.v1alpha1.GetAgentConfigRequest
+	(*GetAgentConfigResponse)(nil),             // 34: config.v1alpha1.GetAgentConfigResponse
+	(*UnassignConfigRequest)(nil),              // 35: config.v1alpha1.UnassignConfigRequest
+	(*UnassignConfigResponse)(nil),             // 36: config.v1alpha1.UnassignConfigResponse
+	(*ListConfigAssignmentsRequest)(nil),       // 37: config.v1alpha1.ListConfigAssignmentsRequest
+	(*ConfigAssignmentInfo)(nil),               // 38: config.v1alpha1.ConfigAssignmentInfo
+	(*ListConfigAssignmentsResponse)(nil),      // 39: config.v1alpha1.ListConfigAssignmentsResponse
+	(*GetConfigStatusRequest)(nil),             // 40: config.v1alpha1.GetConfigStatusRequest
+	(*GetConfigStatusResponse)(nil),            // 41: config.v1alpha1.GetConfigStatusResponse
+	(*BatchAssignConfigRequest)(nil),           // 42: config.v1alpha1.BatchAssignConfigRequest
+	(*BatchAssignConfigResponse)(nil),          // 43: config.v1alpha1.BatchAssignConfigResponse
+	(*AssignConfigByLabelsRequest)(nil),        // 44: config.v1alpha1.AssignConfigByLabelsRequest
+	(*AssignConfigByLabelsResponse)(nil),       // 45: config.v1alpha1.AssignConfigByLabelsResponse
+	(*AssignmentPolicy)(nil),                   // 46: config.v1alpha1.AssignmentPolicy
+	(*PutAssignmentPolicyRequest)(nil),         // 47: config.v1alpha1.PutAssignmentPolicyRequest
+	(*AssignmentPolicyReference)(nil),          // 48: config.v1alpha1.AssignmentPolicyReference
+	(*ListAssignmentPoliciesRequest)(nil),      // 49: config.v1alpha1.ListAssignmentPoliciesRequest
+	(*AgentGroup)(nil),                         // 50: config.v1alpha1.AgentGroup
+	(*CreateGroupRequest)(nil),                 // 51: config.v1alpha1.CreateGroupRequest
+	(*UpdateGroupRequest)(nil),                 // 52: config.v1alpha1.UpdateGroupRequest
+	(*AgentGroupReference)(nil),                // 53: config.v1alpha1.AgentGroupReference
+	(*ListGroupsRequest)(nil),                  // 54: config.v1alpha1.ListGroupsRequest
+	(*ListGroupsResponse)(nil),                 // 55: config.v1alpha1.ListGroupsResponse
+	(*AssignmentPolicyConflict)(nil),           // 56: config.v1alpha1.AssignmentPolicyConflict
+	(*ListAssignmentPoliciesResponse)(nil),     // 57: config.v1alpha1.ListAssignmentPoliciesResponse
+	(*GetAssignmentExplanationRequest)(nil),    // 58: config.v1alpha1.GetAssignmentExplanationRequest
+	(*AssignmentCandidate)(nil),                // 59: config.v1alpha1.AssignmentCandidate
+	(*AssignmentExplanation)(nil),              // 60: config.v1alpha1.AssignmentExplanation
+	(*ComponentPolicy)(nil),                    // 61: config.v1alpha1.ComponentPolicy
+	(*PutComponentPolicyRequest)(nil),          // 62: config.v1alpha1.PutComponentPolicyRequest
+	(*ComponentPolicyReference)(nil),           // 63: config.v1alpha1.ComponentPolicyReference
+	(*ListComponentPoliciesRequest)(nil),       // 64: config.v1alpha1.ListComponentPoliciesRequest
+	(*ComponentPolicyViolation)(nil),           // 65: config.v1alpha1.ComponentPolicyViolation
+	(*ListComponentPoliciesResponse)(nil),      // 66: config.v1alpha1.ListComponentPoliciesResponse
+	(*CollectorDistribution)(nil),              // 67: config.v1alpha1.CollectorDistribution
+	(*DistributionArtifact)(nil),               // 68: config.v1alpha1.DistributionArtifact
+	(*PutCollectorDistributionRequest)(nil),    // 69: config.v1alpha1.PutCollectorDistributionRequest
+	(*CollectorDistributionReference)(nil),     // 70: config.v1alpha1.CollectorDistributionReference
+	(*ListCollectorDistributionsRequest)(nil),  // 71: config.v1alpha1.ListCollectorDistributionsRequest
+	(*ListCollectorDistributionsResponse)(nil), // 72: config.v1alpha1.ListCollectorDistributionsResponse
+	(*CheckConfigCompatibilityRequest)(nil),    // 73: config.v1alpha1.CheckConfigCompatibilityRequest
+	(*ConfigCompatibility)(nil),                // 74: config.v1alpha1.ConfigCompatibility
+	(*RollingDeploymentRequest)(nil),           // 75: config.v1alpha1.RollingDeploymentRequest
+	(*DeploymentJob)(nil),                      // 76: config.v1alpha1.DeploymentJob
+	(*RollingDeploymentResponse)(nil),          // 77: config.v1alpha1.RollingDeploymentResponse
+	(*AgentDeploymentStatus)(nil),              // 78: config.v1alpha1.AgentDeploymentStatus
+	(*DeploymentStatus)(nil),                   // 79: config.v1alpha1.DeploymentStatus
+	(*GetDeploymentStatusRequest)(nil),         // 80: config.v1alpha1.GetDeploymentStatusRequest
+	(*GetDeploymentStatusResponse)(nil),        // 81: config.v1alpha1.GetDeploymentStatusResponse
+	(*PauseDeploymentRequest)(nil),             // 82: config.v1alpha1.PauseDeploymentRequest
+	(*ResumeDeploymentRequest)(nil),            // 83: config.v1alpha1.ResumeDeploymentRequest
+	(*CancelDeploymentRequest)(nil),            // 84: config.v1alpha1.CancelDeploymentRequest
+	(*PurgeDeploymentRequest)(nil),             // 85: config.v1alpha1.PurgeDeploymentRequest
+	(*RollbackDeploymentRequest)(nil),          // 86: config.v1alpha1.RollbackDeploymentRequest
+	(*DeploymentActionResponse)(nil),           // 87: config.v1alpha1.DeploymentActionResponse
+	(*ListDeploymentsRequest)(nil),             // 88: config.v1alpha1.ListDeploymentsRequest
+	(*ListDeploymentsResponse)(nil),            // 89: config.v1alpha1.ListDeploymentsResponse
+	(*SkippedAgent)(nil),                       // 90: config.v1alpha1.SkippedAgent
+	(*CapacityWarning)(nil),                    // 91: config.v1alpha1.CapacityWarning
+	(*DeploymentPlanBatch)(nil),                // 92: config.v1alpha1.DeploymentPlanBatch
+	(*DeploymentPlan)(nil),                     // 93: config.v1alpha1.DeploymentPlan
+	(*GetConfigCoverageRequest)(nil),           // 94: config.v1alpha1.GetConfigCoverageRequest
+	(*SignalCoverage)(nil),                     // 95: config.v1alpha1.SignalCoverage
+	(*ComponentUsage)(nil),                     // 96: config.v1alpha1.ComponentUsage
+	(*ConfigCoverageReport)(nil),               // 97: config.v1alpha1.ConfigCoverageReport
+	(*AgentConfigHistory)(nil),                 // 98: config.v1alpha1.AgentConfigHistory
+	(*AgentConfigHistoryEntry)(nil),            // 99: config.v1alpha1.AgentConfigHistoryEntry
+	(*GetAgentConfigAtTimeRequest)(nil),        // 100: config.v1alpha1.GetAgentConfigAtTimeRequest
+	(*AgentConfigAtTime)(nil),                  // 101: config.v1alpha1.AgentConfigAtTime
+	(*AppliedAgentConfig)(nil),                 // 102: config.v1alpha1.AppliedAgentConfig
+	(*PackageTarget)(nil),                      // 103: config.v1alpha1.PackageTarget
+	(*SetPackageTargetRequest)(nil),            // 104: config.v1alpha1.SetPackageTargetRequest
+	(*PackageTargetReference)(nil),             // 105: config.v1alpha1.PackageTargetReference
+	(*ListPackageTargetsRequest)(nil),          // 106: config.v1alpha1.ListPackageTargetsRequest
+	(*ListPackageTargetsResponse)(nil),         // 107: config.v1alpha1.ListPackageTargetsResponse
+	(*GetAgentPackageStatusRequest)(nil),       // 108: config.v1alpha1.GetAgentPackageStatusRequest
+	(*AgentPackageStatus)(nil),                 // 109: config.v1alpha1.AgentPackageStatus
+	nil,                                        // 110: config.v1alpha1.ListConfigReponse.MetadataEntry
+	nil,                                        // 111: config.v1alpha1.ConfigMetadata.AnnotationsEntry
+	nil,                                        // 112: config.v1alpha1.Labels.LabelsEntry
+	nil,                                        // 113: config.v1alpha1.AssignConfigByLabelsRequest.LabelsEntry
+	nil,                                        // 114: config.v1alpha1.AssignmentPolicy.SelectorEntry
+	nil,                                        // 115: config.v1alpha1.AgentGroup.SelectorEntry
+	nil,                                        // 116: config.v1alpha1.ListGroupsResponse.AgentCountsEntry
+	nil,                                        // 117: config.v1alpha1.ListAssignmentPoliciesResponse.AppliedAgentsEntry
+	nil,                                        // 118: config.v1alpha1.ComponentPolicy.SelectorEntry
+	nil,                                        // 119: config.v1alpha1.RollingDeploymentRequest.AgentLabelsEntry
+	(*timestamppb.Timestamp)(nil),              // 120: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                // 121: google.protobuf.Duration
+	(*emptypb.Empty)(nil),                      // 122: google.protobuf.Empty
 }
 var file_pkg_api_config_v1alpha1_config_proto_depIdxs = []int32{
-	7,  // 0: config.v1alpha1.PutConfigRequest.ref:type_name -> config.v1alpha1.ConfigReference
-	8,  // 1: config.v1alpha1.PutConfigRequest.config:type_name -> config.v1alpha1.Config
-	8,  // 2: config.v1alpha1.ValidateConfigRequest.config:type_name -> config.v1alpha1.Config
-	7,  // 3: config.v1alpha1.ListConfigReponse.configs:type_name -> config.v1alpha1.ConfigReference
-	40, // 4: config.v1alpha1.Labels.labels:type_name -> config.v1alpha1.Labels.LabelsEntry
-	0,  // 5: config.v1alpha1.ConfigAssignment.source:type_name -> config.v1alpha1.ConfigSource
-	43, // 6: config.v1alpha1.ConfigAssignment.assigned_at:type_name -> google.protobuf.Timestamp
-	0,  // 7: config.v1alpha1.GetAgentConfigResponse.source:type_name -> config.v1alpha1.ConfigSource
-	43, // 8: config.v1alpha1.GetAgentConfigResponse.assigned_at:type_name -> google.protobuf.Timestamp
-	0,  // 9: config.v1alpha1.ConfigAssignmentInfo.source:type_name -> config.v1alpha1.ConfigSource
-	43, // 10: config.v1alpha1.ConfigAssignmentInfo.assigned_at:type_name -> google.protobuf.Timestamp
-	1,  // 11: config.v1alpha1.ConfigAssignmentInfo.status:type_name -> config.v1alpha1.ConfigApplicationStatus
-	20, // 12: config.v1alpha1.ListConfigAssignmentsResponse.assignments:type_name -> config.v1alpha1.ConfigAssignmentInfo
-	20, // 13: config.v1alpha1.GetConfigStatusResponse.assignment:type_name -> config.v1alpha1.ConfigAssignmentInfo
-	41, // 14: config.v1alpha1.AssignConfigByLabelsRequest.labels:type_name -> config.v1alpha1.AssignConfigByLabelsRequest.LabelsEntry
-	42, // 15: config.v1alpha1.RollingDeploymentRequest.agent_labels:type_name -> config.v1alpha1.RollingDeploymentRequest.AgentLabelsEntry
-	3,  // 16: config.v1alpha1.AgentDeploymentStatus.state:type_name -> config.v1alpha1.AgentDeploymentState
-	43, // 17: config.v1alpha1.AgentDeploymentStatus.applied_at:type_name -> google.protobuf.Timestamp
-	2,  // 18: config.v1alpha1.DeploymentStatus.state:type_name -> config.v1alpha1.DeploymentState
-	30, // 19: config.v1alpha1.DeploymentStatus.agent_statuses:type_name -> config.v1alpha1.AgentDeploymentStatus
-	43, // 20: config.v1alpha1.DeploymentStatus.started_at:type_name -> google.protobuf.Timestamp
-	43, // 21: config.v1alpha1.DeploymentStatus.completed_at:type_name -> google.protobuf.Timestamp
-	31, // 22: config.v1alpha1.GetDeploymentStatusResponse.status:type_name -> config.v1alpha1.DeploymentStatus
-	2,  // 23: config.v1alpha1.ListDeploymentsRequest.state_filter:type_name -> config.v1alpha1.DeploymentState
-	31, // 24: config.v1alpha1.ListDeploymentsResponse.deployments:type_name -> config.v1alpha1.DeploymentStatus
-	5,  // 25: config.v1alpha1.ConfigService.ValidConfig:input_type -> config.v1alpha1.ValidateConfigRequest
-	4,  // 26: config.v1alpha1.ConfigService.PutConfig:input_type -> config.v1alpha1.PutConfigRequest
-	7,  // 27: config.v1alpha1.ConfigService.GetConfig:input_type -> config.v1alpha1.ConfigReference
-	7,  // 28: config.v1alpha1.ConfigService.DeleteConfig:input_type -> config.v1alpha1.ConfigReference
-	44, // 29: config.v1alpha1.ConfigService.ListConfigs:input_type -> google.protobuf.Empty
-	44, // 30: config.v1alpha1.ConfigService.GetDefaultConfig:input_type -> google.protobuf.Empty
-	4,  // 31: config.v1alpha1.ConfigService.SetDefaultConfig:input_type -> config.v1alpha1.PutConfigRequest
-	13, // 32: config.v1alpha1.ConfigService.AssignConfig:input_type -> config.v1alpha1.AssignConfigRequest
-	15, // 33: config.v1alpha1.ConfigService.GetAgentConfig:input_type -> config.v1alpha1.GetAgentConfigRequest
-	17, // 34: config.v1alpha1.ConfigService.UnassignConfig:input_type -> config.v1alpha1.UnassignConfigRequest
-	19, // 35: config.v1alpha1.ConfigService.ListConfigAssignments:input_type -> config.v1alpha1.ListConfigAssignmentsRequest
-	22, // 36: config.v1alpha1.ConfigService.GetConfigStatus:input_type -> config.v1alpha1.GetConfigStatusRequest
-	24, // 37: config.v1alpha1.ConfigService.BatchAssignConfig:input_type -> config.v1alpha1.BatchAssignConfigRequest
-	26, // 38: config.v1alpha1.ConfigService.AssignConfigByLabels:input_type -> config.v1alpha1.AssignConfigByLabelsRequest
-	28, // 39: config.v1alpha1.ConfigService.StartRollingDeployment:input_type -> config.v1alpha1.RollingDeploymentRequest
-	32, // 40: config.v1alpha1.ConfigService.GetDeploymentStatus:input_type -> config.v1alpha1.GetDeploymentStatusRequest
-	34, // 41: config.v1alpha1.ConfigService.PauseDeployment:input_type -> config.v1alpha1.PauseDeploymentRequest
-	35, // 42: config.v1alpha1.ConfigService.ResumeDeployment:input_type -> config.v1alpha1.ResumeDeploymentRequest
-	36, // 43: config.v1alpha1.ConfigService.CancelDeployment:input_type -> config.v1alpha1.CancelDeploymentRequest
-	38, // 44: config.v1alpha1.ConfigService.ListDeployments:input_type -> config.v1alpha1.ListDeploymentsRequest
-	44, // 45: config.v1alpha1.ConfigService.ValidConfig:output_type -> google.protobuf.Empty
-	44, // 46: config.v1alpha1.ConfigService.PutConfig:output_type -> google.protobuf.Empty
-	8,  // 47: config.v1alpha1.ConfigService.GetConfig:output_type -> config.v1alpha1.Config
-	44, // 48: config.v1alpha1.ConfigService.DeleteConfig:output_type -> google.protobuf.Empty
-	6,  // 49: config.v1alpha1.ConfigService.ListConfigs:output_type -> config.v1alpha1.ListConfigReponse
-	8,  // 50: config.v1alpha1.ConfigService.GetDefaultConfig:output_type -> config.v1alpha1.Config
-	44, // 51: config.v1alpha1.ConfigService.SetDefaultConfig:output_type -> google.protobuf.Empty
-	14, // 52: config.v1alpha1.ConfigService.AssignConfig:output_type -> config.v1alpha1.AssignConfigResponse
-	16, // 53: config.v1alpha1.ConfigService.GetAgentConfig:output_type -> config.v1alpha1.GetAgentConfigResponse
-	18, // 54: config.v1alpha1.ConfigService.UnassignConfig:output_type -> config.v1alpha1.UnassignConfigResponse
-	21, // 55: config.v1alpha1.ConfigService.ListConfigAssignments:output_type -> config.v1alpha1.ListConfigAssignmentsResponse
-	23, // 56: config.v1alpha1.ConfigService.GetConfigStatus:output_type -> config.v1alpha1.GetConfigStatusResponse
-	25, // 57: config.v1alpha1.ConfigService.BatchAssignConfig:output_type -> config.v1alpha1.BatchAssignConfigResponse
-	27, // 58: config.v1alpha1.ConfigService.AssignConfigByLabels:output_type -> config.v1alpha1.AssignConfigByLabelsResponse
-	29, // 59: config.v1alpha1.ConfigService.StartRollingDeployment:output_type -> config.v1alpha1.RollingDeploymentResponse
-	33, // 60: config.v1alpha1.ConfigService.GetDeploymentStatus:output_type -> config.v1alpha1.GetDeploymentStatusResponse
-	37, // 61: config.v1alpha1.ConfigService.PauseDeployment:output_type -> config.v1alpha1.DeploymentActionResponse
-	37, // 62: config.v1alpha1.ConfigService.ResumeDeployment:output_type -> config.v1alpha1.DeploymentActionResponse
-	37, // 63: config.v1alpha1.ConfigService.CancelDeployment:output_type -> config.v1alpha1.DeploymentActionResponse
-	39, // 64: config.v1alpha1.ConfigService.ListDeployments:output_type -> config.v1alpha1.ListDeploymentsResponse
-	45, // [45:65] is the sub-list for method output_type
-	25, // [25:45] is the sub-list for method input_type
-	25, // [25:25] is the sub-list for extension type_name
-	25, // [25:25] is the sub-list for extension extendee
-	0,  // [0:25] is the sub-list for field type_name
+	15,  // 0: config.v1alpha1.PutConfigRequest.ref:type_name -> config.v1alpha1.ConfigReference
+	16,  // 1: config.v1alpha1.PutConfigRequest.config:type_name -> config.v1alpha1.Config
+	16,  // 2: config.v1alpha1.ValidateConfigRequest.config:type_name -> config.v1alpha1.Config
+	16,  // 3: config.v1alpha1.ValidateConfigDetailedRequest.config:type_name -> config.v1alpha1.Config
+	0,   // 4: config.v1alpha1.ConfigFinding.severity:type_name -> config.v1alpha1.FindingSeverity
+	11,  // 5: config.v1alpha1.ConfigValidationResult.findings:type_name -> config.v1alpha1.ConfigFinding
+	20,  // 6: config.v1alpha1.ListConfigsRequest.filter:type_name -> config.v1alpha1.AuditFilter
+	15,  // 7: config.v1alpha1.ListConfigReponse.configs:type_name -> config.v1alpha1.ConfigReference
+	110, // 8: config.v1alpha1.ListConfigReponse.metadata:type_name -> config.v1alpha1.ListConfigReponse.MetadataEntry
+	17,  // 9: config.v1alpha1.Config.metadata:type_name -> config.v1alpha1.ConfigMetadata
+	19,  // 10: config.v1alpha1.ConfigMetadata.audit:type_name -> config.v1alpha1.AuditInfo
+	111, // 11: config.v1alpha1.ConfigMetadata.annotations:type_name -> config.v1alpha1.ConfigMetadata.AnnotationsEntry
+	18,  // 12: config.v1alpha1.ConfigMetadata.resources:type_name -> config.v1alpha1.ResourceRequirements
+	120, // 13: config.v1alpha1.AuditInfo.created_at:type_name -> google.protobuf.Timestamp
+	120, // 14: config.v1alpha1.AuditInfo.modified_at:type_name -> google.protobuf.Timestamp
+	120, // 15: config.v1alpha1.AuditFilter.modified_after:type_name -> google.protobuf.Timestamp
+	120, // 16: config.v1alpha1.AuditFilter.modified_before:type_name -> google.protobuf.Timestamp
+	16,  // 17: config.v1alpha1.ConfigEditSession.base:type_name -> config.v1alpha1.Config
+	120, // 18: config.v1alpha1.ConfigEditSession.expires_at:type_name -> google.protobuf.Timestamp
+	15,  // 19: config.v1alpha1.SaveConfigEditRequest.ref:type_name -> config.v1alpha1.ConfigReference
+	16,  // 20: config.v1alpha1.SaveConfigEditRequest.config:type_name -> config.v1alpha1.Config
+	16,  // 21: config.v1alpha1.SaveConfigEditResult.config:type_name -> config.v1alpha1.Config
+	23,  // 22: config.v1alpha1.SaveConfigEditResult.conflicts:type_name -> config.v1alpha1.ConfigMergeConflict
+	112, // 23: config.v1alpha1.Labels.labels:type_name -> config.v1alpha1.Labels.LabelsEntry
+	1,   // 24: config.v1alpha1.ConfigAssignment.source:type_name -> config.v1alpha1.ConfigSource
+	120, // 25: config.v1alpha1.ConfigAssignment.assigned_at:type_name -> google.protobuf.Timestamp
+	19,  // 26: config.v1alpha1.ConfigAssignment.audit:type_name -> config.v1alpha1.AuditInfo
+	29,  // 27: config.v1alpha1.ConfigAssignment.rollback:type_name -> config.v1alpha1.ConfigRollback
+	120, // 28: config.v1alpha1.ConfigRollback.rolled_back_at:type_name -> google.protobuf.Timestamp
+	28,  // 29: config.v1alpha1.KnownGoodConfig.assignment:type_name -> config.v1alpha1.ConfigAssignment
+	16,  // 30: config.v1alpha1.KnownGoodConfig.config:type_name -> config.v1alpha1.Config
+	120, // 31: config.v1alpha1.KnownGoodConfig.applied_at:type_name -> google.protobuf.Timestamp
+	1,   // 32: config.v1alpha1.AssignConfigRequest.source:type_name -> config.v1alpha1.ConfigSource
+	1,   // 33: config.v1alpha1.GetAgentConfigResponse.source:type_name -> config.v1alpha1.ConfigSource
+	120, // 34: config.v1alpha1.GetAgentConfigResponse.assigned_at:type_name -> google.protobuf.Timestamp
+	20,  // 35: config.v1alpha1.ListConfigAssignmentsRequest.audit_filter:type_name -> config.v1alpha1.AuditFilter
+	1,   // 36: config.v1alpha1.ConfigAssignmentInfo.source:type_name -> config.v1alpha1.ConfigSource
+	120, // 37: config.v1alpha1.ConfigAssignmentInfo.assigned_at:type_name -> google.protobuf.Timestamp
+	2,   // 38: config.v1alpha1.ConfigAssignmentInfo.status:type_name -> config.v1alpha1.ConfigApplicationStatus
+	19,  // 39: config.v1alpha1.ConfigAssignmentInfo.audit:type_name -> config.v1alpha1.AuditInfo
+	29,  // 40: config.v1alpha1.ConfigAssignmentInfo.rollback:type_name -> config.v1alpha1.ConfigRollback
+	38,  // 41: config.v1alpha1.ListConfigAssignmentsResponse.assignments:type_name -> config.v1alpha1.ConfigAssignmentInfo
+	38,  // 42: config.v1alpha1.GetConfigStatusResponse.assignment:type_name -> config.v1alpha1.ConfigAssignmentInfo
+	113, // 43: config.v1alpha1.AssignConfigByLabelsRequest.labels:type_name -> config.v1alpha1.AssignConfigByLabelsRequest.LabelsEntry
+	114, // 44: config.v1alpha1.AssignmentPolicy.selector:type_name -> config.v1alpha1.AssignmentPolicy.SelectorEntry
+	19,  // 45: config.v1alpha1.AssignmentPolicy.audit:type_name -> config.v1alpha1.AuditInfo
+	46,  // 46: config.v1alpha1.PutAssignmentPolicyRequest.policy:type_name -> config.v1alpha1.AssignmentPolicy
+	115, // 47: config.v1alpha1.AgentGroup.selector:type_name -> config.v1alpha1.AgentGroup.SelectorEntry
+	19,  // 48: config.v1alpha1.AgentGroup.audit:type_name -> config.v1alpha1.AuditInfo
+	50,  // 49: config.v1alpha1.CreateGroupRequest.group:type_name -> config.v1alpha1.AgentGroup
+	50,  // 50: config.v1alpha1.UpdateGroupRequest.group:type_name -> config.v1alpha1.AgentGroup
+	50,  // 51: config.v1alpha1.ListGroupsResponse.groups:type_name -> config.v1alpha1.AgentGroup
+	116, // 52: config.v1alpha1.ListGroupsResponse.agent_counts:type_name -> config.v1alpha1.ListGroupsResponse.AgentCountsEntry
+	46,  // 53: config.v1alpha1.ListAssignmentPoliciesResponse.policies:type_name -> config.v1alpha1.AssignmentPolicy
+	56,  // 54: config.v1alpha1.ListAssignmentPoliciesResponse.conflicts:type_name -> config.v1alpha1.AssignmentPolicyConflict
+	117, // 55: config.v1alpha1.ListAssignmentPoliciesResponse.applied_agents:type_name -> config.v1alpha1.ListAssignmentPoliciesResponse.AppliedAgentsEntry
+	1,   // 56: config.v1alpha1.AssignmentCandidate.source:type_name -> config.v1alpha1.ConfigSource
+	1,   // 57: config.v1alpha1.AssignmentExplanation.effective_source:type_name -> config.v1alpha1.ConfigSource
+	59,  // 58: config.v1alpha1.AssignmentExplanation.candidates:type_name -> config.v1alpha1.AssignmentCandidate
+	1,   // 59: config.v1alpha1.AssignmentExplanation.precedence:type_name -> config.v1alpha1.ConfigSource
+	118, // 60: config.v1alpha1.ComponentPolicy.selector:type_name -> config.v1alpha1.ComponentPolicy.SelectorEntry
+	19,  // 61: config.v1alpha1.ComponentPolicy.audit:type_name -> config.v1alpha1.AuditInfo
+	61,  // 62: config.v1alpha1.PutComponentPolicyRequest.policy:type_name -> config.v1alpha1.ComponentPolicy
+	61,  // 63: config.v1alpha1.ListComponentPoliciesResponse.policies:type_name -> config.v1alpha1.ComponentPolicy
+	65,  // 64: config.v1alpha1.ListComponentPoliciesResponse.violations:type_name -> config.v1alpha1.ComponentPolicyViolation
+	68,  // 65: config.v1alpha1.CollectorDistribution.artifacts:type_name -> config.v1alpha1.DistributionArtifact
+	19,  // 66: config.v1alpha1.CollectorDistribution.audit:type_name -> config.v1alpha1.AuditInfo
+	67,  // 67: config.v1alpha1.PutCollectorDistributionRequest.distribution:type_name -> config.v1alpha1.CollectorDistribution
+	67,  // 68: config.v1alpha1.ListCollectorDistributionsResponse.distributions:type_name -> config.v1alpha1.CollectorDistribution
+	70,  // 69: config.v1alpha1.CheckConfigCompatibilityRequest.distribution:type_name -> config.v1alpha1.CollectorDistributionReference
+	119, // 70: config.v1alpha1.RollingDeploymentRequest.agent_labels:type_name -> config.v1alpha1.RollingDeploymentRequest.AgentLabelsEntry
+	75,  // 71: config.v1alpha1.DeploymentJob.request:type_name -> config.v1alpha1.RollingDeploymentRequest
+	4,   // 72: config.v1alpha1.AgentDeploymentStatus.state:type_name -> config.v1alpha1.AgentDeploymentState
+	120, // 73: config.v1alpha1.AgentDeploymentStatus.applied_at:type_name -> google.protobuf.Timestamp
+	120, // 74: config.v1alpha1.AgentDeploymentStatus.started_at:type_name -> google.protobuf.Timestamp
+	28,  // 75: config.v1alpha1.AgentDeploymentStatus.previous_assignment:type_name -> config.v1alpha1.ConfigAssignment
+	16,  // 76: config.v1alpha1.AgentDeploymentStatus.previous_config:type_name -> config.v1alpha1.Config
+	3,   // 77: config.v1alpha1.DeploymentStatus.state:type_name -> config.v1alpha1.DeploymentState
+	78,  // 78: config.v1alpha1.DeploymentStatus.agent_statuses:type_name -> config.v1alpha1.AgentDeploymentStatus
+	120, // 79: config.v1alpha1.DeploymentStatus.started_at:type_name -> google.protobuf.Timestamp
+	120, // 80: config.v1alpha1.DeploymentStatus.completed_at:type_name -> google.protobuf.Timestamp
+	120, // 81: config.v1alpha1.DeploymentStatus.projected_completion_at:type_name -> google.protobuf.Timestamp
+	19,  // 82: config.v1alpha1.DeploymentStatus.audit:type_name -> config.v1alpha1.AuditInfo
+	79,  // 83: config.v1alpha1.GetDeploymentStatusResponse.status:type_name -> config.v1alpha1.DeploymentStatus
+	3,   // 84: config.v1alpha1.ListDeploymentsRequest.state_filter:type_name -> config.v1alpha1.DeploymentState
+	20,  // 85: config.v1alpha1.ListDeploymentsRequest.audit_filter:type_name -> config.v1alpha1.AuditFilter
+	79,  // 86: config.v1alpha1.ListDeploymentsResponse.deployments:type_name -> config.v1alpha1.DeploymentStatus
+	5,   // 87: config.v1alpha1.SkippedAgent.reason:type_name -> config.v1alpha1.DeploymentSkipReason
+	121, // 88: config.v1alpha1.DeploymentPlanBatch.expected_start:type_name -> google.protobuf.Duration
+	121, // 89: config.v1alpha1.DeploymentPlanBatch.expected_duration:type_name -> google.protobuf.Duration
+	92,  // 90: config.v1alpha1.DeploymentPlan.batches:type_name -> config.v1alpha1.DeploymentPlanBatch
+	90,  // 91: config.v1alpha1.DeploymentPlan.skipped_agents:type_name -> config.v1alpha1.SkippedAgent
+	121, // 92: config.v1alpha1.DeploymentPlan.expected_duration:type_name -> google.protobuf.Duration
+	91,  // 93: config.v1alpha1.DeploymentPlan.capacity_warnings:type_name -> config.v1alpha1.CapacityWarning
+	95,  // 94: config.v1alpha1.ConfigCoverageReport.signals:type_name -> config.v1alpha1.SignalCoverage
+	96,  // 95: config.v1alpha1.ConfigCoverageReport.exporters:type_name -> config.v1alpha1.ComponentUsage
+	99,  // 96: config.v1alpha1.AgentConfigHistory.entries:type_name -> config.v1alpha1.AgentConfigHistoryEntry
+	120, // 97: config.v1alpha1.AgentConfigHistoryEntry.time:type_name -> google.protobuf.Timestamp
+	6,   // 98: config.v1alpha1.AgentConfigHistoryEntry.change:type_name -> config.v1alpha1.AgentConfigChange
+	28,  // 99: config.v1alpha1.AgentConfigHistoryEntry.assignment:type_name -> config.v1alpha1.ConfigAssignment
+	16,  // 100: config.v1alpha1.AgentConfigHistoryEntry.config:type_name -> config.v1alpha1.Config
+	120, // 101: config.v1alpha1.GetAgentConfigAtTimeRequest.time:type_name -> google.protobuf.Timestamp
+	120, // 102: config.v1alpha1.AgentConfigAtTime.time:type_name -> google.protobuf.Timestamp
+	28,  // 103: config.v1alpha1.AgentConfigAtTime.assignment:type_name -> config.v1alpha1.ConfigAssignment
+	16,  // 104: config.v1alpha1.AgentConfigAtTime.config:type_name -> config.v1alpha1.Config
+	102, // 105: config.v1alpha1.AgentConfigAtTime.applied:type_name -> config.v1alpha1.AppliedAgentConfig
+	120, // 106: config.v1alpha1.AppliedAgentConfig.applied_at:type_name -> google.protobuf.Timestamp
+	70,  // 107: config.v1alpha1.PackageTarget.distribution:type_name -> config.v1alpha1.CollectorDistributionReference
+	19,  // 108: config.v1alpha1.PackageTarget.audit:type_name -> config.v1alpha1.AuditInfo
+	103, // 109: config.v1alpha1.SetPackageTargetRequest.target:type_name -> config.v1alpha1.PackageTarget
+	103, // 110: config.v1alpha1.ListPackageTargetsResponse.targets:type_name -> config.v1alpha1.PackageTarget
+	103, // 111: config.v1alpha1.AgentPackageStatus.target:type_name -> config.v1alpha1.PackageTarget
+	68,  // 112: config.v1alpha1.AgentPackageStatus.artifact:type_name -> config.v1alpha1.DistributionArtifact
+	7,   // 113: config.v1alpha1.AgentPackageStatus.state:type_name -> config.v1alpha1.PackageInstallState
+	120, // 114: config.v1alpha1.AgentPackageStatus.updated_at:type_name -> google.protobuf.Timestamp
+	17,  // 115: config.v1alpha1.ListConfigReponse.MetadataEntry.value:type_name -> config.v1alpha1.ConfigMetadata
+	9,   // 116: config.v1alpha1.ConfigService.ValidConfig:input_type -> config.v1alpha1.ValidateConfigRequest
+	10,  // 117: config.v1alpha1.ConfigService.ValidateConfigDetailed:input_type -> config.v1alpha1.ValidateConfigDetailedRequest
+	8,   // 118: config.v1alpha1.ConfigService.PutConfig:input_type -> config.v1alpha1.PutConfigRequest
+	15,  // 119: config.v1alpha1.ConfigService.GetConfig:input_type -> config.v1alpha1.ConfigReference
+	15,  // 120: config.v1alpha1.ConfigService.DeleteConfig:input_type -> config.v1alpha1.ConfigReference
+	13,  // 121: config.v1alpha1.ConfigService.ListConfigs:input_type -> config.v1alpha1.ListConfigsRequest
+	122, // 122: config.v1alpha1.ConfigService.GetDefaultConfig:input_type -> google.protobuf.Empty
+	15,  // 123: config.v1alpha1.ConfigService.BeginConfigEdit:input_type -> config.v1alpha1.ConfigReference
+	22,  // 124: config.v1alpha1.ConfigService.SaveConfigEdit:input_type -> config.v1alpha1.SaveConfigEditRequest
+	8,   // 125: config.v1alpha1.ConfigService.SetDefaultConfig:input_type -> config.v1alpha1.PutConfigRequest
+	31,  // 126: config.v1alpha1.ConfigService.AssignConfig:input_type -> config.v1alpha1.AssignConfigRequest
+	33,  // 127: config.v1alpha1.ConfigService.GetAgentConfig:input_type -> config.v1alpha1.GetAgentConfigRequest
+	35,  // 128: config.v1alpha1.ConfigService.UnassignConfig:input_type -> config.v1alpha1.UnassignConfigRequest
+	37,  // 129: config.v1alpha1.ConfigService.ListConfigAssignments:input_type -> config.v1alpha1.ListConfigAssignmentsRequest
+	40,  // 130: config.v1alpha1.ConfigService.GetConfigStatus:input_type -> config.v1alpha1.GetConfigStatusRequest
+	100, // 131: config.v1alpha1.ConfigService.GetAgentConfigAtTime:input_type -> config.v1alpha1.GetAgentConfigAtTimeRequest
+	42,  // 132: config.v1alpha1.ConfigService.BatchAssignConfig:input_type -> config.v1alpha1.BatchAssignConfigRequest
+	44,  // 133: config.v1alpha1.ConfigService.AssignConfigByLabels:input_type -> config.v1alpha1.AssignConfigByLabelsRequest
+	75,  // 134: config.v1alpha1.ConfigService.StartRollingDeployment:input_type -> config.v1alpha1.RollingDeploymentRequest
+	80,  // 135: config.v1alpha1.ConfigService.GetDeploymentStatus:input_type -> config.v1alpha1.GetDeploymentStatusRequest
+	82,  // 136: config.v1alpha1.ConfigService.PauseDeployment:input_type -> config.v1alpha1.PauseDeploymentRequest
+	83,  // 137: config.v1alpha1.ConfigService.ResumeDeployment:input_type -> config.v1alpha1.ResumeDeploymentRequest
+	84,  // 138: config.v1alpha1.ConfigService.CancelDeployment:input_type -> config.v1alpha1.CancelDeploymentRequest
+	88,  // 139: config.v1alpha1.ConfigService.ListDeployments:input_type -> config.v1alpha1.ListDeploymentsRequest
+	85,  // 140: config.v1alpha1.ConfigService.PurgeDeployment:input_type -> config.v1alpha1.PurgeDeploymentRequest
+	86,  // 141: config.v1alpha1.ConfigService.RollbackDeployment:input_type -> config.v1alpha1.RollbackDeploymentRequest
+	75,  // 142: config.v1alpha1.ConfigService.SimulateDeployment:input_type -> config.v1alpha1.RollingDeploymentRequest
+	47,  // 143: config.v1alpha1.ConfigService.PutAssignmentPolicy:input_type -> config.v1alpha1.PutAssignmentPolicyRequest
+	48,  // 144: config.v1alpha1.ConfigService.GetAssignmentPolicy:input_type -> config.v1alpha1.AssignmentPolicyReference
+	48,  // 145: config.v1alpha1.ConfigService.DeleteAssignmentPolicy:input_type -> config.v1alpha1.AssignmentPolicyReference
+	49,  // 146: config.v1alpha1.ConfigService.ListAssignmentPolicies:input_type -> config.v1alpha1.ListAssignmentPoliciesRequest
+	58,  // 147: config.v1alpha1.ConfigService.GetAssignmentExplanation:input_type -> config.v1alpha1.GetAssignmentExplanationRequest
+	51,  // 148: config.v1alpha1.ConfigService.CreateGroup:input_type -> config.v1alpha1.CreateGroupRequest
+	52,  // 149: config.v1alpha1.ConfigService.UpdateGroup:input_type -> config.v1alpha1.UpdateGroupRequest
+	53,  // 150: config.v1alpha1.ConfigService.GetGroup:input_type -> config.v1alpha1.AgentGroupReference
+	53,  // 151: config.v1alpha1.ConfigService.DeleteGroup:input_type -> config.v1alpha1.AgentGroupReference
+	54,  // 152: config.v1alpha1.ConfigService.ListGroups:input_type -> config.v1alpha1.ListGroupsRequest
+	62,  // 153: config.v1alpha1.ConfigService.PutComponentPolicy:input_type -> config.v1alpha1.PutComponentPolicyRequest
+	63,  // 154: config.v1alpha1.ConfigService.GetComponentPolicy:input_type -> config.v1alpha1.ComponentPolicyReference
+	63,  // 155: config.v1alpha1.ConfigService.DeleteComponentPolicy:input_type -> config.v1alpha1.ComponentPolicyReference
+	64,  // 156: config.v1alpha1.ConfigService.ListComponentPolicies:input_type -> config.v1alpha1.ListComponentPoliciesRequest
+	69,  // 157: config.v1alpha1.ConfigService.PutCollectorDistribution:input_type -> config.v1alpha1.PutCollectorDistributionRequest
+	70,  // 158: config.v1alpha1.ConfigService.GetCollectorDistribution:input_type -> config.v1alpha1.CollectorDistributionReference
+	70,  // 159: config.v1alpha1.ConfigService.DeleteCollectorDistribution:input_type -> config.v1alpha1.CollectorDistributionReference
+	71,  // 160: config.v1alpha1.ConfigService.ListCollectorDistributions:input_type -> config.v1alpha1.ListCollectorDistributionsRequest
+	73,  // 161: config.v1alpha1.ConfigService.CheckConfigCompatibility:input_type -> config.v1alpha1.CheckConfigCompatibilityRequest
+	94,  // 162: config.v1alpha1.ConfigService.GetConfigCoverage:input_type -> config.v1alpha1.GetConfigCoverageRequest
+	104, // 163: config.v1alpha1.PackageService.SetPackageTarget:input_type -> config.v1alpha1.SetPackageTargetRequest
+	105, // 164: config.v1alpha1.PackageService.DeletePackageTarget:input_type -> config.v1alpha1.PackageTargetReference
+	106, // 165: config.v1alpha1.PackageService.ListPackageTargets:input_type -> config.v1alpha1.ListPackageTargetsRequest
+	108, // 166: config.v1alpha1.PackageService.GetAgentPackageStatus:input_type -> config.v1alpha1.GetAgentPackageStatusRequest
+	122, // 167: config.v1alpha1.ConfigService.ValidConfig:output_type -> google.protobuf.Empty
+	12,  // 168: config.v1alpha1.ConfigService.ValidateConfigDetailed:output_type -> config.v1alpha1.ConfigValidationResult
+	122, // 169: config.v1alpha1.ConfigService.PutConfig:output_type -> google.protobuf.Empty
+	16,  // 170: config.v1alpha1.ConfigService.GetConfig:output_type -> config.v1alpha1.Config
+	122, // 171: config.v1alpha1.ConfigService.DeleteConfig:output_type -> google.protobuf.Empty
+	14,  // 172: config.v1alpha1.ConfigService.ListConfigs:output_type -> config.v1alpha1.ListConfigReponse
+	16,  // 173: config.v1alpha1.ConfigService.GetDefaultConfig:output_type -> config.v1alpha1.Config
+	21,  // 174: config.v1alpha1.ConfigService.BeginConfigEdit:output_type -> config.v1alpha1.ConfigEditSession
+	24,  // 175: config.v1alpha1.ConfigService.SaveConfigEdit:output_type -> config.v1alpha1.SaveConfigEditResult
+	122, // 176: config.v1alpha1.ConfigService.SetDefaultConfig:output_type -> google.protobuf.Empty
+	32,  // 177: config.v1alpha1.ConfigService.AssignConfig:output_type -> config.v1alpha1.AssignConfigResponse
+	34,  // 178: config.v1alpha1.ConfigService.GetAgentConfig:output_type -> config.v1alpha1.GetAgentConfigResponse
+	36,  // 179: config.v1alpha1.ConfigService.UnassignConfig:output_type -> config.v1alpha1.UnassignConfigResponse
+	39,  // 180: config.v1alpha1.ConfigService.ListConfigAssignments:output_type -> config.v1alpha1.ListConfigAssignmentsResponse
+	41,  // 181: config.v1alpha1.ConfigService.GetConfigStatus:output_type -> config.v1alpha1.GetConfigStatusResponse
+	101, // 182: config.v1alpha1.ConfigService.GetAgentConfigAtTime:output_type -> config.v1alpha1.AgentConfigAtTime
+	43,  // 183: config.v1alpha1.ConfigService.BatchAssignConfig:output_type -> config.v1alpha1.BatchAssignConfigResponse
+	45,  // 184: config.v1alpha1.ConfigService.AssignConfigByLabels:output_type -> config.v1alpha1.AssignConfigByLabelsResponse
+	77,  // 185: config.v1alpha1.ConfigService.StartRollingDeployment:output_type -> config.v1alpha1.RollingDeploymentResponse
+	81,  // 186: config.v1alpha1.ConfigService.GetDeploymentStatus:output_type -> config.v1alpha1.GetDeploymentStatusResponse
+	87,  // 187: config.v1alpha1.ConfigService.PauseDeployment:output_type -> config.v1alpha1.DeploymentActionResponse
+	87,  // 188: config.v1alpha1.ConfigService.ResumeDeployment:output_type -> config.v1alpha1.DeploymentActionResponse
+	87,  // 189: config.v1alpha1.ConfigService.CancelDeployment:output_type -> config.v1alpha1.DeploymentActionResponse
+	89,  // 190: config.v1alpha1.ConfigService.ListDeployments:output_type -> config.v1alpha1.ListDeploymentsResponse
+	87,  // 191: config.v1alpha1.ConfigService.PurgeDeployment:output_type -> config.v1alpha1.DeploymentActionResponse
+	77,  // 192: config.v1alpha1.ConfigService.RollbackDeployment:output_type -> config.v1alpha1.RollingDeploymentResponse
+	93,  // 193: config.v1alpha1.ConfigService.SimulateDeployment:output_type -> config.v1alpha1.DeploymentPlan
+	46,  // 194: config.v1alpha1.ConfigService.PutAssignmentPolicy:output_type -> config.v1alpha1.AssignmentPolicy
+	46,  // 195: config.v1alpha1.ConfigService.GetAssignmentPolicy:output_type -> config.v1alpha1.AssignmentPolicy
+	122, // 196: config.v1alpha1.ConfigService.DeleteAssignmentPolicy:output_type -> google.protobuf.Empty
+	57,  // 197: config.v1alpha1.ConfigService.ListAssignmentPolicies:output_type -> config.v1alpha1.ListAssignmentPoliciesResponse
+	60,  // 198: config.v1alpha1.ConfigService.GetAssignmentExplanation:output_type -> config.v1alpha1.AssignmentExplanation
+	50,  // 199: config.v1alpha1.ConfigService.CreateGroup:output_type -> config.v1alpha1.AgentGroup
+	50,  // 200: config.v1alpha1.ConfigService.UpdateGroup:output_type -> config.v1alpha1.AgentGroup
+	50,  // 201: config.v1alpha1.ConfigService.GetGroup:output_type -> config.v1alpha1.AgentGroup
+	122, // 202: config.v1alpha1.ConfigService.DeleteGroup:output_type -> google.protobuf.Empty
+	55,  // 203: config.v1alpha1.ConfigService.ListGroups:output_type -> config.v1alpha1.ListGroupsResponse
+	61,  // 204: config.v1alpha1.ConfigService.PutComponentPolicy:output_type -> config.v1alpha1.ComponentPolicy
+	61,  // 205: config.v1alpha1.ConfigService.GetComponentPolicy:output_type -> config.v1alpha1.ComponentPolicy
+	122, // 206: config.v1alpha1.ConfigService.DeleteComponentPolicy:output_type -> google.protobuf.Empty
+	66,  // 207: config.v1alpha1.ConfigService.ListComponentPolicies:output_type -> config.v1alpha1.ListComponentPoliciesResponse
+	67,  // 208: config.v1alpha1.ConfigService.PutCollectorDistribution:output_type -> config.v1alpha1.CollectorDistribution
+	67,  // 209: config.v1alpha1.ConfigService.GetCollectorDistribution:output_type -> config.v1alpha1.CollectorDistribution
+	122, // 210: config.v1alpha1.ConfigService.DeleteCollectorDistribution:output_type -> google.protobuf.Empty
+	72,  // 211: config.v1alpha1.ConfigService.ListCollectorDistributions:output_type -> config.v1alpha1.ListCollectorDistributionsResponse
+	74,  // 212: config.v1alpha1.ConfigService.CheckConfigCompatibility:output_type -> config.v1alpha1.ConfigCompatibility
+	97,  // 213: config.v1alpha1.ConfigService.GetConfigCoverage:output_type -> config.v1alpha1.ConfigCoverageReport
+	103, // 214: config.v1alpha1.PackageService.SetPackageTarget:output_type -> config.v1alpha1.PackageTarget
+	122, // 215: config.v1alpha1.PackageService.DeletePackageTarget:output_type -> google.protobuf.Empty
+	107, // 216: config.v1alpha1.PackageService.ListPackageTargets:output_type -> config.v1alpha1.ListPackageTargetsResponse
+	109, // 217: config.v1alpha1.PackageService.GetAgentPackageStatus:output_type -> config.v1alpha1.AgentPackageStatus
+	167, // [167:218] is the sub-list for method output_type
+	116, // [116:167] is the sub-list for method input_type
+	116, // [116:116] is the sub-list for extension type_name
+	116, // [116:116] is the sub-list for extension extendee
+	0,   // [0:116] is the sub-list for field type_name
 }
 
 func init() { file_pkg_api_config_v1alpha1_config_proto_init() }
@@ -2509,17 +7711,17 @@ func file_pkg_api_config_v1alpha1_config_proto_init() {
 	if File_pkg_api_config_v1alpha1_config_proto != nil {
 		return
 	}
-	file_pkg_api_config_v1alpha1_config_proto_msgTypes[15].OneofWrappers = []any{}
-	file_pkg_api_config_v1alpha1_config_proto_msgTypes[34].OneofWrappers = []any{}
+	file_pkg_api_config_v1alpha1_config_proto_msgTypes[29].OneofWrappers = []any{}
+	file_pkg_api_config_v1alpha1_config_proto_msgTypes[80].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_config_v1alpha1_config_proto_rawDesc), len(file_pkg_api_config_v1alpha1_config_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   39,
+			NumEnums:      8,
+			NumMessages:   112,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_pkg_api_config_v1alpha1_config_proto_goTypes,
 		DependencyIndexes: file_pkg_api_config_v1alpha1_config_proto_depIdxs,
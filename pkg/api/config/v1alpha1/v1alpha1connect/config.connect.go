@@ -24,6 +24,8 @@ const _ = connect.IsAtLeastVersion1_13_0
 const (
 	// ConfigServiceName is the fully-qualified name of the ConfigService service.
 	ConfigServiceName = "config.v1alpha1.ConfigService"
+	// PackageServiceName is the fully-qualified name of the PackageService service.
+	PackageServiceName = "config.v1alpha1.PackageService"
 )
 
 // These constants are the fully-qualified names of the RPCs defined in this package. They're
@@ -37,6 +39,9 @@ const (
 	// ConfigServiceValidConfigProcedure is the fully-qualified name of the ConfigService's ValidConfig
 	// RPC.
 	ConfigServiceValidConfigProcedure = "/config.v1alpha1.ConfigService/ValidConfig"
+	// ConfigServiceValidateConfigDetailedProcedure is the fully-qualified name of the ConfigService's
+	// ValidateConfigDetailed RPC.
+	ConfigServiceValidateConfigDetailedProcedure = "/config.v1alpha1.ConfigService/ValidateConfigDetailed"
 	// ConfigServicePutConfigProcedure is the fully-qualified name of the ConfigService's PutConfig RPC.
 	ConfigServicePutConfigProcedure = "/config.v1alpha1.ConfigService/PutConfig"
 	// ConfigServiceGetConfigProcedure is the fully-qualified name of the ConfigService's GetConfig RPC.
@@ -50,6 +55,12 @@ const (
 	// ConfigServiceGetDefaultConfigProcedure is the fully-qualified name of the ConfigService's
 	// GetDefaultConfig RPC.
 	ConfigServiceGetDefaultConfigProcedure = "/config.v1alpha1.ConfigService/GetDefaultConfig"
+	// ConfigServiceBeginConfigEditProcedure is the fully-qualified name of the ConfigService's
+	// BeginConfigEdit RPC.
+	ConfigServiceBeginConfigEditProcedure = "/config.v1alpha1.ConfigService/BeginConfigEdit"
+	// ConfigServiceSaveConfigEditProcedure is the fully-qualified name of the ConfigService's
+	// SaveConfigEdit RPC.
+	ConfigServiceSaveConfigEditProcedure = "/config.v1alpha1.ConfigService/SaveConfigEdit"
 	// ConfigServiceSetDefaultConfigProcedure is the fully-qualified name of the ConfigService's
 	// SetDefaultConfig RPC.
 	ConfigServiceSetDefaultConfigProcedure = "/config.v1alpha1.ConfigService/SetDefaultConfig"
@@ -68,6 +79,9 @@ const (
 	// ConfigServiceGetConfigStatusProcedure is the fully-qualified name of the ConfigService's
 	// GetConfigStatus RPC.
 	ConfigServiceGetConfigStatusProcedure = "/config.v1alpha1.ConfigService/GetConfigStatus"
+	// ConfigServiceGetAgentConfigAtTimeProcedure is the fully-qualified name of the ConfigService's
+	// GetAgentConfigAtTime RPC.
+	ConfigServiceGetAgentConfigAtTimeProcedure = "/config.v1alpha1.ConfigService/GetAgentConfigAtTime"
 	// ConfigServiceBatchAssignConfigProcedure is the fully-qualified name of the ConfigService's
 	// BatchAssignConfig RPC.
 	ConfigServiceBatchAssignConfigProcedure = "/config.v1alpha1.ConfigService/BatchAssignConfig"
@@ -92,17 +106,103 @@ const (
 	// ConfigServiceListDeploymentsProcedure is the fully-qualified name of the ConfigService's
 	// ListDeployments RPC.
 	ConfigServiceListDeploymentsProcedure = "/config.v1alpha1.ConfigService/ListDeployments"
+	// ConfigServicePurgeDeploymentProcedure is the fully-qualified name of the ConfigService's
+	// PurgeDeployment RPC.
+	ConfigServicePurgeDeploymentProcedure = "/config.v1alpha1.ConfigService/PurgeDeployment"
+	// ConfigServiceRollbackDeploymentProcedure is the fully-qualified name of the ConfigService's
+	// RollbackDeployment RPC.
+	ConfigServiceRollbackDeploymentProcedure = "/config.v1alpha1.ConfigService/RollbackDeployment"
+	// ConfigServiceSimulateDeploymentProcedure is the fully-qualified name of the ConfigService's
+	// SimulateDeployment RPC.
+	ConfigServiceSimulateDeploymentProcedure = "/config.v1alpha1.ConfigService/SimulateDeployment"
+	// ConfigServicePutAssignmentPolicyProcedure is the fully-qualified name of the ConfigService's
+	// PutAssignmentPolicy RPC.
+	ConfigServicePutAssignmentPolicyProcedure = "/config.v1alpha1.ConfigService/PutAssignmentPolicy"
+	// ConfigServiceGetAssignmentPolicyProcedure is the fully-qualified name of the ConfigService's
+	// GetAssignmentPolicy RPC.
+	ConfigServiceGetAssignmentPolicyProcedure = "/config.v1alpha1.ConfigService/GetAssignmentPolicy"
+	// ConfigServiceDeleteAssignmentPolicyProcedure is the fully-qualified name of the ConfigService's
+	// DeleteAssignmentPolicy RPC.
+	ConfigServiceDeleteAssignmentPolicyProcedure = "/config.v1alpha1.ConfigService/DeleteAssignmentPolicy"
+	// ConfigServiceListAssignmentPoliciesProcedure is the fully-qualified name of the ConfigService's
+	// ListAssignmentPolicies RPC.
+	ConfigServiceListAssignmentPoliciesProcedure = "/config.v1alpha1.ConfigService/ListAssignmentPolicies"
+	// ConfigServiceGetAssignmentExplanationProcedure is the fully-qualified name of the ConfigService's
+	// GetAssignmentExplanation RPC.
+	ConfigServiceGetAssignmentExplanationProcedure = "/config.v1alpha1.ConfigService/GetAssignmentExplanation"
+	// ConfigServiceCreateGroupProcedure is the fully-qualified name of the ConfigService's CreateGroup
+	// RPC.
+	ConfigServiceCreateGroupProcedure = "/config.v1alpha1.ConfigService/CreateGroup"
+	// ConfigServiceUpdateGroupProcedure is the fully-qualified name of the ConfigService's UpdateGroup
+	// RPC.
+	ConfigServiceUpdateGroupProcedure = "/config.v1alpha1.ConfigService/UpdateGroup"
+	// ConfigServiceGetGroupProcedure is the fully-qualified name of the ConfigService's GetGroup RPC.
+	ConfigServiceGetGroupProcedure = "/config.v1alpha1.ConfigService/GetGroup"
+	// ConfigServiceDeleteGroupProcedure is the fully-qualified name of the ConfigService's DeleteGroup
+	// RPC.
+	ConfigServiceDeleteGroupProcedure = "/config.v1alpha1.ConfigService/DeleteGroup"
+	// ConfigServiceListGroupsProcedure is the fully-qualified name of the ConfigService's ListGroups
+	// RPC.
+	ConfigServiceListGroupsProcedure = "/config.v1alpha1.ConfigService/ListGroups"
+	// ConfigServicePutComponentPolicyProcedure is the fully-qualified name of the ConfigService's
+	// PutComponentPolicy RPC.
+	ConfigServicePutComponentPolicyProcedure = "/config.v1alpha1.ConfigService/PutComponentPolicy"
+	// ConfigServiceGetComponentPolicyProcedure is the fully-qualified name of the ConfigService's
+	// GetComponentPolicy RPC.
+	ConfigServiceGetComponentPolicyProcedure = "/config.v1alpha1.ConfigService/GetComponentPolicy"
+	// ConfigServiceDeleteComponentPolicyProcedure is the fully-qualified name of the ConfigService's
+	// DeleteComponentPolicy RPC.
+	ConfigServiceDeleteComponentPolicyProcedure = "/config.v1alpha1.ConfigService/DeleteComponentPolicy"
+	// ConfigServiceListComponentPoliciesProcedure is the fully-qualified name of the ConfigService's
+	// ListComponentPolicies RPC.
+	ConfigServiceListComponentPoliciesProcedure = "/config.v1alpha1.ConfigService/ListComponentPolicies"
+	// ConfigServicePutCollectorDistributionProcedure is the fully-qualified name of the ConfigService's
+	// PutCollectorDistribution RPC.
+	ConfigServicePutCollectorDistributionProcedure = "/config.v1alpha1.ConfigService/PutCollectorDistribution"
+	// ConfigServiceGetCollectorDistributionProcedure is the fully-qualified name of the ConfigService's
+	// GetCollectorDistribution RPC.
+	ConfigServiceGetCollectorDistributionProcedure = "/config.v1alpha1.ConfigService/GetCollectorDistribution"
+	// ConfigServiceDeleteCollectorDistributionProcedure is the fully-qualified name of the
+	// ConfigService's DeleteCollectorDistribution RPC.
+	ConfigServiceDeleteCollectorDistributionProcedure = "/config.v1alpha1.ConfigService/DeleteCollectorDistribution"
+	// ConfigServiceListCollectorDistributionsProcedure is the fully-qualified name of the
+	// ConfigService's ListCollectorDistributions RPC.
+	ConfigServiceListCollectorDistributionsProcedure = "/config.v1alpha1.ConfigService/ListCollectorDistributions"
+	// ConfigServiceCheckConfigCompatibilityProcedure is the fully-qualified name of the ConfigService's
+	// CheckConfigCompatibility RPC.
+	ConfigServiceCheckConfigCompatibilityProcedure = "/config.v1alpha1.ConfigService/CheckConfigCompatibility"
+	// ConfigServiceGetConfigCoverageProcedure is the fully-qualified name of the ConfigService's
+	// GetConfigCoverage RPC.
+	ConfigServiceGetConfigCoverageProcedure = "/config.v1alpha1.ConfigService/GetConfigCoverage"
+	// PackageServiceSetPackageTargetProcedure is the fully-qualified name of the PackageService's
+	// SetPackageTarget RPC.
+	PackageServiceSetPackageTargetProcedure = "/config.v1alpha1.PackageService/SetPackageTarget"
+	// PackageServiceDeletePackageTargetProcedure is the fully-qualified name of the PackageService's
+	// DeletePackageTarget RPC.
+	PackageServiceDeletePackageTargetProcedure = "/config.v1alpha1.PackageService/DeletePackageTarget"
+	// PackageServiceListPackageTargetsProcedure is the fully-qualified name of the PackageService's
+	// ListPackageTargets RPC.
+	PackageServiceListPackageTargetsProcedure = "/config.v1alpha1.PackageService/ListPackageTargets"
+	// PackageServiceGetAgentPackageStatusProcedure is the fully-qualified name of the PackageService's
+	// GetAgentPackageStatus RPC.
+	PackageServiceGetAgentPackageStatusProcedure = "/config.v1alpha1.PackageService/GetAgentPackageStatus"
 )
 
 // ConfigServiceClient is a client for the config.v1alpha1.ConfigService service.
 type ConfigServiceClient interface {
 	// Config CRUD
+	// Fails with INVALID_ARGUMENT if the config is invalid, with a ConfigValidationResult detail
 	ValidConfig(context.Context, *connect.Request[v1alpha1.ValidateConfigRequest]) (*connect.Response[emptypb.Empty], error)
+	// Returns every finding about a config with its position, for editors
+	ValidateConfigDetailed(context.Context, *connect.Request[v1alpha1.ValidateConfigDetailedRequest]) (*connect.Response[v1alpha1.ConfigValidationResult], error)
 	PutConfig(context.Context, *connect.Request[v1alpha1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error)
 	GetConfig(context.Context, *connect.Request[v1alpha1.ConfigReference]) (*connect.Response[v1alpha1.Config], error)
 	DeleteConfig(context.Context, *connect.Request[v1alpha1.ConfigReference]) (*connect.Response[emptypb.Empty], error)
-	ListConfigs(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListConfigReponse], error)
+	ListConfigs(context.Context, *connect.Request[v1alpha1.ListConfigsRequest]) (*connect.Response[v1alpha1.ListConfigReponse], error)
 	GetDefaultConfig(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.Config], error)
+	// Concurrent edits: saves merge the changes made to the config since the session began
+	BeginConfigEdit(context.Context, *connect.Request[v1alpha1.ConfigReference]) (*connect.Response[v1alpha1.ConfigEditSession], error)
+	SaveConfigEdit(context.Context, *connect.Request[v1alpha1.SaveConfigEditRequest]) (*connect.Response[v1alpha1.SaveConfigEditResult], error)
 	SetDefaultConfig(context.Context, *connect.Request[v1alpha1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error)
 	// Phase 1: Manual Config Assignment
 	AssignConfig(context.Context, *connect.Request[v1alpha1.AssignConfigRequest]) (*connect.Response[v1alpha1.AssignConfigResponse], error)
@@ -111,6 +211,8 @@ type ConfigServiceClient interface {
 	// Phase 2: Config Assignment Queries and Status
 	ListConfigAssignments(context.Context, *connect.Request[v1alpha1.ListConfigAssignmentsRequest]) (*connect.Response[v1alpha1.ListConfigAssignmentsResponse], error)
 	GetConfigStatus(context.Context, *connect.Request[v1alpha1.GetConfigStatusRequest]) (*connect.Response[v1alpha1.GetConfigStatusResponse], error)
+	// Reconstructs the config assigned to an agent, and the one it applied, at a past time
+	GetAgentConfigAtTime(context.Context, *connect.Request[v1alpha1.GetAgentConfigAtTimeRequest]) (*connect.Response[v1alpha1.AgentConfigAtTime], error)
 	// Phase 3: Batch Assignment
 	BatchAssignConfig(context.Context, *connect.Request[v1alpha1.BatchAssignConfigRequest]) (*connect.Response[v1alpha1.BatchAssignConfigResponse], error)
 	AssignConfigByLabels(context.Context, *connect.Request[v1alpha1.AssignConfigByLabelsRequest]) (*connect.Response[v1alpha1.AssignConfigByLabelsResponse], error)
@@ -121,6 +223,42 @@ type ConfigServiceClient interface {
 	ResumeDeployment(context.Context, *connect.Request[v1alpha1.ResumeDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentActionResponse], error)
 	CancelDeployment(context.Context, *connect.Request[v1alpha1.CancelDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentActionResponse], error)
 	ListDeployments(context.Context, *connect.Request[v1alpha1.ListDeploymentsRequest]) (*connect.Response[v1alpha1.ListDeploymentsResponse], error)
+	// Deletes a finished deployment and its per-agent statuses
+	PurgeDeployment(context.Context, *connect.Request[v1alpha1.PurgeDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentActionResponse], error)
+	// Restores the configs agents had before a finished deployment, in reverse batch order,
+	// as a new deployment
+	RollbackDeployment(context.Context, *connect.Request[v1alpha1.RollbackDeploymentRequest]) (*connect.Response[v1alpha1.RollingDeploymentResponse], error)
+	// Plans a rolling deployment without executing it
+	SimulateDeployment(context.Context, *connect.Request[v1alpha1.RollingDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentPlan], error)
+	// Assignment policies: steady-state config assignment by label selector
+	PutAssignmentPolicy(context.Context, *connect.Request[v1alpha1.PutAssignmentPolicyRequest]) (*connect.Response[v1alpha1.AssignmentPolicy], error)
+	GetAssignmentPolicy(context.Context, *connect.Request[v1alpha1.AssignmentPolicyReference]) (*connect.Response[v1alpha1.AssignmentPolicy], error)
+	DeleteAssignmentPolicy(context.Context, *connect.Request[v1alpha1.AssignmentPolicyReference]) (*connect.Response[emptypb.Empty], error)
+	ListAssignmentPolicies(context.Context, *connect.Request[v1alpha1.ListAssignmentPoliciesRequest]) (*connect.Response[v1alpha1.ListAssignmentPoliciesResponse], error)
+	// Lists every source that could assign a config to an agent, and which one applies
+	GetAssignmentExplanation(context.Context, *connect.Request[v1alpha1.GetAssignmentExplanationRequest]) (*connect.Response[v1alpha1.AssignmentExplanation], error)
+	// Agent groups: named sets of agents, selected by labels or listed explicitly, whose config
+	// is assigned to every agent in the group
+	CreateGroup(context.Context, *connect.Request[v1alpha1.CreateGroupRequest]) (*connect.Response[v1alpha1.AgentGroup], error)
+	UpdateGroup(context.Context, *connect.Request[v1alpha1.UpdateGroupRequest]) (*connect.Response[v1alpha1.AgentGroup], error)
+	GetGroup(context.Context, *connect.Request[v1alpha1.AgentGroupReference]) (*connect.Response[v1alpha1.AgentGroup], error)
+	DeleteGroup(context.Context, *connect.Request[v1alpha1.AgentGroupReference]) (*connect.Response[emptypb.Empty], error)
+	ListGroups(context.Context, *connect.Request[v1alpha1.ListGroupsRequest]) (*connect.Response[v1alpha1.ListGroupsResponse], error)
+	// Component policies forbid collector components in the configs of the agents they apply to
+	PutComponentPolicy(context.Context, *connect.Request[v1alpha1.PutComponentPolicyRequest]) (*connect.Response[v1alpha1.ComponentPolicy], error)
+	GetComponentPolicy(context.Context, *connect.Request[v1alpha1.ComponentPolicyReference]) (*connect.Response[v1alpha1.ComponentPolicy], error)
+	DeleteComponentPolicy(context.Context, *connect.Request[v1alpha1.ComponentPolicyReference]) (*connect.Response[emptypb.Empty], error)
+	// Lists the component policies along with the assigned configs violating them
+	ListComponentPolicies(context.Context, *connect.Request[v1alpha1.ListComponentPoliciesRequest]) (*connect.Response[v1alpha1.ListComponentPoliciesResponse], error)
+	// Collector distributions describe the collector builds agents run
+	PutCollectorDistribution(context.Context, *connect.Request[v1alpha1.PutCollectorDistributionRequest]) (*connect.Response[v1alpha1.CollectorDistribution], error)
+	GetCollectorDistribution(context.Context, *connect.Request[v1alpha1.CollectorDistributionReference]) (*connect.Response[v1alpha1.CollectorDistribution], error)
+	DeleteCollectorDistribution(context.Context, *connect.Request[v1alpha1.CollectorDistributionReference]) (*connect.Response[emptypb.Empty], error)
+	ListCollectorDistributions(context.Context, *connect.Request[v1alpha1.ListCollectorDistributionsRequest]) (*connect.Response[v1alpha1.ListCollectorDistributionsResponse], error)
+	// Checks that a distribution includes every component used by a config
+	CheckConfigCompatibility(context.Context, *connect.Request[v1alpha1.CheckConfigCompatibilityRequest]) (*connect.Response[v1alpha1.ConfigCompatibility], error)
+	// Fleet-wide analysis of effective configs
+	GetConfigCoverage(context.Context, *connect.Request[v1alpha1.GetConfigCoverageRequest]) (*connect.Response[v1alpha1.ConfigCoverageReport], error)
 }
 
 // NewConfigServiceClient constructs a client for the config.v1alpha1.ConfigService service. By
@@ -140,6 +278,12 @@ func NewConfigServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(configServiceMethods.ByName("ValidConfig")),
 			connect.WithClientOptions(opts...),
 		),
+		validateConfigDetailed: connect.NewClient[v1alpha1.ValidateConfigDetailedRequest, v1alpha1.ConfigValidationResult](
+			httpClient,
+			baseURL+ConfigServiceValidateConfigDetailedProcedure,
+			connect.WithSchema(configServiceMethods.ByName("ValidateConfigDetailed")),
+			connect.WithClientOptions(opts...),
+		),
 		putConfig: connect.NewClient[v1alpha1.PutConfigRequest, emptypb.Empty](
 			httpClient,
 			baseURL+ConfigServicePutConfigProcedure,
@@ -158,7 +302,7 @@ func NewConfigServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(configServiceMethods.ByName("DeleteConfig")),
 			connect.WithClientOptions(opts...),
 		),
-		listConfigs: connect.NewClient[emptypb.Empty, v1alpha1.ListConfigReponse](
+		listConfigs: connect.NewClient[v1alpha1.ListConfigsRequest, v1alpha1.ListConfigReponse](
 			httpClient,
 			baseURL+ConfigServiceListConfigsProcedure,
 			connect.WithSchema(configServiceMethods.ByName("ListConfigs")),
@@ -170,6 +314,18 @@ func NewConfigServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(configServiceMethods.ByName("GetDefaultConfig")),
 			connect.WithClientOptions(opts...),
 		),
+		beginConfigEdit: connect.NewClient[v1alpha1.ConfigReference, v1alpha1.ConfigEditSession](
+			httpClient,
+			baseURL+ConfigServiceBeginConfigEditProcedure,
+			connect.WithSchema(configServiceMethods.ByName("BeginConfigEdit")),
+			connect.WithClientOptions(opts...),
+		),
+		saveConfigEdit: connect.NewClient[v1alpha1.SaveConfigEditRequest, v1alpha1.SaveConfigEditResult](
+			httpClient,
+			baseURL+ConfigServiceSaveConfigEditProcedure,
+			connect.WithSchema(configServiceMethods.ByName("SaveConfigEdit")),
+			connect.WithClientOptions(opts...),
+		),
 		setDefaultConfig: connect.NewClient[v1alpha1.PutConfigRequest, emptypb.Empty](
 			httpClient,
 			baseURL+ConfigServiceSetDefaultConfigProcedure,
@@ -206,6 +362,12 @@ func NewConfigServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(configServiceMethods.ByName("GetConfigStatus")),
 			connect.WithClientOptions(opts...),
 		),
+		getAgentConfigAtTime: connect.NewClient[v1alpha1.GetAgentConfigAtTimeRequest, v1alpha1.AgentConfigAtTime](
+			httpClient,
+			baseURL+ConfigServiceGetAgentConfigAtTimeProcedure,
+			connect.WithSchema(configServiceMethods.ByName("GetAgentConfigAtTime")),
+			connect.WithClientOptions(opts...),
+		),
 		batchAssignConfig: connect.NewClient[v1alpha1.BatchAssignConfigRequest, v1alpha1.BatchAssignConfigResponse](
 			httpClient,
 			baseURL+ConfigServiceBatchAssignConfigProcedure,
@@ -254,31 +416,196 @@ func NewConfigServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(configServiceMethods.ByName("ListDeployments")),
 			connect.WithClientOptions(opts...),
 		),
+		purgeDeployment: connect.NewClient[v1alpha1.PurgeDeploymentRequest, v1alpha1.DeploymentActionResponse](
+			httpClient,
+			baseURL+ConfigServicePurgeDeploymentProcedure,
+			connect.WithSchema(configServiceMethods.ByName("PurgeDeployment")),
+			connect.WithClientOptions(opts...),
+		),
+		rollbackDeployment: connect.NewClient[v1alpha1.RollbackDeploymentRequest, v1alpha1.RollingDeploymentResponse](
+			httpClient,
+			baseURL+ConfigServiceRollbackDeploymentProcedure,
+			connect.WithSchema(configServiceMethods.ByName("RollbackDeployment")),
+			connect.WithClientOptions(opts...),
+		),
+		simulateDeployment: connect.NewClient[v1alpha1.RollingDeploymentRequest, v1alpha1.DeploymentPlan](
+			httpClient,
+			baseURL+ConfigServiceSimulateDeploymentProcedure,
+			connect.WithSchema(configServiceMethods.ByName("SimulateDeployment")),
+			connect.WithClientOptions(opts...),
+		),
+		putAssignmentPolicy: connect.NewClient[v1alpha1.PutAssignmentPolicyRequest, v1alpha1.AssignmentPolicy](
+			httpClient,
+			baseURL+ConfigServicePutAssignmentPolicyProcedure,
+			connect.WithSchema(configServiceMethods.ByName("PutAssignmentPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		getAssignmentPolicy: connect.NewClient[v1alpha1.AssignmentPolicyReference, v1alpha1.AssignmentPolicy](
+			httpClient,
+			baseURL+ConfigServiceGetAssignmentPolicyProcedure,
+			connect.WithSchema(configServiceMethods.ByName("GetAssignmentPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteAssignmentPolicy: connect.NewClient[v1alpha1.AssignmentPolicyReference, emptypb.Empty](
+			httpClient,
+			baseURL+ConfigServiceDeleteAssignmentPolicyProcedure,
+			connect.WithSchema(configServiceMethods.ByName("DeleteAssignmentPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		listAssignmentPolicies: connect.NewClient[v1alpha1.ListAssignmentPoliciesRequest, v1alpha1.ListAssignmentPoliciesResponse](
+			httpClient,
+			baseURL+ConfigServiceListAssignmentPoliciesProcedure,
+			connect.WithSchema(configServiceMethods.ByName("ListAssignmentPolicies")),
+			connect.WithClientOptions(opts...),
+		),
+		getAssignmentExplanation: connect.NewClient[v1alpha1.GetAssignmentExplanationRequest, v1alpha1.AssignmentExplanation](
+			httpClient,
+			baseURL+ConfigServiceGetAssignmentExplanationProcedure,
+			connect.WithSchema(configServiceMethods.ByName("GetAssignmentExplanation")),
+			connect.WithClientOptions(opts...),
+		),
+		createGroup: connect.NewClient[v1alpha1.CreateGroupRequest, v1alpha1.AgentGroup](
+			httpClient,
+			baseURL+ConfigServiceCreateGroupProcedure,
+			connect.WithSchema(configServiceMethods.ByName("CreateGroup")),
+			connect.WithClientOptions(opts...),
+		),
+		updateGroup: connect.NewClient[v1alpha1.UpdateGroupRequest, v1alpha1.AgentGroup](
+			httpClient,
+			baseURL+ConfigServiceUpdateGroupProcedure,
+			connect.WithSchema(configServiceMethods.ByName("UpdateGroup")),
+			connect.WithClientOptions(opts...),
+		),
+		getGroup: connect.NewClient[v1alpha1.AgentGroupReference, v1alpha1.AgentGroup](
+			httpClient,
+			baseURL+ConfigServiceGetGroupProcedure,
+			connect.WithSchema(configServiceMethods.ByName("GetGroup")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteGroup: connect.NewClient[v1alpha1.AgentGroupReference, emptypb.Empty](
+			httpClient,
+			baseURL+ConfigServiceDeleteGroupProcedure,
+			connect.WithSchema(configServiceMethods.ByName("DeleteGroup")),
+			connect.WithClientOptions(opts...),
+		),
+		listGroups: connect.NewClient[v1alpha1.ListGroupsRequest, v1alpha1.ListGroupsResponse](
+			httpClient,
+			baseURL+ConfigServiceListGroupsProcedure,
+			connect.WithSchema(configServiceMethods.ByName("ListGroups")),
+			connect.WithClientOptions(opts...),
+		),
+		putComponentPolicy: connect.NewClient[v1alpha1.PutComponentPolicyRequest, v1alpha1.ComponentPolicy](
+			httpClient,
+			baseURL+ConfigServicePutComponentPolicyProcedure,
+			connect.WithSchema(configServiceMethods.ByName("PutComponentPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		getComponentPolicy: connect.NewClient[v1alpha1.ComponentPolicyReference, v1alpha1.ComponentPolicy](
+			httpClient,
+			baseURL+ConfigServiceGetComponentPolicyProcedure,
+			connect.WithSchema(configServiceMethods.ByName("GetComponentPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteComponentPolicy: connect.NewClient[v1alpha1.ComponentPolicyReference, emptypb.Empty](
+			httpClient,
+			baseURL+ConfigServiceDeleteComponentPolicyProcedure,
+			connect.WithSchema(configServiceMethods.ByName("DeleteComponentPolicy")),
+			connect.WithClientOptions(opts...),
+		),
+		listComponentPolicies: connect.NewClient[v1alpha1.ListComponentPoliciesRequest, v1alpha1.ListComponentPoliciesResponse](
+			httpClient,
+			baseURL+ConfigServiceListComponentPoliciesProcedure,
+			connect.WithSchema(configServiceMethods.ByName("ListComponentPolicies")),
+			connect.WithClientOptions(opts...),
+		),
+		putCollectorDistribution: connect.NewClient[v1alpha1.PutCollectorDistributionRequest, v1alpha1.CollectorDistribution](
+			httpClient,
+			baseURL+ConfigServicePutCollectorDistributionProcedure,
+			connect.WithSchema(configServiceMethods.ByName("PutCollectorDistribution")),
+			connect.WithClientOptions(opts...),
+		),
+		getCollectorDistribution: connect.NewClient[v1alpha1.CollectorDistributionReference, v1alpha1.CollectorDistribution](
+			httpClient,
+			baseURL+ConfigServiceGetCollectorDistributionProcedure,
+			connect.WithSchema(configServiceMethods.ByName("GetCollectorDistribution")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteCollectorDistribution: connect.NewClient[v1alpha1.CollectorDistributionReference, emptypb.Empty](
+			httpClient,
+			baseURL+ConfigServiceDeleteCollectorDistributionProcedure,
+			connect.WithSchema(configServiceMethods.ByName("DeleteCollectorDistribution")),
+			connect.WithClientOptions(opts...),
+		),
+		listCollectorDistributions: connect.NewClient[v1alpha1.ListCollectorDistributionsRequest, v1alpha1.ListCollectorDistributionsResponse](
+			httpClient,
+			baseURL+ConfigServiceListCollectorDistributionsProcedure,
+			connect.WithSchema(configServiceMethods.ByName("ListCollectorDistributions")),
+			connect.WithClientOptions(opts...),
+		),
+		checkConfigCompatibility: connect.NewClient[v1alpha1.CheckConfigCompatibilityRequest, v1alpha1.ConfigCompatibility](
+			httpClient,
+			baseURL+ConfigServiceCheckConfigCompatibilityProcedure,
+			connect.WithSchema(configServiceMethods.ByName("CheckConfigCompatibility")),
+			connect.WithClientOptions(opts...),
+		),
+		getConfigCoverage: connect.NewClient[v1alpha1.GetConfigCoverageRequest, v1alpha1.ConfigCoverageReport](
+			httpClient,
+			baseURL+ConfigServiceGetConfigCoverageProcedure,
+			connect.WithSchema(configServiceMethods.ByName("GetConfigCoverage")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // configServiceClient implements ConfigServiceClient.
 type configServiceClient struct {
-	validConfig            *connect.Client[v1alpha1.ValidateConfigRequest, emptypb.Empty]
-	putConfig              *connect.Client[v1alpha1.PutConfigRequest, emptypb.Empty]
-	getConfig              *connect.Client[v1alpha1.ConfigReference, v1alpha1.Config]
-	deleteConfig           *connect.Client[v1alpha1.ConfigReference, emptypb.Empty]
-	listConfigs            *connect.Client[emptypb.Empty, v1alpha1.ListConfigReponse]
-	getDefaultConfig       *connect.Client[emptypb.Empty, v1alpha1.Config]
-	setDefaultConfig       *connect.Client[v1alpha1.PutConfigRequest, emptypb.Empty]
-	assignConfig           *connect.Client[v1alpha1.AssignConfigRequest, v1alpha1.AssignConfigResponse]
-	getAgentConfig         *connect.Client[v1alpha1.GetAgentConfigRequest, v1alpha1.GetAgentConfigResponse]
-	unassignConfig         *connect.Client[v1alpha1.UnassignConfigRequest, v1alpha1.UnassignConfigResponse]
-	listConfigAssignments  *connect.Client[v1alpha1.ListConfigAssignmentsRequest, v1alpha1.ListConfigAssignmentsResponse]
-	getConfigStatus        *connect.Client[v1alpha1.GetConfigStatusRequest, v1alpha1.GetConfigStatusResponse]
-	batchAssignConfig      *connect.Client[v1alpha1.BatchAssignConfigRequest, v1alpha1.BatchAssignConfigResponse]
-	assignConfigByLabels   *connect.Client[v1alpha1.AssignConfigByLabelsRequest, v1alpha1.AssignConfigByLabelsResponse]
-	startRollingDeployment *connect.Client[v1alpha1.RollingDeploymentRequest, v1alpha1.RollingDeploymentResponse]
-	getDeploymentStatus    *connect.Client[v1alpha1.GetDeploymentStatusRequest, v1alpha1.GetDeploymentStatusResponse]
-	pauseDeployment        *connect.Client[v1alpha1.PauseDeploymentRequest, v1alpha1.DeploymentActionResponse]
-	resumeDeployment       *connect.Client[v1alpha1.ResumeDeploymentRequest, v1alpha1.DeploymentActionResponse]
-	cancelDeployment       *connect.Client[v1alpha1.CancelDeploymentRequest, v1alpha1.DeploymentActionResponse]
-	listDeployments        *connect.Client[v1alpha1.ListDeploymentsRequest, v1alpha1.ListDeploymentsResponse]
+	validConfig                 *connect.Client[v1alpha1.ValidateConfigRequest, emptypb.Empty]
+	validateConfigDetailed      *connect.Client[v1alpha1.ValidateConfigDetailedRequest, v1alpha1.ConfigValidationResult]
+	putConfig                   *connect.Client[v1alpha1.PutConfigRequest, emptypb.Empty]
+	getConfig                   *connect.Client[v1alpha1.ConfigReference, v1alpha1.Config]
+	deleteConfig                *connect.Client[v1alpha1.ConfigReference, emptypb.Empty]
+	listConfigs                 *connect.Client[v1alpha1.ListConfigsRequest, v1alpha1.ListConfigReponse]
+	getDefaultConfig            *connect.Client[emptypb.Empty, v1alpha1.Config]
+	beginConfigEdit             *connect.Client[v1alpha1.ConfigReference, v1alpha1.ConfigEditSession]
+	saveConfigEdit              *connect.Client[v1alpha1.SaveConfigEditRequest, v1alpha1.SaveConfigEditResult]
+	setDefaultConfig            *connect.Client[v1alpha1.PutConfigRequest, emptypb.Empty]
+	assignConfig                *connect.Client[v1alpha1.AssignConfigRequest, v1alpha1.AssignConfigResponse]
+	getAgentConfig              *connect.Client[v1alpha1.GetAgentConfigRequest, v1alpha1.GetAgentConfigResponse]
+	unassignConfig              *connect.Client[v1alpha1.UnassignConfigRequest, v1alpha1.UnassignConfigResponse]
+	listConfigAssignments       *connect.Client[v1alpha1.ListConfigAssignmentsRequest, v1alpha1.ListConfigAssignmentsResponse]
+	getConfigStatus             *connect.Client[v1alpha1.GetConfigStatusRequest, v1alpha1.GetConfigStatusResponse]
+	getAgentConfigAtTime        *connect.Client[v1alpha1.GetAgentConfigAtTimeRequest, v1alpha1.AgentConfigAtTime]
+	batchAssignConfig           *connect.Client[v1alpha1.BatchAssignConfigRequest, v1alpha1.BatchAssignConfigResponse]
+	assignConfigByLabels        *connect.Client[v1alpha1.AssignConfigByLabelsRequest, v1alpha1.AssignConfigByLabelsResponse]
+	startRollingDeployment      *connect.Client[v1alpha1.RollingDeploymentRequest, v1alpha1.RollingDeploymentResponse]
+	getDeploymentStatus         *connect.Client[v1alpha1.GetDeploymentStatusRequest, v1alpha1.GetDeploymentStatusResponse]
+	pauseDeployment             *connect.Client[v1alpha1.PauseDeploymentRequest, v1alpha1.DeploymentActionResponse]
+	resumeDeployment            *connect.Client[v1alpha1.ResumeDeploymentRequest, v1alpha1.DeploymentActionResponse]
+	cancelDeployment            *connect.Client[v1alpha1.CancelDeploymentRequest, v1alpha1.DeploymentActionResponse]
+	listDeployments             *connect.Client[v1alpha1.ListDeploymentsRequest, v1alpha1.ListDeploymentsResponse]
+	purgeDeployment             *connect.Client[v1alpha1.PurgeDeploymentRequest, v1alpha1.DeploymentActionResponse]
+	rollbackDeployment          *connect.Client[v1alpha1.RollbackDeploymentRequest, v1alpha1.RollingDeploymentResponse]
+	simulateDeployment          *connect.Client[v1alpha1.RollingDeploymentRequest, v1alpha1.DeploymentPlan]
+	putAssignmentPolicy         *connect.Client[v1alpha1.PutAssignmentPolicyRequest, v1alpha1.AssignmentPolicy]
+	getAssignmentPolicy         *connect.Client[v1alpha1.AssignmentPolicyReference, v1alpha1.AssignmentPolicy]
+	deleteAssignmentPolicy      *connect.Client[v1alpha1.AssignmentPolicyReference, emptypb.Empty]
+	listAssignmentPolicies      *connect.Client[v1alpha1.ListAssignmentPoliciesRequest, v1alpha1.ListAssignmentPoliciesResponse]
+	getAssignmentExplanation    *connect.Client[v1alpha1.GetAssignmentExplanationRequest, v1alpha1.AssignmentExplanation]
+	createGroup                 *connect.Client[v1alpha1.CreateGroupRequest, v1alpha1.AgentGroup]
+	updateGroup                 *connect.Client[v1alpha1.UpdateGroupRequest, v1alpha1.AgentGroup]
+	getGroup                    *connect.Client[v1alpha1.AgentGroupReference, v1alpha1.AgentGroup]
+	deleteGroup                 *connect.Client[v1alpha1.AgentGroupReference, emptypb.Empty]
+	listGroups                  *connect.Client[v1alpha1.ListGroupsRequest, v1alpha1.ListGroupsResponse]
+	putComponentPolicy          *connect.Client[v1alpha1.PutComponentPolicyRequest, v1alpha1.ComponentPolicy]
+	getComponentPolicy          *connect.Client[v1alpha1.ComponentPolicyReference, v1alpha1.ComponentPolicy]
+	deleteComponentPolicy       *connect.Client[v1alpha1.ComponentPolicyReference, emptypb.Empty]
+	listComponentPolicies       *connect.Client[v1alpha1.ListComponentPoliciesRequest, v1alpha1.ListComponentPoliciesResponse]
+	putCollectorDistribution    *connect.Client[v1alpha1.PutCollectorDistributionRequest, v1alpha1.CollectorDistribution]
+	getCollectorDistribution    *connect.Client[v1alpha1.CollectorDistributionReference, v1alpha1.CollectorDistribution]
+	deleteCollectorDistribution *connect.Client[v1alpha1.CollectorDistributionReference, emptypb.Empty]
+	listCollectorDistributions  *connect.Client[v1alpha1.ListCollectorDistributionsRequest, v1alpha1.ListCollectorDistributionsResponse]
+	checkConfigCompatibility    *connect.Client[v1alpha1.CheckConfigCompatibilityRequest, v1alpha1.ConfigCompatibility]
+	getConfigCoverage           *connect.Client[v1alpha1.GetConfigCoverageRequest, v1alpha1.ConfigCoverageReport]
 }
 
 // ValidConfig calls config.v1alpha1.ConfigService.ValidConfig.
@@ -286,6 +613,11 @@ func (c *configServiceClient) ValidConfig(ctx context.Context, req *connect.Requ
 	return c.validConfig.CallUnary(ctx, req)
 }
 
+// ValidateConfigDetailed calls config.v1alpha1.ConfigService.ValidateConfigDetailed.
+func (c *configServiceClient) ValidateConfigDetailed(ctx context.Context, req *connect.Request[v1alpha1.ValidateConfigDetailedRequest]) (*connect.Response[v1alpha1.ConfigValidationResult], error) {
+	return c.validateConfigDetailed.CallUnary(ctx, req)
+}
+
 // PutConfig calls config.v1alpha1.ConfigService.PutConfig.
 func (c *configServiceClient) PutConfig(ctx context.Context, req *connect.Request[v1alpha1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error) {
 	return c.putConfig.CallUnary(ctx, req)
@@ -302,7 +634,7 @@ func (c *configServiceClient) DeleteConfig(ctx context.Context, req *connect.Req
 }
 
 // ListConfigs calls config.v1alpha1.ConfigService.ListConfigs.
-func (c *configServiceClient) ListConfigs(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListConfigReponse], error) {
+func (c *configServiceClient) ListConfigs(ctx context.Context, req *connect.Request[v1alpha1.ListConfigsRequest]) (*connect.Response[v1alpha1.ListConfigReponse], error) {
 	return c.listConfigs.CallUnary(ctx, req)
 }
 
@@ -311,6 +643,16 @@ func (c *configServiceClient) GetDefaultConfig(ctx context.Context, req *connect
 	return c.getDefaultConfig.CallUnary(ctx, req)
 }
 
+// BeginConfigEdit calls config.v1alpha1.ConfigService.BeginConfigEdit.
+func (c *configServiceClient) BeginConfigEdit(ctx context.Context, req *connect.Request[v1alpha1.ConfigReference]) (*connect.Response[v1alpha1.ConfigEditSession], error) {
+	return c.beginConfigEdit.CallUnary(ctx, req)
+}
+
+// SaveConfigEdit calls config.v1alpha1.ConfigService.SaveConfigEdit.
+func (c *configServiceClient) SaveConfigEdit(ctx context.Context, req *connect.Request[v1alpha1.SaveConfigEditRequest]) (*connect.Response[v1alpha1.SaveConfigEditResult], error) {
+	return c.saveConfigEdit.CallUnary(ctx, req)
+}
+
 // SetDefaultConfig calls config.v1alpha1.ConfigService.SetDefaultConfig.
 func (c *configServiceClient) SetDefaultConfig(ctx context.Context, req *connect.Request[v1alpha1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error) {
 	return c.setDefaultConfig.CallUnary(ctx, req)
@@ -341,6 +683,11 @@ func (c *configServiceClient) GetConfigStatus(ctx context.Context, req *connect.
 	return c.getConfigStatus.CallUnary(ctx, req)
 }
 
+// GetAgentConfigAtTime calls config.v1alpha1.ConfigService.GetAgentConfigAtTime.
+func (c *configServiceClient) GetAgentConfigAtTime(ctx context.Context, req *connect.Request[v1alpha1.GetAgentConfigAtTimeRequest]) (*connect.Response[v1alpha1.AgentConfigAtTime], error) {
+	return c.getAgentConfigAtTime.CallUnary(ctx, req)
+}
+
 // BatchAssignConfig calls config.v1alpha1.ConfigService.BatchAssignConfig.
 func (c *configServiceClient) BatchAssignConfig(ctx context.Context, req *connect.Request[v1alpha1.BatchAssignConfigRequest]) (*connect.Response[v1alpha1.BatchAssignConfigResponse], error) {
 	return c.batchAssignConfig.CallUnary(ctx, req)
@@ -381,15 +728,136 @@ func (c *configServiceClient) ListDeployments(ctx context.Context, req *connect.
 	return c.listDeployments.CallUnary(ctx, req)
 }
 
+// PurgeDeployment calls config.v1alpha1.ConfigService.PurgeDeployment.
+func (c *configServiceClient) PurgeDeployment(ctx context.Context, req *connect.Request[v1alpha1.PurgeDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentActionResponse], error) {
+	return c.purgeDeployment.CallUnary(ctx, req)
+}
+
+// RollbackDeployment calls config.v1alpha1.ConfigService.RollbackDeployment.
+func (c *configServiceClient) RollbackDeployment(ctx context.Context, req *connect.Request[v1alpha1.RollbackDeploymentRequest]) (*connect.Response[v1alpha1.RollingDeploymentResponse], error) {
+	return c.rollbackDeployment.CallUnary(ctx, req)
+}
+
+// SimulateDeployment calls config.v1alpha1.ConfigService.SimulateDeployment.
+func (c *configServiceClient) SimulateDeployment(ctx context.Context, req *connect.Request[v1alpha1.RollingDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentPlan], error) {
+	return c.simulateDeployment.CallUnary(ctx, req)
+}
+
+// PutAssignmentPolicy calls config.v1alpha1.ConfigService.PutAssignmentPolicy.
+func (c *configServiceClient) PutAssignmentPolicy(ctx context.Context, req *connect.Request[v1alpha1.PutAssignmentPolicyRequest]) (*connect.Response[v1alpha1.AssignmentPolicy], error) {
+	return c.putAssignmentPolicy.CallUnary(ctx, req)
+}
+
+// GetAssignmentPolicy calls config.v1alpha1.ConfigService.GetAssignmentPolicy.
+func (c *configServiceClient) GetAssignmentPolicy(ctx context.Context, req *connect.Request[v1alpha1.AssignmentPolicyReference]) (*connect.Response[v1alpha1.AssignmentPolicy], error) {
+	return c.getAssignmentPolicy.CallUnary(ctx, req)
+}
+
+// DeleteAssignmentPolicy calls config.v1alpha1.ConfigService.DeleteAssignmentPolicy.
+func (c *configServiceClient) DeleteAssignmentPolicy(ctx context.Context, req *connect.Request[v1alpha1.AssignmentPolicyReference]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteAssignmentPolicy.CallUnary(ctx, req)
+}
+
+// ListAssignmentPolicies calls config.v1alpha1.ConfigService.ListAssignmentPolicies.
+func (c *configServiceClient) ListAssignmentPolicies(ctx context.Context, req *connect.Request[v1alpha1.ListAssignmentPoliciesRequest]) (*connect.Response[v1alpha1.ListAssignmentPoliciesResponse], error) {
+	return c.listAssignmentPolicies.CallUnary(ctx, req)
+}
+
+// GetAssignmentExplanation calls config.v1alpha1.ConfigService.GetAssignmentExplanation.
+func (c *configServiceClient) GetAssignmentExplanation(ctx context.Context, req *connect.Request[v1alpha1.GetAssignmentExplanationRequest]) (*connect.Response[v1alpha1.AssignmentExplanation], error) {
+	return c.getAssignmentExplanation.CallUnary(ctx, req)
+}
+
+// CreateGroup calls config.v1alpha1.ConfigService.CreateGroup.
+func (c *configServiceClient) CreateGroup(ctx context.Context, req *connect.Request[v1alpha1.CreateGroupRequest]) (*connect.Response[v1alpha1.AgentGroup], error) {
+	return c.createGroup.CallUnary(ctx, req)
+}
+
+// UpdateGroup calls config.v1alpha1.ConfigService.UpdateGroup.
+func (c *configServiceClient) UpdateGroup(ctx context.Context, req *connect.Request[v1alpha1.UpdateGroupRequest]) (*connect.Response[v1alpha1.AgentGroup], error) {
+	return c.updateGroup.CallUnary(ctx, req)
+}
+
+// GetGroup calls config.v1alpha1.ConfigService.GetGroup.
+func (c *configServiceClient) GetGroup(ctx context.Context, req *connect.Request[v1alpha1.AgentGroupReference]) (*connect.Response[v1alpha1.AgentGroup], error) {
+	return c.getGroup.CallUnary(ctx, req)
+}
+
+// DeleteGroup calls config.v1alpha1.ConfigService.DeleteGroup.
+func (c *configServiceClient) DeleteGroup(ctx context.Context, req *connect.Request[v1alpha1.AgentGroupReference]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteGroup.CallUnary(ctx, req)
+}
+
+// ListGroups calls config.v1alpha1.ConfigService.ListGroups.
+func (c *configServiceClient) ListGroups(ctx context.Context, req *connect.Request[v1alpha1.ListGroupsRequest]) (*connect.Response[v1alpha1.ListGroupsResponse], error) {
+	return c.listGroups.CallUnary(ctx, req)
+}
+
+// PutComponentPolicy calls config.v1alpha1.ConfigService.PutComponentPolicy.
+func (c *configServiceClient) PutComponentPolicy(ctx context.Context, req *connect.Request[v1alpha1.PutComponentPolicyRequest]) (*connect.Response[v1alpha1.ComponentPolicy], error) {
+	return c.putComponentPolicy.CallUnary(ctx, req)
+}
+
+// GetComponentPolicy calls config.v1alpha1.ConfigService.GetComponentPolicy.
+func (c *configServiceClient) GetComponentPolicy(ctx context.Context, req *connect.Request[v1alpha1.ComponentPolicyReference]) (*connect.Response[v1alpha1.ComponentPolicy], error) {
+	return c.getComponentPolicy.CallUnary(ctx, req)
+}
+
+// DeleteComponentPolicy calls config.v1alpha1.ConfigService.DeleteComponentPolicy.
+func (c *configServiceClient) DeleteComponentPolicy(ctx context.Context, req *connect.Request[v1alpha1.ComponentPolicyReference]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteComponentPolicy.CallUnary(ctx, req)
+}
+
+// ListComponentPolicies calls config.v1alpha1.ConfigService.ListComponentPolicies.
+func (c *configServiceClient) ListComponentPolicies(ctx context.Context, req *connect.Request[v1alpha1.ListComponentPoliciesRequest]) (*connect.Response[v1alpha1.ListComponentPoliciesResponse], error) {
+	return c.listComponentPolicies.CallUnary(ctx, req)
+}
+
+// PutCollectorDistribution calls config.v1alpha1.ConfigService.PutCollectorDistribution.
+func (c *configServiceClient) PutCollectorDistribution(ctx context.Context, req *connect.Request[v1alpha1.PutCollectorDistributionRequest]) (*connect.Response[v1alpha1.CollectorDistribution], error) {
+	return c.putCollectorDistribution.CallUnary(ctx, req)
+}
+
+// GetCollectorDistribution calls config.v1alpha1.ConfigService.GetCollectorDistribution.
+func (c *configServiceClient) GetCollectorDistribution(ctx context.Context, req *connect.Request[v1alpha1.CollectorDistributionReference]) (*connect.Response[v1alpha1.CollectorDistribution], error) {
+	return c.getCollectorDistribution.CallUnary(ctx, req)
+}
+
+// DeleteCollectorDistribution calls config.v1alpha1.ConfigService.DeleteCollectorDistribution.
+func (c *configServiceClient) DeleteCollectorDistribution(ctx context.Context, req *connect.Request[v1alpha1.CollectorDistributionReference]) (*connect.Response[emptypb.Empty], error) {
+	return c.deleteCollectorDistribution.CallUnary(ctx, req)
+}
+
+// ListCollectorDistributions calls config.v1alpha1.ConfigService.ListCollectorDistributions.
+func (c *configServiceClient) ListCollectorDistributions(ctx context.Context, req *connect.Request[v1alpha1.ListCollectorDistributionsRequest]) (*connect.Response[v1alpha1.ListCollectorDistributionsResponse], error) {
+	return c.listCollectorDistributions.CallUnary(ctx, req)
+}
+
+// CheckConfigCompatibility calls config.v1alpha1.ConfigService.CheckConfigCompatibility.
+func (c *configServiceClient) CheckConfigCompatibility(ctx context.Context, req *connect.Request[v1alpha1.CheckConfigCompatibilityRequest]) (*connect.Response[v1alpha1.ConfigCompatibility], error) {
+	return c.checkConfigCompatibility.CallUnary(ctx, req)
+}
+
+// GetConfigCoverage calls config.v1alpha1.ConfigService.GetConfigCoverage.
+func (c *configServiceClient) GetConfigCoverage(ctx context.Context, req *connect.Request[v1alpha1.GetConfigCoverageRequest]) (*connect.Response[v1alpha1.ConfigCoverageReport], error) {
+	return c.getConfigCoverage.CallUnary(ctx, req)
+}
+
 // ConfigServiceHandler is an implementation of the config.v1alpha1.ConfigService service.
 type ConfigServiceHandler interface {
 	// Config CRUD
+	// Fails with INVALID_ARGUMENT if the config is invalid, with a ConfigValidationResult detail
 	ValidConfig(context.Context, *connect.Request[v1alpha1.ValidateConfigRequest]) (*connect.Response[emptypb.Empty], error)
+	// Returns every finding about a config with its position, for editors
+	ValidateConfigDetailed(context.Context, *connect.Request[v1alpha1.ValidateConfigDetailedRequest]) (*connect.Response[v1alpha1.ConfigValidationResult], error)
 	PutConfig(context.Context, *connect.Request[v1alpha1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error)
 	GetConfig(context.Context, *connect.Request[v1alpha1.ConfigReference]) (*connect.Response[v1alpha1.Config], error)
 	DeleteConfig(context.Context, *connect.Request[v1alpha1.ConfigReference]) (*connect.Response[emptypb.Empty], error)
-	ListConfigs(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListConfigReponse], error)
+	ListConfigs(context.Context, *connect.Request[v1alpha1.ListConfigsRequest]) (*connect.Response[v1alpha1.ListConfigReponse], error)
 	GetDefaultConfig(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.Config], error)
+	// Concurrent edits: saves merge the changes made to the config since the session began
+	BeginConfigEdit(context.Context, *connect.Request[v1alpha1.ConfigReference]) (*connect.Response[v1alpha1.ConfigEditSession], error)
+	SaveConfigEdit(context.Context, *connect.Request[v1alpha1.SaveConfigEditRequest]) (*connect.Response[v1alpha1.SaveConfigEditResult], error)
 	SetDefaultConfig(context.Context, *connect.Request[v1alpha1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error)
 	// Phase 1: Manual Config Assignment
 	AssignConfig(context.Context, *connect.Request[v1alpha1.AssignConfigRequest]) (*connect.Response[v1alpha1.AssignConfigResponse], error)
@@ -398,6 +866,8 @@ type ConfigServiceHandler interface {
 	// Phase 2: Config Assignment Queries and Status
 	ListConfigAssignments(context.Context, *connect.Request[v1alpha1.ListConfigAssignmentsRequest]) (*connect.Response[v1alpha1.ListConfigAssignmentsResponse], error)
 	GetConfigStatus(context.Context, *connect.Request[v1alpha1.GetConfigStatusRequest]) (*connect.Response[v1alpha1.GetConfigStatusResponse], error)
+	// Reconstructs the config assigned to an agent, and the one it applied, at a past time
+	GetAgentConfigAtTime(context.Context, *connect.Request[v1alpha1.GetAgentConfigAtTimeRequest]) (*connect.Response[v1alpha1.AgentConfigAtTime], error)
 	// Phase 3: Batch Assignment
 	BatchAssignConfig(context.Context, *connect.Request[v1alpha1.BatchAssignConfigRequest]) (*connect.Response[v1alpha1.BatchAssignConfigResponse], error)
 	AssignConfigByLabels(context.Context, *connect.Request[v1alpha1.AssignConfigByLabelsRequest]) (*connect.Response[v1alpha1.AssignConfigByLabelsResponse], error)
@@ -408,6 +878,42 @@ type ConfigServiceHandler interface {
 	ResumeDeployment(context.Context, *connect.Request[v1alpha1.ResumeDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentActionResponse], error)
 	CancelDeployment(context.Context, *connect.Request[v1alpha1.CancelDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentActionResponse], error)
 	ListDeployments(context.Context, *connect.Request[v1alpha1.ListDeploymentsRequest]) (*connect.Response[v1alpha1.ListDeploymentsResponse], error)
+	// Deletes a finished deployment and its per-agent statuses
+	PurgeDeployment(context.Context, *connect.Request[v1alpha1.PurgeDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentActionResponse], error)
+	// Restores the configs agents had before a finished deployment, in reverse batch order,
+	// as a new deployment
+	RollbackDeployment(context.Context, *connect.Request[v1alpha1.RollbackDeploymentRequest]) (*connect.Response[v1alpha1.RollingDeploymentResponse], error)
+	// Plans a rolling deployment without executing it
+	SimulateDeployment(context.Context, *connect.Request[v1alpha1.RollingDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentPlan], error)
+	// Assignment policies: steady-state config assignment by label selector
+	PutAssignmentPolicy(context.Context, *connect.Request[v1alpha1.PutAssignmentPolicyRequest]) (*connect.Response[v1alpha1.AssignmentPolicy], error)
+	GetAssignmentPolicy(context.Context, *connect.Request[v1alpha1.AssignmentPolicyReference]) (*connect.Response[v1alpha1.AssignmentPolicy], error)
+	DeleteAssignmentPolicy(context.Context, *connect.Request[v1alpha1.AssignmentPolicyReference]) (*connect.Response[emptypb.Empty], error)
+	ListAssignmentPolicies(context.Context, *connect.Request[v1alpha1.ListAssignmentPoliciesRequest]) (*connect.Response[v1alpha1.ListAssignmentPoliciesResponse], error)
+	// Lists every source that could assign a config to an agent, and which one applies
+	GetAssignmentExplanation(context.Context, *connect.Request[v1alpha1.GetAssignmentExplanationRequest]) (*connect.Response[v1alpha1.AssignmentExplanation], error)
+	// Agent groups: named sets of agents, selected by labels or listed explicitly, whose config
+	// is assigned to every agent in the group
+	CreateGroup(context.Context, *connect.Request[v1alpha1.CreateGroupRequest]) (*connect.Response[v1alpha1.AgentGroup], error)
+	UpdateGroup(context.Context, *connect.Request[v1alpha1.UpdateGroupRequest]) (*connect.Response[v1alpha1.AgentGroup], error)
+	GetGroup(context.Context, *connect.Request[v1alpha1.AgentGroupReference]) (*connect.Response[v1alpha1.AgentGroup], error)
+	DeleteGroup(context.Context, *connect.Request[v1alpha1.AgentGroupReference]) (*connect.Response[emptypb.Empty], error)
+	ListGroups(context.Context, *connect.Request[v1alpha1.ListGroupsRequest]) (*connect.Response[v1alpha1.ListGroupsResponse], error)
+	// Component policies forbid collector components in the configs of the agents they apply to
+	PutComponentPolicy(context.Context, *connect.Request[v1alpha1.PutComponentPolicyRequest]) (*connect.Response[v1alpha1.ComponentPolicy], error)
+	GetComponentPolicy(context.Context, *connect.Request[v1alpha1.ComponentPolicyReference]) (*connect.Response[v1alpha1.ComponentPolicy], error)
+	DeleteComponentPolicy(context.Context, *connect.Request[v1alpha1.ComponentPolicyReference]) (*connect.Response[emptypb.Empty], error)
+	// Lists the component policies along with the assigned configs violating them
+	ListComponentPolicies(context.Context, *connect.Request[v1alpha1.ListComponentPoliciesRequest]) (*connect.Response[v1alpha1.ListComponentPoliciesResponse], error)
+	// Collector distributions describe the collector builds agents run
+	PutCollectorDistribution(context.Context, *connect.Request[v1alpha1.PutCollectorDistributionRequest]) (*connect.Response[v1alpha1.CollectorDistribution], error)
+	GetCollectorDistribution(context.Context, *connect.Request[v1alpha1.CollectorDistributionReference]) (*connect.Response[v1alpha1.CollectorDistribution], error)
+	DeleteCollectorDistribution(context.Context, *connect.Request[v1alpha1.CollectorDistributionReference]) (*connect.Response[emptypb.Empty], error)
+	ListCollectorDistributions(context.Context, *connect.Request[v1alpha1.ListCollectorDistributionsRequest]) (*connect.Response[v1alpha1.ListCollectorDistributionsResponse], error)
+	// Checks that a distribution includes every component used by a config
+	CheckConfigCompatibility(context.Context, *connect.Request[v1alpha1.CheckConfigCompatibilityRequest]) (*connect.Response[v1alpha1.ConfigCompatibility], error)
+	// Fleet-wide analysis of effective configs
+	GetConfigCoverage(context.Context, *connect.Request[v1alpha1.GetConfigCoverageRequest]) (*connect.Response[v1alpha1.ConfigCoverageReport], error)
 }
 
 // NewConfigServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -423,6 +929,12 @@ func NewConfigServiceHandler(svc ConfigServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(configServiceMethods.ByName("ValidConfig")),
 		connect.WithHandlerOptions(opts...),
 	)
+	configServiceValidateConfigDetailedHandler := connect.NewUnaryHandler(
+		ConfigServiceValidateConfigDetailedProcedure,
+		svc.ValidateConfigDetailed,
+		connect.WithSchema(configServiceMethods.ByName("ValidateConfigDetailed")),
+		connect.WithHandlerOptions(opts...),
+	)
 	configServicePutConfigHandler := connect.NewUnaryHandler(
 		ConfigServicePutConfigProcedure,
 		svc.PutConfig,
@@ -453,6 +965,18 @@ func NewConfigServiceHandler(svc ConfigServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(configServiceMethods.ByName("GetDefaultConfig")),
 		connect.WithHandlerOptions(opts...),
 	)
+	configServiceBeginConfigEditHandler := connect.NewUnaryHandler(
+		ConfigServiceBeginConfigEditProcedure,
+		svc.BeginConfigEdit,
+		connect.WithSchema(configServiceMethods.ByName("BeginConfigEdit")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceSaveConfigEditHandler := connect.NewUnaryHandler(
+		ConfigServiceSaveConfigEditProcedure,
+		svc.SaveConfigEdit,
+		connect.WithSchema(configServiceMethods.ByName("SaveConfigEdit")),
+		connect.WithHandlerOptions(opts...),
+	)
 	configServiceSetDefaultConfigHandler := connect.NewUnaryHandler(
 		ConfigServiceSetDefaultConfigProcedure,
 		svc.SetDefaultConfig,
@@ -489,6 +1013,12 @@ func NewConfigServiceHandler(svc ConfigServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(configServiceMethods.ByName("GetConfigStatus")),
 		connect.WithHandlerOptions(opts...),
 	)
+	configServiceGetAgentConfigAtTimeHandler := connect.NewUnaryHandler(
+		ConfigServiceGetAgentConfigAtTimeProcedure,
+		svc.GetAgentConfigAtTime,
+		connect.WithSchema(configServiceMethods.ByName("GetAgentConfigAtTime")),
+		connect.WithHandlerOptions(opts...),
+	)
 	configServiceBatchAssignConfigHandler := connect.NewUnaryHandler(
 		ConfigServiceBatchAssignConfigProcedure,
 		svc.BatchAssignConfig,
@@ -537,10 +1067,150 @@ func NewConfigServiceHandler(svc ConfigServiceHandler, opts ...connect.HandlerOp
 		connect.WithSchema(configServiceMethods.ByName("ListDeployments")),
 		connect.WithHandlerOptions(opts...),
 	)
+	configServicePurgeDeploymentHandler := connect.NewUnaryHandler(
+		ConfigServicePurgeDeploymentProcedure,
+		svc.PurgeDeployment,
+		connect.WithSchema(configServiceMethods.ByName("PurgeDeployment")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceRollbackDeploymentHandler := connect.NewUnaryHandler(
+		ConfigServiceRollbackDeploymentProcedure,
+		svc.RollbackDeployment,
+		connect.WithSchema(configServiceMethods.ByName("RollbackDeployment")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceSimulateDeploymentHandler := connect.NewUnaryHandler(
+		ConfigServiceSimulateDeploymentProcedure,
+		svc.SimulateDeployment,
+		connect.WithSchema(configServiceMethods.ByName("SimulateDeployment")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServicePutAssignmentPolicyHandler := connect.NewUnaryHandler(
+		ConfigServicePutAssignmentPolicyProcedure,
+		svc.PutAssignmentPolicy,
+		connect.WithSchema(configServiceMethods.ByName("PutAssignmentPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceGetAssignmentPolicyHandler := connect.NewUnaryHandler(
+		ConfigServiceGetAssignmentPolicyProcedure,
+		svc.GetAssignmentPolicy,
+		connect.WithSchema(configServiceMethods.ByName("GetAssignmentPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceDeleteAssignmentPolicyHandler := connect.NewUnaryHandler(
+		ConfigServiceDeleteAssignmentPolicyProcedure,
+		svc.DeleteAssignmentPolicy,
+		connect.WithSchema(configServiceMethods.ByName("DeleteAssignmentPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceListAssignmentPoliciesHandler := connect.NewUnaryHandler(
+		ConfigServiceListAssignmentPoliciesProcedure,
+		svc.ListAssignmentPolicies,
+		connect.WithSchema(configServiceMethods.ByName("ListAssignmentPolicies")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceGetAssignmentExplanationHandler := connect.NewUnaryHandler(
+		ConfigServiceGetAssignmentExplanationProcedure,
+		svc.GetAssignmentExplanation,
+		connect.WithSchema(configServiceMethods.ByName("GetAssignmentExplanation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceCreateGroupHandler := connect.NewUnaryHandler(
+		ConfigServiceCreateGroupProcedure,
+		svc.CreateGroup,
+		connect.WithSchema(configServiceMethods.ByName("CreateGroup")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceUpdateGroupHandler := connect.NewUnaryHandler(
+		ConfigServiceUpdateGroupProcedure,
+		svc.UpdateGroup,
+		connect.WithSchema(configServiceMethods.ByName("UpdateGroup")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceGetGroupHandler := connect.NewUnaryHandler(
+		ConfigServiceGetGroupProcedure,
+		svc.GetGroup,
+		connect.WithSchema(configServiceMethods.ByName("GetGroup")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceDeleteGroupHandler := connect.NewUnaryHandler(
+		ConfigServiceDeleteGroupProcedure,
+		svc.DeleteGroup,
+		connect.WithSchema(configServiceMethods.ByName("DeleteGroup")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceListGroupsHandler := connect.NewUnaryHandler(
+		ConfigServiceListGroupsProcedure,
+		svc.ListGroups,
+		connect.WithSchema(configServiceMethods.ByName("ListGroups")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServicePutComponentPolicyHandler := connect.NewUnaryHandler(
+		ConfigServicePutComponentPolicyProcedure,
+		svc.PutComponentPolicy,
+		connect.WithSchema(configServiceMethods.ByName("PutComponentPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceGetComponentPolicyHandler := connect.NewUnaryHandler(
+		ConfigServiceGetComponentPolicyProcedure,
+		svc.GetComponentPolicy,
+		connect.WithSchema(configServiceMethods.ByName("GetComponentPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceDeleteComponentPolicyHandler := connect.NewUnaryHandler(
+		ConfigServiceDeleteComponentPolicyProcedure,
+		svc.DeleteComponentPolicy,
+		connect.WithSchema(configServiceMethods.ByName("DeleteComponentPolicy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceListComponentPoliciesHandler := connect.NewUnaryHandler(
+		ConfigServiceListComponentPoliciesProcedure,
+		svc.ListComponentPolicies,
+		connect.WithSchema(configServiceMethods.ByName("ListComponentPolicies")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServicePutCollectorDistributionHandler := connect.NewUnaryHandler(
+		ConfigServicePutCollectorDistributionProcedure,
+		svc.PutCollectorDistribution,
+		connect.WithSchema(configServiceMethods.ByName("PutCollectorDistribution")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceGetCollectorDistributionHandler := connect.NewUnaryHandler(
+		ConfigServiceGetCollectorDistributionProcedure,
+		svc.GetCollectorDistribution,
+		connect.WithSchema(configServiceMethods.ByName("GetCollectorDistribution")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceDeleteCollectorDistributionHandler := connect.NewUnaryHandler(
+		ConfigServiceDeleteCollectorDistributionProcedure,
+		svc.DeleteCollectorDistribution,
+		connect.WithSchema(configServiceMethods.ByName("DeleteCollectorDistribution")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceListCollectorDistributionsHandler := connect.NewUnaryHandler(
+		ConfigServiceListCollectorDistributionsProcedure,
+		svc.ListCollectorDistributions,
+		connect.WithSchema(configServiceMethods.ByName("ListCollectorDistributions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceCheckConfigCompatibilityHandler := connect.NewUnaryHandler(
+		ConfigServiceCheckConfigCompatibilityProcedure,
+		svc.CheckConfigCompatibility,
+		connect.WithSchema(configServiceMethods.ByName("CheckConfigCompatibility")),
+		connect.WithHandlerOptions(opts...),
+	)
+	configServiceGetConfigCoverageHandler := connect.NewUnaryHandler(
+		ConfigServiceGetConfigCoverageProcedure,
+		svc.GetConfigCoverage,
+		connect.WithSchema(configServiceMethods.ByName("GetConfigCoverage")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/config.v1alpha1.ConfigService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case ConfigServiceValidConfigProcedure:
 			configServiceValidConfigHandler.ServeHTTP(w, r)
+		case ConfigServiceValidateConfigDetailedProcedure:
+			configServiceValidateConfigDetailedHandler.ServeHTTP(w, r)
 		case ConfigServicePutConfigProcedure:
 			configServicePutConfigHandler.ServeHTTP(w, r)
 		case ConfigServiceGetConfigProcedure:
@@ -551,6 +1221,10 @@ func NewConfigServiceHandler(svc ConfigServiceHandler, opts ...connect.HandlerOp
 			configServiceListConfigsHandler.ServeHTTP(w, r)
 		case ConfigServiceGetDefaultConfigProcedure:
 			configServiceGetDefaultConfigHandler.ServeHTTP(w, r)
+		case ConfigServiceBeginConfigEditProcedure:
+			configServiceBeginConfigEditHandler.ServeHTTP(w, r)
+		case ConfigServiceSaveConfigEditProcedure:
+			configServiceSaveConfigEditHandler.ServeHTTP(w, r)
 		case ConfigServiceSetDefaultConfigProcedure:
 			configServiceSetDefaultConfigHandler.ServeHTTP(w, r)
 		case ConfigServiceAssignConfigProcedure:
@@ -563,6 +1237,8 @@ func NewConfigServiceHandler(svc ConfigServiceHandler, opts ...connect.HandlerOp
 			configServiceListConfigAssignmentsHandler.ServeHTTP(w, r)
 		case ConfigServiceGetConfigStatusProcedure:
 			configServiceGetConfigStatusHandler.ServeHTTP(w, r)
+		case ConfigServiceGetAgentConfigAtTimeProcedure:
+			configServiceGetAgentConfigAtTimeHandler.ServeHTTP(w, r)
 		case ConfigServiceBatchAssignConfigProcedure:
 			configServiceBatchAssignConfigHandler.ServeHTTP(w, r)
 		case ConfigServiceAssignConfigByLabelsProcedure:
@@ -579,6 +1255,52 @@ func NewConfigServiceHandler(svc ConfigServiceHandler, opts ...connect.HandlerOp
 			configServiceCancelDeploymentHandler.ServeHTTP(w, r)
 		case ConfigServiceListDeploymentsProcedure:
 			configServiceListDeploymentsHandler.ServeHTTP(w, r)
+		case ConfigServicePurgeDeploymentProcedure:
+			configServicePurgeDeploymentHandler.ServeHTTP(w, r)
+		case ConfigServiceRollbackDeploymentProcedure:
+			configServiceRollbackDeploymentHandler.ServeHTTP(w, r)
+		case ConfigServiceSimulateDeploymentProcedure:
+			configServiceSimulateDeploymentHandler.ServeHTTP(w, r)
+		case ConfigServicePutAssignmentPolicyProcedure:
+			configServicePutAssignmentPolicyHandler.ServeHTTP(w, r)
+		case ConfigServiceGetAssignmentPolicyProcedure:
+			configServiceGetAssignmentPolicyHandler.ServeHTTP(w, r)
+		case ConfigServiceDeleteAssignmentPolicyProcedure:
+			configServiceDeleteAssignmentPolicyHandler.ServeHTTP(w, r)
+		case ConfigServiceListAssignmentPoliciesProcedure:
+			configServiceListAssignmentPoliciesHandler.ServeHTTP(w, r)
+		case ConfigServiceGetAssignmentExplanationProcedure:
+			configServiceGetAssignmentExplanationHandler.ServeHTTP(w, r)
+		case ConfigServiceCreateGroupProcedure:
+			configServiceCreateGroupHandler.ServeHTTP(w, r)
+		case ConfigServiceUpdateGroupProcedure:
+			configServiceUpdateGroupHandler.ServeHTTP(w, r)
+		case ConfigServiceGetGroupProcedure:
+			configServiceGetGroupHandler.ServeHTTP(w, r)
+		case ConfigServiceDeleteGroupProcedure:
+			configServiceDeleteGroupHandler.ServeHTTP(w, r)
+		case ConfigServiceListGroupsProcedure:
+			configServiceListGroupsHandler.ServeHTTP(w, r)
+		case ConfigServicePutComponentPolicyProcedure:
+			configServicePutComponentPolicyHandler.ServeHTTP(w, r)
+		case ConfigServiceGetComponentPolicyProcedure:
+			configServiceGetComponentPolicyHandler.ServeHTTP(w, r)
+		case ConfigServiceDeleteComponentPolicyProcedure:
+			configServiceDeleteComponentPolicyHandler.ServeHTTP(w, r)
+		case ConfigServiceListComponentPoliciesProcedure:
+			configServiceListComponentPoliciesHandler.ServeHTTP(w, r)
+		case ConfigServicePutCollectorDistributionProcedure:
+			configServicePutCollectorDistributionHandler.ServeHTTP(w, r)
+		case ConfigServiceGetCollectorDistributionProcedure:
+			configServiceGetCollectorDistributionHandler.ServeHTTP(w, r)
+		case ConfigServiceDeleteCollectorDistributionProcedure:
+			configServiceDeleteCollectorDistributionHandler.ServeHTTP(w, r)
+		case ConfigServiceListCollectorDistributionsProcedure:
+			configServiceListCollectorDistributionsHandler.ServeHTTP(w, r)
+		case ConfigServiceCheckConfigCompatibilityProcedure:
+			configServiceCheckConfigCompatibilityHandler.ServeHTTP(w, r)
+		case ConfigServiceGetConfigCoverageProcedure:
+			configServiceGetConfigCoverageHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -592,6 +1314,10 @@ func (UnimplementedConfigServiceHandler) ValidConfig(context.Context, *connect.R
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.ValidConfig is not implemented"))
 }
 
+func (UnimplementedConfigServiceHandler) ValidateConfigDetailed(context.Context, *connect.Request[v1alpha1.ValidateConfigDetailedRequest]) (*connect.Response[v1alpha1.ConfigValidationResult], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.ValidateConfigDetailed is not implemented"))
+}
+
 func (UnimplementedConfigServiceHandler) PutConfig(context.Context, *connect.Request[v1alpha1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.PutConfig is not implemented"))
 }
@@ -604,7 +1330,7 @@ func (UnimplementedConfigServiceHandler) DeleteConfig(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.DeleteConfig is not implemented"))
 }
 
-func (UnimplementedConfigServiceHandler) ListConfigs(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListConfigReponse], error) {
+func (UnimplementedConfigServiceHandler) ListConfigs(context.Context, *connect.Request[v1alpha1.ListConfigsRequest]) (*connect.Response[v1alpha1.ListConfigReponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.ListConfigs is not implemented"))
 }
 
@@ -612,6 +1338,14 @@ func (UnimplementedConfigServiceHandler) GetDefaultConfig(context.Context, *conn
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.GetDefaultConfig is not implemented"))
 }
 
+func (UnimplementedConfigServiceHandler) BeginConfigEdit(context.Context, *connect.Request[v1alpha1.ConfigReference]) (*connect.Response[v1alpha1.ConfigEditSession], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.BeginConfigEdit is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) SaveConfigEdit(context.Context, *connect.Request[v1alpha1.SaveConfigEditRequest]) (*connect.Response[v1alpha1.SaveConfigEditResult], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.SaveConfigEdit is not implemented"))
+}
+
 func (UnimplementedConfigServiceHandler) SetDefaultConfig(context.Context, *connect.Request[v1alpha1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.SetDefaultConfig is not implemented"))
 }
@@ -636,6 +1370,10 @@ func (UnimplementedConfigServiceHandler) GetConfigStatus(context.Context, *conne
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.GetConfigStatus is not implemented"))
 }
 
+func (UnimplementedConfigServiceHandler) GetAgentConfigAtTime(context.Context, *connect.Request[v1alpha1.GetAgentConfigAtTimeRequest]) (*connect.Response[v1alpha1.AgentConfigAtTime], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.GetAgentConfigAtTime is not implemented"))
+}
+
 func (UnimplementedConfigServiceHandler) BatchAssignConfig(context.Context, *connect.Request[v1alpha1.BatchAssignConfigRequest]) (*connect.Response[v1alpha1.BatchAssignConfigResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.BatchAssignConfig is not implemented"))
 }
@@ -667,3 +1405,249 @@ func (UnimplementedConfigServiceHandler) CancelDeployment(context.Context, *conn
 func (UnimplementedConfigServiceHandler) ListDeployments(context.Context, *connect.Request[v1alpha1.ListDeploymentsRequest]) (*connect.Response[v1alpha1.ListDeploymentsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.ListDeployments is not implemented"))
 }
+
+func (UnimplementedConfigServiceHandler) PurgeDeployment(context.Context, *connect.Request[v1alpha1.PurgeDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentActionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.PurgeDeployment is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) RollbackDeployment(context.Context, *connect.Request[v1alpha1.RollbackDeploymentRequest]) (*connect.Response[v1alpha1.RollingDeploymentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.RollbackDeployment is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) SimulateDeployment(context.Context, *connect.Request[v1alpha1.RollingDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentPlan], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.SimulateDeployment is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) PutAssignmentPolicy(context.Context, *connect.Request[v1alpha1.PutAssignmentPolicyRequest]) (*connect.Response[v1alpha1.AssignmentPolicy], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.PutAssignmentPolicy is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) GetAssignmentPolicy(context.Context, *connect.Request[v1alpha1.AssignmentPolicyReference]) (*connect.Response[v1alpha1.AssignmentPolicy], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.GetAssignmentPolicy is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) DeleteAssignmentPolicy(context.Context, *connect.Request[v1alpha1.AssignmentPolicyReference]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.DeleteAssignmentPolicy is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) ListAssignmentPolicies(context.Context, *connect.Request[v1alpha1.ListAssignmentPoliciesRequest]) (*connect.Response[v1alpha1.ListAssignmentPoliciesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.ListAssignmentPolicies is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) GetAssignmentExplanation(context.Context, *connect.Request[v1alpha1.GetAssignmentExplanationRequest]) (*connect.Response[v1alpha1.AssignmentExplanation], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.GetAssignmentExplanation is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) CreateGroup(context.Context, *connect.Request[v1alpha1.CreateGroupRequest]) (*connect.Response[v1alpha1.AgentGroup], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.CreateGroup is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) UpdateGroup(context.Context, *connect.Request[v1alpha1.UpdateGroupRequest]) (*connect.Response[v1alpha1.AgentGroup], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.UpdateGroup is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) GetGroup(context.Context, *connect.Request[v1alpha1.AgentGroupReference]) (*connect.Response[v1alpha1.AgentGroup], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.GetGroup is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) DeleteGroup(context.Context, *connect.Request[v1alpha1.AgentGroupReference]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.DeleteGroup is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) ListGroups(context.Context, *connect.Request[v1alpha1.ListGroupsRequest]) (*connect.Response[v1alpha1.ListGroupsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.ListGroups is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) PutComponentPolicy(context.Context, *connect.Request[v1alpha1.PutComponentPolicyRequest]) (*connect.Response[v1alpha1.ComponentPolicy], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.PutComponentPolicy is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) GetComponentPolicy(context.Context, *connect.Request[v1alpha1.ComponentPolicyReference]) (*connect.Response[v1alpha1.ComponentPolicy], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.GetComponentPolicy is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) DeleteComponentPolicy(context.Context, *connect.Request[v1alpha1.ComponentPolicyReference]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.DeleteComponentPolicy is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) ListComponentPolicies(context.Context, *connect.Request[v1alpha1.ListComponentPoliciesRequest]) (*connect.Response[v1alpha1.ListComponentPoliciesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.ListComponentPolicies is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) PutCollectorDistribution(context.Context, *connect.Request[v1alpha1.PutCollectorDistributionRequest]) (*connect.Response[v1alpha1.CollectorDistribution], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.PutCollectorDistribution is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) GetCollectorDistribution(context.Context, *connect.Request[v1alpha1.CollectorDistributionReference]) (*connect.Response[v1alpha1.CollectorDistribution], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.GetCollectorDistribution is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) DeleteCollectorDistribution(context.Context, *connect.Request[v1alpha1.CollectorDistributionReference]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.DeleteCollectorDistribution is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) ListCollectorDistributions(context.Context, *connect.Request[v1alpha1.ListCollectorDistributionsRequest]) (*connect.Response[v1alpha1.ListCollectorDistributionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.ListCollectorDistributions is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) CheckConfigCompatibility(context.Context, *connect.Request[v1alpha1.CheckConfigCompatibilityRequest]) (*connect.Response[v1alpha1.ConfigCompatibility], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.CheckConfigCompatibility is not implemented"))
+}
+
+func (UnimplementedConfigServiceHandler) GetConfigCoverage(context.Context, *connect.Request[v1alpha1.GetConfigCoverageRequest]) (*connect.Response[v1alpha1.ConfigCoverageReport], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.ConfigService.GetConfigCoverage is not implemented"))
+}
+
+// PackageServiceClient is a client for the config.v1alpha1.PackageService service.
+type PackageServiceClient interface {
+	// Sets the distribution installed on an agent, or on the agents of a group. The target of an
+	// agent outranks the targets of its groups.
+	SetPackageTarget(context.Context, *connect.Request[v1alpha1.SetPackageTargetRequest]) (*connect.Response[v1alpha1.PackageTarget], error)
+	DeletePackageTarget(context.Context, *connect.Request[v1alpha1.PackageTargetReference]) (*connect.Response[emptypb.Empty], error)
+	ListPackageTargets(context.Context, *connect.Request[v1alpha1.ListPackageTargetsRequest]) (*connect.Response[v1alpha1.ListPackageTargetsResponse], error)
+	// Returns the distribution offered to an agent and the installation status it reported
+	GetAgentPackageStatus(context.Context, *connect.Request[v1alpha1.GetAgentPackageStatusRequest]) (*connect.Response[v1alpha1.AgentPackageStatus], error)
+}
+
+// NewPackageServiceClient constructs a client for the config.v1alpha1.PackageService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewPackageServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) PackageServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	packageServiceMethods := v1alpha1.File_pkg_api_config_v1alpha1_config_proto.Services().ByName("PackageService").Methods()
+	return &packageServiceClient{
+		setPackageTarget: connect.NewClient[v1alpha1.SetPackageTargetRequest, v1alpha1.PackageTarget](
+			httpClient,
+			baseURL+PackageServiceSetPackageTargetProcedure,
+			connect.WithSchema(packageServiceMethods.ByName("SetPackageTarget")),
+			connect.WithClientOptions(opts...),
+		),
+		deletePackageTarget: connect.NewClient[v1alpha1.PackageTargetReference, emptypb.Empty](
+			httpClient,
+			baseURL+PackageServiceDeletePackageTargetProcedure,
+			connect.WithSchema(packageServiceMethods.ByName("DeletePackageTarget")),
+			connect.WithClientOptions(opts...),
+		),
+		listPackageTargets: connect.NewClient[v1alpha1.ListPackageTargetsRequest, v1alpha1.ListPackageTargetsResponse](
+			httpClient,
+			baseURL+PackageServiceListPackageTargetsProcedure,
+			connect.WithSchema(packageServiceMethods.ByName("ListPackageTargets")),
+			connect.WithClientOptions(opts...),
+		),
+		getAgentPackageStatus: connect.NewClient[v1alpha1.GetAgentPackageStatusRequest, v1alpha1.AgentPackageStatus](
+			httpClient,
+			baseURL+PackageServiceGetAgentPackageStatusProcedure,
+			connect.WithSchema(packageServiceMethods.ByName("GetAgentPackageStatus")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// packageServiceClient implements PackageServiceClient.
+type packageServiceClient struct {
+	setPackageTarget      *connect.Client[v1alpha1.SetPackageTargetRequest, v1alpha1.PackageTarget]
+	deletePackageTarget   *connect.Client[v1alpha1.PackageTargetReference, emptypb.Empty]
+	listPackageTargets    *connect.Client[v1alpha1.ListPackageTargetsRequest, v1alpha1.ListPackageTargetsResponse]
+	getAgentPackageStatus *connect.Client[v1alpha1.GetAgentPackageStatusRequest, v1alpha1.AgentPackageStatus]
+}
+
+// SetPackageTarget calls config.v1alpha1.PackageService.SetPackageTarget.
+func (c *packageServiceClient) SetPackageTarget(ctx context.Context, req *connect.Request[v1alpha1.SetPackageTargetRequest]) (*connect.Response[v1alpha1.PackageTarget], error) {
+	return c.setPackageTarget.CallUnary(ctx, req)
+}
+
+// DeletePackageTarget calls config.v1alpha1.PackageService.DeletePackageTarget.
+func (c *packageServiceClient) DeletePackageTarget(ctx context.Context, req *connect.Request[v1alpha1.PackageTargetReference]) (*connect.Response[emptypb.Empty], error) {
+	return c.deletePackageTarget.CallUnary(ctx, req)
+}
+
+// ListPackageTargets calls config.v1alpha1.PackageService.ListPackageTargets.
+func (c *packageServiceClient) ListPackageTargets(ctx context.Context, req *connect.Request[v1alpha1.ListPackageTargetsRequest]) (*connect.Response[v1alpha1.ListPackageTargetsResponse], error) {
+	return c.listPackageTargets.CallUnary(ctx, req)
+}
+
+// GetAgentPackageStatus calls config.v1alpha1.PackageService.GetAgentPackageStatus.
+func (c *packageServiceClient) GetAgentPackageStatus(ctx context.Context, req *connect.Request[v1alpha1.GetAgentPackageStatusRequest]) (*connect.Response[v1alpha1.AgentPackageStatus], error) {
+	return c.getAgentPackageStatus.CallUnary(ctx, req)
+}
+
+// PackageServiceHandler is an implementation of the config.v1alpha1.PackageService service.
+type PackageServiceHandler interface {
+	// Sets the distribution installed on an agent, or on the agents of a group. The target of an
+	// agent outranks the targets of its groups.
+	SetPackageTarget(context.Context, *connect.Request[v1alpha1.SetPackageTargetRequest]) (*connect.Response[v1alpha1.PackageTarget], error)
+	DeletePackageTarget(context.Context, *connect.Request[v1alpha1.PackageTargetReference]) (*connect.Response[emptypb.Empty], error)
+	ListPackageTargets(context.Context, *connect.Request[v1alpha1.ListPackageTargetsRequest]) (*connect.Response[v1alpha1.ListPackageTargetsResponse], error)
+	// Returns the distribution offered to an agent and the installation status it reported
+	GetAgentPackageStatus(context.Context, *connect.Request[v1alpha1.GetAgentPackageStatusRequest]) (*connect.Response[v1alpha1.AgentPackageStatus], error)
+}
+
+// NewPackageServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewPackageServiceHandler(svc PackageServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	packageServiceMethods := v1alpha1.File_pkg_api_config_v1alpha1_config_proto.Services().ByName("PackageService").Methods()
+	packageServiceSetPackageTargetHandler := connect.NewUnaryHandler(
+		PackageServiceSetPackageTargetProcedure,
+		svc.SetPackageTarget,
+		connect.WithSchema(packageServiceMethods.ByName("SetPackageTarget")),
+		connect.WithHandlerOptions(opts...),
+	)
+	packageServiceDeletePackageTargetHandler := connect.NewUnaryHandler(
+		PackageServiceDeletePackageTargetProcedure,
+		svc.DeletePackageTarget,
+		connect.WithSchema(packageServiceMethods.ByName("DeletePackageTarget")),
+		connect.WithHandlerOptions(opts...),
+	)
+	packageServiceListPackageTargetsHandler := connect.NewUnaryHandler(
+		PackageServiceListPackageTargetsProcedure,
+		svc.ListPackageTargets,
+		connect.WithSchema(packageServiceMethods.ByName("ListPackageTargets")),
+		connect.WithHandlerOptions(opts...),
+	)
+	packageServiceGetAgentPackageStatusHandler := connect.NewUnaryHandler(
+		PackageServiceGetAgentPackageStatusProcedure,
+		svc.GetAgentPackageStatus,
+		connect.WithSchema(packageServiceMethods.ByName("GetAgentPackageStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/config.v1alpha1.PackageService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case PackageServiceSetPackageTargetProcedure:
+			packageServiceSetPackageTargetHandler.ServeHTTP(w, r)
+		case PackageServiceDeletePackageTargetProcedure:
+			packageServiceDeletePackageTargetHandler.ServeHTTP(w, r)
+		case PackageServiceListPackageTargetsProcedure:
+			packageServiceListPackageTargetsHandler.ServeHTTP(w, r)
+		case PackageServiceGetAgentPackageStatusProcedure:
+			packageServiceGetAgentPackageStatusHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedPackageServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedPackageServiceHandler struct{}
+
+func (UnimplementedPackageServiceHandler) SetPackageTarget(context.Context, *connect.Request[v1alpha1.SetPackageTargetRequest]) (*connect.Response[v1alpha1.PackageTarget], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.PackageService.SetPackageTarget is not implemented"))
+}
+
+func (UnimplementedPackageServiceHandler) DeletePackageTarget(context.Context, *connect.Request[v1alpha1.PackageTargetReference]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.PackageService.DeletePackageTarget is not implemented"))
+}
+
+func (UnimplementedPackageServiceHandler) ListPackageTargets(context.Context, *connect.Request[v1alpha1.ListPackageTargetsRequest]) (*connect.Response[v1alpha1.ListPackageTargetsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.PackageService.ListPackageTargets is not implemented"))
+}
+
+func (UnimplementedPackageServiceHandler) GetAgentPackageStatus(context.Context, *connect.Request[v1alpha1.GetAgentPackageStatusRequest]) (*connect.Response[v1alpha1.AgentPackageStatus], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config.v1alpha1.PackageService.GetAgentPackageStatus is not implemented"))
+}
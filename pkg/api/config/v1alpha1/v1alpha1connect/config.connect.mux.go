@@ -24,6 +24,11 @@ func RegisterConfigServiceHandler(mux *mux.Router, svc ConfigServiceHandler, opt
 		svc.ValidConfig,
 		opts...,
 	))
+	mux.Handle("/config.v1alpha1.ConfigService/ValidateConfigDetailed", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/ValidateConfigDetailed",
+		svc.ValidateConfigDetailed,
+		opts...,
+	))
 	mux.Handle("/config.v1alpha1.ConfigService/PutConfig", connect.NewUnaryHandler(
 		"/config.v1alpha1.ConfigService/PutConfig",
 		svc.PutConfig,
@@ -49,6 +54,16 @@ func RegisterConfigServiceHandler(mux *mux.Router, svc ConfigServiceHandler, opt
 		svc.GetDefaultConfig,
 		opts...,
 	))
+	mux.Handle("/config.v1alpha1.ConfigService/BeginConfigEdit", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/BeginConfigEdit",
+		svc.BeginConfigEdit,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/SaveConfigEdit", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/SaveConfigEdit",
+		svc.SaveConfigEdit,
+		opts...,
+	))
 	mux.Handle("/config.v1alpha1.ConfigService/SetDefaultConfig", connect.NewUnaryHandler(
 		"/config.v1alpha1.ConfigService/SetDefaultConfig",
 		svc.SetDefaultConfig,
@@ -79,6 +94,11 @@ func RegisterConfigServiceHandler(mux *mux.Router, svc ConfigServiceHandler, opt
 		svc.GetConfigStatus,
 		opts...,
 	))
+	mux.Handle("/config.v1alpha1.ConfigService/GetAgentConfigAtTime", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/GetAgentConfigAtTime",
+		svc.GetAgentConfigAtTime,
+		opts...,
+	))
 	mux.Handle("/config.v1alpha1.ConfigService/BatchAssignConfig", connect.NewUnaryHandler(
 		"/config.v1alpha1.ConfigService/BatchAssignConfig",
 		svc.BatchAssignConfig,
@@ -119,4 +139,144 @@ func RegisterConfigServiceHandler(mux *mux.Router, svc ConfigServiceHandler, opt
 		svc.ListDeployments,
 		opts...,
 	))
+	mux.Handle("/config.v1alpha1.ConfigService/PurgeDeployment", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/PurgeDeployment",
+		svc.PurgeDeployment,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/RollbackDeployment", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/RollbackDeployment",
+		svc.RollbackDeployment,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/SimulateDeployment", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/SimulateDeployment",
+		svc.SimulateDeployment,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/PutAssignmentPolicy", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/PutAssignmentPolicy",
+		svc.PutAssignmentPolicy,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/GetAssignmentPolicy", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/GetAssignmentPolicy",
+		svc.GetAssignmentPolicy,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/DeleteAssignmentPolicy", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/DeleteAssignmentPolicy",
+		svc.DeleteAssignmentPolicy,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/ListAssignmentPolicies", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/ListAssignmentPolicies",
+		svc.ListAssignmentPolicies,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/GetAssignmentExplanation", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/GetAssignmentExplanation",
+		svc.GetAssignmentExplanation,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/CreateGroup", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/CreateGroup",
+		svc.CreateGroup,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/UpdateGroup", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/UpdateGroup",
+		svc.UpdateGroup,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/GetGroup", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/GetGroup",
+		svc.GetGroup,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/DeleteGroup", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/DeleteGroup",
+		svc.DeleteGroup,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/ListGroups", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/ListGroups",
+		svc.ListGroups,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/PutComponentPolicy", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/PutComponentPolicy",
+		svc.PutComponentPolicy,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/GetComponentPolicy", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/GetComponentPolicy",
+		svc.GetComponentPolicy,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/DeleteComponentPolicy", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/DeleteComponentPolicy",
+		svc.DeleteComponentPolicy,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/ListComponentPolicies", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/ListComponentPolicies",
+		svc.ListComponentPolicies,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/PutCollectorDistribution", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/PutCollectorDistribution",
+		svc.PutCollectorDistribution,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/GetCollectorDistribution", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/GetCollectorDistribution",
+		svc.GetCollectorDistribution,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/DeleteCollectorDistribution", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/DeleteCollectorDistribution",
+		svc.DeleteCollectorDistribution,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/ListCollectorDistributions", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/ListCollectorDistributions",
+		svc.ListCollectorDistributions,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/CheckConfigCompatibility", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/CheckConfigCompatibility",
+		svc.CheckConfigCompatibility,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.ConfigService/GetConfigCoverage", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/GetConfigCoverage",
+		svc.GetConfigCoverage,
+		opts...,
+	))
+}
+
+// RegisterPackageServiceHandler register an HTTP handler to a mux.Router from the service
+// implementation.
+func RegisterPackageServiceHandler(mux *mux.Router, svc PackageServiceHandler, opts ...connect.HandlerOption) {
+	mux.Handle("/config.v1alpha1.PackageService/SetPackageTarget", connect.NewUnaryHandler(
+		"/config.v1alpha1.PackageService/SetPackageTarget",
+		svc.SetPackageTarget,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.PackageService/DeletePackageTarget", connect.NewUnaryHandler(
+		"/config.v1alpha1.PackageService/DeletePackageTarget",
+		svc.DeletePackageTarget,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.PackageService/ListPackageTargets", connect.NewUnaryHandler(
+		"/config.v1alpha1.PackageService/ListPackageTargets",
+		svc.ListPackageTargets,
+		opts...,
+	))
+	mux.Handle("/config.v1alpha1.PackageService/GetAgentPackageStatus", connect.NewUnaryHandler(
+		"/config.v1alpha1.PackageService/GetAgentPackageStatus",
+		svc.GetAgentPackageStatus,
+		opts...,
+	))
 }
@@ -0,0 +1,1227 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: pkg/api/config/v1beta1/config.proto
+
+package v1beta1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ConfigSource int32
+
+const (
+	ConfigSource_CONFIG_SOURCE_UNSPECIFIED ConfigSource = 0
+	ConfigSource_CONFIG_SOURCE_DEFAULT     ConfigSource = 1
+	ConfigSource_CONFIG_SOURCE_BOOTSTRAP   ConfigSource = 2
+	ConfigSource_CONFIG_SOURCE_MANUAL      ConfigSource = 3
+	// assigned by an assignment policy
+	ConfigSource_CONFIG_SOURCE_POLICY ConfigSource = 4
+)
+
+// Enum value maps for ConfigSource.
+var (
+	ConfigSource_name = map[int32]string{
+		0: "CONFIG_SOURCE_UNSPECIFIED",
+		1: "CONFIG_SOURCE_DEFAULT",
+		2: "CONFIG_SOURCE_BOOTSTRAP",
+		3: "CONFIG_SOURCE_MANUAL",
+		4: "CONFIG_SOURCE_POLICY",
+	}
+	ConfigSource_value = map[string]int32{
+		"CONFIG_SOURCE_UNSPECIFIED": 0,
+		"CONFIG_SOURCE_DEFAULT":     1,
+		"CONFIG_SOURCE_BOOTSTRAP":   2,
+		"CONFIG_SOURCE_MANUAL":      3,
+		"CONFIG_SOURCE_POLICY":      4,
+	}
+)
+
+func (x ConfigSource) Enum() *ConfigSource {
+	p := new(ConfigSource)
+	*p = x
+	return p
+}
+
+func (x ConfigSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_config_v1beta1_config_proto_enumTypes[0].Descriptor()
+}
+
+func (ConfigSource) Type() protoreflect.EnumType {
+	return &file_pkg_api_config_v1beta1_config_proto_enumTypes[0]
+}
+
+func (x ConfigSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigSource.Descriptor instead.
+func (ConfigSource) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{0}
+}
+
+type ConfigApplicationStatus int32
+
+const (
+	ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_UNSPECIFIED ConfigApplicationStatus = 0
+	ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_PENDING     ConfigApplicationStatus = 1
+	ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_APPLIED     ConfigApplicationStatus = 2
+	ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_FAILED      ConfigApplicationStatus = 3
+	// the agent does not accept remote config, so the assignment is never applied
+	ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_UNSUPPORTED ConfigApplicationStatus = 4
+)
+
+// Enum value maps for ConfigApplicationStatus.
+var (
+	ConfigApplicationStatus_name = map[int32]string{
+		0: "CONFIG_APPLICATION_STATUS_UNSPECIFIED",
+		1: "CONFIG_APPLICATION_STATUS_PENDING",
+		2: "CONFIG_APPLICATION_STATUS_APPLIED",
+		3: "CONFIG_APPLICATION_STATUS_FAILED",
+		4: "CONFIG_APPLICATION_STATUS_UNSUPPORTED",
+	}
+	ConfigApplicationStatus_value = map[string]int32{
+		"CONFIG_APPLICATION_STATUS_UNSPECIFIED": 0,
+		"CONFIG_APPLICATION_STATUS_PENDING":     1,
+		"CONFIG_APPLICATION_STATUS_APPLIED":     2,
+		"CONFIG_APPLICATION_STATUS_FAILED":      3,
+		"CONFIG_APPLICATION_STATUS_UNSUPPORTED": 4,
+	}
+)
+
+func (x ConfigApplicationStatus) Enum() *ConfigApplicationStatus {
+	p := new(ConfigApplicationStatus)
+	*p = x
+	return p
+}
+
+func (x ConfigApplicationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfigApplicationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_config_v1beta1_config_proto_enumTypes[1].Descriptor()
+}
+
+func (ConfigApplicationStatus) Type() protoreflect.EnumType {
+	return &file_pkg_api_config_v1beta1_config_proto_enumTypes[1]
+}
+
+func (x ConfigApplicationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfigApplicationStatus.Descriptor instead.
+func (ConfigApplicationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{1}
+}
+
+type PutConfigRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Ref    *ConfigReference       `protobuf:"bytes,1,opt,name=ref,proto3" json:"ref,omitempty"`
+	Config *Config                `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// reject the config if it is invalid
+	Validate      bool `protobuf:"varint,3,opt,name=validate,proto3" json:"validate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutConfigRequest) Reset() {
+	*x = PutConfigRequest{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutConfigRequest) ProtoMessage() {}
+
+func (x *PutConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutConfigRequest.ProtoReflect.Descriptor instead.
+func (*PutConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PutConfigRequest) GetRef() *ConfigReference {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *PutConfigRequest) GetConfig() *Config {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *PutConfigRequest) GetValidate() bool {
+	if x != nil {
+		return x.Validate
+	}
+	return false
+}
+
+type ConfigReference struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigReference) Reset() {
+	*x = ConfigReference{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigReference) ProtoMessage() {}
+
+func (x *ConfigReference) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigReference.ProtoReflect.Descriptor instead.
+func (*ConfigReference) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ConfigReference) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Config struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        []byte                 `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	Metadata      *ConfigMetadata        `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Config) Reset() {
+	*x = Config{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Config) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Config) ProtoMessage() {}
+
+func (x *Config) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Config.ProtoReflect.Descriptor instead.
+func (*Config) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Config) GetConfig() []byte {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *Config) GetMetadata() *ConfigMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type ConfigMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// principal that created the config
+	Owner string   `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Tags  []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// set by the server on every change
+	Audit *AuditInfo `protobuf:"bytes,3,opt,name=audit,proto3" json:"audit,omitempty"`
+	// free-form annotations, e.g. the lineage of configs copied from another server
+	Annotations   map[string]string `protobuf:"bytes,4,rep,name=annotations,proto3" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigMetadata) Reset() {
+	*x = ConfigMetadata{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigMetadata) ProtoMessage() {}
+
+func (x *ConfigMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigMetadata.ProtoReflect.Descriptor instead.
+func (*ConfigMetadata) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ConfigMetadata) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ConfigMetadata) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ConfigMetadata) GetAudit() *AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+func (x *ConfigMetadata) GetAnnotations() map[string]string {
+	if x != nil {
+		return x.Annotations
+	}
+	return nil
+}
+
+type AuditInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedBy     string                 `protobuf:"bytes,1,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ModifiedBy    string                 `protobuf:"bytes,3,opt,name=modified_by,json=modifiedBy,proto3" json:"modified_by,omitempty"`
+	ModifiedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditInfo) Reset() {
+	*x = AuditInfo{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditInfo) ProtoMessage() {}
+
+func (x *AuditInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditInfo.ProtoReflect.Descriptor instead.
+func (*AuditInfo) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AuditInfo) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *AuditInfo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AuditInfo) GetModifiedBy() string {
+	if x != nil {
+		return x.ModifiedBy
+	}
+	return ""
+}
+
+func (x *AuditInfo) GetModifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ModifiedAt
+	}
+	return nil
+}
+
+type ListConfigsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigsRequest) Reset() {
+	*x = ListConfigsRequest{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigsRequest) ProtoMessage() {}
+
+func (x *ListConfigsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigsRequest.ProtoReflect.Descriptor instead.
+func (*ListConfigsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{5}
+}
+
+type ListConfigsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Configs []*ConfigReference     `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty"`
+	// metadata of the listed configs, by config ID
+	Metadata      map[string]*ConfigMetadata `protobuf:"bytes,2,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigsResponse) Reset() {
+	*x = ListConfigsResponse{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigsResponse) ProtoMessage() {}
+
+func (x *ListConfigsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigsResponse.ProtoReflect.Descriptor instead.
+func (*ListConfigsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListConfigsResponse) GetConfigs() []*ConfigReference {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
+func (x *ListConfigsResponse) GetMetadata() map[string]*ConfigMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type AssignConfigRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	AgentId  string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ConfigId string                 `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	// source is MANUAL (the default) to assign config_id, or DEFAULT to have the agent
+	// intentionally follow the global default config, in which case config_id must be empty
+	Source ConfigSource `protobuf:"varint,3,opt,name=source,proto3,enum=config.v1beta1.ConfigSource" json:"source,omitempty"`
+	// roll the agent back to its last known-good config if it fails to apply the config
+	AutoRollback  bool `protobuf:"varint,4,opt,name=auto_rollback,json=autoRollback,proto3" json:"auto_rollback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignConfigRequest) Reset() {
+	*x = AssignConfigRequest{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignConfigRequest) ProtoMessage() {}
+
+func (x *AssignConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignConfigRequest.ProtoReflect.Descriptor instead.
+func (*AssignConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AssignConfigRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *AssignConfigRequest) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *AssignConfigRequest) GetSource() ConfigSource {
+	if x != nil {
+		return x.Source
+	}
+	return ConfigSource_CONFIG_SOURCE_UNSPECIFIED
+}
+
+func (x *AssignConfigRequest) GetAutoRollback() bool {
+	if x != nil {
+		return x.AutoRollback
+	}
+	return false
+}
+
+type AssignConfigResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// the config's resource requirements exceeding the capacity reported by the agent
+	Warnings      []string `protobuf:"bytes,3,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignConfigResponse) Reset() {
+	*x = AssignConfigResponse{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignConfigResponse) ProtoMessage() {}
+
+func (x *AssignConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignConfigResponse.ProtoReflect.Descriptor instead.
+func (*AssignConfigResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AssignConfigResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AssignConfigResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AssignConfigResponse) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+type GetAgentConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentConfigRequest) Reset() {
+	*x = GetAgentConfigRequest{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentConfigRequest) ProtoMessage() {}
+
+func (x *GetAgentConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetAgentConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetAgentConfigRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type GetAgentConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ConfigId      string                 `protobuf:"bytes,1,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	Source        ConfigSource           `protobuf:"varint,2,opt,name=source,proto3,enum=config.v1beta1.ConfigSource" json:"source,omitempty"`
+	AssignedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAgentConfigResponse) Reset() {
+	*x = GetAgentConfigResponse{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAgentConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAgentConfigResponse) ProtoMessage() {}
+
+func (x *GetAgentConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAgentConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetAgentConfigResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAgentConfigResponse) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *GetAgentConfigResponse) GetSource() ConfigSource {
+	if x != nil {
+		return x.Source
+	}
+	return ConfigSource_CONFIG_SOURCE_UNSPECIFIED
+}
+
+func (x *GetAgentConfigResponse) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+type UnassignConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AgentId       string                 `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignConfigRequest) Reset() {
+	*x = UnassignConfigRequest{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignConfigRequest) ProtoMessage() {}
+
+func (x *UnassignConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignConfigRequest.ProtoReflect.Descriptor instead.
+func (*UnassignConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UnassignConfigRequest) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+type UnassignConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnassignConfigResponse) Reset() {
+	*x = UnassignConfigResponse{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignConfigResponse) ProtoMessage() {}
+
+func (x *UnassignConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignConfigResponse.ProtoReflect.Descriptor instead.
+func (*UnassignConfigResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UnassignConfigResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListConfigAssignmentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// only list the assignments of this config
+	ConfigId      *string `protobuf:"bytes,1,opt,name=config_id,json=configId,proto3,oneof" json:"config_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigAssignmentsRequest) Reset() {
+	*x = ListConfigAssignmentsRequest{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigAssignmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigAssignmentsRequest) ProtoMessage() {}
+
+func (x *ListConfigAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListConfigAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListConfigAssignmentsRequest) GetConfigId() string {
+	if x != nil && x.ConfigId != nil {
+		return *x.ConfigId
+	}
+	return ""
+}
+
+type ListConfigAssignmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignments   []*ConfigAssignment    `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConfigAssignmentsResponse) Reset() {
+	*x = ListConfigAssignmentsResponse{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConfigAssignmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConfigAssignmentsResponse) ProtoMessage() {}
+
+func (x *ListConfigAssignmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConfigAssignmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListConfigAssignmentsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListConfigAssignmentsResponse) GetAssignments() []*ConfigAssignment {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+type ConfigAssignment struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	AgentId       string                  `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	ConfigId      string                  `protobuf:"bytes,2,opt,name=config_id,json=configId,proto3" json:"config_id,omitempty"`
+	Source        ConfigSource            `protobuf:"varint,3,opt,name=source,proto3,enum=config.v1beta1.ConfigSource" json:"source,omitempty"`
+	AssignedAt    *timestamppb.Timestamp  `protobuf:"bytes,4,opt,name=assigned_at,json=assignedAt,proto3" json:"assigned_at,omitempty"`
+	Status        ConfigApplicationStatus `protobuf:"varint,5,opt,name=status,proto3,enum=config.v1beta1.ConfigApplicationStatus" json:"status,omitempty"`
+	ErrorMessage  string                  `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Audit         *AuditInfo              `protobuf:"bytes,7,opt,name=audit,proto3" json:"audit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfigAssignment) Reset() {
+	*x = ConfigAssignment{}
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfigAssignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigAssignment) ProtoMessage() {}
+
+func (x *ConfigAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_config_v1beta1_config_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigAssignment.ProtoReflect.Descriptor instead.
+func (*ConfigAssignment) Descriptor() ([]byte, []int) {
+	return file_pkg_api_config_v1beta1_config_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ConfigAssignment) GetAgentId() string {
+	if x != nil {
+		return x.AgentId
+	}
+	return ""
+}
+
+func (x *ConfigAssignment) GetConfigId() string {
+	if x != nil {
+		return x.ConfigId
+	}
+	return ""
+}
+
+func (x *ConfigAssignment) GetSource() ConfigSource {
+	if x != nil {
+		return x.Source
+	}
+	return ConfigSource_CONFIG_SOURCE_UNSPECIFIED
+}
+
+func (x *ConfigAssignment) GetAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AssignedAt
+	}
+	return nil
+}
+
+func (x *ConfigAssignment) GetStatus() ConfigApplicationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_UNSPECIFIED
+}
+
+func (x *ConfigAssignment) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ConfigAssignment) GetAudit() *AuditInfo {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+var File_pkg_api_config_v1beta1_config_proto protoreflect.FileDescriptor
+
+const file_pkg_api_config_v1beta1_config_proto_rawDesc = "" +
+	"\n" +
+	"#pkg/api/config/v1beta1/config.proto\x12\x0econfig.v1beta1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x91\x01\n" +
+	"\x10PutConfigRequest\x121\n" +
+	"\x03ref\x18\x01 \x01(\v2\x1f.config.v1beta1.ConfigReferenceR\x03ref\x12.\n" +
+	"\x06config\x18\x02 \x01(\v2\x16.config.v1beta1.ConfigR\x06config\x12\x1a\n" +
+	"\bvalidate\x18\x03 \x01(\bR\bvalidate\"!\n" +
+	"\x0fConfigReference\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\\\n" +
+	"\x06Config\x12\x16\n" +
+	"\x06config\x18\x01 \x01(\fR\x06config\x12:\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x1e.config.v1beta1.ConfigMetadataR\bmetadata\"\xfe\x01\n" +
+	"\x0eConfigMetadata\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12/\n" +
+	"\x05audit\x18\x03 \x01(\v2\x19.config.v1beta1.AuditInfoR\x05audit\x12Q\n" +
+	"\vannotations\x18\x04 \x03(\v2/.config.v1beta1.ConfigMetadata.AnnotationsEntryR\vannotations\x1a>\n" +
+	"\x10AnnotationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc3\x01\n" +
+	"\tAuditInfo\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x01 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1f\n" +
+	"\vmodified_by\x18\x03 \x01(\tR\n" +
+	"modifiedBy\x12;\n" +
+	"\vmodified_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"modifiedAt\"\x14\n" +
+	"\x12ListConfigsRequest\"\xfc\x01\n" +
+	"\x13ListConfigsResponse\x129\n" +
+	"\aconfigs\x18\x01 \x03(\v2\x1f.config.v1beta1.ConfigReferenceR\aconfigs\x12M\n" +
+	"\bmetadata\x18\x02 \x03(\v21.config.v1beta1.ListConfigsResponse.MetadataEntryR\bmetadata\x1a[\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x124\n" +
+	"\x05value\x18\x02 \x01(\v2\x1e.config.v1beta1.ConfigMetadataR\x05value:\x028\x01\"\xa8\x01\n" +
+	"\x13AssignConfigRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
+	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x124\n" +
+	"\x06source\x18\x03 \x01(\x0e2\x1c.config.v1beta1.ConfigSourceR\x06source\x12#\n" +
+	"\rauto_rollback\x18\x04 \x01(\bR\fautoRollback\"f\n" +
+	"\x14AssignConfigResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
+	"\bwarnings\x18\x03 \x03(\tR\bwarnings\"2\n" +
+	"\x15GetAgentConfigRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"\xa8\x01\n" +
+	"\x16GetAgentConfigResponse\x12\x1b\n" +
+	"\tconfig_id\x18\x01 \x01(\tR\bconfigId\x124\n" +
+	"\x06source\x18\x02 \x01(\x0e2\x1c.config.v1beta1.ConfigSourceR\x06source\x12;\n" +
+	"\vassigned_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\"2\n" +
+	"\x15UnassignConfigRequest\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\"2\n" +
+	"\x16UnassignConfigResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"N\n" +
+	"\x1cListConfigAssignmentsRequest\x12 \n" +
+	"\tconfig_id\x18\x01 \x01(\tH\x00R\bconfigId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_config_id\"c\n" +
+	"\x1dListConfigAssignmentsResponse\x12B\n" +
+	"\vassignments\x18\x01 \x03(\v2 .config.v1beta1.ConfigAssignmentR\vassignments\"\xd4\x02\n" +
+	"\x10ConfigAssignment\x12\x19\n" +
+	"\bagent_id\x18\x01 \x01(\tR\aagentId\x12\x1b\n" +
+	"\tconfig_id\x18\x02 \x01(\tR\bconfigId\x124\n" +
+	"\x06source\x18\x03 \x01(\x0e2\x1c.config.v1beta1.ConfigSourceR\x06source\x12;\n" +
+	"\vassigned_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"assignedAt\x12?\n" +
+	"\x06status\x18\x05 \x01(\x0e2'.config.v1beta1.ConfigApplicationStatusR\x06status\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x12/\n" +
+	"\x05audit\x18\a \x01(\v2\x19.config.v1beta1.AuditInfoR\x05audit*\x99\x01\n" +
+	"\fConfigSource\x12\x1d\n" +
+	"\x19CONFIG_SOURCE_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15CONFIG_SOURCE_DEFAULT\x10\x01\x12\x1b\n" +
+	"\x17CONFIG_SOURCE_BOOTSTRAP\x10\x02\x12\x18\n" +
+	"\x14CONFIG_SOURCE_MANUAL\x10\x03\x12\x18\n" +
+	"\x14CONFIG_SOURCE_POLICY\x10\x04*\xe3\x01\n" +
+	"\x17ConfigApplicationStatus\x12)\n" +
+	"%CONFIG_APPLICATION_STATUS_UNSPECIFIED\x10\x00\x12%\n" +
+	"!CONFIG_APPLICATION_STATUS_PENDING\x10\x01\x12%\n" +
+	"!CONFIG_APPLICATION_STATUS_APPLIED\x10\x02\x12$\n" +
+	" CONFIG_APPLICATION_STATUS_FAILED\x10\x03\x12)\n" +
+	"%CONFIG_APPLICATION_STATUS_UNSUPPORTED\x10\x042\xd0\x05\n" +
+	"\rConfigService\x12E\n" +
+	"\tPutConfig\x12 .config.v1beta1.PutConfigRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
+	"\tGetConfig\x12\x1f.config.v1beta1.ConfigReference\x1a\x16.config.v1beta1.Config\x12G\n" +
+	"\fDeleteConfig\x12\x1f.config.v1beta1.ConfigReference\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\vListConfigs\x12\".config.v1beta1.ListConfigsRequest\x1a#.config.v1beta1.ListConfigsResponse\x12Y\n" +
+	"\fAssignConfig\x12#.config.v1beta1.AssignConfigRequest\x1a$.config.v1beta1.AssignConfigResponse\x12_\n" +
+	"\x0eGetAgentConfig\x12%.config.v1beta1.GetAgentConfigRequest\x1a&.config.v1beta1.GetAgentConfigResponse\x12_\n" +
+	"\x0eUnassignConfig\x12%.config.v1beta1.UnassignConfigRequest\x1a&.config.v1beta1.UnassignConfigResponse\x12t\n" +
+	"\x15ListConfigAssignments\x12,.config.v1beta1.ListConfigAssignmentsRequest\x1a-.config.v1beta1.ListConfigAssignmentsResponseB7Z5github.com/otelfleet/otelfleet/pkg/api/config/v1beta1b\x06proto3"
+
+var (
+	file_pkg_api_config_v1beta1_config_proto_rawDescOnce sync.Once
+	file_pkg_api_config_v1beta1_config_proto_rawDescData []byte
+)
+
+func file_pkg_api_config_v1beta1_config_proto_rawDescGZIP() []byte {
+	file_pkg_api_config_v1beta1_config_proto_rawDescOnce.Do(func() {
+		file_pkg_api_config_v1beta1_config_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_api_config_v1beta1_config_proto_rawDesc), len(file_pkg_api_config_v1beta1_config_proto_rawDesc)))
+	})
+	return file_pkg_api_config_v1beta1_config_proto_rawDescData
+}
+
+var file_pkg_api_config_v1beta1_config_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_pkg_api_config_v1beta1_config_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_pkg_api_config_v1beta1_config_proto_goTypes = []any{
+	(ConfigSource)(0),                     // 0: config.v1beta1.ConfigSource
+	(ConfigApplicationStatus)(0),          // 1: config.v1beta1.ConfigApplicationStatus
+	(*PutConfigRequest)(nil),              // 2: config.v1beta1.PutConfigRequest
+	(*ConfigReference)(nil),               // 3: config.v1beta1.ConfigReference
+	(*Config)(nil),                        // 4: config.v1beta1.Config
+	(*ConfigMetadata)(nil),                // 5: config.v1beta1.ConfigMetadata
+	(*AuditInfo)(nil),                     // 6: config.v1beta1.AuditInfo
+	(*ListConfigsRequest)(nil),            // 7: config.v1beta1.ListConfigsRequest
+	(*ListConfigsResponse)(nil),           // 8: config.v1beta1.ListConfigsResponse
+	(*AssignConfigRequest)(nil),           // 9: config.v1beta1.AssignConfigRequest
+	(*AssignConfigResponse)(nil),          // 10: config.v1beta1.AssignConfigResponse
+	(*GetAgentConfigRequest)(nil),         // 11: config.v1beta1.GetAgentConfigRequest
+	(*GetAgentConfigResponse)(nil),        // 12: config.v1beta1.GetAgentConfigResponse
+	(*UnassignConfigRequest)(nil),         // 13: config.v1beta1.UnassignConfigRequest
+	(*UnassignConfigResponse)(nil),        // 14: config.v1beta1.UnassignConfigResponse
+	(*ListConfigAssignmentsRequest)(nil),  // 15: config.v1beta1.ListConfigAssignmentsRequest
+	(*ListConfigAssignmentsResponse)(nil), // 16: config.v1beta1.ListConfigAssignmentsResponse
+	(*ConfigAssignment)(nil),              // 17: config.v1beta1.ConfigAssignment
+	nil,                                   // 18: config.v1beta1.ConfigMetadata.AnnotationsEntry
+	nil,                                   // 19: config.v1beta1.ListConfigsResponse.MetadataEntry
+	(*timestamppb.Timestamp)(nil),         // 20: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                 // 21: google.protobuf.Empty
+}
+var file_pkg_api_config_v1beta1_config_proto_depIdxs = []int32{
+	3,  // 0: config.v1beta1.PutConfigRequest.ref:type_name -> config.v1beta1.ConfigReference
+	4,  // 1: config.v1beta1.PutConfigRequest.config:type_name -> config.v1beta1.Config
+	5,  // 2: config.v1beta1.Config.metadata:type_name -> config.v1beta1.ConfigMetadata
+	6,  // 3: config.v1beta1.ConfigMetadata.audit:type_name -> config.v1beta1.AuditInfo
+	18, // 4: config.v1beta1.ConfigMetadata.annotations:type_name -> config.v1beta1.ConfigMetadata.AnnotationsEntry
+	20, // 5: config.v1beta1.AuditInfo.created_at:type_name -> google.protobuf.Timestamp
+	20, // 6: config.v1beta1.AuditInfo.modified_at:type_name -> google.protobuf.Timestamp
+	3,  // 7: config.v1beta1.ListConfigsResponse.configs:type_name -> config.v1beta1.ConfigReference
+	19, // 8: config.v1beta1.ListConfigsResponse.metadata:type_name -> config.v1beta1.ListConfigsResponse.MetadataEntry
+	0,  // 9: config.v1beta1.AssignConfigRequest.source:type_name -> config.v1beta1.ConfigSource
+	0,  // 10: config.v1beta1.GetAgentConfigResponse.source:type_name -> config.v1beta1.ConfigSource
+	20, // 11: config.v1beta1.GetAgentConfigResponse.assigned_at:type_name -> google.protobuf.Timestamp
+	17, // 12: config.v1beta1.ListConfigAssignmentsResponse.assignments:type_name -> config.v1beta1.ConfigAssignment
+	0,  // 13: config.v1beta1.ConfigAssignment.source:type_name -> config.v1beta1.ConfigSource
+	20, // 14: config.v1beta1.ConfigAssignment.assigned_at:type_name -> google.protobuf.Timestamp
+	1,  // 15: config.v1beta1.ConfigAssignment.status:type_name -> config.v1beta1.ConfigApplicationStatus
+	6,  // 16: config.v1beta1.ConfigAssignment.audit:type_name -> config.v1beta1.AuditInfo
+	5,  // 17: config.v1beta1.ListConfigsResponse.MetadataEntry.value:type_name -> config.v1beta1.ConfigMetadata
+	2,  // 18: config.v1beta1.ConfigService.PutConfig:input_type -> config.v1beta1.PutConfigRequest
+	3,  // 19: config.v1beta1.ConfigService.GetConfig:input_type -> config.v1beta1.ConfigReference
+	3,  // 20: config.v1beta1.ConfigService.DeleteConfig:input_type -> config.v1beta1.ConfigReference
+	7,  // 21: config.v1beta1.ConfigService.ListConfigs:input_type -> config.v1beta1.ListConfigsRequest
+	9,  // 22: config.v1beta1.ConfigService.AssignConfig:input_type -> config.v1beta1.AssignConfigRequest
+	11, // 23: config.v1beta1.ConfigService.GetAgentConfig:input_type -> config.v1beta1.GetAgentConfigRequest
+	13, // 24: config.v1beta1.ConfigService.UnassignConfig:input_type -> config.v1beta1.UnassignConfigRequest
+	15, // 25: config.v1beta1.ConfigService.ListConfigAssignments:input_type -> config.v1beta1.ListConfigAssignmentsRequest
+	21, // 26: config.v1beta1.ConfigService.PutConfig:output_type -> google.protobuf.Empty
+	4,  // 27: config.v1beta1.ConfigService.GetConfig:output_type -> config.v1beta1.Config
+	21, // 28: config.v1beta1.ConfigService.DeleteConfig:output_type -> google.protobuf.Empty
+	8,  // 29: config.v1beta1.ConfigService.ListConfigs:output_type -> config.v1beta1.ListConfigsResponse
+	10, // 30: config.v1beta1.ConfigService.AssignConfig:output_type -> config.v1beta1.AssignConfigResponse
+	12, // 31: config.v1beta1.ConfigService.GetAgentConfig:output_type -> config.v1beta1.GetAgentConfigResponse
+	14, // 32: config.v1beta1.ConfigService.UnassignConfig:output_type -> config.v1beta1.UnassignConfigResponse
+	16, // 33: config.v1beta1.ConfigService.ListConfigAssignments:output_type -> config.v1beta1.ListConfigAssignmentsResponse
+	26, // [26:34] is the sub-list for method output_type
+	18, // [18:26] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_config_v1beta1_config_proto_init() }
+func file_pkg_api_config_v1beta1_config_proto_init() {
+	if File_pkg_api_config_v1beta1_config_proto != nil {
+		return
+	}
+	file_pkg_api_config_v1beta1_config_proto_msgTypes[13].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_config_v1beta1_config_proto_rawDesc), len(file_pkg_api_config_v1beta1_config_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_config_v1beta1_config_proto_goTypes,
+		DependencyIndexes: file_pkg_api_config_v1beta1_config_proto_depIdxs,
+		EnumInfos:         file_pkg_api_config_v1beta1_config_proto_enumTypes,
+		MessageInfos:      file_pkg_api_config_v1beta1_config_proto_msgTypes,
+	}.Build()
+	File_pkg_api_config_v1beta1_config_proto = out.File
+	file_pkg_api_config_v1beta1_config_proto_goTypes = nil
+	file_pkg_api_config_v1beta1_config_proto_depIdxs = nil
+}
@@ -0,0 +1,552 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: pkg/api/jobs/v1alpha1/jobs.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type JobState int32
+
+const (
+	JobState_JOB_STATE_UNSPECIFIED JobState = 0
+	// waiting for a worker, either for the first time or to be retried
+	JobState_JOB_STATE_PENDING   JobState = 1
+	JobState_JOB_STATE_RUNNING   JobState = 2
+	JobState_JOB_STATE_SUCCEEDED JobState = 3
+	// failed with a permanent error, or on its last attempt
+	JobState_JOB_STATE_FAILED    JobState = 4
+	JobState_JOB_STATE_CANCELLED JobState = 5
+)
+
+// Enum value maps for JobState.
+var (
+	JobState_name = map[int32]string{
+		0: "JOB_STATE_UNSPECIFIED",
+		1: "JOB_STATE_PENDING",
+		2: "JOB_STATE_RUNNING",
+		3: "JOB_STATE_SUCCEEDED",
+		4: "JOB_STATE_FAILED",
+		5: "JOB_STATE_CANCELLED",
+	}
+	JobState_value = map[string]int32{
+		"JOB_STATE_UNSPECIFIED": 0,
+		"JOB_STATE_PENDING":     1,
+		"JOB_STATE_RUNNING":     2,
+		"JOB_STATE_SUCCEEDED":   3,
+		"JOB_STATE_FAILED":      4,
+		"JOB_STATE_CANCELLED":   5,
+	}
+)
+
+func (x JobState) Enum() *JobState {
+	p := new(JobState)
+	*p = x
+	return p
+}
+
+func (x JobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_jobs_v1alpha1_jobs_proto_enumTypes[0].Descriptor()
+}
+
+func (JobState) Type() protoreflect.EnumType {
+	return &file_pkg_api_jobs_v1alpha1_jobs_proto_enumTypes[0]
+}
+
+func (x JobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobState.Descriptor instead.
+func (JobState) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_jobs_v1alpha1_jobs_proto_rawDescGZIP(), []int{0}
+}
+
+// Job is an operation executed in the background by the server, persisted so that it
+// survives restarts
+type Job struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// dot separated job type, e.g. "deployment.rollout"
+	Type  string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	State JobState `protobuf:"varint,3,opt,name=state,proto3,enum=jobs.v1alpha1.JobState" json:"state,omitempty"`
+	// input of the job, specific to its type
+	Payload *anypb.Any `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	// output of the job once it succeeded, specific to its type
+	Result *anypb.Any `protobuf:"bytes,5,opt,name=result,proto3" json:"result,omitempty"`
+	// number of times the job was started, including the current run
+	Attempts    int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	MaxAttempts int32                  `protobuf:"varint,7,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	LastError   string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	// when a pending job may next run, set for retries
+	NextRunAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"`
+	// principal that enqueued the job, the job runs on its behalf
+	Principal     string   `protobuf:"bytes,13,opt,name=principal,proto3" json:"principal,omitempty"`
+	Roles         []string `protobuf:"bytes,14,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_pkg_api_jobs_v1alpha1_jobs_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_jobs_v1alpha1_jobs_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_pkg_api_jobs_v1alpha1_jobs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Job) GetState() JobState {
+	if x != nil {
+		return x.State
+	}
+	return JobState_JOB_STATE_UNSPECIFIED
+}
+
+func (x *Job) GetPayload() *anypb.Any {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Job) GetResult() *anypb.Any {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *Job) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Job) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *Job) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Job) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Job) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Job) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Job) GetNextRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return nil
+}
+
+func (x *Job) GetPrincipal() string {
+	if x != nil {
+		return x.Principal
+	}
+	return ""
+}
+
+func (x *Job) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type GetJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobRequest) Reset() {
+	*x = GetJobRequest{}
+	mi := &file_pkg_api_jobs_v1alpha1_jobs_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobRequest) ProtoMessage() {}
+
+func (x *GetJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_jobs_v1alpha1_jobs_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobRequest.ProtoReflect.Descriptor instead.
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_jobs_v1alpha1_jobs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListJobsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Types  []string               `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	States []JobState             `protobuf:"varint,2,rep,packed,name=states,proto3,enum=jobs.v1alpha1.JobState" json:"states,omitempty"`
+	// maximum number of jobs to return, 0 for the server default
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsRequest) Reset() {
+	*x = ListJobsRequest{}
+	mi := &file_pkg_api_jobs_v1alpha1_jobs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsRequest) ProtoMessage() {}
+
+func (x *ListJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_jobs_v1alpha1_jobs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_jobs_v1alpha1_jobs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListJobsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *ListJobsRequest) GetStates() []JobState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ListJobsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*Job                 `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJobsResponse) Reset() {
+	*x = ListJobsResponse{}
+	mi := &file_pkg_api_jobs_v1alpha1_jobs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJobsResponse) ProtoMessage() {}
+
+func (x *ListJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_jobs_v1alpha1_jobs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_jobs_v1alpha1_jobs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListJobsResponse) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type CancelJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelJobRequest) Reset() {
+	*x = CancelJobRequest{}
+	mi := &file_pkg_api_jobs_v1alpha1_jobs_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJobRequest) ProtoMessage() {}
+
+func (x *CancelJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_jobs_v1alpha1_jobs_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_jobs_v1alpha1_jobs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CancelJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+var File_pkg_api_jobs_v1alpha1_jobs_proto protoreflect.FileDescriptor
+
+const file_pkg_api_jobs_v1alpha1_jobs_proto_rawDesc = "" +
+	"\n" +
+	" pkg/api/jobs/v1alpha1/jobs.proto\x12\rjobs.v1alpha1\x1a\x19google/protobuf/any.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb9\x04\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12-\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x17.jobs.v1alpha1.JobStateR\x05state\x12.\n" +
+	"\apayload\x18\x04 \x01(\v2\x14.google.protobuf.AnyR\apayload\x12,\n" +
+	"\x06result\x18\x05 \x01(\v2\x14.google.protobuf.AnyR\x06result\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12!\n" +
+	"\fmax_attempts\x18\a \x01(\x05R\vmaxAttempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12:\n" +
+	"\vnext_run_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tnextRunAt\x12\x1c\n" +
+	"\tprincipal\x18\r \x01(\tR\tprincipal\x12\x14\n" +
+	"\x05roles\x18\x0e \x03(\tR\x05roles\"\x1f\n" +
+	"\rGetJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"n\n" +
+	"\x0fListJobsRequest\x12\x14\n" +
+	"\x05types\x18\x01 \x03(\tR\x05types\x12/\n" +
+	"\x06states\x18\x02 \x03(\x0e2\x17.jobs.v1alpha1.JobStateR\x06states\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\":\n" +
+	"\x10ListJobsResponse\x12&\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x12.jobs.v1alpha1.JobR\x04jobs\"\"\n" +
+	"\x10CancelJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id*\x9b\x01\n" +
+	"\bJobState\x12\x19\n" +
+	"\x15JOB_STATE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11JOB_STATE_PENDING\x10\x01\x12\x15\n" +
+	"\x11JOB_STATE_RUNNING\x10\x02\x12\x17\n" +
+	"\x13JOB_STATE_SUCCEEDED\x10\x03\x12\x14\n" +
+	"\x10JOB_STATE_FAILED\x10\x04\x12\x17\n" +
+	"\x13JOB_STATE_CANCELLED\x10\x052\xd7\x01\n" +
+	"\n" +
+	"JobService\x12:\n" +
+	"\x06GetJob\x12\x1c.jobs.v1alpha1.GetJobRequest\x1a\x12.jobs.v1alpha1.Job\x12K\n" +
+	"\bListJobs\x12\x1e.jobs.v1alpha1.ListJobsRequest\x1a\x1f.jobs.v1alpha1.ListJobsResponse\x12@\n" +
+	"\tCancelJob\x12\x1f.jobs.v1alpha1.CancelJobRequest\x1a\x12.jobs.v1alpha1.JobB6Z4github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1b\x06proto3"
+
+var (
+	file_pkg_api_jobs_v1alpha1_jobs_proto_rawDescOnce sync.Once
+	file_pkg_api_jobs_v1alpha1_jobs_proto_rawDescData []byte
+)
+
+func file_pkg_api_jobs_v1alpha1_jobs_proto_rawDescGZIP() []byte {
+	file_pkg_api_jobs_v1alpha1_jobs_proto_rawDescOnce.Do(func() {
+		file_pkg_api_jobs_v1alpha1_jobs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_api_jobs_v1alpha1_jobs_proto_rawDesc), len(file_pkg_api_jobs_v1alpha1_jobs_proto_rawDesc)))
+	})
+	return file_pkg_api_jobs_v1alpha1_jobs_proto_rawDescData
+}
+
+var file_pkg_api_jobs_v1alpha1_jobs_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_api_jobs_v1alpha1_jobs_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_pkg_api_jobs_v1alpha1_jobs_proto_goTypes = []any{
+	(JobState)(0),                 // 0: jobs.v1alpha1.JobState
+	(*Job)(nil),                   // 1: jobs.v1alpha1.Job
+	(*GetJobRequest)(nil),         // 2: jobs.v1alpha1.GetJobRequest
+	(*ListJobsRequest)(nil),       // 3: jobs.v1alpha1.ListJobsRequest
+	(*ListJobsResponse)(nil),      // 4: jobs.v1alpha1.ListJobsResponse
+	(*CancelJobRequest)(nil),      // 5: jobs.v1alpha1.CancelJobRequest
+	(*anypb.Any)(nil),             // 6: google.protobuf.Any
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_pkg_api_jobs_v1alpha1_jobs_proto_depIdxs = []int32{
+	0,  // 0: jobs.v1alpha1.Job.state:type_name -> jobs.v1alpha1.JobState
+	6,  // 1: jobs.v1alpha1.Job.payload:type_name -> google.protobuf.Any
+	6,  // 2: jobs.v1alpha1.Job.result:type_name -> google.protobuf.Any
+	7,  // 3: jobs.v1alpha1.Job.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 4: jobs.v1alpha1.Job.started_at:type_name -> google.protobuf.Timestamp
+	7,  // 5: jobs.v1alpha1.Job.completed_at:type_name -> google.protobuf.Timestamp
+	7,  // 6: jobs.v1alpha1.Job.next_run_at:type_name -> google.protobuf.Timestamp
+	0,  // 7: jobs.v1alpha1.ListJobsRequest.states:type_name -> jobs.v1alpha1.JobState
+	1,  // 8: jobs.v1alpha1.ListJobsResponse.jobs:type_name -> jobs.v1alpha1.Job
+	2,  // 9: jobs.v1alpha1.JobService.GetJob:input_type -> jobs.v1alpha1.GetJobRequest
+	3,  // 10: jobs.v1alpha1.JobService.ListJobs:input_type -> jobs.v1alpha1.ListJobsRequest
+	5,  // 11: jobs.v1alpha1.JobService.CancelJob:input_type -> jobs.v1alpha1.CancelJobRequest
+	1,  // 12: jobs.v1alpha1.JobService.GetJob:output_type -> jobs.v1alpha1.Job
+	4,  // 13: jobs.v1alpha1.JobService.ListJobs:output_type -> jobs.v1alpha1.ListJobsResponse
+	1,  // 14: jobs.v1alpha1.JobService.CancelJob:output_type -> jobs.v1alpha1.Job
+	12, // [12:15] is the sub-list for method output_type
+	9,  // [9:12] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_jobs_v1alpha1_jobs_proto_init() }
+func file_pkg_api_jobs_v1alpha1_jobs_proto_init() {
+	if File_pkg_api_jobs_v1alpha1_jobs_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_jobs_v1alpha1_jobs_proto_rawDesc), len(file_pkg_api_jobs_v1alpha1_jobs_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_jobs_v1alpha1_jobs_proto_goTypes,
+		DependencyIndexes: file_pkg_api_jobs_v1alpha1_jobs_proto_depIdxs,
+		EnumInfos:         file_pkg_api_jobs_v1alpha1_jobs_proto_enumTypes,
+		MessageInfos:      file_pkg_api_jobs_v1alpha1_jobs_proto_msgTypes,
+	}.Build()
+	File_pkg_api_jobs_v1alpha1_jobs_proto = out.File
+	file_pkg_api_jobs_v1alpha1_jobs_proto_goTypes = nil
+	file_pkg_api_jobs_v1alpha1_jobs_proto_depIdxs = nil
+}
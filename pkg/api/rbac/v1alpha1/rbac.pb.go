@@ -0,0 +1,836 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: pkg/api/rbac/v1alpha1/rbac.proto
+
+package v1alpha1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetCallerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCallerRequest) Reset() {
+	*x = GetCallerRequest{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCallerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCallerRequest) ProtoMessage() {}
+
+func (x *GetCallerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCallerRequest.ProtoReflect.Descriptor instead.
+func (*GetCallerRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{0}
+}
+
+type Caller struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// e.g. the subject claim of an OIDC token, or apikey:<id> for API keys
+	Subject       string   `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Roles         []string `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Caller) Reset() {
+	*x = Caller{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Caller) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Caller) ProtoMessage() {}
+
+func (x *Caller) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Caller.ProtoReflect.Descriptor instead.
+func (*Caller) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Caller) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Caller) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+// APIKey authenticates callers presenting its token as a bearer token
+type APIKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// subject of the callers presenting the key, its roles are bound to it
+	Subject string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	// SHA-256 of the secret of the token, never returned
+	SecretHash []byte                 `protobuf:"bytes,4,opt,name=secret_hash,json=secretHash,proto3" json:"secret_hash,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// unset for keys that don't expire
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// subject of the caller that created the key
+	CreatedBy     string `protobuf:"bytes,7,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *APIKey) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *APIKey) GetSecretHash() []byte {
+	if x != nil {
+		return x.SecretHash
+	}
+	return nil
+}
+
+func (x *APIKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+// RoleBinding grants roles to the callers authenticated as a subject
+type RoleBinding struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Subject   string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Roles     []string               `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// subject of the caller that last changed the binding
+	UpdatedBy     string `protobuf:"bytes,4,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoleBinding) Reset() {
+	*x = RoleBinding{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleBinding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleBinding) ProtoMessage() {}
+
+func (x *RoleBinding) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleBinding.ProtoReflect.Descriptor instead.
+func (*RoleBinding) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RoleBinding) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *RoleBinding) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *RoleBinding) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *RoleBinding) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+type CreateAPIKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Roles []string               `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	// the key doesn't expire if unset
+	Ttl           *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+type CreateAPIKeyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Key   *APIKey                `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// presented as "Authorization: Bearer <token>"
+	Token         string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyResponse) Reset() {
+	*x = CreateAPIKeyResponse{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyResponse) ProtoMessage() {}
+
+func (x *CreateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateAPIKeyResponse) GetKey() *APIKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ListAPIKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{6}
+}
+
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Keys          []*APIKey              `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListAPIKeysResponse) GetKeys() []*APIKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type DeleteAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAPIKeyRequest) Reset() {
+	*x = DeleteAPIKeyRequest{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAPIKeyRequest) ProtoMessage() {}
+
+func (x *DeleteAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteAPIKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PutRoleBindingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Roles         []string               `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutRoleBindingRequest) Reset() {
+	*x = PutRoleBindingRequest{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutRoleBindingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutRoleBindingRequest) ProtoMessage() {}
+
+func (x *PutRoleBindingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutRoleBindingRequest.ProtoReflect.Descriptor instead.
+func (*PutRoleBindingRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PutRoleBindingRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *PutRoleBindingRequest) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+type ListRoleBindingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoleBindingsRequest) Reset() {
+	*x = ListRoleBindingsRequest{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoleBindingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoleBindingsRequest) ProtoMessage() {}
+
+func (x *ListRoleBindingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoleBindingsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoleBindingsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{10}
+}
+
+type ListRoleBindingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bindings      []*RoleBinding         `protobuf:"bytes,1,rep,name=bindings,proto3" json:"bindings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoleBindingsResponse) Reset() {
+	*x = ListRoleBindingsResponse{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoleBindingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoleBindingsResponse) ProtoMessage() {}
+
+func (x *ListRoleBindingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoleBindingsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoleBindingsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListRoleBindingsResponse) GetBindings() []*RoleBinding {
+	if x != nil {
+		return x.Bindings
+	}
+	return nil
+}
+
+type DeleteRoleBindingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoleBindingRequest) Reset() {
+	*x = DeleteRoleBindingRequest{}
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoleBindingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoleBindingRequest) ProtoMessage() {}
+
+func (x *DeleteRoleBindingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoleBindingRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRoleBindingRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteRoleBindingRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+var File_pkg_api_rbac_v1alpha1_rbac_proto protoreflect.FileDescriptor
+
+const file_pkg_api_rbac_v1alpha1_rbac_proto_rawDesc = "" +
+	"\n" +
+	" pkg/api/rbac/v1alpha1/rbac.proto\x12\rrbac.v1alpha1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x12\n" +
+	"\x10GetCallerRequest\"8\n" +
+	"\x06Caller\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x14\n" +
+	"\x05roles\x18\x02 \x03(\tR\x05roles\"\xfc\x01\n" +
+	"\x06APIKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x1f\n" +
+	"\vsecret_hash\x18\x04 \x01(\fR\n" +
+	"secretHash\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\a \x01(\tR\tcreatedBy\"\x97\x01\n" +
+	"\vRoleBinding\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x14\n" +
+	"\x05roles\x18\x02 \x03(\tR\x05roles\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x04 \x01(\tR\tupdatedBy\"l\n" +
+	"\x13CreateAPIKeyRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05roles\x18\x02 \x03(\tR\x05roles\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\"U\n" +
+	"\x14CreateAPIKeyResponse\x12'\n" +
+	"\x03key\x18\x01 \x01(\v2\x15.rbac.v1alpha1.APIKeyR\x03key\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"\x14\n" +
+	"\x12ListAPIKeysRequest\"@\n" +
+	"\x13ListAPIKeysResponse\x12)\n" +
+	"\x04keys\x18\x01 \x03(\v2\x15.rbac.v1alpha1.APIKeyR\x04keys\"%\n" +
+	"\x13DeleteAPIKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"G\n" +
+	"\x15PutRoleBindingRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x14\n" +
+	"\x05roles\x18\x02 \x03(\tR\x05roles\"\x19\n" +
+	"\x17ListRoleBindingsRequest\"R\n" +
+	"\x18ListRoleBindingsResponse\x126\n" +
+	"\bbindings\x18\x01 \x03(\v2\x1a.rbac.v1alpha1.RoleBindingR\bbindings\"4\n" +
+	"\x18DeleteRoleBindingRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject2\xdc\x04\n" +
+	"\vRBACService\x12C\n" +
+	"\tGetCaller\x12\x1f.rbac.v1alpha1.GetCallerRequest\x1a\x15.rbac.v1alpha1.Caller\x12W\n" +
+	"\fCreateAPIKey\x12\".rbac.v1alpha1.CreateAPIKeyRequest\x1a#.rbac.v1alpha1.CreateAPIKeyResponse\x12T\n" +
+	"\vListAPIKeys\x12!.rbac.v1alpha1.ListAPIKeysRequest\x1a\".rbac.v1alpha1.ListAPIKeysResponse\x12J\n" +
+	"\fDeleteAPIKey\x12\".rbac.v1alpha1.DeleteAPIKeyRequest\x1a\x16.google.protobuf.Empty\x12R\n" +
+	"\x0ePutRoleBinding\x12$.rbac.v1alpha1.PutRoleBindingRequest\x1a\x1a.rbac.v1alpha1.RoleBinding\x12c\n" +
+	"\x10ListRoleBindings\x12&.rbac.v1alpha1.ListRoleBindingsRequest\x1a'.rbac.v1alpha1.ListRoleBindingsResponse\x12T\n" +
+	"\x11DeleteRoleBinding\x12'.rbac.v1alpha1.DeleteRoleBindingRequest\x1a\x16.google.protobuf.EmptyB6Z4github.com/otelfleet/otelfleet/pkg/api/rbac/v1alpha1b\x06proto3"
+
+var (
+	file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescOnce sync.Once
+	file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescData []byte
+)
+
+func file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescGZIP() []byte {
+	file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescOnce.Do(func() {
+		file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_api_rbac_v1alpha1_rbac_proto_rawDesc), len(file_pkg_api_rbac_v1alpha1_rbac_proto_rawDesc)))
+	})
+	return file_pkg_api_rbac_v1alpha1_rbac_proto_rawDescData
+}
+
+var file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_pkg_api_rbac_v1alpha1_rbac_proto_goTypes = []any{
+	(*GetCallerRequest)(nil),         // 0: rbac.v1alpha1.GetCallerRequest
+	(*Caller)(nil),                   // 1: rbac.v1alpha1.Caller
+	(*APIKey)(nil),                   // 2: rbac.v1alpha1.APIKey
+	(*RoleBinding)(nil),              // 3: rbac.v1alpha1.RoleBinding
+	(*CreateAPIKeyRequest)(nil),      // 4: rbac.v1alpha1.CreateAPIKeyRequest
+	(*CreateAPIKeyResponse)(nil),     // 5: rbac.v1alpha1.CreateAPIKeyResponse
+	(*ListAPIKeysRequest)(nil),       // 6: rbac.v1alpha1.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil),      // 7: rbac.v1alpha1.ListAPIKeysResponse
+	(*DeleteAPIKeyRequest)(nil),      // 8: rbac.v1alpha1.DeleteAPIKeyRequest
+	(*PutRoleBindingRequest)(nil),    // 9: rbac.v1alpha1.PutRoleBindingRequest
+	(*ListRoleBindingsRequest)(nil),  // 10: rbac.v1alpha1.ListRoleBindingsRequest
+	(*ListRoleBindingsResponse)(nil), // 11: rbac.v1alpha1.ListRoleBindingsResponse
+	(*DeleteRoleBindingRequest)(nil), // 12: rbac.v1alpha1.DeleteRoleBindingRequest
+	(*timestamppb.Timestamp)(nil),    // 13: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),      // 14: google.protobuf.Duration
+	(*emptypb.Empty)(nil),            // 15: google.protobuf.Empty
+}
+var file_pkg_api_rbac_v1alpha1_rbac_proto_depIdxs = []int32{
+	13, // 0: rbac.v1alpha1.APIKey.created_at:type_name -> google.protobuf.Timestamp
+	13, // 1: rbac.v1alpha1.APIKey.expires_at:type_name -> google.protobuf.Timestamp
+	13, // 2: rbac.v1alpha1.RoleBinding.updated_at:type_name -> google.protobuf.Timestamp
+	14, // 3: rbac.v1alpha1.CreateAPIKeyRequest.ttl:type_name -> google.protobuf.Duration
+	2,  // 4: rbac.v1alpha1.CreateAPIKeyResponse.key:type_name -> rbac.v1alpha1.APIKey
+	2,  // 5: rbac.v1alpha1.ListAPIKeysResponse.keys:type_name -> rbac.v1alpha1.APIKey
+	3,  // 6: rbac.v1alpha1.ListRoleBindingsResponse.bindings:type_name -> rbac.v1alpha1.RoleBinding
+	0,  // 7: rbac.v1alpha1.RBACService.GetCaller:input_type -> rbac.v1alpha1.GetCallerRequest
+	4,  // 8: rbac.v1alpha1.RBACService.CreateAPIKey:input_type -> rbac.v1alpha1.CreateAPIKeyRequest
+	6,  // 9: rbac.v1alpha1.RBACService.ListAPIKeys:input_type -> rbac.v1alpha1.ListAPIKeysRequest
+	8,  // 10: rbac.v1alpha1.RBACService.DeleteAPIKey:input_type -> rbac.v1alpha1.DeleteAPIKeyRequest
+	9,  // 11: rbac.v1alpha1.RBACService.PutRoleBinding:input_type -> rbac.v1alpha1.PutRoleBindingRequest
+	10, // 12: rbac.v1alpha1.RBACService.ListRoleBindings:input_type -> rbac.v1alpha1.ListRoleBindingsRequest
+	12, // 13: rbac.v1alpha1.RBACService.DeleteRoleBinding:input_type -> rbac.v1alpha1.DeleteRoleBindingRequest
+	1,  // 14: rbac.v1alpha1.RBACService.GetCaller:output_type -> rbac.v1alpha1.Caller
+	5,  // 15: rbac.v1alpha1.RBACService.CreateAPIKey:output_type -> rbac.v1alpha1.CreateAPIKeyResponse
+	7,  // 16: rbac.v1alpha1.RBACService.ListAPIKeys:output_type -> rbac.v1alpha1.ListAPIKeysResponse
+	15, // 17: rbac.v1alpha1.RBACService.DeleteAPIKey:output_type -> google.protobuf.Empty
+	3,  // 18: rbac.v1alpha1.RBACService.PutRoleBinding:output_type -> rbac.v1alpha1.RoleBinding
+	11, // 19: rbac.v1alpha1.RBACService.ListRoleBindings:output_type -> rbac.v1alpha1.ListRoleBindingsResponse
+	15, // 20: rbac.v1alpha1.RBACService.DeleteRoleBinding:output_type -> google.protobuf.Empty
+	14, // [14:21] is the sub-list for method output_type
+	7,  // [7:14] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_rbac_v1alpha1_rbac_proto_init() }
+func file_pkg_api_rbac_v1alpha1_rbac_proto_init() {
+	if File_pkg_api_rbac_v1alpha1_rbac_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_rbac_v1alpha1_rbac_proto_rawDesc), len(file_pkg_api_rbac_v1alpha1_rbac_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_rbac_v1alpha1_rbac_proto_goTypes,
+		DependencyIndexes: file_pkg_api_rbac_v1alpha1_rbac_proto_depIdxs,
+		MessageInfos:      file_pkg_api_rbac_v1alpha1_rbac_proto_msgTypes,
+	}.Build()
+	File_pkg_api_rbac_v1alpha1_rbac_proto = out.File
+	file_pkg_api_rbac_v1alpha1_rbac_proto_goTypes = nil
+	file_pkg_api_rbac_v1alpha1_rbac_proto_depIdxs = nil
+}
@@ -0,0 +1,421 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: pkg/api/server/v1beta1/server.proto
+
+package v1beta1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Stability is the compatibility promise of an API version
+type Stability int32
+
+const (
+	Stability_STABILITY_UNSPECIFIED Stability = 0
+	// may change incompatibly between releases
+	Stability_STABILITY_ALPHA Stability = 1
+	// only changes compatibly, fields, values and methods are only added
+	Stability_STABILITY_BETA Stability = 2
+	// only changes compatibly, and is served until the next major version
+	Stability_STABILITY_STABLE Stability = 3
+)
+
+// Enum value maps for Stability.
+var (
+	Stability_name = map[int32]string{
+		0: "STABILITY_UNSPECIFIED",
+		1: "STABILITY_ALPHA",
+		2: "STABILITY_BETA",
+		3: "STABILITY_STABLE",
+	}
+	Stability_value = map[string]int32{
+		"STABILITY_UNSPECIFIED": 0,
+		"STABILITY_ALPHA":       1,
+		"STABILITY_BETA":        2,
+		"STABILITY_STABLE":      3,
+	}
+)
+
+func (x Stability) Enum() *Stability {
+	p := new(Stability)
+	*p = x
+	return p
+}
+
+func (x Stability) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Stability) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_server_v1beta1_server_proto_enumTypes[0].Descriptor()
+}
+
+func (Stability) Type() protoreflect.EnumType {
+	return &file_pkg_api_server_v1beta1_server_proto_enumTypes[0]
+}
+
+func (x Stability) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Stability.Descriptor instead.
+func (Stability) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_server_v1beta1_server_proto_rawDescGZIP(), []int{0}
+}
+
+type GetServerCapabilitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetServerCapabilitiesRequest) Reset() {
+	*x = GetServerCapabilitiesRequest{}
+	mi := &file_pkg_api_server_v1beta1_server_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetServerCapabilitiesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServerCapabilitiesRequest) ProtoMessage() {}
+
+func (x *GetServerCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_server_v1beta1_server_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServerCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetServerCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_server_v1beta1_server_proto_rawDescGZIP(), []int{0}
+}
+
+type ServerCapabilities struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ApiVersions []*APIVersion          `protobuf:"bytes,1,rep,name=api_versions,json=apiVersions,proto3" json:"api_versions,omitempty"`
+	// how long deprecated methods are still served once a more stable version supersedes them
+	DeprecationWindow *durationpb.Duration `protobuf:"bytes,2,opt,name=deprecation_window,json=deprecationWindow,proto3" json:"deprecation_window,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ServerCapabilities) Reset() {
+	*x = ServerCapabilities{}
+	mi := &file_pkg_api_server_v1beta1_server_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerCapabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerCapabilities) ProtoMessage() {}
+
+func (x *ServerCapabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_server_v1beta1_server_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerCapabilities.ProtoReflect.Descriptor instead.
+func (*ServerCapabilities) Descriptor() ([]byte, []int) {
+	return file_pkg_api_server_v1beta1_server_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ServerCapabilities) GetApiVersions() []*APIVersion {
+	if x != nil {
+		return x.ApiVersions
+	}
+	return nil
+}
+
+func (x *ServerCapabilities) GetDeprecationWindow() *durationpb.Duration {
+	if x != nil {
+		return x.DeprecationWindow
+	}
+	return nil
+}
+
+type APIVersion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name of the API, e.g. agents
+	Api string `protobuf:"bytes,1,opt,name=api,proto3" json:"api,omitempty"`
+	// e.g. v1beta1
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// fully qualified name of the service, e.g. agents.v1beta1.AgentService
+	Service   string    `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	Stability Stability `protobuf:"varint,4,opt,name=stability,proto3,enum=server.v1beta1.Stability" json:"stability,omitempty"`
+	// names of the methods of the service
+	Methods []string `protobuf:"bytes,5,rep,name=methods,proto3" json:"methods,omitempty"`
+	// the methods superseded by a more stable version, they are served until their sunset
+	DeprecatedMethods []*DeprecatedMethod `protobuf:"bytes,6,rep,name=deprecated_methods,json=deprecatedMethods,proto3" json:"deprecated_methods,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *APIVersion) Reset() {
+	*x = APIVersion{}
+	mi := &file_pkg_api_server_v1beta1_server_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIVersion) ProtoMessage() {}
+
+func (x *APIVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_server_v1beta1_server_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIVersion.ProtoReflect.Descriptor instead.
+func (*APIVersion) Descriptor() ([]byte, []int) {
+	return file_pkg_api_server_v1beta1_server_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *APIVersion) GetApi() string {
+	if x != nil {
+		return x.Api
+	}
+	return ""
+}
+
+func (x *APIVersion) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *APIVersion) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *APIVersion) GetStability() Stability {
+	if x != nil {
+		return x.Stability
+	}
+	return Stability_STABILITY_UNSPECIFIED
+}
+
+func (x *APIVersion) GetMethods() []string {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *APIVersion) GetDeprecatedMethods() []*DeprecatedMethod {
+	if x != nil {
+		return x.DeprecatedMethods
+	}
+	return nil
+}
+
+type DeprecatedMethod struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Method string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// procedure superseding the method, e.g. /agents.v1beta1.AgentService/ListAgents
+	Successor     string                 `protobuf:"bytes,2,opt,name=successor,proto3" json:"successor,omitempty"`
+	DeprecatedAt  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deprecated_at,json=deprecatedAt,proto3" json:"deprecated_at,omitempty"`
+	SunsetAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=sunset_at,json=sunsetAt,proto3" json:"sunset_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeprecatedMethod) Reset() {
+	*x = DeprecatedMethod{}
+	mi := &file_pkg_api_server_v1beta1_server_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeprecatedMethod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeprecatedMethod) ProtoMessage() {}
+
+func (x *DeprecatedMethod) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_server_v1beta1_server_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeprecatedMethod.ProtoReflect.Descriptor instead.
+func (*DeprecatedMethod) Descriptor() ([]byte, []int) {
+	return file_pkg_api_server_v1beta1_server_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DeprecatedMethod) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *DeprecatedMethod) GetSuccessor() string {
+	if x != nil {
+		return x.Successor
+	}
+	return ""
+}
+
+func (x *DeprecatedMethod) GetDeprecatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeprecatedAt
+	}
+	return nil
+}
+
+func (x *DeprecatedMethod) GetSunsetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SunsetAt
+	}
+	return nil
+}
+
+var File_pkg_api_server_v1beta1_server_proto protoreflect.FileDescriptor
+
+const file_pkg_api_server_v1beta1_server_proto_rawDesc = "" +
+	"\n" +
+	"#pkg/api/server/v1beta1/server.proto\x12\x0eserver.v1beta1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x1e\n" +
+	"\x1cGetServerCapabilitiesRequest\"\x9d\x01\n" +
+	"\x12ServerCapabilities\x12=\n" +
+	"\fapi_versions\x18\x01 \x03(\v2\x1a.server.v1beta1.APIVersionR\vapiVersions\x12H\n" +
+	"\x12deprecation_window\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\x11deprecationWindow\"\xf6\x01\n" +
+	"\n" +
+	"APIVersion\x12\x10\n" +
+	"\x03api\x18\x01 \x01(\tR\x03api\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x18\n" +
+	"\aservice\x18\x03 \x01(\tR\aservice\x127\n" +
+	"\tstability\x18\x04 \x01(\x0e2\x19.server.v1beta1.StabilityR\tstability\x12\x18\n" +
+	"\amethods\x18\x05 \x03(\tR\amethods\x12O\n" +
+	"\x12deprecated_methods\x18\x06 \x03(\v2 .server.v1beta1.DeprecatedMethodR\x11deprecatedMethods\"\xc2\x01\n" +
+	"\x10DeprecatedMethod\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x1c\n" +
+	"\tsuccessor\x18\x02 \x01(\tR\tsuccessor\x12?\n" +
+	"\rdeprecated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fdeprecatedAt\x127\n" +
+	"\tsunset_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bsunsetAt*e\n" +
+	"\tStability\x12\x19\n" +
+	"\x15STABILITY_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fSTABILITY_ALPHA\x10\x01\x12\x12\n" +
+	"\x0eSTABILITY_BETA\x10\x02\x12\x14\n" +
+	"\x10STABILITY_STABLE\x10\x032z\n" +
+	"\rServerService\x12i\n" +
+	"\x15GetServerCapabilities\x12,.server.v1beta1.GetServerCapabilitiesRequest\x1a\".server.v1beta1.ServerCapabilitiesB7Z5github.com/otelfleet/otelfleet/pkg/api/server/v1beta1b\x06proto3"
+
+var (
+	file_pkg_api_server_v1beta1_server_proto_rawDescOnce sync.Once
+	file_pkg_api_server_v1beta1_server_proto_rawDescData []byte
+)
+
+func file_pkg_api_server_v1beta1_server_proto_rawDescGZIP() []byte {
+	file_pkg_api_server_v1beta1_server_proto_rawDescOnce.Do(func() {
+		file_pkg_api_server_v1beta1_server_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_api_server_v1beta1_server_proto_rawDesc), len(file_pkg_api_server_v1beta1_server_proto_rawDesc)))
+	})
+	return file_pkg_api_server_v1beta1_server_proto_rawDescData
+}
+
+var file_pkg_api_server_v1beta1_server_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_api_server_v1beta1_server_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_pkg_api_server_v1beta1_server_proto_goTypes = []any{
+	(Stability)(0),                       // 0: server.v1beta1.Stability
+	(*GetServerCapabilitiesRequest)(nil), // 1: server.v1beta1.GetServerCapabilitiesRequest
+	(*ServerCapabilities)(nil),           // 2: server.v1beta1.ServerCapabilities
+	(*APIVersion)(nil),                   // 3: server.v1beta1.APIVersion
+	(*DeprecatedMethod)(nil),             // 4: server.v1beta1.DeprecatedMethod
+	(*durationpb.Duration)(nil),          // 5: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),        // 6: google.protobuf.Timestamp
+}
+var file_pkg_api_server_v1beta1_server_proto_depIdxs = []int32{
+	3, // 0: server.v1beta1.ServerCapabilities.api_versions:type_name -> server.v1beta1.APIVersion
+	5, // 1: server.v1beta1.ServerCapabilities.deprecation_window:type_name -> google.protobuf.Duration
+	0, // 2: server.v1beta1.APIVersion.stability:type_name -> server.v1beta1.Stability
+	4, // 3: server.v1beta1.APIVersion.deprecated_methods:type_name -> server.v1beta1.DeprecatedMethod
+	6, // 4: server.v1beta1.DeprecatedMethod.deprecated_at:type_name -> google.protobuf.Timestamp
+	6, // 5: server.v1beta1.DeprecatedMethod.sunset_at:type_name -> google.protobuf.Timestamp
+	1, // 6: server.v1beta1.ServerService.GetServerCapabilities:input_type -> server.v1beta1.GetServerCapabilitiesRequest
+	2, // 7: server.v1beta1.ServerService.GetServerCapabilities:output_type -> server.v1beta1.ServerCapabilities
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_server_v1beta1_server_proto_init() }
+func file_pkg_api_server_v1beta1_server_proto_init() {
+	if File_pkg_api_server_v1beta1_server_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_server_v1beta1_server_proto_rawDesc), len(file_pkg_api_server_v1beta1_server_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_server_v1beta1_server_proto_goTypes,
+		DependencyIndexes: file_pkg_api_server_v1beta1_server_proto_depIdxs,
+		EnumInfos:         file_pkg_api_server_v1beta1_server_proto_enumTypes,
+		MessageInfos:      file_pkg_api_server_v1beta1_server_proto_msgTypes,
+	}.Build()
+	File_pkg_api_server_v1beta1_server_proto = out.File
+	file_pkg_api_server_v1beta1_server_proto_goTypes = nil
+	file_pkg_api_server_v1beta1_server_proto_depIdxs = nil
+}
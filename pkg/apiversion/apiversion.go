@@ -0,0 +1,229 @@
+// Package apiversion holds the versioning policy of the APIs of the server, and serves the
+// methods promoted to a more stable version with the handlers of the version they were
+// promoted from.
+//
+// Versions are alpha, beta or stable, see serverv1beta1.Stability. Alpha versions may change
+// incompatibly between releases. Methods are promoted to a beta version once their messages
+// settled: the beta messages are a wire-compatible subset of the alpha ones, checked by
+// CheckPromotions, and the beta methods are served through the alpha handlers with Unary. The
+// promoted alpha methods are deprecated, and still served for DeprecationWindow after the
+// promotion. Calls to them are answered with Deprecation, Sunset and successor Link headers.
+package apiversion
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	adminconnect "github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1/v1alpha1connect"
+	agentsconnect "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1/v1alpha1connect"
+	agentsv1beta1connect "github.com/otelfleet/otelfleet/pkg/api/agents/v1beta1/v1beta1connect"
+	auditconnect "github.com/otelfleet/otelfleet/pkg/api/audit/v1alpha1/v1alpha1connect"
+	bootstrapconnect "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1/v1alpha1connect"
+	bootstrapv1beta1connect "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1beta1/v1beta1connect"
+	configconnect "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1/v1alpha1connect"
+	configv1beta1connect "github.com/otelfleet/otelfleet/pkg/api/config/v1beta1/v1beta1connect"
+	eventsconnect "github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1/v1alpha1connect"
+	jobsconnect "github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1/v1alpha1connect"
+	serverv1beta1 "github.com/otelfleet/otelfleet/pkg/api/server/v1beta1"
+	serverv1beta1connect "github.com/otelfleet/otelfleet/pkg/api/server/v1beta1/v1beta1connect"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DeprecationWindow is how long deprecated methods are still served once they were promoted
+const DeprecationWindow = 180 * 24 * time.Hour
+
+// Version is a version of a service of the server
+type Version struct {
+	// API is the name of the API, e.g. agents
+	API string
+	// Version is the version of the API, e.g. v1beta1
+	Version string
+	// Service is the fully qualified name of the service, e.g. agents.v1beta1.AgentService
+	Service   string
+	Stability serverv1beta1.Stability
+	// PromotedFrom is the service the methods of this version were promoted from, whose
+	// methods of the same name serve them. They are deprecated since PromotedAt.
+	PromotedFrom string
+	PromotedAt   time.Time
+}
+
+// promotedAt is when the first beta versions were introduced
+var promotedAt = time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+
+// Versions are the versions of the services served by the server
+var Versions = []Version{
+	{API: "admin", Version: "v1alpha1", Service: adminconnect.AdminServiceName, Stability: serverv1beta1.Stability_STABILITY_ALPHA},
+	{API: "agents", Version: "v1alpha1", Service: agentsconnect.AgentServiceName, Stability: serverv1beta1.Stability_STABILITY_ALPHA},
+	{API: "agents", Version: "v1alpha1", Service: agentsconnect.GatewayServiceName, Stability: serverv1beta1.Stability_STABILITY_ALPHA},
+	{
+		API: "agents", Version: "v1beta1", Service: agentsv1beta1connect.AgentServiceName, Stability: serverv1beta1.Stability_STABILITY_BETA,
+		PromotedFrom: agentsconnect.AgentServiceName, PromotedAt: promotedAt,
+	},
+	{API: "audit", Version: "v1alpha1", Service: auditconnect.AuditServiceName, Stability: serverv1beta1.Stability_STABILITY_ALPHA},
+	{API: "bootstrap", Version: "v1alpha1", Service: bootstrapconnect.TokenServiceName, Stability: serverv1beta1.Stability_STABILITY_ALPHA},
+	{API: "bootstrap", Version: "v1alpha1", Service: bootstrapconnect.BootstrapServiceName, Stability: serverv1beta1.Stability_STABILITY_ALPHA},
+	{
+		API: "bootstrap", Version: "v1beta1", Service: bootstrapv1beta1connect.TokenServiceName, Stability: serverv1beta1.Stability_STABILITY_BETA,
+		PromotedFrom: bootstrapconnect.TokenServiceName, PromotedAt: promotedAt,
+	},
+	{API: "config", Version: "v1alpha1", Service: configconnect.ConfigServiceName, Stability: serverv1beta1.Stability_STABILITY_ALPHA},
+	{API: "config", Version: "v1alpha1", Service: configconnect.PackageServiceName, Stability: serverv1beta1.Stability_STABILITY_ALPHA},
+	{
+		API: "config", Version: "v1beta1", Service: configv1beta1connect.ConfigServiceName, Stability: serverv1beta1.Stability_STABILITY_BETA,
+		PromotedFrom: configconnect.ConfigServiceName, PromotedAt: promotedAt,
+	},
+	{API: "events", Version: "v1alpha1", Service: eventsconnect.EventServiceName, Stability: serverv1beta1.Stability_STABILITY_ALPHA},
+	{API: "jobs", Version: "v1alpha1", Service: jobsconnect.JobServiceName, Stability: serverv1beta1.Stability_STABILITY_ALPHA},
+	{API: "server", Version: "v1beta1", Service: serverv1beta1connect.ServerServiceName, Stability: serverv1beta1.Stability_STABILITY_BETA},
+}
+
+// successor is the promoted method superseding a deprecated one
+type successor struct {
+	procedure    string
+	deprecatedAt time.Time
+}
+
+func (s successor) sunsetAt() time.Time {
+	return s.deprecatedAt.Add(DeprecationWindow)
+}
+
+type promotions struct {
+	// procedure of a promoted method -> procedure of the method serving it
+	predecessors map[string]string
+	// procedure of a deprecated method -> the method superseding it
+	successors map[string]successor
+}
+
+// loadPromotions indexes the promoted methods of Versions
+var loadPromotions = sync.OnceValue(func() promotions {
+	p := promotions{predecessors: map[string]string{}, successors: map[string]successor{}}
+	for _, v := range Versions {
+		if v.PromotedFrom == "" {
+			continue
+		}
+		for _, method := range methods(v.Service) {
+			promoted := procedure(v.Service, method)
+			from := procedure(v.PromotedFrom, method)
+			p.predecessors[promoted] = from
+			p.successors[from] = successor{procedure: promoted, deprecatedAt: v.PromotedAt}
+		}
+	}
+	return p
+})
+
+// Predecessor returns the procedure of the method serving the promoted method of procedure
+func Predecessor(procedure string) (string, bool) {
+	from, ok := loadPromotions().predecessors[procedure]
+	return from, ok
+}
+
+func procedure(service, method string) string {
+	return "/" + service + "/" + method
+}
+
+// serviceDescriptor returns the descriptor of a service, nil if it isn't linked in
+func serviceDescriptor(service string) protoreflect.ServiceDescriptor {
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil
+	}
+	sd, _ := d.(protoreflect.ServiceDescriptor)
+	return sd
+}
+
+// methodDescriptor returns the descriptor of the method of procedure, nil if it is unknown
+func methodDescriptor(procedure string) protoreflect.MethodDescriptor {
+	service, method, ok := strings.Cut(strings.TrimPrefix(procedure, "/"), "/")
+	if !ok {
+		return nil
+	}
+	sd := serviceDescriptor(service)
+	if sd == nil {
+		return nil
+	}
+	return sd.Methods().ByName(protoreflect.Name(method))
+}
+
+// methods returns the names of the methods of a service, in declaration order
+func methods(service string) []string {
+	sd := serviceDescriptor(service)
+	if sd == nil {
+		return nil
+	}
+	names := make([]string, 0, sd.Methods().Len())
+	for i := range sd.Methods().Len() {
+		names = append(names, string(sd.Methods().Get(i).Name()))
+	}
+	return names
+}
+
+// Capabilities returns the API versions served by the server
+func Capabilities() *serverv1beta1.ServerCapabilities {
+	successors := loadPromotions().successors
+	caps := &serverv1beta1.ServerCapabilities{
+		DeprecationWindow: durationpb.New(DeprecationWindow),
+	}
+	for _, v := range Versions {
+		version := &serverv1beta1.APIVersion{
+			Api:       v.API,
+			Version:   v.Version,
+			Service:   v.Service,
+			Stability: v.Stability,
+			Methods:   methods(v.Service),
+		}
+		for _, method := range version.Methods {
+			s, ok := successors[procedure(v.Service, method)]
+			if !ok {
+				continue
+			}
+			version.DeprecatedMethods = append(version.DeprecatedMethods, &serverv1beta1.DeprecatedMethod{
+				Method:       method,
+				Successor:    s.procedure,
+				DeprecatedAt: timestamppb.New(s.deprecatedAt),
+				SunsetAt:     timestamppb.New(s.sunsetAt()),
+			})
+		}
+		caps.ApiVersions = append(caps.ApiVersions, version)
+	}
+	return caps
+}
+
+// CheckPromotions checks that the messages of every promoted method are wire-compatible with
+// the messages of the method serving it
+func CheckPromotions() error {
+	var errs []string
+	for _, v := range Versions {
+		if v.PromotedFrom == "" {
+			continue
+		}
+		if serviceDescriptor(v.Service) == nil || serviceDescriptor(v.PromotedFrom) == nil {
+			errs = append(errs, fmt.Sprintf("%s: unknown service", v.Service))
+			continue
+		}
+		for _, method := range methods(v.Service) {
+			promoted := methodDescriptor(procedure(v.Service, method))
+			from := methodDescriptor(procedure(v.PromotedFrom, method))
+			if from == nil {
+				errs = append(errs, fmt.Sprintf("%s.%s: %s has no such method", v.Service, method, v.PromotedFrom))
+				continue
+			}
+			if err := checkCompatible(promoted.Input(), from.Input()); err != nil {
+				errs = append(errs, fmt.Sprintf("%s.%s request: %s", v.Service, method, err))
+			}
+			if err := checkCompatible(promoted.Output(), from.Output()); err != nil {
+				errs = append(errs, fmt.Sprintf("%s.%s response: %s", v.Service, method, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		slices.Sort(errs)
+		return fmt.Errorf("incompatible promotions:\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package apiversion_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	agentsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1/v1alpha1connect"
+	agentsv1beta1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1beta1"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1beta1/v1beta1connect"
+	serverv1beta1 "github.com/otelfleet/otelfleet/pkg/api/server/v1beta1"
+	"github.com/otelfleet/otelfleet/pkg/apiversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPromotions(t *testing.T) {
+	require.NoError(t, apiversion.CheckPromotions())
+}
+
+func TestCapabilities(t *testing.T) {
+	caps := apiversion.Capabilities()
+	assert.Equal(t, apiversion.DeprecationWindow, caps.GetDeprecationWindow().AsDuration())
+
+	versions := map[string]*serverv1beta1.APIVersion{}
+	for _, v := range caps.GetApiVersions() {
+		versions[v.GetService()] = v
+	}
+	beta := versions[v1beta1connect.AgentServiceName]
+	require.NotNil(t, beta)
+	assert.Equal(t, serverv1beta1.Stability_STABILITY_BETA, beta.GetStability())
+	assert.Contains(t, beta.GetMethods(), "GetAgent")
+	assert.Empty(t, beta.GetDeprecatedMethods())
+
+	alpha := versions[v1alpha1connect.AgentServiceName]
+	require.NotNil(t, alpha)
+	assert.Equal(t, serverv1beta1.Stability_STABILITY_ALPHA, alpha.GetStability())
+	require.Len(t, alpha.GetDeprecatedMethods(), len(beta.GetMethods()))
+	for _, d := range alpha.GetDeprecatedMethods() {
+		assert.Equal(t, "/"+v1beta1connect.AgentServiceName+"/"+d.GetMethod(), d.GetSuccessor())
+		assert.Equal(t, apiversion.DeprecationWindow, d.GetSunsetAt().AsTime().Sub(d.GetDeprecatedAt().AsTime()))
+	}
+	// gateway methods weren't promoted
+	assert.Empty(t, versions[v1alpha1connect.GatewayServiceName].GetDeprecatedMethods())
+}
+
+type agentServer struct {
+	v1alpha1connect.UnimplementedAgentServiceHandler
+}
+
+func (agentServer) GetAgent(_ context.Context, req *connect.Request[agentsv1alpha1.GetAgentRequest]) (*connect.Response[agentsv1alpha1.GetAgentResponse], error) {
+	resp := connect.NewResponse(&agentsv1alpha1.GetAgentResponse{
+		Agent: &agentsv1alpha1.AgentDescription{Id: req.Msg.GetAgentId(), FriendlyName: "agent"},
+	})
+	resp.Header().Set("X-Served-By", "v1alpha1")
+	return resp, nil
+}
+
+type betaAgentServer struct {
+	v1beta1connect.UnimplementedAgentServiceHandler
+	alpha agentServer
+	shim  *apiversion.Shim
+}
+
+func (s betaAgentServer) GetAgent(ctx context.Context, req *connect.Request[agentsv1beta1.GetAgentRequest]) (*connect.Response[agentsv1beta1.GetAgentResponse], error) {
+	return apiversion.Unary[agentsv1beta1.GetAgentResponse](ctx, s.shim, req, s.alpha.GetAgent)
+}
+
+// procedureRecorder records the procedures and authorization of the calls it intercepts
+type procedureRecorder struct {
+	procedures     []string
+	authorizations []string
+}
+
+func (p *procedureRecorder) intercept(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		p.procedures = append(p.procedures, req.Spec().Procedure)
+		p.authorizations = append(p.authorizations, req.Header().Get("Authorization"))
+		return next(ctx, req)
+	}
+}
+
+func TestUnary(t *testing.T) {
+	rec := &procedureRecorder{}
+	interceptors := []connect.Interceptor{connect.UnaryInterceptorFunc(rec.intercept), apiversion.NewDeprecationInterceptor()}
+
+	mux := http.NewServeMux()
+	mux.Handle(v1alpha1connect.NewAgentServiceHandler(agentServer{}, connect.WithInterceptors(interceptors...)))
+	mux.Handle(v1beta1connect.NewAgentServiceHandler(betaAgentServer{shim: apiversion.NewShim(interceptors...)}))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	alphaReq := connect.NewRequest(&agentsv1alpha1.GetAgentRequest{AgentId: "a1"})
+	alphaReq.Header().Set("Authorization", "token")
+	alphaResp, err := v1alpha1connect.NewAgentServiceClient(srv.Client(), srv.URL).GetAgent(t.Context(), alphaReq)
+	require.NoError(t, err)
+	assert.Equal(t, "a1", alphaResp.Msg.GetAgent().GetId())
+	assert.NotEmpty(t, alphaResp.Header().Get(apiversion.HeaderDeprecation))
+	assert.NotEmpty(t, alphaResp.Header().Get(apiversion.HeaderSunset))
+	assert.Equal(t, `</agents.v1beta1.AgentService/GetAgent>; rel="successor-version"`, alphaResp.Header().Get(apiversion.HeaderLink))
+
+	betaReq := connect.NewRequest(&agentsv1beta1.GetAgentRequest{AgentId: "a2"})
+	betaReq.Header().Set("Authorization", "token")
+	betaResp, err := v1beta1connect.NewAgentServiceClient(srv.Client(), srv.URL).GetAgent(t.Context(), betaReq)
+	require.NoError(t, err)
+	assert.Equal(t, "a2", betaResp.Msg.GetAgent().GetId())
+	assert.Equal(t, "agent", betaResp.Msg.GetAgent().GetFriendlyName())
+	assert.Equal(t, "v1alpha1", betaResp.Header().Get("X-Served-By"))
+	assert.Empty(t, betaResp.Header().Get(apiversion.HeaderDeprecation))
+
+	// interceptors see the procedure of the handler serving the call
+	assert.Equal(t, []string{v1alpha1connect.AgentServiceGetAgentProcedure, v1alpha1connect.AgentServiceGetAgentProcedure}, rec.procedures)
+	assert.Equal(t, []string{"token", "token"}, rec.authorizations)
+
+	// methods which weren't promoted can't be shimmed
+	_, err = apiversion.Unary[agentsv1beta1.GetAgentResponse](t.Context(), apiversion.NewShim(), alphaReq, agentServer{}.GetAgent)
+	assert.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
@@ -0,0 +1,69 @@
+package apiversion
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// convert copies the fields of from that to knows through their wire encoding, the others are
+// dropped
+func convert(from, to proto.Message) error {
+	data, err := proto.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return proto.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, to)
+}
+
+// checkCompatible checks that every field of promoted has the number, kind and cardinality of
+// a field of from, recursively, so that promoted messages convert to and from their
+// predecessor without losing the fields they have. Names may differ.
+func checkCompatible(promoted, from protoreflect.MessageDescriptor) error {
+	return (&compatChecker{seen: map[[2]protoreflect.FullName]bool{}}).messages(promoted, from)
+}
+
+type compatChecker struct {
+	// pairs of messages checked or being checked, for recursive messages
+	seen map[[2]protoreflect.FullName]bool
+}
+
+func (c *compatChecker) messages(promoted, from protoreflect.MessageDescriptor) error {
+	pair := [2]protoreflect.FullName{promoted.FullName(), from.FullName()}
+	if c.seen[pair] {
+		return nil
+	}
+	c.seen[pair] = true
+	fields := promoted.Fields()
+	for i := range fields.Len() {
+		pf := fields.Get(i)
+		ff := from.Fields().ByNumber(pf.Number())
+		if ff == nil {
+			return fmt.Errorf("%s: %s has no field %d", pf.FullName(), from.FullName(), pf.Number())
+		}
+		if err := c.fields(pf, ff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *compatChecker) fields(promoted, from protoreflect.FieldDescriptor) error {
+	if promoted.Kind() != from.Kind() || promoted.Cardinality() != from.Cardinality() || promoted.IsMap() != from.IsMap() {
+		return fmt.Errorf("%s: %s %s doesn't match %s %s of %s",
+			promoted.FullName(), promoted.Cardinality(), promoted.Kind(), from.Cardinality(), from.Kind(), from.FullName())
+	}
+	switch promoted.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return c.messages(promoted.Message(), from.Message())
+	case protoreflect.EnumKind:
+		values := promoted.Enum().Values()
+		for i := range values.Len() {
+			if from.Enum().Values().ByNumber(values.Get(i).Number()) == nil {
+				return fmt.Errorf("%s: %s has no value %d", values.Get(i).FullName(), from.Enum().FullName(), values.Get(i).Number())
+			}
+		}
+	}
+	return nil
+}
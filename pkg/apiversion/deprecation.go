@@ -0,0 +1,61 @@
+package apiversion
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"connectrpc.com/connect"
+)
+
+// Headers of the responses of deprecated methods
+const (
+	// HeaderDeprecation is when the method was deprecated, as in RFC 9745
+	HeaderDeprecation = "Deprecation"
+	// HeaderSunset is when the method may stop being served, as in RFC 8594
+	HeaderSunset = "Sunset"
+	// HeaderLink links to the successor-version of the method
+	HeaderLink = "Link"
+)
+
+type deprecationInterceptor struct{}
+
+// NewDeprecationInterceptor marks the responses of deprecated methods with when they were
+// deprecated, when they may stop being served and the method superseding them. Calls forwarded
+// by a Shim aren't marked, the promoted method they were made to isn't deprecated.
+func NewDeprecationInterceptor() connect.Interceptor {
+	return deprecationInterceptor{}
+}
+
+// mark sets the deprecation headers of procedure in header, if it is deprecated
+func (deprecationInterceptor) mark(ctx context.Context, procedure string, header http.Header) {
+	s, ok := loadPromotions().successors[procedure]
+	if !ok || IsForwarded(ctx) {
+		return
+	}
+	header.Set(HeaderDeprecation, fmt.Sprintf("@%d", s.deprecatedAt.Unix()))
+	header.Set(HeaderSunset, s.sunsetAt().UTC().Format(http.TimeFormat))
+	header.Set(HeaderLink, fmt.Sprintf("<%s>; rel=\"successor-version\"", s.procedure))
+}
+
+func (d deprecationInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		resp, err := next(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		d.mark(ctx, req.Spec().Procedure, resp.Header())
+		return resp, nil
+	}
+}
+
+func (deprecationInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (d deprecationInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		d.mark(ctx, conn.Spec().Procedure, conn.ResponseHeader())
+		return next(ctx, conn)
+	}
+}
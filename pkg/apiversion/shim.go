@@ -0,0 +1,115 @@
+package apiversion
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+)
+
+// message is a pointer to a generated message type
+type message[T any] interface {
+	*T
+	proto.Message
+}
+
+// Shim serves promoted methods with the handlers of the methods they were promoted from,
+// wrapped in the interceptors of those handlers
+type Shim struct {
+	interceptors []connect.Interceptor
+}
+
+// NewShim returns a shim calling handlers through interceptors, which must be the
+// interceptors the handlers are registered with
+func NewShim(interceptors ...connect.Interceptor) *Shim {
+	return &Shim{interceptors: interceptors}
+}
+
+type forwardedKey struct{}
+
+// IsForwarded returns true if ctx is the context of a call forwarded by a shim
+func IsForwarded(ctx context.Context) bool {
+	_, ok := ctx.Value(forwardedKey{}).(string)
+	return ok
+}
+
+// forwardedRequest is a request converted to the predecessor of its method. Interceptors see
+// the specification of the predecessor, and the peer and headers of the original request.
+type forwardedRequest[T any] struct {
+	*connect.Request[T]
+	spec   connect.Spec
+	peer   connect.Peer
+	method string
+}
+
+func (r *forwardedRequest[T]) Spec() connect.Spec { return r.spec }
+func (r *forwardedRequest[T]) Peer() connect.Peer { return r.peer }
+func (r *forwardedRequest[T]) HTTPMethod() string { return r.method }
+
+// Unary serves req, a call to a promoted method, with handler, the handler of its predecessor.
+// The request and response are converted through their wire encoding, dropping the response
+// fields the promoted method doesn't have. Resp is the response type of the promoted method,
+// the others are inferred:
+//
+//	return apiversion.Unary[v1beta1.GetAgentResponse](ctx, s.shim, req, s.agents.GetAgent)
+func Unary[Resp, Req, FromReq, FromResp any, RespP message[Resp], ReqP message[Req], FromReqP message[FromReq], FromRespP message[FromResp]](
+	ctx context.Context,
+	s *Shim,
+	req *connect.Request[Req],
+	handler func(context.Context, *connect.Request[FromReq]) (*connect.Response[FromResp], error),
+) (*connect.Response[Resp], error) {
+	from, ok := Predecessor(req.Spec().Procedure)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("%s was not promoted from another method", req.Spec().Procedure))
+	}
+	var fromMsg FromReq
+	if err := convert(ReqP(req.Msg), FromReqP(&fromMsg)); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("failed to convert request: %w", err))
+	}
+	forwarded := &forwardedRequest[FromReq]{
+		Request: connect.NewRequest(&fromMsg),
+		spec: connect.Spec{
+			StreamType:       connect.StreamTypeUnary,
+			Schema:           methodDescriptor(from),
+			Procedure:        from,
+			IdempotencyLevel: req.Spec().IdempotencyLevel,
+		},
+		peer:   req.Peer(),
+		method: req.HTTPMethod(),
+	}
+	maps.Copy(forwarded.Header(), req.Header())
+
+	next := connect.UnaryFunc(func(ctx context.Context, r connect.AnyRequest) (connect.AnyResponse, error) {
+		fr, ok := r.(*forwardedRequest[FromReq])
+		if !ok {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("unexpected request %T forwarded to %s", r, from))
+		}
+		resp, err := handler(ctx, fr.Request)
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
+	})
+	// the first interceptor is the outermost, as when registering handlers
+	for i := len(s.interceptors) - 1; i >= 0; i-- {
+		next = s.interceptors[i].WrapUnary(next)
+	}
+	fromResp, err := next(context.WithValue(ctx, forwardedKey{}, from), forwarded)
+	if err != nil {
+		return nil, err
+	}
+	fromRespMsg, ok := fromResp.Any().(*FromResp)
+	if !ok {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("unexpected response %T from %s", fromResp.Any(), from))
+	}
+	var msg Resp
+	if err := convert(FromRespP(fromRespMsg), RespP(&msg)); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert response: %w", err))
+	}
+	resp := connect.NewResponse(&msg)
+	maps.Copy(resp.Header(), fromResp.Header())
+	maps.Copy(resp.Trailer(), fromResp.Trailer())
+	return resp, nil
+}
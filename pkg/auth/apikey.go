@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	rbacv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/rbac/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// APIKeyPrefix starts the tokens of API keys, telling them apart from OIDC tokens
+const APIKeyPrefix = "ofk_"
+
+// ErrInvalidToken is returned for bearer tokens that don't authenticate anyone
+var ErrInvalidToken = errors.New("invalid token")
+
+// NewAPIKey generates an API key named name, expiring after ttl unless it is 0. It returns
+// the key to store, and the token presenting it.
+func NewAPIKey(name string, now time.Time, ttl time.Duration) (*rbacv1alpha1.APIKey, string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, "", err
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	encodedSecret := hex.EncodeToString(secret)
+	hash := sha256.Sum256([]byte(encodedSecret))
+	key := &rbacv1alpha1.APIKey{
+		Id:         hex.EncodeToString(id),
+		Name:       name,
+		SecretHash: hash[:],
+		CreatedAt:  timestamppb.New(now),
+	}
+	key.Subject = APIKeySubject(key.Id)
+	if ttl > 0 {
+		key.ExpiresAt = timestamppb.New(now.Add(ttl))
+	}
+	return key, APIKeyPrefix + key.Id + "_" + encodedSecret, nil
+}
+
+// APIKeySubject returns the subject of the callers presenting the API key with the given ID
+func APIKeySubject(id string) string {
+	return "apikey:" + id
+}
+
+// APIKeys authenticates the callers presenting the tokens of stored API keys.
+type APIKeys struct {
+	store storage.KeyValue[*rbacv1alpha1.APIKey]
+	now   func() time.Time
+}
+
+var _ TokenAuthenticator = (*APIKeys)(nil)
+
+// NewAPIKeys creates an authenticator of the API keys in store, keyed by ID.
+func NewAPIKeys(store storage.KeyValue[*rbacv1alpha1.APIKey]) *APIKeys {
+	return &APIKeys{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+func (a *APIKeys) AuthenticateToken(ctx context.Context, token string) (*Principal, error) {
+	id, secret, ok := strings.Cut(strings.TrimPrefix(token, APIKeyPrefix), "_")
+	if !ok || id == "" {
+		return nil, ErrInvalidToken
+	}
+	key, err := a.store.Get(ctx, id)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, fmt.Errorf("%w: unknown API key %s", ErrInvalidToken, id)
+	} else if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256([]byte(secret))
+	if subtle.ConstantTimeCompare(hash[:], key.GetSecretHash()) != 1 {
+		return nil, fmt.Errorf("%w: wrong secret for API key %s", ErrInvalidToken, id)
+	}
+	if expiresAt := key.GetExpiresAt(); expiresAt != nil && !a.now().Before(expiresAt.AsTime()) {
+		return nil, fmt.Errorf("%w: API key %s expired", ErrInvalidToken, id)
+	}
+	return &Principal{Subject: key.GetSubject()}, nil
+}
@@ -0,0 +1,156 @@
+// Package auth provides authentication of management API callers, their
+// authorization by role, and propagation of the authenticated principal through
+// request contexts.
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Well-known roles, each granting the permissions of the previous one
+const (
+	// RoleViewer may call the management API reads
+	RoleViewer = "viewer"
+	// RoleOperator may call the management API mutations
+	RoleOperator = "operator"
+	// RoleAdmin may also manage API keys and role bindings, and read the audit log
+	RoleAdmin = "admin"
+)
+
+// roleRanks orders the well-known roles, other roles only scope configs, see ConfigPolicy
+var roleRanks = map[string]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+const (
+	// HeaderUser carries the authenticated user when running behind an authenticating proxy
+	HeaderUser = "X-Otelfleet-User"
+	// HeaderRoles carries a comma separated list of roles when running behind an authenticating proxy
+	HeaderRoles = "X-Otelfleet-Roles"
+)
+
+// Principal is an authenticated caller of the management APIs.
+type Principal struct {
+	Subject string
+	Roles   []string
+}
+
+// HasRole returns true if the principal has the given role.
+func (p *Principal) HasRole(role string) bool {
+	return p != nil && slices.Contains(p.Roles, role)
+}
+
+// Grants returns true if the principal has the given well-known role, or one granting its
+// permissions.
+func (p *Principal) Grants(role string) bool {
+	if p == nil {
+		return false
+	}
+	want, ok := roleRanks[role]
+	if !ok {
+		return p.HasRole(role)
+	}
+	for _, r := range p.Roles {
+		if roleRanks[r] >= want {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin returns true if the principal has the admin role.
+func (p *Principal) IsAdmin() bool {
+	return p.HasRole(RoleAdmin)
+}
+
+type principalKey struct{}
+
+// NewContext returns a context carrying the authenticated principal.
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the authenticated principal, or nil for anonymous requests.
+func FromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// SubjectFromContext returns the subject of the authenticated principal, or an
+// empty string for anonymous requests.
+func SubjectFromContext(ctx context.Context) string {
+	if p := FromContext(ctx); p != nil {
+		return p.Subject
+	}
+	return ""
+}
+
+// Authenticator authenticates an HTTP request.
+// It returns a nil principal and no error for anonymous requests.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// TokenAuthenticator authenticates a bearer token presented in the Authorization header.
+type TokenAuthenticator interface {
+	AuthenticateToken(ctx context.Context, token string) (*Principal, error)
+}
+
+type headerAuthenticator struct{}
+
+// NewHeaderAuthenticator returns an Authenticator trusting the identity headers
+// set by an authenticating reverse proxy. Only use it when the server is not
+// directly reachable by clients.
+func NewHeaderAuthenticator() Authenticator {
+	return headerAuthenticator{}
+}
+
+func (headerAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	user := strings.TrimSpace(r.Header.Get(HeaderUser))
+	if user == "" {
+		return nil, nil
+	}
+	p := &Principal{Subject: user}
+	for _, role := range strings.Split(r.Header.Get(HeaderRoles), ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			p.Roles = append(p.Roles, role)
+		}
+	}
+	return p, nil
+}
+
+// Middleware authenticates requests and attaches the principal to the request context.
+// It implements the dskit middleware.Interface.
+type Middleware struct {
+	logger        *slog.Logger
+	authenticator Authenticator
+}
+
+// NewMiddleware creates an authentication Middleware.
+func NewMiddleware(logger *slog.Logger, authenticator Authenticator) *Middleware {
+	return &Middleware{
+		logger:        logger,
+		authenticator: authenticator,
+	}
+}
+
+func (m *Middleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, err := m.authenticator.Authenticate(r)
+		if err != nil {
+			m.logger.With("err", err, "remote-addr", r.RemoteAddr).Warn("authentication failed")
+			http.Error(w, "unauthenticated", http.StatusUnauthorized)
+			return
+		}
+		if p != nil {
+			r = r.WithContext(NewContext(r.Context(), p))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	rbacv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/rbac/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+)
+
+// RoleBindings grants authenticated principals the roles bound to their subject.
+type RoleBindings struct {
+	store storage.KeyValue[*rbacv1alpha1.RoleBinding]
+	// subjects granted the admin role regardless of the bindings, so that the first API keys
+	// and bindings can be created
+	admins []string
+}
+
+// NewRoleBindings creates RoleBindings reading the bindings in store, keyed by subject. The
+// admins subjects are granted the admin role without a binding.
+func NewRoleBindings(store storage.KeyValue[*rbacv1alpha1.RoleBinding], admins []string) *RoleBindings {
+	return &RoleBindings{
+		store:  store,
+		admins: admins,
+	}
+}
+
+// Resolve returns the principal granted the roles bound to its subject, in addition to the
+// roles it was authenticated with.
+func (r *RoleBindings) Resolve(ctx context.Context, p *Principal) (*Principal, error) {
+	roles := slices.Clone(p.Roles)
+	if slices.Contains(r.admins, p.Subject) {
+		roles = append(roles, RoleAdmin)
+	}
+	binding, err := r.store.Get(ctx, p.Subject)
+	if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return nil, err
+	}
+	roles = append(roles, binding.GetRoles()...)
+	slices.Sort(roles)
+	return &Principal{
+		Subject: p.Subject,
+		Roles:   slices.Compact(roles),
+	}, nil
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"slices"
+	"strings"
+)
+
+// ConfigScope grants a role management of the configs matching any of its
+// ID prefixes or tags.
+type ConfigScope struct {
+	Role     string   `yaml:"role"`
+	Prefixes []string `yaml:"prefixes"`
+	Tags     []string `yaml:"tags"`
+}
+
+// ConfigPolicy scopes config management per role.
+// An empty policy allows everything, preserving the behaviour of servers
+// that do not configure ownership rules.
+type ConfigPolicy struct {
+	Scopes []ConfigScope `yaml:"scopes"`
+}
+
+// Enabled returns true if any scopes are configured.
+func (c ConfigPolicy) Enabled() bool {
+	return len(c.Scopes) > 0
+}
+
+// Allowed returns true if the principal may manage the config with the given ID and tags.
+func (c ConfigPolicy) Allowed(p *Principal, configID string, tags []string) bool {
+	if !c.Enabled() {
+		return true
+	}
+	if p == nil {
+		return false
+	}
+	if p.IsAdmin() {
+		return true
+	}
+	for _, scope := range c.Scopes {
+		if !p.HasRole(scope.Role) {
+			continue
+		}
+		for _, prefix := range scope.Prefixes {
+			if strings.HasPrefix(configID, prefix) {
+				return true
+			}
+		}
+		for _, tag := range scope.Tags {
+			if slices.Contains(tags, tag) {
+				return true
+			}
+		}
+	}
+	return false
+}
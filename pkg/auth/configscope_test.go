@@ -0,0 +1,56 @@
+package auth_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigPolicy_Allowed(t *testing.T) {
+	policy := auth.ConfigPolicy{
+		Scopes: []auth.ConfigScope{
+			{Role: "team-a", Prefixes: []string{"team-a-"}},
+			{Role: "team-b", Tags: []string{"team-b"}},
+		},
+	}
+	teamA := &auth.Principal{Subject: "alice", Roles: []string{"team-a"}}
+	teamB := &auth.Principal{Subject: "bob", Roles: []string{"team-b"}}
+	admin := &auth.Principal{Subject: "root", Roles: []string{auth.RoleAdmin}}
+
+	assert.True(t, policy.Allowed(teamA, "team-a-logs", nil))
+	assert.False(t, policy.Allowed(teamA, "team-b-logs", []string{"team-b"}))
+	assert.True(t, policy.Allowed(teamB, "shared", []string{"prod", "team-b"}))
+	assert.False(t, policy.Allowed(teamB, "team-a-logs", nil))
+	assert.True(t, policy.Allowed(admin, "anything", nil))
+	assert.False(t, policy.Allowed(nil, "team-a-logs", nil))
+
+	// an empty policy preserves unscoped behaviour
+	assert.True(t, auth.ConfigPolicy{}.Allowed(nil, "anything", nil))
+}
+
+func TestMiddleware_HeaderAuthenticator(t *testing.T) {
+	var got *auth.Principal
+	handler := auth.NewMiddleware(slog.Default(), auth.NewHeaderAuthenticator()).Wrap(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = auth.FromContext(r.Context())
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(auth.HeaderUser, "alice")
+	req.Header.Set(auth.HeaderRoles, "team-a, admin ,")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.NotNil(t, got)
+	assert.Equal(t, "alice", got.Subject)
+	assert.Equal(t, []string{"team-a", "admin"}, got.Roles)
+	assert.True(t, got.IsAdmin())
+
+	got = nil
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Nil(t, got)
+}
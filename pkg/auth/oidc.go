@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jws"
+	"github.com/lestrrat-go/jwx/jwt"
+)
+
+const (
+	// oidcRefreshInterval is how long the keys of the issuer are cached
+	oidcRefreshInterval = time.Hour
+	// oidcMinRefreshInterval bounds how often tokens signed by unknown keys refetch the keys,
+	// so that they can't be used to flood the issuer
+	oidcMinRefreshInterval = time.Minute
+)
+
+// OIDCConfig authenticates callers presenting a token issued by an OpenID Connect provider
+type OIDCConfig struct {
+	// IssuerURL issues the tokens, its keys are discovered from its
+	// /.well-known/openid-configuration document
+	IssuerURL string `yaml:"issuer_url"`
+	// Audience the tokens must be issued for, e.g. the client ID of the UI
+	Audience string `yaml:"audience"`
+	// SubjectClaim identifies the callers, defaults to sub
+	SubjectClaim string `yaml:"subject_claim"`
+	// RolesClaim optionally lists roles granted to the callers in addition to their role
+	// bindings, e.g. groups
+	RolesClaim string `yaml:"roles_claim"`
+}
+
+// Enabled returns true if an issuer is configured.
+func (c OIDCConfig) Enabled() bool {
+	return c.IssuerURL != ""
+}
+
+// OIDCAuthenticator authenticates the callers presenting tokens signed by an OIDC issuer.
+type OIDCAuthenticator struct {
+	cfg    OIDCConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      jwk.Set
+	fetchedAt time.Time
+}
+
+var _ TokenAuthenticator = (*OIDCAuthenticator)(nil)
+
+// NewOIDCAuthenticator creates an OIDCAuthenticator fetching the keys of the issuer with
+// client, or http.DefaultClient if nil. The keys are fetched on first use.
+func NewOIDCAuthenticator(cfg OIDCConfig, client *http.Client) *OIDCAuthenticator {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if cfg.SubjectClaim == "" {
+		cfg.SubjectClaim = jwt.SubjectKey
+	}
+	return &OIDCAuthenticator{
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+func (o *OIDCAuthenticator) AuthenticateToken(ctx context.Context, token string) (*Principal, error) {
+	msg, err := jws.Parse([]byte(token))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	var keyID string
+	if sigs := msg.Signatures(); len(sigs) > 0 {
+		keyID = sigs[0].ProtectedHeaders().KeyID()
+	}
+	keys, err := o.keySet(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := jwt.Parse([]byte(token),
+		jwt.WithKeySet(keys),
+		jwt.InferAlgorithmFromKey(true),
+		jwt.UseDefaultKey(true),
+		jwt.WithValidate(true),
+		jwt.WithIssuer(o.cfg.IssuerURL),
+		jwt.WithAudience(o.cfg.Audience),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	claims, err := parsed.AsMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subject, _ := claims[o.cfg.SubjectClaim].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: no %s claim", ErrInvalidToken, o.cfg.SubjectClaim)
+	}
+	p := &Principal{Subject: subject}
+	if o.cfg.RolesClaim != "" {
+		p.Roles = stringsClaim(claims[o.cfg.RolesClaim])
+	}
+	return p, nil
+}
+
+// stringsClaim returns the strings of a claim listing them, or of a space separated claim
+func stringsClaim(claim any) []string {
+	switch claim := claim.(type) {
+	case string:
+		return strings.Fields(claim)
+	case []any:
+		var values []string
+		for _, v := range claim {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// keySet returns the keys of the issuer, refetching them when they are stale or don't include
+// the key with ID keyID. The cached keys are kept if they can't be refetched.
+func (o *OIDCAuthenticator) keySet(ctx context.Context, keyID string) (jwk.Set, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	age := time.Since(o.fetchedAt)
+	stale := o.keys == nil || age > oidcRefreshInterval
+	if !stale && keyID != "" && age > oidcMinRefreshInterval {
+		_, known := o.keys.LookupKeyID(keyID)
+		stale = !known
+	}
+	if !stale {
+		return o.keys, nil
+	}
+	keys, err := o.fetchKeys(ctx)
+	if err != nil {
+		if o.keys != nil {
+			return o.keys, nil
+		}
+		return nil, err
+	}
+	o.keys, o.fetchedAt = keys, time.Now()
+	return keys, nil
+}
+
+// fetchKeys discovers the key set of the issuer and fetches it
+func (o *OIDCAuthenticator) fetchKeys(ctx context.Context) (jwk.Set, error) {
+	url := strings.TrimSuffix(o.cfg.IssuerURL, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover the OIDC issuer: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to discover the OIDC issuer: %s", resp.Status)
+	}
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, fmt.Errorf("invalid OIDC discovery document: %w", err)
+	}
+	if discovery.Issuer != o.cfg.IssuerURL {
+		return nil, fmt.Errorf("OIDC discovery document of issuer %q, expected %q", discovery.Issuer, o.cfg.IssuerURL)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery document has no jwks_uri")
+	}
+	return jwk.Fetch(ctx, discovery.JWKSURI, jwk.WithHTTPClient(o.client))
+}
@@ -0,0 +1,93 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/jwa"
+	"github.com/lestrrat-go/jwx/jwk"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCAuthenticator(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signingKey, err := jwk.New(privateKey)
+	require.NoError(t, err)
+	require.NoError(t, signingKey.Set(jwk.KeyIDKey, "key-1"))
+	publicKey, err := signingKey.(jwk.RSAPrivateKey).PublicKey()
+	require.NoError(t, err)
+	keys := jwk.NewSet()
+	keys.Add(publicKey)
+
+	var issuer string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": issuer, "jwks_uri": issuer + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(keys)
+	})
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	issuer = ts.URL
+
+	sign := func(claims map[string]any) string {
+		tok := jwt.New()
+		for k, v := range claims {
+			require.NoError(t, tok.Set(k, v))
+		}
+		signed, err := jwt.Sign(tok, jwa.RS256, signingKey)
+		require.NoError(t, err)
+		return string(signed)
+	}
+	valid := func() map[string]any {
+		return map[string]any{
+			jwt.IssuerKey:     issuer,
+			jwt.AudienceKey:   "otelfleet",
+			jwt.SubjectKey:    "1234",
+			jwt.ExpirationKey: time.Now().Add(time.Hour),
+			"email":           "alice@example.com",
+			"groups":          []string{"operator", "team-a"},
+		}
+	}
+
+	authenticator := auth.NewOIDCAuthenticator(auth.OIDCConfig{
+		IssuerURL:    issuer,
+		Audience:     "otelfleet",
+		SubjectClaim: "email",
+		RolesClaim:   "groups",
+	}, ts.Client())
+	p, err := authenticator.AuthenticateToken(t.Context(), sign(valid()))
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", p.Subject)
+	assert.Equal(t, []string{"operator", "team-a"}, p.Roles)
+
+	for name, mutate := range map[string]func(claims map[string]any){
+		"wrong audience": func(claims map[string]any) { claims[jwt.AudienceKey] = "other" },
+		"wrong issuer":   func(claims map[string]any) { claims[jwt.IssuerKey] = "https://other.example.com" },
+		"expired":        func(claims map[string]any) { claims[jwt.ExpirationKey] = time.Now().Add(-time.Hour) },
+		"no subject":     func(claims map[string]any) { delete(claims, "email") },
+	} {
+		claims := valid()
+		mutate(claims)
+		_, err := authenticator.AuthenticateToken(t.Context(), sign(claims))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken, name)
+	}
+
+	// tokens signed by keys of other issuers are rejected
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signed, err := jwt.Sign(jwt.New(), jwa.RS256, otherKey)
+	require.NoError(t, err)
+	_, err = authenticator.AuthenticateToken(t.Context(), string(signed))
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+}
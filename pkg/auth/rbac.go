@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/middleware"
+)
+
+// readVerbs start the names of the procedures that don't change anything
+var readVerbs = map[string]struct{}{
+	"Get": {}, "List": {}, "Watch": {}, "Check": {}, "Diff": {}, "Valid": {}, "Validate": {},
+	"Simulate": {}, "Wait": {}, "Status": {}, "Signatures": {}, "Generate": {},
+}
+
+// publicServices are called by agents and gateways, authenticated by their own credentials,
+// or before callers authenticate
+var publicServices = map[string]struct{}{
+	"bootstrap.v1alpha1.BootstrapService": {},
+	"config.v1alpha1.GatewayService":      {},
+	"server.v1beta1.ServerService":        {},
+}
+
+// adminServices manage who may call the management APIs, or what they did
+var adminServices = map[string]struct{}{
+	"rbac.v1alpha1.RBACService":   {},
+	"admin.v1alpha1.AdminService": {},
+	"audit.v1alpha1.AuditService": {},
+}
+
+// callerProcedures may be called by any authenticated caller, whatever its roles
+var callerProcedures = map[string]struct{}{
+	"/rbac.v1alpha1.RBACService/GetCaller": {},
+}
+
+// operatorProcedures don't change anything, but return secrets or agent data that only
+// operators may see, e.g. bootstrap tokens
+var operatorProcedures = map[string]struct{}{
+	"/bootstrap.v1alpha1.TokenService/ListTokens":            {},
+	"/bootstrap.v1beta1.TokenService/ListTokens":             {},
+	"/bootstrap.v1alpha1.TokenService/GenerateInstallScript": {},
+	"/config.v1alpha1.AgentService/GetAgentSnapshot":         {},
+}
+
+// ReadOnly returns true if the procedure doesn't change anything, e.g.
+// /config.v1alpha1.ConfigService/GetConfig.
+func ReadOnly(procedure string) bool {
+	_, method, _ := strings.Cut(strings.TrimPrefix(procedure, "/"), "/")
+	verb := method
+	if i := strings.IndexFunc(method[min(1, len(method)):], unicode.IsUpper); i >= 0 {
+		verb = method[:i+1]
+	}
+	_, ok := readVerbs[verb]
+	return ok
+}
+
+// RequiredRole returns the role required to call the procedure, empty if any authenticated
+// caller may call it, and false if anonymous callers may call it.
+func RequiredRole(procedure string) (role string, authenticated bool) {
+	service, _, _ := strings.Cut(strings.TrimPrefix(procedure, "/"), "/")
+	if _, ok := publicServices[service]; ok {
+		return "", false
+	}
+	if _, ok := callerProcedures[procedure]; ok {
+		return "", true
+	}
+	if _, ok := adminServices[service]; ok {
+		return RoleAdmin, true
+	}
+	if _, ok := operatorProcedures[procedure]; ok {
+		return RoleOperator, true
+	}
+	if ReadOnly(procedure) {
+		return RoleViewer, true
+	}
+	return RoleOperator, true
+}
+
+// Interceptor authenticates the callers of the management API handlers it intercepts and
+// authorizes them per procedure, see RequiredRole. Callers are authenticated by the
+// Middleware, or by the bearer token they present.
+type Interceptor struct {
+	logger   *slog.Logger
+	bindings *RoleBindings
+	apiKeys  TokenAuthenticator
+	// optional, only API keys are accepted if nil
+	oidc TokenAuthenticator
+}
+
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+// NewInterceptor creates an Interceptor granting callers their role bindings. API key tokens
+// are authenticated by apiKeys, other bearer tokens by oidc if not nil.
+func NewInterceptor(logger *slog.Logger, bindings *RoleBindings, apiKeys *APIKeys, oidc *OIDCAuthenticator) *Interceptor {
+	i := &Interceptor{
+		logger:   logger,
+		bindings: bindings,
+		apiKeys:  apiKeys,
+	}
+	if oidc != nil {
+		// a nil *OIDCAuthenticator would make a non-nil TokenAuthenticator
+		i.oidc = oidc
+	}
+	return i
+}
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, err := i.authorize(ctx, req.Spec().Procedure, req.Header())
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := i.authorize(ctx, conn.Spec().Procedure, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// Middleware authorizes the callers of the raw HTTP handlers of the management API it wraps,
+// e.g. exports, like the callers of a procedure requiring role
+func (i *Interceptor) Middleware(role string) middleware.Interface {
+	return middleware.Func(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, err := i.authorizeRole(r.Context(), role, r.URL.Path, r.Header)
+			if err != nil {
+				if connect.CodeOf(err) == connect.CodeUnauthenticated {
+					w.Header().Set("WWW-Authenticate", "Bearer")
+				}
+				http.Error(w, err.Error(), httpStatus(connect.CodeOf(err)))
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+}
+
+func httpStatus(code connect.Code) int {
+	switch code {
+	case connect.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case connect.CodePermissionDenied:
+		return http.StatusForbidden
+	case connect.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// authorize returns the context carrying the caller granted its role bindings, or an error
+// if it may not call the procedure
+func (i *Interceptor) authorize(ctx context.Context, procedure string, headers http.Header) (context.Context, error) {
+	role, authenticated := RequiredRole(procedure)
+	if !authenticated {
+		return ctx, nil
+	}
+	return i.authorizeRole(ctx, role, procedure, headers)
+}
+
+// authorizeRole returns the context carrying the caller granted its role bindings, or an error
+// if it isn't granted role. Any authenticated caller is granted the empty role.
+func (i *Interceptor) authorizeRole(ctx context.Context, role, procedure string, headers http.Header) (context.Context, error) {
+	p := FromContext(ctx)
+	if p == nil {
+		var err error
+		if p, err = i.authenticate(ctx, headers); err != nil {
+			i.logger.With("err", err, "procedure", procedure).Warn("authentication failed")
+			return ctx, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid bearer token"))
+		}
+	}
+	if p == nil {
+		return ctx, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+	granted, err := i.bindings.Resolve(ctx, p)
+	if err != nil {
+		i.logger.With("err", err, "subject", p.Subject).Error("failed to resolve role bindings")
+		return ctx, connect.NewError(connect.CodeUnavailable, errors.New("failed to resolve role bindings"))
+	}
+	if role != "" && !granted.Grants(role) {
+		return ctx, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("the %s role is required", role))
+	}
+	return NewContext(ctx, granted), nil
+}
+
+// authenticate authenticates the bearer token in headers, it returns a nil principal if there
+// is none
+func (i *Interceptor) authenticate(ctx context.Context, headers http.Header) (*Principal, error) {
+	token, ok := strings.CutPrefix(headers.Get("Authorization"), "Bearer ")
+	if token = strings.TrimSpace(token); !ok || token == "" {
+		return nil, nil
+	}
+	if strings.HasPrefix(token, APIKeyPrefix) {
+		return i.apiKeys.AuthenticateToken(ctx, token)
+	}
+	if i.oidc == nil {
+		return nil, fmt.Errorf("%w: not an API key and OIDC is not configured", ErrInvalidToken)
+	}
+	return i.oidc.AuthenticateToken(ctx, token)
+}
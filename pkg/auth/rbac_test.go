@@ -0,0 +1,154 @@
+package auth_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/mux"
+	rbacv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/rbac/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/rbac/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/services/rbac"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredRole(t *testing.T) {
+	for procedure, want := range map[string]string{
+		"/config.v1alpha1.ConfigService/GetConfig":        auth.RoleViewer,
+		"/config.v1alpha1.ConfigService/ValidateConfig":   auth.RoleViewer,
+		"/config.v1alpha1.AgentService/ListAgents":        auth.RoleViewer,
+		"/config.v1alpha1.ConfigService/PutConfig":        auth.RoleOperator,
+		"/config.v1alpha1.AgentService/RevokeAgent":       auth.RoleOperator,
+		"/bootstrap.v1alpha1.TokenService/CreateToken":    auth.RoleOperator,
+		"/rbac.v1alpha1.RBACService/ListAPIKeys":          auth.RoleAdmin,
+		"/audit.v1alpha1.AuditService/ListEntries":        auth.RoleAdmin,
+		"/rbac.v1alpha1.RBACService/GetCaller":            "",
+		"/config.v1alpha1.ConfigService/GetterIsNotARead": auth.RoleOperator,
+
+		// reads returning secrets or support archives
+		"/bootstrap.v1alpha1.TokenService/ListTokens":            auth.RoleOperator,
+		"/bootstrap.v1beta1.TokenService/ListTokens":             auth.RoleOperator,
+		"/bootstrap.v1alpha1.TokenService/GenerateInstallScript": auth.RoleOperator,
+		"/config.v1alpha1.AgentService/GetAgentSnapshot":         auth.RoleOperator,
+		"/config.v1alpha1.AgentService/ListAgentSnapshots":       auth.RoleViewer,
+	} {
+		role, authenticated := auth.RequiredRole(procedure)
+		assert.True(t, authenticated, procedure)
+		assert.Equal(t, want, role, procedure)
+	}
+	for _, procedure := range []string{
+		"/bootstrap.v1alpha1.BootstrapService/Bootstrap",
+		"/config.v1alpha1.GatewayService/Relay",
+		"/server.v1beta1.ServerService/GetServerCapabilities",
+	} {
+		_, authenticated := auth.RequiredRole(procedure)
+		assert.False(t, authenticated, procedure)
+	}
+}
+
+func TestPrincipal_Grants(t *testing.T) {
+	operator := &auth.Principal{Subject: "alice", Roles: []string{"team-a", auth.RoleOperator}}
+	assert.True(t, operator.Grants(auth.RoleViewer))
+	assert.True(t, operator.Grants(auth.RoleOperator))
+	assert.False(t, operator.Grants(auth.RoleAdmin))
+	assert.True(t, operator.Grants("team-a"))
+	assert.False(t, (&auth.Principal{Subject: "bob", Roles: []string{"team-a"}}).Grants(auth.RoleViewer))
+	assert.False(t, (*auth.Principal)(nil).Grants(auth.RoleViewer))
+}
+
+func TestInterceptor(t *testing.T) {
+	broker := memory.NewKVBroker()
+	keys := storage.NewProtoKV[*rbacv1alpha1.APIKey](slog.Default(), broker.KeyValue("api-keys"))
+	bindings := storage.NewProtoKV[*rbacv1alpha1.RoleBinding](slog.Default(), broker.KeyValue("role-bindings"))
+
+	srv := rbac.NewRBACServer(slog.Default(), keys, bindings)
+	srv.AddInterceptors(auth.NewInterceptor(slog.Default(), auth.NewRoleBindings(bindings, []string{"root"}), auth.NewAPIKeys(keys), nil))
+	router := mux.NewRouter()
+	srv.ConfigureHTTP(router)
+	ts := httptest.NewServer(auth.NewMiddleware(slog.Default(), auth.NewHeaderAuthenticator()).Wrap(router))
+	t.Cleanup(ts.Close)
+	client := v1alpha1connect.NewRBACServiceClient(ts.Client(), ts.URL)
+
+	call := func(headers http.Header) (*rbacv1alpha1.Caller, error) {
+		req := connect.NewRequest(&rbacv1alpha1.GetCallerRequest{})
+		for k, v := range headers {
+			req.Header()[k] = v
+		}
+		resp, err := client.GetCaller(t.Context(), req)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+	bearer := func(token string) http.Header {
+		return http.Header{"Authorization": {"Bearer " + token}}
+	}
+
+	_, err := call(nil)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	_, err = call(bearer("ofk_unknown_secret"))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	_, err = call(bearer("not-an-api-key"))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	// the configured admins manage API keys without a binding
+	root := http.Header{auth.HeaderUser: {"root"}}
+	caller, err := call(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{auth.RoleAdmin}, caller.GetRoles())
+	create := connect.NewRequest(&rbacv1alpha1.CreateAPIKeyRequest{Name: "ci", Roles: []string{auth.RoleOperator}})
+	create.Header().Set(auth.HeaderUser, "root")
+	created, err := client.CreateAPIKey(t.Context(), create)
+	require.NoError(t, err)
+
+	// callers presenting the key are granted its roles
+	caller, err = call(bearer(created.Msg.GetToken()))
+	require.NoError(t, err)
+	assert.Equal(t, created.Msg.GetKey().GetSubject(), caller.GetSubject())
+	assert.Equal(t, []string{auth.RoleOperator}, caller.GetRoles())
+	list := connect.NewRequest(&rbacv1alpha1.ListAPIKeysRequest{})
+	list.Header().Set("Authorization", "Bearer "+created.Msg.GetToken())
+	_, err = client.ListAPIKeys(t.Context(), list)
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	_, err = call(bearer(created.Msg.GetToken() + "0"))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	del := connect.NewRequest(&rbacv1alpha1.DeleteAPIKeyRequest{Id: created.Msg.GetKey().GetId()})
+	del.Header().Set(auth.HeaderUser, "root")
+	_, err = client.DeleteAPIKey(t.Context(), del)
+	require.NoError(t, err)
+	_, err = call(bearer(created.Msg.GetToken()))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+}
+
+func TestInterceptor_Middleware(t *testing.T) {
+	broker := memory.NewKVBroker()
+	keys := storage.NewProtoKV[*rbacv1alpha1.APIKey](slog.Default(), broker.KeyValue("api-keys"))
+	bindings := storage.NewProtoKV[*rbacv1alpha1.RoleBinding](slog.Default(), broker.KeyValue("role-bindings"))
+	interceptor := auth.NewInterceptor(slog.Default(), auth.NewRoleBindings(bindings, []string{"root"}), auth.NewAPIKeys(keys), nil)
+	handler := auth.NewMiddleware(slog.Default(), auth.NewHeaderAuthenticator()).Wrap(
+		interceptor.Middleware(auth.RoleViewer).Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.NotNil(t, auth.FromContext(r.Context()))
+		})),
+	)
+	get := func(header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/export", nil)
+		req.Header = header
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get(http.Header{})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, http.StatusUnauthorized, get(http.Header{"Authorization": {"Bearer ofk_unknown_secret"}}).Code)
+	assert.Equal(t, http.StatusForbidden, get(http.Header{auth.HeaderUser: {"bob"}, auth.HeaderRoles: {"team-a"}}).Code)
+	assert.Equal(t, http.StatusOK, get(http.Header{auth.HeaderUser: {"root"}}).Code)
+}
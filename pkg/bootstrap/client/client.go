@@ -5,6 +5,7 @@ package client
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/tls"
 	"log/slog"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 	"github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
 	"github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1/v1alpha1connect"
 	"github.com/otelfleet/otelfleet/pkg/ident"
+	"github.com/otelfleet/otelfleet/pkg/keyring"
 )
 
 // Bootstrapper defines the interface for agent bootstrap operations.
@@ -38,6 +40,11 @@ type BootstrapRequest struct {
 
 	// ClientPubKey is the agent's ephemeral public key for ECDH (secure mode only).
 	ClientPubKey []byte
+
+	// ClientKey, if set, is the key the client certificate is requested for (secure mode only),
+	// otherwise a key is generated. Registered agents renew their certificate for the key of
+	// their current certificate.
+	ClientKey *ecdsa.PrivateKey
 }
 
 // BootstrapResult contains the result of a successful bootstrap.
@@ -47,6 +54,10 @@ type BootstrapResult struct {
 
 	// ServerPubKey is the server's ephemeral public key (secure mode only).
 	ServerPubKey []byte
+
+	// Keyring holds the keys and certificates negotiated with the server (secure mode only),
+	// which can be persisted to restore the TLS configuration without bootstrapping again.
+	Keyring keyring.Keyring
 }
 
 // Config holds the configuration for creating a bootstrap client.
@@ -133,6 +144,18 @@ func (c *Client) VerifyToken(ctx context.Context, token string) error {
 	return c.bootstrapper.VerifyToken(ctx, token)
 }
 
+// CheckToken asks the server whether agents can bootstrap with the token, without
+// consuming a use of it. The token is presented like in Bootstrap.
+func (c *Client) CheckToken(ctx context.Context, token string) (*v1alpha1.CheckTokenResponse, error) {
+	req := connect.NewRequest(&v1alpha1.CheckTokenRequest{})
+	req.Header().Set("Authorization", token)
+	resp, err := c.bootstrapClient.CheckToken(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
 // Bootstrap registers the agent with the server.
 func (c *Client) Bootstrap(ctx context.Context, req *BootstrapRequest) (*BootstrapResult, error) {
 	return c.bootstrapper.Bootstrap(ctx, req)
@@ -2,11 +2,22 @@ package client
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
 	"log/slog"
 	"net/http"
 
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
 	"github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/ecdh"
+	"github.com/otelfleet/otelfleet/pkg/keyring"
+	"google.golang.org/protobuf/proto"
 )
 
 // secureBootstrapper implements Bootstrapper with full cryptographic verification.
@@ -43,13 +54,131 @@ func (b *secureBootstrapper) VerifyToken(ctx context.Context, token string) erro
 	return nil
 }
 
+// Bootstrap agrees on a shared secret with the server and requests a client certificate for a
+// key generated for the agent, which the server returns sealed with the shared secret.
 func (b *secureBootstrapper) Bootstrap(ctx context.Context, req *BootstrapRequest) (*BootstrapResult, error) {
-	// TODO: Implement secure bootstrap with ECDH key exchange
-	// This should:
-	// 1. Generate ephemeral key pair
-	// 2. Send bootstrap request with client public key
-	// 3. Receive server public key
-	// 4. Derive shared secret
-	// 5. Build TLS config from shared secret
-	return nil, fmt.Errorf("secure bootstrap not yet implemented - use insecure mode for development")
+	ekp := ecdh.NewEphemeralKeyPair()
+	key := req.ClientKey
+	if key == nil {
+		var err error
+		if key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			return nil, fmt.Errorf("failed to generate client key: %w", err)
+		}
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: req.ClientID},
+	}, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate request: %w", err)
+	}
+
+	connectReq := connect.NewRequest(&v1alpha1.BootstrapAuthRequest{
+		ClientId:           req.ClientID,
+		Name:               req.Name,
+		ClientPubKey:       ekp.PublicKey.Bytes(),
+		CertificateRequest: csr,
+	})
+	connectReq.Header().Set("Authorization", req.Token)
+
+	b.logger.With("client_id", req.ClientID, "name", req.Name).Debug("bootstrapping agent")
+
+	resp, err := b.bClient.Bootstrap(ctx, connectReq)
+	if err != nil {
+		return nil, err
+	}
+	serverPubKey, err := ecdh.ServerPubKey(resp.Msg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server public key: %w", err)
+	}
+	sharedSecret, err := ecdh.DeriveSharedSecret(ekp, serverPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive shared secret: %w", err)
+	}
+
+	keys := []any{keyring.NewSharedKeys(sharedSecret)}
+	if len(resp.Msg.GetCredentials()) == 0 {
+		b.logger.Warn("server issued no client certificate, connecting without one")
+	} else {
+		creds, err := openCredentials(sharedSecret, resp.Msg.GetCredentials(), key)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, creds...)
+	}
+	kr := keyring.New(keys...)
+	tlsConfig, err := TLSConfig(kr, b.rootCAs())
+	if err != nil {
+		return nil, err
+	}
+
+	return &BootstrapResult{
+		TLSConfig:    tlsConfig,
+		ServerPubKey: resp.Msg.GetServerPubKey(),
+		Keyring:      kr,
+	}, nil
+}
+
+// TLSConfig returns the TLS configuration of the OpAMP connection, presenting the client
+// certificate in the keyring if there is one, and verifying the server against rootCAs, or the
+// system roots if nil.
+func TLSConfig(kr keyring.Keyring, rootCAs *x509.CertPool) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+	}
+	var err error
+	kr.Try(func(key *keyring.ClientCertKey) {
+		var cert tls.Certificate
+		if cert, err = key.TLSCertificate(); err == nil {
+			tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate in keyring: %w", err)
+	}
+	return tlsConfig, nil
+}
+
+// rootCAs returns the roots the HTTP client verifies the server with, so that the OpAMP
+// connection trusts the same server certificates
+func (b *secureBootstrapper) rootCAs() *x509.CertPool {
+	if t, ok := b.httpClient.Transport.(*http.Transport); ok && t.TLSClientConfig != nil {
+		return t.TLSClientConfig.RootCAs
+	}
+	return nil
+}
+
+// openCredentials opens the credentials sealed by the server and returns the keys of the client
+// certificate issued for key and of the agent CA
+func openCredentials(sharedSecret, sealed []byte, key *ecdsa.PrivateKey) ([]any, error) {
+	data, err := ecdh.Open(sharedSecret, sealed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open credentials: %w", err)
+	}
+	creds := &v1alpha1.BootstrapCredentials{}
+	if err := proto.Unmarshal(data, creds); err != nil {
+		return nil, fmt.Errorf("invalid credentials: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(creds.GetCertificate())
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate: %w", err)
+	}
+	if pub, ok := leaf.PublicKey.(*ecdsa.PublicKey); !ok || !pub.Equal(&key.PublicKey) {
+		return nil, fmt.Errorf("client certificate was not issued for the requested key")
+	}
+	ca, err := x509.ParseCertificate(creds.GetCaCertificate())
+	if err != nil {
+		return nil, fmt.Errorf("invalid agent CA certificate: %w", err)
+	}
+	if err := leaf.CheckSignatureFrom(ca); err != nil {
+		return nil, fmt.Errorf("client certificate was not issued by the agent CA: %w", err)
+	}
+	certKey, err := keyring.NewClientCertKey(tls.Certificate{
+		Certificate: [][]byte{leaf.Raw},
+		PrivateKey:  key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []any{certKey, keyring.NewCACertsKey([]*x509.Certificate{ca})}, nil
 }
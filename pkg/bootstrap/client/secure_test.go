@@ -0,0 +1,120 @@
+package client_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/agentca"
+	bootstrapv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/bootstrap"
+	bootstrapclient "github.com/otelfleet/otelfleet/pkg/bootstrap/client"
+	"github.com/otelfleet/otelfleet/pkg/keyring"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAgentCA(t *testing.T) *agentca.Authority {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "otelfleet agents"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	ca, err := agentca.New(cert, key)
+	require.NoError(t, err)
+	return ca
+}
+
+func TestSecureBootstrap(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := t.Context()
+	client := bootstrapclient.NewSecure(bootstrapclient.Config{
+		Logger:     env.Logger,
+		ServerURL:  env.BaseURL,
+		HTTPClient: env.HTTPServer.Client(),
+	})
+	// enrollment tokens are verified without the server signing key
+	enroll := func(agentID string, key *ecdsa.PrivateKey) (*bootstrapclient.BootstrapResult, error) {
+		resp, err := env.BootstrapServer.CreateEnrollmentURL(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateEnrollmentURLRequest{
+			BaseURL: env.BaseURL,
+		}))
+		require.NoError(t, err)
+		_, signed, err := bootstrap.ParseEnrollmentURL(resp.Msg.GetUrl())
+		require.NoError(t, err)
+		return client.Bootstrap(ctx, &bootstrapclient.BootstrapRequest{
+			ClientID:  agentID,
+			Name:      agentID,
+			Token:     bootstrap.EnrollmentAuthorization(signed),
+			ClientKey: key,
+		})
+	}
+
+	t.Run("without an agent CA", func(t *testing.T) {
+		result, err := enroll("agent-1", nil)
+		require.NoError(t, err)
+		require.NotNil(t, result.TLSConfig)
+		assert.Empty(t, result.TLSConfig.Certificates)
+		assert.NotEmpty(t, result.ServerPubKey)
+	})
+
+	t.Run("issues a client certificate", func(t *testing.T) {
+		ca := newAgentCA(t)
+		env.BootstrapServer.SetAgentCA(ca)
+		defer env.BootstrapServer.SetAgentCA(nil)
+
+		result, err := enroll("agent-2", nil)
+		require.NoError(t, err)
+		require.Len(t, result.TLSConfig.Certificates, 1)
+		cert := result.TLSConfig.Certificates[0]
+		assert.Equal(t, "agent-2", agentca.AgentID(cert.Leaf))
+		_, err = cert.Leaf.Verify(x509.VerifyOptions{
+			Roots:     ca.Pool(),
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		})
+		assert.NoError(t, err)
+
+		// the keyring restores the same configuration
+		tlsConfig, err := bootstrapclient.TLSConfig(result.Keyring, nil)
+		require.NoError(t, err)
+		require.Len(t, tlsConfig.Certificates, 1)
+		assert.Equal(t, cert.Certificate, tlsConfig.Certificates[0].Certificate)
+		assert.Equal(t, cert.PrivateKey, tlsConfig.Certificates[0].PrivateKey)
+		assert.True(t, result.Keyring.Try(func(*keyring.CACertsKey) {}))
+
+		// registered agents only renew their certificate for the key they hold
+		_, err = enroll("agent-2", nil)
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+		renewed, err := enroll("agent-2", cert.PrivateKey.(*ecdsa.PrivateKey))
+		require.NoError(t, err)
+		require.Len(t, renewed.TLSConfig.Certificates, 1)
+		assert.Equal(t, "agent-2", agentca.AgentID(renewed.TLSConfig.Certificates[0].Leaf))
+
+		// agents registered without a certificate can't be issued one
+		_, err = enroll("agent-1", nil)
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+
+	t.Run("refuses agents of another tenant", func(t *testing.T) {
+		require.NoError(t, env.AgentRepo.RegisterInTenant(ctx, "agent-3", "agent-3", "team-a"))
+		_, err := enroll("agent-3", nil)
+		assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	})
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/ecdh"
+	"github.com/otelfleet/otelfleet/pkg/keyring"
+)
+
+// EnrollResult contains the result of a successful SPIFFE enrollment.
+type EnrollResult struct {
+	// AgentID is the agent identity the server derived from the SVID.
+	AgentID string
+
+	// SPIFFEID is the SPIFFE ID the server authenticated.
+	SPIFFEID string
+
+	// ServerPubKey is the server's ephemeral public key.
+	ServerPubKey []byte
+
+	// Keyring holds the shared keys agreed on with the server (secure mode only).
+	Keyring keyring.Keyring
+}
+
+// Enroll registers the agent using the X.509 SVID presented by the client's
+// HTTP transport, skipping token bootstrap entirely. The configured HTTPClient
+// must present the SVID as its TLS client certificate.
+func (c *Client) Enroll(ctx context.Context, name string) (*EnrollResult, error) {
+	c.logger.With("name", name).Debug("enrolling agent with SVID")
+
+	ekp := ecdh.NewEphemeralKeyPair()
+	resp, err := c.bootstrapClient.Enroll(ctx, connect.NewRequest(&v1alpha1.EnrollRequest{
+		Name:         name,
+		ClientPubKey: ekp.PublicKey.Bytes(),
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result := &EnrollResult{
+		AgentID:      resp.Msg.GetAgentId(),
+		SPIFFEID:     resp.Msg.GetSpiffeId(),
+		ServerPubKey: resp.Msg.GetServerPubKey(),
+	}
+	// insecure servers don't agree on a shared secret
+	if len(result.ServerPubKey) == 0 {
+		c.logger.Warn("server agreed on no shared secret")
+		return result, nil
+	}
+	serverPubKey, err := ecdh.ServerPubKey(resp.Msg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server public key: %w", err)
+	}
+	sharedSecret, err := ecdh.DeriveSharedSecret(ekp, serverPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive shared secret: %w", err)
+	}
+
+	result.Keyring = keyring.New(keyring.NewSharedKeys(sharedSecret))
+	return result, nil
+}
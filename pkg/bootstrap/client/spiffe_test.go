@@ -0,0 +1,99 @@
+//go:build !insecure
+
+package client_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	bootstrapclient "github.com/otelfleet/otelfleet/pkg/bootstrap/client"
+	"github.com/otelfleet/otelfleet/pkg/keyring"
+	"github.com/otelfleet/otelfleet/pkg/spiffe"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSVID returns a trust bundle and an X.509 SVID issued by it to id
+func newSVID(t *testing.T, id string) (*x509.CertPool, tls.Certificate) {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "spiffe ca"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	uri, err := url.Parse(id)
+	require.NoError(t, err)
+	der, err = x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		URIs:         []*url.URL{uri},
+	}, caCert, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	bundle := x509.NewCertPool()
+	bundle.AddCert(caCert)
+	return bundle, tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestSecureEnroll(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	bundle, svid := newSVID(t, "spiffe://example.org/collector")
+	auth := spiffe.NewAuthenticatorWithBundle(env.Logger, "example.org", bundle)
+	env.BootstrapServer.SetSPIFFEAuthenticator(auth)
+
+	router := mux.NewRouter()
+	env.BootstrapServer.ConfigureHTTP(router)
+	srv := httptest.NewUnstartedServer(auth.Wrap(router))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	httpClient := srv.Client()
+	httpClient.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{svid}
+	client := bootstrapclient.NewSecure(bootstrapclient.Config{
+		Logger:     env.Logger,
+		ServerURL:  srv.URL,
+		HTTPClient: httpClient,
+	})
+
+	result, err := client.Enroll(t.Context(), "collector")
+	require.NoError(t, err)
+	assert.Equal(t, "spiffe://example.org/collector", result.SPIFFEID)
+	assert.NotEmpty(t, result.ServerPubKey)
+	require.NotNil(t, result.Keyring)
+	var shared *keyring.SharedKeys
+	result.Keyring.Try(func(keys *keyring.SharedKeys) { shared = keys })
+	assert.NotNil(t, shared, "the client agrees on a shared secret with the server")
+
+	agent, err := env.AgentRepo.Get(t.Context(), result.AgentID)
+	require.NoError(t, err)
+	assert.Equal(t, "collector", agent.FriendlyName)
+}
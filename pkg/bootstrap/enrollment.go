@@ -0,0 +1,105 @@
+package bootstrap
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// EnrollmentPath is the path of enrollment URLs, followed by the signed enrollment token
+	EnrollmentPath = "/v1/enroll/"
+	// EnrollmentScheme is the Authorization scheme agents present signed enrollment tokens with
+	EnrollmentScheme = "Enrollment"
+)
+
+var (
+	ErrEnrollmentExpired    = errors.New("enrollment token expired")
+	ErrInvalidEnrollmentMAC = errors.New("invalid enrollment token signature")
+)
+
+// EnrollmentToken is an expiring reference to a bootstrap token, signed with the
+// token's secret so that it can be embedded in a URL without exposing the secret.
+type EnrollmentToken struct {
+	ID     string
+	Expiry time.Time
+	MAC    []byte
+}
+
+func (t *Token) enrollmentMAC(expiry time.Time) []byte {
+	mac := hmac.New(sha256.New, t.Secret)
+	fmt.Fprintf(mac, "%s.%d", t.HexID(), expiry.Unix())
+	return mac.Sum(nil)
+}
+
+// SignEnrollment returns the enrollment token for t valid until expiry, encoded as
+// <id>.<expiry unix seconds>.<signature>
+func (t *Token) SignEnrollment(expiry time.Time) string {
+	return fmt.Sprintf("%s.%d.%s", t.HexID(), expiry.Unix(), base64.RawURLEncoding.EncodeToString(t.enrollmentMAC(expiry)))
+}
+
+// VerifyEnrollment checks the enrollment token was signed by t and hasn't expired at now
+func (t *Token) VerifyEnrollment(e *EnrollmentToken, now time.Time) error {
+	if e.ID != t.HexID() || !hmac.Equal(e.MAC, t.enrollmentMAC(e.Expiry)) {
+		return ErrInvalidEnrollmentMAC
+	}
+	if !now.Before(e.Expiry) {
+		return ErrEnrollmentExpired
+	}
+	return nil
+}
+
+// ParseEnrollment parses an enrollment token created by SignEnrollment
+func ParseEnrollment(str string) (*EnrollmentToken, error) {
+	parts := strings.Split(str, ".")
+	if len(parts) != 3 || len(parts[0]) != 12 {
+		return nil, ErrMalformedToken
+	}
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	return &EnrollmentToken{
+		ID:     parts[0],
+		Expiry: time.Unix(expiry, 0),
+		MAC:    mac,
+	}, nil
+}
+
+// EnrollmentAuthorization returns the Authorization header value presenting a signed enrollment token
+func EnrollmentAuthorization(signed string) string {
+	return EnrollmentScheme + " " + signed
+}
+
+// EnrollmentURL returns the enrollment URL of a signed enrollment token on the server at baseURL
+func EnrollmentURL(baseURL, signed string) string {
+	return strings.TrimSuffix(baseURL, "/") + EnrollmentPath + signed
+}
+
+// ParseEnrollmentURL splits an enrollment URL into the base URL of the server and the
+// signed enrollment token
+func ParseEnrollmentURL(raw string) (baseURL, signed string, err error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid enrollment URL: %w", err)
+	}
+	prefix, signed, ok := strings.Cut(u.Path, EnrollmentPath)
+	if !ok || signed == "" || strings.Contains(signed, "/") || u.Scheme == "" || u.Host == "" {
+		return "", "", fmt.Errorf("invalid enrollment URL %q, expected <server>%s<token>", raw, EnrollmentPath)
+	}
+	if _, err := ParseEnrollment(signed); err != nil {
+		return "", "", err
+	}
+	u.Path, u.RawPath, u.RawQuery, u.Fragment = prefix, "", "", ""
+	return u.String(), signed, nil
+}
@@ -0,0 +1,62 @@
+package bootstrap
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// FormatLabels encodes labels as comma separated key=value pairs, sorted by key, with keys
+// and values URL query escaped
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, url.QueryEscape(k)+"="+url.QueryEscape(labels[k]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// ParseLabels decodes labels encoded by FormatLabels
+func ParseLabels(str string) (map[string]string, error) {
+	labels := map[string]string{}
+	if str == "" {
+		return labels, nil
+	}
+	for _, pair := range strings.Split(str, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid label %q, expected key=value", pair)
+		}
+		key, err := url.QueryUnescape(k)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label %q: %w", pair, err)
+		}
+		value, err := url.QueryUnescape(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label %q: %w", pair, err)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+// OpAMPURL returns the OpAMP endpoint of a server serving OpAMP on its HTTP API listener
+func OpAMPURL(serverURL string) (string, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid server URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return "", fmt.Errorf("invalid server URL %q, expected an http or https URL", serverURL)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/v1/opamp"
+	u.RawPath, u.RawQuery, u.Fragment = "", "", ""
+	return u.String(), nil
+}
@@ -6,9 +6,11 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/otelfleet/otelfleet/pkg/bootstrap"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSigningTokens(t *testing.T) {
@@ -35,3 +37,51 @@ func TestSigningTokens(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(expectedData, encoded)
 }
+
+func TestEnrollmentTokens(t *testing.T) {
+	token := bootstrap.NewToken()
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	signed := token.SignEnrollment(expiry)
+	assert.NotContains(t, signed, token.HexSecret())
+
+	enrollment, err := bootstrap.ParseEnrollment(signed)
+	require.NoError(t, err)
+	assert.Equal(t, token.HexID(), enrollment.ID)
+	assert.True(t, expiry.Equal(enrollment.Expiry))
+	assert.NoError(t, token.VerifyEnrollment(enrollment, time.Now()))
+	assert.ErrorIs(t, token.VerifyEnrollment(enrollment, expiry), bootstrap.ErrEnrollmentExpired)
+	assert.ErrorIs(t, bootstrap.NewToken().VerifyEnrollment(enrollment, time.Now()), bootstrap.ErrInvalidEnrollmentMAC)
+
+	// extending the expiry invalidates the signature
+	enrollment.Expiry = enrollment.Expiry.Add(time.Hour)
+	assert.ErrorIs(t, token.VerifyEnrollment(enrollment, time.Now()), bootstrap.ErrInvalidEnrollmentMAC)
+
+	_, err = bootstrap.ParseEnrollment(token.EncodeToHex())
+	assert.ErrorIs(t, err, bootstrap.ErrMalformedToken)
+
+	url := bootstrap.EnrollmentURL("https://otelfleet.example.com/fleet/", signed)
+	assert.Equal(t, "https://otelfleet.example.com/fleet/v1/enroll/"+signed, url)
+	baseURL, parsed, err := bootstrap.ParseEnrollmentURL(url)
+	require.NoError(t, err)
+	assert.Equal(t, "https://otelfleet.example.com/fleet", baseURL)
+	assert.Equal(t, signed, parsed)
+	_, _, err = bootstrap.ParseEnrollmentURL("https://otelfleet.example.com/v1/agents")
+	assert.Error(t, err)
+}
+
+func TestInstallSettings(t *testing.T) {
+	labels := map[string]string{"env": "prod", "team": "a=b,c"}
+	encoded := bootstrap.FormatLabels(labels)
+	assert.Equal(t, "env=prod,team=a%3Db%2Cc", encoded)
+	decoded, err := bootstrap.ParseLabels(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, labels, decoded)
+	_, err = bootstrap.ParseLabels("env")
+	assert.Error(t, err)
+
+	opampURL, err := bootstrap.OpAMPURL("https://otelfleet.example.com/fleet/")
+	require.NoError(t, err)
+	assert.Equal(t, "wss://otelfleet.example.com/fleet/v1/opamp", opampURL)
+	_, err = bootstrap.OpAMPURL("otelfleet.example.com")
+	assert.Error(t, err)
+}
@@ -0,0 +1,228 @@
+// Package client is a Go SDK for managing an OtelFleet server programmatically.
+// It wraps the Connect clients of the management APIs with authentication headers,
+// retries of transient failures, and helpers for paging, watching and waiting.
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/cenkalti/backoff/v4"
+	adminv1alpha1connect "github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1/v1alpha1connect"
+	bootstrapv1alpha1connect "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1/v1alpha1connect"
+	configv1alpha1connect "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1/v1alpha1connect"
+	eventsv1alpha1connect "github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1/v1alpha1connect"
+	jobsv1alpha1connect "github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1/v1alpha1connect"
+	rbacv1alpha1connect "github.com/otelfleet/otelfleet/pkg/api/rbac/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/requestid"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+)
+
+const (
+	// DefaultMaxRetries is how many times a failed call is retried when Config.MaxRetries is 0
+	DefaultMaxRetries = 3
+	// DefaultRetryInterval is the initial wait before retrying a failed call
+	DefaultRetryInterval = 250 * time.Millisecond
+)
+
+// Config holds the configuration for creating a fleet Client.
+type Config struct {
+	// Logger for client operations. If nil, slog.Default() is used.
+	Logger *slog.Logger
+
+	// ServerURL is the base URL of the OtelFleet server (e.g., "http://127.0.0.1:16587").
+	ServerURL string
+
+	// HTTPClient is the HTTP client to use. If nil, http.DefaultClient is used.
+	HTTPClient *http.Client
+
+	// BearerToken, if set, is sent as the Authorization header of every request,
+	// for servers behind an authenticating proxy.
+	BearerToken string
+
+	// Tenant, if set, selects the tenant of every request, see package tenant.
+	Tenant string
+
+	// Headers are added to every request.
+	Headers http.Header
+
+	// MaxRetries is how many times calls failing with a transient error are retried.
+	// If 0, DefaultMaxRetries is used; a negative value disables retries.
+	MaxRetries int
+
+	// RetryInterval is the initial wait between retries, doubling on each attempt.
+	// If 0, DefaultRetryInterval is used.
+	RetryInterval time.Duration
+}
+
+// Client is a client for the OtelFleet management APIs.
+// Deployments are managed through the Configs client.
+type Client struct {
+	Agents  v1alpha1connect.AgentServiceClient
+	Configs configv1alpha1connect.ConfigServiceClient
+	Tokens  bootstrapv1alpha1connect.TokenServiceClient
+	Events  eventsv1alpha1connect.EventServiceClient
+	Jobs    jobsv1alpha1connect.JobServiceClient
+	Admin   adminv1alpha1connect.AdminServiceClient
+	// RBAC manages the API keys and role bindings of the callers of servers in RBAC mode
+	RBAC rbacv1alpha1connect.RBACServiceClient
+	// Gateway relays the agents of regional gateways
+	Gateway v1alpha1connect.GatewayServiceClient
+
+	logger        *slog.Logger
+	serverURL     string
+	maxRetries    int
+	retryInterval time.Duration
+}
+
+// New creates a new fleet client with the given configuration.
+func New(cfg Config) (*Client, error) {
+	if cfg.ServerURL == "" {
+		return nil, errors.New("server URL is required")
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	c := &Client{
+		logger:        logger,
+		maxRetries:    cfg.MaxRetries,
+		retryInterval: cfg.RetryInterval,
+	}
+	if c.maxRetries == 0 {
+		c.maxRetries = DefaultMaxRetries
+	}
+	if c.retryInterval <= 0 {
+		c.retryInterval = DefaultRetryInterval
+	}
+
+	headers := cfg.Headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	if cfg.BearerToken != "" {
+		headers.Set("Authorization", "Bearer "+cfg.BearerToken)
+	}
+	if cfg.Tenant != "" {
+		headers.Set(tenant.Header, cfg.Tenant)
+	}
+	opts := connect.WithInterceptors(&headerInterceptor{headers: headers}, connect.UnaryInterceptorFunc(c.retryUnary))
+
+	serverURL := strings.TrimRight(cfg.ServerURL, "/")
+	c.serverURL = serverURL
+	c.Agents = v1alpha1connect.NewAgentServiceClient(httpClient, serverURL, opts)
+	c.Configs = configv1alpha1connect.NewConfigServiceClient(httpClient, serverURL, opts)
+	c.Tokens = bootstrapv1alpha1connect.NewTokenServiceClient(httpClient, serverURL, opts)
+	c.Events = eventsv1alpha1connect.NewEventServiceClient(httpClient, serverURL, opts)
+	c.Jobs = jobsv1alpha1connect.NewJobServiceClient(httpClient, serverURL, opts)
+	c.Admin = adminv1alpha1connect.NewAdminServiceClient(httpClient, serverURL, opts)
+	c.RBAC = rbacv1alpha1connect.NewRBACServiceClient(httpClient, serverURL, opts)
+	c.Gateway = v1alpha1connect.NewGatewayServiceClient(httpClient, serverURL, opts)
+	return c, nil
+}
+
+// RequestID returns the ID of the server request that failed with err, to correlate it
+// with the server logs and events, or an empty string if the server did not return one.
+func RequestID(err error) string {
+	return requestid.FromError(err)
+}
+
+// IsTransient returns true if err is a failure that may succeed when retried,
+// such as the server being unavailable or rate limiting the caller.
+func IsTransient(err error) bool {
+	switch connect.CodeOf(err) {
+	case connect.CodeUnavailable, connect.CodeResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// newBackOff returns the backoff used between retries, bounded by maxRetries and ctx
+func (c *Client) newBackOff(ctx context.Context) backoff.BackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = c.retryInterval
+	bo.MaxElapsedTime = 0
+	var b backoff.BackOff = bo
+	if c.maxRetries > 0 {
+		b = backoff.WithMaxRetries(b, uint64(c.maxRetries))
+	}
+	return backoff.WithContext(b, ctx)
+}
+
+func (c *Client) retryUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if c.maxRetries < 0 {
+			return next(ctx, req)
+		}
+		bo := c.newBackOff(ctx)
+		for {
+			resp, err := next(ctx, req)
+			if err == nil || !IsTransient(err) {
+				return resp, err
+			}
+			wait := bo.NextBackOff()
+			if wait == backoff.Stop {
+				return nil, err
+			}
+			c.logger.With("procedure", req.Spec().Procedure, "err", err, "wait", wait).Debug("retrying call")
+			select {
+			case <-ctx.Done():
+				return nil, err
+			case <-time.After(wait):
+			}
+		}
+	}
+}
+
+// headerInterceptor adds static headers to every request
+type headerInterceptor struct {
+	headers http.Header
+}
+
+var _ connect.Interceptor = (*headerInterceptor)(nil)
+
+func (h *headerInterceptor) set(dst http.Header) {
+	for key, values := range h.headers {
+		dst[key] = values
+	}
+}
+
+func (h *headerInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		h.set(req.Header())
+		setRequestID(ctx, req.Header())
+		return next(ctx, req)
+	}
+}
+
+func (h *headerInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		h.set(conn.RequestHeader())
+		setRequestID(ctx, conn.RequestHeader())
+		return conn
+	}
+}
+
+// setRequestID propagates the request ID of ctx, if any, so that the calls made while
+// handling a request can be correlated with it
+func setRequestID(ctx context.Context, dst http.Header) {
+	if id := requestid.FromContext(ctx); id != "" {
+		dst.Set(requestid.Header, id)
+	}
+}
+
+func (h *headerInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
@@ -0,0 +1,193 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	eventsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/client"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEvents serves 5 events in pages of 2, failing the first call of each method
+// with a transient error
+type fakeEvents struct {
+	v1alpha1connect.UnimplementedEventServiceHandler
+
+	mu         sync.Mutex
+	calls      map[string]int
+	authHeader string
+}
+
+func (f *fakeEvents) failFirst(method string, header http.Header) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.authHeader = header.Get("Authorization")
+	f.calls[method]++
+	if f.calls[method] == 1 {
+		return connect.NewError(connect.CodeUnavailable, errors.New("try again"))
+	}
+	return nil
+}
+
+func event(seq int) *eventsv1alpha1.Event {
+	return &eventsv1alpha1.Event{Sequence: uint64(seq), Type: "agent.connected"}
+}
+
+func (f *fakeEvents) ListEvents(_ context.Context, req *connect.Request[eventsv1alpha1.ListEventsRequest]) (*connect.Response[eventsv1alpha1.ListEventsResponse], error) {
+	if err := f.failFirst("list", req.Header()); err != nil {
+		return nil, err
+	}
+	start, _ := strconv.Atoi(req.Msg.GetPageToken())
+	resp := &eventsv1alpha1.ListEventsResponse{}
+	for seq := start; seq < min(start+2, 5); seq++ {
+		resp.Events = append(resp.Events, event(seq))
+	}
+	if start+2 < 5 {
+		resp.NextPageToken = strconv.Itoa(start + 2)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (f *fakeEvents) WatchEvents(_ context.Context, req *connect.Request[eventsv1alpha1.WatchEventsRequest], stream *connect.ServerStream[eventsv1alpha1.WatchEventsResponse]) error {
+	start := 0
+	if token := req.Msg.GetResumeToken(); token != "" {
+		last, _ := strconv.Atoi(token)
+		start = last + 1
+	}
+	// each stream ends after two events, so the client has to resume
+	for seq := start; seq < start+2; seq++ {
+		if err := stream.Send(&eventsv1alpha1.WatchEventsResponse{
+			Event:       event(seq),
+			ResumeToken: strconv.Itoa(seq),
+		}); err != nil {
+			return err
+		}
+	}
+	return connect.NewError(connect.CodeUnavailable, errors.New("server restarting"))
+}
+
+func newEventsClient(t *testing.T) (*client.Client, *fakeEvents) {
+	t.Helper()
+	fake := &fakeEvents{calls: map[string]int{}}
+	mux := http.NewServeMux()
+	mux.Handle(v1alpha1connect.NewEventServiceHandler(fake))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	c, err := client.New(client.Config{
+		ServerURL:     srv.URL,
+		BearerToken:   "secret",
+		RetryInterval: time.Millisecond,
+	})
+	require.NoError(t, err)
+	return c, fake
+}
+
+func TestListAllEvents(t *testing.T) {
+	c, fake := newEventsClient(t)
+
+	events, err := c.ListAllEvents(t.Context(), nil)
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+	for i, event := range events {
+		assert.EqualValues(t, i, event.GetSequence())
+	}
+	// one retried failure, then three pages
+	assert.Equal(t, 4, fake.calls["list"])
+	assert.Equal(t, "Bearer secret", fake.authHeader)
+}
+
+func TestWatchEventsResumes(t *testing.T) {
+	c, _ := newEventsClient(t)
+
+	var seqs []uint64
+	done := errors.New("done")
+	err := c.WatchEvents(t.Context(), nil, func(event *eventsv1alpha1.Event) error {
+		seqs = append(seqs, event.GetSequence())
+		if len(seqs) == 5 {
+			return done
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, done)
+	assert.Equal(t, []uint64{0, 1, 2, 3, 4}, seqs)
+}
+
+func TestConfigs(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	c, err := client.New(client.Config{ServerURL: env.BaseURL})
+	require.NoError(t, err)
+
+	_, err = c.Configs.GetConfig(t.Context(), connect.NewRequest(&configv1alpha1.ConfigReference{Id: "missing"}))
+	require.Error(t, err)
+	assert.False(t, client.IsTransient(err))
+
+	_, err = c.Configs.PutConfig(t.Context(), connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: "logs"},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {}")},
+	}))
+	require.NoError(t, err)
+	resp, err := c.Configs.GetConfig(t.Context(), connect.NewRequest(&configv1alpha1.ConfigReference{Id: "logs"}))
+	require.NoError(t, err)
+	assert.Equal(t, "receivers: {}", string(resp.Msg.GetConfig()))
+
+	_, err = client.New(client.Config{})
+	assert.Error(t, err)
+}
+
+func TestCopyConfig(t *testing.T) {
+	staging := testutil.NewTestEnv(t)
+	prod := testutil.NewTestEnv(t)
+	src, err := client.New(client.Config{ServerURL: staging.BaseURL})
+	require.NoError(t, err)
+	dst, err := client.New(client.Config{ServerURL: prod.BaseURL})
+	require.NoError(t, err)
+
+	_, err = src.Configs.PutConfig(t.Context(), connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref: &configv1alpha1.ConfigReference{Id: "logs"},
+		Config: &configv1alpha1.Config{
+			Config: []byte("receivers: {}"),
+			Metadata: &configv1alpha1.ConfigMetadata{
+				Owner:       "team-a",
+				Tags:        []string{"logs"},
+				Annotations: map[string]string{"ticket": "OPS-1"},
+			},
+		},
+	}))
+	require.NoError(t, err)
+
+	copied, err := src.CopyConfig(t.Context(), dst, "logs", "")
+	require.NoError(t, err)
+	assert.Equal(t, "receivers: {}", string(copied.GetConfig()))
+	md := copied.GetMetadata()
+	assert.Equal(t, "team-a", md.GetOwner())
+	assert.Equal(t, []string{"logs"}, md.GetTags())
+	assert.NotNil(t, md.GetAudit().GetCreatedAt())
+	annotations := md.GetAnnotations()
+	assert.Equal(t, "OPS-1", annotations["ticket"])
+	assert.Equal(t, staging.BaseURL, annotations[client.AnnotationSourceServer])
+	assert.Equal(t, "logs", annotations[client.AnnotationSourceConfig])
+	assert.Len(t, annotations[client.AnnotationSourceHash], 64)
+	assert.NotEmpty(t, annotations[client.AnnotationSourceModifiedAt])
+	assert.Equal(t, staging.BaseURL+"/logs@"+annotations[client.AnnotationSourceHash][:12], annotations[client.AnnotationLineage])
+
+	// copying the copy extends the lineage
+	again, err := dst.CopyConfig(t.Context(), src, "logs", "logs-promoted")
+	require.NoError(t, err)
+	lineage := again.GetMetadata().GetAnnotations()[client.AnnotationLineage]
+	assert.Equal(t, annotations[client.AnnotationLineage]+","+prod.BaseURL+"/logs@"+annotations[client.AnnotationSourceHash][:12], lineage)
+
+	_, err = src.CopyConfig(t.Context(), dst, "missing", "")
+	assert.Error(t, err)
+}
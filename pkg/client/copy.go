@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+)
+
+// Annotations recording where a config copied by CopyConfig comes from
+const (
+	// AnnotationSourceServer is the URL of the server the config was copied from
+	AnnotationSourceServer = "otelfleet.io/source-server"
+	// AnnotationSourceConfig is the ID of the config on the source server
+	AnnotationSourceConfig = "otelfleet.io/source-config"
+	// AnnotationSourceHash is the hex encoded SHA-256 of the copied config body
+	AnnotationSourceHash = "otelfleet.io/source-hash"
+	// AnnotationSourceModifiedAt is when the config was last modified on the source server
+	AnnotationSourceModifiedAt = "otelfleet.io/source-modified-at"
+	// AnnotationLineage lists every copy the config went through, oldest first and
+	// separated by commas, each as <server>/<config>@<hash>
+	AnnotationLineage = "otelfleet.io/lineage"
+)
+
+// shortHashLen is how many hex characters of the config hash are kept in the lineage
+const shortHashLen = 12
+
+// CopyConfig copies a config from the server of c to the server of target, e.g. to
+// promote a config from staging to production. The owner, tags and annotations of the
+// config are preserved, and annotations recording its source are added, extending the
+// lineage of configs that were themselves copied. targetID defaults to sourceID.
+// It returns the config as stored on the target server.
+func (c *Client) CopyConfig(ctx context.Context, target *Client, sourceID, targetID string) (*configv1alpha1.Config, error) {
+	if sourceID == "" {
+		return nil, errors.New("source config ID is required")
+	}
+	if target == nil {
+		return nil, errors.New("target client is required")
+	}
+	if targetID == "" {
+		targetID = sourceID
+	}
+	resp, err := c.Configs.GetConfig(ctx, connect.NewRequest(&configv1alpha1.ConfigReference{Id: sourceID}))
+	if err != nil {
+		return nil, err
+	}
+	config := resp.Msg
+	if config.Metadata == nil {
+		config.Metadata = &configv1alpha1.ConfigMetadata{}
+	}
+	// the audit trail belongs to the target server
+	modifiedAt := config.Metadata.GetAudit().GetModifiedAt()
+	config.Metadata.Audit = nil
+	if config.Metadata.Annotations == nil {
+		config.Metadata.Annotations = map[string]string{}
+	}
+	annotations := config.Metadata.Annotations
+
+	sum := sha256.Sum256(config.GetConfig())
+	hash := hex.EncodeToString(sum[:])
+	annotations[AnnotationSourceServer] = c.serverURL
+	annotations[AnnotationSourceConfig] = sourceID
+	annotations[AnnotationSourceHash] = hash
+	if modifiedAt != nil {
+		annotations[AnnotationSourceModifiedAt] = modifiedAt.AsTime().UTC().Format(time.RFC3339)
+	} else {
+		delete(annotations, AnnotationSourceModifiedAt)
+	}
+	step := c.serverURL + "/" + sourceID + "@" + hash[:shortHashLen]
+	if lineage := annotations[AnnotationLineage]; lineage != "" {
+		annotations[AnnotationLineage] = lineage + "," + step
+	} else {
+		annotations[AnnotationLineage] = step
+	}
+
+	if _, err := target.Configs.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: targetID},
+		Config: config,
+	})); err != nil {
+		return nil, err
+	}
+	stored, err := target.Configs.GetConfig(ctx, connect.NewRequest(&configv1alpha1.ConfigReference{Id: targetID}))
+	if err != nil {
+		return nil, err
+	}
+	return stored.Msg, nil
+}
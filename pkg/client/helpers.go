@@ -0,0 +1,191 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/cenkalti/backoff/v4"
+	agentsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	eventsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+)
+
+// DefaultPollInterval is how often WaitForDeployment polls when no interval is given
+const DefaultPollInterval = 2 * time.Second
+
+// AllEvents returns an iterator over all retained events matching filter, oldest first,
+// fetching pages from the server as needed. Iteration stops after the first error.
+func (c *Client) AllEvents(ctx context.Context, filter *eventsv1alpha1.EventFilter) iter.Seq2[*eventsv1alpha1.Event, error] {
+	return func(yield func(*eventsv1alpha1.Event, error) bool) {
+		pageToken := ""
+		for {
+			resp, err := c.Events.ListEvents(ctx, connect.NewRequest(&eventsv1alpha1.ListEventsRequest{
+				Filter:    filter,
+				PageToken: pageToken,
+			}))
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, event := range resp.Msg.GetEvents() {
+				if !yield(event, nil) {
+					return
+				}
+			}
+			pageToken = resp.Msg.GetNextPageToken()
+			if pageToken == "" {
+				return
+			}
+		}
+	}
+}
+
+// ListAllEvents returns all retained events matching filter, oldest first.
+func (c *Client) ListAllEvents(ctx context.Context, filter *eventsv1alpha1.EventFilter) ([]*eventsv1alpha1.Event, error) {
+	var events []*eventsv1alpha1.Event
+	for event, err := range c.AllEvents(ctx, filter) {
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// WatchEvents calls fn for each new event matching filter until ctx is done or fn
+// returns an error. Streams interrupted by transient errors are reopened from the
+// last received event, so no events are missed or repeated while reconnecting.
+// It returns the error from fn, or ctx.Err() once ctx is done.
+func (c *Client) WatchEvents(ctx context.Context, filter *eventsv1alpha1.EventFilter, fn func(*eventsv1alpha1.Event) error) error {
+	resumeToken := ""
+	bo := c.newBackOff(ctx)
+	for {
+		err := c.watchEventsOnce(ctx, filter, &resumeToken, func(event *eventsv1alpha1.Event) error {
+			bo.Reset()
+			return fn(event)
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var cbErr *callbackError
+		if errors.As(err, &cbErr) {
+			return cbErr.err
+		}
+		if err != nil && !IsTransient(err) {
+			return err
+		}
+		wait := bo.NextBackOff()
+		if wait == backoff.Stop {
+			return err
+		}
+		c.logger.With("err", err, "wait", wait).Debug("reconnecting event watch")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// callbackError wraps errors returned by WatchEvents callbacks, so they are not retried
+type callbackError struct {
+	err error
+}
+
+func (e *callbackError) Error() string {
+	return e.err.Error()
+}
+
+func (c *Client) watchEventsOnce(
+	ctx context.Context,
+	filter *eventsv1alpha1.EventFilter,
+	resumeToken *string,
+	fn func(*eventsv1alpha1.Event) error,
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.Events.WatchEvents(ctx, connect.NewRequest(&eventsv1alpha1.WatchEventsRequest{
+		Filter:      filter,
+		ResumeToken: *resumeToken,
+	}))
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	for stream.Receive() {
+		msg := stream.Msg()
+		*resumeToken = msg.GetResumeToken()
+		if err := fn(msg.GetEvent()); err != nil {
+			return &callbackError{err: err}
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return err
+	}
+	// the server ended the stream, reconnect as for an interruption
+	return connect.NewError(connect.CodeUnavailable, fmt.Errorf("event stream closed"))
+}
+
+// DeploymentFinished returns true if the deployment is in a terminal state.
+func DeploymentFinished(state configv1alpha1.DeploymentState) bool {
+	switch state {
+	case configv1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED,
+		configv1alpha1.DeploymentState_DEPLOYMENT_STATE_FAILED,
+		configv1alpha1.DeploymentState_DEPLOYMENT_STATE_CANCELLED:
+		return true
+	default:
+		return false
+	}
+}
+
+// WaitForDeployment polls a deployment until it finishes and returns its final status.
+// A pollInterval of 0 uses DefaultPollInterval.
+func (c *Client) WaitForDeployment(ctx context.Context, deploymentID string, pollInterval time.Duration) (*configv1alpha1.DeploymentStatus, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+	for {
+		resp, err := c.Configs.GetDeploymentStatus(ctx, connect.NewRequest(&configv1alpha1.GetDeploymentStatusRequest{
+			DeploymentId: deploymentID,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		if status := resp.Msg.GetStatus(); DeploymentFinished(status.GetState()) {
+			return status, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// WaitForAgent waits on the server until the agent satisfies the condition, renewing the
+// wait as the server times it out, and returns the agent's status once it does. configHash
+// is only used with AGENT_CONDITION_CONFIG_APPLIED.
+func (c *Client) WaitForAgent(ctx context.Context, agentID string, condition agentsv1alpha1.AgentCondition, configHash []byte) (*agentsv1alpha1.AgentStatus, error) {
+	for {
+		resp, err := c.Agents.WaitForAgentCondition(ctx, connect.NewRequest(&agentsv1alpha1.WaitForAgentConditionRequest{
+			AgentId:    agentID,
+			Condition:  condition,
+			ConfigHash: configHash,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		if resp.Msg.GetSatisfied() {
+			return resp.Msg.GetStatus(), nil
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -1,5 +1,412 @@
 package config
 
+import (
+	"net"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/labels"
+	"github.com/otelfleet/otelfleet/pkg/secrets"
+	"github.com/otelfleet/otelfleet/pkg/services/notify"
+	"github.com/otelfleet/otelfleet/pkg/spiffe"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/telemetry"
+)
+
 type Config struct {
-	StoragePath string
+	// StoragePath is the directory of the embedded database, or the URL of the etcd, Postgres
+	// or SQLite storage, see NewStorageService of pkg/services/storage
+	StoragePath string `yaml:"storage_path"`
+	// Ephemeral keeps all state in memory instead of StoragePath, it is lost on shutdown
+	Ephemeral bool `yaml:"ephemeral"`
+
+	// HTTPListenAddress and HTTPListenPort are where the HTTP API listens, defaulting to
+	// DefaultHTTPListenAddress and DefaultHTTPListenPort
+	HTTPListenAddress string `yaml:"http_listen_address"`
+	HTTPListenPort    int    `yaml:"http_listen_port"`
+
+	// HTTPTLSCertPath and HTTPTLSKeyPath enable TLS on the HTTP API when both are set
+	HTTPTLSCertPath string `yaml:"http_tls_cert_path"`
+	HTTPTLSKeyPath  string `yaml:"http_tls_key_path"`
+
+	// DevTLSDir enables TLS on the HTTP API and the dedicated OpAMP listener with a certificate
+	// issued by a self-signed CA generated in DevTLSDir on first start, and renewed before it
+	// expires. It is meant for development and can't be combined with TLS certificate paths.
+	DevTLSDir string `yaml:"dev_tls_dir"`
+
+	// CORSOrigins are the origins browsers may call the HTTP API from, defaults to
+	// DefaultCORSOrigins
+	CORSOrigins []string `yaml:"cors_origins"`
+
+	// Log configures the logs of the server
+	Log LogConfig `yaml:"log"`
+
+	// Telemetry exports the traces, and optionally the logs, of the server with OTLP when an
+	// endpoint is set
+	Telemetry telemetry.Config `yaml:"telemetry"`
+
+	// Modules restricts the modules served by the HTTP API, e.g. opamp and bootstrap, along
+	// with the modules they depend on. Every module is served when empty.
+	Modules []string `yaml:"modules"`
+
+	// Tokens configures the bootstrap tokens
+	Tokens TokenConfig `yaml:"tokens"`
+
+	// ExternalURL is the base URL agents reach the server at, e.g. https://otelfleet.example.com,
+	// used to build enrollment URLs
+	ExternalURL string `yaml:"external_url"`
+
+	// UIPath is the directory of the built UI served by the HTTP API, the UI is only served
+	// by its development server when empty
+	UIPath string `yaml:"ui_path"`
+
+	// OpAMP configures the endpoint agents connect to
+	OpAMP OpAMPConfig `yaml:"opamp"`
+
+	// AgentMetrics offers the agents reporting their own metrics where to send the metrics of
+	// their collector
+	AgentMetrics AgentMetricsConfig `yaml:"agent_metrics"`
+
+	// AgentMTLS authenticates the OpAMP connections of agents with client certificates issued
+	// by an agent CA when its certificate and key paths are set. Requires TLS on the listener
+	// serving OpAMP.
+	AgentMTLS AgentMTLSConfig `yaml:"agent_mtls"`
+
+	// Gateway runs the server as a regional gateway relaying its agents to an upstream server
+	// when an upstream URL is set. Gateways only serve OpAMP, the fleet is managed upstream.
+	Gateway GatewayConfig `yaml:"gateway"`
+
+	// Follower runs the server as a read replica of a primary server when a primary URL is
+	// set. Followers only serve the agent read APIs, from the primary's periodic snapshots.
+	Follower FollowerConfig `yaml:"follower"`
+
+	// Cluster runs the server as one of several replicas sharing their state when a replica ID
+	// is set. Replicas require an etcd or Postgres storage, and only the elected leader runs
+	// background jobs such as rolling deployments.
+	Cluster ClusterConfig `yaml:"cluster"`
+
+	// SPIFFE enables agent enrollment with X.509 SVIDs when a trust domain is set.
+	// Requires TLS to be enabled on the HTTP API.
+	SPIFFE spiffe.Config `yaml:"spiffe"`
+
+	// Vault enables resolving ${vault:path#key} references in configs at delivery time
+	Vault secrets.VaultConfig `yaml:"vault"`
+
+	Auth AuthConfig `yaml:"auth"`
+
+	// DesiredStateToken is the bearer token of the read-only desired state export consumed by
+	// external OpAMP servers and pull based agents. The export is enabled when it is set or
+	// callers are authenticated from proxy headers.
+	DesiredStateToken string `yaml:"desired_state_token"`
+
+	// ReplicationToken is the bearer token of the snapshots exported to followers. The export
+	// is enabled when it is set or callers are authenticated from proxy headers.
+	ReplicationToken string `yaml:"replication_token"`
+
+	// GatewayTokens are the bearer tokens regional gateways relay their agents with, by gateway
+	// ID. A gateway must present the token of the ID it relays as, see GatewayConfig. An agent
+	// is relayed by one gateway at a time, until that gateway reports it disconnected.
+	GatewayTokens map[string]string `yaml:"gateway_tokens"`
+
+	// EventRetention is how long fleet events are kept, defaults to events.DefaultRetention
+	EventRetention time.Duration `yaml:"event_retention"`
+
+	// AgentTimelineRetention is how long the events of agent timelines are kept, defaults to
+	// events.DefaultTimelineRetention
+	AgentTimelineRetention time.Duration `yaml:"agent_timeline_retention"`
+
+	// AuditRetention is how long the audit entries of management API mutations are kept,
+	// defaults to audit.DefaultRetention
+	AuditRetention time.Duration `yaml:"audit_retention"`
+
+	// SnapshotRetention is how long agent snapshots are kept, defaults to agent.DefaultSnapshotRetention
+	SnapshotRetention time.Duration `yaml:"snapshot_retention"`
+
+	// AgentDeletionGracePeriod is how long deleted agents can be restored before they are
+	// purged, defaults to agent.DefaultDeletionGracePeriod
+	AgentDeletionGracePeriod time.Duration `yaml:"agent_deletion_grace_period"`
+
+	// DeploymentRetention is how long finished rolling deployments are kept, defaults to
+	// deployment.DefaultRetention. DeploymentRetentionCount limits the number of finished
+	// deployments kept, 0 keeps all of them.
+	DeploymentRetention      time.Duration `yaml:"deployment_retention"`
+	DeploymentRetentionCount int           `yaml:"deployment_retention_count"`
+
+	// JobWorkers is the number of background jobs run concurrently, defaults to jobs.DefaultWorkers
+	JobWorkers int `yaml:"job_workers"`
+	// JobRetention is how long finished background jobs are kept, defaults to jobs.DefaultRetention
+	JobRetention time.Duration `yaml:"job_retention"`
+
+	// AssignmentPrecedence ranks the manual, policy and bootstrap config sources, highest
+	// first, defaults to otelconfig.DefaultPrecedence
+	AssignmentPrecedence []configv1alpha1.ConfigSource `yaml:"-"`
+
+	// BatchConcurrency bounds the storage operations run in parallel by agent deletion, batch
+	// assignments, deployment batches and retention pruning, defaults to parallel.DefaultLimit
+	BatchConcurrency int `yaml:"batch_concurrency"`
+
+	// RPCTimeout bounds unary management API calls, defaults to util.DefaultRPCTimeout.
+	// RPCTimeouts overrides it by procedure or service, 0 leaving them unbounded.
+	RPCTimeout  time.Duration            `yaml:"rpc_timeout"`
+	RPCTimeouts map[string]time.Duration `yaml:"rpc_timeouts"`
+
+	// Storage bounds storage operations and configures the slow operation log
+	Storage storage.Budget `yaml:"storage"`
+
+	// Notify selects how config changes are notified to the OpAMP servers. Replicas sharing an
+	// etcd storage are notified by the default watch transport; replicas sharing another
+	// storage use notify.TransportStorage, which is the default of Cluster replicas.
+	Notify notify.Config `yaml:"notify"`
+
+	// LabelRules restricts the labels of bootstrap tokens and the labels agents report. The
+	// zero value applies the defaults of labels.Rules.
+	LabelRules labels.Rules `yaml:"label_rules"`
+
+	// Features enables or disables feature flags by name, see features.All
+	Features map[string]bool `yaml:"features"`
+}
+
+// Defaults of the zero values of Config
+const (
+	DefaultHTTPListenAddress = "127.0.0.1"
+	DefaultHTTPListenPort    = 16587
+)
+
+// DefaultCORSOrigins allow the development server of the UI
+var DefaultCORSOrigins = []string{"http://localhost:5173"}
+
+// Log formats
+const (
+	LogFormatLogfmt = "logfmt"
+	LogFormatJSON   = "json"
+)
+
+// LogConfig configures the logs of the server
+type LogConfig struct {
+	// Level is the minimum level logged, one of trace, debug, info, warn or error, defaults
+	// to info
+	Level string `yaml:"level"`
+	// Format is LogFormatLogfmt or LogFormatJSON, defaults to LogFormatLogfmt
+	Format string `yaml:"format"`
+}
+
+// TokenConfig configures the bootstrap tokens
+type TokenConfig struct {
+	// DefaultTTL is how long bootstrap tokens created without a TTL are valid, defaults to
+	// bootstrap.DefaultTokenTTL
+	DefaultTTL time.Duration `yaml:"default_ttl"`
+	// MaxTTL bounds the TTL of bootstrap tokens, defaults to bootstrap.DefaultMaxTokenTTL
+	MaxTTL time.Duration `yaml:"max_ttl"`
+	// GCInterval is how often expired bootstrap tokens are deleted, defaults to
+	// bootstrap.DefaultTokenGCInterval
+	GCInterval time.Duration `yaml:"gc_interval"`
+}
+
+// OpAMPConfig configures the OpAMP endpoint. Unless a listen port is set, OpAMP is served
+// on the HTTP API listener, which is convenient for development.
+type OpAMPConfig struct {
+	// ListenAddress and ListenPort configure a dedicated listener for agent traffic,
+	// so that it can be exposed independently of the management API
+	ListenAddress string `yaml:"listen_address"`
+	ListenPort    int    `yaml:"listen_port"`
+
+	// TLSCertPath and TLSKeyPath enable TLS on the dedicated listener when both are set
+	TLSCertPath string `yaml:"tls_cert_path"`
+	TLSKeyPath  string `yaml:"tls_key_path"`
+	// ClientAuth is the TLS client auth type of the dedicated listener, e.g. RequireAndVerifyClientCert,
+	// and ClientCAPath the CA bundle client certificates are verified against
+	ClientAuth   string `yaml:"client_auth"`
+	ClientCAPath string `yaml:"client_ca_path"`
+
+	// RateLimit is the number of OpAMP requests per second that are accepted, with bursts
+	// of up to RateLimitBurst requests. Zero disables rate limiting.
+	RateLimit      float64 `yaml:"rate_limit"`
+	RateLimitBurst int     `yaml:"rate_limit_burst"`
+
+	// PersistWorkers is the number of workers persisting agent reports off the read path,
+	// shedding low priority reports when they fall behind. Zero persists reports inline.
+	// PersistQueueSize is the number of reports each worker queues per priority.
+	PersistWorkers   int `yaml:"persist_workers"`
+	PersistQueueSize int `yaml:"persist_queue_size"`
+
+	// HeartbeatTimeout is how long agents may go without sending a message before they are
+	// marked as disconnected and count as unavailable in availability reports, defaults to
+	// agent.DefaultHeartbeatTimeout
+	HeartbeatTimeout time.Duration `yaml:"heartbeat_timeout"`
+}
+
+// AgentOpAMPURL returns the OpAMP endpoint of the dedicated listener as reached by agents, on
+// the host of the external URL, or "" if OpAMP isn't served on a dedicated listener
+func (c Config) AgentOpAMPURL() string {
+	if !c.OpAMP.Dedicated() || c.ExternalURL == "" {
+		return ""
+	}
+	external, err := url.Parse(c.ExternalURL)
+	if err != nil || external.Hostname() == "" {
+		return ""
+	}
+	u := url.URL{
+		Scheme: "ws",
+		Host:   net.JoinHostPort(external.Hostname(), strconv.Itoa(c.OpAMP.ListenPort)),
+		Path:   "/v1/opamp",
+	}
+	if c.OpAMP.TLSCertPath != "" && c.OpAMP.TLSKeyPath != "" {
+		u.Scheme = "wss"
+	}
+	return u.String()
+}
+
+// Dedicated returns true if OpAMP is served on its own listener
+func (c OpAMPConfig) Dedicated() bool {
+	return c.ListenPort != 0
+}
+
+// AgentMetricsPath is where the own metrics of agents are received, on the listener serving
+// OpAMP
+const AgentMetricsPath = "/v1/agent-metrics"
+
+// AgentMetricsConfig configures the own metrics connection settings offered to agents. Agents
+// are only offered settings when an endpoint is set or the server receives their metrics.
+type AgentMetricsConfig struct {
+	// Endpoint is the OTLP/HTTP metrics URL offered to agents, e.g. of a collector of the
+	// fleet, http://otel-collector:4318/v1/metrics. Defaults to the receiver of the server
+	// when Receive is set.
+	Endpoint string `yaml:"endpoint"`
+	// Headers are offered along with the endpoint, e.g. to authenticate agents to a collector
+	Headers map[string]string `yaml:"headers"`
+	// Receive receives the own metrics of agents at AgentMetricsPath, so that the status of
+	// agents includes the CPU, memory and exporter queue usage of their collector. Agents
+	// authenticate with a token offered along with the endpoint. Requires an external URL.
+	Receive bool `yaml:"receive"`
+}
+
+// Enabled returns true if agents are offered own metrics connection settings
+func (c AgentMetricsConfig) Enabled() bool {
+	return c.Endpoint != "" || c.Receive
+}
+
+// AgentMetricsURL returns the URL agents send their own metrics to: the configured endpoint,
+// or the receiver of the server, on the listener serving OpAMP as reached by agents. It
+// returns "" if the receiver can't be reached without an external URL.
+func (c Config) AgentMetricsURL() string {
+	if c.AgentMetrics.Endpoint != "" || !c.AgentMetrics.Receive {
+		return c.AgentMetrics.Endpoint
+	}
+	external, err := url.Parse(c.ExternalURL)
+	if err != nil || external.Hostname() == "" {
+		return ""
+	}
+	u := url.URL{
+		Scheme: external.Scheme,
+		Host:   external.Host,
+		Path:   path.Join(external.Path, AgentMetricsPath),
+	}
+	if c.OpAMP.Dedicated() {
+		u.Scheme = "http"
+		if c.OpAMP.TLSCertPath != "" && c.OpAMP.TLSKeyPath != "" {
+			u.Scheme = "https"
+		}
+		u.Host = net.JoinHostPort(external.Hostname(), strconv.Itoa(c.OpAMP.ListenPort))
+		u.Path = AgentMetricsPath
+	}
+	return u.String()
+}
+
+// AgentMTLSConfig configures the CA issuing the client certificates of agents, see agentca
+type AgentMTLSConfig struct {
+	// CACertPath and CAKeyPath are the PEM encoded certificate and key of the agent CA
+	CACertPath string `yaml:"ca_cert_path"`
+	CAKeyPath  string `yaml:"ca_key_path"`
+	// Require rejects the OpAMP connections of agents without a client certificate issued by
+	// the agent CA. Otherwise agents without one, e.g. bootstrapped before mTLS was enabled,
+	// still connect, while agents presenting one may only speak for the agent it was issued to.
+	// Gateways can't relay agents while it is set, as their certificates can't be verified
+	// through a gateway.
+	Require bool `yaml:"require"`
+	// CertValidity is how long issued client certificates are valid, defaults to
+	// agentca.DefaultCertValidity
+	CertValidity time.Duration `yaml:"cert_validity"`
+}
+
+// Enabled returns true if agents are authenticated with client certificates
+func (c AgentMTLSConfig) Enabled() bool {
+	return c.CACertPath != "" && c.CAKeyPath != ""
+}
+
+// GatewayConfig configures a regional gateway. The upstream server must enable the gateways
+// feature.
+type GatewayConfig struct {
+	// ID identifies the gateway to the upstream server, it must be unique among its gateways
+	ID string `yaml:"id"`
+	// UpstreamURL is the base URL of the management API of the upstream server
+	UpstreamURL string `yaml:"upstream_url"`
+	// UpstreamToken is sent as the bearer token of the calls to the upstream server, it must
+	// be the token of ID in the Config.GatewayTokens of the upstream server
+	UpstreamToken string `yaml:"upstream_token"`
+	// RelayTimeout bounds relaying a message upstream, agents are answered from the configs
+	// cached by the gateway once it expires. Defaults to gateway.DefaultRelayTimeout.
+	RelayTimeout time.Duration `yaml:"relay_timeout"`
+}
+
+// Enabled returns true if the server runs as a gateway
+func (c GatewayConfig) Enabled() bool {
+	return c.UpstreamURL != ""
+}
+
+// FollowerConfig configures a read replica. The primary server must enable the replication
+// export, see Config.ReplicationToken.
+type FollowerConfig struct {
+	// PrimaryURL is the base URL of the management API of the primary server
+	PrimaryURL string `yaml:"primary_url"`
+	// PrimaryToken, if set, is sent as the bearer token of the calls to the primary server
+	PrimaryToken string `yaml:"primary_token"`
+	// SyncInterval is how often the primary's snapshot is copied, it bounds the staleness of
+	// the replica while the primary is reachable. Defaults to replica.DefaultSyncInterval.
+	SyncInterval time.Duration `yaml:"sync_interval"`
+}
+
+// Enabled returns true if the server runs as a read replica
+func (c FollowerConfig) Enabled() bool {
+	return c.PrimaryURL != ""
+}
+
+// ClusterConfig configures a replica of a highly available server. Replicas keep the bootstrap
+// attempts checked when deleting tokens in the shared storage, instead of in memory.
+type ClusterConfig struct {
+	// ReplicaID identifies the replica, it must be unique among the replicas sharing the storage
+	ReplicaID string `yaml:"replica_id"`
+	// LeaseTTL is how long the leader's lease lasts without being renewed, bounding how long
+	// background jobs stop when the leader fails. Defaults to leader.DefaultLeaseTTL.
+	LeaseTTL time.Duration `yaml:"lease_ttl"`
+}
+
+// Enabled returns true if the server runs as a replica sharing its state
+func (c ClusterConfig) Enabled() bool {
+	return c.ReplicaID != ""
+}
+
+// AuthConfig configures authentication and authorization of the management APIs
+type AuthConfig struct {
+	// TrustProxyHeaders authenticates callers from the identity headers set by an
+	// authenticating reverse proxy, see auth.HeaderUser and auth.HeaderRoles
+	TrustProxyHeaders bool `yaml:"trust_proxy_headers"`
+
+	// RBAC requires the callers of the management APIs to authenticate, with an API key, an
+	// OIDC token or the proxy headers, and to be granted the role each procedure requires
+	RBAC bool `yaml:"rbac"`
+	// Admins are the subjects granted the admin role without a role binding, e.g. to create
+	// the first API keys
+	Admins []string `yaml:"admins"`
+	// OIDC authenticates callers presenting tokens of an OpenID Connect provider
+	OIDC auth.OIDCConfig `yaml:"oidc"`
+	// DefaultTenant is the only tenant the callers granted no tenant role may access in RBAC
+	// mode, admins aside. Defaults to the default tenant, see package tenant.
+	DefaultTenant string `yaml:"default_tenant"`
+
+	// ConfigPolicy scopes config management per role by config ID prefix or tag
+	ConfigPolicy auth.ConfigPolicy `yaml:"config_policy"`
 }
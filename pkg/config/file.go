@@ -0,0 +1,51 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"gopkg.in/yaml.v3"
+)
+
+// precedenceNames are the config sources of the assignment precedence in config files
+var precedenceNames = map[string]configv1alpha1.ConfigSource{
+	"manual":    configv1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL,
+	"policy":    configv1alpha1.ConfigSource_CONFIG_SOURCE_POLICY,
+	"bootstrap": configv1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP,
+}
+
+// LoadFile overrides cfg with the settings of the YAML config file at path. Settings missing
+// from the file are left unchanged, unknown settings are rejected. The assignment precedence
+// is a list of config source names, highest first, e.g. [policy, manual, bootstrap].
+func LoadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	file := struct {
+		*Config              `yaml:",inline"`
+		AssignmentPrecedence []string `yaml:"assignment_precedence"`
+	}{Config: cfg}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if file.AssignmentPrecedence == nil {
+		return nil
+	}
+	cfg.AssignmentPrecedence = nil
+	for _, name := range file.AssignmentPrecedence {
+		source, ok := precedenceNames[strings.ToLower(name)]
+		if !ok {
+			return fmt.Errorf("invalid config file %s: unknown config source %q, expected manual, policy or bootstrap", path, name)
+		}
+		cfg.AssignmentPrecedence = append(cfg.AssignmentPrecedence, source)
+	}
+	return nil
+}
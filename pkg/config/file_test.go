@@ -0,0 +1,132 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "otelfleet.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Run("overrides the settings it sets", func(t *testing.T) {
+		path := writeFile(t, `
+http_listen_address: 0.0.0.0
+http_listen_port: 8080
+cors_origins: [https://otelfleet.example.com]
+log:
+  level: debug
+  format: json
+modules: [opamp, bootstrap]
+tokens:
+  default_ttl: 1h
+  max_ttl: 24h
+opamp:
+  listen_port: 4320
+notify:
+  transport: storage
+  poll_interval: 2s
+assignment_precedence: [policy, manual, bootstrap]
+rpc_timeouts:
+  /config.v1alpha1.ConfigService/: 1m
+`)
+		cfg := Config{StoragePath: "./otelfleet.kv", UIPath: "./ui"}
+		require.NoError(t, LoadFile(path, &cfg))
+		assert.Equal(t, Config{
+			StoragePath:       "./otelfleet.kv",
+			UIPath:            "./ui",
+			HTTPListenAddress: "0.0.0.0",
+			HTTPListenPort:    8080,
+			CORSOrigins:       []string{"https://otelfleet.example.com"},
+			Log:               LogConfig{Level: "debug", Format: LogFormatJSON},
+			Modules:           []string{"opamp", "bootstrap"},
+			Tokens:            TokenConfig{DefaultTTL: time.Hour, MaxTTL: 24 * time.Hour},
+			OpAMP:             OpAMPConfig{ListenPort: 4320},
+			Notify:            notify.Config{Transport: notify.TransportStorage, PollInterval: 2 * time.Second},
+			AssignmentPrecedence: []configv1alpha1.ConfigSource{
+				configv1alpha1.ConfigSource_CONFIG_SOURCE_POLICY,
+				configv1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL,
+				configv1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP,
+			},
+			RPCTimeouts: map[string]time.Duration{"/config.v1alpha1.ConfigService/": time.Minute},
+		}, cfg)
+	})
+
+	t.Run("empty files", func(t *testing.T) {
+		cfg := Config{StoragePath: "./otelfleet.kv"}
+		require.NoError(t, LoadFile(writeFile(t, ""), &cfg))
+		assert.Equal(t, Config{StoragePath: "./otelfleet.kv"}, cfg)
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		for content, err := range map[string]string{
+			"storage_pth: ./otelfleet.kv":               "field storage_pth not found",
+			"assignment_precedence: [manual, fallback]": `unknown config source "fallback"`,
+			"tokens:\n  default_ttl: soon":              "cannot unmarshal",
+		} {
+			assert.ErrorContains(t, LoadFile(writeFile(t, content), &Config{}), err)
+		}
+		assert.ErrorContains(t, LoadFile(filepath.Join(t.TempDir(), "missing.yaml"), &Config{}), "failed to read config file")
+	})
+}
+
+func TestRegisterFlags(t *testing.T) {
+	cfg := Config{StoragePath: "./otelfleet.kv"}
+	require.NoError(t, LoadFile(writeFile(t, "http_listen_port: 8080\nlog:\n  level: debug\n"), &cfg))
+	fs := flag.NewFlagSet("otelfleet", flag.ContinueOnError)
+	cfg.RegisterFlags(fs)
+	require.NoError(t, fs.Parse([]string{"-log.level=warn", "-modules=opamp,bootstrap", "-storage.path=etcd://localhost:2379/otelfleet", "-telemetry.endpoint=http://localhost:4318"}))
+
+	assert.Equal(t, 8080, cfg.HTTPListenPort, "flags that aren't set keep the settings of the file")
+	assert.Equal(t, "warn", cfg.Log.Level)
+	assert.Equal(t, []string{"opamp", "bootstrap"}, cfg.Modules)
+	assert.Equal(t, "etcd://localhost:2379/otelfleet", cfg.StoragePath)
+	assert.Equal(t, "http://localhost:4318", cfg.Telemetry.Endpoint)
+}
+
+func TestAgentMetricsURL(t *testing.T) {
+	for name, tc := range map[string]struct {
+		cfg  Config
+		want string
+	}{
+		"disabled": {
+			cfg: Config{ExternalURL: "https://fleet.example.com"},
+		},
+		"external endpoint": {
+			cfg:  Config{AgentMetrics: AgentMetricsConfig{Endpoint: "http://collector:4318/v1/metrics", Receive: true}},
+			want: "http://collector:4318/v1/metrics",
+		},
+		"receiver of the server": {
+			cfg: Config{
+				ExternalURL:  "https://fleet.example.com/otelfleet/",
+				AgentMetrics: AgentMetricsConfig{Receive: true},
+			},
+			want: "https://fleet.example.com/otelfleet/v1/agent-metrics",
+		},
+		"receiver on the opamp listener": {
+			cfg: Config{
+				ExternalURL:  "https://fleet.example.com",
+				OpAMP:        OpAMPConfig{ListenPort: 4320},
+				AgentMetrics: AgentMetricsConfig{Receive: true},
+			},
+			want: "http://fleet.example.com:4320/v1/agent-metrics",
+		},
+		"receiver without an external URL": {
+			cfg: Config{AgentMetrics: AgentMetricsConfig{Receive: true}},
+		},
+	} {
+		assert.Equal(t, tc.want, tc.cfg.AgentMetricsURL(), name)
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"flag"
+
+	"github.com/grafana/dskit/flagext"
+)
+
+// RegisterFlags registers the flags of the server settings on fs. The flags default to the
+// current settings of c, so that only the flags set on the command line override them.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.HTTPListenAddress, "http.listen-address", c.HTTPListenAddress, "address the HTTP API listens on, defaults to "+DefaultHTTPListenAddress)
+	fs.IntVar(&c.HTTPListenPort, "http.listen-port", c.HTTPListenPort, "port the HTTP API listens on, defaults to 16587")
+	fs.StringVar(&c.HTTPTLSCertPath, "http.tls-cert-path", c.HTTPTLSCertPath, "TLS certificate of the HTTP API")
+	fs.StringVar(&c.HTTPTLSKeyPath, "http.tls-key-path", c.HTTPTLSKeyPath, "TLS key of the HTTP API")
+	fs.Var((*flagext.StringSliceCSV)(&c.CORSOrigins), "http.cors-origins", "comma separated origins browsers may call the HTTP API from")
+
+	fs.StringVar(&c.OpAMP.ListenAddress, "opamp.listen-address", c.OpAMP.ListenAddress, "address of the dedicated OpAMP listener")
+	fs.IntVar(&c.OpAMP.ListenPort, "opamp.listen-port", c.OpAMP.ListenPort, "port of the dedicated OpAMP listener, OpAMP is served by the HTTP API when unset")
+	fs.StringVar(&c.OpAMP.TLSCertPath, "opamp.tls-cert-path", c.OpAMP.TLSCertPath, "TLS certificate of the dedicated OpAMP listener")
+	fs.StringVar(&c.OpAMP.TLSKeyPath, "opamp.tls-key-path", c.OpAMP.TLSKeyPath, "TLS key of the dedicated OpAMP listener")
+
+	fs.StringVar(&c.AgentMetrics.Endpoint, "agent-metrics.endpoint", c.AgentMetrics.Endpoint, "OTLP/HTTP metrics URL agents are offered to send the metrics of their collector to")
+	fs.BoolVar(&c.AgentMetrics.Receive, "agent-metrics.receive", c.AgentMetrics.Receive, "receive the metrics of the collectors of agents, shown with their status")
+
+	fs.StringVar(&c.AgentMTLS.CACertPath, "agent-mtls.ca-cert-path", c.AgentMTLS.CACertPath, "certificate of the CA issuing agent client certificates, enabling agent mTLS")
+	fs.StringVar(&c.AgentMTLS.CAKeyPath, "agent-mtls.ca-key-path", c.AgentMTLS.CAKeyPath, "key of the CA issuing agent client certificates")
+	fs.BoolVar(&c.AgentMTLS.Require, "agent-mtls.require", c.AgentMTLS.Require, "reject agents without a client certificate issued by the agent CA")
+
+	fs.StringVar(&c.StoragePath, "storage.path", c.StoragePath, "directory of the embedded database, or etcd://, postgres:// or sqlite:// URL of the storage")
+	fs.BoolVar(&c.Ephemeral, "ephemeral", c.Ephemeral, "keep all state in memory, it is lost on shutdown")
+
+	fs.StringVar(&c.Log.Level, "log.level", c.Log.Level, "minimum level logged: trace, debug, info, warn or error")
+	fs.StringVar(&c.Log.Format, "log.format", c.Log.Format, "log format: logfmt or json")
+
+	fs.StringVar(&c.Telemetry.Endpoint, "telemetry.endpoint", c.Telemetry.Endpoint, "base URL of the OTLP receiver the traces of the server are exported to, e.g. http://localhost:4318")
+	fs.StringVar(&c.Telemetry.Protocol, "telemetry.protocol", c.Telemetry.Protocol, "OTLP protocol: grpc or http/protobuf")
+	fs.Float64Var(&c.Telemetry.SampleRatio, "telemetry.sample-ratio", c.Telemetry.SampleRatio, "ratio of the traces started by the server that are sampled, defaults to 1")
+	fs.BoolVar(&c.Telemetry.Logs, "telemetry.logs", c.Telemetry.Logs, "export the logs of the server along with its traces")
+
+	fs.Var((*flagext.StringSliceCSV)(&c.Modules), "modules", "comma separated modules to serve, all of them when unset")
+
+	fs.DurationVar(&c.Tokens.DefaultTTL, "tokens.default-ttl", c.Tokens.DefaultTTL, "how long bootstrap tokens created without a TTL are valid")
+	fs.DurationVar(&c.Tokens.MaxTTL, "tokens.max-ttl", c.Tokens.MaxTTL, "upper bound of the TTL of bootstrap tokens")
+	fs.DurationVar(&c.Tokens.GCInterval, "tokens.gc-interval", c.Tokens.GCInterval, "how often expired bootstrap tokens are deleted")
+
+	fs.BoolVar(&c.Auth.RBAC, "auth.rbac", c.Auth.RBAC, "require management API callers to authenticate and to be granted the role of each procedure")
+	fs.Var((*flagext.StringSliceCSV)(&c.Auth.Admins), "auth.admins", "comma separated subjects granted the admin role without a role binding")
+	fs.StringVar(&c.Auth.DefaultTenant, "auth.default-tenant", c.Auth.DefaultTenant, "only tenant the callers granted no tenant role may access in RBAC mode")
+	fs.StringVar(&c.Auth.OIDC.IssuerURL, "auth.oidc.issuer-url", c.Auth.OIDC.IssuerURL, "OpenID Connect issuer of the tokens authenticating management API callers")
+	fs.StringVar(&c.Auth.OIDC.Audience, "auth.oidc.audience", c.Auth.OIDC.Audience, "audience the OpenID Connect tokens must be issued for")
+}
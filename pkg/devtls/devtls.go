@@ -0,0 +1,263 @@
+// Package devtls issues the serving certificate of servers in dev mode, from a self-signed
+// CA generated on first start, so that TLS is exercised without provisioning certificates.
+package devtls
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/grafana/dskit/services"
+	"github.com/natefinch/atomic"
+)
+
+const (
+	// CAValidity is how long generated CAs are valid
+	CAValidity = 365 * 24 * time.Hour
+	// CertValidity is how long issued serving certificates are valid
+	CertValidity = 30 * 24 * time.Hour
+	// RenewBefore is how long before they expire the CA and serving certificate are regenerated
+	RenewBefore = 7 * 24 * time.Hour
+	// CheckInterval is how often a running issuer checks whether the certificates expire
+	CheckInterval = time.Hour
+)
+
+// Files kept in the directory of the issuer
+const (
+	CAFile    = "ca.pem"
+	caKeyFile = "ca-key.pem"
+	CertFile  = "tls.pem"
+	KeyFile   = "tls-key.pem"
+)
+
+const organization = "otelfleet development"
+
+// DefaultHosts are the names the serving certificate is valid for, in addition to the hosts
+// passed to NewIssuer
+var DefaultHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// Issuer keeps a CA and a serving certificate it issued in a directory, regenerating them
+// when they are missing or about to expire. Servers reload the serving certificate from its
+// file on every handshake, so that renewals don't require a restart.
+type Issuer struct {
+	services.Service
+	logger *slog.Logger
+	dir    string
+	hosts  []string
+	now    func() time.Time
+}
+
+// NewIssuer creates the CA and serving certificate valid for hosts in dir if needed, and
+// logs the CA agents must trust
+func NewIssuer(logger *slog.Logger, dir string, hosts []string) (*Issuer, error) {
+	i := &Issuer{
+		logger: logger,
+		dir:    dir,
+		hosts:  hostsOf(hosts),
+		now:    time.Now,
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create dev tls directory: %w", err)
+	}
+	if _, err := i.Ensure(); err != nil {
+		return nil, err
+	}
+	ca, err := os.ReadFile(i.CAPath())
+	if err != nil {
+		return nil, err
+	}
+	logger.With("ca", i.CAPath()).Info(
+		"serving TLS with a development certificate, agents trust its CA with SSL_CERT_FILE=" + i.CAPath() + "\n" + string(ca),
+	)
+	i.Service = services.NewTimerService(CheckInterval, nil, i.check, nil)
+	return i, nil
+}
+
+func hostsOf(hosts []string) []string {
+	all := slices.Clone(DefaultHosts)
+	for _, h := range hosts {
+		if h != "" && !slices.Contains(all, h) {
+			all = append(all, h)
+		}
+	}
+	return all
+}
+
+// CAPath returns the path of the PEM encoded CA certificate
+func (i *Issuer) CAPath() string {
+	return filepath.Join(i.dir, CAFile)
+}
+
+// CertPath returns the path of the PEM encoded serving certificate
+func (i *Issuer) CertPath() string {
+	return filepath.Join(i.dir, CertFile)
+}
+
+// KeyPath returns the path of the PEM encoded key of the serving certificate
+func (i *Issuer) KeyPath() string {
+	return filepath.Join(i.dir, KeyFile)
+}
+
+func (i *Issuer) check(_ context.Context) error {
+	// a failed renewal is retried on the next check, the current certificate is still served
+	if _, err := i.Ensure(); err != nil {
+		i.logger.With("err", err).Error("failed to renew development certificate")
+	}
+	return nil
+}
+
+// Ensure regenerates the CA and serving certificate if they are missing, invalid or expire
+// within RenewBefore, and returns true if the serving certificate was issued
+func (i *Issuer) Ensure() (bool, error) {
+	now := i.now()
+	ca, caKey, err := loadPair(filepath.Join(i.dir, CAFile), filepath.Join(i.dir, caKeyFile))
+	caRenewed := false
+	if err != nil || expires(ca, now) {
+		var genErr error
+		ca, caKey, genErr = i.generateCA(now)
+		if genErr != nil {
+			return false, fmt.Errorf("failed to generate dev CA: %w", genErr)
+		}
+		caRenewed = true
+		if errors.Is(err, fs.ErrNotExist) {
+			i.logger.With("ca", i.CAPath()).Info("generated development CA")
+		} else {
+			i.logger.With("ca", i.CAPath()).Warn("regenerated development CA, agents must trust it again")
+		}
+	}
+
+	cert, _, err := loadPair(i.CertPath(), i.KeyPath())
+	if !caRenewed && err == nil && !expires(cert, now) && cert.CheckSignatureFrom(ca) == nil && i.covers(cert) {
+		return false, nil
+	}
+	if err := i.issue(ca, caKey, now); err != nil {
+		return false, fmt.Errorf("failed to issue dev certificate: %w", err)
+	}
+	i.logger.With("cert", i.CertPath(), "hosts", i.hosts).Info("issued development certificate")
+	return true, nil
+}
+
+// expires returns true if cert expires within RenewBefore, or isn't valid yet after the clock
+// was set back
+func expires(cert *x509.Certificate, now time.Time) bool {
+	return now.Add(RenewBefore).After(cert.NotAfter) || now.Before(cert.NotBefore)
+}
+
+// covers returns true if cert is valid for all the hosts of the issuer
+func (i *Issuer) covers(cert *x509.Certificate) bool {
+	for _, h := range i.hosts {
+		if cert.VerifyHostname(h) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (i *Issuer) generateCA(now time.Time) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{Organization: []string{organization}, CommonName: "otelfleet development CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(CAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, key, err := create(template, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := writePair(filepath.Join(i.dir, CAFile), filepath.Join(i.dir, caKeyFile), der, key); err != nil {
+		return nil, nil, err
+	}
+	ca, err := x509.ParseCertificate(der)
+	return ca, key, err
+}
+
+func (i *Issuer) issue(ca *x509.Certificate, caKey *ecdsa.PrivateKey, now time.Time) error {
+	template := &x509.Certificate{
+		Subject:     pkix.Name{Organization: []string{organization}, CommonName: i.hosts[0]},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(CertValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range i.hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+	der, key, err := create(template, ca, caKey)
+	if err != nil {
+		return err
+	}
+	return writePair(i.CertPath(), i.KeyPath(), der, key)
+}
+
+// create signs template with parent, or self-signs it if parent is nil
+func create(template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) ([]byte, *ecdsa.PrivateKey, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	template.SerialNumber = serial
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	return der, key, err
+}
+
+// writePair replaces the PEM encoded certificate and key, each of them atomically. Handshakes
+// racing a renewal may load a mismatched pair and fail, clients retry them.
+func writePair(certPath, keyPath string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})); err != nil {
+		return err
+	}
+	return writeFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func writeFile(path string, data []byte) error {
+	if err := atomic.WriteFile(path, bytes.NewReader(data)); err != nil {
+		return err
+	}
+	return os.Chmod(path, 0o600)
+}
+
+func loadPair(certPath, keyPath string) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	pair, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, ok := pair.PrivateKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("unexpected key type")
+	}
+	return pair.Leaf, key, nil
+}
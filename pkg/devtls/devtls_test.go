@@ -0,0 +1,79 @@
+package devtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssuer(t *testing.T) {
+	dir := t.TempDir()
+	issuer, err := NewIssuer(slog.Default(), dir, []string{"otelfleet.test"})
+	require.NoError(t, err)
+
+	verify := func(host string) error {
+		t.Helper()
+		caPEM, err := os.ReadFile(issuer.CAPath())
+		require.NoError(t, err)
+		roots := x509.NewCertPool()
+		require.True(t, roots.AppendCertsFromPEM(caPEM))
+		pair, err := tls.LoadX509KeyPair(issuer.CertPath(), issuer.KeyPath())
+		require.NoError(t, err)
+		_, err = pair.Leaf.Verify(x509.VerifyOptions{DNSName: host, Roots: roots, CurrentTime: issuer.now()})
+		return err
+	}
+	for _, host := range []string{"localhost", "127.0.0.1", "otelfleet.test"} {
+		assert.NoError(t, verify(host), host)
+	}
+	assert.Error(t, verify("example.com"))
+
+	ca, err := os.ReadFile(issuer.CAPath())
+	require.NoError(t, err)
+	cert, err := os.ReadFile(issuer.CertPath())
+	require.NoError(t, err)
+	reload := func() ([]byte, []byte) {
+		t.Helper()
+		gotCA, err := os.ReadFile(issuer.CAPath())
+		require.NoError(t, err)
+		gotCert, err := os.ReadFile(issuer.CertPath())
+		require.NoError(t, err)
+		return gotCA, gotCert
+	}
+
+	// valid certificates are kept across restarts
+	issuer, err = NewIssuer(slog.Default(), dir, []string{"otelfleet.test"})
+	require.NoError(t, err)
+	gotCA, gotCert := reload()
+	assert.Equal(t, ca, gotCA)
+	assert.Equal(t, cert, gotCert)
+
+	// the serving certificate is renewed before it expires, with the same CA
+	issuer.now = func() time.Time { return time.Now().Add(CertValidity - RenewBefore + time.Minute) }
+	issued, err := issuer.Ensure()
+	require.NoError(t, err)
+	assert.True(t, issued)
+	gotCA, gotCert = reload()
+	assert.Equal(t, ca, gotCA)
+	assert.NotEqual(t, cert, gotCert)
+	assert.NoError(t, verify("localhost"))
+
+	// so is the CA, along with the serving certificate
+	issuer.now = func() time.Time { return time.Now().Add(CAValidity - RenewBefore + time.Minute) }
+	issued, err = issuer.Ensure()
+	require.NoError(t, err)
+	assert.True(t, issued)
+	gotCA, _ = reload()
+	assert.NotEqual(t, ca, gotCA)
+	assert.NoError(t, verify("localhost"))
+
+	// new hosts are added to the serving certificate
+	issuer, err = NewIssuer(slog.Default(), dir, []string{"fleet.test"})
+	require.NoError(t, err)
+	assert.NoError(t, verify("fleet.test"))
+}
@@ -0,0 +1,127 @@
+package agent
+
+import (
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultHeartbeatTimeout is how long an agent may go without sending a message before it
+	// counts as unavailable, three times the default OpAMP heartbeat interval.
+	DefaultHeartbeatTimeout = 90 * time.Second
+	// MaxAvailabilityWindow is the longest window availability is computed over, older
+	// history is dropped.
+	MaxAvailabilityWindow = 30 * 24 * time.Hour
+
+	// maxAvailabilityPeriods bounds the history of flapping agents
+	maxAvailabilityPeriods = 10000
+)
+
+// DefaultAvailabilityWindows are the windows availability is reported over by default.
+var DefaultAvailabilityWindows = []time.Duration{time.Hour, 24 * time.Hour, 7 * 24 * time.Hour, MaxAvailabilityWindow}
+
+// RecordHeartbeat records a message received from the agent at now. healthy is the health
+// the agent reported with the message, or nil to keep the last known health; agents that
+// never reported their health are assumed healthy.
+//
+// Returns whether the history should be persisted. Extending the current period is only
+// reported every quarter of the heartbeat timeout to limit writes, open periods are
+// assumed to last until the timeout expires anyway.
+func RecordHeartbeat(history *v1alpha1.AvailabilityHistory, now time.Time, healthy *bool, timeout time.Duration) bool {
+	var last *v1alpha1.AvailabilityPeriod
+	if n := len(history.Periods); n > 0 {
+		last = history.Periods[n-1]
+	}
+	health := true
+	if healthy != nil {
+		health = *healthy
+	} else if last != nil {
+		health = last.GetHealthy()
+	}
+
+	ts := timestamppb.New(now)
+	switch {
+	case last == nil || last.GetClosed() || now.Sub(last.GetEnd().AsTime()) > timeout:
+		history.Periods = append(history.Periods, &v1alpha1.AvailabilityPeriod{Start: ts, End: ts, Healthy: health})
+	case last.GetHealthy() != health:
+		last.End = ts
+		history.Periods = append(history.Periods, &v1alpha1.AvailabilityPeriod{Start: ts, End: ts, Healthy: health})
+	default:
+		persist := now.Sub(last.GetEnd().AsTime()) >= timeout/4
+		last.End = ts
+		if !persist {
+			return false
+		}
+	}
+	pruneAvailability(history, now)
+	return true
+}
+
+// RecordDisconnect closes the current period of the history when the agent disconnects at now.
+// Returns false if there is no open period.
+func RecordDisconnect(history *v1alpha1.AvailabilityHistory, now time.Time, timeout time.Duration) bool {
+	n := len(history.Periods)
+	if n == 0 || history.Periods[n-1].GetClosed() {
+		return false
+	}
+	last := history.Periods[n-1]
+	if now.Sub(last.GetEnd().AsTime()) <= timeout {
+		last.End = timestamppb.New(now)
+	}
+	last.Closed = true
+	return true
+}
+
+func pruneAvailability(history *v1alpha1.AvailabilityHistory, now time.Time) {
+	cutoff := now.Add(-MaxAvailabilityWindow)
+	drop := 0
+	for drop < len(history.Periods) && history.Periods[drop].GetEnd().AsTime().Before(cutoff) {
+		drop++
+	}
+	drop = max(drop, len(history.Periods)-maxAvailabilityPeriods)
+	history.Periods = history.Periods[drop:]
+}
+
+// Availability returns the share of the window ending at now the agent was connected, and
+// connected and healthy. The window starts no earlier than the agent's first recorded
+// heartbeat, so that recently registered agents aren't reported as unavailable.
+func Availability(history *v1alpha1.AvailabilityHistory, now time.Time, window, timeout time.Duration) (connected, healthy float64) {
+	periods := history.GetPeriods()
+	if len(periods) == 0 {
+		return 0, 0
+	}
+	start := now.Add(-window)
+	if first := periods[0].GetStart().AsTime(); first.After(start) {
+		start = first
+	}
+	total := now.Sub(start)
+	if total <= 0 {
+		return 0, 0
+	}
+	var up, healthyUp time.Duration
+	for i, period := range periods {
+		end := period.GetEnd().AsTime()
+		// the last open period lasts until the heartbeat timeout expires
+		if i == len(periods)-1 && !period.GetClosed() {
+			end = end.Add(timeout)
+		}
+		from := period.GetStart().AsTime()
+		if from.Before(start) {
+			from = start
+		}
+		to := end
+		if to.After(now) {
+			to = now
+		}
+		if to.Before(from) {
+			continue
+		}
+		up += to.Sub(from)
+		if period.GetHealthy() {
+			healthyUp += to.Sub(from)
+		}
+	}
+	return min(float64(up)/float64(total), 1), min(float64(healthyUp)/float64(total), 1)
+}
@@ -0,0 +1,53 @@
+package agent_test
+
+import (
+	"testing"
+	"time"
+
+	agentsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvailability(t *testing.T) {
+	const timeout = time.Minute
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	unhealthy := false
+	healthy := true
+
+	history := &agentsv1alpha1.AvailabilityHistory{}
+	// heartbeats every 30s, reporting health on the first one
+	beat := func(from, to int, health *bool) {
+		for t := at(from); !t.After(at(to)); t = t.Add(30 * time.Second) {
+			agent.RecordHeartbeat(history, t, health, timeout)
+			health = nil
+		}
+	}
+	beat(0, 30, nil)
+	beat(30, 40, &unhealthy)
+	beat(40, 40, &healthy)
+	// silent for 10m, then disconnected
+	beat(50, 60, nil)
+	assert.True(t, agent.RecordDisconnect(history, at(60), timeout))
+	assert.False(t, agent.RecordDisconnect(history, at(61), timeout))
+	require.Len(t, history.GetPeriods(), 4)
+
+	connected, healthyShare := agent.Availability(history, at(80), 2*time.Hour, timeout)
+	assert.InDelta(t, 50.0/80, connected, 0.001, "the window starts at the first heartbeat")
+	assert.InDelta(t, 40.0/80, healthyShare, 0.001)
+
+	connected, healthyShare = agent.Availability(history, at(80), time.Hour, timeout)
+	assert.InDelta(t, 30.0/60, connected, 0.001)
+	assert.InDelta(t, 20.0/60, healthyShare, 0.001)
+
+	// an open period lasts until the heartbeat timeout expires
+	agent.RecordHeartbeat(history, at(80), nil, timeout)
+	connected, _ = agent.Availability(history, at(80).Add(30*time.Second), time.Minute, timeout)
+	assert.InDelta(t, 0.5, connected, 0.001)
+
+	connected, healthyShare = agent.Availability(&agentsv1alpha1.AvailabilityHistory{}, at(80), time.Hour, timeout)
+	assert.Zero(t, connected)
+	assert.Zero(t, healthyShare)
+}
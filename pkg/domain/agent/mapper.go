@@ -38,6 +38,8 @@ func ToAPIStatus(agent *Agent) *v1alpha1.AgentStatus {
 		DisconnectedAt:   timeToTimestamp(agent.Connection.DisconnectedAt),
 		ConfigSyncStatus: convertToAPIConfigSync(agent.Status.ConfigSyncStatus),
 		ConfigSyncReason: agent.Status.ConfigSyncReason,
+		InstanceUid:      agent.Connection.InstanceUID,
+		InstanceHistory:  toAPIInstanceHistory(agent.Connection.InstanceHistory),
 	}
 
 	if agent.Status.Health != nil {
@@ -99,30 +101,62 @@ func ConvertAttributes(desc *protobufs.AgentDescription) AgentAttributes {
 // ConvertConnectionState converts v1alpha1 AgentConnectionState to domain ConnectionState.
 func ConvertConnectionState(state *v1alpha1.AgentConnectionState) ConnectionState {
 	return ConnectionState{
-		State:          convertFromAPIState(state.GetState()),
-		LastSeen:       timestampToTime(state.GetLastSeen()),
-		ConnectedAt:    timestampToTime(state.GetConnectedAt()),
-		DisconnectedAt: timestampToTime(state.GetDisconnectedAt()),
-		InstanceUID:    state.GetInstanceUid(),
-		Capabilities:   Capabilities(state.GetCapabilities()),
-		SequenceNum:    state.GetSequenceNum(),
+		State:           ConvertState(state.GetState()),
+		LastSeen:        timestampToTime(state.GetLastSeen()),
+		ConnectedAt:     timestampToTime(state.GetConnectedAt()),
+		DisconnectedAt:  timestampToTime(state.GetDisconnectedAt()),
+		InstanceUID:     state.GetInstanceUid(),
+		Capabilities:    Capabilities(state.GetCapabilities()),
+		SequenceNum:     state.GetSequenceNum(),
+		InstanceHistory: convertInstanceHistory(state.GetInstanceHistory()),
 	}
 }
 
 // ConnectionStateToProto converts domain ConnectionState to v1alpha1 AgentConnectionState.
 func ConnectionStateToProto(agentID string, state ConnectionState) *v1alpha1.AgentConnectionState {
 	return &v1alpha1.AgentConnectionState{
-		AgentId:        agentID,
-		State:          convertToAPIState(state.State),
-		LastSeen:       timeToTimestamp(state.LastSeen),
-		ConnectedAt:    timeToTimestamp(state.ConnectedAt),
-		DisconnectedAt: timeToTimestamp(state.DisconnectedAt),
-		InstanceUid:    state.InstanceUID,
-		Capabilities:   uint64(state.Capabilities),
-		SequenceNum:    state.SequenceNum,
+		AgentId:         agentID,
+		State:           convertToAPIState(state.State),
+		LastSeen:        timeToTimestamp(state.LastSeen),
+		ConnectedAt:     timeToTimestamp(state.ConnectedAt),
+		DisconnectedAt:  timeToTimestamp(state.DisconnectedAt),
+		InstanceUid:     state.InstanceUID,
+		Capabilities:    uint64(state.Capabilities),
+		SequenceNum:     state.SequenceNum,
+		InstanceHistory: toAPIInstanceHistory(state.InstanceHistory),
 	}
 }
 
+func convertInstanceHistory(history []*v1alpha1.AgentInstance) []InstanceRecord {
+	if len(history) == 0 {
+		return nil
+	}
+	ret := make([]InstanceRecord, 0, len(history))
+	for _, inst := range history {
+		ret = append(ret, InstanceRecord{
+			InstanceUID: inst.GetInstanceUid(),
+			FirstSeen:   timestampToTime(inst.GetFirstSeen()),
+			LastSeen:    timestampToTime(inst.GetLastSeen()),
+		})
+	}
+	return ret
+}
+
+func toAPIInstanceHistory(history []InstanceRecord) []*v1alpha1.AgentInstance {
+	if len(history) == 0 {
+		return nil
+	}
+	ret := make([]*v1alpha1.AgentInstance, 0, len(history))
+	for _, inst := range history {
+		ret = append(ret, &v1alpha1.AgentInstance{
+			InstanceUid: inst.InstanceUID,
+			FirstSeen:   timeToTimestamp(inst.FirstSeen),
+			LastSeen:    timeToTimestamp(inst.LastSeen),
+		})
+	}
+	return ret
+}
+
 // ConvertHealth converts OpAMP ComponentHealth to domain ComponentHealth.
 func ConvertHealth(h *protobufs.ComponentHealth) *ComponentHealth {
 	if h == nil {
@@ -184,6 +218,8 @@ func ConvertConfigSyncStatus(status v1alpha1.ConfigSyncStatus) ConfigSyncStatus
 		return ConfigSyncApplying
 	case v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_ERROR:
 		return ConfigSyncError
+	case v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_UNSUPPORTED:
+		return ConfigSyncUnsupported
 	default:
 		return ConfigSyncUnknown
 	}
@@ -206,7 +242,8 @@ func timestampToTime(ts *timestamppb.Timestamp) *time.Time {
 	return &t
 }
 
-func convertFromAPIState(state v1alpha1.AgentState) State {
+// ConvertState converts v1alpha1 AgentState to domain State.
+func ConvertState(state v1alpha1.AgentState) State {
 	switch state {
 	case v1alpha1.AgentState_AGENT_STATE_CONNECTED:
 		return StateConnected
@@ -238,6 +275,8 @@ func convertToAPIConfigSync(status ConfigSyncStatus) v1alpha1.ConfigSyncStatus {
 		return v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_APPLYING
 	case ConfigSyncError:
 		return v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_ERROR
+	case ConfigSyncUnsupported:
+		return v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_UNSUPPORTED
 	default:
 		return v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_UNKNOWN
 	}
@@ -0,0 +1,266 @@
+package agent
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"github.com/otelfleet/otelfleet/pkg/util/parallel"
+)
+
+// ErrInvalidCursor is returned for cursors that weren't returned by a previous page
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// SortField orders the agents returned by ListPage, ties are broken by agent ID.
+type SortField int
+
+const (
+	SortByID SortField = iota
+	SortByName
+	SortByLastSeen
+	SortByState
+)
+
+// HealthState is the health of an agent's collector, as filtered by ListPage.
+type HealthState int
+
+const (
+	HealthUnknown HealthState = iota
+	HealthHealthy
+	HealthUnhealthy
+)
+
+// ListQuery filters, orders and pages the agents returned by ListPage. Empty filters match
+// every agent.
+type ListQuery struct {
+	// View selects the status assembled for the agents of the page
+	View StatusView
+	// IncludeDeleted also lists the agents deleted within the grace period
+	IncludeDeleted bool
+
+	States             []State
+	Labels             map[string]string
+	ConfigIDs          []string
+	Health             []HealthState
+	ConfigSyncStatuses []ConfigSyncStatus
+
+	SortBy     SortField
+	Descending bool
+	// After is the cursor returned with the previous page, the first page when empty
+	After string
+	// Limit is the maximum number of agents of the page, all the matching agents when 0
+	Limit int
+}
+
+// ListPage is a page of the agents matching a ListQuery.
+type ListPage struct {
+	ListResult
+	// Matched is the number of agents matching the filters, across all pages
+	Matched int
+	// Next is the cursor of the next page, empty on the last page
+	Next string
+}
+
+// Health returns the health state of the agent, HealthUnknown if it didn't report it
+func (a *Agent) Health() HealthState {
+	switch {
+	case a.Status.Health == nil:
+		return HealthUnknown
+	case a.Status.Health.Healthy:
+		return HealthHealthy
+	default:
+		return HealthUnhealthy
+	}
+}
+
+// ListPage returns a page of the agents matching q. Only the stores needed to filter and
+// order the agents are read for all of them, the status selected by the view is assembled
+// for the agents of the page.
+func (r *repository) ListPage(ctx context.Context, q ListQuery) (*ListPage, error) {
+	after, err := parseCursor(q.After)
+	if err != nil {
+		return nil, err
+	}
+	registrations, err := r.registryStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+
+	candidates := make([]*Agent, 0, len(registrations))
+	for _, reg := range registrations {
+		if (reg.GetDeletedAt() != nil && !q.IncludeDeleted) || !tenant.Visible(ctx, reg.GetTenant()) {
+			continue
+		}
+		candidates = append(candidates, &Agent{
+			ID:           reg.GetId(),
+			FriendlyName: reg.GetFriendlyName(),
+			DeletedAt:    timestampToTime(reg.GetDeletedAt()),
+			RevokedAt:    timestampToTime(reg.GetRevokedAt()),
+			Tenant:       reg.GetTenant(),
+		})
+	}
+	page := &ListPage{ListResult: ListResult{Errors: map[string]error{}, Total: len(candidates)}}
+
+	_ = parallel.ForEach(ctx, r.concurrency, candidates, func(ctx context.Context, agent *Agent) error {
+		r.loadIndexed(ctx, agent, q)
+		return nil
+	})
+	candidates = slices.DeleteFunc(candidates, func(agent *Agent) bool { return !q.matches(agent) })
+	page.Matched = len(candidates)
+
+	slices.SortFunc(candidates, func(x, y *Agent) int {
+		c := cmp.Or(strings.Compare(sortKey(x, q.SortBy), sortKey(y, q.SortBy)), strings.Compare(x.ID, y.ID))
+		if q.Descending {
+			return -c
+		}
+		return c
+	})
+	if after != nil {
+		start, _ := slices.BinarySearchFunc(candidates, after, func(agent *Agent, c *cursor) int {
+			ac := cmp.Or(strings.Compare(sortKey(agent, q.SortBy), c.key), strings.Compare(agent.ID, c.id))
+			if q.Descending {
+				ac = -ac
+			}
+			// the agent of the cursor itself was on the previous page
+			if ac == 0 {
+				return -1
+			}
+			return ac
+		})
+		candidates = candidates[start:]
+	}
+	if q.Limit > 0 && len(candidates) > q.Limit {
+		candidates = candidates[:q.Limit]
+		last := candidates[q.Limit-1]
+		page.Next = formatCursor(&cursor{key: sortKey(last, q.SortBy), id: last.ID})
+	}
+
+	agents := make([]*Agent, len(candidates))
+	positions := make([]int, len(candidates))
+	for i := range positions {
+		positions[i] = i
+	}
+	errs := parallel.Collect(ctx, r.concurrency, positions, func(ctx context.Context, i int) error {
+		agent, err := r.GetView(ctx, candidates[i].ID, q.View)
+		agents[i] = agent
+		return err
+	})
+	for i, err := range errs {
+		switch {
+		case errors.Is(err, ErrAgentNotFound):
+			// purged since it was listed
+			page.Total--
+			page.Matched--
+		case err != nil:
+			page.Errors[candidates[i].ID] = err
+		default:
+			page.Agents = append(page.Agents, agents[i])
+		}
+	}
+	return page, nil
+}
+
+// loadIndexed reads the parts of the agent the query filters or orders on
+func (r *repository) loadIndexed(ctx context.Context, agent *Agent, q ListQuery) {
+	needsConnection := len(q.States) > 0 || len(q.ConfigSyncStatuses) > 0 ||
+		q.SortBy == SortByLastSeen || q.SortBy == SortByState
+	if needsConnection {
+		if conn, err := r.connectionStore.Get(ctx, agent.ID); err == nil {
+			agent.Connection = ConvertConnectionState(conn)
+		} else if !grpcutil.IsErrorNotFound(err) {
+			r.logger.With("agent_id", agent.ID, "err", err).Debug("failed to get connection state")
+		}
+	}
+	if len(q.Labels) > 0 {
+		if attrs, err := r.attributesStore.Get(ctx, agent.ID); err == nil {
+			agent.Attributes = ConvertAttributes(attrs)
+		} else if !grpcutil.IsErrorNotFound(err) {
+			r.logger.With("agent_id", agent.ID, "err", err).Debug("failed to get agent attributes")
+		}
+	}
+	if len(q.Health) > 0 {
+		if health, err := r.healthStore.Get(ctx, agent.ID); err == nil {
+			agent.Status.Health = ConvertHealth(health)
+		} else if !grpcutil.IsErrorNotFound(err) {
+			r.logger.With("agent_id", agent.ID, "err", err).Debug("failed to get health")
+		}
+	}
+	if len(q.ConfigSyncStatuses) > 0 {
+		agent.Status.AssignedConfigID, agent.Status.AssignedConfigHash, agent.Status.ConfigSyncStatus, agent.Status.ConfigSyncReason =
+			r.computeConfigSync(ctx, agent.ID, agent.Connection.Capabilities)
+	} else if len(q.ConfigIDs) > 0 {
+		if assignment, err := r.configAssignmentStore.Get(ctx, agent.ID); err == nil {
+			agent.Status.AssignedConfigID = assignment.GetConfigId()
+		} else if !grpcutil.IsErrorNotFound(err) {
+			r.logger.With("agent_id", agent.ID, "err", err).Debug("failed to get config assignment")
+		}
+	}
+}
+
+// matches returns true if the agent, loaded by loadIndexed, matches the filters of the query
+func (q ListQuery) matches(agent *Agent) bool {
+	if len(q.States) > 0 && !slices.Contains(q.States, agent.Connection.State) {
+		return false
+	}
+	if len(q.Labels) > 0 && !agent.MatchesLabels(q.Labels) {
+		return false
+	}
+	if len(q.ConfigIDs) > 0 && !slices.Contains(q.ConfigIDs, agent.Status.AssignedConfigID) {
+		return false
+	}
+	if len(q.Health) > 0 && !slices.Contains(q.Health, agent.Health()) {
+		return false
+	}
+	if len(q.ConfigSyncStatuses) > 0 && !slices.Contains(q.ConfigSyncStatuses, agent.Status.ConfigSyncStatus) {
+		return false
+	}
+	return true
+}
+
+// sortKey returns the value agents are ordered by, as a string comparing in the same order
+func sortKey(agent *Agent, field SortField) string {
+	switch field {
+	case SortByName:
+		return agent.FriendlyName
+	case SortByLastSeen:
+		if agent.Connection.LastSeen == nil {
+			return ""
+		}
+		return fmt.Sprintf("%020d", agent.Connection.LastSeen.UnixNano())
+	case SortByState:
+		return fmt.Sprintf("%d", agent.Connection.State)
+	default:
+		return ""
+	}
+}
+
+// cursor is the position of the last agent of a page in the order of the query
+type cursor struct {
+	key string
+	id  string
+}
+
+func formatCursor(c *cursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.key + "\x00" + c.id))
+}
+
+func parseCursor(s string) (*cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	key, id, ok := strings.Cut(string(raw), "\x00")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor{key: key, id: id}, nil
+}
@@ -3,6 +3,7 @@ package agent
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/open-telemetry/opamp-go/protobufs"
 )
@@ -12,17 +13,52 @@ var (
 	ErrAgentNotFound = errors.New("agent not found")
 )
 
+// StatusView selects the parts of an agent's runtime status assembled by the repository.
+type StatusView int
+
+const (
+	// StatusViewFull assembles the complete status, including the effective config.
+	StatusViewFull StatusView = iota
+	// StatusViewBasic only assembles the config sync status.
+	StatusViewBasic
+	// StatusViewHealth adds component health and the remote config status to the basic view.
+	StatusViewHealth
+)
+
+// ListResult holds the agents listed, and the registered agents that could not be loaded.
+type ListResult struct {
+	Agents []*Agent
+	// Errors holds the load error of each agent missing from Agents, by agent ID
+	Errors map[string]error
+	// Total is the number of registered agents
+	Total int
+}
+
 // Repository provides unified access to agent data.
 // It abstracts the underlying storage complexity by assembling
 // complete Agent aggregates from multiple stores.
+//
+// Agents of another tenant than the one of the context are not found, see package tenant.
+// Updates, made on behalf of the agents themselves, aren't scoped.
 type Repository interface {
 	// Query operations - assemble complete Agent from multiple stores
 	Get(ctx context.Context, agentID string) (*Agent, error)
 	List(ctx context.Context) ([]*Agent, error)
 	Exists(ctx context.Context, agentID string) (bool, error)
+	// GetView and ListView are Get and List assembling only the status selected by view
+	GetView(ctx context.Context, agentID string, view StatusView) (*Agent, error)
+	ListView(ctx context.Context, view StatusView) ([]*Agent, error)
+	// ListPartial is ListView also returning the agents that could not be loaded
+	ListPartial(ctx context.Context, view StatusView) (*ListResult, error)
+	// ListPage returns a page of the agents matching the query, with the agents of the page
+	// that could not be loaded. Returns ErrInvalidCursor if the query's cursor is invalid.
+	ListPage(ctx context.Context, q ListQuery) (*ListPage, error)
 
 	// Registration operations
 	Register(ctx context.Context, id, friendlyName string) error
+	// RegisterInTenant is Register in the given tenant, Register registers agents in the
+	// default tenant
+	RegisterInTenant(ctx context.Context, id, friendlyName, tenant string) error
 
 	// Update operations - update specific aspects
 	UpdateAttributes(ctx context.Context, agentID string, desc *protobufs.AgentDescription) error
@@ -37,4 +73,25 @@ type Repository interface {
 	// Delete removes an agent and all associated data from all stores.
 	// Returns ErrAgentNotFound if the agent does not exist.
 	Delete(ctx context.Context, agentID string) error
+
+	// SoftDelete marks an agent as deleted at the given time. Deleted agents are left out
+	// of List, ListView and ListPartial but their data is kept until they are deleted.
+	// Returns ErrAgentNotFound if the agent does not exist.
+	SoftDelete(ctx context.Context, agentID string, at time.Time) error
+	// Restore clears the deletion mark of an agent and reports whether it was deleted.
+	// Returns ErrAgentNotFound if the agent does not exist.
+	Restore(ctx context.Context, agentID string) (bool, error)
+	// ListDeleted returns the agents marked as deleted, with the status selected by view
+	ListDeleted(ctx context.Context, view StatusView) ([]*Agent, error)
+
+	// Revoke marks an agent as revoked at the given time and reports whether it wasn't
+	// revoked already. Returns ErrAgentNotFound if the agent does not exist.
+	Revoke(ctx context.Context, agentID string, at time.Time) (bool, error)
+	// IsRevoked reports whether an agent was revoked, reading only its registration.
+	// Returns ErrAgentNotFound if the agent does not exist.
+	IsRevoked(ctx context.Context, agentID string) (bool, error)
+
+	// SetConcurrency bounds the store operations run in parallel, defaults to
+	// parallel.DefaultLimit
+	SetConcurrency(limit int)
 }
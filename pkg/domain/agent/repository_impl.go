@@ -2,15 +2,20 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
 	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
 	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
 	"github.com/otelfleet/otelfleet/pkg/util/configsync"
 	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"github.com/otelfleet/otelfleet/pkg/util/parallel"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // repository implements the Repository interface using existing storage.KeyValue stores.
@@ -25,6 +30,9 @@ type repository struct {
 	effectiveStore       storage.KeyValue[*protobufs.EffectiveConfig]
 	remoteStatusStore    storage.KeyValue[*protobufs.RemoteConfigStatus]
 	configAssignmentStore storage.KeyValue[*configv1alpha1.ConfigAssignment]
+
+	// store operations run in parallel by Delete
+	concurrency int
 }
 
 // NewRepository creates a new agent repository with the specified stores.
@@ -52,18 +60,23 @@ func NewRepository(
 
 // Get assembles the complete Agent domain model from multiple stores.
 func (r *repository) Get(ctx context.Context, agentID string) (*Agent, error) {
+	return r.GetView(ctx, agentID, StatusViewFull)
+}
+
+// GetView assembles the Agent domain model, reading only the status stores needed by view.
+func (r *repository) GetView(ctx context.Context, agentID string, view StatusView) (*Agent, error) {
 	// 1. Get core registration data (required)
-	registration, err := r.registryStore.Get(ctx, agentID)
+	registration, err := r.registration(ctx, agentID)
 	if err != nil {
-		if grpcutil.IsErrorNotFound(err) {
-			return nil, ErrAgentNotFound
-		}
-		return nil, fmt.Errorf("failed to get agent registration: %w", err)
+		return nil, err
 	}
 
 	agent := &Agent{
 		ID:           registration.GetId(),
 		FriendlyName: registration.GetFriendlyName(),
+		DeletedAt:    timestampToTime(registration.GetDeletedAt()),
+		RevokedAt:    timestampToTime(registration.GetRevokedAt()),
+		Tenant:       registration.GetTenant(),
 	}
 
 	// 2. Enrich with attributes (optional - may not exist yet)
@@ -81,36 +94,65 @@ func (r *repository) Get(ctx context.Context, agentID string) (*Agent, error) {
 	}
 
 	// 4. Enrich with status information (all optional)
-	agent.Status = r.assembleStatus(ctx, agentID)
+	agent.Status = r.assembleStatus(ctx, agentID, view, agent.Connection.Capabilities)
 
 	return agent, nil
 }
 
 // List returns all agents with their complete state.
 func (r *repository) List(ctx context.Context) ([]*Agent, error) {
+	return r.ListView(ctx, StatusViewFull)
+}
+
+// ListView returns all agents, with the status selected by view. Agents that could not be
+// loaded are logged and skipped.
+func (r *repository) ListView(ctx context.Context, view StatusView) ([]*Agent, error) {
+	res, err := r.ListPartial(ctx, view)
+	if err != nil {
+		return nil, err
+	}
+	for agentID, err := range res.Errors {
+		r.logger.With("agent_id", agentID, "err", err).Warn("failed to get agent during list")
+	}
+	return res.Agents, nil
+}
+
+// ListPartial returns all agents that could be loaded, with the status selected by view,
+// along with the errors of the others.
+func (r *repository) ListPartial(ctx context.Context, view StatusView) (*ListResult, error) {
 	registrations, err := r.registryStore.List(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list agents: %w", err)
 	}
 
-	agents := make([]*Agent, 0, len(registrations))
+	res := &ListResult{
+		Agents: make([]*Agent, 0, len(registrations)),
+		Errors: map[string]error{},
+	}
 	for _, reg := range registrations {
-		agent, err := r.Get(ctx, reg.GetId())
-		if err != nil {
-			// Log but don't fail the entire list
-			r.logger.With("agent_id", reg.GetId(), "err", err).Warn("failed to get agent during list")
+		if reg.GetDeletedAt() != nil || !tenant.Visible(ctx, reg.GetTenant()) {
 			continue
 		}
-		agents = append(agents, agent)
+		res.Total++
+		agent, err := r.GetView(ctx, reg.GetId(), view)
+		if errors.Is(err, ErrAgentNotFound) {
+			// deleted since it was listed
+			res.Total--
+			continue
+		} else if err != nil {
+			res.Errors[reg.GetId()] = err
+			continue
+		}
+		res.Agents = append(res.Agents, agent)
 	}
 
-	return agents, nil
+	return res, nil
 }
 
 // Exists checks if an agent is registered.
 func (r *repository) Exists(ctx context.Context, agentID string) (bool, error) {
-	_, err := r.registryStore.Get(ctx, agentID)
-	if grpcutil.IsErrorNotFound(err) {
+	_, err := r.registration(ctx, agentID)
+	if errors.Is(err, ErrAgentNotFound) {
 		return false, nil
 	}
 	if err != nil {
@@ -119,14 +161,110 @@ func (r *repository) Exists(ctx context.Context, agentID string) (bool, error) {
 	return true, nil
 }
 
+// registration returns the registration of the agent, or ErrAgentNotFound if it isn't
+// registered or is in another tenant than the one of the context
+func (r *repository) registration(ctx context.Context, agentID string) (*v1alpha1.AgentDescription, error) {
+	registration, err := r.registryStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, ErrAgentNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get agent registration: %w", err)
+	}
+	if !tenant.Visible(ctx, registration.GetTenant()) {
+		return nil, ErrAgentNotFound
+	}
+	return registration, nil
+}
+
 // Register creates the initial agent registration.
 func (r *repository) Register(ctx context.Context, id, friendlyName string) error {
+	return r.RegisterInTenant(ctx, id, friendlyName, "")
+}
+
+// RegisterInTenant creates the initial agent registration in the given tenant.
+func (r *repository) RegisterInTenant(ctx context.Context, id, friendlyName, tenant string) error {
 	return r.registryStore.Put(ctx, id, &v1alpha1.AgentDescription{
 		Id:           id,
 		FriendlyName: friendlyName,
+		Tenant:       tenant,
 	})
 }
 
+// SoftDelete marks the agent's registration as deleted.
+func (r *repository) SoftDelete(ctx context.Context, agentID string, at time.Time) error {
+	registration, err := r.registration(ctx, agentID)
+	if err != nil {
+		return err
+	}
+	registration.DeletedAt = timestamppb.New(at)
+	return r.registryStore.Put(ctx, agentID, registration)
+}
+
+// Restore clears the deletion mark of the agent's registration.
+func (r *repository) Restore(ctx context.Context, agentID string) (bool, error) {
+	registration, err := r.registration(ctx, agentID)
+	if err != nil {
+		return false, err
+	}
+	if registration.GetDeletedAt() == nil {
+		return false, nil
+	}
+	registration.DeletedAt = nil
+	if err := r.registryStore.Put(ctx, agentID, registration); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Revoke marks the agent's registration as revoked.
+func (r *repository) Revoke(ctx context.Context, agentID string, at time.Time) (bool, error) {
+	registration, err := r.registration(ctx, agentID)
+	if err != nil {
+		return false, err
+	}
+	if registration.GetRevokedAt() != nil {
+		return false, nil
+	}
+	registration.RevokedAt = timestamppb.New(at)
+	if err := r.registryStore.Put(ctx, agentID, registration); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// IsRevoked reports whether the agent's registration is marked as revoked.
+func (r *repository) IsRevoked(ctx context.Context, agentID string) (bool, error) {
+	registration, err := r.registration(ctx, agentID)
+	if err != nil {
+		return false, err
+	}
+	return registration.GetRevokedAt() != nil, nil
+}
+
+// ListDeleted returns the agents marked as deleted. Agents that could not be loaded are
+// logged and skipped.
+func (r *repository) ListDeleted(ctx context.Context, view StatusView) ([]*Agent, error) {
+	registrations, err := r.registryStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+	agents := []*Agent{}
+	for _, reg := range registrations {
+		if reg.GetDeletedAt() == nil || !tenant.Visible(ctx, reg.GetTenant()) {
+			continue
+		}
+		agent, err := r.GetView(ctx, reg.GetId(), view)
+		if errors.Is(err, ErrAgentNotFound) {
+			continue
+		} else if err != nil {
+			r.logger.With("agent_id", reg.GetId(), "err", err).Warn("failed to get deleted agent during list")
+			continue
+		}
+		agents = append(agents, agent)
+	}
+	return agents, nil
+}
+
 // UpdateAttributes stores OpAMP-reported agent description.
 func (r *repository) UpdateAttributes(ctx context.Context, agentID string, desc *protobufs.AgentDescription) error {
 	return r.attributesStore.Put(ctx, agentID, desc)
@@ -166,49 +304,66 @@ func (r *repository) GetConnectionState(ctx context.Context, agentID string) (*C
 	return &state, nil
 }
 
-// assembleStatus gathers all status-related data.
-func (r *repository) assembleStatus(ctx context.Context, agentID string) AgentRuntimeStatus {
+// assembleStatus gathers the status-related data selected by view.
+func (r *repository) assembleStatus(ctx context.Context, agentID string, view StatusView, capabilities Capabilities) AgentRuntimeStatus {
 	status := AgentRuntimeStatus{}
 
-	if health, err := r.healthStore.Get(ctx, agentID); err == nil {
-		status.Health = ConvertHealth(health)
-	} else if !grpcutil.IsErrorNotFound(err) {
-		r.logger.With("agent_id", agentID, "err", err).Debug("failed to get health")
-	}
+	if view != StatusViewBasic {
+		if health, err := r.healthStore.Get(ctx, agentID); err == nil {
+			status.Health = ConvertHealth(health)
+		} else if !grpcutil.IsErrorNotFound(err) {
+			r.logger.With("agent_id", agentID, "err", err).Debug("failed to get health")
+		}
 
-	if config, err := r.effectiveStore.Get(ctx, agentID); err == nil {
-		status.EffectiveConfig = ConvertEffectiveConfig(config)
-	} else if !grpcutil.IsErrorNotFound(err) {
-		r.logger.With("agent_id", agentID, "err", err).Debug("failed to get effective config")
+		if remoteStatus, err := r.remoteStatusStore.Get(ctx, agentID); err == nil {
+			status.RemoteConfigStatus = ConvertRemoteConfigStatus(remoteStatus)
+		} else if !grpcutil.IsErrorNotFound(err) {
+			r.logger.With("agent_id", agentID, "err", err).Debug("failed to get remote config status")
+		}
 	}
 
-	if remoteStatus, err := r.remoteStatusStore.Get(ctx, agentID); err == nil {
-		status.RemoteConfigStatus = ConvertRemoteConfigStatus(remoteStatus)
-	} else if !grpcutil.IsErrorNotFound(err) {
-		r.logger.With("agent_id", agentID, "err", err).Debug("failed to get remote config status")
+	if view == StatusViewFull {
+		if config, err := r.effectiveStore.Get(ctx, agentID); err == nil {
+			status.EffectiveConfig = ConvertEffectiveConfig(config)
+		} else if !grpcutil.IsErrorNotFound(err) {
+			r.logger.With("agent_id", agentID, "err", err).Debug("failed to get effective config")
+		}
 	}
 
-	status.ConfigSyncStatus, status.ConfigSyncReason = r.computeConfigSync(ctx, agentID)
+	status.AssignedConfigID, status.AssignedConfigHash, status.ConfigSyncStatus, status.ConfigSyncReason = r.computeConfigSync(ctx, agentID, capabilities)
 
 	return status
 }
 
-// computeConfigSync computes the config sync status using the shared utility.
-func (r *repository) computeConfigSync(ctx context.Context, agentID string) (ConfigSyncStatus, string) {
+// computeConfigSync returns the assigned config ID and hash and computes the config sync
+// status using the shared utility.
+func (r *repository) computeConfigSync(ctx context.Context, agentID string, capabilities Capabilities) (string, []byte, ConfigSyncStatus, string) {
 	assignment, err := r.configAssignmentStore.Get(ctx, agentID)
 	if grpcutil.IsErrorNotFound(err) {
-		return ConfigSyncUnknown, "no assigned config"
+		return "", nil, ConfigSyncUnknown, "no assigned config"
 	} else if err != nil {
 		r.logger.With("agent_id", agentID, "err", err).Debug("failed to get config assignment")
-		return ConfigSyncUnknown, "internal error"
+		return "", nil, ConfigSyncUnknown, "internal error"
 	}
 
-	v1Status, reason, err := configsync.ComputeConfigSyncStatus(ctx, agentID, assignment.GetConfigHash(), r.remoteStatusStore)
+	v1Status, reason, err := configsync.ComputeConfigSyncStatus(ctx, agentID, assignment.GetConfigHash(), uint64(capabilities), r.remoteStatusStore)
 	if err != nil {
 		r.logger.With("agent_id", agentID, "err", err).Debug("failed to compute config sync status")
-		return ConfigSyncUnknown, "internal error"
+		return assignment.GetConfigId(), assignment.GetConfigHash(), ConfigSyncUnknown, "internal error"
 	}
-	return ConvertConfigSyncStatus(v1Status), reason
+	return assignment.GetConfigId(), assignment.GetConfigHash(), ConvertConfigSyncStatus(v1Status), reason
+}
+
+// namedStore is a store an agent's data is deleted from
+type namedStore struct {
+	name  string
+	store interface{ Delete(context.Context, string) error }
+}
+
+// SetConcurrency bounds the store operations Delete runs in parallel, defaults to
+// parallel.DefaultLimit
+func (r *repository) SetConcurrency(limit int) {
+	r.concurrency = limit
 }
 
 // Delete removes an agent and all associated data from all stores.
@@ -227,12 +382,9 @@ func (r *repository) Delete(ctx context.Context, agentID string) error {
 
 	r.logger.With("agent_id", agentID).Info("deleting agent from all stores")
 
-	// Delete from all stores in reverse dependency order (registry last)
+	// Delete from all stores in parallel, the registry last
 	// Log failures but continue - agent may not have data in all stores
-	stores := []struct {
-		name  string
-		store interface{ Delete(context.Context, string) error }
-	}{
+	stores := []namedStore{
 		{"configAssignment", r.configAssignmentStore},
 		{"remoteStatus", r.remoteStatusStore},
 		{"effective", r.effectiveStore},
@@ -241,11 +393,12 @@ func (r *repository) Delete(ctx context.Context, agentID string) error {
 		{"attributes", r.attributesStore},
 	}
 
-	for _, s := range stores {
-		if err := s.store.Delete(ctx, agentID); err != nil {
-			if !grpcutil.IsErrorNotFound(err) {
-				r.logger.With("agent_id", agentID, "store", s.name, "err", err).Warn("failed to delete from store")
-			}
+	errs := parallel.Collect(ctx, r.concurrency, stores, func(ctx context.Context, s namedStore) error {
+		return s.store.Delete(ctx, agentID)
+	})
+	for i, err := range errs {
+		if err != nil && !grpcutil.IsErrorNotFound(err) {
+			r.logger.With("agent_id", agentID, "store", stores[i].name, "err", err).Warn("failed to delete from store")
 		}
 	}
 
@@ -2,8 +2,10 @@ package agent_test
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/pebble/v2"
 	"github.com/cockroachdb/pebble/v2/vfs"
@@ -13,6 +15,7 @@ import (
 	"github.com/otelfleet/otelfleet/pkg/domain/agent"
 	"github.com/otelfleet/otelfleet/pkg/storage"
 	otelpebble "github.com/otelfleet/otelfleet/pkg/storage/pebble"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -214,6 +217,92 @@ func TestRepository_List(t *testing.T) {
 	}
 }
 
+func TestRepository_Tenants(t *testing.T) {
+	repo, _ := setupTest(t)
+	ctx := context.Background()
+	require.NoError(t, repo.Register(ctx, "agent-default", "default"))
+	require.NoError(t, repo.RegisterInTenant(ctx, "agent-a", "a", "team-a"))
+	require.NoError(t, repo.RegisterInTenant(ctx, "agent-b", "b", "team-b"))
+
+	teamA := tenant.NewContext(ctx, "team-a")
+	agents, err := repo.List(teamA)
+	require.NoError(t, err)
+	require.Len(t, agents, 1)
+	assert.Equal(t, "agent-a", agents[0].ID)
+	assert.Equal(t, "team-a", agents[0].Tenant)
+
+	page, err := repo.ListPage(teamA, agent.ListQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Total)
+
+	// agents of other tenants are not found
+	_, err = repo.Get(teamA, "agent-b")
+	assert.ErrorIs(t, err, agent.ErrAgentNotFound)
+	exists, err := repo.Exists(teamA, "agent-default")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	_, err = repo.Revoke(teamA, "agent-b", time.Now())
+	assert.ErrorIs(t, err, agent.ErrAgentNotFound)
+
+	// the default tenant only sees its agents
+	agents, err = repo.List(tenant.NewContext(ctx, ""))
+	require.NoError(t, err)
+	require.Len(t, agents, 1)
+	assert.Equal(t, "agent-default", agents[0].ID)
+
+	// contexts without a tenant see all agents
+	agents, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, agents, 3)
+	exists, err = repo.Exists(tenant.Unscoped(teamA), "agent-b")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+// failingGetKV fails to get the keys in failing
+type failingGetKV[T any] struct {
+	storage.KeyValue[T]
+	failing map[string]bool
+}
+
+func (f failingGetKV[T]) Get(ctx context.Context, key string) (T, error) {
+	if f.failing[key] {
+		var zero T
+		return zero, errors.New("corrupted")
+	}
+	return f.KeyValue.Get(ctx, key)
+}
+
+func TestRepository_ListPartial(t *testing.T) {
+	_, stores := setupTest(t)
+	ctx := context.Background()
+	for _, id := range []string{"agent-1", "agent-2", "agent-3"} {
+		require.NoError(t, stores.registry.Put(ctx, id, &agentsv1alpha1.AgentDescription{Id: id}))
+	}
+	repo := agent.NewRepository(
+		slog.Default(),
+		failingGetKV[*agentsv1alpha1.AgentDescription]{stores.registry, map[string]bool{"agent-2": true}},
+		stores.attributes,
+		stores.connection,
+		stores.health,
+		stores.effective,
+		stores.remoteStatus,
+		stores.configAssignment,
+	)
+
+	res, err := repo.ListPartial(ctx, agent.StatusViewBasic)
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Total)
+	assert.Len(t, res.Agents, 2)
+	require.Len(t, res.Errors, 1)
+	assert.ErrorContains(t, res.Errors["agent-2"], "corrupted")
+
+	// List skips the agents that could not be loaded
+	agents, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, agents, 2)
+}
+
 func TestRepository_UpdateAttributes(t *testing.T) {
 	repo, stores := setupTest(t)
 	ctx := context.Background()
@@ -259,6 +348,47 @@ func TestRepository_UpdateConnectionState(t *testing.T) {
 	assert.Equal(t, agent.StateConnected, ag.Connection.State)
 }
 
+func TestConnectionState_ChangeInstance(t *testing.T) {
+	repo, _ := setupTest(t)
+	ctx := context.Background()
+
+	agentID := "test-agent-reinstall"
+	require.NoError(t, repo.Register(ctx, agentID, "Test Agent"))
+
+	// state persisted before instance history was tracked
+	connectedAt := time.Now().Add(-time.Hour)
+	lastSeen := time.Now().Add(-time.Minute)
+	state := agent.ConnectionState{
+		State:       agent.StateConnected,
+		InstanceUID: []byte("instance-1"),
+		ConnectedAt: &connectedAt,
+		LastSeen:    &lastSeen,
+		SequenceNum: 42,
+	}
+	now := time.Now()
+	state.ChangeInstance([]byte("instance-2"), now)
+	assert.Equal(t, []byte("instance-2"), state.InstanceUID)
+	assert.Equal(t, uint64(0), state.SequenceNum)
+	assert.Equal(t, now, *state.ConnectedAt)
+	require.NoError(t, repo.UpdateConnectionState(ctx, agentID, state))
+
+	stored, err := repo.GetConnectionState(ctx, agentID)
+	require.NoError(t, err)
+	require.Len(t, stored.InstanceHistory, 2)
+	assert.Equal(t, []byte("instance-1"), stored.InstanceHistory[0].InstanceUID)
+	assert.True(t, connectedAt.Equal(*stored.InstanceHistory[0].FirstSeen))
+	assert.True(t, lastSeen.Equal(*stored.InstanceHistory[0].LastSeen))
+	assert.Equal(t, []byte("instance-2"), stored.InstanceHistory[1].InstanceUID)
+	assert.Nil(t, stored.InstanceHistory[1].LastSeen)
+
+	// the history is capped
+	for i := range agent.MaxInstanceHistory {
+		stored.ChangeInstance([]byte{byte(i)}, now)
+	}
+	assert.Len(t, stored.InstanceHistory, agent.MaxInstanceHistory)
+	assert.Equal(t, []byte{agent.MaxInstanceHistory - 1}, stored.InstanceHistory[agent.MaxInstanceHistory-1].InstanceUID)
+}
+
 func TestRepository_UpdateHealth(t *testing.T) {
 	repo, _ := setupTest(t)
 	ctx := context.Background()
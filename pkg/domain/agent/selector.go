@@ -0,0 +1,264 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidSelector is returned for selector expressions that don't parse
+var ErrInvalidSelector = errors.New("invalid selector")
+
+// Operator is the comparison a selector requirement makes on a label
+type Operator string
+
+const (
+	OpEquals       Operator = "="
+	OpNotEquals    Operator = "!="
+	OpIn           Operator = "in"
+	OpNotIn        Operator = "notin"
+	OpExists       Operator = "exists"
+	OpDoesNotExist Operator = "!"
+)
+
+// Requirement is a condition on a single label. Like Kubernetes label selectors, != and
+// notin also match agents without the label.
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Matches returns true if the labels satisfy the requirement
+func (r Requirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch r.Operator {
+	case OpEquals, OpIn:
+		return ok && slices.Contains(r.Values, value)
+	case OpNotEquals, OpNotIn:
+		return !ok || !slices.Contains(r.Values, value)
+	case OpExists:
+		return ok
+	case OpDoesNotExist:
+		return !ok
+	default:
+		return false
+	}
+}
+
+func (r Requirement) String() string {
+	switch r.Operator {
+	case OpExists:
+		return r.Key
+	case OpDoesNotExist:
+		return "!" + r.Key
+	case OpIn, OpNotIn:
+		return fmt.Sprintf("%s %s (%s)", r.Key, r.Operator, strings.Join(r.Values, ","))
+	default:
+		return r.Key + string(r.Operator) + r.Values[0]
+	}
+}
+
+// Selector selects agents by their labels. It matches the agents satisfying all the
+// requirements of any of its groups, and no agent when empty, so that a missing selector
+// never selects the whole fleet.
+type Selector struct {
+	Groups [][]Requirement
+}
+
+// Empty returns true if the selector has no requirements
+func (s Selector) Empty() bool {
+	return len(s.Groups) == 0
+}
+
+// Matches returns true if the labels satisfy every requirement of one of the groups
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, group := range s.Groups {
+		if !slices.ContainsFunc(group, func(r Requirement) bool { return !r.Matches(labels) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the selector as an expression ParseSelector parses back
+func (s Selector) String() string {
+	groups := make([]string, len(s.Groups))
+	for i, group := range s.Groups {
+		reqs := make([]string, len(group))
+		for j, r := range group {
+			reqs[j] = r.String()
+		}
+		groups[i] = strings.Join(reqs, ",")
+	}
+	return strings.Join(groups, " || ")
+}
+
+// NewSelector returns the selector of agents having all the labels and matching the
+// expression, either of which may be empty
+func NewSelector(labels map[string]string, expr string) (Selector, error) {
+	sel, err := ParseSelector(expr)
+	if err != nil || len(labels) == 0 {
+		return sel, err
+	}
+	exact := make([]Requirement, 0, len(labels))
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		exact = append(exact, Requirement{Key: key, Operator: OpEquals, Values: []string{labels[key]}})
+	}
+	if sel.Empty() {
+		return Selector{Groups: [][]Requirement{exact}}, nil
+	}
+	for i, group := range sel.Groups {
+		sel.Groups[i] = append(slices.Clone(exact), group...)
+	}
+	return sel, nil
+}
+
+// ParseSelector parses a selector expression: groups of comma separated requirements,
+// separated by ||. A requirement is one of
+//
+//	key=value, key==value, key!=value
+//	key in (a,b), key notin (a,b)
+//	key, !key
+//
+// For example "env=prod,region in (us,eu) || canary" selects the production agents of the us
+// and eu regions, and the canary agents.
+func ParseSelector(expr string) (Selector, error) {
+	var sel Selector
+	if strings.TrimSpace(expr) == "" {
+		return sel, nil
+	}
+	for _, groupExpr := range strings.Split(expr, "||") {
+		p := &selectorParser{input: groupExpr}
+		group, err := p.parseGroup()
+		if err != nil {
+			return Selector{}, fmt.Errorf("%w %q: %w", ErrInvalidSelector, expr, err)
+		}
+		sel.Groups = append(sel.Groups, group)
+	}
+	return sel, nil
+}
+
+type selectorParser struct {
+	input string
+	pos   int
+}
+
+func (p *selectorParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// peek returns the next non space byte, 0 at the end of the input
+func (p *selectorParser) peek() byte {
+	p.skipSpaces()
+	if p.pos == len(p.input) {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+// word reads a key or value, ending at spaces, operators and delimiters
+func (p *selectorParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune(" ,()=!", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *selectorParser) parseGroup() ([]Requirement, error) {
+	var group []Requirement
+	for {
+		r, err := p.parseRequirement()
+		if err != nil {
+			return nil, err
+		}
+		group = append(group, r)
+		switch p.peek() {
+		case 0:
+			return group, nil
+		case ',':
+			p.pos++
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", p.input[p.pos], p.pos)
+		}
+	}
+}
+
+func (p *selectorParser) parseRequirement() (Requirement, error) {
+	if p.peek() == '!' {
+		p.pos++
+		key := p.word()
+		if key == "" {
+			return Requirement{}, errors.New("missing label key after !")
+		}
+		return Requirement{Key: key, Operator: OpDoesNotExist}, nil
+	}
+	key := p.word()
+	if key == "" {
+		return Requirement{}, errors.New("missing label key")
+	}
+	switch c := p.peek(); {
+	case c == 0 || c == ',':
+		return Requirement{Key: key, Operator: OpExists}, nil
+	case strings.HasPrefix(p.input[p.pos:], "!="):
+		p.pos += 2
+		return p.parseValue(key, OpNotEquals)
+	case strings.HasPrefix(p.input[p.pos:], "=="):
+		p.pos += 2
+		return p.parseValue(key, OpEquals)
+	case c == '=':
+		p.pos++
+		return p.parseValue(key, OpEquals)
+	}
+	switch op := Operator(p.word()); op {
+	case OpIn, OpNotIn:
+		values, err := p.parseSet()
+		if err != nil {
+			return Requirement{}, fmt.Errorf("label %s: %w", key, err)
+		}
+		return Requirement{Key: key, Operator: op, Values: values}, nil
+	case "":
+		return Requirement{}, fmt.Errorf("unexpected %q after label %s", p.input[p.pos], key)
+	default:
+		return Requirement{}, fmt.Errorf("unknown operator %q after label %s", op, key)
+	}
+}
+
+func (p *selectorParser) parseValue(key string, op Operator) (Requirement, error) {
+	value := p.word()
+	if value == "" {
+		return Requirement{}, fmt.Errorf("missing value of label %s", key)
+	}
+	return Requirement{Key: key, Operator: op, Values: []string{value}}, nil
+}
+
+// parseSet parses a parenthesized, comma separated list of values
+func (p *selectorParser) parseSet() ([]string, error) {
+	if p.peek() != '(' {
+		return nil, errors.New("expected ( to start the set of values")
+	}
+	p.pos++
+	var values []string
+	for {
+		value := p.word()
+		if value == "" {
+			return nil, errors.New("empty value in set")
+		}
+		values = append(values, value)
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return values, nil
+		default:
+			return nil, errors.New("expected ) to end the set of values")
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelector(t *testing.T) {
+	labels := map[string]string{"env": "prod", "region": "us-east", "tier": "web"}
+	tests := []struct {
+		expr    string
+		matches bool
+	}{
+		{"env=prod", true},
+		{"env==prod", true},
+		{"env = staging", false},
+		{"env!=staging", true},
+		{"team!=infra", true},
+		{"region in (us-east, eu-west)", true},
+		{"region notin (us-east,eu-west)", false},
+		{"team notin (infra)", true},
+		{"tier", true},
+		{"!tier", false},
+		{"!canary", true},
+		{"env=prod,region=eu-west", false},
+		{"env=prod,region=eu-west || tier=web", true},
+		{"canary || env=staging", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		sel, err := ParseSelector(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.matches, sel.Matches(labels), tt.expr)
+
+		// the formatted selector parses back to the same selector
+		again, err := ParseSelector(sel.String())
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, sel, again, tt.expr)
+	}
+
+	for _, expr := range []string{
+		"env=",
+		"=prod",
+		"env in prod",
+		"env in (prod",
+		"env in (prod,)",
+		"env matches prod",
+		"env=prod,",
+		"env=prod || ",
+		"!",
+		"env=prod)",
+	} {
+		_, err := ParseSelector(expr)
+		assert.ErrorIs(t, err, ErrInvalidSelector, expr)
+	}
+}
+
+func TestNewSelector(t *testing.T) {
+	sel, err := NewSelector(map[string]string{"env": "prod"}, "region=us || canary")
+	require.NoError(t, err)
+	assert.Equal(t, "env=prod,region=us || env=prod,canary", sel.String())
+	assert.True(t, sel.Matches(map[string]string{"env": "prod", "canary": "true"}))
+	assert.False(t, sel.Matches(map[string]string{"env": "staging", "canary": "true"}))
+
+	sel, err = NewSelector(nil, "")
+	require.NoError(t, err)
+	assert.True(t, sel.Empty())
+	assert.False(t, sel.Matches(map[string]string{}))
+}
@@ -13,6 +13,12 @@ type Agent struct {
 	// Core Identity (from bootstrap registration)
 	ID           string
 	FriendlyName string
+	// DeletedAt is set while the agent is deleted, until it is purged or restored
+	DeletedAt *time.Time
+	// RevokedAt is set once the agent is revoked, its connections are rejected from then on
+	RevokedAt *time.Time
+	// Tenant is the tenant the agent joined when it bootstrapped, see package tenant
+	Tenant string
 
 	// OpAMP-Reported Metadata (from attributes store)
 	Attributes AgentAttributes
@@ -40,6 +46,20 @@ type ConnectionState struct {
 	InstanceUID    []byte
 	Capabilities   Capabilities
 	SequenceNum    uint64
+	// InstanceHistory lists the instance UIDs the agent has connected with,
+	// oldest first. The last entry is the current instance.
+	InstanceHistory []InstanceRecord
+}
+
+// MaxInstanceHistory is the number of instance UIDs kept per agent
+const MaxInstanceHistory = 20
+
+// InstanceRecord records an OpAMP instance UID an agent has connected with.
+type InstanceRecord struct {
+	InstanceUID []byte
+	FirstSeen   *time.Time
+	// LastSeen is nil for the current instance
+	LastSeen *time.Time
 }
 
 // Capabilities wraps the bitmask with helper methods.
@@ -56,6 +76,10 @@ const (
 
 // AgentRuntimeStatus represents runtime status from multiple sources.
 type AgentRuntimeStatus struct {
+	// AssignedConfigID is the ID of the config assigned to the agent, if any, and
+	// AssignedConfigHash the hash of the assigned config
+	AssignedConfigID   string
+	AssignedConfigHash []byte
 	Health             *ComponentHealth
 	EffectiveConfig    *EffectiveConfig
 	RemoteConfigStatus *RemoteConfigStatus
@@ -72,6 +96,7 @@ const (
 	ConfigSyncOutOfSync
 	ConfigSyncApplying
 	ConfigSyncError
+	ConfigSyncUnsupported
 )
 
 // ComponentHealth represents the health status of an agent component.
@@ -112,6 +137,35 @@ const (
 	RemoteConfigStatusFailed
 )
 
+// ChangeInstance rebinds the connection state to a new instance UID, closing the
+// current instance in the history. Sequence tracking restarts for the new instance.
+func (c *ConnectionState) ChangeInstance(instanceUID []byte, now time.Time) {
+	// state persisted before instance history was tracked only knows the current instance
+	if len(c.InstanceHistory) == 0 && len(c.InstanceUID) > 0 {
+		c.InstanceHistory = append(c.InstanceHistory, InstanceRecord{
+			InstanceUID: c.InstanceUID,
+			FirstSeen:   c.ConnectedAt,
+		})
+	}
+	if n := len(c.InstanceHistory); n > 0 && c.InstanceHistory[n-1].LastSeen == nil {
+		lastSeen := now
+		if c.LastSeen != nil {
+			lastSeen = *c.LastSeen
+		}
+		c.InstanceHistory[n-1].LastSeen = &lastSeen
+	}
+	c.InstanceHistory = append(c.InstanceHistory, InstanceRecord{
+		InstanceUID: instanceUID,
+		FirstSeen:   &now,
+	})
+	if n := len(c.InstanceHistory); n > MaxInstanceHistory {
+		c.InstanceHistory = c.InstanceHistory[n-MaxInstanceHistory:]
+	}
+	c.InstanceUID = instanceUID
+	c.ConnectedAt = &now
+	c.SequenceNum = 0
+}
+
 // IsConnected returns true if the agent is currently connected.
 func (a *Agent) IsConnected() bool {
 	return a.Connection.State == StateConnected
@@ -122,25 +176,30 @@ func (a *Agent) CanReceiveConfig() bool {
 	return a.Connection.Capabilities.HasAcceptsRemoteConfig()
 }
 
-// MatchesLabels checks if the agent's attributes match all the specified selector labels.
-// Returns false if the selector is empty (to prevent accidentally matching all agents).
-func (a *Agent) MatchesLabels(selector map[string]string) bool {
-	if len(selector) == 0 {
-		return false
-	}
-
-	// Build a map of agent attributes for easier lookup
-	agentLabels := make(map[string]string)
+// Labels returns the agent's string valued attributes, identifying and non-identifying.
+func (a *Agent) Labels() map[string]string {
+	labels := make(map[string]string)
 	for k, v := range a.Attributes.Identifying {
 		if str, ok := v.(string); ok {
-			agentLabels[k] = str
+			labels[k] = str
 		}
 	}
 	for k, v := range a.Attributes.NonIdentifying {
 		if str, ok := v.(string); ok {
-			agentLabels[k] = str
+			labels[k] = str
 		}
 	}
+	return labels
+}
+
+// MatchesLabels checks if the agent's attributes match all the specified selector labels.
+// Returns false if the selector is empty (to prevent accidentally matching all agents).
+func (a *Agent) MatchesLabels(selector map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+
+	agentLabels := a.Labels()
 
 	// Check if all selector labels match
 	for key, value := range selector {
@@ -150,3 +209,9 @@ func (a *Agent) MatchesLabels(selector map[string]string) bool {
 	}
 	return true
 }
+
+// MatchesSelector checks if the agent's labels match the selector.
+// Returns false if the selector is empty, like MatchesLabels.
+func (a *Agent) MatchesSelector(selector Selector) bool {
+	return selector.Matches(a.Labels())
+}
@@ -0,0 +1,53 @@
+package ecdh
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+)
+
+var (
+	ErrInvalidSecret     = errors.New("shared secret is too short")
+	ErrInvalidCiphertext = errors.New("ciphertext is too short")
+)
+
+// newAEAD creates an AES-256-GCM cipher keyed with the first 32 bytes of a shared
+// secret obtained from DeriveSharedSecret.
+func newAEAD(sharedSecret []byte) (cipher.AEAD, error) {
+	if len(sharedSecret) < 32 {
+		return nil, ErrInvalidSecret
+	}
+	block, err := aes.NewCipher(sharedSecret[:32])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Seal encrypts and authenticates plaintext with a shared secret. The random
+// nonce is prepended to the returned ciphertext.
+func Seal(sharedSecret, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(sharedSecret)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Open decrypts and authenticates a ciphertext produced by Seal.
+func Open(sharedSecret, ciphertext []byte) ([]byte, error) {
+	aead, err := newAEAD(sharedSecret)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, nil)
+}
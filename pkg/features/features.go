@@ -0,0 +1,184 @@
+// Package features provides the server's feature flags, so that operators can disable
+// features they aren't ready to use.
+//
+// Flags are consulted when the server's modules are wired up : disabled features are not
+// wired, and their APIs return UNIMPLEMENTED.
+package features
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// Path is the HTTP path the feature flags are served at
+const Path = "/api/features"
+
+// Flag is a feature that can be enabled or disabled in the server config
+type Flag struct {
+	Name        string
+	Description string
+	Default     bool
+}
+
+// Well-known flags
+var (
+	Deployments = Flag{
+		Name:        "deployments",
+		Description: "rolling config deployments",
+		Default:     true,
+	}
+	AssignmentPolicies = Flag{
+		Name:        "assignment_policies",
+		Description: "label based config assignment policies",
+		Default:     true,
+	}
+	AgentGroups = Flag{
+		Name:        "agent_groups",
+		Description: "agent groups defined by label selectors or explicit membership",
+		Default:     true,
+	}
+	AutoRollback = Flag{
+		Name:        "auto_rollback",
+		Description: "rollback of configs agents fail to apply to their last known-good config",
+		Default:     true,
+	}
+	ComponentPolicies = Flag{
+		Name:        "component_policies",
+		Description: "policies restricting collector components by agent labels",
+		Default:     true,
+	}
+	CollectorDistributions = Flag{
+		Name:        "collector_distributions",
+		Description: "collector distribution registry and config compatibility checks",
+		Default:     true,
+	}
+	PackageUpgrades = Flag{
+		Name:        "package_upgrades",
+		Description: "collector upgrades to registered distributions offered to agents as OpAMP packages",
+		Default:     true,
+	}
+	FleetSnapshots = Flag{
+		Name:        "fleet_snapshots",
+		Description: "daily fleet snapshots and fleet state diffs",
+		Default:     true,
+	}
+	Availability = Flag{
+		Name:        "availability",
+		Description: "agent availability tracking from heartbeats",
+		Default:     true,
+	}
+	Gateways = Flag{
+		Name:        "gateways",
+		Description: "relaying the agents connected to regional gateways",
+		Default:     false,
+	}
+)
+
+// All lists the known flags
+var All = []Flag{
+	Deployments,
+	AssignmentPolicies,
+	AgentGroups,
+	AutoRollback,
+	ComponentPolicies,
+	CollectorDistributions,
+	PackageUpgrades,
+	FleetSnapshots,
+	Availability,
+	Gateways,
+}
+
+// Registry holds the state of the feature flags. A nil Registry has all flags at their default.
+type Registry struct {
+	enabled map[string]bool
+}
+
+// NewRegistry returns a Registry with the flags in overrides enabled or disabled, and the
+// others at their default. Returns an error for unknown flags.
+func NewRegistry(overrides map[string]bool) (*Registry, error) {
+	r := &Registry{enabled: map[string]bool{}}
+	for _, f := range All {
+		r.enabled[f.Name] = f.Default
+	}
+	for name, enabled := range overrides {
+		if _, ok := r.enabled[name]; !ok {
+			return nil, fmt.Errorf("unknown feature flag %q", name)
+		}
+		r.enabled[name] = enabled
+	}
+	return r, nil
+}
+
+// Enabled returns true if the flag is enabled
+func (r *Registry) Enabled(f Flag) bool {
+	if r == nil {
+		return f.Default
+	}
+	enabled, ok := r.enabled[f.Name]
+	return ok && enabled
+}
+
+// States returns whether each flag is enabled, by flag name
+func (r *Registry) States() map[string]bool {
+	states := make(map[string]bool, len(All))
+	for _, f := range All {
+		states[f.Name] = r.Enabled(f)
+	}
+	return states
+}
+
+// Parse parses a comma separated list of flag overrides, e.g. deployments=false,availability=true
+func Parse(str string) (map[string]bool, error) {
+	overrides := map[string]bool{}
+	for _, override := range strings.Split(str, ",") {
+		if override = strings.TrimSpace(override); override == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(override, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid feature flag %q, expected name=true or name=false", override)
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid feature flag %q: %w", override, err)
+		}
+		overrides[strings.TrimSpace(name)] = enabled
+	}
+	return overrides, nil
+}
+
+// flagState is a flag as served over HTTP
+type flagState struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Default     bool   `json:"default"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func (r *Registry) ConfigureHTTP(mux *mux.Router) {
+	mux.HandleFunc(Path, r.ServeHTTP).Methods(http.MethodGet)
+}
+
+// ServeHTTP serves the flags and whether they are enabled as JSON, sorted by name
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	flags := make([]flagState, 0, len(All))
+	for _, f := range All {
+		flags = append(flags, flagState{
+			Name:        f.Name,
+			Description: f.Description,
+			Default:     f.Default,
+			Enabled:     r.Enabled(f),
+		})
+	}
+	slices.SortFunc(flags, func(a, b flagState) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(flags)
+}
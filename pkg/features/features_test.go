@@ -0,0 +1,56 @@
+package features_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/otelfleet/otelfleet/pkg/features"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	overrides, err := features.Parse("deployments=false, availability=true,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"deployments": false, "availability": true}, overrides)
+	_, err = features.Parse("deployments")
+	assert.Error(t, err)
+	_, err = features.Parse("deployments=maybe")
+	assert.Error(t, err)
+
+	_, err = features.NewRegistry(map[string]bool{"packages": true})
+	assert.ErrorContains(t, err, `unknown feature flag "packages"`)
+
+	r, err := features.NewRegistry(overrides)
+	require.NoError(t, err)
+	assert.False(t, r.Enabled(features.Deployments))
+	assert.True(t, r.Enabled(features.Availability))
+	assert.True(t, r.Enabled(features.ComponentPolicies), "flags default to enabled")
+	assert.Len(t, r.States(), len(features.All))
+
+	var nilRegistry *features.Registry
+	assert.True(t, nilRegistry.Enabled(features.Deployments))
+
+	router := mux.NewRouter()
+	r.ConfigureHTTP(router)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, features.Path, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var flags []struct {
+		Name    string `json:"name"`
+		Default bool   `json:"default"`
+		Enabled bool   `json:"enabled"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &flags))
+	require.Len(t, flags, len(features.All))
+	assert.Equal(t, "agent_groups", flags[0].Name)
+	for _, f := range flags {
+		if f.Name == "deployments" {
+			assert.True(t, f.Default)
+			assert.False(t, f.Enabled)
+		}
+	}
+}
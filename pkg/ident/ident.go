@@ -11,11 +11,13 @@ import (
 )
 
 const (
-	MetadataIDType = "otelfleet.io/id-type"
+	MetadataIDType   = "otelfleet.io/id-type"
+	MetadataSPIFFEID = "otelfleet.io/spiffe-id"
 )
 
 const (
-	IDTypeMac = "mac"
+	IDTypeMac    = "mac"
+	IDTypeSPIFFE = "spiffe"
 )
 
 type ID struct {
@@ -73,3 +75,35 @@ func IdFromMac(
 		hasher: hasher,
 	}, nil
 }
+
+type spiffeID struct {
+	spiffeID string
+
+	hasher hash.Hash
+}
+
+var _ Identity = (*spiffeID)(nil)
+
+func (s *spiffeID) UniqueIdentifier() ID {
+	s.hasher.Reset()
+	s.hasher.Write([]byte(s.spiffeID))
+	return ID{
+		UUID: hex.EncodeToString(s.hasher.Sum([]byte{})),
+		Metatada: map[string]string{
+			MetadataIDType:   IDTypeSPIFFE,
+			MetadataSPIFFEID: s.spiffeID,
+		},
+	}
+}
+
+// IdFromSPIFFE derives a stable agent identity from a SPIFFE ID,
+// so that the agent and the server agree on the agent ID without a bootstrap token.
+func IdFromSPIFFE(
+	hasher hash.Hash,
+	id string,
+) Identity {
+	return &spiffeID{
+		spiffeID: id,
+		hasher:   hasher,
+	}
+}
@@ -18,3 +18,18 @@ func TestIdentFromMAC(t *testing.T) {
 	require.NotEmpty(t, id2)
 	require.Equal(t, id1, id2)
 }
+
+func TestIdentFromSPIFFE(t *testing.T) {
+	provider := ident.IdFromSPIFFE(sha256.New(), "spiffe://example.org/agent/foo")
+
+	id1 := provider.UniqueIdentifier()
+	require.NotEmpty(t, id1.UUID)
+	require.Equal(t, ident.IDTypeSPIFFE, id1.Metatada[ident.MetadataIDType])
+	require.Equal(t, "spiffe://example.org/agent/foo", id1.Metatada[ident.MetadataSPIFFEID])
+
+	id2 := ident.IdFromSPIFFE(sha256.New(), "spiffe://example.org/agent/foo").UniqueIdentifier()
+	require.Equal(t, id1.UUID, id2.UUID)
+
+	other := ident.IdFromSPIFFE(sha256.New(), "spiffe://example.org/agent/bar").UniqueIdentifier()
+	require.NotEqual(t, id1.UUID, other.UUID)
+}
@@ -13,7 +13,9 @@ var (
 var allowedKeyTypes = map[reflect.Type]struct{}{}
 
 type completeKeyring struct {
-	CACertsKey []*CACertsKey `json:"caCertsKey,omitempty"`
+	CACertsKey    []*CACertsKey    `json:"caCertsKey,omitempty"`
+	SharedKeys    []*SharedKeys    `json:"sharedKeys,omitempty"`
+	ClientCertKey []*ClientCertKey `json:"clientCertKey,omitempty"`
 }
 
 func init() {
@@ -1,7 +1,9 @@
 package keyring
 
 import (
+	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"slices"
 )
 
@@ -18,6 +20,13 @@ type CACertsKey struct {
 	CACerts [][]byte `json:"caCerts"`
 }
 
+type ClientCertKey struct {
+	// DER-encoded certificate chain, leaf first
+	Certificate [][]byte `json:"certificate"`
+	// PKCS #8, ASN.1 DER-encoded private key
+	PrivateKey []byte `json:"privateKey"`
+}
+
 func NewCACertsKey(certs []*x509.Certificate) *CACertsKey {
 	key := &CACertsKey{
 		CACerts: make([][]byte, len(certs)),
@@ -37,3 +46,38 @@ func NewSharedKeys(secret []byte) *SharedKeys {
 		ServerKey: secret[32:],
 	}
 }
+
+func NewClientCertKey(cert tls.Certificate) (*ClientCertKey, error) {
+	privateKey, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	key := &ClientCertKey{
+		Certificate: make([][]byte, len(cert.Certificate)),
+		PrivateKey:  privateKey,
+	}
+	for i, der := range cert.Certificate {
+		key.Certificate[i] = slices.Clone(der)
+	}
+	return key, nil
+}
+
+// TLSCertificate returns the certificate and private key for use in TLS connections
+func (k *ClientCertKey) TLSCertificate() (tls.Certificate, error) {
+	privateKey, err := x509.ParsePKCS8PrivateKey(k.PrivateKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	if len(k.Certificate) == 0 {
+		return tls.Certificate{}, errors.New("no certificate in client cert key")
+	}
+	leaf, err := x509.ParseCertificate(k.Certificate[0])
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{
+		Certificate: slices.Clone(k.Certificate),
+		PrivateKey:  privateKey,
+		Leaf:        leaf,
+	}, nil
+}
@@ -0,0 +1,142 @@
+package keyring
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/otelfleet/otelfleet/pkg/ecdh"
+)
+
+const (
+	keyringFile = "keyring"
+	// keyFile holds the key the keyring is encrypted with, unless stored elsewhere
+	keyFile = "keyring.key"
+	keySize = 32
+)
+
+// ErrNotFound is returned by Store.Get if no keyring was persisted yet
+var ErrNotFound = errors.New("keyring not found")
+
+// Store persists a keyring in a data directory, encrypted with AES-256-GCM. The encryption
+// key is generated on first use; it can be kept apart from the data directory, e.g. on a
+// volume that isn't backed up, so that copies of the data directory don't leak the keyring.
+type Store struct {
+	dir     string
+	keyPath string
+}
+
+// NewStore returns a store persisting the keyring in dir, encrypted with a key kept in dir
+func NewStore(dir string) *Store {
+	return &Store{
+		dir:     dir,
+		keyPath: filepath.Join(dir, keyFile),
+	}
+}
+
+// SetKeyFile sets the path of the file the encryption key is kept in, "" keeps it in the
+// data directory
+func (s *Store) SetKeyFile(path string) {
+	if path != "" {
+		s.keyPath = path
+	}
+}
+
+// Put encrypts and persists the keyring, replacing the previous one
+func (s *Store) Put(kr Keyring) error {
+	data, err := kr.Marshal()
+	if err != nil {
+		return err
+	}
+	key, err := s.key(true)
+	if err != nil {
+		return err
+	}
+	sealed, err := ecdh.Seal(key, data)
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(s.dir, keyringFile), sealed)
+}
+
+// Get returns the persisted keyring, or ErrNotFound if there is none
+func (s *Store) Get() (Keyring, error) {
+	sealed, err := os.ReadFile(filepath.Join(s.dir, keyringFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	key, err := s.key(false)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("keyring encryption key %s is missing", s.keyPath)
+	} else if err != nil {
+		return nil, err
+	}
+	data, err := ecdh.Open(key, sealed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keyring: %w", err)
+	}
+	return Unmarshal(data)
+}
+
+// Delete removes the persisted keyring, keeping the encryption key
+func (s *Store) Delete() error {
+	if err := os.Remove(filepath.Join(s.dir, keyringFile)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// key returns the encryption key, generating it if create is set and there is none
+func (s *Store) key(create bool) ([]byte, error) {
+	key, err := os.ReadFile(s.keyPath)
+	if err == nil {
+		if len(key) != keySize {
+			return nil, fmt.Errorf("invalid keyring encryption key %s", s.keyPath)
+		}
+		return key, nil
+	}
+	if !create || !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	key = make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	if err := writeFile(s.keyPath, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// writeFile atomically replaces the file at path with data, readable by its owner only
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
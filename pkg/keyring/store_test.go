@@ -0,0 +1,58 @@
+package keyring
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	store := NewStore(dir)
+	_, err := store.Get()
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	secret := bytes.Repeat([]byte{1}, 64)
+	require.NoError(t, store.Put(New(NewSharedKeys(secret))))
+	for _, name := range []string{keyringFile, keyFile} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), name)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, keyringFile))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "clientKey", "the keyring is encrypted at rest")
+
+	// a restarted agent loads the keyring with the same key
+	kr, err := NewStore(dir).Get()
+	require.NoError(t, err)
+	assert.True(t, kr.Try(func(key *SharedKeys) {
+		assert.Equal(t, secret, append(key.ClientKey, key.ServerKey...))
+	}))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, keyFile), bytes.Repeat([]byte{2}, keySize), 0o600))
+	_, err = store.Get()
+	assert.ErrorContains(t, err, "failed to decrypt keyring")
+
+	require.NoError(t, store.Delete())
+	_, err = store.Get()
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestStore_KeyFile(t *testing.T) {
+	dir, keyPath := t.TempDir(), filepath.Join(t.TempDir(), "keyring.key")
+	store := NewStore(dir)
+	store.SetKeyFile(keyPath)
+	require.NoError(t, store.Put(New(NewSharedKeys(make([]byte, 64)))))
+	assert.FileExists(t, keyPath)
+	assert.NoFileExists(t, filepath.Join(dir, keyFile))
+
+	_, err := NewStore(dir).Get()
+	assert.ErrorContains(t, err, "is missing", "the key isn't kept with the keyring")
+	_, err = store.Get()
+	assert.NoError(t, err)
+}
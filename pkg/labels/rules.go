@@ -0,0 +1,109 @@
+// Package labels validates the labels attached to agents, so that fleet metadata stays
+// consistent enough to be queried with label selectors.
+package labels
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultMaxKeyLength and DefaultMaxValueLength bound labels when no limits are configured
+	DefaultMaxKeyLength   = 128
+	DefaultMaxValueLength = 256
+)
+
+// DefaultReservedPrefixes are the key prefixes reserved to otelfleet when none are configured
+var DefaultReservedPrefixes = []string{"otelfleet."}
+
+// Rules restricts the keys and values of labels. The zero value only applies the defaults.
+type Rules struct {
+	// AllowedPrefixes are the key prefixes labels set through otelfleet must start with, any
+	// key is allowed when empty
+	AllowedPrefixes []string `yaml:"allowed_prefixes"`
+	// ReservedPrefixes are the key prefixes of the attributes otelfleet sets itself, which
+	// labels can't use, defaults to DefaultReservedPrefixes
+	ReservedPrefixes []string `yaml:"reserved_prefixes"`
+	// MaxKeyLength and MaxValueLength bound the length of keys and values in bytes, defaults
+	// to DefaultMaxKeyLength and DefaultMaxValueLength
+	MaxKeyLength   int `yaml:"max_key_length"`
+	MaxValueLength int `yaml:"max_value_length"`
+}
+
+// Violation is a label breaking a rule
+type Violation struct {
+	Key    string
+	Reason string
+}
+
+// ValidationError lists the labels breaking the rules, sorted by key
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	reasons := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		reasons = append(reasons, fmt.Sprintf("label %q %s", v.Key, v.Reason))
+	}
+	return "invalid labels: " + strings.Join(reasons, "; ")
+}
+
+// Validate checks labels set through otelfleet, e.g. the labels of bootstrap tokens, against
+// all the rules. It returns a *ValidationError if any label breaks them.
+func (r Rules) Validate(labels map[string]string) error {
+	return r.validate(labels, true)
+}
+
+// ValidateReported checks labels reported by agents against the length limits only, since
+// agents also report resource attributes of other namespaces, including otelfleet's own.
+// It returns a *ValidationError if any label breaks them.
+func (r Rules) ValidateReported(labels map[string]string) error {
+	return r.validate(labels, false)
+}
+
+func (r Rules) validate(labels map[string]string, all bool) error {
+	maxKey, maxValue := r.MaxKeyLength, r.MaxValueLength
+	if maxKey <= 0 {
+		maxKey = DefaultMaxKeyLength
+	}
+	if maxValue <= 0 {
+		maxValue = DefaultMaxValueLength
+	}
+	reserved := r.ReservedPrefixes
+	if reserved == nil {
+		reserved = DefaultReservedPrefixes
+	}
+
+	var violations []Violation
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		reason := ""
+		switch {
+		case key == "":
+			reason = "has an empty key"
+		case len(key) > maxKey:
+			reason = fmt.Sprintf("key is longer than %d bytes", maxKey)
+		case len(labels[key]) > maxValue:
+			reason = fmt.Sprintf("value is longer than %d bytes", maxValue)
+		case all && hasPrefix(key, reserved):
+			reason = fmt.Sprintf("uses a reserved prefix, reserved prefixes are %s", strings.Join(reserved, ", "))
+		case all && len(r.AllowedPrefixes) > 0 && !hasPrefix(key, r.AllowedPrefixes):
+			reason = fmt.Sprintf("does not start with an allowed prefix, allowed prefixes are %s", strings.Join(r.AllowedPrefixes, ", "))
+		}
+		if reason != "" {
+			violations = append(violations, Violation{Key: key, Reason: reason})
+		}
+	}
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+func hasPrefix(key string, prefixes []string) bool {
+	return slices.ContainsFunc(prefixes, func(prefix string) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+}
@@ -0,0 +1,51 @@
+package labels_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/otelfleet/otelfleet/pkg/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRules_Validate(t *testing.T) {
+	rules := labels.Rules{
+		AllowedPrefixes: []string{"team.", "env"},
+		MaxKeyLength:    16,
+		MaxValueLength:  8,
+	}
+	assert.NoError(t, rules.Validate(map[string]string{"team.name": "core", "env": "prod"}))
+	assert.NoError(t, rules.Validate(nil))
+
+	err := rules.Validate(map[string]string{
+		"otelfleet.agent.id":              "spoofed",
+		"region":                          "eu",
+		"team.name":                       "platform-observability",
+		"":                                "empty",
+		"team." + strings.Repeat("x", 16): "long",
+	})
+	var validationErr *labels.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []labels.Violation{
+		{Key: "", Reason: "has an empty key"},
+		{Key: "otelfleet.agent.id", Reason: "key is longer than 16 bytes"},
+		{Key: "region", Reason: "does not start with an allowed prefix, allowed prefixes are team., env"},
+		{Key: "team.name", Reason: "value is longer than 8 bytes"},
+		{Key: "team.xxxxxxxxxxxxxxxx", Reason: "key is longer than 16 bytes"},
+	}, validationErr.Violations)
+	assert.Contains(t, err.Error(), `label "region" does not start with an allowed prefix`)
+}
+
+func TestRules_Defaults(t *testing.T) {
+	var rules labels.Rules
+	assert.NoError(t, rules.Validate(map[string]string{"env": "prod"}))
+	assert.ErrorContains(t, rules.Validate(map[string]string{"otelfleet.agent.id": "spoofed"}), "reserved prefix")
+	assert.ErrorContains(t, rules.Validate(map[string]string{"env": strings.Repeat("x", labels.DefaultMaxValueLength+1)}), "value is longer")
+
+	// agents report otelfleet's own attributes and resource attributes of any namespace
+	rules.AllowedPrefixes = []string{"team."}
+	assert.NoError(t, rules.ValidateReported(map[string]string{"otelfleet.agent.id": "id", "host.name": "host"}))
+	assert.Error(t, rules.ValidateReported(map[string]string{"host.name": strings.Repeat("x", labels.DefaultMaxValueLength+1)}))
+}
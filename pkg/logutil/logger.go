@@ -1,12 +1,17 @@
 package logutil
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/lmittmann/tint"
+	"github.com/otelfleet/otelfleet/pkg/requestid"
 )
 
 const (
@@ -35,14 +40,49 @@ func WithMethod(logger *slog.Logger, method string) *slog.Logger {
 }
 
 func init() {
-	w := os.Stderr
+	SetDefault(LevelTrace, false)
+}
 
-	// Create a new logger
+// ParseLevel parses the name of a level, one of trace, debug, info, warn or error. An empty
+// name is LevelInfo.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "trace":
+		return LevelTrace, nil
+	case "":
+		return LevelInfo, nil
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q, expected trace, debug, info, warn or error", name)
+	}
+	return level, nil
+}
+
+// SetDefault sets the default logger, logging from level to stderr as JSON if json is set.
+// The records are also handled by the exporters, e.g. of telemetry.Provider.
+func SetDefault(level slog.Level, json bool, exporters ...slog.Handler) {
+	w := os.Stderr
+	if json {
+		slog.SetDefault(slog.New(requestid.NewLogHandler(tee(
+			slog.NewJSONHandler(w, &slog.HandlerOptions{
+				Level: level,
+				ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+					if attr.Key == slog.LevelKey && attr.Value.Any().(slog.Level) < LevelDebug {
+						attr.Value = slog.StringValue("TRACE")
+					}
+					return attr
+				},
+			}),
+			exporters...,
+		))))
+		return
+	}
 
 	// Set global logger with custom options
-	slog.SetDefault(slog.New(
+	slog.SetDefault(slog.New(requestid.NewLogHandler(tee(
 		tint.NewHandler(w, &tint.Options{
-			Level:      LevelTrace,
+			Level:      level,
 			TimeFormat: time.Kitchen,
 			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
 				if attr.Key == slog.LevelKey {
@@ -74,5 +114,52 @@ func init() {
 				return attr
 			},
 		}),
-	))
+		exporters...,
+	))))
+}
+
+// tee returns a handler handling the records with h and the exporters
+func tee(h slog.Handler, exporters ...slog.Handler) slog.Handler {
+	if len(exporters) == 0 {
+		return h
+	}
+	return teeHandler(append([]slog.Handler{h}, exporters...))
+}
+
+// teeHandler handles the records with each of its handlers that is enabled for them
+type teeHandler []slog.Handler
+
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range t {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(teeHandler, len(t))
+	for i, h := range t {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	handlers := make(teeHandler, len(t))
+	for i, h := range t {
+		handlers[i] = h.WithGroup(name)
+	}
+	return handlers
 }
@@ -0,0 +1,202 @@
+// Package requestid assigns an ID to every management API request and propagates it
+// through request contexts, so that the logs, errors and events caused by a request
+// can be correlated.
+package requestid
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+const (
+	// Header carries the request ID. IDs sent by callers are kept, and the ID of every
+	// request is returned in the response headers.
+	Header = "X-Request-ID"
+	// LogKey is the log attribute holding the request ID
+	LogKey = "request_id"
+
+	// maxLength bounds the length of the request IDs accepted from callers
+	maxLength = 128
+)
+
+type requestIDKey struct{}
+
+// NewContext returns a context carrying the request ID.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext returns the ID of the request, or an empty string outside of requests.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// New generates a new request ID
+func New() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// valid returns true if a request ID sent by a caller can be used as is
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		// printable ASCII without spaces, so that the ID is safe to log and echo back
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// Middleware attaches the request ID sent by the caller to the request context, or a
+// new one if the caller sent none, and returns it in the response headers.
+// It implements the dskit middleware.Interface.
+type Middleware struct{}
+
+func (Middleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(Header)
+		if !valid(id) {
+			id = New()
+		}
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+// FromError returns the request ID attached to an error returned by the management
+// APIs, or an empty string if there is none.
+func FromError(err error) string {
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		return ""
+	}
+	for _, detail := range connectErr.Details() {
+		value, err := detail.Value()
+		if err != nil {
+			continue
+		}
+		if info, ok := value.(*errdetails.RequestInfo); ok {
+			return info.GetRequestId()
+		}
+	}
+	return connectErr.Meta().Get(Header)
+}
+
+type interceptor struct {
+	logger *slog.Logger
+}
+
+// NewInterceptor returns an interceptor attaching the request ID to the errors returned
+// by the handlers, as a RequestInfo error detail, and logging the internal errors.
+// Requests that did not go through the Middleware are assigned a new ID.
+func NewInterceptor(logger *slog.Logger) connect.Interceptor {
+	return &interceptor{logger: logger}
+}
+
+func (i *interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx = ensure(ctx)
+		resp, err := next(ctx, req)
+		if err != nil {
+			return nil, i.annotate(ctx, req.Spec().Procedure, err)
+		}
+		return resp, nil
+	}
+}
+
+func (i *interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx = ensure(ctx)
+		if err := next(ctx, conn); err != nil {
+			return i.annotate(ctx, conn.Spec().Procedure, err)
+		}
+		return nil
+	}
+}
+
+// ensure returns a context carrying a request ID
+func ensure(ctx context.Context) context.Context {
+	if FromContext(ctx) != "" {
+		return ctx
+	}
+	return NewContext(ctx, New())
+}
+
+func (i *interceptor) annotate(ctx context.Context, procedure string, err error) error {
+	id := FromContext(ctx)
+	connectErr := new(connect.Error)
+	if !errors.As(err, &connectErr) {
+		connectErr = connect.NewError(codeOf(err), err)
+	}
+	switch connectErr.Code() {
+	case connect.CodeInternal, connect.CodeUnknown, connect.CodeDataLoss:
+		i.logger.With("procedure", procedure, "err", err).ErrorContext(ctx, "request failed")
+	}
+	detail, detailErr := connect.NewErrorDetail(&errdetails.RequestInfo{RequestId: id})
+	if detailErr != nil {
+		return connectErr
+	}
+	// annotate a copy, handlers may return the same error to several requests
+	annotated := connect.NewError(connectErr.Code(), connectErr.Unwrap())
+	for key, values := range connectErr.Meta() {
+		annotated.Meta()[key] = values
+	}
+	for _, d := range connectErr.Details() {
+		annotated.AddDetail(d)
+	}
+	annotated.AddDetail(detail)
+	return annotated
+}
+
+// codeOf returns the code connect uses for an error that is not a connect.Error
+func codeOf(err error) connect.Code {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return connect.CodeCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return connect.CodeDeadlineExceeded
+	default:
+		return connect.CodeUnknown
+	}
+}
+
+// logHandler adds the request ID of the context to the log records
+type logHandler struct {
+	slog.Handler
+}
+
+// NewLogHandler wraps a log handler to add the request ID to the records logged with
+// the context of a request, e.g. by Logger.InfoContext.
+func NewLogHandler(h slog.Handler) slog.Handler {
+	return &logHandler{Handler: h}
+}
+
+func (h *logHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := FromContext(ctx); id != "" {
+		r = r.Clone()
+		r.AddAttrs(slog.String(LogKey, id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &logHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *logHandler) WithGroup(name string) slog.Handler {
+	return &logHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,118 @@
+package requestid_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingEvents fails every call, recording the request ID seen by the handler
+type failingEvents struct {
+	v1alpha1connect.UnimplementedEventServiceHandler
+
+	err  error
+	seen string
+}
+
+func (f *failingEvents) ListEvents(ctx context.Context, _ *connect.Request[v1alpha1.ListEventsRequest]) (*connect.Response[v1alpha1.ListEventsResponse], error) {
+	f.seen = requestid.FromContext(ctx)
+	return nil, f.err
+}
+
+func newTestClient(t *testing.T, handler *failingEvents, logs *bytes.Buffer) v1alpha1connect.EventServiceClient {
+	t.Helper()
+	logger := slog.New(requestid.NewLogHandler(slog.NewTextHandler(logs, nil)))
+	mux := http.NewServeMux()
+	mux.Handle(v1alpha1connect.NewEventServiceHandler(handler, connect.WithInterceptors(requestid.NewInterceptor(logger))))
+	srv := httptest.NewServer(requestid.Middleware{}.Wrap(mux))
+	t.Cleanup(srv.Close)
+	return v1alpha1connect.NewEventServiceClient(srv.Client(), srv.URL)
+}
+
+func TestInterceptor(t *testing.T) {
+	logs := &bytes.Buffer{}
+	handler := &failingEvents{err: connect.NewError(connect.CodeNotFound, errors.New("missing"))}
+	client := newTestClient(t, handler, logs)
+
+	req := connect.NewRequest(&v1alpha1.ListEventsRequest{})
+	req.Header().Set(requestid.Header, "req-1")
+	_, err := client.ListEvents(t.Context(), req)
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	assert.Equal(t, "req-1", handler.seen)
+	assert.Equal(t, "req-1", requestid.FromError(err))
+
+	// invalid IDs are replaced
+	req = connect.NewRequest(&v1alpha1.ListEventsRequest{})
+	req.Header().Set(requestid.Header, "not valid")
+	_, err = client.ListEvents(t.Context(), req)
+	require.Error(t, err)
+	assert.NotEqual(t, "not valid", handler.seen)
+	assert.Equal(t, handler.seen, requestid.FromError(err))
+	assert.Empty(t, logs.String())
+
+	// internal errors are logged with the request ID
+	handler.err = errors.New("storage failure")
+	req = connect.NewRequest(&v1alpha1.ListEventsRequest{})
+	req.Header().Set(requestid.Header, "req-2")
+	_, err = client.ListEvents(t.Context(), req)
+	assert.Equal(t, connect.CodeUnknown, connect.CodeOf(err))
+	assert.Equal(t, "req-2", requestid.FromError(err))
+	assert.Contains(t, logs.String(), "request_id=req-2")
+
+	handler.err = context.DeadlineExceeded
+	_, err = client.ListEvents(t.Context(), connect.NewRequest(&v1alpha1.ListEventsRequest{}))
+	assert.Equal(t, connect.CodeDeadlineExceeded, connect.CodeOf(err))
+	assert.NotEmpty(t, requestid.FromError(err))
+}
+
+func TestMiddleware(t *testing.T) {
+	var seen string
+	srv := httptest.NewServer(requestid.Middleware{}.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = requestid.FromContext(r.Context())
+	})))
+	t.Cleanup(srv.Close)
+
+	get := func(id string) string {
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		if id != "" {
+			req.Header.Set(requestid.Header, id)
+		}
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, seen, resp.Header.Get(requestid.Header))
+		return seen
+	}
+
+	assert.Equal(t, "abc-123", get("abc-123"))
+	assert.NotEmpty(t, get(""))
+	assert.NotEqual(t, strings.Repeat("a", 200), get(strings.Repeat("a", 200)))
+}
+
+func TestLogHandler(t *testing.T) {
+	logs := &bytes.Buffer{}
+	logger := slog.New(requestid.NewLogHandler(slog.NewTextHandler(logs, nil))).With("component", "test")
+
+	logger.InfoContext(requestid.NewContext(t.Context(), "req-1"), "with request")
+	logger.Info("without request")
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "component=test")
+	assert.Contains(t, lines[0], "request_id=req-1")
+	assert.NotContains(t, lines[1], "request_id")
+}
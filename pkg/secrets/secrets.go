@@ -0,0 +1,197 @@
+// Package secrets resolves secret references embedded in collector configs,
+// e.g. ${vault:secret/data/otel#api_key}, at delivery time so secret values
+// are never persisted by otelfleet.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultCacheTTL is how long resolved secret values are cached.
+const DefaultCacheTTL = 30 * time.Second
+
+// refPattern matches ${<provider>:<path>#<key>}
+var refPattern = regexp.MustCompile(`\$\{([a-zA-Z0-9_-]+):([^#}]+)#([^}]+)\}`)
+
+// Provider resolves secret references for a given backend.
+type Provider interface {
+	// Name is the provider prefix used in references, e.g. "vault"
+	Name() string
+	// Resolve returns the value of key at path
+	Resolve(ctx context.Context, path, key string) (string, error)
+}
+
+// Reference is a parsed secret reference.
+type Reference struct {
+	Provider string
+	Path     string
+	Key      string
+}
+
+func (r Reference) String() string {
+	return fmt.Sprintf("${%s:%s#%s}", r.Provider, r.Path, r.Key)
+}
+
+// AuditRecord records that an agent was delivered a secret reference.
+// It never contains the secret value.
+type AuditRecord struct {
+	AgentID   string
+	Reference Reference
+	Cached    bool
+	Time      time.Time
+}
+
+// Auditor receives an AuditRecord for every secret reference delivered to an agent.
+type Auditor interface {
+	Record(ctx context.Context, record AuditRecord)
+}
+
+type logAuditor struct {
+	logger *slog.Logger
+}
+
+// NewLogAuditor returns an Auditor that writes audit records to the given logger.
+func NewLogAuditor(logger *slog.Logger) Auditor {
+	return &logAuditor{logger: logger}
+}
+
+func (l *logAuditor) Record(ctx context.Context, record AuditRecord) {
+	l.logger.With(
+		"agent_id", record.AgentID,
+		"reference", record.Reference.String(),
+		"cached", record.Cached,
+	).InfoContext(ctx, "secret reference delivered to agent")
+}
+
+type cacheEntry struct {
+	value   string
+	expires time.Time
+}
+
+// Resolver substitutes secret references using the registered providers.
+type Resolver struct {
+	logger    *slog.Logger
+	providers map[string]Provider
+	auditor   Auditor
+	ttl       time.Duration
+
+	mu    sync.Mutex
+	cache map[Reference]cacheEntry
+}
+
+// NewResolver creates a Resolver caching resolved values for ttl.
+func NewResolver(logger *slog.Logger, auditor Auditor, ttl time.Duration, providers ...Provider) *Resolver {
+	r := &Resolver{
+		logger:    logger,
+		providers: map[string]Provider{},
+		auditor:   auditor,
+		ttl:       ttl,
+		cache:     map[Reference]cacheEntry{},
+	}
+	for _, p := range providers {
+		r.providers[p.Name()] = p
+	}
+	return r
+}
+
+// References returns all secret references found in body.
+func References(body []byte) []Reference {
+	var refs []Reference
+	for _, m := range refPattern.FindAllSubmatch(body, -1) {
+		refs = append(refs, Reference{
+			Provider: string(m[1]),
+			Path:     string(m[2]),
+			Key:      string(m[3]),
+		})
+	}
+	return refs
+}
+
+// Redact replaces the path and key of the secret references in body with a placeholder, so
+// that configs can be shown without revealing where their secrets are kept.
+func Redact(body []byte) []byte {
+	return refPattern.ReplaceAll(body, []byte("$${$1:<redacted>}"))
+}
+
+// Substitute replaces all secret references in body with their values.
+// References to unknown providers are an error, so that unresolved references
+// are never delivered to agents.
+func (r *Resolver) Substitute(ctx context.Context, agentID string, body []byte) ([]byte, error) {
+	var resolveErr error
+	out := refPattern.ReplaceAllFunc(body, func(match []byte) []byte {
+		if resolveErr != nil {
+			return match
+		}
+		m := refPattern.FindSubmatch(match)
+		ref := Reference{Provider: string(m[1]), Path: string(m[2]), Key: string(m[3])}
+		val, err := r.resolve(ctx, agentID, ref)
+		if err != nil {
+			resolveErr = err
+			return match
+		}
+		return []byte(val)
+	})
+	if resolveErr != nil {
+		return nil, resolveErr
+	}
+	return out, nil
+}
+
+// SubstituteConfigMap returns a copy of configMap with all secret references substituted.
+func (r *Resolver) SubstituteConfigMap(ctx context.Context, agentID string, configMap *protobufs.AgentConfigMap) (*protobufs.AgentConfigMap, error) {
+	out := proto.Clone(configMap).(*protobufs.AgentConfigMap)
+	for name, file := range out.GetConfigMap() {
+		body, err := r.Substitute(ctx, agentID, file.GetBody())
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve secrets in %s: %w", name, err)
+		}
+		file.Body = body
+	}
+	return out, nil
+}
+
+func (r *Resolver) resolve(ctx context.Context, agentID string, ref Reference) (string, error) {
+	provider, ok := r.providers[ref.Provider]
+	if !ok {
+		return "", fmt.Errorf("unknown secret provider %q in reference %s", ref.Provider, ref)
+	}
+
+	now := time.Now()
+	r.mu.Lock()
+	entry, hit := r.cache[ref]
+	r.mu.Unlock()
+	if hit && now.Before(entry.expires) {
+		r.audit(ctx, agentID, ref, true, now)
+		return entry.value, nil
+	}
+
+	val, err := provider.Resolve(ctx, ref.Path, ref.Key)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	r.mu.Lock()
+	r.cache[ref] = cacheEntry{value: val, expires: now.Add(r.ttl)}
+	r.mu.Unlock()
+	r.audit(ctx, agentID, ref, false, now)
+	return val, nil
+}
+
+func (r *Resolver) audit(ctx context.Context, agentID string, ref Reference, cached bool, now time.Time) {
+	if r.auditor == nil {
+		return
+	}
+	r.auditor.Record(ctx, AuditRecord{
+		AgentID:   agentID,
+		Reference: ref,
+		Cached:    cached,
+		Time:      now,
+	})
+}
@@ -0,0 +1,123 @@
+package secrets_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/secrets"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingAuditor struct {
+	mu      sync.Mutex
+	records []secrets.AuditRecord
+}
+
+func (r *recordingAuditor) Record(_ context.Context, record secrets.AuditRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, record)
+}
+
+func newVault(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/otel":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{
+					"data":     map[string]any{"api_key": "s3cr3t"},
+					"metadata": map[string]any{"version": 1},
+				},
+			})
+		case "/v1/kv/otel":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{"token": "v1-token"},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestReferences(t *testing.T) {
+	refs := secrets.References([]byte("a: ${vault:secret/data/otel#api_key}\nb: ${env:FOO}\nc: ${vault:kv/otel#token}"))
+	require.Equal(t, []secrets.Reference{
+		{Provider: "vault", Path: "secret/data/otel", Key: "api_key"},
+		{Provider: "vault", Path: "kv/otel", Key: "token"},
+	}, refs)
+	require.Equal(t,
+		"a: ${vault:<redacted>}\nb: ${env:FOO}",
+		string(secrets.Redact([]byte("a: ${vault:secret/data/otel#api_key}\nb: ${env:FOO}"))),
+	)
+}
+
+func TestVaultSubstitution(t *testing.T) {
+	calls := &atomic.Int32{}
+	vault := newVault(t, calls)
+	auditor := &recordingAuditor{}
+	resolver := secrets.NewResolver(
+		slog.Default(),
+		auditor,
+		time.Minute,
+		secrets.NewVaultProvider(secrets.VaultConfig{Address: vault.URL, Token: "root"}, nil),
+	)
+
+	configMap := &protobufs.AgentConfigMap{
+		ConfigMap: map[string]*protobufs.AgentConfigFile{
+			"config.yaml": {
+				Body: []byte("key: ${vault:secret/data/otel#api_key}\ntoken: ${vault:kv/otel#token}\nenv: ${env:FOO}"),
+			},
+		},
+	}
+	out, err := resolver.SubstituteConfigMap(context.Background(), "agent-1", configMap)
+	require.NoError(t, err)
+	require.Equal(t, "key: s3cr3t\ntoken: v1-token\nenv: ${env:FOO}", string(out.GetConfigMap()["config.yaml"].GetBody()))
+	// the stored config must not be modified
+	require.Contains(t, string(configMap.GetConfigMap()["config.yaml"].GetBody()), "${vault:secret/data/otel#api_key}")
+
+	_, err = resolver.SubstituteConfigMap(context.Background(), "agent-2", configMap)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, calls.Load(), "second delivery should be served from cache")
+
+	require.Len(t, auditor.records, 4)
+	require.Equal(t, "agent-1", auditor.records[0].AgentID)
+	require.False(t, auditor.records[0].Cached)
+	require.Equal(t, "agent-2", auditor.records[2].AgentID)
+	require.True(t, auditor.records[2].Cached)
+}
+
+func TestSubstitutionErrors(t *testing.T) {
+	calls := &atomic.Int32{}
+	vault := newVault(t, calls)
+	resolver := secrets.NewResolver(
+		slog.Default(),
+		nil,
+		time.Minute,
+		secrets.NewVaultProvider(secrets.VaultConfig{Address: vault.URL, Token: "root"}, nil),
+	)
+
+	_, err := resolver.Substitute(context.Background(), "agent-1", []byte("${aws:foo#bar}"))
+	require.ErrorContains(t, err, "unknown secret provider")
+
+	_, err = resolver.Substitute(context.Background(), "agent-1", []byte("${vault:secret/data/missing#bar}"))
+	require.ErrorContains(t, err, "404")
+
+	_, err = resolver.Substitute(context.Background(), "agent-1", []byte("${vault:secret/data/otel#missing}"))
+	require.ErrorContains(t, err, "not found")
+}
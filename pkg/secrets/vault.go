@@ -0,0 +1,100 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// VaultConfig configures the HashiCorp Vault secret provider.
+type VaultConfig struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200
+	Address string `yaml:"address"`
+	// Token used to authenticate to Vault
+	Token string `yaml:"token"`
+	// Namespace is the optional Vault Enterprise namespace
+	Namespace string `yaml:"namespace"`
+}
+
+// Enabled returns true if a Vault address is configured.
+func (c VaultConfig) Enabled() bool {
+	return c.Address != ""
+}
+
+type vaultProvider struct {
+	cfg        VaultConfig
+	httpClient *http.Client
+}
+
+var _ Provider = (*vaultProvider)(nil)
+
+// NewVaultProvider returns a Provider reading secrets from Vault KV engines.
+// Both KV v1 and KV v2 (paths including "data/") responses are supported.
+func NewVaultProvider(cfg VaultConfig, httpClient *http.Client) Provider {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &vaultProvider{
+		cfg:        cfg,
+		httpClient: httpClient,
+	}
+}
+
+func (v *vaultProvider) Name() string {
+	return "vault"
+}
+
+type vaultResponse struct {
+	Data map[string]any `json:"data"`
+}
+
+func (v *vaultProvider) Resolve(ctx context.Context, path, key string) (string, error) {
+	url := strings.TrimSuffix(v.cfg.Address, "/") + "/v1/" + strings.TrimPrefix(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.cfg.Token)
+	if v.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.cfg.Namespace)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("vault returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	vr := &vaultResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(vr); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	data := vr.Data
+	// KV v2 nests the secret under data.data
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, isKey := data[key]; !isKey {
+			data = nested
+		}
+	}
+	val, ok := data[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found at %s", key, path)
+	}
+	switch val := val.(type) {
+	case string:
+		return val, nil
+	default:
+		b, err := json.Marshal(val)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
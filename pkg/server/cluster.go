@@ -0,0 +1,40 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/otelfleet/otelfleet/pkg/config"
+	"github.com/otelfleet/otelfleet/pkg/services/notify"
+	storagesvc "github.com/otelfleet/otelfleet/pkg/services/storage"
+)
+
+// configureCluster checks that the replicas of cfg share their state and their config change
+// notifications, defaulting their notify transport to one every replica receives
+func configureCluster(cfg *config.Config) error {
+	if !cfg.Cluster.Enabled() {
+		return nil
+	}
+	if cfg.Ephemeral || cfg.Gateway.Enabled() || cfg.Follower.Enabled() {
+		return fmt.Errorf("replicas can't be ephemeral, gateways or read replicas")
+	}
+	backend, err := storagesvc.BackendOf(cfg.StoragePath)
+	if err != nil {
+		return err
+	}
+	if !backend.Shared() {
+		return fmt.Errorf("replicas require an etcd or postgres storage, %s storage isn't shared", backend)
+	}
+	switch cfg.Notify.Transport {
+	case "":
+		if !backend.SharedWatches() {
+			cfg.Notify.Transport = notify.TransportStorage
+		}
+	case notify.TransportInProcess:
+		return fmt.Errorf("replicas can't notify config changes in process")
+	case notify.TransportWatch:
+		if !backend.SharedWatches() {
+			return fmt.Errorf("replicas sharing a %s storage can't watch each other's config changes, use the %s transport", backend, notify.TransportStorage)
+		}
+	}
+	return nil
+}
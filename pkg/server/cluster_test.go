@@ -0,0 +1,72 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/otelfleet/otelfleet/pkg/config"
+	"github.com/otelfleet/otelfleet/pkg/services/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureCluster(t *testing.T) {
+	replica := config.ClusterConfig{ReplicaID: "a"}
+	for _, tc := range []struct {
+		name      string
+		cfg       config.Config
+		transport string
+		err       string
+	}{
+		{
+			name: "single server",
+			cfg:  config.Config{StoragePath: "./otelfleet.kv"},
+		},
+		{
+			name: "etcd replicas watch each other",
+			cfg:  config.Config{StoragePath: "etcd://localhost:2379/otelfleet", Cluster: replica},
+		},
+		{
+			name:      "postgres replicas notify through the storage",
+			cfg:       config.Config{StoragePath: "postgres://localhost/otelfleet", Cluster: replica},
+			transport: notify.TransportStorage,
+		},
+		{
+			name: "postgres replicas can't watch each other",
+			cfg: config.Config{
+				StoragePath: "postgres://localhost/otelfleet",
+				Cluster:     replica,
+				Notify:      notify.Config{Transport: notify.TransportWatch},
+			},
+			err: "can't watch each other's config changes",
+		},
+		{
+			name: "in process notifications",
+			cfg: config.Config{
+				StoragePath: "etcd://localhost:2379/otelfleet",
+				Cluster:     replica,
+				Notify:      notify.Config{Transport: notify.TransportInProcess},
+			},
+			err: "can't notify config changes in process",
+		},
+		{
+			name: "embedded storage",
+			cfg:  config.Config{StoragePath: "./otelfleet.kv", Cluster: replica},
+			err:  "pebble storage isn't shared",
+		},
+		{
+			name: "ephemeral replicas",
+			cfg:  config.Config{StoragePath: "etcd://localhost:2379/otelfleet", Ephemeral: true, Cluster: replica},
+			err:  "replicas can't be ephemeral",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := configureCluster(&tc.cfg)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.transport, tc.cfg.Notify.Transport)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/grafana/dskit/services"
+	adminv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/admin"
+)
+
+var _ admin.ModuleLister = (*OtelFleet)(nil)
+
+// Modules returns the module run by the server and every registered module, sorted by name.
+// Module states are known once the server runs.
+func (o *OtelFleet) Modules() (string, []*adminv1alpha1.Module) {
+	names := map[string]struct{}{}
+	for mod, targets := range o.deps {
+		names[mod] = struct{}{}
+		for _, target := range targets {
+			names[target] = struct{}{}
+		}
+	}
+	included := o.mm.DependenciesForModule(All)
+
+	modules := make([]*adminv1alpha1.Module, 0, len(names))
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		m := &adminv1alpha1.Module{
+			Name:         name,
+			UserVisible:  o.mm.IsUserVisibleModule(name),
+			Included:     name == All || slices.Contains(included, name),
+			Dependencies: slices.Sorted(slices.Values(o.deps[name])),
+		}
+		if svc, ok := o.serviceMap[name]; ok {
+			m.State = moduleState(svc.State())
+			if err := svc.FailureCase(); err != nil {
+				m.Failure = err.Error()
+			}
+		}
+		modules = append(modules, m)
+	}
+	return All, modules
+}
+
+func moduleState(state services.State) adminv1alpha1.ModuleState {
+	switch state {
+	case services.New:
+		return adminv1alpha1.ModuleState_MODULE_STATE_NEW
+	case services.Starting:
+		return adminv1alpha1.ModuleState_MODULE_STATE_STARTING
+	case services.Running:
+		return adminv1alpha1.ModuleState_MODULE_STATE_RUNNING
+	case services.Stopping:
+		return adminv1alpha1.ModuleState_MODULE_STATE_STOPPING
+	case services.Terminated:
+		return adminv1alpha1.ModuleState_MODULE_STATE_TERMINATED
+	case services.Failed:
+		return adminv1alpha1.ModuleState_MODULE_STATE_FAILED
+	default:
+		return adminv1alpha1.ModuleState_MODULE_STATE_UNSPECIFIED
+	}
+}
+
+// logModules logs the registered modules and their dependencies
+func (o *OtelFleet) logModules() {
+	target, modules := o.Modules()
+	var included []string
+	for _, m := range modules {
+		o.logger.With(
+			"module", m.GetName(),
+			"included", m.GetIncluded(),
+			"user_visible", m.GetUserVisible(),
+			"dependencies", m.GetDependencies(),
+		).Info("registered module")
+		if m.GetIncluded() {
+			included = append(included, m.GetName())
+		}
+	}
+	o.logger.With("target", target, "modules", included).Info("modules included in target")
+}
+
+// moduleStateLogger logs the state transitions of a module's service. Failures are logged
+// by the service manager listener.
+func moduleStateLogger(logger *slog.Logger) services.Listener {
+	return services.NewListener(
+		func() { logger.Info("module starting") },
+		func() { logger.Info("module running") },
+		func(from services.State) { logger.With("from", from.String()).Info("module stopping") },
+		func(from services.State) { logger.With("from", from.String()).Info("module terminated") },
+		nil,
+	)
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/grafana/dskit/server"
+	"github.com/otelfleet/otelfleet/pkg/config"
+)
+
+// configureAgentMTLS verifies the client certificates agents present to the listener serving
+// OpAMP against the agent CA. Agents without one still connect unless they are required, which
+// the OpAMP server enforces, so that management API callers on a shared listener are unaffected.
+func configureAgentMTLS(cfg config.AgentMTLSConfig, listener *server.TLSConfig) error {
+	if !cfg.Enabled() {
+		if cfg.Require {
+			return fmt.Errorf("requiring agent client certificates requires an agent CA")
+		}
+		return nil
+	}
+	if listener.TLSCertPath == "" || listener.TLSKeyPath == "" {
+		return fmt.Errorf("agent mtls requires TLS to be enabled on the listener serving opamp")
+	}
+	if listener.ClientCAs != "" {
+		return fmt.Errorf("agent mtls can't be combined with an opamp client CA")
+	}
+	switch listener.ClientAuth {
+	case "":
+		listener.ClientAuth = "VerifyClientCertIfGiven"
+	case "RequestClientCert":
+		// SVIDs are verified against the SPIFFE bundle, not the agent CA
+		return fmt.Errorf("agent mtls can't be combined with spiffe enrollment on the same listener")
+	}
+	listener.ClientCAs = cfg.CACertPath
+	return nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/grafana/dskit/server"
+	"github.com/otelfleet/otelfleet/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureAgentMTLS(t *testing.T) {
+	ca := config.AgentMTLSConfig{CACertPath: "agent-ca.pem", CAKeyPath: "agent-ca-key.pem"}
+	tls := server.TLSConfig{TLSCertPath: "tls.pem", TLSKeyPath: "tls-key.pem"}
+	for _, tc := range []struct {
+		name     string
+		cfg      config.AgentMTLSConfig
+		listener server.TLSConfig
+		expected server.TLSConfig
+		err      string
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:     "verifies client certificates if given",
+			cfg:      ca,
+			listener: tls,
+			expected: server.TLSConfig{TLSCertPath: "tls.pem", TLSKeyPath: "tls-key.pem", ClientAuth: "VerifyClientCertIfGiven", ClientCAs: "agent-ca.pem"},
+		},
+		{
+			name:     "keeps the client auth of the listener",
+			cfg:      ca,
+			listener: server.TLSConfig{TLSCertPath: "tls.pem", TLSKeyPath: "tls-key.pem", ClientAuth: "RequireAndVerifyClientCert"},
+			expected: server.TLSConfig{TLSCertPath: "tls.pem", TLSKeyPath: "tls-key.pem", ClientAuth: "RequireAndVerifyClientCert", ClientCAs: "agent-ca.pem"},
+		},
+		{
+			name: "plaintext listeners",
+			cfg:  ca,
+			err:  "requires TLS",
+		},
+		{
+			name:     "client CAs",
+			cfg:      ca,
+			listener: server.TLSConfig{TLSCertPath: "tls.pem", TLSKeyPath: "tls-key.pem", ClientCAs: "clients.pem"},
+			err:      "can't be combined with an opamp client CA",
+		},
+		{
+			name:     "spiffe enrollment",
+			cfg:      ca,
+			listener: server.TLSConfig{TLSCertPath: "tls.pem", TLSKeyPath: "tls-key.pem", ClientAuth: "RequestClientCert"},
+			err:      "can't be combined with spiffe",
+		},
+		{
+			name: "required without a CA",
+			cfg:  config.AgentMTLSConfig{Require: true},
+			err:  "requires an agent CA",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := configureAgentMTLS(tc.cfg, &tc.listener)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, tc.listener)
+		})
+	}
+}
@@ -1,16 +1,19 @@
 package server
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"io"
 	"log/slog"
 	"maps"
+	"net/url"
 	"os"
 	"slices"
-	"sort"
+	"strings"
 	"time"
 
+	"connectrpc.com/connect"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	dslog "github.com/grafana/dskit/log"
@@ -20,22 +23,49 @@ import (
 	"github.com/grafana/dskit/services"
 	"github.com/grafana/dskit/signals"
 	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/agentca"
 	agentsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	auditv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/audit/v1alpha1"
 	bootstrapv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
 	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	eventsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	jobsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1"
+	rbacv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/rbac/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/apiversion"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/client"
 	"github.com/otelfleet/otelfleet/pkg/config"
+	"github.com/otelfleet/otelfleet/pkg/devtls"
 	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/features"
 	logutil "github.com/otelfleet/otelfleet/pkg/logutil"
+	"github.com/otelfleet/otelfleet/pkg/requestid"
+	"github.com/otelfleet/otelfleet/pkg/secrets"
+	"github.com/otelfleet/otelfleet/pkg/server/util"
+	"github.com/otelfleet/otelfleet/pkg/services/admin"
 	"github.com/otelfleet/otelfleet/pkg/services/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/audit"
 	"github.com/otelfleet/otelfleet/pkg/services/bootstrap"
 	"github.com/otelfleet/otelfleet/pkg/services/deployment"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/services/gateway"
+	"github.com/otelfleet/otelfleet/pkg/services/jobs"
+	"github.com/otelfleet/otelfleet/pkg/services/leader"
+	"github.com/otelfleet/otelfleet/pkg/services/notify"
 	"github.com/otelfleet/otelfleet/pkg/services/opamp"
 	"github.com/otelfleet/otelfleet/pkg/services/otelconfig"
+	"github.com/otelfleet/otelfleet/pkg/services/rbac"
+	"github.com/otelfleet/otelfleet/pkg/services/replica"
 	storagesvc "github.com/otelfleet/otelfleet/pkg/services/storage"
+	"github.com/otelfleet/otelfleet/pkg/services/ui"
+	"github.com/otelfleet/otelfleet/pkg/spiffe"
 	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/rs/cors"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 func initLogger(logFormat string, logLevel dslog.Level) *logger {
@@ -55,6 +85,28 @@ func initLogger(logFormat string, logLevel dslog.Level) *logger {
 	return &logger{w: w, Logger: l}
 }
 
+// logConfig returns the format and level of the server logs configured by cfg
+func logConfig(cfg config.LogConfig) (string, dslog.Level, error) {
+	var logLevel dslog.Level
+	switch cfg.Format {
+	case "", config.LogFormatLogfmt:
+		cfg.Format = dslog.LogfmtFormat
+	case config.LogFormatJSON:
+		cfg.Format = dslog.JSONFormat
+	default:
+		return "", logLevel, fmt.Errorf("unknown log format %q, expected %s or %s", cfg.Format, config.LogFormatLogfmt, config.LogFormatJSON)
+	}
+	l, err := logutil.ParseLevel(cfg.Level)
+	if err != nil {
+		return "", logLevel, err
+	}
+	// the server logs have no trace level
+	if err := logLevel.Set(strings.ToLower(max(l, logutil.LevelDebug).String())); err != nil {
+		return "", logLevel, err
+	}
+	return cfg.Format, logLevel, nil
+}
+
 type logger struct {
 	w io.WriteCloser
 	log.Logger
@@ -67,14 +119,32 @@ const (
 	Bootstrap        = "bootstrap"
 	ServerService    = "server"
 	OpAmp            = "opamp"
+	OpAmpListener    = "opamp-listener"
 	ConfigOTEL       = "config-otel"
 	AgentManager     = "agent-manager"
 	DeploymentModule = "deployment"
+	Events           = "events"
+	Jobs             = "jobs"
+	Notifications    = "notifications"
+	Admin            = "admin"
+	UI               = "ui"
+	Gateway          = "gateway"
+	Follower         = "follower"
+	Audit            = "audit"
+	DevTLS           = "dev-tls"
+	Leader           = "leader"
+	RBAC             = "rbac"
 )
 
 type OtelFleet struct {
-	logger *slog.Logger
-	cfg    config.Config
+	logger   *slog.Logger
+	cfg      config.Config
+	features *features.Registry
+	// interceptors of all the management API handlers
+	interceptors []connect.Interceptor
+	// middleware of the raw HTTP handlers of the management API, which the interceptors
+	// don't intercept
+	httpMiddleware []middleware.Interface
 
 	mm   *modules.Manager
 	deps map[string][]string
@@ -101,7 +171,7 @@ type OtelFleet struct {
 	assignmentConfigStore storage.KeyValue[*configv1alpha1.Config]
 	// store for config assignment metadata
 	// otelfleet agentID -> ConfigAssignment
-	configAssignmentStore storage.KeyValue[*configv1alpha1.ConfigAssignment]
+	configAssignmentStore storage.IndexedKeyValue[*configv1alpha1.ConfigAssignment]
 
 	// store for deployment status
 	deploymentStore storage.KeyValue[*configv1alpha1.DeploymentStatus]
@@ -109,34 +179,183 @@ type OtelFleet struct {
 	agentDeploymentStore storage.KeyValue[*configv1alpha1.AgentDeploymentStatus]
 	// store for persisted connection state (replaces in-memory agentTracker)
 	connectionStateStore storage.KeyValue[*agentsv1alpha1.AgentConnectionState]
+	// store for fleet events, keyed by sequence
+	eventStore storage.KeyValue[*eventsv1alpha1.Event]
+	// store for agent timelines, keyed by agent ID and sequence
+	agentEventStore storage.KeyValue[*eventsv1alpha1.Event]
+	// store for audit entries of management API mutations, keyed by sequence
+	auditStore storage.KeyValue[*auditv1alpha1.AuditEntry]
+	// store for background jobs, keyed by job ID
+	jobStore storage.KeyValue[*jobsv1alpha1.Job]
+	// store for agent support snapshots, keyed by snapshot ID
+	snapshotStore storage.KeyValue[*agentsv1alpha1.AgentSnapshot]
+	// snapshot ID -> fleet snapshot
+	fleetSnapshotStore storage.KeyValue[*agentsv1alpha1.FleetSnapshot]
+	// store for remote config push history
+	// otelfleet agentID -> ConfigPushHistory
+	configPushStore storage.KeyValue[*agentsv1alpha1.ConfigPushHistory]
+	// otelfleet agentID -> last command sent to the agent
+	commandStore storage.KeyValue[*agentsv1alpha1.AgentCommandStatus]
+	problemStore storage.KeyValue[*agentsv1alpha1.AgentProblem]
+	// otelfleet agentID -> AvailabilityHistory
+	availabilityStore storage.KeyValue[*agentsv1alpha1.AvailabilityHistory]
+	// otelfleet agentID -> latest metrics of the agent's collector
+	agentMetricsStore storage.KeyValue[*agentsv1alpha1.AgentMetrics]
+	// key signing the tokens agents send their metrics with
+	agentMetricsKeyStore storage.KeyValue[*wrapperspb.BytesValue]
+	// store for assignment policies, keyed by policy ID
+	policyStore storage.KeyValue[*configv1alpha1.AssignmentPolicy]
+	// store for agent groups, keyed by group ID
+	groupStore storage.KeyValue[*configv1alpha1.AgentGroup]
+	// store for component policies, keyed by policy ID
+	componentPolicyStore storage.KeyValue[*configv1alpha1.ComponentPolicy]
+	// store for collector distributions, keyed by name@version
+	distributionStore storage.KeyValue[*configv1alpha1.CollectorDistribution]
+	// store for package targets, keyed by agent/<agent ID> or group/<group ID>
+	packageTargetStore storage.KeyValue[*configv1alpha1.PackageTarget]
+	// store for the package statuses reported by agents, keyed by agent ID
+	packageStatusStore storage.KeyValue[*configv1alpha1.AgentPackageStatus]
+	// store for the configs agents were bootstrapped with, keyed by agent ID
+	bootstrapAssignmentStore storage.KeyValue[*configv1alpha1.ConfigAssignment]
+	// store for config edit sessions, keyed by session ID
+	editSessionStore storage.KeyValue[*configv1alpha1.ConfigEditSession]
+	// store for the last config each agent applied, keyed by agent ID
+	knownGoodStore storage.KeyValue[*configv1alpha1.KnownGoodConfig]
+	// store for the config history of each agent, keyed by agent ID
+	configHistoryStore storage.KeyValue[*configv1alpha1.AgentConfigHistory]
+	// store for the desired configs cached by gateways, keyed by agent ID
+	gatewayConfigStore storage.KeyValue[*protobufs.AgentRemoteConfig]
+	// store for the API keys of management API callers, keyed by ID
+	apiKeyStore storage.KeyValue[*rbacv1alpha1.APIKey]
+	// store for the roles granted to management API callers, keyed by subject
+	roleBindingStore storage.KeyValue[*rbacv1alpha1.RoleBinding]
 
 	// Agent repository - unified access to agent data
 	agentRepo agentdomain.Repository
 
+	eventLog             *events.Log
+	auditLog             *audit.Log
+	auditInterceptor     *audit.Interceptor
+	jobQueue             *jobs.Queue
+	notifier             notify.Transport
 	opampServer          *opamp.Server
 	configServer         *otelconfig.ConfigServer
 	deploymentController *deployment.Controller
+	// copies the primary's snapshots in follower mode
+	follower *replica.Follower
+	// elects the replica running jobs in cluster mode, nil otherwise
+	elector *leader.Elector
+
+	// optional SVID authentication for agent enrollment
+	spiffeAuth *spiffe.Authenticator
+	// issues the client certificates of agents in agent mTLS mode, nil otherwise
+	agentCA *agentca.Authority
+	// issues the serving certificate in dev TLS mode, nil otherwise
+	devTLS *devtls.Issuer
 
 	serviceMap map[string]services.Service
 	server     *server.Server
 	serverConf server.Config
+
+	// dedicated listener for agent traffic, nil if OpAMP is served by server
+	opampListener     *server.Server
+	opampListenerConf server.Config
 }
 
 func New(cfg config.Config) (*OtelFleet, error) {
 	l := slog.Default()
+	flags, err := features.NewRegistry(cfg.Features)
+	if err != nil {
+		return nil, err
+	}
+	var devIssuer *devtls.Issuer
+	if cfg.DevTLSDir != "" {
+		if cfg.HTTPTLSCertPath != "" || cfg.HTTPTLSKeyPath != "" || cfg.OpAMP.TLSCertPath != "" || cfg.OpAMP.TLSKeyPath != "" {
+			return nil, fmt.Errorf("dev tls can't be combined with tls certificate paths")
+		}
+		var hosts []string
+		if external, err := url.Parse(cfg.ExternalURL); err == nil && external.Hostname() != "" {
+			hosts = append(hosts, external.Hostname())
+		}
+		devIssuer, err = devtls.NewIssuer(l.With("component", "dev-tls"), cfg.DevTLSDir, hosts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set up dev tls: %w", err)
+		}
+		cfg.HTTPTLSCertPath, cfg.HTTPTLSKeyPath = devIssuer.CertPath(), devIssuer.KeyPath()
+		if cfg.OpAMP.Dedicated() {
+			cfg.OpAMP.TLSCertPath, cfg.OpAMP.TLSKeyPath = devIssuer.CertPath(), devIssuer.KeyPath()
+		}
+	}
+	if err := configureCluster(&cfg); err != nil {
+		return nil, err
+	}
+	rpcTimeout := cfg.RPCTimeout
+	if rpcTimeout == 0 {
+		rpcTimeout = util.DefaultRPCTimeout
+	}
 	f := &OtelFleet{
-		logger: l,
-		cfg:    cfg,
+		logger:   l,
+		cfg:      cfg,
+		features: flags,
+		devTLS:   devIssuer,
+		interceptors: []connect.Interceptor{
+			requestid.NewInterceptor(l.With("component", "rpc")),
+			util.NewTimeoutInterceptor(l.With("component", "rpc-timeout"), util.TimeoutConfig{
+				Default:   rpcTimeout,
+				Overrides: cfg.RPCTimeouts,
+			}),
+			apiversion.NewDeprecationInterceptor(),
+		},
 	}
 
+	logFormat, logLevel, err := logConfig(cfg.Log)
+	if err != nil {
+		return nil, err
+	}
 	conf := server.Config{
-		HTTPListenAddress:             "127.0.0.1",
-		HTTPListenPort:                16587,
+		HTTPListenAddress:             cmp.Or(cfg.HTTPListenAddress, config.DefaultHTTPListenAddress),
+		HTTPListenPort:                cmp.Or(cfg.HTTPListenPort, config.DefaultHTTPListenPort),
 		DoNotAddDefaultHTTPMiddleware: true,
-		LogFormat:                     dslog.LogfmtFormat,
-		LogLevel: dslog.Level{
-			Option: level.AllowInfo(),
-		},
+		LogFormat:                     logFormat,
+		LogLevel:                      logLevel,
+	}
+
+	if cfg.HTTPTLSCertPath != "" && cfg.HTTPTLSKeyPath != "" {
+		conf.HTTPTLSConfig = server.TLSConfig{
+			TLSCertPath: cfg.HTTPTLSCertPath,
+			TLSKeyPath:  cfg.HTTPTLSKeyPath,
+		}
+		if cfg.SPIFFE.Enabled() {
+			// SVIDs are verified against the trust bundle by the spiffe middleware,
+			// token based bootstrap must keep working without a client certificate
+			conf.HTTPTLSConfig.ClientAuth = "RequestClientCert"
+		}
+	} else if cfg.SPIFFE.Enabled() {
+		return nil, fmt.Errorf("spiffe enrollment requires TLS to be enabled on the HTTP API")
+	}
+	if maxTTL := cmp.Or(cfg.Tokens.MaxTTL, bootstrap.DefaultMaxTokenTTL); cfg.Tokens.DefaultTTL > maxTTL {
+		return nil, fmt.Errorf("the default token TTL %s exceeds the max token TTL %s", cfg.Tokens.DefaultTTL, maxTTL)
+	}
+	if err := tenant.Validate(cfg.Auth.DefaultTenant); err != nil {
+		return nil, fmt.Errorf("invalid default tenant: %w", err)
+	}
+	if cfg.Auth.OIDC.Enabled() && cfg.Auth.OIDC.Audience == "" {
+		return nil, fmt.Errorf("OIDC authentication requires the audience of the tokens")
+	}
+	if cfg.AgentMetrics.Enabled() && cfg.AgentMetricsURL() == "" {
+		return nil, fmt.Errorf("receiving agent metrics requires the external URL of the server")
+	}
+	if cfg.AgentMTLS.Enabled() {
+		f.agentCA, err = agentca.Load(cfg.AgentMTLS.CACertPath, cfg.AgentMTLS.CAKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		f.agentCA.SetCertValidity(cfg.AgentMTLS.CertValidity)
+	}
+	if !cfg.OpAMP.Dedicated() {
+		if err := configureAgentMTLS(cfg.AgentMTLS, &conf.HTTPTLSConfig); err != nil {
+			return nil, err
+		}
 	}
 
 	conf.Log = initLogger(conf.LogFormat, conf.LogLevel)
@@ -148,6 +367,47 @@ func New(cfg config.Config) (*OtelFleet, error) {
 	f.server = srv
 	f.serverConf = conf
 
+	if cfg.OpAMP.Dedicated() {
+		opampConf := server.Config{
+			HTTPListenAddress: cfg.OpAMP.ListenAddress,
+			HTTPListenPort:    cfg.OpAMP.ListenPort,
+			// the gRPC listener is unused, keep it off the agent facing interface
+			GRPCListenAddress:             "127.0.0.1",
+			DoNotAddDefaultHTTPMiddleware: true,
+			// the API listener exposes the server metrics, don't register them twice
+			Registerer: prometheus.NewRegistry(),
+			LogFormat:  conf.LogFormat,
+			LogLevel:   conf.LogLevel,
+			Log:        conf.Log,
+		}
+		if cfg.OpAMP.TLSCertPath != "" && cfg.OpAMP.TLSKeyPath != "" {
+			opampConf.HTTPTLSConfig = server.TLSConfig{
+				TLSCertPath: cfg.OpAMP.TLSCertPath,
+				TLSKeyPath:  cfg.OpAMP.TLSKeyPath,
+				ClientAuth:  cfg.OpAMP.ClientAuth,
+				ClientCAs:   cfg.OpAMP.ClientCAPath,
+			}
+		} else if cfg.OpAMP.ClientAuth != "" {
+			return nil, fmt.Errorf("opamp client auth requires TLS to be enabled on the opamp listener")
+		}
+		if err := configureAgentMTLS(cfg.AgentMTLS, &opampConf.HTTPTLSConfig); err != nil {
+			return nil, err
+		}
+		opampSrv, err := server.New(opampConf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create opamp listener: %w", err)
+		}
+		f.opampListener = opampSrv
+		f.opampListenerConf = opampConf
+	}
+
+	if cfg.Gateway.Enabled() && cfg.Follower.Enabled() {
+		return nil, fmt.Errorf("a server can't run both as a gateway and as a read replica")
+	}
+	if cfg.Gateway.Enabled() && cfg.Gateway.ID == "" {
+		return nil, fmt.Errorf("gateway id is required to relay agents upstream")
+	}
+
 	if err := f.setupModuleManager(); err != nil {
 		return nil, err
 	}
@@ -159,79 +419,241 @@ func (o *OtelFleet) setupModuleManager() error {
 	mm.RegisterModule(All, nil)
 
 	mm.RegisterModule(Storage, func() (services.Service, error) {
-		storeSvc, err := storagesvc.NewStorageService(
-			o.logger.With("service", Storage),
-			o.cfg.StoragePath,
-		)
-		if err != nil {
-			return nil, err
+		var storeSvc *storagesvc.StorageService
+		if o.cfg.Follower.Enabled() {
+			// the primary's snapshots are copied again on startup
+			storeSvc = storagesvc.NewEphemeralStorageService(o.logger.With("service", Storage), o.cfg.Storage)
+		} else if o.cfg.Ephemeral {
+			o.logger.Warn("using ephemeral storage, all state will be lost on shutdown")
+			storeSvc = storagesvc.NewEphemeralStorageService(o.logger.With("service", Storage), o.cfg.Storage)
+		} else {
+			var err error
+			storeSvc, err = storagesvc.NewStorageService(
+				o.logger.With("service", Storage),
+				o.cfg.StoragePath,
+				o.cfg.Storage,
+			)
+			if err != nil {
+				return nil, err
+			}
 		}
 		o.store = storeSvc
+		// every keyspace is instrumented, labelled by its name
+		storeMetrics := storage.NewMetrics(o.logger.With("service", Storage), o.cfg.Storage)
+		storeMetrics.Register(o.server.Registerer)
 		o.opampAgentStore = storage.NewProtoKV[*protobufs.AgentToServer](
 			o.logger.With("store", "opamp-agent"),
 			o.store.KeyValue("opamp-agents"),
+			storage.WithMetrics(storeMetrics, "opamp-agents"),
 		)
 
 		o.agentStore = storage.NewProtoKV[*agentsv1alpha1.AgentDescription](
 			o.logger.With("store", "agents"),
 			o.store.KeyValue("agents"),
+			storage.WithMetrics(storeMetrics, "agents"),
 		)
 
 		o.tokenStore = storage.NewProtoKV[*bootstrapv1alpha1.BootstrapToken](
 			o.logger.With("store", "tokens"),
 			o.store.KeyValue("tokens"),
+			storage.WithMetrics(storeMetrics, "tokens"),
 		)
 
 		o.configStore = storage.NewProtoKV[*configv1alpha1.Config](
 			o.logger.With("store", "configs"),
 			o.store.KeyValue("configs"),
+			storage.WithMetrics(storeMetrics, "configs"),
+			storage.WithCompression(0),
 		)
 
 		o.defaultConfigStore = storage.NewProtoKV[*configv1alpha1.Config](
 			o.logger.With("store", "default-configs"),
 			o.store.KeyValue("defaultconfigs"),
+			storage.WithMetrics(storeMetrics, "defaultconfigs"),
+			storage.WithCompression(0),
 		)
 
 		o.agentHealthStore = storage.NewProtoKV[*protobufs.ComponentHealth](
 			o.logger.With("store", "agent-health"),
 			o.store.KeyValue("agent-health"),
+			storage.WithMetrics(storeMetrics, "agent-health"),
 		)
 		o.agentEffectiveConfig = storage.NewProtoKV[*protobufs.EffectiveConfig](
 			o.logger.With("store", "agent-effective-config"),
 			o.store.KeyValue("agent-effective-config"),
+			storage.WithMetrics(storeMetrics, "agent-effective-config"),
+			storage.WithCompression(0),
 		)
 		o.agentRemoteConfigStore = storage.NewProtoKV[*protobufs.RemoteConfigStatus](
 			o.logger.With("store", "agent-remote-config-status"),
 			o.store.KeyValue("agent-remote-config-status"),
+			storage.WithMetrics(storeMetrics, "agent-remote-config-status"),
 		)
 
 		o.opampAgentDescription = storage.NewProtoKV[*protobufs.AgentDescription](
 			o.logger.With("store", "opamp-agent-description"),
 			o.store.KeyValue("opamp-agent-description"),
+			storage.WithMetrics(storeMetrics, "opamp-agent-description"),
 		)
 		o.bootstrapConfigStore = storage.NewProtoKV[*configv1alpha1.Config](
 			o.logger.With("store", "bootstrap-configs"),
 			o.store.KeyValue("bootstrapconfigs"),
+			storage.WithMetrics(storeMetrics, "bootstrapconfigs"),
+			storage.WithCompression(0),
 		)
 		o.assignmentConfigStore = storage.NewProtoKV[*configv1alpha1.Config](
 			o.logger.With("store", "assignmentconfigs"),
 			o.store.KeyValue("assignmentconfigs"),
+			storage.WithMetrics(storeMetrics, "assignmentconfigs"),
+			storage.WithCompression(0),
 		)
-		o.configAssignmentStore = storage.NewProtoKV[*configv1alpha1.ConfigAssignment](
+		o.configAssignmentStore = storage.NewIndexedProtoKV[*configv1alpha1.ConfigAssignment](
 			o.logger.With("store", "config-assignments"),
-			o.store.KeyValue("config-assignments"),
+			storage.OpenIndexed(o.store, "config-assignments", otelconfig.AssignmentIndexes...),
+			storage.WithMetrics(storeMetrics, "config-assignments"),
 		)
 		o.deploymentStore = storage.NewProtoKV[*configv1alpha1.DeploymentStatus](
 			o.logger.With("store", "deployments"),
 			o.store.KeyValue("deployments"),
+			storage.WithMetrics(storeMetrics, "deployments"),
 		)
 		o.agentDeploymentStore = storage.NewProtoKV[*configv1alpha1.AgentDeploymentStatus](
 			o.logger.With("store", "agent-deployments"),
 			o.store.KeyValue("agent-deployments"),
+			storage.WithMetrics(storeMetrics, "agent-deployments"),
 		)
 		o.connectionStateStore = storage.NewProtoKV[*agentsv1alpha1.AgentConnectionState](
 			o.logger.With("store", "agent-connection-state"),
 			o.store.KeyValue("agent-connection-state"),
+			storage.WithMetrics(storeMetrics, "agent-connection-state"),
+		)
+		o.eventStore = storage.NewProtoKV[*eventsv1alpha1.Event](
+			o.logger.With("store", "events"),
+			o.store.KeyValue("events"),
+			storage.WithMetrics(storeMetrics, "events"),
+		)
+		o.agentEventStore = storage.NewProtoKV[*eventsv1alpha1.Event](
+			o.logger.With("store", "agent-events"),
+			o.store.KeyValue("agent-events"),
+			storage.WithMetrics(storeMetrics, "agent-events"),
+		)
+		o.auditStore = storage.NewProtoKV[*auditv1alpha1.AuditEntry](
+			o.logger.With("store", "audit"),
+			o.store.KeyValue("audit"),
+			storage.WithMetrics(storeMetrics, "audit"),
+		)
+		o.jobStore = storage.NewProtoKV[*jobsv1alpha1.Job](
+			o.logger.With("store", "jobs"),
+			o.store.KeyValue("jobs"),
+			storage.WithMetrics(storeMetrics, "jobs"),
+		)
+		o.snapshotStore = storage.NewProtoKV[*agentsv1alpha1.AgentSnapshot](
+			o.logger.With("store", "agent-snapshots"),
+			o.store.KeyValue("agent-snapshots"),
+			storage.WithMetrics(storeMetrics, "agent-snapshots"),
+		)
+		o.fleetSnapshotStore = storage.NewProtoKV[*agentsv1alpha1.FleetSnapshot](
+			o.logger.With("store", "fleet-snapshots"),
+			o.store.KeyValue("fleet-snapshots"),
+			storage.WithMetrics(storeMetrics, "fleet-snapshots"),
+		)
+		o.configPushStore = storage.NewProtoKV[*agentsv1alpha1.ConfigPushHistory](
+			o.logger.With("store", "config-pushes"),
+			o.store.KeyValue("config-pushes"),
+			storage.WithMetrics(storeMetrics, "config-pushes"),
+		)
+		o.commandStore = storage.NewProtoKV[*agentsv1alpha1.AgentCommandStatus](
+			o.logger.With("store", "agent-commands"),
+			o.store.KeyValue("agent-commands"),
+			storage.WithMetrics(storeMetrics, "agent-commands"),
+		)
+		o.problemStore = storage.NewProtoKV[*agentsv1alpha1.AgentProblem](
+			o.logger.With("store", "agent-problems"),
+			o.store.KeyValue("agent-problems"),
+			storage.WithMetrics(storeMetrics, "agent-problems"),
+		)
+		o.availabilityStore = storage.NewProtoKV[*agentsv1alpha1.AvailabilityHistory](
+			o.logger.With("store", "agent-availability"),
+			o.store.KeyValue("agent-availability"),
+			storage.WithMetrics(storeMetrics, "agent-availability"),
+		)
+		o.agentMetricsStore = storage.NewProtoKV[*agentsv1alpha1.AgentMetrics](
+			o.logger.With("store", "agent-metrics"),
+			o.store.KeyValue("agent-metrics"),
+			storage.WithMetrics(storeMetrics, "agent-metrics"),
+		)
+		o.agentMetricsKeyStore = storage.NewProtoKV[*wrapperspb.BytesValue](
+			o.logger.With("store", "agent-metrics-key"),
+			o.store.KeyValue("agent-metrics-key"),
+			storage.WithMetrics(storeMetrics, "agent-metrics-key"),
+		)
+		o.policyStore = storage.NewProtoKV[*configv1alpha1.AssignmentPolicy](
+			o.logger.With("store", "assignment-policies"),
+			o.store.KeyValue("assignment-policies"),
+			storage.WithMetrics(storeMetrics, "assignment-policies"),
+		)
+		o.groupStore = storage.NewProtoKV[*configv1alpha1.AgentGroup](
+			o.logger.With("store", "agent-groups"),
+			o.store.KeyValue("agent-groups"),
+			storage.WithMetrics(storeMetrics, "agent-groups"),
+		)
+		o.componentPolicyStore = storage.NewProtoKV[*configv1alpha1.ComponentPolicy](
+			o.logger.With("store", "component-policies"),
+			o.store.KeyValue("component-policies"),
+			storage.WithMetrics(storeMetrics, "component-policies"),
+		)
+		o.distributionStore = storage.NewProtoKV[*configv1alpha1.CollectorDistribution](
+			o.logger.With("store", "collector-distributions"),
+			o.store.KeyValue("collector-distributions"),
+			storage.WithMetrics(storeMetrics, "collector-distributions"),
+		)
+		o.packageTargetStore = storage.NewProtoKV[*configv1alpha1.PackageTarget](
+			o.logger.With("store", "package-targets"),
+			o.store.KeyValue("package-targets"),
+			storage.WithMetrics(storeMetrics, "package-targets"),
+		)
+		o.packageStatusStore = storage.NewProtoKV[*configv1alpha1.AgentPackageStatus](
+			o.logger.With("store", "agent-package-statuses"),
+			o.store.KeyValue("agent-package-statuses"),
+			storage.WithMetrics(storeMetrics, "agent-package-statuses"),
+		)
+		o.bootstrapAssignmentStore = storage.NewProtoKV[*configv1alpha1.ConfigAssignment](
+			o.logger.With("store", "bootstrap-assignments"),
+			o.store.KeyValue("bootstrap-assignments"),
+			storage.WithMetrics(storeMetrics, "bootstrap-assignments"),
+		)
+		o.editSessionStore = storage.NewProtoKV[*configv1alpha1.ConfigEditSession](
+			o.logger.With("store", "config-edit-sessions"),
+			o.store.KeyValue("config-edit-sessions"),
+			storage.WithMetrics(storeMetrics, "config-edit-sessions"),
+			storage.WithCompression(0),
+		)
+		o.knownGoodStore = storage.NewProtoKV[*configv1alpha1.KnownGoodConfig](
+			o.logger.With("store", "known-good-configs"),
+			o.store.KeyValue("known-good-configs"),
+			storage.WithMetrics(storeMetrics, "known-good-configs"),
+		)
+		o.configHistoryStore = storage.NewProtoKV[*configv1alpha1.AgentConfigHistory](
+			o.logger.With("store", "agent-config-history"),
+			o.store.KeyValue("agent-config-history"),
+			storage.WithMetrics(storeMetrics, "agent-config-history"),
+			storage.WithCompression(0),
+		)
+
+		o.gatewayConfigStore = storage.NewProtoKV[*protobufs.AgentRemoteConfig](
+			o.logger.With("store", "gateway-configs"),
+			o.store.KeyValue("gateway-configs"),
+			storage.WithMetrics(storeMetrics, "gateway-configs"),
+		)
+		o.apiKeyStore = storage.NewProtoKV[*rbacv1alpha1.APIKey](
+			o.logger.With("store", "api-keys"),
+			o.store.KeyValue("api-keys"),
+			storage.WithMetrics(storeMetrics, "api-keys"),
+		)
+		o.roleBindingStore = storage.NewProtoKV[*rbacv1alpha1.RoleBinding](
+			o.logger.With("store", "role-bindings"),
+			o.store.KeyValue("role-bindings"),
+			storage.WithMetrics(storeMetrics, "role-bindings"),
 		)
 
 		// Create the agent repository with all the underlying stores
@@ -245,10 +667,140 @@ func (o *OtelFleet) setupModuleManager() error {
 			o.agentRemoteConfigStore,
 			o.configAssignmentStore,
 		)
+		o.agentRepo.SetConcurrency(o.cfg.BatchConcurrency)
+
+		// every management API module depends on storage, the callers of all their handlers are
+		// authorized in RBAC mode, scoped to the tenant they select, and their mutations are
+		// audited
+		// replication snapshots hold the resources of every tenant, only admins may read them
+		var snapshotMiddleware []middleware.Interface
+		if o.cfg.Auth.RBAC {
+			var oidc *auth.OIDCAuthenticator
+			if o.cfg.Auth.OIDC.Enabled() {
+				oidc = auth.NewOIDCAuthenticator(o.cfg.Auth.OIDC, nil)
+			}
+			rbacInterceptor := auth.NewInterceptor(
+				o.logger.With("component", "rbac"),
+				auth.NewRoleBindings(o.roleBindingStore, o.cfg.Auth.Admins),
+				auth.NewAPIKeys(o.apiKeyStore),
+				oidc,
+			)
+			o.interceptors = append(o.interceptors, rbacInterceptor)
+			// the raw handlers only read
+			o.httpMiddleware = append(o.httpMiddleware, rbacInterceptor.Middleware(auth.RoleViewer))
+			snapshotMiddleware = append(snapshotMiddleware, rbacInterceptor.Middleware(auth.RoleAdmin))
+		}
+		// in RBAC mode, callers granted no tenant role are confined to the default tenant
+		tenantInterceptor := tenant.Interceptor{Policy: tenant.Policy{
+			Restrict:      o.cfg.Auth.RBAC,
+			DefaultTenant: o.cfg.Auth.DefaultTenant,
+		}}
+		o.interceptors = append(o.interceptors, tenantInterceptor)
+		o.httpMiddleware = append(o.httpMiddleware, tenantInterceptor.Middleware())
+		snapshotMiddleware = append(snapshotMiddleware, tenantInterceptor.Middleware())
+		auditLog, err := audit.NewLog(o.logger.With("service", Audit), o.auditStore, o.cfg.AuditRetention)
+		if err != nil {
+			return nil, err
+		}
+		o.auditLog = auditLog
+		o.auditInterceptor = audit.NewInterceptor(auditLog)
+		o.interceptors = append(o.interceptors, o.auditInterceptor)
+
+		// snapshots are only exported to authenticated followers
+		if !o.cfg.Follower.Enabled() && (o.cfg.ReplicationToken != "" || o.cfg.Auth.TrustProxyHeaders) {
+			replica.NewExporter(o.logger.With("component", "replication"), o.store).ConfigureHTTP(o.server.HTTP, o.cfg.ReplicationToken, snapshotMiddleware...)
+		}
 
 		return storeSvc, nil
 	}, modules.UserInvisibleModule)
 
+	mm.RegisterModule(Events, func() (services.Service, error) {
+		eventLog, err := events.NewLog(
+			o.logger.With("service", Events),
+			o.eventStore,
+			o.cfg.EventRetention,
+		)
+		if err != nil {
+			return nil, err
+		}
+		eventLog.SetAgentTimeline(o.agentEventStore, o.cfg.AgentTimelineRetention)
+		o.eventLog = eventLog
+		eventServer := events.NewEventServer(o.logger.With("service", Events), eventLog)
+		eventServer.AddInterceptors(o.interceptors...)
+		eventServer.ConfigureHTTP(o.server.HTTP)
+		return eventLog, nil
+	})
+
+	mm.RegisterModule(Jobs, func() (services.Service, error) {
+		queue := jobs.NewQueue(o.logger.With("service", Jobs), o.jobStore, jobs.Config{
+			Workers:     o.cfg.JobWorkers,
+			Retention:   o.cfg.JobRetention,
+			Concurrency: o.cfg.BatchConcurrency,
+		})
+		o.jobQueue = queue
+		if o.elector != nil {
+			queue.SetLeadership(o.elector)
+		}
+		queue.RegisterMetrics(o.server.Registerer)
+		jobServer := jobs.NewJobServer(o.logger.With("service", Jobs), queue)
+		jobServer.AddInterceptors(o.interceptors...)
+		jobServer.ConfigureHTTP(o.server.HTTP)
+		return queue, nil
+	})
+
+	mm.RegisterModule(Leader, func() (services.Service, error) {
+		if !o.cfg.Cluster.Enabled() {
+			return nil, nil
+		}
+		o.elector = leader.NewElector(
+			o.logger.With("service", Leader),
+			o.store.KeyValue("leases"),
+			"jobs",
+			o.cfg.Cluster.ReplicaID,
+			o.cfg.Cluster.LeaseTTL,
+		)
+		o.elector.RegisterMetrics(o.server.Registerer)
+		return o.elector, nil
+	}, modules.UserInvisibleModule)
+
+	mm.RegisterModule(Notifications, func() (services.Service, error) {
+		// the storage transport keeps notifications in their own keyspace, the watch transport
+		// watches the assigned configs
+		notifier, err := notify.New(o.logger.With("service", Notifications), o.cfg.Notify, o.store, "notifications", "assignmentconfigs")
+		if err != nil {
+			return nil, err
+		}
+		o.notifier = notifier
+		return notifier, nil
+	}, modules.UserInvisibleModule)
+
+	mm.RegisterModule(Audit, func() (services.Service, error) {
+		auditServer := audit.NewAuditServer(o.logger.With("service", Audit), o.auditLog, o.cfg.Auth.TrustProxyHeaders)
+		auditServer.AddInterceptors(o.interceptors...)
+		auditServer.ConfigureHTTP(o.server.HTTP)
+		return o.auditLog, nil
+	})
+
+	mm.RegisterModule(RBAC, func() (services.Service, error) {
+		if !o.cfg.Auth.RBAC {
+			return nil, nil
+		}
+		rbacServer := rbac.NewRBACServer(o.logger.With("service", RBAC), o.apiKeyStore, o.roleBindingStore)
+		rbacServer.AddInterceptors(o.interceptors...)
+		rbacServer.ConfigureHTTP(o.server.HTTP)
+		return nil, nil
+	}, modules.UserInvisibleModule)
+
+	mm.RegisterModule(Admin, func() (services.Service, error) {
+		adminServer := admin.NewAdminServer(o.logger.With("service", Admin), o, o.cfg.Auth.TrustProxyHeaders)
+		if o.opampServer != nil {
+			adminServer.SetSessionLister(o.opampServer)
+		}
+		adminServer.AddInterceptors(o.interceptors...)
+		adminServer.ConfigureHTTP(o.server.HTTP)
+		return nil, nil
+	}, modules.UserInvisibleModule)
+
 	mm.RegisterModule(Bootstrap, func() (services.Service, error) {
 		bootstrapSvc := bootstrap.NewBootstrapServer(
 			o.logger.With("service", Bootstrap),
@@ -259,6 +811,32 @@ func (o *OtelFleet) setupModuleManager() error {
 			o.bootstrapConfigStore,
 			o.assignmentConfigStore,
 		)
+		bootstrapSvc.RegisterMetrics(o.server.Registerer)
+		if o.cfg.SPIFFE.Enabled() {
+			auth, err := spiffe.NewAuthenticator(o.logger.With("component", "spiffe"), o.cfg.SPIFFE)
+			if err != nil {
+				return nil, err
+			}
+			o.spiffeAuth = auth
+			bootstrapSvc.SetSPIFFEAuthenticator(auth)
+		}
+		if o.cfg.Cluster.Enabled() {
+			// replicas check the bootstrap attempts made through each other when deleting tokens
+			bootstrapSvc.SetAttemptStore(o.store.KeyValue("bootstrapattempts"))
+		}
+		bootstrapSvc.SetEventRecorder(o.eventLog)
+		bootstrapSvc.SetAssignmentStores(o.configAssignmentStore, o.bootstrapAssignmentStore)
+		bootstrapSvc.SetExternalURL(o.cfg.ExternalURL)
+		bootstrapSvc.SetLabelRules(o.cfg.LabelRules)
+		bootstrapSvc.SetDefaultTokenTTL(o.cfg.Tokens.DefaultTTL)
+		bootstrapSvc.SetMaxTokenTTL(o.cfg.Tokens.MaxTTL)
+		bootstrapSvc.SetTokenGCInterval(o.cfg.Tokens.GCInterval)
+		bootstrapSvc.SetOpAMPURL(o.cfg.AgentOpAMPURL())
+		if o.agentCA != nil {
+			bootstrapSvc.SetAgentCA(o.agentCA)
+			bootstrapSvc.SetAgentCertificateStore(o.store.KeyValue(bootstrap.AgentCertificateKeyspace))
+		}
+		bootstrapSvc.AddInterceptors(o.interceptors...)
 		bootstrapSvc.ConfigureHTTP(o.server.HTTP)
 
 		return bootstrapSvc, nil
@@ -275,8 +853,52 @@ func (o *OtelFleet) setupModuleManager() error {
 			o.agentEffectiveConfig,
 			o.agentRemoteConfigStore,
 		)
+		cfgServer.AddInterceptors(o.interceptors...)
+		cfgServer.AddInterceptors(otelconfig.NewConfigTenantInterceptor(o.configStore), agent.NewTenantInterceptor(o.agentRepo))
+		if o.cfg.Auth.ConfigPolicy.Enabled() {
+			cfgServer.AddInterceptors(otelconfig.NewConfigScopeInterceptor(
+				o.logger.With("component", "config-scope"),
+				o.cfg.Auth.ConfigPolicy,
+				o.configStore,
+			))
+		}
+		cfgServer.SetEventRecorder(o.eventLog)
+		if o.features.Enabled(features.AssignmentPolicies) {
+			cfgServer.SetAssignmentPolicyStore(o.policyStore)
+		}
+		if o.features.Enabled(features.AgentGroups) {
+			cfgServer.SetGroupStore(o.groupStore)
+		}
+		if o.features.Enabled(features.ComponentPolicies) {
+			cfgServer.SetComponentPolicyStore(o.componentPolicyStore)
+		}
+		if o.features.Enabled(features.AutoRollback) {
+			cfgServer.SetKnownGoodStore(o.knownGoodStore)
+		}
+		if o.features.Enabled(features.CollectorDistributions) {
+			cfgServer.SetDistributionStore(o.distributionStore)
+			if o.features.Enabled(features.PackageUpgrades) {
+				cfgServer.SetPackageStores(o.packageTargetStore, o.packageStatusStore)
+			}
+		}
+		cfgServer.SetBootstrapAssignmentStore(o.bootstrapAssignmentStore)
+		cfgServer.SetEditSessionStore(o.editSessionStore)
+		cfgServer.SetConfigHistoryStore(o.configHistoryStore)
+		if len(o.cfg.AssignmentPrecedence) > 0 {
+			if err := cfgServer.SetAssignmentPrecedence(o.cfg.AssignmentPrecedence); err != nil {
+				return nil, fmt.Errorf("invalid assignment precedence: %w", err)
+			}
+		}
+		cfgServer.SetJobQueue(o.jobQueue)
+		cfgServer.SetConcurrency(o.cfg.BatchConcurrency)
+		if publisher, ok := o.notifier.(notify.Publisher); ok {
+			cfgServer.SetNotifier(publisher)
+		}
 		cfgServer.ConfigureHTTP(o.server.HTTP)
 		o.configServer = cfgServer
+		if o.auditInterceptor != nil {
+			o.auditInterceptor.SetConfigSource(cfgServer)
+		}
 
 		return cfgServer, nil
 	})
@@ -288,34 +910,212 @@ func (o *OtelFleet) setupModuleManager() error {
 			o.assignmentConfigStore,
 		)
 		o.opampServer = srv
-		// Wire up the config change notifier so ConfigServer can push configs to agents
+		srv.SetRequireClientCert(o.cfg.AgentMTLS.Require)
+		router, httpServer := o.server.HTTP, o.server.HTTPServer
+		if o.opampListener != nil {
+			router, httpServer = o.opampListener.HTTP, o.opampListener.HTTPServer
+		}
+		var opampMiddleware []middleware.Interface
+		if o.cfg.OpAMP.RateLimit > 0 {
+			opampMiddleware = append(opampMiddleware, util.RateLimit(o.cfg.OpAMP.RateLimit, o.cfg.OpAMP.RateLimitBurst))
+		}
+		if err := srv.ConfigureHTTP(router, httpServer, opampMiddleware...); err != nil {
+			return nil, err
+		}
+		srv.SetEventRecorder(o.eventLog)
+		srv.SetLabelRules(o.cfg.LabelRules)
+		srv.SetConfigPushStore(o.configPushStore)
+		srv.SetCommandStore(o.commandStore)
+		srv.SetProblemStore(o.problemStore)
+		srv.SetHeartbeatTimeout(o.cfg.OpAMP.HeartbeatTimeout)
+		if o.features.Enabled(features.Availability) {
+			srv.SetAvailabilityStore(o.availabilityStore, o.cfg.OpAMP.HeartbeatTimeout)
+		}
+		if o.cfg.AgentMetrics.Enabled() {
+			srv.SetOwnMetricsOffer(o.cfg.AgentMetricsURL(), o.cfg.AgentMetrics.Headers)
+		}
+		if o.cfg.AgentMetrics.Receive {
+			key, err := opamp.LoadOwnMetricsKey(context.Background(), o.agentMetricsKeyStore)
+			if err != nil {
+				return nil, err
+			}
+			srv.ConfigureOwnMetricsHTTP(router, o.agentMetricsStore, key)
+		}
+		srv.SetLoadShedding(opamp.LoadSheddingConfig{
+			Workers:   o.cfg.OpAMP.PersistWorkers,
+			QueueSize: o.cfg.OpAMP.PersistQueueSize,
+		}, o.server.Registerer)
+		if o.cfg.Vault.Enabled() {
+			l := o.logger.With("component", "secrets")
+			srv.SetSecretResolver(secrets.NewResolver(
+				l,
+				secrets.NewLogAuditor(l.With("audit", true)),
+				secrets.DefaultCacheTTL,
+				secrets.NewVaultProvider(o.cfg.Vault, nil),
+			))
+		}
+		// push configs to agents when ConfigServer notifies changes
+		o.notifier.Subscribe(srv.NotifyConfigChange)
+		// the desired state is only exported to authenticated callers
+		if o.cfg.DesiredStateToken != "" || o.cfg.Auth.TrustProxyHeaders {
+			srv.ConfigureDesiredStateHTTP(o.server.HTTP, o.cfg.DesiredStateToken, o.httpMiddleware...)
+		}
+		if o.features.Enabled(features.Gateways) {
+			if len(o.cfg.GatewayTokens) == 0 {
+				o.logger.Warn("gateways are enabled without gateway tokens, no gateway can relay agents")
+			}
+			if o.cfg.AgentMTLS.Require {
+				o.logger.Warn("gateways are enabled while agents are required to present client certificates, no gateway can relay agents")
+			}
+			srv.SetGatewayAgentStore(o.store.KeyValue(opamp.GatewayAgentKeyspace))
+			srv.ConfigureGatewayHTTP(o.server.HTTP, o.cfg.GatewayTokens, o.interceptors...)
+		}
 		if o.configServer != nil {
-			o.configServer.SetNotifier(srv)
+			srv.SetAssignmentEvaluator(o.configServer)
+			// records applied configs in the config history, and rolls agents back when
+			// automatic rollback is enabled
+			srv.SetConfigOutcomeHandler(o.configServer)
+			// offers the collector upgrades targeted at agents
+			srv.SetPackageManager(o.configServer)
+			o.configServer.SetPackageNotifier(srv)
 		}
 		return srv, nil
 	})
 
+	mm.RegisterModule(Gateway, func() (services.Service, error) {
+		upstream, err := client.New(client.Config{
+			Logger:      o.logger.With("component", "upstream"),
+			ServerURL:   o.cfg.Gateway.UpstreamURL,
+			BearerToken: o.cfg.Gateway.UpstreamToken,
+			// agents are answered from the cached configs rather than waiting on retries
+			MaxRetries: -1,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("invalid gateway upstream: %w", err)
+		}
+		gw := gateway.NewGateway(
+			o.logger.With("service", Gateway),
+			o.cfg.Gateway.ID,
+			upstream.Gateway,
+			o.gatewayConfigStore,
+		)
+		gw.SetRelayTimeout(o.cfg.Gateway.RelayTimeout)
+		router, httpServer := o.server.HTTP, o.server.HTTPServer
+		if o.opampListener != nil {
+			router, httpServer = o.opampListener.HTTP, o.opampListener.HTTPServer
+		}
+		var opampMiddleware []middleware.Interface
+		if o.cfg.OpAMP.RateLimit > 0 {
+			opampMiddleware = append(opampMiddleware, util.RateLimit(o.cfg.OpAMP.RateLimit, o.cfg.OpAMP.RateLimitBurst))
+		}
+		if err := gw.ConfigureHTTP(router, httpServer, opampMiddleware...); err != nil {
+			return nil, err
+		}
+		return gw, nil
+	})
+
+	mm.RegisterModule(Follower, func() (services.Service, error) {
+		o.follower = replica.NewFollower(
+			o.logger.With("service", Follower),
+			o.cfg.Follower.PrimaryURL,
+			o.cfg.Follower.PrimaryToken,
+			o.cfg.Follower.SyncInterval,
+			o.store,
+		)
+		return o.follower, nil
+	})
+
 	mm.RegisterModule(AgentManager, func() (services.Service, error) {
 		srv := agent.NewAgentServer(
 			o.logger.With("service", AgentManager),
 			o.agentRepo,
+			o.snapshotStore,
+			o.cfg.SnapshotRetention,
 		)
+		srv.SetConfigPushStore(o.configPushStore)
+		srv.SetCommandStore(o.commandStore)
+		srv.SetProblemStore(o.problemStore)
+		if o.cfg.AgentMetrics.Receive {
+			srv.SetMetricsStore(o.agentMetricsStore)
+		}
+		if o.eventLog != nil {
+			srv.SetEventSubscriber(o.eventLog)
+			srv.SetEventRecorder(o.eventLog)
+			srv.SetAgentTimeline(o.eventLog)
+		}
+		srv.SetDeletionGracePeriod(o.cfg.AgentDeletionGracePeriod)
+		if o.features.Enabled(features.FleetSnapshots) {
+			srv.SetFleetSnapshotStore(o.fleetSnapshotStore)
+		}
+		if o.features.Enabled(features.Availability) {
+			srv.SetAvailabilityStore(o.availabilityStore, o.cfg.OpAMP.HeartbeatTimeout)
+		}
+		// snapshots are requested and uploaded, and commands sent, over OpAMP
+		if o.opampServer != nil {
+			srv.SetSnapshotRequester(o.opampServer)
+			o.opampServer.SetSnapshotReceiver(srv)
+			srv.SetAgentDisconnector(o.opampServer)
+			srv.SetAgentRestarter(o.opampServer)
+		}
+		if o.configServer != nil {
+			srv.SetAssignmentRevoker(o.configServer)
+		}
+		srv.AddInterceptors(o.interceptors...)
+		srv.AddInterceptors(agent.NewTenantInterceptor(o.agentRepo))
+		srv.AddHTTPMiddleware(o.httpMiddleware...)
+		if o.follower != nil {
+			srv.AddInterceptors(replica.NewReadOnlyInterceptor(o.follower))
+			srv.ConfigureHTTP(o.server.HTTP)
+			// the primary maintains the replicated data, the background work isn't run
+			return nil, nil
+		}
 		srv.ConfigureHTTP(o.server.HTTP)
 		return srv, nil
 	})
 
+	mm.RegisterModule(UI, func() (services.Service, error) {
+		uiFeatures := o.features.States()
+		uiFeatures[ui.FeatureAuth] = o.cfg.Auth.TrustProxyHeaders || o.cfg.Auth.RBAC
+		uiFeatures[ui.FeatureSPIFFEEnrollment] = o.cfg.SPIFFE.Enabled()
+		uiFeatures[ui.FeatureVaultSecrets] = o.cfg.Vault.Enabled()
+		uiFeatures[ui.FeatureDedicatedOpAMP] = o.cfg.OpAMP.Dedicated()
+		uiFeatures[ui.FeatureEnrollmentURLs] = o.cfg.ExternalURL != ""
+		uiServer := ui.NewServer(o.logger.With("service", UI), o.agentRepo, uiFeatures, o.cfg.ExternalURL)
+		if o.cfg.UIPath != "" {
+			uiServer.SetAssets(os.DirFS(o.cfg.UIPath))
+		}
+		uiServer.AddHTTPMiddleware(o.httpMiddleware...)
+		uiServer.ConfigureHTTP(o.server.HTTP)
+		o.features.ConfigureHTTP(o.server.HTTP)
+		return nil, nil
+	}, modules.UserInvisibleModule)
+
 	mm.RegisterModule(DeploymentModule, func() (services.Service, error) {
+		if !o.features.Enabled(features.Deployments) {
+			o.logger.With("feature", features.Deployments.Name).Info("feature disabled, not starting the deployment controller")
+			return nil, nil
+		}
 		ctrl := deployment.NewController(
 			o.logger.With("service", DeploymentModule),
 			o.deploymentStore,
 			o.agentDeploymentStore,
 			o.configStore,
 			o.agentRepo,
+			o.jobQueue,
 		)
 		o.deploymentController = ctrl
+		ctrl.SetEventRecorder(o.eventLog)
+		ctrl.SetRetention(deployment.Retention{
+			MaxAge:   o.cfg.DeploymentRetention,
+			MaxCount: o.cfg.DeploymentRetentionCount,
+		})
+		ctrl.SetConcurrency(o.cfg.BatchConcurrency)
 		// Wire up the config assigner so the deployment controller can assign configs
 		if o.configServer != nil {
 			ctrl.SetConfigAssigner(o.configServer)
+			ctrl.SetAssignmentRestorer(o.configServer)
+			ctrl.SetCompatibilityChecker(o.configServer)
+			ctrl.SetCapacityChecker(o.configServer)
 			o.configServer.SetDeploymentController(ctrl)
 		}
 		return ctrl, nil
@@ -332,13 +1132,27 @@ func (o *OtelFleet) setupModuleManager() error {
 			}
 			return svs
 		}
-		defaultHTTPMiddleware := []middleware.Interface{}
+		defaultHTTPMiddleware := []middleware.Interface{requestid.Middleware{}}
+		if o.spiffeAuth != nil {
+			defaultHTTPMiddleware = append(defaultHTTPMiddleware, o.spiffeAuth)
+		}
+		if o.cfg.Auth.TrustProxyHeaders {
+			defaultHTTPMiddleware = append(defaultHTTPMiddleware, auth.NewMiddleware(
+				o.logger.With("component", "auth"),
+				auth.NewHeaderAuthenticator(),
+			))
+		}
 		o.server.HTTPServer.Handler = middleware.Merge(defaultHTTPMiddleware...).Wrap(o.server.HTTP)
-		s := o.newServerService(servicesToWaitFor)
+		s := o.newServerService(ServerService, o.server, o.serverConf, servicesToWaitFor)
+		corsOrigins := o.cfg.CORSOrigins
+		if len(corsOrigins) == 0 {
+			corsOrigins = config.DefaultCORSOrigins
+		}
 		corsHandler := cors.New(cors.Options{
-			AllowedOrigins:   []string{"http://localhost:5173"},
+			AllowedOrigins:   corsOrigins,
 			AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 			AllowedHeaders:   []string{"*"},
+			ExposedHeaders:   []string{requestid.Header, replica.HeaderSyncedAt, replica.HeaderStaleness},
 			AllowCredentials: true,
 		}).Handler(o.server.HTTPServer.Handler)
 		o.server.HTTPServer.Handler = h2c.NewHandler(corsHandler, &http2.Server{})
@@ -347,17 +1161,94 @@ func (o *OtelFleet) setupModuleManager() error {
 		return s, nil
 	}, modules.UserInvisibleModule)
 
+	mm.RegisterModule(OpAmpListener, func() (services.Service, error) {
+		if o.opampListener == nil {
+			return nil, nil
+		}
+		o.opampListener.HTTPServer.Handler = o.opampListener.HTTP
+		opampModule := OpAmp
+		if o.cfg.Gateway.Enabled() {
+			opampModule = Gateway
+		}
+		return o.newServerService(OpAmpListener, o.opampListener, o.opampListenerConf, func() []services.Service {
+			return []services.Service{o.serviceMap[opampModule]}
+		}), nil
+	}, modules.UserInvisibleModule)
+
+	mm.RegisterModule(DevTLS, func() (services.Service, error) {
+		if o.devTLS == nil {
+			return nil, nil
+		}
+		return o.devTLS, nil
+	}, modules.UserInvisibleModule)
+
 	// Add dependencies
 	deps := map[string][]string{
 		All: {
 			ServerService,
 		},
-		ServerService:    {Bootstrap, OpAmp, AgentManager, DeploymentModule},
+		ServerService:    {Bootstrap, OpAmp, OpAmpListener, AgentManager, DeploymentModule, Events, Jobs, UI, Admin, Audit, RBAC},
+		OpAmpListener:    {OpAmp},
 		AgentManager:     {OpAmp},
-		OpAmp:            {ConfigOTEL, Storage},
-		Bootstrap:        {Storage},
-		ConfigOTEL:       {Storage},
-		DeploymentModule: {ConfigOTEL, Storage},
+		Admin:            {OpAmp},
+		OpAmp:            {ConfigOTEL, Storage, Events, Notifications},
+		Bootstrap:        {Storage, Events},
+		ConfigOTEL:       {Storage, Events, Jobs, Notifications},
+		DeploymentModule: {ConfigOTEL, Storage, Events, Jobs},
+		Events:           {Storage},
+		Jobs:             {Storage},
+		Notifications:    {Storage},
+		UI:               {Storage},
+		Audit:            {Storage},
+		RBAC:             {Storage},
+	}
+	if o.cfg.Gateway.Enabled() {
+		// gateways only serve OpAMP, relaying agents to the upstream server
+		deps = map[string][]string{
+			All:           {ServerService},
+			ServerService: {Gateway, OpAmpListener},
+			OpAmpListener: {Gateway},
+			Gateway:       {Storage},
+		}
+	}
+
+	if o.cfg.Follower.Enabled() {
+		// read replicas only serve the agent read APIs, from the primary's snapshots
+		deps = map[string][]string{
+			All:           {ServerService},
+			ServerService: {AgentManager, UI, Admin},
+			AgentManager:  {Follower},
+			Follower:      {Storage},
+			UI:            {Storage},
+		}
+	}
+
+	if len(o.cfg.Modules) > 0 {
+		// serve the enabled modules only, with the modules they depend on
+		served := deps[ServerService]
+		deps[ServerService] = nil
+		for _, mod := range o.cfg.Modules {
+			if !slices.Contains(served, mod) {
+				return fmt.Errorf("unknown module %q, expected one of %s", mod, strings.Join(served, ", "))
+			}
+			deps[ServerService] = append(deps[ServerService], mod)
+		}
+		if slices.Contains(served, OpAmpListener) && slices.ContainsFunc(o.cfg.Modules, func(mod string) bool {
+			return mod == OpAmp || mod == Gateway
+		}) {
+			deps[ServerService] = append(deps[ServerService], OpAmpListener)
+		}
+	}
+
+	if o.cfg.Cluster.Enabled() {
+		// only the leader runs jobs
+		deps[Jobs] = append(deps[Jobs], Leader)
+		deps[Leader] = []string{Storage}
+	}
+
+	if o.devTLS != nil {
+		// renews the serving certificate of the listeners while they serve
+		deps[ServerService] = append(deps[ServerService], DevTLS)
 	}
 
 	for mod, targets := range deps {
@@ -368,30 +1259,19 @@ func (o *OtelFleet) setupModuleManager() error {
 
 	o.mm = mm
 	o.deps = deps
-	allDeps := o.mm.DependenciesForModule(All)
-	for _, m := range o.mm.UserVisibleModuleNames() {
-		ix := sort.SearchStrings(allDeps, m)
-		included := ix < len(allDeps) && allDeps[ix] == m
-
-		if included {
-			fmt.Fprintln(os.Stdout, m, "*")
-		} else {
-			fmt.Fprintln(os.Stdout, m)
-		}
-	}
-
-	fmt.Fprintln(os.Stdout)
-	fmt.Fprintln(os.Stdout, "Modules marked with * are included in target All.")
+	o.logModules()
 	return nil
 }
 
 func (o *OtelFleet) Run(ctx context.Context) error {
-	// FIXME: config driven services
 	svcMap, err := o.mm.InitModuleServices(All)
 	if err != nil {
 		return err
 	}
 	o.serviceMap = svcMap
+	for m, s := range svcMap {
+		s.AddListener(moduleStateLogger(o.logger.With("module", m)))
+	}
 
 	mgr, err := services.NewManager(slices.Collect(maps.Values(svcMap))...)
 	if err != nil {
@@ -460,22 +1340,22 @@ func (o *OtelFleet) Run(ctx context.Context) error {
 // services that need to terminate before server actually stops.
 // N.B.: this function is NOT Cortex specific, please let's keep it that way.
 // Passed server should not react on signals. Early return from Run function is considered to be an error.
-func (o *OtelFleet) newServerService(servicesToWaitFor func() []services.Service) services.Service {
-	l := o.logger.With("service", "server")
+func (o *OtelFleet) newServerService(name string, srv *server.Server, conf server.Config, servicesToWaitFor func() []services.Service) services.Service {
+	l := o.logger.With("service", name)
 	serverDone := make(chan error, 1)
 
 	runFn := func(ctx context.Context) error {
 		go func() {
 			defer close(serverDone)
 			rl := l
-			if o.serverConf.GRPCListenAddress != "" {
-				rl = rl.With("grpc-addr", fmt.Sprintf("%s:%d", o.serverConf.GRPCListenAddress, o.serverConf.GRPCListenPort))
+			if conf.GRPCListenAddress != "" {
+				rl = rl.With("grpc-addr", fmt.Sprintf("%s:%d", conf.GRPCListenAddress, conf.GRPCListenPort))
 			}
-			if o.serverConf.HTTPListenAddress != "" {
-				rl = rl.With("http-addr", fmt.Sprintf("%s:%d", o.serverConf.HTTPListenAddress, o.serverConf.HTTPListenPort))
+			if conf.HTTPListenAddress != "" {
+				rl = rl.With("http-addr", fmt.Sprintf("%s:%d", conf.HTTPListenAddress, conf.HTTPListenPort))
 			}
 			rl.Info("running")
-			serverDone <- o.server.Run()
+			serverDone <- srv.Run()
 		}()
 
 		select {
@@ -496,7 +1376,7 @@ func (o *OtelFleet) newServerService(servicesToWaitFor func() []services.Service
 		}
 
 		// shutdown HTTP and gRPC servers (this also unblocks Run)
-		o.server.Shutdown()
+		srv.Shutdown()
 
 		// if not closed yet, wait until server stops.
 		<-serverDone
@@ -1 +1,23 @@
 package util
+
+import (
+	"net/http"
+
+	"github.com/grafana/dskit/middleware"
+	"golang.org/x/time/rate"
+)
+
+// RateLimit rejects requests with 429 Too Many Requests once more than limit requests
+// per second, with bursts of up to burst requests, are received
+func RateLimit(limit float64, burst int) middleware.Interface {
+	limiter := rate.NewLimiter(rate.Limit(limit), max(burst, 1))
+	return middleware.Func(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !limiter.Allow() {
+				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+}
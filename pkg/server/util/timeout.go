@@ -0,0 +1,95 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+)
+
+// DefaultRPCTimeout bounds unary management API calls unless configured otherwise
+const DefaultRPCTimeout = 30 * time.Second
+
+// TimeoutConfig configures the deadlines of unary RPCs. Streaming RPCs are long lived
+// and are not bounded.
+type TimeoutConfig struct {
+	// Default bounds the RPCs without an override, 0 leaves them unbounded
+	Default time.Duration
+	// Overrides bounds RPCs by procedure, e.g. /config.v1alpha1.ConfigService/BatchAssignConfig,
+	// or by service, e.g. /config.v1alpha1.ConfigService/. 0 leaves them unbounded.
+	Overrides map[string]time.Duration
+}
+
+// timeout returns the timeout of a procedure, the most specific one taking precedence
+func (c TimeoutConfig) timeout(procedure string) time.Duration {
+	if d, ok := c.Overrides[procedure]; ok {
+		return d
+	}
+	if i := strings.LastIndex(procedure, "/"); i > 0 {
+		if d, ok := c.Overrides[procedure[:i+1]]; ok {
+			return d
+		}
+	}
+	return c.Default
+}
+
+// ParseTimeouts parses a comma separated list of per RPC timeouts, e.g.
+// /config.v1alpha1.ConfigService/=1m,/agents.v1alpha1.AgentService/ListAgents=10s
+func ParseTimeouts(str string) (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+	for _, entry := range strings.Split(str, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		procedure, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(procedure, "/") {
+			return nil, fmt.Errorf("invalid RPC timeout %q, expected /package.Service/Method=duration", entry)
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("invalid RPC timeout %q: expected a non-negative duration", entry)
+		}
+		timeouts[procedure] = d
+	}
+	return timeouts, nil
+}
+
+type timeoutInterceptor struct {
+	logger *slog.Logger
+	cfg    TimeoutConfig
+}
+
+// NewTimeoutInterceptor bounds unary RPCs with the configured timeouts, in addition to any
+// deadline set by the caller, and reports the RPCs exceeding their deadline as DeadlineExceeded
+func NewTimeoutInterceptor(logger *slog.Logger, cfg TimeoutConfig) connect.Interceptor {
+	return &timeoutInterceptor{logger: logger, cfg: cfg}
+}
+
+func (t *timeoutInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		if timeout := t.cfg.timeout(procedure); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		resp, err := next(ctx, req)
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && connect.CodeOf(err) != connect.CodeDeadlineExceeded {
+			t.logger.With("procedure", procedure, "err", err).Warn("RPC exceeded its deadline")
+			return nil, connect.NewError(connect.CodeDeadlineExceeded, fmt.Errorf("%s exceeded its deadline", procedure))
+		}
+		return resp, err
+	}
+}
+
+func (t *timeoutInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (t *timeoutInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
@@ -0,0 +1,77 @@
+package util_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/server/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestTimeoutInterceptor(t *testing.T) {
+	interceptor := util.NewTimeoutInterceptor(slog.Default(), util.TimeoutConfig{
+		Default: time.Minute,
+		Overrides: map[string]time.Duration{
+			"/test.v1.TestService/":          time.Hour,
+			"/test.v1.TestService/Unbounded": 0,
+			"/test.v1.TestService/Fast":      time.Millisecond,
+		},
+	})
+	call := func(ctx context.Context, procedure string, fn func(ctx context.Context) error) error {
+		req := connect.NewRequest(&emptypb.Empty{})
+		unary := interceptor.WrapUnary(func(ctx context.Context, _ connect.AnyRequest) (connect.AnyResponse, error) {
+			return nil, fn(ctx)
+		})
+		_, err := unary(ctx, &procedureRequest{Request: req, procedure: procedure})
+		return err
+	}
+
+	deadlineIn := func(procedure string) time.Duration {
+		var left time.Duration
+		require.NoError(t, call(t.Context(), procedure, func(ctx context.Context) error {
+			if deadline, ok := ctx.Deadline(); ok {
+				left = time.Until(deadline)
+			}
+			return nil
+		}))
+		return left
+	}
+	assert.InDelta(t, time.Minute, deadlineIn("/other.v1.OtherService/Get"), float64(time.Second))
+	assert.InDelta(t, time.Hour, deadlineIn("/test.v1.TestService/Get"), float64(time.Second))
+	assert.Zero(t, deadlineIn("/test.v1.TestService/Unbounded"))
+
+	err := call(t.Context(), "/test.v1.TestService/Fast", func(ctx context.Context) error {
+		<-ctx.Done()
+		return connect.NewError(connect.CodeInternal, ctx.Err())
+	})
+	assert.Equal(t, connect.CodeDeadlineExceeded, connect.CodeOf(err))
+}
+
+// procedureRequest is a request for the given procedure
+type procedureRequest struct {
+	*connect.Request[emptypb.Empty]
+	procedure string
+}
+
+func (r *procedureRequest) Spec() connect.Spec {
+	return connect.Spec{Procedure: r.procedure, StreamType: connect.StreamTypeUnary}
+}
+
+func TestParseTimeouts(t *testing.T) {
+	timeouts, err := util.ParseTimeouts(" /config.v1alpha1.ConfigService/=1m, /agents.v1alpha1.AgentService/ListAgents=0s,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{
+		"/config.v1alpha1.ConfigService/":          time.Minute,
+		"/agents.v1alpha1.AgentService/ListAgents": 0,
+	}, timeouts)
+
+	for _, invalid := range []string{"ConfigService=1m", "/config.v1alpha1.ConfigService/", "/config.v1alpha1.ConfigService/=soon", "/a/=-1s"} {
+		_, err := util.ParseTimeouts(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	serverv1beta1 "github.com/otelfleet/otelfleet/pkg/api/server/v1beta1"
+	serverv1beta1connect "github.com/otelfleet/otelfleet/pkg/api/server/v1beta1/v1beta1connect"
+	"github.com/otelfleet/otelfleet/pkg/apiversion"
+)
+
+// capabilitiesServer serves the API versions of the server to every caller, so that clients
+// can pick the versions they use before being granted any role
+type capabilitiesServer struct{}
+
+var _ serverv1beta1connect.ServerServiceHandler = capabilitiesServer{}
+
+func (capabilitiesServer) GetServerCapabilities(context.Context, *connect.Request[serverv1beta1.GetServerCapabilitiesRequest]) (*connect.Response[serverv1beta1.ServerCapabilities], error) {
+	return connect.NewResponse(apiversion.Capabilities()), nil
+}
@@ -0,0 +1,101 @@
+// Package admin serves diagnostics of the server itself to operators
+package admin
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/mux"
+	"github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1/v1alpha1connect"
+	serverv1beta1connect "github.com/otelfleet/otelfleet/pkg/api/server/v1beta1/v1beta1connect"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+)
+
+// ModuleLister lists the modules of the server
+type ModuleLister interface {
+	// Modules returns the module run by the server and every registered module, sorted by name
+	Modules() (target string, modules []*v1alpha1.Module)
+}
+
+// SessionLister lists the live OpAMP sessions of agents
+type SessionLister interface {
+	// Sessions returns the live sessions, sorted by start time
+	Sessions() []*v1alpha1.OpAMPSession
+}
+
+// AdminServer provides the admin API. Its lifecycle is that of the HTTP server.
+type AdminServer struct {
+	logger       *slog.Logger
+	modules      ModuleLister
+	requireAdmin bool
+	// optional, sessions are only listed by servers serving OpAMP
+	sessions     SessionLister
+	interceptors []connect.Interceptor
+}
+
+var _ v1alpha1connect.AdminServiceHandler = (*AdminServer)(nil)
+
+// NewAdminServer creates a new AdminServer. When requireAdmin is set, only callers with the
+// admin role are allowed.
+func NewAdminServer(logger *slog.Logger, modules ModuleLister, requireAdmin bool) *AdminServer {
+	return &AdminServer{
+		logger:       logger,
+		modules:      modules,
+		requireAdmin: requireAdmin,
+	}
+}
+
+// SetSessionLister sets what lists the live OpAMP sessions of agents
+func (a *AdminServer) SetSessionLister(sessions SessionLister) {
+	a.sessions = sessions
+}
+
+// AddInterceptors adds interceptors to the admin service handlers.
+// Must be called before ConfigureHTTP.
+func (a *AdminServer) AddInterceptors(interceptors ...connect.Interceptor) {
+	a.interceptors = append(a.interceptors, interceptors...)
+}
+
+func (a *AdminServer) ConfigureHTTP(mux *mux.Router) {
+	a.logger.Info("configuring routes")
+	v1alpha1connect.RegisterAdminServiceHandler(mux, a, connect.WithInterceptors(a.interceptors...))
+	serverv1beta1connect.RegisterServerServiceHandler(mux, capabilitiesServer{}, connect.WithInterceptors(a.interceptors...))
+}
+
+func (a *AdminServer) authorize(ctx context.Context) error {
+	if a.requireAdmin && !auth.FromContext(ctx).IsAdmin() {
+		return connect.NewError(connect.CodePermissionDenied, errors.New("the admin role is required"))
+	}
+	return nil
+}
+
+func (a *AdminServer) GetModules(ctx context.Context, _ *connect.Request[v1alpha1.GetModulesRequest]) (*connect.Response[v1alpha1.GetModulesResponse], error) {
+	if err := a.authorize(ctx); err != nil {
+		return nil, err
+	}
+	target, modules := a.modules.Modules()
+	return connect.NewResponse(&v1alpha1.GetModulesResponse{
+		Target:  target,
+		Modules: modules,
+	}), nil
+}
+
+func (a *AdminServer) ListSessions(ctx context.Context, req *connect.Request[v1alpha1.ListSessionsRequest]) (*connect.Response[v1alpha1.ListSessionsResponse], error) {
+	if err := a.authorize(ctx); err != nil {
+		return nil, err
+	}
+	if a.sessions == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("this server doesn't serve OpAMP"))
+	}
+	sessions := a.sessions.Sessions()
+	if agentID := req.Msg.GetAgentId(); agentID != "" {
+		sessions = slices.DeleteFunc(sessions, func(session *v1alpha1.OpAMPSession) bool {
+			return session.GetAgentId() != agentID
+		})
+	}
+	return connect.NewResponse(&v1alpha1.ListSessionsResponse{Sessions: sessions}), nil
+}
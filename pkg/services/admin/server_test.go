@@ -0,0 +1,74 @@
+package admin_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/services/admin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticModules []*v1alpha1.Module
+
+func (m staticModules) Modules() (string, []*v1alpha1.Module) {
+	return "all", m
+}
+
+func TestGetModules(t *testing.T) {
+	modules := staticModules{
+		{Name: "all", Included: true, Dependencies: []string{"server"}},
+		{Name: "server", UserVisible: true, Included: true, State: v1alpha1.ModuleState_MODULE_STATE_RUNNING},
+	}
+
+	srv := admin.NewAdminServer(slog.Default(), modules, false)
+	resp, err := srv.GetModules(t.Context(), connect.NewRequest(&v1alpha1.GetModulesRequest{}))
+	require.NoError(t, err)
+	assert.Equal(t, "all", resp.Msg.GetTarget())
+	require.Len(t, resp.Msg.GetModules(), 2)
+	assert.Equal(t, v1alpha1.ModuleState_MODULE_STATE_RUNNING, resp.Msg.GetModules()[1].GetState())
+
+	srv = admin.NewAdminServer(slog.Default(), modules, true)
+	_, err = srv.GetModules(t.Context(), connect.NewRequest(&v1alpha1.GetModulesRequest{}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	ctx := auth.NewContext(t.Context(), &auth.Principal{Subject: "bob", Roles: []string{"ops"}})
+	_, err = srv.GetModules(ctx, connect.NewRequest(&v1alpha1.GetModulesRequest{}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	ctx = auth.NewContext(t.Context(), &auth.Principal{Subject: "alice", Roles: []string{auth.RoleAdmin}})
+	_, err = srv.GetModules(ctx, connect.NewRequest(&v1alpha1.GetModulesRequest{}))
+	assert.NoError(t, err)
+}
+
+type staticSessions []*v1alpha1.OpAMPSession
+
+func (s staticSessions) Sessions() []*v1alpha1.OpAMPSession {
+	return s
+}
+
+func TestListSessions(t *testing.T) {
+	srv := admin.NewAdminServer(slog.Default(), staticModules{}, false)
+	_, err := srv.ListSessions(t.Context(), connect.NewRequest(&v1alpha1.ListSessionsRequest{}))
+	assert.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+
+	srv.SetSessionLister(staticSessions{
+		{Id: "1", AgentId: "agent-1", Transport: "websocket", RemoteAddr: "10.0.0.1:4320"},
+		{Id: "2", AgentId: "agent-2", Transport: "http", RemoteAddr: "10.0.0.2:4320"},
+		{Id: "3", AgentId: "agent-1", Transport: "gateway", GatewayId: "eu-west"},
+	})
+	resp, err := srv.ListSessions(t.Context(), connect.NewRequest(&v1alpha1.ListSessionsRequest{}))
+	require.NoError(t, err)
+	assert.Len(t, resp.Msg.GetSessions(), 3)
+
+	resp, err = srv.ListSessions(t.Context(), connect.NewRequest(&v1alpha1.ListSessionsRequest{AgentId: "agent-1"}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.GetSessions(), 2)
+	assert.Equal(t, "eu-west", resp.Msg.GetSessions()[1].GetGatewayId())
+
+	srv = admin.NewAdminServer(slog.Default(), staticModules{}, true)
+	srv.SetSessionLister(staticSessions{})
+	_, err = srv.ListSessions(t.Context(), connect.NewRequest(&v1alpha1.ListSessionsRequest{}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
@@ -5,14 +5,26 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/gorilla/mux"
+	"github.com/grafana/dskit/middleware"
 	"github.com/grafana/dskit/services"
 	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
 	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1/v1alpha1connect"
+	agentsv1beta1connect "github.com/otelfleet/otelfleet/pkg/api/agents/v1beta1/v1beta1connect"
+	"github.com/otelfleet/otelfleet/pkg/apiversion"
 	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/ecdh"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/storage"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // AgentServer provides the agent management API.
@@ -21,47 +33,159 @@ type AgentServer struct {
 	logger     *slog.Logger
 	repository agentdomain.Repository
 
+	// snapshot ID -> snapshot
+	snapshotStore     storage.KeyValue[*v1alpha1.AgentSnapshot]
+	snapshotRetention time.Duration
+	snapshotRequester SnapshotRequester
+
+	snapshotMu sync.Mutex
+	// snapshot ID -> key pair used to decrypt the agent's upload
+	pendingSnapshots map[string]ecdh.EphemeralKeyPair
+
+	// agent ID -> remote config push history
+	configPushStore storage.KeyValue[*v1alpha1.ConfigPushHistory]
+
+	// optional, snapshot ID -> fleet snapshot
+	fleetSnapshotStore storage.KeyValue[*v1alpha1.FleetSnapshot]
+	fleetSnapshotMu    sync.Mutex
+	lastFleetSnapshot  time.Time
+
+	// optional, agent ID -> availability history
+	availabilityStore storage.KeyValue[*v1alpha1.AvailabilityHistory]
+	heartbeatTimeout  time.Duration
+
+	// optional, wakes up WaitForAgentCondition calls
+	eventSubscriber EventSubscriber
+
+	// deleted agents are purged once the grace period expires
+	deletionGracePeriod time.Duration
+
+	// optional, decommission deleted agents
+	assignmentRevoker AssignmentRevoker
+	disconnector      AgentDisconnector
+
+	// optional, restarts agents and tracks the last command sent to each agent
+	restarter    AgentRestarter
+	commandStore storage.KeyValue[*v1alpha1.AgentCommandStatus]
+
+	// optional, agent ID and problem type -> open problem reported by the agent
+	problemStore storage.KeyValue[*v1alpha1.AgentProblem]
+
+	// optional, agent ID -> latest metrics reported by the agent's collector
+	metricsStore storage.KeyValue[*v1alpha1.AgentMetrics]
+
+	// optional, serves agent timelines and records agent deletions
+	timeline      AgentTimeline
+	eventRecorder events.Recorder
+
+	interceptors []connect.Interceptor
+	// middleware of the raw HTTP handlers, e.g. the export
+	httpMiddleware []middleware.Interface
+
 	services.Service
 }
 
 var _ v1alpha1connect.AgentServiceHandler = (*AgentServer)(nil)
 
 // NewAgentServer creates a new AgentServer with the specified repository.
+// Agent snapshots are kept in snapshotStore for snapshotRetention.
 func NewAgentServer(
 	logger *slog.Logger,
 	repository agentdomain.Repository,
+	snapshotStore storage.KeyValue[*v1alpha1.AgentSnapshot],
+	snapshotRetention time.Duration,
 ) *AgentServer {
+	if snapshotRetention <= 0 {
+		snapshotRetention = DefaultSnapshotRetention
+	}
 	a := &AgentServer{
-		logger:     logger,
-		repository: repository,
+		logger:            logger,
+		repository:        repository,
+		snapshotStore:     snapshotStore,
+		snapshotRetention: snapshotRetention,
+		pendingSnapshots:  map[string]ecdh.EphemeralKeyPair{},
+
+		deletionGracePeriod: DefaultDeletionGracePeriod,
 	}
 	a.Service = services.NewBasicService(nil, a.running, nil)
 	return a
 }
 
 func (a *AgentServer) running(ctx context.Context) error {
-	<-ctx.Done()
-	return nil
+	t := time.NewTicker(time.Minute)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			if err := a.pruneSnapshots(ctx, time.Now()); err != nil {
+				a.logger.With("err", err).Error("failed to prune snapshots")
+			}
+			if err := a.maintainFleetSnapshots(ctx, time.Now()); err != nil {
+				a.logger.With("err", err).Error("failed to capture fleet snapshot")
+			}
+			if err := a.PurgeDeletedAgents(ctx, time.Now()); err != nil {
+				a.logger.With("err", err).Error("failed to purge deleted agents")
+			}
+		}
+	}
+}
+
+// AddInterceptors adds interceptors to the agent service handlers.
+// Must be called before ConfigureHTTP.
+func (a *AgentServer) AddInterceptors(interceptors ...connect.Interceptor) {
+	a.interceptors = append(a.interceptors, interceptors...)
+}
+
+// AddHTTPMiddleware adds middleware to the raw HTTP handlers, which the interceptors don't
+// intercept. Must be called before ConfigureHTTP.
+func (a *AgentServer) AddHTTPMiddleware(mw ...middleware.Interface) {
+	a.httpMiddleware = append(a.httpMiddleware, mw...)
 }
 
 func (a *AgentServer) ConfigureHTTP(mux *mux.Router) {
 	a.logger.Info("configuring routes")
-	v1alpha1connect.RegisterAgentServiceHandler(mux, a)
+	v1alpha1connect.RegisterAgentServiceHandler(mux, a, connect.WithInterceptors(a.interceptors...))
+	// the shim runs the interceptors of the v1alpha1 handlers
+	agentsv1beta1connect.RegisterAgentServiceHandler(mux, &agentServiceV1beta1{agents: a, shim: apiversion.NewShim(a.interceptors...)})
+	mux.Handle(ExportPath, middleware.Merge(a.httpMiddleware...).Wrap(http.HandlerFunc(a.ExportAgents))).Methods(http.MethodGet)
 }
 
 func (a *AgentServer) ListAgents(
 	ctx context.Context, req *connect.Request[v1alpha1.ListAgentsRequest],
 ) (*connect.Response[v1alpha1.ListAgentsResponse], error) {
-	agents, err := a.repository.List(ctx)
-	if err != nil {
+	view := agentdomain.StatusViewBasic
+	if req.Msg.GetWithStatus() {
+		view = statusView(req.Msg.GetView())
+	}
+	query := listQuery(req.Msg)
+	query.View = view
+	page, err := a.repository.ListPage(ctx, query)
+	if errors.Is(err, agentdomain.ErrInvalidCursor) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page token: %w", err))
+	} else if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list agents: %w", err))
 	}
 
-	a.logger.With("numAgents", len(agents)).Debug("found agents")
+	a.logger.With("numAgents", len(page.Agents), "numErrors", len(page.Errors)).Debug("found agents")
+
+	// agents that could not be loaded are reported rather than hidden
+	loadErrors := make([]*v1alpha1.AgentLoadError, 0, len(page.Errors))
+	for agentID, err := range page.Errors {
+		a.logger.With("agent_id", agentID, "err", err).Warn("failed to get agent during list")
+		loadErrors = append(loadErrors, &v1alpha1.AgentLoadError{
+			AgentId: agentID,
+			Message: err.Error(),
+		})
+	}
+	slices.SortFunc(loadErrors, func(x, y *v1alpha1.AgentLoadError) int {
+		return strings.Compare(x.GetAgentId(), y.GetAgentId())
+	})
 
 	// Convert domain agents to API response
-	descAndStatus := make([]*v1alpha1.AgentDescriptionAndStatus, 0, len(agents))
-	for _, domainAgent := range agents {
+	descAndStatus := make([]*v1alpha1.AgentDescriptionAndStatus, 0, len(page.Agents))
+	for _, domainAgent := range page.Agents {
 		if req.Msg.GetWithStatus() {
 			// Full view with status
 			descAndStatus = append(descAndStatus, &v1alpha1.AgentDescriptionAndStatus{
@@ -77,10 +201,51 @@ func (a *AgentServer) ListAgents(
 	}
 
 	return connect.NewResponse(&v1alpha1.ListAgentsResponse{
-		Agents: descAndStatus,
+		Agents:        descAndStatus,
+		Errors:        loadErrors,
+		TotalCount:    int32(page.Total),
+		NextPageToken: page.Next,
+		MatchedCount:  int32(page.Matched),
 	}), nil
 }
 
+// listQuery returns the filters, order and page of the agents requested by req
+func listQuery(req *v1alpha1.ListAgentsRequest) agentdomain.ListQuery {
+	q := agentdomain.ListQuery{
+		IncludeDeleted: req.GetIncludeDeleted(),
+		Labels:         req.GetLabelSelector(),
+		ConfigIDs:      req.GetConfigIds(),
+		Descending:     req.GetDescending(),
+		After:          req.GetPageToken(),
+		Limit:          int(max(req.GetPageSize(), 0)),
+	}
+	switch req.GetSortBy() {
+	case v1alpha1.AgentSortField_AGENT_SORT_FIELD_NAME:
+		q.SortBy = agentdomain.SortByName
+	case v1alpha1.AgentSortField_AGENT_SORT_FIELD_LAST_SEEN:
+		q.SortBy = agentdomain.SortByLastSeen
+	case v1alpha1.AgentSortField_AGENT_SORT_FIELD_STATE:
+		q.SortBy = agentdomain.SortByState
+	}
+	for _, state := range req.GetStates() {
+		q.States = append(q.States, agentdomain.ConvertState(state))
+	}
+	for _, health := range req.GetHealthStates() {
+		switch health {
+		case v1alpha1.AgentHealthState_AGENT_HEALTH_STATE_HEALTHY:
+			q.Health = append(q.Health, agentdomain.HealthHealthy)
+		case v1alpha1.AgentHealthState_AGENT_HEALTH_STATE_UNHEALTHY:
+			q.Health = append(q.Health, agentdomain.HealthUnhealthy)
+		default:
+			q.Health = append(q.Health, agentdomain.HealthUnknown)
+		}
+	}
+	for _, status := range req.GetConfigSyncStatuses() {
+		q.ConfigSyncStatuses = append(q.ConfigSyncStatuses, agentdomain.ConvertConfigSyncStatus(status))
+	}
+	return q
+}
+
 func (a *AgentServer) GetAgent(ctx context.Context, req *connect.Request[v1alpha1.GetAgentRequest]) (*connect.Response[v1alpha1.GetAgentResponse], error) {
 	agentID := req.Msg.GetAgentId()
 
@@ -100,7 +265,8 @@ func (a *AgentServer) GetAgent(ctx context.Context, req *connect.Request[v1alpha
 func (a *AgentServer) Status(ctx context.Context, req *connect.Request[v1alpha1.GetAgentStatusRequest]) (*connect.Response[v1alpha1.GetAgentStatusResponse], error) {
 	agentID := req.Msg.GetAgentId()
 
-	domainAgent, err := a.repository.Get(ctx, agentID)
+	view := statusView(req.Msg.GetView())
+	domainAgent, err := a.repository.GetView(ctx, agentID, view)
 	if err != nil {
 		if errors.Is(err, agentdomain.ErrAgentNotFound) {
 			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
@@ -108,40 +274,114 @@ func (a *AgentServer) Status(ctx context.Context, req *connect.Request[v1alpha1.
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get agent: %w", err))
 	}
 
+	status := agentdomain.ToAPIStatus(domainAgent)
+	if status.LastCommand, err = a.lastCommand(ctx, agentID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get last command: %w", err))
+	}
+	// the metrics of the collector are reported along with its health
+	if view != agentdomain.StatusViewBasic {
+		if status.Metrics, err = a.agentMetrics(ctx, agentID); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get agent metrics: %w", err))
+		}
+	}
 	return connect.NewResponse(&v1alpha1.GetAgentStatusResponse{
-		Status: agentdomain.ToAPIStatus(domainAgent),
+		Status: status,
 	}), nil
 }
 
+// AssignmentRevoker removes the config assignment of purged agents
+type AssignmentRevoker interface {
+	RevokeAssignment(ctx context.Context, agentID string) error
+}
+
+// AgentDisconnector closes the live connection of deleted and revoked agents. DisconnectAgent returns
+// ErrAgentNotConnected if the agent has no connection.
+type AgentDisconnector interface {
+	DisconnectAgent(ctx context.Context, agentID string) error
+}
+
+// SetAssignmentRevoker sets what revokes the config assignment of purged agents
+func (a *AgentServer) SetAssignmentRevoker(revoker AssignmentRevoker) {
+	a.assignmentRevoker = revoker
+}
+
+// SetAgentDisconnector sets what disconnects deleted and revoked agents
+func (a *AgentServer) SetAgentDisconnector(disconnector AgentDisconnector) {
+	a.disconnector = disconnector
+}
+
+// DeleteAgent hides the agent and disconnects it if it is connected. Its config assignment
+// and data are kept until it is purged, once the deletion grace period expires. The agent
+// is restored if it reconnects in the meantime. Purge requests delete it right away.
 func (a *AgentServer) DeleteAgent(ctx context.Context, req *connect.Request[v1alpha1.DeleteAgentRequest]) (*connect.Response[emptypb.Empty], error) {
 	agentID := req.Msg.GetAgentId()
 	if agentID == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("agent_id must not be empty"))
 	}
+	logger := a.logger.With("agent_id", agentID)
 
-	a.logger.With("agent_id", agentID).Info("deleting agent")
+	domainAgent, err := a.repository.GetView(ctx, agentID, agentdomain.StatusViewBasic)
+	if errors.Is(err, agentdomain.ErrAgentNotFound) || (err == nil && domainAgent.DeletedAt != nil && !req.Msg.GetPurge()) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to check agent existence: %w", err))
+	}
 
-	if err := a.repository.Delete(ctx, agentID); err != nil {
+	if req.Msg.GetPurge() {
+		if err := a.purgeAgent(ctx, agentID); err != nil {
+			if errors.Is(err, agentdomain.ErrAgentNotFound) {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+			}
+			logger.With("err", err).ErrorContext(ctx, "failed to purge agent")
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+
+	logger.InfoContext(ctx, "deleting agent")
+	if err := a.repository.SoftDelete(ctx, agentID, time.Now()); err != nil {
 		if errors.Is(err, agentdomain.ErrAgentNotFound) {
 			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
 		}
-		a.logger.With("agent_id", agentID, "err", err).Error("failed to delete agent")
+		logger.With("err", err).ErrorContext(ctx, "failed to delete agent")
 		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete agent: %w", err))
 	}
+	a.disconnectAgent(ctx, agentID)
+	events.RecordAgentEvent(ctx, a.eventRecorder, a.repository, events.TypeAgentDeleted, agentID, fmt.Sprintf("agent deleted, it is purged in %s unless it reconnects", a.deletionGracePeriod))
 
-	a.logger.With("agent_id", agentID).Info("agent deleted successfully")
+	logger.With("grace_period", a.deletionGracePeriod).InfoContext(ctx, "agent deleted, it is purged once the grace period expires")
 	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
+// statusView converts an API status view to the repository's, defaulting to the full view
+func statusView(view v1alpha1.AgentStatusView) agentdomain.StatusView {
+	switch view {
+	case v1alpha1.AgentStatusView_AGENT_STATUS_VIEW_BASIC:
+		return agentdomain.StatusViewBasic
+	case v1alpha1.AgentStatusView_AGENT_STATUS_VIEW_HEALTH:
+		return agentdomain.StatusViewHealth
+	default:
+		return agentdomain.StatusViewFull
+	}
+}
+
 // toAPIAgentDescription converts a domain Agent to the v1alpha1.AgentDescription proto type.
 // This maintains backward compatibility with the existing API.
 func toAPIAgentDescription(agent *agentdomain.Agent) *v1alpha1.AgentDescription {
 	reg := agentdomain.ToAPIAgentRegistration(agent)
-	return &v1alpha1.AgentDescription{
+	desc := &v1alpha1.AgentDescription{
 		Id:                       reg.GetId(),
 		FriendlyName:             reg.GetFriendlyName(),
 		IdentifyingAttributes:    reg.GetIdentifyingAttributes(),
 		NonIdentifyingAttributes: reg.GetNonIdentifyingAttributes(),
 		Capabilities:             reg.GetCapabilities(),
+		Tenant:                   agent.Tenant,
+	}
+	if agent.DeletedAt != nil {
+		desc.DeletedAt = timestamppb.New(*agent.DeletedAt)
+	}
+	if agent.RevokedAt != nil {
+		desc.RevokedAt = timestamppb.New(*agent.RevokedAt)
 	}
+	return desc
 }
@@ -2,11 +2,15 @@ package agent_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
 	"github.com/otelfleet/otelfleet/pkg/util/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -149,6 +153,60 @@ func TestAgentServer_Status_PartialData(t *testing.T) {
 	assert.Nil(t, resp.Msg.Status.RemoteConfigStatus)
 }
 
+func TestAgentServer_Status_Views(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+	agentID := "view-agent"
+
+	require.NoError(t, env.AgentRepo.Register(ctx, agentID, "View Agent"))
+	require.NoError(t, env.ConnectionStateStore.Put(ctx, agentID, &v1alpha1.AgentConnectionState{
+		AgentId: agentID,
+		State:   v1alpha1.AgentState_AGENT_STATE_CONNECTED,
+	}))
+	require.NoError(t, env.HealthStore.Put(ctx, agentID, &protobufs.ComponentHealth{Healthy: true}))
+	require.NoError(t, env.RemoteStatusStore.Put(ctx, agentID, &protobufs.RemoteConfigStatus{
+		Status: protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+	}))
+	require.NoError(t, env.EffectiveConfigStore.Put(ctx, agentID, &protobufs.EffectiveConfig{
+		ConfigMap: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{"config.yaml": {Body: []byte("receivers: {}")}},
+		},
+	}))
+
+	status := func(view v1alpha1.AgentStatusView) *v1alpha1.AgentStatus {
+		t.Helper()
+		resp, err := env.AgentServer.Status(ctx, connect.NewRequest(&v1alpha1.GetAgentStatusRequest{AgentId: agentID, View: view}))
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.AgentState_AGENT_STATE_CONNECTED, resp.Msg.GetStatus().GetState())
+		return resp.Msg.GetStatus()
+	}
+
+	basic := status(v1alpha1.AgentStatusView_AGENT_STATUS_VIEW_BASIC)
+	assert.Nil(t, basic.GetHealth())
+	assert.Nil(t, basic.GetRemoteConfigStatus())
+	assert.Nil(t, basic.GetEffectiveConfig())
+
+	health := status(v1alpha1.AgentStatusView_AGENT_STATUS_VIEW_HEALTH)
+	assert.True(t, health.GetHealth().GetHealthy())
+	assert.NotNil(t, health.GetRemoteConfigStatus())
+	assert.Nil(t, health.GetEffectiveConfig())
+
+	for _, view := range []v1alpha1.AgentStatusView{v1alpha1.AgentStatusView_AGENT_STATUS_VIEW_UNSPECIFIED, v1alpha1.AgentStatusView_AGENT_STATUS_VIEW_FULL} {
+		full := status(view)
+		assert.NotNil(t, full.GetHealth())
+		assert.NotNil(t, full.GetEffectiveConfig())
+	}
+
+	list, err := env.AgentServer.ListAgents(ctx, connect.NewRequest(&v1alpha1.ListAgentsRequest{
+		WithStatus: true,
+		View:       v1alpha1.AgentStatusView_AGENT_STATUS_VIEW_HEALTH,
+	}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.GetAgents(), 1)
+	assert.NotNil(t, list.Msg.GetAgents()[0].GetStatus().GetHealth())
+	assert.Nil(t, list.Msg.GetAgents()[0].GetStatus().GetEffectiveConfig())
+}
+
 func TestAgentServer_GetAgent_Found(t *testing.T) {
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
@@ -186,3 +244,172 @@ func TestAgentServer_GetAgent_NotFound(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, connect.CodeNotFound, connectErr.Code())
 }
+
+func TestAgentServer_DeleteAgent(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: "logs"},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {}")},
+	}))
+	require.NoError(t, err)
+	env.NewAgent("agent-1")
+	_, err = env.ConfigServer.AssignConfig(ctx, connect.NewRequest(&configv1alpha1.AssignConfigRequest{
+		AgentId:  "agent-1",
+		ConfigId: "logs",
+	}))
+	require.NoError(t, err)
+
+	// the agent isn't connected, it is purged regardless
+	_, err = env.AgentServer.DeleteAgent(ctx, connect.NewRequest(&v1alpha1.DeleteAgentRequest{AgentId: "agent-1", Purge: true}))
+	require.NoError(t, err)
+
+	exists, err := env.AgentRepo.Exists(ctx, "agent-1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	_, err = env.ConfigAssignmentStore.Get(ctx, "agent-1")
+	assert.True(t, grpcutil.IsErrorNotFound(err))
+	_, err = env.AssignedConfigStore.Get(ctx, "agent-1")
+	assert.True(t, grpcutil.IsErrorNotFound(err))
+
+	_, err = env.AgentServer.DeleteAgent(ctx, connect.NewRequest(&v1alpha1.DeleteAgentRequest{AgentId: "agent-1", Purge: true}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestAgentServer_ListAgents_ConfigSyncFilter(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	capabilities := map[string]protobufs.AgentCapabilities{
+		"capable": protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus |
+			protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig,
+		"incapable": protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus,
+	}
+	for agentID, caps := range capabilities {
+		require.NoError(t, env.AgentRepo.Register(ctx, agentID, agentID))
+		require.NoError(t, env.ConnectionStateStore.Put(ctx, agentID, &v1alpha1.AgentConnectionState{
+			AgentId:      agentID,
+			State:        v1alpha1.AgentState_AGENT_STATE_CONNECTED,
+			Capabilities: uint64(caps),
+		}))
+		require.NoError(t, env.ConfigAssignmentStore.Put(ctx, agentID, &configv1alpha1.ConfigAssignment{
+			AgentId:    agentID,
+			ConfigId:   "config",
+			ConfigHash: []byte("hash"),
+		}))
+	}
+
+	list := func(statuses ...v1alpha1.ConfigSyncStatus) map[string]v1alpha1.ConfigSyncStatus {
+		t.Helper()
+		resp, err := env.AgentServer.ListAgents(ctx, connect.NewRequest(&v1alpha1.ListAgentsRequest{
+			WithStatus:         true,
+			ConfigSyncStatuses: statuses,
+		}))
+		require.NoError(t, err)
+		ret := map[string]v1alpha1.ConfigSyncStatus{}
+		for _, agent := range resp.Msg.GetAgents() {
+			ret[agent.GetAgent().GetId()] = agent.GetStatus().GetConfigSyncStatus()
+		}
+		return ret
+	}
+
+	assert.Equal(t, map[string]v1alpha1.ConfigSyncStatus{
+		"capable":   v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_OUT_OF_SYNC,
+		"incapable": v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_UNSUPPORTED,
+	}, list())
+	assert.Equal(t, map[string]v1alpha1.ConfigSyncStatus{
+		"incapable": v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_UNSUPPORTED,
+	}, list(v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_UNSUPPORTED))
+	assert.Empty(t, list(v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_IN_SYNC))
+
+	// the total counts registered agents regardless of the filter
+	resp, err := env.AgentServer.ListAgents(ctx, connect.NewRequest(&v1alpha1.ListAgentsRequest{
+		ConfigSyncStatuses: []v1alpha1.ConfigSyncStatus{v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_IN_SYNC},
+	}))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, resp.Msg.GetTotalCount())
+	assert.Empty(t, resp.Msg.GetErrors())
+}
+
+func TestAgentServer_ListAgents_Pages(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	for i, agentID := range []string{"agent-a", "agent-b", "agent-c", "agent-d", "agent-e"} {
+		// names sort in the reverse order of IDs
+		require.NoError(t, env.AgentRepo.Register(ctx, agentID, fmt.Sprintf("name-%d", 5-i)))
+		state := v1alpha1.AgentState_AGENT_STATE_CONNECTED
+		if i%2 == 1 {
+			state = v1alpha1.AgentState_AGENT_STATE_DISCONNECTED
+		}
+		require.NoError(t, env.ConnectionStateStore.Put(ctx, agentID, &v1alpha1.AgentConnectionState{
+			AgentId:  agentID,
+			State:    state,
+			LastSeen: timestamppb.New(now.Add(time.Duration(i%3) * time.Minute)),
+		}))
+	}
+	require.NoError(t, env.ConfigAssignmentStore.Put(ctx, "agent-b", &configv1alpha1.ConfigAssignment{AgentId: "agent-b", ConfigId: "logs"}))
+	require.NoError(t, env.HealthStore.Put(ctx, "agent-c", &protobufs.ComponentHealth{Healthy: true}))
+	require.NoError(t, env.HealthStore.Put(ctx, "agent-d", &protobufs.ComponentHealth{Healthy: false}))
+	require.NoError(t, env.AgentRepo.UpdateAttributes(ctx, "agent-e", &protobufs.AgentDescription{
+		NonIdentifyingAttributes: []*protobufs.KeyValue{
+			{Key: "env", Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: "prod"}}},
+		},
+	}))
+
+	// list returns the IDs of the matching agents, following the pages
+	list := func(req *v1alpha1.ListAgentsRequest) ([]string, int) {
+		t.Helper()
+		var ids []string
+		pages := 0
+		for {
+			resp, err := env.AgentServer.ListAgents(ctx, connect.NewRequest(req))
+			require.NoError(t, err)
+			pages++
+			for _, agent := range resp.Msg.GetAgents() {
+				ids = append(ids, agent.GetAgent().GetId())
+			}
+			assert.EqualValues(t, 5, resp.Msg.GetTotalCount())
+			if resp.Msg.GetNextPageToken() == "" {
+				assert.EqualValues(t, len(ids), resp.Msg.GetMatchedCount())
+				return ids, pages
+			}
+			req.PageToken = resp.Msg.GetNextPageToken()
+		}
+	}
+
+	ids, pages := list(&v1alpha1.ListAgentsRequest{PageSize: 2})
+	assert.Equal(t, []string{"agent-a", "agent-b", "agent-c", "agent-d", "agent-e"}, ids)
+	assert.Equal(t, 3, pages)
+	ids, pages = list(&v1alpha1.ListAgentsRequest{})
+	assert.Len(t, ids, 5)
+	assert.Equal(t, 1, pages, "all agents are returned without a page size")
+
+	ids, _ = list(&v1alpha1.ListAgentsRequest{PageSize: 2, SortBy: v1alpha1.AgentSortField_AGENT_SORT_FIELD_NAME})
+	assert.Equal(t, []string{"agent-e", "agent-d", "agent-c", "agent-b", "agent-a"}, ids)
+	ids, _ = list(&v1alpha1.ListAgentsRequest{PageSize: 2, SortBy: v1alpha1.AgentSortField_AGENT_SORT_FIELD_NAME, Descending: true})
+	assert.Equal(t, []string{"agent-a", "agent-b", "agent-c", "agent-d", "agent-e"}, ids)
+	ids, _ = list(&v1alpha1.ListAgentsRequest{PageSize: 1, SortBy: v1alpha1.AgentSortField_AGENT_SORT_FIELD_LAST_SEEN})
+	assert.Equal(t, []string{"agent-a", "agent-d", "agent-b", "agent-e", "agent-c"}, ids)
+	ids, _ = list(&v1alpha1.ListAgentsRequest{PageSize: 3, SortBy: v1alpha1.AgentSortField_AGENT_SORT_FIELD_STATE, Descending: true})
+	assert.Equal(t, []string{"agent-d", "agent-b", "agent-e", "agent-c", "agent-a"}, ids)
+
+	ids, _ = list(&v1alpha1.ListAgentsRequest{PageSize: 1, States: []v1alpha1.AgentState{v1alpha1.AgentState_AGENT_STATE_DISCONNECTED}})
+	assert.Equal(t, []string{"agent-b", "agent-d"}, ids)
+	ids, _ = list(&v1alpha1.ListAgentsRequest{LabelSelector: map[string]string{"env": "prod"}})
+	assert.Equal(t, []string{"agent-e"}, ids)
+	ids, _ = list(&v1alpha1.ListAgentsRequest{ConfigIds: []string{"logs"}})
+	assert.Equal(t, []string{"agent-b"}, ids)
+	ids, _ = list(&v1alpha1.ListAgentsRequest{HealthStates: []v1alpha1.AgentHealthState{
+		v1alpha1.AgentHealthState_AGENT_HEALTH_STATE_HEALTHY,
+		v1alpha1.AgentHealthState_AGENT_HEALTH_STATE_UNHEALTHY,
+	}})
+	assert.Equal(t, []string{"agent-c", "agent-d"}, ids)
+	ids, _ = list(&v1alpha1.ListAgentsRequest{HealthStates: []v1alpha1.AgentHealthState{v1alpha1.AgentHealthState_AGENT_HEALTH_STATE_UNKNOWN}})
+	assert.Equal(t, []string{"agent-a", "agent-b", "agent-e"}, ids)
+
+	_, err := env.AgentServer.ListAgents(ctx, connect.NewRequest(&v1alpha1.ListAgentsRequest{PageToken: "not a token"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
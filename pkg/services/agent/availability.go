@@ -0,0 +1,154 @@
+package agent
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// SetAvailabilityStore enables availability reports from the availability history of agents,
+// keyed by agent ID. heartbeatTimeout must match the one the history is recorded with.
+func (a *AgentServer) SetAvailabilityStore(store storage.KeyValue[*v1alpha1.AvailabilityHistory], heartbeatTimeout time.Duration) {
+	if heartbeatTimeout <= 0 {
+		heartbeatTimeout = agentdomain.DefaultHeartbeatTimeout
+	}
+	a.availabilityStore = store
+	a.heartbeatTimeout = heartbeatTimeout
+}
+
+var errAvailabilityDisabled = connect.NewError(connect.CodeUnimplemented, errors.New("availability tracking is not enabled"))
+
+// availabilityWindows validates the requested windows, defaulting to agentdomain.DefaultAvailabilityWindows
+func availabilityWindows(requested []*durationpb.Duration) ([]time.Duration, error) {
+	if len(requested) == 0 {
+		return agentdomain.DefaultAvailabilityWindows, nil
+	}
+	windows := make([]time.Duration, 0, len(requested))
+	for _, window := range requested {
+		d := window.AsDuration()
+		if d <= 0 || d > agentdomain.MaxAvailabilityWindow {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf(
+				"windows must be positive and at most %s, got %s", agentdomain.MaxAvailabilityWindow, d))
+		}
+		windows = append(windows, d)
+	}
+	return windows, nil
+}
+
+// agentAvailability computes the availability of an agent over each window ending at now
+func (a *AgentServer) agentAvailability(ctx context.Context, agentID string, windows []time.Duration, now time.Time) ([]*v1alpha1.WindowAvailability, error) {
+	history, err := a.availabilityStore.Get(ctx, agentID)
+	if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return nil, fmt.Errorf("failed to get availability history: %w", err)
+	}
+	result := make([]*v1alpha1.WindowAvailability, 0, len(windows))
+	for _, window := range windows {
+		connected, healthy := agentdomain.Availability(history, now, window, a.heartbeatTimeout)
+		result = append(result, &v1alpha1.WindowAvailability{
+			Window:    durationpb.New(window),
+			Connected: connected,
+			Healthy:   healthy,
+		})
+	}
+	return result, nil
+}
+
+func (a *AgentServer) GetAgentAvailability(
+	ctx context.Context, req *connect.Request[v1alpha1.GetAgentAvailabilityRequest],
+) (*connect.Response[v1alpha1.AgentAvailability], error) {
+	agentID := req.Msg.GetAgentId()
+	if agentID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id must be non-empty"))
+	}
+	if a.availabilityStore == nil {
+		return nil, errAvailabilityDisabled
+	}
+	windows, err := availabilityWindows(req.Msg.GetWindows())
+	if err != nil {
+		return nil, err
+	}
+	exists, err := a.repository.Exists(ctx, agentID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if !exists {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+	}
+	availability, err := a.agentAvailability(ctx, agentID, windows, time.Now())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&v1alpha1.AgentAvailability{
+		AgentId: agentID,
+		Windows: availability,
+	}), nil
+}
+
+func (a *AgentServer) GetFleetAvailability(
+	ctx context.Context, req *connect.Request[v1alpha1.GetFleetAvailabilityRequest],
+) (*connect.Response[v1alpha1.FleetAvailability], error) {
+	if a.availabilityStore == nil {
+		return nil, errAvailabilityDisabled
+	}
+	windows, err := availabilityWindows(req.Msg.GetWindows())
+	if err != nil {
+		return nil, err
+	}
+	// labels come from attributes, the status isn't needed
+	agents, err := a.repository.ListView(ctx, agentdomain.StatusViewBasic)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list agents: %w", err))
+	}
+
+	now := time.Now()
+	groups := map[string]*v1alpha1.AvailabilityGroup{}
+	for _, agent := range agents {
+		if selector := req.Msg.GetSelector(); len(selector) > 0 && !agent.MatchesLabels(selector) {
+			continue
+		}
+		availability, err := a.agentAvailability(ctx, agent.ID, windows, now)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		var value string
+		if key := req.Msg.GetGroupBy(); key != "" {
+			value = agent.Labels()[key]
+		}
+		group, ok := groups[value]
+		if !ok {
+			group = &v1alpha1.AvailabilityGroup{LabelValue: value}
+			for _, window := range windows {
+				group.Windows = append(group.Windows, &v1alpha1.WindowAvailability{Window: durationpb.New(window)})
+			}
+			groups[value] = group
+		}
+		group.AgentCount++
+		for i, window := range availability {
+			group.Windows[i].Connected += window.GetConnected()
+			group.Windows[i].Healthy += window.GetHealthy()
+		}
+	}
+
+	resp := &v1alpha1.FleetAvailability{}
+	for _, group := range groups {
+		for _, window := range group.Windows {
+			window.Connected /= float64(group.AgentCount)
+			window.Healthy /= float64(group.AgentCount)
+		}
+		resp.Groups = append(resp.Groups, group)
+	}
+	slices.SortFunc(resp.Groups, func(x, y *v1alpha1.AvailabilityGroup) int {
+		return cmp.Compare(x.GetLabelValue(), y.GetLabelValue())
+	})
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,96 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAgentServer_Availability(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	// heartbeats are recorded by the OpAMP server
+	live := env.NewAgent("live")
+	require.NoError(t, live.Start())
+	live.WaitForConfig(t, 5*time.Second)
+	require.Eventually(t, func() bool {
+		history, err := env.AvailabilityStore.Get(ctx, live.ID)
+		return err == nil && len(history.GetPeriods()) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	now := time.Now()
+	putAgent := func(agentID, env_ string, up time.Duration) {
+		t.Helper()
+		require.NoError(t, env.AgentRepo.Register(ctx, agentID, agentID))
+		require.NoError(t, env.AgentRepo.UpdateAttributes(ctx, agentID, &protobufs.AgentDescription{
+			NonIdentifyingAttributes: []*protobufs.KeyValue{{
+				Key:   "env",
+				Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: env_}},
+			}},
+		}))
+		// seen for the first 2h, then for the last `up` of the following 2h
+		require.NoError(t, env.AvailabilityStore.Put(ctx, agentID, &v1alpha1.AvailabilityHistory{
+			Periods: []*v1alpha1.AvailabilityPeriod{
+				{Start: timestamppb.New(now.Add(-4 * time.Hour)), End: timestamppb.New(now.Add(-2 * time.Hour)), Healthy: true, Closed: true},
+				{Start: timestamppb.New(now.Add(-up)), End: timestamppb.New(now), Healthy: true},
+			},
+		}))
+	}
+	putAgent("prod-1", "prod", 2*time.Hour)
+	putAgent("prod-2", "prod", time.Hour)
+	putAgent("dev-1", "dev", 0)
+
+	windows := []*durationpb.Duration{durationpb.New(2 * time.Hour)}
+	resp, err := env.AgentServer.GetAgentAvailability(ctx, connect.NewRequest(&v1alpha1.GetAgentAvailabilityRequest{
+		AgentId: "prod-2",
+		Windows: windows,
+	}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.GetWindows(), 1)
+	assert.InDelta(t, 0.5, resp.Msg.GetWindows()[0].GetConnected(), 0.01)
+	assert.InDelta(t, 0.5, resp.Msg.GetWindows()[0].GetHealthy(), 0.01)
+
+	defaults, err := env.AgentServer.GetAgentAvailability(ctx, connect.NewRequest(&v1alpha1.GetAgentAvailabilityRequest{AgentId: "prod-1"}))
+	require.NoError(t, err)
+	assert.Len(t, defaults.Msg.GetWindows(), 4)
+
+	fleet, err := env.AgentServer.GetFleetAvailability(ctx, connect.NewRequest(&v1alpha1.GetFleetAvailabilityRequest{
+		GroupBy:  "env",
+		Selector: map[string]string{"env": "prod"},
+		Windows:  windows,
+	}))
+	require.NoError(t, err)
+	require.Len(t, fleet.Msg.GetGroups(), 1)
+	prod := fleet.Msg.GetGroups()[0]
+	assert.Equal(t, "prod", prod.GetLabelValue())
+	assert.EqualValues(t, 2, prod.GetAgentCount())
+	assert.InDelta(t, 0.75, prod.GetWindows()[0].GetConnected(), 0.01)
+
+	fleet, err = env.AgentServer.GetFleetAvailability(ctx, connect.NewRequest(&v1alpha1.GetFleetAvailabilityRequest{
+		GroupBy: "env",
+		Windows: windows,
+	}))
+	require.NoError(t, err)
+	require.Len(t, fleet.Msg.GetGroups(), 3)
+	assert.Equal(t, "", fleet.Msg.GetGroups()[0].GetLabelValue(), "agents without the label")
+	assert.Equal(t, "dev", fleet.Msg.GetGroups()[1].GetLabelValue())
+	assert.InDelta(t, 0, fleet.Msg.GetGroups()[1].GetWindows()[0].GetConnected(), 0.01)
+
+	_, err = env.AgentServer.GetAgentAvailability(ctx, connect.NewRequest(&v1alpha1.GetAgentAvailabilityRequest{
+		AgentId: "prod-1",
+		Windows: []*durationpb.Duration{durationpb.New(365 * 24 * time.Hour)},
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = env.AgentServer.GetAgentAvailability(ctx, connect.NewRequest(&v1alpha1.GetAgentAvailabilityRequest{AgentId: "missing"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
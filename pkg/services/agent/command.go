@@ -0,0 +1,71 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+)
+
+// ErrCommandNotSupported is returned by an AgentRestarter when the agent doesn't accept the command
+var ErrCommandNotSupported = errors.New("agent does not accept the command")
+
+// AgentRestarter sends restart commands to connected agents
+type AgentRestarter interface {
+	// RestartAgent returns ErrAgentNotConnected if the agent has no connection
+	RestartAgent(ctx context.Context, agentID string) (*v1alpha1.AgentCommandStatus, error)
+}
+
+// SetAgentRestarter sets the transport used to restart agents
+func (a *AgentServer) SetAgentRestarter(restarter AgentRestarter) {
+	a.restarter = restarter
+}
+
+// SetCommandStore sets the store of the last command sent to each agent, keyed by agent ID
+func (a *AgentServer) SetCommandStore(store storage.KeyValue[*v1alpha1.AgentCommandStatus]) {
+	a.commandStore = store
+}
+
+func (a *AgentServer) RestartAgent(
+	ctx context.Context, req *connect.Request[v1alpha1.RestartAgentRequest],
+) (*connect.Response[v1alpha1.RestartAgentResponse], error) {
+	agentID := req.Msg.GetAgentId()
+	if agentID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id must not be empty"))
+	}
+	if a.restarter == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("agent commands are not enabled"))
+	}
+	if exists, err := a.repository.Exists(ctx, agentID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get agent: %w", err))
+	} else if !exists {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+	}
+
+	command, err := a.restarter.RestartAgent(ctx, agentID)
+	if errors.Is(err, ErrAgentNotConnected) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("agent %s is not connected", agentID))
+	} else if errors.Is(err, ErrCommandNotSupported) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("agent %s does not accept restart commands", agentID))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to restart agent: %w", err))
+	}
+	a.logger.With("agent_id", agentID, "command_id", command.GetId()).InfoContext(ctx, "requested agent restart")
+	return connect.NewResponse(&v1alpha1.RestartAgentResponse{Command: command}), nil
+}
+
+// lastCommand returns the last command sent to an agent, or nil if commands are not tracked
+func (a *AgentServer) lastCommand(ctx context.Context, agentID string) (*v1alpha1.AgentCommandStatus, error) {
+	if a.commandStore == nil {
+		return nil, nil
+	}
+	command, err := a.commandStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, nil
+	}
+	return command, err
+}
@@ -0,0 +1,77 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waitForLastCommand(t *testing.T, env *testutil.TestEnv, agentID string) *v1alpha1.AgentCommandStatus {
+	t.Helper()
+	var command *v1alpha1.AgentCommandStatus
+	require.Eventually(t, func() bool {
+		resp, err := env.AgentServer.Status(context.Background(), connect.NewRequest(&v1alpha1.GetAgentStatusRequest{AgentId: agentID}))
+		require.NoError(t, err)
+		command = resp.Msg.GetStatus().GetLastCommand()
+		return command.GetState() != v1alpha1.AgentCommandState_AGENT_COMMAND_STATE_PENDING
+	}, 10*time.Second, 50*time.Millisecond)
+	return command
+}
+
+func TestAgentServer_RestartAgent(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	agent := env.NewAgent("restart-agent")
+	require.NoError(t, agent.Start())
+	agent.WaitForConfig(t, 5*time.Second)
+
+	resp, err := env.AgentServer.RestartAgent(ctx, connect.NewRequest(&v1alpha1.RestartAgentRequest{AgentId: agent.ID}))
+	require.NoError(t, err)
+	id := resp.Msg.GetCommand().GetId()
+	assert.NotEmpty(t, id)
+	assert.Equal(t, "restart", resp.Msg.GetCommand().GetType())
+	assert.Equal(t, v1alpha1.AgentCommandState_AGENT_COMMAND_STATE_PENDING, resp.Msg.GetCommand().GetState())
+
+	command := waitForLastCommand(t, env, agent.ID)
+	assert.Equal(t, id, command.GetId())
+	assert.Equal(t, v1alpha1.AgentCommandState_AGENT_COMMAND_STATE_SUCCEEDED, command.GetState(), command.GetErrorMessage())
+	assert.NotNil(t, command.GetCompletedAt())
+	assert.Equal(t, 1, agent.AgentDriver.GetRestartCount())
+
+	// failures to restart the collector are reported
+	agent.AgentDriver.FailNextRestart(errors.New("collector did not start"))
+	_, err = env.AgentServer.RestartAgent(ctx, connect.NewRequest(&v1alpha1.RestartAgentRequest{AgentId: agent.ID}))
+	require.NoError(t, err)
+	command = waitForLastCommand(t, env, agent.ID)
+	assert.Equal(t, v1alpha1.AgentCommandState_AGENT_COMMAND_STATE_FAILED, command.GetState())
+	assert.Equal(t, "collector did not start", command.GetErrorMessage())
+	assert.Equal(t, 1, agent.AgentDriver.GetRestartCount())
+}
+
+func TestAgentServer_RestartAgent_Errors(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.AgentServer.RestartAgent(ctx, connect.NewRequest(&v1alpha1.RestartAgentRequest{}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = env.AgentServer.RestartAgent(ctx, connect.NewRequest(&v1alpha1.RestartAgentRequest{AgentId: "missing"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	agent := env.NewAgent("restart-agent")
+	require.NoError(t, agent.Start())
+	agent.WaitForConfig(t, 5*time.Second)
+	require.NoError(t, agent.Stop())
+	require.Eventually(t, func() bool {
+		_, err = env.AgentServer.RestartAgent(ctx, connect.NewRequest(&v1alpha1.RestartAgentRequest{AgentId: agent.ID}))
+		return connect.CodeOf(err) == connect.CodeFailedPrecondition
+	}, 5*time.Second, 20*time.Millisecond)
+}
@@ -0,0 +1,43 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+)
+
+// SetConfigPushStore sets the store of remote config push history, keyed by agent ID
+func (a *AgentServer) SetConfigPushStore(store storage.KeyValue[*v1alpha1.ConfigPushHistory]) {
+	a.configPushStore = store
+}
+
+func (a *AgentServer) ListConfigPushes(
+	ctx context.Context, req *connect.Request[v1alpha1.ListConfigPushesRequest],
+) (*connect.Response[v1alpha1.ListConfigPushesResponse], error) {
+	agentID := req.Msg.GetAgentId()
+	if agentID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id must be non-empty"))
+	}
+	if a.configPushStore == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("config push tracking is not enabled"))
+	}
+	history, err := a.configPushStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		return connect.NewResponse(&v1alpha1.ListConfigPushesResponse{}), nil
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get config pushes: %w", err))
+	}
+	resp := &v1alpha1.ListConfigPushesResponse{}
+	for _, push := range history.GetPushes() {
+		if pushID := req.Msg.GetPushId(); pushID != "" && push.GetPushId() != pushID {
+			continue
+		}
+		resp.Pushes = append(resp.Pushes, push)
+	}
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,78 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentServer_ListConfigPushes(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	agent := env.NewAgent("push-agent")
+	require.NoError(t, agent.Start())
+	agent.WaitForConfig(t, 5*time.Second)
+
+	list := func(pushID string) []*v1alpha1.ConfigPush {
+		t.Helper()
+		resp, err := env.AgentServer.ListConfigPushes(ctx, connect.NewRequest(&v1alpha1.ListConfigPushesRequest{
+			AgentId: agent.ID,
+			PushId:  pushID,
+		}))
+		require.NoError(t, err)
+		return resp.Msg.GetPushes()
+	}
+	applied := func(n int) func() bool {
+		return func() bool {
+			pushes := list("")
+			return len(pushes) == n && pushes[n-1].GetState() == v1alpha1.ConfigPushState_CONFIG_PUSH_STATE_APPLIED
+		}
+	}
+	require.Eventually(t, applied(1), 5*time.Second, 20*time.Millisecond)
+	first := list("")[0]
+	assert.Equal(t, agent.ID, first.GetAgentId())
+	assert.NotEmpty(t, first.GetConfigHash())
+	assert.EqualValues(t, 1, first.GetAttempt())
+	require.NotNil(t, first.GetAcknowledgedAt())
+	require.NotNil(t, first.GetAppliedAt())
+	assert.False(t, first.GetAppliedAt().AsTime().Before(first.GetOfferedAt().AsTime()))
+
+	// pushing the same config again is a retry
+	env.OpampServer.NotifyConfigChange(agent.ID)
+	require.Eventually(t, applied(2), 5*time.Second, 20*time.Millisecond)
+	assert.EqualValues(t, 2, list("")[1].GetAttempt())
+
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: "logs"},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {}")},
+	}))
+	require.NoError(t, err)
+	_, err = env.ConfigServer.AssignConfig(ctx, connect.NewRequest(&configv1alpha1.AssignConfigRequest{
+		AgentId:  agent.ID,
+		ConfigId: "logs",
+	}))
+	require.NoError(t, err)
+	require.Eventually(t, applied(3), 5*time.Second, 20*time.Millisecond)
+	third := list("")[2]
+	assert.EqualValues(t, 1, third.GetAttempt())
+	assert.NotEqual(t, first.GetConfigHash(), third.GetConfigHash())
+
+	assert.Equal(t, []string{third.GetPushId()}, pushIDs(list(third.GetPushId())))
+	assert.Empty(t, list("unknown"))
+}
+
+func pushIDs(pushes []*v1alpha1.ConfigPush) []string {
+	ids := make([]string, 0, len(pushes))
+	for _, push := range pushes {
+		ids = append(ids, push.GetPushId())
+	}
+	return ids
+}
@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// DefaultDeletionGracePeriod is how long deleted agents are kept when no grace period is configured
+const DefaultDeletionGracePeriod = 7 * 24 * time.Hour
+
+// SetDeletionGracePeriod sets how long deleted agents can be restored before they are purged
+func (a *AgentServer) SetDeletionGracePeriod(gracePeriod time.Duration) {
+	if gracePeriod <= 0 {
+		gracePeriod = DefaultDeletionGracePeriod
+	}
+	a.deletionGracePeriod = gracePeriod
+}
+
+// UndeleteAgent restores an agent deleted within the grace period
+func (a *AgentServer) UndeleteAgent(ctx context.Context, req *connect.Request[v1alpha1.UndeleteAgentRequest]) (*connect.Response[emptypb.Empty], error) {
+	agentID := req.Msg.GetAgentId()
+	if agentID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id must not be empty"))
+	}
+	restored, err := a.repository.Restore(ctx, agentID)
+	if errors.Is(err, agentdomain.ErrAgentNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to restore agent: %w", err))
+	}
+	if !restored {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("agent %s is not deleted", agentID))
+	}
+	a.logger.With("agent_id", agentID).InfoContext(ctx, "agent undeleted")
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// disconnectAgent closes the connection of a deleted or revoked agent, if it is connected
+func (a *AgentServer) disconnectAgent(ctx context.Context, agentID string) {
+	if a.disconnector == nil {
+		return
+	}
+	if err := a.disconnector.DisconnectAgent(ctx, agentID); err != nil && !errors.Is(err, ErrAgentNotConnected) {
+		a.logger.With("agent_id", agentID, "err", err).WarnContext(ctx, "failed to disconnect agent")
+	}
+}
+
+// purgeAgent revokes the agent's config assignment, disconnects it if it is connected
+// and removes it from all stores. The agent is free to reconnect, it registers again as
+// a new agent.
+func (a *AgentServer) purgeAgent(ctx context.Context, agentID string) error {
+	logger := a.logger.With("agent_id", agentID)
+	logger.InfoContext(ctx, "purging agent")
+	if a.assignmentRevoker != nil {
+		if err := a.assignmentRevoker.RevokeAssignment(ctx, agentID); err != nil {
+			return fmt.Errorf("failed to revoke config assignment: %w", err)
+		}
+	}
+	a.disconnectAgent(ctx, agentID)
+	if err := a.repository.Delete(ctx, agentID); err != nil {
+		if errors.Is(err, agentdomain.ErrAgentNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to delete agent: %w", err)
+	}
+	if err := a.deleteProblems(ctx, agentID); err != nil {
+		logger.With("err", err).WarnContext(ctx, "failed to delete agent problems")
+	}
+	if err := a.deleteMetrics(ctx, agentID); err != nil {
+		logger.With("err", err).WarnContext(ctx, "failed to delete agent metrics")
+	}
+	events.RecordAgentEvent(ctx, a.eventRecorder, a.repository, events.TypeAgentDeleted, agentID, "agent purged")
+	logger.InfoContext(ctx, "agent purged")
+	return nil
+}
+
+// PurgeDeletedAgents purges the agents deleted for longer than the grace period, it is
+// run every minute while the server is running
+func (a *AgentServer) PurgeDeletedAgents(ctx context.Context, now time.Time) error {
+	deleted, err := a.repository.ListDeleted(ctx, agentdomain.StatusViewBasic)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, domainAgent := range deleted {
+		if now.Sub(*domainAgent.DeletedAt) < a.deletionGracePeriod {
+			continue
+		}
+		if err := a.purgeAgent(ctx, domainAgent.ID); err != nil && !errors.Is(err, agentdomain.ErrAgentNotFound) {
+			errs = append(errs, fmt.Errorf("agent %s: %w", domainAgent.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
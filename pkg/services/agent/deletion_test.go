@@ -0,0 +1,109 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listAgentIDs(t *testing.T, env *testutil.TestEnv, includeDeleted bool) []string {
+	t.Helper()
+	resp, err := env.AgentServer.ListAgents(context.Background(), connect.NewRequest(&v1alpha1.ListAgentsRequest{
+		IncludeDeleted: includeDeleted,
+	}))
+	require.NoError(t, err)
+	ids := []string{}
+	for _, agent := range resp.Msg.GetAgents() {
+		ids = append(ids, agent.GetAgent().GetId())
+	}
+	return ids
+}
+
+func TestAgentServer_DeleteAgent_GracePeriod(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: "logs"},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {}")},
+	}))
+	require.NoError(t, err)
+	env.NewAgent("agent-1")
+	env.NewAgent("agent-2")
+	_, err = env.ConfigServer.AssignConfig(ctx, connect.NewRequest(&configv1alpha1.AssignConfigRequest{
+		AgentId:  "agent-1",
+		ConfigId: "logs",
+	}))
+	require.NoError(t, err)
+
+	_, err = env.AgentServer.DeleteAgent(ctx, connect.NewRequest(&v1alpha1.DeleteAgentRequest{AgentId: "agent-1"}))
+	require.NoError(t, err)
+
+	// the agent is hidden, but kept along with its assignment
+	assert.ElementsMatch(t, []string{"agent-2"}, listAgentIDs(t, env, false))
+	assert.ElementsMatch(t, []string{"agent-1", "agent-2"}, listAgentIDs(t, env, true))
+	get, err := env.AgentServer.GetAgent(ctx, connect.NewRequest(&v1alpha1.GetAgentRequest{AgentId: "agent-1"}))
+	require.NoError(t, err)
+	assert.NotNil(t, get.Msg.GetAgent().GetDeletedAt())
+	_, err = env.ConfigAssignmentStore.Get(ctx, "agent-1")
+	require.NoError(t, err)
+
+	// deleting it again requires purging it
+	_, err = env.AgentServer.DeleteAgent(ctx, connect.NewRequest(&v1alpha1.DeleteAgentRequest{AgentId: "agent-1"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// undeleting restores it
+	_, err = env.AgentServer.UndeleteAgent(ctx, connect.NewRequest(&v1alpha1.UndeleteAgentRequest{AgentId: "agent-1"}))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"agent-1", "agent-2"}, listAgentIDs(t, env, false))
+	_, err = env.AgentServer.UndeleteAgent(ctx, connect.NewRequest(&v1alpha1.UndeleteAgentRequest{AgentId: "agent-1"}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	// it is only purged once the grace period expires
+	_, err = env.AgentServer.DeleteAgent(ctx, connect.NewRequest(&v1alpha1.DeleteAgentRequest{AgentId: "agent-1"}))
+	require.NoError(t, err)
+	env.AgentServer.SetDeletionGracePeriod(time.Hour)
+	require.NoError(t, env.AgentServer.PurgeDeletedAgents(ctx, time.Now().Add(59*time.Minute)))
+	exists, err := env.AgentRepo.Exists(ctx, "agent-1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, env.AgentServer.PurgeDeletedAgents(ctx, time.Now().Add(time.Hour)))
+	exists, err = env.AgentRepo.Exists(ctx, "agent-1")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	_, err = env.ConfigAssignmentStore.Get(ctx, "agent-1")
+	assert.True(t, grpcutil.IsErrorNotFound(err))
+	assert.ElementsMatch(t, []string{"agent-2"}, listAgentIDs(t, env, true))
+
+	_, err = env.AgentServer.UndeleteAgent(ctx, connect.NewRequest(&v1alpha1.UndeleteAgentRequest{AgentId: "agent-1"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestAgentServer_DeleteAgent_RestoredOnReconnect(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	agent := env.NewAgent("agent-1")
+	require.NoError(t, agent.Start())
+	agent.WaitForConfig(t, 5*time.Second)
+
+	_, err := env.AgentServer.DeleteAgent(ctx, connect.NewRequest(&v1alpha1.DeleteAgentRequest{AgentId: agent.ID}))
+	require.NoError(t, err)
+
+	// the agent is disconnected, it is restored as soon as it reconnects
+	require.Eventually(t, func() bool {
+		get, err := env.AgentServer.GetAgent(ctx, connect.NewRequest(&v1alpha1.GetAgentRequest{AgentId: agent.ID}))
+		require.NoError(t, err)
+		return get.Msg.GetAgent().GetDeletedAt() == nil
+	}, 10*time.Second, 50*time.Millisecond)
+	assert.ElementsMatch(t, []string{agent.ID}, listAgentIDs(t, env, false))
+}
@@ -0,0 +1,210 @@
+package agent
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+)
+
+const (
+	// ExportPath is the HTTP path agents are exported from
+	ExportPath = "/v1alpha1/agents/export"
+
+	ExportFormatCSV   = "csv"
+	ExportFormatJSONL = "jsonl"
+
+	// exportFlushRows is the number of rows written between flushes of the response
+	exportFlushRows = 100
+)
+
+// exportColumn extracts a column of the agent export
+type exportColumn struct {
+	name  string
+	value func(a *agentdomain.Agent) any
+}
+
+// ExportColumns lists the columns that can be exported, in their default order
+var ExportColumns = []string{"id", "name", "labels", "state", "config", "config_sync", "version", "last_seen"}
+
+var exportColumns = map[string]exportColumn{
+	"id":   {name: "id", value: func(a *agentdomain.Agent) any { return a.ID }},
+	"name": {name: "name", value: func(a *agentdomain.Agent) any { return a.FriendlyName }},
+	"labels": {name: "labels", value: func(a *agentdomain.Agent) any {
+		return a.Labels()
+	}},
+	"state": {name: "state", value: func(a *agentdomain.Agent) any {
+		return enumName(agentdomain.ToAPIStatus(a).GetState().String(), "AGENT_STATE_")
+	}},
+	"config": {name: "config", value: func(a *agentdomain.Agent) any { return a.Status.AssignedConfigID }},
+	"config_sync": {name: "config_sync", value: func(a *agentdomain.Agent) any {
+		return enumName(agentdomain.ToAPIStatus(a).GetConfigSyncStatus().String(), "CONFIG_SYNC_STATUS_")
+	}},
+	"version": {name: "version", value: func(a *agentdomain.Agent) any { return agentVersion(a) }},
+	"last_seen": {name: "last_seen", value: func(a *agentdomain.Agent) any {
+		if a.Connection.LastSeen == nil {
+			return ""
+		}
+		return a.Connection.LastSeen.UTC().Format(time.RFC3339)
+	}},
+}
+
+// enumName turns a proto enum value name into a short lower case name, e.g.
+// AGENT_STATE_CONNECTED into connected
+func enumName(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
+
+// agentVersion returns the agent's reported service.version
+func agentVersion(a *agentdomain.Agent) string {
+	for _, attrs := range []map[string]any{a.Attributes.Identifying, a.Attributes.NonIdentifying} {
+		if v, ok := attrs["service.version"].(string); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// ExportAgents streams the agent inventory as CSV or JSON Lines.
+//
+// The format query parameter selects csv (default) or jsonl, and the columns
+// query parameter optionally selects a comma separated subset of ExportColumns.
+func (a *AgentServer) ExportAgents(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = ExportFormatCSV
+	}
+	if format != ExportFormatCSV && format != ExportFormatJSONL {
+		http.Error(w, fmt.Sprintf("unsupported format %q, expected %s or %s", format, ExportFormatCSV, ExportFormatJSONL), http.StatusBadRequest)
+		return
+	}
+	columns, err := parseExportColumns(r.URL.Query().Get("columns"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	agents, err := a.repository.List(r.Context())
+	if err != nil {
+		a.logger.With("err", err).Error("failed to list agents for export")
+		http.Error(w, "failed to list agents", http.StatusInternalServerError)
+		return
+	}
+	slices.SortFunc(agents, func(x, y *agentdomain.Agent) int {
+		return strings.Compare(x.ID, y.ID)
+	})
+
+	var rows rowWriter
+	switch format {
+	case ExportFormatCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		rows = newCSVRowWriter(w, columns)
+	case ExportFormatJSONL:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		rows = &jsonlRowWriter{enc: json.NewEncoder(w), columns: columns}
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=agents.%s", format))
+	flusher, _ := w.(http.Flusher)
+
+	for i, agent := range agents {
+		if err := rows.write(agent); err != nil {
+			// the response has already started, all we can do is stop writing
+			a.logger.With("err", err).Warn("failed to write agent export")
+			return
+		}
+		if flusher != nil && (i+1)%exportFlushRows == 0 {
+			if err := rows.flush(); err != nil {
+				a.logger.With("err", err).Warn("failed to write agent export")
+				return
+			}
+			flusher.Flush()
+		}
+	}
+	if err := rows.flush(); err != nil {
+		a.logger.With("err", err).Warn("failed to write agent export")
+	}
+}
+
+func parseExportColumns(param string) ([]exportColumn, error) {
+	names := ExportColumns
+	if param != "" {
+		names = strings.Split(param, ",")
+	}
+	columns := make([]exportColumn, 0, len(names))
+	for _, name := range names {
+		col, ok := exportColumns[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q, expected one of %s", name, strings.Join(ExportColumns, ","))
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+type rowWriter interface {
+	write(a *agentdomain.Agent) error
+	flush() error
+}
+
+type csvRowWriter struct {
+	w       *csv.Writer
+	columns []exportColumn
+}
+
+func newCSVRowWriter(w io.Writer, columns []exportColumn) *csvRowWriter {
+	c := &csvRowWriter{w: csv.NewWriter(w), columns: columns}
+	header := make([]string, 0, len(columns))
+	for _, col := range columns {
+		header = append(header, col.name)
+	}
+	// errors are sticky and reported by the next flush
+	_ = c.w.Write(header)
+	return c
+}
+
+func (c *csvRowWriter) write(a *agentdomain.Agent) error {
+	record := make([]string, 0, len(c.columns))
+	for _, col := range c.columns {
+		switch v := col.value(a).(type) {
+		case map[string]string:
+			// labels are flattened to sorted key=value pairs
+			pairs := make([]string, 0, len(v))
+			for _, k := range slices.Sorted(maps.Keys(v)) {
+				pairs = append(pairs, k+"="+v[k])
+			}
+			record = append(record, strings.Join(pairs, ";"))
+		default:
+			record = append(record, fmt.Sprint(v))
+		}
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvRowWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonlRowWriter struct {
+	enc     *json.Encoder
+	columns []exportColumn
+}
+
+func (j *jsonlRowWriter) write(a *agentdomain.Agent) error {
+	row := make(map[string]any, len(j.columns))
+	for _, col := range j.columns {
+		row[col.name] = col.value(a)
+	}
+	return j.enc.Encode(row)
+}
+
+func (j *jsonlRowWriter) flush() error {
+	return nil
+}
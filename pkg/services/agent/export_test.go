@@ -0,0 +1,91 @@
+package agent_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/agent"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func exportAgents(t *testing.T, env *testutil.TestEnv, query string) *http.Response {
+	t.Helper()
+	resp, err := env.HTTPServer.Client().Get(env.BaseURL + agent.ExportPath + query)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestAgentServer_ExportAgents(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: "logs"},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {}")},
+	}))
+	require.NoError(t, err)
+
+	connected := env.NewAgentWithLabels("agent-b", map[string]string{"env": "prod", "service.version": "1.2.3"})
+	_, err = env.ConfigServer.AssignConfig(ctx, connect.NewRequest(&configv1alpha1.AssignConfigRequest{
+		AgentId:  connected.ID,
+		ConfigId: "logs",
+	}))
+	require.NoError(t, err)
+	require.NoError(t, connected.Start())
+	connected.WaitForConfig(t, 5*time.Second)
+
+	lastSeen := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, env.AgentRepo.Register(ctx, "agent-a", "Agent, A"))
+	require.NoError(t, env.ConnectionStateStore.Put(ctx, "agent-a", &v1alpha1.AgentConnectionState{
+		AgentId:  "agent-a",
+		State:    v1alpha1.AgentState_AGENT_STATE_DISCONNECTED,
+		LastSeen: timestamppb.New(lastSeen),
+	}))
+
+	resp := exportAgents(t, env, "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, agent.ExportColumns, records[0])
+	assert.Equal(t, []string{"agent-a", "Agent, A", "", "disconnected", "", "unknown", "", "2024-01-02T03:04:05Z"}, records[1])
+	assert.Equal(t, "agent-b", records[2][0])
+	assert.Contains(t, strings.Split(records[2][2], ";"), "env=prod")
+	assert.Equal(t, []string{"connected", "logs"}, records[2][3:5])
+	assert.Equal(t, "1.2.3", records[2][6])
+
+	resp = exportAgents(t, env, "?format=jsonl&columns=id,labels,state")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+	rows := []map[string]any{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		row := map[string]any{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+		rows = append(rows, row)
+	}
+	require.Len(t, rows, 2)
+	assert.Equal(t, map[string]any{"id": "agent-a", "labels": map[string]any{}, "state": "disconnected"}, rows[0])
+	assert.Equal(t, "prod", rows[1]["labels"].(map[string]any)["env"])
+}
+
+func TestAgentServer_ExportAgents_InvalidRequest(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+
+	assert.Equal(t, http.StatusBadRequest, exportAgents(t, env, "?format=xml").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, exportAgents(t, env, "?columns=id,secret").StatusCode)
+}
@@ -0,0 +1,271 @@
+package agent
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// FleetSnapshotInterval is how often the state of the fleet is captured
+	FleetSnapshotInterval = 24 * time.Hour
+	// FleetSnapshotRetention is how long fleet snapshots are kept
+	FleetSnapshotRetention = 90 * 24 * time.Hour
+)
+
+// SetFleetSnapshotStore enables fleet snapshots, keyed by snapshot ID
+func (a *AgentServer) SetFleetSnapshotStore(store storage.KeyValue[*v1alpha1.FleetSnapshot]) {
+	a.fleetSnapshotStore = store
+}
+
+func (a *AgentServer) CaptureFleetSnapshot(
+	ctx context.Context, _ *connect.Request[v1alpha1.CaptureFleetSnapshotRequest],
+) (*connect.Response[v1alpha1.FleetSnapshot], error) {
+	if a.fleetSnapshotStore == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("fleet snapshots are not enabled"))
+	}
+	snapshot, err := a.captureFleetSnapshot(ctx, time.Now())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(snapshot), nil
+}
+
+func (a *AgentServer) ListFleetSnapshots(
+	ctx context.Context, _ *connect.Request[v1alpha1.ListFleetSnapshotsRequest],
+) (*connect.Response[v1alpha1.ListFleetSnapshotsResponse], error) {
+	if a.fleetSnapshotStore == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("fleet snapshots are not enabled"))
+	}
+	snapshots, err := a.listFleetSnapshots(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp := &v1alpha1.ListFleetSnapshotsResponse{}
+	for _, snapshot := range snapshots {
+		resp.Snapshots = append(resp.Snapshots, fleetSnapshotSummary(snapshot))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AgentServer) DiffFleetState(
+	ctx context.Context, req *connect.Request[v1alpha1.DiffFleetStateRequest],
+) (*connect.Response[v1alpha1.FleetStateDiff], error) {
+	if a.fleetSnapshotStore == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("fleet snapshots are not enabled"))
+	}
+	if req.Msg.GetFromSnapshotId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("from_snapshot_id must not be empty"))
+	}
+	from, err := a.getFleetSnapshot(ctx, req.Msg.GetFromSnapshotId())
+	if err != nil {
+		return nil, err
+	}
+	var to *v1alpha1.FleetSnapshot
+	if id := req.Msg.GetToSnapshotId(); id != "" {
+		to, err = a.getFleetSnapshot(ctx, id)
+	} else {
+		to, err = a.fleetState(ctx, time.Now())
+		if err != nil {
+			err = connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(diffFleetSnapshots(from, to)), nil
+}
+
+func (a *AgentServer) getFleetSnapshot(ctx context.Context, id string) (*v1alpha1.FleetSnapshot, error) {
+	snapshot, err := a.fleetSnapshotStore.Get(ctx, id)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("fleet snapshot not found: %s", id))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get fleet snapshot: %w", err))
+	}
+	return snapshot, nil
+}
+
+// listFleetSnapshots returns the stored fleet snapshots, oldest first
+func (a *AgentServer) listFleetSnapshots(ctx context.Context) ([]*v1alpha1.FleetSnapshot, error) {
+	all, err := a.fleetSnapshotStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fleet snapshots: %w", err)
+	}
+	snapshots := slices.DeleteFunc(all, func(snapshot *v1alpha1.FleetSnapshot) bool { return snapshot == nil })
+	slices.SortFunc(snapshots, func(x, y *v1alpha1.FleetSnapshot) int {
+		return x.GetCapturedAt().AsTime().Compare(y.GetCapturedAt().AsTime())
+	})
+	return snapshots, nil
+}
+
+// fleetState returns the current state of the fleet as an unsaved snapshot
+func (a *AgentServer) fleetState(ctx context.Context, now time.Time) (*v1alpha1.FleetSnapshot, error) {
+	agents, err := a.repository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+	snapshot := &v1alpha1.FleetSnapshot{
+		CapturedAt: timestamppb.New(now),
+		AgentCount: int32(len(agents)),
+	}
+	for _, agent := range agents {
+		snapshot.Agents = append(snapshot.Agents, fleetSnapshotAgent(agent))
+	}
+	slices.SortFunc(snapshot.Agents, func(x, y *v1alpha1.FleetSnapshotAgent) int {
+		return strings.Compare(x.GetAgentId(), y.GetAgentId())
+	})
+	return snapshot, nil
+}
+
+func (a *AgentServer) captureFleetSnapshot(ctx context.Context, now time.Time) (*v1alpha1.FleetSnapshot, error) {
+	snapshot, err := a.fleetState(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.Id = util.NewUUID()
+	if err := a.fleetSnapshotStore.Put(ctx, snapshot.GetId(), snapshot); err != nil {
+		return nil, fmt.Errorf("failed to store fleet snapshot: %w", err)
+	}
+	a.fleetSnapshotMu.Lock()
+	a.lastFleetSnapshot = now
+	a.fleetSnapshotMu.Unlock()
+	a.logger.With("snapshot_id", snapshot.GetId(), "agents", snapshot.GetAgentCount()).Info("captured fleet snapshot")
+	return snapshot, nil
+}
+
+// maintainFleetSnapshots captures a snapshot once FleetSnapshotInterval has passed since the
+// last one, and deletes the snapshots older than FleetSnapshotRetention
+func (a *AgentServer) maintainFleetSnapshots(ctx context.Context, now time.Time) error {
+	if a.fleetSnapshotStore == nil {
+		return nil
+	}
+	a.fleetSnapshotMu.Lock()
+	last := a.lastFleetSnapshot
+	a.fleetSnapshotMu.Unlock()
+	if last.IsZero() {
+		// first run since starting, pick up from the stored snapshots
+		snapshots, err := a.listFleetSnapshots(ctx)
+		if err != nil {
+			return err
+		}
+		if n := len(snapshots); n > 0 {
+			last = snapshots[n-1].GetCapturedAt().AsTime()
+		}
+	}
+	if now.Sub(last) < FleetSnapshotInterval {
+		return nil
+	}
+	if _, err := a.captureFleetSnapshot(ctx, now); err != nil {
+		return err
+	}
+	return a.pruneFleetSnapshots(ctx, now)
+}
+
+func (a *AgentServer) pruneFleetSnapshots(ctx context.Context, now time.Time) error {
+	snapshots, err := a.listFleetSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range snapshots {
+		if now.Sub(snapshot.GetCapturedAt().AsTime()) <= FleetSnapshotRetention {
+			break
+		}
+		if err := a.fleetSnapshotStore.Delete(ctx, snapshot.GetId()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fleetSnapshotAgent(agent *agentdomain.Agent) *v1alpha1.FleetSnapshotAgent {
+	record := &v1alpha1.FleetSnapshotAgent{
+		AgentId:  agent.ID,
+		Name:     agent.FriendlyName,
+		Version:  agentVersion(agent),
+		ConfigId: agent.Status.AssignedConfigID,
+		State:    enumName(agentdomain.ToAPIStatus(agent).GetState().String(), "AGENT_STATE_"),
+		Labels:   agent.Labels(),
+	}
+	if status := agent.Status.RemoteConfigStatus; status != nil {
+		record.ConfigHash = hex.EncodeToString(status.LastRemoteConfigHash)
+	}
+	return record
+}
+
+func fleetSnapshotSummary(snapshot *v1alpha1.FleetSnapshot) *v1alpha1.FleetSnapshot {
+	summary := proto.CloneOf(snapshot)
+	summary.Agents = nil
+	return summary
+}
+
+// diffFleetSnapshots compares the agents of two fleet snapshots
+func diffFleetSnapshots(from, to *v1alpha1.FleetSnapshot) *v1alpha1.FleetStateDiff {
+	diff := &v1alpha1.FleetStateDiff{
+		From: fleetSnapshotSummary(from),
+		To:   fleetSnapshotSummary(to),
+	}
+	before := map[string]*v1alpha1.FleetSnapshotAgent{}
+	for _, agent := range from.GetAgents() {
+		before[agent.GetAgentId()] = agent
+	}
+	for _, agent := range to.GetAgents() {
+		previous, ok := before[agent.GetAgentId()]
+		if !ok {
+			diff.Added = append(diff.Added, agent)
+			continue
+		}
+		delete(before, agent.GetAgentId())
+		if changes := diffFleetSnapshotAgents(previous, agent); len(changes) > 0 {
+			diff.Changed = append(diff.Changed, &v1alpha1.AgentStateChange{
+				AgentId: agent.GetAgentId(),
+				Changes: changes,
+			})
+		}
+	}
+	for _, agent := range from.GetAgents() {
+		if _, ok := before[agent.GetAgentId()]; ok {
+			diff.Removed = append(diff.Removed, agent)
+		}
+	}
+	return diff
+}
+
+func diffFleetSnapshotAgents(from, to *v1alpha1.FleetSnapshotAgent) []*v1alpha1.FieldChange {
+	var changes []*v1alpha1.FieldChange
+	field := func(name, from, to string) {
+		if from != to {
+			changes = append(changes, &v1alpha1.FieldChange{Field: name, From: from, To: to})
+		}
+	}
+	field("config_id", from.GetConfigId(), to.GetConfigId())
+	field("config_hash", from.GetConfigHash(), to.GetConfigHash())
+	field("version", from.GetVersion(), to.GetVersion())
+	field("name", from.GetName(), to.GetName())
+	field("state", from.GetState(), to.GetState())
+	keys := slices.Collect(maps.Keys(from.GetLabels()))
+	for key := range to.GetLabels() {
+		if _, ok := from.GetLabels()[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		field("label."+key, from.GetLabels()[key], to.GetLabels()[key])
+	}
+	return changes
+}
@@ -0,0 +1,91 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reportVersion(t *testing.T, env *testutil.TestEnv, agentID, version string) {
+	t.Helper()
+	require.NoError(t, env.AgentRepo.UpdateAttributes(context.Background(), agentID, &protobufs.AgentDescription{
+		IdentifyingAttributes: []*protobufs.KeyValue{{
+			Key:   "service.version",
+			Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: version}},
+		}},
+	}))
+}
+
+func TestAgentServer_DiffFleetState(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	env.NewAgent("agent-a")
+	env.NewAgent("agent-b")
+	reportVersion(t, env, "agent-a", "0.120.0")
+
+	first, err := env.AgentServer.CaptureFleetSnapshot(ctx, connect.NewRequest(&v1alpha1.CaptureFleetSnapshotRequest{}))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, first.Msg.GetAgentCount())
+	require.Len(t, first.Msg.GetAgents(), 2)
+	assert.Equal(t, "0.120.0", first.Msg.GetAgents()[0].GetVersion())
+
+	env.NewAgent("agent-c")
+	_, err = env.AgentServer.DeleteAgent(ctx, connect.NewRequest(&v1alpha1.DeleteAgentRequest{AgentId: "agent-b"}))
+	require.NoError(t, err)
+	reportVersion(t, env, "agent-a", "0.121.0")
+
+	// against the current state of the fleet
+	diff, err := env.AgentServer.DiffFleetState(ctx, connect.NewRequest(&v1alpha1.DiffFleetStateRequest{
+		FromSnapshotId: first.Msg.GetId(),
+	}))
+	require.NoError(t, err)
+	assertFleetDiff := func(diff *v1alpha1.FleetStateDiff) {
+		t.Helper()
+		require.Len(t, diff.GetAdded(), 1)
+		assert.Equal(t, "agent-c", diff.GetAdded()[0].GetAgentId())
+		require.Len(t, diff.GetRemoved(), 1)
+		assert.Equal(t, "agent-b", diff.GetRemoved()[0].GetAgentId())
+		require.Len(t, diff.GetChanged(), 1)
+		assert.Equal(t, "agent-a", diff.GetChanged()[0].GetAgentId())
+		// the version is also an identifying attribute, so it is reported as a label too
+		require.Len(t, diff.GetChanged()[0].GetChanges(), 2)
+		assert.Equal(t, "label.service.version", diff.GetChanged()[0].GetChanges()[1].GetField())
+		change := diff.GetChanged()[0].GetChanges()[0]
+		assert.Equal(t, "version", change.GetField())
+		assert.Equal(t, "0.120.0", change.GetFrom())
+		assert.Equal(t, "0.121.0", change.GetTo())
+		assert.Equal(t, first.Msg.GetId(), diff.GetFrom().GetId())
+		assert.Empty(t, diff.GetFrom().GetAgents())
+	}
+	assertFleetDiff(diff.Msg)
+	assert.Empty(t, diff.Msg.GetTo().GetId())
+
+	// between two snapshots
+	second, err := env.AgentServer.CaptureFleetSnapshot(ctx, connect.NewRequest(&v1alpha1.CaptureFleetSnapshotRequest{}))
+	require.NoError(t, err)
+	diff, err = env.AgentServer.DiffFleetState(ctx, connect.NewRequest(&v1alpha1.DiffFleetStateRequest{
+		FromSnapshotId: first.Msg.GetId(),
+		ToSnapshotId:   second.Msg.GetId(),
+	}))
+	require.NoError(t, err)
+	assertFleetDiff(diff.Msg)
+	assert.Equal(t, second.Msg.GetId(), diff.Msg.GetTo().GetId())
+
+	list, err := env.AgentServer.ListFleetSnapshots(ctx, connect.NewRequest(&v1alpha1.ListFleetSnapshotsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.GetSnapshots(), 2)
+	assert.Equal(t, first.Msg.GetId(), list.Msg.GetSnapshots()[0].GetId())
+	assert.Empty(t, list.Msg.GetSnapshots()[0].GetAgents())
+
+	_, err = env.AgentServer.DiffFleetState(ctx, connect.NewRequest(&v1alpha1.DiffFleetStateRequest{FromSnapshotId: "missing"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = env.AgentServer.DiffFleetState(ctx, connect.NewRequest(&v1alpha1.DiffFleetStateRequest{}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
@@ -0,0 +1,39 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+)
+
+// SetMetricsStore sets the store of the latest metrics reported by the collector of each
+// agent, keyed by agent ID
+func (a *AgentServer) SetMetricsStore(store storage.KeyValue[*v1alpha1.AgentMetrics]) {
+	a.metricsStore = store
+}
+
+// agentMetrics returns the latest metrics reported by the collector of the agent, nil if it
+// hasn't reported any
+func (a *AgentServer) agentMetrics(ctx context.Context, agentID string) (*v1alpha1.AgentMetrics, error) {
+	if a.metricsStore == nil {
+		return nil, nil
+	}
+	metrics, err := a.metricsStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, nil
+	}
+	return metrics, err
+}
+
+// deleteMetrics deletes the metrics reported by the collector of a purged agent
+func (a *AgentServer) deleteMetrics(ctx context.Context, agentID string) error {
+	if a.metricsStore == nil {
+		return nil
+	}
+	if err := a.metricsStore.Delete(ctx, agentID); err != nil && !grpcutil.IsErrorNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package agent
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ProblemKey is the key of the problem of an agent in the problem store
+func ProblemKey(agentID string, problemType v1alpha1.AgentProblemType) string {
+	return agentID + "/" + problemType.String()
+}
+
+// SetProblemStore sets the store of the open problems reported by agents, keyed by ProblemKey
+func (a *AgentServer) SetProblemStore(store storage.KeyValue[*v1alpha1.AgentProblem]) {
+	a.problemStore = store
+}
+
+func (a *AgentServer) GetProblemsReport(
+	ctx context.Context, req *connect.Request[v1alpha1.GetProblemsReportRequest],
+) (*connect.Response[v1alpha1.GetProblemsReportResponse], error) {
+	if a.problemStore == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("problem reports are not enabled"))
+	}
+	var prefix string
+	if agentID := req.Msg.GetAgentId(); agentID != "" {
+		prefix = agentID + "/"
+	}
+	kvs, err := a.problemStore.ListPrefix(ctx, prefix)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list problems: %w", err))
+	}
+	resp := &v1alpha1.GetProblemsReportResponse{}
+	for _, kv := range kvs {
+		problem := kv.Value
+		if types := req.Msg.GetTypes(); len(types) > 0 && !slices.Contains(types, problem.GetType()) {
+			continue
+		}
+		resp.Problems = append(resp.Problems, problem)
+	}
+	slices.SortFunc(resp.Problems, func(x, y *v1alpha1.AgentProblem) int {
+		return cmp.Or(strings.Compare(x.GetAgentId(), y.GetAgentId()), cmp.Compare(x.GetType(), y.GetType()))
+	})
+	return connect.NewResponse(resp), nil
+}
+
+func (a *AgentServer) AcknowledgeProblem(
+	ctx context.Context, req *connect.Request[v1alpha1.AcknowledgeProblemRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	agentID, problemType := req.Msg.GetAgentId(), req.Msg.GetType()
+	if agentID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id must not be empty"))
+	}
+	if problemType == v1alpha1.AgentProblemType_AGENT_PROBLEM_TYPE_UNSPECIFIED {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("type must be specified"))
+	}
+	if a.problemStore == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("problem reports are not enabled"))
+	}
+	key := ProblemKey(agentID, problemType)
+	if _, err := a.problemStore.Get(ctx, key); grpcutil.IsErrorNotFound(err) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent %s has no open %s problem", agentID, problemType))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get problem: %w", err))
+	}
+	if err := a.problemStore.Delete(ctx, key); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to acknowledge problem: %w", err))
+	}
+	a.logger.With("agent_id", agentID, "type", problemType.String()).InfoContext(ctx, "acknowledged agent problem")
+	events.RecordAgentEvent(ctx, a.eventRecorder, a.repository, events.TypeAgentProblemAcknowledged, agentID,
+		fmt.Sprintf("%s problem acknowledged", problemType))
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// deleteProblems removes the problems of a purged agent
+func (a *AgentServer) deleteProblems(ctx context.Context, agentID string) error {
+	if a.problemStore == nil {
+		return nil
+	}
+	return a.problemStore.DeletePrefix(ctx, agentID+"/")
+}
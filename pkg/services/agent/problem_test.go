@@ -0,0 +1,93 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentServer_ProblemsReport(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	agent := env.NewAgent("problem-agent")
+	require.NoError(t, agent.Start())
+	agent.WaitForConfig(t, 5*time.Second)
+
+	problems := func(req *v1alpha1.GetProblemsReportRequest) []*v1alpha1.AgentProblem {
+		t.Helper()
+		resp, err := env.AgentServer.GetProblemsReport(ctx, connect.NewRequest(req))
+		require.NoError(t, err)
+		return resp.Msg.GetProblems()
+	}
+	pushes := func() []*v1alpha1.ConfigPush {
+		resp, err := env.AgentServer.ListConfigPushes(ctx, connect.NewRequest(&v1alpha1.ListConfigPushesRequest{AgentId: agent.ID}))
+		require.NoError(t, err)
+		return resp.Msg.GetPushes()
+	}
+	failedPushes := func() int {
+		n := 0
+		for _, push := range pushes() {
+			if push.GetState() == v1alpha1.ConfigPushState_CONFIG_PUSH_STATE_FAILED {
+				n++
+			}
+		}
+		return n
+	}
+
+	// the config failing to apply is reported once the failures reach the threshold
+	agent.AgentDriver.FailUpdates(errors.New("invalid pipeline"))
+	for i := 1; i <= supervisor.ConfigFailureThreshold; i++ {
+		assert.Empty(t, problems(&v1alpha1.GetProblemsReportRequest{}))
+		env.OpampServer.NotifyConfigChange(agent.ID)
+		env.WaitFor(t, 5*time.Second, func() bool { return failedPushes() == i }, "config push %d did not fail", i)
+	}
+	env.WaitFor(t, 5*time.Second, func() bool {
+		return len(problems(&v1alpha1.GetProblemsReportRequest{})) == 1
+	}, "problem was not reported")
+
+	problem := problems(&v1alpha1.GetProblemsReportRequest{AgentId: agent.ID})[0]
+	assert.Equal(t, agent.ID, problem.GetAgentId())
+	assert.Equal(t, v1alpha1.AgentProblemType_AGENT_PROBLEM_TYPE_CONFIG_APPLY_FAILING, problem.GetType())
+	assert.EqualValues(t, supervisor.ConfigFailureThreshold, problem.GetOccurrences())
+	assert.Contains(t, problem.GetMessage(), "invalid pipeline")
+	assert.EqualValues(t, 1, problem.GetReports())
+	assert.NotNil(t, problem.GetFirstReportedAt())
+
+	assert.Empty(t, problems(&v1alpha1.GetProblemsReportRequest{AgentId: "other"}))
+	assert.Empty(t, problems(&v1alpha1.GetProblemsReportRequest{
+		Types: []v1alpha1.AgentProblemType{v1alpha1.AgentProblemType_AGENT_PROBLEM_TYPE_DISK_NEARLY_FULL},
+	}))
+
+	// the problem stays open after the agent recovers, until it is acknowledged
+	agent.AgentDriver.FailUpdates(nil)
+	env.OpampServer.NotifyConfigChange(agent.ID)
+	env.WaitFor(t, 5*time.Second, func() bool {
+		all := pushes()
+		return all[len(all)-1].GetState() == v1alpha1.ConfigPushState_CONFIG_PUSH_STATE_APPLIED
+	}, "config was not applied")
+	assert.Len(t, problems(&v1alpha1.GetProblemsReportRequest{}), 1)
+
+	_, err := env.AgentServer.AcknowledgeProblem(ctx, connect.NewRequest(&v1alpha1.AcknowledgeProblemRequest{
+		AgentId: agent.ID,
+		Type:    v1alpha1.AgentProblemType_AGENT_PROBLEM_TYPE_CONFIG_APPLY_FAILING,
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, problems(&v1alpha1.GetProblemsReportRequest{}))
+
+	_, err = env.AgentServer.AcknowledgeProblem(ctx, connect.NewRequest(&v1alpha1.AcknowledgeProblemRequest{
+		AgentId: agent.ID,
+		Type:    v1alpha1.AgentProblemType_AGENT_PROBLEM_TYPE_CONFIG_APPLY_FAILING,
+	}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = env.AgentServer.AcknowledgeProblem(ctx, connect.NewRequest(&v1alpha1.AcknowledgeProblemRequest{AgentId: agent.ID}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
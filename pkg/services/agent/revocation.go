@@ -0,0 +1,41 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// RevokeAgent marks the agent as revoked and disconnects it if it is connected. The OpAMP
+// server rejects the connections of revoked agents, and they can't bootstrap again under the
+// same ID until they are purged.
+func (a *AgentServer) RevokeAgent(ctx context.Context, req *connect.Request[v1alpha1.RevokeAgentRequest]) (*connect.Response[emptypb.Empty], error) {
+	agentID := req.Msg.GetAgentId()
+	if agentID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id must not be empty"))
+	}
+	logger := a.logger.With("agent_id", agentID)
+
+	revoked, err := a.repository.Revoke(ctx, agentID, time.Now())
+	if errors.Is(err, agentdomain.ErrAgentNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+	} else if err != nil {
+		logger.With("err", err).ErrorContext(ctx, "failed to revoke agent")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to revoke agent: %w", err))
+	}
+	if !revoked {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("agent %s is already revoked", agentID))
+	}
+	a.disconnectAgent(ctx, agentID)
+	events.RecordAgentEvent(ctx, a.eventRecorder, a.repository, events.TypeAgentRevoked, agentID, "agent revoked, its connections are rejected")
+
+	logger.InfoContext(ctx, "agent revoked")
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
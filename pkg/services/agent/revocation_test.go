@@ -0,0 +1,67 @@
+package agent_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type revokedConn struct {
+	sent         []*protobufs.ServerToAgent
+	disconnected bool
+}
+
+func (c *revokedConn) Connection() net.Conn { return nil }
+
+func (c *revokedConn) Send(_ context.Context, msg *protobufs.ServerToAgent) error {
+	c.sent = append(c.sent, msg)
+	return nil
+}
+
+func (c *revokedConn) Disconnect() error {
+	c.disconnected = true
+	return nil
+}
+
+func TestAgentServer_RevokeAgent(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+	env.NewAgent("agent-1")
+
+	_, err := env.AgentServer.RevokeAgent(ctx, connect.NewRequest(&v1alpha1.RevokeAgentRequest{AgentId: "agent-1"}))
+	require.NoError(t, err)
+	get, err := env.AgentServer.GetAgent(ctx, connect.NewRequest(&v1alpha1.GetAgentRequest{AgentId: "agent-1"}))
+	require.NoError(t, err)
+	assert.NotNil(t, get.Msg.GetAgent().GetRevokedAt())
+
+	_, err = env.AgentServer.RevokeAgent(ctx, connect.NewRequest(&v1alpha1.RevokeAgentRequest{AgentId: "agent-1"}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	_, err = env.AgentServer.RevokeAgent(ctx, connect.NewRequest(&v1alpha1.RevokeAgentRequest{AgentId: "unknown"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = env.AgentServer.RevokeAgent(ctx, connect.NewRequest(&v1alpha1.RevokeAgentRequest{}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// the revoked agent is told it was rejected and disconnected
+	conn := &revokedConn{}
+	resp := env.OpampServer.OnMessage(ctx, conn, &protobufs.AgentToServer{
+		InstanceUid: []byte("agent-1"),
+		AgentDescription: &protobufs.AgentDescription{
+			IdentifyingAttributes: []*protobufs.KeyValue{{
+				Key:   supervisor.AttributeOtelfleetAgentId,
+				Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: "agent-1"}},
+			}},
+		},
+	})
+	assert.Nil(t, resp)
+	require.Len(t, conn.sent, 1)
+	assert.Equal(t, protobufs.ServerErrorResponseType_ServerErrorResponseType_BadRequest, conn.sent[0].GetErrorResponse().GetType())
+	assert.True(t, conn.disconnected)
+}
@@ -0,0 +1,278 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/ecdh"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/otelfleet/otelfleet/pkg/util"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultSnapshotRetention is how long snapshots are kept when no retention is configured
+	DefaultSnapshotRetention = 24 * time.Hour
+	// DefaultSnapshotMaxBytes is the default size cap of a snapshot archive
+	DefaultSnapshotMaxBytes = 4 << 20
+	// MaxSnapshotMaxBytes is the largest snapshot archive that can be requested
+	MaxSnapshotMaxBytes = 32 << 20
+
+	// snapshotTimeout is how long to wait for an agent to upload a requested snapshot
+	snapshotTimeout = 2 * time.Minute
+)
+
+// ErrAgentNotConnected is returned by a SnapshotRequester when the agent has no active connection
+var ErrAgentNotConnected = errors.New("agent is not connected")
+
+// SnapshotRequester delivers snapshot requests to connected agents.
+type SnapshotRequester interface {
+	RequestSnapshot(ctx context.Context, agentID string, req *v1alpha1.SnapshotRequest) error
+}
+
+// SetSnapshotRequester sets the transport used to request snapshots from agents
+func (a *AgentServer) SetSnapshotRequester(requester SnapshotRequester) {
+	a.snapshotRequester = requester
+}
+
+func (a *AgentServer) CaptureAgentSnapshot(
+	ctx context.Context, req *connect.Request[v1alpha1.CaptureAgentSnapshotRequest],
+) (*connect.Response[v1alpha1.CaptureAgentSnapshotResponse], error) {
+	agentID := req.Msg.GetAgentId()
+	if agentID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id must not be empty"))
+	}
+	maxBytes := req.Msg.GetMaxBytes()
+	if maxBytes <= 0 {
+		maxBytes = DefaultSnapshotMaxBytes
+	}
+	if maxBytes > MaxSnapshotMaxBytes {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("max_bytes must not exceed %d", MaxSnapshotMaxBytes))
+	}
+	if a.snapshotRequester == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("snapshots are not enabled"))
+	}
+	if exists, err := a.repository.Exists(ctx, agentID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get agent: %w", err))
+	} else if !exists {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+	}
+
+	now := time.Now()
+	snapshot := &v1alpha1.AgentSnapshot{
+		Id:          util.NewUUID(),
+		AgentId:     agentID,
+		State:       v1alpha1.AgentSnapshotState_AGENT_SNAPSHOT_STATE_PENDING,
+		RequestedAt: timestamppb.New(now),
+		ExpiresAt:   timestamppb.New(now.Add(a.snapshotRetention)),
+		MaxBytes:    maxBytes,
+	}
+	if err := a.snapshotStore.Put(ctx, snapshot.GetId(), snapshot); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to store snapshot: %w", err))
+	}
+
+	// the private key is only kept in memory, uploads for requests made before
+	// a restart cannot be decrypted and time out
+	ekp := ecdh.NewEphemeralKeyPair()
+	a.snapshotMu.Lock()
+	a.pendingSnapshots[snapshot.GetId()] = ekp
+	a.snapshotMu.Unlock()
+
+	err := a.snapshotRequester.RequestSnapshot(ctx, agentID, &v1alpha1.SnapshotRequest{
+		SnapshotId:   snapshot.GetId(),
+		ServerPubKey: ekp.PublicKey.Bytes(),
+		MaxBytes:     maxBytes,
+	})
+	if err != nil {
+		a.failSnapshot(ctx, snapshot.GetId(), err.Error())
+		if errors.Is(err, ErrAgentNotConnected) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("agent %s is not connected", agentID))
+		}
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("failed to request snapshot: %w", err))
+	}
+	a.logger.With("agent_id", agentID, "snapshot_id", snapshot.GetId()).InfoContext(ctx, "requested agent snapshot")
+	return connect.NewResponse(&v1alpha1.CaptureAgentSnapshotResponse{Snapshot: snapshot}), nil
+}
+
+func (a *AgentServer) GetAgentSnapshot(
+	ctx context.Context, req *connect.Request[v1alpha1.GetAgentSnapshotRequest],
+) (*connect.Response[v1alpha1.GetAgentSnapshotResponse], error) {
+	id := req.Msg.GetSnapshotId()
+	snapshot, err := a.snapshotStore.Get(ctx, id)
+	if grpcutil.IsErrorNotFound(err) || (err == nil && snapshotExpired(snapshot, time.Now())) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("snapshot not found: %s", id))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get snapshot: %w", err))
+	}
+	visible, err := a.snapshotVisible(ctx, snapshot)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if !visible {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("snapshot not found: %s", id))
+	}
+	return connect.NewResponse(&v1alpha1.GetAgentSnapshotResponse{Snapshot: snapshot}), nil
+}
+
+func (a *AgentServer) ListAgentSnapshots(
+	ctx context.Context, req *connect.Request[v1alpha1.ListAgentSnapshotsRequest],
+) (*connect.Response[v1alpha1.ListAgentSnapshotsResponse], error) {
+	all, err := a.snapshotStore.List(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list snapshots: %w", err))
+	}
+	now := time.Now()
+	resp := &v1alpha1.ListAgentSnapshotsResponse{}
+	for _, snapshot := range all {
+		if snapshot == nil || snapshotExpired(snapshot, now) {
+			continue
+		}
+		if agentID := req.Msg.GetAgentId(); agentID != "" && snapshot.GetAgentId() != agentID {
+			continue
+		}
+		visible, err := a.snapshotVisible(ctx, snapshot)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		if !visible {
+			continue
+		}
+		summary := proto.CloneOf(snapshot)
+		summary.Archive = nil
+		resp.Snapshots = append(resp.Snapshots, summary)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// snapshotVisible returns true if the agent of the snapshot is in the tenant of the request,
+// see package tenant. Snapshots don't record their tenant, the one of the agent is looked up.
+func (a *AgentServer) snapshotVisible(ctx context.Context, snapshot *v1alpha1.AgentSnapshot) (bool, error) {
+	if _, scoped := tenant.FromContext(ctx); !scoped {
+		return true, nil
+	}
+	visible, err := a.repository.Exists(ctx, snapshot.GetAgentId())
+	if err != nil {
+		return false, fmt.Errorf("failed to check agent %s: %w", snapshot.GetAgentId(), err)
+	}
+	return visible, nil
+}
+
+// ReceiveSnapshot decrypts and stores a snapshot uploaded by an agent.
+func (a *AgentServer) ReceiveSnapshot(ctx context.Context, agentID string, upload *v1alpha1.SnapshotUpload) {
+	id := upload.GetSnapshotId()
+	l := a.logger.With("agent_id", agentID, "snapshot_id", id)
+
+	snapshot, err := a.snapshotStore.Get(ctx, id)
+	if err != nil {
+		l.With("err", err).Warn("received upload for unknown snapshot")
+		return
+	}
+	if snapshot.GetAgentId() != agentID {
+		l.Warn("rejecting snapshot upload from another agent")
+		return
+	}
+	a.snapshotMu.Lock()
+	ekp, ok := a.pendingSnapshots[id]
+	delete(a.pendingSnapshots, id)
+	a.snapshotMu.Unlock()
+	if !ok {
+		l.Warn("received unexpected snapshot upload")
+		return
+	}
+	if upload.GetError() != "" {
+		a.failSnapshot(ctx, id, upload.GetError())
+		return
+	}
+	archive, err := openSnapshot(ekp, upload, snapshot.GetMaxBytes())
+	if err != nil {
+		l.With("err", err).Error("failed to open snapshot upload")
+		a.failSnapshot(ctx, id, err.Error())
+		return
+	}
+
+	snapshot.State = v1alpha1.AgentSnapshotState_AGENT_SNAPSHOT_STATE_READY
+	snapshot.CompletedAt = timestamppb.Now()
+	snapshot.Archive = archive
+	snapshot.SizeBytes = int64(len(archive))
+	snapshot.Truncated = upload.GetTruncated()
+	if err := a.snapshotStore.Put(ctx, id, snapshot); err != nil {
+		l.With("err", err).Error("failed to store snapshot")
+		return
+	}
+	l.With("size", len(archive), "truncated", snapshot.GetTruncated()).Info("stored agent snapshot")
+}
+
+// openSnapshot decrypts an upload with the key pair of the request it answers
+func openSnapshot(ekp ecdh.EphemeralKeyPair, upload *v1alpha1.SnapshotUpload, maxBytes int64) ([]byte, error) {
+	// gzip can slightly grow incompressible content, allow for it on top of the cap
+	if limit := maxBytes + maxBytes/64 + 4096; int64(len(upload.GetCiphertext())) > limit {
+		return nil, fmt.Errorf("snapshot exceeds %d bytes", maxBytes)
+	}
+	clientPubKey, err := ecdh.ClientPubKey(upload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid client public key: %w", err)
+	}
+	secret, err := ecdh.DeriveSharedSecret(ekp, clientPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive shared secret: %w", err)
+	}
+	archive, err := ecdh.Open(secret, upload.GetCiphertext())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt snapshot: %w", err)
+	}
+	return archive, nil
+}
+
+func (a *AgentServer) failSnapshot(ctx context.Context, id, reason string) {
+	a.snapshotMu.Lock()
+	delete(a.pendingSnapshots, id)
+	a.snapshotMu.Unlock()
+
+	snapshot, err := a.snapshotStore.Get(ctx, id)
+	if err != nil {
+		a.logger.With("err", err, "snapshot_id", id).Error("failed to get snapshot")
+		return
+	}
+	snapshot.State = v1alpha1.AgentSnapshotState_AGENT_SNAPSHOT_STATE_FAILED
+	snapshot.CompletedAt = timestamppb.Now()
+	snapshot.Error = reason
+	if err := a.snapshotStore.Put(ctx, id, snapshot); err != nil {
+		a.logger.With("err", err, "snapshot_id", id).Error("failed to store snapshot")
+	}
+}
+
+// pruneSnapshots deletes expired snapshots and fails those the agent never uploaded
+func (a *AgentServer) pruneSnapshots(ctx context.Context, now time.Time) error {
+	all, err := a.snapshotStore.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, snapshot := range all {
+		if snapshot == nil {
+			continue
+		}
+		switch {
+		case snapshotExpired(snapshot, now):
+			a.snapshotMu.Lock()
+			delete(a.pendingSnapshots, snapshot.GetId())
+			a.snapshotMu.Unlock()
+			if err := a.snapshotStore.Delete(ctx, snapshot.GetId()); err != nil {
+				return err
+			}
+		case snapshot.GetState() == v1alpha1.AgentSnapshotState_AGENT_SNAPSHOT_STATE_PENDING &&
+			now.Sub(snapshot.GetRequestedAt().AsTime()) > snapshotTimeout:
+			a.failSnapshot(ctx, snapshot.GetId(), "timed out waiting for the agent to upload the snapshot")
+		}
+	}
+	return nil
+}
+
+func snapshotExpired(snapshot *v1alpha1.AgentSnapshot, now time.Time) bool {
+	return !now.Before(snapshot.GetExpiresAt().AsTime())
+}
@@ -0,0 +1,141 @@
+package agent_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func archiveFiles(t *testing.T, archive []byte) []string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	names := []string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return names
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+	}
+}
+
+func TestAgentServer_CaptureAgentSnapshot(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	agent := env.NewAgent("snapshot-agent")
+	require.NoError(t, agent.Start())
+	agent.WaitForConfig(t, 5*time.Second)
+
+	resp, err := env.AgentServer.CaptureAgentSnapshot(ctx, connect.NewRequest(&v1alpha1.CaptureAgentSnapshotRequest{
+		AgentId: agent.ID,
+	}))
+	require.NoError(t, err)
+	id := resp.Msg.GetSnapshot().GetId()
+	assert.Equal(t, v1alpha1.AgentSnapshotState_AGENT_SNAPSHOT_STATE_PENDING, resp.Msg.GetSnapshot().GetState())
+
+	var snapshot *v1alpha1.AgentSnapshot
+	require.Eventually(t, func() bool {
+		get, err := env.AgentServer.GetAgentSnapshot(ctx, connect.NewRequest(&v1alpha1.GetAgentSnapshotRequest{SnapshotId: id}))
+		require.NoError(t, err)
+		snapshot = get.Msg.GetSnapshot()
+		return snapshot.GetState() != v1alpha1.AgentSnapshotState_AGENT_SNAPSHOT_STATE_PENDING
+	}, 10*time.Second, 50*time.Millisecond)
+
+	require.Equal(t, v1alpha1.AgentSnapshotState_AGENT_SNAPSHOT_STATE_READY, snapshot.GetState(), snapshot.GetError())
+	assert.Equal(t, agent.ID, snapshot.GetAgentId())
+	assert.EqualValues(t, len(snapshot.GetArchive()), snapshot.GetSizeBytes())
+	assert.True(t, snapshot.GetExpiresAt().AsTime().After(time.Now()))
+	names := archiveFiles(t, snapshot.GetArchive())
+	assert.Subset(t, names, []string{"config.hash", "processes.txt", "config/config.yaml", "supervisor.log"})
+
+	// snapshots are listed without their archive
+	list, err := env.AgentServer.ListAgentSnapshots(ctx, connect.NewRequest(&v1alpha1.ListAgentSnapshotsRequest{AgentId: agent.ID}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.GetSnapshots(), 1)
+	assert.Equal(t, id, list.Msg.GetSnapshots()[0].GetId())
+	assert.Empty(t, list.Msg.GetSnapshots()[0].GetArchive())
+}
+
+func TestAgentServer_CaptureAgentSnapshot_Errors(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.AgentServer.CaptureAgentSnapshot(ctx, connect.NewRequest(&v1alpha1.CaptureAgentSnapshotRequest{AgentId: "missing"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	agent := env.NewAgent("offline-agent")
+	_, err = env.AgentServer.CaptureAgentSnapshot(ctx, connect.NewRequest(&v1alpha1.CaptureAgentSnapshotRequest{AgentId: agent.ID}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	_, err = env.AgentServer.CaptureAgentSnapshot(ctx, connect.NewRequest(&v1alpha1.CaptureAgentSnapshotRequest{
+		AgentId:  agent.ID,
+		MaxBytes: 1 << 40,
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestAgentServer_GetAgentSnapshot_Expired(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	require.NoError(t, env.SnapshotStore.Put(ctx, "expired", &v1alpha1.AgentSnapshot{
+		Id:        "expired",
+		AgentId:   "agent",
+		State:     v1alpha1.AgentSnapshotState_AGENT_SNAPSHOT_STATE_READY,
+		ExpiresAt: timestamppb.New(time.Now().Add(-time.Minute)),
+	}))
+
+	_, err := env.AgentServer.GetAgentSnapshot(ctx, connect.NewRequest(&v1alpha1.GetAgentSnapshotRequest{SnapshotId: "expired"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	list, err := env.AgentServer.ListAgentSnapshots(ctx, connect.NewRequest(&v1alpha1.ListAgentSnapshotsRequest{}))
+	require.NoError(t, err)
+	assert.Empty(t, list.Msg.GetSnapshots())
+}
+
+func TestAgentServer_AgentSnapshot_Tenant(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+	require.NoError(t, env.AgentRepo.RegisterInTenant(ctx, "agent-a", "agent-a", "team-a"))
+	require.NoError(t, env.AgentRepo.RegisterInTenant(ctx, "agent-b", "agent-b", "team-b"))
+	for _, agentID := range []string{"agent-a", "agent-b"} {
+		require.NoError(t, env.SnapshotStore.Put(ctx, "snapshot-"+agentID, &v1alpha1.AgentSnapshot{
+			Id:        "snapshot-" + agentID,
+			AgentId:   agentID,
+			State:     v1alpha1.AgentSnapshotState_AGENT_SNAPSHOT_STATE_READY,
+			Archive:   []byte("archive"),
+			ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+		}))
+	}
+
+	// the snapshots of the agents of another tenant are neither listed nor found
+	teamA := tenant.NewContext(ctx, "team-a")
+	list, err := env.AgentServer.ListAgentSnapshots(teamA, connect.NewRequest(&v1alpha1.ListAgentSnapshotsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.GetSnapshots(), 1)
+	assert.Equal(t, "agent-a", list.Msg.GetSnapshots()[0].GetAgentId())
+	_, err = env.AgentServer.GetAgentSnapshot(teamA, connect.NewRequest(&v1alpha1.GetAgentSnapshotRequest{SnapshotId: "snapshot-agent-b"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	resp, err := env.AgentServer.GetAgentSnapshot(teamA, connect.NewRequest(&v1alpha1.GetAgentSnapshotRequest{SnapshotId: "snapshot-agent-a"}))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("archive"), resp.Msg.GetSnapshot().GetArchive())
+
+	list, err = env.AgentServer.ListAgentSnapshots(ctx, connect.NewRequest(&v1alpha1.ListAgentSnapshotsRequest{}))
+	require.NoError(t, err)
+	assert.Len(t, list.Msg.GetSnapshots(), 2)
+}
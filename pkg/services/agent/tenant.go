@@ -0,0 +1,43 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+)
+
+// NewTenantInterceptor returns an interceptor answering NotFound to the RPCs naming, in their
+// agent_id or agent_ids fields, agents of another tenant than the one of the request. The
+// repository doesn't find them, see package tenant, but not every handler reads the agents
+// through it. Unknown agents are left for the handlers to report.
+func NewTenantInterceptor(repo agentdomain.Repository) connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			var agentIDs []string
+			if msg, ok := req.Any().(interface{ GetAgentId() string }); ok && msg.GetAgentId() != "" {
+				agentIDs = append(agentIDs, msg.GetAgentId())
+			}
+			if msg, ok := req.Any().(interface{ GetAgentIds() []string }); ok {
+				agentIDs = append(agentIDs, msg.GetAgentIds()...)
+			}
+			for _, agentID := range agentIDs {
+				visible, err := repo.Exists(ctx, agentID)
+				if err != nil {
+					return nil, connect.NewError(connect.CodeInternal, err)
+				}
+				if visible {
+					continue
+				}
+				if exists, err := repo.Exists(tenant.Unscoped(ctx), agentID); err != nil {
+					return nil, connect.NewError(connect.CodeInternal, err)
+				} else if exists {
+					return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+				}
+			}
+			return next(ctx, req)
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	eventsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+)
+
+const (
+	defaultAgentEventsLimit = 100
+	maxAgentEventsLimit     = 1000
+)
+
+// AgentTimeline serves the timelines of agents, implemented by events.Log
+type AgentTimeline interface {
+	AgentEvents(ctx context.Context, agentID string, filter *eventsv1alpha1.EventFilter, pageToken string, limit int) ([]*eventsv1alpha1.Event, string, error)
+}
+
+var errTimelinesDisabled = connect.NewError(connect.CodeUnimplemented, errors.New("agent timelines are not enabled"))
+
+// SetAgentTimeline sets the source of the timelines served by GetAgentEvents
+func (a *AgentServer) SetAgentTimeline(timeline AgentTimeline) {
+	a.timeline = timeline
+}
+
+// SetEventRecorder sets the recorder for agent deletions
+func (a *AgentServer) SetEventRecorder(recorder events.Recorder) {
+	a.eventRecorder = recorder
+}
+
+// GetAgentEvents returns the timeline of an agent. Timelines outlive purged agents, so the
+// agent isn't required to exist.
+func (a *AgentServer) GetAgentEvents(ctx context.Context, req *connect.Request[v1alpha1.GetAgentEventsRequest]) (*connect.Response[v1alpha1.GetAgentEventsResponse], error) {
+	if a.timeline == nil {
+		return nil, errTimelinesDisabled
+	}
+	agentID := req.Msg.GetAgentId()
+	if agentID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id must not be empty"))
+	}
+	start, end := req.Msg.GetStart(), req.Msg.GetEnd()
+	if start != nil && end != nil && !start.AsTime().Before(end.AsTime()) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("start must be before end"))
+	}
+	limit := int(req.Msg.GetLimit())
+	if limit <= 0 {
+		limit = defaultAgentEventsLimit
+	}
+	limit = min(limit, maxAgentEventsLimit)
+
+	filter := &eventsv1alpha1.EventFilter{
+		Start: start,
+		End:   end,
+		Types: req.Msg.GetTypes(),
+	}
+	evs, next, err := a.timeline.AgentEvents(ctx, agentID, filter, req.Msg.GetPageToken(), limit)
+	if errors.Is(err, events.ErrInvalidPageToken) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list agent events: %w", err))
+	}
+	return connect.NewResponse(&v1alpha1.GetAgentEventsResponse{
+		Events:        evs,
+		NextPageToken: next,
+	}), nil
+}
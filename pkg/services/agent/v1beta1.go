@@ -0,0 +1,35 @@
+package agent
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1beta1"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1beta1/v1beta1connect"
+	"github.com/otelfleet/otelfleet/pkg/apiversion"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// agentServiceV1beta1 serves the v1beta1 agent API with the v1alpha1 handlers
+type agentServiceV1beta1 struct {
+	agents *AgentServer
+	shim   *apiversion.Shim
+}
+
+var _ v1beta1connect.AgentServiceHandler = (*agentServiceV1beta1)(nil)
+
+func (s *agentServiceV1beta1) ListAgents(ctx context.Context, req *connect.Request[v1beta1.ListAgentsRequest]) (*connect.Response[v1beta1.ListAgentsResponse], error) {
+	return apiversion.Unary[v1beta1.ListAgentsResponse](ctx, s.shim, req, s.agents.ListAgents)
+}
+
+func (s *agentServiceV1beta1) GetAgent(ctx context.Context, req *connect.Request[v1beta1.GetAgentRequest]) (*connect.Response[v1beta1.GetAgentResponse], error) {
+	return apiversion.Unary[v1beta1.GetAgentResponse](ctx, s.shim, req, s.agents.GetAgent)
+}
+
+func (s *agentServiceV1beta1) DeleteAgent(ctx context.Context, req *connect.Request[v1beta1.DeleteAgentRequest]) (*connect.Response[emptypb.Empty], error) {
+	return apiversion.Unary[emptypb.Empty](ctx, s.shim, req, s.agents.DeleteAgent)
+}
+
+func (s *agentServiceV1beta1) UndeleteAgent(ctx context.Context, req *connect.Request[v1beta1.UndeleteAgentRequest]) (*connect.Response[emptypb.Empty], error) {
+	return apiversion.Unary[emptypb.Empty](ctx, s.shim, req, s.agents.UndeleteAgent)
+}
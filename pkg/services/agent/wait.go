@@ -0,0 +1,130 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	eventsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+)
+
+const (
+	defaultWaitTimeout = 30 * time.Second
+	maxWaitTimeout     = 5 * time.Minute
+	// waitRecheckInterval bounds how long a change goes unnoticed when no event is seen for
+	// it, e.g. when the agent is connected to another replica
+	waitRecheckInterval = 2 * time.Second
+)
+
+// EventSubscriber subscribes to the fleet events recorded from now on, implemented by
+// events.Log. The channel is closed if the subscriber falls behind.
+type EventSubscriber interface {
+	Subscribe() (<-chan *eventsv1alpha1.Event, func())
+}
+
+// SetEventSubscriber sets the source of the events waking up WaitForAgentCondition calls,
+// which otherwise only check the agent's status periodically
+func (a *AgentServer) SetEventSubscriber(subscriber EventSubscriber) {
+	a.eventSubscriber = subscriber
+}
+
+func (a *AgentServer) WaitForAgentCondition(ctx context.Context, req *connect.Request[v1alpha1.WaitForAgentConditionRequest]) (*connect.Response[v1alpha1.WaitForAgentConditionResponse], error) {
+	agentID := req.Msg.GetAgentId()
+	condition := req.Msg.GetCondition()
+	switch {
+	case agentID == "":
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id must not be empty"))
+	case condition == v1alpha1.AgentCondition_AGENT_CONDITION_UNSPECIFIED:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("condition must be specified"))
+	case len(req.Msg.GetConfigHash()) > 0 && condition != v1alpha1.AgentCondition_AGENT_CONDITION_CONFIG_APPLIED:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("config_hash is only supported with AGENT_CONDITION_CONFIG_APPLIED"))
+	case req.Msg.GetTimeout().AsDuration() < 0:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("timeout must not be negative"))
+	}
+	timeout := defaultWaitTimeout
+	if req.Msg.Timeout != nil {
+		timeout = min(req.Msg.GetTimeout().AsDuration(), maxWaitTimeout)
+	}
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	recheck := time.NewTicker(waitRecheckInterval)
+	defer recheck.Stop()
+
+	// subscribe before checking, so that no change falls between the two
+	var events <-chan *eventsv1alpha1.Event
+	unsubscribe := func() {}
+	defer func() { unsubscribe() }()
+	if a.eventSubscriber != nil {
+		events, unsubscribe = a.eventSubscriber.Subscribe()
+	}
+
+	for {
+		agent, err := a.repository.GetView(ctx, agentID, agentdomain.StatusViewHealth)
+		if errors.Is(err, agentdomain.ErrAgentNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+		} else if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get agent: %w", err))
+		}
+		status := agentdomain.ToAPIStatus(agent)
+		if conditionHolds(status, condition, req.Msg.GetConfigHash()) {
+			return connect.NewResponse(&v1alpha1.WaitForAgentConditionResponse{Satisfied: true, Status: status}), nil
+		}
+
+	wait:
+		for {
+			select {
+			case <-ctx.Done():
+				return nil, connect.NewError(connect.CodeCanceled, ctx.Err())
+			case <-deadline.C:
+				return connect.NewResponse(&v1alpha1.WaitForAgentConditionResponse{Status: status}), nil
+			case <-recheck.C:
+				break wait
+			case ev, ok := <-events:
+				if !ok {
+					// fell behind, changes may have been missed
+					unsubscribe()
+					events, unsubscribe = a.eventSubscriber.Subscribe()
+					break wait
+				}
+				if ev.GetAgentId() == agentID {
+					break wait
+				}
+			}
+		}
+	}
+}
+
+// conditionHolds returns true if the status of the agent satisfies the condition
+func conditionHolds(status *v1alpha1.AgentStatus, condition v1alpha1.AgentCondition, configHash []byte) bool {
+	switch condition {
+	case v1alpha1.AgentCondition_AGENT_CONDITION_CONNECTED:
+		return status.GetState() == v1alpha1.AgentState_AGENT_STATE_CONNECTED
+	case v1alpha1.AgentCondition_AGENT_CONDITION_DISCONNECTED:
+		return status.GetState() != v1alpha1.AgentState_AGENT_STATE_CONNECTED
+	case v1alpha1.AgentCondition_AGENT_CONDITION_HEALTHY:
+		return status.GetHealth().GetHealthy()
+	case v1alpha1.AgentCondition_AGENT_CONDITION_CONFIG_APPLIED:
+		remote := status.GetRemoteConfigStatus()
+		if remote.GetStatus() != v1alpha1.RemoteConfigStatuses_REMOTE_CONFIG_STATUSES_APPLIED {
+			return false
+		}
+		if len(configHash) > 0 {
+			return bytes.Equal(remote.GetLastRemoteConfigHash(), configHash)
+		}
+		// the sync status is unknown when nothing is assigned and the agent runs the
+		// built-in config
+		switch status.GetConfigSyncStatus() {
+		case v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_IN_SYNC, v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_UNKNOWN:
+			return true
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
@@ -0,0 +1,96 @@
+package agent_test
+
+import (
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	eventsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestAgentServer_WaitForAgentCondition(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := t.Context()
+	log, err := events.NewLog(env.Logger, storage.NewProtoKV[*eventsv1alpha1.Event](env.Logger, env.Broker.KeyValue("events")), time.Hour)
+	require.NoError(t, err)
+	env.OpampServer.SetEventRecorder(log)
+	env.AgentServer.SetEventSubscriber(log)
+
+	wait := func(agentID string, condition v1alpha1.AgentCondition, hash []byte, timeout time.Duration) (*v1alpha1.WaitForAgentConditionResponse, error) {
+		resp, err := env.AgentServer.WaitForAgentCondition(ctx, connect.NewRequest(&v1alpha1.WaitForAgentConditionRequest{
+			AgentId:    agentID,
+			Condition:  condition,
+			ConfigHash: hash,
+			Timeout:    durationpb.New(timeout),
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	agent := env.NewAgent("waiting-agent")
+	resp, err := wait(agent.ID, v1alpha1.AgentCondition_AGENT_CONDITION_CONNECTED, nil, 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, resp.GetSatisfied())
+	assert.NotEqual(t, v1alpha1.AgentState_AGENT_STATE_CONNECTED, resp.GetStatus().GetState())
+
+	// the wait returns as soon as the agent connects
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		assert.NoError(t, agent.Start())
+	}()
+	resp, err = wait(agent.ID, v1alpha1.AgentCondition_AGENT_CONDITION_CONNECTED, nil, 10*time.Second)
+	require.NoError(t, err)
+	assert.True(t, resp.GetSatisfied())
+	assert.Equal(t, v1alpha1.AgentState_AGENT_STATE_CONNECTED, resp.GetStatus().GetState())
+
+	resp, err = wait(agent.ID, v1alpha1.AgentCondition_AGENT_CONDITION_CONFIG_APPLIED, nil, 10*time.Second)
+	require.NoError(t, err)
+	assert.True(t, resp.GetSatisfied())
+	assert.Equal(t, v1alpha1.RemoteConfigStatuses_REMOTE_CONFIG_STATUSES_APPLIED, resp.GetStatus().GetRemoteConfigStatus().GetStatus())
+
+	_, err = env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: "logs"},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {}")},
+	}))
+	require.NoError(t, err)
+	_, err = env.ConfigServer.AssignConfig(ctx, connect.NewRequest(&configv1alpha1.AssignConfigRequest{AgentId: agent.ID, ConfigId: "logs"}))
+	require.NoError(t, err)
+	assignment, err := env.ConfigAssignmentStore.Get(ctx, agent.ID)
+	require.NoError(t, err)
+	resp, err = wait(agent.ID, v1alpha1.AgentCondition_AGENT_CONDITION_CONFIG_APPLIED, assignment.GetConfigHash(), 10*time.Second)
+	require.NoError(t, err)
+	assert.True(t, resp.GetSatisfied())
+	assert.Equal(t, assignment.GetConfigHash(), resp.GetStatus().GetRemoteConfigStatus().GetLastRemoteConfigHash())
+	resp, err = wait(agent.ID, v1alpha1.AgentCondition_AGENT_CONDITION_CONFIG_APPLIED, nil, 10*time.Second)
+	require.NoError(t, err)
+	assert.True(t, resp.GetSatisfied())
+	assert.Equal(t, v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_IN_SYNC, resp.GetStatus().GetConfigSyncStatus())
+
+	resp, err = wait(agent.ID, v1alpha1.AgentCondition_AGENT_CONDITION_CONFIG_APPLIED, []byte("other"), 50*time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, resp.GetSatisfied())
+
+	// status changes are recorded as events
+	listed, err := events.NewEventServer(env.Logger, log).ListEvents(ctx, connect.NewRequest(&eventsv1alpha1.ListEventsRequest{
+		Filter: &eventsv1alpha1.EventFilter{AgentIds: []string{agent.ID}, Types: []string{events.TypeAgentConfigApplied}},
+	}))
+	require.NoError(t, err)
+	assert.NotEmpty(t, listed.Msg.GetEvents())
+
+	_, err = wait("", v1alpha1.AgentCondition_AGENT_CONDITION_CONNECTED, nil, time.Second)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = wait(agent.ID, v1alpha1.AgentCondition_AGENT_CONDITION_CONNECTED, []byte("hash"), time.Second)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = wait("missing", v1alpha1.AgentCondition_AGENT_CONDITION_CONNECTED, nil, time.Second)
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
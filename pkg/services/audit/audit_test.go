@@ -0,0 +1,183 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/mux"
+	agentsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/audit/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/audit/v1alpha1/v1alpha1connect"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestClassify(t *testing.T) {
+	for procedure, want := range map[string]string{
+		"/config.v1alpha1.ConfigService/PutConfig":              "config",
+		"/config.v1alpha1.ConfigService/AssignConfigByLabels":   "config",
+		"/config.v1alpha1.ConfigService/BatchAssignConfig":      "config",
+		"/config.v1alpha1.ConfigService/StartRollingDeployment": "deployment",
+		"/config.v1alpha1.ConfigService/PutAssignmentPolicy":    "assignment_policy",
+		"/bootstrap.v1alpha1.TokenService/CreateEnrollmentURL":  "enrollment_url",
+		"/agents.v1alpha1.AgentService/DeleteAgent":             "agent",
+		"/jobs.v1alpha1.JobService/CancelJob":                   "job",
+	} {
+		got, audited := classify(procedure)
+		assert.True(t, audited, procedure)
+		assert.Equal(t, want, got, procedure)
+	}
+	for _, procedure := range []string{
+		"/config.v1alpha1.ConfigService/GetConfig",
+		"/config.v1alpha1.ConfigService/ValidateConfigDetailed",
+		"/config.v1alpha1.ConfigService/SimulateDeployment",
+		"/agents.v1alpha1.AgentService/Status",
+		"/bootstrap.v1alpha1.BootstrapService/Bootstrap",
+		"/agents.v1alpha1.GatewayService/Relay",
+	} {
+		_, audited := classify(procedure)
+		assert.False(t, audited, procedure)
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	ctx := t.Context()
+	store := storage.NewProtoKV[*v1alpha1.AuditEntry](slog.Default(), memory.NewKVBroker().KeyValue("audit"))
+	log, err := NewLog(slog.Default(), store, time.Hour)
+	require.NoError(t, err)
+
+	interceptors := connect.WithInterceptors(NewInterceptor(log))
+	router := mux.NewRouter()
+	router.Handle("/config.v1alpha1.ConfigService/PutConfig", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/PutConfig",
+		func(context.Context, *connect.Request[configv1alpha1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error) {
+			return connect.NewResponse(&emptypb.Empty{}), nil
+		},
+		interceptors,
+	))
+	router.Handle("/agents.v1alpha1.AgentService/DeleteAgent", connect.NewUnaryHandler(
+		"/agents.v1alpha1.AgentService/DeleteAgent",
+		func(context.Context, *connect.Request[agentsv1alpha1.DeleteAgentRequest]) (*connect.Response[emptypb.Empty], error) {
+			return nil, connect.NewError(connect.CodePermissionDenied, errors.New("not allowed"))
+		},
+		interceptors,
+	))
+	router.Handle("/config.v1alpha1.ConfigService/GetConfig", connect.NewUnaryHandler(
+		"/config.v1alpha1.ConfigService/GetConfig",
+		func(context.Context, *connect.Request[configv1alpha1.ConfigReference]) (*connect.Response[configv1alpha1.Config], error) {
+			return connect.NewResponse(&configv1alpha1.Config{}), nil
+		},
+		interceptors,
+	))
+	NewAuditServer(slog.Default(), log, true).ConfigureHTTP(router)
+	srv := httptest.NewServer(auth.NewMiddleware(slog.Default(), auth.NewHeaderAuthenticator()).Wrap(router))
+	t.Cleanup(srv.Close)
+
+	as := func(user string, roles string) connect.ClientOption {
+		return connect.WithInterceptors(connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+			return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+				req.Header().Set(auth.HeaderUser, user)
+				req.Header().Set(auth.HeaderRoles, roles)
+				return next(ctx, req)
+			}
+		}))
+	}
+	putConfig := connect.NewClient[configv1alpha1.PutConfigRequest, emptypb.Empty](srv.Client(), srv.URL+"/config.v1alpha1.ConfigService/PutConfig", as("alice", ""))
+	deleteAgent := connect.NewClient[agentsv1alpha1.DeleteAgentRequest, emptypb.Empty](srv.Client(), srv.URL+"/agents.v1alpha1.AgentService/DeleteAgent", as("bob", ""))
+	getConfig := connect.NewClient[configv1alpha1.ConfigReference, configv1alpha1.Config](srv.Client(), srv.URL+"/config.v1alpha1.ConfigService/GetConfig", as("alice", ""))
+
+	_, err = putConfig.CallUnary(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{Ref: &configv1alpha1.ConfigReference{Id: "logs"}}))
+	require.NoError(t, err)
+	_, err = deleteAgent.CallUnary(ctx, connect.NewRequest(&agentsv1alpha1.DeleteAgentRequest{AgentId: "agent-1"}))
+	require.Error(t, err)
+	_, err = getConfig.CallUnary(ctx, connect.NewRequest(&configv1alpha1.ConfigReference{Id: "logs"}))
+	require.NoError(t, err)
+
+	admin := v1alpha1connect.NewAuditServiceClient(srv.Client(), srv.URL, as("root", auth.RoleAdmin))
+	list := func(filter *v1alpha1.EntryFilter) []*v1alpha1.AuditEntry {
+		resp, err := admin.ListEntries(ctx, connect.NewRequest(&v1alpha1.ListEntriesRequest{Filter: filter}))
+		require.NoError(t, err)
+		return resp.Msg.GetEntries()
+	}
+
+	entries := list(nil)
+	require.Len(t, entries, 2, "reads are not audited")
+	assert.Equal(t, "alice", entries[0].GetPrincipal())
+	assert.Equal(t, "/config.v1alpha1.ConfigService/PutConfig", entries[0].GetProcedure())
+	assert.Equal(t, "config", entries[0].GetResourceType())
+	assert.Equal(t, "logs", entries[0].GetResourceId())
+	assert.Empty(t, entries[0].GetCode())
+	assert.NotEmpty(t, entries[0].GetPeerAddr())
+	assert.Equal(t, "bob", entries[1].GetPrincipal())
+	assert.Equal(t, "agent", entries[1].GetResourceType())
+	assert.Equal(t, "agent-1", entries[1].GetResourceId())
+	assert.Equal(t, "permission_denied", entries[1].GetCode())
+	assert.Equal(t, "not allowed", entries[1].GetError())
+
+	assert.Len(t, list(&v1alpha1.EntryFilter{ResourceTypes: []string{"agent"}}), 1)
+	assert.Len(t, list(&v1alpha1.EntryFilter{Principals: []string{"alice"}}), 1)
+	assert.Empty(t, list(&v1alpha1.EntryFilter{Start: timestamppb.New(time.Now().Add(time.Minute))}))
+
+	// only admins read the audit log
+	_, err = v1alpha1connect.NewAuditServiceClient(srv.Client(), srv.URL, as("alice", "")).
+		ListEntries(ctx, connect.NewRequest(&v1alpha1.ListEntriesRequest{}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// paging
+	resp, err := admin.ListEntries(ctx, connect.NewRequest(&v1alpha1.ListEntriesRequest{Limit: 1}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.GetEntries(), 1)
+	resp, err = admin.ListEntries(ctx, connect.NewRequest(&v1alpha1.ListEntriesRequest{Limit: 1, PageToken: resp.Msg.GetNextPageToken()}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.GetEntries(), 1)
+	assert.Equal(t, "bob", resp.Msg.GetEntries()[0].GetPrincipal())
+
+	// the sequence continues after a restart, and expired entries are pruned
+	log, err = NewLog(slog.Default(), store, time.Hour)
+	require.NoError(t, err)
+	log.Record(ctx, &v1alpha1.AuditEntry{Procedure: "/jobs.v1alpha1.JobService/CancelJob", Time: timestamppb.New(time.Now().Add(time.Minute))})
+	require.NoError(t, log.prune(ctx, time.Now().Add(time.Hour+time.Second)))
+	all, err := log.since(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.EqualValues(t, 3, all[0].GetSequence())
+}
+
+func TestNewConfigChange(t *testing.T) {
+	target := configTarget{kind: ConfigKindConfig, id: "logs"}
+	before := []byte("exporters:\n  otlp:\n    headers:\n      key: ${vault:secret/otel#old}\n")
+	after := []byte("exporters:\n  otlp:\n    headers:\n      key: ${vault:secret/otel#new}\n    timeout: 5s\n")
+
+	change := newConfigChange(target, before, after)
+	assert.Equal(t, "config/logs", change.GetSubject())
+	assert.Len(t, change.GetOldHash(), 64)
+	assert.NotEqual(t, change.GetOldHash(), change.GetNewHash())
+	assert.Contains(t, change.GetDiff(), "+    timeout: 5s")
+	assert.NotContains(t, change.GetDiff(), "secret/otel", "secret references are redacted")
+	assert.False(t, change.GetDiffTruncated())
+
+	created := newConfigChange(configTarget{kind: ConfigKindDefault}, nil, after)
+	assert.Equal(t, "default_config", created.GetSubject())
+	assert.Empty(t, created.GetOldHash())
+	assert.NotEmpty(t, created.GetDiff())
+
+	rollout := newConfigChange(configTarget{kind: ConfigKindConfig, id: "logs", rollout: true}, nil, after)
+	assert.NotEmpty(t, rollout.GetNewHash())
+	assert.Empty(t, rollout.GetDiff(), "rollouts aren't diffed")
+
+	large := newConfigChange(target, nil, []byte(strings.Repeat("a: b\n", maxDiffBytes)))
+	assert.True(t, large.GetDiffTruncated())
+	assert.Len(t, large.GetDiff(), maxDiffBytes)
+}
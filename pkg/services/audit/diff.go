@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/otelfleet/otelfleet/pkg/api/audit/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/secrets"
+	"github.com/pmezard/go-difflib/difflib"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxDiffBytes bounds the diffs recorded in audit entries
+const maxDiffBytes = 8 << 10
+
+// ConfigKind is the kind of config changed by an operation
+type ConfigKind string
+
+const (
+	// ConfigKindConfig is a stored config, identified by its ID
+	ConfigKindConfig ConfigKind = "config"
+	// ConfigKindDefault is the global default config
+	ConfigKindDefault ConfigKind = "default_config"
+	// ConfigKindAgent is the config assigned to an agent, identified by the agent ID
+	ConfigKindAgent ConfigKind = "agent"
+)
+
+// ConfigSource returns the configs changed by operations, implemented by otelconfig.ConfigServer
+type ConfigSource interface {
+	// AuditedConfig returns the body of the config, nil if there is none
+	AuditedConfig(ctx context.Context, kind ConfigKind, id string) ([]byte, error)
+}
+
+// configTarget is the config changed by an operation
+type configTarget struct {
+	kind ConfigKind
+	id   string
+	// rollouts assign the config to agents whose previous config varies, it isn't diffed
+	rollout bool
+}
+
+func (t configTarget) subject() string {
+	if t.kind == ConfigKindDefault {
+		return string(t.kind)
+	}
+	return string(t.kind) + "/" + t.id
+}
+
+// configTargets returns the config changed by the config-affecting procedures from their request
+var configTargets = map[string]func(req protoreflect.Message) configTarget{
+	"/config.v1alpha1.ConfigService/PutConfig":      refTarget,
+	"/config.v1alpha1.ConfigService/SaveConfigEdit": refTarget,
+	"/config.v1alpha1.ConfigService/DeleteConfig": func(req protoreflect.Message) configTarget {
+		return configTarget{kind: ConfigKindConfig, id: stringField(req, "id")}
+	},
+	"/config.v1alpha1.ConfigService/SetDefaultConfig": func(protoreflect.Message) configTarget {
+		return configTarget{kind: ConfigKindDefault}
+	},
+	"/config.v1alpha1.ConfigService/AssignConfig":           agentTarget,
+	"/config.v1alpha1.ConfigService/UnassignConfig":         agentTarget,
+	"/config.v1alpha1.ConfigService/BatchAssignConfig":      rolloutTarget,
+	"/config.v1alpha1.ConfigService/AssignConfigByLabels":   rolloutTarget,
+	"/config.v1alpha1.ConfigService/StartRollingDeployment": rolloutTarget,
+}
+
+func refTarget(req protoreflect.Message) configTarget {
+	fd := req.Descriptor().Fields().ByName("ref")
+	return configTarget{kind: ConfigKindConfig, id: stringField(req.Get(fd).Message(), "id")}
+}
+
+func agentTarget(req protoreflect.Message) configTarget {
+	return configTarget{kind: ConfigKindAgent, id: stringField(req, "agent_id")}
+}
+
+func rolloutTarget(req protoreflect.Message) configTarget {
+	return configTarget{kind: ConfigKindConfig, id: stringField(req, "config_id"), rollout: true}
+}
+
+// configHash returns the hex encoded SHA-256 of the config, empty if there is none
+func configHash(body []byte) string {
+	if body == nil {
+		return ""
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// newConfigChange describes the change of the config of target from before to after. Secret
+// references are redacted from the diff, it is truncated to maxDiffBytes.
+func newConfigChange(target configTarget, before, after []byte) *v1alpha1.ConfigChange {
+	change := &v1alpha1.ConfigChange{
+		Subject: target.subject(),
+		OldHash: configHash(before),
+		NewHash: configHash(after),
+	}
+	if target.rollout || change.OldHash == change.NewHash {
+		return change
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(secrets.Redact(before))),
+		B:        difflib.SplitLines(string(secrets.Redact(after))),
+		FromFile: "before",
+		ToFile:   "after",
+		Context:  1,
+	})
+	if err != nil {
+		return change
+	}
+	if len(diff) > maxDiffBytes {
+		diff = diff[:maxDiffBytes]
+		change.DiffTruncated = true
+	}
+	// proto strings must be valid UTF-8, configs or the truncation may break it
+	change.Diff = strings.ToValidUTF8(diff, "")
+	return change
+}
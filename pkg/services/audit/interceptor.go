@@ -0,0 +1,206 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/audit/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/requestid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// mutationVerbs are stripped from the names of mutations to get the type of their resource
+var mutationVerbs = map[string]struct{}{
+	"Put": {}, "Create": {}, "Delete": {}, "Update": {}, "Set": {}, "Assign": {}, "Unassign": {},
+	"Batch": {}, "Cancel": {}, "Capture": {}, "Begin": {}, "Save": {}, "Pause": {}, "Resume": {},
+	"Rollback": {}, "Purge": {}, "Restart": {}, "Undelete": {}, "Start": {}, "Rolling": {}, "Acknowledge": {},
+}
+
+// unauditedServices are called by agents and gateways rather than operators
+var unauditedServices = map[string]struct{}{
+	"bootstrap.v1alpha1.BootstrapService": {},
+	"agents.v1alpha1.GatewayService":      {},
+}
+
+// Recorder records audit entries, implemented by Log
+type Recorder interface {
+	Record(ctx context.Context, entry *v1alpha1.AuditEntry)
+}
+
+// Interceptor audits the mutations of the management API handlers it intercepts
+type Interceptor struct {
+	recorder Recorder
+	// optional, config-affecting operations are audited with the change of their config
+	configSource ConfigSource
+}
+
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+// NewInterceptor records the calls to mutations in recorder, once they return. Mutations are
+// the procedures of the management APIs whose name doesn't start with a read verb, such as
+// Get or List.
+func NewInterceptor(recorder Recorder) *Interceptor {
+	return &Interceptor{recorder: recorder}
+}
+
+// SetConfigSource sets where the configs changed by config-affecting operations are read
+// from, before and after the operation. It must be called before the handlers serve.
+func (i *Interceptor) SetConfigSource(source ConfigSource) {
+	i.configSource = source
+}
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		resourceType, audited := classify(procedure)
+		if !audited {
+			return next(ctx, req)
+		}
+		msg, _ := req.Any().(proto.Message)
+		target, diffed := configTargets[procedure]
+		diffed = diffed && msg != nil && i.configSource != nil
+		var before []byte
+		if diffed && !target(msg.ProtoReflect()).rollout {
+			before = i.auditedConfig(ctx, target(msg.ProtoReflect()))
+		}
+
+		resp, err := next(ctx, req)
+		entry := &v1alpha1.AuditEntry{
+			Principal:    auth.SubjectFromContext(ctx),
+			Procedure:    procedure,
+			ResourceType: resourceType,
+			RequestId:    requestid.FromContext(ctx),
+			PeerAddr:     req.Peer().Addr,
+		}
+		if msg != nil {
+			entry.ResourceId = resourceID(msg.ProtoReflect(), resourceType)
+		}
+		if diffed && err == nil {
+			t := target(msg.ProtoReflect())
+			entry.ConfigChange = newConfigChange(t, before, i.auditedConfig(ctx, t))
+		}
+		if err != nil {
+			entry.Code = connect.CodeOf(err).String()
+			entry.Error = err.Error()
+			var connectErr *connect.Error
+			if errors.As(err, &connectErr) {
+				entry.Error = connectErr.Message()
+			}
+		}
+		i.recorder.Record(ctx, entry)
+		return resp, err
+	}
+}
+
+// auditedConfig returns the config of the target, nil if it has none or it can't be read
+func (i *Interceptor) auditedConfig(ctx context.Context, target configTarget) []byte {
+	body, err := i.configSource.AuditedConfig(context.WithoutCancel(ctx), target.kind, target.id)
+	if err != nil {
+		return nil
+	}
+	return body
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler doesn't audit streams, the management APIs only stream reads
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// classify returns the snake cased type of the resource changed by the procedure, e.g. config
+// for /config.v1alpha1.ConfigService/AssignConfigByLabels, and whether the procedure is audited
+func classify(procedure string) (string, bool) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(procedure, "/"), "/")
+	if !ok {
+		return "", false
+	}
+	if _, ok := unauditedServices[service]; ok {
+		return "", false
+	}
+	words := splitWords(method)
+	if len(words) == 0 || auth.ReadOnly(procedure) {
+		return "", false
+	}
+	for len(words) > 0 {
+		if _, ok := mutationVerbs[words[0]]; !ok {
+			break
+		}
+		words = words[1:]
+	}
+	for i, word := range words {
+		if word == "By" {
+			words = words[:i]
+			break
+		}
+	}
+	if len(words) == 0 {
+		// e.g. Restart, fall back to the service, e.g. agent for agents.v1alpha1.AgentService
+		_, name, _ := strings.Cut(service, ".")
+		_, name, _ = strings.Cut(name, ".")
+		words = splitWords(strings.TrimSuffix(name, "Service"))
+	}
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_"), true
+}
+
+// splitWords splits a camel cased name into its words, keeping acronyms whole, e.g.
+// CreateEnrollmentURL into Create, Enrollment and URL
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		if !unicode.IsUpper(runes[i]) {
+			continue
+		}
+		prevLower := !unicode.IsUpper(runes[i-1])
+		acronymEnd := i+1 < len(runes) && !unicode.IsUpper(runes[i+1]) && unicode.IsUpper(runes[i-1])
+		if prevLower || acronymEnd {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
+
+// resourceID returns the ID of the resource named by the request: its <resource type>_id, id,
+// or agent_id field, else the id field of its first message field setting one
+func resourceID(msg protoreflect.Message, resourceType string) string {
+	for _, name := range []string{resourceType + "_id", "id", "agent_id"} {
+		if id := stringField(msg, name); id != "" {
+			return id
+		}
+	}
+	fields := msg.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() || !msg.Has(fd) {
+			continue
+		}
+		if id := stringField(msg.Get(fd).Message(), "id"); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+func stringField(msg protoreflect.Message, name string) string {
+	fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+		return ""
+	}
+	return msg.Get(fd).String()
+}
@@ -0,0 +1,142 @@
+// Package audit records who called the mutations of the management APIs, when and on what
+// resource, retains the entries for a rolling window and serves them over the AuditService API.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/grafana/dskit/services"
+	"github.com/otelfleet/otelfleet/pkg/api/audit/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultRetention is how long audit entries are kept when no retention is configured
+const DefaultRetention = 90 * 24 * time.Hour
+
+// Log persists audit entries for the retention window
+type Log struct {
+	logger    *slog.Logger
+	store     storage.KeyValue[*v1alpha1.AuditEntry]
+	retention time.Duration
+
+	mu       sync.Mutex
+	sequence uint64
+
+	services.Service
+}
+
+// NewLog creates an audit Log, continuing the sequence of the entries already in store
+func NewLog(
+	logger *slog.Logger,
+	store storage.KeyValue[*v1alpha1.AuditEntry],
+	retention time.Duration,
+) (*Log, error) {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	keys, err := store.ListKeys(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	l := &Log{
+		logger:    logger,
+		store:     store,
+		retention: retention,
+	}
+	if len(keys) > 0 {
+		seq, err := strconv.ParseUint(keys[len(keys)-1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid audit entry key %s: %w", keys[len(keys)-1], err)
+		}
+		l.sequence = seq
+	}
+	l.Service = services.NewBasicService(nil, l.running, nil)
+	return l, nil
+}
+
+func (l *Log) running(ctx context.Context) error {
+	t := time.NewTicker(min(l.retention/10, time.Hour))
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			if err := l.prune(ctx, time.Now()); err != nil {
+				l.logger.With("err", err).Error("failed to prune audit entries")
+			}
+		}
+	}
+}
+
+// entryKey zero pads the sequence so that keys sort in sequence order
+func entryKey(sequence uint64) string {
+	return fmt.Sprintf("%020d", sequence)
+}
+
+// Record assigns the entry its sequence number and persists it
+func (l *Log) Record(ctx context.Context, entry *v1alpha1.AuditEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sequence++
+	entry.Sequence = l.sequence
+	if entry.Time == nil {
+		entry.Time = timestamppb.Now()
+	}
+	// the entry is recorded even if the call was cancelled
+	if err := l.store.Put(context.WithoutCancel(ctx), entryKey(entry.Sequence), entry); err != nil {
+		l.logger.With(
+			"err", err,
+			"procedure", entry.GetProcedure(),
+			"principal", entry.GetPrincipal(),
+		).Error("failed to persist audit entry")
+	}
+}
+
+// since returns the retained entries with a sequence greater than after, oldest first
+func (l *Log) since(ctx context.Context, after uint64) ([]*v1alpha1.AuditEntry, error) {
+	all, err := l.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := []*v1alpha1.AuditEntry{}
+	for _, entry := range all {
+		if entry != nil && entry.GetSequence() > after {
+			ret = append(ret, entry)
+		}
+	}
+	return ret, nil
+}
+
+// prune deletes entries older than the retention window
+func (l *Log) prune(ctx context.Context, now time.Time) error {
+	all, err := l.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	cutoff := now.Add(-l.retention)
+	pruned := 0
+	for _, entry := range all {
+		if entry == nil {
+			continue
+		}
+		if !entry.GetTime().AsTime().Before(cutoff) {
+			// entries are stored in sequence order
+			break
+		}
+		if err := l.store.Delete(ctx, entryKey(entry.GetSequence())); err != nil {
+			return err
+		}
+		pruned++
+	}
+	if pruned > 0 {
+		l.logger.With("count", pruned).Debug("pruned expired audit entries")
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/mux"
+	"github.com/otelfleet/otelfleet/pkg/api/audit/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/audit/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+)
+
+const (
+	defaultListLimit = 100
+	maxListLimit     = 1000
+)
+
+// AuditServer provides the audit API.
+// Its lifecycle is that of the underlying Log.
+type AuditServer struct {
+	logger       *slog.Logger
+	log          *Log
+	requireAdmin bool
+	interceptors []connect.Interceptor
+}
+
+var _ v1alpha1connect.AuditServiceHandler = (*AuditServer)(nil)
+
+// NewAuditServer creates a new AuditServer serving entries from log. When requireAdmin is
+// set, only callers with the admin role are allowed.
+func NewAuditServer(logger *slog.Logger, log *Log, requireAdmin bool) *AuditServer {
+	return &AuditServer{
+		logger:       logger,
+		log:          log,
+		requireAdmin: requireAdmin,
+	}
+}
+
+// AddInterceptors adds interceptors to the audit service handlers.
+// Must be called before ConfigureHTTP.
+func (a *AuditServer) AddInterceptors(interceptors ...connect.Interceptor) {
+	a.interceptors = append(a.interceptors, interceptors...)
+}
+
+func (a *AuditServer) ConfigureHTTP(mux *mux.Router) {
+	a.logger.Info("configuring routes")
+	v1alpha1connect.RegisterAuditServiceHandler(mux, a, connect.WithInterceptors(a.interceptors...))
+}
+
+func (a *AuditServer) authorize(ctx context.Context) error {
+	if a.requireAdmin && !auth.FromContext(ctx).IsAdmin() {
+		return connect.NewError(connect.CodePermissionDenied, errors.New("the admin role is required"))
+	}
+	return nil
+}
+
+func (a *AuditServer) ListEntries(
+	ctx context.Context, req *connect.Request[v1alpha1.ListEntriesRequest],
+) (*connect.Response[v1alpha1.ListEntriesResponse], error) {
+	if err := a.authorize(ctx); err != nil {
+		return nil, err
+	}
+	after, err := parseToken(req.Msg.GetPageToken())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page token: %w", err))
+	}
+	limit := int(req.Msg.GetLimit())
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	limit = min(limit, maxListLimit)
+
+	entries, err := a.log.since(ctx, after)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list audit entries: %w", err))
+	}
+	resp := &v1alpha1.ListEntriesResponse{}
+	for _, entry := range entries {
+		if !matches(req.Msg.GetFilter(), entry) {
+			continue
+		}
+		if len(resp.Entries) == limit {
+			resp.NextPageToken = formatToken(resp.Entries[limit-1].GetSequence())
+			break
+		}
+		resp.Entries = append(resp.Entries, entry)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func matches(filter *v1alpha1.EntryFilter, entry *v1alpha1.AuditEntry) bool {
+	if filter == nil {
+		return true
+	}
+	t := entry.GetTime().AsTime()
+	if filter.GetStart() != nil && t.Before(filter.GetStart().AsTime()) {
+		return false
+	}
+	if filter.GetEnd() != nil && !t.Before(filter.GetEnd().AsTime()) {
+		return false
+	}
+	if len(filter.GetResourceTypes()) > 0 && !slices.Contains(filter.GetResourceTypes(), entry.GetResourceType()) {
+		return false
+	}
+	if len(filter.GetPrincipals()) > 0 && !slices.Contains(filter.GetPrincipals(), entry.GetPrincipal()) {
+		return false
+	}
+	return true
+}
+
+// tokens are opaque to clients, but encode the sequence of the last entry seen
+func formatToken(sequence uint64) string {
+	return strconv.FormatUint(sequence, 10)
+}
+
+func parseToken(token string) (uint64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(token, 10, 64)
+}
@@ -0,0 +1,139 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/bootstrap"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+)
+
+// defaultAttemptWindow is how long a bootstrap attempt is considered in-flight
+const defaultAttemptWindow = 2 * time.Minute
+
+// maxAttemptWait bounds how long DeleteToken waits for in-flight bootstrap attempts. Attempts
+// fall out of the tracking window by then, so waiting longer only holds the request open.
+const maxAttemptWait = defaultAttemptWindow
+
+// bootstrapAttempt records an agent using a token to bootstrap
+type bootstrapAttempt struct {
+	AgentID    string    `json:"agent_id"`
+	Name       string    `json:"name"`
+	RemoteAddr string    `json:"remote_addr"`
+	Time       time.Time `json:"time"`
+}
+
+func (a bootstrapAttempt) String() string {
+	return fmt.Sprintf("agent %s (%s) from %s at %s", a.AgentID, a.Name, a.RemoteAddr, a.Time.Format(time.RFC3339))
+}
+
+// attemptTracker keeps a short-lived record of bootstrap attempts per token, so that deleting
+// a token in use by in-flight provisioning can be detected. Attempts are kept in memory, unless
+// a shared keyspace is set so that replicas sharing the storage see the attempts of each other.
+type attemptTracker struct {
+	window time.Duration
+
+	mu       sync.Mutex
+	attempts map[string][]bootstrapAttempt
+	// optional, keyed by token ID and attempt
+	shared storage.KV
+}
+
+func newAttemptTracker(window time.Duration) *attemptTracker {
+	return &attemptTracker{
+		window:   window,
+		attempts: map[string][]bootstrapAttempt{},
+	}
+}
+
+// tokenIDFromHeader normalizes the token presented by agents, which is either
+// the token ID or the full hex encoded token, to the token ID.
+func tokenIDFromHeader(token string) string {
+	if t, err := bootstrap.ParseHex(token); err == nil {
+		return t.HexID()
+	}
+	return strings.TrimSpace(token)
+}
+
+func attemptPrefix(tokenID string) string {
+	return tokenID + "/"
+}
+
+func attemptKey(tokenID string, attempt bootstrapAttempt) string {
+	return fmt.Sprintf("%s%020d/%s", attemptPrefix(tokenID), attempt.Time.UnixNano(), attempt.AgentID)
+}
+
+func (t *attemptTracker) record(ctx context.Context, tokenID string, attempt bootstrapAttempt) error {
+	if t.shared != nil {
+		data, err := json.Marshal(attempt)
+		if err != nil {
+			return err
+		}
+		return t.shared.Put(ctx, attemptKey(tokenID, attempt), data)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.attempts[tokenID] = append(t.pruneLocked(tokenID, attempt.Time), attempt)
+	return nil
+}
+
+// recent returns the attempts for tokenID within the tracking window
+func (t *attemptTracker) recent(ctx context.Context, tokenID string, now time.Time) ([]bootstrapAttempt, error) {
+	if t.shared != nil {
+		return t.recentShared(ctx, tokenID, now)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	recent := t.pruneLocked(tokenID, now)
+	if len(recent) == 0 {
+		delete(t.attempts, tokenID)
+		return nil, nil
+	}
+	t.attempts[tokenID] = recent
+	return append([]bootstrapAttempt(nil), recent...), nil
+}
+
+// recentShared lists the attempts for tokenID in the shared keyspace, deleting the ones that
+// fell out of the tracking window
+func (t *attemptTracker) recentShared(ctx context.Context, tokenID string, now time.Time) ([]bootstrapAttempt, error) {
+	entries, err := t.shared.ListPrefix(ctx, attemptPrefix(tokenID))
+	if err != nil {
+		return nil, err
+	}
+	var ret []bootstrapAttempt
+	for _, entry := range entries {
+		var a bootstrapAttempt
+		if err := json.Unmarshal(entry.Value, &a); err != nil || now.Sub(a.Time) >= t.window {
+			if err := t.shared.Delete(ctx, entry.Key); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		ret = append(ret, a)
+	}
+	return ret, nil
+}
+
+func (t *attemptTracker) forget(ctx context.Context, tokenID string) error {
+	if t.shared != nil {
+		return t.shared.DeletePrefix(ctx, attemptPrefix(tokenID))
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.attempts, tokenID)
+	return nil
+}
+
+func (t *attemptTracker) pruneLocked(tokenID string, now time.Time) []bootstrapAttempt {
+	var ret []bootstrapAttempt
+	for _, a := range t.attempts[tokenID] {
+		if now.Sub(a.Time) < t.window {
+			ret = append(ret, a)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,68 @@
+package bootstrap
+
+import (
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	v1alpha1bootstrap "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttemptTracker_Shared(t *testing.T) {
+	ctx := t.Context()
+	kv := memory.NewKVBroker().KeyValue("bootstrapattempts")
+	// trackers of two replicas sharing the storage
+	a, b := newAttemptTracker(time.Minute), newAttemptTracker(time.Minute)
+	a.shared, b.shared = kv, kv
+
+	now := time.Now()
+	require.NoError(t, a.record(ctx, "token", bootstrapAttempt{AgentID: "stale", Time: now.Add(-2 * time.Minute)}))
+	require.NoError(t, a.record(ctx, "token", bootstrapAttempt{AgentID: "agent", Time: now}))
+	require.NoError(t, a.record(ctx, "other", bootstrapAttempt{AgentID: "agent", Time: now}))
+
+	recent, err := b.recent(ctx, "token", now)
+	require.NoError(t, err)
+	require.Len(t, recent, 1)
+	assert.Equal(t, "agent", recent[0].AgentID)
+
+	// attempts out of the window are deleted
+	keys, err := kv.ListKeys(ctx)
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	require.NoError(t, b.forget(ctx, "token"))
+	recent, err = a.recent(ctx, "token", now)
+	require.NoError(t, err)
+	assert.Empty(t, recent)
+	recent, err = a.recent(ctx, "other", now)
+	require.NoError(t, err)
+	assert.Len(t, recent, 1)
+}
+
+func TestConsumeSingleUseToken_ForgetsAttempts(t *testing.T) {
+	ctx := t.Context()
+	broker := memory.NewKVBroker()
+	tokens := storage.NewProtoKV[*v1alpha1bootstrap.BootstrapToken](slog.Default(), broker.KeyValue("tokens"))
+	b := &BootstrapServer{
+		logger:     slog.Default(),
+		tokenStore: tokens,
+		attempts:   newAttemptTracker(time.Minute),
+	}
+	b.attempts.shared = broker.KeyValue("bootstrapattempts")
+	require.NoError(t, tokens.Put(ctx, "single", &v1alpha1bootstrap.BootstrapToken{ID: "single", SingleUse: true}))
+	require.NoError(t, tokens.Put(ctx, "reusable", &v1alpha1bootstrap.BootstrapToken{ID: "reusable"}))
+	for _, id := range []string{"single", "reusable"} {
+		require.NoError(t, b.attempts.record(ctx, id, bootstrapAttempt{AgentID: "agent", Time: time.Now()}))
+		require.NoError(t, b.consumeSingleUseToken(ctx, id))
+	}
+
+	keys, err := b.attempts.shared.ListKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.True(t, strings.HasPrefix(keys[0], attemptPrefix("reusable")))
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"crypto"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	cryptoecdh "crypto/ecdh"
@@ -17,21 +19,39 @@ import (
 	"github.com/grafana/dskit/services"
 	"github.com/lestrrat-go/jwx/jwa"
 	"github.com/lestrrat-go/jwx/jws"
+	"github.com/otelfleet/otelfleet/pkg/agentca"
 	v1alpha1bootstrap "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
 	bootstrapconnect "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1/v1alpha1connect"
+	bootstrapv1beta1connect "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1beta1/v1beta1connect"
 	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/apiversion"
+	"github.com/otelfleet/otelfleet/pkg/auth"
 	"github.com/otelfleet/otelfleet/pkg/bootstrap"
 	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
 	"github.com/otelfleet/otelfleet/pkg/ecdh"
+	"github.com/otelfleet/otelfleet/pkg/labels"
 	otelfleetsvc "github.com/otelfleet/otelfleet/pkg/services"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/spiffe"
 	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/otelfleet/otelfleet/pkg/util"
 	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const (
+	// DefaultTokenTTL is how long bootstrap tokens are valid unless configured otherwise
+	DefaultTokenTTL = 5 * time.Minute
+	// DefaultMaxTokenTTL bounds the TTL of bootstrap tokens unless configured otherwise
+	DefaultMaxTokenTTL = 30 * 24 * time.Hour
+)
+
 // for secure vs insecure implementations
 type Bootstrapper interface {
 	VerifyToken(context.Context, http.Header) (token string, err error)
@@ -47,9 +67,37 @@ type BootstrapServer struct {
 	services.Service
 
 	bootstrapper         Bootstrapper
+	spiffeAuth           *spiffe.Authenticator
+	agentCA              *agentca.Authority
+	attempts             *attemptTracker
+	gc                   *tokenGC
+	eventRecorder        events.Recorder
 	configStore          storage.KeyValue[*configv1alpha1.Config]
 	bootstrapConfigStore storage.KeyValue[*configv1alpha1.Config]
 	assignedConfigStore  storage.KeyValue[*configv1alpha1.Config]
+	// optional, record which config an agent was bootstrapped with
+	configAssignmentStore    storage.KeyValue[*configv1alpha1.ConfigAssignment]
+	bootstrapAssignmentStore storage.KeyValue[*configv1alpha1.ConfigAssignment]
+	// optional, agent ID -> DER of the last client certificate issued to the agent
+	agentCertStore storage.KV
+
+	// base URL of enrollment URLs and install scripts, unless set by the caller
+	externalURL string
+	// optional, OpAMP endpoint embedded in install scripts
+	opampURL string
+	// rules the labels of tokens are validated against
+	labelRules labels.Rules
+	// how long tokens created without a TTL are valid
+	tokenTTL time.Duration
+	// upper bound of the TTL of tokens
+	maxTokenTTL time.Duration
+
+	enrollMu sync.Mutex
+	// IDs of single-use tokens with a bootstrap in progress
+	claimedEnrollments map[string]struct{}
+	checkLimiter       *checkTokenLimiter
+
+	interceptors []connect.Interceptor
 }
 
 var _ otelfleetsvc.HTTPExtension = (*BootstrapServer)(nil)
@@ -66,6 +114,7 @@ func NewBootstrapServer(
 	bootstrapConfigStore storage.KeyValue[*configv1alpha1.Config],
 	assignedConfigStore storage.KeyValue[*configv1alpha1.Config],
 ) *BootstrapServer {
+	attempts := newAttemptTracker(defaultAttemptWindow)
 	b := &BootstrapServer{
 		tokenStore:           tokenStore,
 		privateKey:           privateKey,
@@ -75,21 +124,107 @@ func NewBootstrapServer(
 		configStore:          configStore,
 		bootstrapConfigStore: bootstrapConfigStore,
 		assignedConfigStore:  assignedConfigStore,
+		attempts:             attempts,
+		gc:                   newTokenGC(logger, tokenStore, attempts),
+		claimedEnrollments:   map[string]struct{}{},
+		checkLimiter:         newCheckTokenLimiter(),
+		tokenTTL:             DefaultTokenTTL,
+		maxTokenTTL:          DefaultMaxTokenTTL,
 	}
 
 	b.Service = services.NewBasicService(nil, b.running, nil)
 	return b
 }
 
+// SetAttemptStore keeps the bootstrap attempts DeleteToken checks in kv instead of in memory,
+// so that replicas sharing the storage see the attempts made through each other
+func (b *BootstrapServer) SetAttemptStore(kv storage.KV) {
+	b.attempts.shared = kv
+}
+
+// SetSPIFFEAuthenticator enables SVID based enrollment via the Enroll RPC
+func (b *BootstrapServer) SetSPIFFEAuthenticator(auth *spiffe.Authenticator) {
+	b.spiffeAuth = auth
+}
+
+// AgentCertificateKeyspace is the keyspace of the store set with SetAgentCertificateStore
+const AgentCertificateKeyspace = "agent-certificates"
+
+// SetAgentCertificateStore records the last client certificate issued to each agent in kv, so
+// that registered agents can only renew their certificate for the key they hold
+func (b *BootstrapServer) SetAgentCertificateStore(kv storage.KV) {
+	b.agentCertStore = kv
+}
+
+// SetAgentCA sets the CA issuing client certificates to agents that request one when they
+// bootstrap, which they authenticate their OpAMP connections with
+func (b *BootstrapServer) SetAgentCA(ca *agentca.Authority) {
+	b.agentCA = ca
+}
+
+// SetAssignmentStores sets the stores of config assignments and of the configs agents were
+// bootstrapped with, so that bootstrap configs take part in assignment precedence
+func (b *BootstrapServer) SetAssignmentStores(assignments, bootstrapAssignments storage.KeyValue[*configv1alpha1.ConfigAssignment]) {
+	b.configAssignmentStore = assignments
+	b.bootstrapAssignmentStore = bootstrapAssignments
+}
+
+// SetLabelRules sets the rules the labels of tokens are validated against, when they are
+// created and when agents bootstrap with them
+func (b *BootstrapServer) SetLabelRules(rules labels.Rules) {
+	b.labelRules = rules
+}
+
+// SetDefaultTokenTTL sets how long tokens created without a TTL are valid, 0 keeps
+// DefaultTokenTTL
+func (b *BootstrapServer) SetDefaultTokenTTL(ttl time.Duration) {
+	if ttl > 0 {
+		b.tokenTTL = ttl
+	}
+}
+
+// SetMaxTokenTTL sets the upper bound of the TTL of tokens, 0 keeps DefaultMaxTokenTTL
+func (b *BootstrapServer) SetMaxTokenTTL(ttl time.Duration) {
+	if ttl > 0 {
+		b.maxTokenTTL = ttl
+	}
+}
+
+// SetTokenGCInterval sets how often expired tokens are garbage collected, 0 keeps
+// DefaultTokenGCInterval
+func (b *BootstrapServer) SetTokenGCInterval(interval time.Duration) {
+	if interval > 0 {
+		b.gc.interval = interval
+	}
+}
+
+// SetEventRecorder sets the recorder for agent registration events
+func (b *BootstrapServer) SetEventRecorder(recorder events.Recorder) {
+	b.eventRecorder = recorder
+}
+
+// RegisterMetrics registers the metrics of the expired token garbage collection
+func (b *BootstrapServer) RegisterMetrics(reg prometheus.Registerer) {
+	reg.MustRegister(b.gc.collectors()...)
+}
+
 func (b *BootstrapServer) running(ctx context.Context) error {
-	<-ctx.Done()
+	b.gc.run(ctx)
 	return nil
 }
 
+// AddInterceptors adds interceptors to the token and bootstrap service handlers.
+// Must be called before ConfigureHTTP.
+func (b *BootstrapServer) AddInterceptors(interceptors ...connect.Interceptor) {
+	b.interceptors = append(b.interceptors, interceptors...)
+}
+
 func (b *BootstrapServer) ConfigureHTTP(mux *mux.Router) {
 	b.logger.Info("configuring routes")
-	bootstrapconnect.RegisterTokenServiceHandler(mux, b)
-	bootstrapconnect.RegisterBootstrapServiceHandler(mux, b)
+	bootstrapconnect.RegisterTokenServiceHandler(mux, b, connect.WithInterceptors(b.interceptors...))
+	bootstrapconnect.RegisterBootstrapServiceHandler(mux, b, connect.WithInterceptors(b.interceptors...))
+	// the shim runs the interceptors of the v1alpha1 handlers
+	bootstrapv1beta1connect.RegisterTokenServiceHandler(mux, &tokenServiceV1beta1{bootstrap: b, shim: apiversion.NewShim(b.interceptors...)})
 }
 
 func (b *BootstrapServer) CreateToken(ctx context.Context, connectReq *connect.Request[v1alpha1bootstrap.CreateTokenRequest]) (*connect.Response[v1alpha1bootstrap.BootstrapToken], error) {
@@ -97,30 +232,56 @@ func (b *BootstrapServer) CreateToken(ctx context.Context, connectReq *connect.R
 	if err := req.Validate(); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	if err := b.labelRules.Validate(req.GetLabels()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ttl := req.GetTTL().AsDuration()
+	if ttl == 0 {
+		ttl = b.tokenTTL
+	}
+	if ttl > b.maxTokenTTL {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("TTL must be at most %s", b.maxTokenTTL))
+	}
 	token := bootstrap.NewToken()
 	bT := token.ToBootstrapToken()
-	bT.TTL = req.TTL
-	bT.Expiry = timestamppb.New(time.Now().Add(time.Minute * 5))
+	bT.TTL = durationpb.New(ttl)
+	bT.Expiry = timestamppb.New(time.Now().Add(ttl))
 	bT.ConfigReference = req.ConfigReference
 	bT.Labels = req.Labels
+	if err := b.storeToken(ctx, token, bT); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(bT), nil
+}
+
+// storeToken persists a new bootstrap token in the tenant of the request, along with the
+// config it references
+func (b *BootstrapServer) storeToken(ctx context.Context, token *bootstrap.Token, bT *v1alpha1bootstrap.BootstrapToken) error {
+	bT.Audit = configv1alpha1.NewAuditInfo(auth.SubjectFromContext(ctx), time.Now())
+	bT.Tenant, _ = tenant.FromContext(ctx)
 	logger := b.logger.With("token", bT.GetID()).With("config-ref", bT.GetConfigReference())
 
-	if ref := req.GetConfigReference(); ref != "" {
+	if ref := bT.GetConfigReference(); ref != "" {
 		logger.Info("checking bootstrap token config reference")
 		config, err := b.configStore.Get(ctx, ref)
 		if err != nil {
-			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get associated config for ref %s : %s", ref, err))
+			return status.Error(codes.Internal, fmt.Sprintf("failed to get associated config for ref %s : %s", ref, err))
+		}
+		if !tenant.Visible(ctx, config.GetMetadata().GetTenant()) {
+			return status.Error(codes.NotFound, fmt.Sprintf("config not found: %s", ref))
 		}
 		logger.Info("persisting bootstrap config")
 		if err := b.bootstrapConfigStore.Put(ctx, token.EncodeToHex(), config); err != nil {
-			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to persist bootstrap config : %s", err))
+			return status.Error(codes.Internal, fmt.Sprintf("failed to persist bootstrap config : %s", err))
 		}
 	}
 	if err := b.tokenStore.Put(ctx, bT.GetID(), bT); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return status.Error(codes.Internal, err.Error())
 	}
-
-	return connect.NewResponse(bT), nil
+	events.RecordChange(ctx, b.eventRecorder, events.TypeTokenCreated, "bootstrap token created", map[string]string{
+		"token_id": bT.GetID(),
+	})
+	return nil
 }
 
 func (b *BootstrapServer) GetBootstrapConfig(ctx context.Context, connectReq *connect.Request[v1alpha1bootstrap.GetConfigRequest]) (*connect.Response[v1alpha1bootstrap.GetConfigResponse], error) {
@@ -138,7 +299,7 @@ func (b *BootstrapServer) GetBootstrapConfig(ctx context.Context, connectReq *co
 	), nil
 }
 
-func (b *BootstrapServer) ListTokens(ctx context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1bootstrap.ListTokenReponse], error) {
+func (b *BootstrapServer) ListTokens(ctx context.Context, req *connect.Request[v1alpha1bootstrap.ListTokensRequest]) (*connect.Response[v1alpha1bootstrap.ListTokenReponse], error) {
 	if b.tokenStore == nil {
 		panic("token store is nil")
 	}
@@ -147,16 +308,17 @@ func (b *BootstrapServer) ListTokens(ctx context.Context, _ *connect.Request[emp
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	now := time.Now()
+	resp := &v1alpha1bootstrap.ListTokenReponse{}
 	for _, token := range tokens {
 		b.logger.With("expire", token.Expiry.AsTime(), "now", now).Debug("token expiry check")
 		if token.Expiry.AsTime().Before(now) {
-			go b.gc(token.ID)
+			b.gc.enqueue(token.ID)
+		}
+		if tenant.Visible(ctx, token.GetTenant()) && req.Msg.GetFilter().Matches(token.GetAudit()) {
+			resp.Tokens = append(resp.Tokens, token)
 		}
 	}
 
-	resp := &v1alpha1bootstrap.ListTokenReponse{
-		Tokens: tokens,
-	}
 	return connect.NewResponse(resp), nil
 }
 
@@ -165,13 +327,92 @@ func (b *BootstrapServer) DeleteToken(ctx context.Context, connectReq *connect.R
 	if err := req.Validate(); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	b.logger.With("key", req.ID).Debug("deleting key")
+	logger := b.logger.With("key", req.ID)
+	if err := b.checkTokenTenant(ctx, req.ID); err != nil {
+		return nil, err
+	}
+
+	recent, err := b.attempts.recent(ctx, req.ID, time.Now())
+	if err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	if wait := min(req.GetWait().AsDuration(), maxAttemptWait); len(recent) > 0 && !req.GetForce() && wait > 0 {
+		logger.With("attempts", len(recent), "wait", wait).Info("waiting for in-flight bootstrap attempts to settle")
+		recent, err = b.waitForAttempts(ctx, req.ID, wait)
+		if ctx.Err() != nil {
+			return nil, status.Error(codes.Canceled, err.Error())
+		} else if err != nil {
+			return nil, grpcutil.ErrorInternal(err)
+		}
+	}
+	if len(recent) > 0 {
+		if !req.GetForce() {
+			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf(
+				"token %s was used by %d bootstrap attempt(s) in the last %s: %s; wait for provisioning to finish or delete with force",
+				req.ID, len(recent), b.attempts.window, describeAttempts(recent),
+			))
+		}
+		logger.With("attempts", describeAttempts(recent)).Warn("force deleting token with recent bootstrap attempts")
+	}
+
+	logger.Debug("deleting key")
 	if err := b.tokenStore.Delete(ctx, req.ID); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	if err := b.attempts.forget(ctx, req.ID); err != nil {
+		logger.With("err", err).Warn("failed to forget bootstrap attempts of deleted token")
+	}
+	events.RecordChange(ctx, b.eventRecorder, events.TypeTokenDeleted, "bootstrap token deleted", map[string]string{
+		"token_id": req.ID,
+	})
 	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
+// checkTokenTenant returns a NotFound error if the token is in another tenant than the one of
+// the request
+func (b *BootstrapServer) checkTokenTenant(ctx context.Context, tokenID string) error {
+	bT, err := b.tokenStore.Get(ctx, tokenID)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil
+	} else if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if !tenant.Visible(ctx, bT.GetTenant()) {
+		return status.Error(codes.NotFound, fmt.Sprintf("token not found: %s", tokenID))
+	}
+	return nil
+}
+
+// waitForAttempts waits up to wait for the recent bootstrap attempts of a token to
+// fall out of the tracking window, returning the attempts still in-flight.
+func (b *BootstrapServer) waitForAttempts(ctx context.Context, tokenID string, wait time.Duration) ([]bootstrapAttempt, error) {
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			return b.attempts.recent(ctx, tokenID, time.Now())
+		case <-ticker.C:
+			recent, err := b.attempts.recent(ctx, tokenID, time.Now())
+			if err != nil || len(recent) == 0 {
+				return recent, err
+			}
+		}
+	}
+}
+
+func describeAttempts(attempts []bootstrapAttempt) string {
+	desc := make([]string, 0, len(attempts))
+	for _, a := range attempts {
+		desc = append(desc, a.String())
+	}
+	return strings.Join(desc, ", ")
+}
+
 func (b *BootstrapServer) Signatures(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1bootstrap.SignatureResponse], error) {
 	signatures := map[string][]byte{}
 	tokenList, err := b.tokenStore.List(ctx)
@@ -214,48 +455,170 @@ func (b *BootstrapServer) Bootstrap(ctx context.Context, req *connect.Request[v1
 	if !ok {
 		return nil, grpcutil.ErrorInvalid(fmt.Errorf("can't access headers: no CallInfo for handler context"))
 	}
-	token, err := b.bootstrapper.VerifyToken(ctx, callInfo.RequestHeader())
-	if err != nil {
+	var (
+		token string
+		err   error
+	)
+	if signed, ok := enrollmentFromHeader(callInfo.RequestHeader()); ok {
+		token, err = b.claimEnrollment(ctx, signed)
+		if err != nil {
+			return nil, err
+		}
+		defer b.releaseEnrollment(tokenIDFromHeader(token))
+	} else {
+		token, err = b.bootstrapper.VerifyToken(ctx, callInfo.RequestHeader())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := b.checkTokenLabels(ctx, tokenIDFromHeader(token)); err != nil {
 		return nil, err
 	}
+	if err := b.attempts.record(ctx, tokenIDFromHeader(token), bootstrapAttempt{
+		AgentID:    req.Msg.GetClientId(),
+		Name:       req.Msg.GetName(),
+		RemoteAddr: req.Peer().Addr,
+		Time:       time.Now(),
+	}); err != nil {
+		b.logger.With("err", err).Warn("failed to record bootstrap attempt")
+	}
 
 	sharedSecret, ekp, err := b.bootstrapper.DeriveSharedSecret(req.Msg)
 	if err != nil {
 		return nil, grpcutil.ErrorInvalid(err)
 	}
+	csr, err := parseCertificateRequest(req.Msg)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenTenant, err := b.tokenTenant(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.checkExistingAgent(ctx, req.Msg.GetClientId(), tokenTenant, csr); err != nil {
+		return nil, err
+	}
 
-	if err := b.updateAgentDetails(ctx, req.Msg.GetClientId(), req.Msg.GetName(), token); err != nil {
+	if err := b.updateAgentDetails(ctx, req.Msg.GetClientId(), req.Msg.GetName(), token, tokenTenant); err != nil {
+		return nil, err
+	}
+	credentials, err := b.issueCredentials(ctx, req.Msg.GetClientId(), csr, sharedSecret)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.consumeSingleUseToken(ctx, tokenIDFromHeader(token)); err != nil {
 		return nil, err
 	}
 
-	b.logger.With("shared-secret", sharedSecret).Info("got shared secret")
 	return connect.NewResponse(
 		&v1alpha1bootstrap.BootstrapAuthResponse{
 			ServerPubKey: ekp.PublicKey.Bytes(),
+			Credentials:  credentials,
 		},
 	), nil
 }
 
-func (b *BootstrapServer) updateAgentDetails(
-	ctx context.Context,
-	agentID string,
-	name string,
-	token string,
-) error {
-	l := b.logger.With("agentID", agentID).With("friendly-name", name).With("token", token)
-	l.Info("bootstrap successful, persisting agent details")
+// checkTokenLabels validates the labels of a token again, the rules may have changed since
+// it was created
+func (b *BootstrapServer) checkTokenLabels(ctx context.Context, tokenID string) error {
+	bT, err := b.tokenStore.Get(ctx, tokenID)
+	if grpcutil.IsErrorNotFound(err) {
+		// tokens verified by the insecure bootstrapper may not be stored
+		return nil
+	} else if err != nil {
+		return grpcutil.ErrorInternal(err)
+	}
+	if err := b.labelRules.Validate(bT.GetLabels()); err != nil {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("bootstrap token %s: %w", tokenID, err))
+	}
+	return nil
+}
+
+// Enroll registers an agent authenticated by the SPIFFE ID of its X.509 SVID.
+// The SVID is verified by the spiffe middleware, so no bootstrap token is required.
+func (b *BootstrapServer) Enroll(ctx context.Context, req *connect.Request[v1alpha1bootstrap.EnrollRequest]) (*connect.Response[v1alpha1bootstrap.EnrollResponse], error) {
+	if b.spiffeAuth == nil {
+		return nil, grpcutil.Error(codes.Unimplemented, fmt.Errorf("spiffe enrollment is not enabled"))
+	}
+	id, ok := spiffe.FromContext(ctx)
+	if !ok {
+		return nil, grpcutil.Error(codes.Unauthenticated, fmt.Errorf("no valid SVID presented"))
+	}
 
-	// Check if agent exists using repository
-	exists, err := b.agentRepo.Exists(ctx, agentID)
+	agentID := spiffe.AgentID(id).UniqueIdentifier().UUID
+	name := req.Msg.GetName()
+	if name == "" {
+		name = id.String()
+	}
+
+	_, ekp, err := b.bootstrapper.DeriveSharedSecret(&v1alpha1bootstrap.BootstrapAuthRequest{
+		ClientId:     agentID,
+		Name:         name,
+		ClientPubKey: req.Msg.GetClientPubKey(),
+	})
 	if err != nil {
+		return nil, grpcutil.ErrorInvalid(err)
+	}
+
+	l := b.logger.With("agentID", agentID, "friendly-name", name, "spiffe-id", id.String())
+	if err := b.registerAgent(ctx, l, agentID, name, ""); err != nil {
+		return nil, err
+	}
+	l.Info("spiffe enrollment successful")
+
+	return connect.NewResponse(&v1alpha1bootstrap.EnrollResponse{
+		AgentId:      agentID,
+		SpiffeId:     id.String(),
+		ServerPubKey: ekp.PublicKey.Bytes(),
+	}), nil
+}
+
+// registerAgent registers the agent in the given tenant, unless it is already registered
+func (b *BootstrapServer) registerAgent(ctx context.Context, l *slog.Logger, agentID, name, tenant string) error {
+	revoked, err := b.agentRepo.IsRevoked(ctx, agentID)
+	exists := !errors.Is(err, agentdomain.ErrAgentNotFound)
+	if exists && err != nil {
 		return grpcutil.ErrorInternal(err)
 	}
+	if revoked {
+		l.Warn("rejecting bootstrap of revoked agent")
+		return grpcutil.Error(codes.PermissionDenied, fmt.Errorf("agent %s has been revoked", agentID))
+	}
 
 	if !exists {
 		l.Info("persisting agent details")
-		if err := b.agentRepo.Register(ctx, agentID, name); err != nil {
+		if err := b.agentRepo.RegisterInTenant(ctx, agentID, name, tenant); err != nil {
 			return grpcutil.ErrorInternal(err)
 		}
+		events.RecordAgentEvent(ctx, b.eventRecorder, b.agentRepo, events.TypeAgentRegistered, agentID, fmt.Sprintf("agent %s registered", name))
+	}
+	return nil
+}
+
+// tokenTenant returns the tenant of the token, which agents bootstrapping with it join
+func (b *BootstrapServer) tokenTenant(ctx context.Context, token string) (string, error) {
+	bT, err := b.tokenStore.Get(ctx, tokenIDFromHeader(token))
+	if grpcutil.IsErrorNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", grpcutil.ErrorInternal(fmt.Errorf("failed to get bootstrap token: %w", err))
+	}
+	return bT.GetTenant(), nil
+}
+
+func (b *BootstrapServer) updateAgentDetails(
+	ctx context.Context,
+	agentID string,
+	name string,
+	token string,
+	tokenTenant string,
+) error {
+	l := b.logger.With("agentID", agentID).With("friendly-name", name).With("token", token)
+	l.Info("bootstrap successful, persisting agent details")
+
+	if err := b.registerAgent(ctx, l, agentID, name, tokenTenant); err != nil {
+		return err
 	}
 
 	incomingConfig, err := b.bootstrapConfigStore.Get(ctx, token)
@@ -268,12 +631,27 @@ func (b *BootstrapServer) updateAgentDetails(
 	}
 
 	l.Info("agent has an assigned config")
+	assignment, err := b.bootstrapAssignment(ctx, agentID, token, incomingConfig)
+	if err != nil {
+		return grpcutil.ErrorInternal(err)
+	}
+	if assignment != nil && b.bootstrapAssignmentStore != nil {
+		// kept so the agent can fall back to it once other assignments no longer apply
+		if err := b.bootstrapAssignmentStore.Put(ctx, agentID, assignment); err != nil {
+			return grpcutil.ErrorInternal(fmt.Errorf("failed to record bootstrap assignment: %w", err))
+		}
+	}
 	_, configErr := b.assignedConfigStore.Get(ctx, agentID)
 	if grpcutil.IsErrorNotFound(configErr) {
 		l.Info("no config has been assigned to an agent yet, associating bootstrap config with agent")
 		if err := b.assignedConfigStore.Put(ctx, agentID, incomingConfig); err != nil {
 			return err
 		}
+		if assignment != nil && b.configAssignmentStore != nil {
+			if err := b.configAssignmentStore.Put(ctx, agentID, assignment); err != nil {
+				return grpcutil.ErrorInternal(fmt.Errorf("failed to record config assignment: %w", err))
+			}
+		}
 	} else if configErr != nil {
 		return grpcutil.ErrorInternal(fmt.Errorf("failed to check assigned config: %w", configErr))
 	}
@@ -281,16 +659,31 @@ func (b *BootstrapServer) updateAgentDetails(
 	return nil
 }
 
-func (b *BootstrapServer) gc(key string) {
-	b.logger.With("key", key).Debug("garbage collecting token")
-
-	go func() {
-		ctx, ca := context.WithTimeout(context.Background(), time.Minute)
-		defer ca()
-		if err := b.tokenStore.Delete(ctx, key); err != nil {
-			b.logger.With("key", key, "err", err).Error("failed to delete token")
-		}
-	}()
+// bootstrapAssignment returns the assignment of the token's config to the agent, or nil if
+// the token no longer exists to tell which config it references
+func (b *BootstrapServer) bootstrapAssignment(
+	ctx context.Context,
+	agentID string,
+	token string,
+	config *configv1alpha1.Config,
+) (*configv1alpha1.ConfigAssignment, error) {
+	bT, err := b.tokenStore.Get(ctx, tokenIDFromHeader(token))
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get bootstrap token: %w", err)
+	}
+	if bT.GetConfigReference() == "" {
+		return nil, nil
+	}
+	return &configv1alpha1.ConfigAssignment{
+		AgentId:    agentID,
+		ConfigId:   bT.GetConfigReference(),
+		Source:     configv1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP,
+		AssignedAt: timestamppb.Now(),
+		ConfigHash: util.HashAgentConfigMap(util.ProtoConfigToAgentConfigMap(config)),
+		Audit:      configv1alpha1.NewAuditInfo(auth.SubjectFromContext(ctx), time.Now()),
+	}, nil
 }
 
 type noopBootstrapper struct {
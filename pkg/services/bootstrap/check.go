@@ -0,0 +1,175 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	v1alpha1bootstrap "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/bootstrap"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	// checkTokenRate bounds the token checks per second of each peer, so that CheckToken
+	// can't be used to guess tokens
+	checkTokenRate  = 1
+	checkTokenBurst = 10
+	// checkTokenGlobalRate bounds the token checks per second of all peers together, as a
+	// ceiling for callers spreading their checks over many addresses
+	checkTokenGlobalRate  = 20
+	checkTokenGlobalBurst = 100
+	// maxCheckTokenPeers bounds the peers tracked. Idle peers are dropped once it is
+	// reached, and peers that don't fit are only limited by the global rate.
+	maxCheckTokenPeers = 10000
+)
+
+// checkTokenLimiter rate limits token checks per peer address, under a global ceiling
+type checkTokenLimiter struct {
+	global *rate.Limiter
+
+	mu    sync.Mutex
+	peers map[string]*peerLimiter
+}
+
+type peerLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newCheckTokenLimiter() *checkTokenLimiter {
+	return &checkTokenLimiter{
+		global: rate.NewLimiter(checkTokenGlobalRate, checkTokenGlobalBurst),
+		peers:  map[string]*peerLimiter{},
+	}
+}
+
+// Allow reports whether the peer at addr may check a token now
+func (l *checkTokenLimiter) Allow(addr string, now time.Time) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	l.mu.Lock()
+	peer, ok := l.peers[host]
+	if !ok {
+		if len(l.peers) >= maxCheckTokenPeers {
+			l.pruneLocked(now)
+		}
+		// with every tracked peer active, new peers are only bounded by the global limit
+		if len(l.peers) >= maxCheckTokenPeers {
+			l.mu.Unlock()
+			return l.global.AllowN(now, 1)
+		}
+		peer = &peerLimiter{limiter: rate.NewLimiter(checkTokenRate, checkTokenBurst)}
+		l.peers[host] = peer
+	}
+	peer.lastSeen = now
+	allowed := peer.limiter.AllowN(now, 1)
+	l.mu.Unlock()
+	return allowed && l.global.AllowN(now, 1)
+}
+
+// pruneLocked drops the peers whose limiter refilled since they were last seen, which are
+// limited the same as new peers
+func (l *checkTokenLimiter) pruneLocked(now time.Time) {
+	refill := time.Duration(checkTokenBurst/checkTokenRate) * time.Second
+	for host, peer := range l.peers {
+		if now.Sub(peer.lastSeen) >= refill {
+			delete(l.peers, host)
+		}
+	}
+}
+
+// CheckToken verifies the token presented in the Authorization header the way Bootstrap
+// does, without consuming a use of the token or registering an agent. Every check is
+// audit logged.
+func (b *BootstrapServer) CheckToken(ctx context.Context, req *connect.Request[v1alpha1bootstrap.CheckTokenRequest]) (*connect.Response[v1alpha1bootstrap.CheckTokenResponse], error) {
+	logger := b.logger.With("audit", true, "remote_addr", req.Peer().Addr)
+	if !b.checkLimiter.Allow(req.Peer().Addr, time.Now()) {
+		logger.WarnContext(ctx, "bootstrap token check rate limited")
+		return nil, connect.NewError(connect.CodeResourceExhausted, errors.New("too many token checks, retry later"))
+	}
+	resp, err := b.checkToken(ctx, req.Header(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	logger.With("token", resp.GetTokenID(), "valid", resp.GetValid(), "reason", resp.GetReason()).InfoContext(ctx, "bootstrap token checked")
+	return connect.NewResponse(resp), nil
+}
+
+// checkToken reports whether an agent presenting the headers could bootstrap at the given time
+func (b *BootstrapServer) checkToken(ctx context.Context, headers http.Header, now time.Time) (*v1alpha1bootstrap.CheckTokenResponse, error) {
+	invalid := func(reason string) (*v1alpha1bootstrap.CheckTokenResponse, error) {
+		return &v1alpha1bootstrap.CheckTokenResponse{Reason: reason}, nil
+	}
+
+	var bT *v1alpha1bootstrap.BootstrapToken
+	if signed, ok := enrollmentFromHeader(headers); ok {
+		enrollment, err := bootstrap.ParseEnrollment(signed)
+		if err != nil {
+			return invalid(err.Error())
+		}
+		bT, err = b.tokenStore.Get(ctx, enrollment.ID)
+		if grpcutil.IsErrorNotFound(err) {
+			return invalid("enrollment token was already used or deleted")
+		} else if err != nil {
+			return nil, grpcutil.ErrorInternal(err)
+		}
+		token, err := bootstrap.FromBootstrapToken(bT)
+		if err != nil {
+			return nil, grpcutil.ErrorInternal(err)
+		}
+		if !bT.GetSingleUse() {
+			return invalid(bootstrap.ErrInvalidEnrollmentMAC.Error())
+		}
+		if err := token.VerifyEnrollment(enrollment, now); err != nil {
+			return invalid(err.Error())
+		}
+		b.enrollMu.Lock()
+		_, claimed := b.claimedEnrollments[enrollment.ID]
+		b.enrollMu.Unlock()
+		if claimed {
+			return invalid("enrollment token is already in use")
+		}
+	} else {
+		token, err := b.bootstrapper.VerifyToken(ctx, headers)
+		if err != nil {
+			return invalid("token verification failed")
+		}
+		bT, err = b.tokenStore.Get(ctx, tokenIDFromHeader(token))
+		if grpcutil.IsErrorNotFound(err) {
+			return invalid("token not found")
+		} else if err != nil {
+			return nil, grpcutil.ErrorInternal(err)
+		}
+	}
+	if bT.Expiry != nil && !bT.GetExpiry().AsTime().After(now) {
+		return invalid("token expired")
+	}
+	if err := b.labelRules.Validate(bT.GetLabels()); err != nil {
+		return invalid(err.Error())
+	}
+
+	resp := &v1alpha1bootstrap.CheckTokenResponse{
+		Valid:           true,
+		TokenID:         bT.GetID(),
+		Expiry:          bT.GetExpiry(),
+		ConfigReference: bT.ConfigReference,
+		Labels:          bT.GetLabels(),
+	}
+	if bT.Expiry != nil {
+		resp.RemainingTTL = durationpb.New(bT.GetExpiry().AsTime().Sub(now))
+	}
+	if bT.GetSingleUse() {
+		remaining := int32(1)
+		resp.RemainingUses = &remaining
+	}
+	return resp, nil
+}
@@ -0,0 +1,42 @@
+package bootstrap
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTokenLimiter(t *testing.T) {
+	l := newCheckTokenLimiter()
+	now := time.Now()
+	for range checkTokenBurst {
+		assert.True(t, l.Allow("10.0.0.1:1234", now))
+	}
+	// the peer is limited on every port, other peers aren't
+	assert.False(t, l.Allow("10.0.0.1:4321", now))
+	assert.True(t, l.Allow("10.0.0.2:1234", now))
+	assert.True(t, l.Allow("10.0.0.1:1234", now.Add(time.Second/checkTokenRate)))
+
+	// peers together are bounded by the global limit
+	l = newCheckTokenLimiter()
+	var allowed int
+	for i := range 2 * checkTokenGlobalBurst {
+		if l.Allow(fmt.Sprintf("10.0.%d.%d:1234", i/256, i%256), now) {
+			allowed++
+		}
+	}
+	assert.Equal(t, checkTokenGlobalBurst, allowed)
+
+	// peers rotating addresses faster than they go idle don't grow the tracked peers
+	// past the cap
+	l = newCheckTokenLimiter()
+	for i := range maxCheckTokenPeers + 100 {
+		l.Allow(fmt.Sprintf("10.%d.%d.%d:1234", i/65536, i/256%256, i%256), now)
+	}
+	assert.Len(t, l.peers, maxCheckTokenPeers)
+	assert.False(t, l.Allow("192.168.0.1:1234", now), "untracked peers are limited globally")
+	assert.True(t, l.Allow("192.168.0.1:1234", now.Add(time.Second)))
+	assert.Len(t, l.peers, maxCheckTokenPeers)
+}
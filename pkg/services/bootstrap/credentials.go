@@ -0,0 +1,123 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	v1alpha1bootstrap "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/ecdh"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/proto"
+)
+
+// parseCertificateRequest returns the certificate request of a bootstrap request, or nil if
+// the agent didn't request a client certificate
+func parseCertificateRequest(req *v1alpha1bootstrap.BootstrapAuthRequest) (*x509.CertificateRequest, error) {
+	if len(req.GetCertificateRequest()) == 0 {
+		return nil, nil
+	}
+	csr, err := x509.ParseCertificateRequest(req.GetCertificateRequest())
+	if err != nil {
+		return nil, grpcutil.ErrorInvalid(fmt.Errorf("invalid certificate request: %w", err))
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, grpcutil.ErrorInvalid(fmt.Errorf("invalid certificate request signature: %w", err))
+	}
+	return csr, nil
+}
+
+// checkExistingAgent refuses to bootstrap an agent ID registered in another tenant than the
+// token's, and to issue a registered agent a client certificate for another key than the one
+// of its current certificate, which the signature of the certificate request proves the caller
+// holds. Agents that lost their key must be deleted before they bootstrap again.
+func (b *BootstrapServer) checkExistingAgent(ctx context.Context, agentID, tokenTenant string, csr *x509.CertificateRequest) error {
+	existing, err := b.agentRepo.GetView(tenant.Unscoped(ctx), agentID, agentdomain.StatusViewBasic)
+	if errors.Is(err, agentdomain.ErrAgentNotFound) {
+		return nil
+	} else if err != nil {
+		return grpcutil.ErrorInternal(err)
+	}
+	l := b.logger.With("agentID", agentID)
+	if existing.Tenant != tokenTenant {
+		l.With("tenant", existing.Tenant, "token_tenant", tokenTenant).Warn("rejecting bootstrap of an agent registered in another tenant")
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("agent %s is registered in another tenant than the bootstrap token", agentID))
+	}
+	if csr == nil || b.agentCA == nil {
+		return nil
+	}
+	current, err := b.currentCertificate(ctx, agentID)
+	if err != nil {
+		return err
+	}
+	if current == nil || !publicKeyEqual(current.PublicKey, csr.PublicKey) {
+		l.Warn("rejecting certificate request of a registered agent for another key than its current one")
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf(
+			"agent %s is already registered, its client certificate can only be renewed for the key of its current certificate", agentID,
+		))
+	}
+	return nil
+}
+
+// currentCertificate returns the last client certificate issued to the agent, or nil if none
+// was recorded
+func (b *BootstrapServer) currentCertificate(ctx context.Context, agentID string) (*x509.Certificate, error) {
+	if b.agentCertStore == nil {
+		return nil, nil
+	}
+	der, err := b.agentCertStore.Get(ctx, agentID)
+	if storage.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, grpcutil.ErrorInternal(fmt.Errorf("invalid client certificate of agent %s: %w", agentID, err))
+	}
+	return cert, nil
+}
+
+func publicKeyEqual(a, b crypto.PublicKey) bool {
+	key, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && key.Equal(b)
+}
+
+// issueCredentials issues the client certificate the agent requested and seals it with the
+// shared secret, so that only the party of the key agreement can use the response
+func (b *BootstrapServer) issueCredentials(ctx context.Context, agentID string, csr *x509.CertificateRequest, sharedSecret []byte) ([]byte, error) {
+	if csr == nil {
+		return nil, nil
+	}
+	if b.agentCA == nil {
+		b.logger.With("agentID", agentID).Debug("no agent CA configured, not issuing a client certificate")
+		return nil, nil
+	}
+	der, err := b.agentCA.Issue(agentID, csr.PublicKey)
+	if err != nil {
+		return nil, grpcutil.ErrorInternal(fmt.Errorf("failed to issue client certificate: %w", err))
+	}
+	if b.agentCertStore != nil {
+		if err := b.agentCertStore.Put(ctx, agentID, der); err != nil {
+			return nil, grpcutil.ErrorInternal(fmt.Errorf("failed to record client certificate: %w", err))
+		}
+	}
+	data, err := proto.Marshal(&v1alpha1bootstrap.BootstrapCredentials{
+		Certificate:   der,
+		CaCertificate: b.agentCA.Certificate().Raw,
+	})
+	if err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	sealed, err := ecdh.Seal(sharedSecret, data)
+	if err != nil {
+		return nil, grpcutil.ErrorInternal(fmt.Errorf("failed to seal credentials: %w", err))
+	}
+	return sealed, nil
+}
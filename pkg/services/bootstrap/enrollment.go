@@ -0,0 +1,138 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	v1alpha1bootstrap "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/bootstrap"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultEnrollmentTTL is how long enrollment URLs are valid unless requested otherwise
+	DefaultEnrollmentTTL = time.Hour
+	maxEnrollmentTTL     = 7 * 24 * time.Hour
+)
+
+// SetExternalURL sets the base URL agents reach the server at, used for enrollment URLs
+func (b *BootstrapServer) SetExternalURL(url string) {
+	b.externalURL = url
+}
+
+func (b *BootstrapServer) CreateEnrollmentURL(ctx context.Context, req *connect.Request[v1alpha1bootstrap.CreateEnrollmentURLRequest]) (*connect.Response[v1alpha1bootstrap.EnrollmentURL], error) {
+	ttl := req.Msg.GetTTL().AsDuration()
+	if ttl == 0 {
+		ttl = DefaultEnrollmentTTL
+	}
+	if ttl < 0 || ttl > maxEnrollmentTTL {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("TTL must be positive and at most %s", maxEnrollmentTTL))
+	}
+	if err := b.labelRules.Validate(req.Msg.GetLabels()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	baseURL := req.Msg.GetBaseURL()
+	if baseURL == "" {
+		baseURL = b.externalURL
+	}
+	if baseURL == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("baseURL must be set when the server has no external URL configured"))
+	}
+
+	// enrollment tokens expire on second boundaries
+	expiry := time.Now().Add(ttl).Truncate(time.Second)
+	token := bootstrap.NewToken()
+	bT := token.ToBootstrapToken()
+	bT.Expiry = timestamppb.New(expiry)
+	bT.ConfigReference = req.Msg.ConfigReference
+	bT.Labels = req.Msg.GetLabels()
+	bT.SingleUse = true
+	if err := b.storeToken(ctx, token, bT); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&v1alpha1bootstrap.EnrollmentURL{
+		Url:     bootstrap.EnrollmentURL(baseURL, token.SignEnrollment(expiry)),
+		TokenID: bT.GetID(),
+		Expiry:  bT.GetExpiry(),
+	}), nil
+}
+
+// enrollmentFromHeader returns the signed enrollment token presented in the Authorization header
+func enrollmentFromHeader(headers http.Header) (string, bool) {
+	scheme, signed, ok := strings.Cut(strings.TrimSpace(headers.Get("Authorization")), " ")
+	if !ok || scheme != bootstrap.EnrollmentScheme {
+		return "", false
+	}
+	return strings.TrimSpace(signed), true
+}
+
+// claimEnrollment verifies a signed enrollment token and claims its single-use token for
+// the bootstrap in progress, returning the hex encoded token. Claims must be released with
+// releaseEnrollment once the bootstrap is done.
+func (b *BootstrapServer) claimEnrollment(ctx context.Context, signed string) (string, error) {
+	enrollment, err := bootstrap.ParseEnrollment(signed)
+	if err != nil {
+		return "", connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	bT, err := b.tokenStore.Get(ctx, enrollment.ID)
+	if grpcutil.IsErrorNotFound(err) {
+		return "", connect.NewError(connect.CodeUnauthenticated, errors.New("enrollment token was already used or deleted"))
+	} else if err != nil {
+		return "", grpcutil.ErrorInternal(err)
+	}
+	token, err := bootstrap.FromBootstrapToken(bT)
+	if err != nil {
+		return "", grpcutil.ErrorInternal(err)
+	}
+	if !bT.GetSingleUse() {
+		return "", connect.NewError(connect.CodeUnauthenticated, bootstrap.ErrInvalidEnrollmentMAC)
+	}
+	if err := token.VerifyEnrollment(enrollment, time.Now()); err != nil {
+		return "", connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	b.enrollMu.Lock()
+	defer b.enrollMu.Unlock()
+	if _, ok := b.claimedEnrollments[enrollment.ID]; ok {
+		return "", connect.NewError(connect.CodeUnauthenticated, errors.New("enrollment token is already in use"))
+	}
+	b.claimedEnrollments[enrollment.ID] = struct{}{}
+	return token.EncodeToHex(), nil
+}
+
+func (b *BootstrapServer) releaseEnrollment(tokenID string) {
+	b.enrollMu.Lock()
+	defer b.enrollMu.Unlock()
+	delete(b.claimedEnrollments, tokenID)
+}
+
+// consumeSingleUseToken deletes the token once an agent bootstrapped with it, if it is single-use
+func (b *BootstrapServer) consumeSingleUseToken(ctx context.Context, tokenID string) error {
+	bT, err := b.tokenStore.Get(ctx, tokenID)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil
+	} else if err != nil {
+		return grpcutil.ErrorInternal(err)
+	}
+	if !bT.GetSingleUse() {
+		return nil
+	}
+	b.logger.With("token", tokenID).InfoContext(ctx, "deleting consumed single-use token")
+	if err := b.tokenStore.Delete(ctx, tokenID); err != nil {
+		return grpcutil.ErrorInternal(fmt.Errorf("failed to delete consumed token: %w", err))
+	}
+	if err := b.attempts.forget(ctx, tokenID); err != nil {
+		b.logger.With("token", tokenID, "err", err).WarnContext(ctx, "failed to forget bootstrap attempts of consumed token")
+	}
+	events.RecordChange(ctx, b.eventRecorder, events.TypeTokenDeleted, "single-use bootstrap token consumed", map[string]string{
+		"token_id": tokenID,
+	})
+	return nil
+}
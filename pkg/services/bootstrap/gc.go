@@ -0,0 +1,160 @@
+package bootstrap
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	v1alpha1bootstrap "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultTokenGCInterval is how often expired tokens are garbage collected unless
+// configured otherwise
+const DefaultTokenGCInterval = time.Minute
+
+const (
+	// gcWorkers is the number of expired tokens deleted at once
+	gcWorkers = 2
+	// gcQueueSize bounds the expired tokens waiting to be deleted. Tokens that don't fit
+	// are collected by the next sweep.
+	gcQueueSize = 128
+	// gcTimeout bounds the deletion of a token
+	gcTimeout = time.Minute
+)
+
+// tokenGC deletes expired tokens on a bounded set of workers, run by the BootstrapServer service.
+// Expired tokens are queued by periodic sweeps of the token store, and when they are listed.
+type tokenGC struct {
+	logger *slog.Logger
+	store  storage.KeyValue[*v1alpha1bootstrap.BootstrapToken]
+	// bootstrap attempts of collected tokens are forgotten
+	attempts *attemptTracker
+	queue    chan string
+	// how often the token store is swept for expired tokens
+	interval time.Duration
+
+	mu sync.Mutex
+	// tokens queued or being deleted, so that they are only queued once
+	pending map[string]struct{}
+	active  atomic.Int64
+}
+
+func newTokenGC(logger *slog.Logger, store storage.KeyValue[*v1alpha1bootstrap.BootstrapToken], attempts *attemptTracker) *tokenGC {
+	return &tokenGC{
+		logger:   logger,
+		store:    store,
+		attempts: attempts,
+		queue:    make(chan string, gcQueueSize),
+		interval: DefaultTokenGCInterval,
+		pending:  map[string]struct{}{},
+	}
+}
+
+// enqueue queues an expired token for deletion, unless it is already queued or the queue is full
+func (g *tokenGC) enqueue(key string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.pending[key]; ok {
+		return
+	}
+	select {
+	case g.queue <- key:
+		g.pending[key] = struct{}{}
+		g.logger.With("key", key).Debug("garbage collecting token")
+	default:
+		g.logger.With("key", key).Debug("token garbage collection queue full, deferring")
+	}
+}
+
+// run sweeps the token store and deletes queued tokens until ctx is done, then waits for the
+// deletions in progress. Tokens still queued are collected by the first sweep after a restart.
+func (g *tokenGC) run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(g.interval)
+		defer ticker.Stop()
+		for {
+			g.sweep(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	for range gcWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case key := <-g.queue:
+					g.delete(ctx, key)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// sweep queues the expired tokens of the token store for deletion
+func (g *tokenGC) sweep(ctx context.Context) {
+	tokens, err := g.store.List(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			g.logger.With("err", err).Error("failed to list tokens for garbage collection")
+		}
+		return
+	}
+	now := time.Now()
+	for _, token := range tokens {
+		if token.Expiry != nil && token.GetExpiry().AsTime().Before(now) {
+			g.enqueue(token.GetID())
+		}
+	}
+}
+
+func (g *tokenGC) delete(ctx context.Context, key string) {
+	g.active.Add(1)
+	defer g.active.Add(-1)
+	defer func() {
+		g.mu.Lock()
+		delete(g.pending, key)
+		g.mu.Unlock()
+	}()
+	// let a deletion in progress finish when the service stops
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), gcTimeout)
+	defer cancel()
+	if err := g.store.Delete(ctx, key); err != nil {
+		g.logger.With("key", key, "err", err).Error("failed to delete token")
+		return
+	}
+	if err := g.attempts.forget(ctx, key); err != nil {
+		g.logger.With("key", key, "err", err).Warn("failed to forget bootstrap attempts of collected token")
+	}
+}
+
+func (g *tokenGC) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "otelfleet_bootstrap_token_gc_active_workers",
+			Help: "Workers deleting expired bootstrap tokens.",
+		}, func() float64 {
+			return float64(g.active.Load())
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "otelfleet_bootstrap_token_gc_queued",
+			Help: "Expired bootstrap tokens waiting to be deleted.",
+		}, func() float64 {
+			return float64(len(g.queue))
+		}),
+	}
+}
@@ -0,0 +1,52 @@
+package bootstrap
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	v1alpha1bootstrap "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTokenGC_Sweep(t *testing.T) {
+	ctx := t.Context()
+	store := storage.NewProtoKV[*v1alpha1bootstrap.BootstrapToken](slog.Default(), memory.NewKVBroker().KeyValue("tokens"))
+	for id, expiry := range map[string]time.Time{
+		"expired": time.Now().Add(-time.Minute),
+		"valid":   time.Now().Add(time.Hour),
+	} {
+		require.NoError(t, store.Put(ctx, id, &v1alpha1bootstrap.BootstrapToken{ID: id, Expiry: timestamppb.New(expiry)}))
+	}
+
+	attempts := newAttemptTracker(time.Minute)
+	attempts.shared = memory.NewKVBroker().KeyValue("bootstrapattempts")
+	require.NoError(t, attempts.record(ctx, "expired", bootstrapAttempt{AgentID: "agent", Time: time.Now()}))
+	require.NoError(t, attempts.record(ctx, "valid", bootstrapAttempt{AgentID: "agent", Time: time.Now()}))
+
+	gc := newTokenGC(slog.Default(), store, attempts)
+	gc.interval = 10 * time.Millisecond
+	go gc.run(ctx)
+
+	// expired tokens are collected without being listed
+	require.Eventually(t, func() bool {
+		_, err := store.Get(ctx, "expired")
+		return grpcutil.IsErrorNotFound(err)
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err := store.Get(ctx, "valid")
+	assert.NoError(t, err)
+
+	// attempts of collected tokens are forgotten
+	require.Eventually(t, func() bool {
+		recent, err := attempts.recent(ctx, "expired", time.Now())
+		return err == nil && len(recent) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	recent, err := attempts.recent(ctx, "valid", time.Now())
+	require.NoError(t, err)
+	assert.Len(t, recent, 1)
+}
@@ -0,0 +1,186 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"connectrpc.com/connect"
+	v1alpha1bootstrap "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/bootstrap"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+)
+
+// installSettings are the agent settings install scripts may set
+var installSettings = []string{"HEALTHZ_ADDR", "HEALTHZ_MAX_CONTACT_AGE", "OPAMP_ADDR", "WATCHDOG_THRESHOLD"}
+
+// SetOpAMPURL sets the OpAMP endpoint embedded in install scripts, for servers serving OpAMP
+// on a dedicated listener. Agents otherwise derive it from the server URL.
+func (b *BootstrapServer) SetOpAMPURL(url string) {
+	b.opampURL = url
+}
+
+// installVar is an environment variable of the agent. Unset values default to the host name.
+type installVar struct {
+	Name  string
+	Value string
+}
+
+type installScript struct {
+	TokenID string
+	Expiry  string
+	Vars    []installVar
+}
+
+var installTemplates = template.Must(template.New("install").Funcs(template.FuncMap{
+	"shell":      shellValue,
+	"powershell": powershellValue,
+}).Parse(`
+{{- define "env" }}
+{{- range .Vars }}export {{ .Name }}={{ shell .Value }}
+{{ end }}
+{{- end }}
+
+{{- define "INSTALL_PLATFORM_SHELL" -}}
+#!/bin/sh
+# Installs an otelfleet agent bootstrapped with token {{ .TokenID }}, expiring at {{ .Expiry }}.
+set -eu
+mkdir -p /etc/otelfleet
+cat > /etc/otelfleet/agent.env <<'EOF'
+{{ template "env" . -}}
+EOF
+chmod 600 /etc/otelfleet/agent.env
+. /etc/otelfleet/agent.env
+nohup otelfleet-agent >/var/log/otelfleet-agent.log 2>&1 &
+{{ end }}
+
+{{- define "INSTALL_PLATFORM_CLOUD_INIT" -}}
+#cloud-config
+# Installs an otelfleet agent bootstrapped with token {{ .TokenID }}, expiring at {{ .Expiry }}.
+write_files:
+  - path: /etc/otelfleet/agent.env
+    permissions: "0600"
+    content: |
+{{- range .Vars }}
+      export {{ .Name }}={{ shell .Value }}
+{{- end }}
+runcmd:
+  - [sh, -c, ". /etc/otelfleet/agent.env && nohup otelfleet-agent >/var/log/otelfleet-agent.log 2>&1 &"]
+{{ end }}
+
+{{- define "INSTALL_PLATFORM_POWERSHELL" -}}
+# Installs an otelfleet agent bootstrapped with token {{ .TokenID }}, expiring at {{ .Expiry }}.
+$ErrorActionPreference = 'Stop'
+{{ range .Vars }}$env:{{ .Name }} = {{ powershell .Value }}
+{{ end -}}
+Start-Process -FilePath 'otelfleet-agent.exe' -NoNewWindow
+{{ end }}
+`))
+
+var installFilenames = map[v1alpha1bootstrap.InstallPlatform]string{
+	v1alpha1bootstrap.InstallPlatform_INSTALL_PLATFORM_SHELL:      "install-agent.sh",
+	v1alpha1bootstrap.InstallPlatform_INSTALL_PLATFORM_CLOUD_INIT: "cloud-init.yaml",
+	v1alpha1bootstrap.InstallPlatform_INSTALL_PLATFORM_POWERSHELL: "install-agent.ps1",
+}
+
+// shellValue quotes a value for POSIX shells, expanding unset values to the host name
+func shellValue(value string) string {
+	if value == "" {
+		return `"$(hostname)"`
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// powershellValue quotes a value for PowerShell, expanding unset values to the host name
+func powershellValue(value string) string {
+	if value == "" {
+		return "$env:COMPUTERNAME"
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// validInstallValue rejects values that can't be embedded on a single line of a script
+func validInstallValue(value string) bool {
+	return !strings.ContainsFunc(value, unicode.IsControl)
+}
+
+func (b *BootstrapServer) GenerateInstallScript(ctx context.Context, req *connect.Request[v1alpha1bootstrap.GenerateInstallScriptRequest]) (*connect.Response[v1alpha1bootstrap.InstallScript], error) {
+	filename, ok := installFilenames[req.Msg.GetPlatform()]
+	if !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("platform must be set"))
+	}
+	baseURL := req.Msg.GetBaseURL()
+	if baseURL == "" {
+		baseURL = b.externalURL
+	}
+	if baseURL == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("baseURL must be set when the server has no external URL configured"))
+	}
+	for name, value := range req.Msg.GetSettings() {
+		if !slices.Contains(installSettings, name) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported agent setting %s, expected one of %s", name, strings.Join(installSettings, ", ")))
+		}
+		if value == "" || !validInstallValue(value) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid value for agent setting %s", name))
+		}
+	}
+	if !validInstallValue(req.Msg.GetAgentName()) || !validInstallValue(baseURL) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agentName and baseURL must not contain control characters"))
+	}
+
+	bT, err := b.tokenStore.Get(ctx, req.Msg.GetTokenID())
+	if grpcutil.IsErrorNotFound(err) || (err == nil && !tenant.Visible(ctx, bT.GetTenant())) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("token not found: %s", req.Msg.GetTokenID()))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	expiry := bT.GetExpiry().AsTime()
+	if !time.Now().Before(expiry) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("token %s expired at %s", bT.GetID(), expiry.Format(time.RFC3339)))
+	}
+	token, err := bootstrap.FromBootstrapToken(bT)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	vars := []installVar{{Name: "SERVER_URL", Value: baseURL}}
+	if b.opampURL != "" {
+		vars = append(vars, installVar{Name: "OPAMP_ADDR", Value: b.opampURL})
+	}
+	if bT.GetSingleUse() {
+		vars = append(vars, installVar{Name: "ENROLLMENT_URL", Value: bootstrap.EnrollmentURL(baseURL, token.SignEnrollment(expiry))})
+	} else {
+		vars = append(vars, installVar{Name: "BOOTSTRAP_TOKEN", Value: token.EncodeToHex()})
+	}
+	vars = append(vars, installVar{Name: "AGENT_NAME", Value: req.Msg.GetAgentName()})
+	if labels := bT.GetLabels(); len(labels) > 0 {
+		vars = append(vars, installVar{Name: "AGENT_LABELS", Value: bootstrap.FormatLabels(labels)})
+	}
+	for _, name := range slices.Sorted(maps.Keys(req.Msg.GetSettings())) {
+		// explicit settings take precedence over the server's
+		vars = slices.DeleteFunc(vars, func(v installVar) bool { return v.Name == name })
+		vars = append(vars, installVar{Name: name, Value: req.Msg.GetSettings()[name]})
+	}
+
+	buf := new(bytes.Buffer)
+	if err := installTemplates.ExecuteTemplate(buf, req.Msg.GetPlatform().String(), installScript{
+		TokenID: bT.GetID(),
+		Expiry:  expiry.UTC().Format(time.RFC3339),
+		Vars:    vars,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&v1alpha1bootstrap.InstallScript{
+		Script:   buf.String(),
+		Filename: filename,
+		Expiry:   bT.GetExpiry(),
+	}), nil
+}
@@ -0,0 +1,31 @@
+package bootstrap
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1beta1"
+	"github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1beta1/v1beta1connect"
+	"github.com/otelfleet/otelfleet/pkg/apiversion"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// tokenServiceV1beta1 serves the v1beta1 token API with the v1alpha1 handlers
+type tokenServiceV1beta1 struct {
+	bootstrap *BootstrapServer
+	shim      *apiversion.Shim
+}
+
+var _ v1beta1connect.TokenServiceHandler = (*tokenServiceV1beta1)(nil)
+
+func (s *tokenServiceV1beta1) CreateToken(ctx context.Context, req *connect.Request[v1beta1.CreateTokenRequest]) (*connect.Response[v1beta1.BootstrapToken], error) {
+	return apiversion.Unary[v1beta1.BootstrapToken](ctx, s.shim, req, s.bootstrap.CreateToken)
+}
+
+func (s *tokenServiceV1beta1) ListTokens(ctx context.Context, req *connect.Request[v1beta1.ListTokensRequest]) (*connect.Response[v1beta1.ListTokensResponse], error) {
+	return apiversion.Unary[v1beta1.ListTokensResponse](ctx, s.shim, req, s.bootstrap.ListTokens)
+}
+
+func (s *tokenServiceV1beta1) DeleteToken(ctx context.Context, req *connect.Request[v1beta1.DeleteTokenRequest]) (*connect.Response[emptypb.Empty], error) {
+	return apiversion.Unary[emptypb.Empty](ctx, s.shim, req, s.bootstrap.DeleteToken)
+}
@@ -1,27 +1,47 @@
 package deployment
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/grafana/dskit/services"
 	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	jobsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
 	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/services/jobs"
 	"github.com/otelfleet/otelfleet/pkg/services/otelconfig"
 	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/telemetry"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/otelfleet/otelfleet/pkg/util/clock"
 	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"github.com/otelfleet/otelfleet/pkg/util/parallel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
 	maxRetries     = 3
 	retryBaseDelay = 100 * time.Millisecond
+	// applyPollInterval is how often the status of agents is checked while waiting for them
+	// to apply a deployed config
+	applyPollInterval = 250 * time.Millisecond
 )
 
+// JobType is the type of the jobs executing rolling deployments, whose ID is the deployment ID
+const JobType = "deployment.rollout"
+
 // retryWithBackoff retries the given function with exponential backoff.
 // Returns the result and error from the last attempt.
 func retryWithBackoff[T any](ctx context.Context, logger *slog.Logger, operation string, fn func() (T, error)) (T, error) {
@@ -62,7 +82,32 @@ func retryWithBackoff[T any](ctx context.Context, logger *slog.Logger, operation
 
 // ConfigAssigner is an interface for assigning configs to agents
 type ConfigAssigner interface {
-	AssignConfigToAgent(ctx context.Context, agentID, configID string) error
+	// AssignConfigToAgent assigns the config, rolling the agent back to its last known-good
+	// config if autoRollback is set and it fails to apply it
+	AssignConfigToAgent(ctx context.Context, agentID, configID string, autoRollback bool) error
+}
+
+// AssignmentRestorer captures the assignments of agents when a deployment starts, and
+// restores them when the deployment is rolled back (typically the ConfigServer)
+type AssignmentRestorer interface {
+	// CurrentAssignment returns the assignment of an agent and the config it assigns, nil if
+	// the agent has none
+	CurrentAssignment(ctx context.Context, agentID string) (*configv1alpha1.ConfigAssignment, *configv1alpha1.Config, error)
+	// RestoreAssignment assigns a captured assignment and config again, or removes the
+	// agent's assignment if assignment is nil
+	RestoreAssignment(ctx context.Context, agentID string, assignment *configv1alpha1.ConfigAssignment, config *configv1alpha1.Config) error
+}
+
+// CompatibilityChecker reports the components of a config missing from the collector
+// distribution an agent runs (typically the ConfigServer)
+type CompatibilityChecker interface {
+	MissingComponents(ctx context.Context, agent *agentdomain.Agent, configID string) ([]string, error)
+}
+
+// CapacityChecker reports the resource requirements of a config exceeding the capacity
+// an agent reports for its host (typically the ConfigServer)
+type CapacityChecker interface {
+	CapacityWarnings(ctx context.Context, agent *agentdomain.Agent, configID string) ([]string, error)
 }
 
 // Controller manages rolling deployments of configs to agents
@@ -75,9 +120,21 @@ type Controller struct {
 	agentRepo            agentdomain.Repository
 
 	configAssigner ConfigAssigner
-
-	mu                sync.RWMutex
-	activeDeployments map[string]context.CancelFunc
+	// optional, enables rollbacks
+	assignmentRestorer AssignmentRestorer
+	// deployments are executed as jobs, so that they resume when the server restarts
+	queue *jobs.Queue
+	// optional
+	compatibilityChecker CompatibilityChecker
+	capacityChecker      CapacityChecker
+	eventRecorder        events.Recorder
+	retention            Retention
+	// agents a deployment batch is applied to in parallel
+	concurrency int
+	// serializes the updates of deployment statuses by the agents of a batch
+	statusMu sync.Mutex
+	// times batches, their delays and jitter
+	clock clock.Clock
 
 	services.Service
 }
@@ -85,13 +142,14 @@ type Controller struct {
 // Ensure Controller implements the DeploymentController interface
 var _ otelconfig.DeploymentController = (*Controller)(nil)
 
-// NewController creates a new deployment controller
+// NewController creates a new deployment controller, executing deployments on queue
 func NewController(
 	logger *slog.Logger,
 	deploymentStore storage.KeyValue[*configv1alpha1.DeploymentStatus],
 	agentDeploymentStore storage.KeyValue[*configv1alpha1.AgentDeploymentStatus],
 	configStore storage.KeyValue[*configv1alpha1.Config],
 	agentRepo agentdomain.Repository,
+	queue *jobs.Queue,
 ) *Controller {
 	c := &Controller{
 		logger:               logger,
@@ -99,9 +157,13 @@ func NewController(
 		agentDeploymentStore: agentDeploymentStore,
 		configStore:          configStore,
 		agentRepo:            agentRepo,
-		activeDeployments:    make(map[string]context.CancelFunc),
+		queue:                queue,
+		retention:            Retention{MaxAge: DefaultRetention},
+		clock:                clock.Real{},
 	}
-	c.Service = services.NewBasicService(nil, c.running, c.stopping)
+	// deployments record their own failures and are not retried
+	queue.Register(JobType, c.runDeploymentJob, jobs.RetryPolicy{MaxAttempts: 1})
+	c.Service = services.NewBasicService(nil, c.running, nil)
 	return c
 }
 
@@ -110,19 +172,54 @@ func (c *Controller) SetConfigAssigner(assigner ConfigAssigner) {
 	c.configAssigner = assigner
 }
 
-func (c *Controller) running(ctx context.Context) error {
-	<-ctx.Done()
-	return nil
+// SetAssignmentRestorer enables capturing the assignments deployments replace, so that
+// deployments can be rolled back
+func (c *Controller) SetAssignmentRestorer(restorer AssignmentRestorer) {
+	c.assignmentRestorer = restorer
 }
 
-func (c *Controller) stopping(_ error) error {
-	// Cancel all active deployments
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	for _, cancel := range c.activeDeployments {
-		cancel()
+// SetCompatibilityChecker enables skipping agents whose collector distribution lacks
+// components used by the deployed config
+func (c *Controller) SetCompatibilityChecker(checker CompatibilityChecker) {
+	c.compatibilityChecker = checker
+}
+
+// SetCapacityChecker enables warning about agents whose hosts lack the resources the
+// deployed config requires in deployment plans
+func (c *Controller) SetCapacityChecker(checker CapacityChecker) {
+	c.capacityChecker = checker
+}
+
+// SetConcurrency bounds the agents of a batch a deployment applies its config to in
+// parallel, and the deployments pruned in parallel. Defaults to parallel.DefaultLimit.
+func (c *Controller) SetConcurrency(limit int) {
+	c.concurrency = limit
+}
+
+// SetClock sets the clock timing deployment batches, their delays and jitter. Defaults to
+// the wall clock.
+func (c *Controller) SetClock(clk clock.Clock) {
+	c.clock = clk
+}
+
+// SetEventRecorder sets the recorder for deployment events
+func (c *Controller) SetEventRecorder(recorder events.Recorder) {
+	c.eventRecorder = recorder
+}
+
+func (c *Controller) running(ctx context.Context) error {
+	t := time.NewTicker(pruneInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			if err := c.pruneDeployments(ctx, time.Now()); err != nil {
+				c.logger.With("err", err).Error("failed to prune deployments")
+			}
+		}
 	}
-	return nil
 }
 
 // StartDeployment starts a new rolling deployment
@@ -137,13 +234,9 @@ func (c *Controller) StartDeployment(ctx context.Context, req *configv1alpha1.Ro
 		return "", fmt.Errorf("config not found: %s", req.GetConfigId())
 	}
 
-	// Resolve agent IDs (from list or labels)
-	agentIDs := req.GetAgentIds()
-	if len(agentIDs) == 0 && len(req.GetAgentLabels()) > 0 {
-		agentIDs, err = c.resolveAgentsByLabels(ctx, req.GetAgentLabels())
-		if err != nil {
-			return "", err
-		}
+	agentIDs, err := c.targetAgents(ctx, req)
+	if err != nil {
+		return "", err
 	}
 
 	if len(agentIDs) == 0 {
@@ -153,6 +246,9 @@ func (c *Controller) StartDeployment(ctx context.Context, req *configv1alpha1.Ro
 	deploymentID := uuid.New().String()
 
 	// Create deployment status
+	now := c.clock.Now()
+	batchSize := max(int(req.GetBatchSize()), 1)
+	numBatches := (len(agentIDs) + batchSize - 1) / batchSize
 	status := &configv1alpha1.DeploymentStatus{
 		DeploymentId:  deploymentID,
 		ConfigId:      req.GetConfigId(),
@@ -160,40 +256,96 @@ func (c *Controller) StartDeployment(ctx context.Context, req *configv1alpha1.Ro
 		TotalAgents:   int32(len(agentIDs)),
 		PendingAgents: int32(len(agentIDs)),
 		CurrentBatch:  0,
-		StartedAt:     timestamppb.Now(),
+		StartedAt:     timestamppb.New(now),
+		ProjectedCompletionAt: timestamppb.New(projectCompletion(
+			now,
+			numBatches,
+			time.Duration(req.GetBatchDelaySeconds())*time.Second,
+			time.Duration(req.GetMaxApplyJitterSeconds())*time.Second,
+		)),
+		Audit: configv1alpha1.NewAuditInfo(auth.SubjectFromContext(ctx), now),
 	}
+	status.Tenant, _ = tenant.FromContext(ctx)
 
 	// Store initial status
 	if err := c.deploymentStore.Put(ctx, deploymentID, status); err != nil {
 		return "", err
 	}
 
-	// Initialize per-agent status
-	for _, agentID := range agentIDs {
-		agentStatus := &configv1alpha1.AgentDeploymentStatus{
-			AgentId: agentID,
-			State:   configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_PENDING,
-		}
-		key := fmt.Sprintf("%s/%s", deploymentID, agentID)
-		if err := c.agentDeploymentStore.Put(ctx, key, agentStatus); err != nil {
-			c.logger.With("err", err, "agent_id", agentID).Error("failed to store agent deployment status")
-		}
+	c.initAgentStatuses(ctx, deploymentID, splitBatches(agentIDs, batchSize, nil))
+
+	// The job runs on behalf of the principal that started the deployment, so that
+	// the resulting assignments are attributed to it
+	if _, err := c.queue.Enqueue(ctx, JobType, &configv1alpha1.DeploymentJob{
+		DeploymentId: deploymentID,
+		AgentIds:     agentIDs,
+		Request:      req,
+	}, jobs.WithID(deploymentID)); err != nil {
+		c.updateDeploymentState(ctx, deploymentID, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_FAILED)
+		return "", err
 	}
 
-	// Start deployment goroutine
-	deployCtx, cancel := context.WithCancel(context.Background())
-	c.mu.Lock()
-	c.activeDeployments[deploymentID] = cancel
-	c.mu.Unlock()
+	c.logger.With("deployment_id", deploymentID, "config_id", req.GetConfigId(), "agent_count", len(agentIDs)).InfoContext(ctx, "started rolling deployment")
+	c.recordEvent(ctx, events.TypeDeploymentStarted, deploymentID, req.GetConfigId(), "deployment started")
 
-	go c.runDeployment(deployCtx, deploymentID, agentIDs, req)
+	return deploymentID, nil
+}
 
-	c.logger.With("deployment_id", deploymentID, "config_id", req.GetConfigId(), "agent_count", len(agentIDs)).Info("started rolling deployment")
+// initAgentStatuses stores the pending status of the agents of each batch, along with
+// their current assignment if rollbacks are enabled
+func (c *Controller) initAgentStatuses(ctx context.Context, deploymentID string, batches [][]string) {
+	for i, batch := range batches {
+		for _, agentID := range batch {
+			agentStatus := &configv1alpha1.AgentDeploymentStatus{
+				AgentId: agentID,
+				State:   configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_PENDING,
+				Batch:   int32(i + 1),
+			}
+			if c.assignmentRestorer != nil {
+				assignment, config, err := c.assignmentRestorer.CurrentAssignment(ctx, agentID)
+				if err != nil {
+					c.logger.With("err", err, "agent_id", agentID).Error("failed to capture agent assignment, it is removed if the deployment is rolled back")
+				}
+				agentStatus.PreviousAssignment = assignment
+				agentStatus.PreviousConfig = config
+			}
+			key := agentStatusKey(deploymentID, agentID)
+			if err := c.agentDeploymentStore.Put(ctx, key, agentStatus); err != nil {
+				c.logger.With("err", err, "agent_id", agentID).Error("failed to store agent deployment status")
+			}
+		}
+	}
+}
 
-	return deploymentID, nil
+// splitBatches splits agents in batches of the given sizes, or of batchSize agents if
+// sizes is empty
+func splitBatches(agentIDs []string, batchSize int, sizes []int32) [][]string {
+	var batches [][]string
+	for i, n := 0, 0; i < len(agentIDs); i += n {
+		n = batchSize
+		if len(batches) < len(sizes) {
+			n = int(sizes[len(batches)])
+		}
+		n = max(min(n, len(agentIDs)-i), 1)
+		batches = append(batches, agentIDs[i:i+n])
+	}
+	return batches
+}
+
+// targetAgents resolves the agents a deployment applies to, from its list of agent IDs or
+// its label selector
+func (c *Controller) targetAgents(ctx context.Context, req *configv1alpha1.RollingDeploymentRequest) ([]string, error) {
+	if agentIDs := req.GetAgentIds(); len(agentIDs) > 0 {
+		return agentIDs, nil
+	}
+	selector, err := agentdomain.NewSelector(req.GetAgentLabels(), req.GetAgentSelectorExpression())
+	if err != nil || selector.Empty() {
+		return nil, err
+	}
+	return c.resolveAgentsBySelector(ctx, selector)
 }
 
-func (c *Controller) resolveAgentsByLabels(ctx context.Context, labels map[string]string) ([]string, error) {
+func (c *Controller) resolveAgentsBySelector(ctx context.Context, selector agentdomain.Selector) ([]string, error) {
 	agents, err := c.agentRepo.List(ctx)
 	if err != nil {
 		return nil, err
@@ -201,39 +353,80 @@ func (c *Controller) resolveAgentsByLabels(ctx context.Context, labels map[strin
 
 	var matchedAgentIDs []string
 	for _, agent := range agents {
-		if agent.MatchesLabels(labels) {
+		if agent.MatchesSelector(selector) {
 			matchedAgentIDs = append(matchedAgentIDs, agent.ID)
 		}
 	}
 	return matchedAgentIDs, nil
 }
 
-func (c *Controller) runDeployment(ctx context.Context, deploymentID string, agentIDs []string, req *configv1alpha1.RollingDeploymentRequest) {
-	defer func() {
-		c.mu.Lock()
-		delete(c.activeDeployments, deploymentID)
-		c.mu.Unlock()
-	}()
+func (c *Controller) runDeploymentJob(ctx context.Context, job *jobsv1alpha1.Job) (proto.Message, error) {
+	payload := &configv1alpha1.DeploymentJob{}
+	if err := job.GetPayload().UnmarshalTo(payload); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("invalid deployment job: %w", err))
+	}
+	return nil, c.runDeployment(ctx, payload)
+}
+
+// runDeployment rolls out a deployment, skipping the agents it already completed when it
+// resumes after a restart. It is traced by a deployment.run span, parent of a
+// deployment.batch span per batch.
+func (c *Controller) runDeployment(ctx context.Context, job *configv1alpha1.DeploymentJob) (err error) {
+	deploymentID, req := job.GetDeploymentId(), job.GetRequest()
+	ctx, span := telemetry.Start(ctx, "deployment.run", trace.WithAttributes(
+		attribute.String("deployment.id", deploymentID),
+		attribute.String("config.id", req.GetConfigId()),
+		attribute.Int("deployment.agents", len(job.GetAgentIds())),
+		attribute.String("deployment.rollback_of", job.GetRollbackOf()),
+	))
+	defer func() { telemetry.End(span, err) }()
 
-	batchSize := int(req.GetBatchSize())
-	if batchSize <= 0 {
-		batchSize = 1
+	status, err := retryWithBackoff(ctx, c.logger, "get deployment status", func() (*configv1alpha1.DeploymentStatus, error) {
+		return c.deploymentStore.Get(ctx, deploymentID)
+	})
+	if err != nil {
+		return jobs.Permanent(fmt.Errorf("failed to get deployment status: %w", err))
+	}
+	if finished(status.GetState()) {
+		return nil
+	}
+	done, err := c.finishedAgents(ctx, deploymentID)
+	if err != nil {
+		c.updateDeploymentState(ctx, deploymentID, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_FAILED)
+		return jobs.Permanent(err)
 	}
 
+	assign := func(ctx context.Context, agentID string) error {
+		return c.configAssigner.AssignConfigToAgent(ctx, agentID, req.GetConfigId(), req.GetAutoRollback())
+	}
+	if job.GetRollbackOf() != "" {
+		assign, err = c.restoreFunc(ctx, job.GetRollbackOf())
+		if err != nil {
+			c.updateDeploymentState(ctx, deploymentID, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_FAILED)
+			return jobs.Permanent(err)
+		}
+	}
+
+	batches := splitBatches(job.GetAgentIds(), max(int(req.GetBatchSize()), 1), job.GetBatchSizes())
 	batchDelay := time.Duration(req.GetBatchDelaySeconds()) * time.Second
-	failureCount := 0
+	maxJitter := time.Duration(req.GetMaxApplyJitterSeconds()) * time.Second
+	applyTimeout := time.Duration(req.GetApplyTimeoutSeconds()) * time.Second
+	numBatches := len(batches)
+	var failureCount atomic.Int32
+	failureCount.Store(status.GetFailedAgents())
 	maxFailures := int(req.GetMaxFailures())
 
-	// Update status to in_progress
-	c.updateDeploymentState(ctx, deploymentID, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_IN_PROGRESS)
+	// Update status to in_progress, unless it is resumed while paused
+	if status.GetState() == configv1alpha1.DeploymentState_DEPLOYMENT_STATE_PENDING {
+		c.updateDeploymentState(ctx, deploymentID, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_IN_PROGRESS)
+	}
 
 	// Process in batches
-	for i := 0; i < len(agentIDs); i += batchSize {
-		select {
-		case <-ctx.Done():
-			c.updateDeploymentState(ctx, deploymentID, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_CANCELLED)
-			return
-		default:
+	for i, batch := range batches {
+		// cancelled deployments are marked as such by CancelDeployment, interrupted ones
+		// resume on restart
+		if ctx.Err() != nil {
+			return ctx.Err()
 		}
 
 		// Check if paused (with retry for transient storage errors)
@@ -243,7 +436,7 @@ func (c *Controller) runDeployment(ctx context.Context, deploymentID string, age
 		if err != nil {
 			c.logger.With("err", err, "deployment_id", deploymentID).Error("failed to check deployment state, failing deployment")
 			c.updateDeploymentState(ctx, deploymentID, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_FAILED)
-			return
+			return jobs.Permanent(err)
 		}
 		if status.GetState() == configv1alpha1.DeploymentState_DEPLOYMENT_STATE_PAUSED {
 			// Wait for resume or cancel
@@ -252,8 +445,8 @@ func (c *Controller) runDeployment(ctx context.Context, deploymentID string, age
 			for status.GetState() == configv1alpha1.DeploymentState_DEPLOYMENT_STATE_PAUSED {
 				select {
 				case <-ctx.Done():
-					return
-				case <-time.After(1 * time.Second):
+					return ctx.Err()
+				case <-c.clock.After(1 * time.Second):
 					status, err = retryWithBackoff(ctx, c.logger, "check deployment paused state", func() (*configv1alpha1.DeploymentStatus, error) {
 						return c.deploymentStore.Get(ctx, deploymentID)
 					})
@@ -263,7 +456,7 @@ func (c *Controller) runDeployment(ctx context.Context, deploymentID string, age
 						if pauseCheckFailures >= maxPauseCheckFailures {
 							c.logger.With("deployment_id", deploymentID).Error("too many storage failures while paused, failing deployment")
 							c.updateDeploymentState(ctx, deploymentID, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_FAILED)
-							return
+							return jobs.Permanent(err)
 						}
 					} else {
 						pauseCheckFailures = 0 // Reset on success
@@ -272,42 +465,50 @@ func (c *Controller) runDeployment(ctx context.Context, deploymentID string, age
 			}
 		}
 
-		// Get batch
-		end := i + batchSize
-		if end > len(agentIDs) {
-			end = len(agentIDs)
-		}
-		batch := agentIDs[i:end]
-
 		// Update current batch
-		c.updateCurrentBatch(ctx, deploymentID, int32(i/batchSize+1))
-
-		// Apply config to batch
-		for _, agentID := range batch {
-			c.updateAgentState(ctx, deploymentID, agentID, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLYING, "")
-
-			err := c.configAssigner.AssignConfigToAgent(ctx, agentID, req.GetConfigId())
-			if err != nil {
-				c.updateAgentState(ctx, deploymentID, agentID, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_FAILED, err.Error())
-				failureCount++
-				c.incrementFailureCount(ctx, deploymentID)
-
-				if maxFailures > 0 && failureCount >= maxFailures {
-					c.updateDeploymentState(ctx, deploymentID, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_FAILED)
-					return
+		batchNum := i + 1
+		batchStart := c.clock.Now()
+		c.updateCurrentBatch(ctx, deploymentID, int32(batchNum), projectCompletion(batchStart, numBatches-batchNum+1, batchDelay, maxJitter))
+
+		// Apply config to batch, smeared over the jitter window so that
+		// agents do not all restart their collectors at once
+		batch, delays := jitteredBatch(deploymentID, batch, maxJitter)
+		pending := make([]int, 0, len(batch))
+		for idx, agentID := range batch {
+			if _, ok := done[agentID]; !ok {
+				pending = append(pending, idx)
+			}
+		}
+		spanCtx, batchSpan := telemetry.Start(ctx, "deployment.batch", trace.WithAttributes(
+			attribute.String("deployment.id", deploymentID),
+			attribute.Int("deployment.batch", batchNum),
+			attribute.Int("deployment.batch_agents", len(pending)),
+		))
+		err = parallel.ForEachUntilError(spanCtx, c.concurrency, pending, func(batchCtx context.Context, idx int) error {
+			if wait := clock.Until(c.clock, batchStart.Add(delays[idx])); wait > 0 {
+				select {
+				case <-batchCtx.Done():
+					return batchCtx.Err()
+				case <-c.clock.After(wait):
 				}
-			} else {
-				c.updateAgentState(ctx, deploymentID, agentID, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLIED, "")
-				c.incrementCompletedCount(ctx, deploymentID)
 			}
+			// assignments in flight complete when another agent of the batch fails the deployment
+			return c.applyToAgent(spanCtx, deploymentID, batch[idx], assign, applyTimeout, &failureCount, maxFailures)
+		})
+		telemetry.End(batchSpan, err)
+		if errors.Is(err, errTooManyFailures) {
+			c.updateDeploymentState(ctx, deploymentID, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_FAILED)
+			return jobs.Permanent(err)
+		} else if err != nil {
+			return err
 		}
 
 		// Batch delay
-		if batchDelay > 0 && i+batchSize < len(agentIDs) {
+		if batchDelay > 0 && batchNum < numBatches {
 			select {
 			case <-ctx.Done():
-				return
-			case <-time.After(batchDelay):
+				return ctx.Err()
+			case <-c.clock.After(batchDelay):
 			}
 		}
 	}
@@ -315,9 +516,151 @@ func (c *Controller) runDeployment(ctx context.Context, deploymentID string, age
 	// Mark as completed
 	c.updateDeploymentState(ctx, deploymentID, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED)
 	c.logger.With("deployment_id", deploymentID).Info("rolling deployment completed")
+	return nil
+}
+
+var (
+	errTooManyFailures = errors.New("too many agent failures")
+	// errNotApplied is returned when an agent fails to apply a deployed config or doesn't in time
+	errNotApplied = errors.New("config not applied")
+	// errSkipped is returned by assign functions leaving an agent as is
+	errSkipped = errors.New("agent skipped")
+)
+
+// applyToAgent assigns the config of a deployment to an agent with assign and records the
+// outcome, waiting up to timeout for the agent to apply it if set. It returns
+// errTooManyFailures once failures reaches maxFailures.
+func (c *Controller) applyToAgent(
+	ctx context.Context,
+	deploymentID, agentID string,
+	assign func(ctx context.Context, agentID string) error,
+	timeout time.Duration,
+	failures *atomic.Int32,
+	maxFailures int,
+) error {
+	c.updateAgentState(ctx, deploymentID, agentID, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLYING, "")
+
+	if err := assign(ctx, agentID); errors.Is(err, errSkipped) {
+		c.agentSkipped(ctx, deploymentID, agentID, err)
+		return nil
+	} else if err != nil {
+		return c.agentFailed(ctx, deploymentID, agentID, err, failures, maxFailures)
+	}
+	if timeout > 0 {
+		err := c.waitForApplied(ctx, agentID, timeout)
+		if errors.Is(err, errNotApplied) {
+			return c.agentFailed(ctx, deploymentID, agentID, err, failures, maxFailures)
+		} else if err != nil {
+			// the config is applied to the agent again when the deployment resumes
+			return err
+		}
+	}
+	c.updateAgentState(ctx, deploymentID, agentID, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLIED, "")
+	c.incrementCompletedCount(ctx, deploymentID)
+	return nil
+}
+
+// agentFailed records that a deployment failed to apply its config to an agent. It returns
+// errTooManyFailures once failures reaches maxFailures.
+func (c *Controller) agentFailed(ctx context.Context, deploymentID, agentID string, err error, failures *atomic.Int32, maxFailures int) error {
+	c.updateAgentState(ctx, deploymentID, agentID, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_FAILED, err.Error())
+	c.incrementFailureCount(ctx, deploymentID)
+	if n := int(failures.Add(1)); maxFailures > 0 && n >= maxFailures {
+		return fmt.Errorf("%w: deployment reached %d agent failures", errTooManyFailures, n)
+	}
+	return nil
+}
+
+// agentSkipped records that a deployment left an agent as is
+func (c *Controller) agentSkipped(ctx context.Context, deploymentID, agentID string, err error) {
+	c.updateAgentState(ctx, deploymentID, agentID, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_SKIPPED, err.Error())
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+	status, err := retryWithBackoff(ctx, c.logger, "get deployment for skipped agent", func() (*configv1alpha1.DeploymentStatus, error) {
+		return c.deploymentStore.Get(ctx, deploymentID)
+	})
+	if err != nil {
+		c.logger.With("err", err, "deployment_id", deploymentID).Warn("failed to get deployment for skipped agent")
+		return
+	}
+	status.PendingAgents--
+	_, err = retryWithBackoff(ctx, c.logger, "decrement pending count", func() (struct{}, error) {
+		return struct{}{}, c.deploymentStore.Put(ctx, deploymentID, status)
+	})
+	if err != nil {
+		c.logger.With("err", err, "deployment_id", deploymentID).Warn("failed to decrement pending count")
+	}
+}
+
+// waitForApplied waits up to timeout for an agent to report applying the config it was just
+// assigned, or to report being healthy if it doesn't report remote config statuses. It
+// returns an error wrapping errNotApplied if the agent failed to apply the config, was
+// assigned another config meanwhile, e.g. by being rolled back, or timed out.
+func (c *Controller) waitForApplied(ctx context.Context, agentID string, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	t := time.NewTicker(applyPollInterval)
+	defer t.Stop()
+
+	var assignedHash []byte
+	for {
+		agent, err := c.agentRepo.GetView(waitCtx, agentID, agentdomain.StatusViewHealth)
+		if errors.Is(err, agentdomain.ErrAgentNotFound) {
+			return fmt.Errorf("%w: agent was deleted", errNotApplied)
+		} else if err != nil {
+			c.logger.With("agent_id", agentID, "err", err).Warn("failed to get agent status")
+		} else {
+			status := agent.Status
+			if assignedHash == nil {
+				assignedHash = status.AssignedConfigHash
+			}
+			if !bytes.Equal(status.AssignedConfigHash, assignedHash) {
+				return fmt.Errorf("%w: agent was assigned config %q before applying the config", errNotApplied, status.AssignedConfigID)
+			}
+			switch status.ConfigSyncStatus {
+			case agentdomain.ConfigSyncInSync:
+				return nil
+			case agentdomain.ConfigSyncError:
+				return fmt.Errorf("%w: agent failed to apply the config: %s", errNotApplied, status.ConfigSyncReason)
+			case agentdomain.ConfigSyncUnsupported:
+				if status.Health != nil && status.Health.Healthy {
+					return nil
+				}
+			}
+		}
+
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("%w: agent did not apply the config within %s", errNotApplied, timeout)
+		case <-t.C:
+		}
+	}
+}
+
+// finishedAgents returns the agents a deployment already applied its config to, or failed to
+func (c *Controller) finishedAgents(ctx context.Context, deploymentID string) (map[string]struct{}, error) {
+	entries, err := c.agentDeploymentStore.ListPrefix(ctx, agentStatusPrefix(deploymentID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agent deployment statuses: %w", err)
+	}
+	done := map[string]struct{}{}
+	for _, entry := range entries {
+		switch entry.Value.GetState() {
+		case configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLIED,
+			configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_FAILED,
+			configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_SKIPPED:
+			done[entry.Value.GetAgentId()] = struct{}{}
+		}
+	}
+	return done, nil
 }
 
 func (c *Controller) updateDeploymentState(ctx context.Context, deploymentID string, state configv1alpha1.DeploymentState) {
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
 	status, err := retryWithBackoff(ctx, c.logger, "get deployment status", func() (*configv1alpha1.DeploymentStatus, error) {
 		return c.deploymentStore.Get(ctx, deploymentID)
 	})
@@ -339,7 +682,9 @@ func (c *Controller) updateDeploymentState(ctx context.Context, deploymentID str
 	}
 }
 
-func (c *Controller) updateCurrentBatch(ctx context.Context, deploymentID string, batch int32) {
+func (c *Controller) updateCurrentBatch(ctx context.Context, deploymentID string, batch int32, projectedCompletion time.Time) {
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
 	status, err := retryWithBackoff(ctx, c.logger, "get deployment for batch update", func() (*configv1alpha1.DeploymentStatus, error) {
 		return c.deploymentStore.Get(ctx, deploymentID)
 	})
@@ -348,6 +693,7 @@ func (c *Controller) updateCurrentBatch(ctx context.Context, deploymentID string
 		return
 	}
 	status.CurrentBatch = batch
+	status.ProjectedCompletionAt = timestamppb.New(projectedCompletion)
 	_, err = retryWithBackoff(ctx, c.logger, "update current batch", func() (struct{}, error) {
 		return struct{}{}, c.deploymentStore.Put(ctx, deploymentID, status)
 	})
@@ -357,7 +703,7 @@ func (c *Controller) updateCurrentBatch(ctx context.Context, deploymentID string
 }
 
 func (c *Controller) updateAgentState(ctx context.Context, deploymentID, agentID string, state configv1alpha1.AgentDeploymentState, errorMsg string) {
-	key := fmt.Sprintf("%s/%s", deploymentID, agentID)
+	key := agentStatusKey(deploymentID, agentID)
 	agentStatus, err := retryWithBackoff(ctx, c.logger, "get agent deployment status", func() (*configv1alpha1.AgentDeploymentStatus, error) {
 		return c.agentDeploymentStore.Get(ctx, key)
 	})
@@ -369,7 +715,10 @@ func (c *Controller) updateAgentState(ctx context.Context, deploymentID, agentID
 	}
 	agentStatus.State = state
 	agentStatus.ErrorMessage = errorMsg
-	if state == configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLIED {
+	switch state {
+	case configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLYING:
+		agentStatus.StartedAt = timestamppb.Now()
+	case configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLIED:
 		agentStatus.AppliedAt = timestamppb.Now()
 	}
 	_, err = retryWithBackoff(ctx, c.logger, "update agent deployment status", func() (struct{}, error) {
@@ -381,6 +730,8 @@ func (c *Controller) updateAgentState(ctx context.Context, deploymentID, agentID
 }
 
 func (c *Controller) incrementCompletedCount(ctx context.Context, deploymentID string) {
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
 	status, err := retryWithBackoff(ctx, c.logger, "get deployment for completed count", func() (*configv1alpha1.DeploymentStatus, error) {
 		return c.deploymentStore.Get(ctx, deploymentID)
 	})
@@ -399,6 +750,8 @@ func (c *Controller) incrementCompletedCount(ctx context.Context, deploymentID s
 }
 
 func (c *Controller) incrementFailureCount(ctx context.Context, deploymentID string) {
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
 	status, err := retryWithBackoff(ctx, c.logger, "get deployment for failure count", func() (*configv1alpha1.DeploymentStatus, error) {
 		return c.deploymentStore.Get(ctx, deploymentID)
 	})
@@ -416,8 +769,9 @@ func (c *Controller) incrementFailureCount(ctx context.Context, deploymentID str
 	}
 }
 
-// GetStatus returns the status of a deployment
-func (c *Controller) GetStatus(ctx context.Context, deploymentID string) (*configv1alpha1.DeploymentStatus, error) {
+// getDeployment returns the status of a deployment, without its agent statuses. Deployments
+// of another tenant than the one of the context are not found.
+func (c *Controller) getDeployment(ctx context.Context, deploymentID string) (*configv1alpha1.DeploymentStatus, error) {
 	status, err := c.deploymentStore.Get(ctx, deploymentID)
 	if err != nil {
 		if grpcutil.IsErrorNotFound(err) {
@@ -425,24 +779,27 @@ func (c *Controller) GetStatus(ctx context.Context, deploymentID string) (*confi
 		}
 		return nil, fmt.Errorf("failed to get deployment: %w", err)
 	}
+	if !tenant.Visible(ctx, status.GetTenant()) {
+		return nil, fmt.Errorf("deployment not found: %s", deploymentID)
+	}
+	return status, nil
+}
+
+// GetStatus returns the status of a deployment
+func (c *Controller) GetStatus(ctx context.Context, deploymentID string) (*configv1alpha1.DeploymentStatus, error) {
+	status, err := c.getDeployment(ctx, deploymentID)
+	if err != nil {
+		return nil, err
+	}
 
 	// Fetch agent statuses
-	keys, err := c.agentDeploymentStore.ListKeys(ctx)
+	entries, err := c.agentDeploymentStore.ListPrefix(ctx, agentStatusPrefix(deploymentID))
 	if err != nil {
-		return nil, fmt.Errorf("failed to list agent deployment keys: %w", err)
+		return nil, fmt.Errorf("failed to list agent deployment statuses: %w", err)
 	}
 	var agentStatuses []*configv1alpha1.AgentDeploymentStatus
-	prefix := deploymentID + "/"
-	for _, key := range keys {
-		if len(key) > len(prefix) && key[:len(prefix)] == prefix {
-			agentStatus, err := c.agentDeploymentStore.Get(ctx, key)
-			if err != nil && !grpcutil.IsErrorNotFound(err) {
-				return nil, fmt.Errorf("failed to get agent deployment status for %s: %w", key, err)
-			}
-			if err == nil {
-				agentStatuses = append(agentStatuses, agentStatus)
-			}
-		}
+	for _, entry := range entries {
+		agentStatuses = append(agentStatuses, entry.Value)
 	}
 	status.AgentStatuses = agentStatuses
 
@@ -451,12 +808,9 @@ func (c *Controller) GetStatus(ctx context.Context, deploymentID string) (*confi
 
 // PauseDeployment pauses a running deployment
 func (c *Controller) PauseDeployment(ctx context.Context, deploymentID string) error {
-	status, err := c.deploymentStore.Get(ctx, deploymentID)
+	status, err := c.getDeployment(ctx, deploymentID)
 	if err != nil {
-		if grpcutil.IsErrorNotFound(err) {
-			return fmt.Errorf("deployment not found: %s", deploymentID)
-		}
-		return fmt.Errorf("failed to get deployment: %w", err)
+		return err
 	}
 
 	if status.GetState() != configv1alpha1.DeploymentState_DEPLOYMENT_STATE_IN_PROGRESS {
@@ -464,17 +818,19 @@ func (c *Controller) PauseDeployment(ctx context.Context, deploymentID string) e
 	}
 
 	status.State = configv1alpha1.DeploymentState_DEPLOYMENT_STATE_PAUSED
-	return c.deploymentStore.Put(ctx, deploymentID, status)
+	status.Audit = status.GetAudit().Touched(auth.SubjectFromContext(ctx), time.Now())
+	if err := c.deploymentStore.Put(ctx, deploymentID, status); err != nil {
+		return err
+	}
+	c.recordEvent(ctx, events.TypeDeploymentPaused, deploymentID, status.GetConfigId(), "deployment paused")
+	return nil
 }
 
 // ResumeDeployment resumes a paused deployment
 func (c *Controller) ResumeDeployment(ctx context.Context, deploymentID string) error {
-	status, err := c.deploymentStore.Get(ctx, deploymentID)
+	status, err := c.getDeployment(ctx, deploymentID)
 	if err != nil {
-		if grpcutil.IsErrorNotFound(err) {
-			return fmt.Errorf("deployment not found: %s", deploymentID)
-		}
-		return fmt.Errorf("failed to get deployment: %w", err)
+		return err
 	}
 
 	if status.GetState() != configv1alpha1.DeploymentState_DEPLOYMENT_STATE_PAUSED {
@@ -482,30 +838,44 @@ func (c *Controller) ResumeDeployment(ctx context.Context, deploymentID string)
 	}
 
 	status.State = configv1alpha1.DeploymentState_DEPLOYMENT_STATE_IN_PROGRESS
-	return c.deploymentStore.Put(ctx, deploymentID, status)
+	status.Audit = status.GetAudit().Touched(auth.SubjectFromContext(ctx), time.Now())
+	if err := c.deploymentStore.Put(ctx, deploymentID, status); err != nil {
+		return err
+	}
+	c.recordEvent(ctx, events.TypeDeploymentResumed, deploymentID, status.GetConfigId(), "deployment resumed")
+	return nil
 }
 
 // CancelDeployment cancels a deployment
 func (c *Controller) CancelDeployment(ctx context.Context, deploymentID string) error {
-	c.mu.Lock()
-	cancel, exists := c.activeDeployments[deploymentID]
-	c.mu.Unlock()
-
-	if exists {
-		cancel()
+	if _, err := c.getDeployment(ctx, deploymentID); err != nil {
+		return err
+	}
+	// stop the deployment job first, so that it doesn't overwrite the cancelled state
+	if _, err := c.queue.Cancel(ctx, deploymentID); err != nil && !grpcutil.IsErrorNotFound(err) && !errors.Is(err, jobs.ErrJobFinished) {
+		return fmt.Errorf("failed to cancel deployment job: %w", err)
 	}
 
-	status, err := c.deploymentStore.Get(ctx, deploymentID)
+	status, err := c.getDeployment(ctx, deploymentID)
 	if err != nil {
-		if grpcutil.IsErrorNotFound(err) {
-			return fmt.Errorf("deployment not found: %s", deploymentID)
-		}
-		return fmt.Errorf("failed to get deployment: %w", err)
+		return err
 	}
 
 	status.State = configv1alpha1.DeploymentState_DEPLOYMENT_STATE_CANCELLED
 	status.CompletedAt = timestamppb.Now()
-	return c.deploymentStore.Put(ctx, deploymentID, status)
+	status.Audit = status.GetAudit().Touched(auth.SubjectFromContext(ctx), time.Now())
+	if err := c.deploymentStore.Put(ctx, deploymentID, status); err != nil {
+		return err
+	}
+	c.recordEvent(ctx, events.TypeDeploymentCancelled, deploymentID, status.GetConfigId(), "deployment cancelled")
+	return nil
+}
+
+func (c *Controller) recordEvent(ctx context.Context, eventType, deploymentID, configID, message string) {
+	events.RecordChange(ctx, c.eventRecorder, eventType, message, map[string]string{
+		"deployment_id": deploymentID,
+		"config_id":     configID,
+	})
 }
 
 // ListDeployments lists all deployments, optionally filtered by state
@@ -515,13 +885,12 @@ func (c *Controller) ListDeployments(ctx context.Context, stateFilter *configv1a
 		return nil, err
 	}
 
-	if stateFilter == nil {
-		return deployments, nil
-	}
-
 	var filtered []*configv1alpha1.DeploymentStatus
 	for _, d := range deployments {
-		if d.GetState() == *stateFilter {
+		if !tenant.Visible(ctx, d.GetTenant()) {
+			continue
+		}
+		if stateFilter == nil || d.GetState() == *stateFilter {
 			filtered = append(filtered, d)
 		}
 	}
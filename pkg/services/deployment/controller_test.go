@@ -0,0 +1,217 @@
+package deployment_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestController_ApplyTimeout(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: "logs"},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {otlp: {}}")},
+	}))
+	require.NoError(t, err)
+
+	connected := env.NewAgent("connected")
+	require.NoError(t, connected.Start())
+	connected.WaitForConfig(t, 5*time.Second)
+	require.NoError(t, env.AgentRepo.Register(ctx, "offline", "offline"))
+	require.NoError(t, env.AgentRepo.Register(ctx, "failing", "failing"))
+
+	// the failing agent reports failing to apply the config as soon as it is assigned
+	go func() {
+		for ctx.Err() == nil {
+			if assignment, err := env.ConfigAssignmentStore.Get(ctx, "failing"); err == nil {
+				_ = env.AgentRepo.UpdateRemoteConfigStatus(ctx, "failing", &protobufs.RemoteConfigStatus{
+					LastRemoteConfigHash: assignment.GetConfigHash(),
+					Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+					ErrorMessage:         "unknown receiver",
+				})
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	deploymentID, err := env.DeploymentController.StartDeployment(ctx, &configv1alpha1.RollingDeploymentRequest{
+		ConfigId:            "logs",
+		AgentIds:            []string{connected.ID, "failing", "offline"},
+		BatchSize:           3,
+		ApplyTimeoutSeconds: 1,
+	})
+	require.NoError(t, err)
+
+	var status *configv1alpha1.DeploymentStatus
+	require.Eventually(t, func() bool {
+		status, err = env.DeploymentController.GetStatus(ctx, deploymentID)
+		require.NoError(t, err)
+		return status.GetState() == configv1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED
+	}, 10*time.Second, 50*time.Millisecond)
+	assert.EqualValues(t, 1, status.GetCompletedAgents())
+	assert.EqualValues(t, 2, status.GetFailedAgents())
+
+	agents := map[string]*configv1alpha1.AgentDeploymentStatus{}
+	for _, agentStatus := range status.GetAgentStatuses() {
+		agents[agentStatus.GetAgentId()] = agentStatus
+	}
+	assert.Equal(t, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLIED, agents[connected.ID].GetState())
+	assert.Equal(t, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_FAILED, agents["failing"].GetState())
+	assert.Contains(t, agents["failing"].GetErrorMessage(), "unknown receiver")
+	assert.Equal(t, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_FAILED, agents["offline"].GetState())
+	assert.Contains(t, agents["offline"].GetErrorMessage(), "did not apply the config within 1s")
+}
+
+func TestController_Rollback(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	for id, config := range map[string]string{"old": "receivers: {otlp: {}}", "new": "receivers: {zipkin: {}}"} {
+		_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+			Ref:    &configv1alpha1.ConfigReference{Id: id},
+			Config: &configv1alpha1.Config{Config: []byte(config)},
+		}))
+		require.NoError(t, err)
+	}
+	for _, agentID := range []string{"a", "b", "c"} {
+		require.NoError(t, env.AgentRepo.Register(ctx, agentID, agentID))
+	}
+	assign := func(agentID, configID string) {
+		t.Helper()
+		_, err := env.ConfigServer.AssignConfig(ctx, connect.NewRequest(&configv1alpha1.AssignConfigRequest{
+			AgentId:  agentID,
+			ConfigId: configID,
+		}))
+		require.NoError(t, err)
+	}
+	waitFinished := func(deploymentID string) *configv1alpha1.DeploymentStatus {
+		t.Helper()
+		var status *configv1alpha1.DeploymentStatus
+		require.Eventually(t, func() bool {
+			var err error
+			status, err = env.DeploymentController.GetStatus(ctx, deploymentID)
+			require.NoError(t, err)
+			return status.GetState() == configv1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED
+		}, 10*time.Second, 50*time.Millisecond)
+		return status
+	}
+	assign("a", "old")
+
+	deploymentID, err := env.DeploymentController.StartDeployment(ctx, &configv1alpha1.RollingDeploymentRequest{
+		ConfigId:  "new",
+		AgentIds:  []string{"a", "b", "c"},
+		BatchSize: 2,
+	})
+	require.NoError(t, err)
+	waitFinished(deploymentID)
+
+	// unknown deployments cannot be rolled back
+	_, err = env.DeploymentController.RollbackDeployment(ctx, &configv1alpha1.RollbackDeploymentRequest{DeploymentId: "missing"})
+	assert.Error(t, err)
+
+	// c was reassigned since, the rollback leaves it as is
+	assign("c", "old")
+	rollbackID, err := env.DeploymentController.RollbackDeployment(ctx, &configv1alpha1.RollbackDeploymentRequest{DeploymentId: deploymentID})
+	require.NoError(t, err)
+	rollback := waitFinished(rollbackID)
+	assert.Equal(t, deploymentID, rollback.GetRollbackOf())
+	assert.EqualValues(t, 2, rollback.GetCompletedAgents())
+	assert.EqualValues(t, 0, rollback.GetPendingAgents())
+
+	agents := map[string]*configv1alpha1.AgentDeploymentStatus{}
+	for _, agentStatus := range rollback.GetAgentStatuses() {
+		agents[agentStatus.GetAgentId()] = agentStatus
+	}
+	// the last batch is rolled back first
+	assert.EqualValues(t, 1, agents["c"].GetBatch())
+	assert.EqualValues(t, 2, agents["a"].GetBatch())
+	assert.Equal(t, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_SKIPPED, agents["c"].GetState())
+	assert.Equal(t, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLIED, agents["a"].GetState())
+	assert.Equal(t, configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLIED, agents["b"].GetState())
+
+	assignment, err := env.ConfigAssignmentStore.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "old", assignment.GetConfigId())
+	_, err = env.ConfigAssignmentStore.Get(ctx, "b")
+	assert.Error(t, err)
+	assignment, err = env.ConfigAssignmentStore.Get(ctx, "c")
+	require.NoError(t, err)
+	assert.Equal(t, "old", assignment.GetConfigId())
+
+	original, err := env.DeploymentController.GetStatus(ctx, deploymentID)
+	require.NoError(t, err)
+	assert.Equal(t, rollbackID, original.GetRolledBackBy())
+	_, err = env.DeploymentController.RollbackDeployment(ctx, &configv1alpha1.RollbackDeploymentRequest{DeploymentId: deploymentID})
+	assert.ErrorContains(t, err, "already rolled back")
+	_, err = env.DeploymentController.RollbackDeployment(ctx, &configv1alpha1.RollbackDeploymentRequest{DeploymentId: rollbackID})
+	assert.ErrorContains(t, err, "is a rollback")
+}
+
+func TestController_Traces(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: "logs"},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {otlp: {}}")},
+	}))
+	require.NoError(t, err)
+	require.NoError(t, env.AgentRepo.Register(ctx, "agent-1", "agent-1"))
+	require.NoError(t, env.AgentRepo.Register(ctx, "agent-2", "agent-2"))
+
+	deploymentID, err := env.DeploymentController.StartDeployment(ctx, &configv1alpha1.RollingDeploymentRequest{
+		ConfigId:  "logs",
+		AgentIds:  []string{"agent-1", "agent-2"},
+		BatchSize: 1,
+	})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		status, err := env.DeploymentController.GetStatus(ctx, deploymentID)
+		require.NoError(t, err)
+		return status.GetState() == configv1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// the deployment span ends once its state is stored
+	var run sdktrace.ReadOnlySpan
+	require.Eventually(t, func() bool {
+		for _, span := range recorder.Ended() {
+			if span.Name() == "deployment.run" {
+				run = span
+			}
+		}
+		return run != nil
+	}, time.Second, 10*time.Millisecond)
+	spans := map[string][]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = append(spans[span.Name()], span)
+	}
+	require.Len(t, spans["deployment.batch"], 2)
+	for _, batch := range spans["deployment.batch"] {
+		assert.Equal(t, run.SpanContext().SpanID(), batch.Parent().SpanID())
+	}
+	assigned := map[trace.SpanID]int{}
+	for _, assign := range spans["config.assign"] {
+		assigned[assign.Parent().SpanID()]++
+	}
+	for _, batch := range spans["deployment.batch"] {
+		assert.Equal(t, 1, assigned[batch.SpanContext().SpanID()], "the assignments are traced in the span of their batch")
+	}
+}
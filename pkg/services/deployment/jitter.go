@@ -0,0 +1,44 @@
+package deployment
+
+import (
+	"cmp"
+	"hash/fnv"
+	"slices"
+	"time"
+)
+
+// applyJitter returns how long after the start of its batch an agent is assigned the config.
+// The delay is derived from the deployment and agent IDs, so it is stable for an agent
+// within a deployment while agents are spread across [0, maxJitter).
+func applyJitter(deploymentID, agentID string, maxJitter time.Duration) time.Duration {
+	if maxJitter <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(deploymentID))
+	h.Write([]byte{'/'})
+	h.Write([]byte(agentID))
+	return time.Duration(h.Sum64() % uint64(maxJitter))
+}
+
+// jitteredBatch returns the agents of a batch in the order they are due, with their delays.
+func jitteredBatch(deploymentID string, batch []string, maxJitter time.Duration) ([]string, []time.Duration) {
+	agents := slices.Clone(batch)
+	slices.SortStableFunc(agents, func(a, b string) int {
+		return cmp.Compare(applyJitter(deploymentID, a, maxJitter), applyJitter(deploymentID, b, maxJitter))
+	})
+	delays := make([]time.Duration, len(agents))
+	for i, agentID := range agents {
+		delays[i] = applyJitter(deploymentID, agentID, maxJitter)
+	}
+	return agents, delays
+}
+
+// projectCompletion estimates when the remaining batches of a deployment complete if
+// the next one starts at now, assuming every batch waits out the full jitter window.
+func projectCompletion(now time.Time, remainingBatches int, batchDelay, maxJitter time.Duration) time.Time {
+	if remainingBatches <= 0 {
+		return now
+	}
+	return now.Add(time.Duration(remainingBatches)*maxJitter + time.Duration(remainingBatches-1)*batchDelay)
+}
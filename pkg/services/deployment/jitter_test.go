@@ -0,0 +1,46 @@
+package deployment
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyJitter_SpreadsAgentsAcrossWindow(t *testing.T) {
+	maxJitter := 10 * time.Second
+	buckets := make([]int, 10)
+	for i := 0; i < 1000; i++ {
+		agentID := fmt.Sprintf("agent-%d", i)
+		d := applyJitter("deployment", agentID, maxJitter)
+		require.GreaterOrEqual(t, d, time.Duration(0))
+		require.Less(t, d, maxJitter)
+		assert.Equal(t, d, applyJitter("deployment", agentID, maxJitter), "jitter must be stable per agent")
+		buckets[d/time.Second]++
+	}
+	for i, n := range buckets {
+		assert.Greater(t, n, 50, "bucket %d is underpopulated", i)
+	}
+
+	assert.Zero(t, applyJitter("deployment", "agent", 0))
+}
+
+func TestJitteredBatch_OrdersByDelay(t *testing.T) {
+	batch := []string{"a", "b", "c", "d", "e"}
+	agents, delays := jitteredBatch("deployment", batch, time.Minute)
+	assert.ElementsMatch(t, batch, agents)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, batch, "input batch must not be reordered")
+	for i := 1; i < len(delays); i++ {
+		assert.LessOrEqual(t, delays[i-1], delays[i])
+	}
+}
+
+func TestProjectCompletion(t *testing.T) {
+	now := time.Now()
+	// 3 batches, each up to 10s of jitter, with 5s between batches
+	assert.Equal(t, now.Add(40*time.Second), projectCompletion(now, 3, 5*time.Second, 10*time.Second))
+	assert.Equal(t, now, projectCompletion(now, 0, 5*time.Second, 10*time.Second))
+	assert.Equal(t, now, projectCompletion(now, 1, 5*time.Second, 0))
+}
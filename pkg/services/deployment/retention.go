@@ -0,0 +1,116 @@
+package deployment
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/util/parallel"
+)
+
+const (
+	// DefaultRetention is how long finished deployments are kept when no retention is configured
+	DefaultRetention = 30 * 24 * time.Hour
+
+	pruneInterval = 10 * time.Minute
+)
+
+// Retention configures how long finished deployments and their per-agent statuses are kept
+type Retention struct {
+	// MaxAge is how long a deployment is kept after it finished, defaults to DefaultRetention
+	MaxAge time.Duration
+	// MaxCount is the number of finished deployments kept, 0 keeps all of them
+	MaxCount int
+}
+
+// SetRetention sets the retention of finished deployments
+func (c *Controller) SetRetention(retention Retention) {
+	if retention.MaxAge <= 0 {
+		retention.MaxAge = DefaultRetention
+	}
+	c.retention = retention
+}
+
+// agentStatusKey returns the key of an agent's status in a deployment. Statuses are keyed
+// by deployment first so that they can be listed and deleted by prefix.
+func agentStatusKey(deploymentID, agentID string) string {
+	return agentStatusPrefix(deploymentID) + agentID
+}
+
+func agentStatusPrefix(deploymentID string) string {
+	return deploymentID + "/"
+}
+
+func finished(state configv1alpha1.DeploymentState) bool {
+	switch state {
+	case configv1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED,
+		configv1alpha1.DeploymentState_DEPLOYMENT_STATE_FAILED,
+		configv1alpha1.DeploymentState_DEPLOYMENT_STATE_CANCELLED:
+		return true
+	}
+	return false
+}
+
+// PurgeDeployment deletes a finished deployment and its per-agent statuses
+func (c *Controller) PurgeDeployment(ctx context.Context, deploymentID string) error {
+	status, err := c.getDeployment(ctx, deploymentID)
+	if err != nil {
+		return err
+	}
+	if c.queue.IsRunning(deploymentID) || !finished(status.GetState()) {
+		return fmt.Errorf("deployment has not finished, cancel it before purging it")
+	}
+	if err := c.purge(ctx, deploymentID); err != nil {
+		return err
+	}
+	c.recordEvent(ctx, events.TypeDeploymentPurged, deploymentID, status.GetConfigId(), "deployment purged")
+	return nil
+}
+
+// purge deletes the per-agent statuses before the deployment, so that a failed purge
+// can be retried
+func (c *Controller) purge(ctx context.Context, deploymentID string) error {
+	if err := c.agentDeploymentStore.DeletePrefix(ctx, agentStatusPrefix(deploymentID)); err != nil {
+		return fmt.Errorf("failed to delete agent deployment statuses: %w", err)
+	}
+	if err := c.deploymentStore.Delete(ctx, deploymentID); err != nil {
+		return fmt.Errorf("failed to delete deployment: %w", err)
+	}
+	return nil
+}
+
+// pruneDeployments purges finished deployments that are older than the retention, or
+// beyond the number of deployments kept
+func (c *Controller) pruneDeployments(ctx context.Context, now time.Time) error {
+	deployments, err := c.deploymentStore.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list deployments: %w", err)
+	}
+	deployments = slices.DeleteFunc(deployments, func(d *configv1alpha1.DeploymentStatus) bool {
+		return d == nil || !finished(d.GetState())
+	})
+	// newest first
+	slices.SortFunc(deployments, func(a, b *configv1alpha1.DeploymentStatus) int {
+		return cmp.Compare(b.GetCompletedAt().AsTime().UnixNano(), a.GetCompletedAt().AsTime().UnixNano())
+	})
+
+	var prune []*configv1alpha1.DeploymentStatus
+	for i, d := range deployments {
+		expired := now.Sub(d.GetCompletedAt().AsTime()) > c.retention.MaxAge
+		excess := c.retention.MaxCount > 0 && i >= c.retention.MaxCount
+		if expired || excess {
+			prune = append(prune, d)
+		}
+	}
+	return parallel.ForEach(ctx, c.concurrency, prune, func(ctx context.Context, d *configv1alpha1.DeploymentStatus) error {
+		if err := c.purge(ctx, d.GetDeploymentId()); err != nil {
+			return fmt.Errorf("deployment %s: %w", d.GetDeploymentId(), err)
+		}
+		c.logger.With("deployment_id", d.GetDeploymentId(), "completed_at", d.GetCompletedAt().AsTime()).Debug("pruned deployment")
+		return nil
+	})
+}
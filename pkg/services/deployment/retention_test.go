@@ -0,0 +1,97 @@
+package deployment
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+	"github.com/cockroachdb/pebble/v2/vfs"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	jobsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/jobs"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	otelpebble "github.com/otelfleet/otelfleet/pkg/storage/pebble"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newRetentionTestController(t *testing.T) *Controller {
+	t.Helper()
+	db, err := pebble.Open("", &pebble.Options{FS: vfs.NewMem()})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	broker := otelpebble.NewKVBroker(db)
+	return NewController(
+		slog.Default(),
+		storage.NewProtoKV[*configv1alpha1.DeploymentStatus](slog.Default(), broker.KeyValue("deployments")),
+		storage.NewProtoKV[*configv1alpha1.AgentDeploymentStatus](slog.Default(), broker.KeyValue("agent-deployments")),
+		storage.NewProtoKV[*configv1alpha1.Config](slog.Default(), broker.KeyValue("configs")),
+		nil,
+		jobs.NewQueue(slog.Default(), storage.NewProtoKV[*jobsv1alpha1.Job](slog.Default(), broker.KeyValue("jobs")), jobs.Config{}),
+	)
+}
+
+func putDeployment(t *testing.T, c *Controller, id string, state configv1alpha1.DeploymentState, completedAt time.Time, agentIDs ...string) {
+	t.Helper()
+	status := &configv1alpha1.DeploymentStatus{DeploymentId: id, ConfigId: "logs", State: state}
+	if finished(state) {
+		status.CompletedAt = timestamppb.New(completedAt)
+	}
+	require.NoError(t, c.deploymentStore.Put(t.Context(), id, status))
+	for _, agentID := range agentIDs {
+		require.NoError(t, c.agentDeploymentStore.Put(t.Context(), agentStatusKey(id, agentID), &configv1alpha1.AgentDeploymentStatus{AgentId: agentID}))
+	}
+}
+
+func deploymentIDs(t *testing.T, c *Controller) []string {
+	t.Helper()
+	ids, err := c.deploymentStore.ListKeys(t.Context())
+	require.NoError(t, err)
+	return ids
+}
+
+func agentStatusKeys(t *testing.T, c *Controller) []string {
+	t.Helper()
+	keys, err := c.agentDeploymentStore.ListKeys(t.Context())
+	require.NoError(t, err)
+	return keys
+}
+
+func TestPruneDeployments(t *testing.T) {
+	now := time.Now()
+	c := newRetentionTestController(t)
+	c.SetRetention(Retention{MaxAge: 24 * time.Hour, MaxCount: 2})
+
+	putDeployment(t, c, "expired", configv1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED, now.Add(-48*time.Hour), "a1", "a2")
+	putDeployment(t, c, "oldest", configv1alpha1.DeploymentState_DEPLOYMENT_STATE_FAILED, now.Add(-3*time.Hour), "a1")
+	putDeployment(t, c, "older", configv1alpha1.DeploymentState_DEPLOYMENT_STATE_CANCELLED, now.Add(-2*time.Hour), "a1")
+	putDeployment(t, c, "newest", configv1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED, now.Add(-time.Hour), "a1")
+	// unfinished deployments are never pruned
+	putDeployment(t, c, "running", configv1alpha1.DeploymentState_DEPLOYMENT_STATE_IN_PROGRESS, time.Time{}, "a1")
+
+	require.NoError(t, c.pruneDeployments(t.Context(), now))
+	assert.ElementsMatch(t, []string{"older", "newest", "running"}, deploymentIDs(t, c))
+	assert.ElementsMatch(t, []string{"older/a1", "newest/a1", "running/a1"}, agentStatusKeys(t, c))
+
+	status, err := c.GetStatus(t.Context(), "older")
+	require.NoError(t, err)
+	require.Len(t, status.GetAgentStatuses(), 1)
+	assert.Equal(t, "a1", status.GetAgentStatuses()[0].GetAgentId())
+}
+
+func TestPurgeDeployment(t *testing.T) {
+	c := newRetentionTestController(t)
+	putDeployment(t, c, "done", configv1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED, time.Now(), "a1", "a2")
+	// deployment IDs sharing a prefix are kept apart
+	putDeployment(t, c, "done2", configv1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED, time.Now(), "a1")
+	putDeployment(t, c, "running", configv1alpha1.DeploymentState_DEPLOYMENT_STATE_IN_PROGRESS, time.Time{}, "a1")
+
+	assert.ErrorContains(t, c.PurgeDeployment(t.Context(), "running"), "has not finished")
+	assert.ErrorContains(t, c.PurgeDeployment(t.Context(), "missing"), "not found")
+
+	require.NoError(t, c.PurgeDeployment(t.Context(), "done"))
+	assert.ElementsMatch(t, []string{"done2", "running"}, deploymentIDs(t, c))
+	assert.ElementsMatch(t, []string{"done2/a1", "running/a1"}, agentStatusKeys(t, c))
+}
@@ -0,0 +1,158 @@
+package deployment
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/services/jobs"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RollbackDeployment starts a deployment restoring the assignments the agents of a
+// finished deployment had before it, in reverse batch order. Agents whose assignment
+// changed since the deployment are skipped. It returns the ID of the new deployment.
+func (c *Controller) RollbackDeployment(ctx context.Context, req *configv1alpha1.RollbackDeploymentRequest) (string, error) {
+	if c.assignmentRestorer == nil {
+		return "", fmt.Errorf("assignment restorer not set")
+	}
+	rollbackOf := req.GetDeploymentId()
+
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+
+	original, err := c.getDeployment(ctx, rollbackOf)
+	if err != nil {
+		return "", err
+	}
+	if c.queue.IsRunning(rollbackOf) || !finished(original.GetState()) {
+		return "", fmt.Errorf("deployment has not finished, cancel it before rolling it back")
+	}
+	if original.GetRollbackOf() != "" {
+		return "", fmt.Errorf("deployment is a rollback, start a new deployment instead")
+	}
+	if original.GetRolledBackBy() != "" {
+		return "", fmt.Errorf("deployment was already rolled back by %s", original.GetRolledBackBy())
+	}
+
+	entries, err := c.agentDeploymentStore.ListPrefix(ctx, agentStatusPrefix(rollbackOf))
+	if err != nil {
+		return "", fmt.Errorf("failed to list agent deployment statuses: %w", err)
+	}
+	// agents the deployment did not reach are left as is
+	var touched []*configv1alpha1.AgentDeploymentStatus
+	for _, entry := range entries {
+		if entry.Value.GetState() == configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_PENDING {
+			continue
+		}
+		if entry.Value.GetBatch() == 0 {
+			return "", fmt.Errorf("deployment did not capture the previous assignments of its agents")
+		}
+		touched = append(touched, entry.Value)
+	}
+	if len(touched) == 0 {
+		return "", fmt.Errorf("no agents to roll back")
+	}
+	slices.SortFunc(touched, func(a, b *configv1alpha1.AgentDeploymentStatus) int {
+		return cmp.Or(cmp.Compare(b.GetBatch(), a.GetBatch()), cmp.Compare(a.GetAgentId(), b.GetAgentId()))
+	})
+	var (
+		agentIDs   []string
+		batchSizes []int32
+	)
+	for i, agent := range touched {
+		if i == 0 || agent.GetBatch() != touched[i-1].GetBatch() {
+			batchSizes = append(batchSizes, 0)
+		}
+		batchSizes[len(batchSizes)-1]++
+		agentIDs = append(agentIDs, agent.GetAgentId())
+	}
+
+	deploymentID := uuid.New().String()
+	now := time.Now()
+	rollbackReq := &configv1alpha1.RollingDeploymentRequest{
+		BatchDelaySeconds:   req.GetBatchDelaySeconds(),
+		ApplyTimeoutSeconds: req.GetApplyTimeoutSeconds(),
+	}
+	status := &configv1alpha1.DeploymentStatus{
+		DeploymentId:  deploymentID,
+		State:         configv1alpha1.DeploymentState_DEPLOYMENT_STATE_PENDING,
+		TotalAgents:   int32(len(agentIDs)),
+		PendingAgents: int32(len(agentIDs)),
+		StartedAt:     timestamppb.New(now),
+		ProjectedCompletionAt: timestamppb.New(projectCompletion(
+			now,
+			len(batchSizes),
+			time.Duration(req.GetBatchDelaySeconds())*time.Second,
+			0,
+		)),
+		Audit:      configv1alpha1.NewAuditInfo(auth.SubjectFromContext(ctx), now),
+		RollbackOf: rollbackOf,
+		Tenant:     original.GetTenant(),
+	}
+	if err := c.deploymentStore.Put(ctx, deploymentID, status); err != nil {
+		return "", err
+	}
+	c.initAgentStatuses(ctx, deploymentID, splitBatches(agentIDs, 1, batchSizes))
+
+	original.RolledBackBy = deploymentID
+	original.Audit = original.GetAudit().Touched(auth.SubjectFromContext(ctx), now)
+	if err := c.deploymentStore.Put(ctx, rollbackOf, original); err != nil {
+		return "", err
+	}
+
+	if _, err := c.queue.Enqueue(ctx, JobType, &configv1alpha1.DeploymentJob{
+		DeploymentId: deploymentID,
+		AgentIds:     agentIDs,
+		Request:      rollbackReq,
+		RollbackOf:   rollbackOf,
+		BatchSizes:   batchSizes,
+	}, jobs.WithID(deploymentID)); err != nil {
+		status.State = configv1alpha1.DeploymentState_DEPLOYMENT_STATE_FAILED
+		status.CompletedAt = timestamppb.Now()
+		if err := c.deploymentStore.Put(ctx, deploymentID, status); err != nil {
+			c.logger.With("err", err, "deployment_id", deploymentID).Error("failed to update deployment state")
+		}
+		return "", err
+	}
+
+	c.logger.With("deployment_id", deploymentID, "rollback_of", rollbackOf, "agent_count", len(agentIDs)).InfoContext(ctx, "started deployment rollback")
+	c.recordEvent(ctx, events.TypeDeploymentRolledBack, rollbackOf, original.GetConfigId(), fmt.Sprintf("deployment rolled back by %s", deploymentID))
+	c.recordEvent(ctx, events.TypeDeploymentStarted, deploymentID, "", fmt.Sprintf("rollback of deployment %s started", rollbackOf))
+
+	return deploymentID, nil
+}
+
+// restoreFunc returns the assign function of a rollback of the given deployment, which
+// restores the assignment an agent had before it unless it changed since
+func (c *Controller) restoreFunc(ctx context.Context, rollbackOf string) (func(ctx context.Context, agentID string) error, error) {
+	if c.assignmentRestorer == nil {
+		return nil, fmt.Errorf("assignment restorer not set")
+	}
+	original, err := c.deploymentStore.Get(ctx, rollbackOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rolled back deployment %s: %w", rollbackOf, err)
+	}
+	configID := original.GetConfigId()
+
+	return func(ctx context.Context, agentID string) error {
+		previous, err := c.agentDeploymentStore.Get(ctx, agentStatusKey(rollbackOf, agentID))
+		if err != nil {
+			return fmt.Errorf("failed to get agent status in deployment %s: %w", rollbackOf, err)
+		}
+		current, _, err := c.assignmentRestorer.CurrentAssignment(ctx, agentID)
+		if err != nil {
+			return err
+		}
+		if current.GetConfigId() != configID || current.GetSource() != configv1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL {
+			return fmt.Errorf("%w: assignment changed since deployment %s", errSkipped, rollbackOf)
+		}
+		return c.assignmentRestorer.RestoreAssignment(ctx, agentID, previous.GetPreviousAssignment(), previous.GetPreviousConfig())
+	}, nil
+}
@@ -0,0 +1,193 @@
+package deployment
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// SimulateDeployment plans the given deployment without executing it. The plan lists the
+// batches the deployment would run with their expected timing, the targeted agents that
+// would not apply the config or lack the resources it requires, and the reasons the
+// deployment would be rejected or fail.
+func (c *Controller) SimulateDeployment(ctx context.Context, req *configv1alpha1.RollingDeploymentRequest) (*configv1alpha1.DeploymentPlan, error) {
+	plan := &configv1alpha1.DeploymentPlan{
+		ConfigId: req.GetConfigId(),
+	}
+
+	configFound := false
+	if req.GetConfigId() == "" {
+		plan.PolicyViolations = append(plan.PolicyViolations, "config_id must not be empty")
+	} else if _, err := c.configStore.Get(ctx, req.GetConfigId()); grpcutil.IsErrorNotFound(err) {
+		plan.PolicyViolations = append(plan.PolicyViolations, fmt.Sprintf("config not found: %s", req.GetConfigId()))
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	} else {
+		configFound = true
+	}
+
+	agentIDs, err := c.targetAgents(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve agents: %w", err)
+	}
+	plan.TotalAgents = int32(len(agentIDs))
+	if len(agentIDs) == 0 {
+		plan.PolicyViolations = append(plan.PolicyViolations, "no agents to deploy to")
+		return plan, nil
+	}
+
+	agents, err := c.agentRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+	byID := make(map[string]*agentdomain.Agent, len(agents))
+	for _, agent := range agents {
+		byID[agent.ID] = agent
+	}
+	notFound := 0
+	for _, agentID := range agentIDs {
+		reason := skipReason(byID[agentID])
+		var detail string
+		if reason == configv1alpha1.DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_UNSPECIFIED && configFound && c.compatibilityChecker != nil {
+			missing, err := c.compatibilityChecker.MissingComponents(ctx, byID[agentID], req.GetConfigId())
+			if err != nil {
+				return nil, fmt.Errorf("failed to check compatibility of agent %s: %w", agentID, err)
+			}
+			if len(missing) > 0 {
+				reason = configv1alpha1.DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_INCOMPATIBLE
+				detail = "distribution is missing components: " + strings.Join(missing, ", ")
+			}
+		}
+		if reason == configv1alpha1.DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_UNSPECIFIED {
+			// agents are deployed to regardless of their capacity, it is only a hint
+			if configFound && c.capacityChecker != nil {
+				warnings, err := c.capacityChecker.CapacityWarnings(ctx, byID[agentID], req.GetConfigId())
+				if err != nil {
+					return nil, fmt.Errorf("failed to check capacity of agent %s: %w", agentID, err)
+				}
+				if len(warnings) > 0 {
+					plan.CapacityWarnings = append(plan.CapacityWarnings, &configv1alpha1.CapacityWarning{
+						AgentId:  agentID,
+						Warnings: warnings,
+					})
+				}
+			}
+			continue
+		}
+		if reason == configv1alpha1.DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_NOT_FOUND {
+			notFound++
+		}
+		plan.SkippedAgents = append(plan.SkippedAgents, &configv1alpha1.SkippedAgent{
+			AgentId: agentID,
+			Reason:  reason,
+			Detail:  detail,
+		})
+	}
+
+	batchSize := max(int(req.GetBatchSize()), 1)
+	if maxFailures := int(req.GetMaxFailures()); maxFailures > 0 && notFound >= maxFailures {
+		plan.PolicyViolations = append(plan.PolicyViolations, fmt.Sprintf(
+			"%d targeted agents are not registered, the deployment would fail after max_failures (%d)", notFound, maxFailures))
+	}
+	if len(agentIDs) > 1 && batchSize >= len(agentIDs) {
+		plan.PolicyViolations = append(plan.PolicyViolations, fmt.Sprintf(
+			"batch_size %d covers all %d agents, the config would not be rolled out gradually", batchSize, len(agentIDs)))
+	}
+
+	latencies, err := c.applyLatencies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get apply latency history: %w", err)
+	}
+	var all []time.Duration
+	for _, l := range latencies {
+		all = append(all, l...)
+	}
+	plan.LatencySamples = int32(len(all))
+	fleetLatency := median(all)
+
+	// batches are planned the way runDeployment executes them: agents are assigned one
+	// after another, and the last agent of a batch may be due at the end of the jitter window
+	batchDelay := time.Duration(req.GetBatchDelaySeconds()) * time.Second
+	maxJitter := time.Duration(req.GetMaxApplyJitterSeconds()) * time.Second
+	var elapsed time.Duration
+	for i := 0; i < len(agentIDs); i += batchSize {
+		batch := agentIDs[i:min(i+batchSize, len(agentIDs))]
+		duration := maxJitter
+		for _, agentID := range batch {
+			if l, ok := latencies[agentID]; ok {
+				duration += median(l)
+			} else {
+				duration += fleetLatency
+			}
+		}
+		if i > 0 {
+			elapsed += batchDelay
+		}
+		plan.Batches = append(plan.Batches, &configv1alpha1.DeploymentPlanBatch{
+			Number:           int32(i/batchSize + 1),
+			AgentIds:         slices.Clone(batch),
+			ExpectedStart:    durationpb.New(elapsed),
+			ExpectedDuration: durationpb.New(duration),
+		})
+		elapsed += duration
+	}
+	plan.ExpectedDuration = durationpb.New(elapsed)
+	return plan, nil
+}
+
+// skipReason returns why a targeted agent would not apply the config, if it wouldn't
+func skipReason(agent *agentdomain.Agent) configv1alpha1.DeploymentSkipReason {
+	switch {
+	case agent == nil:
+		return configv1alpha1.DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_NOT_FOUND
+	// capabilities are only known once the agent has reported them
+	case !agent.CanReceiveConfig() && (agent.IsConnected() || agent.Connection.Capabilities != 0):
+		return configv1alpha1.DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_INCOMPATIBLE
+	case !agent.IsConnected():
+		return configv1alpha1.DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_OFFLINE
+	}
+	return configv1alpha1.DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_UNSPECIFIED
+}
+
+// applyLatencies returns the apply latencies recorded by past deployments, by agent
+func (c *Controller) applyLatencies(ctx context.Context) (map[string][]time.Duration, error) {
+	statuses, err := c.agentDeploymentStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latencies := map[string][]time.Duration{}
+	for _, status := range statuses {
+		// statuses recorded before start times were tracked have no latency
+		if status.GetState() != configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLIED ||
+			status.GetStartedAt() == nil || status.GetAppliedAt() == nil {
+			continue
+		}
+		latency := status.GetAppliedAt().AsTime().Sub(status.GetStartedAt().AsTime())
+		if latency < 0 {
+			continue
+		}
+		latencies[status.GetAgentId()] = append(latencies[status.GetAgentId()], latency)
+	}
+	return latencies, nil
+}
+
+// median returns the median of the given durations, or 0 if there are none
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,173 @@
+package deployment_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func putApplied(t *testing.T, env *testutil.TestEnv, key, agentID string, latency time.Duration) {
+	t.Helper()
+	started := time.Now().Add(-time.Hour)
+	require.NoError(t, env.AgentDeploymentStore.Put(context.Background(), key, &configv1alpha1.AgentDeploymentStatus{
+		AgentId:   agentID,
+		State:     configv1alpha1.AgentDeploymentState_AGENT_DEPLOYMENT_STATE_APPLIED,
+		StartedAt: timestamppb.New(started),
+		AppliedAt: timestamppb.New(started.Add(latency)),
+	}))
+}
+
+func TestController_SimulateDeployment(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: "logs"},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {}")},
+	}))
+	require.NoError(t, err)
+
+	connected := env.NewAgent("connected")
+	require.NoError(t, connected.Start())
+	connected.WaitForConfig(t, 5*time.Second)
+	require.NoError(t, env.AgentRepo.Register(ctx, "offline", "offline"))
+
+	putApplied(t, env, "previous/"+connected.ID, connected.ID, 2*time.Second)
+	putApplied(t, env, "previous/other", "other", 4*time.Second)
+
+	plan, err := env.DeploymentController.SimulateDeployment(ctx, &configv1alpha1.RollingDeploymentRequest{
+		ConfigId:              "logs",
+		AgentIds:              []string{connected.ID, "offline", "missing"},
+		BatchSize:             2,
+		BatchDelaySeconds:     10,
+		MaxFailures:           1,
+		MaxApplyJitterSeconds: 5,
+	})
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 3, plan.GetTotalAgents())
+	assert.EqualValues(t, 2, plan.GetLatencySamples())
+	require.Len(t, plan.GetBatches(), 2)
+	// the connected agent's own history is used, the fleet median for the others
+	assert.Equal(t, []string{connected.ID, "offline"}, plan.GetBatches()[0].GetAgentIds())
+	assert.Equal(t, time.Duration(0), plan.GetBatches()[0].GetExpectedStart().AsDuration())
+	assert.Equal(t, 10*time.Second, plan.GetBatches()[0].GetExpectedDuration().AsDuration())
+	assert.Equal(t, []string{"missing"}, plan.GetBatches()[1].GetAgentIds())
+	assert.Equal(t, 20*time.Second, plan.GetBatches()[1].GetExpectedStart().AsDuration())
+	assert.Equal(t, 8*time.Second, plan.GetBatches()[1].GetExpectedDuration().AsDuration())
+	assert.Equal(t, 28*time.Second, plan.GetExpectedDuration().AsDuration())
+
+	require.Len(t, plan.GetSkippedAgents(), 2)
+	assert.Equal(t, "offline", plan.GetSkippedAgents()[0].GetAgentId())
+	assert.Equal(t, configv1alpha1.DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_OFFLINE, plan.GetSkippedAgents()[0].GetReason())
+	assert.Equal(t, "missing", plan.GetSkippedAgents()[1].GetAgentId())
+	assert.Equal(t, configv1alpha1.DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_NOT_FOUND, plan.GetSkippedAgents()[1].GetReason())
+	require.Len(t, plan.GetPolicyViolations(), 1)
+	assert.Contains(t, plan.GetPolicyViolations()[0], "max_failures")
+
+	// nothing was executed
+	deployments, err := env.DeploymentStore.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, deployments)
+}
+
+func TestController_SimulateDeployment_Violations(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	resp, err := env.ConfigServer.SimulateDeployment(ctx, connect.NewRequest(&configv1alpha1.RollingDeploymentRequest{
+		ConfigId:    "missing",
+		AgentLabels: map[string]string{"env": "none"},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"config not found: missing", "no agents to deploy to"}, resp.Msg.GetPolicyViolations())
+	assert.Empty(t, resp.Msg.GetBatches())
+}
+
+func TestController_SimulateDeployment_Distribution(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: "kafka"},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {otlp: {}}\nexporters: {kafka/logs: {}}")},
+	}))
+	require.NoError(t, err)
+	_, err = env.ConfigServer.PutCollectorDistribution(ctx, connect.NewRequest(&configv1alpha1.PutCollectorDistributionRequest{
+		Distribution: &configv1alpha1.CollectorDistribution{
+			Name:       "otelcol",
+			Version:    "0.120.0",
+			Components: []string{"receivers/otlp", "exporters/otlp"},
+		},
+	}))
+	require.NoError(t, err)
+
+	core := env.NewAgent("core")
+	require.NoError(t, core.Start())
+	core.WaitForConfig(t, 5*time.Second)
+	require.NoError(t, env.AgentRepo.UpdateAttributes(ctx, core.ID, &protobufs.AgentDescription{
+		IdentifyingAttributes: []*protobufs.KeyValue{
+			{Key: "service.name", Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: "otelcol"}}},
+			{Key: "service.version", Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: "0.120.0"}}},
+		},
+	}))
+	// agents running unregistered distributions are not checked
+	unknown := env.NewAgent("unknown")
+	require.NoError(t, unknown.Start())
+	unknown.WaitForConfig(t, 5*time.Second)
+
+	plan, err := env.DeploymentController.SimulateDeployment(ctx, &configv1alpha1.RollingDeploymentRequest{
+		ConfigId:  "kafka",
+		AgentIds:  []string{core.ID, unknown.ID},
+		BatchSize: 1,
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.GetSkippedAgents(), 1)
+	skipped := plan.GetSkippedAgents()[0]
+	assert.Equal(t, core.ID, skipped.GetAgentId())
+	assert.Equal(t, configv1alpha1.DeploymentSkipReason_DEPLOYMENT_SKIP_REASON_INCOMPATIBLE, skipped.GetReason())
+	assert.Contains(t, skipped.GetDetail(), "exporters/kafka")
+}
+
+func TestController_SimulateDeployment_Capacity(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref: &configv1alpha1.ConfigReference{Id: "heavy"},
+		Config: &configv1alpha1.Config{
+			Config:   []byte("receivers: {otlp: {}}"),
+			Metadata: &configv1alpha1.ConfigMetadata{Resources: &configv1alpha1.ResourceRequirements{MinMemoryBytes: 1 << 50}},
+		},
+	}))
+	require.NoError(t, err)
+
+	agent := env.NewAgent("agent")
+	require.NoError(t, agent.Start())
+	agent.WaitForConfig(t, 5*time.Second)
+	require.NoError(t, env.AgentRepo.UpdateAttributes(ctx, agent.ID, &protobufs.AgentDescription{
+		NonIdentifyingAttributes: []*protobufs.KeyValue{
+			{Key: supervisor.AttributeHostMemoryBytes, Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: "1073741824"}}},
+		},
+	}))
+
+	plan, err := env.DeploymentController.SimulateDeployment(ctx, &configv1alpha1.RollingDeploymentRequest{
+		ConfigId:  "heavy",
+		AgentIds:  []string{agent.ID},
+		BatchSize: 1,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, plan.GetSkippedAgents(), "agents are deployed to regardless of their capacity")
+	require.Len(t, plan.GetCapacityWarnings(), 1)
+	assert.Equal(t, agent.ID, plan.GetCapacityWarnings()[0].GetAgentId())
+	assert.Len(t, plan.GetCapacityWarnings()[0].GetWarnings(), 1)
+}
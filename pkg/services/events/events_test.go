@@ -0,0 +1,209 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/pebble/v2"
+	"github.com/cockroachdb/pebble/v2/vfs"
+	"github.com/gorilla/mux"
+	"github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/requestid"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	otelpebble "github.com/otelfleet/otelfleet/pkg/storage/pebble"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestStore(t *testing.T) storage.KeyValue[*v1alpha1.Event] {
+	t.Helper()
+	db, err := pebble.Open("", &pebble.Options{FS: vfs.NewMem()})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	return storage.NewProtoKV[*v1alpha1.Event](slog.Default(), otelpebble.NewKVBroker(db).KeyValue("events"))
+}
+
+func newTestClient(t *testing.T, log *Log) v1alpha1connect.EventServiceClient {
+	t.Helper()
+	router := mux.NewRouter()
+	NewEventServer(slog.Default(), log).ConfigureHTTP(router)
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return v1alpha1connect.NewEventServiceClient(srv.Client(), srv.URL)
+}
+
+func TestListEvents_Filters(t *testing.T) {
+	ctx := t.Context()
+	log, err := NewLog(slog.Default(), newTestStore(t), time.Hour)
+	require.NoError(t, err)
+	client := newTestClient(t, log)
+
+	base := time.Now().Add(-10 * time.Minute)
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentConnected, AgentId: "a", Labels: map[string]string{"env": "prod"}, Time: timestamppb.New(base)})
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentDisconnected, AgentId: "a", Labels: map[string]string{"env": "prod"}, Time: timestamppb.New(base.Add(time.Minute))})
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentConnected, AgentId: "b", Labels: map[string]string{"env": "dev"}, Time: timestamppb.New(base.Add(2 * time.Minute))})
+
+	list := func(filter *v1alpha1.EventFilter) []string {
+		resp, err := client.ListEvents(ctx, connect.NewRequest(&v1alpha1.ListEventsRequest{Filter: filter}))
+		require.NoError(t, err)
+		ret := []string{}
+		for _, ev := range resp.Msg.GetEvents() {
+			ret = append(ret, ev.GetAgentId()+"/"+ev.GetType())
+		}
+		return ret
+	}
+
+	assert.Len(t, list(nil), 3)
+	assert.Equal(t, []string{"b/agent.connected"}, list(&v1alpha1.EventFilter{AgentIds: []string{"b"}}))
+	assert.Equal(t, []string{"a/agent.connected", "b/agent.connected"}, list(&v1alpha1.EventFilter{Types: []string{TypeAgentConnected}}))
+	assert.Equal(t, []string{"a/agent.connected", "a/agent.disconnected"}, list(&v1alpha1.EventFilter{Labels: map[string]string{"env": "prod"}}))
+	assert.Equal(t, []string{"a/agent.disconnected"}, list(&v1alpha1.EventFilter{
+		Start: timestamppb.New(base.Add(time.Minute)),
+		End:   timestamppb.New(base.Add(2 * time.Minute)),
+	}))
+
+	// paging
+	resp, err := client.ListEvents(ctx, connect.NewRequest(&v1alpha1.ListEventsRequest{Limit: 2}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.GetEvents(), 2)
+	require.NotEmpty(t, resp.Msg.GetNextPageToken())
+	resp, err = client.ListEvents(ctx, connect.NewRequest(&v1alpha1.ListEventsRequest{Limit: 2, PageToken: resp.Msg.GetNextPageToken()}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.GetEvents(), 1)
+	assert.Equal(t, "b", resp.Msg.GetEvents()[0].GetAgentId())
+	assert.Empty(t, resp.Msg.GetNextPageToken())
+}
+
+func TestLog_RecordsPrincipal(t *testing.T) {
+	log, err := NewLog(slog.Default(), newTestStore(t), time.Hour)
+	require.NoError(t, err)
+	client := newTestClient(t, log)
+
+	alice := requestid.NewContext(auth.NewContext(t.Context(), &auth.Principal{Subject: "alice"}), "req-1")
+	RecordChange(alice, log, TypeConfigUpdated, "config logs updated", map[string]string{"config_id": "logs"})
+	log.Record(t.Context(), &v1alpha1.Event{Type: TypeAgentConnected, AgentId: "a"})
+
+	resp, err := client.ListEvents(t.Context(), connect.NewRequest(&v1alpha1.ListEventsRequest{
+		Filter: &v1alpha1.EventFilter{Principals: []string{"alice"}},
+	}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.GetEvents(), 1)
+	assert.Equal(t, TypeConfigUpdated, resp.Msg.GetEvents()[0].GetType())
+	assert.Equal(t, "alice", resp.Msg.GetEvents()[0].GetPrincipal())
+	assert.Equal(t, "req-1", resp.Msg.GetEvents()[0].GetRequestId())
+	assert.Equal(t, "logs", resp.Msg.GetEvents()[0].GetLabels()["config_id"])
+}
+
+func TestListEvents_Tenant(t *testing.T) {
+	log, err := NewLog(slog.Default(), newTestStore(t), time.Hour)
+	require.NoError(t, err)
+	router := mux.NewRouter()
+	srv := NewEventServer(slog.Default(), log)
+	srv.AddInterceptors(tenant.Interceptor{})
+	srv.ConfigureHTTP(router)
+	ts := httptest.NewServer(router)
+	t.Cleanup(ts.Close)
+	client := v1alpha1connect.NewEventServiceClient(ts.Client(), ts.URL)
+
+	RecordChange(tenant.NewContext(t.Context(), "team-a"), log, TypeConfigUpdated, "config logs updated", nil)
+	log.Record(t.Context(), &v1alpha1.Event{Type: TypeAgentConnected, AgentId: "a", Tenant: "team-a"})
+	log.Record(t.Context(), &v1alpha1.Event{Type: TypeAgentConnected, AgentId: "b"})
+
+	list := func(name string) []string {
+		req := connect.NewRequest(&v1alpha1.ListEventsRequest{})
+		req.Header().Set(tenant.Header, name)
+		resp, err := client.ListEvents(t.Context(), req)
+		require.NoError(t, err)
+		ret := []string{}
+		for _, ev := range resp.Msg.GetEvents() {
+			ret = append(ret, ev.GetAgentId()+"/"+ev.GetType())
+		}
+		return ret
+	}
+	assert.Equal(t, []string{"/config.updated", "a/agent.connected"}, list("team-a"))
+	assert.Equal(t, []string{"b/agent.connected"}, list(""))
+}
+
+func TestWatchEvents_Resume(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	store := newTestStore(t)
+	log, err := NewLog(slog.Default(), store, time.Hour)
+	require.NoError(t, err)
+	client := newTestClient(t, log)
+
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentConnected, AgentId: "a"})
+
+	// new watchers only receive new events
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	stream, err := client.WatchEvents(watchCtx, connect.NewRequest(&v1alpha1.WatchEventsRequest{}))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		log.mu.Lock()
+		defer log.mu.Unlock()
+		return len(log.subscribers) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentDisconnected, AgentId: "a"})
+	require.True(t, stream.Receive(), stream.Err())
+	assert.Equal(t, TypeAgentDisconnected, stream.Msg().GetEvent().GetType())
+	token := stream.Msg().GetResumeToken()
+	stopWatch()
+	_ = stream.Close()
+
+	// events recorded while disconnected are replayed on resume
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentConnected, AgentId: "b"})
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentConnected, AgentId: "c"})
+
+	// the sequence continues across restarts
+	log, err = NewLog(slog.Default(), store, time.Hour)
+	require.NoError(t, err)
+	client = newTestClient(t, log)
+
+	stream, err = client.WatchEvents(ctx, connect.NewRequest(&v1alpha1.WatchEventsRequest{
+		ResumeToken: token,
+		Filter:      &v1alpha1.EventFilter{AgentIds: []string{"c", "d"}},
+	}))
+	require.NoError(t, err)
+	defer stream.Close()
+	require.True(t, stream.Receive(), stream.Err())
+	assert.Equal(t, "c", stream.Msg().GetEvent().GetAgentId())
+	assert.EqualValues(t, 4, stream.Msg().GetEvent().GetSequence())
+
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentConnected, AgentId: "d"})
+	require.True(t, stream.Receive(), stream.Err())
+	assert.Equal(t, "d", stream.Msg().GetEvent().GetAgentId())
+	assert.EqualValues(t, 5, stream.Msg().GetEvent().GetSequence())
+}
+
+func TestLog_PruneExpiredEvents(t *testing.T) {
+	ctx := t.Context()
+	log, err := NewLog(slog.Default(), newTestStore(t), time.Hour)
+	require.NoError(t, err)
+	client := newTestClient(t, log)
+
+	now := time.Now()
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentConnected, Time: timestamppb.New(now.Add(-2 * time.Hour))})
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentConnected, Time: timestamppb.New(now.Add(-time.Minute))})
+	token := formatToken(0)
+
+	require.NoError(t, log.prune(ctx, now))
+	resp, err := client.ListEvents(ctx, connect.NewRequest(&v1alpha1.ListEventsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.GetEvents(), 1)
+	assert.EqualValues(t, 2, resp.Msg.GetEvents()[0].GetSequence())
+
+	// resuming from before the retained events cannot be done without losing events
+	stream, err := client.WatchEvents(ctx, connect.NewRequest(&v1alpha1.WatchEventsRequest{ResumeToken: token}))
+	require.NoError(t, err)
+	defer stream.Close()
+	assert.False(t, stream.Receive())
+	assert.Equal(t, connect.CodeOutOfRange, connect.CodeOf(stream.Err()))
+}
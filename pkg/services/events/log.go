@@ -0,0 +1,329 @@
+// Package events records notable fleet changes, retains them for a rolling
+// window and serves them over the EventService API.
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/grafana/dskit/services"
+	"github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/requestid"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Event types
+const (
+	TypeAgentRegistered   = "agent.registered"
+	TypeAgentConnected    = "agent.connected"
+	TypeAgentDisconnected = "agent.disconnected"
+	// TypeAgentInstanceChanged is recorded when an agent connects with a new OpAMP instance UID
+	TypeAgentInstanceChanged = "agent.instance_changed"
+	// TypeAgentSessionOrigin is recorded when an agent opens an OpAMP session from an origin
+	// (address, gateway, user agent or client certificate) it wasn't last seen from
+	TypeAgentSessionOrigin = "agent.session_origin"
+	// TypeAgentRestored is recorded when a deleted agent reconnects within the grace period
+	TypeAgentRestored = "agent.restored"
+	// TypeAgentDeleted is recorded when an agent is deleted, and when it is purged
+	TypeAgentDeleted = "agent.deleted"
+	// TypeAgentRevoked is recorded when an agent is revoked
+	TypeAgentRevoked         = "agent.revoked"
+	TypeConfigAssigned       = "config.assigned"
+	TypeConfigUnassigned     = "config.unassigned"
+	TypeConfigUpdated        = "config.updated"
+	TypeConfigDeleted        = "config.deleted"
+	TypePolicyUpdated        = "policy.updated"
+	TypePolicyDeleted        = "policy.deleted"
+	TypeGroupUpdated         = "group.updated"
+	TypeGroupDeleted         = "group.deleted"
+	TypeTokenCreated         = "token.created"
+	TypeTokenDeleted         = "token.deleted"
+	TypeDeploymentStarted    = "deployment.started"
+	TypeDeploymentPaused     = "deployment.paused"
+	TypeDeploymentResumed    = "deployment.resumed"
+	TypeDeploymentCancelled  = "deployment.cancelled"
+	TypeDeploymentPurged     = "deployment.purged"
+	TypeDeploymentRolledBack = "deployment.rolled_back"
+	TypeDistributionUpdated  = "distribution.updated"
+	TypeDistributionDeleted  = "distribution.deleted"
+	TypePackageTargetUpdated = "package_target.updated"
+	TypePackageTargetDeleted = "package_target.deleted"
+
+	// TypeAgentConfigApplied and TypeAgentConfigFailed are recorded when an agent reports a
+	// new remote config status
+	TypeAgentConfigApplied = "agent.config_applied"
+	TypeAgentConfigFailed  = "agent.config_failed"
+	// TypeConfigRolledBack is recorded when an agent failing to apply its config is rolled
+	// back to its last known-good config
+	TypeConfigRolledBack = "config.rolled_back"
+	// TypeAgentHealthChanged is recorded when an agent becomes healthy or unhealthy
+	TypeAgentHealthChanged = "agent.health_changed"
+	// TypeAgentCommandSent and TypeAgentCommandCompleted are recorded when a command, such as
+	// a restart, is sent to an agent and when the agent reports its result
+	TypeAgentCommandSent      = "agent.command_sent"
+	TypeAgentCommandCompleted = "agent.command_completed"
+	// TypeAgentPackageInstalled and TypeAgentPackageFailed are recorded when an agent reports
+	// installing or failing to install the collector package offered to it
+	TypeAgentPackageInstalled = "agent.package_installed"
+	TypeAgentPackageFailed    = "agent.package_failed"
+	// TypeAgentProblemReported and TypeAgentProblemAcknowledged are recorded when an agent
+	// reports a problem, e.g. a crash-looping collector, and when the problem is acknowledged
+	TypeAgentProblemReported     = "agent.problem_reported"
+	TypeAgentProblemAcknowledged = "agent.problem_acknowledged"
+)
+
+const (
+	// DefaultRetention is how long events are kept when no retention is configured
+	DefaultRetention = 24 * time.Hour
+
+	// subscriberBuffer is the number of events buffered per watcher before it is
+	// considered too slow and disconnected
+	subscriberBuffer = 256
+)
+
+// Recorder records fleet events.
+type Recorder interface {
+	Record(ctx context.Context, event *v1alpha1.Event)
+}
+
+// Log persists events for the retention window and fans them out to watchers.
+type Log struct {
+	logger    *slog.Logger
+	store     storage.KeyValue[*v1alpha1.Event]
+	retention time.Duration
+
+	// optional, agent ID/sequence -> event about the agent
+	timelineStore     storage.KeyValue[*v1alpha1.Event]
+	timelineRetention time.Duration
+
+	mu          sync.Mutex
+	sequence    uint64
+	subscribers map[*subscriber]struct{}
+
+	services.Service
+}
+
+var _ Recorder = (*Log)(nil)
+
+type subscriber struct {
+	ch chan *v1alpha1.Event
+}
+
+// NewLog creates an event Log, continuing the sequence of events already in store.
+func NewLog(
+	logger *slog.Logger,
+	store storage.KeyValue[*v1alpha1.Event],
+	retention time.Duration,
+) (*Log, error) {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	keys, err := store.ListKeys(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	l := &Log{
+		logger:      logger,
+		store:       store,
+		retention:   retention,
+		subscribers: map[*subscriber]struct{}{},
+	}
+	if len(keys) > 0 {
+		seq, err := strconv.ParseUint(keys[len(keys)-1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event key %s: %w", keys[len(keys)-1], err)
+		}
+		l.sequence = seq
+	}
+	l.Service = services.NewBasicService(nil, l.running, nil)
+	return l, nil
+}
+
+func (l *Log) running(ctx context.Context) error {
+	t := time.NewTicker(min(l.retention/10, time.Minute))
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			if err := l.prune(ctx, time.Now()); err != nil {
+				l.logger.With("err", err).Error("failed to prune events")
+			}
+			if err := l.pruneTimelines(ctx, time.Now()); err != nil {
+				l.logger.With("err", err).Error("failed to prune agent timelines")
+			}
+		}
+	}
+}
+
+// eventKey zero pads the sequence so that keys sort in sequence order
+func eventKey(sequence uint64) string {
+	return fmt.Sprintf("%020d", sequence)
+}
+
+// Record assigns the event its sequence number, persists it and notifies watchers.
+// Events recorded on behalf of an authenticated caller are attributed to its principal.
+func (l *Log) Record(ctx context.Context, event *v1alpha1.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sequence++
+	event.Sequence = l.sequence
+	if event.Time == nil {
+		event.Time = timestamppb.Now()
+	}
+	if event.Principal == "" {
+		event.Principal = auth.SubjectFromContext(ctx)
+	}
+	if event.RequestId == "" {
+		event.RequestId = requestid.FromContext(ctx)
+	}
+	// agent events are in the tenant of the agent, see RecordAgentEvent
+	if name, ok := tenant.FromContext(ctx); ok && event.AgentId == "" && event.Tenant == "" {
+		event.Tenant = name
+	}
+	if err := l.store.Put(ctx, eventKey(event.Sequence), event); err != nil {
+		l.logger.With("err", err, "type", event.GetType(), "agent_id", event.GetAgentId()).Error("failed to persist event")
+	}
+	l.appendTimeline(ctx, event)
+	for sub := range l.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+			// the watcher is too slow, it has to resume from its last token
+			delete(l.subscribers, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// since returns the retained events with a sequence greater than after, oldest first
+func (l *Log) since(ctx context.Context, after uint64) ([]*v1alpha1.Event, error) {
+	all, err := l.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ret := []*v1alpha1.Event{}
+	for _, ev := range all {
+		if ev != nil && ev.GetSequence() > after {
+			ret = append(ret, ev)
+		}
+	}
+	return ret, nil
+}
+
+// oldest returns the sequence of the oldest retained event, or the next sequence
+// if all events have expired
+func (l *Log) oldest(ctx context.Context) (uint64, error) {
+	keys, err := l.store.ListKeys(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(keys) == 0 {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.sequence + 1, nil
+	}
+	return strconv.ParseUint(keys[0], 10, 64)
+}
+
+// subscribe registers a watcher for new events. The returned channel is closed
+// if the watcher falls behind.
+func (l *Log) subscribe() (*subscriber, func()) {
+	sub := &subscriber{ch: make(chan *v1alpha1.Event, subscriberBuffer)}
+	l.mu.Lock()
+	l.subscribers[sub] = struct{}{}
+	l.mu.Unlock()
+	return sub, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, ok := l.subscribers[sub]; ok {
+			delete(l.subscribers, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Subscribe returns a channel receiving the events recorded from now on, until unsubscribe
+// is called. The channel is closed if the subscriber falls behind.
+func (l *Log) Subscribe() (<-chan *v1alpha1.Event, func()) {
+	sub, unsubscribe := l.subscribe()
+	return sub.ch, unsubscribe
+}
+
+// prune deletes events older than the retention window
+func (l *Log) prune(ctx context.Context, now time.Time) error {
+	all, err := l.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	cutoff := now.Add(-l.retention)
+	pruned := 0
+	for _, ev := range all {
+		if ev == nil {
+			continue
+		}
+		if !ev.GetTime().AsTime().Before(cutoff) {
+			// events are stored in sequence order
+			break
+		}
+		if err := l.store.Delete(ctx, eventKey(ev.GetSequence())); err != nil {
+			return err
+		}
+		pruned++
+	}
+	if pruned > 0 {
+		l.logger.With("count", pruned).Debug("pruned expired events")
+	}
+	return nil
+}
+
+// RecordAgentEvent records an event about an agent, labelled with the agent's attributes, in
+// the tenant of the agent.
+// It is a no-op if recorder is nil.
+func RecordAgentEvent(
+	ctx context.Context,
+	recorder Recorder,
+	repo agentdomain.Repository,
+	eventType, agentID, message string,
+) {
+	if recorder == nil {
+		return
+	}
+	ev := &v1alpha1.Event{
+		Type:    eventType,
+		AgentId: agentID,
+		Message: message,
+	}
+	if a, err := repo.Get(tenant.Unscoped(ctx), agentID); err == nil {
+		ev.Labels = a.Labels()
+		ev.Tenant = a.Tenant
+	}
+	recorder.Record(ctx, ev)
+}
+
+// RecordChange records an event about a change to a managed resource, e.g. a config,
+// labelled with the given labels identifying the resource. It is a no-op if recorder is nil.
+func RecordChange(
+	ctx context.Context,
+	recorder Recorder,
+	eventType, message string,
+	labels map[string]string,
+) {
+	if recorder == nil {
+		return
+	}
+	recorder.Record(ctx, &v1alpha1.Event{
+		Type:    eventType,
+		Labels:  labels,
+		Message: message,
+	})
+}
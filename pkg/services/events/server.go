@@ -0,0 +1,191 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/mux"
+	"github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+)
+
+const (
+	defaultListLimit = 100
+	maxListLimit     = 1000
+)
+
+// EventServer provides the fleet event API.
+// Its lifecycle is that of the underlying Log.
+type EventServer struct {
+	logger       *slog.Logger
+	log          *Log
+	interceptors []connect.Interceptor
+}
+
+var _ v1alpha1connect.EventServiceHandler = (*EventServer)(nil)
+
+// NewEventServer creates a new EventServer serving events from log.
+func NewEventServer(logger *slog.Logger, log *Log) *EventServer {
+	return &EventServer{
+		logger: logger,
+		log:    log,
+	}
+}
+
+// AddInterceptors adds interceptors to the event service handlers.
+// Must be called before ConfigureHTTP.
+func (e *EventServer) AddInterceptors(interceptors ...connect.Interceptor) {
+	e.interceptors = append(e.interceptors, interceptors...)
+}
+
+func (e *EventServer) ConfigureHTTP(mux *mux.Router) {
+	e.logger.Info("configuring routes")
+	v1alpha1connect.RegisterEventServiceHandler(mux, e, connect.WithInterceptors(e.interceptors...))
+}
+
+func (e *EventServer) ListEvents(
+	ctx context.Context, req *connect.Request[v1alpha1.ListEventsRequest],
+) (*connect.Response[v1alpha1.ListEventsResponse], error) {
+	after, err := parseToken(req.Msg.GetPageToken())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid page token: %w", err))
+	}
+	limit := int(req.Msg.GetLimit())
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	limit = min(limit, maxListLimit)
+
+	events, err := e.log.since(ctx, after)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list events: %w", err))
+	}
+	resp := &v1alpha1.ListEventsResponse{}
+	for _, ev := range events {
+		if !tenant.Visible(ctx, ev.GetTenant()) || !matches(req.Msg.GetFilter(), ev) {
+			continue
+		}
+		if len(resp.Events) == limit {
+			resp.NextPageToken = formatToken(resp.Events[limit-1].GetSequence())
+			break
+		}
+		resp.Events = append(resp.Events, ev)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (e *EventServer) WatchEvents(
+	ctx context.Context,
+	req *connect.Request[v1alpha1.WatchEventsRequest],
+	stream *connect.ServerStream[v1alpha1.WatchEventsResponse],
+) error {
+	filter := req.Msg.GetFilter()
+	// subscribe before replaying, so that no event falls between the two
+	sub, unsubscribe := e.log.subscribe()
+	defer unsubscribe()
+	// flush the response headers so the client knows the watch is established
+	if err := stream.Send(nil); err != nil {
+		return err
+	}
+
+	var last uint64
+	if token := req.Msg.GetResumeToken(); token != "" {
+		after, err := parseToken(token)
+		if err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid resume token: %w", err))
+		}
+		oldest, err := e.log.oldest(ctx)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to read events: %w", err))
+		}
+		if oldest > after+1 {
+			return connect.NewError(connect.CodeOutOfRange, fmt.Errorf("resume token %s has expired, events after it are no longer retained", token))
+		}
+		missed, err := e.log.since(ctx, after)
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to read events: %w", err))
+		}
+		last = after
+		for _, ev := range missed {
+			if err := e.send(ctx, stream, filter, ev); err != nil {
+				return err
+			}
+			last = ev.GetSequence()
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-sub.ch:
+			if !ok {
+				return connect.NewError(connect.CodeResourceExhausted, errors.New("watcher fell behind, resume from the last received token"))
+			}
+			if ev.GetSequence() <= last {
+				continue
+			}
+			if err := e.send(ctx, stream, filter, ev); err != nil {
+				return err
+			}
+			last = ev.GetSequence()
+		}
+	}
+}
+
+func (e *EventServer) send(ctx context.Context, stream *connect.ServerStream[v1alpha1.WatchEventsResponse], filter *v1alpha1.EventFilter, ev *v1alpha1.Event) error {
+	if !tenant.Visible(ctx, ev.GetTenant()) || !matches(filter, ev) {
+		return nil
+	}
+	return stream.Send(&v1alpha1.WatchEventsResponse{
+		Event:       ev,
+		ResumeToken: formatToken(ev.GetSequence()),
+	})
+}
+
+// matches returns true if the event satisfies all criteria of the filter
+func matches(filter *v1alpha1.EventFilter, ev *v1alpha1.Event) bool {
+	if filter == nil {
+		return true
+	}
+	t := ev.GetTime().AsTime()
+	if filter.Start != nil && t.Before(filter.GetStart().AsTime()) {
+		return false
+	}
+	if filter.End != nil && !t.Before(filter.GetEnd().AsTime()) {
+		return false
+	}
+	if len(filter.GetAgentIds()) > 0 && !slices.Contains(filter.GetAgentIds(), ev.GetAgentId()) {
+		return false
+	}
+	if len(filter.GetTypes()) > 0 && !slices.Contains(filter.GetTypes(), ev.GetType()) {
+		return false
+	}
+	if len(filter.GetPrincipals()) > 0 && !slices.Contains(filter.GetPrincipals(), ev.GetPrincipal()) {
+		return false
+	}
+	for k, v := range filter.GetLabels() {
+		if ev.GetLabels()[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// tokens are opaque to clients, but encode the sequence of the last event seen
+func formatToken(sequence uint64) string {
+	return strconv.FormatUint(sequence, 10)
+}
+
+func parseToken(token string) (uint64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(token, 10, 64)
+}
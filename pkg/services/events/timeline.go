@@ -0,0 +1,98 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+)
+
+// DefaultTimelineRetention is how long the events of agent timelines are kept when no
+// retention is configured
+const DefaultTimelineRetention = 30 * 24 * time.Hour
+
+// ErrInvalidPageToken is returned for page tokens that weren't returned by a previous page
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// SetAgentTimeline also appends the events recorded about agents to the agent's timeline
+// in store, where they are kept for retention, it defaults to DefaultTimelineRetention.
+// Timelines are keyed by agent ID and sequence. It must be called before the log starts.
+func (l *Log) SetAgentTimeline(store storage.KeyValue[*v1alpha1.Event], retention time.Duration) {
+	if retention <= 0 {
+		retention = DefaultTimelineRetention
+	}
+	l.timelineStore = store
+	l.timelineRetention = retention
+}
+
+func timelinePrefix(agentID string) string {
+	return agentID + "/"
+}
+
+func timelineKey(agentID string, sequence uint64) string {
+	return timelinePrefix(agentID) + eventKey(sequence)
+}
+
+// appendTimeline appends an event to the timeline of its agent, if any
+func (l *Log) appendTimeline(ctx context.Context, event *v1alpha1.Event) {
+	if l.timelineStore == nil || event.GetAgentId() == "" {
+		return
+	}
+	if err := l.timelineStore.Put(ctx, timelineKey(event.GetAgentId(), event.GetSequence()), event); err != nil {
+		l.logger.With("err", err, "type", event.GetType(), "agent_id", event.GetAgentId()).Error("failed to append event to agent timeline")
+	}
+}
+
+// AgentEvents returns up to limit events of the agent's timeline matching the filter, oldest
+// first, starting after the given page token. The returned token is set if there are more.
+func (l *Log) AgentEvents(ctx context.Context, agentID string, filter *v1alpha1.EventFilter, pageToken string, limit int) ([]*v1alpha1.Event, string, error) {
+	after, err := parseToken(pageToken)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", ErrInvalidPageToken, err)
+	}
+	entries, err := l.timelineStore.ListPrefix(ctx, timelinePrefix(agentID))
+	if err != nil {
+		return nil, "", err
+	}
+	events := []*v1alpha1.Event{}
+	for _, entry := range entries {
+		ev := entry.Value
+		if ev == nil || ev.GetSequence() <= after || !matches(filter, ev) {
+			continue
+		}
+		if len(events) == limit {
+			return events, formatToken(events[limit-1].GetSequence()), nil
+		}
+		events = append(events, ev)
+	}
+	return events, "", nil
+}
+
+// pruneTimelines deletes the timeline events older than the timeline retention
+func (l *Log) pruneTimelines(ctx context.Context, now time.Time) error {
+	if l.timelineStore == nil {
+		return nil
+	}
+	entries, err := l.timelineStore.ListPrefix(ctx, "")
+	if err != nil {
+		return err
+	}
+	cutoff := now.Add(-l.timelineRetention)
+	pruned := 0
+	for _, entry := range entries {
+		if entry.Value == nil || !entry.Value.GetTime().AsTime().Before(cutoff) {
+			continue
+		}
+		if err := l.timelineStore.Delete(ctx, entry.Key); err != nil {
+			return err
+		}
+		pruned++
+	}
+	if pruned > 0 {
+		l.logger.With("count", pruned).Debug("pruned expired agent timeline events")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package events
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAgentTimeline(t *testing.T) {
+	ctx := t.Context()
+	log, err := NewLog(slog.Default(), newTestStore(t), time.Hour)
+	require.NoError(t, err)
+	log.SetAgentTimeline(newTestStore(t), 24*time.Hour)
+
+	now := time.Now()
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentConnected, AgentId: "a", Time: timestamppb.New(now.Add(-2 * time.Hour))})
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentConnected, AgentId: "ab", Time: timestamppb.New(now.Add(-2 * time.Hour))})
+	log.Record(ctx, &v1alpha1.Event{Type: TypeConfigUpdated, Time: timestamppb.New(now.Add(-2 * time.Hour))})
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentDisconnected, AgentId: "a", Time: timestamppb.New(now.Add(-time.Hour))})
+	log.Record(ctx, &v1alpha1.Event{Type: TypeAgentConnected, AgentId: "a", Time: timestamppb.New(now.Add(-time.Minute))})
+
+	types := func(evs []*v1alpha1.Event) []string {
+		ret := []string{}
+		for _, ev := range evs {
+			ret = append(ret, ev.GetType())
+		}
+		return ret
+	}
+
+	evs, next, err := log.AgentEvents(ctx, "a", nil, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{TypeAgentConnected, TypeAgentDisconnected, TypeAgentConnected}, types(evs))
+	assert.Empty(t, next)
+
+	evs, _, err = log.AgentEvents(ctx, "a", &v1alpha1.EventFilter{Types: []string{TypeAgentDisconnected}}, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{TypeAgentDisconnected}, types(evs))
+	evs, _, err = log.AgentEvents(ctx, "a", &v1alpha1.EventFilter{Start: timestamppb.New(now.Add(-90 * time.Minute))}, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{TypeAgentDisconnected, TypeAgentConnected}, types(evs))
+
+	// paging
+	evs, next, err = log.AgentEvents(ctx, "a", nil, "", 2)
+	require.NoError(t, err)
+	require.Len(t, evs, 2)
+	require.NotEmpty(t, next)
+	evs, next, err = log.AgentEvents(ctx, "a", nil, next, 2)
+	require.NoError(t, err)
+	require.Len(t, evs, 1)
+	assert.EqualValues(t, 5, evs[0].GetSequence())
+	assert.Empty(t, next)
+	_, _, err = log.AgentEvents(ctx, "a", nil, "not-a-token", 2)
+	assert.ErrorIs(t, err, ErrInvalidPageToken)
+
+	// timelines are pruned separately from the log
+	require.NoError(t, log.pruneTimelines(ctx, now.Add(23*time.Hour+30*time.Minute)))
+	evs, _, err = log.AgentEvents(ctx, "a", nil, "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{TypeAgentConnected}, types(evs))
+	evs, _, err = log.AgentEvents(ctx, "ab", nil, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, evs)
+}
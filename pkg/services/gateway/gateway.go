@@ -0,0 +1,352 @@
+// Package gateway implements regional OpAMP gateways. A gateway terminates the OpAMP
+// connections of the agents of its region and relays their messages to an upstream server
+// through its management API, so that the upstream server tracks and configures them.
+//
+// The desired configs the upstream server sends to agents are cached by the gateway, so
+// that agents connecting or restarting while the upstream server is unreachable are still
+// offered their config. Messages received during an outage are not replayed, the upstream
+// server detects the gap in their sequence numbers and asks the agents for a full report.
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/grafana/dskit/middleware"
+	"github.com/grafana/dskit/services"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/open-telemetry/opamp-go/server"
+	"github.com/open-telemetry/opamp-go/server/types"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/logutil"
+	"github.com/otelfleet/otelfleet/pkg/services/opamp"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultRelayTimeout bounds relaying a message upstream, agents are answered from the
+	// cached configs once it expires
+	DefaultRelayTimeout = 5 * time.Second
+	// maxWatchBackoff caps the wait between attempts to watch the upstream server
+	maxWatchBackoff = 30 * time.Second
+)
+
+// Gateway serves OpAMP to agents and relays their messages to an upstream server
+type Gateway struct {
+	logger   *slog.Logger
+	id       string
+	opampSrv server.OpAMPServer
+	upstream v1alpha1connect.GatewayServiceClient
+	// desired configs last sent by the upstream server, agentID -> remote config
+	configStore  storage.KeyValue[*protobufs.AgentRemoteConfig]
+	relayTimeout time.Duration
+
+	mu sync.Mutex
+	// connection ID -> agent connection
+	conns map[string]*agentConn
+	// opamp connection -> agent connection
+	byConn map[types.Connection]*agentConn
+
+	services.Service
+}
+
+// agentConn is an agent connection to the gateway
+type agentConn struct {
+	id   string
+	conn types.Connection
+
+	mu sync.Mutex
+	// the agent identified on the connection, empty until it describes itself
+	agentID string
+}
+
+func (c *agentConn) getAgentID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.agentID
+}
+
+func (c *agentConn) setAgentID(agentID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.agentID = agentID
+}
+
+// NewGateway returns a gateway identified by id to the upstream server, caching the desired
+// configs of its agents in configStore
+func NewGateway(
+	logger *slog.Logger,
+	id string,
+	upstream v1alpha1connect.GatewayServiceClient,
+	configStore storage.KeyValue[*protobufs.AgentRemoteConfig],
+) *Gateway {
+	g := &Gateway{
+		logger:       logger.With("gateway_id", id),
+		id:           id,
+		opampSrv:     server.New(logutil.NewOpAMPLogger(logger)),
+		upstream:     upstream,
+		configStore:  configStore,
+		relayTimeout: DefaultRelayTimeout,
+		conns:        map[string]*agentConn{},
+		byConn:       map[types.Connection]*agentConn{},
+	}
+	g.Service = services.NewBasicService(nil, g.running, nil)
+	return g
+}
+
+// SetRelayTimeout bounds relaying a message upstream, a timeout <= 0 uses DefaultRelayTimeout
+func (g *Gateway) SetRelayTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultRelayTimeout
+	}
+	g.relayTimeout = timeout
+}
+
+// ConfigureHTTP serves OpAMP at opamp.OpAMPPath on the router of httpServer, wrapped in the
+// given middleware. It must be called before httpServer starts serving.
+func (g *Gateway) ConfigureHTTP(router *mux.Router, httpServer *http.Server, middlewares ...middleware.Interface) error {
+	handler, connContext, err := g.opampSrv.Attach(server.Settings{
+		Callbacks: types.Callbacks{
+			OnConnecting: func(request *http.Request) types.ConnectionResponse {
+				return types.ConnectionResponse{
+					Accept: true,
+					ConnectionCallbacks: types.ConnectionCallbacks{
+						OnConnected:       g.OnConnected,
+						OnMessage:         g.OnMessage,
+						OnConnectionClose: g.OnConnectionClose,
+					},
+				}
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to attach opamp server: %w", err)
+	}
+	httpServer.ConnContext = connContext
+
+	middlewares = append(middlewares, middleware.Func(otelhttp.NewMiddleware("v1/opamp")))
+	router.Handle(opamp.OpAMPPath, middleware.Merge(middlewares...).Wrap(http.HandlerFunc(handler)))
+	g.logger.With("path", opamp.OpAMPPath).Info("serving opamp")
+	return nil
+}
+
+// running watches the upstream server for messages to the agents, until ctx is done
+func (g *Gateway) running(ctx context.Context) error {
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxInterval = maxWatchBackoff
+	bo.MaxElapsedTime = 0
+	for {
+		err := g.watch(ctx, bo.Reset)
+		if ctx.Err() != nil {
+			return nil
+		}
+		wait := bo.NextBackOff()
+		g.logger.With("err", err, "wait", wait).Warn("upstream watch ended, reconnecting")
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+// watch forwards the messages streamed by the upstream server to the agents, calling
+// established once the watch is established
+func (g *Gateway) watch(ctx context.Context, established func()) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := g.upstream.Watch(ctx, connect.NewRequest(&v1alpha1.WatchGatewayRequest{GatewayId: g.id}))
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	established()
+	g.logger.Info("watching upstream server")
+	for stream.Receive() {
+		g.forward(ctx, stream.Msg())
+	}
+	if err := stream.Err(); err != nil {
+		return err
+	}
+	return errors.New("upstream closed the watch")
+}
+
+// forward sends a message streamed by the upstream server to the agent it is addressed to
+func (g *Gateway) forward(ctx context.Context, relayed *v1alpha1.RelayedMessage) {
+	logger := g.logger.With("connection_id", relayed.GetConnectionId())
+	g.mu.Lock()
+	c, ok := g.conns[relayed.GetConnectionId()]
+	g.mu.Unlock()
+	if !ok {
+		logger.Debug("dropping message to a closed connection")
+		return
+	}
+	msg := &protobufs.ServerToAgent{}
+	if err := proto.Unmarshal(relayed.GetServerToAgent(), msg); err != nil {
+		logger.With("err", err).Error("failed to decode relayed message")
+		return
+	}
+	g.cacheConfig(ctx, c.getAgentID(), msg)
+	if err := c.conn.Send(ctx, msg); err != nil {
+		logger.With("err", err).Error("failed to send relayed message to agent")
+	}
+}
+
+func (g *Gateway) OnConnected(ctx context.Context, conn types.Connection) {
+	c := &agentConn{id: uuid.NewString(), conn: conn}
+	g.mu.Lock()
+	g.conns[c.id] = c
+	g.byConn[conn] = c
+	g.mu.Unlock()
+	g.logger.With("connection_id", c.id, "addr", conn.Connection().RemoteAddr().String()).Info("agent connected")
+}
+
+// agentConn returns the agent connection of conn, tracking it if OnConnected wasn't called
+// for it, as for agents using the plain HTTP transport
+func (g *Gateway) agentConn(conn types.Connection) *agentConn {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	c, ok := g.byConn[conn]
+	if !ok {
+		c = &agentConn{id: uuid.NewString(), conn: conn}
+		g.conns[c.id] = c
+		g.byConn[conn] = c
+	}
+	return c
+}
+
+func (g *Gateway) OnMessage(ctx context.Context, conn types.Connection, msg *protobufs.AgentToServer) *protobufs.ServerToAgent {
+	c := g.agentConn(conn)
+	if agentID := agentIDFromDescription(msg.GetAgentDescription()); agentID != "" {
+		c.setAgentID(agentID)
+	}
+	logger := g.logger.With("connection_id", c.id, "agent_id", c.getAgentID())
+
+	replies, err := g.relay(ctx, c, msg)
+	if err != nil {
+		logger.With("err", err).Warn("failed to relay agent message upstream, answering from the cached config")
+		return g.localReply(ctx, c, msg)
+	}
+	if len(replies) == 0 {
+		return &protobufs.ServerToAgent{InstanceUid: msg.GetInstanceUid()}
+	}
+	// messages sent by the upstream server while handling the message precede its reply
+	for _, reply := range replies[:len(replies)-1] {
+		if err := conn.Send(ctx, reply); err != nil {
+			logger.With("err", err).Error("failed to send relayed message to agent")
+		}
+	}
+	return replies[len(replies)-1]
+}
+
+// relay relays a message upstream and returns the messages to send back to the agent
+func (g *Gateway) relay(ctx context.Context, c *agentConn, msg *protobufs.AgentToServer) ([]*protobufs.ServerToAgent, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, g.relayTimeout)
+	defer cancel()
+	resp, err := g.upstream.Relay(ctx, connect.NewRequest(&v1alpha1.RelayRequest{
+		GatewayId:     g.id,
+		ConnectionId:  c.id,
+		AgentId:       c.getAgentID(),
+		AgentToServer: data,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	replies := make([]*protobufs.ServerToAgent, 0, len(resp.Msg.GetServerToAgent()))
+	for _, data := range resp.Msg.GetServerToAgent() {
+		reply := &protobufs.ServerToAgent{}
+		if err := proto.Unmarshal(data, reply); err != nil {
+			return nil, fmt.Errorf("failed to decode relayed message: %w", err)
+		}
+		g.cacheConfig(ctx, c.getAgentID(), reply)
+		replies = append(replies, reply)
+	}
+	return replies, nil
+}
+
+// localReply answers a message the upstream server couldn't be reached for, offering the
+// agent its cached config if it reported running another one
+func (g *Gateway) localReply(ctx context.Context, c *agentConn, msg *protobufs.AgentToServer) *protobufs.ServerToAgent {
+	resp := &protobufs.ServerToAgent{InstanceUid: msg.GetInstanceUid()}
+	agentID := c.getAgentID()
+	status := msg.GetRemoteConfigStatus()
+	if agentID == "" || status == nil {
+		return resp
+	}
+	config, err := g.configStore.Get(ctx, agentID)
+	if err != nil {
+		if !grpcutil.IsErrorNotFound(err) {
+			g.logger.With("agent_id", agentID, "err", err).Error("failed to get cached config")
+		}
+		return resp
+	}
+	if !bytes.Equal(config.GetConfigHash(), status.GetLastRemoteConfigHash()) {
+		g.logger.With("agent_id", agentID).Info("offering cached config to agent")
+		resp.RemoteConfig = config
+	}
+	return resp
+}
+
+// cacheConfig caches the remote config of a message to the agent, if any
+func (g *Gateway) cacheConfig(ctx context.Context, agentID string, msg *protobufs.ServerToAgent) {
+	if agentID == "" || msg.GetRemoteConfig() == nil {
+		return
+	}
+	if err := g.configStore.Put(ctx, agentID, msg.GetRemoteConfig()); err != nil {
+		g.logger.With("agent_id", agentID, "err", err).Error("failed to cache config")
+	}
+}
+
+func (g *Gateway) OnConnectionClose(conn types.Connection) {
+	g.mu.Lock()
+	c, ok := g.byConn[conn]
+	delete(g.byConn, conn)
+	if ok {
+		delete(g.conns, c.id)
+	}
+	g.mu.Unlock()
+	if !ok {
+		return
+	}
+	logger := g.logger.With("connection_id", c.id, "agent_id", c.getAgentID())
+	logger.Info("agent disconnected")
+
+	ctx, cancel := context.WithTimeout(context.Background(), g.relayTimeout)
+	defer cancel()
+	// the upstream server also disconnects the agents of the gateway when its watch ends
+	if _, err := g.upstream.Disconnect(ctx, connect.NewRequest(&v1alpha1.DisconnectRelayedAgentRequest{
+		GatewayId:    g.id,
+		ConnectionId: c.id,
+	})); err != nil {
+		logger.With("err", err).Warn("failed to report agent disconnection upstream")
+	}
+}
+
+// agentIDFromDescription extracts the persistent otelfleet agent ID from the agent description
+func agentIDFromDescription(desc *protobufs.AgentDescription) string {
+	for _, entry := range desc.GetIdentifyingAttributes() {
+		if entry.Key == supervisor.AttributeOtelfleetAgentId {
+			return entry.Value.GetStringValue()
+		}
+	}
+	return ""
+}
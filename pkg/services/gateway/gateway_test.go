@@ -0,0 +1,143 @@
+package gateway_test
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/services"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	agentsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/client"
+	"github.com/otelfleet/otelfleet/pkg/services/gateway"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/otelfleet/otelfleet/pkg/util"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeConn records the messages sent to an agent
+type fakeConn struct {
+	mu   sync.Mutex
+	sent []*protobufs.ServerToAgent
+}
+
+func (c *fakeConn) Connection() net.Conn {
+	conn, _ := net.Pipe()
+	return conn
+}
+
+func (c *fakeConn) Send(_ context.Context, msg *protobufs.ServerToAgent) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, msg)
+	return nil
+}
+
+func (c *fakeConn) Disconnect() error { return nil }
+
+func (c *fakeConn) messages() []*protobufs.ServerToAgent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*protobufs.ServerToAgent(nil), c.sent...)
+}
+
+func newGateway(t *testing.T, upstreamURL string, configStore storage.KeyValue[*protobufs.AgentRemoteConfig]) *gateway.Gateway {
+	t.Helper()
+	upstream, err := client.New(client.Config{ServerURL: upstreamURL, BearerToken: testutil.TestGatewayToken, MaxRetries: -1})
+	require.NoError(t, err)
+	gw := gateway.NewGateway(slog.Default(), testutil.TestGatewayID, upstream.Gateway, configStore)
+	gw.SetRelayTimeout(time.Second)
+	return gw
+}
+
+func agentMessage(agentID string, seq uint64, hash []byte) *protobufs.AgentToServer {
+	return &protobufs.AgentToServer{
+		InstanceUid: []byte(agentID),
+		SequenceNum: seq,
+		AgentDescription: &protobufs.AgentDescription{
+			IdentifyingAttributes: []*protobufs.KeyValue{{
+				Key:   supervisor.AttributeOtelfleetAgentId,
+				Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: agentID}},
+			}},
+		},
+		RemoteConfigStatus: &protobufs.RemoteConfigStatus{LastRemoteConfigHash: hash},
+	}
+}
+
+func TestGateway(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := t.Context()
+	require.NoError(t, env.AgentRepo.Register(ctx, "agent-1", "agent-1"))
+	require.NoError(t, env.ConfigStore.Put(ctx, "base", &configv1alpha1.Config{Config: []byte("receivers: {otlp: {}}")}))
+	_, err := env.ConfigServer.AssignConfig(ctx, connect.NewRequest(&configv1alpha1.AssignConfigRequest{AgentId: "agent-1", ConfigId: "base"}))
+	require.NoError(t, err)
+
+	configStore := storage.NewProtoKV[*protobufs.AgentRemoteConfig](env.Logger, memory.NewKVBroker().KeyValue("gateway-configs"))
+	gw := newGateway(t, env.BaseURL, configStore)
+	require.NoError(t, services.StartAndAwaitRunning(ctx, gw))
+	t.Cleanup(func() { require.NoError(t, services.StopAndAwaitTerminated(context.Background(), gw)) })
+
+	// the agent is relayed upstream and sent its assigned config
+	conn := &fakeConn{}
+	gw.OnConnected(ctx, conn)
+	reply := gw.OnMessage(ctx, conn, agentMessage("agent-1", 0, nil))
+	require.Nil(t, reply.GetErrorResponse())
+	require.Len(t, conn.messages(), 1)
+	config := conn.messages()[0].GetRemoteConfig()
+	require.NotNil(t, config)
+	assert.Contains(t, string(config.GetConfig().GetConfigMap()["config.yaml"].GetBody()), "otlp")
+	cached, err := configStore.Get(ctx, "agent-1")
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(config, cached))
+	state, err := env.OpampServer.GetConnectionState(ctx, "agent-1")
+	require.NoError(t, err)
+	assert.Equal(t, agentsv1alpha1.AgentState_AGENT_STATE_CONNECTED, state.GetState())
+
+	// config pushes are streamed to the gateway
+	require.NoError(t, env.ConfigStore.Put(ctx, "debug", &configv1alpha1.Config{Config: []byte("receivers: {otlp: {}}\nexporters: {debug: {}}")}))
+	_, err = env.ConfigServer.AssignConfig(ctx, connect.NewRequest(&configv1alpha1.AssignConfigRequest{AgentId: "agent-1", ConfigId: "debug"}))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		if len(conn.messages()) > 1 {
+			return true
+		}
+		// the push is lost if the gateway isn't watching yet
+		env.OpampServer.NotifyConfigChange("agent-1")
+		return false
+	}, 5*time.Second, 50*time.Millisecond)
+	pushed := conn.messages()[1].GetRemoteConfig()
+	assert.Contains(t, string(pushed.GetConfig().GetConfigMap()["config.yaml"].GetBody()), "debug")
+	require.Eventually(t, func() bool {
+		cached, err := configStore.Get(ctx, "agent-1")
+		return err == nil && proto.Equal(pushed, cached)
+	}, time.Second, 10*time.Millisecond)
+
+	// agents reconnecting during an upstream outage are offered their cached config
+	offline := newGateway(t, "http://127.0.0.1:1", configStore)
+	offlineConn := &fakeConn{}
+	offline.OnConnected(ctx, offlineConn)
+	reply = offline.OnMessage(ctx, offlineConn, agentMessage("agent-1", 0, hashOf(config)))
+	assert.True(t, proto.Equal(pushed, reply.GetRemoteConfig()))
+	reply = offline.OnMessage(ctx, offlineConn, agentMessage("agent-1", 1, pushed.GetConfigHash()))
+	assert.Nil(t, reply.GetRemoteConfig())
+
+	// closed connections are disconnected upstream
+	gw.OnConnectionClose(conn)
+	state, err = env.OpampServer.GetConnectionState(ctx, "agent-1")
+	require.NoError(t, err)
+	assert.Equal(t, agentsv1alpha1.AgentState_AGENT_STATE_DISCONNECTED, state.GetState())
+}
+
+func hashOf(config *protobufs.AgentRemoteConfig) []byte {
+	return util.HashAgentConfigMap(config.GetConfig())
+}
@@ -0,0 +1,559 @@
+// Package jobs executes background operations, such as rolling deployments, on a pool of
+// workers. Jobs are persisted so that they are visible through the job API and resume when
+// the server restarts, and are retried with exponential backoff when they fail.
+package jobs
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grafana/dskit/services"
+	"github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/parallel"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// DefaultWorkers is the number of jobs run concurrently unless configured otherwise
+	DefaultWorkers = 4
+	// DefaultRetention is how long finished jobs are kept unless configured otherwise
+	DefaultRetention = 7 * 24 * time.Hour
+
+	// pollInterval is how often pending jobs are checked for, e.g. retries becoming due
+	pollInterval  = time.Second
+	pruneInterval = time.Hour
+)
+
+// ErrJobFinished is returned when cancelling a job that already finished
+var ErrJobFinished = errors.New("job already finished")
+
+// Handler runs a job, returning its result, if any. Handlers must return when ctx is
+// cancelled, which happens when the job is cancelled or the server shuts down; jobs
+// interrupted by a shutdown run again once the server restarts, so handlers should
+// pick up where they stopped.
+type Handler func(ctx context.Context, job *v1alpha1.Job) (proto.Message, error)
+
+// RetryPolicy configures how failed jobs are retried
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a job is run before it fails, defaults to 3
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, doubling on each attempt up to
+	// MaxBackoff. Defaults to 1s and 1m.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy retries failed jobs twice
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Second,
+	MaxBackoff:     time.Minute,
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = DefaultRetryPolicy.InitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = DefaultRetryPolicy.MaxBackoff
+	}
+	return p
+}
+
+// backoff returns the wait before retrying a job that failed its attempt-th run
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, p.MaxBackoff)
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks a job error as permanent, so that the job fails without being retried
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent returns true if err was marked with Permanent
+func IsPermanent(err error) bool {
+	var perm *permanentError
+	return errors.As(err, &perm)
+}
+
+// Config configures a Queue
+type Config struct {
+	// Workers is the number of jobs run concurrently, defaults to DefaultWorkers
+	Workers int
+	// Retention is how long finished jobs are kept, defaults to DefaultRetention
+	Retention time.Duration
+	// Concurrency bounds the jobs deleted in parallel when pruning, defaults to
+	// parallel.DefaultLimit
+	Concurrency int
+}
+
+// Leadership elects the replica running jobs among the replicas of a server sharing their
+// storage (typically a leader.Elector), so that jobs such as rolling deployments don't run
+// twice
+type Leadership interface {
+	// Lead runs fn whenever the replica leads until ctx is done, cancelling the context of fn
+	// once it stops leading
+	Lead(ctx context.Context, fn func(ctx context.Context))
+}
+
+type registration struct {
+	handler Handler
+	policy  RetryPolicy
+}
+
+// runningJob is a job being run by a worker
+type runningJob struct {
+	cancel    context.CancelFunc
+	cancelled bool
+	done      chan struct{}
+}
+
+// Queue is a persistent queue of jobs, run by a pool of workers while the service is running.
+// Jobs can be enqueued before the service starts.
+type Queue struct {
+	logger      *slog.Logger
+	store       storage.KeyValue[*v1alpha1.Job]
+	workers     int
+	retention   time.Duration
+	concurrency int
+	// optional, only the leading replica runs jobs
+	leadership Leadership
+
+	// mu guards the handlers, the running jobs and state transitions of pending jobs
+	mu       sync.Mutex
+	handlers map[string]registration
+	active   map[string]*runningJob
+	wg       sync.WaitGroup
+	wake     chan struct{}
+	// due jobs left waiting for a worker by the last dispatch
+	queued int
+
+	services.Service
+}
+
+// NewQueue creates a new Queue persisting jobs to store
+func NewQueue(logger *slog.Logger, store storage.KeyValue[*v1alpha1.Job], cfg Config) *Queue {
+	q := &Queue{
+		logger:      logger,
+		store:       store,
+		workers:     cfg.Workers,
+		retention:   cfg.Retention,
+		concurrency: cfg.Concurrency,
+		handlers:    map[string]registration{},
+		active:      map[string]*runningJob{},
+		wake:        make(chan struct{}, 1),
+	}
+	if q.workers <= 0 {
+		q.workers = DefaultWorkers
+	}
+	if q.retention <= 0 {
+		q.retention = DefaultRetention
+	}
+	q.Service = services.NewBasicService(q.starting, q.running, q.stopping)
+	return q
+}
+
+// RegisterMetrics registers the metrics of the workers running jobs
+func (q *Queue) RegisterMetrics(reg prometheus.Registerer) {
+	reg.MustRegister(
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "otelfleet_jobs_active_workers",
+			Help: "Workers running a job.",
+		}, func() float64 {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			return float64(len(q.active))
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "otelfleet_jobs_queued",
+			Help: "Jobs due to run waiting for a worker.",
+		}, func() float64 {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			return float64(q.queued)
+		}),
+	)
+}
+
+// SetLeadership makes the queue only run jobs while the replica leads the replicas sharing
+// its storage. Jobs can be enqueued and cancelled through any replica, jobs cancelled
+// through another replica are interrupted on the next dispatch of the leader.
+func (q *Queue) SetLeadership(l Leadership) {
+	q.leadership = l
+}
+
+// Register sets the handler running jobs of the given type, and how they are retried
+func (q *Queue) Register(jobType string, handler Handler, policy RetryPolicy) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = registration{handler: handler, policy: policy.withDefaults()}
+}
+
+// EnqueueOption configures an enqueued job
+type EnqueueOption func(job *v1alpha1.Job)
+
+// WithID sets the ID of the job instead of generating one, e.g. to share the ID of the
+// object the job operates on
+func WithID(id string) EnqueueOption {
+	return func(job *v1alpha1.Job) {
+		job.Id = id
+	}
+}
+
+// Enqueue persists a job of the given type, to be run on behalf of the principal of ctx
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload proto.Message, opts ...EnqueueOption) (*v1alpha1.Job, error) {
+	q.mu.Lock()
+	reg, ok := q.handlers[jobType]
+	q.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no handler registered for job type %s", jobType)
+	}
+	packed, err := anypb.New(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job payload: %w", err)
+	}
+	now := timestamppb.Now()
+	job := &v1alpha1.Job{
+		Id:          uuid.New().String(),
+		Type:        jobType,
+		State:       v1alpha1.JobState_JOB_STATE_PENDING,
+		Payload:     packed,
+		MaxAttempts: int32(reg.policy.MaxAttempts),
+		CreatedAt:   now,
+		NextRunAt:   now,
+	}
+	if p := auth.FromContext(ctx); p != nil {
+		job.Principal = p.Subject
+		job.Roles = p.Roles
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	if err := q.store.Put(ctx, job.GetId(), job); err != nil {
+		return nil, fmt.Errorf("failed to store job: %w", err)
+	}
+	q.logger.With("job_id", job.GetId(), "type", jobType).Debug("enqueued job")
+	q.notify()
+	return job, nil
+}
+
+// Get returns a job by ID
+func (q *Queue) Get(ctx context.Context, id string) (*v1alpha1.Job, error) {
+	return q.store.Get(ctx, id)
+}
+
+// List returns the jobs of the given types and states, all of them if unset, most
+// recently created first
+func (q *Queue) List(ctx context.Context, types []string, states []v1alpha1.JobState) ([]*v1alpha1.Job, error) {
+	all, err := q.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobs := slices.DeleteFunc(all, func(job *v1alpha1.Job) bool {
+		return (len(types) > 0 && !slices.Contains(types, job.GetType())) ||
+			(len(states) > 0 && !slices.Contains(states, job.GetState()))
+	})
+	slices.SortFunc(jobs, func(a, b *v1alpha1.Job) int {
+		return b.GetCreatedAt().AsTime().Compare(a.GetCreatedAt().AsTime())
+	})
+	return jobs, nil
+}
+
+// IsRunning returns true if a worker is running the job
+func (q *Queue) IsRunning(id string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, ok := q.active[id]
+	return ok
+}
+
+// Cancel cancels a pending or running job. Running jobs are interrupted, and Cancel returns
+// once their handler returned. Returns ErrJobFinished if the job already finished.
+func (q *Queue) Cancel(ctx context.Context, id string) (*v1alpha1.Job, error) {
+	q.mu.Lock()
+	if r, ok := q.active[id]; ok {
+		r.cancelled = true
+		r.cancel()
+		q.mu.Unlock()
+		select {
+		case <-r.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return q.store.Get(ctx, id)
+	}
+	defer q.mu.Unlock()
+	job, err := q.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	runningElsewhere := q.leadership != nil && job.GetState() == v1alpha1.JobState_JOB_STATE_RUNNING
+	if job.GetState() != v1alpha1.JobState_JOB_STATE_PENDING && !runningElsewhere {
+		return nil, ErrJobFinished
+	}
+	job.State = v1alpha1.JobState_JOB_STATE_CANCELLED
+	job.CompletedAt = timestamppb.Now()
+	job.NextRunAt = nil
+	if err := q.store.Put(ctx, id, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// starting requeues the jobs that were running when the server stopped. With a leadership,
+// they are requeued once the replica leads, since another replica may be running them.
+func (q *Queue) starting(ctx context.Context) error {
+	if q.leadership != nil {
+		return nil
+	}
+	return q.requeueInterrupted(ctx)
+}
+
+// requeueInterrupted requeues the jobs left running by a server that stopped
+func (q *Queue) requeueInterrupted(ctx context.Context) error {
+	jobs, err := q.store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, job := range jobs {
+		if job.GetState() != v1alpha1.JobState_JOB_STATE_RUNNING {
+			continue
+		}
+		q.logger.With("job_id", job.GetId(), "type", job.GetType()).Info("resuming interrupted job")
+		job.State = v1alpha1.JobState_JOB_STATE_PENDING
+		job.NextRunAt = timestamppb.Now()
+		if err := q.store.Put(ctx, job.GetId(), job); err != nil {
+			return fmt.Errorf("failed to requeue job %s: %w", job.GetId(), err)
+		}
+	}
+	return nil
+}
+
+// running dispatches due jobs to workers until the service stops, or while the replica leads
+func (q *Queue) running(ctx context.Context) error {
+	if q.leadership == nil {
+		q.work(ctx)
+		return nil
+	}
+	q.leadership.Lead(ctx, func(ctx context.Context) {
+		q.logger.Info("running jobs while leading")
+		// the jobs of the previous leader were interrupted once it stopped leading
+		if err := q.requeueInterrupted(ctx); err != nil {
+			q.logger.With("err", err).Error("failed to requeue interrupted jobs")
+		}
+		q.work(ctx)
+		// the running jobs were interrupted, and are resumed by the next leader
+		q.wg.Wait()
+		q.logger.Info("stopped running jobs")
+	})
+	return nil
+}
+
+// work dispatches due jobs to workers until ctx is done
+func (q *Queue) work(ctx context.Context) {
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	prune := time.NewTicker(pruneInterval)
+	defer prune.Stop()
+	for {
+		if err := q.dispatch(ctx, time.Now()); err != nil {
+			q.logger.With("err", err).Error("failed to dispatch jobs")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-poll.C:
+		case <-prune.C:
+			if err := q.prune(ctx, time.Now()); err != nil {
+				q.logger.With("err", err).Error("failed to prune jobs")
+			}
+		}
+	}
+}
+
+func (q *Queue) stopping(_ error) error {
+	// running jobs were interrupted by the service context, and are resumed on restart
+	q.wg.Wait()
+	return nil
+}
+
+// dispatch starts the pending jobs that are due, oldest first, while workers are available
+func (q *Queue) dispatch(ctx context.Context, now time.Time) error {
+	jobs, err := q.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	q.interruptCancelled(jobs)
+	jobs = slices.DeleteFunc(jobs, func(job *v1alpha1.Job) bool {
+		return job.GetState() != v1alpha1.JobState_JOB_STATE_PENDING || job.GetNextRunAt().AsTime().After(now)
+	})
+	slices.SortFunc(jobs, func(a, b *v1alpha1.Job) int {
+		return cmp.Or(
+			a.GetCreatedAt().AsTime().Compare(b.GetCreatedAt().AsTime()),
+			cmp.Compare(a.GetId(), b.GetId()),
+		)
+	})
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.queued = len(jobs)
+	for _, job := range jobs {
+		if len(q.active) >= q.workers {
+			return nil
+		}
+		if _, ok := q.active[job.GetId()]; ok {
+			continue
+		}
+		reg, ok := q.handlers[job.GetType()]
+		if !ok {
+			continue
+		}
+		// the job may have been cancelled since it was listed
+		job, err := q.store.Get(ctx, job.GetId())
+		if err != nil || job.GetState() != v1alpha1.JobState_JOB_STATE_PENDING {
+			continue
+		}
+		job.State = v1alpha1.JobState_JOB_STATE_RUNNING
+		job.Attempts++
+		if job.GetStartedAt() == nil {
+			job.StartedAt = timestamppb.New(now)
+		}
+		job.NextRunAt = nil
+		if err := q.store.Put(ctx, job.GetId(), job); err != nil {
+			return fmt.Errorf("failed to start job %s: %w", job.GetId(), err)
+		}
+
+		jobCtx := ctx
+		if job.GetPrincipal() != "" {
+			jobCtx = auth.NewContext(ctx, &auth.Principal{Subject: job.GetPrincipal(), Roles: job.GetRoles()})
+		}
+		jobCtx, cancel := context.WithCancel(jobCtx)
+		r := &runningJob{cancel: cancel, done: make(chan struct{})}
+		q.active[job.GetId()] = r
+		q.queued--
+		q.wg.Add(1)
+		go q.run(ctx, jobCtx, job, reg, r)
+	}
+	return nil
+}
+
+// interruptCancelled interrupts the running jobs that were cancelled through another replica
+func (q *Queue) interruptCancelled(jobs []*v1alpha1.Job) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, job := range jobs {
+		if r, ok := q.active[job.GetId()]; ok && !r.cancelled && job.GetState() == v1alpha1.JobState_JOB_STATE_CANCELLED {
+			r.cancelled = true
+			r.cancel()
+		}
+	}
+}
+
+// run runs a job and records its outcome
+func (q *Queue) run(ctx, jobCtx context.Context, job *v1alpha1.Job, reg registration, r *runningJob) {
+	defer q.wg.Done()
+	lg := q.logger.With("job_id", job.GetId(), "type", job.GetType(), "attempt", job.GetAttempts())
+	lg.Debug("running job")
+	result, err := reg.handler(jobCtx, job)
+
+	q.mu.Lock()
+	defer func() {
+		delete(q.active, job.GetId())
+		r.cancel()
+		close(r.done)
+		q.mu.Unlock()
+		q.notify()
+	}()
+
+	now := time.Now()
+	switch {
+	case r.cancelled:
+		lg.Info("job cancelled")
+		job.State = v1alpha1.JobState_JOB_STATE_CANCELLED
+		job.CompletedAt = timestamppb.New(now)
+	case err == nil:
+		job.State = v1alpha1.JobState_JOB_STATE_SUCCEEDED
+		job.CompletedAt = timestamppb.New(now)
+		job.LastError = ""
+		if result != nil {
+			if job.Result, err = anypb.New(result); err != nil {
+				lg.With("err", err).Warn("failed to encode job result")
+			}
+		}
+	case ctx.Err() != nil:
+		// interrupted by a shutdown or the replica no longer leading, this attempt doesn't count
+		lg.Info("job interrupted, it will resume on restart or on the next leader")
+		job.State = v1alpha1.JobState_JOB_STATE_PENDING
+		job.Attempts--
+		job.NextRunAt = timestamppb.New(now)
+	case IsPermanent(err) || int(job.GetAttempts()) >= reg.policy.MaxAttempts:
+		lg.With("err", err).Error("job failed")
+		job.State = v1alpha1.JobState_JOB_STATE_FAILED
+		job.CompletedAt = timestamppb.New(now)
+		job.LastError = err.Error()
+	default:
+		backoff := reg.policy.backoff(int(job.GetAttempts()))
+		lg.With("err", err, "backoff", backoff).Warn("job failed, retrying")
+		job.State = v1alpha1.JobState_JOB_STATE_PENDING
+		job.LastError = err.Error()
+		job.NextRunAt = timestamppb.New(now.Add(backoff))
+	}
+	if err := q.store.Put(context.WithoutCancel(ctx), job.GetId(), job); err != nil {
+		lg.With("err", err).Error("failed to record job outcome")
+	}
+}
+
+// prune deletes the jobs that finished before the retention period
+func (q *Queue) prune(ctx context.Context, now time.Time) error {
+	jobs, err := q.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	cutoff := now.Add(-q.retention)
+	jobs = slices.DeleteFunc(jobs, func(job *v1alpha1.Job) bool {
+		return job.GetCompletedAt() == nil || job.GetCompletedAt().AsTime().After(cutoff)
+	})
+	return parallel.ForEach(ctx, q.concurrency, jobs, func(ctx context.Context, job *v1alpha1.Job) error {
+		if err := q.store.Delete(ctx, job.GetId()); err != nil {
+			return fmt.Errorf("failed to delete job %s: %w", job.GetId(), err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,324 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/cockroachdb/pebble/v2"
+	"github.com/cockroachdb/pebble/v2/vfs"
+	"github.com/grafana/dskit/services"
+	"github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/services/jobs"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	otelpebble "github.com/otelfleet/otelfleet/pkg/storage/pebble"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newTestStore(t *testing.T) storage.KeyValue[*v1alpha1.Job] {
+	t.Helper()
+	db, err := pebble.Open("", &pebble.Options{FS: vfs.NewMem()})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return storage.NewProtoKV[*v1alpha1.Job](slog.Default(), otelpebble.NewKVBroker(db).KeyValue("jobs"))
+}
+
+func startQueue(t *testing.T, q *jobs.Queue) {
+	t.Helper()
+	require.NoError(t, services.StartAndAwaitRunning(t.Context(), q))
+	t.Cleanup(func() {
+		require.NoError(t, services.StopAndAwaitTerminated(context.Background(), q))
+	})
+}
+
+func waitForState(t *testing.T, q *jobs.Queue, id string, state v1alpha1.JobState) *v1alpha1.Job {
+	t.Helper()
+	var job *v1alpha1.Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = q.Get(t.Context(), id)
+		require.NoError(t, err)
+		return job.GetState() == state
+	}, 10*time.Second, 10*time.Millisecond)
+	return job
+}
+
+// testLeadership leads for the terms sent on it, each ending once it is closed
+type testLeadership chan chan struct{}
+
+func (l testLeadership) Lead(ctx context.Context, fn func(ctx context.Context)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case term := <-l:
+			termCtx, cancel := context.WithCancel(ctx)
+			go func() {
+				select {
+				case <-term:
+					cancel()
+				case <-termCtx.Done():
+				}
+			}()
+			fn(termCtx)
+			cancel()
+		}
+	}
+}
+
+func TestQueue(t *testing.T) {
+	ctx := auth.NewContext(t.Context(), &auth.Principal{Subject: "alice", Roles: []string{"ops"}})
+	fastRetry := jobs.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	t.Run("runs jobs on behalf of the principal that enqueued them", func(t *testing.T) {
+		q := jobs.NewQueue(slog.Default(), newTestStore(t), jobs.Config{})
+		q.Register("echo", func(ctx context.Context, job *v1alpha1.Job) (proto.Message, error) {
+			in := &wrapperspb.StringValue{}
+			require.NoError(t, job.GetPayload().UnmarshalTo(in))
+			return wrapperspb.String(in.GetValue() + " from " + auth.SubjectFromContext(ctx)), nil
+		}, jobs.DefaultRetryPolicy)
+		startQueue(t, q)
+
+		job, err := q.Enqueue(ctx, "echo", wrapperspb.String("hello"))
+		require.NoError(t, err)
+		assert.Equal(t, "alice", job.GetPrincipal())
+		assert.Equal(t, int32(3), job.GetMaxAttempts())
+
+		job = waitForState(t, q, job.GetId(), v1alpha1.JobState_JOB_STATE_SUCCEEDED)
+		out := &wrapperspb.StringValue{}
+		require.NoError(t, job.GetResult().UnmarshalTo(out))
+		assert.Equal(t, "hello from alice", out.GetValue())
+		assert.Equal(t, int32(1), job.GetAttempts())
+		assert.NotNil(t, job.GetCompletedAt())
+
+		_, err = q.Enqueue(ctx, "unknown", wrapperspb.String("hello"))
+		assert.Error(t, err)
+	})
+
+	t.Run("retries failed jobs", func(t *testing.T) {
+		q := jobs.NewQueue(slog.Default(), newTestStore(t), jobs.Config{})
+		var runs atomic.Int32
+		q.Register("flaky", func(context.Context, *v1alpha1.Job) (proto.Message, error) {
+			if runs.Add(1) < 2 {
+				return nil, errors.New("transient")
+			}
+			return nil, nil
+		}, fastRetry)
+		q.Register("broken", func(context.Context, *v1alpha1.Job) (proto.Message, error) {
+			return nil, errors.New("still broken")
+		}, fastRetry)
+		q.Register("invalid", func(context.Context, *v1alpha1.Job) (proto.Message, error) {
+			return nil, jobs.Permanent(errors.New("invalid payload"))
+		}, fastRetry)
+		startQueue(t, q)
+
+		flaky, err := q.Enqueue(ctx, "flaky", wrapperspb.String(""))
+		require.NoError(t, err)
+		broken, err := q.Enqueue(ctx, "broken", wrapperspb.String(""))
+		require.NoError(t, err)
+		invalid, err := q.Enqueue(ctx, "invalid", wrapperspb.String(""))
+		require.NoError(t, err)
+
+		flaky = waitForState(t, q, flaky.GetId(), v1alpha1.JobState_JOB_STATE_SUCCEEDED)
+		assert.Equal(t, int32(2), flaky.GetAttempts())
+
+		broken = waitForState(t, q, broken.GetId(), v1alpha1.JobState_JOB_STATE_FAILED)
+		assert.Equal(t, int32(3), broken.GetAttempts())
+		assert.Equal(t, "still broken", broken.GetLastError())
+
+		invalid = waitForState(t, q, invalid.GetId(), v1alpha1.JobState_JOB_STATE_FAILED)
+		assert.Equal(t, int32(1), invalid.GetAttempts())
+
+		failed, err := q.List(ctx, nil, []v1alpha1.JobState{v1alpha1.JobState_JOB_STATE_FAILED})
+		require.NoError(t, err)
+		assert.Len(t, failed, 2)
+		flakyJobs, err := q.List(ctx, []string{"flaky"}, nil)
+		require.NoError(t, err)
+		assert.Len(t, flakyJobs, 1)
+	})
+
+	t.Run("cancels pending and running jobs", func(t *testing.T) {
+		q := jobs.NewQueue(slog.Default(), newTestStore(t), jobs.Config{Workers: 1})
+		started := make(chan struct{})
+		q.Register("block", func(ctx context.Context, _ *v1alpha1.Job) (proto.Message, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}, jobs.DefaultRetryPolicy)
+		startQueue(t, q)
+
+		running, err := q.Enqueue(ctx, "block", wrapperspb.String(""))
+		require.NoError(t, err)
+		<-started
+		// the only worker is busy, so this one stays pending
+		pending, err := q.Enqueue(ctx, "block", wrapperspb.String(""), jobs.WithID("pending"))
+		require.NoError(t, err)
+		assert.Equal(t, "pending", pending.GetId())
+
+		pending, err = q.Cancel(ctx, pending.GetId())
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.JobState_JOB_STATE_CANCELLED, pending.GetState())
+
+		assert.True(t, q.IsRunning(running.GetId()))
+		running, err = q.Cancel(ctx, running.GetId())
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.JobState_JOB_STATE_CANCELLED, running.GetState())
+		assert.False(t, q.IsRunning(running.GetId()))
+
+		_, err = q.Cancel(ctx, running.GetId())
+		assert.ErrorIs(t, err, jobs.ErrJobFinished)
+	})
+
+	t.Run("reports active workers and queued jobs", func(t *testing.T) {
+		q := jobs.NewQueue(slog.Default(), newTestStore(t), jobs.Config{Workers: 1})
+		reg := prometheus.NewRegistry()
+		q.RegisterMetrics(reg)
+		release := make(chan struct{})
+		q.Register("block", func(ctx context.Context, _ *v1alpha1.Job) (proto.Message, error) {
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+			return nil, nil
+		}, jobs.DefaultRetryPolicy)
+		startQueue(t, q)
+
+		gauge := func(name string) float64 {
+			families, err := reg.Gather()
+			require.NoError(t, err)
+			for _, family := range families {
+				if family.GetName() == name {
+					return family.GetMetric()[0].GetGauge().GetValue()
+				}
+			}
+			t.Fatalf("metric %s not found", name)
+			return 0
+		}
+		first, err := q.Enqueue(ctx, "block", wrapperspb.String(""))
+		require.NoError(t, err)
+		second, err := q.Enqueue(ctx, "block", wrapperspb.String(""))
+		require.NoError(t, err)
+		require.Eventually(t, func() bool {
+			return gauge("otelfleet_jobs_active_workers") == 1 && gauge("otelfleet_jobs_queued") == 1
+		}, 10*time.Second, 10*time.Millisecond)
+
+		close(release)
+		waitForState(t, q, first.GetId(), v1alpha1.JobState_JOB_STATE_SUCCEEDED)
+		waitForState(t, q, second.GetId(), v1alpha1.JobState_JOB_STATE_SUCCEEDED)
+		require.Eventually(t, func() bool {
+			return gauge("otelfleet_jobs_active_workers") == 0 && gauge("otelfleet_jobs_queued") == 0
+		}, 10*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("resumes jobs interrupted by a restart", func(t *testing.T) {
+		store := newTestStore(t)
+		first := jobs.NewQueue(slog.Default(), store, jobs.Config{})
+		started := make(chan struct{})
+		first.Register("resumable", func(ctx context.Context, _ *v1alpha1.Job) (proto.Message, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}, jobs.DefaultRetryPolicy)
+		require.NoError(t, services.StartAndAwaitRunning(ctx, first))
+		job, err := first.Enqueue(ctx, "resumable", wrapperspb.String(""))
+		require.NoError(t, err)
+		<-started
+		require.NoError(t, services.StopAndAwaitTerminated(ctx, first))
+
+		job, err = first.Get(ctx, job.GetId())
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.JobState_JOB_STATE_PENDING, job.GetState())
+		assert.Equal(t, int32(0), job.GetAttempts())
+
+		second := jobs.NewQueue(slog.Default(), store, jobs.Config{})
+		second.Register("resumable", func(context.Context, *v1alpha1.Job) (proto.Message, error) {
+			return nil, nil
+		}, jobs.DefaultRetryPolicy)
+		startQueue(t, second)
+		waitForState(t, second, job.GetId(), v1alpha1.JobState_JOB_STATE_SUCCEEDED)
+	})
+}
+
+func TestLeadership(t *testing.T) {
+	store := newTestStore(t)
+	started := make(chan string, 1)
+	newReplica := func(name string) (*jobs.Queue, testLeadership) {
+		q := jobs.NewQueue(slog.Default(), store, jobs.Config{})
+		leadership := make(testLeadership)
+		q.SetLeadership(leadership)
+		q.Register("block", func(ctx context.Context, _ *v1alpha1.Job) (proto.Message, error) {
+			started <- name
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}, jobs.DefaultRetryPolicy)
+		startQueue(t, q)
+		return q, leadership
+	}
+	a, leadA := newReplica("a")
+	b, leadB := newReplica("b")
+	termA := make(chan struct{})
+	leadA <- termA
+
+	// jobs enqueued and cancelled through another replica run on the leader
+	job, err := b.Enqueue(t.Context(), "block", wrapperspb.String(""))
+	require.NoError(t, err)
+	assert.Equal(t, "a", <-started)
+	assert.True(t, a.IsRunning(job.GetId()))
+	assert.False(t, b.IsRunning(job.GetId()))
+	job, err = b.Cancel(t.Context(), job.GetId())
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.JobState_JOB_STATE_CANCELLED, job.GetState())
+	require.Eventually(t, func() bool { return !a.IsRunning(job.GetId()) }, 10*time.Second, 10*time.Millisecond)
+	waitForState(t, a, job.GetId(), v1alpha1.JobState_JOB_STATE_CANCELLED)
+
+	// the jobs of a replica that stops leading resume on the next leader
+	job, err = a.Enqueue(t.Context(), "block", wrapperspb.String(""))
+	require.NoError(t, err)
+	assert.Equal(t, "a", <-started)
+	close(termA)
+	waitForState(t, a, job.GetId(), v1alpha1.JobState_JOB_STATE_PENDING)
+	leadB <- make(chan struct{})
+	assert.Equal(t, "b", <-started)
+	assert.True(t, b.IsRunning(job.GetId()))
+	assert.False(t, a.IsRunning(job.GetId()))
+}
+
+func TestJobServer(t *testing.T) {
+	q := jobs.NewQueue(slog.Default(), newTestStore(t), jobs.Config{})
+	q.Register("noop", func(context.Context, *v1alpha1.Job) (proto.Message, error) {
+		return nil, nil
+	}, jobs.DefaultRetryPolicy)
+	startQueue(t, q)
+	srv := jobs.NewJobServer(slog.Default(), q)
+	ctx := t.Context()
+
+	for range 3 {
+		_, err := q.Enqueue(ctx, "noop", wrapperspb.String(""))
+		require.NoError(t, err)
+	}
+	list, err := srv.ListJobs(ctx, connect.NewRequest(&v1alpha1.ListJobsRequest{Types: []string{"noop"}, Limit: 2}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.GetJobs(), 2)
+
+	id := list.Msg.GetJobs()[0].GetId()
+	waitForState(t, q, id, v1alpha1.JobState_JOB_STATE_SUCCEEDED)
+	job, err := srv.GetJob(ctx, connect.NewRequest(&v1alpha1.GetJobRequest{Id: id}))
+	require.NoError(t, err)
+	assert.Equal(t, "noop", job.Msg.GetType())
+
+	_, err = srv.CancelJob(ctx, connect.NewRequest(&v1alpha1.CancelJobRequest{Id: id}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	_, err = srv.GetJob(ctx, connect.NewRequest(&v1alpha1.GetJobRequest{Id: "missing"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = srv.CancelJob(ctx, connect.NewRequest(&v1alpha1.CancelJobRequest{Id: "missing"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
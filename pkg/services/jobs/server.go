@@ -0,0 +1,87 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/mux"
+	"github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+)
+
+const (
+	defaultListLimit = 100
+	maxListLimit     = 1000
+)
+
+// JobServer provides the job status API.
+// Its lifecycle is that of the underlying Queue.
+type JobServer struct {
+	logger       *slog.Logger
+	queue        *Queue
+	interceptors []connect.Interceptor
+}
+
+var _ v1alpha1connect.JobServiceHandler = (*JobServer)(nil)
+
+// NewJobServer creates a new JobServer serving the jobs of queue
+func NewJobServer(logger *slog.Logger, queue *Queue) *JobServer {
+	return &JobServer{
+		logger: logger,
+		queue:  queue,
+	}
+}
+
+// AddInterceptors adds interceptors to the job service handlers.
+// Must be called before ConfigureHTTP.
+func (j *JobServer) AddInterceptors(interceptors ...connect.Interceptor) {
+	j.interceptors = append(j.interceptors, interceptors...)
+}
+
+func (j *JobServer) ConfigureHTTP(mux *mux.Router) {
+	j.logger.Info("configuring routes")
+	v1alpha1connect.RegisterJobServiceHandler(mux, j, connect.WithInterceptors(j.interceptors...))
+}
+
+func (j *JobServer) GetJob(ctx context.Context, req *connect.Request[v1alpha1.GetJobRequest]) (*connect.Response[v1alpha1.Job], error) {
+	job, err := j.queue.Get(ctx, req.Msg.GetId())
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("job not found: %s", req.Msg.GetId()))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(job), nil
+}
+
+func (j *JobServer) ListJobs(ctx context.Context, req *connect.Request[v1alpha1.ListJobsRequest]) (*connect.Response[v1alpha1.ListJobsResponse], error) {
+	limit := int(req.Msg.GetLimit())
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	limit = min(limit, maxListLimit)
+
+	jobs, err := j.queue.List(ctx, req.Msg.GetTypes(), req.Msg.GetStates())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list jobs: %w", err))
+	}
+	return connect.NewResponse(&v1alpha1.ListJobsResponse{
+		Jobs: jobs[:min(limit, len(jobs))],
+	}), nil
+}
+
+func (j *JobServer) CancelJob(ctx context.Context, req *connect.Request[v1alpha1.CancelJobRequest]) (*connect.Response[v1alpha1.Job], error) {
+	job, err := j.queue.Cancel(ctx, req.Msg.GetId())
+	switch {
+	case grpcutil.IsErrorNotFound(err):
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("job not found: %s", req.Msg.GetId()))
+	case errors.Is(err, ErrJobFinished):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case err != nil:
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(job), nil
+}
@@ -0,0 +1,269 @@
+// Package leader elects the replica running the work that must not run twice among the
+// replicas of a server sharing their storage, such as rolling deployments. The leader holds a
+// lease stored in a keyspace, which it renews while it runs; the other replicas take it over
+// once it expires. Replicas must have loosely synchronized clocks: leaders that fail to renew
+// their lease stop leading a third of its TTL before it expires.
+package leader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/grafana/dskit/services"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/clock"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultLeaseTTL is how long a lease lasts without being renewed unless configured otherwise
+const DefaultLeaseTTL = 15 * time.Second
+
+// lease is the record of the replica holding a lease
+type lease struct {
+	Holder    string    `json:"holder"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Elector campaigns for a lease while the service runs, and resigns it when it stops
+type Elector struct {
+	logger *slog.Logger
+	kv     storage.KV
+	name   string
+	id     string
+	ttl    time.Duration
+	clock  clock.Clock
+
+	mu sync.Mutex
+	// term is done once the replica stops leading, nil while it doesn't lead
+	term    context.Context
+	endTerm context.CancelFunc
+	// renewedAt is when the lease was last acquired or renewed
+	renewedAt time.Time
+	// elected is closed and replaced once the replica starts leading
+	elected chan struct{}
+
+	services.Service
+}
+
+// NewElector creates an elector campaigning as id for the lease stored under name in kv,
+// lasting ttl, DefaultLeaseTTL if 0
+func NewElector(logger *slog.Logger, kv storage.KV, name, id string, ttl time.Duration) *Elector {
+	if ttl <= 0 {
+		ttl = DefaultLeaseTTL
+	}
+	e := &Elector{
+		logger:  logger,
+		kv:      kv,
+		name:    name,
+		id:      id,
+		ttl:     ttl,
+		clock:   clock.Real{},
+		elected: make(chan struct{}),
+	}
+	e.Service = services.NewBasicService(nil, e.running, e.stopping)
+	return e
+}
+
+// SetClock sets the clock timing the lease. Defaults to the wall clock.
+func (e *Elector) SetClock(clk clock.Clock) {
+	e.clock = clk
+}
+
+// RegisterMetrics registers whether the replica leads
+func (e *Elector) RegisterMetrics(reg prometheus.Registerer) {
+	reg.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "otelfleet_leader",
+		Help: "1 if the replica is the leader of the replicas sharing its storage.",
+	}, func() float64 {
+		if e.IsLeader() {
+			return 1
+		}
+		return 0
+	}))
+}
+
+// ID returns the ID the replica campaigns as
+func (e *Elector) ID() string {
+	return e.id
+}
+
+// IsLeader returns true while the replica leads
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.term != nil
+}
+
+// Leader returns the ID of the replica holding the lease, "" if it expired
+func (e *Elector) Leader(ctx context.Context) (string, error) {
+	data, err := e.kv.Get(ctx, e.name)
+	if storage.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	var held lease
+	if err := json.Unmarshal(data, &held); err != nil || !e.clock.Now().Before(held.ExpiresAt) {
+		return "", nil
+	}
+	return held.Holder, nil
+}
+
+// Lead runs fn whenever the replica leads, until ctx is done. The context of fn is cancelled
+// once the replica stops leading, and fn must then return.
+func (e *Elector) Lead(ctx context.Context, fn func(ctx context.Context)) {
+	for {
+		e.mu.Lock()
+		term, elected := e.term, e.elected
+		e.mu.Unlock()
+		if term == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-elected:
+				continue
+			}
+		}
+		termCtx, cancel := context.WithCancel(ctx)
+		stop := context.AfterFunc(term, cancel)
+		fn(termCtx)
+		stop()
+		cancel()
+		select {
+		case <-ctx.Done():
+			return
+		case <-term.Done():
+		}
+	}
+}
+
+// renewInterval is how often the lease is acquired or renewed
+func (e *Elector) renewInterval() time.Duration {
+	return e.ttl / 3
+}
+
+func (e *Elector) running(ctx context.Context) error {
+	for {
+		e.campaign(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-e.clock.After(e.renewInterval()):
+		}
+	}
+}
+
+// stopping stops leading, and resigns the lease so that another replica takes it over
+// without waiting for it to expire
+func (e *Elector) stopping(_ error) error {
+	e.mu.Lock()
+	leading := e.term != nil
+	e.stepDown()
+	e.mu.Unlock()
+	if !leading {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.renewInterval())
+	defer cancel()
+	if _, err := e.swap(ctx, lease{Holder: e.id}); err != nil {
+		e.logger.With("err", err).Warn("failed to resign the lease")
+	}
+	return nil
+}
+
+// campaign acquires or renews the lease, and starts or stops leading accordingly
+func (e *Elector) campaign(ctx context.Context) {
+	timeout := e.renewInterval()
+	e.mu.Lock()
+	if e.term != nil {
+		// renewals failing past the step down deadline end the term
+		timeout = min(timeout, clock.Until(e.clock, e.stepDownAt()))
+	}
+	e.mu.Unlock()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	now := e.clock.Now()
+	held, err := e.swap(ctx, lease{Holder: e.id, ExpiresAt: now.Add(e.ttl)})
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch {
+	case held:
+		e.renewedAt = now
+		if e.term == nil {
+			e.logger.Info("leading the replicas sharing the storage")
+			e.term, e.endTerm = context.WithCancel(context.Background())
+			close(e.elected)
+			e.elected = make(chan struct{})
+		}
+	case err == nil:
+		// another replica holds the lease
+		e.stepDown()
+	default:
+		lg := e.logger.With("err", err)
+		if e.term != nil && !e.clock.Now().Before(e.stepDownAt()) {
+			lg.Error("failed to renew the lease before it expires")
+			e.stepDown()
+		} else if e.term != nil {
+			lg.Warn("failed to renew the lease")
+		} else {
+			lg.Debug("failed to acquire the lease")
+		}
+	}
+}
+
+// stepDownAt is when the replica stops leading unless it renews the lease, a renewal interval
+// before the lease expires. The lock must be held.
+func (e *Elector) stepDownAt() time.Time {
+	return e.renewedAt.Add(e.ttl - e.renewInterval())
+}
+
+// stepDown stops leading. The lock must be held.
+func (e *Elector) stepDown() {
+	if e.term == nil {
+		return
+	}
+	e.logger.Info("stopped leading the replicas sharing the storage")
+	e.endTerm()
+	e.term, e.endTerm = nil, nil
+}
+
+// swap stores next in place of the lease if it expired or is held by the replica, returning
+// false if another replica holds it
+func (e *Elector) swap(ctx context.Context, next lease) (bool, error) {
+	data, err := json.Marshal(next)
+	if err != nil {
+		return false, err
+	}
+	current, err := e.kv.Get(ctx, e.name)
+	if storage.IsNotFound(err) {
+		if next.ExpiresAt.IsZero() {
+			return false, nil
+		}
+		err := e.kv.Create(ctx, e.name, data)
+		if storage.IsAlreadyExists(err) {
+			return false, nil
+		}
+		return err == nil, err
+	} else if err != nil {
+		return false, err
+	}
+	var held lease
+	if err := json.Unmarshal(current, &held); err != nil {
+		e.logger.With("err", err).Warn("replacing an invalid lease")
+	} else if held.Holder != e.id && e.clock.Now().Before(held.ExpiresAt) {
+		return false, nil
+	}
+	err = e.kv.CompareAndSwap(ctx, e.name, current, data)
+	if storage.IsConflict(err) || storage.IsNotFound(err) {
+		// another replica acquired the lease since it was read
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to store the lease: %w", err)
+	}
+	return true, nil
+}
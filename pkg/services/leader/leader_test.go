@@ -0,0 +1,113 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grafana/dskit/services"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
+	"github.com/otelfleet/otelfleet/pkg/util/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ttl = 3 * time.Second
+
+// unreachableKV fails every operation while unreachable is set
+type unreachableKV struct {
+	storage.KV
+	unreachable atomic.Bool
+}
+
+func (kv *unreachableKV) Get(ctx context.Context, key string) ([]byte, error) {
+	if kv.unreachable.Load() {
+		return nil, errors.New("unreachable")
+	}
+	return kv.KV.Get(ctx, key)
+}
+
+func startElector(t *testing.T, kv storage.KV, id string, clk *clock.Fake) *Elector {
+	t.Helper()
+	e := NewElector(slog.Default(), kv, "leader", id, ttl)
+	e.SetClock(clk)
+	require.NoError(t, services.StartAndAwaitRunning(t.Context(), e))
+	t.Cleanup(func() { services.StopAndAwaitTerminated(context.Background(), e) })
+	return e
+}
+
+// advance moves clk by a renewal interval once the n running electors wait on it, and waits
+// for them to campaign
+func advance(t *testing.T, clk *clock.Fake, n int) {
+	t.Helper()
+	require.True(t, clk.BlockUntil(n, 5*time.Second))
+	clk.Advance(ttl / 3)
+	require.True(t, clk.BlockUntil(n, 5*time.Second))
+}
+
+func TestElector(t *testing.T) {
+	t.Run("one replica leads until it resigns", func(t *testing.T) {
+		clk := clock.NewFake(time.Now())
+		kv := memory.NewKVBroker().KeyValue("leases")
+		a := startElector(t, kv, "a", clk)
+		require.True(t, clk.BlockUntil(1, 5*time.Second))
+		b := startElector(t, kv, "b", clk)
+		advance(t, clk, 2)
+		assert.True(t, a.IsLeader())
+		assert.False(t, b.IsLeader())
+		leader, err := b.Leader(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "a", leader)
+
+		terms := make(chan context.Context, 1)
+		go a.Lead(t.Context(), func(ctx context.Context) {
+			terms <- ctx
+			<-ctx.Done()
+		})
+		term := <-terms
+
+		// the other replica takes over on its next campaign
+		require.NoError(t, services.StopAndAwaitTerminated(t.Context(), a))
+		select {
+		case <-term.Done():
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "the term must end once the replica stops leading")
+		}
+		advance(t, clk, 1)
+		assert.True(t, b.IsLeader())
+		leader, err = a.Leader(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, "b", leader)
+	})
+
+	t.Run("leases expire", func(t *testing.T) {
+		clk := clock.NewFake(time.Now())
+		broker := memory.NewKVBroker()
+		kv := &unreachableKV{KV: broker.KeyValue("leases")}
+		a := startElector(t, kv, "a", clk)
+		require.True(t, clk.BlockUntil(1, 5*time.Second))
+		b := startElector(t, broker.KeyValue("leases"), "b", clk)
+		advance(t, clk, 2)
+		require.True(t, a.IsLeader())
+
+		kv.unreachable.Store(true)
+		advance(t, clk, 2)
+		assert.True(t, a.IsLeader(), "leaders keep leading while their lease is valid")
+		assert.False(t, b.IsLeader())
+		advance(t, clk, 2)
+		assert.False(t, a.IsLeader(), "leaders must stop leading before their lease expires")
+		assert.False(t, b.IsLeader())
+		advance(t, clk, 2)
+		assert.True(t, b.IsLeader())
+
+		// the former leader follows once the storage is reachable again
+		kv.unreachable.Store(false)
+		advance(t, clk, 2)
+		assert.False(t, a.IsLeader())
+		assert.True(t, b.IsLeader())
+	})
+}
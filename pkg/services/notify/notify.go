@@ -0,0 +1,105 @@
+// Package notify delivers config change notifications to the OpAMP servers pushing configs to
+// the connected agents
+package notify
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/grafana/dskit/services"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+)
+
+// Transports
+const (
+	// TransportWatch derives notifications from the changes to the assigned configs in the
+	// storage, seen by every server sharing it
+	TransportWatch = "watch"
+	// TransportInProcess delivers notifications to the subscribers of the same process
+	TransportInProcess = "inprocess"
+	// TransportStorage delivers notifications through the storage, to the subscribers of every
+	// server sharing it
+	TransportStorage = "storage"
+)
+
+// Config selects the notification transport
+type Config struct {
+	// Transport is TransportWatch, TransportInProcess or TransportStorage, defaults to
+	// TransportWatch
+	Transport string `yaml:"transport"`
+	// PollInterval is how often the storage transport checks for notifications, defaults to
+	// DefaultPollInterval
+	PollInterval time.Duration `yaml:"poll_interval"`
+}
+
+// Transport delivers the config change notifications of agents to their subscribers
+type Transport interface {
+	services.Service
+	// Subscribe registers fn to be called with the agents whose config changed.
+	// Must be called before the transport is started.
+	Subscribe(fn func(agentID string))
+}
+
+// Publisher is a transport delivering the notifications it is given, rather than observing
+// the config changes itself. It implements otelconfig.ConfigChangeNotifier.
+type Publisher interface {
+	Transport
+	// NotifyConfigChange notifies the subscribers that the config of an agent changed
+	NotifyConfigChange(agentID string)
+}
+
+// New creates the transport selected by cfg. The storage transport keeps notifications in the
+// notifications keyspace of broker, the watch transport watches the assignments keyspace.
+func New(logger *slog.Logger, cfg Config, broker storage.KVBroker, notifications, assignments string) (Transport, error) {
+	switch cfg.Transport {
+	case "", TransportWatch:
+		return NewWatchTransport(logger, broker, assignments), nil
+	case TransportInProcess:
+		return NewInProcess(), nil
+	case TransportStorage:
+		return NewStorageTransport(logger, broker.KeyValue(notifications), cfg.PollInterval), nil
+	default:
+		return nil, fmt.Errorf("unknown notification transport %q, expected %s, %s or %s",
+			cfg.Transport, TransportWatch, TransportInProcess, TransportStorage)
+	}
+}
+
+type subscribers struct {
+	mu  sync.RWMutex
+	fns []func(agentID string)
+}
+
+func (s *subscribers) Subscribe(fn func(agentID string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fns = append(s.fns, fn)
+}
+
+func (s *subscribers) dispatch(agentID string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, fn := range s.fns {
+		fn(agentID)
+	}
+}
+
+// InProcess delivers notifications synchronously to the subscribers of the same process
+type InProcess struct {
+	services.Service
+	subscribers
+}
+
+var _ Publisher = (*InProcess)(nil)
+
+// NewInProcess creates a transport for servers running a single replica
+func NewInProcess() *InProcess {
+	t := &InProcess{}
+	t.Service = services.NewIdleService(nil, nil)
+	return t
+}
+
+func (t *InProcess) NotifyConfigChange(agentID string) {
+	t.dispatch(agentID)
+}
@@ -0,0 +1,132 @@
+package notify_test
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/grafana/dskit/services"
+	"github.com/otelfleet/otelfleet/pkg/services/notify"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mu       sync.Mutex
+	agentIDs []string
+}
+
+func (r *recorder) notify(agentID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.agentIDs = append(r.agentIDs, agentID)
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.agentIDs...)
+}
+
+func start(t *testing.T, transport notify.Transport) {
+	t.Helper()
+	require.NoError(t, services.StartAndAwaitRunning(t.Context(), transport))
+	t.Cleanup(func() {
+		require.NoError(t, services.StopAndAwaitTerminated(context.Background(), transport))
+	})
+}
+
+func TestNew(t *testing.T) {
+	broker := memory.NewKVBroker()
+	transport, err := notify.New(slog.Default(), notify.Config{}, broker, "notifications", "assignments")
+	require.NoError(t, err)
+	assert.IsType(t, &notify.WatchTransport{}, transport)
+
+	transport, err = notify.New(slog.Default(), notify.Config{Transport: notify.TransportInProcess}, broker, "notifications", "assignments")
+	require.NoError(t, err)
+	assert.IsType(t, &notify.InProcess{}, transport)
+
+	transport, err = notify.New(slog.Default(), notify.Config{Transport: notify.TransportStorage}, broker, "notifications", "assignments")
+	require.NoError(t, err)
+	assert.IsType(t, &notify.StorageTransport{}, transport)
+
+	_, err = notify.New(slog.Default(), notify.Config{Transport: "nats"}, broker, "notifications", "assignments")
+	assert.Error(t, err)
+}
+
+func TestInProcess(t *testing.T) {
+	transport := notify.NewInProcess()
+	var a, b recorder
+	transport.Subscribe(a.notify)
+	transport.Subscribe(b.notify)
+	start(t, transport)
+
+	transport.NotifyConfigChange("agent-1")
+	assert.Equal(t, []string{"agent-1"}, a.get())
+	assert.Equal(t, []string{"agent-1"}, b.get())
+}
+
+func TestStorageTransport(t *testing.T) {
+	kv := memory.NewKVBroker().KeyValue("notifications")
+	require.NoError(t, kv.Put(t.Context(), "00000000000000000001/stale", []byte("stale-agent")))
+
+	// two replicas sharing the storage
+	first := notify.NewStorageTransport(slog.Default(), kv, 10*time.Millisecond)
+	second := notify.NewStorageTransport(slog.Default(), kv, 10*time.Millisecond)
+	var firstRec, secondRec recorder
+	first.Subscribe(firstRec.notify)
+	second.Subscribe(secondRec.notify)
+	start(t, first)
+	start(t, second)
+
+	first.NotifyConfigChange("agent-1")
+	// delivered immediately to the subscribers of the notifying server
+	assert.Equal(t, []string{"agent-1"}, firstRec.get())
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"agent-1"}, secondRec.get())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	second.NotifyConfigChange("agent-2")
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"agent-1", "agent-2"}, firstRec.get())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// notifications are delivered once, and expired ones are removed
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []string{"agent-1", "agent-2"}, firstRec.get())
+	assert.Equal(t, []string{"agent-1", "agent-2"}, secondRec.get())
+	_, err := kv.Get(t.Context(), "00000000000000000001/stale")
+	assert.Error(t, err)
+}
+
+func TestWatchTransport(t *testing.T) {
+	broker := memory.NewKVBroker()
+	assignments := broker.KeyValue("assignments")
+	require.NoError(t, assignments.Put(t.Context(), "agent-0", []byte("before")))
+
+	// two replicas sharing the storage
+	first := notify.NewWatchTransport(slog.Default(), broker, "assignments")
+	second := notify.NewWatchTransport(slog.Default(), broker, "assignments")
+	var firstRec, secondRec recorder
+	first.Subscribe(firstRec.notify)
+	second.Subscribe(secondRec.notify)
+	start(t, first)
+	start(t, second)
+
+	require.NoError(t, assignments.Put(t.Context(), "agent-1", []byte("config")))
+	require.NoError(t, broker.KeyValue("other").Put(t.Context(), "agent-2", []byte("config")))
+	require.NoError(t, assignments.Delete(t.Context(), "agent-0"))
+	b := assignments.NewBatch()
+	require.NoError(t, assignments.PutBatch(t.Context(), b, "agent-3", []byte("config")))
+	require.NoError(t, b.Commit(t.Context()))
+
+	expected := []string{"agent-1", "agent-0", "agent-3"}
+	for _, rec := range []*recorder{&firstRec, &secondRec} {
+		require.Eventually(t, func() bool {
+			return assert.ObjectsAreEqual(expected, rec.get())
+		}, 5*time.Second, time.Millisecond)
+	}
+}
@@ -0,0 +1,135 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grafana/dskit/services"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+)
+
+const (
+	// DefaultPollInterval is how often the storage transport checks for notifications
+	DefaultPollInterval = time.Second
+	// notifications are kept long enough for every server to see them
+	notificationRetention = time.Minute
+)
+
+// StorageTransport delivers notifications through a keyspace polled by every server sharing
+// the storage, so that agents are notified whichever server they are connected to.
+// Notifications of the server itself are delivered immediately.
+type StorageTransport struct {
+	services.Service
+	subscribers
+	logger       *slog.Logger
+	kv           storage.KV
+	pollInterval time.Duration
+
+	mu sync.Mutex
+	// time of the notifications already delivered, by key
+	seen map[string]time.Time
+	// notifications written before the transport started are not delivered
+	started time.Time
+}
+
+var _ Publisher = (*StorageTransport)(nil)
+
+// NewStorageTransport creates a transport keeping notifications in kv, checking it for new
+// ones every pollInterval, defaulting to DefaultPollInterval
+func NewStorageTransport(logger *slog.Logger, kv storage.KV, pollInterval time.Duration) *StorageTransport {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	t := &StorageTransport{
+		logger:       logger,
+		kv:           kv,
+		pollInterval: pollInterval,
+		seen:         map[string]time.Time{},
+	}
+	t.Service = services.NewTimerService(pollInterval, t.starting, t.poll, nil)
+	return t
+}
+
+// notificationKey orders notifications by time, unique across servers
+func notificationKey(at time.Time) string {
+	return fmt.Sprintf("%020d/%s", at.UnixNano(), uuid.NewString())
+}
+
+func notificationTime(key string) (time.Time, bool) {
+	nanos, _, ok := strings.Cut(key, "/")
+	if !ok {
+		return time.Time{}, false
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(0, n), true
+}
+
+func (t *StorageTransport) starting(_ context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.started = time.Now()
+	return nil
+}
+
+func (t *StorageTransport) NotifyConfigChange(agentID string) {
+	now := time.Now()
+	key := notificationKey(now)
+	if err := t.kv.Put(context.Background(), key, []byte(agentID)); err != nil {
+		t.logger.With("agent_id", agentID, "err", err).Error("failed to publish config change notification")
+	} else {
+		t.markSeen(key, now)
+	}
+	t.dispatch(agentID)
+}
+
+// markSeen returns false if the notification was already delivered
+func (t *StorageTransport) markSeen(key string, at time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.seen[key]; ok || at.Before(t.started) {
+		return false
+	}
+	t.seen[key] = at
+	return true
+}
+
+// poll delivers the notifications of other servers and removes expired ones
+func (t *StorageTransport) poll(ctx context.Context) error {
+	entries, err := t.kv.ListPrefix(ctx, "")
+	if err != nil {
+		t.logger.With("err", err).Warn("failed to list config change notifications")
+		return nil
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		at, ok := notificationTime(entry.Key)
+		if !ok || now.Sub(at) > notificationRetention {
+			// every server removes expired notifications, deleting a missing key is a no-op
+			if err := t.kv.Delete(ctx, entry.Key); err != nil {
+				t.logger.With("key", entry.Key, "err", err).Debug("failed to remove expired notification")
+			}
+			continue
+		}
+		if t.markSeen(entry.Key, at) {
+			t.dispatch(string(entry.Value))
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, at := range t.seen {
+		if now.Sub(at) > notificationRetention {
+			delete(t.seen, key)
+		}
+	}
+	return nil
+}
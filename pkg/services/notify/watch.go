@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/grafana/dskit/services"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+)
+
+// WatchTransport derives notifications from the changes to the keyspace holding the configs
+// assigned to agents, keyed by agent ID. Every server sharing the storage watches it, so
+// agents are notified whichever server changed their assignment, without the ConfigServer
+// notifying the transport and without polling.
+type WatchTransport struct {
+	services.Service
+	subscribers
+	logger   *slog.Logger
+	broker   storage.KVBroker
+	keyspace string
+}
+
+var _ Transport = (*WatchTransport)(nil)
+
+// NewWatchTransport creates a transport notifying the agents whose key of the keyspace of
+// broker is written
+func NewWatchTransport(logger *slog.Logger, broker storage.KVBroker, keyspace string) *WatchTransport {
+	t := &WatchTransport{
+		logger:   logger,
+		broker:   broker,
+		keyspace: keyspace,
+	}
+	t.Service = services.NewBasicService(nil, t.running, nil)
+	return t
+}
+
+func (t *WatchTransport) running(ctx context.Context) error {
+	for {
+		events, err := t.broker.Watch(ctx, t.keyspace)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for event := range events {
+			switch event.Type {
+			case storage.EventPut, storage.EventDelete:
+				t.dispatch(event.Key)
+			default:
+				// assignments are deleted one agent at a time, and the agents whose
+				// assignment was deleted have nothing to be notified of
+				t.logger.With("type", event.Type, "key", event.Key).Debug("ignoring assignment change")
+			}
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		t.logger.Warn("fell behind the changes to the assigned configs, notifying every assigned agent")
+		t.notifyAll(ctx)
+	}
+}
+
+// notifyAll notifies every agent with an assigned config, once changes may have been missed
+func (t *WatchTransport) notifyAll(ctx context.Context) {
+	agentIDs, err := t.broker.KeyValue(t.keyspace).ListKeys(ctx)
+	if err != nil {
+		t.logger.With("err", err).Error("failed to list the agents with an assigned config")
+		return
+	}
+	for _, agentID := range agentIDs {
+		t.dispatch(agentID)
+	}
+}
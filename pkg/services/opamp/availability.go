@@ -0,0 +1,60 @@
+package opamp
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+)
+
+// SetAvailabilityStore enables recording the availability history of agents, keyed by agent ID.
+// Agents missing heartbeats for longer than heartbeatTimeout count as unavailable, it defaults
+// to agentdomain.DefaultHeartbeatTimeout.
+func (s *Server) SetAvailabilityStore(store storage.KeyValue[*v1alpha1.AvailabilityHistory], heartbeatTimeout time.Duration) {
+	if heartbeatTimeout <= 0 {
+		heartbeatTimeout = agentdomain.DefaultHeartbeatTimeout
+	}
+	s.availabilityStore = store
+	s.heartbeatTimeout = heartbeatTimeout
+}
+
+// updateAvailability applies update to the availability history of an agent, and persists
+// the history if update reports it should be
+func (s *Server) updateAvailability(ctx context.Context, agentID string, update func(history *v1alpha1.AvailabilityHistory) bool) error {
+	if s.availabilityStore == nil {
+		return nil
+	}
+	s.availabilityMu.Lock()
+	defer s.availabilityMu.Unlock()
+	history, err := s.availabilityStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		history = &v1alpha1.AvailabilityHistory{}
+	} else if err != nil {
+		return err
+	}
+	if !update(history) {
+		return nil
+	}
+	return s.availabilityStore.Put(ctx, agentID, history)
+}
+
+// recordHeartbeat records a message from the agent, along with the health it reported if any
+func (s *Server) recordHeartbeat(ctx context.Context, agentID string, now time.Time, health *protobufs.ComponentHealth) error {
+	var healthy *bool
+	if health != nil {
+		healthy = &health.Healthy
+	}
+	return s.updateAvailability(ctx, agentID, func(history *v1alpha1.AvailabilityHistory) bool {
+		return agentdomain.RecordHeartbeat(history, now, healthy, s.heartbeatTimeout)
+	})
+}
+
+func (s *Server) recordDisconnect(ctx context.Context, agentID string, now time.Time) error {
+	return s.updateAvailability(ctx, agentID, func(history *v1alpha1.AvailabilityHistory) bool {
+		return agentdomain.RecordDisconnect(history, now, s.heartbeatTimeout)
+	})
+}
@@ -0,0 +1,88 @@
+package opamp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/agentca"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verifiedRequest returns a connection request presenting a client certificate issued to agentID
+func verifiedRequest(t *testing.T, agentID string) *http.Request {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "otelfleet agents"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	ca, err := agentca.New(caCert, key)
+	require.NoError(t, err)
+	der, err = ca.Issue(agentID, &key.PublicKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, OpAMPPath, nil)
+	req.TLS = &tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{cert},
+		VerifiedChains:   [][]*x509.Certificate{{cert, caCert}},
+	}
+	return req
+}
+
+func TestOnConnecting_ClientCertificates(t *testing.T) {
+	s := NewServer(slog.Default(), nil, nil)
+
+	t.Run("agents without a certificate connect unless required", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, OpAMPPath, nil)
+		assert.True(t, s.onConnecting(req).Accept)
+
+		s.SetRequireClientCert(true)
+		defer s.SetRequireClientCert(false)
+		resp := s.onConnecting(req)
+		assert.False(t, resp.Accept)
+		assert.Equal(t, http.StatusUnauthorized, resp.HTTPStatusCode)
+		assert.True(t, s.onConnecting(verifiedRequest(t, "agent-1")).Accept)
+	})
+
+	t.Run("agents only speak for themselves", func(t *testing.T) {
+		resp := s.onConnecting(verifiedRequest(t, "agent-1"))
+		require.True(t, resp.Accept)
+		msg := resp.ConnectionCallbacks.OnMessage(context.Background(), nil, &protobufs.AgentToServer{
+			InstanceUid: []byte("instance"),
+			AgentDescription: &protobufs.AgentDescription{
+				IdentifyingAttributes: []*protobufs.KeyValue{{
+					Key:   supervisor.AttributeOtelfleetAgentId,
+					Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: "agent-2"}},
+				}},
+			},
+		})
+		assert.Equal(t, protobufs.ServerErrorResponseType_ServerErrorResponseType_BadRequest, msg.GetErrorResponse().GetType())
+		assert.Contains(t, msg.GetErrorResponse().GetErrorMessage(), "issued to another agent")
+	})
+}
@@ -0,0 +1,110 @@
+package opamp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/services/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/otelfleet/otelfleet/pkg/util"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetCommandStore enables tracking the last command sent to each agent, keyed by agent ID
+func (s *Server) SetCommandStore(store storage.KeyValue[*v1alpha1.AgentCommandStatus]) {
+	s.commandStore = store
+}
+
+// RestartAgent asks a connected agent to restart its collector.
+// This implements the agent.AgentRestarter interface
+func (s *Server) RestartAgent(ctx context.Context, agentID string) (*v1alpha1.AgentCommandStatus, error) {
+	if _, ok := s.conns.Lookup(agentID); !ok {
+		return nil, agent.ErrAgentNotConnected
+	}
+	state, err := s.agentRepo.GetConnectionState(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	if !state.Capabilities.Has(protobufs.AgentCapabilities_AgentCapabilities_AcceptsRestartCommand) {
+		return nil, agent.ErrCommandNotSupported
+	}
+
+	command := &v1alpha1.AgentCommandStatus{
+		Id:          util.NewUUID(),
+		Type:        supervisor.CommandRestart,
+		State:       v1alpha1.AgentCommandState_AGENT_COMMAND_STATE_PENDING,
+		RequestedBy: auth.SubjectFromContext(ctx),
+		RequestedAt: timestamppb.Now(),
+	}
+	if err := s.putCommand(ctx, agentID, command); err != nil {
+		return nil, err
+	}
+	sendErr := s.conns.Send(ctx, agentID, &protobufs.ServerToAgent{
+		Command: &protobufs.ServerToAgentCommand{
+			Type: protobufs.CommandType_CommandType_Restart,
+		},
+	})
+	if sendErr != nil {
+		command.State = v1alpha1.AgentCommandState_AGENT_COMMAND_STATE_FAILED
+		command.CompletedAt = timestamppb.Now()
+		command.ErrorMessage = fmt.Sprintf("failed to send command: %s", sendErr)
+		if err := s.putCommand(ctx, agentID, command); err != nil {
+			s.logger.With("err", err, "agent_id", agentID).Error("failed to record command failure")
+		}
+		return nil, sendErr
+	}
+	s.logger.With("agent_id", agentID, "command_id", command.GetId()).Info("sent restart command to agent")
+	events.RecordAgentEvent(ctx, s.eventRecorder, s.agentRepo, events.TypeAgentCommandSent, agentID, "restart requested")
+	return command, nil
+}
+
+var _ agent.AgentRestarter = (*Server)(nil)
+
+func (s *Server) putCommand(ctx context.Context, agentID string, command *v1alpha1.AgentCommandStatus) error {
+	if s.commandStore == nil {
+		return nil
+	}
+	s.commandMu.Lock()
+	defer s.commandMu.Unlock()
+	return s.commandStore.Put(ctx, agentID, command)
+}
+
+// recordCommandResult completes the pending command of an agent with the result it reported
+func (s *Server) recordCommandResult(ctx context.Context, agentID string, result *v1alpha1.AgentCommandResult) error {
+	if s.commandStore == nil {
+		return nil
+	}
+	s.commandMu.Lock()
+	defer s.commandMu.Unlock()
+	command, err := s.commandStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if command.GetType() != result.GetType() || command.GetState() != v1alpha1.AgentCommandState_AGENT_COMMAND_STATE_PENDING {
+		return nil
+	}
+	command.CompletedAt = timestamppb.Now()
+	if result.GetSucceeded() {
+		command.State = v1alpha1.AgentCommandState_AGENT_COMMAND_STATE_SUCCEEDED
+	} else {
+		command.State = v1alpha1.AgentCommandState_AGENT_COMMAND_STATE_FAILED
+		command.ErrorMessage = result.GetErrorMessage()
+	}
+	return s.commandStore.Put(ctx, agentID, command)
+}
+
+// describeCommandResult is the message of the event recorded for a command result
+func describeCommandResult(result *v1alpha1.AgentCommandResult) string {
+	if result.GetSucceeded() {
+		return fmt.Sprintf("agent completed %s command", result.GetType())
+	}
+	return fmt.Sprintf("agent failed %s command: %s", result.GetType(), result.GetErrorMessage())
+}
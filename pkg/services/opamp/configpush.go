@@ -0,0 +1,164 @@
+package opamp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxConfigPushHistory is the number of pushes kept per agent
+const maxConfigPushHistory = 50
+
+// SetConfigPushStore enables tracking the delivery of remote config pushes, keyed by agent ID
+func (s *Server) SetConfigPushStore(store storage.KeyValue[*v1alpha1.ConfigPushHistory]) {
+	s.configPushStore = store
+}
+
+// updateConfigPushes applies update to the push history of an agent, and persists
+// the history if update reports it changed
+func (s *Server) updateConfigPushes(ctx context.Context, agentID string, update func(history *v1alpha1.ConfigPushHistory) bool) error {
+	if s.configPushStore == nil {
+		return nil
+	}
+	s.pushMu.Lock()
+	defer s.pushMu.Unlock()
+	history, err := s.configPushStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		history = &v1alpha1.ConfigPushHistory{}
+	} else if err != nil {
+		return err
+	}
+	if !update(history) {
+		return nil
+	}
+	return s.configPushStore.Put(ctx, agentID, history)
+}
+
+// recordConfigPushOffer records that the config with the given hash is being offered to
+// the agent, and returns the ID of the push, or an empty ID if pushes are not tracked
+func (s *Server) recordConfigPushOffer(ctx context.Context, agentID string, hash []byte) (string, error) {
+	if s.configPushStore == nil {
+		return "", nil
+	}
+	push := &v1alpha1.ConfigPush{
+		PushId:     uuid.NewString(),
+		AgentId:    agentID,
+		ConfigHash: hash,
+		State:      v1alpha1.ConfigPushState_CONFIG_PUSH_STATE_OFFERED,
+		OfferedAt:  timestamppb.Now(),
+		Attempt:    1,
+	}
+	err := s.updateConfigPushes(ctx, agentID, func(history *v1alpha1.ConfigPushHistory) bool {
+		if n := len(history.Pushes); n > 0 && bytes.Equal(history.Pushes[n-1].GetConfigHash(), hash) {
+			push.Attempt = history.Pushes[n-1].GetAttempt() + 1
+		}
+		history.Pushes = append(history.Pushes, push)
+		if n := len(history.Pushes); n > maxConfigPushHistory {
+			history.Pushes = history.Pushes[n-maxConfigPushHistory:]
+		}
+		return true
+	})
+	if err != nil {
+		return "", err
+	}
+	return push.GetPushId(), nil
+}
+
+// recordConfigPushSendFailure marks a push that could not be sent to the agent as failed
+func (s *Server) recordConfigPushSendFailure(ctx context.Context, agentID, pushID string, sendErr error) error {
+	return s.updateConfigPushes(ctx, agentID, func(history *v1alpha1.ConfigPushHistory) bool {
+		for _, push := range history.GetPushes() {
+			if push.GetPushId() == pushID {
+				push.State = v1alpha1.ConfigPushState_CONFIG_PUSH_STATE_FAILED
+				push.ErrorMessage = fmt.Sprintf("failed to send config: %s", sendErr)
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// recordConfigPushReceipt marks the push of a receipt as applied or failed. The push
+// is acknowledged as well, since the agent only sends receipts for configs it received.
+func (s *Server) recordConfigPushReceipt(ctx context.Context, agentID string, receipt *v1alpha1.ConfigPushReceipt) error {
+	return s.updateConfigPushes(ctx, agentID, func(history *v1alpha1.ConfigPushHistory) bool {
+		for _, push := range history.GetPushes() {
+			if push.GetPushId() != receipt.GetPushId() {
+				continue
+			}
+			now := timestamppb.Now()
+			if push.AcknowledgedAt == nil {
+				push.AcknowledgedAt = now
+			}
+			if receipt.GetApplied() {
+				push.State = v1alpha1.ConfigPushState_CONFIG_PUSH_STATE_APPLIED
+				if push.AppliedAt == nil {
+					push.AppliedAt = now
+				}
+				push.ErrorMessage = ""
+			} else {
+				push.State = v1alpha1.ConfigPushState_CONFIG_PUSH_STATE_FAILED
+				push.ErrorMessage = receipt.GetErrorMessage()
+			}
+			return true
+		}
+		return false
+	})
+}
+
+// recordConfigPushStatus correlates the remote config status reported by an agent with
+// the most recent push of the reported config hash. This tracks delivery to agents
+// that don't send push receipts.
+func (s *Server) recordConfigPushStatus(ctx context.Context, agentID string, status *protobufs.RemoteConfigStatus) error {
+	return s.updateConfigPushes(ctx, agentID, func(history *v1alpha1.ConfigPushHistory) bool {
+		pushes := history.GetPushes()
+		for i := len(pushes) - 1; i >= 0; i-- {
+			push := pushes[i]
+			if !bytes.Equal(push.GetConfigHash(), status.GetLastRemoteConfigHash()) {
+				continue
+			}
+			if push.GetState() != v1alpha1.ConfigPushState_CONFIG_PUSH_STATE_OFFERED &&
+				push.GetState() != v1alpha1.ConfigPushState_CONFIG_PUSH_STATE_ACKNOWLEDGED {
+				return false
+			}
+			now := timestamppb.Now()
+			if push.AcknowledgedAt == nil {
+				push.AcknowledgedAt = now
+			}
+			switch status.GetStatus() {
+			case protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED:
+				push.State = v1alpha1.ConfigPushState_CONFIG_PUSH_STATE_APPLIED
+				push.AppliedAt = now
+			case protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED:
+				push.State = v1alpha1.ConfigPushState_CONFIG_PUSH_STATE_FAILED
+				push.ErrorMessage = status.GetErrorMessage()
+			default:
+				push.State = v1alpha1.ConfigPushState_CONFIG_PUSH_STATE_ACKNOWLEDGED
+			}
+			return true
+		}
+		return false
+	})
+}
+
+// configPushOffer returns the custom message identifying the push of a remote config
+func configPushOffer(pushID string) (*protobufs.CustomMessage, error) {
+	data, err := proto.Marshal(&v1alpha1.ConfigPushOffer{PushId: pushID})
+	if err != nil {
+		return nil, err
+	}
+	return &protobufs.CustomMessage{
+		Capability: supervisor.ConfigPushCapability,
+		Type:       supervisor.ConfigPushMessageOffer,
+		Data:       data,
+	}, nil
+}
@@ -0,0 +1,190 @@
+package opamp
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/open-telemetry/opamp-go/server/types"
+	adminv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/agent"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ConnectionHooks are called as agents are bound to and unbound from connections.
+// They are called outside of the registry's lock and may use the registry.
+type ConnectionHooks struct {
+	// OnBind is called when an agent is first identified on a connection
+	OnBind func(agentID string, conn types.Connection)
+	// OnUnbind is called when the connection an agent is bound to closes. It is not
+	// called for connections the agent has since been bound away from, e.g. when it
+	// reconnected before the old connection was closed.
+	OnUnbind func(agentID string, conn types.Connection)
+}
+
+// ConnectionRegistry tracks live OpAMP connections and the agents identified on them,
+// so that messages can be sent to an agent by its ID.
+type ConnectionRegistry struct {
+	mu sync.RWMutex
+	// connection -> agent ID, empty until the agent identifies itself
+	conns map[types.Connection]string
+	// agent ID -> its current connection
+	agents map[string]types.Connection
+	// connection -> the session opened on it
+	sessions map[types.Connection]*adminv1alpha1.OpAMPSession
+
+	hooks ConnectionHooks
+}
+
+func NewConnectionRegistry(hooks ConnectionHooks) *ConnectionRegistry {
+	return &ConnectionRegistry{
+		conns:    map[types.Connection]string{},
+		agents:   map[string]types.Connection{},
+		sessions: map[types.Connection]*adminv1alpha1.OpAMPSession{},
+		hooks:    hooks,
+	}
+}
+
+// Register starts tracking a newly established connection and the session opened on it.
+// The registry assigns the session its ID and start time, session may be nil if nothing
+// is known about it.
+func (r *ConnectionRegistry) Register(conn types.Connection, session *adminv1alpha1.OpAMPSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.conns[conn]; ok {
+		return
+	}
+	if session == nil {
+		session = &adminv1alpha1.OpAMPSession{}
+	}
+	session.Id = uuid.NewString()
+	session.StartedAt = timestamppb.Now()
+	r.conns[conn] = ""
+	r.sessions[conn] = session
+}
+
+// Bind associates the agent with the connection, replacing any previous connection of
+// the agent. Rebinding an agent to its current connection is a no-op.
+func (r *ConnectionRegistry) Bind(conn types.Connection, agentID string) {
+	r.mu.Lock()
+	if r.agents[agentID] == conn && r.conns[conn] == agentID {
+		r.mu.Unlock()
+		return
+	}
+	if previous, ok := r.conns[conn]; ok && previous != "" && previous != agentID && r.agents[previous] == conn {
+		// the connection now speaks for another agent
+		delete(r.agents, previous)
+	}
+	r.conns[conn] = agentID
+	r.agents[agentID] = conn
+	if session, ok := r.sessions[conn]; ok {
+		session.AgentId = agentID
+	}
+	r.mu.Unlock()
+
+	if r.hooks.OnBind != nil {
+		r.hooks.OnBind(agentID, conn)
+	}
+}
+
+// AgentID returns the agent identified on the connection
+func (r *ConnectionRegistry) AgentID(conn types.Connection) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	agentID, ok := r.conns[conn]
+	return agentID, ok && agentID != ""
+}
+
+// Lookup returns the current connection of the agent
+func (r *ConnectionRegistry) Lookup(agentID string) (types.Connection, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	conn, ok := r.agents[agentID]
+	return conn, ok
+}
+
+// Unregister stops tracking a closed connection. It returns the agent that was bound to
+// it, and whether the connection was still the agent's current one.
+func (r *ConnectionRegistry) Unregister(conn types.Connection) (agentID string, current bool) {
+	r.mu.Lock()
+	agentID = r.conns[conn]
+	delete(r.conns, conn)
+	delete(r.sessions, conn)
+	if agentID != "" && r.agents[agentID] == conn {
+		delete(r.agents, agentID)
+		current = true
+	}
+	r.mu.Unlock()
+
+	if current && r.hooks.OnUnbind != nil {
+		r.hooks.OnUnbind(agentID, conn)
+	}
+	return agentID, current
+}
+
+// Evict unbinds the agent from its current connection without calling OnUnbind, e.g.
+// because the agent was deleted, and returns the connection so that it can be closed.
+// The connection stays tracked until it is unregistered.
+func (r *ConnectionRegistry) Evict(agentID string) (types.Connection, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	conn, ok := r.agents[agentID]
+	if ok {
+		delete(r.agents, agentID)
+	}
+	return conn, ok
+}
+
+// UpdateSession calls update with the session of the connection under the registry's
+// lock, update must not use the registry. It returns false if the connection isn't tracked.
+func (r *ConnectionRegistry) UpdateSession(conn types.Connection, update func(session *adminv1alpha1.OpAMPSession)) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.sessions[conn]
+	if ok {
+		update(session)
+	}
+	return ok
+}
+
+// Sessions returns copies of the sessions of tracked connections, sorted by start time
+func (r *ConnectionRegistry) Sessions() []*adminv1alpha1.OpAMPSession {
+	r.mu.RLock()
+	sessions := make([]*adminv1alpha1.OpAMPSession, 0, len(r.sessions))
+	for _, session := range r.sessions {
+		sessions = append(sessions, proto.Clone(session).(*adminv1alpha1.OpAMPSession))
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(sessions, func(a, b *adminv1alpha1.OpAMPSession) int {
+		if c := a.GetStartedAt().AsTime().Compare(b.GetStartedAt().AsTime()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.GetId(), b.GetId())
+	})
+	return sessions
+}
+
+// Send sends a message to the agent on its current connection. It returns
+// agent.ErrAgentNotConnected if the agent has no connection.
+func (r *ConnectionRegistry) Send(ctx context.Context, agentID string, msg *protobufs.ServerToAgent) error {
+	conn, ok := r.Lookup(agentID)
+	if !ok {
+		return agent.ErrAgentNotConnected
+	}
+	return conn.Send(ctx, msg)
+}
+
+// ConnectedAgents returns the IDs of the agents with a connection
+func (r *ConnectionRegistry) ConnectedAgents() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	agentIDs := make([]string, 0, len(r.agents))
+	for agentID := range r.agents {
+		agentIDs = append(agentIDs, agentID)
+	}
+	return agentIDs
+}
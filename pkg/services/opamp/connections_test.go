@@ -0,0 +1,107 @@
+package opamp_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/open-telemetry/opamp-go/server/types"
+	adminv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/opamp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingConn struct {
+	sent []*protobufs.ServerToAgent
+}
+
+func (c *recordingConn) Connection() net.Conn { return nil }
+
+func (c *recordingConn) Send(_ context.Context, msg *protobufs.ServerToAgent) error {
+	c.sent = append(c.sent, msg)
+	return nil
+}
+
+func (c *recordingConn) Disconnect() error { return nil }
+
+func TestConnectionRegistry(t *testing.T) {
+	var bound, unbound []string
+	registry := opamp.NewConnectionRegistry(opamp.ConnectionHooks{
+		OnBind:   func(agentID string, _ types.Connection) { bound = append(bound, agentID) },
+		OnUnbind: func(agentID string, _ types.Connection) { unbound = append(unbound, agentID) },
+	})
+	msg := &protobufs.ServerToAgent{}
+
+	assert.ErrorIs(t, registry.Send(t.Context(), "agent-1", msg), agent.ErrAgentNotConnected)
+
+	first := &recordingConn{}
+	registry.Register(first, &adminv1alpha1.OpAMPSession{Transport: opamp.TransportWebSocket, RemoteAddr: "10.0.0.1:4320"})
+	_, ok := registry.AgentID(first)
+	assert.False(t, ok, "agents are unknown until they identify themselves")
+	registry.Bind(first, "agent-1")
+	registry.Bind(first, "agent-1")
+	assert.Equal(t, []string{"agent-1"}, bound)
+	agentID, ok := registry.AgentID(first)
+	require.True(t, ok)
+	assert.Equal(t, "agent-1", agentID)
+	require.NoError(t, registry.Send(t.Context(), "agent-1", msg))
+	assert.Len(t, first.sent, 1)
+	sessions := registry.Sessions()
+	require.Len(t, sessions, 1)
+	assert.NotEmpty(t, sessions[0].GetId())
+	assert.Equal(t, "agent-1", sessions[0].GetAgentId())
+	assert.Equal(t, "10.0.0.1:4320", sessions[0].GetRemoteAddr())
+	assert.True(t, registry.UpdateSession(first, func(session *adminv1alpha1.OpAMPSession) {
+		session.Capabilities = []string{"ReportsStatus"}
+	}))
+	assert.Equal(t, []string{"ReportsStatus"}, registry.Sessions()[0].GetCapabilities())
+
+	// the agent reconnects before its old connection is closed
+	second := &recordingConn{}
+	registry.Register(second, nil)
+	registry.Bind(second, "agent-1")
+	agentID, current := registry.Unregister(first)
+	assert.Equal(t, "agent-1", agentID)
+	assert.False(t, current)
+	assert.Empty(t, unbound)
+	conn, ok := registry.Lookup("agent-1")
+	require.True(t, ok)
+	assert.Same(t, second, conn)
+	require.NoError(t, registry.Send(t.Context(), "agent-1", msg))
+	assert.Len(t, second.sent, 1)
+	assert.Equal(t, []string{"agent-1"}, registry.ConnectedAgents())
+
+	agentID, current = registry.Unregister(second)
+	assert.Equal(t, "agent-1", agentID)
+	assert.True(t, current)
+	assert.Equal(t, []string{"agent-1"}, unbound)
+	assert.Empty(t, registry.ConnectedAgents())
+	assert.Empty(t, registry.Sessions())
+	assert.False(t, registry.UpdateSession(second, func(*adminv1alpha1.OpAMPSession) {}))
+	assert.ErrorIs(t, registry.Send(t.Context(), "agent-1", msg), agent.ErrAgentNotConnected)
+
+	// evicting a deleted agent leaves its connection tracked until it closes
+	fourth := &recordingConn{}
+	registry.Register(fourth, nil)
+	registry.Bind(fourth, "agent-2")
+	conn, ok = registry.Evict("agent-2")
+	require.True(t, ok)
+	assert.Same(t, fourth, conn)
+	assert.Empty(t, registry.ConnectedAgents())
+	_, ok = registry.Evict("agent-2")
+	assert.False(t, ok)
+	agentID, current = registry.Unregister(fourth)
+	assert.Equal(t, "agent-2", agentID)
+	assert.False(t, current)
+	assert.Equal(t, []string{"agent-1"}, unbound)
+
+	// closing a connection no agent identified on
+	third := &recordingConn{}
+	registry.Register(third, nil)
+	agentID, current = registry.Unregister(third)
+	assert.Empty(t, agentID)
+	assert.False(t, current)
+}
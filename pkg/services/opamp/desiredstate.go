@@ -0,0 +1,153 @@
+package opamp
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/grafana/dskit/middleware"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/util"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DesiredStatePath serves the remote config an agent should run, for external OpAMP
+	// servers and pull based agents
+	DesiredStatePath = "/v1alpha1/agents/{id}/desired-state"
+	// ContentTypeProtobuf is accepted by the desired state endpoint to get the
+	// protobufs.AgentRemoteConfig instead of JSON
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// DesiredState is the JSON representation of the desired remote config of an agent
+type DesiredState struct {
+	AgentID string `json:"agent_id"`
+	// ConfigHash is the hex encoded hash agents report the config as
+	ConfigHash string                      `json:"config_hash"`
+	Files      map[string]DesiredStateFile `json:"files"`
+}
+
+type DesiredStateFile struct {
+	ContentType string `json:"content_type"`
+	Body        string `json:"body"`
+}
+
+// DesiredState returns the remote config of an agent as it is pushed over OpAMP, except that
+// secret references are left unresolved. Agents of another tenant than the one of ctx are
+// not found.
+func (s *Server) DesiredState(ctx context.Context, agentID string) (*protobufs.AgentRemoteConfig, error) {
+	if _, err := s.agentRepo.GetView(ctx, agentID, agentdomain.StatusViewBasic); err != nil {
+		return nil, err
+	}
+	configMap, err := s.constructConfig(ctx, agentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct config : %w", err)
+	}
+	if err := util.ValidateAgentConfigMap(configMap); err != nil {
+		return nil, fmt.Errorf("invalid config : %w", err)
+	}
+	return &protobufs.AgentRemoteConfig{
+		Config:     configMap,
+		ConfigHash: s.calculateHash(configMap),
+	}, nil
+}
+
+// ConfigureDesiredStateHTTP serves the desired state of agents, read-only, to the callers
+// presenting token as a bearer token, if set, and to the callers authenticated by the auth
+// middleware, once authorized by mw. Callers presenting the token see the agents of every
+// tenant, others those of the tenant mw scopes their request to.
+func (s *Server) ConfigureDesiredStateHTTP(router *mux.Router, token string, mw ...middleware.Interface) {
+	s.logger.Info("configuring desired state routes")
+	h := &desiredStateHandler{server: s, token: token}
+	h.authenticated = middleware.Merge(mw...).Wrap(http.HandlerFunc(h.serveAuthenticated))
+	router.Handle(DesiredStatePath, h).Methods(http.MethodGet)
+}
+
+type desiredStateHandler struct {
+	server *Server
+	token  string
+	// serves the callers not presenting the token
+	authenticated http.Handler
+}
+
+func (h *desiredStateHandler) hasToken(r *http.Request) bool {
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && h.token != "" && subtle.ConstantTimeCompare([]byte(bearer), []byte(h.token)) == 1
+}
+
+func (h *desiredStateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.hasToken(r) {
+		h.serve(w, r)
+		return
+	}
+	h.authenticated.ServeHTTP(w, r)
+}
+
+func (h *desiredStateHandler) serveAuthenticated(w http.ResponseWriter, r *http.Request) {
+	if auth.FromContext(r.Context()) == nil {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+	h.serve(w, r)
+}
+
+func (h *desiredStateHandler) serve(w http.ResponseWriter, r *http.Request) {
+	agentID := mux.Vars(r)["id"]
+	remoteConfig, err := h.server.DesiredState(r.Context(), agentID)
+	if errors.Is(err, agentdomain.ErrAgentNotFound) {
+		http.Error(w, fmt.Sprintf("agent not found: %s", agentID), http.StatusNotFound)
+		return
+	} else if err != nil {
+		h.server.logger.With("agent_id", agentID, "err", err).Error("failed to get desired state")
+		http.Error(w, "failed to get desired state", http.StatusInternalServerError)
+		return
+	}
+
+	hash := hex.EncodeToString(remoteConfig.GetConfigHash())
+	etag := `"` + hash + `"`
+	w.Header().Set("ETag", etag)
+	// consumers poll for changes, revalidating with the hash
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), ContentTypeProtobuf) {
+		data, err := proto.Marshal(remoteConfig)
+		if err != nil {
+			h.server.logger.With("agent_id", agentID, "err", err).Error("failed to encode desired state")
+			http.Error(w, "failed to encode desired state", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", ContentTypeProtobuf)
+		_, _ = w.Write(data)
+		return
+	}
+
+	state := DesiredState{
+		AgentID:    agentID,
+		ConfigHash: hash,
+		Files:      map[string]DesiredStateFile{},
+	}
+	for name, file := range remoteConfig.GetConfig().GetConfigMap() {
+		state.Files[name] = DesiredStateFile{
+			ContentType: file.GetContentType(),
+			Body:        string(file.GetBody()),
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(state); err != nil {
+		h.server.logger.With("agent_id", agentID, "err", err).Warn("failed to write desired state")
+	}
+}
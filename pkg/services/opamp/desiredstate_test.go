@@ -0,0 +1,78 @@
+package opamp_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/services/opamp"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDesiredState(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := t.Context()
+	require.NoError(t, env.AgentRepo.Register(ctx, "agent-1", "agent-1"))
+	require.NoError(t, env.AssignedConfigStore.Put(ctx, "agent-1", &configv1alpha1.Config{
+		Config: []byte("receivers:\n  otlp: {}\n"),
+	}))
+
+	require.NoError(t, env.AgentRepo.RegisterInTenant(ctx, "agent-2", "agent-2", "team-a"))
+	require.NoError(t, env.AssignedConfigStore.Put(ctx, "agent-2", &configv1alpha1.Config{
+		Config: []byte("receivers:\n  otlp: {}\n"),
+	}))
+
+	router := mux.NewRouter()
+	env.OpampServer.ConfigureDesiredStateHTTP(router, "s3cret", tenant.Interceptor{}.Middleware())
+	handler := auth.NewMiddleware(slog.Default(), auth.NewHeaderAuthenticator()).Wrap(router)
+
+	get := func(agentID string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1alpha1/agents/"+agentID+"/desired-state", nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	bearer := http.Header{"Authorization": {"Bearer s3cret"}}
+
+	assert.Equal(t, http.StatusUnauthorized, get("agent-1", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, get("agent-1", http.Header{"Authorization": {"Bearer wrong"}}).Code)
+	assert.Equal(t, http.StatusNotFound, get("missing", bearer).Code)
+
+	rec := get("agent-1", bearer)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var state opamp.DesiredState
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&state))
+	assert.Equal(t, "agent-1", state.AgentID)
+	assert.Equal(t, "receivers:\n  otlp: {}\n", state.Files["config.yaml"].Body)
+	assert.Equal(t, `"`+state.ConfigHash+`"`, rec.Header().Get("ETag"))
+
+	// callers authenticated by the proxy don't need the token
+	rec = get("agent-1", http.Header{auth.HeaderUser: {"alice"}, "If-None-Match": {rec.Header().Get("ETag")}})
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	rec = get("agent-1", http.Header{"Authorization": {"Bearer s3cret"}, "Accept": {opamp.ContentTypeProtobuf}})
+	require.Equal(t, http.StatusOK, rec.Code)
+	remoteConfig := &protobufs.AgentRemoteConfig{}
+	require.NoError(t, proto.Unmarshal(rec.Body.Bytes(), remoteConfig))
+	assert.Equal(t, state.ConfigHash, hex.EncodeToString(remoteConfig.GetConfigHash()))
+
+	// callers authenticated by the proxy only see the agents of their tenant, callers
+	// presenting the token those of every tenant
+	assert.Equal(t, http.StatusNotFound, get("agent-2", http.Header{auth.HeaderUser: {"bob"}, auth.HeaderRoles: {tenant.Role("team-b")}}).Code)
+	assert.Equal(t, http.StatusOK, get("agent-2", http.Header{auth.HeaderUser: {"alice"}, auth.HeaderRoles: {tenant.Role("team-a")}}).Code)
+	assert.Equal(t, http.StatusOK, get("agent-2", bearer).Code)
+}
@@ -0,0 +1,148 @@
+package opamp
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+)
+
+// GatewayAgentKeyspace is the keyspace of the store set with SetGatewayAgentStore
+const GatewayAgentKeyspace = "gateway-agents"
+
+type gatewayIDKey struct{}
+
+// gatewayAuthInterceptor authenticates gateways by the bearer token configured for their ID
+type gatewayAuthInterceptor struct {
+	// gateway ID -> token
+	tokens map[string]string
+}
+
+var _ connect.Interceptor = (*gatewayAuthInterceptor)(nil)
+
+// authenticate returns the ID of the gateway whose token is presented in header. Every
+// token is compared so the time taken doesn't tell which one matched.
+func (i *gatewayAuthInterceptor) authenticate(header http.Header) (string, error) {
+	bearer, ok := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+	if !ok || bearer == "" {
+		return "", connect.NewError(connect.CodeUnauthenticated, errors.New("gateway token required"))
+	}
+	var gatewayID string
+	for id, token := range i.tokens {
+		if token != "" && subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1 {
+			gatewayID = id
+		}
+	}
+	if gatewayID == "" {
+		return "", connect.NewError(connect.CodeUnauthenticated, errors.New("invalid gateway token"))
+	}
+	return gatewayID, nil
+}
+
+func (i *gatewayAuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		gatewayID, err := i.authenticate(req.Header())
+		if err != nil {
+			return nil, err
+		}
+		return next(context.WithValue(ctx, gatewayIDKey{}, gatewayID), req)
+	}
+}
+
+func (i *gatewayAuthInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *gatewayAuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		gatewayID, err := i.authenticate(conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+		return next(context.WithValue(ctx, gatewayIDKey{}, gatewayID), conn)
+	}
+}
+
+// authorizeGateway returns an error unless the caller authenticated as the gateway
+func authorizeGateway(ctx context.Context, gatewayID string) error {
+	authenticated, ok := ctx.Value(gatewayIDKey{}).(string)
+	if !ok {
+		return connect.NewError(connect.CodeUnauthenticated, errors.New("gateway not authenticated"))
+	}
+	if gatewayID == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("gateway_id is required"))
+	}
+	if authenticated != gatewayID {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("authenticated as gateway %s, not %s", authenticated, gatewayID))
+	}
+	return nil
+}
+
+// SetGatewayAgentStore records which gateway each relayed agent identified itself through, so
+// that gateways may only speak for the agents that connected through them
+func (s *Server) SetGatewayAgentStore(kv storage.KV) {
+	s.gatewayAgents = kv
+}
+
+// gatewayOf returns the gateway the agent identified itself through, empty if none
+func (s *Server) gatewayOf(ctx context.Context, agentID string) (string, error) {
+	if s.gatewayAgents == nil {
+		return "", nil
+	}
+	current, err := s.gatewayAgents.Get(ctx, agentID)
+	if storage.IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	return string(current), nil
+}
+
+// checkGatewayAgent returns an error if the agent identified itself through another gateway,
+// which must report it disconnected before the agent may connect through the gateway
+func (s *Server) checkGatewayAgent(ctx context.Context, gatewayID, agentID string) error {
+	current, err := s.gatewayOf(ctx, agentID)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to check the gateway of agent %s: %w", agentID, err))
+	}
+	if current != "" && current != gatewayID {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("agent %s is connected through gateway %s", agentID, current))
+	}
+	return nil
+}
+
+// recordGatewayAgent records that the agent identified itself through the gateway, unless it
+// is bound to another gateway
+func (s *Server) recordGatewayAgent(ctx context.Context, gatewayID, agentID string) error {
+	if err := s.checkGatewayAgent(ctx, gatewayID, agentID); err != nil {
+		return err
+	}
+	if s.gatewayAgents == nil {
+		return nil
+	}
+	return s.gatewayAgents.Put(ctx, agentID, []byte(gatewayID))
+}
+
+// releaseGatewayAgent forgets that the agent identified itself through the gateway, once the
+// gateway reports it disconnected
+func (s *Server) releaseGatewayAgent(ctx context.Context, gatewayID, agentID string) error {
+	current, err := s.gatewayOf(ctx, agentID)
+	if err != nil || current != gatewayID {
+		return err
+	}
+	return s.gatewayAgents.Delete(ctx, agentID)
+}
+
+// relayedBy returns true if the agent last identified itself through the gateway
+func (s *Server) relayedBy(ctx context.Context, gatewayID, agentID string) (bool, error) {
+	current, err := s.gatewayOf(ctx, agentID)
+	return current != "" && current == gatewayID, err
+}
@@ -0,0 +1,42 @@
+package opamp
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/labels"
+	"google.golang.org/protobuf/proto"
+)
+
+// SetLabelRules sets the rules the labels reported by agents are checked against
+func (s *Server) SetLabelRules(rules labels.Rules) {
+	s.labelRules = rules
+}
+
+// dropInvalidLabels removes the non-identifying attributes of an agent description breaking
+// the label rules, so that they don't end up in the fleet metadata. Identifying attributes
+// are kept, agents are known by them.
+func (s *Server) dropInvalidLabels(logger *slog.Logger, desc *protobufs.AgentDescription) *protobufs.AgentDescription {
+	reported := make(map[string]string, len(desc.GetNonIdentifyingAttributes()))
+	for _, kv := range desc.GetNonIdentifyingAttributes() {
+		reported[kv.GetKey()] = kv.GetValue().GetStringValue()
+	}
+	var validationErr *labels.ValidationError
+	if err := s.labelRules.ValidateReported(reported); !errors.As(err, &validationErr) {
+		return desc
+	}
+	logger.With("err", validationErr).Warn("dropping agent labels breaking the label rules")
+
+	invalid := make(map[string]struct{}, len(validationErr.Violations))
+	for _, v := range validationErr.Violations {
+		invalid[v.Key] = struct{}{}
+	}
+	filtered := proto.Clone(desc).(*protobufs.AgentDescription)
+	filtered.NonIdentifyingAttributes = slices.DeleteFunc(filtered.NonIdentifyingAttributes, func(kv *protobufs.KeyValue) bool {
+		_, ok := invalid[kv.GetKey()]
+		return ok
+	})
+	return filtered
+}
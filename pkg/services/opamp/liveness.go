@@ -0,0 +1,82 @@
+package opamp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+)
+
+// minReapInterval bounds how often stale agents are looked for with short heartbeat timeouts
+const minReapInterval = time.Second
+
+// SetHeartbeatTimeout sets how long agents may go without sending a message before they are
+// marked as disconnected, it defaults to agentdomain.DefaultHeartbeatTimeout. It must be
+// called before the server starts.
+func (s *Server) SetHeartbeatTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = agentdomain.DefaultHeartbeatTimeout
+	}
+	s.heartbeatTimeout = timeout
+}
+
+// reapInterval is how often stale agents are looked for, a few times per heartbeat timeout
+func (s *Server) reapInterval() time.Duration {
+	return max(s.heartbeatTimeout/4, minReapInterval)
+}
+
+// ReapStaleAgents marks the agents that sent no message within the heartbeat timeout as
+// disconnected, and closes their connection if they still have one, e.g. half-open
+// connections of agents that died silently. Agents are marked as connected again as soon
+// as they send a message. It is called periodically while the server runs.
+func (s *Server) ReapStaleAgents(ctx context.Context, now time.Time) error {
+	agents, err := s.agentRepo.ListView(ctx, agentdomain.StatusViewBasic)
+	if err != nil {
+		return fmt.Errorf("failed to list agents: %w", err)
+	}
+	for _, a := range agents {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !s.stale(a.Connection, now) {
+			continue
+		}
+		// the agent may have sent a message since it was listed
+		state, err := s.agentRepo.GetConnectionState(ctx, a.ID)
+		if err != nil || !s.stale(*state, now) {
+			continue
+		}
+		lastSeen := now.Sub(*state.LastSeen).Truncate(time.Second)
+		s.logger.With("agent_id", a.ID, "last_seen", lastSeen).Warn("agent missed its heartbeats, marking it as disconnected")
+		if conn, ok := s.conns.Evict(a.ID); ok {
+			if err := conn.Disconnect(); err != nil {
+				s.logger.With("err", err, "agent_id", a.ID).Warn("failed to close the connection of a stale agent")
+			}
+		}
+		s.markDisconnected(ctx, a.ID, *state, now, fmt.Sprintf("agent sent no message for %s", lastSeen))
+	}
+	return nil
+}
+
+// stale returns true if the agent is connected but sent no message within the heartbeat timeout
+func (s *Server) stale(state agentdomain.ConnectionState, now time.Time) bool {
+	return state.State == agentdomain.StateConnected &&
+		state.LastSeen != nil &&
+		now.Sub(*state.LastSeen) > s.heartbeatTimeout
+}
+
+// markDisconnected persists the disconnected state of an agent and records it
+func (s *Server) markDisconnected(ctx context.Context, agentID string, state agentdomain.ConnectionState, now time.Time, message string) {
+	logger := s.logger.With("agent_id", agentID)
+	state.State = agentdomain.StateDisconnected
+	state.DisconnectedAt = &now
+	if err := s.agentRepo.UpdateConnectionState(ctx, agentID, state); err != nil {
+		logger.With("err", err).Error("failed to persist disconnected state")
+	}
+	events.RecordAgentEvent(ctx, s.eventRecorder, s.agentRepo, events.TypeAgentDisconnected, agentID, message)
+	if err := s.recordDisconnect(ctx, agentID, now); err != nil {
+		logger.With("err", err).Error("failed to record agent availability")
+	}
+}
@@ -0,0 +1,56 @@
+package opamp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_ReapStaleAgents(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := t.Context()
+	env.OpampServer.SetHeartbeatTimeout(time.Minute)
+
+	// an agent that died silently, without its connection being closed
+	now := time.Now()
+	lastSeen := now.Add(-2 * time.Minute)
+	require.NoError(t, env.AgentRepo.Register(ctx, "silent", "silent"))
+	require.NoError(t, env.AgentRepo.UpdateConnectionState(ctx, "silent", agentdomain.ConnectionState{
+		State:       agentdomain.StateConnected,
+		ConnectedAt: &lastSeen,
+		LastSeen:    &lastSeen,
+	}))
+
+	agent := env.NewAgent("live")
+	require.NoError(t, agent.Start())
+	agent.WaitForConfig(t, 5*time.Second)
+
+	require.NoError(t, env.OpampServer.ReapStaleAgents(ctx, now))
+	state, err := env.AgentRepo.GetConnectionState(ctx, "silent")
+	require.NoError(t, err)
+	assert.Equal(t, agentdomain.StateDisconnected, state.State)
+	require.NotNil(t, state.DisconnectedAt)
+	assert.WithinDuration(t, now, *state.DisconnectedAt, 0)
+	state, err = env.AgentRepo.GetConnectionState(ctx, agent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, agentdomain.StateConnected, state.State, "agents sending heartbeats are kept")
+
+	// once the live agent is stale its connection is closed, it is connected again once it
+	// reconnects
+	later := time.Now().Add(2 * time.Minute)
+	require.NoError(t, env.OpampServer.ReapStaleAgents(ctx, later))
+	state, err = env.AgentRepo.GetConnectionState(ctx, agent.ID)
+	require.NoError(t, err)
+	require.NotNil(t, state.DisconnectedAt)
+	assert.WithinDuration(t, later, *state.DisconnectedAt, 0)
+	require.Eventually(t, func() bool {
+		state, err := env.AgentRepo.GetConnectionState(context.Background(), agent.ID)
+		require.NoError(t, err)
+		return state.State == agentdomain.StateConnected
+	}, 10*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,320 @@
+package opamp
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/config"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	collectormetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	// ownMetricsKeyID is the key of the key signing own metrics tokens in its store
+	ownMetricsKeyID = "signing-key"
+	// maxOwnMetricsBody bounds the size of the own metrics exports of agents
+	maxOwnMetricsBody = 4 << 20
+)
+
+// SetOwnMetricsOffer offers the agents reporting their own metrics to send them to endpoint,
+// an OTLP/HTTP metrics URL, with headers. Offers are sent along with the responses to the
+// reports carrying an agent description, i.e. once per connection and on full state reports.
+func (s *Server) SetOwnMetricsOffer(endpoint string, headers map[string]string) {
+	s.ownMetrics = &protobufs.TelemetryConnectionSettings{
+		DestinationEndpoint: endpoint,
+		Headers:             toOpAMPHeaders(headers),
+	}
+}
+
+func toOpAMPHeaders(headers map[string]string) *protobufs.Headers {
+	if len(headers) == 0 {
+		return nil
+	}
+	h := &protobufs.Headers{}
+	for _, key := range slices.Sorted(maps.Keys(headers)) {
+		h.Headers = append(h.Headers, &protobufs.Header{Key: key, Value: headers[key]})
+	}
+	return h
+}
+
+// LoadOwnMetricsKey returns the key signing the tokens agents authenticate to the own metrics
+// receiver with, generating it on first use. Replicas sharing store share the key.
+func LoadOwnMetricsKey(ctx context.Context, store storage.KeyValue[*wrapperspb.BytesValue]) ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	err := store.Create(ctx, ownMetricsKeyID, wrapperspb.Bytes(key))
+	if err == nil {
+		return key, nil
+	} else if !errors.Is(err, storage.ErrAlreadyExists) {
+		return nil, fmt.Errorf("failed to store own metrics key: %w", err)
+	}
+	stored, err := store.Get(ctx, ownMetricsKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get own metrics key: %w", err)
+	}
+	return stored.GetValue(), nil
+}
+
+// ConfigureOwnMetricsHTTP receives the own metrics of agents at config.AgentMetricsPath on the
+// router, keeping the latest metrics of each agent in store. Agents authenticate with the
+// bearer token offered to them, signed with key.
+func (s *Server) ConfigureOwnMetricsHTTP(router *mux.Router, store storage.KeyValue[*v1alpha1.AgentMetrics], key []byte) {
+	s.ownMetricsKey = key
+	s.ownMetricsStore = store
+	router.Handle(config.AgentMetricsPath, &ownMetricsHandler{server: s}).Methods(http.MethodPost)
+	s.logger.With("path", config.AgentMetricsPath).Info("receiving agent metrics")
+}
+
+// ownMetricsSettings returns the connection settings offered to an agent, nil unless own
+// metrics settings are offered and the agent reports its own metrics
+func (s *Server) ownMetricsSettings(agentID string, capabilities uint64) *protobufs.ConnectionSettingsOffers {
+	if s.ownMetrics == nil || capabilities&uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnMetrics) == 0 {
+		return nil
+	}
+	settings := proto.Clone(s.ownMetrics).(*protobufs.TelemetryConnectionSettings)
+	if s.ownMetricsKey != nil {
+		if settings.Headers == nil {
+			settings.Headers = &protobufs.Headers{}
+		}
+		settings.Headers.Headers = append(settings.Headers.Headers, &protobufs.Header{
+			Key:   "Authorization",
+			Value: "Bearer " + s.ownMetricsToken(agentID),
+		})
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(settings)
+	if err != nil {
+		s.logger.With("agent_id", agentID, "err", err).Error("failed to hash own metrics settings")
+		return nil
+	}
+	hash := sha256.Sum256(data)
+	return &protobufs.ConnectionSettingsOffers{
+		Hash:       hash[:],
+		OwnMetrics: settings,
+	}
+}
+
+// ownMetricsToken returns the token an agent authenticates to the own metrics receiver with:
+// its ID and the signature of its ID
+func (s *Server) ownMetricsToken(agentID string) string {
+	return agentID + "." + base64.RawURLEncoding.EncodeToString(s.signAgentID(agentID))
+}
+
+func (s *Server) signAgentID(agentID string) []byte {
+	mac := hmac.New(sha256.New, s.ownMetricsKey)
+	mac.Write([]byte(agentID))
+	return mac.Sum(nil)
+}
+
+// verifyOwnMetricsToken returns the ID of the agent a token was offered to
+func (s *Server) verifyOwnMetricsToken(token string) (string, bool) {
+	i := strings.LastIndexByte(token, '.')
+	if i <= 0 {
+		return "", false
+	}
+	agentID := token[:i]
+	signature, err := base64.RawURLEncoding.DecodeString(token[i+1:])
+	if err != nil {
+		return "", false
+	}
+	return agentID, subtle.ConstantTimeCompare(signature, s.signAgentID(agentID)) == 1
+}
+
+type ownMetricsHandler struct {
+	server *Server
+}
+
+func (h *ownMetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	agentID, ok := h.server.verifyOwnMetricsToken(token)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+	logger := h.server.logger.With("agent_id", agentID)
+	revoked, err := h.server.agentRepo.IsRevoked(r.Context(), agentID)
+	if errors.Is(err, agentdomain.ErrAgentNotFound) {
+		http.Error(w, fmt.Sprintf("agent not found: %s", agentID), http.StatusNotFound)
+		return
+	} else if err != nil {
+		logger.With("err", err).Error("failed to check agent registration")
+		http.Error(w, "failed to check agent registration", http.StatusServiceUnavailable)
+		return
+	}
+	if revoked {
+		http.Error(w, "agent revoked", http.StatusForbidden)
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxOwnMetricsBody)
+	defer body.Close()
+	var reader io.Reader = body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			http.Error(w, "invalid gzip body", http.StatusBadRequest)
+			return
+		}
+		defer gz.Close()
+		reader = io.LimitReader(gz, maxOwnMetricsBody)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	json := strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+	var export collectormetricspb.ExportMetricsServiceRequest
+	if json {
+		err = protojson.Unmarshal(data, &export)
+	} else {
+		err = proto.Unmarshal(data, &export)
+	}
+	if err != nil {
+		http.Error(w, "failed to unmarshal metrics payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.server.receiveOwnMetrics(r.Context(), agentID, &export, time.Now()); err != nil {
+		logger.With("err", err).Error("failed to store agent metrics")
+		http.Error(w, "failed to store agent metrics", http.StatusServiceUnavailable)
+		return
+	}
+	var resp []byte
+	if json {
+		w.Header().Set("Content-Type", "application/json")
+		resp, _ = protojson.Marshal(&collectormetricspb.ExportMetricsServiceResponse{})
+	} else {
+		w.Header().Set("Content-Type", ContentTypeProtobuf)
+		resp, _ = proto.Marshal(&collectormetricspb.ExportMetricsServiceResponse{})
+	}
+	_, _ = w.Write(resp)
+}
+
+// receiveOwnMetrics stores the metrics of an agent's collector found in export, along with
+// its CPU utilization since its previous export
+func (s *Server) receiveOwnMetrics(ctx context.Context, agentID string, export *collectormetricspb.ExportMetricsServiceRequest, now time.Time) error {
+	metrics := agentMetricsFromOTLP(export)
+	metrics.ReceivedAt = timestamppb.New(now)
+	previous, err := s.ownMetricsStore.Get(ctx, agentID)
+	if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return err
+	}
+	if previous != nil {
+		elapsed := now.Sub(previous.GetReceivedAt().AsTime()).Seconds()
+		used := metrics.GetCpuSeconds() - previous.GetCpuSeconds()
+		// the CPU time is reset when the collector restarts
+		if elapsed > 0 && used >= 0 {
+			metrics.CpuUtilization = used / elapsed
+		}
+	}
+	return s.ownMetricsStore.Put(ctx, agentID, metrics)
+}
+
+// agentMetricsFromOTLP extracts the process and exporter queue metrics of a collector from
+// the metrics of its internal telemetry, named either otelcol_process_memory_rss or
+// otelcol.process.memory.rss depending on the collector version
+func agentMetricsFromOTLP(export *collectormetricspb.ExportMetricsServiceRequest) *v1alpha1.AgentMetrics {
+	metrics := &v1alpha1.AgentMetrics{}
+	queues := map[string]*v1alpha1.ExporterQueue{}
+	queue := func(exporter string) *v1alpha1.ExporterQueue {
+		if queues[exporter] == nil {
+			queues[exporter] = &v1alpha1.ExporterQueue{Exporter: exporter}
+		}
+		return queues[exporter]
+	}
+	for _, rm := range export.GetResourceMetrics() {
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, m := range sm.GetMetrics() {
+				name := strings.ReplaceAll(m.GetName(), ".", "_")
+				name = strings.TrimPrefix(name, "otelcol_")
+				points := numberDataPoints(m)
+				if len(points) == 0 {
+					continue
+				}
+				last := points[len(points)-1]
+				switch name {
+				case "process_cpu_seconds":
+					metrics.CpuSeconds = numberValue(last)
+				case "process_memory_rss":
+					metrics.MemoryRssBytes = int64(numberValue(last))
+				case "process_runtime_heap_alloc_bytes":
+					metrics.HeapAllocBytes = int64(numberValue(last))
+				case "process_uptime":
+					metrics.Uptime = durationpb.New(time.Duration(numberValue(last) * float64(time.Second)))
+				case "exporter_queue_size":
+					for _, dp := range points {
+						queue(exporterID(dp.GetAttributes())).Size = int64(numberValue(dp))
+					}
+				case "exporter_queue_capacity":
+					for _, dp := range points {
+						queue(exporterID(dp.GetAttributes())).Capacity = int64(numberValue(dp))
+					}
+				}
+			}
+		}
+	}
+	for _, exporter := range slices.Sorted(maps.Keys(queues)) {
+		metrics.ExporterQueues = append(metrics.ExporterQueues, queues[exporter])
+	}
+	return metrics
+}
+
+func numberDataPoints(m *metricspb.Metric) []*metricspb.NumberDataPoint {
+	switch data := m.GetData().(type) {
+	case *metricspb.Metric_Gauge:
+		return data.Gauge.GetDataPoints()
+	case *metricspb.Metric_Sum:
+		return data.Sum.GetDataPoints()
+	}
+	return nil
+}
+
+func numberValue(dp *metricspb.NumberDataPoint) float64 {
+	if v, ok := dp.GetValue().(*metricspb.NumberDataPoint_AsInt); ok {
+		return float64(v.AsInt)
+	}
+	return dp.GetAsDouble()
+}
+
+// exporterID returns the ID of the exporter of a queue metric, named by the exporter attribute
+// or, in recent collector versions, the otelcol.component.id attribute
+func exporterID(attrs []*commonpb.KeyValue) string {
+	var id string
+	for _, attr := range attrs {
+		switch attr.GetKey() {
+		case "exporter":
+			return attr.GetValue().GetStringValue()
+		case "otelcol.component.id":
+			id = attr.GetValue().GetStringValue()
+		}
+	}
+	return id
+}
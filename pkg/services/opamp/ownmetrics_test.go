@@ -0,0 +1,137 @@
+package opamp_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/mux"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	agentsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/config"
+	"github.com/otelfleet/otelfleet/pkg/services/opamp"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collectormetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// collectorMetrics returns the internal telemetry export of a collector having used cpuSeconds
+func collectorMetrics(cpuSeconds float64) *collectormetricspb.ExportMetricsServiceRequest {
+	gauge := func(name string, value float64, attrs ...*commonpb.KeyValue) *metricspb.Metric {
+		return &metricspb.Metric{Name: name, Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{
+			DataPoints: []*metricspb.NumberDataPoint{{
+				Attributes: attrs,
+				Value:      &metricspb.NumberDataPoint_AsDouble{AsDouble: value},
+			}},
+		}}}
+	}
+	exporter := &commonpb.KeyValue{
+		Key:   "exporter",
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "otlp"}},
+	}
+	return &collectormetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Metrics: []*metricspb.Metric{
+					gauge("otelcol_process_cpu_seconds", cpuSeconds),
+					gauge("otelcol.process.memory.rss", 64<<20),
+					gauge("otelcol_process_uptime", 90),
+					gauge("otelcol_exporter_queue_size", 12, exporter),
+					gauge("otelcol_exporter_queue_capacity", 1000, exporter),
+				},
+			}},
+		}},
+	}
+}
+
+func TestOwnMetrics(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := t.Context()
+	require.NoError(t, env.AgentRepo.Register(ctx, "agent-1", "agent-1"))
+
+	keyStore := storage.NewProtoKV[*wrapperspb.BytesValue](env.Logger, env.Broker.KeyValue("agent-metrics-key"))
+	key, err := opamp.LoadOwnMetricsKey(ctx, keyStore)
+	require.NoError(t, err)
+	again, err := opamp.LoadOwnMetricsKey(ctx, keyStore)
+	require.NoError(t, err)
+	assert.Equal(t, key, again, "replicas share the signing key")
+
+	router := mux.NewRouter()
+	env.OpampServer.SetOwnMetricsOffer("https://fleet.example.com"+config.AgentMetricsPath, map[string]string{"X-Scope-OrgID": "team-a"})
+	env.OpampServer.ConfigureOwnMetricsHTTP(router, env.AgentMetricsStore, key)
+
+	report := func(capabilities protobufs.AgentCapabilities) *protobufs.ServerToAgent {
+		return env.OpampServer.OnMessage(ctx, &recordingConn{}, &protobufs.AgentToServer{
+			InstanceUid: []byte("agent-1"),
+			AgentDescription: &protobufs.AgentDescription{
+				IdentifyingAttributes: []*protobufs.KeyValue{{
+					Key:   supervisor.AttributeOtelfleetAgentId,
+					Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: "agent-1"}},
+				}},
+			},
+			Capabilities: uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus | capabilities),
+		})
+	}
+	assert.Nil(t, report(0).GetConnectionSettings(), "settings are only offered to agents reporting their own metrics")
+	offer := report(protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnMetrics).GetConnectionSettings()
+	require.NotNil(t, offer)
+	assert.NotEmpty(t, offer.GetHash())
+	assert.Equal(t, "https://fleet.example.com/v1/agent-metrics", offer.GetOwnMetrics().GetDestinationEndpoint())
+	headers := map[string]string{}
+	for _, h := range offer.GetOwnMetrics().GetHeaders().GetHeaders() {
+		headers[h.GetKey()] = h.GetValue()
+	}
+	assert.Equal(t, "team-a", headers["X-Scope-OrgID"])
+	authorization := headers["Authorization"]
+	require.NotEmpty(t, authorization)
+
+	post := func(authorization string, cpuSeconds float64) int {
+		body, err := proto.Marshal(collectorMetrics(cpuSeconds))
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, config.AgentMetricsPath, bytes.NewReader(body))
+		req.Header.Set("Content-Type", opamp.ContentTypeProtobuf)
+		req.Header.Set("Authorization", authorization)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	assert.Equal(t, http.StatusUnauthorized, post("", 1))
+	assert.Equal(t, http.StatusUnauthorized, post("Bearer agent-2"+authorization[len("Bearer agent-1"):], 1),
+		"tokens are bound to the agent they were offered to")
+	require.Equal(t, http.StatusOK, post(authorization, 1))
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, http.StatusOK, post(authorization, 1.5))
+
+	resp, err := env.AgentServer.Status(ctx, connect.NewRequest(&agentsv1alpha1.GetAgentStatusRequest{
+		AgentId: "agent-1",
+		View:    agentsv1alpha1.AgentStatusView_AGENT_STATUS_VIEW_HEALTH,
+	}))
+	require.NoError(t, err)
+	metrics := resp.Msg.GetStatus().GetMetrics()
+	require.NotNil(t, metrics)
+	assert.Equal(t, 1.5, metrics.GetCpuSeconds())
+	assert.Greater(t, metrics.GetCpuUtilization(), 0.0, "the CPU utilization is computed from the previous report")
+	assert.Equal(t, int64(64<<20), metrics.GetMemoryRssBytes())
+	assert.Equal(t, 90*time.Second, metrics.GetUptime().AsDuration())
+	require.Len(t, metrics.GetExporterQueues(), 1)
+	assert.Equal(t, "otlp", metrics.GetExporterQueues()[0].GetExporter())
+	assert.Equal(t, int64(12), metrics.GetExporterQueues()[0].GetSize())
+	assert.Equal(t, int64(1000), metrics.GetExporterQueues()[0].GetCapacity())
+
+	resp, err = env.AgentServer.Status(ctx, connect.NewRequest(&agentsv1alpha1.GetAgentStatusRequest{
+		AgentId: "agent-1",
+		View:    agentsv1alpha1.AgentStatusView_AGENT_STATUS_VIEW_BASIC,
+	}))
+	require.NoError(t, err)
+	assert.Nil(t, resp.Msg.GetStatus().GetMetrics(), "metrics are reported along with the health of agents")
+}
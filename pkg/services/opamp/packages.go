@@ -0,0 +1,88 @@
+package opamp
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/services/otelconfig"
+)
+
+// PackageManager offers collector packages to agents and tracks their installation
+type PackageManager interface {
+	// AvailablePackages returns the packages offered to the agent, nil if none are
+	AvailablePackages(ctx context.Context, agentID string) (*protobufs.PackagesAvailable, error)
+	PackageStatusesReported(ctx context.Context, agentID string, statuses *protobufs.PackageStatuses) error
+}
+
+// SetPackageManager enables offering packages to the agents accepting them
+func (s *Server) SetPackageManager(manager PackageManager) {
+	s.packageManager = manager
+}
+
+// packageOffer returns the packages offered to the agent, or nil if the agent already has the
+// current offer, identified by the hash of all packages it last reported
+func (s *Server) packageOffer(ctx context.Context, agentID string, serverProvidedHash []byte) (*protobufs.PackagesAvailable, error) {
+	available, err := s.packageManager.AvailablePackages(ctx, agentID)
+	if err != nil || available == nil {
+		return nil, err
+	}
+	if bytes.Equal(available.GetAllPackagesHash(), serverProvidedHash) {
+		return nil, nil
+	}
+	return available, nil
+}
+
+// handlePackageStatuses records the package statuses reported by an agent, returning the
+// packages to offer it if its offer is outdated
+func (s *Server) handlePackageStatuses(ctx context.Context, agentID string, capabilities uint64, statuses *protobufs.PackageStatuses) *protobufs.PackagesAvailable {
+	logger := s.logger.With("agent_id", agentID)
+	if err := s.packageManager.PackageStatusesReported(ctx, agentID, statuses); err != nil {
+		logger.With("err", err).Error("failed to record package statuses")
+	}
+	if capabilities&uint64(protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages) == 0 {
+		return nil
+	}
+	offer, err := s.packageOffer(ctx, agentID, statuses.GetServerProvidedAllPackagesHash())
+	if err != nil {
+		logger.With("err", err).Error("failed to get packages offered to agent")
+		return nil
+	}
+	return offer
+}
+
+// NotifyPackageChange offers its packages to a connected agent accepting packages, if they
+// changed. Agents that aren't connected are offered their packages when they reconnect.
+// This implements the otelconfig.PackageChangeNotifier interface
+func (s *Server) NotifyPackageChange(agentID string) {
+	if s.packageManager == nil {
+		return
+	}
+	if _, ok := s.conns.Lookup(agentID); !ok {
+		return
+	}
+	logger := s.logger.With("agent_id", agentID)
+	ctx := context.Background()
+	state, err := s.agentRepo.GetConnectionState(ctx, agentID)
+	if err != nil {
+		logger.With("err", err).Error("failed to get agent connection state")
+		return
+	}
+	if !state.Capabilities.Has(protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages) {
+		return
+	}
+	offer, err := s.packageOffer(ctx, agentID, nil)
+	if err != nil {
+		logger.With("err", err).Error("failed to get packages offered to agent")
+		return
+	} else if offer == nil {
+		return
+	}
+	if err := s.conns.Send(ctx, agentID, &protobufs.ServerToAgent{PackagesAvailable: offer}); err != nil {
+		logger.With("err", err).Error("failed to offer packages to agent")
+		return
+	}
+	logger.Info("offered packages to agent")
+}
+
+var _ otelconfig.PackageChangeNotifier = (*Server)(nil)
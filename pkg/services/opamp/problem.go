@@ -0,0 +1,48 @@
+package opamp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/agent"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetProblemStore enables recording the problems reported by agents, keyed by agent.ProblemKey
+func (s *Server) SetProblemStore(store storage.KeyValue[*v1alpha1.AgentProblem]) {
+	s.problemStore = store
+}
+
+// recordProblem opens the problem reported by an agent, or updates it if it is still open
+func (s *Server) recordProblem(ctx context.Context, agentID string, report *v1alpha1.AgentProblemReport) error {
+	if s.problemStore == nil {
+		return nil
+	}
+	s.problemMu.Lock()
+	defer s.problemMu.Unlock()
+	key := agent.ProblemKey(agentID, report.GetType())
+	problem, err := s.problemStore.Get(ctx, key)
+	if grpcutil.IsErrorNotFound(err) {
+		problem = &v1alpha1.AgentProblem{
+			AgentId:         agentID,
+			Type:            report.GetType(),
+			FirstReportedAt: timestamppb.Now(),
+		}
+	} else if err != nil {
+		return err
+	}
+	problem.Message = report.GetMessage()
+	problem.Occurrences = report.GetOccurrences()
+	problem.Details = report.GetDetails()
+	problem.Reports++
+	problem.LastReportedAt = timestamppb.Now()
+	return s.problemStore.Put(ctx, key, problem)
+}
+
+// describeProblem is the message of the event recorded for a problem report
+func describeProblem(report *v1alpha1.AgentProblemReport) string {
+	return fmt.Sprintf("agent reported %s problem: %s", report.GetType(), report.GetMessage())
+}
@@ -0,0 +1,320 @@
+package opamp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/mux"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	adminv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1/v1alpha1connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// relayQueueSize is the number of messages queued for a gateway before sends block
+const relayQueueSize = 64
+
+var errGatewayNotWatching = errors.New("gateway is not watching for messages")
+
+// gateway tracks the agent connections relayed by a regional gateway
+type gateway struct {
+	mu    sync.Mutex
+	conns map[string]*relayConn
+	// the watch delivering messages to the gateway, nil when it isn't watching
+	watch *relayWatch
+}
+
+type relayWatch struct {
+	messages chan *v1alpha1.RelayedMessage
+	// closed when the watch ends
+	done chan struct{}
+}
+
+// relayConn is the connection of an agent to a gateway. Messages sent while one of its
+// messages is relayed are returned with the reply, others are streamed to the gateway.
+type relayConn struct {
+	gateway      *gateway
+	connectionID string
+
+	mu sync.Mutex
+	// messages sent while a message is relayed, nil otherwise
+	replies *[]*protobufs.ServerToAgent
+}
+
+var _ v1alpha1connect.GatewayServiceHandler = (*Server)(nil)
+
+// Connection returns nil, relayed agents are connected to the gateway
+func (c *relayConn) Connection() net.Conn {
+	return nil
+}
+
+func (c *relayConn) Send(ctx context.Context, msg *protobufs.ServerToAgent) error {
+	c.mu.Lock()
+	if c.replies != nil {
+		*c.replies = append(*c.replies, msg)
+		c.mu.Unlock()
+		return nil
+	}
+	c.mu.Unlock()
+
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.gateway.mu.Lock()
+	w := c.gateway.watch
+	c.gateway.mu.Unlock()
+	if w == nil {
+		return errGatewayNotWatching
+	}
+	select {
+	case w.messages <- &v1alpha1.RelayedMessage{ConnectionId: c.connectionID, ServerToAgent: data}:
+		return nil
+	case <-w.done:
+		return errGatewayNotWatching
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Disconnect forgets the connection, the gateway keeps the agent connected until it
+// relays its next message
+func (c *relayConn) Disconnect() error {
+	c.gateway.mu.Lock()
+	defer c.gateway.mu.Unlock()
+	if c.gateway.conns[c.connectionID] == c {
+		delete(c.gateway.conns, c.connectionID)
+	}
+	return nil
+}
+
+// collect starts collecting the messages sent on the connection
+func (c *relayConn) collect() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.replies = &[]*protobufs.ServerToAgent{}
+}
+
+// collected stops collecting messages and returns the messages sent since collect
+func (c *relayConn) collected() []*protobufs.ServerToAgent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	replies := *c.replies
+	c.replies = nil
+	return replies
+}
+
+// ConfigureGatewayHTTP serves the gateway relay API on the management API router. Gateways
+// authenticate with the bearer token of their ID in tokens.
+func (s *Server) ConfigureGatewayHTTP(router *mux.Router, tokens map[string]string, interceptors ...connect.Interceptor) {
+	interceptors = append([]connect.Interceptor{&gatewayAuthInterceptor{tokens: tokens}}, interceptors...)
+	v1alpha1connect.RegisterGatewayServiceHandler(router, s, connect.WithInterceptors(interceptors...))
+}
+
+func (s *Server) gateway(gatewayID string) *gateway {
+	s.gatewaysMu.Lock()
+	defer s.gatewaysMu.Unlock()
+	gw, ok := s.gateways[gatewayID]
+	if !ok {
+		gw = &gateway{conns: map[string]*relayConn{}}
+		s.gateways[gatewayID] = gw
+	}
+	return gw
+}
+
+// relayConnOf returns the connection of a gateway if it is registered
+func (s *Server) relayConnOf(gatewayID, connectionID string) (*relayConn, bool) {
+	gw := s.gateway(gatewayID)
+	gw.mu.Lock()
+	defer gw.mu.Unlock()
+	conn, ok := gw.conns[connectionID]
+	return conn, ok
+}
+
+// relayConn returns the connection of a gateway, registering it on first use
+func (s *Server) relayConn(gatewayID, connectionID string) *relayConn {
+	gw := s.gateway(gatewayID)
+	gw.mu.Lock()
+	conn, ok := gw.conns[connectionID]
+	if !ok {
+		conn = &relayConn{gateway: gw, connectionID: connectionID}
+		gw.conns[connectionID] = conn
+	}
+	gw.mu.Unlock()
+	if !ok {
+		s.conns.Register(conn, &adminv1alpha1.OpAMPSession{Transport: TransportGateway, GatewayId: gatewayID})
+	}
+	return conn
+}
+
+func (s *Server) Relay(
+	ctx context.Context, req *connect.Request[v1alpha1.RelayRequest],
+) (*connect.Response[v1alpha1.RelayResponse], error) {
+	gatewayID := req.Msg.GetGatewayId()
+	if err := authorizeGateway(ctx, gatewayID); err != nil {
+		return nil, err
+	}
+	// gateways terminate the connections of agents, so the client certificates of relayed
+	// agents can't be verified
+	if s.requireClientCert {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("agents must connect with a client certificate, gateways can't relay them"))
+	}
+	if req.Msg.GetConnectionId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("connection_id is required"))
+	}
+	msg := &protobufs.AgentToServer{}
+	if err := proto.Unmarshal(req.Msg.GetAgentToServer(), msg); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid agent message: %w", err))
+	}
+	describedID := extractAgentID(msg.GetAgentDescription())
+	// agents identify themselves through one gateway at a time
+	if describedID != "" {
+		if err := s.checkGatewayAgent(ctx, gatewayID, describedID); err != nil {
+			s.logger.With("gateway_id", gatewayID, "agent_id", describedID, "err", err).Warn("rejecting agent relayed by another gateway")
+			return nil, err
+		}
+	}
+	// the gateway identifies agents whose connections it relayed before the server restarted,
+	// which it may only do for the agents that identified themselves through it
+	var boundID string
+	if agentID := req.Msg.GetAgentId(); agentID != "" && describedID == "" {
+		var bound bool
+		if conn, ok := s.relayConnOf(gatewayID, req.Msg.GetConnectionId()); ok {
+			_, bound = s.conns.AgentID(conn)
+		}
+		if !bound {
+			relayed, err := s.relayedBy(ctx, gatewayID, agentID)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to check the gateway of agent %s: %w", agentID, err))
+			}
+			if !relayed {
+				s.logger.With("gateway_id", gatewayID, "agent_id", agentID).Warn("rejecting agent not relayed by the gateway")
+				return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("agent %s did not connect through gateway %s", agentID, gatewayID))
+			}
+			boundID = agentID
+		}
+	}
+	conn := s.relayConn(gatewayID, req.Msg.GetConnectionId())
+	if boundID != "" {
+		if _, ok := s.conns.AgentID(conn); !ok {
+			s.conns.Bind(conn, boundID)
+		}
+	}
+
+	conn.collect()
+	reply := s.OnMessage(ctx, conn, msg)
+	replies := append(conn.collected(), reply)
+	if describedID != "" && reply.GetErrorResponse() == nil {
+		if err := s.recordGatewayAgent(ctx, gatewayID, describedID); err != nil {
+			s.logger.With("gateway_id", gatewayID, "agent_id", describedID, "err", err).Error("failed to record the gateway of agent")
+		}
+	}
+
+	resp := &v1alpha1.RelayResponse{}
+	for _, reply := range replies {
+		data, err := proto.Marshal(reply)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to encode reply: %w", err))
+		}
+		resp.ServerToAgent = append(resp.ServerToAgent, data)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *Server) Watch(
+	ctx context.Context,
+	req *connect.Request[v1alpha1.WatchGatewayRequest],
+	stream *connect.ServerStream[v1alpha1.RelayedMessage],
+) error {
+	gatewayID := req.Msg.GetGatewayId()
+	if err := authorizeGateway(ctx, gatewayID); err != nil {
+		return err
+	}
+	logger := s.logger.With("gateway_id", gatewayID)
+	gw := s.gateway(gatewayID)
+	w := &relayWatch{
+		messages: make(chan *v1alpha1.RelayedMessage, relayQueueSize),
+		done:     make(chan struct{}),
+	}
+	// a reconnecting gateway supersedes its previous watch
+	gw.mu.Lock()
+	if gw.watch != nil {
+		close(gw.watch.done)
+	}
+	gw.watch = w
+	gw.mu.Unlock()
+	defer s.endWatch(gatewayID, gw, w)
+
+	// flush the response headers so the gateway knows the watch is established
+	if err := stream.Send(nil); err != nil {
+		return err
+	}
+	logger.Info("gateway watching")
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.done:
+			return nil
+		case msg := <-w.messages:
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// endWatch disconnects the agents relayed by the gateway once its current watch ends,
+// they can't be sent messages until it watches again
+func (s *Server) endWatch(gatewayID string, gw *gateway, w *relayWatch) {
+	gw.mu.Lock()
+	if gw.watch != w {
+		gw.mu.Unlock()
+		return
+	}
+	gw.watch = nil
+	close(w.done)
+	conns := make([]*relayConn, 0, len(gw.conns))
+	for _, conn := range gw.conns {
+		conns = append(conns, conn)
+	}
+	clear(gw.conns)
+	gw.mu.Unlock()
+
+	s.logger.With("gateway_id", gatewayID, "agents", len(conns)).Info("gateway stopped watching, disconnecting its agents")
+	for _, conn := range conns {
+		s.conns.Unregister(conn)
+	}
+}
+
+func (s *Server) Disconnect(
+	ctx context.Context, req *connect.Request[v1alpha1.DisconnectRelayedAgentRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	gatewayID := req.Msg.GetGatewayId()
+	if err := authorizeGateway(ctx, gatewayID); err != nil {
+		return nil, err
+	}
+	gw := s.gateway(gatewayID)
+	gw.mu.Lock()
+	conn, ok := gw.conns[req.Msg.GetConnectionId()]
+	delete(gw.conns, req.Msg.GetConnectionId())
+	gw.mu.Unlock()
+	if !ok {
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	}
+	agentID, current := s.conns.Unregister(conn)
+	s.logger.With("gateway_id", gatewayID, "agent_id", agentID).Info("relayed agent disconnected")
+	// the agent may connect through another gateway, unless it reconnected through this one
+	if current {
+		if err := s.releaseGatewayAgent(ctx, gatewayID, agentID); err != nil {
+			s.logger.With("gateway_id", gatewayID, "agent_id", agentID, "err", err).Error("failed to release the gateway of agent")
+		}
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
@@ -0,0 +1,181 @@
+package opamp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/client"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newGatewayClient(t *testing.T, env *testutil.TestEnv, token string) v1alpha1connect.GatewayServiceClient {
+	t.Helper()
+	c, err := client.New(client.Config{ServerURL: env.BaseURL, BearerToken: token, MaxRetries: -1})
+	require.NoError(t, err)
+	return c.Gateway
+}
+
+func agentToServer(t *testing.T, agentID string) []byte {
+	t.Helper()
+	msg, err := proto.Marshal(&protobufs.AgentToServer{
+		InstanceUid: []byte(agentID),
+		AgentDescription: &protobufs.AgentDescription{
+			IdentifyingAttributes: []*protobufs.KeyValue{{
+				Key:   supervisor.AttributeOtelfleetAgentId,
+				Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: agentID}},
+			}},
+		},
+	})
+	require.NoError(t, err)
+	return msg
+}
+
+func TestRelay_AuthenticatesGateways(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := t.Context()
+	require.NoError(t, env.AgentRepo.Register(ctx, "agent-1", "agent-1"))
+	relay := func(gw v1alpha1connect.GatewayServiceClient, req *v1alpha1.RelayRequest) error {
+		_, err := gw.Relay(ctx, connect.NewRequest(req))
+		return err
+	}
+
+	// gateways must present the token of the ID they relay as
+	err := relay(newGatewayClient(t, env, ""), &v1alpha1.RelayRequest{
+		GatewayId: testutil.TestGatewayID, ConnectionId: "conn-1", AgentToServer: agentToServer(t, "agent-1"),
+	})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	err = relay(newGatewayClient(t, env, "guessed"), &v1alpha1.RelayRequest{
+		GatewayId: testutil.TestGatewayID, ConnectionId: "conn-1", AgentToServer: agentToServer(t, "agent-1"),
+	})
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	other := newGatewayClient(t, env, testutil.TestOtherGatewayToken)
+	err = relay(other, &v1alpha1.RelayRequest{
+		GatewayId: testutil.TestGatewayID, ConnectionId: "conn-1", AgentToServer: agentToServer(t, "agent-1"),
+	})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	gw := newGatewayClient(t, env, testutil.TestGatewayToken)
+	require.NoError(t, relay(gw, &v1alpha1.RelayRequest{
+		GatewayId: testutil.TestGatewayID, ConnectionId: "conn-1", AgentToServer: agentToServer(t, "agent-1"),
+	}))
+
+	// gateways may only identify the agents that connected through them by ID
+	heartbeat, err := proto.Marshal(&protobufs.AgentToServer{InstanceUid: []byte("agent-1")})
+	require.NoError(t, err)
+	err = relay(other, &v1alpha1.RelayRequest{
+		GatewayId: testutil.TestOtherGatewayID, ConnectionId: "conn-1", AgentId: "agent-1", AgentToServer: heartbeat,
+	})
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	require.NoError(t, relay(gw, &v1alpha1.RelayRequest{
+		GatewayId: testutil.TestGatewayID, ConnectionId: "conn-2", AgentId: "agent-1", AgentToServer: heartbeat,
+	}))
+}
+
+func TestRelay_OneGatewayPerAgent(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := t.Context()
+	require.NoError(t, env.AgentRepo.Register(ctx, "agent-1", "agent-1"))
+	gw := newGatewayClient(t, env, testutil.TestGatewayToken)
+	other := newGatewayClient(t, env, testutil.TestOtherGatewayToken)
+	_, err := gw.Relay(ctx, connect.NewRequest(&v1alpha1.RelayRequest{
+		GatewayId: testutil.TestGatewayID, ConnectionId: "conn-1", AgentToServer: agentToServer(t, "agent-1"),
+	}))
+	require.NoError(t, err)
+
+	// another gateway can't claim the agent by describing it
+	claim := func() error {
+		_, err := other.Relay(ctx, connect.NewRequest(&v1alpha1.RelayRequest{
+			GatewayId: testutil.TestOtherGatewayID, ConnectionId: "conn-1", AgentToServer: agentToServer(t, "agent-1"),
+		}))
+		return err
+	}
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(claim()))
+	heartbeat, err := proto.Marshal(&protobufs.AgentToServer{InstanceUid: []byte("agent-1")})
+	require.NoError(t, err)
+	_, err = gw.Relay(ctx, connect.NewRequest(&v1alpha1.RelayRequest{
+		GatewayId: testutil.TestGatewayID, ConnectionId: "conn-2", AgentId: "agent-1", AgentToServer: heartbeat,
+	}))
+	require.NoError(t, err, "the binding is kept")
+
+	// once the gateway reports the agent disconnected, it may connect through another one
+	_, err = gw.Disconnect(ctx, connect.NewRequest(&v1alpha1.DisconnectRelayedAgentRequest{
+		GatewayId: testutil.TestGatewayID, ConnectionId: "conn-2",
+	}))
+	require.NoError(t, err)
+	require.NoError(t, claim())
+	_, err = gw.Relay(ctx, connect.NewRequest(&v1alpha1.RelayRequest{
+		GatewayId: testutil.TestGatewayID, ConnectionId: "conn-3", AgentToServer: agentToServer(t, "agent-1"),
+	}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func TestRelay_RequireClientCert(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := t.Context()
+	require.NoError(t, env.AgentRepo.Register(ctx, "agent-1", "agent-1"))
+	env.OpampServer.SetRequireClientCert(true)
+
+	// the client certificates of relayed agents can't be verified
+	_, err := newGatewayClient(t, env, testutil.TestGatewayToken).Relay(ctx, connect.NewRequest(&v1alpha1.RelayRequest{
+		GatewayId: testutil.TestGatewayID, ConnectionId: "conn-1", AgentToServer: agentToServer(t, "agent-1"),
+	}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+}
+
+func TestRelay_WatchEndDisconnectsAgents(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := t.Context()
+	require.NoError(t, env.AgentRepo.Register(ctx, "agent-1", "agent-1"))
+	gw := newGatewayClient(t, env, testutil.TestGatewayToken)
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := gw.Watch(watchCtx, connect.NewRequest(&v1alpha1.WatchGatewayRequest{GatewayId: testutil.TestGatewayID}))
+	require.NoError(t, err)
+
+	resp, err := gw.Relay(ctx, connect.NewRequest(&v1alpha1.RelayRequest{
+		GatewayId:     testutil.TestGatewayID,
+		ConnectionId:  "conn-1",
+		AgentToServer: agentToServer(t, "agent-1"),
+	}))
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Msg.GetServerToAgent())
+
+	// pushes are streamed to the gateway, once its watch is established
+	received := make(chan *v1alpha1.RelayedMessage, 1)
+	go func() {
+		if stream.Receive() {
+			received <- stream.Msg()
+		}
+	}()
+	var relayed *v1alpha1.RelayedMessage
+	require.Eventually(t, func() bool {
+		env.OpampServer.NotifyConfigChange("agent-1")
+		select {
+		case relayed = <-received:
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "conn-1", relayed.GetConnectionId())
+	pushed := &protobufs.ServerToAgent{}
+	require.NoError(t, proto.Unmarshal(relayed.GetServerToAgent(), pushed))
+	assert.NotNil(t, pushed.GetRemoteConfig())
+
+	// the agents of a gateway that stopped watching can't be reached
+	cancel()
+	require.Eventually(t, func() bool {
+		state, err := env.OpampServer.GetConnectionState(ctx, "agent-1")
+		return err == nil && state.GetState() == v1alpha1.AgentState_AGENT_STATE_DISCONNECTED
+	}, 5*time.Second, 10*time.Millisecond)
+}
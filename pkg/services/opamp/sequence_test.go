@@ -8,8 +8,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/open-telemetry/opamp-go/protobufs"
+	eventsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
 	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/otelfleet/otelfleet/pkg/util"
 	"github.com/otelfleet/otelfleet/pkg/util/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -219,9 +223,82 @@ func TestServer_SequenceNumTracking_MultipleAgents(t *testing.T) {
 	assert.Equal(t, uint64(0), resp2.Flags, "Agent 2 should not need full state")
 }
 
+func TestServer_InstanceUIDChange(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	recorder := &recordingRecorder{}
+	env.OpampServer.SetEventRecorder(recorder)
+	ctx := context.Background()
+
+	agentID := "test-agent-reinstall"
+	require.NoError(t, env.AgentRepo.Register(ctx, agentID, agentID))
+	desc := makeSeqAgentDescription(agentID)
+
+	oldUID := util.NewInstanceUUID()
+	conn := &seqMockConnection{instanceUID: oldUID[:]}
+	for seq := range uint64(3) {
+		resp := env.OpampServer.OnMessage(ctx, conn, &protobufs.AgentToServer{
+			InstanceUid:      oldUID[:],
+			AgentDescription: desc,
+			SequenceNum:      seq,
+		})
+		require.Nil(t, resp.ErrorResponse)
+	}
+	assert.Empty(t, conn.sent, "no config is pushed while the instance is unchanged")
+
+	// the supervisor was reinstalled and regenerated its instance UID
+	newUID := util.NewInstanceUUID()
+	newConn := &seqMockConnection{instanceUID: newUID[:]}
+	resp := env.OpampServer.OnMessage(ctx, newConn, &protobufs.AgentToServer{
+		InstanceUid:      newUID[:],
+		AgentDescription: desc,
+		SequenceNum:      0,
+	})
+	expectedFlag := uint64(protobufs.ServerToAgentFlags_ServerToAgentFlags_ReportFullState)
+	assert.Equal(t, expectedFlag, resp.Flags, "a new instance should report its full state")
+	require.Len(t, newConn.sent, 1, "the agent's config should be pushed to the new instance")
+	assert.NotNil(t, newConn.sent[0].GetRemoteConfig())
+
+	state, err := env.AgentRepo.GetConnectionState(ctx, agentID)
+	require.NoError(t, err)
+	assert.Equal(t, newUID[:], state.InstanceUID)
+	assert.Equal(t, uint64(0), state.SequenceNum)
+	require.Len(t, state.InstanceHistory, 2)
+	assert.Equal(t, oldUID[:], state.InstanceHistory[0].InstanceUID)
+	assert.NotNil(t, state.InstanceHistory[0].LastSeen)
+	assert.Equal(t, newUID[:], state.InstanceHistory[1].InstanceUID)
+	assert.Nil(t, state.InstanceHistory[1].LastSeen)
+
+	// sequence tracking continues for the new instance
+	resp = env.OpampServer.OnMessage(ctx, newConn, &protobufs.AgentToServer{
+		InstanceUid: newUID[:],
+		SequenceNum: 1,
+	})
+	assert.Equal(t, uint64(0), resp.Flags)
+
+	var changed []*eventsv1alpha1.Event
+	for _, ev := range recorder.events {
+		if ev.GetType() == events.TypeAgentInstanceChanged {
+			changed = append(changed, ev)
+		}
+	}
+	require.Len(t, changed, 1)
+	assert.Equal(t, agentID, changed[0].GetAgentId())
+	assert.Contains(t, changed[0].GetMessage(), uuid.UUID(oldUID).String())
+	assert.Contains(t, changed[0].GetMessage(), uuid.UUID(newUID).String())
+}
+
+type recordingRecorder struct {
+	events []*eventsv1alpha1.Event
+}
+
+func (r *recordingRecorder) Record(_ context.Context, event *eventsv1alpha1.Event) {
+	r.events = append(r.events, event)
+}
+
 // Mock connection for sequence tests
 type seqMockConnection struct {
 	instanceUID []byte
+	sent        []*protobufs.ServerToAgent
 }
 
 func (m *seqMockConnection) Connection() net.Conn {
@@ -229,6 +306,7 @@ func (m *seqMockConnection) Connection() net.Conn {
 }
 
 func (m *seqMockConnection) Send(ctx context.Context, msg *protobufs.ServerToAgent) error {
+	m.sent = append(m.sent, msg)
 	return nil
 }
 
@@ -3,6 +3,7 @@ package opamp
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -10,23 +11,40 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/grafana/dskit/middleware"
 	"github.com/grafana/dskit/services"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/open-telemetry/opamp-go/server"
 	"github.com/open-telemetry/opamp-go/server/types"
+	"github.com/otelfleet/otelfleet/pkg/agentca"
+	adminv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1"
 	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
 	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
 	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/labels"
 	"github.com/otelfleet/otelfleet/pkg/logutil"
+	"github.com/otelfleet/otelfleet/pkg/secrets"
 	services_int "github.com/otelfleet/otelfleet/pkg/services"
+	"github.com/otelfleet/otelfleet/pkg/services/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
 	"github.com/otelfleet/otelfleet/pkg/services/otelconfig"
 	"github.com/otelfleet/otelfleet/pkg/storage"
 	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/otelfleet/otelfleet/pkg/telemetry"
 	"github.com/otelfleet/otelfleet/pkg/util"
 	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
 )
 
+// OpAMPPath is the path agents connect to
+const OpAMPPath = "/v1/opamp"
+
 type Server struct {
 	logger   *slog.Logger
 	opampSrv server.OpAMPServer
@@ -36,17 +54,95 @@ type Server struct {
 
 	// Keep remoteStatusStore for direct access during config sync checks
 
-	// Connection tracking for active connections (protocol concern)
-	mu       sync.RWMutex
-	addrToId map[string]string
-	idToConn map[string]types.Connection // agentID -> connection
+	// live connections and the agents on them
+	conns *ConnectionRegistry
 
 	// Config store for OpAMP-specific config logic
 	assignedConfigStore storage.KeyValue[*configv1alpha1.Config]
 
+	// optional resolver for secret references, applied at delivery time
+	secretResolver *secrets.Resolver
+
+	eventRecorder events.Recorder
+
+	snapshotReceiver SnapshotReceiver
+
+	// optional, re-evaluates config assignments when agents report their labels
+	assignmentEvaluator AssignmentEvaluator
+
+	// optional, tracks known-good configs and rolls back configs agents fail to apply
+	configOutcomeHandler ConfigOutcomeHandler
+
+	// optional, offers collector packages to agents
+	packageManager PackageManager
+
+	// rules the labels reported by agents are checked against
+	labelRules labels.Rules
+
+	// optional store for remote config push history, agentID -> history
+	configPushStore storage.KeyValue[*v1alpha1.ConfigPushHistory]
+	pushMu          sync.Mutex
+
+	// optional store for availability history, agentID -> history
+	availabilityStore storage.KeyValue[*v1alpha1.AvailabilityHistory]
+	heartbeatTimeout  time.Duration
+	availabilityMu    sync.Mutex
+
+	// optional, persists agent reports off the read path
+	persistPool *persistPool
+	// agents that must send a full state report, because some of their reports were not persisted
+	resyncMu sync.Mutex
+	resync   map[string]struct{}
+
+	// regional gateways relaying agent connections, by gateway ID
+	gatewaysMu sync.Mutex
+	gateways   map[string]*gateway
+	// optional, agent ID -> ID of the gateway the agent last identified itself through
+	gatewayAgents storage.KV
+
+	// optional store for the last command sent to each agent, agentID -> command
+	commandStore storage.KeyValue[*v1alpha1.AgentCommandStatus]
+	commandMu    sync.Mutex
+
+	// optional store for the open problems reported by agents, see agent.ProblemKey
+	problemStore storage.KeyValue[*v1alpha1.AgentProblem]
+	problemMu    sync.Mutex
+
+	// optional, own metrics connection settings offered to the agents reporting their own
+	// metrics
+	ownMetrics *protobufs.TelemetryConnectionSettings
+	// optional, receives the own metrics of agents, agentID -> latest metrics. Agents are
+	// offered tokens signed with ownMetricsKey.
+	ownMetricsStore storage.KeyValue[*v1alpha1.AgentMetrics]
+	ownMetricsKey   []byte
+
+	// reject agents without a client certificate issued by the agent CA
+	requireClientCert bool
+
+	// agent ID -> origin of the last session the agent was identified on
+	originsMu sync.Mutex
+	origins   map[string]string
+
 	services.Service
 }
 
+// SnapshotReceiver handles snapshots uploaded by agents
+type SnapshotReceiver interface {
+	ReceiveSnapshot(ctx context.Context, agentID string, upload *v1alpha1.SnapshotUpload)
+}
+
+// AssignmentEvaluator re-evaluates the config assignment of an agent from its labels
+type AssignmentEvaluator interface {
+	EvaluateAgentPolicies(ctx context.Context, agentID string) error
+}
+
+// ConfigOutcomeHandler is notified when agents report applying or failing to apply a config
+type ConfigOutcomeHandler interface {
+	ConfigApplied(ctx context.Context, agentID string, hash []byte) error
+	// ConfigFailed reports whether the config assigned to the agent was changed in response
+	ConfigFailed(ctx context.Context, agentID string, hash []byte, errorMessage string) (bool, error)
+}
+
 var _ services_int.OpAmpServerHandler = (*Server)(nil)
 
 func NewServer(
@@ -59,58 +155,171 @@ func NewServer(
 		logger:              l,
 		opampSrv:            opampSvr,
 		agentRepo:           agentRepo,
-		addrToId:            map[string]string{},
-		idToConn:            map[string]types.Connection{},
 		assignedConfigStore: assignedConfigStore,
+		resync:              map[string]struct{}{},
+		gateways:            map[string]*gateway{},
+		origins:             map[string]string{},
+		heartbeatTimeout:    agentdomain.DefaultHeartbeatTimeout,
 	}
+	s.conns = NewConnectionRegistry(ConnectionHooks{
+		OnUnbind: s.agentDisconnected,
+	})
 
-	s.Service = services.NewBasicService(s.start, s.running, s.stop)
+	s.Service = services.NewBasicService(nil, s.running, nil)
 	return s
 }
 
+// SetSecretResolver enables substitution of secret references in configs sent to agents
+func (s *Server) SetSecretResolver(resolver *secrets.Resolver) {
+	s.secretResolver = resolver
+}
+
+// SetRequireClientCert rejects the connections of agents that present no client certificate
+// issued by the agent CA, see agentca. Agents presenting one may only ever speak for the agent
+// it was issued to.
+func (s *Server) SetRequireClientCert(require bool) {
+	s.requireClientCert = require
+}
+
+// SetEventRecorder sets the recorder for agent connection events
+func (s *Server) SetEventRecorder(recorder events.Recorder) {
+	s.eventRecorder = recorder
+}
+
+// SetSnapshotReceiver sets the handler for snapshots uploaded by agents
+func (s *Server) SetSnapshotReceiver(receiver SnapshotReceiver) {
+	s.snapshotReceiver = receiver
+}
+
+// SetAssignmentEvaluator sets the evaluator called when agents report their description
+func (s *Server) SetAssignmentEvaluator(evaluator AssignmentEvaluator) {
+	s.assignmentEvaluator = evaluator
+}
+
+// SetConfigOutcomeHandler sets the handler called when agents report a remote config status
+func (s *Server) SetConfigOutcomeHandler(handler ConfigOutcomeHandler) {
+	s.configOutcomeHandler = handler
+}
+
+// SetLoadShedding persists agent reports on cfg.Workers bounded workers instead of on the
+// read path, shedding low priority reports when the workers fall behind. Queue metrics
+// are registered with reg, if set. It must be called before the server starts.
+func (s *Server) SetLoadShedding(cfg LoadSheddingConfig, reg prometheus.Registerer) {
+	if cfg.Workers <= 0 {
+		s.persistPool = nil
+		return
+	}
+	s.persistPool = newPersistPool(s.logger, cfg, reg, s.requestResync)
+}
+
 func (s *Server) running(ctx context.Context) error {
-	<-ctx.Done()
+	if s.persistPool != nil {
+		wait := s.persistPool.start(ctx)
+		defer wait()
+	}
+	t := time.NewTicker(s.reapInterval())
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			if err := s.ReapStaleAgents(ctx, time.Now()); err != nil && ctx.Err() == nil {
+				s.logger.With("err", err).Error("failed to reap stale agents")
+			}
+		}
+	}
+}
+
+// persist runs fn, on the persistence workers if load shedding is enabled. Errors of queued
+// work are not returned, the agent is asked for a full state report instead.
+func (s *Server) persist(ctx context.Context, agentID, kind string, priority Priority, fn func(context.Context) error) error {
+	if s.persistPool == nil {
+		return fn(ctx)
+	}
+	s.persistPool.submit(ctx, agentID, kind, priority, fn)
 	return nil
 }
 
-func (s *Server) start(ctx context.Context) error {
-	addr := "127.0.0.1:4320"
-	s.logger.With("addr", addr).Info("starting opamp server")
-	settings := server.StartSettings{
-		ListenEndpoint: addr,
-		HTTPMiddleware: otelhttp.NewMiddleware("v1/opamp"),
-		Settings: server.Settings{
-			Callbacks: types.Callbacks{
-				OnConnecting: func(request *http.Request) types.ConnectionResponse {
-					return types.ConnectionResponse{
-						Accept: true,
-						ConnectionCallbacks: types.ConnectionCallbacks{
-							OnConnected:        s.OnConnected,
-							OnMessage:          s.OnMessage,
-							OnConnectionClose:  s.OnConnectionClose,
-							OnReadMessageError: s.OnReadMessageError,
-						},
-					}
-				},
-			},
+// requestResync asks the agent for a full state report on its next message
+func (s *Server) requestResync(agentID string) {
+	s.resyncMu.Lock()
+	defer s.resyncMu.Unlock()
+	s.resync[agentID] = struct{}{}
+}
+
+// takeResync returns whether the agent must send a full state report, and clears the request
+func (s *Server) takeResync(agentID string) bool {
+	s.resyncMu.Lock()
+	defer s.resyncMu.Unlock()
+	_, ok := s.resync[agentID]
+	delete(s.resync, agentID)
+	return ok
+}
+
+// ConfigureHTTP serves OpAMP at OpAMPPath on the router of httpServer, wrapped in the
+// given middleware. It must be called before httpServer starts serving.
+func (s *Server) ConfigureHTTP(router *mux.Router, httpServer *http.Server, middlewares ...middleware.Interface) error {
+	handler, connContext, err := s.opampSrv.Attach(server.Settings{
+		Callbacks: types.Callbacks{
+			OnConnecting: s.onConnecting,
 		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to attach opamp server: %w", err)
 	}
-	if err := s.opampSrv.Start(settings); err != nil {
-		s.logger.With("err", err.Error()).Error("failed to start opamp server")
-		return fmt.Errorf("failed to start opamp server: %w", err)
-	}
+	// agents using the plain HTTP transport are tracked by their underlying connection
+	httpServer.ConnContext = connContext
 
+	middlewares = append(middlewares, middleware.Func(otelhttp.NewMiddleware("v1/opamp")))
+	router.Handle(OpAMPPath, middleware.Merge(middlewares...).Wrap(http.HandlerFunc(handler)))
+	s.logger.With("path", OpAMPPath).Info("serving opamp")
 	return nil
 }
 
-func (s *Server) stop(failureCase error) error {
-	ctxca, ca := context.WithTimeout(context.TODO(), time.Second)
-	defer ca()
-	return s.opampSrv.Stop(ctxca)
+// onConnecting accepts the connection request of an agent, bound to the agent its client
+// certificate was issued to if it presented one
+func (s *Server) onConnecting(request *http.Request) types.ConnectionResponse {
+	certAgentID := agentca.VerifiedAgentID(request.TLS)
+	if certAgentID == "" && s.requireClientCert {
+		s.logger.With("remote_addr", request.RemoteAddr).Warn("rejecting agent connection without a client certificate")
+		return types.ConnectionResponse{HTTPStatusCode: http.StatusUnauthorized}
+	}
+	session := newSession(request)
+	onMessage := s.OnMessage
+	if certAgentID != "" {
+		onMessage = func(ctx context.Context, conn types.Connection, message *protobufs.AgentToServer) *protobufs.ServerToAgent {
+			if agentID := extractAgentID(message.AgentDescription); agentID != "" && agentID != certAgentID {
+				s.logger.With("agent_id", agentID, "certificate_agent_id", certAgentID).Warn("rejecting message for another agent than its client certificate")
+				return ErrorResponse(message.InstanceUid, NewBadRequestError("client certificate was issued to another agent"))
+			}
+			return s.OnMessage(ctx, conn, message)
+		}
+	}
+	return types.ConnectionResponse{
+		Accept: true,
+		ConnectionCallbacks: types.ConnectionCallbacks{
+			OnConnected: func(ctx context.Context, conn types.Connection) {
+				s.connected(conn, session)
+				if certAgentID != "" {
+					s.conns.Bind(conn, certAgentID)
+				}
+			},
+			OnMessage:          onMessage,
+			OnConnectionClose:  s.OnConnectionClose,
+			OnReadMessageError: s.OnReadMessageError,
+		},
+	}
 }
 
 func (s *Server) OnConnected(ctx context.Context, conn types.Connection) {
+	s.connected(conn, nil)
+}
+
+// connected starts tracking a connection and the session opened on it
+func (s *Server) connected(conn types.Connection, session *adminv1alpha1.OpAMPSession) {
 	s.logger.With("addr", conn.Connection().LocalAddr().String()).Info("agent connected")
+	s.conns.Register(conn, session)
 }
 
 func (s *Server) calculateHash(agentToConfigMap *protobufs.AgentConfigMap) []byte {
@@ -144,14 +353,43 @@ func (s *Server) sendConfig(ctx context.Context, conn types.Connection, agentID
 	if err != nil {
 		return fmt.Errorf("failed to construct config : %w", err)
 	}
+	if err := util.ValidateAgentConfigMap(configMap); err != nil {
+		return fmt.Errorf("refusing to send config : %w", err)
+	}
+	// the hash is computed over the unresolved config, so that secret values
+	// never influence (or leak through) the hash reported back by agents
 	hash := s.calculateHash(configMap)
+	if s.secretResolver != nil {
+		configMap, err = s.secretResolver.SubstituteConfigMap(ctx, agentID, configMap)
+		if err != nil {
+			return fmt.Errorf("failed to resolve secrets : %w", err)
+		}
+	}
 
-	return conn.Send(ctx, &protobufs.ServerToAgent{
+	msg := &protobufs.ServerToAgent{
 		RemoteConfig: &protobufs.AgentRemoteConfig{
 			Config:     configMap,
 			ConfigHash: hash,
 		},
-	})
+	}
+	// failing to track the push must not hold back the config
+	pushID, err := s.recordConfigPushOffer(ctx, agentID, hash)
+	if err != nil {
+		s.logger.With("agent_id", agentID, "err", err).Error("failed to record config push")
+	} else if pushID != "" {
+		if msg.CustomMessage, err = configPushOffer(pushID); err != nil {
+			return fmt.Errorf("failed to encode config push offer : %w", err)
+		}
+	}
+	if err := conn.Send(ctx, msg); err != nil {
+		if pushID != "" {
+			if err := s.recordConfigPushSendFailure(ctx, agentID, pushID, err); err != nil {
+				s.logger.With("agent_id", agentID, "err", err).Error("failed to record config push")
+			}
+		}
+		return err
+	}
+	return nil
 }
 
 func (s *Server) OnReadMessageError(conn types.Connection, mt int, msgByte []byte, err error) {
@@ -162,13 +400,29 @@ func (s *Server) OnReadMessageError(conn types.Connection, mt int, msgByte []byt
 		Error("failed to read / deserialize agent message")
 }
 
+// OnMessage handles a message of an agent, traced by an opamp.message span failing with the
+// error response of the server, if any
 func (s *Server) OnMessage(ctx context.Context, conn types.Connection, message *protobufs.AgentToServer) *protobufs.ServerToAgent {
+	ctx, span := telemetry.Start(ctx, "opamp.message", trace.WithAttributes(
+		attribute.String("opamp.instance_uid", formatInstanceUID(message.InstanceUid)),
+		attribute.Int64("opamp.sequence_num", int64(message.SequenceNum)),
+	))
+	resp := s.handleMessage(ctx, conn, message)
+	var err error
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		err = errors.New(errResp.GetErrorMessage())
+	}
+	telemetry.End(span, err)
+	return resp
+}
+
+func (s *Server) handleMessage(ctx context.Context, conn types.Connection, message *protobufs.AgentToServer) *protobufs.ServerToAgent {
 	instanceUID := string(message.InstanceUid)
-	agentAddr := conn.Connection().RemoteAddr().String()
 
-	// Resolve the persistent agentID: extract from description or use cached mapping
+	// Resolve the persistent agentID: extract from description or use the connection's agent
 	// FIXME: AgentDescription may not always be set
-	agentID := s.resolveAgentID(ctx, agentAddr, conn, message.AgentDescription)
+	agentID := s.resolveAgentID(conn, message.AgentDescription)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("agent.id", agentID))
 	logger := s.logger.With("agent-id", agentID, "instance-uid", instanceUID)
 	logger.With("sequenceNum", message.SequenceNum).Debug("received message from agent")
 
@@ -182,60 +436,111 @@ func (s *Server) OnMessage(ctx context.Context, conn types.Connection, message *
 		return resp
 	}
 
-	// Verify agent is registered before processing any messages
-	registered, err := s.agentRepo.Exists(ctx, agentID)
-	if err != nil {
+	// Verify agent is registered and not revoked before processing any messages
+	revoked, err := s.agentRepo.IsRevoked(ctx, agentID)
+	if errors.Is(err, agentdomain.ErrAgentNotFound) {
+		logger.Warn("rejecting message from unregistered agent")
+		return ErrorResponse(message.InstanceUid, NewBadRequestError("agent not registered"))
+	} else if err != nil {
 		logger.With("err", err).Error("failed to check agent registration")
 		return ErrorResponse(message.InstanceUid, NewUnavailableError("failed to verify agent registration"))
 	}
-	if !registered {
-		logger.Warn("rejecting message from unregistered agent")
-		return ErrorResponse(message.InstanceUid, NewBadRequestError("agent not registered"))
+	if revoked {
+		logger.Warn("rejecting message from revoked agent")
+		return s.rejectRevoked(ctx, conn, message.InstanceUid)
 	}
+	s.observeSession(ctx, conn, agentID, message.Capabilities)
 
 	// Update connection state and check for sequence gaps
-	needsFullState := s.updateConnectionState(ctx, agentID, message)
-	if message.RemoteConfigStatus != nil {
-		if err := s.handleRemoteConfigStatus(ctx, conn, agentID, message.RemoteConfigStatus); err != nil {
+	needsFullState, instanceChanged := s.updateConnectionState(ctx, agentID, message)
+	if status := message.RemoteConfigStatus; status != nil {
+		if err := s.persist(ctx, agentID, "remote_config_status", PriorityHigh, func(ctx context.Context) error {
+			return s.handleRemoteConfigStatus(ctx, conn, agentID, status)
+		}); err != nil {
 			logger.With("err", err).Error("failed to handle remote config status message")
 		}
+	} else if instanceChanged {
+		// a reinstalled agent may have lost its config, rebind its assignment to the new instance
+		if err := s.sendConfig(ctx, conn, agentID); err != nil {
+			logger.With("err", err).Error("failed to send config to new agent instance")
+		}
 	}
 
-	if message.AgentDescription != nil {
+	if desc := message.AgentDescription; desc != nil {
+		desc = s.dropInvalidLabels(logger, desc)
 		logger.Info("persisting agent description")
-		if err := s.agentRepo.UpdateAttributes(ctx, agentID, message.AgentDescription); err != nil {
+		if err := s.persist(ctx, agentID, "agent_description", PriorityHigh, func(ctx context.Context) error {
+			if err := s.agentRepo.UpdateAttributes(ctx, agentID, desc); err != nil {
+				return err
+			}
+			if s.assignmentEvaluator != nil {
+				if err := s.assignmentEvaluator.EvaluateAgentPolicies(ctx, agentID); err != nil {
+					logutil.FromContext(ctx).With("err", err).Error("failed to evaluate assignment policies")
+				}
+			}
+			return nil
+		}); err != nil {
 			logger.With("err", err).Error("failed to persist opamp agent-description")
 			return ErrorResponse(message.InstanceUid, NewUnavailableError("failed to persist agent description"))
 		}
 	}
-	if message.Health != nil {
+	if health := message.Health; health != nil {
 		logger.Info("persisting agent health")
-		if err := s.agentRepo.UpdateHealth(ctx, agentID, message.Health); err != nil {
+		if err := s.persist(ctx, agentID, "health", PriorityLow, func(ctx context.Context) error {
+			previous := s.previousStatus(ctx, agentID)
+			if err := s.agentRepo.UpdateHealth(ctx, agentID, health); err != nil {
+				return err
+			}
+			s.recordHealthChange(ctx, agentID, previous, health)
+			return nil
+		}); err != nil {
 			logger.With("err", err).Error("failed to persist health")
 			return ErrorResponse(message.InstanceUid, NewUnavailableError("failed to persist agent health"))
 		}
 	}
+	if err := s.recordHeartbeat(ctx, agentID, time.Now(), message.Health); err != nil {
+		logger.With("err", err).Error("failed to record agent availability")
+	}
+
+	if message.CustomMessage != nil {
+		s.handleCustomMessage(ctx, agentID, message.CustomMessage)
+	}
+
+	if statuses := message.PackageStatuses; statuses != nil && s.packageManager != nil {
+		resp.PackagesAvailable = s.handlePackageStatuses(ctx, agentID, message.Capabilities, statuses)
+	}
 
-	if message.EffectiveConfig != nil {
+	if effectiveConfig := message.EffectiveConfig; effectiveConfig != nil {
 		logger.Info("persisting effective config")
-		if err := s.agentRepo.UpdateEffectiveConfig(ctx, agentID, message.EffectiveConfig); err != nil {
+		if err := s.persist(ctx, agentID, "effective_config", PriorityLow, func(ctx context.Context) error {
+			return s.agentRepo.UpdateEffectiveConfig(ctx, agentID, effectiveConfig)
+		}); err != nil {
 			logger.With("err", err).Error("failed to persist effective config")
 			return ErrorResponse(message.InstanceUid, NewUnavailableError("failed to persist effective config"))
 		}
 	}
+	// agents report their description when they connect, and may have lost their settings
+	if message.AgentDescription != nil {
+		resp.ConnectionSettings = s.ownMetricsSettings(agentID, message.Capabilities)
+	}
+	// a full state report also resends the reports that were not persisted
+	resync := s.takeResync(agentID)
 	if needsFullState {
 		resp.Flags = uint64(protobufs.ServerToAgentFlags_ServerToAgentFlags_ReportFullState)
 		logger.Info("requesting full state report due to sequence gap")
+	} else if resync {
+		resp.Flags = uint64(protobufs.ServerToAgentFlags_ServerToAgentFlags_ReportFullState)
+		logger.Info("requesting full state report, previous reports were not persisted")
 	}
 	return resp
 }
 
 // updateConnectionState updates the persisted connection state for an agent.
-// Returns true if a full state report is needed (sequence gap or instance change detected).
-func (s *Server) updateConnectionState(ctx context.Context, agentID string, msg *protobufs.AgentToServer) bool {
+// Returns whether a full state report is needed (sequence gap or instance change detected),
+// and whether the agent connected with a new instance UID.
+func (s *Server) updateConnectionState(ctx context.Context, agentID string, msg *protobufs.AgentToServer) (needsFullState, instanceChanged bool) {
 	// Try to get existing state from repository
 	existingState, err := s.agentRepo.GetConnectionState(ctx, agentID)
-	needsFullState := false
 
 	now := time.Now()
 
@@ -248,26 +553,40 @@ func (s *Server) updateConnectionState(ctx context.Context, agentID string, msg
 			InstanceUID:  msg.InstanceUid,
 			Capabilities: agentdomain.Capabilities(msg.Capabilities),
 			SequenceNum:  msg.SequenceNum,
+			InstanceHistory: []agentdomain.InstanceRecord{
+				{InstanceUID: msg.InstanceUid, FirstSeen: &now},
+			},
 		}
 		if err := s.agentRepo.UpdateConnectionState(ctx, agentID, newState); err != nil {
 			s.logger.With("err", err, "agent_id", agentID).Error("failed to persist connection state")
 		}
+		events.RecordAgentEvent(ctx, s.eventRecorder, s.agentRepo, events.TypeAgentConnected, agentID, "agent connected")
+		s.restoreDeleted(ctx, agentID)
 		// Only request full state if the agent didn't start at sequence 0
 		// A new agent starting at 0 is a clean start and doesn't need full state
-		return msg.SequenceNum != 0
+		return msg.SequenceNum != 0, false
 	} else if err != nil {
 		// Actual storage error - log and request full state to be safe
 		s.logger.With("err", err, "agent_id", agentID).Error("failed to get connection state")
-		return true
+		return true, false
 	}
 
-	// Check if this is a new instance (agent restarted)
+	// Check if this is a new instance (agent restarted or reinstalled)
+	reconnected := false
 	if !bytes.Equal(existingState.InstanceUID, msg.InstanceUid) {
-		s.logger.With("agent_id", agentID).Info("agent instance changed, requesting full state")
-		existingState.InstanceUID = msg.InstanceUid
-		existingState.ConnectedAt = &now
-		existingState.SequenceNum = 0
+		previous := existingState.InstanceUID
+		s.logger.With(
+			"agent_id", agentID,
+			"previous_instance_uid", formatInstanceUID(previous),
+			"instance_uid", formatInstanceUID(msg.InstanceUid),
+		).Info("agent instance changed, requesting full state")
+		existingState.ChangeInstance(msg.InstanceUid, now)
+		reconnected = true
+		instanceChanged = true
 		needsFullState = true
+		events.RecordAgentEvent(ctx, s.eventRecorder, s.agentRepo, events.TypeAgentInstanceChanged, agentID, fmt.Sprintf(
+			"instance uid changed from %s to %s", formatInstanceUID(previous), formatInstanceUID(msg.InstanceUid),
+		))
 	} else if msg.SequenceNum > 0 {
 		// Check for sequence gap (status compression support)
 		expectedSeq := existingState.SequenceNum + 1
@@ -278,6 +597,7 @@ func (s *Server) updateConnectionState(ctx context.Context, agentID string, msg
 
 	// Always update LastSeen on every message
 	existingState.LastSeen = &now
+	reconnected = reconnected || existingState.State != agentdomain.StateConnected
 	existingState.State = agentdomain.StateConnected
 
 	// Update capabilities if provided
@@ -289,8 +609,21 @@ func (s *Server) updateConnectionState(ctx context.Context, agentID string, msg
 	if err := s.agentRepo.UpdateConnectionState(ctx, agentID, *existingState); err != nil {
 		s.logger.With("err", err, "agent_id", agentID).Error("failed to persist connection state")
 	}
+	if reconnected {
+		events.RecordAgentEvent(ctx, s.eventRecorder, s.agentRepo, events.TypeAgentConnected, agentID, "agent connected")
+		s.restoreDeleted(ctx, agentID)
+	}
+
+	return needsFullState, instanceChanged
+}
 
-	return needsFullState
+// formatInstanceUID formats an instance UID as a UUID, as recommended by the OpAMP spec,
+// falling back to hex for UIDs of other lengths
+func formatInstanceUID(uid []byte) string {
+	if u, err := uuid.FromBytes(uid); err == nil {
+		return u.String()
+	}
+	return hex.EncodeToString(uid)
 }
 
 func (s *Server) handleRemoteConfigStatus(
@@ -301,6 +634,12 @@ func (s *Server) handleRemoteConfigStatus(
 ) error {
 	logger := logutil.FromContext(ctx)
 
+	if err := s.recordConfigPushStatus(ctx, agentID, remoteConfigStatus); err != nil {
+		logger.With("err", err).Error("failed to record config push status")
+	}
+	// handled before comparing hashes, so that a restored config is sent right away
+	s.handleConfigOutcome(ctx, agentID, remoteConfigStatus)
+
 	// Get the assigned config and calculate its expected hash
 	assignedConfigMap, err := s.constructConfig(ctx, agentID)
 	if err != nil {
@@ -311,12 +650,14 @@ func (s *Server) handleRemoteConfigStatus(
 	// Compare agent's reported hash against the assigned config hash
 	incomingHash := remoteConfigStatus.GetLastRemoteConfigHash()
 
+	previous := s.previousStatus(ctx, agentID)
 	if bytes.Equal(expectedHash, incomingHash) {
 		logger.Info("agent remote config up-to-date")
 		// Persist the status
 		if err := s.agentRepo.UpdateRemoteConfigStatus(ctx, agentID, remoteConfigStatus); err != nil {
 			return fmt.Errorf("failed to persist remote config status: %w", err)
 		}
+		s.recordRemoteConfigStatusChange(ctx, agentID, previous, remoteConfigStatus)
 		return nil
 	}
 
@@ -330,33 +671,98 @@ func (s *Server) handleRemoteConfigStatus(
 	if err := s.agentRepo.UpdateRemoteConfigStatus(ctx, agentID, remoteConfigStatus); err != nil {
 		return fmt.Errorf("failed to persist remote config status: %w", err)
 	}
+	s.recordRemoteConfigStatusChange(ctx, agentID, previous, remoteConfigStatus)
 	return nil
 }
 
-// resolveAgentID returns the persistent agent ID, either by extracting it from the
-// agent description or by looking it up from the address mapping.
-// It also stores the connection for later use by NotifyConfigChange.
-func (s *Server) resolveAgentID(ctx context.Context, agentAddr string, conn types.Connection, desc *protobufs.AgentDescription) string {
-	// Try to extract from description first
-	if desc != nil {
-		if agentID := extractAgentID(desc); agentID != "" {
-			s.mu.Lock()
-			s.addrToId[agentAddr] = agentID
-			s.idToConn[agentID] = conn
-			s.mu.Unlock()
-			// Note: Connection state is now updated in updateConnectionState
-			return agentID
+// handleConfigOutcome notifies the config outcome handler of the status an agent reported
+func (s *Server) handleConfigOutcome(ctx context.Context, agentID string, status *protobufs.RemoteConfigStatus) {
+	if s.configOutcomeHandler == nil {
+		return
+	}
+	logger := logutil.FromContext(ctx)
+	switch status.GetStatus() {
+	case protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED:
+		if err := s.configOutcomeHandler.ConfigApplied(ctx, agentID, status.GetLastRemoteConfigHash()); err != nil {
+			logger.With("err", err).Error("failed to record applied config")
+		}
+	case protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED:
+		rolledBack, err := s.configOutcomeHandler.ConfigFailed(ctx, agentID, status.GetLastRemoteConfigHash(), status.GetErrorMessage())
+		if err != nil {
+			logger.With("err", err).Error("failed to handle failed config")
+		} else if rolledBack {
+			logger.Warn("agent config rolled back")
+		}
+	}
+}
+
+// previousStatus returns the health and remote config status of the agent before a report
+// is persisted, so that changes can be recorded as events. It returns nil if no events are
+// recorded or the status can't be read.
+func (s *Server) previousStatus(ctx context.Context, agentID string) *agentdomain.AgentRuntimeStatus {
+	if s.eventRecorder == nil {
+		return nil
+	}
+	agent, err := s.agentRepo.GetView(ctx, agentID, agentdomain.StatusViewHealth)
+	if err != nil {
+		logutil.FromContext(ctx).With("err", err).Debug("failed to get agent status")
+		return nil
+	}
+	return &agent.Status
+}
+
+// recordRemoteConfigStatusChange records that the agent applied or failed to apply a config,
+// unless it already reported so
+func (s *Server) recordRemoteConfigStatusChange(ctx context.Context, agentID string, previous *agentdomain.AgentRuntimeStatus, status *protobufs.RemoteConfigStatus) {
+	if previous == nil {
+		return
+	}
+	if prev := previous.RemoteConfigStatus; prev != nil &&
+		prev.Status == agentdomain.ConvertRemoteConfigStatus(status).Status &&
+		bytes.Equal(prev.LastRemoteConfigHash, status.GetLastRemoteConfigHash()) {
+		return
+	}
+	switch status.GetStatus() {
+	case protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED:
+		events.RecordAgentEvent(ctx, s.eventRecorder, s.agentRepo, events.TypeAgentConfigApplied, agentID,
+			fmt.Sprintf("config %x applied", status.GetLastRemoteConfigHash()))
+	case protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED:
+		events.RecordAgentEvent(ctx, s.eventRecorder, s.agentRepo, events.TypeAgentConfigFailed, agentID,
+			fmt.Sprintf("config %x failed to apply: %s", status.GetLastRemoteConfigHash(), status.GetErrorMessage()))
+	}
+}
+
+// recordHealthChange records that the agent became healthy or unhealthy
+func (s *Server) recordHealthChange(ctx context.Context, agentID string, previous *agentdomain.AgentRuntimeStatus, health *protobufs.ComponentHealth) {
+	if previous == nil || (previous.Health != nil && previous.Health.Healthy == health.GetHealthy()) {
+		return
+	}
+	message := "agent is healthy"
+	if !health.GetHealthy() {
+		message = "agent is unhealthy"
+		if health.GetLastError() != "" {
+			message += ": " + health.GetLastError()
 		}
 	}
-	// Fall back to cached mapping
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	return s.addrToId[agentAddr]
+	events.RecordAgentEvent(ctx, s.eventRecorder, s.agentRepo, events.TypeAgentHealthChanged, agentID, message)
+}
+
+// resolveAgentID returns the persistent agent ID, either by extracting it from the
+// agent description or from the agent previously identified on the connection.
+// It also binds the agent to the connection for later use by NotifyConfigChange.
+func (s *Server) resolveAgentID(conn types.Connection, desc *protobufs.AgentDescription) string {
+	if agentID := extractAgentID(desc); agentID != "" {
+		s.conns.Bind(conn, agentID)
+		// Note: Connection state is now updated in updateConnectionState
+		return agentID
+	}
+	agentID, _ := s.conns.AgentID(conn)
+	return agentID
 }
 
 // extractAgentID extracts the persistent otelfleet agent ID from the agent description.
 func extractAgentID(desc *protobufs.AgentDescription) string {
-	for _, entry := range desc.IdentifyingAttributes {
+	for _, entry := range desc.GetIdentifyingAttributes() {
 		if entry.Key == supervisor.AttributeOtelfleetAgentId {
 			return entry.Value.GetStringValue()
 		}
@@ -369,20 +775,18 @@ func (s *Server) OnConnectionClose(conn types.Connection) {
 	logger := s.logger.With("remote_addr", remoteAddr)
 	logger.Info("agent disconnected")
 
-	s.mu.Lock()
-	agentID, ok := s.addrToId[remoteAddr]
-	if ok {
-		delete(s.addrToId, remoteAddr)
-		delete(s.idToConn, agentID)
-	}
-	s.mu.Unlock()
-
-	if !ok {
-		logger.Error("agent not tracked in addr to persistent ID map")
-		return
+	agentID, current := s.conns.Unregister(conn)
+	switch {
+	case agentID == "":
+		logger.Error("agent not tracked in connection registry")
+	case !current:
+		logger.With("agent_id", agentID).Debug("closed connection was superseded by a newer one or evicted")
 	}
+}
 
-	// Persist disconnected state
+// agentDisconnected persists the disconnected state of an agent whose connection closed
+func (s *Server) agentDisconnected(agentID string, _ types.Connection) {
+	logger := s.logger.With("agent_id", agentID)
 	ctx := context.Background()
 	existingState, err := s.agentRepo.GetConnectionState(ctx, agentID)
 	if err != nil {
@@ -395,11 +799,46 @@ func (s *Server) OnConnectionClose(conn types.Connection) {
 		}
 		return
 	}
-	now := time.Now()
-	existingState.State = agentdomain.StateDisconnected
-	existingState.DisconnectedAt = &now
-	if err := s.agentRepo.UpdateConnectionState(ctx, agentID, *existingState); err != nil {
-		logger.With("err", err).Error("failed to persist disconnected state")
+	s.markDisconnected(ctx, agentID, *existingState, time.Now(), "agent disconnected")
+}
+
+// DisconnectAgent closes the live connection of a deleted or revoked agent and marks it as
+// disconnected, so that a deleted agent is restored if it reconnects. This implements the agent.AgentDisconnector interface
+func (s *Server) DisconnectAgent(_ context.Context, agentID string) error {
+	conn, ok := s.conns.Evict(agentID)
+	if !ok {
+		return agent.ErrAgentNotConnected
+	}
+	s.logger.With("agent_id", agentID).Info("disconnecting agent")
+	err := conn.Disconnect()
+	s.agentDisconnected(agentID, conn)
+	return err
+}
+
+// rejectRevoked tells a revoked agent it was rejected and closes its connection. Plain HTTP
+// connections can't send messages or be closed, so the rejection is returned as the response
+// instead; websocket connections skip nil responses.
+func (s *Server) rejectRevoked(ctx context.Context, conn types.Connection, instanceUID []byte) *protobufs.ServerToAgent {
+	resp := ErrorResponse(instanceUID, NewBadRequestError("agent has been revoked"))
+	if err := conn.Send(ctx, resp); err != nil {
+		return resp
+	}
+	if err := conn.Disconnect(); err != nil {
+		logutil.FromContext(ctx).With("err", err).Warn("failed to close the connection of a revoked agent")
+	}
+	return nil
+}
+
+// restoreDeleted restores an agent that reconnected within its deletion grace period
+func (s *Server) restoreDeleted(ctx context.Context, agentID string) {
+	restored, err := s.agentRepo.Restore(ctx, agentID)
+	if err != nil {
+		s.logger.With("err", err, "agent_id", agentID).Error("failed to restore deleted agent")
+		return
+	}
+	if restored {
+		s.logger.With("agent_id", agentID).Info("deleted agent reconnected, restoring it")
+		events.RecordAgentEvent(ctx, s.eventRecorder, s.agentRepo, events.TypeAgentRestored, agentID, "agent reconnected within the deletion grace period and was restored")
 	}
 }
 
@@ -408,10 +847,7 @@ func (s *Server) OnConnectionClose(conn types.Connection) {
 // If the agent is not connected, this is a no-op (the agent will receive
 // the config when it reconnects).
 func (s *Server) NotifyConfigChange(agentID string) {
-	s.mu.RLock()
-	conn, ok := s.idToConn[agentID]
-	s.mu.RUnlock()
-
+	conn, ok := s.conns.Lookup(agentID)
 	if !ok {
 		s.logger.With("agent_id", agentID).Debug("agent not connected, config will be sent on reconnect")
 		return
@@ -429,6 +865,82 @@ func (s *Server) NotifyConfigChange(agentID string) {
 // Ensure Server implements ConfigChangeNotifier
 var _ otelconfig.ConfigChangeNotifier = (*Server)(nil)
 
+// RequestSnapshot asks a connected agent's supervisor to capture and upload a snapshot.
+// This implements the agent.SnapshotRequester interface.
+func (s *Server) RequestSnapshot(ctx context.Context, agentID string, req *v1alpha1.SnapshotRequest) error {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	return s.conns.Send(ctx, agentID, &protobufs.ServerToAgent{
+		CustomMessage: &protobufs.CustomMessage{
+			Capability: supervisor.SnapshotCapability,
+			Type:       supervisor.SnapshotMessageRequest,
+			Data:       data,
+		},
+	})
+}
+
+var _ agent.SnapshotRequester = (*Server)(nil)
+
+func (s *Server) handleCustomMessage(ctx context.Context, agentID string, msg *protobufs.CustomMessage) {
+	logger := logutil.FromContext(ctx)
+	if msg.GetCapability() == supervisor.ConfigPushCapability && msg.GetType() == supervisor.ConfigPushMessageReceipt {
+		receipt := &v1alpha1.ConfigPushReceipt{}
+		if err := proto.Unmarshal(msg.GetData(), receipt); err != nil {
+			logger.With("err", err).Error("failed to decode config push receipt")
+			return
+		}
+		if err := s.recordConfigPushReceipt(ctx, agentID, receipt); err != nil {
+			logger.With("err", err, "push_id", receipt.GetPushId()).Error("failed to record config push receipt")
+		}
+		return
+	}
+	if msg.GetCapability() == supervisor.CommandCapability && msg.GetType() == supervisor.CommandMessageResult {
+		result := &v1alpha1.AgentCommandResult{}
+		if err := proto.Unmarshal(msg.GetData(), result); err != nil {
+			logger.With("err", err).Error("failed to decode command result")
+			return
+		}
+		if err := s.recordCommandResult(ctx, agentID, result); err != nil {
+			logger.With("err", err, "command", result.GetType()).Error("failed to record command result")
+		}
+		events.RecordAgentEvent(ctx, s.eventRecorder, s.agentRepo, events.TypeAgentCommandCompleted, agentID, describeCommandResult(result))
+		return
+	}
+	if msg.GetCapability() == supervisor.ProblemCapability && msg.GetType() == supervisor.ProblemMessageReport {
+		report := &v1alpha1.AgentProblemReport{}
+		if err := proto.Unmarshal(msg.GetData(), report); err != nil {
+			logger.With("err", err).Error("failed to decode problem report")
+			return
+		}
+		if report.GetType() == v1alpha1.AgentProblemType_AGENT_PROBLEM_TYPE_UNSPECIFIED {
+			logger.Warn("ignoring problem report without a type")
+			return
+		}
+		logger.With("type", report.GetType().String(), "message", report.GetMessage()).Warn("agent reported a problem")
+		if err := s.recordProblem(ctx, agentID, report); err != nil {
+			logger.With("err", err, "type", report.GetType().String()).Error("failed to record problem report")
+		}
+		events.RecordAgentEvent(ctx, s.eventRecorder, s.agentRepo, events.TypeAgentProblemReported, agentID, describeProblem(report))
+		return
+	}
+	if msg.GetCapability() != supervisor.SnapshotCapability || msg.GetType() != supervisor.SnapshotMessageUpload {
+		logger.With("capability", msg.GetCapability(), "type", msg.GetType()).Warn("ignoring unsupported custom message")
+		return
+	}
+	if s.snapshotReceiver == nil {
+		logger.Warn("dropping snapshot upload, no snapshot receiver configured")
+		return
+	}
+	upload := &v1alpha1.SnapshotUpload{}
+	if err := proto.Unmarshal(msg.GetData(), upload); err != nil {
+		logger.With("err", err).Error("failed to decode snapshot upload")
+		return
+	}
+	s.snapshotReceiver.ReceiveSnapshot(ctx, agentID, upload)
+}
+
 // GetConnectionState is needed for tests or external access to connection state.
 func (s *Server) GetConnectionState(ctx context.Context, agentID string) (*v1alpha1.AgentConnectionState, error) {
 	state, err := s.agentRepo.GetConnectionState(ctx, agentID)
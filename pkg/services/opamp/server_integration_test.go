@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/labels"
 	"github.com/otelfleet/otelfleet/pkg/supervisor"
 	"github.com/otelfleet/otelfleet/pkg/util/testutil"
 	"github.com/stretchr/testify/assert"
@@ -69,6 +70,35 @@ func TestServer_OnMessage_PersistsHealth(t *testing.T) {
 	assert.Empty(t, cmp.Diff(health, stored, protocmp.Transform()))
 }
 
+func TestServer_OnMessage_DropsInvalidLabels(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	agentID := "test-agent-labels"
+	instanceUID := []byte(agentID)
+	require.NoError(t, env.AgentRepo.Register(ctx, agentID, agentID))
+	env.OpampServer.SetLabelRules(labels.Rules{MaxValueLength: 8})
+
+	desc := makeAgentDescription(agentID)
+	for key, value := range map[string]string{"env": "prod", "owner": "observability-team"} {
+		desc.NonIdentifyingAttributes = append(desc.NonIdentifyingAttributes, &protobufs.KeyValue{
+			Key:   key,
+			Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: value}},
+		})
+	}
+	resp := env.OpampServer.OnMessage(ctx, &testMockConnection{instanceUID: instanceUID}, &protobufs.AgentToServer{
+		InstanceUid:      instanceUID,
+		AgentDescription: desc,
+	})
+	require.NotNil(t, resp)
+
+	stored, err := env.OpampAgentDescriptionStore.Get(ctx, agentID)
+	require.NoError(t, err)
+	require.Len(t, stored.GetNonIdentifyingAttributes(), 1)
+	assert.Equal(t, "env", stored.GetNonIdentifyingAttributes()[0].GetKey())
+	assert.Len(t, stored.GetIdentifyingAttributes(), 1)
+}
+
 func TestServer_OnMessage_PersistsEffectiveConfig(t *testing.T) {
 	env := testutil.NewTestEnv(t)
 
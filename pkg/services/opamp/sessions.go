@@ -0,0 +1,133 @@
+package opamp
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/open-telemetry/opamp-go/server/types"
+	adminv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+)
+
+// Session transports
+const (
+	TransportWebSocket = "websocket"
+	TransportHTTP      = "http"
+	TransportGateway   = "gateway"
+)
+
+// newSession describes the session opened by an OpAMP connection request
+func newSession(req *http.Request) *adminv1alpha1.OpAMPSession {
+	session := &adminv1alpha1.OpAMPSession{
+		Transport:    TransportWebSocket,
+		RemoteAddr:   req.RemoteAddr,
+		ForwardedFor: req.Header.Get("X-Forwarded-For"),
+		UserAgent:    req.UserAgent(),
+		Tls:          sessionTLS(req.TLS),
+	}
+	// the opamp server treats protobuf requests as plain HTTP, others are upgraded
+	if req.Header.Get("Content-Type") == "application/x-protobuf" {
+		session.Transport = TransportHTTP
+	}
+	return session
+}
+
+func sessionTLS(state *tls.ConnectionState) *adminv1alpha1.SessionTLS {
+	if state == nil {
+		return nil
+	}
+	info := &adminv1alpha1.SessionTLS{
+		Version:            tls.VersionName(state.Version),
+		CipherSuite:        tls.CipherSuiteName(state.CipherSuite),
+		ServerName:         state.ServerName,
+		NegotiatedProtocol: state.NegotiatedProtocol,
+	}
+	if len(state.PeerCertificates) > 0 {
+		leaf := state.PeerCertificates[0]
+		info.ClientSubject = leaf.Subject.String()
+		for _, uri := range leaf.URIs {
+			info.ClientUris = append(info.ClientUris, uri.String())
+		}
+	}
+	return info
+}
+
+// Sessions returns the live OpAMP sessions, sorted by start time.
+// This implements the admin.SessionLister interface
+func (s *Server) Sessions() []*adminv1alpha1.OpAMPSession {
+	return s.conns.Sessions()
+}
+
+// observeSession records the capabilities the agent declared on its session, and records
+// an event when the agent opened the session from an origin it wasn't last seen from
+func (s *Server) observeSession(ctx context.Context, conn types.Connection, agentID string, capabilities uint64) {
+	var origin, description string
+	if !s.conns.UpdateSession(conn, func(session *adminv1alpha1.OpAMPSession) {
+		if capabilities != 0 {
+			names := agentdomain.Capabilities(capabilities).ToStringSlice()
+			slices.Sort(names)
+			session.Capabilities = names
+		}
+		origin = sessionOrigin(session)
+		description = describeSession(session)
+	}) {
+		return
+	}
+
+	s.originsMu.Lock()
+	known := s.origins[agentID] == origin
+	s.origins[agentID] = origin
+	s.originsMu.Unlock()
+	if known {
+		return
+	}
+	s.logger.With("agent_id", agentID, "session", description).Info("agent opened a session from a new origin")
+	events.RecordAgentEvent(ctx, s.eventRecorder, s.agentRepo, events.TypeAgentSessionOrigin, agentID, description)
+}
+
+// sessionOrigin identifies where a session was opened from, the source port changes
+// with every connection so it is left out
+func sessionOrigin(session *adminv1alpha1.OpAMPSession) string {
+	host := session.GetRemoteAddr()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.Join([]string{
+		session.GetTransport(),
+		host,
+		session.GetForwardedFor(),
+		session.GetGatewayId(),
+		session.GetUserAgent(),
+		session.GetTls().GetClientSubject(),
+	}, "\x00")
+}
+
+func describeSession(session *adminv1alpha1.OpAMPSession) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "agent opened a %s session", session.GetTransport())
+	if session.GetGatewayId() != "" {
+		fmt.Fprintf(&b, " through gateway %s", session.GetGatewayId())
+	}
+	if session.GetRemoteAddr() != "" {
+		fmt.Fprintf(&b, " from %s", session.GetRemoteAddr())
+	}
+	if session.GetForwardedFor() != "" {
+		fmt.Fprintf(&b, " forwarded for %s", session.GetForwardedFor())
+	}
+	if tlsInfo := session.GetTls(); tlsInfo != nil {
+		fmt.Fprintf(&b, " over %s", tlsInfo.GetVersion())
+		if tlsInfo.GetClientSubject() != "" {
+			fmt.Fprintf(&b, " with client certificate %q", tlsInfo.GetClientSubject())
+		}
+	}
+	if session.GetUserAgent() != "" {
+		fmt.Fprintf(&b, ", user agent %q", session.GetUserAgent())
+	}
+	return b.String()
+}
@@ -0,0 +1,84 @@
+package opamp_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	adminv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/admin/v1alpha1"
+	eventsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/events/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/services/opamp"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type syncRecorder struct {
+	mu     sync.Mutex
+	events []*eventsv1alpha1.Event
+}
+
+func (r *syncRecorder) Record(_ context.Context, event *eventsv1alpha1.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *syncRecorder) ofType(eventType string) []*eventsv1alpha1.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ret []*eventsv1alpha1.Event
+	for _, ev := range r.events {
+		if ev.GetType() == eventType {
+			ret = append(ret, ev)
+		}
+	}
+	return ret
+}
+
+func TestServer_Sessions(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	recorder := &syncRecorder{}
+	env.OpampServer.SetEventRecorder(recorder)
+
+	agent := env.NewAgent("agent-1")
+	require.NoError(t, agent.Start())
+	agent.WaitForConfig(t, 5*time.Second)
+
+	var session *adminv1alpha1.OpAMPSession
+	require.Eventually(t, func() bool {
+		sessions := env.OpampServer.Sessions()
+		if len(sessions) != 1 || len(sessions[0].GetCapabilities()) == 0 {
+			return false
+		}
+		session = sessions[0]
+		return true
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.NotEmpty(t, session.GetId())
+	assert.Equal(t, agent.ID, session.GetAgentId())
+	assert.Equal(t, opamp.TransportWebSocket, session.GetTransport())
+	assert.Contains(t, session.GetRemoteAddr(), "127.0.0.1")
+	assert.Nil(t, session.GetTls(), "the test server doesn't serve TLS")
+	assert.Contains(t, session.GetCapabilities(), "AcceptsRemoteConfig")
+	assert.NotNil(t, session.GetStartedAt())
+
+	// the first session of an agent is from a new origin
+	originEvents := recorder.ofType(events.TypeAgentSessionOrigin)
+	require.Len(t, originEvents, 1)
+	assert.Equal(t, agent.ID, originEvents[0].GetAgentId())
+	assert.Contains(t, originEvents[0].GetMessage(), "agent opened a websocket session from 127.0.0.1")
+
+	// reconnecting from the same origin isn't reported again
+	require.NoError(t, agent.Stop())
+	require.Eventually(t, func() bool {
+		return len(env.OpampServer.Sessions()) == 0
+	}, 5*time.Second, 20*time.Millisecond)
+	require.NoError(t, agent.Start())
+	require.Eventually(t, func() bool {
+		sessions := env.OpampServer.Sessions()
+		return len(sessions) == 1 && sessions[0].GetId() != session.GetId() && len(sessions[0].GetCapabilities()) > 0
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.Len(t, recorder.ofType(events.TypeAgentSessionOrigin), 1)
+}
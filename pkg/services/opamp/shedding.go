@@ -0,0 +1,206 @@
+package opamp
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Priority of persistence work queued by the OpAMP server
+type Priority int
+
+const (
+	// PriorityHigh work, such as acknowledging remote config statuses, is never shed.
+	// It runs inline on the read path when its queue is full.
+	PriorityHigh Priority = iota
+	// PriorityLow work, such as persisting health and effective configs, is shed when its
+	// queue is full. The agent is asked for a full state report on its next message, so
+	// shed reports are resent once the pressure eases.
+	PriorityLow
+)
+
+func (p Priority) String() string {
+	if p == PriorityHigh {
+		return "high"
+	}
+	return "low"
+}
+
+// DefaultPersistQueueSize is the per worker queue size used when none is configured
+const DefaultPersistQueueSize = 256
+
+// LoadSheddingConfig configures the workers persisting agent reports off the OpAMP read path
+type LoadSheddingConfig struct {
+	// Workers is the number of persistence workers. Reports of an agent are always handled
+	// by the same worker, in order.
+	Workers int
+	// QueueSize is the number of tasks each worker queues per priority
+	QueueSize int
+}
+
+type persistTask struct {
+	agentID string
+	kind    string
+	ctx     context.Context
+	fn      func(context.Context) error
+}
+
+type persistWorker struct {
+	high chan persistTask
+	low  chan persistTask
+}
+
+// persistPool runs persistence work on a bounded set of workers, so that slow storage
+// backs up into bounded queues instead of every agent connection
+type persistPool struct {
+	logger  *slog.Logger
+	workers []*persistWorker
+	// called when a queued task fails or is shed
+	onLost func(agentID string)
+
+	shed     *prometheus.CounterVec
+	inline   *prometheus.CounterVec
+	failures *prometheus.CounterVec
+}
+
+func newPersistPool(logger *slog.Logger, cfg LoadSheddingConfig, reg prometheus.Registerer, onLost func(agentID string)) *persistPool {
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = DefaultPersistQueueSize
+	}
+	p := &persistPool{
+		logger: logger,
+		onLost: onLost,
+		shed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "otelfleet_opamp_persist_shed_total",
+			Help: "Agent reports dropped because the persistence queue was full.",
+		}, []string{"kind"}),
+		inline: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "otelfleet_opamp_persist_inline_total",
+			Help: "High priority agent reports persisted on the read path because the persistence queue was full.",
+		}, []string{"kind"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "otelfleet_opamp_persist_failures_total",
+			Help: "Queued agent reports that failed to persist.",
+		}, []string{"kind"}),
+	}
+	for range cfg.Workers {
+		p.workers = append(p.workers, &persistWorker{
+			high: make(chan persistTask, cfg.QueueSize),
+			low:  make(chan persistTask, cfg.QueueSize),
+		})
+	}
+	if reg != nil {
+		reg.MustRegister(p.shed, p.inline, p.failures)
+		for _, priority := range []Priority{PriorityHigh, PriorityLow} {
+			reg.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+				Name:        "otelfleet_opamp_persist_queue_depth",
+				Help:        "Agent reports waiting to be persisted.",
+				ConstLabels: prometheus.Labels{"priority": priority.String()},
+			}, func() float64 {
+				return float64(p.depth(priority))
+			}))
+		}
+	}
+	return p
+}
+
+// depth returns the number of queued tasks of the given priority
+func (p *persistPool) depth(priority Priority) int {
+	depth := 0
+	for _, w := range p.workers {
+		if priority == PriorityHigh {
+			depth += len(w.high)
+		} else {
+			depth += len(w.low)
+		}
+	}
+	return depth
+}
+
+func (p *persistPool) worker(agentID string) *persistWorker {
+	h := fnv.New32a()
+	h.Write([]byte(agentID))
+	return p.workers[h.Sum32()%uint32(len(p.workers))]
+}
+
+// submit queues fn to be run for the agent. The context is detached from ctx's cancellation,
+// since queued work outlives the message it came from.
+func (p *persistPool) submit(ctx context.Context, agentID, kind string, priority Priority, fn func(context.Context) error) {
+	task := persistTask{agentID: agentID, kind: kind, ctx: context.WithoutCancel(ctx), fn: fn}
+	w := p.worker(agentID)
+	if priority == PriorityHigh {
+		select {
+		case w.high <- task:
+		default:
+			p.inline.WithLabelValues(kind).Inc()
+			p.run(task)
+		}
+		return
+	}
+	select {
+	case w.low <- task:
+	default:
+		p.shed.WithLabelValues(kind).Inc()
+		p.logger.With("agent_id", agentID, "kind", kind).Warn("persistence queue full, dropping agent report")
+		p.onLost(agentID)
+	}
+}
+
+func (p *persistPool) run(task persistTask) {
+	if err := task.fn(task.ctx); err != nil {
+		p.failures.WithLabelValues(task.kind).Inc()
+		p.logger.With("agent_id", task.agentID, "kind", task.kind, "err", err).Error("failed to persist agent report")
+		p.onLost(task.agentID)
+	}
+}
+
+// start runs the workers until ctx is done, after which they finish the queued tasks.
+// Tasks must not be submitted once the returned function is called.
+func (p *persistPool) start(ctx context.Context) (wait func()) {
+	var wg sync.WaitGroup
+	for _, w := range p.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.work(ctx, w)
+		}()
+	}
+	return wg.Wait
+}
+
+func (p *persistPool) work(ctx context.Context, w *persistWorker) {
+	for {
+		// high priority tasks go first
+		select {
+		case task := <-w.high:
+			p.run(task)
+			continue
+		default:
+		}
+		select {
+		case task := <-w.high:
+			p.run(task)
+		case task := <-w.low:
+			p.run(task)
+		case <-ctx.Done():
+			p.drain(w)
+			return
+		}
+	}
+}
+
+func (p *persistPool) drain(w *persistWorker) {
+	for {
+		select {
+		case task := <-w.high:
+			p.run(task)
+		case task := <-w.low:
+			p.run(task)
+		default:
+			return
+		}
+	}
+}
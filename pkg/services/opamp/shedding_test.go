@@ -0,0 +1,81 @@
+package opamp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistPool(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		lost []string
+		ran  []string
+	)
+	record := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			ran = append(ran, name)
+			return nil
+		}
+	}
+	reg := prometheus.NewRegistry()
+	pool := newPersistPool(slog.Default(), LoadSheddingConfig{Workers: 1, QueueSize: 1}, reg, func(agentID string) {
+		mu.Lock()
+		defer mu.Unlock()
+		lost = append(lost, agentID)
+	})
+
+	// queued before the worker starts, so the queues fill up
+	pool.submit(t.Context(), "agent-1", "health", PriorityLow, record("health-1"))
+	pool.submit(t.Context(), "agent-1", "health", PriorityLow, record("health-2"))
+	pool.submit(t.Context(), "agent-1", "remote_config_status", PriorityHigh, record("status-1"))
+	pool.submit(t.Context(), "agent-1", "remote_config_status", PriorityHigh, record("status-2"))
+	pool.submit(t.Context(), "agent-2", "effective_config", PriorityLow, func(context.Context) error {
+		return errors.New("storage unavailable")
+	})
+
+	assert.Equal(t, 1, pool.depth(PriorityHigh))
+	assert.Equal(t, 1, pool.depth(PriorityLow))
+	assert.Equal(t, []string{"status-2"}, ran, "high priority work runs inline when its queue is full")
+	assert.Equal(t, []string{"agent-1", "agent-2"}, lost, "shed low priority work requests a resync")
+	assert.Equal(t, 1.0, promtestutil.ToFloat64(pool.shed.WithLabelValues("health")))
+	assert.Equal(t, 1.0, promtestutil.ToFloat64(pool.shed.WithLabelValues("effective_config")))
+	assert.Equal(t, 1.0, promtestutil.ToFloat64(pool.inline.WithLabelValues("remote_config_status")))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	// queued work is finished once stopped, high priority first
+	pool.start(ctx)()
+	assert.Equal(t, []string{"status-2", "status-1", "health-1"}, ran)
+	assert.Zero(t, pool.depth(PriorityHigh))
+	assert.Zero(t, pool.depth(PriorityLow))
+
+	count, err := promtestutil.GatherAndCount(reg, "otelfleet_opamp_persist_queue_depth")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestPersistPoolFailure(t *testing.T) {
+	lost := make(chan string, 1)
+	pool := newPersistPool(slog.Default(), LoadSheddingConfig{Workers: 2}, nil, func(agentID string) {
+		lost <- agentID
+	})
+	ctx, cancel := context.WithCancel(t.Context())
+	wait := pool.start(ctx)
+	pool.submit(t.Context(), "agent-1", "agent_description", PriorityHigh, func(context.Context) error {
+		return errors.New("storage unavailable")
+	})
+	assert.Equal(t, "agent-1", <-lost, "failed queued work requests a resync")
+	cancel()
+	wait()
+	assert.Equal(t, 1.0, promtestutil.ToFloat64(pool.failures.WithLabelValues("agent_description")))
+}
@@ -0,0 +1,133 @@
+package otelconfig_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	bootstrapv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAudit_Configs(t *testing.T) {
+	env := setupTestEnv(t)
+	alice := auth.NewContext(t.Context(), &auth.Principal{Subject: "alice"})
+	bob := auth.NewContext(t.Context(), &auth.Principal{Subject: "bob"})
+
+	put := func(ctx context.Context, id string) {
+		t.Helper()
+		_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&v1alpha1.PutConfigRequest{
+			Ref:    &v1alpha1.ConfigReference{Id: id},
+			Config: &v1alpha1.Config{Config: []byte("receivers: {}")},
+		}))
+		require.NoError(t, err)
+	}
+	put(alice, "logs")
+	put(alice, "traces")
+	put(bob, "logs")
+
+	stored, err := env.ConfigStore.Get(t.Context(), "logs")
+	require.NoError(t, err)
+	audit := stored.GetMetadata().GetAudit()
+	assert.Equal(t, "alice", audit.GetCreatedBy())
+	assert.Equal(t, "bob", audit.GetModifiedBy())
+	assert.False(t, audit.GetModifiedAt().AsTime().Before(audit.GetCreatedAt().AsTime()))
+
+	list := func(filter *v1alpha1.AuditFilter) []string {
+		t.Helper()
+		resp, err := env.ConfigServer.ListConfigs(t.Context(), connect.NewRequest(&v1alpha1.ListConfigsRequest{Filter: filter}))
+		require.NoError(t, err)
+		ids := []string{}
+		for _, ref := range resp.Msg.GetConfigs() {
+			ids = append(ids, ref.GetId())
+			assert.NotNil(t, resp.Msg.GetMetadata()[ref.GetId()].GetAudit())
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"logs", "traces"}, list(nil))
+	assert.Equal(t, []string{"logs"}, list(&v1alpha1.AuditFilter{ModifiedBy: "bob"}))
+	assert.Equal(t, []string{"logs", "traces"}, list(&v1alpha1.AuditFilter{CreatedBy: "alice"}))
+	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	assert.Equal(t, []string{"traces"}, list(&v1alpha1.AuditFilter{ModifiedBy: "alice", ModifiedAfter: timestamppb.New(weekAgo)}))
+	assert.Empty(t, list(&v1alpha1.AuditFilter{ModifiedBefore: timestamppb.New(weekAgo)}))
+}
+
+func TestAudit_AssignmentsAndDeployments(t *testing.T) {
+	env := setupTestEnv(t)
+	ctx := t.Context()
+	carol := auth.NewContext(ctx, &auth.Principal{Subject: "carol"})
+	dave := auth.NewContext(ctx, &auth.Principal{Subject: "dave"})
+
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&v1alpha1.PutConfigRequest{
+		Ref:    &v1alpha1.ConfigReference{Id: "logs"},
+		Config: &v1alpha1.Config{Config: []byte("receivers: {}")},
+	}))
+	require.NoError(t, err)
+	env.createTestAgent(ctx, t, "agent-1", nil)
+	env.createTestAgent(ctx, t, "agent-2", nil)
+
+	_, err = env.ConfigServer.AssignConfig(carol, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: "agent-1", ConfigId: "logs"}))
+	require.NoError(t, err)
+
+	// assignments made by a deployment are attributed to the principal that started it
+	started, err := env.ConfigServer.StartRollingDeployment(dave, connect.NewRequest(&v1alpha1.RollingDeploymentRequest{
+		ConfigId: "logs",
+		AgentIds: []string{"agent-2"},
+	}))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		resp, err := env.ConfigServer.GetDeploymentStatus(ctx, connect.NewRequest(&v1alpha1.GetDeploymentStatusRequest{
+			DeploymentId: started.Msg.GetDeploymentId(),
+		}))
+		require.NoError(t, err)
+		return resp.Msg.GetStatus().GetState() == v1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assignments, err := env.ConfigServer.ListConfigAssignments(ctx, connect.NewRequest(&v1alpha1.ListConfigAssignmentsRequest{
+		AuditFilter: &v1alpha1.AuditFilter{CreatedBy: "dave"},
+	}))
+	require.NoError(t, err)
+	require.Len(t, assignments.Msg.GetAssignments(), 1)
+	assert.Equal(t, "agent-2", assignments.Msg.GetAssignments()[0].GetAgentId())
+	assert.Equal(t, "dave", assignments.Msg.GetAssignments()[0].GetAudit().GetCreatedBy())
+
+	deployments, err := env.ConfigServer.ListDeployments(ctx, connect.NewRequest(&v1alpha1.ListDeploymentsRequest{
+		AuditFilter: &v1alpha1.AuditFilter{CreatedBy: "dave"},
+	}))
+	require.NoError(t, err)
+	require.Len(t, deployments.Msg.GetDeployments(), 1)
+	assert.Equal(t, "dave", deployments.Msg.GetDeployments()[0].GetAudit().GetModifiedBy())
+	deployments, err = env.ConfigServer.ListDeployments(ctx, connect.NewRequest(&v1alpha1.ListDeploymentsRequest{
+		AuditFilter: &v1alpha1.AuditFilter{CreatedBy: "carol"},
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, deployments.Msg.GetDeployments())
+}
+
+func TestAudit_Tokens(t *testing.T) {
+	env := setupTestEnv(t)
+	erin := auth.NewContext(t.Context(), &auth.Principal{Subject: "erin"})
+
+	created, err := env.BootstrapServer.CreateToken(erin, connect.NewRequest(&bootstrapv1alpha1.CreateTokenRequest{
+		TTL: durationpb.New(time.Hour),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "erin", created.Msg.GetAudit().GetCreatedBy())
+	_, err = env.BootstrapServer.CreateToken(t.Context(), connect.NewRequest(&bootstrapv1alpha1.CreateTokenRequest{
+		TTL: durationpb.New(time.Hour),
+	}))
+	require.NoError(t, err)
+
+	tokens, err := env.BootstrapServer.ListTokens(t.Context(), connect.NewRequest(&bootstrapv1alpha1.ListTokensRequest{
+		Filter: &v1alpha1.AuditFilter{CreatedBy: "erin"},
+	}))
+	require.NoError(t, err)
+	require.Len(t, tokens.Msg.GetTokens(), 1)
+	assert.Equal(t, created.Msg.GetID(), tokens.Msg.GetTokens()[0].GetID())
+}
@@ -0,0 +1,269 @@
+package otelconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gopkg.in/yaml.v3"
+)
+
+// SetComponentPolicyStore sets the store of component policies, keyed by policy ID,
+// enabling component policies
+func (c *ConfigServer) SetComponentPolicyStore(store storage.KeyValue[*v1alpha1.ComponentPolicy]) {
+	c.componentPolicyStore = store
+}
+
+var errComponentPoliciesDisabled = connect.NewError(connect.CodeUnimplemented, errors.New("component policies are not enabled"))
+
+// componentKinds are the sections of a collector config declaring components
+var componentKinds = []string{"receivers", "processors", "exporters", "connectors", "extensions"}
+
+// collectorComponent is a component declared in a collector config
+type collectorComponent struct {
+	kind string
+	// type, optionally followed by /name
+	id string
+}
+
+func (c collectorComponent) String() string {
+	return c.kind + "/" + c.id
+}
+
+// configComponents returns the components declared in a collector config
+func configComponents(config *v1alpha1.Config) ([]collectorComponent, error) {
+	var parsed map[string]any
+	if err := yaml.Unmarshal(config.GetConfig(), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid collector config: %w", err)
+	}
+	var components []collectorComponent
+	for _, kind := range componentKinds {
+		section, _ := parsed[kind].(map[string]any)
+		for id := range section {
+			components = append(components, collectorComponent{kind: kind, id: id})
+		}
+	}
+	slices.SortFunc(components, func(a, b collectorComponent) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return components, nil
+}
+
+// componentMatches returns true if rule, kind/type or a bare type, matches the component
+func componentMatches(rule string, component collectorComponent) bool {
+	typ := componentType(component.id)
+	if kind, ruleType, ok := strings.Cut(rule, "/"); ok {
+		return kind == component.kind && ruleType == typ
+	}
+	return rule == typ
+}
+
+// componentViolation returns why the policy forbids the component, or an empty string
+func componentViolation(policy *v1alpha1.ComponentPolicy, component collectorComponent) string {
+	for _, rule := range policy.GetDenied() {
+		if componentMatches(rule, component) {
+			return fmt.Sprintf("%s is denied by component policy %s", component, policy.GetId())
+		}
+	}
+	if len(policy.GetAllowed()) > 0 && !slices.ContainsFunc(policy.GetAllowed(), func(rule string) bool {
+		return componentMatches(rule, component)
+	}) {
+		return fmt.Sprintf("%s is not allowed by component policy %s", component, policy.GetId())
+	}
+	return ""
+}
+
+// ComponentPolicyError is returned when a config uses components forbidden by component policies
+type ComponentPolicyError struct {
+	Violations []*v1alpha1.ComponentPolicyViolation
+}
+
+func (e *ComponentPolicyError) Error() string {
+	reasons := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		reasons = append(reasons, violation.GetReason())
+	}
+	return "config violates component policies: " + strings.Join(reasons, "; ")
+}
+
+// listComponentPolicies returns the component policies sorted by ID, or none if component
+// policies are not enabled
+func (c *ConfigServer) listComponentPolicies(ctx context.Context) ([]*v1alpha1.ComponentPolicy, error) {
+	if c.componentPolicyStore == nil {
+		return nil, nil
+	}
+	policies, err := c.componentPolicyStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list component policies: %w", err)
+	}
+	slices.SortFunc(policies, func(a, b *v1alpha1.ComponentPolicy) int {
+		return strings.Compare(a.GetId(), b.GetId())
+	})
+	return policies, nil
+}
+
+// componentPolicyViolations checks the config against the policies applying to the agent.
+// A nil agent is only checked against fleet-wide policies.
+func componentPolicyViolations(agent *agentdomain.Agent, config *v1alpha1.Config, policies []*v1alpha1.ComponentPolicy) ([]*v1alpha1.ComponentPolicyViolation, error) {
+	policies = slices.DeleteFunc(slices.Clone(policies), func(policy *v1alpha1.ComponentPolicy) bool {
+		if len(policy.GetSelector()) == 0 {
+			return false
+		}
+		return agent == nil || !agent.MatchesLabels(policy.GetSelector())
+	})
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	components, err := configComponents(config)
+	if err != nil {
+		return nil, err
+	}
+	var violations []*v1alpha1.ComponentPolicyViolation
+	for _, policy := range policies {
+		for _, component := range components {
+			if reason := componentViolation(policy, component); reason != "" {
+				violations = append(violations, &v1alpha1.ComponentPolicyViolation{
+					PolicyId:  policy.GetId(),
+					Component: component.String(),
+					Reason:    reason,
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// checkComponentPolicies returns a *ComponentPolicyError if the config uses components
+// forbidden by the policies applying to the agent, or by fleet-wide policies if agent is nil
+func (c *ConfigServer) checkComponentPolicies(ctx context.Context, agent *agentdomain.Agent, config *v1alpha1.Config) error {
+	policies, err := c.listComponentPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	violations, err := componentPolicyViolations(agent, config, policies)
+	if err != nil {
+		return fmt.Errorf("failed to check component policies: %w", err)
+	}
+	if len(violations) > 0 {
+		return &ComponentPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// componentPolicyConnectError converts an error from checkComponentPolicies or
+// checkRemovedComponents, reporting violations with the given code
+func componentPolicyConnectError(err error, code connect.Code) *connect.Error {
+	var policyErr *ComponentPolicyError
+	var removedErr *RemovedComponentsError
+	if errors.As(err, &policyErr) || errors.As(err, &removedErr) {
+		return connect.NewError(code, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+func (c *ConfigServer) PutComponentPolicy(ctx context.Context, req *connect.Request[v1alpha1.PutComponentPolicyRequest]) (*connect.Response[v1alpha1.ComponentPolicy], error) {
+	if c.componentPolicyStore == nil {
+		return nil, errComponentPoliciesDisabled
+	}
+	policy := req.Msg.GetPolicy()
+	switch {
+	case policy.GetId() == "":
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("policy id must be non-empty"))
+	case len(policy.GetDenied()) == 0 && len(policy.GetAllowed()) == 0:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("denied or allowed must be non-empty"))
+	case slices.Contains(policy.GetDenied(), "") || slices.Contains(policy.GetAllowed(), ""):
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("components must be non-empty"))
+	}
+	existing, err := c.componentPolicyStore.Get(ctx, policy.GetId())
+	if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	policy.Audit = existing.GetAudit().Touched(auth.SubjectFromContext(ctx), time.Now())
+	if err := c.componentPolicyStore.Put(ctx, policy.GetId(), policy); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	events.RecordChange(ctx, c.eventRecorder, events.TypePolicyUpdated, fmt.Sprintf("component policy %s updated", policy.GetId()), map[string]string{
+		"component_policy_id": policy.GetId(),
+	})
+	return connect.NewResponse(policy), nil
+}
+
+func (c *ConfigServer) GetComponentPolicy(ctx context.Context, req *connect.Request[v1alpha1.ComponentPolicyReference]) (*connect.Response[v1alpha1.ComponentPolicy], error) {
+	if c.componentPolicyStore == nil {
+		return nil, errComponentPoliciesDisabled
+	}
+	if req.Msg.GetId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("policy id must be non-empty"))
+	}
+	policy, err := c.componentPolicyStore.Get(ctx, req.Msg.GetId())
+	if err != nil {
+		if grpcutil.IsErrorNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("component policy not found: %s", req.Msg.GetId()))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(policy), nil
+}
+
+func (c *ConfigServer) DeleteComponentPolicy(ctx context.Context, req *connect.Request[v1alpha1.ComponentPolicyReference]) (*connect.Response[emptypb.Empty], error) {
+	if _, err := c.GetComponentPolicy(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := c.componentPolicyStore.Delete(ctx, req.Msg.GetId()); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	events.RecordChange(ctx, c.eventRecorder, events.TypePolicyDeleted, fmt.Sprintf("component policy %s deleted", req.Msg.GetId()), map[string]string{
+		"component_policy_id": req.Msg.GetId(),
+	})
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (c *ConfigServer) ListComponentPolicies(ctx context.Context, _ *connect.Request[v1alpha1.ListComponentPoliciesRequest]) (*connect.Response[v1alpha1.ListComponentPoliciesResponse], error) {
+	if c.componentPolicyStore == nil {
+		return nil, errComponentPoliciesDisabled
+	}
+	policies, err := c.listComponentPolicies(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	agents, err := c.agentRepo.List(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	slices.SortFunc(agents, func(a, b *agentdomain.Agent) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	resp := &v1alpha1.ListComponentPoliciesResponse{Policies: policies}
+	for _, agent := range agents {
+		config, err := c.assignedConfigStore.Get(ctx, agent.ID)
+		if grpcutil.IsErrorNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		violations, err := componentPolicyViolations(agent, config, policies)
+		if err != nil {
+			// unparsable configs are rejected by the collector anyway
+			c.logger.With("agent_id", agent.ID, "err", err).WarnContext(ctx, "failed to check assigned config against component policies")
+			continue
+		}
+		for _, violation := range violations {
+			violation.AgentId = agent.ID
+			violation.ConfigId = agent.Status.AssignedConfigID
+			resp.Violations = append(resp.Violations, violation)
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
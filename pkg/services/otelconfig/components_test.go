@@ -0,0 +1,98 @@
+package otelconfig_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentPolicies(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	h.createTestAgent(ctx, t, "prod-1", map[string]string{"env": "prod"})
+	h.createTestAgent(ctx, t, "dev-1", map[string]string{"env": "dev"})
+	h.createTestConfig(ctx, t, "debug", "receivers: {otlp: {}}\nexporters: {debug/verbose: {verbosity: detailed}}")
+	h.createTestConfig(ctx, t, "filelog", "receivers: {filelog: {include: [/var/log/*]}}\nexporters: {otlp: {}}")
+
+	// assigned before any policy exists, reported as a violation later on
+	_, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: "prod-1", ConfigId: "filelog"}))
+	require.NoError(t, err)
+
+	putPolicy := func(policy *v1alpha1.ComponentPolicy) {
+		t.Helper()
+		_, err := h.ConfigServer.PutComponentPolicy(ctx, connect.NewRequest(&v1alpha1.PutComponentPolicyRequest{Policy: policy}))
+		require.NoError(t, err)
+	}
+	_, err = h.ConfigServer.PutComponentPolicy(ctx, connect.NewRequest(&v1alpha1.PutComponentPolicyRequest{
+		Policy: &v1alpha1.ComponentPolicy{Id: "empty"},
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	putPolicy(&v1alpha1.ComponentPolicy{
+		Id:       "prod",
+		Selector: map[string]string{"env": "prod"},
+		Denied:   []string{"exporters/debug"},
+		Allowed:  []string{"otlp", "exporters/debug"},
+	})
+
+	// the policy only applies to prod agents
+	_, err = h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: "prod-1", ConfigId: "debug"}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	assert.ErrorContains(t, err, "exporters/debug/verbose is denied by component policy prod")
+	_, err = h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: "dev-1", ConfigId: "debug"}))
+	require.NoError(t, err)
+	batch, err := h.ConfigServer.BatchAssignConfig(ctx, connect.NewRequest(&v1alpha1.BatchAssignConfigRequest{
+		AgentIds: []string{"prod-1", "dev-1"},
+		ConfigId: "debug",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod-1"}, batch.Msg.GetFailedAgentIds())
+	assignment, err := h.ConfigAssignmentStore.Get(ctx, "prod-1")
+	require.NoError(t, err)
+	assert.Equal(t, "filelog", assignment.GetConfigId())
+
+	// labelled policies are not enforced when configs are saved
+	_, err = h.ConfigServer.ValidConfig(ctx, connect.NewRequest(&v1alpha1.ValidateConfigRequest{
+		Config: &v1alpha1.Config{Config: []byte("receivers: {otlp: {}}\nexporters: {debug: {}}\nservice: {pipelines: {logs: {receivers: [otlp], exporters: [debug]}}}")},
+	}))
+	require.NoError(t, err)
+
+	list, err := h.ConfigServer.ListComponentPolicies(ctx, connect.NewRequest(&v1alpha1.ListComponentPoliciesRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.GetPolicies(), 1)
+	require.Len(t, list.Msg.GetViolations(), 1)
+	violation := list.Msg.GetViolations()[0]
+	assert.Equal(t, "prod-1", violation.GetAgentId())
+	assert.Equal(t, "filelog", violation.GetConfigId())
+	assert.Equal(t, "prod", violation.GetPolicyId())
+	assert.Equal(t, "receivers/filelog", violation.GetComponent())
+	assert.Contains(t, violation.GetReason(), "not allowed")
+
+	// fleet-wide policies are also enforced when configs are saved
+	putPolicy(&v1alpha1.ComponentPolicy{Id: "global", Denied: []string{"filelog"}})
+	_, err = h.ConfigServer.ValidConfig(ctx, connect.NewRequest(&v1alpha1.ValidateConfigRequest{
+		Config: &v1alpha1.Config{Config: []byte("receivers: {filelog/app: {}}")},
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = h.ConfigServer.PutConfig(ctx, connect.NewRequest(&v1alpha1.PutConfigRequest{
+		Ref:    &v1alpha1.ConfigReference{Id: "filelog-2"},
+		Config: &v1alpha1.Config{Config: []byte("receivers: {filelog: {}}")},
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = h.ConfigServer.SetDefaultConfig(ctx, connect.NewRequest(&v1alpha1.PutConfigRequest{
+		Config: &v1alpha1.Config{Config: []byte("receivers: {filelog: {}}")},
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: "dev-1", ConfigId: "filelog"}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	_, err = h.ConfigServer.DeleteComponentPolicy(ctx, connect.NewRequest(&v1alpha1.ComponentPolicyReference{Id: "prod"}))
+	require.NoError(t, err)
+	_, err = h.ConfigServer.GetComponentPolicy(ctx, connect.NewRequest(&v1alpha1.ComponentPolicyReference{Id: "prod"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: "prod-1", ConfigId: "debug"}))
+	require.NoError(t, err)
+}
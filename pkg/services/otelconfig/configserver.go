@@ -3,8 +3,12 @@ package otelconfig
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"sync"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/gorilla/mux"
@@ -13,20 +17,34 @@ import (
 	agentsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
 	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
 	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1/v1alpha1connect"
+	configv1beta1connect "github.com/otelfleet/otelfleet/pkg/api/config/v1beta1/v1beta1connect"
+	jobsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/apiversion"
+	"github.com/otelfleet/otelfleet/pkg/auth"
 	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/audit"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/services/jobs"
 	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/telemetry"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
 	"github.com/otelfleet/otelfleet/pkg/util"
 	"github.com/otelfleet/otelfleet/pkg/util/configsync"
 	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"github.com/otelfleet/otelfleet/pkg/util/parallel"
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // ConfigChangeNotifier is an interface for notifying when a config changes for an agent.
-// This is implemented by the OpAMP server to push configs to connected agents.
+// This is implemented by the notify transports, delivering the changes to the OpAMP servers
+// pushing configs to connected agents.
 type ConfigChangeNotifier interface {
 	NotifyConfigChange(agentID string)
 }
@@ -39,32 +57,97 @@ type DeploymentController interface {
 	ResumeDeployment(ctx context.Context, deploymentID string) error
 	CancelDeployment(ctx context.Context, deploymentID string) error
 	ListDeployments(ctx context.Context, stateFilter *v1alpha1.DeploymentState) ([]*v1alpha1.DeploymentStatus, error)
+	PurgeDeployment(ctx context.Context, deploymentID string) error
+	RollbackDeployment(ctx context.Context, req *v1alpha1.RollbackDeploymentRequest) (string, error)
+	SimulateDeployment(ctx context.Context, req *v1alpha1.RollingDeploymentRequest) (*v1alpha1.DeploymentPlan, error)
+}
+
+// Indexes of the config assignment store
+const (
+	// AssignmentsByConfig indexes assignments by the ID of their config
+	AssignmentsByConfig = "config_id"
+	// AssignmentsBySource indexes assignments by the name of their ConfigSource
+	AssignmentsBySource = "source"
+)
+
+// AssignmentIndexes are the indexes the config assignment store is opened with
+var AssignmentIndexes = []storage.Index{
+	storage.ProtoIndex(AssignmentsByConfig, func(a *v1alpha1.ConfigAssignment) []string {
+		if a.GetConfigId() == "" {
+			return nil
+		}
+		return []string{a.GetConfigId()}
+	}),
+	storage.ProtoIndex(AssignmentsBySource, func(a *v1alpha1.ConfigAssignment) []string {
+		return []string{a.GetSource().String()}
+	}),
 }
 
 type ConfigServer struct {
-	configStore           storage.KeyValue[*v1alpha1.Config]
-	defaultConfigStore    storage.KeyValue[*v1alpha1.Config]
-	assignedConfigStore   storage.KeyValue[*v1alpha1.Config]
-	configAssignmentStore storage.KeyValue[*v1alpha1.ConfigAssignment]
+	configStore         storage.KeyValue[*v1alpha1.Config]
+	defaultConfigStore  storage.KeyValue[*v1alpha1.Config]
+	assignedConfigStore storage.KeyValue[*v1alpha1.Config]
+	// agent ID -> assignment, indexed by AssignmentIndexes
+	configAssignmentStore storage.IndexedKeyValue[*v1alpha1.ConfigAssignment]
 	agentRepo             agentdomain.Repository
 	effectiveConfigStore  storage.KeyValue[*protobufs.EffectiveConfig]
 	remoteStatusStore     storage.KeyValue[*protobufs.RemoteConfigStatus]
-	logger                *slog.Logger
+	// optional, assignment policies keyed by policy ID
+	policyStore storage.KeyValue[*v1alpha1.AssignmentPolicy]
+	// optional, agent groups keyed by group ID
+	groupStore storage.KeyValue[*v1alpha1.AgentGroup]
+	// optional, the configs agents were bootstrapped with keyed by agent ID
+	bootstrapAssignmentStore storage.KeyValue[*v1alpha1.ConfigAssignment]
+	// optional, component policies keyed by policy ID
+	componentPolicyStore storage.KeyValue[*v1alpha1.ComponentPolicy]
+	// optional, collector distributions keyed by name@version
+	distributionStore storage.KeyValue[*v1alpha1.CollectorDistribution]
+	// optional, package targets keyed by agent/<agent ID> or group/<group ID>
+	packageTargetStore storage.KeyValue[*v1alpha1.PackageTarget]
+	// optional, the package statuses reported by agents keyed by agent ID
+	packageStatusStore storage.KeyValue[*v1alpha1.AgentPackageStatus]
+	// optional, config edit sessions keyed by session ID
+	editSessionStore storage.KeyValue[*v1alpha1.ConfigEditSession]
+	// optional, the last config each agent applied keyed by agent ID
+	knownGoodStore storage.KeyValue[*v1alpha1.KnownGoodConfig]
+	// optional, the config history of each agent keyed by agent ID
+	configHistoryStore storage.KeyValue[*v1alpha1.AgentConfigHistory]
+	// serializes rollbacks with the tracking of known-good configs
+	rollbackMu sync.Mutex
+	// serializes the updates of config histories
+	historyMu sync.Mutex
+	// serializes the saves of edit sessions
+	editMu sync.Mutex
+	// serializes the updates of package statuses
+	packageMu sync.Mutex
+	// serializes policy evaluation
+	policyMu sync.Mutex
+	logger   *slog.Logger
 
 	notifier             ConfigChangeNotifier
+	packageNotifier      PackageChangeNotifier
 	deploymentController DeploymentController
+	interceptors         []connect.Interceptor
+	eventRecorder        events.Recorder
+	// optional, runs async batch assignments
+	jobQueue *jobs.Queue
+	// assignments run in parallel by batch assignments
+	concurrency int
+	// config sources, highest precedence first
+	precedence []v1alpha1.ConfigSource
 
 	services.Service
 }
 
 var _ v1alpha1connect.ConfigServiceHandler = (*ConfigServer)(nil)
+var _ v1alpha1connect.PackageServiceHandler = (*ConfigServer)(nil)
 
 func NewConfigServer(
 	logger *slog.Logger,
 	configStore storage.KeyValue[*v1alpha1.Config],
 	defaultConfigStore storage.KeyValue[*v1alpha1.Config],
 	assignedConfigStore storage.KeyValue[*v1alpha1.Config],
-	configAssignmentStore storage.KeyValue[*v1alpha1.ConfigAssignment],
+	configAssignmentStore storage.IndexedKeyValue[*v1alpha1.ConfigAssignment],
 	agentRepo agentdomain.Repository,
 	effectiveConfigStore storage.KeyValue[*protobufs.EffectiveConfig],
 	remoteStatusStore storage.KeyValue[*protobufs.RemoteConfigStatus],
@@ -78,12 +161,13 @@ func NewConfigServer(
 		agentRepo:             agentRepo,
 		effectiveConfigStore:  effectiveConfigStore,
 		remoteStatusStore:     remoteStatusStore,
+		precedence:            slices.Clone(DefaultPrecedence),
 	}
 	cs.Service = services.NewBasicService(nil, cs.running, nil)
 	return cs
 }
 
-// SetNotifier sets the config change notifier (typically the OpAMP server)
+// SetNotifier sets the config change notifier (typically a notify.Transport)
 func (c *ConfigServer) SetNotifier(notifier ConfigChangeNotifier) {
 	c.notifier = notifier
 }
@@ -93,6 +177,29 @@ func (c *ConfigServer) SetDeploymentController(controller DeploymentController)
 	c.deploymentController = controller
 }
 
+// SetJobQueue enables running batch assignments as background jobs
+func (c *ConfigServer) SetJobQueue(queue *jobs.Queue) {
+	c.jobQueue = queue
+	queue.Register(BatchAssignJobType, c.runBatchAssignJob, jobs.DefaultRetryPolicy)
+}
+
+// SetEventRecorder sets the recorder for config assignment events
+func (c *ConfigServer) SetEventRecorder(recorder events.Recorder) {
+	c.eventRecorder = recorder
+}
+
+// SetConcurrency bounds the assignments batch assignments run in parallel, defaults to
+// parallel.DefaultLimit
+func (c *ConfigServer) SetConcurrency(limit int) {
+	c.concurrency = limit
+}
+
+// AddInterceptors adds interceptors to the config service handlers.
+// Must be called before ConfigureHTTP.
+func (c *ConfigServer) AddInterceptors(interceptors ...connect.Interceptor) {
+	c.interceptors = append(c.interceptors, interceptors...)
+}
+
 // notifyConfigChange notifies the OpAMP server that a config has changed for an agent
 func (c *ConfigServer) notifyConfigChange(agentID string) {
 	if c.notifier != nil {
@@ -101,18 +208,31 @@ func (c *ConfigServer) notifyConfigChange(agentID string) {
 }
 
 func (c *ConfigServer) running(ctx context.Context) error {
-	<-ctx.Done()
-	return nil
+	t := time.NewTicker(editSessionPruneInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			if c.editSessionStore == nil {
+				continue
+			}
+			if err := c.pruneEditSessions(ctx, time.Now()); err != nil {
+				c.logger.With("err", err).Error("failed to prune config edit sessions")
+			}
+		}
+	}
 }
 
 func (c *ConfigServer) ConfigureHTTP(mux *mux.Router) {
 	c.logger.Info("configuring routes")
-	v1alpha1connect.RegisterConfigServiceHandler(mux, c)
+	v1alpha1connect.RegisterConfigServiceHandler(mux, c, connect.WithInterceptors(c.interceptors...))
+	v1alpha1connect.RegisterPackageServiceHandler(mux, c, connect.WithInterceptors(c.interceptors...))
+	// the shim runs the interceptors of the v1alpha1 handlers
+	configv1beta1connect.RegisterConfigServiceHandler(mux, &configServiceV1beta1{configs: c, shim: apiversion.NewShim(c.interceptors...)})
 }
 
-func (c *ConfigServer) ValidConfig(context.Context, *connect.Request[v1alpha1.ValidateConfigRequest]) (*connect.Response[emptypb.Empty], error) {
-	return connect.NewResponse(&emptypb.Empty{}), nil
-}
 func (c *ConfigServer) PutConfig(ctx context.Context, connectReq *connect.Request[v1alpha1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error) {
 	req := connectReq.Msg
 
@@ -122,8 +242,53 @@ func (c *ConfigServer) PutConfig(ctx context.Context, connectReq *connect.Reques
 	if req.GetRef().GetId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "config key must be non-empty")
 	}
-	err := c.configStore.Put(ctx, req.GetRef().GetId(), req.GetConfig())
-	return connect.NewResponse(&emptypb.Empty{}), err
+	if req.GetValidate() {
+		if err := c.checkConfig(ctx, req.GetConfig()); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.putConfig(ctx, req.GetRef().GetId(), req.GetConfig()); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// putConfig stores a config after checking it against the component policies, keeping the
+// owner and tenant of an existing config and updating its audit info. New configs are
+// created in the tenant of the request.
+func (c *ConfigServer) putConfig(ctx context.Context, id string, config *v1alpha1.Config) error {
+	if err := c.checkComponentPolicies(ctx, nil, config); err != nil {
+		return componentPolicyConnectError(err, connect.CodeInvalidArgument)
+	}
+	if err := validateResources(config.GetMetadata().GetResources()); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if config.GetMetadata() == nil {
+		config.Metadata = &v1alpha1.ConfigMetadata{}
+	}
+	// the owner is the principal that created the config, and is kept across updates
+	existing, err := c.configStore.Get(ctx, id)
+	if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if owner := existing.GetMetadata().GetOwner(); owner != "" {
+		config.Metadata.Owner = owner
+	} else if p := auth.FromContext(ctx); p != nil && config.Metadata.Owner == "" {
+		config.Metadata.Owner = p.Subject
+	}
+	if err == nil {
+		config.Metadata.Tenant = existing.GetMetadata().GetTenant()
+	} else {
+		config.Metadata.Tenant, _ = tenant.FromContext(ctx)
+	}
+	config.Metadata.Audit = existing.GetMetadata().GetAudit().Touched(auth.SubjectFromContext(ctx), time.Now())
+	if err := c.configStore.Put(ctx, id, config); err != nil {
+		return err
+	}
+	events.RecordChange(ctx, c.eventRecorder, events.TypeConfigUpdated, fmt.Sprintf("config %s updated", id), map[string]string{
+		"config_id": id,
+	})
+	return nil
 }
 
 func (c *ConfigServer) GetConfig(ctx context.Context, connectReq *connect.Request[v1alpha1.ConfigReference]) (*connect.Response[v1alpha1.Config], error) {
@@ -142,105 +307,258 @@ func (c *ConfigServer) DeleteConfig(ctx context.Context, connectReq *connect.Req
 		return nil, status.Error(codes.InvalidArgument, "config key must be non-empty")
 	}
 
-	return connect.NewResponse(&emptypb.Empty{}), c.configStore.Delete(ctx, req.GetId())
+	if err := c.configStore.Delete(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
+	events.RecordChange(ctx, c.eventRecorder, events.TypeConfigDeleted, fmt.Sprintf("config %s deleted", req.GetId()), map[string]string{
+		"config_id": req.GetId(),
+	})
+	return connect.NewResponse(&emptypb.Empty{}), nil
 }
 
 // ListConfigs by matchers
-func (c *ConfigServer) ListConfigs(ctx context.Context, _ *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.ListConfigReponse], error) {
-	resp := &v1alpha1.ListConfigReponse{}
+func (c *ConfigServer) ListConfigs(ctx context.Context, req *connect.Request[v1alpha1.ListConfigsRequest]) (*connect.Response[v1alpha1.ListConfigReponse], error) {
+	resp := &v1alpha1.ListConfigReponse{
+		Metadata: map[string]*v1alpha1.ConfigMetadata{},
+	}
 
-	configs, err := c.configStore.ListKeys(ctx)
+	keys, err := c.configStore.ListKeys(ctx)
 	if err != nil {
 		return nil, err
 	}
-	resp.Configs = lo.Map(configs, func(key string, _ int) *v1alpha1.ConfigReference {
-		c.logger.With("key", key).Info("got config key")
-		return &v1alpha1.ConfigReference{
-			Id: key,
+	for _, key := range keys {
+		config, err := c.configStore.Get(ctx, key)
+		if grpcutil.IsErrorNotFound(err) {
+			// deleted since listing the keys
+			continue
+		} else if err != nil {
+			return nil, err
 		}
-	})
+		if !tenant.Visible(ctx, config.GetMetadata().GetTenant()) || !req.Msg.GetFilter().Matches(config.GetMetadata().GetAudit()) {
+			continue
+		}
+		resp.Configs = append(resp.Configs, &v1alpha1.ConfigReference{Id: key})
+		if config.GetMetadata() != nil {
+			resp.Metadata[key] = config.GetMetadata()
+		}
+	}
 	return connect.NewResponse(resp), nil
 }
 
 const globalDefaultKey = "global"
 
-func (c *ConfigServer) GetDefaultConfig(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.Config], error) {
+// defaultConfig returns the global default config, or the built-in one if none was set
+func (c *ConfigServer) defaultConfig(ctx context.Context) (*v1alpha1.Config, error) {
 	val, err := c.defaultConfigStore.Get(ctx, globalDefaultKey)
-	if err == nil {
-		return connect.NewResponse(val), nil
-	}
-	st, ok := status.FromError(err)
-	if ok && st.Code() == codes.NotFound {
-		return connect.NewResponse(&v1alpha1.Config{
+	if grpcutil.IsErrorNotFound(err) {
+		return &v1alpha1.Config{
 			Config: []byte(DefaultOtelConfig),
-		}), nil
+		}, nil
 	}
-	return nil, status.Error(codes.Internal, err.Error())
+	return val, err
 }
 
-func (c *ConfigServer) SetDefaultConfig(context.Context, *connect.Request[v1alpha1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error) {
-	panic("implement me")
+func (c *ConfigServer) GetDefaultConfig(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1alpha1.Config], error) {
+	val, err := c.defaultConfig(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return connect.NewResponse(val), nil
+}
+
+// SetDefaultConfig replaces the global default config, and pushes it to the agents
+// that were assigned the default config
+func (c *ConfigServer) SetDefaultConfig(ctx context.Context, connectReq *connect.Request[v1alpha1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error) {
+	config := connectReq.Msg.GetConfig()
+	if config == nil {
+		return nil, status.Error(codes.InvalidArgument, "config must be non-empty")
+	}
+	if connectReq.Msg.GetValidate() {
+		if err := c.checkConfig(ctx, config); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.checkComponentPolicies(ctx, nil, config); err != nil {
+		return nil, componentPolicyConnectError(err, connect.CodeInvalidArgument)
+	}
+	existing, err := c.defaultConfigStore.Get(ctx, globalDefaultKey)
+	if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if config.GetMetadata() == nil {
+		config.Metadata = &v1alpha1.ConfigMetadata{}
+	}
+	config.Metadata.Audit = existing.GetMetadata().GetAudit().Touched(auth.SubjectFromContext(ctx), time.Now())
+	if err := c.defaultConfigStore.Put(ctx, globalDefaultKey, config); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	events.RecordChange(ctx, c.eventRecorder, events.TypeConfigUpdated, "default config updated", nil)
+
+	if err := c.refreshDefaultAssignments(ctx, config); err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to update agents following the default config: %s", err))
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// putAssignment stores the config assigned to an agent and its assignment in one batch, so
+// that the assignment never names a config the agent wasn't assigned. Assignments of every
+// source are traced by a config.assign span.
+func (c *ConfigServer) putAssignment(ctx context.Context, agentID string, config *v1alpha1.Config, assignment *v1alpha1.ConfigAssignment) (err error) {
+	ctx, span := telemetry.Start(ctx, "config.assign", trace.WithAttributes(
+		attribute.String("agent.id", agentID),
+		attribute.String("config.id", assignment.GetConfigId()),
+		attribute.String("config.source", assignment.GetSource().String()),
+	))
+	defer func() { telemetry.End(span, err) }()
+
+	b := c.configAssignmentStore.NewBatch()
+	if err := c.assignedConfigStore.PutBatch(ctx, b, agentID, config); err != nil {
+		return err
+	}
+	if err := c.configAssignmentStore.PutBatch(ctx, b, agentID, assignment); err != nil {
+		return err
+	}
+	return b.Commit(ctx)
+}
+
+// deleteAssignment removes the config assigned to an agent and its assignment in one batch
+func (c *ConfigServer) deleteAssignment(ctx context.Context, agentID string) error {
+	b := c.configAssignmentStore.NewBatch()
+	if err := c.assignedConfigStore.DeleteBatch(ctx, b, agentID); err != nil {
+		return err
+	}
+	if err := c.configAssignmentStore.DeleteBatch(ctx, b, agentID); err != nil {
+		return err
+	}
+	return b.Commit(ctx)
+}
+
+// refreshDefaultAssignments assigns the given default config to the agents that follow it
+func (c *ConfigServer) refreshDefaultAssignments(ctx context.Context, config *v1alpha1.Config) error {
+	entries, err := c.configAssignmentStore.ListByIndex(ctx, AssignmentsBySource, v1alpha1.ConfigSource_CONFIG_SOURCE_DEFAULT.String())
+	if err != nil {
+		return err
+	}
+	hash := util.HashAgentConfigMap(util.ProtoConfigToAgentConfigMap(config))
+	for _, entry := range entries {
+		assignment := entry.Value
+		agentID := assignment.GetAgentId()
+		assignment.AssignedAt = timestamppb.Now()
+		assignment.ConfigHash = hash
+		assignment.Audit = assignment.GetAudit().Touched(auth.SubjectFromContext(ctx), time.Now())
+		if err := c.putAssignment(ctx, agentID, config, assignment); err != nil {
+			return err
+		}
+		c.recordAssigned(ctx, assignment, config)
+		c.notifyConfigChange(agentID)
+		c.logger.With("agent_id", agentID).InfoContext(ctx, "default config updated for agent")
+	}
+	return nil
 }
 
 // ============================================================================
 // Phase 1: Manual Config Assignment
 // ============================================================================
 
-// AssignConfig assigns a config to a single agent
+// AssignConfig assigns a config to a single agent. Assigning the DEFAULT source makes
+// the agent follow the global default config, as opposed to having no config assigned.
 func (c *ConfigServer) AssignConfig(ctx context.Context, req *connect.Request[v1alpha1.AssignConfigRequest]) (*connect.Response[v1alpha1.AssignConfigResponse], error) {
 	agentID := req.Msg.GetAgentId()
 	configID := req.Msg.GetConfigId()
+	source := req.Msg.GetSource()
 
 	if agentID == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("agent_id must be non-empty"))
 	}
-	if configID == "" {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("config_id must be non-empty"))
-	}
 
-	// Validate config exists
-	config, err := c.configStore.Get(ctx, configID)
-	if err != nil {
-		if grpcutil.IsErrorNotFound(err) {
-			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("config not found: %s", configID))
+	var config *v1alpha1.Config
+	var err error
+	switch source {
+	case v1alpha1.ConfigSource_CONFIG_SOURCE_UNSPECIFIED, v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL:
+		source = v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL
+		if configID == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("config_id must be non-empty"))
 		}
-		return nil, connect.NewError(connect.CodeInternal, err)
+		// Validate config exists
+		config, err = c.configStore.Get(ctx, configID)
+		if err != nil {
+			if grpcutil.IsErrorNotFound(err) {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("config not found: %s", configID))
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	case v1alpha1.ConfigSource_CONFIG_SOURCE_DEFAULT:
+		if configID != "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("config_id must be empty when assigning the default config"))
+		}
+		config, err = c.defaultConfig(ctx)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unsupported config source: %s", source))
+	}
+	if req.Msg.GetAutoRollback() && c.knownGoodStore == nil {
+		return nil, errRollbackDisabled
 	}
 
 	// Validate agent exists
-	exists, err := c.agentRepo.Exists(ctx, agentID)
-	if err != nil {
+	agent, err := c.agentRepo.Get(ctx, agentID)
+	if errors.Is(err, agentdomain.ErrAgentNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+	} else if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	if !exists {
-		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+	if source == v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL {
+		if err := checkTenant(agent, configID, config); err != nil {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		}
 	}
-
-	// Store the config in assignedConfigStore (keyed by agentID)
-	if err := c.assignedConfigStore.Put(ctx, agentID, config); err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+	if err := c.checkComponentPolicies(ctx, agent, config); err != nil {
+		return nil, componentPolicyConnectError(err, connect.CodeFailedPrecondition)
+	}
+	if err := checkRemovedComponents(agent, config); err != nil {
+		return nil, componentPolicyConnectError(err, connect.CodeFailedPrecondition)
+	}
+	if err := c.checkManualPrecedence(ctx, agent); err != nil {
+		return nil, precedenceConnectError(err)
 	}
 
-	// Store assignment metadata
+	// Store the config and its assignment metadata together (keyed by agentID)
 	assignment := &v1alpha1.ConfigAssignment{
-		AgentId:    agentID,
-		ConfigId:   configID,
-		Source:     v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL,
-		AssignedAt: timestamppb.Now(),
-		ConfigHash: util.HashAgentConfigMap(util.ProtoConfigToAgentConfigMap(config)),
+		AgentId:      agentID,
+		ConfigId:     configID,
+		Source:       source,
+		AssignedAt:   timestamppb.Now(),
+		ConfigHash:   util.HashAgentConfigMap(util.ProtoConfigToAgentConfigMap(config)),
+		Audit:        v1alpha1.NewAuditInfo(auth.SubjectFromContext(ctx), time.Now()),
+		AutoRollback: req.Msg.GetAutoRollback(),
 	}
-	if err := c.configAssignmentStore.Put(ctx, agentID, assignment); err != nil {
+	if err := c.putAssignment(ctx, agentID, config, assignment); err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	c.recordAssigned(ctx, assignment, config)
 
 	// Notify OpAMP server to push config
 	c.notifyConfigChange(agentID)
 
-	c.logger.With("agent_id", agentID, "config_id", configID).Info("config assigned to agent")
+	message := fmt.Sprintf("config %s assigned", configID)
+	if source == v1alpha1.ConfigSource_CONFIG_SOURCE_DEFAULT {
+		message = "default config assigned"
+	}
+	c.logger.With("agent_id", agentID, "config_id", configID, "source", source.String()).InfoContext(ctx, "config assigned to agent")
+	events.RecordAgentEvent(ctx, c.eventRecorder, c.agentRepo, events.TypeConfigAssigned, agentID, message)
+
+	// the config is assigned regardless, the capacity agents report is only a hint
+	warnings := capacityWarnings(agent, config.GetMetadata().GetResources())
+	if len(warnings) > 0 {
+		c.logger.With("agent_id", agentID, "config_id", configID, "warnings", warnings).WarnContext(ctx, "config requires more resources than the agent's host has")
+	}
 
 	return connect.NewResponse(&v1alpha1.AssignConfigResponse{
-		Success: true,
-		Message: "Config assigned successfully",
+		Success:  true,
+		Message:  "Config assigned successfully",
+		Warnings: warnings,
 	}), nil
 }
 
@@ -273,24 +591,24 @@ func (c *ConfigServer) UnassignConfig(ctx context.Context, req *connect.Request[
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("agent_id must be non-empty"))
 	}
 
-	// Delete from assignedConfigStore
-	if err := c.assignedConfigStore.Delete(ctx, agentID); err != nil {
-		if !grpcutil.IsErrorNotFound(err) {
-			return nil, connect.NewError(connect.CodeInternal, err)
-		}
+	// Delete from assignedConfigStore and configAssignmentStore together
+	if err := c.deleteAssignment(ctx, agentID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	// Delete from configAssignmentStore
-	if err := c.configAssignmentStore.Delete(ctx, agentID); err != nil {
-		if !grpcutil.IsErrorNotFound(err) {
-			return nil, connect.NewError(connect.CodeInternal, err)
-		}
-	}
+	c.recordUnassigned(ctx, agentID)
 
 	// Notify OpAMP server - agent will get default config
 	c.notifyConfigChange(agentID)
 
-	c.logger.With("agent_id", agentID).Info("config unassigned from agent")
+	c.logger.With("agent_id", agentID).InfoContext(ctx, "config unassigned from agent")
+	events.RecordAgentEvent(ctx, c.eventRecorder, c.agentRepo, events.TypeConfigUnassigned, agentID, "config unassigned")
+
+	// the agent is no longer manually overridden, so assignment policies or its
+	// bootstrap config apply again
+	if err := c.EvaluateAgentPolicies(ctx, agentID); err != nil {
+		c.logger.With("agent_id", agentID, "err", err).ErrorContext(ctx, "failed to apply assignment policies")
+	}
 
 	return connect.NewResponse(&v1alpha1.UnassignConfigResponse{
 		Success: true,
@@ -301,17 +619,29 @@ func (c *ConfigServer) UnassignConfig(ctx context.Context, req *connect.Request[
 // Phase 2: Config Assignment Queries and Status
 // ============================================================================
 
-// ListConfigAssignments lists all config assignments, optionally filtered by config ID
+// ListConfigAssignments lists all config assignments, optionally filtered by config ID.
+// Filtering by config ID only reads the assignments of the config.
 func (c *ConfigServer) ListConfigAssignments(ctx context.Context, req *connect.Request[v1alpha1.ListConfigAssignmentsRequest]) (*connect.Response[v1alpha1.ListConfigAssignmentsResponse], error) {
-	assignments, err := c.configAssignmentStore.List(ctx)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+	var assignments []*v1alpha1.ConfigAssignment
+	if req.Msg.ConfigId != nil {
+		entries, err := c.configAssignmentStore.ListByIndex(ctx, AssignmentsByConfig, req.Msg.GetConfigId())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		for _, entry := range entries {
+			assignments = append(assignments, entry.Value)
+		}
+	} else {
+		var err error
+		assignments, err = c.configAssignmentStore.List(ctx)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
 	}
 
 	var result []*v1alpha1.ConfigAssignmentInfo
 	for _, assignment := range assignments {
-		// Filter by configId if specified
-		if req.Msg.ConfigId != nil && assignment.GetConfigId() != *req.Msg.ConfigId {
+		if !req.Msg.GetAuditFilter().Matches(assignment.GetAudit()) {
 			continue
 		}
 
@@ -328,6 +658,8 @@ func (c *ConfigServer) ListConfigAssignments(ctx context.Context, req *connect.R
 			AssignedAt:   assignment.GetAssignedAt(),
 			Status:       appStatus,
 			ErrorMessage: errorMsg,
+			Audit:        assignment.GetAudit(),
+			Rollback:     assignment.GetRollback(),
 		})
 	}
 
@@ -339,7 +671,15 @@ func (c *ConfigServer) ListConfigAssignments(ctx context.Context, req *connect.R
 // getRemoteConfigStatus returns the application status for an agent's config.
 // Uses the shared configsync helper for consistent status computation.
 func (c *ConfigServer) getRemoteConfigStatus(ctx context.Context, agentID string, assignedHash []byte) (v1alpha1.ConfigApplicationStatus, string, error) {
-	syncStatus, reason, err := configsync.ComputeConfigSyncStatus(ctx, agentID, assignedHash, c.remoteStatusStore)
+	var capabilities uint64
+	conn, err := c.agentRepo.GetConnectionState(ctx, agentID)
+	if err == nil {
+		capabilities = uint64(conn.Capabilities)
+	} else if !errors.Is(err, agentdomain.ErrAgentNotFound) {
+		return v1alpha1.ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_UNSPECIFIED, "internal error", err
+	}
+
+	syncStatus, reason, err := configsync.ComputeConfigSyncStatus(ctx, agentID, assignedHash, capabilities, c.remoteStatusStore)
 	if err != nil {
 		return v1alpha1.ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_UNSPECIFIED, reason, err
 	}
@@ -350,6 +690,8 @@ func (c *ConfigServer) getRemoteConfigStatus(ctx context.Context, agentID string
 		return v1alpha1.ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_APPLIED, "", nil
 	case agentsv1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_ERROR:
 		return v1alpha1.ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_FAILED, reason, nil
+	case agentsv1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_UNSUPPORTED:
+		return v1alpha1.ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_UNSUPPORTED, reason, nil
 	default:
 		return v1alpha1.ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_PENDING, reason, nil
 	}
@@ -397,6 +739,8 @@ func (c *ConfigServer) GetConfigStatus(ctx context.Context, req *connect.Request
 			AssignedAt:   assignment.GetAssignedAt(),
 			Status:       appStatus,
 			ErrorMessage: errorMsg,
+			Audit:        assignment.GetAudit(),
+			Rollback:     assignment.GetRollback(),
 		},
 		EffectiveConfigHash: effectiveHash,
 		AssignedConfigHash:  assignment.GetConfigHash(),
@@ -409,35 +753,51 @@ func (c *ConfigServer) GetConfigStatus(ctx context.Context, req *connect.Request
 // ============================================================================
 
 // assignConfigToAgent is a helper that assigns a config to an agent (used by batch operations)
-func (c *ConfigServer) assignConfigToAgent(ctx context.Context, agentID, configID string, config *v1alpha1.Config) error {
+func (c *ConfigServer) assignConfigToAgent(ctx context.Context, agentID, configID string, config *v1alpha1.Config, autoRollback bool) error {
 	// Validate agent exists
-	exists, err := c.agentRepo.Exists(ctx, agentID)
-	if err != nil {
+	agent, err := c.agentRepo.Get(ctx, agentID)
+	if errors.Is(err, agentdomain.ErrAgentNotFound) {
+		return fmt.Errorf("agent not found: %s", agentID)
+	} else if err != nil {
 		return fmt.Errorf("failed to check agent existence: %w", err)
 	}
-	if !exists {
-		return fmt.Errorf("agent not found: %s", agentID)
+	if err := checkTenant(agent, configID, config); err != nil {
+		return err
 	}
-
-	// Store the config in assignedConfigStore
-	if err := c.assignedConfigStore.Put(ctx, agentID, config); err != nil {
+	if err := c.checkComponentPolicies(ctx, agent, config); err != nil {
+		return err
+	}
+	if err := checkRemovedComponents(agent, config); err != nil {
+		return err
+	}
+	if err := c.checkManualPrecedence(ctx, agent); err != nil {
 		return err
 	}
 
-	// Store assignment metadata
+	// Store the config and its assignment metadata together
 	assignment := &v1alpha1.ConfigAssignment{
-		AgentId:    agentID,
-		ConfigId:   configID,
-		Source:     v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL,
-		AssignedAt: timestamppb.Now(),
-		ConfigHash: util.HashAgentConfigMap(util.ProtoConfigToAgentConfigMap(config)),
+		AgentId:      agentID,
+		ConfigId:     configID,
+		Source:       v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL,
+		AssignedAt:   timestamppb.Now(),
+		ConfigHash:   util.HashAgentConfigMap(util.ProtoConfigToAgentConfigMap(config)),
+		Audit:        v1alpha1.NewAuditInfo(auth.SubjectFromContext(ctx), time.Now()),
+		AutoRollback: autoRollback,
 	}
-	return c.configAssignmentStore.Put(ctx, agentID, assignment)
+	if err := c.putAssignment(ctx, agentID, config, assignment); err != nil {
+		return err
+	}
+	c.recordAssigned(ctx, assignment, config)
+	events.RecordAgentEvent(ctx, c.eventRecorder, c.agentRepo, events.TypeConfigAssigned, agentID, fmt.Sprintf("config %s assigned", configID))
+	return nil
 }
 
 // AssignConfigToAgent assigns a config to an agent by config ID (used by deployment controller)
 // This implements the deployment.ConfigAssigner interface
-func (c *ConfigServer) AssignConfigToAgent(ctx context.Context, agentID, configID string) error {
+func (c *ConfigServer) AssignConfigToAgent(ctx context.Context, agentID, configID string, autoRollback bool) error {
+	if autoRollback && c.knownGoodStore == nil {
+		return errRollbackDisabled
+	}
 	// Get the config
 	config, err := c.configStore.Get(ctx, configID)
 	if err != nil {
@@ -445,7 +805,7 @@ func (c *ConfigServer) AssignConfigToAgent(ctx context.Context, agentID, configI
 	}
 
 	// Assign the config
-	if err := c.assignConfigToAgent(ctx, agentID, configID, config); err != nil {
+	if err := c.assignConfigToAgent(ctx, agentID, configID, config, autoRollback); err != nil {
 		return err
 	}
 
@@ -455,6 +815,99 @@ func (c *ConfigServer) AssignConfigToAgent(ctx context.Context, agentID, configI
 	return nil
 }
 
+// RevokeAssignment removes the assignment of an agent that is being deleted. Unlike
+// UnassignConfig, assignment policies aren't evaluated again.
+// This implements the agent.AssignmentRevoker interface
+func (c *ConfigServer) RevokeAssignment(ctx context.Context, agentID string) error {
+	if err := c.deleteAssignment(ctx, agentID); err != nil {
+		return err
+	}
+	c.recordUnassigned(ctx, agentID)
+	c.logger.With("agent_id", agentID).InfoContext(ctx, "config assignment revoked from deleted agent")
+	events.RecordAgentEvent(ctx, c.eventRecorder, c.agentRepo, events.TypeConfigUnassigned, agentID, "config revoked, agent deleted")
+	return nil
+}
+
+// CurrentAssignment returns the assignment of an agent and the config it assigns, nil if
+// the agent has none. This implements the deployment.AssignmentRestorer interface
+func (c *ConfigServer) CurrentAssignment(ctx context.Context, agentID string) (*v1alpha1.ConfigAssignment, *v1alpha1.Config, error) {
+	assignment, err := c.configAssignmentStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	config, err := c.assignedConfigStore.Get(ctx, agentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return assignment, config, nil
+}
+
+// AuditedConfig returns the body of a stored config, the default config or the config
+// assigned to an agent, nil if there is none. This implements the audit.ConfigSource interface
+func (c *ConfigServer) AuditedConfig(ctx context.Context, kind audit.ConfigKind, id string) ([]byte, error) {
+	var (
+		config *v1alpha1.Config
+		err    error
+	)
+	switch kind {
+	case audit.ConfigKindConfig:
+		config, err = c.configStore.Get(ctx, id)
+	case audit.ConfigKindDefault:
+		config, err = c.defaultConfig(ctx)
+	case audit.ConfigKindAgent:
+		config, err = c.assignedConfigStore.Get(ctx, id)
+	default:
+		return nil, fmt.Errorf("unknown config kind %q", kind)
+	}
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return config.GetConfig(), nil
+}
+
+// RestoreAssignment assigns an agent an assignment captured by CurrentAssignment again, or
+// removes its assignment if assignment is nil (used by deployment rollbacks)
+func (c *ConfigServer) RestoreAssignment(ctx context.Context, agentID string, assignment *v1alpha1.ConfigAssignment, config *v1alpha1.Config) error {
+	if assignment == nil {
+		if err := c.deleteAssignment(ctx, agentID); err != nil {
+			return err
+		}
+		c.recordUnassigned(ctx, agentID)
+		c.notifyConfigChange(agentID)
+		events.RecordAgentEvent(ctx, c.eventRecorder, c.agentRepo, events.TypeConfigUnassigned, agentID, "config unassigned, restored by a deployment rollback")
+		// as when unassigning, assignment policies or the bootstrap config apply again
+		return c.EvaluateAgentPolicies(ctx, agentID)
+	}
+
+	restored := proto.Clone(assignment).(*v1alpha1.ConfigAssignment)
+	if restored.GetSource() == v1alpha1.ConfigSource_CONFIG_SOURCE_DEFAULT {
+		// the default config may have changed since, agents follow the current one
+		current, err := c.defaultConfig(ctx)
+		if err != nil {
+			return err
+		}
+		config = current
+		restored.ConfigHash = util.HashAgentConfigMap(util.ProtoConfigToAgentConfigMap(config))
+	}
+	restored.AgentId = agentID
+	restored.AssignedAt = timestamppb.Now()
+	restored.Audit = v1alpha1.NewAuditInfo(auth.SubjectFromContext(ctx), time.Now())
+	restored.Rollback = nil
+	restored.AutoRollback = restored.GetAutoRollback() && c.knownGoodStore != nil
+	if err := c.putAssignment(ctx, agentID, config, restored); err != nil {
+		return err
+	}
+	c.recordAssigned(ctx, restored, config)
+	c.notifyConfigChange(agentID)
+	events.RecordAgentEvent(ctx, c.eventRecorder, c.agentRepo, events.TypeConfigAssigned, agentID,
+		fmt.Sprintf("%s restored by a deployment rollback", describeAssignedConfig(restored)))
+	return nil
+}
+
 // BatchAssignConfig assigns a config to multiple agents
 func (c *ConfigServer) BatchAssignConfig(ctx context.Context, req *connect.Request[v1alpha1.BatchAssignConfigRequest]) (*connect.Response[v1alpha1.BatchAssignConfigResponse], error) {
 	configID := req.Msg.GetConfigId()
@@ -471,42 +924,83 @@ func (c *ConfigServer) BatchAssignConfig(ctx context.Context, req *connect.Reque
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
+	if req.Msg.GetAsync() {
+		if c.jobQueue == nil {
+			return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("background jobs not configured"))
+		}
+		job, err := c.jobQueue.Enqueue(ctx, BatchAssignJobType, req.Msg)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		return connect.NewResponse(&v1alpha1.BatchAssignConfigResponse{
+			JobId: job.GetId(),
+		}), nil
+	}
+	return connect.NewResponse(c.batchAssign(ctx, configID, config, req.Msg.GetAgentIds())), nil
+}
+
+// BatchAssignJobType is the type of the jobs running async batch assignments
+const BatchAssignJobType = "config.batch-assign"
+
+func (c *ConfigServer) runBatchAssignJob(ctx context.Context, job *jobsv1alpha1.Job) (proto.Message, error) {
+	req := &v1alpha1.BatchAssignConfigRequest{}
+	if err := job.GetPayload().UnmarshalTo(req); err != nil {
+		return nil, jobs.Permanent(fmt.Errorf("invalid batch assignment job: %w", err))
+	}
+	config, err := c.configStore.Get(ctx, req.GetConfigId())
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, jobs.Permanent(fmt.Errorf("config not found: %s", req.GetConfigId()))
+	} else if err != nil {
+		return nil, err
+	}
+	return c.batchAssign(ctx, req.GetConfigId(), config, req.GetAgentIds()), nil
+}
+
+// batchAssign assigns a config to each of the agents, reporting the agents it failed to assign it to
+func (c *ConfigServer) batchAssign(ctx context.Context, configID string, config *v1alpha1.Config, agentIDs []string) *v1alpha1.BatchAssignConfigResponse {
 	var successful, failed int32
 	var failedAgentIDs, errorMessages []string
 
-	for _, agentID := range req.Msg.GetAgentIds() {
-		err := c.assignConfigToAgent(ctx, agentID, configID, config)
+	errs := parallel.Collect(ctx, c.concurrency, agentIDs, func(ctx context.Context, agentID string) error {
+		if err := c.assignConfigToAgent(ctx, agentID, configID, config, false); err != nil {
+			return err
+		}
+		c.notifyConfigChange(agentID)
+		return nil
+	})
+	for i, err := range errs {
 		if err != nil {
 			failed++
-			failedAgentIDs = append(failedAgentIDs, agentID)
+			failedAgentIDs = append(failedAgentIDs, agentIDs[i])
 			errorMessages = append(errorMessages, err.Error())
 		} else {
 			successful++
-			c.notifyConfigChange(agentID)
 		}
 	}
 
-	c.logger.With("config_id", configID, "successful", successful, "failed", failed).Info("batch config assignment completed")
+	c.logger.With("config_id", configID, "successful", successful, "failed", failed).InfoContext(ctx, "batch config assignment completed")
 
-	return connect.NewResponse(&v1alpha1.BatchAssignConfigResponse{
+	return &v1alpha1.BatchAssignConfigResponse{
 		Successful:     successful,
 		Failed:         failed,
 		FailedAgentIds: failedAgentIDs,
 		ErrorMessages:  errorMessages,
-	}), nil
+	}
 }
 
-
-// AssignConfigByLabels assigns a config to agents matching the specified labels
+// AssignConfigByLabels assigns a config to agents matching the specified labels and selector expression
 func (c *ConfigServer) AssignConfigByLabels(ctx context.Context, req *connect.Request[v1alpha1.AssignConfigByLabelsRequest]) (*connect.Response[v1alpha1.AssignConfigByLabelsResponse], error) {
 	configID := req.Msg.GetConfigId()
-	labels := req.Msg.GetLabels()
 
 	if configID == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("config_id must be non-empty"))
 	}
-	if len(labels) == 0 {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("labels must be non-empty"))
+	selector, err := agentdomain.NewSelector(req.Msg.GetLabels(), req.Msg.GetSelectorExpression())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if selector.Empty() {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("labels or selector_expression must be non-empty"))
 	}
 
 	// Find agents matching labels using repository
@@ -517,7 +1011,7 @@ func (c *ConfigServer) AssignConfigByLabels(ctx context.Context, req *connect.Re
 
 	var matchedAgentIDs []string
 	for _, agent := range agents {
-		if agent.MatchesLabels(labels) {
+		if agent.MatchesSelector(selector) {
 			matchedAgentIDs = append(matchedAgentIDs, agent.ID)
 		}
 	}
@@ -557,6 +1051,12 @@ func (c *ConfigServer) StartRollingDeployment(ctx context.Context, req *connect.
 	if c.deploymentController == nil {
 		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("deployment controller not configured"))
 	}
+	if req.Msg.GetAutoRollback() && c.knownGoodStore == nil {
+		return nil, errRollbackDisabled
+	}
+	if _, err := agentdomain.ParseSelector(req.Msg.GetAgentSelectorExpression()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 
 	deploymentID, err := c.deploymentController.StartDeployment(ctx, req.Msg)
 	if err != nil {
@@ -632,6 +1132,42 @@ func (c *ConfigServer) CancelDeployment(ctx context.Context, req *connect.Reques
 	}), nil
 }
 
+// PurgeDeployment deletes a finished deployment and its per-agent statuses
+func (c *ConfigServer) PurgeDeployment(ctx context.Context, req *connect.Request[v1alpha1.PurgeDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentActionResponse], error) {
+	if c.deploymentController == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("deployment controller not configured"))
+	}
+
+	if err := c.deploymentController.PurgeDeployment(ctx, req.Msg.GetDeploymentId()); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&v1alpha1.DeploymentActionResponse{
+		Success: true,
+		Message: "Deployment purged",
+	}), nil
+}
+
+// RollbackDeployment starts a deployment restoring the configs the agents of a finished
+// deployment had before it
+func (c *ConfigServer) RollbackDeployment(ctx context.Context, req *connect.Request[v1alpha1.RollbackDeploymentRequest]) (*connect.Response[v1alpha1.RollingDeploymentResponse], error) {
+	if c.deploymentController == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("deployment controller not configured"))
+	}
+	if req.Msg.GetDeploymentId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("deployment_id must be non-empty"))
+	}
+
+	deploymentID, err := c.deploymentController.RollbackDeployment(ctx, req.Msg)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&v1alpha1.RollingDeploymentResponse{
+		DeploymentId: deploymentID,
+	}), nil
+}
+
 // ListDeployments lists all deployments, optionally filtered by state
 func (c *ConfigServer) ListDeployments(ctx context.Context, req *connect.Request[v1alpha1.ListDeploymentsRequest]) (*connect.Response[v1alpha1.ListDeploymentsResponse], error) {
 	if c.deploymentController == nil {
@@ -647,8 +1183,27 @@ func (c *ConfigServer) ListDeployments(ctx context.Context, req *connect.Request
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	if filter := req.Msg.GetAuditFilter(); filter != nil {
+		deployments = lo.Filter(deployments, func(d *v1alpha1.DeploymentStatus, _ int) bool {
+			return filter.Matches(d.GetAudit())
+		})
+	}
 
 	return connect.NewResponse(&v1alpha1.ListDeploymentsResponse{
 		Deployments: deployments,
 	}), nil
 }
+
+// SimulateDeployment plans a rolling deployment without executing it
+func (c *ConfigServer) SimulateDeployment(ctx context.Context, req *connect.Request[v1alpha1.RollingDeploymentRequest]) (*connect.Response[v1alpha1.DeploymentPlan], error) {
+	if c.deploymentController == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("deployment controller not configured"))
+	}
+
+	plan, err := c.deploymentController.SimulateDeployment(ctx, req.Msg)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(plan), nil
+}
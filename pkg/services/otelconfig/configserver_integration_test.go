@@ -4,16 +4,18 @@ import (
 	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	agentsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
 	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	jobsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/otelconfig"
 	"github.com/otelfleet/otelfleet/pkg/util"
 	"github.com/otelfleet/otelfleet/pkg/util/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 // mockNotifier tracks config change notifications for testing.
@@ -191,6 +193,53 @@ func TestHashConsistency_MismatchedHashShowsPending(t *testing.T) {
 		"Status should be PENDING when agent reports different hash")
 }
 
+// TestCapabilities_NoRemoteConfigShowsUnsupported verifies that an agent which
+// reported capabilities without AcceptsRemoteConfig is UNSUPPORTED rather than
+// PENDING forever.
+func TestCapabilities_NoRemoteConfigShowsUnsupported(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := context.Background()
+
+	agentID := "agent-no-remote-config"
+	configID := "config-no-remote-config"
+
+	h.createTestAgent(ctx, t, agentID, nil)
+	h.createTestConfig(ctx, t, configID, "receivers:\n  otlp:\n")
+	require.NoError(t, h.ConnectionStateStore.Put(ctx, agentID, &agentsv1alpha1.AgentConnectionState{
+		AgentId:      agentID,
+		State:        agentsv1alpha1.AgentState_AGENT_STATE_CONNECTED,
+		Capabilities: uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus),
+	}))
+
+	_, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{
+		AgentId:  agentID,
+		ConfigId: configID,
+	}))
+	require.NoError(t, err)
+
+	statusResp, err := h.ConfigServer.GetConfigStatus(ctx, connect.NewRequest(&v1alpha1.GetConfigStatusRequest{
+		AgentId: agentID,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_UNSUPPORTED,
+		statusResp.Msg.Assignment.GetStatus())
+	assert.NotEmpty(t, statusResp.Msg.Assignment.GetErrorMessage())
+
+	// once the agent accepts remote config it waits on the agent again
+	require.NoError(t, h.ConnectionStateStore.Put(ctx, agentID, &agentsv1alpha1.AgentConnectionState{
+		AgentId: agentID,
+		State:   agentsv1alpha1.AgentState_AGENT_STATE_CONNECTED,
+		Capabilities: uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus |
+			protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig),
+	}))
+	statusResp, err = h.ConfigServer.GetConfigStatus(ctx, connect.NewRequest(&v1alpha1.GetConfigStatusRequest{
+		AgentId: agentID,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ConfigApplicationStatus_CONFIG_APPLICATION_STATUS_PENDING,
+		statusResp.Msg.Assignment.GetStatus())
+}
+
 // ============================================================================
 // Test: Store Consistency Between assignedConfigStore and configAssignmentStore
 // ============================================================================
@@ -555,6 +604,42 @@ func TestLabelMatching_EmptyLabelsReturnsError(t *testing.T) {
 	assert.Error(t, err, "Empty labels should return an error")
 }
 
+// TestLabelMatching_SelectorExpression verifies that selector expressions combine with
+// labels, and that invalid expressions are rejected.
+func TestLabelMatching_SelectorExpression(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := context.Background()
+
+	configID := "config-selector"
+	h.createTestConfig(ctx, t, configID, "receivers:\n  otlp:\n")
+	h.createTestAgent(ctx, t, "agent-us", map[string]string{"env": "prod", "region": "us-east"})
+	h.createTestAgent(ctx, t, "agent-eu", map[string]string{"env": "prod", "region": "eu-west"})
+	h.createTestAgent(ctx, t, "agent-legacy", map[string]string{"env": "prod", "region": "us-east", "legacy": "true"})
+	h.createTestAgent(ctx, t, "agent-canary", map[string]string{"env": "staging", "canary": "true"})
+
+	resp, err := h.ConfigServer.AssignConfigByLabels(ctx, connect.NewRequest(&v1alpha1.AssignConfigByLabelsRequest{
+		ConfigId:           configID,
+		SelectorExpression: "env=prod,region in (us-east,ap-south),!legacy || canary",
+	}))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"agent-us", "agent-canary"}, resp.Msg.GetMatchedAgentIds())
+
+	// labels apply to every group of the expression
+	resp, err = h.ConfigServer.AssignConfigByLabels(ctx, connect.NewRequest(&v1alpha1.AssignConfigByLabelsRequest{
+		ConfigId:           configID,
+		Labels:             map[string]string{"env": "prod"},
+		SelectorExpression: "region!=us-east || canary",
+	}))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"agent-eu"}, resp.Msg.GetMatchedAgentIds())
+
+	_, err = h.ConfigServer.AssignConfigByLabels(ctx, connect.NewRequest(&v1alpha1.AssignConfigByLabelsRequest{
+		ConfigId:           configID,
+		SelectorExpression: "region in us-east",
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
 // ============================================================================
 // Test: List Operations Consistency
 // ============================================================================
@@ -598,6 +683,58 @@ func TestListConfigAssignments_FilterByConfigId(t *testing.T) {
 	}
 }
 
+// TestListConfigAssignments_FilterFollowsReassignAndUnassign verifies the
+// config filter reflects assignments moved to another config or removed.
+func TestListConfigAssignments_FilterFollowsReassignAndUnassign(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := context.Background()
+
+	config1 := "moved-config-1"
+	config2 := "moved-config-2"
+
+	h.createTestConfig(ctx, t, config1, "version: 1")
+	h.createTestConfig(ctx, t, config2, "version: 2")
+
+	h.createTestAgent(ctx, t, "moved-agent-1", nil)
+	h.createTestAgent(ctx, t, "moved-agent-2", nil)
+
+	for _, agentID := range []string{"moved-agent-1", "moved-agent-2"} {
+		_, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{
+			AgentId: agentID, ConfigId: config1,
+		}))
+		require.NoError(t, err)
+	}
+
+	agentsOf := func(configID string) []string {
+		t.Helper()
+		resp, err := h.ConfigServer.ListConfigAssignments(ctx, connect.NewRequest(&v1alpha1.ListConfigAssignmentsRequest{
+			ConfigId: &configID,
+		}))
+		require.NoError(t, err)
+		var agentIDs []string
+		for _, assignment := range resp.Msg.GetAssignments() {
+			agentIDs = append(agentIDs, assignment.GetAgentId())
+		}
+		return agentIDs
+	}
+
+	// Move agent 1 to config2
+	_, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{
+		AgentId: "moved-agent-1", ConfigId: config2,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"moved-agent-2"}, agentsOf(config1))
+	assert.Equal(t, []string{"moved-agent-1"}, agentsOf(config2))
+
+	// Unassign agent 2
+	_, err = h.ConfigServer.UnassignConfig(ctx, connect.NewRequest(&v1alpha1.UnassignConfigRequest{
+		AgentId: "moved-agent-2",
+	}))
+	require.NoError(t, err)
+	assert.Empty(t, agentsOf(config1))
+	assert.Equal(t, []string{"moved-agent-1"}, agentsOf(config2))
+}
+
 // TestListConfigAssignments_NoFilterReturnsAll verifies unfiltered list returns all.
 func TestListConfigAssignments_NoFilterReturnsAll(t *testing.T) {
 	h := setupTestEnv(t)
@@ -743,7 +880,7 @@ func TestConfigCRUD_ListShowsAllConfigs(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	resp, err := h.ConfigServer.ListConfigs(ctx, connect.NewRequest(&emptypb.Empty{}))
+	resp, err := h.ConfigServer.ListConfigs(ctx, connect.NewRequest(&v1alpha1.ListConfigsRequest{}))
 	require.NoError(t, err)
 
 	ids := make([]string, len(resp.Msg.GetConfigs()))
@@ -811,3 +948,37 @@ func TestBatchAssign_NotificationsOnlyForSuccessful(t *testing.T) {
 	assert.NotContains(t, notifications, "notify-failure",
 		"Failed agent should NOT be notified")
 }
+
+// TestBatchAssign_Async verifies that async batch assignments run as background
+// jobs whose result is the batch response.
+func TestBatchAssign_Async(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := context.Background()
+
+	configID := "async-config"
+	h.createTestConfig(ctx, t, configID, "receivers:\n  otlp:\n")
+	h.createTestAgent(ctx, t, "async-agent-1", nil)
+
+	resp, err := h.ConfigServer.BatchAssignConfig(ctx, connect.NewRequest(&v1alpha1.BatchAssignConfigRequest{
+		AgentIds: []string{"async-agent-1", "async-agent-missing"},
+		ConfigId: configID,
+		Async:    true,
+	}))
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Msg.GetJobId())
+	assert.Zero(t, resp.Msg.GetSuccessful())
+
+	var job *jobsv1alpha1.Job
+	require.Eventually(t, func() bool {
+		job, err = h.JobQueue.Get(ctx, resp.Msg.GetJobId())
+		require.NoError(t, err)
+		return job.GetState() == jobsv1alpha1.JobState_JOB_STATE_SUCCEEDED
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, otelconfig.BatchAssignJobType, job.GetType())
+
+	result := &v1alpha1.BatchAssignConfigResponse{}
+	require.NoError(t, job.GetResult().UnmarshalTo(result))
+	assert.Equal(t, int32(1), result.GetSuccessful())
+	assert.Equal(t, []string{"async-agent-missing"}, result.GetFailedAgentIds())
+	assert.Contains(t, h.notifier.getNotifications(), "async-agent-1")
+}
@@ -0,0 +1,110 @@
+package otelconfig
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"gopkg.in/yaml.v3"
+)
+
+// collectorPipelines is the part of a collector config the coverage report looks at
+type collectorPipelines struct {
+	Service struct {
+		Pipelines map[string]struct {
+			Exporters []string `yaml:"exporters"`
+		} `yaml:"pipelines"`
+	} `yaml:"service"`
+}
+
+// componentType strips the optional "/name" suffix from a pipeline or component ID
+func componentType(id string) string {
+	t, _, _ := strings.Cut(id, "/")
+	return t
+}
+
+// effectivePipelines returns the exporter IDs of every pipeline in the effective config,
+// by pipeline ID. Pipelines defined in several config files are merged, like the
+// collector does, and files that aren't collector configs are ignored.
+func effectivePipelines(config *agentdomain.EffectiveConfig) map[string][]string {
+	pipelines := map[string][]string{}
+	for _, file := range config.ConfigMap {
+		var parsed collectorPipelines
+		if err := yaml.Unmarshal(file.Body, &parsed); err != nil {
+			continue
+		}
+		for id, pipeline := range parsed.Service.Pipelines {
+			pipelines[id] = append(pipelines[id], pipeline.Exporters...)
+		}
+	}
+	return pipelines
+}
+
+func (c *ConfigServer) GetConfigCoverage(ctx context.Context, req *connect.Request[v1alpha1.GetConfigCoverageRequest]) (*connect.Response[v1alpha1.ConfigCoverageReport], error) {
+	agents, err := c.agentRepo.List(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list agents: %w", err))
+	}
+
+	report := &v1alpha1.ConfigCoverageReport{
+		TotalAgents: int32(len(agents)),
+	}
+	signals := map[string]*v1alpha1.SignalCoverage{}
+	exporters := map[string]int32{}
+	for _, agent := range agents {
+		effective := agent.Status.EffectiveConfig
+		if effective == nil || len(effective.ConfigMap) == 0 {
+			report.AgentsNotReporting = append(report.AgentsNotReporting, agent.ID)
+			continue
+		}
+		report.ReportingAgents++
+
+		pipelines := effectivePipelines(effective)
+		if len(pipelines) == 0 {
+			report.AgentsWithoutPipelines = append(report.AgentsWithoutPipelines, agent.ID)
+			continue
+		}
+		agentSignals := map[string]struct{}{}
+		agentExporters := map[string]struct{}{}
+		for id, pipelineExporters := range pipelines {
+			signal := componentType(id)
+			coverage, ok := signals[signal]
+			if !ok {
+				coverage = &v1alpha1.SignalCoverage{Signal: signal}
+				signals[signal] = coverage
+			}
+			coverage.Pipelines++
+			agentSignals[signal] = struct{}{}
+			for _, exporter := range pipelineExporters {
+				agentExporters[componentType(exporter)] = struct{}{}
+			}
+		}
+		for signal := range agentSignals {
+			signals[signal].Agents++
+		}
+		for exporter := range agentExporters {
+			exporters[exporter]++
+		}
+	}
+
+	for _, coverage := range signals {
+		report.Signals = append(report.Signals, coverage)
+	}
+	slices.SortFunc(report.Signals, func(a, b *v1alpha1.SignalCoverage) int {
+		return strings.Compare(a.GetSignal(), b.GetSignal())
+	})
+	for exporter, count := range exporters {
+		report.Exporters = append(report.Exporters, &v1alpha1.ComponentUsage{Type: exporter, Agents: count})
+	}
+	slices.SortFunc(report.Exporters, func(a, b *v1alpha1.ComponentUsage) int {
+		return cmp.Or(cmp.Compare(b.GetAgents(), a.GetAgents()), strings.Compare(a.GetType(), b.GetType()))
+	})
+	slices.Sort(report.AgentsWithoutPipelines)
+	slices.Sort(report.AgentsNotReporting)
+	return connect.NewResponse(report), nil
+}
@@ -0,0 +1,73 @@
+package otelconfig_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/go-cmp/cmp"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/otelconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func effectiveConfig(files map[string]string) *protobufs.EffectiveConfig {
+	configMap := map[string]*protobufs.AgentConfigFile{}
+	for name, body := range files {
+		configMap[name] = &protobufs.AgentConfigFile{Body: []byte(body)}
+	}
+	return &protobufs.EffectiveConfig{ConfigMap: &protobufs.AgentConfigMap{ConfigMap: configMap}}
+}
+
+func TestGetConfigCoverage(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	for _, id := range []string{"default", "split", "empty", "silent"} {
+		h.createTestAgent(ctx, t, id, nil)
+	}
+	require.NoError(t, h.AgentRepo.UpdateEffectiveConfig(ctx, "default", effectiveConfig(map[string]string{
+		"config.yaml": otelconfig.DefaultOtelConfig,
+	})))
+	// pipelines split over several files are merged
+	require.NoError(t, h.AgentRepo.UpdateEffectiveConfig(ctx, "split", effectiveConfig(map[string]string{
+		"traces.yaml": `
+service:
+  pipelines:
+    traces:
+      exporters: [otlp/primary, otlp/backup]
+    traces/sampled:
+      exporters: [otlphttp]
+`,
+		"metrics.yaml": `
+service:
+  pipelines:
+    metrics:
+      exporters: [prometheusremotewrite]
+`,
+	})))
+	require.NoError(t, h.AgentRepo.UpdateEffectiveConfig(ctx, "empty", effectiveConfig(map[string]string{
+		"default": "none",
+	})))
+
+	resp, err := h.ConfigServer.GetConfigCoverage(ctx, connect.NewRequest(&v1alpha1.GetConfigCoverageRequest{}))
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(&v1alpha1.ConfigCoverageReport{
+		TotalAgents:     4,
+		ReportingAgents: 3,
+		Signals: []*v1alpha1.SignalCoverage{
+			{Signal: "logs", Agents: 1, Pipelines: 1},
+			{Signal: "metrics", Agents: 2, Pipelines: 2},
+			{Signal: "traces", Agents: 2, Pipelines: 3},
+		},
+		Exporters: []*v1alpha1.ComponentUsage{
+			{Type: "debug", Agents: 1},
+			{Type: "otlp", Agents: 1},
+			{Type: "otlphttp", Agents: 1},
+			{Type: "prometheusremotewrite", Agents: 1},
+		},
+		AgentsWithoutPipelines: []string{"empty"},
+		AgentsNotReporting:     []string{"silent"},
+	}, resp.Msg, protocmp.Transform()))
+}
@@ -0,0 +1,74 @@
+package otelconfig_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/otelconfig"
+	"github.com/otelfleet/otelfleet/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestDefaultAssignment(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	h.createTestAgent(ctx, t, "follows-default", nil)
+	h.createTestAgent(ctx, t, "manual", nil)
+	h.createTestAgent(ctx, t, "unmanaged", nil)
+	h.createTestConfig(ctx, t, "logs", "receivers: {}")
+
+	_, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{
+		AgentId:  "follows-default",
+		ConfigId: "logs",
+		Source:   v1alpha1.ConfigSource_CONFIG_SOURCE_DEFAULT,
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "config_id can't be combined with the default")
+
+	_, err = h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{
+		AgentId: "follows-default",
+		Source:  v1alpha1.ConfigSource_CONFIG_SOURCE_DEFAULT,
+	}))
+	require.NoError(t, err)
+	_, err = h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{
+		AgentId:  "manual",
+		ConfigId: "logs",
+	}))
+	require.NoError(t, err)
+
+	resp, err := h.ConfigServer.GetAgentConfig(ctx, connect.NewRequest(&v1alpha1.GetAgentConfigRequest{AgentId: "follows-default"}))
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ConfigSource_CONFIG_SOURCE_DEFAULT, resp.Msg.GetSource())
+	assigned, err := h.AssignedConfigStore.Get(ctx, "follows-default")
+	require.NoError(t, err)
+	assert.Equal(t, otelconfig.DefaultOtelConfig, string(assigned.GetConfig()))
+
+	// deliberately default agents are tracked, unlike unmanaged ones
+	_, err = h.ConfigServer.GetConfigStatus(ctx, connect.NewRequest(&v1alpha1.GetConfigStatusRequest{AgentId: "follows-default"}))
+	require.NoError(t, err)
+	_, err = h.ConfigServer.GetConfigStatus(ctx, connect.NewRequest(&v1alpha1.GetConfigStatusRequest{AgentId: "unmanaged"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// changing the default only reaches the agents that follow it
+	h.notifier.reset()
+	newDefault := &v1alpha1.Config{Config: []byte("receivers: {otlp: {}}")}
+	_, err = h.ConfigServer.SetDefaultConfig(ctx, connect.NewRequest(&v1alpha1.PutConfigRequest{Config: newDefault}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"follows-default"}, h.notifier.getNotifications())
+
+	current, err := h.ConfigServer.GetDefaultConfig(ctx, connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	assert.Equal(t, newDefault.GetConfig(), current.Msg.GetConfig())
+	assigned, err = h.AssignedConfigStore.Get(ctx, "follows-default")
+	require.NoError(t, err)
+	assert.Equal(t, newDefault.GetConfig(), assigned.GetConfig())
+	assignment, err := h.ConfigAssignmentStore.Get(ctx, "follows-default")
+	require.NoError(t, err)
+	assert.Equal(t, util.HashAgentConfigMap(util.ProtoConfigToAgentConfigMap(newDefault)), assignment.GetConfigHash())
+
+	assigned, err = h.AssignedConfigStore.Get(ctx, "manual")
+	require.NoError(t, err)
+	assert.Equal(t, "receivers: {}", string(assigned.GetConfig()))
+}
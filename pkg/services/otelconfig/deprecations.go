@@ -0,0 +1,140 @@
+package otelconfig
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+)
+
+// componentDeprecation records a collector component that was deprecated, and possibly
+// removed, in favour of another one
+type componentDeprecation struct {
+	// kind/type of the deprecated component
+	component string
+	// kind/type of the component to use instead
+	replacement string
+	// collector versions the component was deprecated and removed in, removed is empty
+	// while the component is still shipped
+	deprecated string
+	removed    string
+}
+
+// componentDeprecations are the deprecated components of the core and contrib collector
+// distributions
+var componentDeprecations = []componentDeprecation{
+	{component: "exporters/logging", replacement: "exporters/debug", deprecated: "0.86.0", removed: "0.111.0"},
+	{component: "exporters/jaeger", replacement: "exporters/otlp", deprecated: "0.80.0", removed: "0.85.0"},
+	{component: "exporters/jaeger_thrift", replacement: "exporters/otlp", deprecated: "0.80.0", removed: "0.85.0"},
+}
+
+// findDeprecation returns the deprecation of the component, if it is deprecated
+func findDeprecation(component collectorComponent) (componentDeprecation, bool) {
+	id := component.kind + "/" + componentType(component.id)
+	for _, deprecation := range componentDeprecations {
+		if deprecation.component == id {
+			return deprecation, true
+		}
+	}
+	return componentDeprecation{}, false
+}
+
+// deprecatedIn returns true if the component is deprecated in the collector version, or
+// deprecated at all if the version is unknown
+func (d componentDeprecation) deprecatedIn(version string) bool {
+	return version == "" || compareVersions(version, d.deprecated) >= 0
+}
+
+// removedIn returns true if the component was removed in the collector version, which
+// must be known
+func (d componentDeprecation) removedIn(version string) bool {
+	return version != "" && d.removed != "" && compareVersions(version, d.removed) >= 0
+}
+
+func (d componentDeprecation) String() string {
+	msg := fmt.Sprintf("%s is deprecated since collector %s", d.component, d.deprecated)
+	if d.removed != "" {
+		msg += fmt.Sprintf(" and removed in %s", d.removed)
+	}
+	return msg + fmt.Sprintf(", use %s instead", d.replacement)
+}
+
+// compareVersions compares collector versions such as v0.111.0, ignoring pre-release and
+// build suffixes. Missing or non-numeric parts compare as 0.
+func compareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := range max(len(pa), len(pb)) {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if c := cmp.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []int {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	var parts []int
+	for part := range strings.SplitSeq(version, ".") {
+		n, _ := strconv.Atoi(part)
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// agentCollectorVersion returns the collector version the agent reports, or an empty
+// string if the agent is nil or doesn't report it
+func agentCollectorVersion(agent *agentdomain.Agent) string {
+	if agent == nil {
+		return ""
+	}
+	return agent.Labels()["service.version"]
+}
+
+// RemovedComponentsError is returned when a config uses components removed from the
+// collector version an agent runs
+type RemovedComponentsError struct {
+	Version    string
+	Components []string
+}
+
+func (e *RemovedComponentsError) Error() string {
+	return fmt.Sprintf("config uses components removed in collector %s: %s", e.Version, strings.Join(e.Components, ", "))
+}
+
+// checkRemovedComponents returns a *RemovedComponentsError if the config uses components
+// removed from the collector version the agent reports. Agents that don't report their
+// version are not checked.
+func checkRemovedComponents(agent *agentdomain.Agent, config *v1alpha1.Config) error {
+	version := agentCollectorVersion(agent)
+	if version == "" {
+		return nil
+	}
+	components, err := configComponents(config)
+	if err != nil {
+		// unparsable configs are rejected by the collector anyway
+		return nil
+	}
+	var removed []string
+	for _, component := range components {
+		if deprecation, ok := findDeprecation(component); ok && deprecation.removedIn(version) {
+			removed = append(removed, component.String())
+		}
+	}
+	if len(removed) > 0 {
+		return &RemovedComponentsError{Version: version, Components: removed}
+	}
+	return nil
+}
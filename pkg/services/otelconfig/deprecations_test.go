@@ -0,0 +1,83 @@
+package otelconfig_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const loggingConfig = `receivers:
+  otlp:
+exporters:
+  logging:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [logging]
+`
+
+func TestDeprecatedComponents(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	h.createTestAgent(ctx, t, "old", map[string]string{"service.version": "0.80.0"})
+	h.createTestAgent(ctx, t, "current", map[string]string{"service.version": "v0.100.0"})
+	h.createTestAgent(ctx, t, "recent", map[string]string{"service.version": "0.111.0-rc.1"})
+	h.createTestAgent(ctx, t, "unknown", nil)
+
+	validate := func(agentID string) (bool, []string) {
+		t.Helper()
+		resp, err := h.ConfigServer.ValidateConfigDetailed(ctx, connect.NewRequest(&v1alpha1.ValidateConfigDetailedRequest{
+			Config:  &v1alpha1.Config{Config: []byte(loggingConfig)},
+			AgentId: agentID,
+		}))
+		require.NoError(t, err)
+		var rules []string
+		for _, f := range resp.Msg.GetFindings() {
+			rules = append(rules, f.GetRuleId())
+			if f.GetRuleId() != "unknown-component-type" {
+				assert.Equal(t, uint32(4), f.GetLine(), "findings point at the component")
+			}
+		}
+		return resp.Msg.GetValid(), rules
+	}
+
+	valid, rules := validate("")
+	assert.True(t, valid)
+	assert.Contains(t, rules, "deprecated-component")
+	valid, rules = validate("unknown")
+	assert.True(t, valid)
+	assert.Contains(t, rules, "deprecated-component")
+	valid, rules = validate("old")
+	assert.True(t, valid)
+	assert.NotContains(t, rules, "deprecated-component", "the component isn't deprecated yet in 0.80.0")
+	valid, rules = validate("current")
+	assert.True(t, valid)
+	assert.Contains(t, rules, "deprecated-component")
+	valid, rules = validate("recent")
+	assert.False(t, valid)
+	assert.Contains(t, rules, "removed-component")
+
+	// configs using removed components can't be assigned to the agent
+	h.createTestConfig(ctx, t, "logging", loggingConfig)
+	for _, agentID := range []string{"old", "current", "unknown"} {
+		_, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: agentID, ConfigId: "logging"}))
+		require.NoError(t, err, agentID)
+	}
+	_, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: "recent", ConfigId: "logging"}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	assert.ErrorContains(t, err, "exporters/logging")
+	_, err = h.AssignedConfigStore.Get(ctx, "recent")
+	assert.Error(t, err)
+
+	resp, err := h.ConfigServer.BatchAssignConfig(ctx, connect.NewRequest(&v1alpha1.BatchAssignConfigRequest{
+		AgentIds: []string{"current", "recent"},
+		ConfigId: "logging",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Msg.GetSuccessful())
+	assert.Equal(t, []string{"recent"}, resp.Msg.GetFailedAgentIds())
+}
@@ -0,0 +1,202 @@
+package otelconfig
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SetDistributionStore sets the store of collector distributions, keyed by name@version,
+// enabling the distribution registry
+func (c *ConfigServer) SetDistributionStore(store storage.KeyValue[*v1alpha1.CollectorDistribution]) {
+	c.distributionStore = store
+}
+
+var errDistributionsDisabled = connect.NewError(connect.CodeUnimplemented, errors.New("collector distributions are not enabled"))
+
+func distributionKey(name, version string) string {
+	return name + "@" + version
+}
+
+// validateDistribution checks the distribution's components are given as kind/type
+func validateDistribution(distribution *v1alpha1.CollectorDistribution) error {
+	switch {
+	case distribution.GetName() == "":
+		return errors.New("distribution name must be non-empty")
+	case distribution.GetVersion() == "":
+		return errors.New("distribution version must be non-empty")
+	}
+	for _, component := range distribution.GetComponents() {
+		kind, typ, ok := strings.Cut(component, "/")
+		if !ok || typ == "" || strings.Contains(typ, "/") || !slices.Contains(componentKinds, kind) {
+			return fmt.Errorf("invalid component %q, expected kind/type, e.g. receivers/otlp", component)
+		}
+	}
+	for _, artifact := range distribution.GetArtifacts() {
+		if artifact.GetPlatform() == "" || artifact.GetUrl() == "" {
+			return errors.New("artifacts must have a platform and a url")
+		}
+	}
+	return nil
+}
+
+// missingComponents returns the components, as kind/type, the distribution doesn't include
+func missingComponents(distribution *v1alpha1.CollectorDistribution, components []collectorComponent) []string {
+	var missing []string
+	for _, component := range components {
+		id := component.kind + "/" + componentType(component.id)
+		if !slices.Contains(distribution.GetComponents(), id) && !slices.Contains(missing, id) {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func (c *ConfigServer) getDistribution(ctx context.Context, ref *v1alpha1.CollectorDistributionReference) (*v1alpha1.CollectorDistribution, error) {
+	if ref.GetName() == "" || ref.GetVersion() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("distribution name and version must be non-empty"))
+	}
+	distribution, err := c.distributionStore.Get(ctx, distributionKey(ref.GetName(), ref.GetVersion()))
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("collector distribution not found: %s %s", ref.GetName(), ref.GetVersion()))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return distribution, nil
+}
+
+// MissingComponents returns the components of the config missing from the collector
+// distribution the agent runs, identified by the service.name and service.version it reports.
+// Nothing is reported for agents running distributions that aren't registered.
+func (c *ConfigServer) MissingComponents(ctx context.Context, agent *agentdomain.Agent, configID string) ([]string, error) {
+	if c.distributionStore == nil {
+		return nil, nil
+	}
+	labels := agent.Labels()
+	distribution, err := c.distributionStore.Get(ctx, distributionKey(labels["service.name"], labels["service.version"]))
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get collector distribution: %w", err)
+	}
+	config, err := c.configStore.Get(ctx, configID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config %s: %w", configID, err)
+	}
+	components, err := configComponents(config)
+	if err != nil {
+		return nil, err
+	}
+	return missingComponents(distribution, components), nil
+}
+
+func (c *ConfigServer) PutCollectorDistribution(ctx context.Context, req *connect.Request[v1alpha1.PutCollectorDistributionRequest]) (*connect.Response[v1alpha1.CollectorDistribution], error) {
+	if c.distributionStore == nil {
+		return nil, errDistributionsDisabled
+	}
+	distribution := req.Msg.GetDistribution()
+	if err := validateDistribution(distribution); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	key := distributionKey(distribution.GetName(), distribution.GetVersion())
+	existing, err := c.distributionStore.Get(ctx, key)
+	if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	slices.Sort(distribution.Components)
+	distribution.Components = slices.Compact(distribution.Components)
+	distribution.Audit = existing.GetAudit().Touched(auth.SubjectFromContext(ctx), time.Now())
+	if err := c.distributionStore.Put(ctx, key, distribution); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	events.RecordChange(ctx, c.eventRecorder, events.TypeDistributionUpdated, fmt.Sprintf("collector distribution %s %s updated", distribution.GetName(), distribution.GetVersion()), map[string]string{
+		"distribution": distribution.GetName(),
+		"version":      distribution.GetVersion(),
+	})
+	return connect.NewResponse(distribution), nil
+}
+
+func (c *ConfigServer) GetCollectorDistribution(ctx context.Context, req *connect.Request[v1alpha1.CollectorDistributionReference]) (*connect.Response[v1alpha1.CollectorDistribution], error) {
+	if c.distributionStore == nil {
+		return nil, errDistributionsDisabled
+	}
+	distribution, err := c.getDistribution(ctx, req.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(distribution), nil
+}
+
+func (c *ConfigServer) DeleteCollectorDistribution(ctx context.Context, req *connect.Request[v1alpha1.CollectorDistributionReference]) (*connect.Response[emptypb.Empty], error) {
+	if _, err := c.GetCollectorDistribution(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := c.distributionStore.Delete(ctx, distributionKey(req.Msg.GetName(), req.Msg.GetVersion())); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	events.RecordChange(ctx, c.eventRecorder, events.TypeDistributionDeleted, fmt.Sprintf("collector distribution %s %s deleted", req.Msg.GetName(), req.Msg.GetVersion()), map[string]string{
+		"distribution": req.Msg.GetName(),
+		"version":      req.Msg.GetVersion(),
+	})
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (c *ConfigServer) ListCollectorDistributions(ctx context.Context, req *connect.Request[v1alpha1.ListCollectorDistributionsRequest]) (*connect.Response[v1alpha1.ListCollectorDistributionsResponse], error) {
+	if c.distributionStore == nil {
+		return nil, errDistributionsDisabled
+	}
+	distributions, err := c.distributionStore.List(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if name := req.Msg.GetName(); name != "" {
+		distributions = slices.DeleteFunc(distributions, func(d *v1alpha1.CollectorDistribution) bool {
+			return d.GetName() != name
+		})
+	}
+	slices.SortFunc(distributions, func(a, b *v1alpha1.CollectorDistribution) int {
+		return cmp.Or(strings.Compare(a.GetName(), b.GetName()), strings.Compare(a.GetVersion(), b.GetVersion()))
+	})
+	return connect.NewResponse(&v1alpha1.ListCollectorDistributionsResponse{Distributions: distributions}), nil
+}
+
+func (c *ConfigServer) CheckConfigCompatibility(ctx context.Context, req *connect.Request[v1alpha1.CheckConfigCompatibilityRequest]) (*connect.Response[v1alpha1.ConfigCompatibility], error) {
+	if c.distributionStore == nil {
+		return nil, errDistributionsDisabled
+	}
+	if req.Msg.GetConfigId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("config_id must be non-empty"))
+	}
+	distribution, err := c.getDistribution(ctx, req.Msg.GetDistribution())
+	if err != nil {
+		return nil, err
+	}
+	config, err := c.configStore.Get(ctx, req.Msg.GetConfigId())
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("config not found: %s", req.Msg.GetConfigId()))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	components, err := configComponents(config)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	missing := missingComponents(distribution, components)
+	return connect.NewResponse(&v1alpha1.ConfigCompatibility{
+		Compatible:        len(missing) == 0,
+		MissingComponents: missing,
+	}), nil
+}
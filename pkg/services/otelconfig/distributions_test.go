@@ -0,0 +1,75 @@
+package otelconfig_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorDistributions(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	h.createTestConfig(ctx, t, "kafka", "receivers: {otlp: {}, otlp/internal: {}}\nexporters: {kafka: {}, otlp: {}}")
+
+	put := func(distribution *v1alpha1.CollectorDistribution) error {
+		t.Helper()
+		_, err := h.ConfigServer.PutCollectorDistribution(ctx, connect.NewRequest(&v1alpha1.PutCollectorDistributionRequest{Distribution: distribution}))
+		return err
+	}
+	err := put(&v1alpha1.CollectorDistribution{Name: "otelcol", Version: "0.120.0", Components: []string{"otlp"}})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "components must have a kind")
+	err = put(&v1alpha1.CollectorDistribution{Name: "otelcol"})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	require.NoError(t, put(&v1alpha1.CollectorDistribution{
+		Name:       "otelcol",
+		Version:    "0.120.0",
+		Components: []string{"receivers/otlp", "exporters/otlp", "receivers/otlp"},
+		Artifacts: []*v1alpha1.DistributionArtifact{{
+			Platform: "linux/amd64",
+			Url:      "https://example.com/otelcol_0.120.0_linux_amd64.tar.gz",
+			Sha256:   "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		}},
+	}))
+	require.NoError(t, put(&v1alpha1.CollectorDistribution{
+		Name:       "otelcol-contrib",
+		Version:    "0.120.0",
+		Components: []string{"receivers/otlp", "exporters/otlp", "exporters/kafka"},
+	}))
+
+	got, err := h.ConfigServer.GetCollectorDistribution(ctx, connect.NewRequest(&v1alpha1.CollectorDistributionReference{Name: "otelcol", Version: "0.120.0"}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"exporters/otlp", "receivers/otlp"}, got.Msg.GetComponents())
+	require.Len(t, got.Msg.GetArtifacts(), 1)
+	assert.NotNil(t, got.Msg.GetAudit().GetCreatedAt())
+
+	list, err := h.ConfigServer.ListCollectorDistributions(ctx, connect.NewRequest(&v1alpha1.ListCollectorDistributionsRequest{Name: "otelcol-contrib"}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.GetDistributions(), 1)
+	assert.Equal(t, "otelcol-contrib", list.Msg.GetDistributions()[0].GetName())
+
+	check := func(name string) *v1alpha1.ConfigCompatibility {
+		t.Helper()
+		resp, err := h.ConfigServer.CheckConfigCompatibility(ctx, connect.NewRequest(&v1alpha1.CheckConfigCompatibilityRequest{
+			ConfigId:     "kafka",
+			Distribution: &v1alpha1.CollectorDistributionReference{Name: name, Version: "0.120.0"},
+		}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+	core := check("otelcol")
+	assert.False(t, core.GetCompatible())
+	assert.Equal(t, []string{"exporters/kafka"}, core.GetMissingComponents())
+	assert.True(t, check("otelcol-contrib").GetCompatible())
+
+	_, err = h.ConfigServer.DeleteCollectorDistribution(ctx, connect.NewRequest(&v1alpha1.CollectorDistributionReference{Name: "otelcol", Version: "0.120.0"}))
+	require.NoError(t, err)
+	_, err = h.ConfigServer.CheckConfigCompatibility(ctx, connect.NewRequest(&v1alpha1.CheckConfigCompatibilityRequest{
+		ConfigId:     "kafka",
+		Distribution: &v1alpha1.CollectorDistributionReference{Name: "otelcol", Version: "0.120.0"},
+	}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
@@ -0,0 +1,156 @@
+package otelconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// editSessionTTL is how long an edit session can be saved after it began or was last saved
+	editSessionTTL = 24 * time.Hour
+	// editSessionPruneInterval is how often expired edit sessions are deleted
+	editSessionPruneInterval = time.Hour
+)
+
+// SetEditSessionStore enables merging concurrent edits of configs through edit sessions
+func (c *ConfigServer) SetEditSessionStore(store storage.KeyValue[*v1alpha1.ConfigEditSession]) {
+	c.editSessionStore = store
+}
+
+var errEditSessionsDisabled = connect.NewError(connect.CodeUnimplemented, errors.New("config edit sessions are not enabled"))
+
+// BeginConfigEdit records the current revision of a config as the base of an edit session.
+// The config doesn't need to exist, for sessions creating it.
+func (c *ConfigServer) BeginConfigEdit(ctx context.Context, req *connect.Request[v1alpha1.ConfigReference]) (*connect.Response[v1alpha1.ConfigEditSession], error) {
+	if c.editSessionStore == nil {
+		return nil, errEditSessionsDisabled
+	}
+	configID := req.Msg.GetId()
+	if configID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("config id must be non-empty"))
+	}
+	base, err := c.configStore.Get(ctx, configID)
+	if grpcutil.IsErrorNotFound(err) {
+		base = nil
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get config: %w", err))
+	}
+	session := &v1alpha1.ConfigEditSession{
+		Id:        util.NewUUID(),
+		ConfigId:  configID,
+		Base:      base,
+		ExpiresAt: timestamppb.New(time.Now().Add(editSessionTTL)),
+	}
+	if err := c.editSessionStore.Put(ctx, session.GetId(), session); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to store edit session: %w", err))
+	}
+	return connect.NewResponse(session), nil
+}
+
+// SaveConfigEdit saves the config edited in a session. Changes made to the config since
+// the session began are merged with the edited config, and if they conflict nothing is
+// saved and the conflicts are returned instead.
+func (c *ConfigServer) SaveConfigEdit(ctx context.Context, req *connect.Request[v1alpha1.SaveConfigEditRequest]) (*connect.Response[v1alpha1.SaveConfigEditResult], error) {
+	if c.editSessionStore == nil {
+		return nil, errEditSessionsDisabled
+	}
+	configID := req.Msg.GetRef().GetId()
+	switch {
+	case configID == "":
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("config id must be non-empty"))
+	case req.Msg.GetSessionId() == "":
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("session_id must be non-empty"))
+	case req.Msg.GetConfig() == nil:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("config must be non-empty"))
+	}
+
+	c.editMu.Lock()
+	defer c.editMu.Unlock()
+	session, err := c.editSessionStore.Get(ctx, req.Msg.GetSessionId())
+	if grpcutil.IsErrorNotFound(err) || (err == nil && session.GetExpiresAt().AsTime().Before(time.Now())) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("edit session %s not found or expired", req.Msg.GetSessionId()))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get edit session: %w", err))
+	}
+	if session.GetConfigId() != configID {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("edit session %s is for config %s", session.GetId(), session.GetConfigId()))
+	}
+	current, err := c.configStore.Get(ctx, configID)
+	if grpcutil.IsErrorNotFound(err) {
+		current = nil
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get config: %w", err))
+	}
+
+	edited := req.Msg.GetConfig()
+	result := &v1alpha1.SaveConfigEditResult{}
+	if !bytes.Equal(session.GetBase().GetConfig(), current.GetConfig()) && !bytes.Equal(edited.GetConfig(), current.GetConfig()) {
+		merged, conflicts, err := mergeConfigs(session.GetBase().GetConfig(), edited.GetConfig(), current.GetConfig())
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		if len(conflicts) > 0 {
+			// the editor has now seen the current config, so that its resolution can be saved
+			if err := c.advanceEditSession(ctx, session, current); err != nil {
+				return nil, err
+			}
+			result.Config = current
+			result.Conflicts = conflicts
+			return connect.NewResponse(result), nil
+		}
+		edited = proto.CloneOf(edited)
+		edited.Config = merged
+		result.Merged = true
+	}
+	if edited.GetMetadata() == nil && current.GetMetadata() != nil {
+		edited = proto.CloneOf(edited)
+		edited.Metadata = proto.CloneOf(current.GetMetadata())
+	}
+	if err := c.putConfig(ctx, configID, edited); err != nil {
+		return nil, err
+	}
+	if err := c.advanceEditSession(ctx, session, edited); err != nil {
+		return nil, err
+	}
+	result.Saved = true
+	result.Config = edited
+	return connect.NewResponse(result), nil
+}
+
+// advanceEditSession makes config the base of the session, and extends its expiry
+func (c *ConfigServer) advanceEditSession(ctx context.Context, session *v1alpha1.ConfigEditSession, config *v1alpha1.Config) error {
+	session.Base = config
+	session.ExpiresAt = timestamppb.New(time.Now().Add(editSessionTTL))
+	if err := c.editSessionStore.Put(ctx, session.GetId(), session); err != nil {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to store edit session: %w", err))
+	}
+	return nil
+}
+
+// pruneEditSessions deletes the edit sessions that expired before now
+func (c *ConfigServer) pruneEditSessions(ctx context.Context, now time.Time) error {
+	sessions, err := c.editSessionStore.List(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, session := range sessions {
+		if session.GetExpiresAt().AsTime().Before(now) {
+			if err := c.editSessionStore.Delete(ctx, session.GetId()); err != nil && !grpcutil.IsErrorNotFound(err) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
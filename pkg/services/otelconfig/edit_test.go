@@ -0,0 +1,172 @@
+package otelconfig_test
+
+import (
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const editBase = `# shared pipeline
+receivers:
+  otlp: {}
+processors:
+  batch:
+    timeout: 1s
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug]
+`
+
+func TestConfigEditSessions(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+
+	begin := func(configID string) string {
+		t.Helper()
+		resp, err := h.ConfigServer.BeginConfigEdit(ctx, connect.NewRequest(&v1alpha1.ConfigReference{Id: configID}))
+		require.NoError(t, err)
+		return resp.Msg.GetId()
+	}
+	save := func(configID, sessionID, config string) *v1alpha1.SaveConfigEditResult {
+		t.Helper()
+		resp, err := h.ConfigServer.SaveConfigEdit(ctx, connect.NewRequest(&v1alpha1.SaveConfigEditRequest{
+			Ref:       &v1alpha1.ConfigReference{Id: configID},
+			SessionId: sessionID,
+			Config:    &v1alpha1.Config{Config: []byte(config)},
+		}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+	current := func(configID string) string {
+		t.Helper()
+		config, err := h.ConfigStore.Get(ctx, configID)
+		require.NoError(t, err)
+		return string(config.GetConfig())
+	}
+
+	t.Run("saves edits without concurrent changes as is", func(t *testing.T) {
+		session := begin("new")
+		result := save("new", session, editBase)
+		assert.True(t, result.GetSaved())
+		assert.False(t, result.GetMerged())
+		assert.Equal(t, editBase, current("new"))
+	})
+
+	t.Run("merges concurrent changes to different values", func(t *testing.T) {
+		h.createTestConfig(ctx, t, "merge", editBase)
+		alice, bob := begin("merge"), begin("merge")
+
+		require.True(t, save("merge", bob, `# shared pipeline
+receivers:
+  otlp: {}
+  prometheus: {}
+processors:
+  batch:
+    timeout: 1s
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, prometheus]
+      processors: [batch]
+      exporters: [debug]
+`).GetSaved())
+
+		result := save("merge", alice, `# shared pipeline
+receivers:
+  otlp: {}
+processors:
+  batch:
+    timeout: 5s # slower
+exporters:
+  debug: {}
+  otlp: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug, otlp]
+`)
+		require.True(t, result.GetSaved())
+		assert.True(t, result.GetMerged())
+		assert.Empty(t, result.GetConflicts())
+		assert.Equal(t, `# shared pipeline
+receivers:
+  otlp: {}
+  prometheus: {}
+processors:
+  batch:
+    timeout: 5s # slower
+exporters:
+  debug: {}
+  otlp: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp, prometheus]
+      processors: [batch]
+      exporters: [debug, otlp]
+`, current("merge"))
+	})
+
+	t.Run("returns conflicts instead of overwriting concurrent changes", func(t *testing.T) {
+		h.createTestConfig(ctx, t, "conflict", editBase)
+		alice, bob := begin("conflict"), begin("conflict")
+		bobs := editBase + "extensions:\n  health_check: {}\n"
+		bobs = replaceOnce(t, bobs, "timeout: 1s", "timeout: 2s")
+		require.True(t, save("conflict", bob, bobs).GetSaved())
+
+		alices := replaceOnce(t, editBase, "timeout: 1s", "timeout: 5s")
+		result := save("conflict", alice, alices)
+		assert.False(t, result.GetSaved())
+		require.Len(t, result.GetConflicts(), 1)
+		conflict := result.GetConflicts()[0]
+		assert.Equal(t, "processors.batch.timeout", conflict.GetPath())
+		assert.Equal(t, "1s", conflict.GetBase())
+		assert.Equal(t, "5s", conflict.GetOurs())
+		assert.Equal(t, "2s", conflict.GetTheirs())
+		assert.Equal(t, bobs, string(result.GetConfig().GetConfig()))
+		assert.Equal(t, bobs, current("conflict"))
+
+		// the session continues from the config the conflicts were resolved against
+		resolved := replaceOnce(t, bobs, "timeout: 2s", "timeout: 5s")
+		result = save("conflict", alice, resolved)
+		assert.True(t, result.GetSaved())
+		assert.False(t, result.GetMerged())
+		assert.Equal(t, resolved, current("conflict"))
+	})
+
+	t.Run("rejects unknown sessions and sessions of other configs", func(t *testing.T) {
+		session := begin("new")
+		_, err := h.ConfigServer.SaveConfigEdit(ctx, connect.NewRequest(&v1alpha1.SaveConfigEditRequest{
+			Ref:       &v1alpha1.ConfigReference{Id: "merge"},
+			SessionId: session,
+			Config:    &v1alpha1.Config{Config: []byte(editBase)},
+		}))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+		_, err = h.ConfigServer.SaveConfigEdit(ctx, connect.NewRequest(&v1alpha1.SaveConfigEditRequest{
+			Ref:       &v1alpha1.ConfigReference{Id: "new"},
+			SessionId: "missing",
+			Config:    &v1alpha1.Config{Config: []byte(editBase)},
+		}))
+		assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	})
+}
+
+func replaceOnce(t *testing.T, s, old, new string) string {
+	t.Helper()
+	replaced := strings.Replace(s, old, new, 1)
+	require.NotEqual(t, s, replaced)
+	return replaced
+}
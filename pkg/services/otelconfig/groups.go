@@ -0,0 +1,177 @@
+package otelconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// SetGroupStore sets the store of agent groups, keyed by group ID, enabling agent groups
+func (c *ConfigServer) SetGroupStore(store storage.KeyValue[*v1alpha1.AgentGroup]) {
+	c.groupStore = store
+}
+
+var errGroupsDisabled = connect.NewError(connect.CodeUnimplemented, errors.New("agent groups are not enabled"))
+
+// groupPolicyPrefix prefixes the IDs of the assignment policies of agent groups
+const groupPolicyPrefix = "group:"
+
+// groupPolicy returns the assignment policy assigning the config of the group to its agents
+func groupPolicy(group *v1alpha1.AgentGroup) *v1alpha1.AssignmentPolicy {
+	return &v1alpha1.AssignmentPolicy{
+		Id:                 groupPolicyPrefix + group.GetId(),
+		Selector:           group.GetSelector(),
+		SelectorExpression: group.GetSelectorExpression(),
+		AgentIds:           group.GetAgentIds(),
+		ConfigId:           group.GetConfigId(),
+		Priority:           group.GetPriority(),
+	}
+}
+
+// describePolicy names an assignment policy in messages, which may be the policy of a group
+func describePolicy(policyID string) string {
+	if group, ok := strings.CutPrefix(policyID, groupPolicyPrefix); ok {
+		return "group " + group
+	}
+	return "policy " + policyID
+}
+
+func validateGroup(group *v1alpha1.AgentGroup) error {
+	switch {
+	case group.GetId() == "":
+		return errors.New("group id must be non-empty")
+	case len(group.GetSelector()) == 0 && group.GetSelectorExpression() == "" && len(group.GetAgentIds()) == 0:
+		return errors.New("selector, selector_expression or agent_ids must be non-empty")
+	case slices.Contains(group.GetAgentIds(), ""):
+		return errors.New("agent_ids must not contain empty IDs")
+	}
+	_, err := agentdomain.ParseSelector(group.GetSelectorExpression())
+	return err
+}
+
+func (c *ConfigServer) CreateGroup(ctx context.Context, req *connect.Request[v1alpha1.CreateGroupRequest]) (*connect.Response[v1alpha1.AgentGroup], error) {
+	return c.putGroup(ctx, req.Msg.GetGroup(), false)
+}
+
+func (c *ConfigServer) UpdateGroup(ctx context.Context, req *connect.Request[v1alpha1.UpdateGroupRequest]) (*connect.Response[v1alpha1.AgentGroup], error) {
+	return c.putGroup(ctx, req.Msg.GetGroup(), true)
+}
+
+// putGroup stores a group, which must already exist for updates and must not for creates,
+// and assigns its config to its agents
+func (c *ConfigServer) putGroup(ctx context.Context, group *v1alpha1.AgentGroup, update bool) (*connect.Response[v1alpha1.AgentGroup], error) {
+	if c.groupStore == nil {
+		return nil, errGroupsDisabled
+	}
+	if err := validateGroup(group); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if group.GetConfigId() != "" {
+		if _, err := c.configStore.Get(ctx, group.GetConfigId()); err != nil {
+			if grpcutil.IsErrorNotFound(err) {
+				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("config not found: %s", group.GetConfigId()))
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	existing, err := c.groupStore.Get(ctx, group.GetId())
+	switch {
+	case err != nil && !grpcutil.IsErrorNotFound(err):
+		return nil, connect.NewError(connect.CodeInternal, err)
+	case err != nil && update:
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent group not found: %s", group.GetId()))
+	case err == nil && !update:
+		return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("agent group already exists: %s", group.GetId()))
+	}
+	slices.Sort(group.AgentIds)
+	group.AgentIds = slices.Compact(group.AgentIds)
+	group.Audit = existing.GetAudit().Touched(auth.SubjectFromContext(ctx), time.Now())
+	if err := c.groupStore.Put(ctx, group.GetId(), group); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	events.RecordChange(ctx, c.eventRecorder, events.TypeGroupUpdated, fmt.Sprintf("agent group %s updated", group.GetId()), map[string]string{
+		"group_id":  group.GetId(),
+		"config_id": group.GetConfigId(),
+	})
+	// the group is stored, agents that failed to update are picked up by the next evaluation
+	if err := c.evaluatePolicies(ctx); err != nil {
+		c.logger.With("err", err, "group_id", group.GetId()).ErrorContext(ctx, "failed to apply assignment policies")
+	}
+	return connect.NewResponse(group), nil
+}
+
+func (c *ConfigServer) GetGroup(ctx context.Context, req *connect.Request[v1alpha1.AgentGroupReference]) (*connect.Response[v1alpha1.AgentGroup], error) {
+	if c.groupStore == nil {
+		return nil, errGroupsDisabled
+	}
+	if req.Msg.GetId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("group id must be non-empty"))
+	}
+	group, err := c.groupStore.Get(ctx, req.Msg.GetId())
+	if err != nil {
+		if grpcutil.IsErrorNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent group not found: %s", req.Msg.GetId()))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(group), nil
+}
+
+// DeleteGroup deletes a group, its config stops applying to its agents
+func (c *ConfigServer) DeleteGroup(ctx context.Context, req *connect.Request[v1alpha1.AgentGroupReference]) (*connect.Response[emptypb.Empty], error) {
+	if _, err := c.GetGroup(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := c.groupStore.Delete(ctx, req.Msg.GetId()); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	events.RecordChange(ctx, c.eventRecorder, events.TypeGroupDeleted, fmt.Sprintf("agent group %s deleted", req.Msg.GetId()), map[string]string{
+		"group_id": req.Msg.GetId(),
+	})
+	if err := c.evaluatePolicies(ctx); err != nil {
+		c.logger.With("err", err, "group_id", req.Msg.GetId()).ErrorContext(ctx, "failed to apply assignment policies")
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (c *ConfigServer) ListGroups(ctx context.Context, req *connect.Request[v1alpha1.ListGroupsRequest]) (*connect.Response[v1alpha1.ListGroupsResponse], error) {
+	if c.groupStore == nil {
+		return nil, errGroupsDisabled
+	}
+	groups, err := c.groupStore.List(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	agents, err := c.agentRepo.List(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	slices.SortFunc(groups, func(a, b *v1alpha1.AgentGroup) int {
+		return strings.Compare(a.GetId(), b.GetId())
+	})
+	resp := &v1alpha1.ListGroupsResponse{
+		Groups:      groups,
+		AgentCounts: map[string]int32{},
+	}
+	for _, group := range groups {
+		policy := groupPolicy(group)
+		for _, agent := range agents {
+			if policyMatches(agent, policy) {
+				resp.AgentCounts[group.GetId()]++
+			}
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,96 @@
+package otelconfig_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentGroups(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	h.createTestAgent(ctx, t, "edge-1", map[string]string{"site": "edge"})
+	h.createTestAgent(ctx, t, "core-1", map[string]string{"site": "core"})
+	h.createTestConfig(ctx, t, "edge", "receivers: {}")
+	h.createTestConfig(ctx, t, "canary", "receivers: {otlp: {}}")
+
+	assignedConfig := func(agentID string) string {
+		t.Helper()
+		assignment, err := h.ConfigAssignmentStore.Get(ctx, agentID)
+		if grpcutil.IsErrorNotFound(err) {
+			return ""
+		}
+		require.NoError(t, err)
+		return assignment.GetConfigId()
+	}
+
+	_, err := h.ConfigServer.CreateGroup(ctx, connect.NewRequest(&v1alpha1.CreateGroupRequest{
+		Group: &v1alpha1.AgentGroup{Id: "empty"},
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "groups must have a selector or members")
+	_, err = h.ConfigServer.UpdateGroup(ctx, connect.NewRequest(&v1alpha1.UpdateGroupRequest{
+		Group: &v1alpha1.AgentGroup{Id: "edge", Selector: map[string]string{"site": "edge"}},
+	}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	created, err := h.ConfigServer.CreateGroup(ctx, connect.NewRequest(&v1alpha1.CreateGroupRequest{
+		Group: &v1alpha1.AgentGroup{Id: "edge", Selector: map[string]string{"site": "edge"}, ConfigId: "edge"},
+	}))
+	require.NoError(t, err)
+	assert.NotNil(t, created.Msg.GetAudit().GetCreatedAt())
+	_, err = h.ConfigServer.CreateGroup(ctx, connect.NewRequest(&v1alpha1.CreateGroupRequest{
+		Group: &v1alpha1.AgentGroup{Id: "edge", Selector: map[string]string{"site": "edge"}},
+	}))
+	assert.Equal(t, connect.CodeAlreadyExists, connect.CodeOf(err))
+	assert.Equal(t, "edge", assignedConfig("edge-1"))
+	assert.Empty(t, assignedConfig("core-1"))
+	assignment, err := h.ConfigAssignmentStore.Get(ctx, "edge-1")
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY, assignment.GetSource())
+	assert.Equal(t, "group:edge", assignment.GetPolicyId())
+
+	// agents joining later inherit the config of the group
+	h.createTestAgent(ctx, t, "edge-2", map[string]string{"site": "edge"})
+	require.NoError(t, h.ConfigServer.EvaluateAgentPolicies(ctx, "edge-2"))
+	assert.Equal(t, "edge", assignedConfig("edge-2"))
+
+	// explicit members are in the group regardless of their labels, and the higher
+	// priority group wins
+	_, err = h.ConfigServer.CreateGroup(ctx, connect.NewRequest(&v1alpha1.CreateGroupRequest{
+		Group: &v1alpha1.AgentGroup{Id: "canary", AgentIds: []string{"core-1", "edge-1", "core-1"}, ConfigId: "canary", Priority: 10},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "canary", assignedConfig("edge-1"))
+	assert.Equal(t, "canary", assignedConfig("core-1"))
+	assert.Equal(t, "edge", assignedConfig("edge-2"))
+
+	list, err := h.ConfigServer.ListGroups(ctx, connect.NewRequest(&v1alpha1.ListGroupsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.GetGroups(), 2)
+	assert.Equal(t, "canary", list.Msg.GetGroups()[0].GetId())
+	assert.Equal(t, []string{"core-1", "edge-1"}, list.Msg.GetGroups()[0].GetAgentIds())
+	assert.Equal(t, map[string]int32{"canary": 2, "edge": 2}, list.Msg.GetAgentCounts())
+
+	// manual assignments take precedence over groups, like over assignment policies
+	_, err = h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: "core-1", ConfigId: "edge"}))
+	require.NoError(t, err)
+	assert.Equal(t, "edge", assignedConfig("core-1"))
+
+	// groups without a config only group agents
+	_, err = h.ConfigServer.UpdateGroup(ctx, connect.NewRequest(&v1alpha1.UpdateGroupRequest{
+		Group: &v1alpha1.AgentGroup{Id: "canary", AgentIds: []string{"core-1", "edge-1"}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "edge", assignedConfig("edge-1"))
+
+	_, err = h.ConfigServer.DeleteGroup(ctx, connect.NewRequest(&v1alpha1.AgentGroupReference{Id: "edge"}))
+	require.NoError(t, err)
+	assert.Empty(t, assignedConfig("edge-1"))
+	assert.Empty(t, assignedConfig("edge-2"))
+	_, err = h.ConfigServer.GetGroup(ctx, connect.NewRequest(&v1alpha1.AgentGroupReference{Id: "edge"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
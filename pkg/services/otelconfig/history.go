@@ -0,0 +1,179 @@
+package otelconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxConfigHistory is the number of config history entries kept per agent
+const maxConfigHistory = 200
+
+// SetConfigHistoryStore sets the store of the config history of each agent, keyed by agent
+// ID, enabling GetAgentConfigAtTime
+func (c *ConfigServer) SetConfigHistoryStore(store storage.KeyValue[*v1alpha1.AgentConfigHistory]) {
+	c.configHistoryStore = store
+}
+
+var errConfigHistoryDisabled = connect.NewError(connect.CodeUnimplemented, errors.New("config history is not enabled"))
+
+// recordAssigned appends an assignment and the config version it assigned to the config
+// history of its agent. Failures are logged, the assignment stands regardless.
+func (c *ConfigServer) recordAssigned(ctx context.Context, assignment *v1alpha1.ConfigAssignment, config *v1alpha1.Config) {
+	c.recordConfigHistory(ctx, assignment.GetAgentId(), &v1alpha1.AgentConfigHistoryEntry{
+		Time:       timestamppb.Now(),
+		Change:     v1alpha1.AgentConfigChange_AGENT_CONFIG_CHANGE_ASSIGNED,
+		Assignment: assignment,
+		Config:     config,
+	})
+}
+
+// recordUnassigned appends the removal of an agent's assignment to its config history
+func (c *ConfigServer) recordUnassigned(ctx context.Context, agentID string) {
+	c.recordConfigHistory(ctx, agentID, &v1alpha1.AgentConfigHistoryEntry{
+		Time:   timestamppb.Now(),
+		Change: v1alpha1.AgentConfigChange_AGENT_CONFIG_CHANGE_UNASSIGNED,
+	})
+}
+
+// recordReported appends a config an agent reported applying or failing to apply to its
+// config history, unless it is the same as the agent's previous report
+func (c *ConfigServer) recordReported(ctx context.Context, agentID string, change v1alpha1.AgentConfigChange, hash []byte, errorMessage string) {
+	c.recordConfigHistory(ctx, agentID, &v1alpha1.AgentConfigHistoryEntry{
+		Time:         timestamppb.Now(),
+		Change:       change,
+		ConfigHash:   hash,
+		ErrorMessage: errorMessage,
+	})
+}
+
+func (c *ConfigServer) recordConfigHistory(ctx context.Context, agentID string, entry *v1alpha1.AgentConfigHistoryEntry) {
+	if c.configHistoryStore == nil {
+		return
+	}
+	if err := c.appendConfigHistory(ctx, agentID, entry); err != nil {
+		c.logger.With("agent_id", agentID, "change", entry.GetChange().String(), "err", err).ErrorContext(ctx, "failed to record config history")
+	}
+}
+
+func (c *ConfigServer) appendConfigHistory(ctx context.Context, agentID string, entry *v1alpha1.AgentConfigHistoryEntry) error {
+	c.historyMu.Lock()
+	defer c.historyMu.Unlock()
+
+	history, err := c.configHistoryStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		history = &v1alpha1.AgentConfigHistory{}
+	} else if err != nil {
+		return err
+	}
+	if isReport(entry.GetChange()) && repeatsLastReport(history, entry) {
+		return nil
+	}
+	history.Entries = append(history.Entries, entry)
+	if n := len(history.Entries) - maxConfigHistory; n > 0 {
+		history.Entries = history.Entries[n:]
+		history.Truncated = true
+	}
+	return c.configHistoryStore.Put(ctx, agentID, history)
+}
+
+func isReport(change v1alpha1.AgentConfigChange) bool {
+	return change == v1alpha1.AgentConfigChange_AGENT_CONFIG_CHANGE_APPLIED ||
+		change == v1alpha1.AgentConfigChange_AGENT_CONFIG_CHANGE_FAILED
+}
+
+// repeatsLastReport reports whether the last report in the history is the same as entry,
+// agents repeat their remote config status in every message
+func repeatsLastReport(history *v1alpha1.AgentConfigHistory, entry *v1alpha1.AgentConfigHistoryEntry) bool {
+	for i := len(history.GetEntries()) - 1; i >= 0; i-- {
+		last := history.GetEntries()[i]
+		if !isReport(last.GetChange()) {
+			continue
+		}
+		return last.GetChange() == entry.GetChange() &&
+			bytes.Equal(last.GetConfigHash(), entry.GetConfigHash()) &&
+			last.GetErrorMessage() == entry.GetErrorMessage()
+	}
+	return false
+}
+
+// GetAgentConfigAtTime reconstructs the config assigned to an agent, and the last config it
+// reported applying, at a past time
+func (c *ConfigServer) GetAgentConfigAtTime(ctx context.Context, req *connect.Request[v1alpha1.GetAgentConfigAtTimeRequest]) (*connect.Response[v1alpha1.AgentConfigAtTime], error) {
+	agentID := req.Msg.GetAgentId()
+	if agentID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("agent_id must be non-empty"))
+	}
+	if req.Msg.GetTime() == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("time must be set"))
+	}
+	if c.configHistoryStore == nil {
+		return nil, errConfigHistoryDisabled
+	}
+
+	history, err := c.configHistoryStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		// the history outlives deleted agents, only agents without any are unknown
+		if _, err := c.agentRepo.Get(ctx, agentID); errors.Is(err, agentdomain.ErrAgentNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+		} else if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		history = &v1alpha1.AgentConfigHistory{}
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(configAtTime(agentID, history, req.Msg.GetTime().AsTime())), nil
+}
+
+// configAtTime replays the history of an agent up to t
+func configAtTime(agentID string, history *v1alpha1.AgentConfigHistory, t time.Time) *v1alpha1.AgentConfigAtTime {
+	res := &v1alpha1.AgentConfigAtTime{
+		AgentId: agentID,
+		Time:    timestamppb.New(t),
+	}
+	entries := history.GetEntries()
+	if len(entries) == 0 || entries[0].GetTime().AsTime().After(t) {
+		return res
+	}
+	// dropped entries may have assigned a config, which is only known again once replaying
+	// an assignment change
+	known := !history.GetTruncated()
+	// config IDs of the assignments replayed, by config hash
+	assigned := map[string]string{}
+	for _, entry := range entries {
+		if entry.GetTime().AsTime().After(t) {
+			break
+		}
+		switch entry.GetChange() {
+		case v1alpha1.AgentConfigChange_AGENT_CONFIG_CHANGE_ASSIGNED:
+			res.Assignment = entry.GetAssignment()
+			res.Config = entry.GetConfig()
+			assigned[string(entry.GetAssignment().GetConfigHash())] = entry.GetAssignment().GetConfigId()
+			known = true
+		case v1alpha1.AgentConfigChange_AGENT_CONFIG_CHANGE_UNASSIGNED:
+			res.Assignment = nil
+			res.Config = nil
+			known = true
+		case v1alpha1.AgentConfigChange_AGENT_CONFIG_CHANGE_APPLIED:
+			res.Applied = &v1alpha1.AppliedAgentConfig{
+				ConfigHash: entry.GetConfigHash(),
+				AppliedAt:  entry.GetTime(),
+			}
+		}
+	}
+	if res.Applied != nil {
+		res.Applied.ConfigId = assigned[string(res.Applied.GetConfigHash())]
+	}
+	res.Complete = known
+	return res
+}
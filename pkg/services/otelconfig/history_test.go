@@ -0,0 +1,118 @@
+package otelconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestGetAgentConfigAtTime(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	h.createTestAgent(ctx, t, "agent-1", nil)
+	first := h.createTestConfig(ctx, t, "first", "receivers: {otlp: {}}")
+	second := h.createTestConfig(ctx, t, "second", "receivers: {hostmetrics: {}}")
+	hash := func(config *v1alpha1.Config) []byte {
+		return util.HashAgentConfigMap(util.ProtoConfigToAgentConfigMap(config))
+	}
+	assign := func(configID string) {
+		t.Helper()
+		_, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{
+			AgentId:  "agent-1",
+			ConfigId: configID,
+		}))
+		require.NoError(t, err)
+	}
+	at := func(ts time.Time) *v1alpha1.AgentConfigAtTime {
+		t.Helper()
+		resp, err := h.ConfigServer.GetAgentConfigAtTime(ctx, connect.NewRequest(&v1alpha1.GetAgentConfigAtTimeRequest{
+			AgentId: "agent-1",
+			Time:    timestamppb.New(ts),
+		}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+
+	beforeHistory := time.Now()
+	assign("first")
+	afterFirst := time.Now()
+	require.NoError(t, h.ConfigServer.ConfigApplied(ctx, "agent-1", hash(first)))
+	afterApplied := time.Now()
+
+	// the edited config is a new version, the history keeps the one that was assigned
+	h.createTestConfig(ctx, t, "first", "receivers: {zipkin: {}}")
+	assign("second")
+	_, err := h.ConfigServer.ConfigFailed(ctx, "agent-1", hash(second), "unknown receiver")
+	require.NoError(t, err)
+	afterFailed := time.Now()
+
+	_, err = h.ConfigServer.UnassignConfig(ctx, connect.NewRequest(&v1alpha1.UnassignConfigRequest{AgentId: "agent-1"}))
+	require.NoError(t, err)
+	afterUnassigned := time.Now()
+
+	res := at(beforeHistory)
+	assert.False(t, res.GetComplete())
+	assert.Nil(t, res.GetAssignment())
+
+	res = at(afterFirst)
+	assert.True(t, res.GetComplete())
+	assert.Equal(t, "first", res.GetAssignment().GetConfigId())
+	assert.Equal(t, first.GetConfig(), res.GetConfig().GetConfig())
+	assert.Nil(t, res.GetApplied())
+
+	res = at(afterApplied)
+	assert.Equal(t, "first", res.GetAssignment().GetConfigId())
+	require.NotNil(t, res.GetApplied())
+	assert.Equal(t, hash(first), res.GetApplied().GetConfigHash())
+	assert.Equal(t, "first", res.GetApplied().GetConfigId())
+
+	// the agent kept running the first config after failing to apply the second
+	res = at(afterFailed)
+	assert.Equal(t, "second", res.GetAssignment().GetConfigId())
+	assert.Equal(t, hash(second), res.GetAssignment().GetConfigHash())
+	assert.Equal(t, "first", res.GetApplied().GetConfigId())
+
+	res = at(afterUnassigned)
+	assert.True(t, res.GetComplete())
+	assert.Nil(t, res.GetAssignment())
+	assert.Nil(t, res.GetConfig())
+	assert.Equal(t, hash(first), res.GetApplied().GetConfigHash())
+
+	// repeated status reports are recorded once
+	require.NoError(t, h.ConfigServer.ConfigApplied(ctx, "agent-1", hash(first)))
+	require.NoError(t, h.ConfigServer.ConfigApplied(ctx, "agent-1", hash(first)))
+	history, err := h.ConfigHistoryStore.Get(ctx, "agent-1")
+	require.NoError(t, err)
+	assert.Len(t, history.GetEntries(), 6)
+}
+
+func TestGetAgentConfigAtTime_Errors(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+
+	_, err := h.ConfigServer.GetAgentConfigAtTime(ctx, connect.NewRequest(&v1alpha1.GetAgentConfigAtTimeRequest{
+		AgentId: "agent-1",
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = h.ConfigServer.GetAgentConfigAtTime(ctx, connect.NewRequest(&v1alpha1.GetAgentConfigAtTimeRequest{
+		AgentId: "missing",
+		Time:    timestamppb.Now(),
+	}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// agents without history are known, but their past config is not
+	h.createTestAgent(ctx, t, "agent-1", nil)
+	resp, err := h.ConfigServer.GetAgentConfigAtTime(ctx, connect.NewRequest(&v1alpha1.GetAgentConfigAtTimeRequest{
+		AgentId: "agent-1",
+		Time:    timestamppb.Now(),
+	}))
+	require.NoError(t, err)
+	assert.False(t, resp.Msg.GetComplete())
+}
@@ -0,0 +1,231 @@
+package otelconfig
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"gopkg.in/yaml.v3"
+)
+
+// mergeConfigs merges the changes made to a collector config by the editor (ours) and
+// concurrently by someone else (theirs) since base, and returns the conflicting changes.
+// The merge is structural: maps are merged key by key, so that edits to different
+// components never conflict, and the receivers and exporters of pipelines are merged
+// as sets. Other values conflict when both sides changed them differently.
+// The merged config keeps the key order and comments of ours.
+func mergeConfigs(base, ours, theirs []byte) ([]byte, []*v1alpha1.ConfigMergeConflict, error) {
+	oursDoc, err := parseDocument(ours)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid config: %w", err)
+	}
+	baseDoc, err := parseDocument(base)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid base config: %w", err)
+	}
+	theirsDoc, err := parseDocument(theirs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid current config: %w", err)
+	}
+
+	m := &merger{}
+	merged := m.merge(nil, documentRoot(baseDoc), documentRoot(oursDoc), documentRoot(theirsDoc))
+	if len(m.conflicts) > 0 {
+		return nil, m.conflicts, nil
+	}
+	if merged == nil {
+		return []byte{}, nil, nil
+	}
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{merged}}
+	if oursDoc != nil {
+		doc.HeadComment = oursDoc.HeadComment
+		doc.FootComment = oursDoc.FootComment
+	}
+	buf := &bytes.Buffer{}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode merged config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode merged config: %w", err)
+	}
+	return buf.Bytes(), nil, nil
+}
+
+// parseDocument parses a YAML document, returning nil for empty configs
+func parseDocument(data []byte) (*yaml.Node, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc == nil || len(doc.Content) == 0 {
+		return nil
+	}
+	return doc.Content[0]
+}
+
+type merger struct {
+	conflicts []*v1alpha1.ConfigMergeConflict
+}
+
+// merge returns the merged value at path, nil if it is absent. Absent values are nil.
+func (m *merger) merge(path []string, base, ours, theirs *yaml.Node) *yaml.Node {
+	switch {
+	case equalNodes(ours, theirs):
+		return ours
+	case equalNodes(base, ours):
+		return theirs
+	case equalNodes(base, theirs):
+		return ours
+	case isKind(ours, yaml.MappingNode) && isKind(theirs, yaml.MappingNode):
+		if !isKind(base, yaml.MappingNode) {
+			base = nil
+		}
+		return m.mergeMaps(path, base, ours, theirs)
+	case isSetPath(path) && isScalarSequence(ours) && isScalarSequence(theirs) && (base == nil || isScalarSequence(base)):
+		return mergeSets(base, ours, theirs)
+	}
+	m.conflicts = append(m.conflicts, &v1alpha1.ConfigMergeConflict{
+		Path:   strings.Join(path, "."),
+		Base:   encodeNode(base),
+		Ours:   encodeNode(ours),
+		Theirs: encodeNode(theirs),
+	})
+	return ours
+}
+
+func (m *merger) mergeMaps(path []string, base, ours, theirs *yaml.Node) *yaml.Node {
+	merged := &yaml.Node{
+		Kind:        yaml.MappingNode,
+		Tag:         ours.Tag,
+		Style:       ours.Style,
+		HeadComment: ours.HeadComment,
+		LineComment: ours.LineComment,
+		FootComment: ours.FootComment,
+	}
+	// keys of ours in order, then the keys only theirs has
+	var keys []*yaml.Node
+	for i := 0; i+1 < len(ours.Content); i += 2 {
+		keys = append(keys, ours.Content[i])
+	}
+	for i := 0; i+1 < len(theirs.Content); i += 2 {
+		if mapValue(ours, theirs.Content[i].Value) == nil {
+			keys = append(keys, theirs.Content[i])
+		}
+	}
+	for _, key := range keys {
+		value := m.merge(
+			append(slices.Clip(path), key.Value),
+			mapValue(base, key.Value),
+			mapValue(ours, key.Value),
+			mapValue(theirs, key.Value),
+		)
+		if value != nil {
+			merged.Content = append(merged.Content, key, value)
+		}
+	}
+	return merged
+}
+
+// mergeSets merges sequences of scalars whose order doesn't matter: the items added by
+// ours are appended to theirs, and the items ours removed are removed from it
+func mergeSets(base, ours, theirs *yaml.Node) *yaml.Node {
+	merged := *theirs
+	merged.Content = nil
+	for _, item := range theirs.Content {
+		if containsScalar(base, item.Value) && !containsScalar(ours, item.Value) {
+			continue
+		}
+		merged.Content = append(merged.Content, item)
+	}
+	for _, item := range ours.Content {
+		if !containsScalar(base, item.Value) && !containsScalar(&merged, item.Value) {
+			merged.Content = append(merged.Content, item)
+		}
+	}
+	return &merged
+}
+
+// isSetPath returns true for the collector config values that are sets of component IDs
+func isSetPath(path []string) bool {
+	switch {
+	case len(path) == 4 && path[0] == "service" && path[1] == "pipelines":
+		return path[3] == "receivers" || path[3] == "exporters"
+	case len(path) == 2 && path[0] == "service":
+		return path[1] == "extensions"
+	default:
+		return false
+	}
+}
+
+func isKind(node *yaml.Node, kind yaml.Kind) bool {
+	return node != nil && node.Kind == kind
+}
+
+func isScalarSequence(node *yaml.Node) bool {
+	if !isKind(node, yaml.SequenceNode) {
+		return false
+	}
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+	return true
+}
+
+func containsScalar(seq *yaml.Node, value string) bool {
+	if seq == nil {
+		return false
+	}
+	return slices.ContainsFunc(seq.Content, func(item *yaml.Node) bool {
+		return item.Value == value
+	})
+}
+
+// mapValue returns the value of key in a mapping node, nil if absent
+func mapValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// equalNodes compares the values of nodes, ignoring their formatting and comments
+func equalNodes(a, b *yaml.Node) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	var av, bv any
+	if a.Decode(&av) != nil || b.Decode(&bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+func encodeNode(node *yaml.Node) string {
+	if node == nil {
+		return ""
+	}
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(string(out), "\n")
+}
@@ -0,0 +1,386 @@
+package otelconfig
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// PackageChangeNotifier is notified when the package offered to an agent may have changed.
+// This is implemented by the OpAMP server, offering the package to the agent if it is connected.
+type PackageChangeNotifier interface {
+	NotifyPackageChange(agentID string)
+}
+
+// SetPackageStores sets the store of package targets, keyed by agent/<agent ID> or
+// group/<group ID>, and the store of the package statuses reported by agents, keyed by agent
+// ID, enabling package upgrades. Requires the distribution registry.
+func (c *ConfigServer) SetPackageStores(
+	targetStore storage.KeyValue[*v1alpha1.PackageTarget],
+	statusStore storage.KeyValue[*v1alpha1.AgentPackageStatus],
+) {
+	c.packageTargetStore = targetStore
+	c.packageStatusStore = statusStore
+}
+
+// SetPackageNotifier sets the notifier called when package targets change
+func (c *ConfigServer) SetPackageNotifier(notifier PackageChangeNotifier) {
+	c.packageNotifier = notifier
+}
+
+var errPackagesDisabled = connect.NewError(connect.CodeUnimplemented, errors.New("package upgrades are not enabled"))
+
+func (c *ConfigServer) packagesEnabled() bool {
+	return c.packageTargetStore != nil && c.distributionStore != nil
+}
+
+func packageTargetKey(agentID, groupID string) string {
+	if agentID != "" {
+		return "agent/" + agentID
+	}
+	return "group/" + groupID
+}
+
+func validatePackageTargetReference(agentID, groupID string) error {
+	if (agentID == "") == (groupID == "") {
+		return errors.New("exactly one of agent_id and group_id must be set")
+	}
+	return nil
+}
+
+// describePackageTarget names a package target in messages
+func describePackageTarget(agentID, groupID string) string {
+	if agentID != "" {
+		return "agent " + agentID
+	}
+	return "group " + groupID
+}
+
+// agentPlatform returns the platform of the agent as os/arch, matching the platforms of
+// distribution artifacts
+func agentPlatform(agent *agentdomain.Agent) string {
+	labels := agent.Labels()
+	return labels["os.type"] + "/" + labels["host.arch"]
+}
+
+// packageTarget returns the target applying to the agent: its own, or else the target of its
+// highest priority group. Returns nil if no target applies.
+func (c *ConfigServer) packageTarget(ctx context.Context, agent *agentdomain.Agent) (*v1alpha1.PackageTarget, error) {
+	target, err := c.packageTargetStore.Get(ctx, packageTargetKey(agent.ID, ""))
+	if err == nil {
+		return target, nil
+	} else if !grpcutil.IsErrorNotFound(err) {
+		return nil, err
+	}
+	if c.groupStore == nil {
+		return nil, nil
+	}
+	targets, err := c.packageTargetStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		best      *v1alpha1.PackageTarget
+		bestGroup *v1alpha1.AgentGroup
+	)
+	for _, target := range targets {
+		if target.GetGroupId() == "" {
+			continue
+		}
+		group, err := c.groupStore.Get(ctx, target.GetGroupId())
+		if grpcutil.IsErrorNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if !policyMatches(agent, groupPolicy(group)) {
+			continue
+		}
+		if bestGroup == nil || cmp.Or(cmp.Compare(bestGroup.GetPriority(), group.GetPriority()), strings.Compare(group.GetId(), bestGroup.GetId())) < 0 {
+			best, bestGroup = target, group
+		}
+	}
+	return best, nil
+}
+
+// offeredArtifact returns the target applying to the agent and the artifact of its
+// distribution for the agent's platform, which is nil if the distribution has none
+func (c *ConfigServer) offeredArtifact(ctx context.Context, agent *agentdomain.Agent) (*v1alpha1.PackageTarget, *v1alpha1.CollectorDistribution, *v1alpha1.DistributionArtifact, error) {
+	target, err := c.packageTarget(ctx, agent)
+	if err != nil || target == nil {
+		return nil, nil, nil, err
+	}
+	ref := target.GetDistribution()
+	distribution, err := c.distributionStore.Get(ctx, distributionKey(ref.GetName(), ref.GetVersion()))
+	if grpcutil.IsErrorNotFound(err) {
+		return target, nil, nil, nil
+	} else if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get collector distribution: %w", err)
+	}
+	platform := agentPlatform(agent)
+	for _, artifact := range distribution.GetArtifacts() {
+		if artifact.GetPlatform() == platform {
+			return target, distribution, artifact, nil
+		}
+	}
+	return target, distribution, nil, nil
+}
+
+// AvailablePackages returns the collector package offered to the agent, or nil if no target
+// with an artifact for its platform applies to it, in which case its collector is left as is.
+func (c *ConfigServer) AvailablePackages(ctx context.Context, agentID string) (*protobufs.PackagesAvailable, error) {
+	if !c.packagesEnabled() {
+		return nil, nil
+	}
+	agent, err := c.agentRepo.Get(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	_, distribution, artifact, err := c.offeredArtifact(ctx, agent)
+	if err != nil || artifact == nil {
+		return nil, err
+	}
+	digest, err := hex.DecodeString(artifact.GetSha256())
+	if err != nil || len(digest) != sha256.Size {
+		return nil, fmt.Errorf("invalid sha256 of collector distribution %s %s for %s", distribution.GetName(), distribution.GetVersion(), artifact.GetPlatform())
+	}
+	allHash := sha256.New()
+	allHash.Write([]byte(supervisor.CollectorPackage))
+	allHash.Write([]byte(distribution.GetVersion()))
+	allHash.Write(digest)
+	return &protobufs.PackagesAvailable{
+		Packages: map[string]*protobufs.PackageAvailable{
+			supervisor.CollectorPackage: {
+				Type:    protobufs.PackageType_PackageType_TopLevel,
+				Version: distribution.GetVersion(),
+				Hash:    digest,
+				File: &protobufs.DownloadableFile{
+					DownloadUrl: artifact.GetUrl(),
+					ContentHash: digest,
+					Signature:   artifact.GetSignature(),
+				},
+			},
+		},
+		AllPackagesHash: allHash.Sum(nil),
+	}, nil
+}
+
+func packageInstallState(status protobufs.PackageStatusEnum) v1alpha1.PackageInstallState {
+	switch status {
+	case protobufs.PackageStatusEnum_PackageStatusEnum_Installed:
+		return v1alpha1.PackageInstallState_PACKAGE_INSTALL_STATE_INSTALLED
+	case protobufs.PackageStatusEnum_PackageStatusEnum_InstallPending:
+		return v1alpha1.PackageInstallState_PACKAGE_INSTALL_STATE_INSTALL_PENDING
+	case protobufs.PackageStatusEnum_PackageStatusEnum_Installing:
+		return v1alpha1.PackageInstallState_PACKAGE_INSTALL_STATE_INSTALLING
+	case protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed:
+		return v1alpha1.PackageInstallState_PACKAGE_INSTALL_STATE_INSTALL_FAILED
+	case protobufs.PackageStatusEnum_PackageStatusEnum_Downloading:
+		return v1alpha1.PackageInstallState_PACKAGE_INSTALL_STATE_DOWNLOADING
+	default:
+		return v1alpha1.PackageInstallState_PACKAGE_INSTALL_STATE_UNSPECIFIED
+	}
+}
+
+// PackageStatusesReported records the status of the collector package reported by the agent,
+// recording an event when the agent finishes or fails installing it
+func (c *ConfigServer) PackageStatusesReported(ctx context.Context, agentID string, statuses *protobufs.PackageStatuses) error {
+	if c.packageStatusStore == nil {
+		return nil
+	}
+	reported, ok := statuses.GetPackages()[supervisor.CollectorPackage]
+	if !ok {
+		return nil
+	}
+	status := &v1alpha1.AgentPackageStatus{
+		AgentId:          agentID,
+		InstalledVersion: reported.GetAgentHasVersion(),
+		State:            packageInstallState(reported.GetStatus()),
+		ErrorMessage:     reported.GetErrorMessage(),
+		UpdatedAt:        timestamppb.Now(),
+	}
+	c.packageMu.Lock()
+	defer c.packageMu.Unlock()
+	previous, err := c.packageStatusStore.Get(ctx, agentID)
+	if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return err
+	}
+	if err := c.packageStatusStore.Put(ctx, agentID, status); err != nil {
+		return err
+	}
+	if previous.GetState() == status.GetState() && previous.GetInstalledVersion() == status.GetInstalledVersion() {
+		return nil
+	}
+	switch status.GetState() {
+	case v1alpha1.PackageInstallState_PACKAGE_INSTALL_STATE_INSTALLED:
+		events.RecordAgentEvent(ctx, c.eventRecorder, c.agentRepo, events.TypeAgentPackageInstalled, agentID,
+			fmt.Sprintf("collector %s installed", reported.GetAgentHasVersion()))
+	case v1alpha1.PackageInstallState_PACKAGE_INSTALL_STATE_INSTALL_FAILED:
+		events.RecordAgentEvent(ctx, c.eventRecorder, c.agentRepo, events.TypeAgentPackageFailed, agentID,
+			fmt.Sprintf("failed to install collector %s: %s", reported.GetServerOfferedVersion(), reported.GetErrorMessage()))
+	}
+	return nil
+}
+
+// notifyPackageTargetChange notifies the agents a target applies to that their package may
+// have changed
+func (c *ConfigServer) notifyPackageTargetChange(ctx context.Context, target *v1alpha1.PackageTarget) {
+	if c.packageNotifier == nil {
+		return
+	}
+	if agentID := target.GetAgentId(); agentID != "" {
+		c.packageNotifier.NotifyPackageChange(agentID)
+		return
+	}
+	group, err := c.groupStore.Get(ctx, target.GetGroupId())
+	if err != nil {
+		c.logger.With("err", err, "group_id", target.GetGroupId()).ErrorContext(ctx, "failed to get group of package target")
+		return
+	}
+	agents, err := c.agentRepo.List(ctx)
+	if err != nil {
+		c.logger.With("err", err, "group_id", target.GetGroupId()).ErrorContext(ctx, "failed to list agents of package target")
+		return
+	}
+	policy := groupPolicy(group)
+	for _, agent := range agents {
+		if policyMatches(agent, policy) {
+			c.packageNotifier.NotifyPackageChange(agent.ID)
+		}
+	}
+}
+
+func (c *ConfigServer) SetPackageTarget(ctx context.Context, req *connect.Request[v1alpha1.SetPackageTargetRequest]) (*connect.Response[v1alpha1.PackageTarget], error) {
+	if !c.packagesEnabled() {
+		return nil, errPackagesDisabled
+	}
+	target := req.Msg.GetTarget()
+	if err := validatePackageTargetReference(target.GetAgentId(), target.GetGroupId()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if _, err := c.getDistribution(ctx, target.GetDistribution()); err != nil {
+		return nil, err
+	}
+	if agentID := target.GetAgentId(); agentID != "" {
+		exists, err := c.agentRepo.Exists(ctx, agentID)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		} else if !exists {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+		}
+	} else {
+		if c.groupStore == nil {
+			return nil, errGroupsDisabled
+		}
+		if _, err := c.groupStore.Get(ctx, target.GetGroupId()); grpcutil.IsErrorNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent group not found: %s", target.GetGroupId()))
+		} else if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	key := packageTargetKey(target.GetAgentId(), target.GetGroupId())
+	existing, err := c.packageTargetStore.Get(ctx, key)
+	if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	target.Audit = existing.GetAudit().Touched(auth.SubjectFromContext(ctx), time.Now())
+	if err := c.packageTargetStore.Put(ctx, key, target); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	ref := target.GetDistribution()
+	events.RecordChange(ctx, c.eventRecorder, events.TypePackageTargetUpdated, fmt.Sprintf("package target of %s set to %s %s", describePackageTarget(target.GetAgentId(), target.GetGroupId()), ref.GetName(), ref.GetVersion()), map[string]string{
+		"agent_id":     target.GetAgentId(),
+		"group_id":     target.GetGroupId(),
+		"distribution": ref.GetName(),
+		"version":      ref.GetVersion(),
+	})
+	c.notifyPackageTargetChange(ctx, target)
+	return connect.NewResponse(target), nil
+}
+
+// DeletePackageTarget deletes a target, the collectors it was installed on are left as is
+func (c *ConfigServer) DeletePackageTarget(ctx context.Context, req *connect.Request[v1alpha1.PackageTargetReference]) (*connect.Response[emptypb.Empty], error) {
+	if !c.packagesEnabled() {
+		return nil, errPackagesDisabled
+	}
+	agentID, groupID := req.Msg.GetAgentId(), req.Msg.GetGroupId()
+	if err := validatePackageTargetReference(agentID, groupID); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	key := packageTargetKey(agentID, groupID)
+	target, err := c.packageTargetStore.Get(ctx, key)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("package target not found: %s", describePackageTarget(agentID, groupID)))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if err := c.packageTargetStore.Delete(ctx, key); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	events.RecordChange(ctx, c.eventRecorder, events.TypePackageTargetDeleted, fmt.Sprintf("package target of %s deleted", describePackageTarget(agentID, groupID)), map[string]string{
+		"agent_id": agentID,
+		"group_id": groupID,
+	})
+	// agents may fall back to the target of one of their groups
+	c.notifyPackageTargetChange(ctx, target)
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (c *ConfigServer) ListPackageTargets(ctx context.Context, _ *connect.Request[v1alpha1.ListPackageTargetsRequest]) (*connect.Response[v1alpha1.ListPackageTargetsResponse], error) {
+	if !c.packagesEnabled() {
+		return nil, errPackagesDisabled
+	}
+	targets, err := c.packageTargetStore.List(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	slices.SortFunc(targets, func(a, b *v1alpha1.PackageTarget) int {
+		return strings.Compare(packageTargetKey(a.GetAgentId(), a.GetGroupId()), packageTargetKey(b.GetAgentId(), b.GetGroupId()))
+	})
+	return connect.NewResponse(&v1alpha1.ListPackageTargetsResponse{Targets: targets}), nil
+}
+
+func (c *ConfigServer) GetAgentPackageStatus(ctx context.Context, req *connect.Request[v1alpha1.GetAgentPackageStatusRequest]) (*connect.Response[v1alpha1.AgentPackageStatus], error) {
+	if !c.packagesEnabled() {
+		return nil, errPackagesDisabled
+	}
+	agentID := req.Msg.GetAgentId()
+	if agentID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id must be non-empty"))
+	}
+	agent, err := c.agentRepo.Get(ctx, agentID)
+	if errors.Is(err, agentdomain.ErrAgentNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	status, err := c.packageStatusStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		status = &v1alpha1.AgentPackageStatus{AgentId: agentID}
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	status.Target, _, status.Artifact, err = c.offeredArtifact(ctx, agent)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(status), nil
+}
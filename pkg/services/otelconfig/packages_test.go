@@ -0,0 +1,170 @@
+package otelconfig_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// putSignedDistribution registers a distribution whose artifact for the current platform is
+// served by an HTTP server, signed with key
+func (h *testEnv) putSignedDistribution(ctx context.Context, t *testing.T, version string, binary []byte, key ed25519.PrivateKey) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(binary)
+	}))
+	t.Cleanup(srv.Close)
+	digest := sha256.Sum256(binary)
+	_, err := h.ConfigServer.PutCollectorDistribution(ctx, connect.NewRequest(&v1alpha1.PutCollectorDistributionRequest{
+		Distribution: &v1alpha1.CollectorDistribution{
+			Name:    "otelcol",
+			Version: version,
+			Artifacts: []*v1alpha1.DistributionArtifact{{
+				Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+				Url:       srv.URL + "/otelcol",
+				Sha256:    hex.EncodeToString(digest[:]),
+				Signature: ed25519.Sign(key, digest[:]),
+			}},
+		},
+	}))
+	require.NoError(t, err)
+}
+
+func (h *testEnv) waitForPackageState(t *testing.T, agentID string, state v1alpha1.PackageInstallState) *v1alpha1.AgentPackageStatus {
+	t.Helper()
+	var status *v1alpha1.AgentPackageStatus
+	require.Eventually(t, func() bool {
+		resp, err := h.ConfigServer.GetAgentPackageStatus(context.Background(), connect.NewRequest(&v1alpha1.GetAgentPackageStatusRequest{AgentId: agentID}))
+		require.NoError(t, err)
+		status = resp.Msg
+		return status.GetState() == state
+	}, 10*time.Second, 50*time.Millisecond)
+	return status
+}
+
+func TestPackageUpgrades(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	pub, key, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	binary := []byte("#!/bin/sh\necho otelcol 0.120.0\n")
+	h.putSignedDistribution(ctx, t, "0.120.0", binary, key)
+	// signed with a key the supervisor doesn't trust
+	h.putSignedDistribution(ctx, t, "0.121.0", []byte("#!/bin/sh\necho otelcol 0.121.0\n"), otherKey)
+
+	agent := h.NewAgent("upgraded-agent")
+	packages, err := supervisor.NewPackageStore(t.TempDir(), pub)
+	require.NoError(t, err)
+	agent.Supervisor.SetPackageStore(packages)
+	require.NoError(t, agent.Start())
+	agent.WaitForConfig(t, 5*time.Second)
+
+	_, err = h.ConfigServer.SetPackageTarget(ctx, connect.NewRequest(&v1alpha1.SetPackageTargetRequest{
+		Target: &v1alpha1.PackageTarget{
+			AgentId:      agent.ID,
+			Distribution: &v1alpha1.CollectorDistributionReference{Name: "otelcol", Version: "0.120.0"},
+		},
+	}))
+	require.NoError(t, err)
+
+	status := h.waitForPackageState(t, agent.ID, v1alpha1.PackageInstallState_PACKAGE_INSTALL_STATE_INSTALLED)
+	assert.Equal(t, "0.120.0", status.GetInstalledVersion())
+	assert.Equal(t, agent.ID, status.GetTarget().GetAgentId())
+	assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, status.GetArtifact().GetPlatform())
+	installed := agent.AgentDriver.GetBinaryPath()
+	require.NotEmpty(t, installed)
+	assert.Equal(t, installed, packages.InstalledCollector())
+	content, err := os.ReadFile(installed)
+	require.NoError(t, err)
+	assert.Equal(t, binary, content)
+
+	// the agent's own target outranks the targets of its groups
+	_, err = h.ConfigServer.CreateGroup(ctx, connect.NewRequest(&v1alpha1.CreateGroupRequest{
+		Group: &v1alpha1.AgentGroup{Id: "canary", AgentIds: []string{agent.ID}},
+	}))
+	require.NoError(t, err)
+	_, err = h.ConfigServer.SetPackageTarget(ctx, connect.NewRequest(&v1alpha1.SetPackageTargetRequest{
+		Target: &v1alpha1.PackageTarget{
+			GroupId:      "canary",
+			Distribution: &v1alpha1.CollectorDistributionReference{Name: "otelcol", Version: "0.121.0"},
+		},
+	}))
+	require.NoError(t, err)
+	resp, err := h.ConfigServer.GetAgentPackageStatus(ctx, connect.NewRequest(&v1alpha1.GetAgentPackageStatusRequest{AgentId: agent.ID}))
+	require.NoError(t, err)
+	assert.Equal(t, agent.ID, resp.Msg.GetTarget().GetAgentId())
+
+	list, err := h.ConfigServer.ListPackageTargets(ctx, connect.NewRequest(&v1alpha1.ListPackageTargetsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.GetTargets(), 2)
+	assert.Equal(t, agent.ID, list.Msg.GetTargets()[0].GetAgentId())
+	assert.Equal(t, "canary", list.Msg.GetTargets()[1].GetGroupId())
+
+	// without its own target the agent falls back to its group's, whose signature is rejected
+	_, err = h.ConfigServer.DeletePackageTarget(ctx, connect.NewRequest(&v1alpha1.PackageTargetReference{AgentId: agent.ID}))
+	require.NoError(t, err)
+	status = h.waitForPackageState(t, agent.ID, v1alpha1.PackageInstallState_PACKAGE_INSTALL_STATE_INSTALL_FAILED)
+	assert.Equal(t, "canary", status.GetTarget().GetGroupId())
+	assert.Contains(t, status.GetErrorMessage(), "invalid package signature")
+	assert.Equal(t, installed, agent.AgentDriver.GetBinaryPath(), "the installed collector is kept")
+}
+
+func TestPackageTargets_Errors(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	h.createTestAgent(ctx, t, "agent-1", nil)
+	_, err := h.ConfigServer.PutCollectorDistribution(ctx, connect.NewRequest(&v1alpha1.PutCollectorDistributionRequest{
+		Distribution: &v1alpha1.CollectorDistribution{Name: "otelcol", Version: "0.120.0"},
+	}))
+	require.NoError(t, err)
+
+	set := func(target *v1alpha1.PackageTarget) error {
+		t.Helper()
+		_, err := h.ConfigServer.SetPackageTarget(ctx, connect.NewRequest(&v1alpha1.SetPackageTargetRequest{Target: target}))
+		return err
+	}
+	distribution := &v1alpha1.CollectorDistributionReference{Name: "otelcol", Version: "0.120.0"}
+	err = set(&v1alpha1.PackageTarget{Distribution: distribution})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	err = set(&v1alpha1.PackageTarget{AgentId: "agent-1", GroupId: "group-1", Distribution: distribution})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	err = set(&v1alpha1.PackageTarget{AgentId: "agent-1"})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	err = set(&v1alpha1.PackageTarget{AgentId: "agent-1", Distribution: &v1alpha1.CollectorDistributionReference{Name: "otelcol", Version: "0.1.0"}})
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	err = set(&v1alpha1.PackageTarget{AgentId: "missing", Distribution: distribution})
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	err = set(&v1alpha1.PackageTarget{GroupId: "missing", Distribution: distribution})
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	_, err = h.ConfigServer.DeletePackageTarget(ctx, connect.NewRequest(&v1alpha1.PackageTargetReference{AgentId: "agent-1"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = h.ConfigServer.GetAgentPackageStatus(ctx, connect.NewRequest(&v1alpha1.GetAgentPackageStatusRequest{}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = h.ConfigServer.GetAgentPackageStatus(ctx, connect.NewRequest(&v1alpha1.GetAgentPackageStatusRequest{AgentId: "missing"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// agents without a target are reported as such
+	require.NoError(t, set(&v1alpha1.PackageTarget{AgentId: "agent-1", Distribution: distribution}))
+	resp, err := h.ConfigServer.GetAgentPackageStatus(ctx, connect.NewRequest(&v1alpha1.GetAgentPackageStatusRequest{AgentId: "agent-1"}))
+	require.NoError(t, err)
+	assert.Equal(t, "agent-1", resp.Msg.GetTarget().GetAgentId())
+	assert.Nil(t, resp.Msg.GetArtifact(), "the distribution has no artifact for the agent's platform")
+	assert.Equal(t, v1alpha1.PackageInstallState_PACKAGE_INSTALL_STATE_UNSPECIFIED, resp.Msg.GetState())
+}
@@ -0,0 +1,332 @@
+package otelconfig
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetAssignmentPolicyStore sets the store of assignment policies, keyed by policy ID,
+// enabling assignment policies
+func (c *ConfigServer) SetAssignmentPolicyStore(store storage.KeyValue[*v1alpha1.AssignmentPolicy]) {
+	c.policyStore = store
+}
+
+var errPoliciesDisabled = connect.NewError(connect.CodeUnimplemented, errors.New("assignment policies are not enabled"))
+
+// sortPolicies orders policies by precedence : highest priority first, then by ID
+func sortPolicies(policies []*v1alpha1.AssignmentPolicy) {
+	slices.SortFunc(policies, func(a, b *v1alpha1.AssignmentPolicy) int {
+		return cmp.Or(cmp.Compare(b.GetPriority(), a.GetPriority()), strings.Compare(a.GetId(), b.GetId()))
+	})
+}
+
+// listPolicies returns all assignment policies in order of precedence, including the
+// policies of agent groups with a config, or none if neither is enabled
+func (c *ConfigServer) listPolicies(ctx context.Context) ([]*v1alpha1.AssignmentPolicy, error) {
+	var policies []*v1alpha1.AssignmentPolicy
+	if c.policyStore != nil {
+		stored, err := c.policyStore.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list assignment policies: %w", err)
+		}
+		policies = append(policies, stored...)
+	}
+	if c.groupStore != nil {
+		groups, err := c.groupStore.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list agent groups: %w", err)
+		}
+		for _, group := range groups {
+			if group.GetConfigId() != "" {
+				policies = append(policies, groupPolicy(group))
+			}
+		}
+	}
+	sortPolicies(policies)
+	return policies, nil
+}
+
+// policyMatches returns true if the policy applies to the agent
+func policyMatches(agent *agentdomain.Agent, policy *v1alpha1.AssignmentPolicy) bool {
+	if slices.Contains(policy.GetAgentIds(), agent.ID) {
+		return true
+	}
+	// stored policies were validated, an invalid selector matches no agent
+	selector, _ := agentdomain.NewSelector(policy.GetSelector(), policy.GetSelectorExpression())
+	return agent.MatchesSelector(selector)
+}
+
+// matchingPolicies returns the policies applying to the agent, keeping their order
+func matchingPolicies(agent *agentdomain.Agent, policies []*v1alpha1.AssignmentPolicy) []*v1alpha1.AssignmentPolicy {
+	var matches []*v1alpha1.AssignmentPolicy
+	for _, policy := range policies {
+		if policyMatches(agent, policy) {
+			matches = append(matches, policy)
+		}
+	}
+	return matches
+}
+
+// overridesPolicies returns true if the assignment outranks assignment policies,
+// in which case policies leave the agent alone
+func (c *ConfigServer) overridesPolicies(assignment *v1alpha1.ConfigAssignment) bool {
+	return assignment != nil && c.sourcePrecedence(assignment.GetSource()) > c.sourcePrecedence(v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY)
+}
+
+// applyPolicies brings the agent's assignment in line with the policies, which must be in
+// order of precedence, and its bootstrap config, keeping the highest ranked of its manual
+// assignment, matching policy and bootstrap config. A manual assignment outranked by the
+// other sources is replaced. Callers must hold policyMu.
+func (c *ConfigServer) applyPolicies(ctx context.Context, agent *agentdomain.Agent, policies []*v1alpha1.AssignmentPolicy) error {
+	assignment, err := c.configAssignmentStore.Get(ctx, agent.ID)
+	if grpcutil.IsErrorNotFound(err) {
+		assignment = nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get config assignment: %w", err)
+	}
+
+	for _, source := range c.precedence {
+		switch source {
+		case v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL:
+			if isManual(assignment) {
+				return nil
+			}
+		case v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY:
+			if matches := matchingPolicies(agent, policies); len(matches) > 0 {
+				return c.applyPolicy(ctx, agent, assignment, matches[0])
+			}
+		case v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP:
+			if applied, err := c.applyBootstrapAssignment(ctx, agent.ID, assignment); err != nil || applied {
+				return err
+			}
+		}
+	}
+	return c.clearAssignment(ctx, agent.ID, assignment)
+}
+
+// applyPolicy assigns the config of the policy to the agent, unless already assigned.
+// Callers must hold policyMu.
+func (c *ConfigServer) applyPolicy(ctx context.Context, agent *agentdomain.Agent, assignment *v1alpha1.ConfigAssignment, policy *v1alpha1.AssignmentPolicy) error {
+	config, err := c.configStore.Get(ctx, policy.GetConfigId())
+	if err != nil {
+		return fmt.Errorf("failed to get config %s of policy %s: %w", policy.GetConfigId(), policy.GetId(), err)
+	}
+	if err := checkTenant(agent, policy.GetConfigId(), config); err != nil {
+		return fmt.Errorf("cannot apply policy %s: %w", policy.GetId(), err)
+	}
+	if err := c.checkComponentPolicies(ctx, agent, config); err != nil {
+		return fmt.Errorf("cannot apply policy %s: %w", policy.GetId(), err)
+	}
+	if err := checkRemovedComponents(agent, config); err != nil {
+		return fmt.Errorf("cannot apply policy %s: %w", policy.GetId(), err)
+	}
+	hash := util.HashAgentConfigMap(util.ProtoConfigToAgentConfigMap(config))
+	if assignment.GetPolicyId() == policy.GetId() &&
+		assignment.GetConfigId() == policy.GetConfigId() &&
+		bytes.Equal(assignment.GetConfigHash(), hash) {
+		return nil
+	}
+
+	updated := &v1alpha1.ConfigAssignment{
+		AgentId:    agent.ID,
+		ConfigId:   policy.GetConfigId(),
+		Source:     v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY,
+		AssignedAt: timestamppb.Now(),
+		ConfigHash: hash,
+		Audit:      v1alpha1.NewAuditInfo(auth.SubjectFromContext(ctx), time.Now()),
+		PolicyId:   policy.GetId(),
+	}
+	if err := c.putAssignment(ctx, agent.ID, config, updated); err != nil {
+		return err
+	}
+	c.recordAssigned(ctx, updated, config)
+	c.notifyConfigChange(agent.ID)
+	c.logger.With("agent_id", agent.ID, "config_id", policy.GetConfigId(), "policy_id", policy.GetId()).InfoContext(ctx, "config assigned to agent by policy")
+	events.RecordAgentEvent(ctx, c.eventRecorder, c.agentRepo, events.TypeConfigAssigned, agent.ID,
+		fmt.Sprintf("config %s assigned by %s", policy.GetConfigId(), describePolicy(policy.GetId())))
+	return nil
+}
+
+// evaluatePolicies applies the assignment policies to every agent
+func (c *ConfigServer) evaluatePolicies(ctx context.Context) error {
+	c.policyMu.Lock()
+	defer c.policyMu.Unlock()
+	policies, err := c.listPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	agents, err := c.agentRepo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list agents: %w", err)
+	}
+	var errs []error
+	for _, agent := range agents {
+		if err := c.applyPolicies(ctx, agent, policies); err != nil {
+			errs = append(errs, fmt.Errorf("agent %s: %w", agent.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// EvaluateAgentPolicies applies the assignment policies to a single agent, or its bootstrap
+// config if none match. It is called when the agent reports its description, the first time
+// after bootstrap and whenever its labels change.
+func (c *ConfigServer) EvaluateAgentPolicies(ctx context.Context, agentID string) error {
+	c.policyMu.Lock()
+	defer c.policyMu.Unlock()
+	agent, err := c.agentRepo.Get(ctx, agentID)
+	if err != nil {
+		return fmt.Errorf("failed to get agent: %w", err)
+	}
+	policies, err := c.listPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	return c.applyPolicies(ctx, agent, policies)
+}
+
+func (c *ConfigServer) PutAssignmentPolicy(ctx context.Context, req *connect.Request[v1alpha1.PutAssignmentPolicyRequest]) (*connect.Response[v1alpha1.AssignmentPolicy], error) {
+	if c.policyStore == nil {
+		return nil, errPoliciesDisabled
+	}
+	policy := req.Msg.GetPolicy()
+	switch {
+	case policy.GetId() == "":
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("policy id must be non-empty"))
+	case strings.HasPrefix(policy.GetId(), groupPolicyPrefix):
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("policy ids starting with %q are reserved for agent groups", groupPolicyPrefix))
+	case len(policy.GetSelector()) == 0 && policy.GetSelectorExpression() == "" && len(policy.GetAgentIds()) == 0:
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("selector, selector_expression or agent_ids must be non-empty"))
+	case policy.GetConfigId() == "":
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("config_id must be non-empty"))
+	}
+	if _, err := agentdomain.ParseSelector(policy.GetSelectorExpression()); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if _, err := c.configStore.Get(ctx, policy.GetConfigId()); err != nil {
+		if grpcutil.IsErrorNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("config not found: %s", policy.GetConfigId()))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	existing, err := c.policyStore.Get(ctx, policy.GetId())
+	if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	policy.Audit = existing.GetAudit().Touched(auth.SubjectFromContext(ctx), time.Now())
+	if err := c.policyStore.Put(ctx, policy.GetId(), policy); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	events.RecordChange(ctx, c.eventRecorder, events.TypePolicyUpdated, fmt.Sprintf("assignment policy %s updated", policy.GetId()), map[string]string{
+		"policy_id": policy.GetId(),
+		"config_id": policy.GetConfigId(),
+	})
+	// the policy is stored, agents that failed to update are picked up by the next evaluation
+	if err := c.evaluatePolicies(ctx); err != nil {
+		c.logger.With("err", err, "policy_id", policy.GetId()).ErrorContext(ctx, "failed to apply assignment policies")
+	}
+	return connect.NewResponse(policy), nil
+}
+
+func (c *ConfigServer) GetAssignmentPolicy(ctx context.Context, req *connect.Request[v1alpha1.AssignmentPolicyReference]) (*connect.Response[v1alpha1.AssignmentPolicy], error) {
+	if c.policyStore == nil {
+		return nil, errPoliciesDisabled
+	}
+	if req.Msg.GetId() == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("policy id must be non-empty"))
+	}
+	policy, err := c.policyStore.Get(ctx, req.Msg.GetId())
+	if err != nil {
+		if grpcutil.IsErrorNotFound(err) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("assignment policy not found: %s", req.Msg.GetId()))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(policy), nil
+}
+
+func (c *ConfigServer) DeleteAssignmentPolicy(ctx context.Context, req *connect.Request[v1alpha1.AssignmentPolicyReference]) (*connect.Response[emptypb.Empty], error) {
+	if _, err := c.GetAssignmentPolicy(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := c.policyStore.Delete(ctx, req.Msg.GetId()); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	events.RecordChange(ctx, c.eventRecorder, events.TypePolicyDeleted, fmt.Sprintf("assignment policy %s deleted", req.Msg.GetId()), map[string]string{
+		"policy_id": req.Msg.GetId(),
+	})
+	if err := c.evaluatePolicies(ctx); err != nil {
+		c.logger.With("err", err, "policy_id", req.Msg.GetId()).ErrorContext(ctx, "failed to apply assignment policies")
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (c *ConfigServer) ListAssignmentPolicies(ctx context.Context, req *connect.Request[v1alpha1.ListAssignmentPoliciesRequest]) (*connect.Response[v1alpha1.ListAssignmentPoliciesResponse], error) {
+	if c.policyStore == nil {
+		return nil, errPoliciesDisabled
+	}
+	policies, err := c.listPolicies(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	agents, err := c.agentRepo.List(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	assignments, err := c.configAssignmentStore.List(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	resp := &v1alpha1.ListAssignmentPoliciesResponse{
+		Policies:      policies,
+		AppliedAgents: map[string]int32{},
+	}
+	byAgent := map[string]*v1alpha1.ConfigAssignment{}
+	for _, assignment := range assignments {
+		byAgent[assignment.GetAgentId()] = assignment
+		if assignment.GetSource() == v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY {
+			resp.AppliedAgents[assignment.GetPolicyId()]++
+		}
+	}
+	for _, agent := range agents {
+		matches := matchingPolicies(agent, policies)
+		if len(matches) < 2 || !slices.ContainsFunc(matches[1:], func(p *v1alpha1.AssignmentPolicy) bool {
+			return p.GetConfigId() != matches[0].GetConfigId()
+		}) {
+			continue
+		}
+		conflict := &v1alpha1.AssignmentPolicyConflict{
+			AgentId:   agent.ID,
+			Ambiguous: matches[1].GetPriority() == matches[0].GetPriority(),
+		}
+		for _, policy := range matches {
+			conflict.PolicyIds = append(conflict.PolicyIds, policy.GetId())
+		}
+		if !c.overridesPolicies(byAgent[agent.ID]) {
+			conflict.AppliedPolicyId = matches[0].GetId()
+		}
+		resp.Conflicts = append(resp.Conflicts, conflict)
+	}
+	slices.SortFunc(resp.Conflicts, func(a, b *v1alpha1.AssignmentPolicyConflict) int {
+		return strings.Compare(a.GetAgentId(), b.GetAgentId())
+	})
+	return connect.NewResponse(resp), nil
+}
@@ -0,0 +1,114 @@
+package otelconfig_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignmentPolicies(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	h.createTestAgent(ctx, t, "web-1", map[string]string{"role": "web", "env": "prod"})
+	h.createTestAgent(ctx, t, "web-2", map[string]string{"role": "web", "env": "dev"})
+	h.createTestAgent(ctx, t, "db-1", map[string]string{"role": "db"})
+	h.createTestAgent(ctx, t, "pinned", map[string]string{"role": "web"})
+	h.createTestConfig(ctx, t, "web", "receivers: {}")
+	h.createTestConfig(ctx, t, "web-prod", "receivers: {otlp: {}}")
+	h.createTestConfig(ctx, t, "pinned", "exporters: {}")
+
+	_, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: "pinned", ConfigId: "pinned"}))
+	require.NoError(t, err)
+
+	putPolicy := func(policy *v1alpha1.AssignmentPolicy) {
+		t.Helper()
+		_, err := h.ConfigServer.PutAssignmentPolicy(ctx, connect.NewRequest(&v1alpha1.PutAssignmentPolicyRequest{Policy: policy}))
+		require.NoError(t, err)
+	}
+	deletePolicy := func(id string) {
+		t.Helper()
+		_, err := h.ConfigServer.DeleteAssignmentPolicy(ctx, connect.NewRequest(&v1alpha1.AssignmentPolicyReference{Id: id}))
+		require.NoError(t, err)
+	}
+	assignedConfigs := func() map[string]string {
+		t.Helper()
+		configs := map[string]string{}
+		for _, agentID := range []string{"web-1", "web-2", "db-1", "pinned"} {
+			assignment, err := h.ConfigAssignmentStore.Get(ctx, agentID)
+			if grpcutil.IsErrorNotFound(err) {
+				continue
+			}
+			require.NoError(t, err)
+			configs[agentID] = assignment.GetConfigId()
+		}
+		return configs
+	}
+
+	_, err = h.ConfigServer.PutAssignmentPolicy(ctx, connect.NewRequest(&v1alpha1.PutAssignmentPolicyRequest{
+		Policy: &v1alpha1.AssignmentPolicy{Id: "everything", ConfigId: "web"},
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "an empty selector must not match every agent")
+	_, err = h.ConfigServer.PutAssignmentPolicy(ctx, connect.NewRequest(&v1alpha1.PutAssignmentPolicyRequest{
+		Policy: &v1alpha1.AssignmentPolicy{Id: "missing", Selector: map[string]string{"role": "web"}, ConfigId: "missing"},
+	}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// manually assigned agents are left alone
+	h.notifier.reset()
+	putPolicy(&v1alpha1.AssignmentPolicy{Id: "web", Selector: map[string]string{"role": "web"}, ConfigId: "web"})
+	assert.Equal(t, map[string]string{"web-1": "web", "web-2": "web", "pinned": "pinned"}, assignedConfigs())
+	assert.ElementsMatch(t, []string{"web-1", "web-2"}, h.notifier.getNotifications())
+	assignment, err := h.ConfigAssignmentStore.Get(ctx, "web-1")
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY, assignment.GetSource())
+	assert.Equal(t, "web", assignment.GetPolicyId())
+
+	// the higher priority policy wins, unchanged assignments are not pushed again
+	h.notifier.reset()
+	putPolicy(&v1alpha1.AssignmentPolicy{Id: "prod", Selector: map[string]string{"env": "prod"}, ConfigId: "web-prod", Priority: 10})
+	assert.Equal(t, map[string]string{"web-1": "web-prod", "web-2": "web", "pinned": "pinned"}, assignedConfigs())
+	assert.Equal(t, []string{"web-1"}, h.notifier.getNotifications())
+
+	// label changes are picked up when the agent reports them
+	h.createTestAgent(ctx, t, "web-2", map[string]string{"role": "web", "env": "prod"})
+	require.NoError(t, h.ConfigServer.EvaluateAgentPolicies(ctx, "web-2"))
+	assert.Equal(t, map[string]string{"web-1": "web-prod", "web-2": "web-prod", "pinned": "pinned"}, assignedConfigs())
+
+	putPolicy(&v1alpha1.AssignmentPolicy{Id: "web-alt", Selector: map[string]string{"role": "web"}, ConfigId: "pinned"})
+	list, err := h.ConfigServer.ListAssignmentPolicies(ctx, connect.NewRequest(&v1alpha1.ListAssignmentPoliciesRequest{}))
+	require.NoError(t, err)
+	var ids []string
+	for _, policy := range list.Msg.GetPolicies() {
+		ids = append(ids, policy.GetId())
+	}
+	assert.Equal(t, []string{"prod", "web", "web-alt"}, ids)
+	assert.Equal(t, map[string]int32{"prod": 2}, list.Msg.GetAppliedAgents())
+	conflicts := map[string]*v1alpha1.AssignmentPolicyConflict{}
+	for _, conflict := range list.Msg.GetConflicts() {
+		conflicts[conflict.GetAgentId()] = conflict
+	}
+	require.Len(t, conflicts, 3)
+	assert.Equal(t, []string{"prod", "web", "web-alt"}, conflicts["web-1"].GetPolicyIds())
+	assert.Equal(t, "prod", conflicts["web-1"].GetAppliedPolicyId())
+	assert.False(t, conflicts["web-1"].GetAmbiguous())
+	assert.Equal(t, []string{"web", "web-alt"}, conflicts["pinned"].GetPolicyIds())
+	assert.Empty(t, conflicts["pinned"].GetAppliedPolicyId(), "pinned is manually overridden")
+	assert.True(t, conflicts["pinned"].GetAmbiguous())
+	deletePolicy("web-alt")
+
+	// removing the manual assignment hands the agent over to the policies
+	_, err = h.ConfigServer.UnassignConfig(ctx, connect.NewRequest(&v1alpha1.UnassignConfigRequest{AgentId: "pinned"}))
+	require.NoError(t, err)
+	assert.Equal(t, "web", assignedConfigs()["pinned"])
+
+	deletePolicy("prod")
+	assert.Equal(t, map[string]string{"web-1": "web", "web-2": "web", "pinned": "web"}, assignedConfigs())
+	deletePolicy("web")
+	assert.Empty(t, assignedConfigs())
+	_, err = h.AssignedConfigStore.Get(ctx, "web-1")
+	assert.True(t, grpcutil.IsErrorNotFound(err), "agents fall back to the default config")
+}
@@ -0,0 +1,381 @@
+package otelconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetBootstrapAssignmentStore sets the store of the configs agents were bootstrapped with,
+// keyed by agent ID, so that agents fall back to them when nothing else is assigned
+func (c *ConfigServer) SetBootstrapAssignmentStore(store storage.KeyValue[*v1alpha1.ConfigAssignment]) {
+	c.bootstrapAssignmentStore = store
+}
+
+// DefaultPrecedence ranks manual assignments, including following the default config, over
+// assignment policies, and assignment policies over the config of the bootstrap token
+var DefaultPrecedence = []v1alpha1.ConfigSource{
+	v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL,
+	v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY,
+	v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP,
+}
+
+// precedenceNames are the names of the sources in precedence configurations
+var precedenceNames = map[string]v1alpha1.ConfigSource{
+	"manual":    v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL,
+	"policy":    v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY,
+	"bootstrap": v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP,
+}
+
+// ParsePrecedence parses a comma separated precedence of config sources, highest first,
+// e.g. "policy,manual,bootstrap". Every source must be listed once, an empty string
+// returns DefaultPrecedence.
+func ParsePrecedence(s string) ([]v1alpha1.ConfigSource, error) {
+	if strings.TrimSpace(s) == "" {
+		return slices.Clone(DefaultPrecedence), nil
+	}
+	var precedence []v1alpha1.ConfigSource
+	for name := range strings.SplitSeq(s, ",") {
+		source, ok := precedenceNames[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown config source %q, expected manual, policy or bootstrap", strings.TrimSpace(name))
+		}
+		precedence = append(precedence, source)
+	}
+	if err := validatePrecedence(precedence); err != nil {
+		return nil, err
+	}
+	return precedence, nil
+}
+
+func validatePrecedence(precedence []v1alpha1.ConfigSource) error {
+	if len(precedence) != len(DefaultPrecedence) {
+		return fmt.Errorf("precedence must list each of manual, policy and bootstrap once")
+	}
+	for _, source := range DefaultPrecedence {
+		if !slices.Contains(precedence, source) {
+			return fmt.Errorf("precedence must list each of manual, policy and bootstrap once")
+		}
+	}
+	return nil
+}
+
+// SetAssignmentPrecedence sets the precedence of config sources, highest first, which must
+// be an ordering of DefaultPrecedence
+func (c *ConfigServer) SetAssignmentPrecedence(precedence []v1alpha1.ConfigSource) error {
+	if err := validatePrecedence(precedence); err != nil {
+		return err
+	}
+	c.precedence = slices.Clone(precedence)
+	return nil
+}
+
+// sourcePrecedence ranks config sources, the highest ranked candidate of an agent wins.
+// Following the default config ranks as a manual assignment, and the built-in fallback
+// config ranks last.
+func (c *ConfigServer) sourcePrecedence(source v1alpha1.ConfigSource) int {
+	if source == v1alpha1.ConfigSource_CONFIG_SOURCE_DEFAULT {
+		source = v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL
+	}
+	i := slices.Index(c.precedence, source)
+	if i < 0 {
+		return 0
+	}
+	return len(c.precedence) - i
+}
+
+// isManual returns true if the assignment was made by hand, to a config or the default config
+func isManual(assignment *v1alpha1.ConfigAssignment) bool {
+	switch assignment.GetSource() {
+	case v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL, v1alpha1.ConfigSource_CONFIG_SOURCE_DEFAULT:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkManualPrecedence fails if a source outranking manual assignments applies to the
+// agent, as it would replace a manual assignment
+func (c *ConfigServer) checkManualPrecedence(ctx context.Context, agent *agentdomain.Agent) error {
+	for _, source := range c.precedence {
+		switch source {
+		case v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL:
+			return nil
+		case v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY:
+			policies, err := c.listPolicies(ctx)
+			if err != nil {
+				return err
+			}
+			if matches := matchingPolicies(agent, policies); len(matches) > 0 {
+				return &precedenceError{fmt.Sprintf("%s takes precedence over manual assignments", describePolicy(matches[0].GetId()))}
+			}
+		case v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP:
+			bootstrap, err := c.bootstrapAssignment(ctx, agent.ID)
+			if err != nil {
+				return err
+			}
+			if bootstrap.GetConfigId() == "" {
+				continue
+			}
+			if _, err := c.configStore.Get(ctx, bootstrap.GetConfigId()); err == nil {
+				return &precedenceError{fmt.Sprintf("bootstrap config %s takes precedence over manual assignments", bootstrap.GetConfigId())}
+			} else if !grpcutil.IsErrorNotFound(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// precedenceError is returned when a manual assignment is outranked by another source
+type precedenceError struct {
+	reason string
+}
+
+func (e *precedenceError) Error() string {
+	return e.reason
+}
+
+func precedenceConnectError(err error) *connect.Error {
+	var precedenceErr *precedenceError
+	if errors.As(err, &precedenceErr) {
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
+// bootstrapAssignment returns the config the agent was bootstrapped with, if any
+func (c *ConfigServer) bootstrapAssignment(ctx context.Context, agentID string) (*v1alpha1.ConfigAssignment, error) {
+	if c.bootstrapAssignmentStore == nil {
+		return nil, nil
+	}
+	assignment, err := c.bootstrapAssignmentStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get bootstrap assignment: %w", err)
+	}
+	return assignment, nil
+}
+
+// applyBootstrapAssignment assigns the agent's bootstrap config in place of its current
+// assignment. It returns false if the agent has no bootstrap config, or it no longer exists.
+// Callers must hold policyMu.
+func (c *ConfigServer) applyBootstrapAssignment(ctx context.Context, agentID string, assignment *v1alpha1.ConfigAssignment) (bool, error) {
+	bootstrap, err := c.bootstrapAssignment(ctx, agentID)
+	if err != nil {
+		return false, err
+	}
+	if bootstrap.GetConfigId() == "" {
+		return false, nil
+	}
+	if assignment.GetSource() == v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP && assignment.GetConfigId() == bootstrap.GetConfigId() {
+		return true, nil
+	}
+	config, err := c.configStore.Get(ctx, bootstrap.GetConfigId())
+	if grpcutil.IsErrorNotFound(err) {
+		// the bootstrap config was deleted
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to get bootstrap config %s: %w", bootstrap.GetConfigId(), err)
+	}
+	updated := &v1alpha1.ConfigAssignment{
+		AgentId:    agentID,
+		ConfigId:   bootstrap.GetConfigId(),
+		Source:     v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP,
+		AssignedAt: timestamppb.Now(),
+		ConfigHash: util.HashAgentConfigMap(util.ProtoConfigToAgentConfigMap(config)),
+		Audit:      v1alpha1.NewAuditInfo(auth.SubjectFromContext(ctx), time.Now()),
+	}
+	if err := c.putAssignment(ctx, agentID, config, updated); err != nil {
+		return false, err
+	}
+	c.recordAssigned(ctx, updated, config)
+	c.notifyConfigChange(agentID)
+	c.logger.With("agent_id", agentID, "config_id", bootstrap.GetConfigId()).InfoContext(ctx, "agent fell back to its bootstrap config")
+	events.RecordAgentEvent(ctx, c.eventRecorder, c.agentRepo, events.TypeConfigAssigned, agentID,
+		fmt.Sprintf("bootstrap config %s assigned", bootstrap.GetConfigId()))
+	return true, nil
+}
+
+// clearAssignment removes an assignment that no longer applies, so the agent gets the
+// built-in config. Callers must hold policyMu.
+func (c *ConfigServer) clearAssignment(ctx context.Context, agentID string, assignment *v1alpha1.ConfigAssignment) error {
+	if assignment == nil {
+		return nil
+	}
+	if err := c.deleteAssignment(ctx, agentID); err != nil {
+		return err
+	}
+	c.recordUnassigned(ctx, agentID)
+	c.notifyConfigChange(agentID)
+	message := fmt.Sprintf("config %s unassigned, it no longer applies", assignment.GetConfigId())
+	if assignment.GetSource() == v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY {
+		message = fmt.Sprintf("config unassigned, %s no longer applies", describePolicy(assignment.GetPolicyId()))
+	}
+	c.logger.With("agent_id", agentID, "config_id", assignment.GetConfigId(), "source", assignment.GetSource().String()).InfoContext(ctx, "config assignment no longer applies to agent")
+	events.RecordAgentEvent(ctx, c.eventRecorder, c.agentRepo, events.TypeConfigUnassigned, agentID, message)
+	return nil
+}
+
+// describeCandidate names a candidate in explanations
+func describeCandidate(candidate *v1alpha1.AssignmentCandidate) string {
+	switch candidate.GetSource() {
+	case v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL:
+		return fmt.Sprintf("manual assignment of config %s", candidate.GetConfigId())
+	case v1alpha1.ConfigSource_CONFIG_SOURCE_DEFAULT:
+		return "manual assignment of the default config"
+	case v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY:
+		return describePolicy(candidate.GetPolicyId())
+	case v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP:
+		return fmt.Sprintf("bootstrap config %s", candidate.GetConfigId())
+	default:
+		return "built-in config"
+	}
+}
+
+// GetAssignmentExplanation lists the candidate config sources of an agent in order of
+// precedence, along with why the effective one applies and the others don't
+func (c *ConfigServer) GetAssignmentExplanation(ctx context.Context, req *connect.Request[v1alpha1.GetAssignmentExplanationRequest]) (*connect.Response[v1alpha1.AssignmentExplanation], error) {
+	agentID := req.Msg.GetAgentId()
+	if agentID == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("agent_id must be non-empty"))
+	}
+	agent, err := c.agentRepo.Get(ctx, agentID)
+	if err != nil {
+		if errors.Is(err, agentdomain.ErrAgentNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	assignment, err := c.configAssignmentStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		assignment = nil
+	} else if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	policies, err := c.listPolicies(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	bootstrap, err := c.bootstrapAssignment(ctx, agentID)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var candidates []*v1alpha1.AssignmentCandidate
+	for _, source := range c.precedence {
+		switch source {
+		case v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL:
+			if isManual(assignment) {
+				candidates = append(candidates, &v1alpha1.AssignmentCandidate{
+					Source:   assignment.GetSource(),
+					ConfigId: assignment.GetConfigId(),
+				})
+			}
+		case v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY:
+			for _, policy := range matchingPolicies(agent, policies) {
+				candidates = append(candidates, &v1alpha1.AssignmentCandidate{
+					Source:   v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY,
+					ConfigId: policy.GetConfigId(),
+					PolicyId: policy.GetId(),
+				})
+			}
+		case v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP:
+			if bootstrap.GetConfigId() == "" {
+				continue
+			}
+			candidate := &v1alpha1.AssignmentCandidate{
+				Source:   v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP,
+				ConfigId: bootstrap.GetConfigId(),
+			}
+			if _, err := c.configStore.Get(ctx, bootstrap.GetConfigId()); grpcutil.IsErrorNotFound(err) {
+				candidate.Reason = fmt.Sprintf("bootstrap config %s no longer exists", bootstrap.GetConfigId())
+			} else if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+	candidates = append(candidates, &v1alpha1.AssignmentCandidate{
+		Source: v1alpha1.ConfigSource_CONFIG_SOURCE_FALLBACK,
+	})
+
+	resp := &v1alpha1.AssignmentExplanation{
+		AgentId:           agentID,
+		EffectiveSource:   v1alpha1.ConfigSource_CONFIG_SOURCE_FALLBACK,
+		EffectiveConfigId: assignment.GetConfigId(),
+		Candidates:        candidates,
+		Precedence:        slices.Clone(c.precedence),
+	}
+	if assignment != nil {
+		resp.EffectiveSource = assignment.GetSource()
+	}
+
+	var winner *v1alpha1.AssignmentCandidate
+	for _, candidate := range candidates {
+		candidate.Effective = resp.EffectiveSource == candidate.GetSource() &&
+			resp.EffectiveConfigId == candidate.GetConfigId() &&
+			assignment.GetPolicyId() == candidate.GetPolicyId()
+		switch {
+		case candidate.GetReason() != "":
+			// unusable, e.g. the config no longer exists
+		case winner != nil:
+			candidate.Reason = "overridden by " + describeCandidate(winner)
+		default:
+			winner = candidate
+			candidate.Reason = c.winningReason(candidate)
+		}
+	}
+	if !winner.GetEffective() {
+		winner.Reason += ", but is not applied yet"
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (c *ConfigServer) winningReason(candidate *v1alpha1.AssignmentCandidate) string {
+	source := candidate.GetSource()
+	if source == v1alpha1.ConfigSource_CONFIG_SOURCE_DEFAULT {
+		source = v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL
+	}
+	i := slices.Index(c.precedence, source)
+	var higher, lower []string
+	for j, s := range c.precedence {
+		switch {
+		case j < i:
+			higher = append(higher, sourceNames[s][0])
+		case j > i:
+			lower = append(lower, sourceNames[s][1])
+		}
+	}
+	switch {
+	case source == v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY:
+		return "highest precedence policy matching the agent's labels"
+	case i < 0:
+		return "nothing is assigned, so the built-in config is used"
+	case len(higher) == 0:
+		return fmt.Sprintf("%s take precedence over %s", sourceNames[source][1], strings.Join(lower, " and "))
+	default:
+		return fmt.Sprintf("no %s applies, so the %s is used", strings.Join(higher, " or "), sourceNames[source][0])
+	}
+}
+
+// sourceNames are the singular and plural names of the ranked sources in explanations
+var sourceNames = map[v1alpha1.ConfigSource][2]string{
+	v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL:    {"manual assignment", "manual assignments"},
+	v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY:    {"policy", "policies"},
+	v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP: {"bootstrap config", "bootstrap configs"},
+}
@@ -0,0 +1,171 @@
+package otelconfig_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/otelconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// candidateSummary is the part of an AssignmentCandidate that matters for precedence
+type candidateSummary struct {
+	Source    v1alpha1.ConfigSource
+	ID        string
+	Effective bool
+}
+
+func TestAssignmentPrecedence(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	h.createTestAgent(ctx, t, "web-1", map[string]string{"role": "web"})
+	h.createTestConfig(ctx, t, "boot", "receivers: {}")
+	h.createTestConfig(ctx, t, "web", "receivers: {otlp: {}}")
+	h.createTestConfig(ctx, t, "pinned", "exporters: {}")
+
+	// as recorded by the bootstrap server
+	bootstrap := &v1alpha1.ConfigAssignment{AgentId: "web-1", ConfigId: "boot", Source: v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP}
+	require.NoError(t, h.BootstrapAssignmentStore.Put(ctx, "web-1", bootstrap))
+	require.NoError(t, h.ConfigAssignmentStore.Put(ctx, "web-1", bootstrap))
+
+	explain := func() *v1alpha1.AssignmentExplanation {
+		t.Helper()
+		resp, err := h.ConfigServer.GetAssignmentExplanation(ctx, connect.NewRequest(&v1alpha1.GetAssignmentExplanationRequest{AgentId: "web-1"}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+	summarize := func(explanation *v1alpha1.AssignmentExplanation) []candidateSummary {
+		var summaries []candidateSummary
+		for _, c := range explanation.GetCandidates() {
+			id := c.GetConfigId()
+			if c.GetPolicyId() != "" {
+				id = c.GetPolicyId()
+			}
+			summaries = append(summaries, candidateSummary{Source: c.GetSource(), ID: id, Effective: c.GetEffective()})
+		}
+		return summaries
+	}
+	var (
+		manual   = v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL
+		policy   = v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY
+		boot     = v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP
+		fallback = v1alpha1.ConfigSource_CONFIG_SOURCE_FALLBACK
+	)
+
+	explanation := explain()
+	assert.Equal(t, boot, explanation.GetEffectiveSource())
+	assert.Equal(t, otelconfig.DefaultPrecedence, explanation.GetPrecedence())
+	assert.Equal(t, []candidateSummary{{boot, "boot", true}, {fallback, "", false}}, summarize(explanation))
+	assert.Equal(t, "overridden by bootstrap config boot", explanation.GetCandidates()[1].GetReason())
+
+	// policies outrank the bootstrap config
+	_, err := h.ConfigServer.PutAssignmentPolicy(ctx, connect.NewRequest(&v1alpha1.PutAssignmentPolicyRequest{
+		Policy: &v1alpha1.AssignmentPolicy{Id: "web", Selector: map[string]string{"role": "web"}, ConfigId: "web"},
+	}))
+	require.NoError(t, err)
+	explanation = explain()
+	assert.Equal(t, "web", explanation.GetEffectiveConfigId())
+	assert.Equal(t, []candidateSummary{{policy, "web", true}, {boot, "boot", false}, {fallback, "", false}}, summarize(explanation))
+	assert.Equal(t, "overridden by policy web", explanation.GetCandidates()[1].GetReason())
+
+	// manual assignments outrank policies
+	_, err = h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: "web-1", ConfigId: "pinned"}))
+	require.NoError(t, err)
+	explanation = explain()
+	assert.Equal(t, manual, explanation.GetEffectiveSource())
+	assert.Equal(t, []candidateSummary{{manual, "pinned", true}, {policy, "web", false}, {boot, "boot", false}, {fallback, "", false}}, summarize(explanation))
+	assert.Equal(t, "overridden by manual assignment of config pinned", explanation.GetCandidates()[1].GetReason())
+
+	// removing an assignment hands the agent to the next candidate
+	_, err = h.ConfigServer.UnassignConfig(ctx, connect.NewRequest(&v1alpha1.UnassignConfigRequest{AgentId: "web-1"}))
+	require.NoError(t, err)
+	assert.Equal(t, policy, explain().GetEffectiveSource())
+
+	_, err = h.ConfigServer.DeleteAssignmentPolicy(ctx, connect.NewRequest(&v1alpha1.AssignmentPolicyReference{Id: "web"}))
+	require.NoError(t, err)
+	explanation = explain()
+	assert.Equal(t, boot, explanation.GetEffectiveSource())
+	assert.Equal(t, "boot", explanation.GetEffectiveConfigId())
+	assigned, err := h.AssignedConfigStore.Get(ctx, "web-1")
+	require.NoError(t, err)
+	assert.Equal(t, "receivers: {}", string(assigned.GetConfig()))
+
+	_, err = h.ConfigServer.GetAssignmentExplanation(ctx, connect.NewRequest(&v1alpha1.GetAssignmentExplanationRequest{AgentId: "missing"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestAssignmentPrecedence_Configured(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	var (
+		manual = v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL
+		policy = v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY
+		boot   = v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP
+	)
+	// labels override manual assignments
+	require.NoError(t, h.ConfigServer.SetAssignmentPrecedence([]v1alpha1.ConfigSource{policy, manual, boot}))
+	assert.Error(t, h.ConfigServer.SetAssignmentPrecedence([]v1alpha1.ConfigSource{policy, manual}))
+
+	h.createTestAgent(ctx, t, "web-1", map[string]string{"role": "web"})
+	h.createTestConfig(ctx, t, "boot", "receivers: {}")
+	h.createTestConfig(ctx, t, "web", "receivers: {otlp: {}}")
+	h.createTestConfig(ctx, t, "pinned", "exporters: {}")
+	bootstrap := &v1alpha1.ConfigAssignment{AgentId: "web-1", ConfigId: "boot", Source: boot}
+	require.NoError(t, h.BootstrapAssignmentStore.Put(ctx, "web-1", bootstrap))
+	require.NoError(t, h.ConfigAssignmentStore.Put(ctx, "web-1", bootstrap))
+
+	explain := func() *v1alpha1.AssignmentExplanation {
+		t.Helper()
+		resp, err := h.ConfigServer.GetAssignmentExplanation(ctx, connect.NewRequest(&v1alpha1.GetAssignmentExplanationRequest{AgentId: "web-1"}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+
+	_, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: "web-1", ConfigId: "pinned"}))
+	require.NoError(t, err)
+	explanation := explain()
+	assert.Equal(t, []v1alpha1.ConfigSource{policy, manual, boot}, explanation.GetPrecedence())
+	assert.Equal(t, manual, explanation.GetEffectiveSource())
+	assert.Equal(t, "no policy applies, so the manual assignment is used", explanation.GetCandidates()[0].GetReason())
+
+	// the policy replaces the manual assignment
+	_, err = h.ConfigServer.PutAssignmentPolicy(ctx, connect.NewRequest(&v1alpha1.PutAssignmentPolicyRequest{
+		Policy: &v1alpha1.AssignmentPolicy{Id: "web", Selector: map[string]string{"role": "web"}, ConfigId: "web"},
+	}))
+	require.NoError(t, err)
+	explanation = explain()
+	assert.Equal(t, policy, explanation.GetEffectiveSource())
+	assert.Equal(t, "web", explanation.GetEffectiveConfigId())
+	assert.Equal(t, "overridden by policy web", explanation.GetCandidates()[1].GetReason())
+
+	_, err = h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{AgentId: "web-1", ConfigId: "pinned"}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	assert.ErrorContains(t, err, "policy web takes precedence over manual assignments")
+
+	_, err = h.ConfigServer.DeleteAssignmentPolicy(ctx, connect.NewRequest(&v1alpha1.AssignmentPolicyReference{Id: "web"}))
+	require.NoError(t, err)
+	explanation = explain()
+	assert.Equal(t, boot, explanation.GetEffectiveSource())
+	assert.Equal(t, "no policy or manual assignment applies, so the bootstrap config is used", explanation.GetCandidates()[0].GetReason())
+}
+
+func TestParsePrecedence(t *testing.T) {
+	precedence, err := otelconfig.ParsePrecedence("")
+	require.NoError(t, err)
+	assert.Equal(t, otelconfig.DefaultPrecedence, precedence)
+
+	precedence, err = otelconfig.ParsePrecedence(" Bootstrap, policy,manual")
+	require.NoError(t, err)
+	assert.Equal(t, []v1alpha1.ConfigSource{
+		v1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP,
+		v1alpha1.ConfigSource_CONFIG_SOURCE_POLICY,
+		v1alpha1.ConfigSource_CONFIG_SOURCE_MANUAL,
+	}, precedence)
+
+	for _, invalid := range []string{"policy,manual", "policy,manual,manual", "policy,manual,bootstrap,manual", "policy,manual,fallback"} {
+		_, err := otelconfig.ParsePrecedence(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
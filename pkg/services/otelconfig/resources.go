@@ -0,0 +1,49 @@
+package otelconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+)
+
+func validateResources(resources *v1alpha1.ResourceRequirements) error {
+	cpu := resources.GetExpectedCpuCores()
+	if cpu < 0 || math.IsNaN(cpu) || math.IsInf(cpu, 0) {
+		return errors.New("expected_cpu_cores must be a non-negative number")
+	}
+	return nil
+}
+
+// capacityWarnings returns the resource requirements exceeding the capacity of the
+// agent's host. Resources the agent doesn't report aren't checked.
+func capacityWarnings(agent *agentdomain.Agent, resources *v1alpha1.ResourceRequirements) []string {
+	var warnings []string
+	labels := agent.Labels()
+	if required := resources.GetMinMemoryBytes(); required > 0 {
+		if memory, err := strconv.ParseUint(labels[supervisor.AttributeHostMemoryBytes], 10, 64); err == nil && memory < required {
+			warnings = append(warnings, fmt.Sprintf("config requires %d bytes of memory, host has %d bytes", required, memory))
+		}
+	}
+	if expected := resources.GetExpectedCpuCores(); expected > 0 {
+		if cpus, err := strconv.Atoi(labels[supervisor.AttributeHostCPUCount]); err == nil && float64(cpus) < expected {
+			warnings = append(warnings, fmt.Sprintf("config expects %g CPU cores, host has %d", expected, cpus))
+		}
+	}
+	return warnings
+}
+
+// CapacityWarnings returns the resource requirements of the config exceeding the capacity
+// the agent reports for its host
+func (c *ConfigServer) CapacityWarnings(ctx context.Context, agent *agentdomain.Agent, configID string) ([]string, error) {
+	config, err := c.configStore.Get(ctx, configID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config %s: %w", configID, err)
+	}
+	return capacityWarnings(agent, config.GetMetadata().GetResources()), nil
+}
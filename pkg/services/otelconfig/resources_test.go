@@ -0,0 +1,65 @@
+package otelconfig_test
+
+import (
+	"math"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/supervisor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceRequirements(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+
+	put := func(id string, resources *v1alpha1.ResourceRequirements) error {
+		t.Helper()
+		_, err := h.ConfigServer.PutConfig(ctx, connect.NewRequest(&v1alpha1.PutConfigRequest{
+			Ref: &v1alpha1.ConfigReference{Id: id},
+			Config: &v1alpha1.Config{
+				Config:   []byte("receivers: {otlp: {}}"),
+				Metadata: &v1alpha1.ConfigMetadata{Resources: resources},
+			},
+		}))
+		return err
+	}
+	err := put("invalid", &v1alpha1.ResourceRequirements{ExpectedCpuCores: -1})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	err = put("invalid", &v1alpha1.ResourceRequirements{ExpectedCpuCores: math.NaN()})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	require.NoError(t, put("heavy", &v1alpha1.ResourceRequirements{
+		MinMemoryBytes:   4 << 30,
+		ExpectedCpuCores: 2.5,
+	}))
+
+	h.createTestAgent(ctx, t, "small", map[string]string{
+		supervisor.AttributeHostCPUCount:    "2",
+		supervisor.AttributeHostMemoryBytes: "2147483648",
+	})
+	h.createTestAgent(ctx, t, "large", map[string]string{
+		supervisor.AttributeHostCPUCount:    "8",
+		supervisor.AttributeHostMemoryBytes: "17179869184",
+	})
+	// agents not reporting their capacity aren't checked
+	h.createTestAgent(ctx, t, "unknown", nil)
+
+	assign := func(agentID string) *v1alpha1.AssignConfigResponse {
+		t.Helper()
+		resp, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{
+			AgentId:  agentID,
+			ConfigId: "heavy",
+		}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+	small := assign("small")
+	assert.True(t, small.GetSuccess(), "the config is assigned despite the warnings")
+	require.Len(t, small.GetWarnings(), 2)
+	assert.Contains(t, small.GetWarnings()[0], "4294967296 bytes of memory")
+	assert.Contains(t, small.GetWarnings()[1], "2.5 CPU cores")
+	assert.Empty(t, assign("large").GetWarnings())
+	assert.Empty(t, assign("unknown").GetWarnings())
+}
@@ -0,0 +1,133 @@
+package otelconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/events"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SetKnownGoodStore sets the store of the last config each agent applied, keyed by agent
+// ID, enabling automatic rollbacks
+func (c *ConfigServer) SetKnownGoodStore(store storage.KeyValue[*v1alpha1.KnownGoodConfig]) {
+	c.knownGoodStore = store
+}
+
+var errRollbackDisabled = connect.NewError(connect.CodeUnimplemented, errors.New("automatic rollback is not enabled"))
+
+// ConfigApplied records the config assigned to an agent as its last known-good config,
+// if hash is the hash of the assigned config the agent reported applying
+func (c *ConfigServer) ConfigApplied(ctx context.Context, agentID string, hash []byte) error {
+	c.recordReported(ctx, agentID, v1alpha1.AgentConfigChange_AGENT_CONFIG_CHANGE_APPLIED, hash, "")
+	if c.knownGoodStore == nil {
+		return nil
+	}
+	c.rollbackMu.Lock()
+	defer c.rollbackMu.Unlock()
+
+	assignment, err := c.configAssignmentStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !bytes.Equal(assignment.GetConfigHash(), hash) {
+		return nil
+	}
+	known, err := c.knownGoodStore.Get(ctx, agentID)
+	if err == nil && bytes.Equal(known.GetAssignment().GetConfigHash(), hash) {
+		return nil
+	} else if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return err
+	}
+	config, err := c.assignedConfigStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return c.knownGoodStore.Put(ctx, agentID, &v1alpha1.KnownGoodConfig{
+		Assignment: assignment,
+		Config:     config,
+		AppliedAt:  timestamppb.Now(),
+	})
+}
+
+// ConfigFailed rolls an agent back to its last known-good config if it failed to apply an
+// assignment opted into automatic rollback, and reports whether it did. hash is the config
+// hash the agent reported with the failure, either of the config it rejected or of the
+// config it kept running. The caller is responsible for pushing the restored config.
+func (c *ConfigServer) ConfigFailed(ctx context.Context, agentID string, hash []byte, errorMessage string) (bool, error) {
+	c.recordReported(ctx, agentID, v1alpha1.AgentConfigChange_AGENT_CONFIG_CHANGE_FAILED, hash, errorMessage)
+	if c.knownGoodStore == nil {
+		return false, nil
+	}
+	c.rollbackMu.Lock()
+	defer c.rollbackMu.Unlock()
+
+	assignment, err := c.configAssignmentStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if !assignment.GetAutoRollback() {
+		return false, nil
+	}
+	known, err := c.knownGoodStore.Get(ctx, agentID)
+	if grpcutil.IsErrorNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	knownHash := known.GetAssignment().GetConfigHash()
+	if bytes.Equal(knownHash, assignment.GetConfigHash()) {
+		// the assigned config is the known-good one, there is nothing to roll back to
+		return false, nil
+	}
+	if !bytes.Equal(hash, assignment.GetConfigHash()) && !bytes.Equal(hash, knownHash) {
+		// the failure is about another config
+		return false, nil
+	}
+
+	now := timestamppb.Now()
+	restored := proto.Clone(known.GetAssignment()).(*v1alpha1.ConfigAssignment)
+	restored.AssignedAt = now
+	restored.AutoRollback = false
+	restored.Rollback = &v1alpha1.ConfigRollback{
+		FailedConfigId:   assignment.GetConfigId(),
+		FailedConfigHash: assignment.GetConfigHash(),
+		ErrorMessage:     errorMessage,
+		RolledBackAt:     now,
+	}
+	if err := c.putAssignment(ctx, agentID, known.GetConfig(), restored); err != nil {
+		return false, err
+	}
+	c.recordAssigned(ctx, restored, known.GetConfig())
+
+	c.logger.With(
+		"agent_id", agentID,
+		"failed_config_id", assignment.GetConfigId(),
+		"config_id", restored.GetConfigId(),
+		"err", errorMessage,
+	).WarnContext(ctx, "agent failed to apply its config, rolled back to its last known-good config")
+	events.RecordAgentEvent(ctx, c.eventRecorder, c.agentRepo, events.TypeConfigRolledBack, agentID,
+		fmt.Sprintf("%s failed to apply, rolled back to %s: %s", describeAssignedConfig(assignment), describeAssignedConfig(restored), errorMessage))
+	return true, nil
+}
+
+// describeAssignedConfig names the config of an assignment in messages
+func describeAssignedConfig(assignment *v1alpha1.ConfigAssignment) string {
+	if assignment.GetConfigId() == "" {
+		return "default config"
+	}
+	return "config " + assignment.GetConfigId()
+}
@@ -0,0 +1,100 @@
+package otelconfig_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoRollback(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	h.createTestAgent(ctx, t, "agent-1", nil)
+	good := h.createTestConfig(ctx, t, "good", "receivers: {otlp: {}}")
+	h.createTestConfig(ctx, t, "bad", "receivers: {bogus: {}}")
+	hash := func(config *v1alpha1.Config) []byte {
+		return util.HashAgentConfigMap(util.ProtoConfigToAgentConfigMap(config))
+	}
+	assign := func(configID string, autoRollback bool) *v1alpha1.ConfigAssignment {
+		t.Helper()
+		_, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{
+			AgentId:      "agent-1",
+			ConfigId:     configID,
+			AutoRollback: autoRollback,
+		}))
+		require.NoError(t, err)
+		assignment, err := h.ConfigAssignmentStore.Get(ctx, "agent-1")
+		require.NoError(t, err)
+		return assignment
+	}
+
+	// nothing to roll back to until the agent applied a config
+	bad := assign("bad", true)
+	rolledBack, err := h.ConfigServer.ConfigFailed(ctx, "agent-1", bad.GetConfigHash(), "unknown receiver")
+	require.NoError(t, err)
+	assert.False(t, rolledBack)
+
+	assign("good", false)
+	require.NoError(t, h.ConfigServer.ConfigApplied(ctx, "agent-1", hash(good)))
+	known, err := h.KnownGoodStore.Get(ctx, "agent-1")
+	require.NoError(t, err)
+	assert.Equal(t, "good", known.GetAssignment().GetConfigId())
+
+	// agents failing to apply assignments without auto rollback keep them
+	bad = assign("bad", false)
+	rolledBack, err = h.ConfigServer.ConfigFailed(ctx, "agent-1", bad.GetConfigHash(), "unknown receiver")
+	require.NoError(t, err)
+	assert.False(t, rolledBack)
+
+	// failures of other configs are ignored
+	bad = assign("bad", true)
+	rolledBack, err = h.ConfigServer.ConfigFailed(ctx, "agent-1", []byte("other"), "unknown receiver")
+	require.NoError(t, err)
+	assert.False(t, rolledBack)
+
+	rolledBack, err = h.ConfigServer.ConfigFailed(ctx, "agent-1", hash(good), "unknown receiver")
+	require.NoError(t, err)
+	assert.True(t, rolledBack)
+	assignment, err := h.ConfigAssignmentStore.Get(ctx, "agent-1")
+	require.NoError(t, err)
+	assert.Equal(t, "good", assignment.GetConfigId())
+	assert.Equal(t, hash(good), assignment.GetConfigHash())
+	assert.False(t, assignment.GetAutoRollback())
+	require.NotNil(t, assignment.GetRollback())
+	assert.Equal(t, "bad", assignment.GetRollback().GetFailedConfigId())
+	assert.Equal(t, bad.GetConfigHash(), assignment.GetRollback().GetFailedConfigHash())
+	assert.Equal(t, "unknown receiver", assignment.GetRollback().GetErrorMessage())
+	assigned, err := h.AssignedConfigStore.Get(ctx, "agent-1")
+	require.NoError(t, err)
+	assert.Equal(t, good.GetConfig(), assigned.GetConfig())
+
+	// the restored assignment is not rolled back again
+	rolledBack, err = h.ConfigServer.ConfigFailed(ctx, "agent-1", hash(good), "unknown receiver")
+	require.NoError(t, err)
+	assert.False(t, rolledBack)
+
+	status, err := h.ConfigServer.GetConfigStatus(ctx, connect.NewRequest(&v1alpha1.GetConfigStatusRequest{AgentId: "agent-1"}))
+	require.NoError(t, err)
+	assert.Equal(t, "bad", status.Msg.GetAssignment().GetRollback().GetFailedConfigId())
+}
+
+func TestAutoRollback_Disabled(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	h.ConfigServer.SetKnownGoodStore(nil)
+	h.createTestAgent(ctx, t, "agent-1", nil)
+	h.createTestConfig(ctx, t, "good", "receivers: {otlp: {}}")
+
+	_, err := h.ConfigServer.AssignConfig(ctx, connect.NewRequest(&v1alpha1.AssignConfigRequest{
+		AgentId:      "agent-1",
+		ConfigId:     "good",
+		AutoRollback: true,
+	}))
+	assert.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+	assert.Equal(t, connect.CodeUnimplemented, connect.CodeOf(h.ConfigServer.AssignConfigToAgent(ctx, "agent-1", "good", true)))
+	require.NoError(t, h.ConfigServer.AssignConfigToAgent(ctx, "agent-1", "good", false))
+}
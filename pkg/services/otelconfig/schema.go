@@ -0,0 +1,34 @@
+package otelconfig
+
+// knownComponents are the component types of the core and contrib collector distributions,
+// by kind. Configs may use other components from custom distributions, so unknown types
+// are only reported as warnings.
+var knownComponents = map[string][]string{
+	"receivers": {
+		"awsxray", "carbon", "collectd", "docker_stats", "filelog", "fluentforward", "hostmetrics",
+		"httpcheck", "jaeger", "journald", "k8s_cluster", "k8s_events", "k8sobjects", "kafka",
+		"kafkametrics", "kubeletstats", "mysql", "nop", "opencensus", "otlp", "postgresql",
+		"prometheus", "receiver_creator", "redis", "sqlquery", "statsd", "syslog", "tcplog",
+		"udplog", "windowseventlog", "zipkin",
+	},
+	"processors": {
+		"attributes", "batch", "cumulativetodelta", "deltatorate", "filter", "groupbyattrs",
+		"k8sattributes", "memory_limiter", "metricstransform", "probabilistic_sampler",
+		"redaction", "resource", "resourcedetection", "routing", "span", "tail_sampling",
+		"transform",
+	},
+	"exporters": {
+		"awsemf", "awsxray", "azuremonitor", "clickhouse", "datadog", "debug", "elasticsearch",
+		"file", "googlecloud", "kafka", "loadbalancing", "logging", "loki", "nop", "otlp",
+		"otlphttp", "prometheus", "prometheusremotewrite", "splunk_hec", "zipkin",
+	},
+	"connectors": {
+		"count", "exceptions", "failover", "forward", "roundrobin", "routing", "servicegraph",
+		"spanmetrics",
+	},
+	"extensions": {
+		"basicauth", "bearertokenauth", "docker_observer", "file_storage", "headers_setter",
+		"health_check", "host_observer", "k8s_observer", "memory_ballast", "oauth2client",
+		"oidc", "opamp", "pprof", "zpages",
+	},
+}
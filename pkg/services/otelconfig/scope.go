@@ -0,0 +1,110 @@
+package otelconfig
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+)
+
+// NewConfigScopeInterceptor returns an interceptor enforcing the config ownership policy
+// on RPCs that modify or assign configs. Read-only RPCs are not scoped.
+func NewConfigScopeInterceptor(
+	logger *slog.Logger,
+	policy auth.ConfigPolicy,
+	configStore storage.KeyValue[*v1alpha1.Config],
+) connect.Interceptor {
+	s := &configScope{
+		logger:      logger,
+		policy:      policy,
+		configStore: configStore,
+	}
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if s.policy.Enabled() {
+				if err := s.authorize(ctx, req); err != nil {
+					return nil, err
+				}
+			}
+			return next(ctx, req)
+		}
+	})
+}
+
+type configScope struct {
+	logger      *slog.Logger
+	policy      auth.ConfigPolicy
+	configStore storage.KeyValue[*v1alpha1.Config]
+}
+
+func (s *configScope) authorize(ctx context.Context, req connect.AnyRequest) error {
+	var configID string
+	var newTags []string
+	switch msg := req.Any().(type) {
+	case *v1alpha1.PutConfigRequest:
+		configID = msg.GetRef().GetId()
+		newTags = msg.GetConfig().GetMetadata().GetTags()
+	case *v1alpha1.SaveConfigEditRequest:
+		configID = msg.GetRef().GetId()
+		newTags = msg.GetConfig().GetMetadata().GetTags()
+	case *v1alpha1.ConfigReference:
+		if req.Spec().Procedure != v1alpha1connect.ConfigServiceDeleteConfigProcedure {
+			return nil
+		}
+		configID = msg.GetId()
+	case *v1alpha1.AssignConfigRequest:
+		configID = msg.GetConfigId()
+	case *v1alpha1.BatchAssignConfigRequest:
+		configID = msg.GetConfigId()
+	case *v1alpha1.AssignConfigByLabelsRequest:
+		configID = msg.GetConfigId()
+	case *v1alpha1.RollingDeploymentRequest:
+		configID = msg.GetConfigId()
+	case *v1alpha1.PutAssignmentPolicyRequest:
+		configID = msg.GetPolicy().GetConfigId()
+	case *v1alpha1.CreateGroupRequest:
+		configID = msg.GetGroup().GetConfigId()
+	case *v1alpha1.UpdateGroupRequest:
+		configID = msg.GetGroup().GetConfigId()
+	default:
+		return nil
+	}
+	if configID == "" {
+		// let the handler report the invalid argument
+		return nil
+	}
+
+	p := auth.FromContext(ctx)
+	existing, err := s.configStore.Get(ctx, configID)
+	if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get config %s: %w", configID, err))
+	}
+	// the principal must own the existing config, and for puts also the resulting one,
+	// so that configs cannot be taken over by re-tagging them
+	if err == nil && !s.policy.Allowed(p, configID, existing.GetMetadata().GetTags()) {
+		return s.denied(p, req, configID)
+	}
+	if _, ok := req.Any().(*v1alpha1.PutConfigRequest); ok && !s.policy.Allowed(p, configID, newTags) {
+		return s.denied(p, req, configID)
+	}
+	// edits without metadata keep the tags of an existing config
+	if msg, ok := req.Any().(*v1alpha1.SaveConfigEditRequest); ok && (msg.GetConfig().GetMetadata() != nil || err != nil) && !s.policy.Allowed(p, configID, newTags) {
+		return s.denied(p, req, configID)
+	}
+	return nil
+}
+
+func (s *configScope) denied(p *auth.Principal, req connect.AnyRequest, configID string) error {
+	subject := "anonymous"
+	if p != nil {
+		subject = p.Subject
+	}
+	s.logger.With("principal", subject, "procedure", req.Spec().Procedure, "config_id", configID).Warn("config access denied")
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("%s is not allowed to manage config %s", subject, configID))
+}
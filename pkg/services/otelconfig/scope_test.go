@@ -0,0 +1,68 @@
+package otelconfig_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/services/otelconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigScope_PutConfig(t *testing.T) {
+	env := setupTestEnv(t)
+	policy := auth.ConfigPolicy{
+		Scopes: []auth.ConfigScope{
+			{Role: "team-a", Prefixes: []string{"team-a-"}},
+			{Role: "team-b", Tags: []string{"team-b"}},
+		},
+	}
+	interceptor := otelconfig.NewConfigScopeInterceptor(slog.Default(), policy, env.ConfigStore)
+	putConfig := func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return env.ConfigServer.PutConfig(ctx, req.(*connect.Request[v1alpha1.PutConfigRequest]))
+	}
+	put := interceptor.WrapUnary(putConfig)
+
+	alice := auth.NewContext(t.Context(), &auth.Principal{Subject: "alice", Roles: []string{"team-a"}})
+	bob := auth.NewContext(t.Context(), &auth.Principal{Subject: "bob", Roles: []string{"team-b"}})
+
+	newReq := func(id string, tags ...string) *connect.Request[v1alpha1.PutConfigRequest] {
+		return connect.NewRequest(&v1alpha1.PutConfigRequest{
+			Ref: &v1alpha1.ConfigReference{Id: id},
+			Config: &v1alpha1.Config{
+				Config:   []byte("receivers: {}"),
+				Metadata: &v1alpha1.ConfigMetadata{Tags: tags},
+			},
+		})
+	}
+
+	_, err := put(alice, newReq("team-a-logs"))
+	require.NoError(t, err)
+	stored, err := env.ConfigStore.Get(t.Context(), "team-a-logs")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", stored.GetMetadata().GetOwner())
+
+	// bob cannot take over team-a's config by tagging it
+	_, err = put(bob, newReq("team-a-logs", "team-b"))
+	require.Error(t, err)
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// anonymous callers are denied once a policy is configured
+	_, err = put(t.Context(), newReq("team-a-logs"))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	_, err = put(bob, newReq("shared-traces", "team-b"))
+	require.NoError(t, err)
+
+	// the owner is preserved on updates
+	admin := auth.NewContext(t.Context(), &auth.Principal{Subject: "root", Roles: []string{auth.RoleAdmin}})
+	_, err = put(admin, newReq("shared-traces", "team-b"))
+	require.NoError(t, err)
+	stored, err = env.ConfigStore.Get(t.Context(), "shared-traces")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", stored.GetMetadata().GetOwner())
+}
@@ -0,0 +1,71 @@
+package otelconfig
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+)
+
+// NewConfigTenantInterceptor returns an interceptor answering NotFound to the RPCs naming a
+// config of another tenant than the one of the request, see package tenant.
+func NewConfigTenantInterceptor(configStore storage.KeyValue[*v1alpha1.Config]) connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if configID := tenantScopedConfigID(req.Any()); configID != "" {
+				existing, err := configStore.Get(ctx, configID)
+				if err != nil && !grpcutil.IsErrorNotFound(err) {
+					return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get config %s: %w", configID, err))
+				}
+				if err == nil && !tenant.Visible(ctx, existing.GetMetadata().GetTenant()) {
+					return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("config not found: %s", configID))
+				}
+			}
+			return next(ctx, req)
+		}
+	})
+}
+
+// tenantScopedConfigID returns the ID of the config a request reads or changes, if any
+func tenantScopedConfigID(msg any) string {
+	switch msg := msg.(type) {
+	case *v1alpha1.ConfigReference:
+		return msg.GetId()
+	case *v1alpha1.PutConfigRequest:
+		return msg.GetRef().GetId()
+	case *v1alpha1.SaveConfigEditRequest:
+		return msg.GetRef().GetId()
+	case *v1alpha1.AssignConfigRequest:
+		return msg.GetConfigId()
+	case *v1alpha1.BatchAssignConfigRequest:
+		return msg.GetConfigId()
+	case *v1alpha1.AssignConfigByLabelsRequest:
+		return msg.GetConfigId()
+	case *v1alpha1.RollingDeploymentRequest:
+		return msg.GetConfigId()
+	case *v1alpha1.ListConfigAssignmentsRequest:
+		return msg.GetConfigId()
+	case *v1alpha1.CheckConfigCompatibilityRequest:
+		return msg.GetConfigId()
+	case *v1alpha1.PutAssignmentPolicyRequest:
+		return msg.GetPolicy().GetConfigId()
+	case *v1alpha1.CreateGroupRequest:
+		return msg.GetGroup().GetConfigId()
+	case *v1alpha1.UpdateGroupRequest:
+		return msg.GetGroup().GetConfigId()
+	}
+	return ""
+}
+
+// checkTenant returns an error if a config is assigned to an agent of another tenant
+func checkTenant(agent *agentdomain.Agent, configID string, config *v1alpha1.Config) error {
+	if configTenant := config.GetMetadata().GetTenant(); configTenant != agent.Tenant {
+		return fmt.Errorf("config %s is in tenant %q, agent %s is in tenant %q", configID, configTenant, agent.ID, agent.Tenant)
+	}
+	return nil
+}
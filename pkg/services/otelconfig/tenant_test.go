@@ -0,0 +1,66 @@
+package otelconfig_test
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/services/otelconfig"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigTenants(t *testing.T) {
+	env := setupTestEnv(t)
+	teamA := tenant.NewContext(t.Context(), "team-a")
+	teamB := tenant.NewContext(t.Context(), "team-b")
+
+	put := func(ctx context.Context, id string) error {
+		_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&v1alpha1.PutConfigRequest{
+			Ref:    &v1alpha1.ConfigReference{Id: id},
+			Config: &v1alpha1.Config{Config: []byte("receivers: {}")},
+		}))
+		return err
+	}
+	require.NoError(t, put(teamA, "logs"))
+	stored, err := env.ConfigStore.Get(t.Context(), "logs")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", stored.GetMetadata().GetTenant())
+	require.NoError(t, put(teamB, "traces"))
+
+	list, err := env.ConfigServer.ListConfigs(teamA, connect.NewRequest(&v1alpha1.ListConfigsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.GetConfigs(), 1)
+	assert.Equal(t, "logs", list.Msg.GetConfigs()[0].GetId())
+
+	// configs of other tenants are not found
+	interceptor := otelconfig.NewConfigTenantInterceptor(env.ConfigStore)
+	get := interceptor.WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		return env.ConfigServer.GetConfig(ctx, req.(*connect.Request[v1alpha1.ConfigReference]))
+	})
+	_, err = get(teamB, connect.NewRequest(&v1alpha1.ConfigReference{Id: "logs"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = get(teamA, connect.NewRequest(&v1alpha1.ConfigReference{Id: "logs"}))
+	require.NoError(t, err)
+
+	// updates keep the tenant of the config
+	require.NoError(t, put(t.Context(), "logs"))
+	stored, err = env.ConfigStore.Get(t.Context(), "logs")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", stored.GetMetadata().GetTenant())
+
+	// configs are only assigned to the agents of their tenant
+	require.NoError(t, env.AgentRepo.RegisterInTenant(t.Context(), "agent-b", "agent-b", "team-b"))
+	_, err = env.ConfigServer.AssignConfig(t.Context(), connect.NewRequest(&v1alpha1.AssignConfigRequest{
+		AgentId:  "agent-b",
+		ConfigId: "logs",
+	}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	_, err = env.ConfigServer.AssignConfig(t.Context(), connect.NewRequest(&v1alpha1.AssignConfigRequest{
+		AgentId:  "agent-b",
+		ConfigId: "traces",
+	}))
+	require.NoError(t, err)
+}
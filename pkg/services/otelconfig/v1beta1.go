@@ -0,0 +1,51 @@
+package otelconfig
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1beta1"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1beta1/v1beta1connect"
+	"github.com/otelfleet/otelfleet/pkg/apiversion"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// configServiceV1beta1 serves the v1beta1 config API with the v1alpha1 handlers
+type configServiceV1beta1 struct {
+	configs *ConfigServer
+	shim    *apiversion.Shim
+}
+
+var _ v1beta1connect.ConfigServiceHandler = (*configServiceV1beta1)(nil)
+
+func (s *configServiceV1beta1) PutConfig(ctx context.Context, req *connect.Request[v1beta1.PutConfigRequest]) (*connect.Response[emptypb.Empty], error) {
+	return apiversion.Unary[emptypb.Empty](ctx, s.shim, req, s.configs.PutConfig)
+}
+
+func (s *configServiceV1beta1) GetConfig(ctx context.Context, req *connect.Request[v1beta1.ConfigReference]) (*connect.Response[v1beta1.Config], error) {
+	return apiversion.Unary[v1beta1.Config](ctx, s.shim, req, s.configs.GetConfig)
+}
+
+func (s *configServiceV1beta1) DeleteConfig(ctx context.Context, req *connect.Request[v1beta1.ConfigReference]) (*connect.Response[emptypb.Empty], error) {
+	return apiversion.Unary[emptypb.Empty](ctx, s.shim, req, s.configs.DeleteConfig)
+}
+
+func (s *configServiceV1beta1) ListConfigs(ctx context.Context, req *connect.Request[v1beta1.ListConfigsRequest]) (*connect.Response[v1beta1.ListConfigsResponse], error) {
+	return apiversion.Unary[v1beta1.ListConfigsResponse](ctx, s.shim, req, s.configs.ListConfigs)
+}
+
+func (s *configServiceV1beta1) AssignConfig(ctx context.Context, req *connect.Request[v1beta1.AssignConfigRequest]) (*connect.Response[v1beta1.AssignConfigResponse], error) {
+	return apiversion.Unary[v1beta1.AssignConfigResponse](ctx, s.shim, req, s.configs.AssignConfig)
+}
+
+func (s *configServiceV1beta1) GetAgentConfig(ctx context.Context, req *connect.Request[v1beta1.GetAgentConfigRequest]) (*connect.Response[v1beta1.GetAgentConfigResponse], error) {
+	return apiversion.Unary[v1beta1.GetAgentConfigResponse](ctx, s.shim, req, s.configs.GetAgentConfig)
+}
+
+func (s *configServiceV1beta1) UnassignConfig(ctx context.Context, req *connect.Request[v1beta1.UnassignConfigRequest]) (*connect.Response[v1beta1.UnassignConfigResponse], error) {
+	return apiversion.Unary[v1beta1.UnassignConfigResponse](ctx, s.shim, req, s.configs.UnassignConfig)
+}
+
+func (s *configServiceV1beta1) ListConfigAssignments(ctx context.Context, req *connect.Request[v1beta1.ListConfigAssignmentsRequest]) (*connect.Response[v1beta1.ListConfigAssignmentsResponse], error) {
+	return apiversion.Unary[v1beta1.ListConfigAssignmentsResponse](ctx, s.shim, req, s.configs.ListConfigAssignments)
+}
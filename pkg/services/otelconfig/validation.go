@@ -0,0 +1,331 @@
+package otelconfig
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gopkg.in/yaml.v3"
+)
+
+// rule IDs of the findings reported by ValidateConfigDetailed
+const (
+	ruleYAMLSyntax          = "yaml-syntax"
+	ruleEmptyConfig         = "empty-config"
+	ruleInvalidStructure    = "invalid-structure"
+	ruleUnknownSection      = "unknown-section"
+	ruleMissingService      = "missing-service"
+	ruleUnknownPipelineType = "unknown-pipeline-type"
+	ruleEmptyPipeline       = "empty-pipeline"
+	ruleUndefinedComponent  = "undefined-component"
+	ruleUnknownComponent    = "unknown-component-type"
+	ruleUnusedComponent     = "unused-component"
+	ruleComponentPolicy     = "component-policy"
+	ruleDeprecatedComponent = "deprecated-component"
+	ruleRemovedComponent    = "removed-component"
+)
+
+// pipelineTypes are the signals a collector pipeline can carry
+var pipelineTypes = []string{"traces", "metrics", "logs", "profiles"}
+
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// ValidateConfigDetailed returns every finding about the config, positioned for editors.
+// Unlike ValidConfig an invalid config is not an error, it is reported in the findings.
+func (c *ConfigServer) ValidateConfigDetailed(ctx context.Context, req *connect.Request[v1alpha1.ValidateConfigDetailedRequest]) (*connect.Response[v1alpha1.ConfigValidationResult], error) {
+	var agent *agentdomain.Agent
+	if agentID := req.Msg.GetAgentId(); agentID != "" {
+		var err error
+		agent, err = c.agentRepo.Get(ctx, agentID)
+		if errors.Is(err, agentdomain.ErrAgentNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("agent not found: %s", agentID))
+		} else if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	result, err := c.validateConfig(ctx, agent, req.Msg.GetConfig())
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(result), nil
+}
+
+// ValidConfig checks the structure of the config and the fleet-wide component policies.
+// The findings about invalid configs are attached to the error as a ConfigValidationResult.
+func (c *ConfigServer) ValidConfig(ctx context.Context, req *connect.Request[v1alpha1.ValidateConfigRequest]) (*connect.Response[emptypb.Empty], error) {
+	if err := c.checkConfig(ctx, req.Msg.GetConfig()); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// checkConfig returns an InvalidArgument error reporting the findings about the config if
+// it is invalid
+func (c *ConfigServer) checkConfig(ctx context.Context, config *v1alpha1.Config) error {
+	result, err := c.validateConfig(ctx, nil, config)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if result.GetValid() {
+		return nil
+	}
+	var errs []string
+	for _, finding := range result.GetFindings() {
+		if finding.GetSeverity() != v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR {
+			continue
+		}
+		if finding.GetLine() > 0 {
+			errs = append(errs, fmt.Sprintf("line %d: %s", finding.GetLine(), finding.GetMessage()))
+		} else {
+			errs = append(errs, finding.GetMessage())
+		}
+	}
+	connectErr := connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid config: %s", strings.Join(errs, "; ")))
+	if detail, err := connect.NewErrorDetail(result); err == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// validateConfig returns every finding about the config, including the violations of the
+// component policies applying to the agent, or only of fleet-wide ones if agent is nil
+func (c *ConfigServer) validateConfig(ctx context.Context, agent *agentdomain.Agent, config *v1alpha1.Config) (*v1alpha1.ConfigValidationResult, error) {
+	v := &configValidator{declared: map[string]*yaml.Node{}, version: agentCollectorVersion(agent)}
+	v.validate(config.GetConfig())
+
+	// policies are only checked against configs which parse
+	if v.root != nil {
+		policies, err := c.listComponentPolicies(ctx)
+		if err != nil {
+			return nil, err
+		}
+		violations, err := componentPolicyViolations(agent, config, policies)
+		if err != nil {
+			return nil, err
+		}
+		for _, violation := range violations {
+			v.addf(v.declared[violation.GetComponent()], v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleComponentPolicy, "%s", violation.GetReason())
+		}
+	}
+
+	slices.SortStableFunc(v.findings, func(a, b *v1alpha1.ConfigFinding) int {
+		return cmp.Or(cmp.Compare(a.GetLine(), b.GetLine()), cmp.Compare(a.GetColumn(), b.GetColumn()))
+	})
+	valid := !slices.ContainsFunc(v.findings, func(finding *v1alpha1.ConfigFinding) bool {
+		return finding.GetSeverity() == v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR
+	})
+	return &v1alpha1.ConfigValidationResult{
+		Valid:    valid,
+		Findings: v.findings,
+	}, nil
+}
+
+// configValidator accumulates the findings about a collector config
+type configValidator struct {
+	// root mapping of the config, nil if it doesn't parse
+	root *yaml.Node
+	// key nodes of the declared components, by kind/id
+	declared map[string]*yaml.Node
+	// kind/id of the components referenced by the service section
+	used map[string]bool
+	// collector version the config is validated for, empty if unknown
+	version  string
+	findings []*v1alpha1.ConfigFinding
+}
+
+// addf records a finding positioned at node, or at the start of the config if node is nil
+func (v *configValidator) addf(node *yaml.Node, severity v1alpha1.FindingSeverity, rule, format string, args ...any) {
+	finding := &v1alpha1.ConfigFinding{
+		Severity: severity,
+		RuleId:   rule,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if node != nil {
+		finding.Line = uint32(node.Line)
+		finding.Column = uint32(node.Column)
+	}
+	v.findings = append(v.findings, finding)
+}
+
+func (v *configValidator) validate(data []byte) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		finding := &v1alpha1.ConfigFinding{
+			Severity: v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR,
+			RuleId:   ruleYAMLSyntax,
+			Message:  strings.TrimPrefix(err.Error(), "yaml: "),
+		}
+		if m := yamlErrorLine.FindStringSubmatch(err.Error()); m != nil {
+			line, _ := strconv.ParseUint(m[1], 10, 32)
+			finding.Line = uint32(line)
+			finding.Message = m[2]
+		}
+		v.findings = append(v.findings, finding)
+		return
+	}
+	if len(doc.Content) == 0 {
+		v.addf(nil, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleEmptyConfig, "config is empty")
+		return
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		v.addf(root, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleInvalidStructure, "config must be a mapping of sections")
+		return
+	}
+	v.root = root
+
+	var service *yaml.Node
+	for key, value := range mappingPairs(root) {
+		switch {
+		case key.Value == "service":
+			service = value
+		case slices.Contains(componentKinds, key.Value):
+			v.declare(key.Value, value)
+		default:
+			v.addf(key, v1alpha1.FindingSeverity_FINDING_SEVERITY_WARNING, ruleUnknownSection, "unknown section %q", key.Value)
+		}
+	}
+	if service == nil {
+		v.addf(root, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleMissingService, "config has no service section, no pipeline will run")
+		return
+	}
+	v.validateService(service)
+
+	for id, key := range v.declared {
+		if !v.used[id] {
+			v.addf(key, v1alpha1.FindingSeverity_FINDING_SEVERITY_WARNING, ruleUnusedComponent, "%s is declared but not used by the service", id)
+		}
+	}
+}
+
+// declare records the components declared in the section of the given kind
+func (v *configValidator) declare(kind string, section *yaml.Node) {
+	if section.Kind != yaml.MappingNode {
+		if !isNull(section) {
+			v.addf(section, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleInvalidStructure, "%s must be a mapping of components", kind)
+		}
+		return
+	}
+	for key := range mappingPairs(section) {
+		typ := componentType(key.Value)
+		if typ == "" {
+			v.addf(key, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleInvalidStructure, "%s %q has no type", kind, key.Value)
+			continue
+		}
+		if !slices.Contains(knownComponents[kind], typ) {
+			v.addf(key, v1alpha1.FindingSeverity_FINDING_SEVERITY_WARNING, ruleUnknownComponent, "unknown %s type %q, it must be built into the agent's collector", kind, typ)
+		}
+		component := collectorComponent{kind: kind, id: key.Value}
+		v.checkDeprecation(key, component)
+		v.declared[component.String()] = key
+	}
+}
+
+// checkDeprecation reports components removed from the collector version the config is
+// validated for as errors, and deprecated ones as warnings
+func (v *configValidator) checkDeprecation(key *yaml.Node, component collectorComponent) {
+	deprecation, ok := findDeprecation(component)
+	switch {
+	case !ok:
+	case deprecation.removedIn(v.version):
+		v.addf(key, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleRemovedComponent, "%s was removed in collector %s, the agent runs %s; use %s instead", component, deprecation.removed, v.version, deprecation.replacement)
+	case deprecation.deprecatedIn(v.version):
+		v.addf(key, v1alpha1.FindingSeverity_FINDING_SEVERITY_WARNING, ruleDeprecatedComponent, "%s", deprecation)
+	}
+}
+
+func (v *configValidator) validateService(service *yaml.Node) {
+	v.used = map[string]bool{}
+	if service.Kind != yaml.MappingNode {
+		v.addf(service, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleInvalidStructure, "service must be a mapping")
+		return
+	}
+	for key, value := range mappingPairs(service) {
+		switch key.Value {
+		case "extensions":
+			v.reference(value, "extensions")
+		case "pipelines":
+			if value.Kind != yaml.MappingNode {
+				v.addf(value, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleInvalidStructure, "service pipelines must be a mapping")
+				continue
+			}
+			for name, pipeline := range mappingPairs(value) {
+				v.validatePipeline(name, pipeline)
+			}
+		}
+	}
+}
+
+func (v *configValidator) validatePipeline(name, pipeline *yaml.Node) {
+	if typ, _, _ := strings.Cut(name.Value, "/"); !slices.Contains(pipelineTypes, typ) {
+		v.addf(name, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleUnknownPipelineType, "pipeline %s has unknown type %q", name.Value, typ)
+	}
+	if pipeline.Kind != yaml.MappingNode {
+		v.addf(pipeline, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleInvalidStructure, "pipeline %s must be a mapping", name.Value)
+		return
+	}
+	lists := map[string]*yaml.Node{}
+	for key, value := range mappingPairs(pipeline) {
+		lists[key.Value] = value
+	}
+	// connectors act as exporters of one pipeline and receivers of another
+	v.reference(lists["receivers"], "receivers", "connectors")
+	v.reference(lists["processors"], "processors")
+	v.reference(lists["exporters"], "exporters", "connectors")
+	for _, required := range []string{"receivers", "exporters"} {
+		if list := lists[required]; list == nil || len(list.Content) == 0 {
+			v.addf(name, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleEmptyPipeline, "pipeline %s has no %s", name.Value, required)
+		}
+	}
+}
+
+// reference marks the components listed by a service section as used, the first of
+// kinds is the one expected in the list
+func (v *configValidator) reference(list *yaml.Node, kinds ...string) {
+	if list == nil || isNull(list) {
+		return
+	}
+	if list.Kind != yaml.SequenceNode {
+		v.addf(list, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleInvalidStructure, "%s must be a list of component IDs", kinds[0])
+		return
+	}
+	for _, item := range list.Content {
+		found := false
+		for _, kind := range kinds {
+			id := collectorComponent{kind: kind, id: item.Value}.String()
+			if _, ok := v.declared[id]; ok {
+				v.used[id] = true
+				found = true
+			}
+		}
+		if !found {
+			v.addf(item, v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR, ruleUndefinedComponent, "%s/%s is referenced but not declared", kinds[0], item.Value)
+		}
+	}
+}
+
+// mappingPairs iterates over the keys and values of a mapping node
+func mappingPairs(node *yaml.Node) iter.Seq2[*yaml.Node, *yaml.Node] {
+	return func(yield func(key, value *yaml.Node) bool) {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if !yield(node.Content[i], node.Content[i+1]) {
+				return
+			}
+		}
+	}
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
@@ -0,0 +1,208 @@
+package otelconfig_test
+
+import (
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfigDetailed(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+	h.createTestAgent(ctx, t, "prod-1", map[string]string{"env": "prod"})
+
+	type finding struct {
+		rule         string
+		severity     v1alpha1.FindingSeverity
+		line, column uint32
+	}
+	validate := func(agentID, config string) (bool, []finding) {
+		t.Helper()
+		resp, err := h.ConfigServer.ValidateConfigDetailed(ctx, connect.NewRequest(&v1alpha1.ValidateConfigDetailedRequest{
+			Config:  &v1alpha1.Config{Config: []byte(config)},
+			AgentId: agentID,
+		}))
+		require.NoError(t, err)
+		var findings []finding
+		for _, f := range resp.Msg.GetFindings() {
+			assert.NotEmpty(t, f.GetMessage())
+			findings = append(findings, finding{f.GetRuleId(), f.GetSeverity(), f.GetLine(), f.GetColumn()})
+		}
+		return resp.Msg.GetValid(), findings
+	}
+	const (
+		errorSeverity   = v1alpha1.FindingSeverity_FINDING_SEVERITY_ERROR
+		warningSeverity = v1alpha1.FindingSeverity_FINDING_SEVERITY_WARNING
+	)
+
+	valid, findings := validate("", `receivers:
+  otlp:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`)
+	assert.True(t, valid)
+	assert.Empty(t, findings)
+
+	valid, findings = validate("", "receivers:\n  otlp: {\n")
+	assert.False(t, valid)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "yaml-syntax", findings[0].rule)
+	assert.NotZero(t, findings[0].line)
+
+	valid, findings = validate("", "")
+	assert.False(t, valid)
+	assert.Equal(t, []finding{{"empty-config", errorSeverity, 0, 0}}, findings)
+
+	valid, findings = validate("", "receivers:\n  otlp:\n")
+	assert.False(t, valid)
+	assert.Equal(t, []finding{{"missing-service", errorSeverity, 1, 1}}, findings)
+
+	// warnings alone leave the config valid
+	valid, findings = validate("", `receivers:
+  otlp:
+processors:
+  batch:
+exporters:
+  debug:
+extras: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`)
+	assert.True(t, valid)
+	assert.Equal(t, []finding{
+		{"unused-component", warningSeverity, 4, 3},
+		{"unknown-section", warningSeverity, 7, 1},
+	}, findings)
+
+	valid, findings = validate("", `receivers:
+  otlp:
+exporters:
+  debug:
+connectors:
+  forward:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [forward]
+    logs/audit:
+      receivers: [forward]
+    spans:
+      receivers: [otlp]
+      exporters: [debug]
+`)
+	assert.False(t, valid)
+	assert.Equal(t, []finding{
+		{"undefined-component", errorSeverity, 8, 16},
+		{"undefined-component", errorSeverity, 12, 20},
+		{"empty-pipeline", errorSeverity, 14, 5},
+		{"unknown-pipeline-type", errorSeverity, 16, 5},
+	}, findings)
+
+	// component policies are reported at the declaration of the forbidden component
+	_, err := h.ConfigServer.PutComponentPolicy(ctx, connect.NewRequest(&v1alpha1.PutComponentPolicyRequest{
+		Policy: &v1alpha1.ComponentPolicy{Id: "prod", Selector: map[string]string{"env": "prod"}, Denied: []string{"debug"}},
+	}))
+	require.NoError(t, err)
+	config := `receivers:
+  otlp:
+exporters:
+  debug/verbose:
+service:
+  pipelines:
+    logs:
+      receivers: [otlp]
+      exporters: [debug/verbose]
+`
+	valid, findings = validate("", config)
+	assert.True(t, valid)
+	assert.Empty(t, findings)
+	valid, findings = validate("prod-1", config)
+	assert.False(t, valid)
+	assert.Equal(t, []finding{{"component-policy", errorSeverity, 4, 3}}, findings)
+
+	_, err = h.ConfigServer.ValidateConfigDetailed(ctx, connect.NewRequest(&v1alpha1.ValidateConfigDetailedRequest{
+		Config:  &v1alpha1.Config{Config: []byte(config)},
+		AgentId: "missing",
+	}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestValidConfig(t *testing.T) {
+	h := setupTestEnv(t)
+	ctx := t.Context()
+
+	// unknown component types are only warned about
+	resp, err := h.ConfigServer.ValidateConfigDetailed(ctx, connect.NewRequest(&v1alpha1.ValidateConfigDetailedRequest{
+		Config: &v1alpha1.Config{Config: []byte(`receivers:
+  mystery/custom:
+exporters:
+  debug:
+service:
+  pipelines:
+    logs:
+      receivers: [mystery/custom]
+      exporters: [debug]
+`)},
+	}))
+	require.NoError(t, err)
+	assert.True(t, resp.Msg.GetValid())
+	require.Len(t, resp.Msg.GetFindings(), 1)
+	assert.Equal(t, "unknown-component-type", resp.Msg.GetFindings()[0].GetRuleId())
+	assert.Equal(t, uint32(2), resp.Msg.GetFindings()[0].GetLine())
+
+	invalid := []byte(`receivers:
+  otlp:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug, otlphttp]
+`)
+	_, err = h.ConfigServer.ValidConfig(ctx, connect.NewRequest(&v1alpha1.ValidateConfigRequest{
+		Config: &v1alpha1.Config{Config: invalid},
+	}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	assert.Contains(t, err.Error(), "line 9: exporters/otlphttp")
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	require.Len(t, connectErr.Details(), 1)
+	detail, err := connectErr.Details()[0].Value()
+	require.NoError(t, err)
+	result, ok := detail.(*v1alpha1.ConfigValidationResult)
+	require.True(t, ok)
+	assert.False(t, result.GetValid())
+	require.Len(t, result.GetFindings(), 1)
+	assert.Equal(t, "undefined-component", result.GetFindings()[0].GetRuleId())
+	assert.Equal(t, uint32(9), result.GetFindings()[0].GetLine())
+
+	// configs are only validated when saved if requested
+	_, err = h.ConfigServer.PutConfig(ctx, connect.NewRequest(&v1alpha1.PutConfigRequest{
+		Ref:      &v1alpha1.ConfigReference{Id: "validated"},
+		Config:   &v1alpha1.Config{Config: invalid},
+		Validate: true,
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = h.ConfigStore.Get(ctx, "validated")
+	assert.Error(t, err)
+	_, err = h.ConfigServer.PutConfig(ctx, connect.NewRequest(&v1alpha1.PutConfigRequest{
+		Ref:    &v1alpha1.ConfigReference{Id: "unvalidated"},
+		Config: &v1alpha1.Config{Config: invalid},
+	}))
+	require.NoError(t, err)
+}
@@ -0,0 +1,230 @@
+// Package rbac serves the management of the API keys and role bindings of the management API
+// callers
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/mux"
+	"github.com/otelfleet/otelfleet/pkg/api/rbac/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/rbac/v1alpha1/v1alpha1connect"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RBACServer provides the RBAC API. Callers are authorized by the auth.Interceptor, its
+// lifecycle is that of the HTTP server.
+type RBACServer struct {
+	logger       *slog.Logger
+	keys         storage.KeyValue[*v1alpha1.APIKey]
+	bindings     storage.KeyValue[*v1alpha1.RoleBinding]
+	interceptors []connect.Interceptor
+	now          func() time.Time
+}
+
+var _ v1alpha1connect.RBACServiceHandler = (*RBACServer)(nil)
+
+// NewRBACServer creates a new RBACServer storing API keys by ID in keys, and role bindings by
+// subject in bindings.
+func NewRBACServer(
+	logger *slog.Logger,
+	keys storage.KeyValue[*v1alpha1.APIKey],
+	bindings storage.KeyValue[*v1alpha1.RoleBinding],
+) *RBACServer {
+	return &RBACServer{
+		logger:   logger,
+		keys:     keys,
+		bindings: bindings,
+		now:      time.Now,
+	}
+}
+
+// AddInterceptors adds interceptors to the RBAC service handlers.
+// Must be called before ConfigureHTTP.
+func (r *RBACServer) AddInterceptors(interceptors ...connect.Interceptor) {
+	r.interceptors = append(r.interceptors, interceptors...)
+}
+
+func (r *RBACServer) ConfigureHTTP(mux *mux.Router) {
+	r.logger.Info("configuring routes")
+	v1alpha1connect.RegisterRBACServiceHandler(mux, r, connect.WithInterceptors(r.interceptors...))
+}
+
+func (r *RBACServer) GetCaller(ctx context.Context, _ *connect.Request[v1alpha1.GetCallerRequest]) (*connect.Response[v1alpha1.Caller], error) {
+	p := auth.FromContext(ctx)
+	if p == nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("anonymous caller"))
+	}
+	return connect.NewResponse(&v1alpha1.Caller{
+		Subject: p.Subject,
+		Roles:   p.Roles,
+	}), nil
+}
+
+func (r *RBACServer) CreateAPIKey(ctx context.Context, req *connect.Request[v1alpha1.CreateAPIKeyRequest]) (*connect.Response[v1alpha1.CreateAPIKeyResponse], error) {
+	name := strings.TrimSpace(req.Msg.GetName())
+	if name == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name must not be empty"))
+	}
+	roles, err := validateRoles(req.Msg.GetRoles())
+	if err != nil {
+		return nil, err
+	}
+	var ttl time.Duration
+	if req.Msg.GetTtl() != nil {
+		if ttl = req.Msg.GetTtl().AsDuration(); ttl <= 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("ttl must be positive"))
+		}
+	}
+
+	now := r.now()
+	key, token, err := auth.NewAPIKey(name, now, ttl)
+	if err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	key.CreatedBy = auth.SubjectFromContext(ctx)
+	binding := &v1alpha1.RoleBinding{
+		Subject:   key.GetSubject(),
+		Roles:     roles,
+		UpdatedAt: timestamppb.New(now),
+		UpdatedBy: key.GetCreatedBy(),
+	}
+	b := r.keys.NewBatch()
+	if err := r.keys.PutBatch(ctx, b, key.GetId(), key); err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	if err := r.bindings.PutBatch(ctx, b, binding.GetSubject(), binding); err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	if err := b.Commit(ctx); err != nil {
+		return nil, grpcutil.ErrorInternal(fmt.Errorf("failed to store API key: %w", err))
+	}
+	r.logger.With("id", key.GetId(), "name", name, "roles", roles).InfoContext(ctx, "created API key")
+	return connect.NewResponse(&v1alpha1.CreateAPIKeyResponse{
+		Key:   redact(key),
+		Token: token,
+	}), nil
+}
+
+func (r *RBACServer) ListAPIKeys(ctx context.Context, _ *connect.Request[v1alpha1.ListAPIKeysRequest]) (*connect.Response[v1alpha1.ListAPIKeysResponse], error) {
+	keys, err := r.keys.List(ctx)
+	if err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	resp := &v1alpha1.ListAPIKeysResponse{}
+	for _, key := range keys {
+		resp.Keys = append(resp.Keys, redact(key))
+	}
+	slices.SortFunc(resp.Keys, func(a, b *v1alpha1.APIKey) int {
+		return a.GetCreatedAt().AsTime().Compare(b.GetCreatedAt().AsTime())
+	})
+	return connect.NewResponse(resp), nil
+}
+
+func (r *RBACServer) DeleteAPIKey(ctx context.Context, req *connect.Request[v1alpha1.DeleteAPIKeyRequest]) (*connect.Response[emptypb.Empty], error) {
+	id := req.Msg.GetId()
+	if id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id must not be empty"))
+	}
+	key, err := r.keys.Get(ctx, id)
+	if grpcutil.IsErrorNotFound(err) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("API key not found: %s", id))
+	} else if err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	b := r.keys.NewBatch()
+	if err := r.keys.DeleteBatch(ctx, b, id); err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	if err := r.bindings.DeleteBatch(ctx, b, key.GetSubject()); err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	if err := b.Commit(ctx); err != nil {
+		return nil, grpcutil.ErrorInternal(fmt.Errorf("failed to delete API key: %w", err))
+	}
+	r.logger.With("id", id).InfoContext(ctx, "deleted API key")
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+func (r *RBACServer) PutRoleBinding(ctx context.Context, req *connect.Request[v1alpha1.PutRoleBindingRequest]) (*connect.Response[v1alpha1.RoleBinding], error) {
+	subject := strings.TrimSpace(req.Msg.GetSubject())
+	if subject == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("subject must not be empty"))
+	}
+	roles, err := validateRoles(req.Msg.GetRoles())
+	if err != nil {
+		return nil, err
+	}
+	binding := &v1alpha1.RoleBinding{
+		Subject:   subject,
+		Roles:     roles,
+		UpdatedAt: timestamppb.New(r.now()),
+		UpdatedBy: auth.SubjectFromContext(ctx),
+	}
+	if err := r.bindings.Put(ctx, subject, binding); err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	r.logger.With("subject", subject, "roles", roles).InfoContext(ctx, "bound roles")
+	return connect.NewResponse(binding), nil
+}
+
+func (r *RBACServer) ListRoleBindings(ctx context.Context, _ *connect.Request[v1alpha1.ListRoleBindingsRequest]) (*connect.Response[v1alpha1.ListRoleBindingsResponse], error) {
+	bindings, err := r.bindings.List(ctx)
+	if err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	slices.SortFunc(bindings, func(a, b *v1alpha1.RoleBinding) int {
+		return strings.Compare(a.GetSubject(), b.GetSubject())
+	})
+	return connect.NewResponse(&v1alpha1.ListRoleBindingsResponse{Bindings: bindings}), nil
+}
+
+func (r *RBACServer) DeleteRoleBinding(ctx context.Context, req *connect.Request[v1alpha1.DeleteRoleBindingRequest]) (*connect.Response[emptypb.Empty], error) {
+	subject := req.Msg.GetSubject()
+	if subject == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("subject must not be empty"))
+	}
+	if _, err := r.bindings.Get(ctx, subject); grpcutil.IsErrorNotFound(err) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("role binding not found: %s", subject))
+	} else if err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	if err := r.bindings.Delete(ctx, subject); err != nil {
+		return nil, grpcutil.ErrorInternal(err)
+	}
+	r.logger.With("subject", subject).InfoContext(ctx, "deleted role binding")
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// validateRoles returns the sorted roles, or an error if there are none or one is empty
+func validateRoles(roles []string) ([]string, error) {
+	if len(roles) == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("at least one role is required"))
+	}
+	roles = slices.Clone(roles)
+	for i, role := range roles {
+		if roles[i] = strings.TrimSpace(role); roles[i] == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("roles must not be empty"))
+		}
+	}
+	slices.Sort(roles)
+	return slices.Compact(roles), nil
+}
+
+// redact returns a copy of the key without its secret hash
+func redact(key *v1alpha1.APIKey) *v1alpha1.APIKey {
+	key = proto.CloneOf(key)
+	key.SecretHash = nil
+	return key
+}
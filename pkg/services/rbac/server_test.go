@@ -0,0 +1,109 @@
+package rbac_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/rbac/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/services/rbac"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func newServer(t *testing.T) (*rbac.RBACServer, *auth.RoleBindings) {
+	t.Helper()
+	broker := memory.NewKVBroker()
+	keys := storage.NewProtoKV[*v1alpha1.APIKey](slog.Default(), broker.KeyValue("api-keys"))
+	bindings := storage.NewProtoKV[*v1alpha1.RoleBinding](slog.Default(), broker.KeyValue("role-bindings"))
+	return rbac.NewRBACServer(slog.Default(), keys, bindings), auth.NewRoleBindings(bindings, nil)
+}
+
+func TestRBACServer_APIKeys(t *testing.T) {
+	srv, bindings := newServer(t)
+	ctx := auth.NewContext(t.Context(), &auth.Principal{Subject: "root", Roles: []string{auth.RoleAdmin}})
+
+	for _, req := range []*v1alpha1.CreateAPIKeyRequest{
+		{Roles: []string{auth.RoleViewer}},
+		{Name: "ci"},
+		{Name: "ci", Roles: []string{" "}},
+		{Name: "ci", Roles: []string{auth.RoleViewer}, Ttl: durationpb.New(0)},
+	} {
+		_, err := srv.CreateAPIKey(ctx, connect.NewRequest(req))
+		assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), req)
+	}
+
+	created, err := srv.CreateAPIKey(ctx, connect.NewRequest(&v1alpha1.CreateAPIKeyRequest{
+		Name:  "ci",
+		Roles: []string{auth.RoleOperator, "team-a", auth.RoleOperator},
+	}))
+	require.NoError(t, err)
+	key := created.Msg.GetKey()
+	assert.Equal(t, "root", key.GetCreatedBy())
+	assert.Empty(t, key.GetSecretHash())
+	assert.Nil(t, key.GetExpiresAt())
+	granted, err := bindings.Resolve(t.Context(), &auth.Principal{Subject: key.GetSubject()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{auth.RoleOperator, "team-a"}, granted.Roles)
+
+	list, err := srv.ListAPIKeys(ctx, connect.NewRequest(&v1alpha1.ListAPIKeysRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.GetKeys(), 1)
+	assert.Equal(t, key.GetId(), list.Msg.GetKeys()[0].GetId())
+	assert.Empty(t, list.Msg.GetKeys()[0].GetSecretHash())
+
+	// deleting the key deletes its binding
+	_, err = srv.DeleteAPIKey(ctx, connect.NewRequest(&v1alpha1.DeleteAPIKeyRequest{Id: key.GetId()}))
+	require.NoError(t, err)
+	granted, err = bindings.Resolve(t.Context(), &auth.Principal{Subject: key.GetSubject()})
+	require.NoError(t, err)
+	assert.Empty(t, granted.Roles)
+	_, err = srv.DeleteAPIKey(ctx, connect.NewRequest(&v1alpha1.DeleteAPIKeyRequest{Id: key.GetId()}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestRBACServer_RoleBindings(t *testing.T) {
+	srv, bindings := newServer(t)
+	ctx := auth.NewContext(t.Context(), &auth.Principal{Subject: "root", Roles: []string{auth.RoleAdmin}})
+
+	_, err := srv.PutRoleBinding(ctx, connect.NewRequest(&v1alpha1.PutRoleBindingRequest{Roles: []string{auth.RoleViewer}}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	for _, subject := range []string{"bob@example.com", "alice@example.com"} {
+		_, err := srv.PutRoleBinding(ctx, connect.NewRequest(&v1alpha1.PutRoleBindingRequest{
+			Subject: subject,
+			Roles:   []string{auth.RoleViewer},
+		}))
+		require.NoError(t, err)
+	}
+	// putting a binding replaces its roles
+	put, err := srv.PutRoleBinding(ctx, connect.NewRequest(&v1alpha1.PutRoleBindingRequest{
+		Subject: "alice@example.com",
+		Roles:   []string{auth.RoleOperator},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "root", put.Msg.GetUpdatedBy())
+	granted, err := bindings.Resolve(t.Context(), &auth.Principal{Subject: "alice@example.com", Roles: []string{"team-a"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{auth.RoleOperator, "team-a"}, granted.Roles)
+
+	list, err := srv.ListRoleBindings(ctx, connect.NewRequest(&v1alpha1.ListRoleBindingsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.GetBindings(), 2)
+	assert.Equal(t, "alice@example.com", list.Msg.GetBindings()[0].GetSubject())
+
+	_, err = srv.DeleteRoleBinding(ctx, connect.NewRequest(&v1alpha1.DeleteRoleBindingRequest{Subject: "alice@example.com"}))
+	require.NoError(t, err)
+	_, err = srv.DeleteRoleBinding(ctx, connect.NewRequest(&v1alpha1.DeleteRoleBindingRequest{Subject: "alice@example.com"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	caller, err := srv.GetCaller(ctx, connect.NewRequest(&v1alpha1.GetCallerRequest{}))
+	require.NoError(t, err)
+	assert.Equal(t, "root", caller.Msg.GetSubject())
+	_, err = srv.GetCaller(t.Context(), connect.NewRequest(&v1alpha1.GetCallerRequest{}))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+}
@@ -0,0 +1,157 @@
+package replica
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafana/dskit/services"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+)
+
+// DefaultSyncInterval is how often followers copy the primary's snapshot when no interval
+// is configured
+const DefaultSyncInterval = 30 * time.Second
+
+// ErrNotSynced is returned by followers that haven't copied a snapshot of the primary yet
+var ErrNotSynced = errors.New("the replica hasn't synced with the primary yet")
+
+// Follower periodically copies the snapshots of a primary server into its own storage
+type Follower struct {
+	logger     *slog.Logger
+	primaryURL string
+	token      string
+	interval   time.Duration
+	broker     storage.KVBroker
+	client     *http.Client
+
+	mu sync.Mutex
+	// time of the last snapshot copied, zero until the first sync
+	syncedAt time.Time
+
+	services.Service
+}
+
+// NewFollower creates a Follower copying the snapshots of the primary at primaryURL into
+// broker every interval, it defaults to DefaultSyncInterval. If set, token is sent as the
+// bearer token of the primary's replication endpoint.
+func NewFollower(logger *slog.Logger, primaryURL, token string, interval time.Duration, broker storage.KVBroker) *Follower {
+	if interval <= 0 {
+		interval = DefaultSyncInterval
+	}
+	f := &Follower{
+		logger:     logger,
+		primaryURL: strings.TrimSuffix(primaryURL, "/"),
+		token:      token,
+		interval:   interval,
+		broker:     broker,
+		client:     &http.Client{Timeout: interval},
+	}
+	f.Service = services.NewBasicService(f.starting, f.running, nil)
+	return f
+}
+
+func (f *Follower) starting(ctx context.Context) error {
+	// the primary may be down, reads fail until the first sync succeeds
+	if err := f.Sync(ctx); err != nil {
+		f.logger.With("err", err).Warn("failed to sync with the primary")
+	}
+	return nil
+}
+
+func (f *Follower) running(ctx context.Context) error {
+	t := time.NewTicker(f.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			if err := f.Sync(ctx); err != nil {
+				f.logger.With("err", err, "synced_at", f.SyncedAt()).Warn("failed to sync with the primary")
+			}
+		}
+	}
+}
+
+// SyncedAt returns the time of the primary's snapshot the follower last copied, it is zero
+// until the first sync
+func (f *Follower) SyncedAt() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.syncedAt
+}
+
+// Sync copies the primary's current snapshot
+func (f *Follower) Sync(ctx context.Context) error {
+	snapshot, err := f.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	if err := Restore(ctx, f.broker, snapshot); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	f.syncedAt = snapshot.Time
+	f.mu.Unlock()
+	f.logger.With("snapshot_time", snapshot.Time).Debug("synced with the primary")
+	return nil
+}
+
+func (f *Follower) fetch(ctx context.Context) (*Snapshot, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.primaryURL+SnapshotPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	if f.token != "" {
+		req.Header.Set("Authorization", "Bearer "+f.token)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch snapshot: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch snapshot: %s", resp.Status)
+	}
+	snapshot := &Snapshot{}
+	if err := json.NewDecoder(resp.Body).Decode(snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// Restore replaces the keyspaces of broker held by the snapshot with their snapshotted
+// entries. Entries are written before the stale ones are deleted, so that readers don't
+// see keyspaces emptied during the restore.
+func Restore(ctx context.Context, broker storage.KVBroker, snapshot *Snapshot) error {
+	for keyspace, kvs := range snapshot.Keyspaces {
+		kv := broker.KeyValue(keyspace)
+		keys, err := kv.ListKeys(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list keyspace %s: %w", keyspace, err)
+		}
+		stale := make(map[string]struct{}, len(keys))
+		for _, key := range keys {
+			stale[key] = struct{}{}
+		}
+		for _, entry := range kvs {
+			if err := kv.Put(ctx, entry.Key, entry.Value); err != nil {
+				return fmt.Errorf("failed to restore keyspace %s: %w", keyspace, err)
+			}
+			delete(stale, entry.Key)
+		}
+		for key := range stale {
+			if err := kv.Delete(ctx, key); err != nil {
+				return fmt.Errorf("failed to restore keyspace %s: %w", keyspace, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package replica
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1/v1alpha1connect"
+)
+
+const (
+	// HeaderSyncedAt is set on the responses of followers to the RFC 3339 time of the
+	// primary's snapshot they were served from
+	HeaderSyncedAt = "Otelfleet-Replica-Synced-At"
+	// HeaderStaleness is set on the responses of followers to the age, in seconds, of the
+	// primary's snapshot they were served from
+	HeaderStaleness = "Otelfleet-Replica-Staleness"
+)
+
+// ReadProcedures are the procedures followers serve, those only reading replicated keyspaces
+var ReadProcedures = []string{
+	v1alpha1connect.AgentServiceListAgentsProcedure,
+	v1alpha1connect.AgentServiceGetAgentProcedure,
+	v1alpha1connect.AgentServiceStatusProcedure,
+	v1alpha1connect.AgentServiceListConfigPushesProcedure,
+	v1alpha1connect.AgentServiceListFleetSnapshotsProcedure,
+	v1alpha1connect.AgentServiceDiffFleetStateProcedure,
+	v1alpha1connect.AgentServiceGetAgentAvailabilityProcedure,
+	v1alpha1connect.AgentServiceGetFleetAvailabilityProcedure,
+}
+
+type readOnlyInterceptor struct {
+	follower   *Follower
+	procedures map[string]struct{}
+	now        func() time.Time
+}
+
+// NewReadOnlyInterceptor rejects the RPCs of followers other than ReadProcedures, with
+// FailedPrecondition, and marks the staleness of the data read by the others. Reads fail with
+// Unavailable until the follower first synced.
+func NewReadOnlyInterceptor(follower *Follower) connect.Interceptor {
+	procedures := make(map[string]struct{}, len(ReadProcedures))
+	for _, procedure := range ReadProcedures {
+		procedures[procedure] = struct{}{}
+	}
+	return &readOnlyInterceptor{follower: follower, procedures: procedures, now: time.Now}
+}
+
+func (r *readOnlyInterceptor) allowed(procedure string) error {
+	if _, ok := r.procedures[procedure]; !ok {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("%s is not served by read replicas, call the primary", procedure))
+	}
+	if r.follower.SyncedAt().IsZero() {
+		return connect.NewError(connect.CodeUnavailable, ErrNotSynced)
+	}
+	return nil
+}
+
+func (r *readOnlyInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := r.allowed(req.Spec().Procedure); err != nil {
+			return nil, err
+		}
+		// the snapshot may be replaced while serving, mark the older one
+		syncedAt := r.follower.SyncedAt()
+		resp, err := next(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Header().Set(HeaderSyncedAt, syncedAt.UTC().Format(time.RFC3339Nano))
+		resp.Header().Set(HeaderStaleness, strconv.FormatInt(int64(r.now().Sub(syncedAt).Seconds()), 10))
+		return resp, nil
+	}
+}
+
+func (r *readOnlyInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (r *readOnlyInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := r.allowed(conn.Spec().Procedure); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
@@ -0,0 +1,144 @@
+// Package replica implements read replicas. A primary server exports snapshots of the
+// keyspaces its read APIs are served from, followers periodically copy them into their own
+// storage and serve the read APIs only, marking how stale their data is.
+//
+// Snapshots are not atomic across keyspaces, the keyspaces of a snapshot may be taken a few
+// writes apart.
+package replica
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/grafana/dskit/middleware"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+)
+
+// SnapshotPath serves the snapshots of the replicated keyspaces to followers
+const SnapshotPath = "/v1alpha1/replication/snapshot"
+
+// Keyspaces are the keyspaces replicated to followers, those the agent read APIs are served from
+var Keyspaces = []string{
+	"agents",
+	"opamp-agent-description",
+	"agent-connection-state",
+	"agent-health",
+	"agent-effective-config",
+	"agent-remote-config-status",
+	"config-assignments",
+	"config-pushes",
+	"agent-availability",
+	"fleet-snapshots",
+}
+
+// Snapshot holds the raw entries of the replicated keyspaces
+type Snapshot struct {
+	// Time is when the primary started taking the snapshot
+	Time      time.Time               `json:"time"`
+	Keyspaces map[string][]SnapshotKV `json:"keyspaces"`
+}
+
+type SnapshotKV struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// TakeSnapshot returns the entries of the keyspaces of broker
+func TakeSnapshot(ctx context.Context, broker storage.KVBroker, keyspaces []string) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Time:      time.Now(),
+		Keyspaces: make(map[string][]SnapshotKV, len(keyspaces)),
+	}
+	for _, keyspace := range keyspaces {
+		entries, err := broker.KeyValue(keyspace).ListPrefix(ctx, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list keyspace %s: %w", keyspace, err)
+		}
+		kvs := make([]SnapshotKV, 0, len(entries))
+		for _, entry := range entries {
+			kvs = append(kvs, SnapshotKV{Key: entry.Key, Value: entry.Value})
+		}
+		snapshot.Keyspaces[keyspace] = kvs
+	}
+	return snapshot, nil
+}
+
+// Exporter serves snapshots of the replicated keyspaces to followers
+type Exporter struct {
+	logger *slog.Logger
+	broker storage.KVBroker
+}
+
+// NewExporter creates an Exporter of the keyspaces of broker
+func NewExporter(logger *slog.Logger, broker storage.KVBroker) *Exporter {
+	return &Exporter{
+		logger: logger,
+		broker: broker,
+	}
+}
+
+// ConfigureHTTP serves the snapshots to the followers presenting token as a bearer token, if
+// set, and to the admins authenticated by the auth middleware and authorized by mw. Snapshots
+// hold the resources of every tenant.
+func (e *Exporter) ConfigureHTTP(router *mux.Router, token string, mw ...middleware.Interface) {
+	e.logger.Info("configuring replication routes")
+	h := &snapshotHandler{exporter: e, token: token}
+	h.authenticated = middleware.Merge(mw...).Wrap(http.HandlerFunc(h.serveAdmin))
+	router.Handle(SnapshotPath, h).Methods(http.MethodGet)
+}
+
+type snapshotHandler struct {
+	exporter *Exporter
+	token    string
+	// serves the callers not presenting the token
+	authenticated http.Handler
+}
+
+func (h *snapshotHandler) hasToken(r *http.Request) bool {
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && h.token != "" && subtle.ConstantTimeCompare([]byte(bearer), []byte(h.token)) == 1
+}
+
+func (h *snapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.hasToken(r) {
+		h.serve(w, r)
+		return
+	}
+	h.authenticated.ServeHTTP(w, r)
+}
+
+func (h *snapshotHandler) serveAdmin(w http.ResponseWriter, r *http.Request) {
+	p := auth.FromContext(r.Context())
+	if p == nil {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthenticated", http.StatusUnauthorized)
+		return
+	}
+	if !p.IsAdmin() {
+		http.Error(w, fmt.Sprintf("%s is not an admin", p.Subject), http.StatusForbidden)
+		return
+	}
+	h.serve(w, r)
+}
+
+func (h *snapshotHandler) serve(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := TakeSnapshot(r.Context(), h.exporter.broker, Keyspaces)
+	if err != nil {
+		h.exporter.logger.With("err", err).Error("failed to take replication snapshot")
+		http.Error(w, "failed to take snapshot", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(snapshot); err != nil {
+		h.exporter.logger.With("err", err).Warn("failed to write replication snapshot")
+	}
+}
@@ -0,0 +1,119 @@
+package replica_test
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/mux"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1/v1alpha1connect"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/agent"
+	"github.com/otelfleet/otelfleet/pkg/services/replica"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRepository(broker storage.KVBroker) agentdomain.Repository {
+	logger := slog.Default()
+	return agentdomain.NewRepository(
+		logger,
+		storage.NewProtoKV[*v1alpha1.AgentDescription](logger, broker.KeyValue("agents")),
+		storage.NewProtoKV[*protobufs.AgentDescription](logger, broker.KeyValue("opamp-agent-description")),
+		storage.NewProtoKV[*v1alpha1.AgentConnectionState](logger, broker.KeyValue("agent-connection-state")),
+		storage.NewProtoKV[*protobufs.ComponentHealth](logger, broker.KeyValue("agent-health")),
+		storage.NewProtoKV[*protobufs.EffectiveConfig](logger, broker.KeyValue("agent-effective-config")),
+		storage.NewProtoKV[*protobufs.RemoteConfigStatus](logger, broker.KeyValue("agent-remote-config-status")),
+		storage.NewProtoKV[*configv1alpha1.ConfigAssignment](logger, broker.KeyValue("config-assignments")),
+	)
+}
+
+func TestFollower(t *testing.T) {
+	ctx := t.Context()
+	primary := memory.NewKVBroker()
+	agents := storage.NewProtoKV[*v1alpha1.AgentDescription](slog.Default(), primary.KeyValue("agents"))
+	require.NoError(t, agents.Put(ctx, "a", &v1alpha1.AgentDescription{Id: "a", FriendlyName: "a"}))
+	require.NoError(t, agents.Put(ctx, "b", &v1alpha1.AgentDescription{Id: "b", FriendlyName: "b"}))
+	// keyspaces that aren't replicated stay on the primary
+	require.NoError(t, primary.KeyValue("tokens").Put(ctx, "token", []byte("secret")))
+
+	router := mux.NewRouter()
+	replica.NewExporter(slog.Default(), primary).ConfigureHTTP(router, "replication-token")
+	primarySrv := httptest.NewServer(router)
+	t.Cleanup(primarySrv.Close)
+
+	// unauthenticated followers are refused
+	err := replica.NewFollower(slog.Default(), primarySrv.URL, "wrong", time.Minute, memory.NewKVBroker()).Sync(ctx)
+	assert.ErrorContains(t, err, "401")
+
+	local := memory.NewKVBroker()
+	follower := replica.NewFollower(slog.Default(), primarySrv.URL+"/", "replication-token", time.Minute, local)
+
+	// followers serve the agent read APIs only
+	agentRouter := mux.NewRouter()
+	agentSrv := agent.NewAgentServer(slog.Default(), newRepository(local), nil, 0)
+	agentSrv.AddInterceptors(replica.NewReadOnlyInterceptor(follower))
+	agentSrv.ConfigureHTTP(agentRouter)
+	followerSrv := httptest.NewServer(agentRouter)
+	t.Cleanup(followerSrv.Close)
+	client := v1alpha1connect.NewAgentServiceClient(followerSrv.Client(), followerSrv.URL)
+
+	_, err = client.ListAgents(ctx, connect.NewRequest(&v1alpha1.ListAgentsRequest{}))
+	assert.Equal(t, connect.CodeUnavailable, connect.CodeOf(err), "reads fail until the first sync")
+
+	before := time.Now()
+	require.NoError(t, follower.Sync(ctx))
+	assert.False(t, follower.SyncedAt().Before(before))
+	_, err = local.KeyValue("tokens").Get(ctx, "token")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	resp, err := client.ListAgents(ctx, connect.NewRequest(&v1alpha1.ListAgentsRequest{}))
+	require.NoError(t, err)
+	assert.Len(t, resp.Msg.GetAgents(), 2)
+	syncedAt, err := time.Parse(time.RFC3339Nano, resp.Header().Get(replica.HeaderSyncedAt))
+	require.NoError(t, err)
+	assert.True(t, syncedAt.Equal(follower.SyncedAt()))
+	staleness, err := strconv.Atoi(resp.Header().Get(replica.HeaderStaleness))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, staleness, 0)
+
+	_, err = client.DeleteAgent(ctx, connect.NewRequest(&v1alpha1.DeleteAgentRequest{AgentId: "a"}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	// deletions on the primary are replicated
+	require.NoError(t, agents.Delete(ctx, "b"))
+	require.NoError(t, follower.Sync(ctx))
+	resp, err = client.ListAgents(ctx, connect.NewRequest(&v1alpha1.ListAgentsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.GetAgents(), 1)
+	assert.Equal(t, "a", resp.Msg.GetAgents()[0].GetAgent().GetId())
+}
+
+func TestExporter_Admins(t *testing.T) {
+	router := mux.NewRouter()
+	replica.NewExporter(slog.Default(), memory.NewKVBroker()).ConfigureHTTP(router, "replication-token")
+	handler := auth.NewMiddleware(slog.Default(), auth.NewHeaderAuthenticator()).Wrap(router)
+	get := func(header http.Header) int {
+		req := httptest.NewRequest(http.MethodGet, replica.SnapshotPath, nil)
+		req.Header = header
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// snapshots hold every tenant, callers authenticated by the proxy must be admins
+	assert.Equal(t, http.StatusUnauthorized, get(http.Header{}))
+	assert.Equal(t, http.StatusForbidden, get(http.Header{auth.HeaderUser: {"alice"}, auth.HeaderRoles: {auth.RoleViewer}}))
+	assert.Equal(t, http.StatusOK, get(http.Header{auth.HeaderUser: {"root"}, auth.HeaderRoles: {auth.RoleAdmin}}))
+	assert.Equal(t, http.StatusOK, get(http.Header{"Authorization": {"Bearer replication-token"}}))
+}
@@ -2,17 +2,29 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"io"
 	"log/slog"
+	"net/url"
+	"strings"
+	"time"
 
-	"github.com/cockroachdb/pebble/v2"
 	"github.com/grafana/dskit/services"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/etcd"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
 	otelpebble "github.com/otelfleet/otelfleet/pkg/storage/pebble"
+	"github.com/otelfleet/otelfleet/pkg/storage/relational"
+	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 type StorageService struct {
 	logger *slog.Logger
-	db     *pebble.DB
+	// db is the database or client the broker stores in
+	db     io.Closer
 	broker storage.KVBroker
 
 	services.Service
@@ -24,23 +36,27 @@ var _ storage.KVBroker = (*StorageService)(nil)
 
 // var _ storage.KVStorageFactory = (*StorageService)(nil)
 
+// NewStorageService opens the storage of storagePath, with storage operations bounded by
+// budget. storagePath selects the backend:
+//   - a directory, or file:///path, opens an embedded pebble database
+//   - etcd://host1:2379,host2:2379/root stores under /root/ in an etcd cluster
+//   - postgres://... or postgresql://... stores in a Postgres database, the URL being its DSN
+//   - sqlite:///path stores in a SQLite database file
 func NewStorageService(
 	logger *slog.Logger,
 	storagePath string,
+	budget storage.Budget,
 ) (*StorageService, error) {
-	kvDb, err := otelpebble.Open(
-		storagePath,
-		nil,
-	)
+	kv, db, err := open(storagePath)
 	if err != nil {
 		logger.Error("failed to start KV store")
 		return nil, err
 	}
-	broker := otelpebble.NewKVBroker(kvDb)
+	broker := storage.NewBudgetBroker(logger, kv, budget)
 	s := &StorageService{
 		logger:      logger,
 		storagePath: storagePath,
-		db:          kvDb,
+		db:          db,
 		broker:      broker,
 		Service:     nil,
 	}
@@ -49,6 +65,119 @@ func NewStorageService(
 	return s, nil
 }
 
+// NewEphemeralStorageService keeps storage in memory, with storage operations bounded by
+// budget. Everything stored is lost once the service stops.
+func NewEphemeralStorageService(
+	logger *slog.Logger,
+	budget storage.Budget,
+) *StorageService {
+	s := &StorageService{
+		logger: logger,
+		broker: storage.NewBudgetBroker(logger, memory.NewKVBroker(), budget),
+	}
+	s.Service = services.NewBasicService(s.starting, s.running, s.stopping)
+	return s
+}
+
+// Backend is the kind of storage selected by a storage path
+type Backend string
+
+// Backends
+const (
+	BackendPebble   Backend = "pebble"
+	BackendEtcd     Backend = "etcd"
+	BackendPostgres Backend = "postgres"
+	BackendSQLite   Backend = "sqlite"
+)
+
+// Shared returns true if the servers using the same storage of the backend share their state
+func (b Backend) Shared() bool {
+	return b == BackendEtcd || b == BackendPostgres
+}
+
+// SharedWatches returns true if the servers sharing the storage of the backend watch each
+// other's changes, see storage.KVBroker.Watch
+func (b Backend) SharedWatches() bool {
+	return b == BackendEtcd
+}
+
+// BackendOf returns the backend selected by storagePath, see NewStorageService
+func BackendOf(storagePath string) (Backend, error) {
+	u, err := url.Parse(storagePath)
+	if err != nil || u.Scheme == "" || u.Scheme == "file" {
+		// plain paths, including those that aren't URLs
+		return BackendPebble, nil
+	}
+	switch u.Scheme {
+	case "etcd":
+		return BackendEtcd, nil
+	case "postgres", "postgresql":
+		return BackendPostgres, nil
+	case "sqlite":
+		return BackendSQLite, nil
+	default:
+		return "", fmt.Errorf("unsupported storage scheme %q", u.Scheme)
+	}
+}
+
+// open opens the broker of storagePath, and what closes it
+func open(storagePath string) (storage.KVBroker, io.Closer, error) {
+	backend, err := BackendOf(storagePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if backend == BackendPebble {
+		path := storagePath
+		if u, err := url.Parse(storagePath); err == nil && u.Scheme == "file" {
+			path = u.Path
+		}
+		db, err := otelpebble.Open(path, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		return otelpebble.NewKVBroker(db), db, nil
+	}
+	u, _ := url.Parse(storagePath)
+	switch backend {
+	case BackendEtcd:
+		client, err := clientv3.New(clientv3.Config{
+			Endpoints:   strings.Split(u.Host, ","),
+			DialTimeout: 5 * time.Second,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to etcd at %s: %w", u.Host, err)
+		}
+		root := strings.TrimSuffix(u.Path, "/") + "/"
+		return etcd.NewKVBroker(client, root), client, nil
+	case BackendPostgres:
+		db, err := sql.Open("pgx", storagePath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return openRelational(db, relational.Postgres)
+	default:
+		db, err := sql.Open("sqlite3", u.Host+u.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		// SQLite serializes writes, concurrent writers would fail with SQLITE_BUSY
+		db.SetMaxOpenConns(1)
+		return openRelational(db, relational.SQLite)
+	}
+}
+
+// openRelational opens the broker storing in db, closing db when it fails
+func openRelational(db *sql.DB, dialect relational.Dialect) (storage.KVBroker, io.Closer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	broker, err := relational.NewKVBroker(ctx, db, dialect)
+	if err != nil {
+		db.Close()
+		return nil, nil, fmt.Errorf("failed to open the %s database: %w", dialect.Name, err)
+	}
+	return broker, db, nil
+}
+
 func (s *StorageService) starting(_ context.Context) error {
 	return nil
 }
@@ -69,3 +198,7 @@ func (s *StorageService) stopping(_ error) error {
 func (s *StorageService) KeyValue(prefix string) storage.KV {
 	return s.broker.KeyValue(prefix)
 }
+
+func (s *StorageService) Watch(ctx context.Context, prefix string) (<-chan storage.WatchEvent, error) {
+	return s.broker.Watch(ctx, prefix)
+}
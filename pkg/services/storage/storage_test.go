@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoragePaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, path := range []string{
+		filepath.Join(dir, "plain"),
+		"file://" + filepath.Join(dir, "file"),
+		"sqlite://" + filepath.Join(dir, "otelfleet.db"),
+	} {
+		t.Run(path, func(t *testing.T) {
+			s, err := NewStorageService(slog.Default(), path, storage.Budget{})
+			require.NoError(t, err)
+			kv := s.KeyValue("test")
+			require.NoError(t, kv.Put(t.Context(), "key", []byte("value")))
+			require.NoError(t, s.stopping(nil))
+
+			// the state outlives the service
+			s, err = NewStorageService(slog.Default(), path, storage.Budget{})
+			require.NoError(t, err)
+			defer s.stopping(nil)
+			value, err := s.KeyValue("test").Get(t.Context(), "key")
+			require.NoError(t, err)
+			assert.Equal(t, "value", string(value))
+		})
+	}
+
+	_, err := NewStorageService(slog.Default(), "mysql://localhost/otelfleet", storage.Budget{})
+	assert.ErrorContains(t, err, `unsupported storage scheme "mysql"`)
+}
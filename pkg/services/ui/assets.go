@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const indexFile = "index.html"
+
+// SetAssets sets the built UI, e.g. the dist directory of the ui package, served on the
+// paths no other route handles. It must be called before ConfigureHTTP.
+func (s *Server) SetAssets(assets fs.FS) {
+	s.assets = assets
+}
+
+// assetHandler serves the files of the built UI. The UI routes its pages client side, so
+// the paths of its pages, that have no file, serve the index.
+func (s *Server) assetHandler() http.Handler {
+	files := http.FileServerFS(s.assets)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.NotFound(w, r)
+			return
+		}
+		name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+		if name == "" || name == "." {
+			s.serveIndex(w, r)
+			return
+		}
+		info, err := fs.Stat(s.assets, name)
+		switch {
+		case err == nil && !info.IsDir():
+			files.ServeHTTP(w, r)
+		case err != nil && !errors.Is(err, fs.ErrNotExist):
+			s.logger.With("err", err, "path", name).Error("failed to read UI asset")
+			http.Error(w, "failed to read UI asset", http.StatusInternalServerError)
+		case isPage(name):
+			s.serveIndex(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// serveIndex serves the entrypoint of the UI, which references the current assets so must
+// not be cached
+func (s *Server) serveIndex(w http.ResponseWriter, r *http.Request) {
+	index, err := fs.ReadFile(s.assets, indexFile)
+	if err != nil {
+		s.logger.With("err", err).Error("failed to read UI index")
+		http.Error(w, "UI is not available", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(index)
+	}
+}
+
+// isPage reports whether name may be a page of the UI rather than a missing file, API
+// endpoint or RPC, whose paths hold a dot
+func isPage(name string) bool {
+	return !strings.HasPrefix(name, "api/") && !strings.Contains(name, ".")
+}
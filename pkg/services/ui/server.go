@@ -0,0 +1,139 @@
+// Package ui serves the web UI and the data it needs when it loads.
+package ui
+
+import (
+	"encoding/json"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/grafana/dskit/middleware"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
+)
+
+// BootstrapPath is the HTTP path the UI loads its bootstrap data from
+const BootstrapPath = "/api/ui/bootstrap"
+
+// Features the UI can hide when the server doesn't support them, in addition to the
+// server's feature flags
+const (
+	// FeatureAuth is set when callers are authenticated, so that users and roles are known
+	FeatureAuth = "auth"
+	// FeatureSPIFFEEnrollment is set when agents can enroll with X.509 SVIDs
+	FeatureSPIFFEEnrollment = "spiffe_enrollment"
+	// FeatureVaultSecrets is set when configs can reference Vault secrets
+	FeatureVaultSecrets = "vault_secrets"
+	// FeatureDedicatedOpAMP is set when OpAMP is served on its own listener
+	FeatureDedicatedOpAMP = "dedicated_opamp"
+	// FeatureEnrollmentURLs is set when enrollment URLs and install scripts can be generated
+	// without passing the server's base URL
+	FeatureEnrollmentURLs = "enrollment_urls"
+)
+
+// BootstrapData is everything the UI needs when it loads
+type BootstrapData struct {
+	// User is the authenticated caller, nil for anonymous requests
+	User     *User           `json:"user,omitempty"`
+	Features map[string]bool `json:"features"`
+	// ExternalURL is the base URL agents reach the server at, if configured
+	ExternalURL string       `json:"external_url,omitempty"`
+	Fleet       FleetSummary `json:"fleet"`
+}
+
+type User struct {
+	Subject string   `json:"subject"`
+	Roles   []string `json:"roles"`
+	Admin   bool     `json:"admin"`
+}
+
+// FleetSummary counts agents, overall and by connection state and config sync status
+type FleetSummary struct {
+	Agents     int            `json:"agents"`
+	States     map[string]int `json:"states"`
+	ConfigSync map[string]int `json:"config_sync"`
+}
+
+// Server serves the UI bootstrap data, and the UI itself when its assets are set. Its
+// lifecycle is that of the HTTP server.
+type Server struct {
+	logger      *slog.Logger
+	repository  agentdomain.Repository
+	features    map[string]bool
+	externalURL string
+	// optional, built UI served on the paths no other route handles
+	assets fs.FS
+	// middleware of the bootstrap handler
+	httpMiddleware []middleware.Interface
+}
+
+// NewServer creates a new Server reporting the given features as supported
+func NewServer(logger *slog.Logger, repository agentdomain.Repository, features map[string]bool, externalURL string) *Server {
+	return &Server{
+		logger:      logger,
+		repository:  repository,
+		features:    features,
+		externalURL: externalURL,
+	}
+}
+
+// AddHTTPMiddleware adds middleware to the bootstrap handler, e.g. to authorize its callers.
+// Must be called before ConfigureHTTP.
+func (s *Server) AddHTTPMiddleware(mw ...middleware.Interface) {
+	s.httpMiddleware = append(s.httpMiddleware, mw...)
+}
+
+func (s *Server) ConfigureHTTP(mux *mux.Router) {
+	s.logger.Info("configuring routes")
+	mux.Handle(BootstrapPath, middleware.Merge(s.httpMiddleware...).Wrap(http.HandlerFunc(s.Bootstrap))).Methods(http.MethodGet)
+	if s.assets != nil {
+		mux.NotFoundHandler = s.assetHandler()
+	}
+}
+
+// Bootstrap serves the BootstrapData of the caller as JSON
+func (s *Server) Bootstrap(w http.ResponseWriter, r *http.Request) {
+	agents, err := s.repository.ListView(r.Context(), agentdomain.StatusViewBasic)
+	if err != nil {
+		s.logger.With("err", err).Error("failed to list agents")
+		http.Error(w, "failed to list agents", http.StatusInternalServerError)
+		return
+	}
+	data := BootstrapData{
+		Features:    maps.Clone(s.features),
+		ExternalURL: s.externalURL,
+		Fleet: FleetSummary{
+			Agents:     len(agents),
+			States:     map[string]int{},
+			ConfigSync: map[string]int{},
+		},
+	}
+	if p := auth.FromContext(r.Context()); p != nil {
+		data.User = &User{
+			Subject: p.Subject,
+			Roles:   append([]string{}, p.Roles...),
+			Admin:   p.IsAdmin(),
+		}
+	}
+	for _, a := range agents {
+		status := agentdomain.ToAPIStatus(a)
+		data.Fleet.States[enumName(status.GetState().String(), "AGENT_STATE_")]++
+		data.Fleet.ConfigSync[enumName(status.GetConfigSyncStatus().String(), "CONFIG_SYNC_STATUS_")]++
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	// the data depends on the caller and changes with the fleet
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		s.logger.With("err", err).Warn("failed to write UI bootstrap data")
+	}
+}
+
+// enumName turns a proto enum value name into a short lower case name, e.g.
+// AGENT_STATE_CONNECTED into connected
+func enumName(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
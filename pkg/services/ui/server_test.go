@@ -0,0 +1,122 @@
+package ui_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/gorilla/mux"
+	rbacv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/rbac/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/services/ui"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Bootstrap(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	ctx := t.Context()
+
+	connected := env.NewAgent("connected")
+	require.NoError(t, connected.Start())
+	connected.WaitForConfig(t, 5*time.Second)
+	require.NoError(t, env.AgentRepo.Register(ctx, "registered", "registered"))
+
+	router := mux.NewRouter()
+	ui.NewServer(env.Logger, env.AgentRepo, map[string]bool{ui.FeatureAuth: true, ui.FeatureVaultSecrets: false}, "https://otelfleet.example.com").ConfigureHTTP(router)
+	handler := auth.NewMiddleware(env.Logger, auth.NewHeaderAuthenticator()).Wrap(router)
+
+	get := func(header http.Header) ui.BootstrapData {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, ui.BootstrapPath, nil)
+		req.Header = header
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		var data ui.BootstrapData
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &data))
+		return data
+	}
+
+	data := get(http.Header{auth.HeaderUser: {"alice"}, auth.HeaderRoles: {"admin, ops"}})
+	require.NotNil(t, data.User)
+	assert.Equal(t, "alice", data.User.Subject)
+	assert.Equal(t, []string{"admin", "ops"}, data.User.Roles)
+	assert.True(t, data.User.Admin)
+	assert.Equal(t, map[string]bool{ui.FeatureAuth: true, ui.FeatureVaultSecrets: false}, data.Features)
+	assert.Equal(t, "https://otelfleet.example.com", data.ExternalURL)
+	assert.Equal(t, 2, data.Fleet.Agents)
+	assert.Equal(t, 1, data.Fleet.States["connected"])
+
+	data = get(http.Header{})
+	assert.Nil(t, data.User)
+}
+
+func TestServer_BootstrapRBAC(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+	router := mux.NewRouter()
+	srv := ui.NewServer(env.Logger, env.AgentRepo, nil, "")
+	srv.AddHTTPMiddleware(auth.NewInterceptor(
+		env.Logger,
+		auth.NewRoleBindings(storage.NewProtoKV[*rbacv1alpha1.RoleBinding](env.Logger, env.Broker.KeyValue("role-bindings")), nil),
+		auth.NewAPIKeys(storage.NewProtoKV[*rbacv1alpha1.APIKey](env.Logger, env.Broker.KeyValue("api-keys"))),
+		nil,
+	).Middleware(auth.RoleViewer))
+	srv.ConfigureHTTP(router)
+
+	// unauthenticated callers don't learn about the fleet in RBAC mode
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ui.BootstrapPath, nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestServer_Assets(t *testing.T) {
+	env := testutil.NewTestEnv(t)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/other", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("other"))
+	})
+	srv := ui.NewServer(env.Logger, env.AgentRepo, nil, "")
+	srv.SetAssets(fstest.MapFS{
+		"index.html":       {Data: []byte("<html>index</html>")},
+		"assets/app-1.js":  {Data: []byte("console.log(1)")},
+		"assets/app-1.css": {Data: []byte("body {}")},
+	})
+	srv.ConfigureHTTP(router)
+
+	get := func(method, path string) *httptest.ResponseRecorder {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+
+	rec := get(http.MethodGet, "/")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "<html>index</html>", rec.Body.String())
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+
+	rec = get(http.MethodGet, "/assets/app-1.js")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "console.log(1)", rec.Body.String())
+
+	// pages are routed by the UI
+	rec = get(http.MethodGet, "/agents/agent-1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "<html>index</html>", rec.Body.String())
+
+	// other routes take precedence
+	assert.Equal(t, "other", get(http.MethodGet, "/api/other").Body.String())
+
+	assert.Equal(t, http.StatusNotFound, get(http.MethodGet, "/assets/missing.js").Code)
+	assert.Equal(t, http.StatusNotFound, get(http.MethodGet, "/api/missing").Code)
+	assert.Equal(t, http.StatusNotFound, get(http.MethodPost, "/otelfleet.agents.v1alpha1.AgentService/Missing").Code)
+	assert.Equal(t, http.StatusNotFound, get(http.MethodPost, "/agents").Code)
+}
@@ -0,0 +1,221 @@
+// Package spiffe provides SPIFFE X.509 SVID based authentication of agents,
+// as an alternative to bootstrap tokens for clusters running SPIRE.
+package spiffe
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/otelfleet/otelfleet/pkg/ident"
+)
+
+const scheme = "spiffe"
+
+var (
+	ErrNoPeerCertificate = errors.New("no peer certificate presented")
+	ErrInvalidSPIFFEID   = errors.New("invalid SPIFFE ID")
+	ErrUntrustedDomain   = errors.New("SPIFFE ID is not a member of the trust domain")
+)
+
+// Config configures SPIFFE authentication on the server.
+type Config struct {
+	// TrustDomain is the SPIFFE trust domain agents must belong to, e.g. "example.org"
+	TrustDomain string `yaml:"trust_domain"`
+	// BundlePath is the path to a PEM encoded X.509 bundle for the trust domain
+	BundlePath string `yaml:"bundle_path"`
+}
+
+// Enabled returns true if SPIFFE authentication is configured.
+func (c Config) Enabled() bool {
+	return c.TrustDomain != ""
+}
+
+// ID is a parsed SPIFFE ID of the form spiffe://<trust-domain>/<path>.
+type ID struct {
+	TrustDomain string
+	Path        string
+}
+
+func (i ID) String() string {
+	return fmt.Sprintf("%s://%s%s", scheme, i.TrustDomain, i.Path)
+}
+
+// ParseID parses and validates a SPIFFE ID.
+func ParseID(raw string) (ID, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ID{}, fmt.Errorf("%w: %s", ErrInvalidSPIFFEID, err)
+	}
+	return idFromURL(u)
+}
+
+func idFromURL(u *url.URL) (ID, error) {
+	if u.Scheme != scheme {
+		return ID{}, fmt.Errorf("%w: scheme must be %s", ErrInvalidSPIFFEID, scheme)
+	}
+	if u.Host == "" {
+		return ID{}, fmt.Errorf("%w: empty trust domain", ErrInvalidSPIFFEID)
+	}
+	if u.User != nil || u.Port() != "" || u.RawQuery != "" || u.Fragment != "" {
+		return ID{}, fmt.Errorf("%w: must not contain userinfo, port, query or fragment", ErrInvalidSPIFFEID)
+	}
+	if u.Path == "" || u.Path == "/" || strings.HasSuffix(u.Path, "/") {
+		return ID{}, fmt.Errorf("%w: workload path must be non-empty and must not end with /", ErrInvalidSPIFFEID)
+	}
+	return ID{
+		TrustDomain: strings.ToLower(u.Host),
+		Path:        u.Path,
+	}, nil
+}
+
+// IDFromCertificate extracts the SPIFFE ID from the URI SAN of an X.509 SVID.
+// An SVID must contain exactly one URI SAN.
+func IDFromCertificate(cert *x509.Certificate) (ID, error) {
+	if len(cert.URIs) != 1 {
+		return ID{}, fmt.Errorf("%w: expected exactly one URI SAN, got %d", ErrInvalidSPIFFEID, len(cert.URIs))
+	}
+	return idFromURL(cert.URIs[0])
+}
+
+// AgentID maps a SPIFFE ID to the otelfleet agent identity.
+func AgentID(id ID) ident.Identity {
+	return ident.IdFromSPIFFE(sha256.New(), id.String())
+}
+
+// Authenticator verifies X.509 SVIDs presented by agents against the
+// configured trust domain bundle.
+type Authenticator struct {
+	logger      *slog.Logger
+	trustDomain string
+	bundle      *x509.CertPool
+}
+
+// NewAuthenticator creates an Authenticator from the given config, loading
+// the trust bundle from disk.
+func NewAuthenticator(logger *slog.Logger, cfg Config) (*Authenticator, error) {
+	if cfg.TrustDomain == "" {
+		return nil, fmt.Errorf("spiffe trust domain must be set")
+	}
+	data, err := os.ReadFile(cfg.BundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spiffe trust bundle: %w", err)
+	}
+	bundle := x509.NewCertPool()
+	if !bundle.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in spiffe trust bundle %s", cfg.BundlePath)
+	}
+	return NewAuthenticatorWithBundle(logger, cfg.TrustDomain, bundle), nil
+}
+
+// NewAuthenticatorWithBundle creates an Authenticator with an in-memory trust bundle.
+func NewAuthenticatorWithBundle(logger *slog.Logger, trustDomain string, bundle *x509.CertPool) *Authenticator {
+	return &Authenticator{
+		logger:      logger,
+		trustDomain: strings.ToLower(trustDomain),
+		bundle:      bundle,
+	}
+}
+
+// Authenticate verifies the peer certificate chain of a TLS connection and
+// returns the SPIFFE ID of the presented SVID.
+func (a *Authenticator) Authenticate(state *tls.ConnectionState) (ID, error) {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return ID{}, ErrNoPeerCertificate
+	}
+	leaf := state.PeerCertificates[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         a.bundle,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return ID{}, fmt.Errorf("failed to verify SVID: %w", err)
+	}
+	id, err := IDFromCertificate(leaf)
+	if err != nil {
+		return ID{}, err
+	}
+	if id.TrustDomain != a.trustDomain {
+		return ID{}, fmt.Errorf("%w: %s", ErrUntrustedDomain, id)
+	}
+	return id, nil
+}
+
+// Wrap implements the dskit middleware.Interface. Requests presenting a valid
+// SVID have the SPIFFE ID attached to their context; other requests pass
+// through untouched so token based bootstrap keeps working.
+func (a *Authenticator) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			id, err := a.Authenticate(r.TLS)
+			if err != nil {
+				a.logger.With("err", err, "remote-addr", r.RemoteAddr).Warn("rejected SVID")
+			} else {
+				r = r.WithContext(NewContext(r.Context(), id))
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+type idKey struct{}
+
+// NewContext returns a context carrying the authenticated SPIFFE ID.
+func NewContext(ctx context.Context, id ID) context.Context {
+	return context.WithValue(ctx, idKey{}, id)
+}
+
+// FromContext returns the authenticated SPIFFE ID, if any.
+func FromContext(ctx context.Context) (ID, bool) {
+	id, ok := ctx.Value(idKey{}).(ID)
+	return id, ok
+}
+
+// ClientTLSConfig builds the TLS config an agent uses to present its SVID.
+// If bundlePath is empty, the system roots are used to verify the server.
+func ClientTLSConfig(certPath, keyPath, bundlePath string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load SVID: %w", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if bundlePath != "" {
+		data, err := os.ReadFile(bundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trust bundle: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in trust bundle %s", bundlePath)
+		}
+		cfg.RootCAs = roots
+	}
+	return cfg, nil
+}
+
+// IDFromTLSConfig returns the SPIFFE ID of the SVID configured in a client TLS config.
+func IDFromTLSConfig(cfg *tls.Config) (ID, error) {
+	if cfg == nil || len(cfg.Certificates) == 0 || len(cfg.Certificates[0].Certificate) == 0 {
+		return ID{}, ErrNoPeerCertificate
+	}
+	leaf, err := x509.ParseCertificate(cfg.Certificates[0].Certificate[0])
+	if err != nil {
+		return ID{}, err
+	}
+	return IDFromCertificate(leaf)
+}
@@ -0,0 +1,156 @@
+package spiffe_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/spiffe"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+func (c *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(c.cert)
+	return pool
+}
+
+func (c *testCA) svid(t *testing.T, uris ...string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, raw := range uris {
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		tmpl.URIs = append(tmpl.URIs, u)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, c.cert, &key.PublicKey, c.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestParseID(t *testing.T) {
+	id, err := spiffe.ParseID("spiffe://Example.org/ns/prod/sa/otelcol")
+	require.NoError(t, err)
+	require.Equal(t, "example.org", id.TrustDomain)
+	require.Equal(t, "/ns/prod/sa/otelcol", id.Path)
+	require.Equal(t, "spiffe://example.org/ns/prod/sa/otelcol", id.String())
+
+	for _, invalid := range []string{
+		"https://example.org/foo",
+		"spiffe:///foo",
+		"spiffe://example.org",
+		"spiffe://example.org/",
+		"spiffe://example.org/foo/",
+		"spiffe://example.org:8080/foo",
+		"spiffe://example.org/foo?bar=baz",
+	} {
+		_, err := spiffe.ParseID(invalid)
+		require.ErrorIs(t, err, spiffe.ErrInvalidSPIFFEID, invalid)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	ca := newTestCA(t)
+	auth := spiffe.NewAuthenticatorWithBundle(slog.Default(), "example.org", ca.pool())
+
+	t.Run("valid svid", func(t *testing.T) {
+		svid := ca.svid(t, "spiffe://example.org/agent/foo")
+		id, err := auth.Authenticate(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{svid}})
+		require.NoError(t, err)
+		require.Equal(t, "spiffe://example.org/agent/foo", id.String())
+		require.Equal(t, spiffe.AgentID(id).UniqueIdentifier(), spiffe.AgentID(id).UniqueIdentifier())
+	})
+
+	t.Run("no peer certificate", func(t *testing.T) {
+		_, err := auth.Authenticate(&tls.ConnectionState{})
+		require.ErrorIs(t, err, spiffe.ErrNoPeerCertificate)
+	})
+
+	t.Run("foreign trust domain", func(t *testing.T) {
+		svid := ca.svid(t, "spiffe://other.org/agent/foo")
+		_, err := auth.Authenticate(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{svid}})
+		require.ErrorIs(t, err, spiffe.ErrUntrustedDomain)
+	})
+
+	t.Run("untrusted issuer", func(t *testing.T) {
+		svid := newTestCA(t).svid(t, "spiffe://example.org/agent/foo")
+		_, err := auth.Authenticate(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{svid}})
+		require.Error(t, err)
+	})
+
+	t.Run("multiple URI SANs", func(t *testing.T) {
+		svid := ca.svid(t, "spiffe://example.org/agent/foo", "spiffe://example.org/agent/bar")
+		_, err := auth.Authenticate(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{svid}})
+		require.ErrorIs(t, err, spiffe.ErrInvalidSPIFFEID)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	ca := newTestCA(t)
+	auth := spiffe.NewAuthenticatorWithBundle(slog.Default(), "example.org", ca.pool())
+
+	var gotID spiffe.ID
+	var gotOK bool
+	handler := auth.Wrap(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		gotID, gotOK = spiffe.FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.False(t, gotOK)
+
+	req = httptest.NewRequest(http.MethodPost, "/", nil)
+	req.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{ca.svid(t, "spiffe://example.org/agent/foo")}}
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	require.True(t, gotOK)
+	require.Equal(t, "spiffe://example.org/agent/foo", gotID.String())
+
+	_, ok := spiffe.FromContext(context.Background())
+	require.False(t, ok)
+}
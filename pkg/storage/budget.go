@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// Default slow operation thresholds
+const (
+	DefaultSlowReadThreshold = 100 * time.Millisecond
+	DefaultSlowScanThreshold = time.Second
+)
+
+// Budget bounds the time spent in storage operations
+type Budget struct {
+	// Timeout bounds the operations whose context has no deadline, 0 leaves them unbounded.
+	// Operations always stop once their context is done.
+	Timeout time.Duration `yaml:"timeout"`
+	// SlowReadThreshold logs single key operations taking longer, defaults to
+	// DefaultSlowReadThreshold
+	SlowReadThreshold time.Duration `yaml:"slow_read_threshold"`
+	// SlowScanThreshold logs listings and prefix deletions taking longer, defaults to
+	// DefaultSlowScanThreshold
+	SlowScanThreshold time.Duration `yaml:"slow_scan_threshold"`
+}
+
+// NewBudgetBroker returns a broker whose keyspaces enforce the deadline of operation
+// contexts, and log operations exceeding the slow thresholds of budget
+func NewBudgetBroker(logger *slog.Logger, broker KVBroker, budget Budget) KVBroker {
+	if budget.SlowReadThreshold <= 0 {
+		budget.SlowReadThreshold = DefaultSlowReadThreshold
+	}
+	if budget.SlowScanThreshold <= 0 {
+		budget.SlowScanThreshold = DefaultSlowScanThreshold
+	}
+	return &budgetBroker{logger: logger, broker: broker, budget: budget}
+}
+
+type budgetBroker struct {
+	logger *slog.Logger
+	broker KVBroker
+	budget Budget
+}
+
+func (b *budgetBroker) KeyValue(prefix string) KV {
+	return &budgetKV{
+		logger:     b.logger.With("store", prefix),
+		underlying: b.broker.KeyValue(prefix),
+		budget:     b.budget,
+	}
+}
+
+// IndexedKeyValue returns the indexed keyspace of the underlying broker within the budget
+func (b *budgetBroker) IndexedKeyValue(prefix string, indexes ...Index) IndexedKV {
+	underlying := OpenIndexed(b.broker, prefix, indexes...)
+	return &budgetIndexedKV{
+		budgetKV: &budgetKV{
+			logger:     b.logger.With("store", prefix),
+			underlying: underlying,
+			budget:     b.budget,
+		},
+		indexed: underlying,
+	}
+}
+
+// Watch watches the keyspace of the underlying broker, watches aren't bounded by the budget
+func (b *budgetBroker) Watch(ctx context.Context, prefix string) (<-chan WatchEvent, error) {
+	return b.broker.Watch(ctx, prefix)
+}
+
+type budgetKV struct {
+	logger     *slog.Logger
+	underlying KV
+	budget     Budget
+}
+
+// do runs a storage operation within the budget of ctx
+func (kv *budgetKV) do(ctx context.Context, op, key string, slow time.Duration, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Deadline(); !ok && kv.budget.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, kv.budget.Timeout)
+		defer cancel()
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("storage %s aborted: %w", op, context.Cause(ctx))
+	}
+	start := time.Now()
+	err := fn(ctx)
+	if elapsed := time.Since(start); elapsed > slow {
+		lg := kv.logger.With("op", op, "key", key, "duration", elapsed)
+		if deadline, ok := ctx.Deadline(); ok {
+			lg = lg.With("budget_left", time.Until(deadline))
+		}
+		lg.Warn("slow storage operation")
+	}
+	return err
+}
+
+func (kv *budgetKV) Put(ctx context.Context, key string, obj []byte) error {
+	return kv.do(ctx, "put", key, kv.budget.SlowReadThreshold, func(ctx context.Context) error {
+		return kv.underlying.Put(ctx, key, obj)
+	})
+}
+
+func (kv *budgetKV) Create(ctx context.Context, key string, obj []byte) error {
+	return kv.do(ctx, "create", key, kv.budget.SlowReadThreshold, func(ctx context.Context) error {
+		return kv.underlying.Create(ctx, key, obj)
+	})
+}
+
+func (kv *budgetKV) CompareAndSwap(ctx context.Context, key string, old, obj []byte) error {
+	return kv.do(ctx, "compare_and_swap", key, kv.budget.SlowReadThreshold, func(ctx context.Context) error {
+		return kv.underlying.CompareAndSwap(ctx, key, old, obj)
+	})
+}
+
+func (kv *budgetKV) Get(ctx context.Context, key string) (data []byte, err error) {
+	err = kv.do(ctx, "get", key, kv.budget.SlowReadThreshold, func(ctx context.Context) (err error) {
+		data, err = kv.underlying.Get(ctx, key)
+		return err
+	})
+	return data, err
+}
+
+func (kv *budgetKV) ListKeys(ctx context.Context) (keys []string, err error) {
+	err = kv.do(ctx, "list_keys", "", kv.budget.SlowScanThreshold, func(ctx context.Context) (err error) {
+		keys, err = kv.underlying.ListKeys(ctx)
+		return err
+	})
+	return keys, err
+}
+
+func (kv *budgetKV) List(ctx context.Context) (values [][]byte, err error) {
+	err = kv.do(ctx, "list", "", kv.budget.SlowScanThreshold, func(ctx context.Context) (err error) {
+		values, err = kv.underlying.List(ctx)
+		return err
+	})
+	return values, err
+}
+
+func (kv *budgetKV) Delete(ctx context.Context, key string) error {
+	return kv.do(ctx, "delete", key, kv.budget.SlowReadThreshold, func(ctx context.Context) error {
+		return kv.underlying.Delete(ctx, key)
+	})
+}
+
+func (kv *budgetKV) ListPrefix(ctx context.Context, prefix string) (entries []KeyValuePair[[]byte], err error) {
+	err = kv.do(ctx, "list_prefix", prefix, kv.budget.SlowScanThreshold, func(ctx context.Context) (err error) {
+		entries, err = kv.underlying.ListPrefix(ctx, prefix)
+		return err
+	})
+	return entries, err
+}
+
+func (kv *budgetKV) DeletePrefix(ctx context.Context, prefix string) error {
+	return kv.do(ctx, "delete_prefix", prefix, kv.budget.SlowScanThreshold, func(ctx context.Context) error {
+		return kv.underlying.DeletePrefix(ctx, prefix)
+	})
+}
+
+func (kv *budgetKV) NewBatch() Batch {
+	return kv.underlying.NewBatch()
+}
+
+func (kv *budgetKV) PutBatch(ctx context.Context, b Batch, key string, obj []byte) error {
+	return kv.do(ctx, "put_batch", key, kv.budget.SlowReadThreshold, func(ctx context.Context) error {
+		return kv.underlying.PutBatch(ctx, b, key, obj)
+	})
+}
+
+func (kv *budgetKV) DeleteBatch(ctx context.Context, b Batch, key string) error {
+	return kv.do(ctx, "delete_batch", key, kv.budget.SlowReadThreshold, func(ctx context.Context) error {
+		return kv.underlying.DeleteBatch(ctx, b, key)
+	})
+}
+
+type budgetIndexedKV struct {
+	*budgetKV
+	indexed IndexedKV
+}
+
+func (kv *budgetIndexedKV) KeysByIndex(ctx context.Context, index, value string) (keys []string, err error) {
+	err = kv.do(ctx, "keys_by_index", value, kv.budget.SlowScanThreshold, func(ctx context.Context) (err error) {
+		keys, err = kv.indexed.KeysByIndex(ctx, index, value)
+		return err
+	})
+	return keys, err
+}
+
+var _ IndexedKVBroker = (*budgetBroker)(nil)
+var _ KV = (*budgetKV)(nil)
+var _ IndexedKV = (*budgetIndexedKV)(nil)
@@ -0,0 +1,96 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble/v2"
+	"github.com/cockroachdb/pebble/v2/vfs"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	otelpebble "github.com/otelfleet/otelfleet/pkg/storage/pebble"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowKV delays every operation, reporting the deadline of the last operation
+type slowKV struct {
+	storage.KV
+	delay    time.Duration
+	deadline time.Time
+}
+
+func (s *slowKV) Get(ctx context.Context, key string) ([]byte, error) {
+	s.deadline, _ = ctx.Deadline()
+	time.Sleep(s.delay)
+	return s.KV.Get(ctx, key)
+}
+
+type slowBroker struct {
+	storage.KVBroker
+	kv *slowKV
+}
+
+func (b slowBroker) KeyValue(string) storage.KV { return b.kv }
+
+func TestBudgetBroker(t *testing.T) {
+	db, err := pebble.Open("", &pebble.Options{FS: vfs.NewMem()})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	t.Run("enforces deadlines", func(t *testing.T) {
+		kv := storage.NewBudgetBroker(slog.Default(), otelpebble.NewKVBroker(db), storage.Budget{}).KeyValue("deadlines")
+		for i := range 10 {
+			require.NoError(t, kv.Put(t.Context(), string(rune('a'+i)), []byte("v")))
+		}
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err := kv.Get(ctx, "a")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, kv.Put(ctx, "a", []byte("v")), context.Canceled)
+		_, err = kv.List(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		expired, cancel := context.WithDeadline(t.Context(), time.Now().Add(-time.Second))
+		defer cancel()
+		_, err = kv.ListPrefix(expired, "")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		values, err := kv.List(t.Context())
+		require.NoError(t, err)
+		assert.Len(t, values, 10)
+	})
+
+	t.Run("bounds operations without a deadline", func(t *testing.T) {
+		underlying := &slowKV{KV: otelpebble.NewKVBroker(db).KeyValue("timeouts")}
+		kv := storage.NewBudgetBroker(slog.Default(), slowBroker{kv: underlying}, storage.Budget{Timeout: time.Minute}).KeyValue("timeouts")
+
+		_, err := kv.Get(t.Context(), "missing")
+		require.Error(t, err)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), underlying.deadline, 5*time.Second)
+
+		// the caller's deadline is kept
+		ctx, cancel := context.WithTimeout(t.Context(), time.Hour)
+		defer cancel()
+		_, _ = kv.Get(ctx, "missing")
+		assert.WithinDuration(t, time.Now().Add(time.Hour), underlying.deadline, 5*time.Second)
+	})
+
+	t.Run("logs slow operations", func(t *testing.T) {
+		logs := &bytes.Buffer{}
+		logger := slog.New(slog.NewTextHandler(logs, nil))
+		underlying := &slowKV{KV: otelpebble.NewKVBroker(db).KeyValue("slow"), delay: 20 * time.Millisecond}
+		kv := storage.NewBudgetBroker(logger, slowBroker{kv: underlying}, storage.Budget{SlowReadThreshold: 10 * time.Millisecond}).KeyValue("slow")
+
+		require.NoError(t, kv.Put(t.Context(), "fast", []byte("v")))
+		assert.Empty(t, logs.String())
+
+		_, err := kv.Get(t.Context(), "fast")
+		require.NoError(t, err)
+		assert.Contains(t, logs.String(), "slow storage operation")
+		assert.Contains(t, logs.String(), "store=slow op=get key=fast")
+	})
+}
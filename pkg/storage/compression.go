@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressedMagic prefixes compressed values. A valid protobuf encoding never
+// starts with a zero byte (field number 0 is reserved), so values written before
+// compression was enabled for a keyspace remain readable.
+var compressedMagic = []byte{0x00, 'z', 's', 't'}
+
+// defaultMinCompressSize is the size below which values are stored uncompressed,
+// since small values gain little and pay for the frame header.
+const defaultMinCompressSize = 512
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+func initZstd() error {
+	zstdOnce.Do(func() {
+		zstdEncoder, zstdErr = zstd.NewWriter(nil)
+		if zstdErr != nil {
+			return
+		}
+		zstdDecoder, zstdErr = zstd.NewReader(nil)
+	})
+	return zstdErr
+}
+
+func compress(data []byte) ([]byte, error) {
+	if err := initZstd(); err != nil {
+		return nil, err
+	}
+	dst := make([]byte, len(compressedMagic), len(compressedMagic)+len(data)/2)
+	copy(dst, compressedMagic)
+	return zstdEncoder.EncodeAll(data, dst), nil
+}
+
+// decompress returns data as is, unless it carries the compressed header
+func decompress(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, compressedMagic) {
+		return data, nil
+	}
+	if err := initZstd(); err != nil {
+		return nil, err
+	}
+	out, err := zstdDecoder.DecodeAll(data[len(compressedMagic):], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress value: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors returned by KV implementations, wrapped in a *KeyError naming the key
+var (
+	// ErrNotFound is returned when reading a key that doesn't exist
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists is returned when creating a key that already exists
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrConflict is returned when swapping the value of a key that was changed
+	ErrConflict = errors.New("was changed")
+)
+
+// Errors returned by batches
+var (
+	// ErrForeignBatch is returned when adding a write to a batch started by another broker
+	ErrForeignBatch = errors.New("batch was started by another broker")
+	// ErrBatchCommitted is returned when using a batch that was committed
+	ErrBatchCommitted = errors.New("batch was already committed")
+)
+
+// ErrUnknownIndex is returned when looking up an index the keyspace wasn't opened with
+var ErrUnknownIndex = errors.New("unknown index")
+
+// UnknownIndex returns the error for an index the keyspace wasn't opened with
+func UnknownIndex(name string) error {
+	return fmt.Errorf("%w %q", ErrUnknownIndex, name)
+}
+
+// KeyError is an error about a key of a keyspace, wrapping ErrNotFound, ErrAlreadyExists or
+// ErrConflict
+type KeyError struct {
+	Key string
+	Err error
+}
+
+// NotFound returns the error for a key that doesn't exist
+func NotFound(key string) error {
+	return &KeyError{Key: key, Err: ErrNotFound}
+}
+
+// AlreadyExists returns the error for a key that already exists
+func AlreadyExists(key string) error {
+	return &KeyError{Key: key, Err: ErrAlreadyExists}
+}
+
+// Conflict returns the error for a key whose value isn't the expected one
+func Conflict(key string) error {
+	return &KeyError{Key: key, Err: ErrConflict}
+}
+
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("key %q %s", e.Key, e.Err)
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus reports key errors with their gRPC code, so that they are recognized by
+// grpcutil.IsErrorNotFound
+func (e *KeyError) GRPCStatus() *status.Status {
+	code := codes.Unknown
+	switch {
+	case errors.Is(e.Err, ErrNotFound):
+		code = codes.NotFound
+	case errors.Is(e.Err, ErrAlreadyExists):
+		code = codes.AlreadyExists
+	case errors.Is(e.Err, ErrConflict):
+		code = codes.Aborted
+	}
+	return status.New(code, e.Error())
+}
+
+// IsNotFound returns true if err reports a key that doesn't exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsAlreadyExists returns true if err reports a key that already exists
+func IsAlreadyExists(err error) bool {
+	return errors.Is(err, ErrAlreadyExists)
+}
+
+// IsConflict returns true if err reports a key whose value was changed
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
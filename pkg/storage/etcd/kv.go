@@ -0,0 +1,273 @@
+// Package etcd implements storage keyspaces in an etcd cluster. Every server using the same
+// cluster and root shares the keyspaces, and watches the changes made by the others.
+package etcd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// KVBroker keeps the keys of its keyspaces under root, as root, keyspace, '/', key. Batches are
+// committed as single transactions, so they are bounded by the maximum number of operations
+// of a transaction of the cluster, 128 by default.
+type KVBroker struct {
+	client *clientv3.Client
+	root   string
+}
+
+// NewKVBroker creates a broker keeping its keyspaces under root, e.g. "/otelfleet/"
+func NewKVBroker(client *clientv3.Client, root string) *KVBroker {
+	return &KVBroker{client: client, root: root}
+}
+
+func (b *KVBroker) KeyValue(prefix string) storage.KV {
+	return &etcdKV{broker: b, prefix: b.root + prefix + "/"}
+}
+
+// Watch watches the keyspace from the revision of the cluster when it is called. The deletions
+// of prefixes are streamed as the EventDelete of each deleted key. Watches are also closed
+// when the revision they watch from was compacted.
+func (b *KVBroker) Watch(ctx context.Context, prefix string) (<-chan storage.WatchEvent, error) {
+	kv := &etcdKV{broker: b, prefix: b.root + prefix + "/"}
+	if err := kv.checkContext(ctx, "watch"); err != nil {
+		return nil, err
+	}
+	// changes committed once Watch returns come after the current revision
+	resp, err := b.client.Get(ctx, kv.prefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	changes := b.client.Watch(clientv3.WithRequireLeader(ctx), kv.prefix, clientv3.WithPrefix(), clientv3.WithRev(resp.Header.Revision+1))
+	events := make(chan storage.WatchEvent, storage.WatchBuffer)
+	go func() {
+		defer close(events)
+		defer cancel()
+		for resp := range changes {
+			if resp.Err() != nil {
+				return
+			}
+			for _, ev := range resp.Events {
+				event := storage.WatchEvent{Type: storage.EventDelete, Key: kv.trim(ev.Kv.Key)}
+				if ev.Type == clientv3.EventTypePut {
+					event.Type, event.Value = storage.EventPut, ev.Kv.Value
+				}
+				select {
+				case events <- event:
+				default:
+					// fell behind
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
+
+type etcdKV struct {
+	broker *KVBroker
+	// prefix of the keys of the keyspace
+	prefix string
+}
+
+func (k *etcdKV) key(key string) string {
+	return k.prefix + key
+}
+
+func (k *etcdKV) trim(key []byte) string {
+	return strings.TrimPrefix(string(key), k.prefix)
+}
+
+// checkContext fails operations whose context is done
+func (k *etcdKV) checkContext(ctx context.Context, op string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s in %s aborted: %w", op, k.prefix, context.Cause(ctx))
+	}
+	return nil
+}
+
+func (k *etcdKV) Put(ctx context.Context, key string, obj []byte) error {
+	if err := k.checkContext(ctx, "put"); err != nil {
+		return err
+	}
+	_, err := k.broker.client.Put(ctx, k.key(key), string(obj))
+	return err
+}
+
+func (k *etcdKV) Create(ctx context.Context, key string, obj []byte) error {
+	if err := k.checkContext(ctx, "create"); err != nil {
+		return err
+	}
+	resp, err := k.broker.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(k.key(key)), "=", 0)).
+		Then(clientv3.OpPut(k.key(key), string(obj))).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return storage.AlreadyExists(key)
+	}
+	return nil
+}
+
+func (k *etcdKV) CompareAndSwap(ctx context.Context, key string, old, obj []byte) error {
+	if err := k.checkContext(ctx, "compare_and_swap"); err != nil {
+		return err
+	}
+	resp, err := k.broker.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.Value(k.key(key)), "=", string(old))).
+		Then(clientv3.OpPut(k.key(key), string(obj))).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if resp.Succeeded {
+		return nil
+	}
+	// the comparison of the value of a missing key fails
+	if _, err := k.Get(ctx, key); err != nil {
+		return err
+	}
+	return storage.Conflict(key)
+}
+
+func (k *etcdKV) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := k.checkContext(ctx, "get"); err != nil {
+		return nil, err
+	}
+	resp, err := k.broker.client.Get(ctx, k.key(key))
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, storage.NotFound(key)
+	}
+	return resp.Kvs[0].Value, nil
+}
+
+// listPrefix returns the entries whose key starts with prefix, in key order
+func (k *etcdKV) listPrefix(ctx context.Context, op, prefix string, opts ...clientv3.OpOption) ([]storage.KeyValuePair[[]byte], error) {
+	if err := k.checkContext(ctx, op); err != nil {
+		return nil, err
+	}
+	opts = append(opts, clientv3.WithPrefix(), clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend))
+	resp, err := k.broker.client.Get(ctx, k.key(prefix), opts...)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]storage.KeyValuePair[[]byte], 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		entries = append(entries, storage.KeyValuePair[[]byte]{Key: k.trim(kv.Key), Value: kv.Value})
+	}
+	return entries, nil
+}
+
+func (k *etcdKV) ListKeys(ctx context.Context) ([]string, error) {
+	entries, err := k.listPrefix(ctx, "list_keys", "", clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		keys = append(keys, entry.Key)
+	}
+	return keys, nil
+}
+
+func (k *etcdKV) List(ctx context.Context) ([][]byte, error) {
+	entries, err := k.listPrefix(ctx, "list", "")
+	if err != nil {
+		return nil, err
+	}
+	values := make([][]byte, 0, len(entries))
+	for _, entry := range entries {
+		values = append(values, entry.Value)
+	}
+	return values, nil
+}
+
+func (k *etcdKV) Delete(ctx context.Context, key string) error {
+	if err := k.checkContext(ctx, "delete"); err != nil {
+		return err
+	}
+	_, err := k.broker.client.Delete(ctx, k.key(key))
+	return err
+}
+
+func (k *etcdKV) ListPrefix(ctx context.Context, prefix string) ([]storage.KeyValuePair[[]byte], error) {
+	return k.listPrefix(ctx, "list_prefix", prefix)
+}
+
+func (k *etcdKV) DeletePrefix(ctx context.Context, prefix string) error {
+	if err := k.checkContext(ctx, "delete_prefix"); err != nil {
+		return err
+	}
+	_, err := k.broker.client.Delete(ctx, k.key(prefix), clientv3.WithPrefix())
+	return err
+}
+
+func (k *etcdKV) NewBatch() storage.Batch {
+	return &batch{broker: k.broker}
+}
+
+// addToBatch adds op to b
+func (k *etcdKV) addToBatch(ctx context.Context, name string, b storage.Batch, op clientv3.Op) error {
+	if err := k.checkContext(ctx, name); err != nil {
+		return err
+	}
+	eb, ok := b.(*batch)
+	if !ok || eb.broker != k.broker {
+		return storage.ErrForeignBatch
+	}
+	if eb.committed {
+		return storage.ErrBatchCommitted
+	}
+	eb.ops = append(eb.ops, op)
+	return nil
+}
+
+func (k *etcdKV) PutBatch(ctx context.Context, b storage.Batch, key string, obj []byte) error {
+	return k.addToBatch(ctx, "put_batch", b, clientv3.OpPut(k.key(key), string(obj)))
+}
+
+func (k *etcdKV) DeleteBatch(ctx context.Context, b storage.Batch, key string) error {
+	return k.addToBatch(ctx, "delete_batch", b, clientv3.OpDelete(k.key(key)))
+}
+
+// batch commits its operations in a single transaction
+type batch struct {
+	broker    *KVBroker
+	ops       []clientv3.Op
+	callbacks []func()
+	committed bool
+}
+
+func (b *batch) Commit(ctx context.Context) error {
+	if b.committed {
+		return storage.ErrBatchCommitted
+	}
+	b.committed = true
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("batch commit aborted: %w", context.Cause(ctx))
+	}
+	if _, err := b.broker.client.Txn(ctx).Then(b.ops...).Commit(); err != nil {
+		return err
+	}
+	for _, fn := range b.callbacks {
+		fn()
+	}
+	return nil
+}
+
+func (b *batch) OnCommit(fn func()) {
+	b.callbacks = append(b.callbacks, fn)
+}
+
+var _ storage.KV = (*etcdKV)(nil)
+var _ storage.Batch = (*batch)(nil)
+var _ storage.KVBroker = (*KVBroker)(nil)
@@ -0,0 +1,43 @@
+package etcd_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/etcd"
+	"github.com/otelfleet/otelfleet/pkg/storage/storagetest"
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// TestKVBrokerConformance runs against the cluster of the comma separated
+// OTELFLEET_TEST_ETCD_ENDPOINTS, under roots deleted once the tests end
+func TestKVBrokerConformance(t *testing.T) {
+	endpoints := os.Getenv("OTELFLEET_TEST_ETCD_ENDPOINTS")
+	if endpoints == "" {
+		t.Skip("OTELFLEET_TEST_ETCD_ENDPOINTS is not set")
+	}
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   strings.Split(endpoints, ","),
+		DialTimeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	run := time.Now().UnixNano()
+	brokers := 0
+	storagetest.RunKVBrokerConformance(t, func(t *testing.T) storage.KVBroker {
+		brokers++
+		root := fmt.Sprintf("/otelfleet-test/%d/%d/", run, brokers)
+		t.Cleanup(func() {
+			_, err := client.Delete(context.Background(), root, clientv3.WithPrefix())
+			require.NoError(t, err)
+		})
+		return etcd.NewKVBroker(client, root)
+	})
+}
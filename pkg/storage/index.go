@@ -0,0 +1,140 @@
+package storage
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// IndexFunc returns the values a stored value is indexed under, e.g. the config ID of an
+// assignment. Values it can't decode are indexed under no value.
+type IndexFunc func(value []byte) []string
+
+// Index is a secondary index of the values of a keyspace
+type Index struct {
+	Name   string
+	Values IndexFunc
+}
+
+// IndexedKV is a keyspace maintaining secondary indexes of its values. Indexes are updated
+// atomically with the values they index, so that lookups never miss a stored value nor
+// return the key of a deleted one.
+type IndexedKV interface {
+	KV
+	// KeysByIndex returns the keys of the values indexed under value by the named index, in
+	// key order
+	KeysByIndex(ctx context.Context, index, value string) ([]string, error)
+}
+
+// IndexedKVBroker provides keyspaces maintaining secondary indexes. A keyspace must always be
+// opened with the same indexes, the values stored before an index was added are indexed
+// when the keyspace is opened.
+type IndexedKVBroker interface {
+	KVBroker
+	IndexedKeyValue(prefix string, indexes ...Index) IndexedKV
+}
+
+// OpenIndexed returns the keyspace of prefix indexed by indexes. The keyspaces of brokers that
+// don't maintain indexes scan all their values on every lookup.
+func OpenIndexed(broker KVBroker, prefix string, indexes ...Index) IndexedKV {
+	if b, ok := broker.(IndexedKVBroker); ok {
+		return b.IndexedKeyValue(prefix, indexes...)
+	}
+	return &scanIndexedKV{KV: broker.KeyValue(prefix), indexes: indexes}
+}
+
+// FindIndex returns the index named name
+func FindIndex(indexes []Index, name string) (Index, bool) {
+	i := slices.IndexFunc(indexes, func(idx Index) bool { return idx.Name == name })
+	if i < 0 {
+		return Index{}, false
+	}
+	return indexes[i], true
+}
+
+// scanIndexedKV looks up indexes by decoding every value of the keyspace
+type scanIndexedKV struct {
+	KV
+	indexes []Index
+}
+
+func (kv *scanIndexedKV) KeysByIndex(ctx context.Context, index, value string) ([]string, error) {
+	idx, ok := FindIndex(kv.indexes, index)
+	if !ok {
+		return nil, UnknownIndex(index)
+	}
+	entries, err := kv.ListPrefix(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	for _, entry := range entries {
+		if slices.Contains(idx.Values(entry.Value), value) {
+			keys = append(keys, entry.Key)
+		}
+	}
+	return keys, nil
+}
+
+// ProtoIndex indexes the values of a proto keyspace by the values fn returns for them
+func ProtoIndex[T proto.Message](name string, fn func(T) []string) Index {
+	return Index{
+		Name: name,
+		Values: func(value []byte) []string {
+			data, err := decompress(value)
+			if err != nil {
+				return nil
+			}
+			t := NewMessage[T]()
+			if err := proto.Unmarshal(data, t); err != nil {
+				return nil
+			}
+			return fn(t)
+		},
+	}
+}
+
+// IndexedKeyValue is a keyspace of typed values maintaining secondary indexes
+type IndexedKeyValue[T any] interface {
+	KeyValue[T]
+	// ListByIndex returns the entries whose value is indexed under value by the named index,
+	// in key order
+	ListByIndex(ctx context.Context, index, value string) ([]KeyValuePair[T], error)
+}
+
+// NewIndexedProtoKV is NewProtoKV for an indexed keyspace
+func NewIndexedProtoKV[T proto.Message](logger *slog.Logger, kv IndexedKV, opts ...ProtoKVOption) IndexedKeyValue[T] {
+	return &indexedProtoKeyValue[T]{
+		protoKeyValue: NewProtoKV[T](logger, kv, opts...).(*protoKeyValue[T]),
+		indexed:       kv,
+	}
+}
+
+type indexedProtoKeyValue[T proto.Message] struct {
+	*protoKeyValue[T]
+	indexed IndexedKV
+}
+
+func (kv *indexedProtoKeyValue[T]) ListByIndex(ctx context.Context, index, value string) (_ []KeyValuePair[T], err error) {
+	start := time.Now()
+	defer func() { kv.observe("list_by_index", index, start, -1, err) }()
+	keys, err := kv.indexed.KeysByIndex(ctx, index, value)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]KeyValuePair[T], 0, len(keys))
+	for _, key := range keys {
+		t, err := kv.Get(ctx, key)
+		if IsNotFound(err) {
+			// deleted since it was looked up
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		ret = append(ret, KeyValuePair[T]{Key: key, Value: t})
+	}
+	return ret, nil
+}
@@ -0,0 +1,259 @@
+// Package memory implements storage keyspaces held in memory, for tests and ephemeral servers
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/otelfleet/otelfleet/pkg/storage"
+)
+
+// KVBroker holds its keyspaces in memory, their content is lost once the broker is released
+type KVBroker struct {
+	mu        sync.RWMutex
+	keyspaces map[string]map[string][]byte
+	// changes are published under mu, in the order they are applied
+	watchers storage.Watchers
+}
+
+// NewKVBroker creates an empty in-memory broker
+func NewKVBroker() *KVBroker {
+	return &KVBroker{
+		keyspaces: map[string]map[string][]byte{},
+	}
+}
+
+func (b *KVBroker) KeyValue(prefix string) storage.KV {
+	return &memoryKV{broker: b, prefix: prefix}
+}
+
+func (b *KVBroker) Watch(ctx context.Context, prefix string) (<-chan storage.WatchEvent, error) {
+	return b.watchers.Watch(ctx, prefix)
+}
+
+type memoryKV struct {
+	broker *KVBroker
+	prefix string
+}
+
+// checkContext fails operations whose context is done
+func (k *memoryKV) checkContext(ctx context.Context, op string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s in %s aborted: %w", op, k.prefix, context.Cause(ctx))
+	}
+	return nil
+}
+
+// entries returns the entries of the keyspace, creating it if needed. The lock of the broker
+// must be held.
+func (k *memoryKV) entries() map[string][]byte {
+	entries, ok := k.broker.keyspaces[k.prefix]
+	if !ok {
+		entries = map[string][]byte{}
+		k.broker.keyspaces[k.prefix] = entries
+	}
+	return entries
+}
+
+// write calls fn with the entries of the keyspace, creating it if needed, and publishes
+// event once fn succeeds
+func (k *memoryKV) write(ctx context.Context, op string, event storage.WatchEvent, fn func(entries map[string][]byte) error) error {
+	if err := k.checkContext(ctx, op); err != nil {
+		return err
+	}
+	k.broker.mu.Lock()
+	defer k.broker.mu.Unlock()
+	if err := fn(k.entries()); err != nil {
+		return err
+	}
+	k.broker.watchers.Publish(k.prefix, event)
+	return nil
+}
+
+// iterPrefix calls fn for every entry whose key starts with prefix, in key order
+func (k *memoryKV) iterPrefix(ctx context.Context, op, prefix string, fn func(key string, value []byte)) error {
+	if err := k.checkContext(ctx, op); err != nil {
+		return err
+	}
+	k.broker.mu.RLock()
+	defer k.broker.mu.RUnlock()
+	entries := k.broker.keyspaces[k.prefix]
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		fn(key, entries[key])
+	}
+	return nil
+}
+
+func (k *memoryKV) Put(ctx context.Context, key string, obj []byte) error {
+	return k.write(ctx, "put", storage.WatchEvent{Type: storage.EventPut, Key: key, Value: obj}, func(entries map[string][]byte) error {
+		entries[key] = bytes.Clone(obj)
+		return nil
+	})
+}
+
+func (k *memoryKV) Create(ctx context.Context, key string, obj []byte) error {
+	return k.write(ctx, "create", storage.WatchEvent{Type: storage.EventPut, Key: key, Value: obj}, func(entries map[string][]byte) error {
+		if _, ok := entries[key]; ok {
+			return storage.AlreadyExists(key)
+		}
+		entries[key] = bytes.Clone(obj)
+		return nil
+	})
+}
+
+func (k *memoryKV) CompareAndSwap(ctx context.Context, key string, old, obj []byte) error {
+	return k.write(ctx, "compare_and_swap", storage.WatchEvent{Type: storage.EventPut, Key: key, Value: obj}, func(entries map[string][]byte) error {
+		value, ok := entries[key]
+		if !ok {
+			return storage.NotFound(key)
+		}
+		if !bytes.Equal(value, old) {
+			return storage.Conflict(key)
+		}
+		entries[key] = bytes.Clone(obj)
+		return nil
+	})
+}
+
+func (k *memoryKV) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := k.checkContext(ctx, "get"); err != nil {
+		return nil, err
+	}
+	k.broker.mu.RLock()
+	defer k.broker.mu.RUnlock()
+	value, ok := k.broker.keyspaces[k.prefix][key]
+	if !ok {
+		return nil, storage.NotFound(key)
+	}
+	return bytes.Clone(value), nil
+}
+
+func (k *memoryKV) ListKeys(ctx context.Context) ([]string, error) {
+	keys := []string{}
+	if err := k.iterPrefix(ctx, "list_keys", "", func(key string, _ []byte) {
+		keys = append(keys, key)
+	}); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (k *memoryKV) List(ctx context.Context) ([][]byte, error) {
+	values := [][]byte{}
+	if err := k.iterPrefix(ctx, "list", "", func(_ string, value []byte) {
+		values = append(values, bytes.Clone(value))
+	}); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (k *memoryKV) Delete(ctx context.Context, key string) error {
+	return k.write(ctx, "delete", storage.WatchEvent{Type: storage.EventDelete, Key: key}, func(entries map[string][]byte) error {
+		delete(entries, key)
+		return nil
+	})
+}
+
+func (k *memoryKV) ListPrefix(ctx context.Context, prefix string) ([]storage.KeyValuePair[[]byte], error) {
+	entries := []storage.KeyValuePair[[]byte]{}
+	if err := k.iterPrefix(ctx, "list_prefix", prefix, func(key string, value []byte) {
+		entries = append(entries, storage.KeyValuePair[[]byte]{Key: key, Value: bytes.Clone(value)})
+	}); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (k *memoryKV) DeletePrefix(ctx context.Context, prefix string) error {
+	return k.write(ctx, "delete_prefix", storage.WatchEvent{Type: storage.EventDeletePrefix, Key: prefix}, func(entries map[string][]byte) error {
+		for key := range entries {
+			if strings.HasPrefix(key, prefix) {
+				delete(entries, key)
+			}
+		}
+		return nil
+	})
+}
+
+func (k *memoryKV) NewBatch() storage.Batch {
+	return &batch{broker: k.broker}
+}
+
+// addToBatch adds a write to the entries of the keyspace to b, publishing event once b commits
+func (k *memoryKV) addToBatch(ctx context.Context, op string, b storage.Batch, event storage.WatchEvent, fn func(entries map[string][]byte)) error {
+	if err := k.checkContext(ctx, op); err != nil {
+		return err
+	}
+	mb, ok := b.(*batch)
+	if !ok || mb.broker != k.broker {
+		return storage.ErrForeignBatch
+	}
+	if mb.committed {
+		return storage.ErrBatchCommitted
+	}
+	mb.writes = append(mb.writes, func() {
+		fn(k.entries())
+		k.broker.watchers.Publish(k.prefix, event)
+	})
+	return nil
+}
+
+func (k *memoryKV) PutBatch(ctx context.Context, b storage.Batch, key string, obj []byte) error {
+	obj = bytes.Clone(obj)
+	return k.addToBatch(ctx, "put_batch", b, storage.WatchEvent{Type: storage.EventPut, Key: key, Value: obj}, func(entries map[string][]byte) {
+		entries[key] = obj
+	})
+}
+
+func (k *memoryKV) DeleteBatch(ctx context.Context, b storage.Batch, key string) error {
+	return k.addToBatch(ctx, "delete_batch", b, storage.WatchEvent{Type: storage.EventDelete, Key: key}, func(entries map[string][]byte) {
+		delete(entries, key)
+	})
+}
+
+// batch applies its writes at once under the lock of its broker
+type batch struct {
+	broker    *KVBroker
+	writes    []func()
+	callbacks []func()
+	committed bool
+}
+
+func (b *batch) Commit(ctx context.Context) error {
+	if b.committed {
+		return storage.ErrBatchCommitted
+	}
+	b.committed = true
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("batch commit aborted: %w", context.Cause(ctx))
+	}
+	b.broker.mu.Lock()
+	for _, write := range b.writes {
+		write()
+	}
+	b.broker.mu.Unlock()
+	for _, fn := range b.callbacks {
+		fn()
+	}
+	return nil
+}
+
+func (b *batch) OnCommit(fn func()) {
+	b.callbacks = append(b.callbacks, fn)
+}
+
+var _ storage.KV = (*memoryKV)(nil)
+var _ storage.Batch = (*batch)(nil)
+var _ storage.KVBroker = (*KVBroker)(nil)
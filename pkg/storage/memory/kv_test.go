@@ -0,0 +1,21 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
+	"github.com/otelfleet/otelfleet/pkg/storage/storagetest"
+)
+
+func TestKVBrokerConformance(t *testing.T) {
+	storagetest.RunKVBrokerConformance(t, func(*testing.T) storage.KVBroker {
+		return memory.NewKVBroker()
+	})
+}
+
+func TestIndexedKVBrokerConformance(t *testing.T) {
+	storagetest.RunIndexedKVBrokerConformance(t, func(*testing.T) storage.KVBroker {
+		return memory.NewKVBroker()
+	})
+}
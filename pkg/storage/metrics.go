@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics instruments the operations of proto keyspaces, labelled by keyspace
+type Metrics struct {
+	logger *slog.Logger
+	// slow operation thresholds, operations taking longer are logged
+	slowRead time.Duration
+	slowScan time.Duration
+
+	operations *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	payload    *prometheus.HistogramVec
+}
+
+// NewMetrics returns metrics for proto keyspaces, logging operations exceeding the slow
+// thresholds of budget. Unlike the budget broker, the logged durations include encoding
+// and decoding values, and the logs report the size of the values.
+func NewMetrics(logger *slog.Logger, budget Budget) *Metrics {
+	m := &Metrics{
+		logger:   logger,
+		slowRead: budget.SlowReadThreshold,
+		slowScan: budget.SlowScanThreshold,
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "otelfleet_storage_operations_total",
+			Help: "Storage operations by keyspace, operation and result.",
+		}, []string{"keyspace", "op", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "otelfleet_storage_operation_duration_seconds",
+			Help:    "Duration of storage operations, including encoding and decoding values.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"keyspace", "op"}),
+		payload: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "otelfleet_storage_payload_bytes",
+			Help:    "Stored size of the values written or read by storage operations.",
+			Buckets: prometheus.ExponentialBuckets(64, 4, 10),
+		}, []string{"keyspace", "op"}),
+	}
+	if m.slowRead <= 0 {
+		m.slowRead = DefaultSlowReadThreshold
+	}
+	if m.slowScan <= 0 {
+		m.slowScan = DefaultSlowScanThreshold
+	}
+	return m
+}
+
+// Register registers the metrics with reg
+func (m *Metrics) Register(reg prometheus.Registerer) {
+	reg.MustRegister(m.operations, m.duration, m.payload)
+}
+
+// WithMetrics instruments the operations of the keyspace with m. A nil m leaves the
+// keyspace uninstrumented.
+func WithMetrics(m *Metrics, keyspace string) ProtoKVOption {
+	return func(o *protoKeyValueOptions) {
+		o.metrics = m
+		o.keyspace = keyspace
+	}
+}
+
+// scan operations are held to the slow scan threshold
+var scanOps = map[string]bool{
+	"list_keys":     true,
+	"list":          true,
+	"list_prefix":   true,
+	"delete_prefix": true,
+}
+
+// observe records an operation on key of keyspace started at start, which wrote or read
+// size bytes, or none if size is negative
+func (m *Metrics) observe(keyspace, op, key string, start time.Time, size int, err error) {
+	if m == nil {
+		return
+	}
+	elapsed := time.Since(start)
+	m.operations.WithLabelValues(keyspace, op, operationResult(err)).Inc()
+	m.duration.WithLabelValues(keyspace, op).Observe(elapsed.Seconds())
+	if size >= 0 {
+		m.payload.WithLabelValues(keyspace, op).Observe(float64(size))
+	}
+	slow := m.slowRead
+	if scanOps[op] {
+		slow = m.slowScan
+	}
+	if elapsed > slow {
+		lg := m.logger.With("keyspace", keyspace, "op", op, "duration", elapsed)
+		if key != "" {
+			lg = lg.With("key", key)
+		}
+		if size >= 0 {
+			lg = lg.With("bytes", size)
+		}
+		lg.Warn("slow storage operation")
+	}
+}
+
+func operationResult(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrAlreadyExists):
+		return "already_exists"
+	default:
+		return "error"
+	}
+}
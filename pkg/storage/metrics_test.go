@@ -0,0 +1,91 @@
+package storage_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
+	"github.com/prometheus/client_golang/prometheus"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProtoKVMetrics(t *testing.T) {
+	logs := &bytes.Buffer{}
+	metrics := storage.NewMetrics(slog.New(slog.NewTextHandler(logs, nil)), storage.Budget{})
+	reg := prometheus.NewRegistry()
+	metrics.Register(reg)
+	kv := storage.NewProtoKV[*configv1alpha1.Config](slog.Default(), memory.NewKVBroker().KeyValue("configs"), storage.WithMetrics(metrics, "configs"))
+	ctx := t.Context()
+
+	config := &configv1alpha1.Config{Config: []byte("receivers: {otlp: {}}")}
+	require.NoError(t, kv.Put(ctx, "a", config))
+	require.NoError(t, kv.Put(ctx, "b", config))
+	assert.True(t, storage.IsAlreadyExists(kv.Create(ctx, "a", config)))
+	_, err := kv.Get(ctx, "a")
+	require.NoError(t, err)
+	_, err = kv.Get(ctx, "missing")
+	assert.True(t, storage.IsNotFound(err))
+	_, err = kv.List(ctx)
+	require.NoError(t, err)
+
+	assert.NoError(t, promtestutil.CollectAndCompare(reg, strings.NewReader(`
+# HELP otelfleet_storage_operations_total Storage operations by keyspace, operation and result.
+# TYPE otelfleet_storage_operations_total counter
+otelfleet_storage_operations_total{keyspace="configs",op="create",result="already_exists"} 1
+otelfleet_storage_operations_total{keyspace="configs",op="get",result="not_found"} 1
+otelfleet_storage_operations_total{keyspace="configs",op="get",result="ok"} 1
+otelfleet_storage_operations_total{keyspace="configs",op="list",result="ok"} 1
+otelfleet_storage_operations_total{keyspace="configs",op="put",result="ok"} 2
+`), "otelfleet_storage_operations_total"))
+
+	// payloads are measured as stored, a listing reports the size of every value
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	payloads := map[string]float64{}
+	for _, family := range families {
+		if family.GetName() != "otelfleet_storage_payload_bytes" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "op" {
+					payloads[label.GetValue()] = metric.GetHistogram().GetSampleSum()
+				}
+			}
+		}
+	}
+	encoded, err := proto.Marshal(config)
+	require.NoError(t, err)
+	size := float64(len(encoded))
+	assert.Equal(t, map[string]float64{
+		"put":    2 * size,
+		"create": size,
+		"get":    size,
+		"list":   2 * size,
+	}, payloads)
+	assert.Empty(t, logs.String())
+}
+
+func TestProtoKVMetrics_SlowOperations(t *testing.T) {
+	logs := &bytes.Buffer{}
+	metrics := storage.NewMetrics(slog.New(slog.NewTextHandler(logs, nil)), storage.Budget{SlowReadThreshold: 10 * time.Millisecond})
+	underlying := &slowKV{KV: memory.NewKVBroker().KeyValue("slow"), delay: 20 * time.Millisecond}
+	kv := storage.NewProtoKV[*configv1alpha1.Config](slog.Default(), underlying, storage.WithMetrics(metrics, "slow"))
+
+	require.NoError(t, kv.Put(t.Context(), "fast", &configv1alpha1.Config{Config: []byte("v")}))
+	assert.Empty(t, logs.String())
+
+	_, err := kv.Get(t.Context(), "fast")
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "slow storage operation")
+	assert.Contains(t, logs.String(), "keyspace=slow op=get")
+	assert.Contains(t, logs.String(), "key=fast bytes=3")
+}
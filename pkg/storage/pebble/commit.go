@@ -1,9 +1,13 @@
 package pebble
 
 import (
-	"slices"
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
 
 	"github.com/cockroachdb/pebble/v2"
+	"github.com/otelfleet/otelfleet/pkg/storage"
 )
 
 type (
@@ -27,9 +31,37 @@ func (kv *prefixedKV) WithReadOnlyTransaction(fn func(tx readOnlyTransaction) er
 	return fn(readOnlyTransaction{kv.db})
 }
 
+// change is a change to a keyspace, published to its watchers once committed
+type change struct {
+	keyspace string
+	event    storage.WatchEvent
+}
+
+// publisher serializes the commits of a broker with the publication of their changes, so that
+// watchers see the changes in commit order
+type publisher struct {
+	mu       sync.Mutex
+	watchers storage.Watchers
+}
+
+// commit runs commit, and publishes changes once it succeeds
+func (p *publisher) commit(commit func() error, changes ...change) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := commit(); err != nil {
+		return err
+	}
+	for _, c := range changes {
+		p.watchers.Publish(c.keyspace, c.event)
+	}
+	return nil
+}
+
 type readWriteTransaction struct {
 	*pebble.Batch
 
+	publisher         *publisher
+	changes           []change
 	onCommitCallbacks []func()
 }
 
@@ -37,14 +69,118 @@ func (tx *readWriteTransaction) onCommit(callback func()) {
 	tx.onCommitCallbacks = append(tx.onCommitCallbacks, callback)
 }
 
+// changed records a change made by the transaction, published once it commits
+func (tx *readWriteTransaction) changed(keyspace []byte, event storage.WatchEvent) {
+	tx.changes = append(tx.changes, change{keyspace: string(keyspace), event: event})
+}
+
+// commit applies the writes of the transaction, and runs its callbacks once they are applied
+func (tx *readWriteTransaction) commit() error {
+	if err := tx.publisher.commit(func() error { return tx.Commit(pebble.NoSync) }, tx.changes...); err != nil {
+		return err
+	}
+	for _, f := range tx.onCommitCallbacks {
+		f()
+	}
+	return nil
+}
+
+// withReadWriteTransaction commits the writes of fn atomically, unless it fails. The batch of
+// the transaction reads its own writes.
 func (kv *prefixedKV) withReadWriteTransaction(fn func(tx *readWriteTransaction) error) error {
-	batch := kv.db.NewIndexedBatch()
-	tx := &readWriteTransaction{Batch: batch}
-	err := batch.Commit(nil)
+	tx := &readWriteTransaction{Batch: kv.db.NewIndexedBatch(), publisher: kv.publisher}
+	defer tx.Close()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.commit()
+}
+
+// batch is a storage.Batch committed as a single pebble batch
+type batch struct {
+	db *pebble.DB
+	tx *readWriteTransaction
+	// indexMu is the lock of the writes to the indexed keyspaces of the broker
+	indexMu *sync.Mutex
+	// the writes to indexed keyspaces, which read the previous values to update their index
+	// entries, so are only added to the batch under indexMu when committing
+	indexedWrites []func(tx *readWriteTransaction) error
+	committed     bool
+}
+
+func (kv *prefixedKV) NewBatch() storage.Batch {
+	return &batch{
+		db:      kv.db,
+		tx:      &readWriteTransaction{Batch: kv.db.NewIndexedBatch(), publisher: kv.publisher},
+		indexMu: kv.indexMu,
+	}
+}
+
+// batch returns b if it can hold writes to the keyspace
+func (kv *prefixedKV) batch(b storage.Batch) (*batch, error) {
+	pb, ok := b.(*batch)
+	if !ok || pb.db != kv.db {
+		return nil, storage.ErrForeignBatch
+	}
+	if pb.committed {
+		return nil, storage.ErrBatchCommitted
+	}
+	return pb, nil
+}
+
+func (kv *prefixedKV) PutBatch(ctx context.Context, b storage.Batch, key string, obj []byte) error {
+	if err := kv.checkContext(ctx, "put_batch"); err != nil {
+		return err
+	}
+	pb, err := kv.batch(b)
 	if err != nil {
-		for _, f := range slices.Backward(tx.onCommitCallbacks) {
-			f()
+		return err
+	}
+	if err := pb.tx.Set(kv.key(key), obj, nil); err != nil {
+		return err
+	}
+	pb.tx.changed(kv.prefix, storage.WatchEvent{Type: storage.EventPut, Key: key, Value: bytes.Clone(obj)})
+	return nil
+}
+
+func (kv *prefixedKV) DeleteBatch(ctx context.Context, b storage.Batch, key string) error {
+	if err := kv.checkContext(ctx, "delete_batch"); err != nil {
+		return err
+	}
+	pb, err := kv.batch(b)
+	if err != nil {
+		return err
+	}
+	if err := pb.tx.Delete(kv.key(key), nil); err != nil {
+		return err
+	}
+	pb.tx.changed(kv.prefix, storage.WatchEvent{Type: storage.EventDelete, Key: key})
+	return nil
+}
+
+func (b *batch) Commit(ctx context.Context) error {
+	if b.committed {
+		return storage.ErrBatchCommitted
+	}
+	b.committed = true
+	defer b.tx.Close()
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("batch commit aborted: %w", context.Cause(ctx))
+	}
+	if len(b.indexedWrites) > 0 {
+		b.indexMu.Lock()
+		defer b.indexMu.Unlock()
+		for _, write := range b.indexedWrites {
+			if err := write(b.tx); err != nil {
+				return err
+			}
 		}
 	}
-	return err
+	return b.tx.commit()
 }
+
+func (b *batch) OnCommit(fn func()) {
+	b.tx.onCommit(fn)
+}
+
+var _ storage.Batch = (*batch)(nil)
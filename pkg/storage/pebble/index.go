@@ -0,0 +1,269 @@
+package pebble
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cockroachdb/pebble/v2"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+)
+
+// indexSep separates the parts of the keys of index entries. The entries of a keyspace are
+// kept under its prefix followed by indexSep, which sorts before the '/' its keys are under,
+// as prefix, indexSep, index name, indexSep, indexed value, indexSep, key. The key made of
+// the prefix, indexSep and index name marks the index as built.
+const indexSep = '\x00'
+
+// IndexedKeyValue returns the keyspace of prefix, maintaining indexes in the same batches as
+// its values. Writes to indexed keyspaces are serialized, since they read the previous value
+// to update its index entries.
+func (k *KVBroker) IndexedKeyValue(prefix string, indexes ...storage.Index) storage.IndexedKV {
+	return &indexedKV{
+		prefixedKV: k.newPrefixedKeyValue(prefix),
+		indexes:    indexes,
+	}
+}
+
+type indexedKV struct {
+	*prefixedKV
+	indexes []storage.Index
+
+	// indexes the values stored before the indexes were added, on first use
+	buildOnce sync.Once
+	buildErr  error
+}
+
+func (k *indexedKV) indexKey(index string) []byte {
+	return fmt.Appendf(nil, "%s%c%s", k.prefix, indexSep, index)
+}
+
+func (k *indexedKV) entryPrefix(index, value string) []byte {
+	return fmt.Appendf(k.indexKey(index), "%c%s%c", indexSep, value, indexSep)
+}
+
+// setEntries adds or, if del is true, removes the index entries of value stored under key
+func (k *indexedKV) setEntries(tx *readWriteTransaction, key string, value []byte, del bool) error {
+	for _, idx := range k.indexes {
+		for _, v := range idx.Values(value) {
+			entry := append(k.entryPrefix(idx.Name, v), key...)
+			var err error
+			if del {
+				err = tx.Delete(entry, nil)
+			} else {
+				err = tx.Set(entry, nil, nil)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ensureBuilt indexes the existing values of the keyspace for the indexes that weren't built
+func (k *indexedKV) ensureBuilt() error {
+	k.buildOnce.Do(func() {
+		k.indexMu.Lock()
+		defer k.indexMu.Unlock()
+		k.buildErr = k.withReadWriteTransaction(func(tx *readWriteTransaction) error {
+			for _, idx := range k.indexes {
+				_, closer, err := tx.Get(k.indexKey(idx.Name))
+				if err == nil {
+					closer.Close()
+					continue
+				} else if !errors.Is(err, pebble.ErrNotFound) {
+					return err
+				}
+				var setErr error
+				if err := k.iterPrefix(context.Background(), "", func(key string, value []byte) {
+					for _, v := range idx.Values(value) {
+						setErr = errors.Join(setErr, tx.Set(append(k.entryPrefix(idx.Name, v), key...), nil, nil))
+					}
+				}); err != nil {
+					return err
+				}
+				if setErr != nil {
+					return setErr
+				}
+				if err := tx.Set(k.indexKey(idx.Name), nil, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if k.buildErr != nil {
+			k.buildErr = fmt.Errorf("failed to build the indexes of %s: %w", k.prefix, k.buildErr)
+		}
+	})
+	return k.buildErr
+}
+
+// write runs fn in a transaction with the previous value of key, nil if it doesn't exist
+func (k *indexedKV) write(ctx context.Context, op, key string, fn func(tx *readWriteTransaction, previous []byte, exists bool) error) error {
+	if err := k.checkContext(ctx, op); err != nil {
+		return err
+	}
+	if err := k.ensureBuilt(); err != nil {
+		return err
+	}
+	k.indexMu.Lock()
+	defer k.indexMu.Unlock()
+	return k.withReadWriteTransaction(func(tx *readWriteTransaction) error {
+		return k.writeKey(tx, key, fn)
+	})
+}
+
+// writeKey runs fn in tx with the previous value of key, nil if it doesn't exist
+func (k *indexedKV) writeKey(tx *readWriteTransaction, key string, fn func(tx *readWriteTransaction, previous []byte, exists bool) error) error {
+	previous, closer, err := tx.Get(k.key(key))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return fn(tx, nil, false)
+	} else if err != nil {
+		return err
+	}
+	defer closer.Close()
+	return fn(tx, previous, true)
+}
+
+// writeBatch adds a write of key to b, run with its previous value when b commits
+func (k *indexedKV) writeBatch(ctx context.Context, op string, b storage.Batch, key string, fn func(tx *readWriteTransaction, previous []byte, exists bool) error) error {
+	if err := k.checkContext(ctx, op); err != nil {
+		return err
+	}
+	pb, err := k.batch(b)
+	if err != nil {
+		return err
+	}
+	if err := k.ensureBuilt(); err != nil {
+		return err
+	}
+	pb.indexedWrites = append(pb.indexedWrites, func(tx *readWriteTransaction) error {
+		return k.writeKey(tx, key, fn)
+	})
+	return nil
+}
+
+// replace stores value under key in place of previous
+func (k *indexedKV) replace(tx *readWriteTransaction, key string, previous []byte, exists bool, value []byte) error {
+	if exists {
+		if err := k.setEntries(tx, key, previous, true); err != nil {
+			return err
+		}
+	}
+	if err := tx.Set(k.key(key), value, nil); err != nil {
+		return err
+	}
+	tx.changed(k.prefix, storage.WatchEvent{Type: storage.EventPut, Key: key, Value: value})
+	return k.setEntries(tx, key, value, false)
+}
+
+func (k *indexedKV) Put(ctx context.Context, key string, value []byte) error {
+	return k.write(ctx, "put", key, func(tx *readWriteTransaction, previous []byte, exists bool) error {
+		return k.replace(tx, key, previous, exists, value)
+	})
+}
+
+func (k *indexedKV) Create(ctx context.Context, key string, value []byte) error {
+	return k.write(ctx, "create", key, func(tx *readWriteTransaction, previous []byte, exists bool) error {
+		if exists {
+			return storage.AlreadyExists(key)
+		}
+		return k.replace(tx, key, nil, false, value)
+	})
+}
+
+func (k *indexedKV) CompareAndSwap(ctx context.Context, key string, old, value []byte) error {
+	return k.write(ctx, "compare_and_swap", key, func(tx *readWriteTransaction, previous []byte, exists bool) error {
+		if !exists {
+			return storage.NotFound(key)
+		}
+		if !bytes.Equal(previous, old) {
+			return storage.Conflict(key)
+		}
+		return k.replace(tx, key, previous, exists, value)
+	})
+}
+
+func (k *indexedKV) Delete(ctx context.Context, key string) error {
+	return k.write(ctx, "delete", key, k.deleteKey(key))
+}
+
+// deleteKey returns the write deleting key and its index entries
+func (k *indexedKV) deleteKey(key string) func(tx *readWriteTransaction, previous []byte, exists bool) error {
+	return func(tx *readWriteTransaction, previous []byte, exists bool) error {
+		if !exists {
+			return nil
+		}
+		if err := k.setEntries(tx, key, previous, true); err != nil {
+			return err
+		}
+		tx.changed(k.prefix, storage.WatchEvent{Type: storage.EventDelete, Key: key})
+		return tx.Delete(k.key(key), nil)
+	}
+}
+
+func (k *indexedKV) PutBatch(ctx context.Context, b storage.Batch, key string, value []byte) error {
+	value = bytes.Clone(value)
+	return k.writeBatch(ctx, "put_batch", b, key, func(tx *readWriteTransaction, previous []byte, exists bool) error {
+		return k.replace(tx, key, previous, exists, value)
+	})
+}
+
+func (k *indexedKV) DeleteBatch(ctx context.Context, b storage.Batch, key string) error {
+	return k.writeBatch(ctx, "delete_batch", b, key, k.deleteKey(key))
+}
+
+func (k *indexedKV) DeletePrefix(ctx context.Context, prefix string) error {
+	if err := k.checkContext(ctx, "delete_prefix"); err != nil {
+		return err
+	}
+	if err := k.ensureBuilt(); err != nil {
+		return err
+	}
+	k.indexMu.Lock()
+	defer k.indexMu.Unlock()
+	return k.withReadWriteTransaction(func(tx *readWriteTransaction) error {
+		var entriesErr error
+		if err := k.iterPrefix(ctx, prefix, func(key string, value []byte) {
+			entriesErr = errors.Join(entriesErr, k.setEntries(tx, key, value, true))
+		}); err != nil {
+			return err
+		}
+		if entriesErr != nil {
+			return entriesErr
+		}
+		lower, upper := k.bounds(prefix)
+		tx.changed(k.prefix, storage.WatchEvent{Type: storage.EventDeletePrefix, Key: prefix})
+		return tx.DeleteRange(lower, upper, nil)
+	})
+}
+
+func (k *indexedKV) KeysByIndex(ctx context.Context, index, value string) ([]string, error) {
+	if err := k.checkContext(ctx, "keys_by_index"); err != nil {
+		return nil, err
+	}
+	if _, ok := storage.FindIndex(k.indexes, index); !ok {
+		return nil, storage.UnknownIndex(index)
+	}
+	if err := k.ensureBuilt(); err != nil {
+		return nil, err
+	}
+	lower := k.entryPrefix(index, value)
+	upper := append(lower[:len(lower)-1:len(lower)-1], indexSep+1)
+	iter, err := k.db.NewIterWithContext(ctx, &pebble.IterOptions{LowerBound: lower, UpperBound: upper})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	keys := []string{}
+	for iter.First(); iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key()[len(lower):]))
+	}
+	return keys, iter.Error()
+}
+
+var _ storage.IndexedKV = (*indexedKV)(nil)
+var _ storage.IndexedKVBroker = (*KVBroker)(nil)
@@ -1,16 +1,17 @@
 package pebble
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"log"
 	"log/slog"
+	"sync"
 
 	"github.com/cockroachdb/pebble/v2"
 	"github.com/cockroachdb/pebble/v2/vfs"
 	"github.com/otelfleet/otelfleet/pkg/storage"
-	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
 )
 
 type pebbleLogger struct {
@@ -64,6 +65,11 @@ func Open(dirname string, options *pebble.Options) (*pebble.DB, error) {
 
 type KVBroker struct {
 	db *pebble.DB
+	// createMu serializes creations, which check for the key before writing it
+	createMu sync.Mutex
+	// indexMu serializes the writes to indexed keyspaces
+	indexMu   sync.Mutex
+	publisher publisher
 }
 
 func NewKVBroker(db *pebble.DB) *KVBroker {
@@ -76,16 +82,26 @@ func (k *KVBroker) KeyValue(prefix string) storage.KV {
 	return k.newPrefixedKeyValue(prefix)
 }
 
+func (k *KVBroker) Watch(ctx context.Context, prefix string) (<-chan storage.WatchEvent, error) {
+	return k.publisher.watchers.Watch(ctx, prefix)
+}
+
 func (k *KVBroker) newPrefixedKeyValue(prefix string) *prefixedKV {
 	return &prefixedKV{
-		db:     k.db,
-		prefix: []byte(prefix),
+		db:        k.db,
+		prefix:    []byte(prefix),
+		createMu:  &k.createMu,
+		indexMu:   &k.indexMu,
+		publisher: &k.publisher,
 	}
 }
 
 type prefixedKV struct {
-	prefix []byte
-	db     *pebble.DB
+	prefix    []byte
+	db        *pebble.DB
+	createMu  *sync.Mutex
+	indexMu   *sync.Mutex
+	publisher *publisher
 }
 
 func (k *prefixedKV) key(key string) []byte {
@@ -96,80 +112,161 @@ func (k *prefixedKV) key(key string) []byte {
 	return fullKey
 }
 
-func (k *prefixedKV) Put(_ context.Context, key string, value []byte) error {
-	return k.db.Set(k.key(key), value, &pebble.WriteOptions{})
+// checkContext fails operations whose context is done
+func (k *prefixedKV) checkContext(ctx context.Context, op string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s in %s aborted: %w", op, k.prefix, context.Cause(ctx))
+	}
+	return nil
+}
+
+func (k *prefixedKV) Put(ctx context.Context, key string, value []byte) error {
+	if err := k.checkContext(ctx, "put"); err != nil {
+		return err
+	}
+	return k.set(key, value)
+}
+
+// set stores value under key, and publishes the change
+func (k *prefixedKV) set(key string, value []byte) error {
+	return k.publisher.commit(func() error {
+		return k.db.Set(k.key(key), value, &pebble.WriteOptions{})
+	}, change{keyspace: string(k.prefix), event: storage.WatchEvent{Type: storage.EventPut, Key: key, Value: value}})
+}
+
+func (k *prefixedKV) Create(ctx context.Context, key string, value []byte) error {
+	if err := k.checkContext(ctx, "create"); err != nil {
+		return err
+	}
+	k.createMu.Lock()
+	defer k.createMu.Unlock()
+	_, closer, err := k.db.Get(k.key(key))
+	if err == nil {
+		closer.Close()
+		return storage.AlreadyExists(key)
+	} else if !errors.Is(err, pebble.ErrNotFound) {
+		return err
+	}
+	return k.set(key, value)
+}
+
+// CompareAndSwap is serialized with Create, but not with Put
+func (k *prefixedKV) CompareAndSwap(ctx context.Context, key string, old, value []byte) error {
+	if err := k.checkContext(ctx, "compare_and_swap"); err != nil {
+		return err
+	}
+	k.createMu.Lock()
+	defer k.createMu.Unlock()
+	current, err := k.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(current, old) {
+		return storage.Conflict(key)
+	}
+	return k.set(key, value)
 }
 
-func (k *prefixedKV) Get(_ context.Context, key string) ([]byte, error) {
+func (k *prefixedKV) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := k.checkContext(ctx, "get"); err != nil {
+		return nil, err
+	}
 	data, closer, err := k.db.Get(k.key(key))
 	if err != nil {
 		if errors.Is(err, pebble.ErrNotFound) {
-			return nil, grpcutil.ErrorNotFound(err)
+			return nil, storage.NotFound(key)
 		}
 		return nil, err
 	}
 	defer closer.Close()
-	return data, nil
+	// data is only valid until closer is closed
+	return bytes.Clone(data), nil
 }
 
-func (k *prefixedKV) listPrefix() []byte {
-	prefix := make([]byte, len(k.prefix)+1)
-	copy(prefix, k.prefix)
-	prefix[len(k.prefix)] = '/'
-	return prefix
+// bounds returns the key range holding the keys that start with prefix
+func (k *prefixedKV) bounds(prefix string) (lower, upper []byte) {
+	lower = k.key(prefix)
+	upper = make([]byte, len(lower))
+	copy(upper, lower)
+	// the smallest key greater than every key with the prefix
+	for i := len(upper) - 1; i >= 0; i-- {
+		if upper[i] < 0xff {
+			upper[i]++
+			return lower, upper[:i+1]
+		}
+	}
+	return lower, nil
 }
 
-func (k *prefixedKV) ListKeys(ctx context.Context) ([]string, error) {
-	prefix := k.listPrefix()
-	pn := len(prefix)
-	upper := make([]byte, len(prefix))
-	copy(upper, prefix)
-	upper[len(prefix)-1]++
+// iterPrefix calls fn for every entry whose key starts with prefix, in key order. The
+// key and value are only valid until fn returns. Iteration stops once ctx is done.
+func (k *prefixedKV) iterPrefix(ctx context.Context, prefix string, fn func(key string, value []byte)) error {
+	lower, upper := k.bounds(prefix)
 	iter, err := k.db.NewIterWithContext(ctx, &pebble.IterOptions{
-		LowerBound: prefix,
+		LowerBound: lower,
 		UpperBound: upper,
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer iter.Close()
-	keys := []string{}
+	pn := len(k.prefix) + 1
 	for iter.First(); iter.Valid(); iter.Next() {
-		iKey := iter.Key()[pn:]
-		keys = append(keys, string(iKey))
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("iteration of %s aborted: %w", k.prefix, context.Cause(ctx))
+		}
+		fn(string(iter.Key()[pn:]), iter.Value())
 	}
-	if err := iter.Error(); err != nil {
+	return iter.Error()
+}
+
+func (k *prefixedKV) ListKeys(ctx context.Context) ([]string, error) {
+	keys := []string{}
+	if err := k.iterPrefix(ctx, "", func(key string, _ []byte) {
+		keys = append(keys, key)
+	}); err != nil {
 		return nil, err
 	}
-
 	return keys, nil
 }
 
 func (k *prefixedKV) List(ctx context.Context) ([][]byte, error) {
-	prefix := k.listPrefix()
-	upper := make([]byte, len(prefix))
-	copy(upper, prefix)
-	upper[len(prefix)-1]++
-	iter, err := k.db.NewIterWithContext(ctx, &pebble.IterOptions{
-		LowerBound: prefix,
-		UpperBound: upper,
-	})
-	if err != nil {
-		return nil, err
-	}
-	defer iter.Close()
 	vs := [][]byte{}
-	for iter.First(); iter.Valid(); iter.Next() {
-		vs = append(vs, iter.Value())
-	}
-	if err := iter.Error(); err != nil {
+	if err := k.iterPrefix(ctx, "", func(_ string, value []byte) {
+		vs = append(vs, bytes.Clone(value))
+	}); err != nil {
 		return nil, err
 	}
 	return vs, nil
 }
 
+func (k *prefixedKV) ListPrefix(ctx context.Context, prefix string) ([]storage.KeyValuePair[[]byte], error) {
+	entries := []storage.KeyValuePair[[]byte]{}
+	if err := k.iterPrefix(ctx, prefix, func(key string, value []byte) {
+		entries = append(entries, storage.KeyValuePair[[]byte]{Key: key, Value: bytes.Clone(value)})
+	}); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (k *prefixedKV) DeletePrefix(ctx context.Context, prefix string) error {
+	if err := k.checkContext(ctx, "delete_prefix"); err != nil {
+		return err
+	}
+	lower, upper := k.bounds(prefix)
+	return k.publisher.commit(func() error {
+		return k.db.DeleteRange(lower, upper, &pebble.WriteOptions{})
+	}, change{keyspace: string(k.prefix), event: storage.WatchEvent{Type: storage.EventDeletePrefix, Key: prefix}})
+}
+
 func (k *prefixedKV) Delete(ctx context.Context, key string) error {
-	return k.db.Delete(k.key(key), &pebble.WriteOptions{})
+	if err := k.checkContext(ctx, "delete"); err != nil {
+		return err
+	}
+	return k.publisher.commit(func() error {
+		return k.db.Delete(k.key(key), &pebble.WriteOptions{})
+	}, change{keyspace: string(k.prefix), event: storage.WatchEvent{Type: storage.EventDelete, Key: key}})
 }
 
 var _ storage.KV = (*prefixedKV)(nil)
@@ -0,0 +1,43 @@
+package pebble_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/cockroachdb/pebble/v2"
+	"github.com/cockroachdb/pebble/v2/vfs"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	otelpebble "github.com/otelfleet/otelfleet/pkg/storage/pebble"
+	"github.com/otelfleet/otelfleet/pkg/storage/storagetest"
+	"github.com/stretchr/testify/require"
+)
+
+func newBroker(t *testing.T) *otelpebble.KVBroker {
+	db, err := pebble.Open("", &pebble.Options{FS: vfs.NewMem()})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return otelpebble.NewKVBroker(db)
+}
+
+func TestKVBrokerConformance(t *testing.T) {
+	storagetest.RunKVBrokerConformance(t, func(t *testing.T) storage.KVBroker {
+		return newBroker(t)
+	})
+}
+
+func TestIndexedKVBrokerConformance(t *testing.T) {
+	storagetest.RunIndexedKVBrokerConformance(t, func(t *testing.T) storage.KVBroker {
+		return newBroker(t)
+	})
+	t.Run("budget", func(t *testing.T) {
+		storagetest.RunIndexedKVBrokerConformance(t, func(t *testing.T) storage.KVBroker {
+			return storage.NewBudgetBroker(slog.Default(), newBroker(t), storage.Budget{})
+		})
+	})
+}
+
+func TestBudgetBrokerConformance(t *testing.T) {
+	storagetest.RunKVBrokerConformance(t, func(t *testing.T) storage.KVBroker {
+		return storage.NewBudgetBroker(slog.Default(), newBroker(t), storage.Budget{})
+	})
+}
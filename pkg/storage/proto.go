@@ -4,39 +4,109 @@ import (
 	"context"
 	"log/slog"
 	"reflect"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 )
 
+// ProtoKVOption configures a proto KeyValue
+type ProtoKVOption func(*protoKeyValueOptions)
+
+type protoKeyValueOptions struct {
+	compress        bool
+	minCompressSize int
+	// optional
+	metrics  *Metrics
+	keyspace string
+}
+
+// WithCompression zstd compresses values of at least minSize bytes when writing.
+// A minSize of 0 uses a default threshold. Compressed and uncompressed values are
+// read transparently either way, so compression can be toggled for existing keyspaces.
+func WithCompression(minSize int) ProtoKVOption {
+	return func(o *protoKeyValueOptions) {
+		o.compress = true
+		o.minCompressSize = minSize
+		if minSize <= 0 {
+			o.minCompressSize = defaultMinCompressSize
+		}
+	}
+}
+
 func NewProtoKV[T proto.Message](
 	logger *slog.Logger,
 	kv KV,
+	opts ...ProtoKVOption,
 ) KeyValue[T] {
+	options := protoKeyValueOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
 	return &protoKeyValue[T]{
 		underlying: kv,
 		logger:     logger,
+		options:    options,
 	}
 }
 
 type protoKeyValue[T proto.Message] struct {
 	logger     *slog.Logger
 	underlying KV
+	options    protoKeyValueOptions
 }
 
-func (kv *protoKeyValue[T]) Put(ctx context.Context, key string, obj T) error {
+// encode marshals obj, compressing it if enabled
+func (kv *protoKeyValue[T]) encode(obj T) ([]byte, error) {
 	data, err := proto.Marshal(obj)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	if kv.options.compress && len(data) >= kv.options.minCompressSize {
+		return compress(data)
+	}
+	return data, nil
+}
+
+// observe records an operation in the metrics of the keyspace, if any
+func (kv *protoKeyValue[T]) observe(op, key string, start time.Time, size int, err error) {
+	kv.options.metrics.observe(kv.options.keyspace, op, key, start, size, err)
+}
 
+func (kv *protoKeyValue[T]) Put(ctx context.Context, key string, obj T) (err error) {
+	start, size := time.Now(), -1
+	defer func() { kv.observe("put", key, start, size, err) }()
+	data, err := kv.encode(obj)
+	if err != nil {
+		return err
+	}
+	size = len(data)
 	return kv.underlying.Put(ctx, key, data)
 }
-func (kv *protoKeyValue[T]) Get(ctx context.Context, key string) (T, error) {
+
+func (kv *protoKeyValue[T]) Create(ctx context.Context, key string, obj T) (err error) {
+	start, size := time.Now(), -1
+	defer func() { kv.observe("create", key, start, size, err) }()
+	data, err := kv.encode(obj)
+	if err != nil {
+		return err
+	}
+	size = len(data)
+	return kv.underlying.Create(ctx, key, data)
+}
+
+func (kv *protoKeyValue[T]) Get(ctx context.Context, key string) (_ T, err error) {
+	start, size := time.Now(), -1
+	defer func() { kv.observe("get", key, start, size, err) }()
 	var t T
 	raw, err := kv.underlying.Get(ctx, key)
 	if err != nil {
 		return t, err
 	}
+	size = len(raw)
+	raw, err = decompress(raw)
+	if err != nil {
+		return t, err
+	}
 	t = NewMessage[T]()
 	if err := proto.Unmarshal(raw, t); err != nil {
 		return t, err
@@ -44,17 +114,28 @@ func (kv *protoKeyValue[T]) Get(ctx context.Context, key string) (T, error) {
 	return t, nil
 }
 
-func (kv *protoKeyValue[T]) ListKeys(ctx context.Context) ([]string, error) {
+func (kv *protoKeyValue[T]) ListKeys(ctx context.Context) (keys []string, err error) {
+	start := time.Now()
+	defer func() { kv.observe("list_keys", "", start, -1, err) }()
 	return kv.underlying.ListKeys(ctx)
 }
-func (kv *protoKeyValue[T]) List(ctx context.Context) ([]T, error) {
+func (kv *protoKeyValue[T]) List(ctx context.Context) (_ []T, err error) {
+	start, size := time.Now(), -1
+	defer func() { kv.observe("list", "", start, size, err) }()
 	raw, err := kv.underlying.List(ctx)
 	if err != nil {
 		return nil, err
 	}
+	size = 0
 	ret := make([]T, len(raw))
 	for idx, el := range raw {
+		size += len(el)
 		t := NewMessage[T]()
+		el, err := decompress(el)
+		if err != nil {
+			kv.logger.With("type", reflect.TypeOf(t)).With("error", err).Error("failed to decompress proto-type")
+			continue
+		}
 		if err := proto.Unmarshal(el, t); err != nil {
 			kv.logger.With("type", reflect.TypeOf(t)).With("error", err).Error("failed to unmarshal proto-type")
 			continue
@@ -64,10 +145,65 @@ func (kv *protoKeyValue[T]) List(ctx context.Context) ([]T, error) {
 	return ret, nil
 
 }
-func (kv *protoKeyValue[T]) Delete(ctx context.Context, key string) error {
+func (kv *protoKeyValue[T]) Delete(ctx context.Context, key string) (err error) {
+	start := time.Now()
+	defer func() { kv.observe("delete", key, start, -1, err) }()
 	return kv.underlying.Delete(ctx, key)
 }
 
+func (kv *protoKeyValue[T]) ListPrefix(ctx context.Context, prefix string) (_ []KeyValuePair[T], err error) {
+	start, size := time.Now(), -1
+	defer func() { kv.observe("list_prefix", prefix, start, size, err) }()
+	raw, err := kv.underlying.ListPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	size = 0
+	ret := make([]KeyValuePair[T], 0, len(raw))
+	for _, el := range raw {
+		size += len(el.Value)
+		t := NewMessage[T]()
+		data, err := decompress(el.Value)
+		if err != nil {
+			kv.logger.With("type", reflect.TypeOf(t)).With("key", el.Key).With("error", err).Error("failed to decompress proto-type")
+			continue
+		}
+		if err := proto.Unmarshal(data, t); err != nil {
+			kv.logger.With("type", reflect.TypeOf(t)).With("key", el.Key).With("error", err).Error("failed to unmarshal proto-type")
+			continue
+		}
+		ret = append(ret, KeyValuePair[T]{Key: el.Key, Value: t})
+	}
+	return ret, nil
+}
+
+func (kv *protoKeyValue[T]) DeletePrefix(ctx context.Context, prefix string) (err error) {
+	start := time.Now()
+	defer func() { kv.observe("delete_prefix", prefix, start, -1, err) }()
+	return kv.underlying.DeletePrefix(ctx, prefix)
+}
+
+func (kv *protoKeyValue[T]) NewBatch() Batch {
+	return kv.underlying.NewBatch()
+}
+
+func (kv *protoKeyValue[T]) PutBatch(ctx context.Context, b Batch, key string, obj T) (err error) {
+	start, size := time.Now(), -1
+	defer func() { kv.observe("put_batch", key, start, size, err) }()
+	data, err := kv.encode(obj)
+	if err != nil {
+		return err
+	}
+	size = len(data)
+	return kv.underlying.PutBatch(ctx, b, key, data)
+}
+
+func (kv *protoKeyValue[T]) DeleteBatch(ctx context.Context, b Batch, key string) (err error) {
+	start := time.Now()
+	defer func() { kv.observe("delete_batch", key, start, -1, err) }()
+	return kv.underlying.DeleteBatch(ctx, b, key)
+}
+
 func NewMessage[T proto.Message]() T {
 	var t T
 	return t.ProtoReflect().New().Interface().(T)
@@ -2,6 +2,7 @@ package storage_test
 
 import (
 	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,10 +10,12 @@ import (
 	"github.com/cockroachdb/pebble/v2/vfs"
 	"github.com/google/go-cmp/cmp"
 	bootstrapv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
 	"github.com/otelfleet/otelfleet/pkg/storage"
 	otelpebble "github.com/otelfleet/otelfleet/pkg/storage/pebble"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -47,4 +50,91 @@ func TestProtoStorage(t *testing.T) {
 	vals, err := protoKv.List(t.Context())
 	require.NoError(t, err)
 	assert.Equal(t, 1, len(vals))
+
+	err = protoKv.Create(t.Context(), "b1", &bootstrapv1alpha1.BootstrapToken{ID: "other"})
+	assert.True(t, storage.IsAlreadyExists(err))
+	require.NoError(t, protoKv.Create(t.Context(), "b2", tok))
+	_, err = protoKv.Get(t.Context(), "b3")
+	assert.True(t, storage.IsNotFound(err))
+}
+
+func TestProtoStorage_Compression(t *testing.T) {
+	db, err := pebble.Open("", &pebble.Options{
+		FS: vfs.NewMem(),
+	})
+	require.NoError(t, err)
+	kv := otelpebble.NewKVBroker(db).KeyValue("configs")
+	plainKv := storage.NewProtoKV[*configv1alpha1.Config](slog.Default(), kv)
+	compressedKv := storage.NewProtoKV[*configv1alpha1.Config](slog.Default(), kv, storage.WithCompression(0))
+
+	large := &configv1alpha1.Config{
+		Config: []byte(strings.Repeat("receivers:\n  otlp:\n    protocols:\n      grpc: {}\n", 100)),
+	}
+	small := &configv1alpha1.Config{Config: []byte("receivers: {}")}
+
+	// values written before compression was enabled remain readable
+	require.NoError(t, plainKv.Put(t.Context(), "before", large))
+	ret, err := compressedKv.Get(t.Context(), "before")
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(large, ret, protocmp.Transform()))
+
+	require.NoError(t, compressedKv.Put(t.Context(), "large", large))
+	require.NoError(t, compressedKv.Put(t.Context(), "small", small))
+
+	raw, err := kv.Get(t.Context(), "large")
+	require.NoError(t, err)
+	assert.Less(t, len(raw), len(large.GetConfig())/4)
+	raw, err = kv.Get(t.Context(), "small")
+	require.NoError(t, err)
+	assert.NoError(t, proto.Unmarshal(raw, &configv1alpha1.Config{}), "small values are stored uncompressed")
+
+	// compression can be disabled again without losing data
+	ret, err = plainKv.Get(t.Context(), "large")
+	require.NoError(t, err)
+	assert.Empty(t, cmp.Diff(large, ret, protocmp.Transform()))
+
+	vals, err := plainKv.List(t.Context())
+	require.NoError(t, err)
+	require.Len(t, vals, 3)
+	for _, v := range vals {
+		assert.NotEmpty(t, v.GetConfig())
+	}
+}
+
+func TestProtoStoragePrefix(t *testing.T) {
+	db, err := pebble.Open("", &pebble.Options{
+		FS: vfs.NewMem(),
+	})
+	require.NoError(t, err)
+	broker := otelpebble.NewKVBroker(db)
+	protoKv := storage.NewProtoKV[*configv1alpha1.AgentDeploymentStatus](slog.Default(), broker.KeyValue("test"))
+	// a keyspace sharing the name prefix must not be visible
+	sibling := storage.NewProtoKV[*configv1alpha1.AgentDeploymentStatus](slog.Default(), broker.KeyValue("test2"))
+
+	ctx := t.Context()
+	for _, key := range []string{"d1/a1", "d1/a2", "d10/a1", "d2/a1"} {
+		require.NoError(t, protoKv.Put(ctx, key, &configv1alpha1.AgentDeploymentStatus{AgentId: key}))
+	}
+	require.NoError(t, sibling.Put(ctx, "d1/a3", &configv1alpha1.AgentDeploymentStatus{AgentId: "d1/a3"}))
+
+	entries, err := protoKv.ListPrefix(ctx, "d1/")
+	require.NoError(t, err)
+	var keys []string
+	for _, entry := range entries {
+		keys = append(keys, entry.Key)
+		assert.Equal(t, entry.Key, entry.Value.GetAgentId())
+	}
+	assert.Equal(t, []string{"d1/a1", "d1/a2"}, keys)
+
+	entries, err = protoKv.ListPrefix(ctx, "")
+	require.NoError(t, err)
+	assert.Len(t, entries, 4)
+
+	require.NoError(t, protoKv.DeletePrefix(ctx, "d1/"))
+	remaining, err := protoKv.ListKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d10/a1", "d2/a1"}, remaining)
+	siblingKeys, err := sibling.ListKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"d1/a3"}, siblingKeys)
 }
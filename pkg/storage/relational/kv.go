@@ -0,0 +1,417 @@
+// Package relational implements storage keyspaces in a table of a relational database, through
+// database/sql, so that the server can keep its state in a managed database such as Postgres
+package relational
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/otelfleet/otelfleet/pkg/storage"
+)
+
+// Table holds the entries of every keyspace
+const Table = "otelfleet_kv"
+
+// Dialect is the SQL dialect of a database
+type Dialect struct {
+	Name string
+	// placeholder returns the placeholder of the nth argument of a statement, from 1
+	placeholder func(n int) string
+	// blob is the type of binary columns
+	blob string
+}
+
+// Dialects of the supported databases. Both support upserts and order binary columns
+// bytewise, as listings require.
+var (
+	Postgres = Dialect{Name: "postgres", placeholder: func(n int) string { return fmt.Sprintf("$%d", n) }, blob: "BYTEA"}
+	SQLite   = Dialect{Name: "sqlite", placeholder: func(int) string { return "?" }, blob: "BLOB"}
+)
+
+// bind replaces the ? placeholders of query with those of the dialect
+func (d Dialect) bind(query string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString(d.placeholder(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// KVBroker keeps its keyspaces in Table, rows are keyed by keyspace and key. Changes are
+// watched in process: servers sharing the database don't see each other's changes.
+type KVBroker struct {
+	db      *sql.DB
+	dialect Dialect
+
+	// keyspace -> lock of the commits to the keyspace, held until their changes are
+	// published so that watchers see them in commit order
+	mu       sync.Mutex
+	locks    map[string]*sync.Mutex
+	watchers storage.Watchers
+}
+
+// NewKVBroker creates Table in db if it doesn't exist. Databases serving a single connection
+// at a time, such as in-memory SQLite databases, must be limited to one open connection.
+func NewKVBroker(ctx context.Context, db *sql.DB, dialect Dialect) (*KVBroker, error) {
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	keyspace TEXT NOT NULL,
+	key %s NOT NULL,
+	value %s NOT NULL,
+	PRIMARY KEY (keyspace, key)
+)`, Table, dialect.blob, dialect.blob)); err != nil {
+		return nil, fmt.Errorf("failed to create the %s table: %w", Table, err)
+	}
+	return &KVBroker{db: db, dialect: dialect, locks: map[string]*sync.Mutex{}}, nil
+}
+
+func (b *KVBroker) KeyValue(prefix string) storage.KV {
+	return &relationalKV{broker: b, keyspace: prefix}
+}
+
+func (b *KVBroker) Watch(ctx context.Context, prefix string) (<-chan storage.WatchEvent, error) {
+	return b.watchers.Watch(ctx, prefix)
+}
+
+// lock locks the commits to keyspaces, in order, and returns the function unlocking them
+func (b *KVBroker) lock(keyspaces ...string) func() {
+	slices.Sort(keyspaces)
+	keyspaces = slices.Compact(keyspaces)
+	b.mu.Lock()
+	locks := make([]*sync.Mutex, 0, len(keyspaces))
+	for _, keyspace := range keyspaces {
+		l, ok := b.locks[keyspace]
+		if !ok {
+			l = &sync.Mutex{}
+			b.locks[keyspace] = l
+		}
+		locks = append(locks, l)
+	}
+	b.mu.Unlock()
+	for _, l := range locks {
+		l.Lock()
+	}
+	return func() {
+		for _, l := range slices.Backward(locks) {
+			l.Unlock()
+		}
+	}
+}
+
+// execer runs statements, in or out of a transaction
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type relationalKV struct {
+	broker   *KVBroker
+	keyspace string
+}
+
+// checkContext fails operations whose context is done
+func (k *relationalKV) checkContext(ctx context.Context, op string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s in %s aborted: %w", op, k.keyspace, context.Cause(ctx))
+	}
+	return nil
+}
+
+// write runs fn, and publishes event once it succeeds
+func (k *relationalKV) write(ctx context.Context, op string, event storage.WatchEvent, fn func(ctx context.Context) error) error {
+	if err := k.checkContext(ctx, op); err != nil {
+		return err
+	}
+	unlock := k.broker.lock(k.keyspace)
+	defer unlock()
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	k.broker.watchers.Publish(k.keyspace, event)
+	return nil
+}
+
+func (k *relationalKV) exec(ctx context.Context, db execer, query string, args ...any) (sql.Result, error) {
+	return db.ExecContext(ctx, k.broker.dialect.bind(query), args...)
+}
+
+// put stores obj under key
+func (k *relationalKV) put(ctx context.Context, db execer, key string, obj []byte) error {
+	if obj == nil {
+		// values are never NULL
+		obj = []byte{}
+	}
+	_, err := k.exec(ctx, db, fmt.Sprintf(`INSERT INTO %s (keyspace, key, value) VALUES (?, ?, ?)
+ON CONFLICT (keyspace, key) DO UPDATE SET value = excluded.value`, Table), k.keyspace, []byte(key), obj)
+	return err
+}
+
+// delete deletes key
+func (k *relationalKV) delete(ctx context.Context, db execer, key string) error {
+	_, err := k.exec(ctx, db, fmt.Sprintf(`DELETE FROM %s WHERE keyspace = ? AND key = ?`, Table), k.keyspace, []byte(key))
+	return err
+}
+
+func (k *relationalKV) Put(ctx context.Context, key string, obj []byte) error {
+	return k.write(ctx, "put", storage.WatchEvent{Type: storage.EventPut, Key: key, Value: obj}, func(ctx context.Context) error {
+		return k.put(ctx, k.broker.db, key, obj)
+	})
+}
+
+func (k *relationalKV) Create(ctx context.Context, key string, obj []byte) error {
+	return k.write(ctx, "create", storage.WatchEvent{Type: storage.EventPut, Key: key, Value: obj}, func(ctx context.Context) error {
+		if obj == nil {
+			obj = []byte{}
+		}
+		res, err := k.exec(ctx, k.broker.db, fmt.Sprintf(`INSERT INTO %s (keyspace, key, value) VALUES (?, ?, ?)
+ON CONFLICT (keyspace, key) DO NOTHING`, Table), k.keyspace, []byte(key), obj)
+		if err != nil {
+			return err
+		}
+		created, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if created == 0 {
+			return storage.AlreadyExists(key)
+		}
+		return nil
+	})
+}
+
+func (k *relationalKV) CompareAndSwap(ctx context.Context, key string, old, obj []byte) error {
+	return k.write(ctx, "compare_and_swap", storage.WatchEvent{Type: storage.EventPut, Key: key, Value: obj}, func(ctx context.Context) error {
+		if old == nil {
+			old = []byte{}
+		}
+		if obj == nil {
+			obj = []byte{}
+		}
+		// the row is only updated while it holds old, whichever server updates it
+		res, err := k.exec(ctx, k.broker.db, fmt.Sprintf(`UPDATE %s SET value = ? WHERE keyspace = ? AND key = ? AND value = ?`, Table),
+			obj, k.keyspace, []byte(key), old)
+		if err != nil {
+			return err
+		}
+		swapped, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if swapped > 0 {
+			return nil
+		}
+		if _, err := k.Get(ctx, key); err != nil {
+			return err
+		}
+		return storage.Conflict(key)
+	})
+}
+
+func (k *relationalKV) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := k.checkContext(ctx, "get"); err != nil {
+		return nil, err
+	}
+	var value []byte
+	err := k.broker.db.QueryRowContext(ctx, k.broker.dialect.bind(fmt.Sprintf(`SELECT value FROM %s WHERE keyspace = ? AND key = ?`, Table)),
+		k.keyspace, []byte(key)).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.NotFound(key)
+	} else if err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// bounds returns the conditions and arguments selecting the keys that start with prefix
+func (k *relationalKV) bounds(prefix string) (string, []any) {
+	cond, args := "keyspace = ?", []any{k.keyspace}
+	if prefix == "" {
+		return cond, args
+	}
+	lower := []byte(prefix)
+	cond, args = cond+" AND key >= ?", append(args, lower)
+	// the smallest key greater than every key with the prefix
+	upper := slices.Clone(lower)
+	for i := len(upper) - 1; i >= 0; i-- {
+		if upper[i] < 0xff {
+			upper[i]++
+			return cond + " AND key < ?", append(args, upper[:i+1])
+		}
+	}
+	return cond, args
+}
+
+// iterPrefix calls fn for every entry whose key starts with prefix, in key order
+func (k *relationalKV) iterPrefix(ctx context.Context, op, prefix string, fn func(key string, value []byte)) error {
+	if err := k.checkContext(ctx, op); err != nil {
+		return err
+	}
+	cond, args := k.bounds(prefix)
+	rows, err := k.broker.db.QueryContext(ctx, k.broker.dialect.bind(fmt.Sprintf(`SELECT key, value FROM %s WHERE %s ORDER BY key`, Table, cond)), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key, value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			return err
+		}
+		fn(string(key), value)
+	}
+	return rows.Err()
+}
+
+func (k *relationalKV) ListKeys(ctx context.Context) ([]string, error) {
+	keys := []string{}
+	if err := k.iterPrefix(ctx, "list_keys", "", func(key string, _ []byte) {
+		keys = append(keys, key)
+	}); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (k *relationalKV) List(ctx context.Context) ([][]byte, error) {
+	values := [][]byte{}
+	if err := k.iterPrefix(ctx, "list", "", func(_ string, value []byte) {
+		values = append(values, value)
+	}); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+func (k *relationalKV) Delete(ctx context.Context, key string) error {
+	return k.write(ctx, "delete", storage.WatchEvent{Type: storage.EventDelete, Key: key}, func(ctx context.Context) error {
+		return k.delete(ctx, k.broker.db, key)
+	})
+}
+
+func (k *relationalKV) ListPrefix(ctx context.Context, prefix string) ([]storage.KeyValuePair[[]byte], error) {
+	entries := []storage.KeyValuePair[[]byte]{}
+	if err := k.iterPrefix(ctx, "list_prefix", prefix, func(key string, value []byte) {
+		entries = append(entries, storage.KeyValuePair[[]byte]{Key: key, Value: value})
+	}); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (k *relationalKV) DeletePrefix(ctx context.Context, prefix string) error {
+	return k.write(ctx, "delete_prefix", storage.WatchEvent{Type: storage.EventDeletePrefix, Key: prefix}, func(ctx context.Context) error {
+		cond, args := k.bounds(prefix)
+		_, err := k.exec(ctx, k.broker.db, fmt.Sprintf(`DELETE FROM %s WHERE %s`, Table, cond), args...)
+		return err
+	})
+}
+
+func (k *relationalKV) NewBatch() storage.Batch {
+	return &batch{broker: k.broker}
+}
+
+// addToBatch adds a write to b, publishing event once b commits
+func (k *relationalKV) addToBatch(ctx context.Context, op string, b storage.Batch, event storage.WatchEvent, write func(ctx context.Context, tx *sql.Tx) error) error {
+	if err := k.checkContext(ctx, op); err != nil {
+		return err
+	}
+	rb, ok := b.(*batch)
+	if !ok || rb.broker != k.broker {
+		return storage.ErrForeignBatch
+	}
+	if rb.committed {
+		return storage.ErrBatchCommitted
+	}
+	rb.writes = append(rb.writes, write)
+	rb.changes = append(rb.changes, change{keyspace: k.keyspace, event: event})
+	return nil
+}
+
+func (k *relationalKV) PutBatch(ctx context.Context, b storage.Batch, key string, obj []byte) error {
+	obj = slices.Clone(obj)
+	return k.addToBatch(ctx, "put_batch", b, storage.WatchEvent{Type: storage.EventPut, Key: key, Value: obj}, func(ctx context.Context, tx *sql.Tx) error {
+		return k.put(ctx, tx, key, obj)
+	})
+}
+
+func (k *relationalKV) DeleteBatch(ctx context.Context, b storage.Batch, key string) error {
+	return k.addToBatch(ctx, "delete_batch", b, storage.WatchEvent{Type: storage.EventDelete, Key: key}, func(ctx context.Context, tx *sql.Tx) error {
+		return k.delete(ctx, tx, key)
+	})
+}
+
+// change is a change to a keyspace, published to its watchers once committed
+type change struct {
+	keyspace string
+	event    storage.WatchEvent
+}
+
+// batch applies its writes in a single database transaction
+type batch struct {
+	broker    *KVBroker
+	writes    []func(ctx context.Context, tx *sql.Tx) error
+	changes   []change
+	callbacks []func()
+	committed bool
+}
+
+func (b *batch) Commit(ctx context.Context) error {
+	if b.committed {
+		return storage.ErrBatchCommitted
+	}
+	b.committed = true
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("batch commit aborted: %w", context.Cause(ctx))
+	}
+	if err := b.apply(ctx); err != nil {
+		return err
+	}
+	for _, fn := range b.callbacks {
+		fn()
+	}
+	return nil
+}
+
+// apply runs the writes of the batch in a transaction, and publishes their changes once it
+// commits
+func (b *batch) apply(ctx context.Context) error {
+	keyspaces := make([]string, 0, len(b.changes))
+	for _, c := range b.changes {
+		keyspaces = append(keyspaces, c.keyspace)
+	}
+	unlock := b.broker.lock(keyspaces...)
+	defer unlock()
+	tx, err := b.broker.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, write := range b.writes {
+		if err := write(ctx, tx); err != nil {
+			return errors.Join(err, tx.Rollback())
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	for _, c := range b.changes {
+		b.broker.watchers.Publish(c.keyspace, c.event)
+	}
+	return nil
+}
+
+func (b *batch) OnCommit(fn func()) {
+	b.callbacks = append(b.callbacks, fn)
+}
+
+var _ storage.KV = (*relationalKV)(nil)
+var _ storage.Batch = (*batch)(nil)
+var _ storage.KVBroker = (*KVBroker)(nil)
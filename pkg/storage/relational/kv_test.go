@@ -0,0 +1,46 @@
+package relational_test
+
+import (
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/storage/relational"
+	"github.com/otelfleet/otelfleet/pkg/storage/storagetest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteKVBrokerConformance(t *testing.T) {
+	storagetest.RunKVBrokerConformance(t, func(t *testing.T) storage.KVBroker {
+		db, err := sql.Open("sqlite3", ":memory:")
+		require.NoError(t, err)
+		// every connection opens its own in-memory database
+		db.SetMaxOpenConns(1)
+		t.Cleanup(func() { db.Close() })
+		broker, err := relational.NewKVBroker(t.Context(), db, relational.SQLite)
+		require.NoError(t, err)
+		return broker
+	})
+}
+
+// TestPostgresKVBrokerConformance runs against the database of OTELFLEET_TEST_POSTGRES_DSN,
+// whose otelfleet_kv table is dropped
+func TestPostgresKVBrokerConformance(t *testing.T) {
+	dsn := os.Getenv("OTELFLEET_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("OTELFLEET_TEST_POSTGRES_DSN is not set")
+	}
+	storagetest.RunKVBrokerConformance(t, func(t *testing.T) storage.KVBroker {
+		db, err := sql.Open("pgx", dsn)
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+		_, err = db.ExecContext(t.Context(), "DROP TABLE IF EXISTS "+relational.Table)
+		require.NoError(t, err)
+		broker, err := relational.NewKVBroker(t.Context(), db, relational.Postgres)
+		require.NoError(t, err)
+		return broker
+	})
+}
@@ -2,24 +2,86 @@ package storage
 
 import "context"
 
+// KeyValuePair is an entry returned by a prefix listing
+type KeyValuePair[T any] struct {
+	Key   string
+	Value T
+}
+
+// KV is a keyspace of raw values. Implementations must follow this contract, which is
+// checked by storagetest.RunKVBrokerConformance:
+//   - Get of a missing key returns an error matching ErrNotFound
+//   - Create of an existing key returns an error matching ErrAlreadyExists, and exactly one
+//     of concurrent Creates of the same key succeeds
+//   - Put overwrites existing keys, Delete and DeletePrefix of missing keys succeed
+//   - CompareAndSwap of a key whose value isn't old returns an error matching ErrConflict, of
+//     a missing key one matching ErrNotFound, and exactly one of concurrent swaps succeeds
+//   - returned values are owned by the caller, and stored values don't alias the caller's
+//   - listings are in key order and only hold the keys of the keyspace
+//   - operations fail with the context error once their context is done
+//   - the writes of a batch are stored together once it commits, and none is visible before
 type KV interface {
 	Put(ctx context.Context, key string, obj []byte) error
+	// Create stores obj under key, unless key already exists
+	Create(ctx context.Context, key string, obj []byte) error
+	// CompareAndSwap stores obj under key if its value is old, e.g. to update a value read
+	// by several servers sharing the storage without overwriting their writes
+	CompareAndSwap(ctx context.Context, key string, old, obj []byte) error
 	Get(ctx context.Context, key string) ([]byte, error)
 	ListKeys(ctx context.Context) ([]string, error)
 	List(ctx context.Context) ([][]byte, error)
 	Delete(ctx context.Context, key string) error
+	// ListPrefix returns the entries whose key starts with prefix, in key order
+	ListPrefix(ctx context.Context, prefix string) ([]KeyValuePair[[]byte], error)
+	// DeletePrefix deletes the entries whose key starts with prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+	// NewBatch starts a batch of writes to the keyspaces of the broker of the keyspace
+	NewBatch() Batch
+	// PutBatch adds a Put of obj under key to b
+	PutBatch(ctx context.Context, b Batch, key string, obj []byte) error
+	// DeleteBatch adds a Delete of key to b
+	DeleteBatch(ctx context.Context, b Batch, key string) error
+}
+
+// Batch collects writes to the keyspaces of one broker, which Commit stores atomically: a
+// crash or a failed commit leaves none of them stored. Batches aren't safe for concurrent
+// use, and are discarded if they are never committed.
+type Batch interface {
+	// Commit stores the writes of the batch. The batch can't be used once Commit was called.
+	Commit(ctx context.Context) error
+	// OnCommit registers fn to be called once the batch committed
+	OnCommit(fn func())
 }
 
+// KVBroker provides independent keyspaces, keys of one keyspace are never visible from another
 type KVBroker interface {
 	KeyValue(prefix string) KV
+	// Watch streams the changes to the keyspace prefix committed after it returns, in commit
+	// order. The writes of a batch are streamed once it commits, and failed writes aren't.
+	// The channel is closed once ctx is done, or once the watcher falls more than WatchBuffer
+	// events behind; watchers then list the keyspace to catch up, and watch it again.
+	Watch(ctx context.Context, prefix string) (<-chan WatchEvent, error)
 }
 
+// KeyValue is a keyspace of typed values, following the contract of KV
 type KeyValue[T any] interface {
 	Put(ctx context.Context, key string, obj T) error
+	// Create stores obj under key, unless key already exists
+	Create(ctx context.Context, key string, obj T) error
 	Get(ctx context.Context, key string) (T, error)
 	ListKeys(ctx context.Context) ([]string, error)
 	List(ctx context.Context) ([]T, error)
 	Delete(ctx context.Context, key string) error
+	// ListPrefix returns the entries whose key starts with prefix, in key order
+	ListPrefix(ctx context.Context, prefix string) ([]KeyValuePair[T], error)
+	// DeletePrefix deletes the entries whose key starts with prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+	// NewBatch starts a batch of writes to the keyspaces of the broker of the keyspace
+	NewBatch() Batch
+	// PutBatch adds a Put of obj under key to b
+	PutBatch(ctx context.Context, b Batch, key string, obj T) error
+	// DeleteBatch adds a Delete of key to b
+	DeleteBatch(ctx context.Context, b Batch, key string) error
 }
 
 type KeyValueBroker[T any] interface {
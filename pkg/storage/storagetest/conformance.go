@@ -0,0 +1,552 @@
+// Package storagetest checks that storage backends follow the storage.KV contract
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RunKVBrokerConformance checks that the brokers returned by newBroker follow the storage.KV
+// contract. newBroker is called once per subtest and must return an empty broker.
+func RunKVBrokerConformance(t *testing.T, newBroker func(t *testing.T) storage.KVBroker) {
+	t.Run("missing keys", func(t *testing.T) {
+		kv := newBroker(t).KeyValue("test")
+		_, err := kv.Get(t.Context(), "missing")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+		assert.True(t, storage.IsNotFound(err))
+		assert.True(t, grpcutil.IsErrorNotFound(err), "not found errors must map to codes.NotFound")
+		assert.False(t, storage.IsAlreadyExists(err))
+
+		assert.NoError(t, kv.Delete(t.Context(), "missing"))
+		assert.NoError(t, kv.DeletePrefix(t.Context(), "missing"))
+		keys, err := kv.ListKeys(t.Context())
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+		values, err := kv.List(t.Context())
+		require.NoError(t, err)
+		assert.Empty(t, values)
+		entries, err := kv.ListPrefix(t.Context(), "missing")
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("put, get and delete", func(t *testing.T) {
+		kv := newBroker(t).KeyValue("test")
+		require.NoError(t, kv.Put(t.Context(), "key", []byte("v1")))
+		got, err := kv.Get(t.Context(), "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v1"), got)
+
+		require.NoError(t, kv.Put(t.Context(), "key", []byte("v2")))
+		got, err = kv.Get(t.Context(), "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v2"), got)
+
+		require.NoError(t, kv.Put(t.Context(), "empty", []byte{}))
+		got, err = kv.Get(t.Context(), "empty")
+		require.NoError(t, err)
+		assert.Empty(t, got)
+
+		require.NoError(t, kv.Delete(t.Context(), "key"))
+		_, err = kv.Get(t.Context(), "key")
+		assert.True(t, storage.IsNotFound(err))
+	})
+
+	t.Run("create", func(t *testing.T) {
+		kv := newBroker(t).KeyValue("test")
+		require.NoError(t, kv.Create(t.Context(), "key", []byte("v1")))
+		err := kv.Create(t.Context(), "key", []byte("v2"))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, storage.ErrAlreadyExists)
+		assert.False(t, storage.IsNotFound(err))
+		got, err := kv.Get(t.Context(), "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v1"), got, "a failed create must not overwrite the value")
+
+		require.NoError(t, kv.Delete(t.Context(), "key"))
+		assert.NoError(t, kv.Create(t.Context(), "key", []byte("v3")))
+	})
+
+	t.Run("concurrent creates", func(t *testing.T) {
+		kv := newBroker(t).KeyValue("test")
+		var created atomic.Int32
+		var wg sync.WaitGroup
+		for i := range 16 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := kv.Create(t.Context(), "key", fmt.Appendf(nil, "v%d", i))
+				if err == nil {
+					created.Add(1)
+				} else {
+					assert.True(t, storage.IsAlreadyExists(err), "unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), created.Load())
+	})
+
+	t.Run("compare and swap", func(t *testing.T) {
+		kv := newBroker(t).KeyValue("test")
+		err := kv.CompareAndSwap(t.Context(), "key", []byte("v1"), []byte("v2"))
+		assert.True(t, storage.IsNotFound(err), "unexpected error: %v", err)
+
+		require.NoError(t, kv.Put(t.Context(), "key", []byte("v1")))
+		require.NoError(t, kv.CompareAndSwap(t.Context(), "key", []byte("v1"), []byte("v2")))
+		err = kv.CompareAndSwap(t.Context(), "key", []byte("v1"), []byte("v3"))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, storage.ErrConflict)
+		assert.False(t, storage.IsNotFound(err))
+		got, err := kv.Get(t.Context(), "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v2"), got, "a failed swap must not overwrite the value")
+
+		require.NoError(t, kv.CompareAndSwap(t.Context(), "key", []byte("v2"), []byte{}))
+		require.NoError(t, kv.CompareAndSwap(t.Context(), "key", nil, []byte("v4")))
+		got, err = kv.Get(t.Context(), "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v4"), got)
+	})
+
+	t.Run("concurrent swaps", func(t *testing.T) {
+		kv := newBroker(t).KeyValue("test")
+		require.NoError(t, kv.Put(t.Context(), "key", []byte("v0")))
+		var swapped atomic.Int32
+		var wg sync.WaitGroup
+		for i := range 16 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := kv.CompareAndSwap(t.Context(), "key", []byte("v0"), fmt.Appendf(nil, "v%d", i+1))
+				if err == nil {
+					swapped.Add(1)
+				} else {
+					assert.True(t, storage.IsConflict(err), "unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), swapped.Load())
+	})
+
+	t.Run("keyspaces are isolated", func(t *testing.T) {
+		broker := newBroker(t)
+		a, ab := broker.KeyValue("a"), broker.KeyValue("ab")
+		require.NoError(t, a.Put(t.Context(), "key", []byte("a")))
+		require.NoError(t, a.Put(t.Context(), "bkey", []byte("a")))
+		require.NoError(t, ab.Put(t.Context(), "key", []byte("ab")))
+
+		got, err := ab.Get(t.Context(), "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("ab"), got)
+		_, err = ab.Get(t.Context(), "bkey")
+		assert.True(t, storage.IsNotFound(err))
+
+		keys, err := a.ListKeys(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bkey", "key"}, keys)
+		keys, err = ab.ListKeys(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"key"}, keys)
+
+		require.NoError(t, a.DeletePrefix(t.Context(), ""))
+		keys, err = a.ListKeys(t.Context())
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+		got, err = ab.Get(t.Context(), "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("ab"), got)
+	})
+
+	t.Run("listings", func(t *testing.T) {
+		kv := newBroker(t).KeyValue("test")
+		for _, key := range []string{"b/2", "a", "b/1", "b", "c/\xff", "c/\xff\xff", "d"} {
+			require.NoError(t, kv.Put(t.Context(), key, []byte("value of "+key)))
+		}
+
+		keys, err := kv.ListKeys(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "b/1", "b/2", "c/\xff", "c/\xff\xff", "d"}, keys)
+		values, err := kv.List(t.Context())
+		require.NoError(t, err)
+		require.Len(t, values, len(keys))
+		for i, key := range keys {
+			assert.Equal(t, []byte("value of "+key), values[i])
+		}
+
+		entries, err := kv.ListPrefix(t.Context(), "b/")
+		require.NoError(t, err)
+		assert.Equal(t, []storage.KeyValuePair[[]byte]{
+			{Key: "b/1", Value: []byte("value of b/1")},
+			{Key: "b/2", Value: []byte("value of b/2")},
+		}, entries)
+		entries, err = kv.ListPrefix(t.Context(), "c/\xff")
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+
+		require.NoError(t, kv.DeletePrefix(t.Context(), "b/"))
+		keys, err = kv.ListKeys(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c/\xff", "c/\xff\xff", "d"}, keys)
+		require.NoError(t, kv.DeletePrefix(t.Context(), "c/\xff"))
+		keys, err = kv.ListKeys(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "d"}, keys)
+	})
+
+	t.Run("values are not aliased", func(t *testing.T) {
+		kv := newBroker(t).KeyValue("test")
+		value := []byte("value")
+		require.NoError(t, kv.Put(t.Context(), "key", value))
+		value[0] = 'X'
+
+		got, err := kv.Get(t.Context(), "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value"), got)
+		got[0] = 'X'
+		values, err := kv.List(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{[]byte("value")}, values)
+		values[0][0] = 'X'
+		entries, err := kv.ListPrefix(t.Context(), "")
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		entries[0].Value[0] = 'X'
+
+		// values read before later writes are kept
+		require.NoError(t, kv.Put(t.Context(), "key", []byte("other")))
+		got, err = kv.Get(t.Context(), "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("other"), got)
+		assert.Equal(t, []byte("Xalue"), values[0])
+	})
+
+	t.Run("done contexts", func(t *testing.T) {
+		kv := newBroker(t).KeyValue("test")
+		require.NoError(t, kv.Put(t.Context(), "key", []byte("value")))
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		assert.ErrorIs(t, kv.Put(ctx, "other", []byte("value")), context.Canceled)
+		assert.ErrorIs(t, kv.Create(ctx, "other", []byte("value")), context.Canceled)
+		assert.ErrorIs(t, kv.CompareAndSwap(ctx, "key", []byte("value"), []byte("other")), context.Canceled)
+		_, err := kv.Get(ctx, "key")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.False(t, storage.IsNotFound(err))
+		_, err = kv.ListKeys(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = kv.List(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = kv.ListPrefix(ctx, "")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.ErrorIs(t, kv.Delete(ctx, "key"), context.Canceled)
+		assert.ErrorIs(t, kv.DeletePrefix(ctx, ""), context.Canceled)
+
+		got, err := kv.Get(t.Context(), "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value"), got)
+		_, err = kv.Get(t.Context(), "other")
+		assert.True(t, storage.IsNotFound(err))
+	})
+
+	t.Run("batches", func(t *testing.T) {
+		broker := newBroker(t)
+		kv := broker.KeyValue("test")
+		other := broker.KeyValue("test-other")
+		require.NoError(t, kv.Put(t.Context(), "gone", []byte("value")))
+
+		b := kv.NewBatch()
+		var committed atomic.Int32
+		b.OnCommit(func() { committed.Add(1) })
+		value := []byte("a")
+		require.NoError(t, kv.PutBatch(t.Context(), b, "a", value))
+		value[0] = 'X'
+		require.NoError(t, other.PutBatch(t.Context(), b, "b", []byte("b")))
+		require.NoError(t, kv.DeleteBatch(t.Context(), b, "gone"))
+		require.NoError(t, kv.DeleteBatch(t.Context(), b, "missing"))
+
+		// nothing is visible before the batch commits
+		_, err := kv.Get(t.Context(), "a")
+		assert.True(t, storage.IsNotFound(err))
+		_, err = other.Get(t.Context(), "b")
+		assert.True(t, storage.IsNotFound(err))
+		_, err = kv.Get(t.Context(), "gone")
+		assert.NoError(t, err)
+		assert.Zero(t, committed.Load())
+
+		require.NoError(t, b.Commit(t.Context()))
+		assert.EqualValues(t, 1, committed.Load())
+		got, err := kv.Get(t.Context(), "a")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("a"), got)
+		got, err = other.Get(t.Context(), "b")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("b"), got)
+		_, err = kv.Get(t.Context(), "gone")
+		assert.True(t, storage.IsNotFound(err))
+
+		// committed batches can't be reused
+		assert.ErrorIs(t, kv.PutBatch(t.Context(), b, "c", []byte("c")), storage.ErrBatchCommitted)
+		assert.ErrorIs(t, b.Commit(t.Context()), storage.ErrBatchCommitted)
+
+		// batches only hold writes to the keyspaces of their broker
+		foreign := newBroker(t).KeyValue("test")
+		assert.ErrorIs(t, foreign.PutBatch(t.Context(), kv.NewBatch(), "c", []byte("c")), storage.ErrForeignBatch)
+		assert.ErrorIs(t, foreign.DeleteBatch(t.Context(), kv.NewBatch(), "a"), storage.ErrForeignBatch)
+	})
+
+	t.Run("aborted batches", func(t *testing.T) {
+		kv := newBroker(t).KeyValue("test")
+		b := kv.NewBatch()
+		require.NoError(t, kv.PutBatch(t.Context(), b, "a", []byte("a")))
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		assert.ErrorIs(t, kv.PutBatch(ctx, b, "b", []byte("b")), context.Canceled)
+		assert.ErrorIs(t, kv.DeleteBatch(ctx, b, "a"), context.Canceled)
+		assert.ErrorIs(t, b.Commit(ctx), context.Canceled)
+		keys, err := kv.ListKeys(t.Context())
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		broker := newBroker(t)
+		kv := broker.KeyValue("test")
+		other := broker.KeyValue("test-other")
+		require.NoError(t, kv.Put(t.Context(), "before", []byte("value")))
+		require.NoError(t, kv.Put(t.Context(), "p/1", []byte("value")))
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+		events, err := broker.Watch(ctx, "test")
+		require.NoError(t, err)
+
+		require.NoError(t, kv.Put(t.Context(), "a", []byte("a")))
+		require.NoError(t, kv.Create(t.Context(), "b", []byte("b")))
+		// failed writes and writes to other keyspaces aren't streamed
+		require.Error(t, kv.Create(t.Context(), "a", []byte("other")))
+		require.NoError(t, other.Put(t.Context(), "a", []byte("other")))
+		require.NoError(t, kv.Delete(t.Context(), "a"))
+		require.NoError(t, kv.DeletePrefix(t.Context(), "p/"))
+		b := kv.NewBatch()
+		require.NoError(t, kv.PutBatch(t.Context(), b, "c", []byte("c")))
+		require.NoError(t, other.PutBatch(t.Context(), b, "c", []byte("other")))
+		require.NoError(t, kv.DeleteBatch(t.Context(), b, "b"))
+		require.NoError(t, b.Commit(t.Context()))
+		require.NoError(t, kv.Put(t.Context(), "end", nil))
+
+		expected := []storage.WatchEvent{
+			{Type: storage.EventPut, Key: "a", Value: []byte("a")},
+			{Type: storage.EventPut, Key: "b", Value: []byte("b")},
+			{Type: storage.EventDelete, Key: "a"},
+			{Type: storage.EventDeletePrefix, Key: "p/"},
+			{Type: storage.EventPut, Key: "c", Value: []byte("c")},
+			{Type: storage.EventDelete, Key: "b"},
+			{Type: storage.EventPut, Key: "end"},
+		}
+		for _, want := range expected {
+			got := nextEvent(t, events)
+			if want.Type == storage.EventDeletePrefix && got.Type == storage.EventDelete {
+				// backends may stream the deletion of each key of the prefix instead
+				want = storage.WatchEvent{Type: storage.EventDelete, Key: "p/1"}
+			}
+			assert.Equal(t, want.Type, got.Type, "event of %s", want.Key)
+			assert.Equal(t, want.Key, got.Key)
+			assert.Equal(t, string(want.Value), string(got.Value), "value of %s", want.Key)
+		}
+
+		// watches are closed once their context is done
+		cancel()
+		select {
+		case _, ok := <-events:
+			assert.False(t, ok, "unexpected event")
+		case <-time.After(5 * time.Second):
+			assert.Fail(t, "watch not closed")
+		}
+		_, err = broker.Watch(ctx, "test")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("watchers falling behind", func(t *testing.T) {
+		broker := newBroker(t)
+		kv := broker.KeyValue("test")
+		slow, err := broker.Watch(t.Context(), "test")
+		require.NoError(t, err)
+		for i := range storage.WatchBuffer + 1 {
+			require.NoError(t, kv.Put(t.Context(), fmt.Sprintf("%04d", i), nil))
+		}
+		received := 0
+		for range slow {
+			received++
+		}
+		assert.LessOrEqual(t, received, storage.WatchBuffer)
+
+		// other watches aren't affected
+		events, err := broker.Watch(t.Context(), "test")
+		require.NoError(t, err)
+		require.NoError(t, kv.Put(t.Context(), "key", nil))
+		assert.Equal(t, "key", nextEvent(t, events).Key)
+	})
+}
+
+// nextEvent returns the next event of a watch, failing the test if none is streamed in time
+func nextEvent(t *testing.T, events <-chan storage.WatchEvent) storage.WatchEvent {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "watch closed")
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no event streamed")
+		return storage.WatchEvent{}
+	}
+}
+
+// testIndex indexes values made of comma separated words by each of their words
+var testIndex = storage.Index{
+	Name: "words",
+	Values: func(value []byte) []string {
+		if len(value) == 0 {
+			return nil
+		}
+		return strings.Split(string(value), ",")
+	},
+}
+
+// indexedBroker opens its keyspaces indexed by testIndex
+type indexedBroker struct {
+	storage.KVBroker
+}
+
+func (b indexedBroker) KeyValue(prefix string) storage.KV {
+	return storage.OpenIndexed(b.KVBroker, prefix, testIndex)
+}
+
+// RunIndexedKVBrokerConformance checks that the indexed keyspaces of the brokers returned by
+// newBroker follow the storage.KV contract, and keep their indexes up to date with their
+// values. Brokers that don't maintain indexes are checked through the scanning fallback.
+func RunIndexedKVBrokerConformance(t *testing.T, newBroker func(t *testing.T) storage.KVBroker) {
+	t.Run("kv", func(t *testing.T) {
+		RunKVBrokerConformance(t, func(t *testing.T) storage.KVBroker {
+			return indexedBroker{newBroker(t)}
+		})
+	})
+
+	lookup := func(t *testing.T, kv storage.IndexedKV, value string) []string {
+		t.Helper()
+		keys, err := kv.KeysByIndex(t.Context(), testIndex.Name, value)
+		require.NoError(t, err)
+		return keys
+	}
+
+	t.Run("writes update the index", func(t *testing.T) {
+		kv := storage.OpenIndexed(newBroker(t), "test", testIndex)
+		require.NoError(t, kv.Put(t.Context(), "b", []byte("red,blue")))
+		require.NoError(t, kv.Create(t.Context(), "a", []byte("red")))
+		require.NoError(t, kv.Put(t.Context(), "c", []byte{}))
+		assert.Equal(t, []string{"a", "b"}, lookup(t, kv, "red"))
+		assert.Equal(t, []string{"b"}, lookup(t, kv, "blue"))
+		assert.Empty(t, lookup(t, kv, "green"))
+
+		// overwriting a value moves its key to the new index values
+		require.NoError(t, kv.Put(t.Context(), "b", []byte("green")))
+		assert.Equal(t, []string{"a"}, lookup(t, kv, "red"))
+		assert.Empty(t, lookup(t, kv, "blue"))
+		assert.Equal(t, []string{"b"}, lookup(t, kv, "green"))
+
+		// failed creations and swaps leave the index as is
+		assert.True(t, storage.IsAlreadyExists(kv.Create(t.Context(), "a", []byte("blue"))))
+		assert.True(t, storage.IsConflict(kv.CompareAndSwap(t.Context(), "a", []byte("green"), []byte("blue"))))
+		assert.Equal(t, []string{"a"}, lookup(t, kv, "red"))
+		assert.Empty(t, lookup(t, kv, "blue"))
+
+		require.NoError(t, kv.CompareAndSwap(t.Context(), "b", []byte("green"), []byte("blue")))
+		assert.Equal(t, []string{"b"}, lookup(t, kv, "blue"))
+		assert.Empty(t, lookup(t, kv, "green"))
+		require.NoError(t, kv.Put(t.Context(), "b", []byte("green")))
+
+		require.NoError(t, kv.Delete(t.Context(), "a"))
+		require.NoError(t, kv.Delete(t.Context(), "missing"))
+		assert.Empty(t, lookup(t, kv, "red"))
+
+		require.NoError(t, kv.Put(t.Context(), "prefix/1", []byte("green")))
+		require.NoError(t, kv.Put(t.Context(), "prefix/2", []byte("green,red")))
+		assert.Equal(t, []string{"b", "prefix/1", "prefix/2"}, lookup(t, kv, "green"))
+		require.NoError(t, kv.DeletePrefix(t.Context(), "prefix/"))
+		assert.Equal(t, []string{"b"}, lookup(t, kv, "green"))
+		assert.Empty(t, lookup(t, kv, "red"))
+
+		_, err := kv.KeysByIndex(t.Context(), "unknown", "red")
+		assert.ErrorIs(t, err, storage.ErrUnknownIndex)
+	})
+
+	t.Run("batches update the index", func(t *testing.T) {
+		kv := storage.OpenIndexed(newBroker(t), "test", testIndex)
+		require.NoError(t, kv.Put(t.Context(), "a", []byte("red")))
+		require.NoError(t, kv.Put(t.Context(), "b", []byte("red")))
+
+		b := kv.NewBatch()
+		require.NoError(t, kv.PutBatch(t.Context(), b, "a", []byte("blue")))
+		require.NoError(t, kv.DeleteBatch(t.Context(), b, "b"))
+		require.NoError(t, kv.PutBatch(t.Context(), b, "c", []byte("red")))
+		assert.Equal(t, []string{"a", "b"}, lookup(t, kv, "red"))
+		assert.Empty(t, lookup(t, kv, "blue"))
+
+		require.NoError(t, b.Commit(t.Context()))
+		assert.Equal(t, []string{"c"}, lookup(t, kv, "red"))
+		assert.Equal(t, []string{"a"}, lookup(t, kv, "blue"))
+	})
+
+	t.Run("existing values are indexed", func(t *testing.T) {
+		broker := newBroker(t)
+		require.NoError(t, broker.KeyValue("test").Put(t.Context(), "a", []byte("red")))
+		require.NoError(t, broker.KeyValue("test").Put(t.Context(), "b", []byte("blue")))
+
+		kv := storage.OpenIndexed(broker, "test", testIndex)
+		assert.Equal(t, []string{"a"}, lookup(t, kv, "red"))
+		require.NoError(t, kv.Put(t.Context(), "c", []byte("red")))
+		assert.Equal(t, []string{"a", "c"}, lookup(t, kv, "red"))
+
+		// reopening the keyspace keeps the index
+		kv = storage.OpenIndexed(broker, "test", testIndex)
+		assert.Equal(t, []string{"a", "c"}, lookup(t, kv, "red"))
+		assert.Equal(t, []string{"b"}, lookup(t, kv, "blue"))
+	})
+
+	t.Run("indexes are isolated", func(t *testing.T) {
+		broker := newBroker(t)
+		kv := storage.OpenIndexed(broker, "test", testIndex)
+		other := storage.OpenIndexed(broker, "test-other", testIndex)
+		require.NoError(t, kv.Put(t.Context(), "a", []byte("red")))
+		require.NoError(t, other.Put(t.Context(), "b", []byte("red")))
+		assert.Equal(t, []string{"a"}, lookup(t, kv, "red"))
+		assert.Equal(t, []string{"b"}, lookup(t, other, "red"))
+
+		// index entries aren't keys of the keyspace
+		keys, err := kv.ListKeys(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a"}, keys)
+		require.NoError(t, kv.DeletePrefix(t.Context(), ""))
+		assert.Empty(t, lookup(t, kv, "red"))
+		assert.Equal(t, []string{"b"}, lookup(t, other, "red"))
+	})
+
+	t.Run("done contexts", func(t *testing.T) {
+		kv := storage.OpenIndexed(newBroker(t), "test", testIndex)
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err := kv.KeysByIndex(ctx, testIndex.Name, "red")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
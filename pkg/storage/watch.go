@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// WatchBuffer is how many events a watcher may fall behind before its watch is closed
+const WatchBuffer = 256
+
+// EventType is the kind of change of a WatchEvent
+type EventType int
+
+const (
+	// EventPut is the write of the value of a key, by Put, Create or a batch
+	EventPut EventType = iota + 1
+	// EventDelete is the deletion of a key, which may not have existed
+	EventDelete
+	// EventDeletePrefix is the deletion of every key starting with the key of the event. Backends
+	// may stream the EventDelete of each deleted key instead.
+	EventDeletePrefix
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventPut:
+		return "put"
+	case EventDelete:
+		return "delete"
+	case EventDeletePrefix:
+		return "delete_prefix"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(t))
+	}
+}
+
+// WatchEvent is a change to a key of a watched keyspace
+type WatchEvent struct {
+	Type EventType
+	Key  string
+	// Value is the value written by EventPut, owned by the watcher
+	Value []byte
+}
+
+// Watchers dispatches the changes to the keyspaces of a broker to their watchers. Brokers
+// implement KVBroker.Watch with it, and publish the changes of their writes once committed,
+// in commit order. The zero value is ready to use.
+type Watchers struct {
+	mu       sync.Mutex
+	watchers map[string]map[*watcher]struct{}
+}
+
+type watcher struct {
+	ch chan WatchEvent
+}
+
+// Watch returns the events of the changes to the keyspace prefix published after it returns.
+// The channel is closed once ctx is done, or once the watcher falls more than WatchBuffer
+// events behind.
+func (w *Watchers) Watch(ctx context.Context, prefix string) (<-chan WatchEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("watch of %s aborted: %w", prefix, context.Cause(ctx))
+	}
+	wt := &watcher{ch: make(chan WatchEvent, WatchBuffer)}
+	w.mu.Lock()
+	if w.watchers == nil {
+		w.watchers = map[string]map[*watcher]struct{}{}
+	}
+	if w.watchers[prefix] == nil {
+		w.watchers[prefix] = map[*watcher]struct{}{}
+	}
+	w.watchers[prefix][wt] = struct{}{}
+	w.mu.Unlock()
+	context.AfterFunc(ctx, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.remove(prefix, wt)
+	})
+	return wt.ch, nil
+}
+
+// remove closes the watch of wt, unless it was already removed. The lock must be held.
+func (w *Watchers) remove(prefix string, wt *watcher) {
+	if _, ok := w.watchers[prefix][wt]; !ok {
+		return
+	}
+	delete(w.watchers[prefix], wt)
+	if len(w.watchers[prefix]) == 0 {
+		delete(w.watchers, prefix)
+	}
+	close(wt.ch)
+}
+
+// Publish sends the events of changes to the keyspace prefix to its watchers, without
+// blocking. The watches of the watchers that fell behind are closed.
+func (w *Watchers) Publish(prefix string, events ...WatchEvent) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for wt := range w.watchers[prefix] {
+		for _, event := range events {
+			event.Value = bytes.Clone(event.Value)
+			select {
+			case wt.ch <- event:
+				continue
+			default:
+			}
+			w.remove(prefix, wt)
+			break
+		}
+	}
+}
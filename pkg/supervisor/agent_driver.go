@@ -20,6 +20,12 @@ type AgentDriver interface {
 	// GetCurrentHash returns the hash of the currently applied configuration.
 	GetCurrentHash() []byte
 
+	// Restart restarts the running agent with its current configuration
+	Restart(ctx context.Context) error
+
+	// UpdateBinary restarts the running agent with the collector binary at path
+	UpdateBinary(ctx context.Context, path string) error
+
 	// Shutdown gracefully stops the running agent
 	Shutdown() error
 }
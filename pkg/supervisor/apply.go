@@ -0,0 +1,58 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"google.golang.org/protobuf/proto"
+)
+
+// ApplyFunc applies a remote config to the managed collector
+type ApplyFunc func(ctx context.Context, incoming *protobufs.AgentRemoteConfig) error
+
+// ApplyMiddleware wraps the next step of the apply pipeline. A middleware can change the
+// config it passes on, run its own steps before or after calling next, or reject the config
+// by returning an error without calling next.
+//
+// Middlewares see every remote config the supervisor receives, including ones the agent
+// driver later skips as unchanged. They should leave the config hash as is, since it is what
+// the applied config is reported as.
+type ApplyMiddleware func(next ApplyFunc) ApplyFunc
+
+// Use appends middlewares to the apply pipeline. The first middleware added is the outermost
+// and the agent driver's Update is always the last step. It must be called before Start.
+func (s *Supervisor) Use(middlewares ...ApplyMiddleware) {
+	s.applyMiddlewares = append(s.applyMiddlewares, middlewares...)
+}
+
+// apply runs incoming through the apply pipeline
+func (s *Supervisor) apply(ctx context.Context, incoming *protobufs.AgentRemoteConfig) error {
+	next := s.agentDriver.Update
+	for i := len(s.applyMiddlewares) - 1; i >= 0; i-- {
+		next = s.applyMiddlewares[i](next)
+	}
+	start := time.Now()
+	err := next(ctx, incoming)
+	s.metrics.observeApply(start, err)
+	return err
+}
+
+// TransformConfigFiles returns a middleware that rewrites the body of each config file,
+// for example to render local variables into it. The received config is left untouched.
+func TransformConfigFiles(fn func(name string, body []byte) ([]byte, error)) ApplyMiddleware {
+	return func(next ApplyFunc) ApplyFunc {
+		return func(ctx context.Context, incoming *protobufs.AgentRemoteConfig) error {
+			transformed := proto.Clone(incoming).(*protobufs.AgentRemoteConfig)
+			for name, file := range transformed.GetConfig().GetConfigMap() {
+				body, err := fn(name, file.GetBody())
+				if err != nil {
+					return fmt.Errorf("transforming config file %s: %w", name, err)
+				}
+				file.Body = body
+			}
+			return next(ctx, transformed)
+		}
+	}
+}
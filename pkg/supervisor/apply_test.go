@@ -0,0 +1,65 @@
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingDriver struct {
+	AgentDriver
+	applied *protobufs.AgentRemoteConfig
+}
+
+func (d *recordingDriver) Update(_ context.Context, incoming *protobufs.AgentRemoteConfig) error {
+	d.applied = incoming
+	return nil
+}
+
+func TestApplyPipeline(t *testing.T) {
+	driver := &recordingDriver{}
+	s := NewSupervisor(slog.Default(), nil, "ws://127.0.0.1:0/v1/opamp", nil, driver, ExtraAttributes{})
+
+	var order []string
+	step := func(name string) ApplyMiddleware {
+		return func(next ApplyFunc) ApplyFunc {
+			return func(ctx context.Context, incoming *protobufs.AgentRemoteConfig) error {
+				order = append(order, name)
+				return next(ctx, incoming)
+			}
+		}
+	}
+	s.Use(step("first"), step("second"))
+	s.Use(TransformConfigFiles(func(_ string, body []byte) ([]byte, error) {
+		return bytes.ReplaceAll(body, []byte("${region}"), []byte("eu-west-1")), nil
+	}))
+
+	incoming := &protobufs.AgentRemoteConfig{
+		ConfigHash: []byte{0x01},
+		Config: &protobufs.AgentConfigMap{ConfigMap: map[string]*protobufs.AgentConfigFile{
+			"config.yaml": {Body: []byte("region: ${region}")},
+		}},
+	}
+	require.NoError(t, s.apply(t.Context(), incoming))
+	assert.Equal(t, []string{"first", "second"}, order)
+	require.NotNil(t, driver.applied)
+	assert.Equal(t, "region: eu-west-1", string(driver.applied.GetConfig().GetConfigMap()["config.yaml"].GetBody()))
+	assert.Equal(t, []byte{0x01}, driver.applied.GetConfigHash())
+	assert.Equal(t, "region: ${region}", string(incoming.GetConfig().GetConfigMap()["config.yaml"].GetBody()))
+
+	// a failing step stops the config from reaching the driver
+	driver.applied = nil
+	s.Use(func(ApplyFunc) ApplyFunc {
+		return func(context.Context, *protobufs.AgentRemoteConfig) error {
+			return errors.New("sidecar unavailable")
+		}
+	})
+	assert.ErrorContains(t, s.apply(t.Context(), incoming), "sidecar unavailable")
+	assert.Nil(t, driver.applied)
+}
@@ -2,4 +2,7 @@ package supervisor
 
 const (
 	AttributeOtelfleetAgentId = "otelfleet.agent.id"
+	// capacity of the agent's host, checked against the resource requirements of configs
+	AttributeHostCPUCount    = "otelfleet.host.cpu.count"
+	AttributeHostMemoryBytes = "otelfleet.host.memory.bytes"
 )
@@ -0,0 +1,60 @@
+package supervisor
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// CommandCapability is the OpAMP custom capability for reporting the result of commands
+	CommandCapability = "io.otelfleet.command"
+	// CommandMessageResult is the custom message type of a v1alpha1.AgentCommandResult
+	CommandMessageResult = "result"
+
+	// CommandRestart is the type of OpAMP restart commands in command results
+	CommandRestart = "restart"
+)
+
+// onCommand carries out a command sent by the server and reports its result
+func (s *Supervisor) onCommand(_ context.Context, command *protobufs.ServerToAgentCommand) error {
+	if command.GetType() != protobufs.CommandType_CommandType_Restart {
+		s.logger.With("type", command.GetType().String()).Warn("ignoring unsupported command")
+		return nil
+	}
+	// waiting for the collector to exit must not block the OpAMP client's message loop
+	go func() {
+		s.logger.Info("restarting the collector as requested by the server")
+		err := s.agentDriver.Restart(context.Background())
+		if err != nil {
+			s.logger.With("err", err).Error("failed to restart the collector")
+		}
+		s.sendCommandResult(CommandRestart, err)
+	}()
+	return nil
+}
+
+// sendCommandResult reports the outcome of a command to the server
+func (s *Supervisor) sendCommandResult(commandType string, commandErr error) {
+	result := &v1alpha1.AgentCommandResult{
+		Type:      commandType,
+		Succeeded: commandErr == nil,
+	}
+	if commandErr != nil {
+		result.ErrorMessage = commandErr.Error()
+	}
+	data, err := proto.Marshal(result)
+	if err != nil {
+		s.logger.With("err", err).Error("failed to encode command result")
+		return
+	}
+	if err := s.sendCustomMessage(&protobufs.CustomMessage{
+		Capability: CommandCapability,
+		Type:       CommandMessageResult,
+		Data:       data,
+	}); err != nil {
+		s.logger.With("err", err, "command", commandType).Error("failed to send command result")
+	}
+}
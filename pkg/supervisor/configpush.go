@@ -0,0 +1,76 @@
+package supervisor
+
+import (
+	"errors"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// ConfigPushCapability is the OpAMP custom capability for remote config delivery receipts
+	ConfigPushCapability = "io.otelfleet.configpush"
+	// ConfigPushMessageOffer is the custom message type of a v1alpha1.ConfigPushOffer,
+	// sent in the same message as the remote config it identifies
+	ConfigPushMessageOffer = "offer"
+	// ConfigPushMessageReceipt is the custom message type of a v1alpha1.ConfigPushReceipt
+	ConfigPushMessageReceipt = "receipt"
+)
+
+// configPushID returns the push ID of a config push offer, if msg is one
+func (s *Supervisor) configPushID(msg *protobufs.CustomMessage) (string, bool) {
+	if msg.GetCapability() != ConfigPushCapability || msg.GetType() != ConfigPushMessageOffer {
+		return "", false
+	}
+	offer := &v1alpha1.ConfigPushOffer{}
+	if err := proto.Unmarshal(msg.GetData(), offer); err != nil {
+		s.logger.With("err", err).Error("failed to decode config push offer")
+		return "", true
+	}
+	return offer.GetPushId(), true
+}
+
+// sendConfigPushReceipt reports the outcome of applying the remote config of a push.
+// It is a no-op if the config was not pushed with an ID.
+func (s *Supervisor) sendConfigPushReceipt(pushID string, applyErr error) {
+	if pushID == "" {
+		return
+	}
+	receipt := &v1alpha1.ConfigPushReceipt{
+		PushId:  pushID,
+		Applied: applyErr == nil,
+	}
+	if applyErr != nil {
+		receipt.ErrorMessage = applyErr.Error()
+	}
+	data, err := proto.Marshal(receipt)
+	if err != nil {
+		s.logger.With("err", err).Error("failed to encode config push receipt")
+		return
+	}
+	// waiting for another custom message to be sent must not block the OpAMP client's message loop
+	go func() {
+		if err := s.sendCustomMessage(&protobufs.CustomMessage{
+			Capability: ConfigPushCapability,
+			Type:       ConfigPushMessageReceipt,
+			Data:       data,
+		}); err != nil {
+			s.logger.With("err", err, "push_id", pushID).Error("failed to send config push receipt")
+		}
+	}()
+}
+
+// sendCustomMessage sends msg once the custom message in flight, if any, was sent
+func (s *Supervisor) sendCustomMessage(msg *protobufs.CustomMessage) error {
+	for {
+		sent, err := s.client().SendCustomMessage(msg)
+		if errors.Is(err, types.ErrCustomMessagePending) {
+			// only one custom message can be in flight at a time
+			<-sent
+			continue
+		}
+		return err
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package supervisor
+
+// freeBytes is not implemented on this platform, so the disk space check is skipped
+func freeBytes(string) (uint64, bool, error) {
+	return 0, false, nil
+}
@@ -0,0 +1,140 @@
+package supervisor
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HealthStatus is the supervisor's own health, as served on /healthz.
+// It is independent of the health of the managed collector.
+type HealthStatus struct {
+	Healthy   bool        `json:"healthy"`
+	StartTime time.Time   `json:"start_time"`
+	Uptime    string      `json:"uptime"`
+	OpAMP     OpAMPStatus `json:"opamp"`
+}
+
+// OpAMPStatus describes the supervisor's connection to the OpAMP server.
+type OpAMPStatus struct {
+	Connected bool `json:"connected"`
+	// LastContact is the last time the server was successfully reached, if ever
+	LastContact *time.Time `json:"last_contact,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	// ClientRestarts is the number of times the watchdog restarted the OpAMP client
+	ClientRestarts int `json:"client_restarts"`
+}
+
+// connectionTracker records the state of the OpAMP connection from client callbacks
+type connectionTracker struct {
+	mu          sync.Mutex
+	connected   bool
+	lastContact time.Time
+	lastError   string
+	// lastActive is the last time the client showed it is making progress, by
+	// reaching the server or failing to connect to it
+	lastActive time.Time
+	restarts   int
+	connects   int
+}
+
+func (c *connectionTracker) onClientStart(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastActive = now
+}
+
+func (c *connectionTracker) onClientRestart() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.connected = false
+	c.restarts++
+}
+
+// onConnect records a connection to the server and returns whether the supervisor
+// had connected before
+func (c *connectionTracker) onConnect(now time.Time) (reconnected bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	reconnected = c.connects > 0
+	c.connects++
+	c.connected = true
+	c.lastContact = now
+	c.lastActive = now
+	c.lastError = ""
+	return reconnected
+}
+
+func (c *connectionTracker) onContact(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastContact = now
+	c.lastActive = now
+}
+
+func (c *connectionTracker) onConnectFailed(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.connected = false
+	c.lastError = err.Error()
+	c.lastActive = time.Now()
+}
+
+func (c *connectionTracker) lastActivity() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastActive
+}
+
+func (c *connectionTracker) status() OpAMPStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	st := OpAMPStatus{
+		Connected:      c.connected,
+		LastError:      c.lastError,
+		ClientRestarts: c.restarts,
+	}
+	if !c.lastContact.IsZero() {
+		lastContact := c.lastContact
+		st.LastContact = &lastContact
+	}
+	return st
+}
+
+// Health returns the supervisor's health. The supervisor is unhealthy if maxContactAge
+// is positive and the OpAMP server has not been reached within it since the supervisor started.
+func (s *Supervisor) Health(maxContactAge time.Duration) HealthStatus {
+	now := time.Now()
+	opamp := s.conn.status()
+	healthy := true
+	if maxContactAge > 0 {
+		lastContact := s.startTime
+		if opamp.LastContact != nil {
+			lastContact = *opamp.LastContact
+		}
+		healthy = now.Sub(lastContact) <= maxContactAge
+	}
+	return HealthStatus{
+		Healthy:   healthy,
+		StartTime: s.startTime,
+		Uptime:    now.Sub(s.startTime).Round(time.Second).String(),
+		OpAMP:     opamp,
+	}
+}
+
+// HealthHandler serves the supervisor's health as JSON, responding with
+// 503 Service Unavailable when it is unhealthy so that liveness probes
+// and watchdogs can restart the supervisor.
+func (s *Supervisor) HealthHandler(maxContactAge time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health := s.Health(maxContactAge)
+		w.Header().Set("Content-Type", "application/json")
+		if !health.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(health); err != nil {
+			s.logger.With("err", err).Warn("failed to write health response")
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package supervisor
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler(t *testing.T) {
+	s := NewSupervisor(slog.Default(), nil, "ws://127.0.0.1:0/v1/opamp", nil, nil, ExtraAttributes{})
+	s.startTime = time.Now().Add(-time.Hour)
+
+	get := func(maxContactAge time.Duration) (int, HealthStatus) {
+		rec := httptest.NewRecorder()
+		s.HealthHandler(maxContactAge).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		var health HealthStatus
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&health))
+		return rec.Code, health
+	}
+
+	// never reached the server
+	s.conn.onConnectFailed(errors.New("connection refused"))
+	code, health := get(0)
+	assert.Equal(t, http.StatusOK, code, "staleness is only checked when a max contact age is set")
+	assert.False(t, health.OpAMP.Connected)
+	assert.Nil(t, health.OpAMP.LastContact)
+	assert.Equal(t, "connection refused", health.OpAMP.LastError)
+
+	code, health = get(time.Minute)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, health.Healthy)
+
+	// recent contact
+	s.conn.onConnect(time.Now())
+	code, health = get(time.Minute)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, health.Healthy)
+	assert.True(t, health.OpAMP.Connected)
+	assert.Empty(t, health.OpAMP.LastError)
+	require.NotNil(t, health.OpAMP.LastContact)
+
+	// stale contact
+	s.conn.onContact(time.Now().Add(-2 * time.Minute))
+	code, _ = get(time.Minute)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
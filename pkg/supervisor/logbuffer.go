@@ -0,0 +1,91 @@
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// defaultLogBufferSize is the amount of recent supervisor logs kept for snapshots
+const defaultLogBufferSize = 1 << 20
+
+// logBuffer retains the most recent log output, up to a fixed number of bytes.
+type logBuffer struct {
+	mu    sync.Mutex
+	size  int
+	lines [][]byte
+	total int
+}
+
+func newLogBuffer(size int) *logBuffer {
+	return &logBuffer{size: size}
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	line := bytes.Clone(p)
+	b.lines = append(b.lines, line)
+	b.total += len(line)
+	for b.total > b.size && len(b.lines) > 0 {
+		b.total -= len(b.lines[0])
+		b.lines = b.lines[1:]
+	}
+	return len(p), nil
+}
+
+// tail returns at most n bytes of the most recent whole lines, and whether
+// all buffered lines fit
+func (b *logBuffer) tail(n int) ([]byte, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	start, size := len(b.lines), 0
+	for start > 0 && size+len(b.lines[start-1]) <= n {
+		start--
+		size += len(b.lines[start])
+	}
+	return bytes.Join(b.lines[start:], nil), start == 0
+}
+
+// teeHandler sends log records to both the supervisor's handler and its log buffer
+type teeHandler struct {
+	primary slog.Handler
+	buffer  slog.Handler
+}
+
+func newTeeLogger(logger *slog.Logger, buf *logBuffer) *slog.Logger {
+	return slog.New(&teeHandler{
+		primary: logger.Handler(),
+		buffer:  slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	})
+}
+
+func (t *teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return t.primary.Enabled(ctx, level) || t.buffer.Enabled(ctx, level)
+}
+
+func (t *teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var err error
+	if t.primary.Enabled(ctx, r.Level) {
+		err = t.primary.Handle(ctx, r.Clone())
+	}
+	if t.buffer.Enabled(ctx, r.Level) {
+		_ = t.buffer.Handle(ctx, r)
+	}
+	return err
+}
+
+func (t *teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &teeHandler{
+		primary: t.primary.WithAttrs(attrs),
+		buffer:  t.buffer.WithAttrs(attrs),
+	}
+}
+
+func (t *teeHandler) WithGroup(name string) slog.Handler {
+	return &teeHandler{
+		primary: t.primary.WithGroup(name),
+		buffer:  t.buffer.WithGroup(name),
+	}
+}
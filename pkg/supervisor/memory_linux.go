@@ -0,0 +1,37 @@
+//go:build linux
+
+package supervisor
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// totalMemoryBytes returns the physical memory of the host, as reported by /proc/meminfo
+func totalMemoryBytes() (uint64, bool, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, false, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// MemTotal:       16314736 kB
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid MemTotal in /proc/meminfo: %w", err)
+		}
+		return kb * 1024, true, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, false, err
+	}
+	return 0, false, nil
+}
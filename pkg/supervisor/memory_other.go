@@ -0,0 +1,8 @@
+//go:build !linux
+
+package supervisor
+
+// totalMemoryBytes is not implemented on this platform, so the host memory is not reported
+func totalMemoryBytes() (uint64, bool, error) {
+	return 0, false, nil
+}
@@ -0,0 +1,91 @@
+package supervisor
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// supervisorMetrics describe the supervisor's own behavior, so that it can be scraped on the
+// node independently of the server. They are kept in a registry of their own, separate from
+// the default registry of the process embedding the supervisor.
+type supervisorMetrics struct {
+	registry *prometheus.Registry
+
+	configApplies       prometheus.Counter
+	configApplyFailures prometheus.Counter
+	configApplyDuration prometheus.Histogram
+	lastSync            prometheus.Gauge
+	collectorRestarts   prometheus.Counter
+	opampReconnects     prometheus.Counter
+	// reconnects whose first report omitted the effective config
+	opampCompactReconnects prometheus.Counter
+	opampClientRestarts    prometheus.Counter
+}
+
+func newSupervisorMetrics() *supervisorMetrics {
+	m := &supervisorMetrics{
+		registry: prometheus.NewRegistry(),
+		configApplies: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "otelfleet_supervisor_config_applies_total",
+			Help: "Remote configs the supervisor attempted to apply.",
+		}),
+		configApplyFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "otelfleet_supervisor_config_apply_failures_total",
+			Help: "Remote configs the supervisor failed to apply.",
+		}),
+		configApplyDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "otelfleet_supervisor_config_apply_duration_seconds",
+			Help:    "Time taken to apply remote configs, including restarting the collector.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		lastSync: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "otelfleet_supervisor_last_config_sync_timestamp_seconds",
+			Help: "Unix time the remote config was last applied successfully.",
+		}),
+		collectorRestarts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "otelfleet_supervisor_collector_restarts_total",
+			Help: "Times the managed collector was restarted.",
+		}),
+		opampReconnects: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "otelfleet_supervisor_opamp_reconnects_total",
+			Help: "Times the supervisor reconnected to the OpAMP server after its first connection.",
+		}),
+		opampCompactReconnects: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "otelfleet_supervisor_opamp_compact_reconnects_total",
+			Help: "Reconnects that skipped resending the effective config already acknowledged by the server.",
+		}),
+		opampClientRestarts: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "otelfleet_supervisor_opamp_client_restarts_total",
+			Help: "Times the watchdog restarted an unresponsive OpAMP client.",
+		}),
+	}
+	m.registry.MustRegister(
+		m.configApplies,
+		m.configApplyFailures,
+		m.configApplyDuration,
+		m.lastSync,
+		m.collectorRestarts,
+		m.opampReconnects,
+		m.opampCompactReconnects,
+		m.opampClientRestarts,
+	)
+	return m
+}
+
+func (m *supervisorMetrics) observeApply(start time.Time, err error) {
+	m.configApplies.Inc()
+	m.configApplyDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.configApplyFailures.Inc()
+		return
+	}
+	m.lastSync.SetToCurrentTime()
+}
+
+// MetricsHandler serves the supervisor's metrics in the Prometheus exposition format
+func (s *Supervisor) MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(s.metrics.registry, promhttp.HandlerOpts{})
+}
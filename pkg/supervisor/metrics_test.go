@@ -0,0 +1,52 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	driver := &recordingDriver{}
+	s := NewSupervisor(slog.Default(), nil, "ws://127.0.0.1:0/v1/opamp", nil, driver, ExtraAttributes{})
+
+	require.NoError(t, s.apply(t.Context(), &protobufs.AgentRemoteConfig{ConfigHash: []byte{0x01}}))
+	lastSync := promtestutil.ToFloat64(s.metrics.lastSync)
+	assert.InDelta(t, float64(time.Now().Unix()), lastSync, 5)
+
+	s.Use(func(ApplyFunc) ApplyFunc {
+		return func(context.Context, *protobufs.AgentRemoteConfig) error {
+			return errors.New("sidecar unavailable")
+		}
+	})
+	require.Error(t, s.apply(t.Context(), &protobufs.AgentRemoteConfig{ConfigHash: []byte{0x02}}))
+	assert.Equal(t, 2.0, promtestutil.ToFloat64(s.metrics.configApplies))
+	assert.Equal(t, 1.0, promtestutil.ToFloat64(s.metrics.configApplyFailures))
+	assert.Equal(t, lastSync, promtestutil.ToFloat64(s.metrics.lastSync), "failed applies don't count as a sync")
+
+	assert.False(t, s.conn.onConnect(time.Now()))
+	assert.True(t, s.conn.onConnect(time.Now()))
+
+	rec := httptest.NewRecorder()
+	s.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	for _, name := range []string{
+		"otelfleet_supervisor_config_applies_total 2",
+		"otelfleet_supervisor_config_apply_failures_total 1",
+		"otelfleet_supervisor_config_apply_duration_seconds_count 2",
+		"otelfleet_supervisor_last_config_sync_timestamp_seconds",
+		"otelfleet_supervisor_collector_restarts_total 0",
+		"otelfleet_supervisor_opamp_reconnects_total 0",
+	} {
+		assert.Contains(t, rec.Body.String(), name)
+	}
+}
@@ -0,0 +1,295 @@
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/natefinch/atomic"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// CollectorPackage is the name of the OpAMP package holding the collector binary
+const CollectorPackage = "collector"
+
+// packageStateFile holds the state of the package store, in its directory
+const packageStateFile = "packages.json"
+
+// PackageStore keeps the packages offered by the server in a directory, so that they survive
+// restarts of the supervisor. Only top-level packages are supported: the content of the
+// collector package is the collector binary, installed with OnInstall once verified.
+type PackageStore struct {
+	dir string
+	// optional, the key the signatures of package contents are verified with
+	verifyKey ed25519.PublicKey
+	// OnInstall is called with the path of each new collector binary
+	OnInstall func(ctx context.Context, path string) error
+
+	mu    sync.Mutex
+	state packageStoreState
+}
+
+type packageStoreState struct {
+	AllPackagesHash []byte                    `json:"all_packages_hash,omitempty"`
+	Packages        map[string]*storedPackage `json:"packages,omitempty"`
+	// protojson encoded PackageStatuses
+	LastReportedStatuses json.RawMessage `json:"last_reported_statuses,omitempty"`
+}
+
+type storedPackage struct {
+	Type        protobufs.PackageType `json:"type"`
+	Hash        []byte                `json:"hash,omitempty"`
+	Version     string                `json:"version,omitempty"`
+	ContentHash []byte                `json:"content_hash,omitempty"`
+	// name of the file holding the content, in the store directory
+	File string `json:"file,omitempty"`
+}
+
+var _ types.PackagesStateProvider = (*PackageStore)(nil)
+
+// NewPackageStore opens the package store in dir, creating it if needed. If verifyKey is set,
+// package contents must be signed with its private key.
+func NewPackageStore(dir string, verifyKey ed25519.PublicKey) (*PackageStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create package directory: %w", err)
+	}
+	p := &PackageStore{
+		dir:       dir,
+		verifyKey: verifyKey,
+		state:     packageStoreState{Packages: map[string]*storedPackage{}},
+	}
+	data, err := os.ReadFile(filepath.Join(dir, packageStateFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read package state: %w", err)
+	}
+	if err := json.Unmarshal(data, &p.state); err != nil {
+		return nil, fmt.Errorf("failed to decode package state: %w", err)
+	}
+	if p.state.Packages == nil {
+		p.state.Packages = map[string]*storedPackage{}
+	}
+	return p, nil
+}
+
+// InstalledCollector returns the path of the installed collector binary, or an empty string
+// if none was installed
+func (p *PackageStore) InstalledCollector() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pkg, ok := p.state.Packages[CollectorPackage]; ok && pkg.File != "" {
+		return filepath.Join(p.dir, pkg.File)
+	}
+	return ""
+}
+
+func (p *PackageStore) saveLocked() error {
+	data, err := json.Marshal(p.state)
+	if err != nil {
+		return err
+	}
+	return atomic.WriteFile(filepath.Join(p.dir, packageStateFile), bytes.NewReader(data))
+}
+
+func (p *PackageStore) AllPackagesHash() ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state.AllPackagesHash, nil
+}
+
+func (p *PackageStore) SetAllPackagesHash(hash []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.AllPackagesHash = hash
+	return p.saveLocked()
+}
+
+func (p *PackageStore) Packages() ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	names := make([]string, 0, len(p.state.Packages))
+	for name := range p.state.Packages {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (p *PackageStore) PackageState(packageName string) (types.PackageState, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return types.PackageState{}, nil
+	}
+	return types.PackageState{
+		Exists:  true,
+		Type:    pkg.Type,
+		Hash:    pkg.Hash,
+		Version: pkg.Version,
+	}, nil
+}
+
+func (p *PackageStore) SetPackageState(packageName string, state types.PackageState) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return fmt.Errorf("package %s does not exist", packageName)
+	}
+	if pkg.Type != state.Type {
+		return fmt.Errorf("package %s is of type %s", packageName, pkg.Type)
+	}
+	pkg.Hash = state.Hash
+	pkg.Version = state.Version
+	return p.saveLocked()
+}
+
+func (p *PackageStore) CreatePackage(packageName string, typ protobufs.PackageType) error {
+	if typ != protobufs.PackageType_PackageType_TopLevel {
+		return fmt.Errorf("unsupported type of package %s: %s", packageName, typ)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.state.Packages[packageName]; ok {
+		return fmt.Errorf("package %s already exists", packageName)
+	}
+	p.state.Packages[packageName] = &storedPackage{Type: typ}
+	return p.saveLocked()
+}
+
+func (p *PackageStore) FileContentHash(packageName string) ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pkg, ok := p.state.Packages[packageName]; ok {
+		return pkg.ContentHash, nil
+	}
+	return nil, nil
+}
+
+// contextReader stops reading once its context is cancelled
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(b []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(b)
+}
+
+// UpdateContent writes the content of the package once it is verified against its content
+// hash and, if the store has a verification key, its signature of the content hash.
+// New collector binaries are installed before the previous binary is removed.
+func (p *PackageStore) UpdateContent(ctx context.Context, packageName string, data io.Reader, contentHash, signature []byte) error {
+	f, err := os.CreateTemp(p.dir, packageName+"-*.download")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	digest := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, digest), contextReader{ctx: ctx, r: data})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write package content: %w", err)
+	}
+	if !bytes.Equal(digest.Sum(nil), contentHash) {
+		return errors.New("package content does not match its content hash")
+	}
+	if p.verifyKey != nil && !ed25519.Verify(p.verifyKey, contentHash, signature) {
+		return errors.New("invalid package signature")
+	}
+
+	file := packageName + "-" + hex.EncodeToString(contentHash)
+	path := filepath.Join(p.dir, file)
+	if err := os.Chmod(f.Name(), 0755); err != nil {
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+	if packageName == CollectorPackage && p.OnInstall != nil {
+		if err := p.OnInstall(ctx, path); err != nil {
+			if path != p.InstalledCollector() {
+				_ = os.Remove(path)
+			}
+			return fmt.Errorf("failed to install collector: %w", err)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return fmt.Errorf("package %s does not exist", packageName)
+	}
+	previous := pkg.File
+	pkg.File = file
+	pkg.ContentHash = contentHash
+	if err := p.saveLocked(); err != nil {
+		return err
+	}
+	if previous != "" && previous != file {
+		_ = os.Remove(filepath.Join(p.dir, previous))
+	}
+	return nil
+}
+
+// DeletePackage deletes a package, except for the collector package whose binary is running
+func (p *PackageStore) DeletePackage(packageName string) error {
+	if packageName == CollectorPackage {
+		return errors.New("the collector package can't be deleted")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pkg, ok := p.state.Packages[packageName]
+	if !ok {
+		return nil
+	}
+	if pkg.File != "" {
+		if err := os.Remove(filepath.Join(p.dir, pkg.File)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	delete(p.state.Packages, packageName)
+	return p.saveLocked()
+}
+
+func (p *PackageStore) LastReportedStatuses() (*protobufs.PackageStatuses, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.state.LastReportedStatuses) == 0 {
+		return nil, nil
+	}
+	statuses := &protobufs.PackageStatuses{}
+	if err := protojson.Unmarshal(p.state.LastReportedStatuses, statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+func (p *PackageStore) SetLastReportedStatuses(statuses *protobufs.PackageStatuses) error {
+	data, err := protojson.Marshal(statuses)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.state.LastReportedStatuses = data
+	return p.saveLocked()
+}
@@ -0,0 +1,80 @@
+package supervisor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageStore(t *testing.T) {
+	dir := t.TempDir()
+	pub, key, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	store, err := NewPackageStore(dir, pub)
+	require.NoError(t, err)
+	assert.Empty(t, store.InstalledCollector())
+
+	var installed []string
+	store.OnInstall = func(_ context.Context, path string) error {
+		installed = append(installed, path)
+		return nil
+	}
+	require.NoError(t, store.CreatePackage(CollectorPackage, protobufs.PackageType_PackageType_TopLevel))
+	assert.Error(t, store.CreatePackage("addon", protobufs.PackageType_PackageType_Addon))
+
+	content := []byte("otelcol")
+	digest := sha256.Sum256(content)
+	err = store.UpdateContent(t.Context(), CollectorPackage, bytes.NewReader([]byte("tampered")), digest[:], ed25519.Sign(key, digest[:]))
+	assert.ErrorContains(t, err, "does not match its content hash")
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	err = store.UpdateContent(t.Context(), CollectorPackage, bytes.NewReader(content), digest[:], ed25519.Sign(otherKey, digest[:]))
+	assert.ErrorContains(t, err, "invalid package signature")
+	assert.Empty(t, installed)
+
+	require.NoError(t, store.UpdateContent(t.Context(), CollectorPackage, bytes.NewReader(content), digest[:], ed25519.Sign(key, digest[:])))
+	require.NoError(t, store.SetPackageState(CollectorPackage, types.PackageState{
+		Exists:  true,
+		Type:    protobufs.PackageType_PackageType_TopLevel,
+		Hash:    []byte("hash"),
+		Version: "0.120.0",
+	}))
+	require.Len(t, installed, 1)
+	assert.Equal(t, installed[0], store.InstalledCollector())
+	data, err := os.ReadFile(installed[0])
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+	assert.Error(t, store.DeletePackage(CollectorPackage))
+
+	// a failed install keeps the previous binary
+	store.OnInstall = func(context.Context, string) error { return errors.New("collector failed to start") }
+	next := []byte("otelcol-next")
+	nextDigest := sha256.Sum256(next)
+	err = store.UpdateContent(t.Context(), CollectorPackage, bytes.NewReader(next), nextDigest[:], ed25519.Sign(key, nextDigest[:]))
+	assert.ErrorContains(t, err, "collector failed to start")
+	assert.Equal(t, installed[0], store.InstalledCollector())
+	entries, err := filepath.Glob(filepath.Join(dir, CollectorPackage+"-*"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{installed[0]}, entries)
+
+	reopened, err := NewPackageStore(dir, pub)
+	require.NoError(t, err)
+	assert.Equal(t, installed[0], reopened.InstalledCollector())
+	state, err := reopened.PackageState(CollectorPackage)
+	require.NoError(t, err)
+	assert.True(t, state.Exists)
+	assert.Equal(t, "0.120.0", state.Version)
+	hash, err := reopened.FileContentHash(CollectorPackage)
+	require.NoError(t, err)
+	assert.Equal(t, digest[:], hash)
+}
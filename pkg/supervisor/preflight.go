@@ -0,0 +1,84 @@
+package supervisor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/util"
+)
+
+// DefaultMinFreeBytes is the free space that must remain in the config directory
+// after a remote config is written
+const DefaultMinFreeBytes = 10 << 20
+
+const (
+	PreflightCheckConfigName = "config_name"
+	PreflightCheckWritable   = "writable"
+	PreflightCheckDiskSpace  = "disk_space"
+)
+
+// PreflightError is returned when a remote config is rejected before any file is
+// written, so the running collector is left untouched
+type PreflightError struct {
+	// Check is the preflight check that failed, one of the PreflightCheck* constants
+	Check string
+	// Path is the file or directory the check failed for
+	Path string
+	Err  error
+}
+
+func (e *PreflightError) Error() string {
+	return fmt.Sprintf("preflight check %q failed for %s: %s", e.Check, e.Path, e.Err)
+}
+
+func (e *PreflightError) Unwrap() error {
+	return e.Err
+}
+
+// preflightConfigWrite checks that configMap can be written to dir : every file name
+// stays inside dir, dir is writable and enough space is left afterwards
+func preflightConfigWrite(dir string, configMap map[string]*protobufs.AgentConfigFile, minFreeBytes uint64) error {
+	var required uint64
+	for name, file := range configMap {
+		if err := validateConfigName(name); err != nil {
+			return &PreflightError{Check: PreflightCheckConfigName, Path: name, Err: err}
+		}
+		required += uint64(len(file.GetBody()))
+	}
+
+	f, err := os.CreateTemp(dir, ".preflight-*")
+	if err != nil {
+		return &PreflightError{Check: PreflightCheckWritable, Path: dir, Err: err}
+	}
+	f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		return &PreflightError{Check: PreflightCheckWritable, Path: dir, Err: err}
+	}
+
+	free, ok, err := freeBytes(dir)
+	if err != nil {
+		return &PreflightError{Check: PreflightCheckDiskSpace, Path: dir, Err: err}
+	}
+	if ok && free < required+minFreeBytes {
+		return &PreflightError{
+			Check: PreflightCheckDiskSpace,
+			Path:  dir,
+			Err: fmt.Errorf(
+				"%d bytes free, need %d bytes for configs and %d bytes headroom",
+				free, required, minFreeBytes,
+			),
+		}
+	}
+	return nil
+}
+
+// validateConfigName rejects config file names from the server that can't be safely
+// written to the config directory, or that would overwrite the hash file
+func validateConfigName(name string) error {
+	if name == "config.hash" {
+		return errors.New("file name is reserved")
+	}
+	return util.ValidateConfigFileName(name)
+}
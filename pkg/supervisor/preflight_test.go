@@ -0,0 +1,69 @@
+package supervisor
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func configFiles(names ...string) map[string]*protobufs.AgentConfigFile {
+	files := map[string]*protobufs.AgentConfigFile{}
+	for _, name := range names {
+		files[name] = &protobufs.AgentConfigFile{Body: []byte("receivers: {}")}
+	}
+	return files
+}
+
+func requirePreflightError(t *testing.T, err error, check string) {
+	t.Helper()
+	var preflightErr *PreflightError
+	require.True(t, errors.As(err, &preflightErr), "expected a preflight error, got %v", err)
+	assert.Equal(t, check, preflightErr.Check)
+}
+
+func TestPreflightConfigWrite(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, preflightConfigWrite(dir, configFiles("config.yaml", "extra.yaml"), DefaultMinFreeBytes))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "preflight should not leave files behind")
+
+	for _, name := range []string{"", ".", "..", "../config.yaml", "../../etc/cron.d/x", "/etc/passwd", "sub/config.yaml", `..\config.yaml`, "config.hash"} {
+		t.Run(name, func(t *testing.T) {
+			err := preflightConfigWrite(dir, configFiles("config.yaml", name), DefaultMinFreeBytes)
+			requirePreflightError(t, err, PreflightCheckConfigName)
+		})
+	}
+
+	err = preflightConfigWrite(filepath.Join(dir, "missing"), configFiles("config.yaml"), DefaultMinFreeBytes)
+	requirePreflightError(t, err, PreflightCheckWritable)
+
+	if runtime.GOOS == "linux" {
+		err = preflightConfigWrite(dir, configFiles("config.yaml"), 1<<62)
+		requirePreflightError(t, err, PreflightCheckDiskSpace)
+	}
+}
+
+func TestProcManagerRejectsConfigBeforeWriting(t *testing.T) {
+	dir := t.TempDir()
+	p := NewProcManager(slog.Default(), "otelcol", dir, nil)
+
+	err := p.Update(t.Context(), &protobufs.AgentRemoteConfig{
+		Config:     &protobufs.AgentConfigMap{ConfigMap: configFiles("config.yaml", "../escape.yaml")},
+		ConfigHash: []byte{0x01},
+	})
+	requirePreflightError(t, err, PreflightCheckConfigName)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(dir), "escape.yaml"))
+	assert.Empty(t, p.GetCurrentHash())
+}
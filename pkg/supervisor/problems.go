@@ -0,0 +1,176 @@
+package supervisor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// ProblemCapability is the OpAMP custom capability for reporting problems the supervisor
+	// can't recover from by itself
+	ProblemCapability = "io.otelfleet.problem"
+	// ProblemMessageReport is the custom message type of a v1alpha1.AgentProblemReport
+	ProblemMessageReport = "report"
+
+	// ConfigFailureThreshold is the number of consecutive failed config applies reported as a problem
+	ConfigFailureThreshold = 3
+	// CrashLoopThreshold is the number of unexpected collector exits within CrashLoopWindow
+	// reported as a crash loop
+	CrashLoopThreshold = 3
+	CrashLoopWindow    = 10 * time.Minute
+	// DefaultLowDiskFreeBytes is the free space of the config directory below which the disk is
+	// reported as nearly full, well before config writes start failing preflight checks
+	DefaultLowDiskFreeBytes = 20 * DefaultMinFreeBytes
+
+	diskCheckInterval = time.Minute
+)
+
+// problemTracker detects the problems reported to the server. Each problem is reported once
+// when it starts, and again only after it cleared and recurred.
+type problemTracker struct {
+	mu sync.Mutex
+
+	configFailures int
+	// unexpected collector exits within the crash loop window, oldest first
+	exits       []time.Time
+	crashLooped bool
+	diskLow     bool
+}
+
+// onConfigApply records the outcome of applying a remote config, and returns the report to
+// send if the config failed to apply too many times in a row
+func (t *problemTracker) onConfigApply(applyErr error) *v1alpha1.AgentProblemReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if applyErr == nil {
+		t.configFailures = 0
+		return nil
+	}
+	t.configFailures++
+	if t.configFailures != ConfigFailureThreshold {
+		return nil
+	}
+	return &v1alpha1.AgentProblemReport{
+		Type:        v1alpha1.AgentProblemType_AGENT_PROBLEM_TYPE_CONFIG_APPLY_FAILING,
+		Message:     fmt.Sprintf("remote config failed to apply %d times in a row: %s", t.configFailures, applyErr),
+		Occurrences: int32(t.configFailures),
+	}
+}
+
+// onCollectorExit records an unexpected exit of the collector, and returns the report to send
+// if the collector exited too many times within the crash loop window
+func (t *problemTracker) onCollectorExit(now time.Time, exitErr error) *v1alpha1.AgentProblemReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	cutoff := now.Add(-CrashLoopWindow)
+	i := 0
+	for i < len(t.exits) && !t.exits[i].After(cutoff) {
+		i++
+	}
+	t.exits = append(t.exits[i:], now)
+	if len(t.exits) < CrashLoopThreshold {
+		t.crashLooped = false
+		return nil
+	}
+	if t.crashLooped {
+		return nil
+	}
+	t.crashLooped = true
+	message := fmt.Sprintf("collector exited %d times within %s", len(t.exits), CrashLoopWindow)
+	if exitErr != nil {
+		message += ": " + exitErr.Error()
+	}
+	return &v1alpha1.AgentProblemReport{
+		Type:        v1alpha1.AgentProblemType_AGENT_PROBLEM_TYPE_COLLECTOR_CRASH_LOOP,
+		Message:     message,
+		Occurrences: int32(len(t.exits)),
+	}
+}
+
+// onDiskSpace records the free space of the config directory, and returns the report to send
+// if it fell below lowFreeBytes
+func (t *problemTracker) onDiskSpace(dir string, free, lowFreeBytes uint64) *v1alpha1.AgentProblemReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	low := free < lowFreeBytes
+	report := low && !t.diskLow
+	t.diskLow = low
+	if !report {
+		return nil
+	}
+	return &v1alpha1.AgentProblemReport{
+		Type:        v1alpha1.AgentProblemType_AGENT_PROBLEM_TYPE_DISK_NEARLY_FULL,
+		Message:     fmt.Sprintf("%d bytes free in %s, below %d bytes", free, dir, lowFreeBytes),
+		Occurrences: 1,
+		Details: map[string]string{
+			"path":       dir,
+			"free_bytes": strconv.FormatUint(free, 10),
+		},
+	}
+}
+
+// SetLowDiskFreeBytes sets the free space of the config directory below which the disk is
+// reported as nearly full. It must be called before Start; 0 disables the check.
+func (s *Supervisor) SetLowDiskFreeBytes(n uint64) {
+	s.lowDiskFreeBytes = n
+}
+
+// runDiskCheck periodically checks the free space of the config directory of the agent driver
+func (s *Supervisor) runDiskCheck(ctx context.Context, dir string) {
+	t := time.NewTicker(diskCheckInterval)
+	defer t.Stop()
+	for {
+		s.checkDiskSpace(dir)
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+func (s *Supervisor) checkDiskSpace(dir string) {
+	free, ok, err := freeBytes(dir)
+	if err != nil {
+		s.logger.With("err", err, "dir", dir).Warn("failed to check free disk space")
+		return
+	}
+	if ok {
+		s.sendProblem(s.problems.onDiskSpace(dir, free, s.lowDiskFreeBytes))
+	}
+}
+
+// onCollectorExit is called when the collector exits without being asked to
+func (s *Supervisor) onCollectorExit(err error) {
+	s.sendProblem(s.problems.onCollectorExit(time.Now(), err))
+}
+
+// sendProblem reports a problem to the server in the background. It is a no-op if report is nil.
+func (s *Supervisor) sendProblem(report *v1alpha1.AgentProblemReport) {
+	if report == nil {
+		return
+	}
+	s.logger.With("type", report.GetType().String(), "message", report.GetMessage()).Warn("reporting problem to the server")
+	data, err := proto.Marshal(report)
+	if err != nil {
+		s.logger.With("err", err).Error("failed to encode problem report")
+		return
+	}
+	// waiting for another custom message to be sent must not block the caller
+	go func() {
+		if err := s.sendCustomMessage(&protobufs.CustomMessage{
+			Capability: ProblemCapability,
+			Type:       ProblemMessageReport,
+			Data:       data,
+		}); err != nil {
+			s.logger.With("err", err, "type", report.GetType().String()).Error("failed to send problem report")
+		}
+	}()
+}
@@ -0,0 +1,79 @@
+package supervisor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblemTracker_ConfigApply(t *testing.T) {
+	var tracker problemTracker
+	applyErr := errors.New("invalid pipeline")
+
+	for range ConfigFailureThreshold - 1 {
+		assert.Nil(t, tracker.onConfigApply(applyErr))
+	}
+	report := tracker.onConfigApply(applyErr)
+	require.NotNil(t, report)
+	assert.Equal(t, v1alpha1.AgentProblemType_AGENT_PROBLEM_TYPE_CONFIG_APPLY_FAILING, report.GetType())
+	assert.EqualValues(t, ConfigFailureThreshold, report.GetOccurrences())
+	assert.Contains(t, report.GetMessage(), "invalid pipeline")
+
+	// reported once while the failures go on
+	assert.Nil(t, tracker.onConfigApply(applyErr))
+
+	// and again once they recur after a config applied
+	assert.Nil(t, tracker.onConfigApply(nil))
+	for range ConfigFailureThreshold - 1 {
+		assert.Nil(t, tracker.onConfigApply(applyErr))
+	}
+	assert.NotNil(t, tracker.onConfigApply(applyErr))
+}
+
+func TestProblemTracker_CrashLoop(t *testing.T) {
+	var tracker problemTracker
+	now := time.Now()
+	exitErr := errors.New("exit status 1")
+
+	// exits spread over more than the window aren't a crash loop
+	for i := range CrashLoopThreshold + 1 {
+		assert.Nil(t, tracker.onCollectorExit(now.Add(time.Duration(i)*CrashLoopWindow), exitErr))
+	}
+
+	now = now.Add(10 * CrashLoopWindow)
+	for i := range CrashLoopThreshold - 1 {
+		assert.Nil(t, tracker.onCollectorExit(now.Add(time.Duration(i)*time.Second), exitErr))
+	}
+	report := tracker.onCollectorExit(now.Add(time.Minute), exitErr)
+	require.NotNil(t, report)
+	assert.Equal(t, v1alpha1.AgentProblemType_AGENT_PROBLEM_TYPE_COLLECTOR_CRASH_LOOP, report.GetType())
+	assert.EqualValues(t, CrashLoopThreshold, report.GetOccurrences())
+	assert.Contains(t, report.GetMessage(), "exit status 1")
+	assert.Nil(t, tracker.onCollectorExit(now.Add(2*time.Minute), exitErr))
+
+	// reported again once the collector stayed up for a window
+	now = now.Add(2 * CrashLoopWindow)
+	for i := range CrashLoopThreshold - 1 {
+		assert.Nil(t, tracker.onCollectorExit(now.Add(time.Duration(i)*time.Second), nil))
+	}
+	assert.NotNil(t, tracker.onCollectorExit(now.Add(time.Minute), nil))
+}
+
+func TestProblemTracker_DiskSpace(t *testing.T) {
+	var tracker problemTracker
+	const low = 100
+
+	assert.Nil(t, tracker.onDiskSpace("/etc/otelcol", 200, low))
+	report := tracker.onDiskSpace("/etc/otelcol", 50, low)
+	require.NotNil(t, report)
+	assert.Equal(t, v1alpha1.AgentProblemType_AGENT_PROBLEM_TYPE_DISK_NEARLY_FULL, report.GetType())
+	assert.Equal(t, map[string]string{"path": "/etc/otelcol", "free_bytes": "50"}, report.GetDetails())
+
+	assert.Nil(t, tracker.onDiskSpace("/etc/otelcol", 40, low))
+	assert.Nil(t, tracker.onDiskSpace("/etc/otelcol", 200, low))
+	assert.NotNil(t, tracker.onDiskSpace("/etc/otelcol", 10, low))
+}
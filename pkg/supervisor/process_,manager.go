@@ -14,6 +14,7 @@ import (
 	"path"
 	"strings"
 	"sync"
+	stdatomic "sync/atomic"
 	"syscall"
 	"time"
 
@@ -27,11 +28,21 @@ type ProcManager struct {
 	logger     *slog.Logger
 	BinaryPath string
 	ConfigDir  string
+	// MinFreeBytes is the free space that must remain in ConfigDir after writing configs
+	MinFreeBytes uint64
+	// OnRestart is called when the collector is started in place of a previous process
+	OnRestart func()
+	// OnExit is called when the collector exits without being stopped, with its exit error
+	OnExit func(err error)
 
 	runMu     *sync.Mutex
 	cmd       *exec.Cmd
 	cmdExited chan struct{}
 	curHash   []byte
+	// arguments the collector was last started with
+	args []string
+	// set when the collector is stopped, so that its exit isn't reported to OnExit
+	cmdStopping *stdatomic.Bool
 
 	// TODO : this is a hacky implementation
 	// we want all health drivers to be able to report their health - Need to
@@ -57,11 +68,17 @@ func NewProcManager(
 		logger:         logger,
 		BinaryPath:     binaryPath,
 		ConfigDir:      configPath,
+		MinFreeBytes:   DefaultMinFreeBytes,
 		reportHealthFn: reportFn,
 		curHash:        []byte{},
 	}
 }
 
+// ConfigDirectory returns the directory the collector's config files are written to
+func (p *ProcManager) ConfigDirectory() string {
+	return p.ConfigDir
+}
+
 func (p *ProcManager) Update(
 	ctx context.Context,
 	incoming *protobufs.AgentRemoteConfig,
@@ -80,12 +97,15 @@ func (p *ProcManager) Update(
 func (p *ProcManager) runLocked(ctx context.Context, incoming *protobufs.AgentRemoteConfig) error {
 	// TODO : this doens't handle cleanup of dangling names
 	configMap := incoming.GetConfig().GetConfigMap()
+	if err := preflightConfigWrite(p.ConfigDir, configMap, p.MinFreeBytes); err != nil {
+		return err
+	}
 	for name, contents := range configMap {
 		if err := p.writeConfigLocked(name, contents); err != nil {
 			return err
 		}
 	}
-	p.curHash = util.HashAgentConfigMap(incoming.GetConfig())
+	p.curHash = util.RemoteConfigHash(incoming)
 	args := []string{}
 	for name := range configMap {
 		args = append(
@@ -98,7 +118,14 @@ func (p *ProcManager) runLocked(ctx context.Context, incoming *protobufs.AgentRe
 	if len(args) == 0 {
 		panic("0 configs not handled")
 	}
+	restart := p.cmd != nil
 	p.releaseLocked()
+	return p.startLocked(ctx, args, restart)
+}
+
+// startLocked starts the collector with args, restart reports whether it replaces a
+// previous process
+func (p *ProcManager) startLocked(ctx context.Context, args []string, restart bool) error {
 	p.logger.With("binary", p.BinaryPath, "args", strings.Join(args, " ")).Info("executing command...")
 	cmd := exec.Command(p.BinaryPath, args...)
 
@@ -121,7 +148,11 @@ func (p *ProcManager) runLocked(ctx context.Context, incoming *protobufs.AgentRe
 	if err := cmd.Start(); err != nil {
 		return fmt.Errorf("error starting collector")
 	}
+	if restart && p.OnRestart != nil {
+		p.OnRestart()
+	}
 	exited := make(chan struct{})
+	stopping := &stdatomic.Bool{}
 	// TODO : this report health fn likely has potential synchronization issues
 	p.reportHealthFn(true, "running", "")
 	go func() {
@@ -132,12 +163,52 @@ func (p *ProcManager) runLocked(ctx context.Context, incoming *protobufs.AgentRe
 			p.logger.Info("reporting failure to opamp server")
 			p.reportHealthFn(false, fmt.Sprintf("collector exited : %s", err), "TODO : last error message")
 		}
+		if !stopping.Load() && p.OnExit != nil {
+			p.OnExit(err)
+		}
 	}()
 
 	// is there a ready check for otelcol collector we can
 	// leverage here, or just health?
 	p.cmd = cmd
 	p.cmdExited = exited
+	p.cmdStopping = stopping
+	p.args = args
+	return nil
+}
+
+// Restart stops the collector and starts it again with its current config
+func (p *ProcManager) Restart(ctx context.Context) error {
+	p.runMu.Lock()
+	defer p.runMu.Unlock()
+	if p.cmd == nil {
+		return errors.New("collector is not running")
+	}
+	p.logger.Info("restarting collector")
+	p.stopLocked()
+	return p.startLocked(ctx, p.args, true)
+}
+
+// UpdateBinary restarts the collector with the binary at binaryPath, going back to the
+// previous binary if the new one fails to start
+func (p *ProcManager) UpdateBinary(ctx context.Context, binaryPath string) error {
+	p.runMu.Lock()
+	defer p.runMu.Unlock()
+	previous := p.BinaryPath
+	p.BinaryPath = binaryPath
+	if p.cmd == nil {
+		// the binary is started when the first config is applied
+		return nil
+	}
+	p.logger.With("binary", binaryPath).Info("restarting collector with new binary")
+	p.stopLocked()
+	if err := p.startLocked(ctx, p.args, true); err != nil {
+		p.BinaryPath = previous
+		if err := p.startLocked(ctx, p.args, true); err != nil {
+			p.logger.With("err", err, "binary", previous).Error("failed to restart collector with previous binary")
+		}
+		return err
+	}
 	return nil
 }
 
@@ -179,12 +250,22 @@ func (p *ProcManager) GetCurrentHash() []byte {
 
 func (p *ProcManager) Shutdown() error {
 	// TODO:
+	p.runMu.Lock()
+	defer p.runMu.Unlock()
+	p.stopLocked()
+	return nil
+}
+
+// stopLocked stops the collector, killing it if it doesn't exit within a minute
+func (p *ProcManager) stopLocked() {
 	if p.cmd != nil && p.cmd.Process != nil {
+		p.cmdStopping.Store(true)
 		gracefulShutdown := time.Minute
 		_ = p.cmd.Process.Signal(shutdownSignal)
 		select {
 		case <-p.cmdExited:
-			return nil
+			p.cmd = nil
+			return
 		case <-time.After(gracefulShutdown):
 
 		}
@@ -196,7 +277,6 @@ func (p *ProcManager) Shutdown() error {
 		p.cmd = nil
 
 	}
-	return nil
 }
 
 func (p *ProcManager) releaseLocked() {
@@ -209,6 +289,10 @@ func (p *ProcManager) releaseLocked() {
 }
 
 func (p *ProcManager) writeConfigLocked(name string, config *protobufs.AgentConfigFile) error {
+	// checked again here since this is where the name is joined onto the config dir
+	if err := validateConfigName(name); err != nil {
+		return &PreflightError{Check: PreflightCheckConfigName, Path: name, Err: err}
+	}
 	fileName := path.Join(p.ConfigDir, name)
 	p.logger.With("file", fileName).Info("writing config file")
 	if err := atomic.WriteFile(fileName, bytes.NewReader(config.GetBody())); err != nil {
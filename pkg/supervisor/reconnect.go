@@ -0,0 +1,69 @@
+package supervisor
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"google.golang.org/protobuf/proto"
+)
+
+// reportCache remembers the state last acknowledged by the server, so that reconnects only
+// send a compact status instead of the full effective config. The server asks for the full
+// state with the ReportFullState flag when it doesn't know it.
+type reportCache struct {
+	mu sync.Mutex
+	// hash of the applied config when the effective config was last reported
+	configHash []byte
+	// last remote config status reported
+	status *protobufs.RemoteConfigStatus
+	// a report was sent that the server didn't reply to yet
+	pending bool
+	// the next effective config report is skipped
+	compact bool
+}
+
+// onConnect decides whether the first report after connecting can be compact, which is the
+// case when reconnecting with the config whose effective config the server acknowledged
+func (c *reportCache) onConnect(reconnected bool, currentHash []byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.compact = reconnected && !c.pending && c.configHash != nil && bytes.Equal(c.configHash, currentHash)
+	return c.compact
+}
+
+// reportEffectiveConfig returns whether the effective config of the config with hash must
+// be reported, and records it as pending if so
+func (c *reportCache) reportEffectiveConfig(hash []byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.compact {
+		c.compact = false
+		return false
+	}
+	c.configHash = bytes.Clone(hash)
+	c.pending = true
+	return true
+}
+
+// reportStatus records a remote config status report as pending
+func (c *reportCache) reportStatus(status *protobufs.RemoteConfigStatus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.status = proto.Clone(status).(*protobufs.RemoteConfigStatus)
+	c.pending = true
+}
+
+// acknowledge records that the server replied to the reports sent so far
+func (c *reportCache) acknowledge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending = false
+}
+
+// lastStatus returns the last remote config status reported, if any
+func (c *reportCache) lastStatus() *protobufs.RemoteConfigStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.status
+}
@@ -0,0 +1,43 @@
+package supervisor
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportCache(t *testing.T) {
+	var c reportCache
+	hash := []byte{0x01}
+
+	// the first connection always reports the full state
+	assert.False(t, c.onConnect(false, hash))
+	assert.True(t, c.reportEffectiveConfig(hash))
+
+	// reports the server didn't reply to are resent
+	assert.False(t, c.onConnect(true, hash))
+	assert.True(t, c.reportEffectiveConfig(hash))
+	c.acknowledge()
+
+	assert.True(t, c.onConnect(true, hash))
+	assert.False(t, c.reportEffectiveConfig(hash))
+	// the server asking for the full state gets it
+	assert.True(t, c.reportEffectiveConfig(hash))
+	c.acknowledge()
+
+	// a status the server didn't reply to is resent with the full state
+	status := &protobufs.RemoteConfigStatus{
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+		LastRemoteConfigHash: hash,
+	}
+	c.reportStatus(status)
+	assert.False(t, c.onConnect(true, hash))
+	assert.True(t, c.reportEffectiveConfig(hash))
+	c.acknowledge()
+	assert.Equal(t, status.GetStatus(), c.lastStatus().GetStatus())
+
+	// the applied config changed since it was reported
+	assert.False(t, c.onConnect(true, []byte{0x02}))
+	assert.True(t, c.reportEffectiveConfig([]byte{0x02}))
+}
@@ -0,0 +1,228 @@
+package supervisor
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/ecdh"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// SnapshotCapability is the OpAMP custom capability for capturing support snapshots
+	SnapshotCapability = "io.otelfleet.snapshot"
+	// SnapshotMessageRequest is the custom message type of a v1alpha1.SnapshotRequest
+	SnapshotMessageRequest = "request"
+	// SnapshotMessageUpload is the custom message type of a v1alpha1.SnapshotUpload
+	SnapshotMessageUpload = "upload"
+
+	// tar pads each entry to 512 byte blocks, and ends the archive with two empty blocks
+	tarBlockSize   = 512
+	tarTrailerSize = 2 * tarBlockSize
+
+	processListTimeout = 10 * time.Second
+)
+
+// configDirProvider is implemented by agent drivers that manage their configuration on disk
+type configDirProvider interface {
+	ConfigDirectory() string
+}
+
+func (s *Supervisor) handleCustomMessage(ctx context.Context, msg *protobufs.CustomMessage) {
+	if msg.GetCapability() != SnapshotCapability || msg.GetType() != SnapshotMessageRequest {
+		s.logger.With("capability", msg.GetCapability(), "type", msg.GetType()).Warn("ignoring unsupported custom message")
+		return
+	}
+	req := &v1alpha1.SnapshotRequest{}
+	if err := proto.Unmarshal(msg.GetData(), req); err != nil {
+		s.logger.With("err", err).Error("failed to decode snapshot request")
+		return
+	}
+	// capturing the process list and sending the upload can take a while, so
+	// don't block the OpAMP client's message loop
+	go func() {
+		upload := s.captureSnapshot(context.WithoutCancel(ctx), req)
+		if err := s.sendSnapshot(upload); err != nil {
+			s.logger.With("err", err, "snapshot_id", req.GetSnapshotId()).Error("failed to send snapshot")
+		}
+	}()
+}
+
+func (s *Supervisor) captureSnapshot(ctx context.Context, req *v1alpha1.SnapshotRequest) *v1alpha1.SnapshotUpload {
+	l := s.logger.With("snapshot_id", req.GetSnapshotId())
+	l.Info("capturing snapshot")
+	upload := &v1alpha1.SnapshotUpload{SnapshotId: req.GetSnapshotId()}
+
+	serverPubKey, err := ecdh.ServerPubKey(req)
+	if err != nil {
+		upload.Error = fmt.Sprintf("invalid server public key: %s", err)
+		return upload
+	}
+	archive, truncated, err := s.buildSnapshot(ctx, req.GetMaxBytes())
+	if err != nil {
+		upload.Error = fmt.Sprintf("failed to build snapshot: %s", err)
+		return upload
+	}
+	ekp := ecdh.NewEphemeralKeyPair()
+	secret, err := ecdh.DeriveSharedSecret(ekp, serverPubKey)
+	if err != nil {
+		upload.Error = fmt.Sprintf("failed to derive shared secret: %s", err)
+		return upload
+	}
+	ciphertext, err := ecdh.Seal(secret, archive)
+	if err != nil {
+		upload.Error = fmt.Sprintf("failed to encrypt snapshot: %s", err)
+		return upload
+	}
+	upload.ClientPubKey = ekp.PublicKey.Bytes()
+	upload.Ciphertext = ciphertext
+	upload.Truncated = truncated
+	l.With("size", len(archive), "truncated", truncated).Info("captured snapshot")
+	return upload
+}
+
+func (s *Supervisor) sendSnapshot(upload *v1alpha1.SnapshotUpload) error {
+	data, err := proto.Marshal(upload)
+	if err != nil {
+		return err
+	}
+	return s.sendCustomMessage(&protobufs.CustomMessage{
+		Capability: SnapshotCapability,
+		Type:       SnapshotMessageUpload,
+		Data:       data,
+	})
+}
+
+// buildSnapshot packages the applied config hash, the process list, the collector's
+// config and the most recent supervisor logs into a gzipped tarball, in that order of
+// priority. Content that doesn't fit in maxBytes is left out.
+func (s *Supervisor) buildSnapshot(ctx context.Context, maxBytes int64) ([]byte, bool, error) {
+	w := newSnapshotWriter(maxBytes)
+
+	w.add("config.hash", []byte(hex.EncodeToString(s.agentDriver.GetCurrentHash())+"\n"))
+	w.add("processes.txt", processList(ctx))
+
+	if p, ok := s.agentDriver.(configDirProvider); ok {
+		dir := p.ConfigDirectory()
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.Type().IsRegular() {
+				return err
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			w.add(filepath.ToSlash(filepath.Join("config", rel)), contents)
+			return nil
+		})
+		if err != nil {
+			s.logger.With("err", err, "dir", dir).Warn("failed to read config directory for snapshot")
+		}
+	} else if configMap, err := s.agentDriver.GetConfigMap(); err == nil {
+		for name, file := range configMap.GetConfigMap() {
+			w.add(filepath.ToSlash(filepath.Join("config", name)), file.GetBody())
+		}
+	}
+
+	if s.logs != nil {
+		w.addTail("supervisor.log", s.logs.tail)
+	}
+	return w.close()
+}
+
+// processList lists the processes running on the host
+func processList(ctx context.Context) []byte {
+	ctx, cancel := context.WithTimeout(ctx, processListTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "ps", "-eo", "pid,ppid,user,etime,rss,args").Output()
+	if err != nil {
+		return fmt.Appendf(out, "failed to list processes: %s\n", err)
+	}
+	return out
+}
+
+// snapshotWriter writes a size-capped gzipped tarball
+type snapshotWriter struct {
+	buf       bytes.Buffer
+	gz        *gzip.Writer
+	tw        *tar.Writer
+	remaining int64
+	truncated bool
+	err       error
+}
+
+func newSnapshotWriter(maxBytes int64) *snapshotWriter {
+	w := &snapshotWriter{remaining: maxBytes - tarTrailerSize}
+	w.gz = gzip.NewWriter(&w.buf)
+	w.tw = tar.NewWriter(w.gz)
+	return w
+}
+
+// entrySize is the size of a file's tar entry, including its header and padding
+func entrySize(size int64) int64 {
+	return tarBlockSize + (size+tarBlockSize-1)/tarBlockSize*tarBlockSize
+}
+
+func (w *snapshotWriter) add(name string, contents []byte) {
+	if w.err != nil {
+		return
+	}
+	size := entrySize(int64(len(contents)))
+	if size > w.remaining {
+		w.truncated = true
+		return
+	}
+	w.remaining -= size
+	if w.err = w.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    int64(len(contents)),
+		ModTime: time.Now(),
+	}); w.err != nil {
+		return
+	}
+	_, w.err = w.tw.Write(contents)
+}
+
+// addTail adds as much of the end of some content as fits in the remaining space
+func (w *snapshotWriter) addTail(name string, tail func(n int) ([]byte, bool)) {
+	if w.remaining <= tarBlockSize {
+		w.truncated = true
+		return
+	}
+	// round down to whole blocks so the padded entry still fits
+	avail := (w.remaining - tarBlockSize) / tarBlockSize * tarBlockSize
+	contents, complete := tail(int(avail))
+	if !complete {
+		w.truncated = true
+	}
+	w.add(name, contents)
+}
+
+func (w *snapshotWriter) close() ([]byte, bool, error) {
+	if w.err != nil {
+		return nil, false, w.err
+	}
+	if err := w.tw.Close(); err != nil {
+		return nil, false, err
+	}
+	if err := w.gz.Close(); err != nil {
+		return nil, false, err
+	}
+	return w.buf.Bytes(), w.truncated, nil
+}
@@ -0,0 +1,86 @@
+package supervisor
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readArchive(t *testing.T, archive []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		contents, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(contents)
+	}
+}
+
+func TestBuildSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte("receivers: {}"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "large.yaml"), bytes.Repeat([]byte("#"), 64<<10), 0600))
+	driver := NewProcManager(slog.Default(), "otelcol", dir, nil)
+	driver.curHash = []byte{0xab, 0xcd}
+	s := NewSupervisor(slog.Default(), nil, "ws://127.0.0.1:0/v1/opamp", nil, driver, ExtraAttributes{})
+	s.logger.Info("first line")
+	s.logger.With("key", "value").Info("second line")
+
+	archive, truncated, err := s.buildSnapshot(t.Context(), 1<<20)
+	require.NoError(t, err)
+	assert.False(t, truncated)
+	files := readArchive(t, archive)
+	assert.Equal(t, "abcd\n", files["config.hash"])
+	assert.Contains(t, files, "processes.txt")
+	assert.Equal(t, "receivers: {}", files["config/config.yaml"])
+	assert.Len(t, files["config/large.yaml"], 64<<10)
+	assert.Contains(t, files["supervisor.log"], "msg=\"first line\"")
+	assert.Contains(t, files["supervisor.log"], "msg=\"second line\" key=value")
+
+	// content that doesn't fit is left out, leaving room for the small files and
+	// some slack for the process list changing in between
+	maxBytes := entrySize(int64(len(files["processes.txt"]))) + 16<<10
+	archive, truncated, err = s.buildSnapshot(t.Context(), maxBytes)
+	require.NoError(t, err)
+	assert.True(t, truncated)
+	files = readArchive(t, archive)
+	assert.Equal(t, "abcd\n", files["config.hash"])
+	assert.Equal(t, "receivers: {}", files["config/config.yaml"])
+	assert.NotContains(t, files, "config/large.yaml")
+}
+
+func TestLogBuffer(t *testing.T) {
+	buf := newLogBuffer(10)
+	for _, line := range []string{"one\n", "two\n", "three\n"} {
+		_, err := buf.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	// "one" was evicted to stay within the buffer size
+	tail, complete := buf.tail(100)
+	assert.Equal(t, "two\nthree\n", string(tail))
+	assert.True(t, complete)
+
+	tail, complete = buf.tail(8)
+	assert.Equal(t, "three\n", string(tail))
+	assert.False(t, complete)
+
+	tail, _ = buf.tail(2)
+	assert.Empty(t, strings.TrimSpace(string(tail)))
+}
@@ -1,7 +1,9 @@
 package supervisor
 
 import (
+	"fmt"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/open-telemetry/opamp-go/protobufs"
@@ -28,6 +30,12 @@ func (s *Supervisor) buildAgentDescription(agentID string) *protobufs.AgentDescr
 		util.KeyVal("host.arch", runtime.GOARCH),
 		util.KeyVal("process.runtime.name", "go"),
 		util.KeyVal("process.runtime.version", runtime.Version()),
+		util.KeyVal(AttributeHostCPUCount, strconv.Itoa(runtime.NumCPU())),
+	}
+	if memory, ok, err := totalMemoryBytes(); err != nil {
+		s.logger.With("err", err).Warn("failed to get host memory")
+	} else if ok {
+		nonIdentifyingAttrs = append(nonIdentifyingAttrs, util.KeyVal(AttributeHostMemoryBytes, strconv.FormatUint(memory, 10)))
 	}
 
 	// Append extra non-identifying attributes
@@ -52,6 +60,11 @@ func (s *Supervisor) buildComponentHealth(healthy bool, status, lastError string
 				StartTimeUnixNano: uint64(s.startTime.UnixNano()),
 				Status:            "some details here",
 			},
+			"opamp-client": {
+				Healthy:           true,
+				StartTimeUnixNano: uint64(s.startTime.UnixNano()),
+				Status:            fmt.Sprintf("restarts: %d", s.conn.status().ClientRestarts),
+			},
 		},
 		StartTimeUnixNano:  uint64(startTime.UnixNano()),
 		StatusTimeUnixNano: uint64(time.Now().UnixNano()),
@@ -66,6 +79,8 @@ func GetCapabilities() uint64 {
 			protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig |
 			protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig |
 			protobufs.AgentCapabilities_AgentCapabilities_ReportsHealth |
-			protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig,
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig |
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsHeartbeat |
+			protobufs.AgentCapabilities_AgentCapabilities_AcceptsRestartCommand,
 	)
 }
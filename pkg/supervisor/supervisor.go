@@ -4,9 +4,11 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/hex"
+	"errors"
 	"log/slog"
 	"os"
 	"path"
+	"sync"
 	"time"
 
 	"github.com/open-telemetry/opamp-go/client"
@@ -30,16 +32,38 @@ type Supervisor struct {
 
 	tlsConfig *tls.Config
 
+	// the OpAMP client is replaced when the watchdog restarts it
+	clientMu    sync.Mutex
 	opampClient client.OpAMPClient
 	opAmpAddr   string
+	// instanceUID is kept across client restarts
+	instanceUID types.InstanceUid
+
+	watchdogThreshold time.Duration
+	stopWatchdog      context.CancelFunc
+
+	problems problemTracker
+	// the free space of the config directory reported as nearly full, 0 to disable the check
+	lowDiskFreeBytes uint64
+	stopDiskCheck    context.CancelFunc
 
 	agentId         ident.Identity
 	extraAttributes ExtraAttributes
 	startTime       time.Time
+	conn            connectionTracker
+	reports         reportCache
+	// recent supervisor logs, included in snapshots
+	logs *logBuffer
 
 	// for direct in-process management
 	agentDriver AgentDriver
 	appliedHash string
+	// steps run before the agent driver applies a remote config
+	applyMiddlewares []ApplyMiddleware
+	// optional, the packages offered by the server
+	packages *PackageStore
+
+	metrics *supervisorMetrics
 }
 
 func NewSupervisorWithProcManager(
@@ -49,14 +73,20 @@ func NewSupervisorWithProcManager(
 	agentId ident.Identity,
 	extraAttrs ExtraAttributes,
 ) *Supervisor {
+	logs := newLogBuffer(defaultLogBufferSize)
+	logger = newTeeLogger(logger, logs)
 	s := &Supervisor{
 		logger:          logger,
+		logs:            logs,
 		tlsConfig:       tlsConfig,
 		clientLogger:    logutil.NewOpAMPLogger(logger),
 		opAmpAddr:       opAmpAddr,
 		agentId:         agentId,
 		startTime:       time.Now(),
 		extraAttributes: extraAttrs,
+		metrics:         newSupervisorMetrics(),
+
+		lowDiskFreeBytes: DefaultLowDiskFreeBytes,
 	}
 	basePath, err := os.UserConfigDir()
 	// FIXME: temporary hack
@@ -69,13 +99,16 @@ func NewSupervisorWithProcManager(
 	if err := os.MkdirAll(configPath, 0700); err != nil {
 		panic(err)
 	}
-	s.agentDriver = NewProcManager(
+	procManager := NewProcManager(
 		logger.With("process", "otelcol"),
 		//FIXME:
 		"/home/alex/.asdf/shims/otelcol",
 		configPath,
 		s.reportHealth,
 	)
+	procManager.OnRestart = s.metrics.collectorRestarts.Inc
+	procManager.OnExit = s.onCollectorExit
+	s.agentDriver = procManager
 	return s
 }
 
@@ -91,8 +124,11 @@ func NewSupervisor(
 	agentDriver AgentDriver,
 	extraAttrs ExtraAttributes,
 ) *Supervisor {
+	logs := newLogBuffer(defaultLogBufferSize)
+	logger = newTeeLogger(logger, logs)
 	return &Supervisor{
 		logger:          logger,
+		logs:            logs,
 		tlsConfig:       tlsConfig,
 		clientLogger:    logutil.NewOpAMPLogger(logger),
 		opAmpAddr:       opAmpAddr,
@@ -100,30 +136,87 @@ func NewSupervisor(
 		extraAttributes: extraAttrs,
 		startTime:       time.Now(),
 		agentDriver:     agentDriver,
+		metrics:         newSupervisorMetrics(),
+
+		lowDiskFreeBytes: DefaultLowDiskFreeBytes,
 	}
 }
 
+// SetPackageStore enables the collector upgrades offered by the server, installing the
+// collector binaries kept in store. Must be called before Start.
+func (s *Supervisor) SetPackageStore(store *PackageStore) {
+	store.OnInstall = s.agentDriver.UpdateBinary
+	s.packages = store
+}
+
 func (s *Supervisor) Start() error {
+	s.instanceUID = types.InstanceUid(util.NewInstanceUUID())
+	if s.packages != nil {
+		if path := s.packages.InstalledCollector(); path != "" {
+			if err := s.agentDriver.UpdateBinary(context.Background(), path); err != nil {
+				return err
+			}
+		}
+	}
 	if err := s.startOpAMP(); err != nil {
 		return err
 	}
+	if s.watchdogThreshold > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.stopWatchdog = cancel
+		go s.runWatchdog(ctx)
+	}
+	if p, ok := s.agentDriver.(configDirProvider); ok && s.lowDiskFreeBytes > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.stopDiskCheck = cancel
+		go s.runDiskCheck(ctx, p.ConfigDirectory())
+	}
 	return nil
 }
 
+// client returns the current OpAMP client
+func (s *Supervisor) client() client.OpAMPClient {
+	s.clientMu.Lock()
+	defer s.clientMu.Unlock()
+	return s.opampClient
+}
+
 func (s *Supervisor) startOpAMP() error {
-	s.opampClient = client.NewWebSocket(s.clientLogger)
+	opampClient := client.NewWebSocket(s.clientLogger)
+	s.clientMu.Lock()
+	s.opampClient = opampClient
+	s.clientMu.Unlock()
+	heartbeat := heartbeatInterval
+	capabilities := GetCapabilities()
+	if s.packages != nil {
+		capabilities |= uint64(protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages |
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses)
+	}
 	settings := types.StartSettings{
-		OpAMPServerURL: s.opAmpAddr,
-		TLSConfig:      s.tlsConfig,
-		InstanceUid:    types.InstanceUid([]byte(util.NewUUID())),
-		Capabilities:   protobufs.AgentCapabilities(GetCapabilities()),
+		OpAMPServerURL:    s.opAmpAddr,
+		TLSConfig:         s.tlsConfig,
+		InstanceUid:       s.instanceUID,
+		Capabilities:      protobufs.AgentCapabilities(capabilities),
+		HeartbeatInterval: &heartbeat,
 		Callbacks: types.Callbacks{
 			OnConnect: func(ctx context.Context) {
 				s.logger.Info("connected to OpAMP server")
+				reconnected := s.conn.onConnect(time.Now())
+				if reconnected {
+					s.metrics.opampReconnects.Inc()
+				}
+				if s.reports.onConnect(reconnected, s.agentDriver.GetCurrentHash()) {
+					s.metrics.opampCompactReconnects.Inc()
+					s.logger.With(
+						"cur-hash", hex.EncodeToString(s.agentDriver.GetCurrentHash()),
+						"status", s.reports.lastStatus().GetStatus().String(),
+					).Info("server acknowledged the current config, sending a compact status")
+				}
 				s.reportHealth(true, "connected", "")
 			},
 			OnConnectFailed: func(ctx context.Context, err error) {
 				s.logger.With("err", err).Error("failed to connect to the server")
+				s.conn.onConnectFailed(err)
 			},
 			OnError: func(ctx context.Context, err *protobufs.ServerErrorResponse) {
 				s.logger.With(
@@ -138,20 +231,36 @@ func (s *Supervisor) startOpAMP() error {
 				}
 			},
 			GetEffectiveConfig: func(ctx context.Context) (*protobufs.EffectiveConfig, error) {
+				if !s.reports.reportEffectiveConfig(s.agentDriver.GetCurrentHash()) {
+					// omitted from the first report after reconnecting, the server asks for
+					// it with ReportFullState if it doesn't know it
+					return nil, nil
+				}
 				return s.createEffectiveConfigMsg(), nil
 			},
 			OnMessage: s.onMessage,
+			OnCommand: s.onCommand,
 		},
 	}
 
+	if s.packages != nil {
+		settings.PackagesStateProvider = s.packages
+	}
+
 	// Use enhanced agent description
-	err := s.opampClient.SetAgentDescription(s.createAgentDescription())
+	err := opampClient.SetAgentDescription(s.createAgentDescription())
 	if err != nil {
 		return err
 	}
 
+	if err := opampClient.SetCustomCapabilities(&protobufs.CustomCapabilities{
+		Capabilities: []string{SnapshotCapability, ConfigPushCapability, CommandCapability, ProblemCapability},
+	}); err != nil {
+		return err
+	}
+
 	// Set initial health status
-	if err := s.opampClient.SetHealth(s.buildHealth(
+	if err := opampClient.SetHealth(s.buildHealth(
 		true,
 		"initialized",
 		"",
@@ -159,7 +268,8 @@ func (s *Supervisor) startOpAMP() error {
 		s.logger.With("err", err).Warn("failed to set initial health")
 	}
 
-	if err := s.opampClient.Start(context.TODO(), settings); err != nil {
+	s.conn.onClientStart(time.Now())
+	if err := opampClient.Start(context.TODO(), settings); err != nil {
 		return err
 	}
 
@@ -169,36 +279,75 @@ func (s *Supervisor) startOpAMP() error {
 func (s *Supervisor) onMessage(ctx context.Context, msg *types.MessageData) {
 	l := s.logger
 	l.Debug("received message")
+	s.conn.onContact(time.Now())
+	s.reports.acknowledge()
+	var pushID string
+	if msg.CustomMessage != nil {
+		if id, ok := s.configPushID(msg.CustomMessage); ok {
+			pushID = id
+		} else {
+			s.handleCustomMessage(ctx, msg.CustomMessage)
+		}
+	}
+	if msg.PackageSyncer != nil {
+		// syncs in the background, reporting the package statuses itself
+		if err := msg.PackageSyncer.Sync(context.Background()); err != nil {
+			l.With("err", err).Error("failed to sync packages")
+		}
+	}
 	if incomingCfg := msg.RemoteConfig; incomingCfg != nil {
 		l = l.With("type", "remote-config")
 		l.With("incoming-hash", hex.EncodeToString(msg.RemoteConfig.ConfigHash)).With(
 			"cur-hash", hex.EncodeToString(s.agentDriver.GetCurrentHash()),
 		).Info("received effective configuration update")
-		if err := s.agentDriver.Update(ctx, incomingCfg); err != nil {
-			if err := s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
+		err := s.apply(ctx, incomingCfg)
+		s.sendProblem(s.problems.onConfigApply(err))
+		if err != nil {
+			failedHash := s.agentDriver.GetCurrentHash()
+			if preflightErr := (*PreflightError)(nil); errors.As(err, &preflightErr) {
+				// the config was rejected as a whole, report it against the rejected hash so
+				// the server doesn't resend it until it changes
+				l.With("check", preflightErr.Check, "path", preflightErr.Path).Warn("rejected remote config")
+				failedHash = incomingCfg.GetConfigHash()
+			}
+			if err := s.setRemoteConfigStatus(&protobufs.RemoteConfigStatus{
 				Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
-				LastRemoteConfigHash: s.agentDriver.GetCurrentHash(),
+				LastRemoteConfigHash: failedHash,
 				ErrorMessage:         err.Error(),
 			}); err != nil {
 				l.With("err", err).With("status", "failed").Error("failed to report remote config status to upstream server")
 			}
+			s.sendConfigPushReceipt(pushID, err)
 			return
 		}
 		l.With("cur-hash", hex.EncodeToString(s.agentDriver.GetCurrentHash())).Info("sending remote status update")
-		if err := s.opampClient.SetRemoteConfigStatus(&protobufs.RemoteConfigStatus{
+		if err := s.setRemoteConfigStatus(&protobufs.RemoteConfigStatus{
 			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
 			LastRemoteConfigHash: s.agentDriver.GetCurrentHash(),
 		}); err != nil {
 			l.With("err", err).With("status", "succeeded").Error("failed to report remote config status to upstream server")
 		}
+		s.sendConfigPushReceipt(pushID, nil)
 	}
 }
 
+// setRemoteConfigStatus reports status to the server
+func (s *Supervisor) setRemoteConfigStatus(status *protobufs.RemoteConfigStatus) error {
+	s.reports.reportStatus(status)
+	return s.client().SetRemoteConfigStatus(status)
+}
+
 func (s *Supervisor) Shutdown() error {
+	if s.stopWatchdog != nil {
+		s.stopWatchdog()
+	}
+	if s.stopDiskCheck != nil {
+		s.stopDiskCheck()
+	}
 	if err := s.agentDriver.Shutdown(); err != nil {
 		s.logger.With("err", err).Error("failed to shutdown agent driver")
 	}
-	return s.opampClient.Stop(context.TODO())
+	return s.client().Stop(context.TODO())
 }
 
 func (s *Supervisor) createEffectiveConfigMsg() *protobufs.EffectiveConfig {
@@ -239,7 +388,12 @@ func (s *Supervisor) reportHealth(
 	status string,
 	lastErrorMessage string,
 ) {
-	if err := s.opampClient.SetHealth(s.buildHealth(healthy, status, lastErrorMessage)); err != nil {
+	opampClient := s.client()
+	if opampClient == nil {
+		// not started yet, the initial health is set on start
+		return
+	}
+	if err := opampClient.SetHealth(s.buildHealth(healthy, status, lastErrorMessage)); err != nil {
 		s.logger.With("err", err).Warn("failed to report health")
 	}
 }
@@ -0,0 +1,70 @@
+package supervisor
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultWatchdogThreshold is how long the OpAMP client may go without activity
+	// before the watchdog restarts it
+	DefaultWatchdogThreshold = 2 * time.Minute
+
+	// heartbeatInterval is how often the OpAMP client sends heartbeats, so that an
+	// idle but healthy connection still shows activity
+	heartbeatInterval = 30 * time.Second
+	// clientStopTimeout bounds how long a wedged client is given to stop before it is
+	// abandoned, unless the watchdog threshold is shorter
+	clientStopTimeout = 5 * time.Second
+)
+
+// SetWatchdogThreshold enables the OpAMP client watchdog. If the client shows no activity
+// for longer than threshold it is restarted, leaving the managed collector running.
+// It must be called before Start; a threshold of 0 disables the watchdog.
+func (s *Supervisor) SetWatchdogThreshold(threshold time.Duration) {
+	s.watchdogThreshold = threshold
+}
+
+func (s *Supervisor) runWatchdog(ctx context.Context) {
+	t := time.NewTicker(s.watchdogThreshold / 4)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-t.C:
+			idle := now.Sub(s.conn.lastActivity())
+			if idle <= s.watchdogThreshold {
+				continue
+			}
+			s.logger.With("idle", idle.Round(time.Millisecond)).Warn("OpAMP client is unresponsive, restarting it")
+			if err := s.restartOpAMP(); err != nil {
+				s.logger.With("err", err).Error("failed to restart OpAMP client")
+			}
+		}
+	}
+}
+
+// restartOpAMP replaces the OpAMP client with a new one. The old client is abandoned
+// if it does not stop in time, since it may be the reason for the restart.
+func (s *Supervisor) restartOpAMP() error {
+	old := s.client()
+	stopTimeout := min(clientStopTimeout, s.watchdogThreshold)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+		defer cancel()
+		if err := old.Stop(ctx); err != nil {
+			s.logger.With("err", err).Warn("failed to stop OpAMP client")
+		}
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(stopTimeout):
+		s.logger.Warn("abandoning OpAMP client that did not stop")
+	}
+	s.conn.onClientRestart()
+	s.metrics.opampClientRestarts.Inc()
+	return s.startOpAMP()
+}
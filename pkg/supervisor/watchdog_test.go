@@ -0,0 +1,64 @@
+package supervisor
+
+import (
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/ident"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticIdentity string
+
+func (i staticIdentity) UniqueIdentifier() ident.ID {
+	return ident.ID{UUID: string(i)}
+}
+
+func TestWatchdog_RestartsWedgedClient(t *testing.T) {
+	// accepts connections but never completes the websocket handshake,
+	// which leaves the client stuck dialing
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	var (
+		mu    sync.Mutex
+		conns []net.Conn
+	)
+	t.Cleanup(func() {
+		lis.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			conn.Close()
+		}
+	})
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+		}
+	}()
+
+	s := NewSupervisor(slog.Default(), nil, "ws://"+lis.Addr().String()+"/v1/opamp", staticIdentity("agent"), nil, ExtraAttributes{})
+	s.SetWatchdogThreshold(200 * time.Millisecond)
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		s.stopWatchdog()
+		_ = s.client().Stop(t.Context())
+	})
+	instanceUID := s.instanceUID
+
+	require.Eventually(t, func() bool {
+		return s.Health(0).OpAMP.ClientRestarts >= 2
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.False(t, s.Health(0).OpAMP.Connected)
+	assert.Equal(t, instanceUID, s.instanceUID, "restarts keep the instance uid")
+}
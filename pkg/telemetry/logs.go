@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// logHandler emits slog records as OpenTelemetry log records, correlated with the span of
+// the context they are logged with
+type logHandler struct {
+	logger log.Logger
+	level  slog.Leveler
+	// attrs are the attributes added by WithAttrs, prefix the group added by WithGroup
+	attrs  []log.KeyValue
+	prefix string
+}
+
+// NewLogHandler returns a log handler emitting the records from level with logger. Groups
+// are flattened into the keys of their attributes, e.g. group.key.
+func NewLogHandler(logger log.Logger, level slog.Leveler) slog.Handler {
+	return &logHandler{logger: logger, level: level}
+}
+
+func (h *logHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.logger.Enabled(ctx, log.EnabledParameters{Severity: severity(level)})
+}
+
+func (h *logHandler) Handle(ctx context.Context, r slog.Record) error {
+	var record log.Record
+	record.SetTimestamp(r.Time)
+	record.SetObservedTimestamp(time.Now())
+	record.SetSeverity(severity(r.Level))
+	record.SetSeverityText(r.Level.String())
+	record.SetBody(log.StringValue(r.Message))
+	record.AddAttributes(h.attrs...)
+	r.Attrs(func(attr slog.Attr) bool {
+		record.AddAttributes(convertAttr(h.prefix, attr)...)
+		return true
+	})
+	h.logger.Emit(ctx, record)
+	return nil
+}
+
+func (h *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append([]log.KeyValue(nil), h.attrs...)
+	for _, attr := range attrs {
+		clone.attrs = append(clone.attrs, convertAttr(h.prefix, attr)...)
+	}
+	return &clone
+}
+
+func (h *logHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.prefix = h.prefix + name + "."
+	return &clone
+}
+
+// severity maps slog levels to OpenTelemetry severities, e.g. slog.LevelInfo to INFO and
+// the trace level of logutil to TRACE
+func severity(level slog.Level) log.Severity {
+	return log.Severity(level + 9)
+}
+
+// convertAttr converts a slog attribute to the log attributes it flattens to
+func convertAttr(prefix string, attr slog.Attr) []log.KeyValue {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		// inline groups have no key
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		var kvs []log.KeyValue
+		for _, attr := range value.Group() {
+			kvs = append(kvs, convertAttr(prefix, attr)...)
+		}
+		return kvs
+	}
+	if attr.Key == "" {
+		return nil
+	}
+	key := prefix + attr.Key
+	switch value.Kind() {
+	case slog.KindString:
+		return []log.KeyValue{log.String(key, value.String())}
+	case slog.KindInt64:
+		return []log.KeyValue{log.Int64(key, value.Int64())}
+	case slog.KindUint64:
+		return []log.KeyValue{log.Int64(key, int64(value.Uint64()))}
+	case slog.KindFloat64:
+		return []log.KeyValue{log.Float64(key, value.Float64())}
+	case slog.KindBool:
+		return []log.KeyValue{log.Bool(key, value.Bool())}
+	case slog.KindDuration:
+		return []log.KeyValue{log.String(key, value.Duration().String())}
+	case slog.KindTime:
+		return []log.KeyValue{log.String(key, value.Time().Format(time.RFC3339Nano))}
+	}
+	if err, ok := value.Any().(error); ok {
+		return []log.KeyValue{log.String(key, err.Error())}
+	}
+	return []log.KeyValue{log.String(key, fmt.Sprint(value.Any()))}
+}
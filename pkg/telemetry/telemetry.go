@@ -0,0 +1,241 @@
+// Package telemetry instruments the server itself with OpenTelemetry, exporting the traces
+// of OpAMP message handling, config assignments and deployment batches, and optionally the
+// logs of the server, with OTLP.
+//
+// Spans are started with Start, from the global tracer provider set by Setup, so that they
+// cost nothing when telemetry is disabled.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"path"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ScopeName is the instrumentation scope of the spans and logs of the server
+	ScopeName = "github.com/otelfleet/otelfleet"
+	// ServiceName is the service.name of the server, unless OTEL_SERVICE_NAME overrides it
+	ServiceName = "otelfleet"
+)
+
+// OTLP protocols
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http/protobuf"
+)
+
+// Config configures the telemetry of the server.
+type Config struct {
+	// Endpoint is the base URL of the OTLP receiver, e.g. http://otel-collector:4318, with
+	// an https scheme to export with TLS. Telemetry is disabled when it is empty.
+	Endpoint string `yaml:"endpoint"`
+	// Protocol is ProtocolGRPC or ProtocolHTTP, defaults to ProtocolHTTP
+	Protocol string `yaml:"protocol"`
+	// Headers are sent with every export, e.g. to authenticate to the receiver
+	Headers map[string]string `yaml:"headers"`
+	// SampleRatio is the ratio of the traces started by the server that are sampled, between
+	// 0 and 1, defaults to 1. Traces started by the callers of the server follow their
+	// sampling decision.
+	SampleRatio float64 `yaml:"sample_ratio"`
+	// Logs exports the logs of the server along with its traces
+	Logs bool `yaml:"logs"`
+}
+
+// Enabled returns true if the telemetry of the server is exported
+func (c Config) Enabled() bool {
+	return c.Endpoint != ""
+}
+
+// Provider holds the providers exporting the telemetry of the server.
+type Provider struct {
+	traces *sdktrace.TracerProvider
+	logs   *sdklog.LoggerProvider
+}
+
+// Setup sets the global tracer provider and propagator of the server according to cfg, and
+// returns the provider to flush on shutdown. Export errors are logged with logger, which
+// shouldn't export its own logs. Nothing is exported when telemetry is disabled.
+func Setup(ctx context.Context, logger *slog.Logger, cfg Config) (*Provider, error) {
+	if !cfg.Enabled() {
+		if cfg.Logs {
+			return nil, fmt.Errorf("exporting logs requires a telemetry endpoint")
+		}
+		return &Provider{}, nil
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid telemetry endpoint: %w", err)
+	}
+	if (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid telemetry endpoint %q: expected an http or https URL", cfg.Endpoint)
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, fmt.Errorf("invalid telemetry sample ratio %v: must be between 0 and 1", cfg.SampleRatio)
+	}
+	ratio := cfg.SampleRatio
+	if ratio == 0 {
+		ratio = 1
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithAttributes(semconv.ServiceName(ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithHost(),
+		resource.WithProcessPID(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect telemetry resource: %w", err)
+	}
+
+	p := &Provider{}
+	spanExporter, err := newSpanExporter(ctx, endpoint, cfg)
+	if err != nil {
+		return nil, err
+	}
+	p.traces = sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	if cfg.Logs {
+		logExporter, err := newLogExporter(ctx, endpoint, cfg)
+		if err != nil {
+			return nil, errors.Join(err, p.traces.Shutdown(ctx))
+		}
+		p.logs = sdklog.NewLoggerProvider(
+			sdklog.WithResource(res),
+			sdklog.WithProcessor(sdklog.NewBatchProcessor(logExporter)),
+		)
+	}
+
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.With("err", err).Warn("failed to export telemetry")
+	}))
+	otel.SetTracerProvider(p.traces)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	logger.With("endpoint", cfg.Endpoint, "protocol", protocol(cfg), "logs", cfg.Logs).Info("exporting telemetry")
+	return p, nil
+}
+
+func protocol(cfg Config) string {
+	if cfg.Protocol == "" {
+		return ProtocolHTTP
+	}
+	return cfg.Protocol
+}
+
+// signalPath returns the path of a signal on an OTLP/HTTP receiver served under the path of
+// the endpoint, or "" if it is served at the default path
+func signalPath(endpoint *url.URL, signal string) string {
+	if endpoint.Path == "" || endpoint.Path == "/" {
+		return ""
+	}
+	return path.Join(endpoint.Path, "v1", signal)
+}
+
+func newSpanExporter(ctx context.Context, endpoint *url.URL, cfg Config) (sdktrace.SpanExporter, error) {
+	switch protocol(cfg) {
+	case ProtocolGRPC:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint.Host)}
+		if endpoint.Scheme == "http" {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracegrpc.WithHeaders(cfg.Headers))
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case ProtocolHTTP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint.Host)}
+		if endpoint.Scheme == "http" {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if p := signalPath(endpoint, "traces"); p != "" {
+			opts = append(opts, otlptracehttp.WithURLPath(p))
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlptracehttp.WithHeaders(cfg.Headers))
+		}
+		return otlptracehttp.New(ctx, opts...)
+	}
+	return nil, fmt.Errorf("unknown telemetry protocol %q, expected %s or %s", cfg.Protocol, ProtocolGRPC, ProtocolHTTP)
+}
+
+func newLogExporter(ctx context.Context, endpoint *url.URL, cfg Config) (sdklog.Exporter, error) {
+	switch protocol(cfg) {
+	case ProtocolGRPC:
+		opts := []otlploggrpc.Option{otlploggrpc.WithEndpoint(endpoint.Host)}
+		if endpoint.Scheme == "http" {
+			opts = append(opts, otlploggrpc.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlploggrpc.WithHeaders(cfg.Headers))
+		}
+		return otlploggrpc.New(ctx, opts...)
+	case ProtocolHTTP:
+		opts := []otlploghttp.Option{otlploghttp.WithEndpoint(endpoint.Host)}
+		if endpoint.Scheme == "http" {
+			opts = append(opts, otlploghttp.WithInsecure())
+		}
+		if p := signalPath(endpoint, "logs"); p != "" {
+			opts = append(opts, otlploghttp.WithURLPath(p))
+		}
+		if len(cfg.Headers) > 0 {
+			opts = append(opts, otlploghttp.WithHeaders(cfg.Headers))
+		}
+		return otlploghttp.New(ctx, opts...)
+	}
+	return nil, fmt.Errorf("unknown telemetry protocol %q, expected %s or %s", cfg.Protocol, ProtocolGRPC, ProtocolHTTP)
+}
+
+// LogHandler returns a log handler exporting the records from level, or nil unless the logs
+// of the server are exported.
+func (p *Provider) LogHandler(level slog.Leveler) slog.Handler {
+	if p.logs == nil {
+		return nil
+	}
+	return NewLogHandler(p.logs.Logger(ScopeName), level)
+}
+
+// Shutdown flushes the telemetry that wasn't exported yet and stops exporting it.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	var errs []error
+	if p.traces != nil {
+		errs = append(errs, p.traces.Shutdown(ctx))
+	}
+	if p.logs != nil {
+		errs = append(errs, p.logs.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// Start starts a span of the server with the global tracer provider.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(ScopeName).Start(ctx, name, opts...)
+}
+
+// End records err, if any, as the status of span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
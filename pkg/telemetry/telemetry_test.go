@@ -0,0 +1,159 @@
+package telemetry_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/otelfleet/otelfleet/pkg/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace/noop"
+	collectorlogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSetupValidation(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for name, cfg := range map[string]telemetry.Config{
+		"logs without endpoint":   {Logs: true},
+		"endpoint without scheme": {Endpoint: "localhost:4318"},
+		"unknown scheme":          {Endpoint: "tcp://localhost:4318"},
+		"sample ratio":            {Endpoint: "http://localhost:4318", SampleRatio: 2},
+		"unknown protocol":        {Endpoint: "http://localhost:4318", Protocol: "http/json"},
+	} {
+		_, err := telemetry.Setup(t.Context(), logger, cfg)
+		assert.Error(t, err, name)
+	}
+
+	p, err := telemetry.Setup(t.Context(), logger, telemetry.Config{})
+	require.NoError(t, err)
+	assert.Nil(t, p.LogHandler(slog.LevelInfo), "logs aren't exported when telemetry is disabled")
+	require.NoError(t, p.Shutdown(t.Context()))
+}
+
+// receiver records the OTLP/HTTP exports it receives
+type receiver struct {
+	mu    sync.Mutex
+	spans []string
+	logs  []string
+	// traceIDs are the trace IDs of the log records
+	traceIDs [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch req.URL.Path {
+	case "/otlp/v1/traces":
+		var export collectortracepb.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &export); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, rs := range export.GetResourceSpans() {
+			for _, ss := range rs.GetScopeSpans() {
+				for _, span := range ss.GetSpans() {
+					r.spans = append(r.spans, span.GetName())
+				}
+			}
+		}
+	case "/otlp/v1/logs":
+		var export collectorlogspb.ExportLogsServiceRequest
+		if err := proto.Unmarshal(body, &export); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, rl := range export.GetResourceLogs() {
+			for _, sl := range rl.GetScopeLogs() {
+				for _, record := range sl.GetLogRecords() {
+					r.logs = append(r.logs, record.GetBody().GetStringValue())
+					r.traceIDs = append(r.traceIDs, record.GetTraceId())
+				}
+			}
+		}
+	default:
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+}
+
+func TestExport(t *testing.T) {
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	r := &receiver{}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	p, err := telemetry.Setup(t.Context(), logger, telemetry.Config{Endpoint: srv.URL + "/otlp", Logs: true})
+	require.NoError(t, err)
+	handler := p.LogHandler(slog.LevelInfo)
+	require.NotNil(t, handler)
+
+	ctx, span := telemetry.Start(t.Context(), "opamp.message")
+	slog.New(handler).InfoContext(ctx, "persisting agent health")
+	slog.New(handler).DebugContext(ctx, "below the level of the server")
+	telemetry.End(span, nil)
+	require.NoError(t, p.Shutdown(t.Context()))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	assert.Equal(t, []string{"opamp.message"}, r.spans)
+	assert.Equal(t, []string{"persisting agent health"}, r.logs)
+	traceID := span.SpanContext().TraceID()
+	assert.Equal(t, [][]byte{traceID[:]}, r.traceIDs, "logs are correlated with the span of their context")
+}
+
+// recordingExporter records the log records it exports
+type recordingExporter struct {
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+func TestLogHandler(t *testing.T) {
+	exporter := &recordingExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	logger := slog.New(telemetry.NewLogHandler(provider.Logger(telemetry.ScopeName), slog.LevelDebug))
+
+	logger.With("component", "opamp").WithGroup("agent").Warn("rejecting message", "id", "agent-1", slog.Group("health", "healthy", false))
+	logger.Log(t.Context(), slog.Level(-8), "below the level of the handler")
+
+	require.Len(t, exporter.records, 1)
+	record := exporter.records[0]
+	assert.Equal(t, "rejecting message", record.Body().AsString())
+	assert.Equal(t, log.SeverityWarn1, record.Severity())
+	assert.Equal(t, "WARN", record.SeverityText())
+	attrs := map[string]string{}
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.String()
+		return true
+	})
+	assert.Equal(t, map[string]string{
+		"component":            "opamp",
+		"agent.id":             "agent-1",
+		"agent.health.healthy": "false",
+	}, attrs)
+}
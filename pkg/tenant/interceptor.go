@@ -0,0 +1,86 @@
+package tenant
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/middleware"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+)
+
+// Interceptor attaches the tenant selected by the caller to the contexts of the management
+// API handlers it intercepts, once authorized by its Policy. It must run after the
+// interceptors authenticating the callers. The handlers of public services, called by agents
+// and gateways, are left without a tenant.
+type Interceptor struct {
+	Policy
+}
+
+var _ connect.Interceptor = Interceptor{}
+
+func (i Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		ctx, err := i.scope(ctx, req.Spec().Procedure, req.Header())
+		if err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		ctx, err := i.scope(ctx, conn.Spec().Procedure, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// Middleware attaches the tenant selected by the caller to the contexts of the raw HTTP
+// handlers of the management API it wraps, e.g. exports, like the interceptor. It reads
+// the principal authenticated by an outer middleware.
+func (i Interceptor) Middleware() middleware.Interface {
+	return middleware.Func(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, err := i.resolve(r.Context(), r.Header)
+			if err != nil {
+				status := http.StatusForbidden
+				if connect.CodeOf(err) == connect.CodeInvalidArgument {
+					status = http.StatusBadRequest
+				}
+				http.Error(w, err.Error(), status)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	})
+}
+
+// scope returns the context carrying the tenant of the request
+func (i Interceptor) scope(ctx context.Context, procedure string, headers http.Header) (context.Context, error) {
+	if _, authenticated := auth.RequiredRole(procedure); !authenticated {
+		return ctx, nil
+	}
+	return i.resolve(ctx, headers)
+}
+
+// resolve returns the context carrying the tenant selected in headers, once authorized
+func (i Interceptor) resolve(ctx context.Context, headers http.Header) (context.Context, error) {
+	name := strings.TrimSpace(headers.Get(Header))
+	if err := Validate(name); err != nil {
+		return ctx, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	name, err := i.Authorize(auth.FromContext(ctx), name)
+	if err != nil {
+		return ctx, connect.NewError(connect.CodePermissionDenied, err)
+	}
+	return NewContext(ctx, name), nil
+}
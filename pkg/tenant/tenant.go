@@ -0,0 +1,134 @@
+// Package tenant scopes bootstrap tokens, agents, configs and deployments to tenants, so
+// that teams can share one server without seeing each other's resources.
+//
+// The tenant of a management API request is selected by the Header sent by the caller, and
+// carried by the request context. Resources are created in the tenant of the request that
+// created them, agents in the tenant of the token they bootstrapped with, and are only
+// visible to the requests of their tenant. Resources created before tenants were introduced,
+// and by requests selecting no tenant, are in the default tenant, named "".
+//
+// Contexts outside of management API requests, e.g. of the OpAMP server or of background
+// controllers, carry no tenant and see the resources of all tenants.
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/otelfleet/otelfleet/pkg/auth"
+)
+
+const (
+	// Header selects the tenant of a management API request, the default tenant if unset
+	Header = "X-Otelfleet-Tenant"
+	// RolePrefix starts the roles granting access to a tenant, e.g. tenant:payments
+	RolePrefix = "tenant:"
+
+	// maxLength bounds the length of tenant names, so that they fit in a DNS label
+	maxLength = 63
+)
+
+// Role returns the role granting access to the tenant.
+func Role(name string) string {
+	return RolePrefix + name
+}
+
+// Validate returns an error if name is not a valid tenant name: lowercase alphanumeric
+// characters or '-', starting and ending with an alphanumeric character.
+func Validate(name string) error {
+	if name == "" {
+		return nil
+	}
+	if len(name) > maxLength {
+		return fmt.Errorf("tenant name must be at most %d characters", maxLength)
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		alnum := (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+		if !alnum && (c != '-' || i == 0 || i == len(name)-1) {
+			return fmt.Errorf("invalid tenant name %q: must consist of lowercase alphanumeric characters or '-', and start and end with an alphanumeric character", name)
+		}
+	}
+	return nil
+}
+
+type tenantKey struct{}
+
+// NewContext returns a context carrying the tenant of a request.
+func NewContext(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, &name)
+}
+
+// Unscoped returns a context carrying no tenant, seeing the resources of all tenants, for
+// the checks made on behalf of the server during a request.
+func Unscoped(ctx context.Context) context.Context {
+	return context.WithValue(ctx, tenantKey{}, (*string)(nil))
+}
+
+// FromContext returns the tenant of the request, and false outside of management API
+// requests.
+func FromContext(ctx context.Context) (string, bool) {
+	name, _ := ctx.Value(tenantKey{}).(*string)
+	if name == nil {
+		return "", false
+	}
+	return *name, true
+}
+
+// Visible returns true if a resource of the given tenant is visible to the context, i.e. it
+// is in the tenant of the request, or the context carries no tenant.
+func Visible(ctx context.Context, name string) bool {
+	want, ok := FromContext(ctx)
+	return !ok || want == name
+}
+
+// Authorize returns the tenant a principal may access by requesting name, or an error if it
+// may not. Principals granted tenant roles may only access those tenants, and default to
+// theirs if they are granted a single one. Other principals, and admins, may access any
+// tenant.
+func Authorize(p *auth.Principal, name string) (string, error) {
+	return Policy{}.Authorize(p, name)
+}
+
+// Policy decides which tenants the principals granted no tenant role may access
+type Policy struct {
+	// Restrict confines the principals granted no tenant role, other than admins, to
+	// DefaultTenant, e.g. in RBAC mode. Otherwise they may access any tenant.
+	Restrict bool
+	// DefaultTenant is the tenant restricted principals are confined to
+	DefaultTenant string
+}
+
+// Authorize is the package level Authorize, confining the principals granted no tenant role
+// to the default tenant of the policy if it is restrictive
+func (pol Policy) Authorize(p *auth.Principal, name string) (string, error) {
+	if p == nil || p.IsAdmin() {
+		return name, nil
+	}
+	var granted []string
+	for _, role := range p.Roles {
+		if t, ok := strings.CutPrefix(role, RolePrefix); ok {
+			granted = append(granted, t)
+		}
+	}
+	if len(granted) == 0 {
+		if !pol.Restrict {
+			return name, nil
+		}
+		if name != "" && name != pol.DefaultTenant {
+			return "", fmt.Errorf("%s is granted no tenant role, it may only access the default tenant", p.Subject)
+		}
+		return pol.DefaultTenant, nil
+	}
+	if name == "" && len(granted) == 1 {
+		return granted[0], nil
+	}
+	if !p.HasRole(Role(name)) {
+		if name == "" {
+			return "", fmt.Errorf("%s must select one of its tenants with the %s header", p.Subject, Header)
+		}
+		return "", fmt.Errorf("%s may not access tenant %s", p.Subject, name)
+	}
+	return name, nil
+}
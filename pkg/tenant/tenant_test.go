@@ -0,0 +1,172 @@
+package tenant_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/otelfleet/otelfleet/pkg/auth"
+	"github.com/otelfleet/otelfleet/pkg/tenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestValidate(t *testing.T) {
+	for _, name := range []string{"", "a", "team-a", "0ps"} {
+		assert.NoError(t, tenant.Validate(name), name)
+	}
+	for _, name := range []string{"Team", "-a", "a-", "a_b", "a.b", string(make([]byte, 64))} {
+		assert.Error(t, tenant.Validate(name), name)
+	}
+}
+
+func TestVisible(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, tenant.Visible(ctx, "team-a"), "contexts without a tenant see all tenants")
+
+	teamA := tenant.NewContext(ctx, "team-a")
+	assert.True(t, tenant.Visible(teamA, "team-a"))
+	assert.False(t, tenant.Visible(teamA, ""))
+	assert.True(t, tenant.Visible(tenant.Unscoped(teamA), "team-b"))
+
+	assert.False(t, tenant.Visible(tenant.NewContext(ctx, ""), "team-a"))
+}
+
+func TestAuthorize(t *testing.T) {
+	member := &auth.Principal{Subject: "alice", Roles: []string{auth.RoleOperator, tenant.Role("team-a")}}
+	twoTeams := &auth.Principal{Subject: "bob", Roles: []string{tenant.Role("team-a"), tenant.Role("team-b")}}
+	admin := &auth.Principal{Subject: "root", Roles: []string{auth.RoleAdmin}}
+	shared := &auth.Principal{Subject: "carol", Roles: []string{auth.RoleViewer}}
+
+	for _, tc := range []struct {
+		name      string
+		principal *auth.Principal
+		requested string
+		want      string
+		wantErr   bool
+	}{
+		{name: "anonymous", requested: "team-b", want: "team-b"},
+		{name: "admin", principal: admin, requested: "team-b", want: "team-b"},
+		{name: "no tenant roles", principal: shared, requested: "team-b", want: "team-b"},
+		{name: "member", principal: member, requested: "team-a", want: "team-a"},
+		{name: "member defaults to its tenant", principal: member, want: "team-a"},
+		{name: "member of another tenant", principal: member, requested: "team-b", wantErr: true},
+		{name: "member of several tenants must select one", principal: twoTeams, wantErr: true},
+		{name: "member of several tenants", principal: twoTeams, requested: "team-b", want: "team-b"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tenant.Authorize(tc.principal, tc.requested)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestPolicy_Restrict(t *testing.T) {
+	shared := &auth.Principal{Subject: "carol", Roles: []string{auth.RoleViewer}}
+	admin := &auth.Principal{Subject: "root", Roles: []string{auth.RoleAdmin}}
+	member := &auth.Principal{Subject: "alice", Roles: []string{tenant.Role("team-a")}}
+
+	// principals granted no tenant role are confined to the default tenant
+	policy := tenant.Policy{Restrict: true}
+	got, err := policy.Authorize(shared, "")
+	require.NoError(t, err)
+	assert.Equal(t, "", got)
+	_, err = policy.Authorize(shared, "team-b")
+	assert.Error(t, err)
+	got, err = policy.Authorize(admin, "team-b")
+	require.NoError(t, err)
+	assert.Equal(t, "team-b", got)
+	got, err = policy.Authorize(member, "")
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", got)
+
+	policy.DefaultTenant = "shared"
+	got, err = policy.Authorize(shared, "")
+	require.NoError(t, err)
+	assert.Equal(t, "shared", got)
+	_, err = policy.Authorize(shared, "team-b")
+	assert.Error(t, err)
+}
+
+func TestInterceptor_Middleware(t *testing.T) {
+	var got *string
+	handler := tenant.Interceptor{Policy: tenant.Policy{Restrict: true}}.Middleware().Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if name, ok := tenant.FromContext(r.Context()); ok {
+			got = &name
+		}
+	}))
+	get := func(ctx context.Context, name string) int {
+		got = nil
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/api/export", nil)
+		req.Header.Set(tenant.Header, name)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	member := auth.NewContext(t.Context(), &auth.Principal{Subject: "alice", Roles: []string{tenant.Role("team-a")}})
+	assert.Equal(t, http.StatusOK, get(member, ""))
+	require.NotNil(t, got)
+	assert.Equal(t, "team-a", *got)
+	assert.Equal(t, http.StatusForbidden, get(member, "team-b"))
+	assert.Equal(t, http.StatusBadRequest, get(member, "Team A"))
+	shared := auth.NewContext(t.Context(), &auth.Principal{Subject: "carol", Roles: []string{auth.RoleViewer}})
+	assert.Equal(t, http.StatusForbidden, get(shared, "team-a"))
+}
+
+func TestInterceptor(t *testing.T) {
+	var got *string
+	handler := tenant.Interceptor{}.WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if name, ok := tenant.FromContext(ctx); ok {
+			got = &name
+		}
+		return connect.NewResponse(&emptypb.Empty{}), nil
+	})
+	call := func(ctx context.Context, procedure, name string) error {
+		got = nil
+		req := connect.NewRequest(&emptypb.Empty{})
+		if name != "" {
+			req.Header().Set(tenant.Header, name)
+		}
+		_, err := handler(ctx, &procedureRequest{Request: req, procedure: procedure})
+		return err
+	}
+	const listAgents = "/agents.v1alpha1.AgentService/ListAgents"
+
+	require.NoError(t, call(t.Context(), listAgents, "team-a"))
+	require.NotNil(t, got)
+	assert.Equal(t, "team-a", *got)
+
+	require.NoError(t, call(t.Context(), listAgents, ""))
+	require.NotNil(t, got)
+	assert.Equal(t, "", *got, "requests without a tenant are in the default tenant")
+
+	err := call(t.Context(), listAgents, "Team A")
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	member := auth.NewContext(t.Context(), &auth.Principal{Subject: "alice", Roles: []string{tenant.Role("team-a")}})
+	err = call(member, listAgents, "team-b")
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// agents bootstrap without a tenant, they join the tenant of their token
+	require.NoError(t, call(t.Context(), "/bootstrap.v1alpha1.BootstrapService/Bootstrap", "team-a"))
+	assert.Nil(t, got)
+}
+
+// procedureRequest overrides the procedure of a request built by connect.NewRequest
+type procedureRequest struct {
+	*connect.Request[emptypb.Empty]
+	procedure string
+}
+
+func (r *procedureRequest) Spec() connect.Spec {
+	return connect.Spec{Procedure: r.procedure}
+}
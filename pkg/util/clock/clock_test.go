@@ -0,0 +1,41 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFake(start)
+
+	short, long := c.After(time.Second), c.After(time.Minute)
+	require.True(t, c.BlockUntil(2, time.Second))
+
+	c.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(30*time.Second), <-short)
+	assert.Equal(t, 1, c.Waiters())
+	select {
+	case <-long:
+		t.Fatal("fired before it was due")
+	default:
+	}
+
+	c.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(time.Minute), <-long)
+	assert.Equal(t, time.Duration(0), Until(c, start.Add(time.Minute)))
+
+	// BlockUntil returns once the code under test waits on the clock
+	done := make(chan struct{})
+	go func() {
+		<-c.After(time.Hour)
+		close(done)
+	}()
+	require.True(t, c.BlockUntil(1, time.Second))
+	c.Advance(time.Hour)
+	<-done
+	assert.False(t, c.BlockUntil(1, 10*time.Millisecond))
+}
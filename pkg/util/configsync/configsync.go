@@ -15,12 +15,17 @@ import (
 // It compares the assigned config hash with the agent-reported hash and maps
 // the OpAMP status to our unified ConfigSyncStatus.
 //
+// capabilities is the agent's last reported capability bitmask, or zero when
+// the agent has never connected. An agent known to lack AcceptsRemoteConfig is
+// reported as UNSUPPORTED instead of waiting on a status it will never send.
+//
 // This function is shared between AgentService and ConfigServer to ensure
 // consistent status computation.
 func ComputeConfigSyncStatus(
 	ctx context.Context,
 	agentID string,
 	assignedHash []byte,
+	capabilities uint64,
 	remoteStatusStore storage.KeyValue[*protobufs.RemoteConfigStatus],
 ) (v1alpha1.ConfigSyncStatus, string, error) {
 	// If no assigned hash, we can't determine sync status
@@ -28,6 +33,10 @@ func ComputeConfigSyncStatus(
 		return v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_UNKNOWN, "no assigned config", nil
 	}
 
+	if capabilities != 0 && capabilities&uint64(protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig) == 0 {
+		return v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_UNSUPPORTED, "agent does not accept remote config", nil
+	}
+
 	remoteStatus, err := remoteStatusStore.Get(ctx, agentID)
 	if grpcutil.IsErrorNotFound(err) {
 		return v1alpha1.ConfigSyncStatus_CONFIG_SYNC_STATUS_OUT_OF_SYNC, "no status reported", nil
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+)
+
+// MaxConfigFileNameLength bounds the length of a config file name sent to agents
+const MaxConfigFileNameLength = 128
+
+// ValidateConfigFileName checks that name is a plain file name that agents can safely
+// join onto their config directory : no path separators, no "." or ".." and no
+// control characters.
+func ValidateConfigFileName(name string) error {
+	switch {
+	case name == "":
+		return errors.New("empty file name")
+	case len(name) > MaxConfigFileNameLength:
+		return fmt.Errorf("file name longer than %d bytes", MaxConfigFileNameLength)
+	case name == "." || name == "..":
+		return errors.New("file name must not be a directory")
+	case strings.ContainsAny(name, `/\`), !filepath.IsLocal(name):
+		return errors.New("file name must not contain a path")
+	case strings.ContainsFunc(name, unicode.IsControl):
+		return errors.New("file name must not contain control characters")
+	}
+	return nil
+}
+
+// ValidateAgentConfigMap checks every file name in configMap with ValidateConfigFileName
+func ValidateAgentConfigMap(configMap *protobufs.AgentConfigMap) error {
+	for name := range configMap.GetConfigMap() {
+		if err := ValidateConfigFileName(name); err != nil {
+			return fmt.Errorf("invalid config file name %q: %w", name, err)
+		}
+	}
+	return nil
+}
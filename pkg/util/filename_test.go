@@ -0,0 +1,42 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfigFileName(t *testing.T) {
+	for _, name := range []string{"config.yaml", "otelcol-extra.yml", "..config.yaml", "config..yaml"} {
+		assert.NoError(t, ValidateConfigFileName(name), name)
+	}
+	for _, name := range []string{
+		"",
+		".",
+		"..",
+		"../config.yaml",
+		"../../etc/cron.d/x",
+		"/etc/cron.d/x",
+		"sub/config.yaml",
+		`..\config.yaml`,
+		`C:\config.yaml`,
+		"config\x00.yaml",
+		"config\n.yaml",
+		strings.Repeat("a", MaxConfigFileNameLength+1),
+	} {
+		assert.Error(t, ValidateConfigFileName(name), name)
+	}
+}
+
+func TestValidateAgentConfigMap(t *testing.T) {
+	assert.NoError(t, ValidateAgentConfigMap(nil))
+	assert.NoError(t, ValidateAgentConfigMap(ProtoConfigToAgentConfigMap(nil)))
+	assert.ErrorContains(t, ValidateAgentConfigMap(&protobufs.AgentConfigMap{
+		ConfigMap: map[string]*protobufs.AgentConfigFile{
+			"config.yaml":        {},
+			"../../etc/cron.d/x": {},
+		},
+	}), `"../../etc/cron.d/x"`)
+}
@@ -51,3 +51,13 @@ func HashAgentConfigMap(configMap *protobufs.AgentConfigMap) []byte {
 
 	return h.Sum(nil)
 }
+
+// RemoteConfigHash returns the hash an agent should report for an applied remote config.
+// The server provided hash is preferred, since the delivered body may differ from the
+// stored config (e.g. after secret substitution); otherwise it is computed from the body.
+func RemoteConfigHash(remoteConfig *protobufs.AgentRemoteConfig) []byte {
+	if hash := remoteConfig.GetConfigHash(); len(hash) > 0 {
+		return hash
+	}
+	return HashAgentConfigMap(remoteConfig.GetConfig())
+}
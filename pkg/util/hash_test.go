@@ -192,3 +192,20 @@ func TestHashAgentConfigMap_NilFileSkipped(t *testing.T) {
 	assert.NotNil(t, hash)
 	assert.Len(t, hash, 32)
 }
+
+func TestRemoteConfigHash(t *testing.T) {
+	configMap := &protobufs.AgentConfigMap{
+		ConfigMap: map[string]*protobufs.AgentConfigFile{
+			"config.yaml": {Body: []byte("content")},
+		},
+	}
+
+	// Falls back to hashing the body when no hash is provided
+	assert.Equal(t, HashAgentConfigMap(configMap), RemoteConfigHash(&protobufs.AgentRemoteConfig{Config: configMap}))
+
+	// Prefers the server provided hash
+	assert.Equal(t, []byte("server-hash"), RemoteConfigHash(&protobufs.AgentRemoteConfig{
+		Config:     configMap,
+		ConfigHash: []byte("server-hash"),
+	}))
+}
@@ -0,0 +1,69 @@
+// Package parallel runs independent operations, typically storage operations on many keys,
+// with bounded concurrency.
+package parallel
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultLimit is the number of operations run at once unless configured otherwise
+const DefaultLimit = 8
+
+// Limit returns limit, or DefaultLimit if it is not positive
+func Limit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	return limit
+}
+
+// ForEach calls fn for every item, with at most limit calls running at once. Every item
+// is processed regardless of the failures of the others, and the errors are returned
+// joined in the order of the items.
+func ForEach[T any](ctx context.Context, limit int, items []T, fn func(ctx context.Context, item T) error) error {
+	errs := Collect(ctx, limit, items, fn)
+	return errors.Join(errs...)
+}
+
+// Collect is ForEach returning the error of each item, nil for the items that succeeded
+func Collect[T any](ctx context.Context, limit int, items []T, fn func(ctx context.Context, item T) error) []error {
+	errs := make([]error, len(items))
+	g := &errgroup.Group{}
+	g.SetLimit(Limit(limit))
+	for i, item := range items {
+		g.Go(func() error {
+			errs[i] = fn(ctx, item)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return errs
+}
+
+// ForEachUntilError is ForEach stopping at the first error: the calls not started yet
+// are skipped, the context of the running ones is cancelled and the first error is
+// returned. It returns the error of ctx if it is done before every item is processed.
+func ForEachUntilError[T any](ctx context.Context, limit int, items []T, fn func(ctx context.Context, item T) error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(Limit(limit))
+	skipped := false
+	for _, item := range items {
+		if gctx.Err() != nil {
+			skipped = true
+			break
+		}
+		g.Go(func() error {
+			return fn(gctx, item)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if skipped {
+		return ctx.Err()
+	}
+	return nil
+}
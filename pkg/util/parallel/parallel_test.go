@@ -0,0 +1,70 @@
+package parallel_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/otelfleet/otelfleet/pkg/util/parallel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollect(t *testing.T) {
+	errOdd := errors.New("odd")
+	items := []int{0, 1, 2, 3, 4, 5}
+	var running, maxRunning atomic.Int32
+	errs := parallel.Collect(t.Context(), 2, items, func(_ context.Context, i int) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if i%2 == 1 {
+			return errOdd
+		}
+		return nil
+	})
+	assert.Equal(t, []error{nil, errOdd, nil, errOdd, nil, errOdd}, errs)
+	assert.LessOrEqual(t, maxRunning.Load(), int32(2))
+
+	err := parallel.ForEach(t.Context(), 0, items, func(_ context.Context, i int) error {
+		if i == 3 {
+			return errOdd
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errOdd)
+}
+
+func TestForEachUntilError(t *testing.T) {
+	errFailed := errors.New("failed")
+	var processed atomic.Int32
+	items := make([]int, 100)
+	err := parallel.ForEachUntilError(t.Context(), 1, items, func(_ context.Context, _ int) error {
+		if processed.Add(1) == 3 {
+			return errFailed
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errFailed)
+	assert.Less(t, processed.Load(), int32(len(items)))
+
+	processed.Store(0)
+	require.NoError(t, parallel.ForEachUntilError(t.Context(), 4, items, func(_ context.Context, _ int) error {
+		processed.Add(1)
+		return nil
+	}))
+	assert.Equal(t, int32(len(items)), processed.Load())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	err = parallel.ForEachUntilError(ctx, 4, items, func(context.Context, int) error { return nil })
+	assert.ErrorIs(t, err, context.Canceled)
+}
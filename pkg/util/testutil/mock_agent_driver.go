@@ -34,11 +34,26 @@ type MockAgentDriver struct {
 	// FailUpdateError is the error to return when FailNextUpdate is true.
 	FailUpdateError error
 
+	// updateErr is returned by every Update call while set, see FailUpdates.
+	updateErr error
+
 	// UpdateDelay adds artificial delay to Update calls.
 	UpdateDelay time.Duration
 
 	// UpdateCount tracks the number of successful updates.
 	UpdateCount int
+
+	// restartErr is returned by the next Restart call, see FailNextRestart.
+	restartErr error
+
+	// RestartCount tracks the number of successful restarts.
+	RestartCount int
+
+	// BinaryPath is the collector binary the agent was last restarted with, see UpdateBinary.
+	BinaryPath string
+
+	// onUpdate is called after every applied config, outside of the lock
+	onUpdate func()
 }
 
 // Ensure MockAgentDriver implements AgentDriver.
@@ -55,6 +70,14 @@ func NewMockAgentDriver(reportFn func(bool, string, string)) *MockAgentDriver {
 
 // Update applies a new configuration.
 func (m *MockAgentDriver) Update(ctx context.Context, incoming *protobufs.AgentRemoteConfig) error {
+	applied, err := m.update(ctx, incoming)
+	if applied && m.onUpdate != nil {
+		m.onUpdate()
+	}
+	return err
+}
+
+func (m *MockAgentDriver) update(ctx context.Context, incoming *protobufs.AgentRemoteConfig) (bool, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -62,29 +85,32 @@ func (m *MockAgentDriver) Update(ctx context.Context, incoming *protobufs.AgentR
 		select {
 		case <-time.After(m.UpdateDelay):
 		case <-ctx.Done():
-			return ctx.Err()
+			return false, ctx.Err()
 		}
 	}
 
 	if m.FailNextUpdate {
 		m.FailNextUpdate = false
-		return m.FailUpdateError
+		return false, m.FailUpdateError
+	}
+	if m.updateErr != nil {
+		return false, m.updateErr
 	}
 
 	// Skip if hash matches
 	incomingHash := incoming.GetConfigHash()
 	if len(m.CurrentHash) > 0 && len(incomingHash) > 0 {
 		if string(m.CurrentHash) == string(incomingHash) {
-			return nil
+			return false, nil
 		}
 	}
 
 	m.CurrentConfig = incoming
-	m.CurrentHash = util.HashAgentConfigMap(incoming.GetConfig())
+	m.CurrentHash = util.RemoteConfigHash(incoming)
 	m.ConfigHistory = append(m.ConfigHistory, incoming)
 	m.UpdateCount++
 
-	return nil
+	return true, nil
 }
 
 // GetConfigMap returns the current effective configuration.
@@ -106,6 +132,13 @@ func (m *MockAgentDriver) GetConfigMap() (*protobufs.AgentConfigMap, error) {
 	return m.CurrentConfig.GetConfig(), nil
 }
 
+// GetCurrentConfig returns the most recently applied configuration, nil if none was applied.
+func (m *MockAgentDriver) GetCurrentConfig() *protobufs.AgentRemoteConfig {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.CurrentConfig
+}
+
 // GetCurrentHash returns the hash of the currently applied configuration.
 func (m *MockAgentDriver) GetCurrentHash() []byte {
 	m.mu.Lock()
@@ -113,6 +146,54 @@ func (m *MockAgentDriver) GetCurrentHash() []byte {
 	return m.CurrentHash
 }
 
+// Restart records the restart, no process is restarted.
+func (m *MockAgentDriver) Restart(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.restartErr; err != nil {
+		m.restartErr = nil
+		return err
+	}
+	m.RestartCount++
+	return nil
+}
+
+// FailUpdates causes every Update call to return err, until it is called with nil.
+func (m *MockAgentDriver) FailUpdates(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updateErr = err
+}
+
+// FailNextRestart causes the next Restart call to return err.
+func (m *MockAgentDriver) FailNextRestart(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.restartErr = err
+}
+
+// GetRestartCount returns the number of successful restarts.
+func (m *MockAgentDriver) GetRestartCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.RestartCount
+}
+
+// UpdateBinary records the binary path, no process is restarted.
+func (m *MockAgentDriver) UpdateBinary(ctx context.Context, path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.BinaryPath = path
+	return nil
+}
+
+// GetBinaryPath returns the collector binary the agent was last restarted with.
+func (m *MockAgentDriver) GetBinaryPath() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.BinaryPath
+}
+
 // Shutdown is a no-op for the mock.
 func (m *MockAgentDriver) Shutdown() error {
 	return nil
@@ -143,4 +224,8 @@ func (m *MockAgentDriver) Reset() {
 	m.ConfigHistory = make([]*protobufs.AgentRemoteConfig, 0)
 	m.UpdateCount = 0
 	m.FailNextUpdate = false
+	m.updateErr = nil
+	m.RestartCount = 0
+	m.restartErr = nil
+	m.BinaryPath = ""
 }
@@ -62,6 +62,7 @@ func (e *TestEnv) NewAgentWithLabels(agentID string, labels map[string]string) *
 
 	// Create mock agent driver
 	agentDriver := NewMockAgentDriver(nil)
+	agentDriver.onUpdate = e.changes.signal
 
 	// Create test identity
 	identity := &testIdentity{id: agentID}
@@ -138,6 +139,7 @@ func (e *TestEnv) NewAgentWithBootstrap(agentID string, name string, labels map[
 
 	// Step 4: Create mock agent driver and supervisor with labels as extra attributes
 	agentDriver := NewMockAgentDriver(nil)
+	agentDriver.onUpdate = e.changes.signal
 
 	sup := supervisor.NewSupervisor(
 		logger,
@@ -208,25 +210,15 @@ func (a *TestAgent) IsStarted() bool {
 // WaitForConfig waits for the agent to receive a configuration.
 func (a *TestAgent) WaitForConfig(t *testing.T, timeout time.Duration) {
 	t.Helper()
-	deadline := time.Now().Add(timeout)
-	for time.Now().Before(deadline) {
-		if a.AgentDriver.CurrentConfig != nil {
-			return
-		}
-		time.Sleep(10 * time.Millisecond)
-	}
-	t.Fatalf("agent %s did not receive config within %v", a.ID, timeout)
+	a.env.WaitFor(t, timeout, func() bool {
+		return a.AgentDriver.GetCurrentConfig() != nil
+	}, "agent %s did not receive config", a.ID)
 }
 
 // WaitForConfigCount waits until the agent has received at least n config updates.
 func (a *TestAgent) WaitForConfigCount(t *testing.T, n int, timeout time.Duration) {
 	t.Helper()
-	deadline := time.Now().Add(timeout)
-	for time.Now().Before(deadline) {
-		if a.AgentDriver.GetUpdateCount() >= n {
-			return
-		}
-		time.Sleep(10 * time.Millisecond)
-	}
-	t.Fatalf("agent %s did not receive %d configs within %v (got %d)", a.ID, n, timeout, a.AgentDriver.GetUpdateCount())
+	a.env.WaitFor(t, timeout, func() bool {
+		return a.AgentDriver.GetUpdateCount() >= n
+	}, "agent %s did not receive %d configs", a.ID, n)
 }
@@ -1,32 +1,35 @@
 package testutil
 
 import (
+	"context"
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
 	"io"
 	"log/slog"
-	"net/http"
 	"net/http/httptest"
 	"sync"
 	"testing"
+	"time"
 
-	"github.com/cockroachdb/pebble/v2"
-	"github.com/cockroachdb/pebble/v2/vfs"
 	"github.com/gorilla/mux"
+	"github.com/grafana/dskit/services"
 	"github.com/open-telemetry/opamp-go/protobufs"
-	"github.com/open-telemetry/opamp-go/server"
 	agentsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
 	bootstrapv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
 	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	jobsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/jobs/v1alpha1"
 	agentdomain "github.com/otelfleet/otelfleet/pkg/domain/agent"
 	"github.com/otelfleet/otelfleet/pkg/services/agent"
 	"github.com/otelfleet/otelfleet/pkg/services/bootstrap"
 	"github.com/otelfleet/otelfleet/pkg/services/deployment"
+	"github.com/otelfleet/otelfleet/pkg/services/jobs"
+	"github.com/otelfleet/otelfleet/pkg/services/notify"
 	"github.com/otelfleet/otelfleet/pkg/services/opamp"
 	"github.com/otelfleet/otelfleet/pkg/services/otelconfig"
 	"github.com/otelfleet/otelfleet/pkg/storage"
-	otelpebble "github.com/otelfleet/otelfleet/pkg/storage/pebble"
+	"github.com/otelfleet/otelfleet/pkg/storage/memory"
+	"github.com/otelfleet/otelfleet/pkg/util/clock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,11 +37,18 @@ func init() {
 	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
 }
 
+// Gateways that may relay agents to a TestEnv, authenticated by their tokens
+const (
+	TestGatewayID         = "eu-west"
+	TestGatewayToken      = "eu-west-token"
+	TestOtherGatewayID    = "us-east"
+	TestOtherGatewayToken = "us-east-token"
+)
+
 // TestEnv provides a complete OtelFleet server environment for integration testing.
 // All KV stores and services are exposed for direct test access.
 type TestEnv struct {
 	// Storage
-	db     *pebble.DB
 	Broker storage.KVBroker
 
 	// KV Stores - all exposed for direct test manipulation
@@ -49,7 +59,7 @@ type TestEnv struct {
 	DefaultConfigStore         storage.KeyValue[*configv1alpha1.Config]
 	BootstrapConfigStore       storage.KeyValue[*configv1alpha1.Config]
 	AssignedConfigStore        storage.KeyValue[*configv1alpha1.Config]
-	ConfigAssignmentStore      storage.KeyValue[*configv1alpha1.ConfigAssignment]
+	ConfigAssignmentStore      storage.IndexedKeyValue[*configv1alpha1.ConfigAssignment]
 	HealthStore                storage.KeyValue[*protobufs.ComponentHealth]
 	EffectiveConfigStore       storage.KeyValue[*protobufs.EffectiveConfig]
 	RemoteStatusStore          storage.KeyValue[*protobufs.RemoteConfigStatus]
@@ -58,6 +68,25 @@ type TestEnv struct {
 	AgentDeploymentStore       storage.KeyValue[*configv1alpha1.AgentDeploymentStatus]
 	// ConnectionStateStore replaces the in-memory AgentTracker
 	ConnectionStateStore storage.KeyValue[*agentsv1alpha1.AgentConnectionState]
+	SnapshotStore        storage.KeyValue[*agentsv1alpha1.AgentSnapshot]
+	FleetSnapshotStore   storage.KeyValue[*agentsv1alpha1.FleetSnapshot]
+	ConfigPushStore      storage.KeyValue[*agentsv1alpha1.ConfigPushHistory]
+	CommandStore         storage.KeyValue[*agentsv1alpha1.AgentCommandStatus]
+	ProblemStore         storage.KeyValue[*agentsv1alpha1.AgentProblem]
+	AvailabilityStore    storage.KeyValue[*agentsv1alpha1.AvailabilityHistory]
+	AgentMetricsStore    storage.KeyValue[*agentsv1alpha1.AgentMetrics]
+	PolicyStore          storage.KeyValue[*configv1alpha1.AssignmentPolicy]
+	GroupStore           storage.KeyValue[*configv1alpha1.AgentGroup]
+	ComponentPolicyStore storage.KeyValue[*configv1alpha1.ComponentPolicy]
+	DistributionStore    storage.KeyValue[*configv1alpha1.CollectorDistribution]
+	PackageTargetStore   storage.KeyValue[*configv1alpha1.PackageTarget]
+	PackageStatusStore   storage.KeyValue[*configv1alpha1.AgentPackageStatus]
+	// BootstrapAssignmentStore records the config each agent was bootstrapped with
+	BootstrapAssignmentStore storage.KeyValue[*configv1alpha1.ConfigAssignment]
+	JobStore                 storage.KeyValue[*jobsv1alpha1.Job]
+	EditSessionStore         storage.KeyValue[*configv1alpha1.ConfigEditSession]
+	KnownGoodStore           storage.KeyValue[*configv1alpha1.KnownGoodConfig]
+	ConfigHistoryStore       storage.KeyValue[*configv1alpha1.AgentConfigHistory]
 
 	// Agent Repository - unified access to agent data
 	AgentRepo agentdomain.Repository
@@ -68,6 +97,12 @@ type TestEnv struct {
 	OpampServer          *opamp.Server
 	AgentServer          *agent.AgentServer
 	DeploymentController *deployment.Controller
+	// JobQueue runs deployments and async batch assignments, it is started with the environment
+	JobQueue *jobs.Queue
+	// Notifier delivers the config changes of ConfigServer to OpampServer
+	Notifier *notify.InProcess
+	// Clock times deployments when the environment is created WithFakeClock, nil otherwise
+	Clock *clock.Fake
 
 	// HTTP
 	HTTPServer    *httptest.Server
@@ -87,20 +122,30 @@ type TestEnv struct {
 	// Track test agents
 	mu     sync.Mutex
 	agents map[string]*TestAgent
+	// config change notifications by agent ID
+	notifications map[string]int
+	changes       *changes
+}
+
+// Option configures a TestEnv
+type Option func(*TestEnv)
+
+// WithFakeClock times deployments with a fake clock set to start, exposed as TestEnv.Clock,
+// so that tests advance it instead of waiting out batch delays and jitter
+func WithFakeClock(start time.Time) Option {
+	return func(e *TestEnv) {
+		e.Clock = clock.NewFake(start)
+	}
 }
 
 // NewTestEnv creates a new test environment with all services initialized.
-// The environment uses in-memory storage and httptest servers.
-func NewTestEnv(t *testing.T) *TestEnv {
+// The environment uses in-memory storage and httptest servers listening on their own
+// ephemeral ports, so that tests using separate environments can run in parallel.
+func NewTestEnv(t *testing.T, opts ...Option) *TestEnv {
 	t.Helper()
 
-	// Create in-memory Pebble database
-	db, err := pebble.Open("", &pebble.Options{
-		FS: vfs.NewMem(),
-	})
-	require.NoError(t, err)
-
-	broker := otelpebble.NewKVBroker(db)
+	changes := newChanges()
+	broker := &watchedBroker{KVBroker: memory.NewKVBroker(), changes: changes}
 	logger := slog.Default()
 
 	// Generate a test RSA key for bootstrap signing
@@ -108,12 +153,16 @@ func NewTestEnv(t *testing.T) *TestEnv {
 	require.NoError(t, err)
 
 	env := &TestEnv{
-		db:         db,
-		Broker:     broker,
-		Logger:     logger,
-		PrivateKey: privateKey,
-		t:          t,
-		agents:     make(map[string]*TestAgent),
+		Broker:        broker,
+		Logger:        logger,
+		PrivateKey:    privateKey,
+		t:             t,
+		agents:        make(map[string]*TestAgent),
+		notifications: make(map[string]int),
+		changes:       changes,
+	}
+	for _, opt := range opts {
+		opt(env)
 	}
 
 	// Initialize all KV stores
@@ -128,6 +177,8 @@ func NewTestEnv(t *testing.T) *TestEnv {
 	// Setup HTTP servers
 	env.setupHTTPServers(t)
 
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), env.JobQueue))
+
 	// Register cleanup
 	t.Cleanup(func() {
 		env.Close()
@@ -140,18 +191,38 @@ func (e *TestEnv) initStores(logger *slog.Logger, broker storage.KVBroker) {
 	e.TokenStore = storage.NewProtoKV[*bootstrapv1alpha1.BootstrapToken](logger, broker.KeyValue("tokens"))
 	e.AgentStore = storage.NewProtoKV[*agentsv1alpha1.AgentDescription](logger, broker.KeyValue("agents"))
 	e.OpampAgentStore = storage.NewProtoKV[*protobufs.AgentToServer](logger, broker.KeyValue("opamp-agents"))
-	e.ConfigStore = storage.NewProtoKV[*configv1alpha1.Config](logger, broker.KeyValue("configs"))
-	e.DefaultConfigStore = storage.NewProtoKV[*configv1alpha1.Config](logger, broker.KeyValue("default-configs"))
-	e.BootstrapConfigStore = storage.NewProtoKV[*configv1alpha1.Config](logger, broker.KeyValue("bootstrap-configs"))
-	e.AssignedConfigStore = storage.NewProtoKV[*configv1alpha1.Config](logger, broker.KeyValue("assigned-configs"))
-	e.ConfigAssignmentStore = storage.NewProtoKV[*configv1alpha1.ConfigAssignment](logger, broker.KeyValue("config-assignments"))
+	e.ConfigStore = storage.NewProtoKV[*configv1alpha1.Config](logger, broker.KeyValue("configs"), storage.WithCompression(0))
+	e.DefaultConfigStore = storage.NewProtoKV[*configv1alpha1.Config](logger, broker.KeyValue("default-configs"), storage.WithCompression(0))
+	e.BootstrapConfigStore = storage.NewProtoKV[*configv1alpha1.Config](logger, broker.KeyValue("bootstrap-configs"), storage.WithCompression(0))
+	e.AssignedConfigStore = storage.NewProtoKV[*configv1alpha1.Config](logger, broker.KeyValue("assigned-configs"), storage.WithCompression(0))
+	e.ConfigAssignmentStore = storage.NewIndexedProtoKV[*configv1alpha1.ConfigAssignment](
+		logger, storage.OpenIndexed(broker, "config-assignments", otelconfig.AssignmentIndexes...),
+	)
 	e.HealthStore = storage.NewProtoKV[*protobufs.ComponentHealth](logger, broker.KeyValue("agent-health"))
-	e.EffectiveConfigStore = storage.NewProtoKV[*protobufs.EffectiveConfig](logger, broker.KeyValue("effective-config"))
+	e.EffectiveConfigStore = storage.NewProtoKV[*protobufs.EffectiveConfig](logger, broker.KeyValue("effective-config"), storage.WithCompression(0))
 	e.RemoteStatusStore = storage.NewProtoKV[*protobufs.RemoteConfigStatus](logger, broker.KeyValue("remote-config-status"))
 	e.OpampAgentDescriptionStore = storage.NewProtoKV[*protobufs.AgentDescription](logger, broker.KeyValue("opamp-agent-description"))
 	e.DeploymentStore = storage.NewProtoKV[*configv1alpha1.DeploymentStatus](logger, broker.KeyValue("deployments"))
 	e.AgentDeploymentStore = storage.NewProtoKV[*configv1alpha1.AgentDeploymentStatus](logger, broker.KeyValue("agent-deployments"))
 	e.ConnectionStateStore = storage.NewProtoKV[*agentsv1alpha1.AgentConnectionState](logger, broker.KeyValue("connection-state"))
+	e.SnapshotStore = storage.NewProtoKV[*agentsv1alpha1.AgentSnapshot](logger, broker.KeyValue("agent-snapshots"))
+	e.FleetSnapshotStore = storage.NewProtoKV[*agentsv1alpha1.FleetSnapshot](logger, broker.KeyValue("fleet-snapshots"))
+	e.ConfigPushStore = storage.NewProtoKV[*agentsv1alpha1.ConfigPushHistory](logger, broker.KeyValue("config-pushes"))
+	e.CommandStore = storage.NewProtoKV[*agentsv1alpha1.AgentCommandStatus](logger, broker.KeyValue("agent-commands"))
+	e.ProblemStore = storage.NewProtoKV[*agentsv1alpha1.AgentProblem](logger, broker.KeyValue("agent-problems"))
+	e.AvailabilityStore = storage.NewProtoKV[*agentsv1alpha1.AvailabilityHistory](logger, broker.KeyValue("agent-availability"))
+	e.AgentMetricsStore = storage.NewProtoKV[*agentsv1alpha1.AgentMetrics](logger, broker.KeyValue("agent-metrics"))
+	e.PolicyStore = storage.NewProtoKV[*configv1alpha1.AssignmentPolicy](logger, broker.KeyValue("assignment-policies"))
+	e.GroupStore = storage.NewProtoKV[*configv1alpha1.AgentGroup](logger, broker.KeyValue("agent-groups"))
+	e.ComponentPolicyStore = storage.NewProtoKV[*configv1alpha1.ComponentPolicy](logger, broker.KeyValue("component-policies"))
+	e.DistributionStore = storage.NewProtoKV[*configv1alpha1.CollectorDistribution](logger, broker.KeyValue("collector-distributions"))
+	e.PackageTargetStore = storage.NewProtoKV[*configv1alpha1.PackageTarget](logger, broker.KeyValue("package-targets"))
+	e.PackageStatusStore = storage.NewProtoKV[*configv1alpha1.AgentPackageStatus](logger, broker.KeyValue("agent-package-statuses"))
+	e.BootstrapAssignmentStore = storage.NewProtoKV[*configv1alpha1.ConfigAssignment](logger, broker.KeyValue("bootstrap-assignments"))
+	e.JobStore = storage.NewProtoKV[*jobsv1alpha1.Job](logger, broker.KeyValue("jobs"))
+	e.EditSessionStore = storage.NewProtoKV[*configv1alpha1.ConfigEditSession](logger, broker.KeyValue("config-edit-sessions"), storage.WithCompression(0))
+	e.KnownGoodStore = storage.NewProtoKV[*configv1alpha1.KnownGoodConfig](logger, broker.KeyValue("known-good-configs"))
+	e.ConfigHistoryStore = storage.NewProtoKV[*configv1alpha1.AgentConfigHistory](logger, broker.KeyValue("agent-config-history"), storage.WithCompression(0))
 
 	// Create the agent repository with all stores
 	e.AgentRepo = agentdomain.NewRepository(
@@ -201,8 +272,13 @@ func (e *TestEnv) initServices(logger *slog.Logger, privateKey crypto.Signer) {
 	e.AgentServer = agent.NewAgentServer(
 		logger.With("service", "agent"),
 		e.AgentRepo,
+		e.SnapshotStore,
+		0,
 	)
 
+	// JobQueue
+	e.JobQueue = jobs.NewQueue(logger.With("service", "jobs"), e.JobStore, jobs.Config{})
+
 	// DeploymentController
 	e.DeploymentController = deployment.NewController(
 		logger.With("service", "deployment"),
@@ -210,18 +286,79 @@ func (e *TestEnv) initServices(logger *slog.Logger, privateKey crypto.Signer) {
 		e.AgentDeploymentStore,
 		e.ConfigStore,
 		e.AgentRepo,
+		e.JobQueue,
 	)
 }
 
 func (e *TestEnv) wireServices() {
 	// ConfigServer notifies OpampServer of config changes
-	e.ConfigServer.SetNotifier(e.OpampServer)
+	e.Notifier = notify.NewInProcess()
+	e.Notifier.Subscribe(e.OpampServer.NotifyConfigChange)
+	e.Notifier.Subscribe(e.recordNotification)
+	e.ConfigServer.SetNotifier(e.Notifier)
 
 	// ConfigServer uses DeploymentController for rolling deployments
 	e.ConfigServer.SetDeploymentController(e.DeploymentController)
+	if e.Clock != nil {
+		e.DeploymentController.SetClock(e.Clock)
+	}
 
 	// DeploymentController uses ConfigServer for assigning configs
 	e.DeploymentController.SetConfigAssigner(e.ConfigServer)
+	e.DeploymentController.SetAssignmentRestorer(e.ConfigServer)
+	e.DeploymentController.SetCompatibilityChecker(e.ConfigServer)
+	e.DeploymentController.SetCapacityChecker(e.ConfigServer)
+
+	// Agent snapshots are requested and uploaded over OpAMP
+	e.AgentServer.SetSnapshotRequester(e.OpampServer)
+	e.OpampServer.SetSnapshotReceiver(e.AgentServer)
+
+	// Deleted agents are disconnected and their config assignment revoked
+	e.AgentServer.SetAgentDisconnector(e.OpampServer)
+	e.AgentServer.SetAssignmentRevoker(e.ConfigServer)
+
+	// Remote config pushes are tracked by the OpAMP server and listed by the AgentServer
+	e.OpampServer.SetConfigPushStore(e.ConfigPushStore)
+	e.AgentServer.SetConfigPushStore(e.ConfigPushStore)
+
+	// Commands are sent by the OpAMP server, which tracks their results
+	e.AgentServer.SetAgentRestarter(e.OpampServer)
+	e.OpampServer.SetCommandStore(e.CommandStore)
+	e.AgentServer.SetCommandStore(e.CommandStore)
+	e.OpampServer.SetProblemStore(e.ProblemStore)
+	e.AgentServer.SetProblemStore(e.ProblemStore)
+
+	e.OpampServer.SetAvailabilityStore(e.AvailabilityStore, 0)
+	e.AgentServer.SetAvailabilityStore(e.AvailabilityStore, 0)
+	e.AgentServer.SetFleetSnapshotStore(e.FleetSnapshotStore)
+	// The metrics reported by the collectors of agents are served with their status
+	e.AgentServer.SetMetricsStore(e.AgentMetricsStore)
+
+	// Assignment policies are re-evaluated when agents report their labels
+	e.ConfigServer.SetAssignmentPolicyStore(e.PolicyStore)
+	e.ConfigServer.SetGroupStore(e.GroupStore)
+	e.OpampServer.SetAssignmentEvaluator(e.ConfigServer)
+	e.ConfigServer.SetComponentPolicyStore(e.ComponentPolicyStore)
+	e.ConfigServer.SetDistributionStore(e.DistributionStore)
+	e.ConfigServer.SetEditSessionStore(e.EditSessionStore)
+
+	// Collector upgrades are offered to agents by the OpAMP server
+	e.ConfigServer.SetPackageStores(e.PackageTargetStore, e.PackageStatusStore)
+	e.ConfigServer.SetPackageNotifier(e.OpampServer)
+	e.OpampServer.SetPackageManager(e.ConfigServer)
+
+	// Agents failing to apply their config are rolled back to the last config they applied
+	e.ConfigServer.SetKnownGoodStore(e.KnownGoodStore)
+	e.OpampServer.SetConfigOutcomeHandler(e.ConfigServer)
+	e.ConfigServer.SetConfigHistoryStore(e.ConfigHistoryStore)
+
+	// Bootstrap configs take part in assignment precedence
+	e.BootstrapServer.SetAssignmentStores(e.ConfigAssignmentStore, e.BootstrapAssignmentStore)
+	e.BootstrapServer.SetAgentCertificateStore(e.Broker.KeyValue(bootstrap.AgentCertificateKeyspace))
+	e.ConfigServer.SetBootstrapAssignmentStore(e.BootstrapAssignmentStore)
+
+	// Async batch assignments run as background jobs
+	e.ConfigServer.SetJobQueue(e.JobQueue)
 }
 
 func (e *TestEnv) setupHTTPServers(t *testing.T) {
@@ -230,19 +367,22 @@ func (e *TestEnv) setupHTTPServers(t *testing.T) {
 	e.BootstrapServer.ConfigureHTTP(router)
 	e.ConfigServer.ConfigureHTTP(router)
 	e.AgentServer.ConfigureHTTP(router)
+	e.OpampServer.SetGatewayAgentStore(e.Broker.KeyValue(opamp.GatewayAgentKeyspace))
+	e.OpampServer.ConfigureGatewayHTTP(router, map[string]string{
+		TestGatewayID:      TestGatewayToken,
+		TestOtherGatewayID: TestOtherGatewayToken,
+	})
 
 	// Create HTTP test server
 	e.HTTPServer = httptest.NewServer(router)
 	e.BaseURL = e.HTTPServer.URL
 
-	// Create separate OpAMP WebSocket test server
-	opampSrv := server.New(nil)
-	settings := SetupOpampServerImpl(t, e.OpampServer)
-	handlerFunc, _, err := opampSrv.Attach(settings)
-	require.NoError(t, err)
-
-	e.OpampWSServer = httptest.NewServer(http.HandlerFunc(handlerFunc))
-	e.OpampURL = "ws" + e.OpampWSServer.URL[4:] // Convert http:// to ws://
+	// Create separate OpAMP WebSocket test server, mirroring the dedicated listener
+	opampRouter := mux.NewRouter()
+	e.OpampWSServer = httptest.NewUnstartedServer(opampRouter)
+	require.NoError(t, e.OpampServer.ConfigureHTTP(opampRouter, e.OpampWSServer.Config))
+	e.OpampWSServer.Start()
+	e.OpampURL = "ws" + e.OpampWSServer.URL[4:] + opamp.OpAMPPath // Convert http:// to ws://
 }
 
 // Close cleans up all test environment resources.
@@ -255,6 +395,11 @@ func (e *TestEnv) Close() {
 		_ = agent.Stop()
 	}
 
+	// Stop running jobs before the servers are closed
+	if e.JobQueue != nil {
+		_ = services.StopAndAwaitTerminated(context.Background(), e.JobQueue)
+	}
+
 	// Close HTTP servers
 	if e.HTTPServer != nil {
 		e.HTTPServer.Close()
@@ -262,11 +407,6 @@ func (e *TestEnv) Close() {
 	if e.OpampWSServer != nil {
 		e.OpampWSServer.Close()
 	}
-
-	// Close database
-	if e.db != nil {
-		_ = e.db.Close()
-	}
 }
 
 // GetAgent returns a previously created test agent by ID.
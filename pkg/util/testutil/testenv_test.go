@@ -0,0 +1,59 @@
+package testutil_test
+
+import (
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/util/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestEnv_FakeClockDeployment(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	env := testutil.NewTestEnv(t, testutil.WithFakeClock(start))
+	ctx := t.Context()
+
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: "logs"},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {}")},
+	}))
+	require.NoError(t, err)
+	env.NewAgent("agent-1")
+	env.NewAgent("agent-2")
+
+	resp, err := env.ConfigServer.StartRollingDeployment(ctx, connect.NewRequest(&configv1alpha1.RollingDeploymentRequest{
+		ConfigId:          "logs",
+		AgentIds:          []string{"agent-1", "agent-2"},
+		BatchSize:         1,
+		BatchDelaySeconds: 3600,
+	}))
+	require.NoError(t, err)
+	deployment := testutil.RecordTransitions(env, env.DeploymentStore, resp.Msg.GetDeploymentId())
+	assignment := testutil.RecordTransitions(env, env.ConfigAssignmentStore, "agent-2")
+
+	// the deployment waits out the batch delay on the fake clock
+	require.True(t, env.Clock.BlockUntil(1, 5*time.Second))
+	assert.Nil(t, assignment.Last())
+	assert.Equal(t, start, deployment.Last().GetStartedAt().AsTime())
+
+	env.Clock.Advance(time.Hour)
+	status := deployment.WaitFor(t, 5*time.Second, func(status *configv1alpha1.DeploymentStatus) bool {
+		return status.GetState() == configv1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED
+	})
+	assert.Equal(t, int32(2), status.GetCompletedAgents())
+	assert.Equal(t, "logs", assignment.Last().GetConfigId())
+	env.WaitForNotifications(t, "agent-2", 1, 5*time.Second)
+
+	var states []configv1alpha1.DeploymentState
+	for _, value := range deployment.Values() {
+		if len(states) == 0 || states[len(states)-1] != value.GetState() {
+			states = append(states, value.GetState())
+		}
+	}
+	assert.Equal(t, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_COMPLETED, states[len(states)-1])
+	assert.Contains(t, states, configv1alpha1.DeploymentState_DEPLOYMENT_STATE_IN_PROGRESS)
+}
@@ -0,0 +1,248 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	agentsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/storage"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// recheckInterval is how often waits re-check their condition without a change, for the
+// conditions depending on state kept outside the stores
+const recheckInterval = 100 * time.Millisecond
+
+// changes broadcasts the writes to the stores of an environment, its config change
+// notifications and the config updates of its agents, so that waits re-check their
+// condition on every change instead of sleeping
+type changes struct {
+	mu        sync.Mutex
+	ch        chan struct{}
+	listeners []func()
+}
+
+func newChanges() *changes {
+	return &changes{ch: make(chan struct{})}
+}
+
+// signal wakes up the waits, and calls the listeners synchronously
+func (c *changes) signal() {
+	c.mu.Lock()
+	close(c.ch)
+	c.ch = make(chan struct{})
+	listeners := c.listeners
+	c.mu.Unlock()
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+// next returns a channel closed on the next change
+func (c *changes) next() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ch
+}
+
+func (c *changes) listen(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listeners = append(c.listeners, fn)
+}
+
+// watchedBroker signals the writes to its keyspaces
+type watchedBroker struct {
+	storage.KVBroker
+	changes *changes
+}
+
+func (b *watchedBroker) KeyValue(prefix string) storage.KV {
+	return &watchedKV{KV: b.KVBroker.KeyValue(prefix), changes: b.changes}
+}
+
+type watchedKV struct {
+	storage.KV
+	changes *changes
+}
+
+func (kv *watchedKV) signalOn(err error) error {
+	if err == nil {
+		kv.changes.signal()
+	}
+	return err
+}
+
+func (kv *watchedKV) Put(ctx context.Context, key string, obj []byte) error {
+	return kv.signalOn(kv.KV.Put(ctx, key, obj))
+}
+
+func (kv *watchedKV) Create(ctx context.Context, key string, obj []byte) error {
+	return kv.signalOn(kv.KV.Create(ctx, key, obj))
+}
+
+func (kv *watchedKV) CompareAndSwap(ctx context.Context, key string, old, obj []byte) error {
+	return kv.signalOn(kv.KV.CompareAndSwap(ctx, key, old, obj))
+}
+
+func (kv *watchedKV) Delete(ctx context.Context, key string) error {
+	return kv.signalOn(kv.KV.Delete(ctx, key))
+}
+
+func (kv *watchedKV) DeletePrefix(ctx context.Context, prefix string) error {
+	return kv.signalOn(kv.KV.DeletePrefix(ctx, prefix))
+}
+
+func (kv *watchedKV) PutBatch(ctx context.Context, b storage.Batch, key string, obj []byte) error {
+	if err := kv.KV.PutBatch(ctx, b, key, obj); err != nil {
+		return err
+	}
+	b.OnCommit(kv.changes.signal)
+	return nil
+}
+
+func (kv *watchedKV) DeleteBatch(ctx context.Context, b storage.Batch, key string) error {
+	if err := kv.KV.DeleteBatch(ctx, b, key); err != nil {
+		return err
+	}
+	b.OnCommit(kv.changes.signal)
+	return nil
+}
+
+// WaitFor waits until cond returns true, re-checking it whenever a store of the environment
+// is written, a config change is notified or an agent applies a config. The test fails if
+// cond is still false after timeout.
+func (e *TestEnv) WaitFor(t *testing.T, timeout time.Duration, cond func() bool, msgAndArgs ...any) {
+	t.Helper()
+	deadline := time.After(timeout)
+	recheck := time.NewTicker(recheckInterval)
+	defer recheck.Stop()
+	for {
+		// taken before checking, so that changes made while checking aren't missed
+		changed := e.changes.next()
+		if cond() {
+			return
+		}
+		select {
+		case <-changed:
+		case <-recheck.C:
+		case <-deadline:
+			require.Fail(t, fmt.Sprintf("condition not met within %v", timeout), msgAndArgs...)
+		}
+	}
+}
+
+// WaitForAgentState waits until the connection state of the agent is state
+func (e *TestEnv) WaitForAgentState(t *testing.T, agentID string, state agentsv1alpha1.AgentState, timeout time.Duration) {
+	t.Helper()
+	e.WaitFor(t, timeout, func() bool {
+		conn, err := e.ConnectionStateStore.Get(context.Background(), agentID)
+		return err == nil && conn.GetState() == state
+	}, "agent %s did not reach state %s", agentID, state)
+}
+
+// WaitForNotifications waits until at least n config changes of the agent were notified
+func (e *TestEnv) WaitForNotifications(t *testing.T, agentID string, n int, timeout time.Duration) {
+	t.Helper()
+	e.WaitFor(t, timeout, func() bool {
+		return e.Notifications(agentID) >= n
+	}, "config changes of agent %s were not notified %d times", agentID, n)
+}
+
+// Notifications returns the number of config changes of the agent notified to the OpAMP server
+func (e *TestEnv) Notifications(agentID string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.notifications[agentID]
+}
+
+func (e *TestEnv) recordNotification(agentID string) {
+	e.mu.Lock()
+	e.notifications[agentID]++
+	e.mu.Unlock()
+	e.changes.signal()
+}
+
+// Transitions records the distinct values a key of a store takes, see RecordTransitions
+type Transitions[T proto.Message] struct {
+	env   *TestEnv
+	store storage.KeyValue[T]
+	key   string
+
+	mu      sync.Mutex
+	values  []T
+	present bool
+}
+
+// RecordTransitions records the values of key in store from now on, checking the key after
+// every write to the stores of the environment. Deletions are recorded as the zero value.
+// Writes made concurrently may be observed as a single transition.
+func RecordTransitions[T proto.Message](e *TestEnv, store storage.KeyValue[T], key string) *Transitions[T] {
+	r := &Transitions[T]{env: e, store: store, key: key}
+	r.observe()
+	e.changes.listen(r.observe)
+	return r
+}
+
+func (r *Transitions[T]) observe() {
+	// held while reading, so that values are recorded in the order they were read
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, err := r.store.Get(context.Background(), r.key)
+	present := err == nil
+	if err != nil && !grpcutil.IsErrorNotFound(err) {
+		return
+	}
+	if len(r.values) > 0 && present == r.present && (!present || proto.Equal(value, r.values[len(r.values)-1])) {
+		return
+	}
+	if !present {
+		var zero T
+		value = zero
+	}
+	r.values = append(r.values, value)
+	r.present = present
+}
+
+// Values returns the values recorded so far, oldest first
+func (r *Transitions[T]) Values() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]T(nil), r.values...)
+}
+
+// Last returns the current value of the key, the zero value if it doesn't exist
+func (r *Transitions[T]) Last() T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var last T
+	if len(r.values) > 0 {
+		last = r.values[len(r.values)-1]
+	}
+	return last
+}
+
+// WaitFor waits until the current value of the key satisfies cond and returns it
+func (r *Transitions[T]) WaitFor(t *testing.T, timeout time.Duration, cond func(T) bool) T {
+	t.Helper()
+	var value T
+	r.env.WaitFor(t, timeout, func() bool {
+		value = r.Last()
+		return cond(value)
+	}, "key %s did not reach the expected value, transitions: %v", r.key, transitionsOf[T]{r})
+	return value
+}
+
+// transitionsOf formats the values recorded when the failure message is built
+type transitionsOf[T proto.Message] struct {
+	r *Transitions[T]
+}
+
+func (f transitionsOf[T]) String() string {
+	return fmt.Sprint(f.r.Values())
+}
@@ -4,18 +4,28 @@ package integration_test
 
 import (
 	"context"
+	"os"
+	"os/exec"
+	"strings"
 	"testing"
 	"time"
 
 	"connectrpc.com/connect"
+	"github.com/open-telemetry/opamp-go/protobufs"
 	agentsv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/agents/v1alpha1"
 	bootstrapv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/bootstrap/v1alpha1"
 	configv1alpha1 "github.com/otelfleet/otelfleet/pkg/api/config/v1alpha1"
+	"github.com/otelfleet/otelfleet/pkg/bootstrap"
 	bootstrapclient "github.com/otelfleet/otelfleet/pkg/bootstrap/client"
 	"github.com/otelfleet/otelfleet/pkg/ident"
+	"github.com/otelfleet/otelfleet/pkg/labels"
+	bootstrapsvc "github.com/otelfleet/otelfleet/pkg/services/bootstrap"
+	"github.com/otelfleet/otelfleet/pkg/util/grpcutil"
 	"github.com/otelfleet/otelfleet/pkg/util/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -30,6 +40,7 @@ func defaultTTL() *durationpb.Duration {
 // ============================================================================
 
 func TestBootstrap_AgentRegistersSuccessfully(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -62,6 +73,7 @@ func TestBootstrap_AgentRegistersSuccessfully(t *testing.T) {
 }
 
 func TestBootstrap_AgentGetsDefaultConfig(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -95,6 +107,7 @@ exporters:
 }
 
 func TestBootstrap_TokenWithConfigReference_StoresConfigOnTokenCreation(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -161,7 +174,50 @@ exporters:
 	// This is a known limitation of insecure mode testing.
 }
 
+func TestBootstrap_ConfigAssignmentRecordsBootstrapSource(t *testing.T) {
+	t.Parallel()
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	configID := "bootstrap-config"
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: configID},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {}")},
+	}))
+	require.NoError(t, err)
+	tokenResp, err := env.BootstrapServer.CreateToken(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateTokenRequest{
+		TTL:             defaultTTL(),
+		ConfigReference: &configID,
+	}))
+	require.NoError(t, err)
+	token := tokenResp.Msg
+
+	client := bootstrapclient.NewInsecure(bootstrapclient.Config{
+		Logger:     env.Logger,
+		ServerURL:  env.BaseURL,
+		HTTPClient: env.HTTPServer.Client(),
+	})
+	// the full token key, so the bootstrap config is found in insecure mode
+	agentID := "agent-bootstrap-source"
+	_, err = client.BootstrapAgent(ctx, &testIdentity{id: agentID}, "Bootstrap Source Agent", token.GetID()+"."+token.GetSecret())
+	require.NoError(t, err)
+
+	assignment, err := env.ConfigAssignmentStore.Get(ctx, agentID)
+	require.NoError(t, err)
+	assert.Equal(t, configv1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP, assignment.GetSource())
+	assert.Equal(t, configID, assignment.GetConfigId())
+	bootstrapAssignment, err := env.BootstrapAssignmentStore.Get(ctx, agentID)
+	require.NoError(t, err)
+	assert.Equal(t, configID, bootstrapAssignment.GetConfigId())
+
+	explanation, err := env.ConfigServer.GetAssignmentExplanation(ctx, connect.NewRequest(&configv1alpha1.GetAssignmentExplanationRequest{AgentId: agentID}))
+	require.NoError(t, err)
+	assert.Equal(t, configv1alpha1.ConfigSource_CONFIG_SOURCE_BOOTSTRAP, explanation.Msg.GetEffectiveSource())
+	assert.Equal(t, configID, explanation.Msg.GetEffectiveConfigId())
+}
+
 func TestBootstrap_InvalidToken_Fails(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -184,11 +240,106 @@ func TestBootstrap_InvalidToken_Fails(t *testing.T) {
 	assert.NoError(t, err) // Insecure mode allows any token
 }
 
+func TestBootstrap_EnrollmentURL(t *testing.T) {
+	t.Parallel()
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	configID := "enrollment-config"
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: configID},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {}")},
+	}))
+	require.NoError(t, err)
+
+	_, err = env.BootstrapServer.CreateEnrollmentURL(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateEnrollmentURLRequest{}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err), "no base URL to build the URL from")
+	resp, err := env.BootstrapServer.CreateEnrollmentURL(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateEnrollmentURLRequest{
+		ConfigReference: &configID,
+		BaseURL:         env.BaseURL,
+	}))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resp.Msg.GetUrl(), env.BaseURL+bootstrap.EnrollmentPath))
+	assert.WithinDuration(t, time.Now().Add(time.Hour), resp.Msg.GetExpiry().AsTime(), time.Minute)
+	assert.NotContains(t, resp.Msg.GetUrl(), mustGetToken(t, env, resp.Msg.GetTokenID()).GetSecret())
+
+	// the URL is all an agent needs to bootstrap
+	serverURL, signed, err := bootstrap.ParseEnrollmentURL(resp.Msg.GetUrl())
+	require.NoError(t, err)
+	assert.Equal(t, env.BaseURL, serverURL)
+	client := bootstrapclient.NewInsecure(bootstrapclient.Config{
+		Logger:     env.Logger,
+		ServerURL:  serverURL,
+		HTTPClient: env.HTTPServer.Client(),
+	})
+	tampered := signed[:len(signed)-2] + "AA"
+	_, err = client.BootstrapAgent(ctx, &testIdentity{id: "agent-tampered"}, "Tampered", bootstrap.EnrollmentAuthorization(tampered))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	_, err = client.BootstrapAgent(ctx, &testIdentity{id: "agent-enrolled"}, "Enrolled Agent", bootstrap.EnrollmentAuthorization(signed))
+	require.NoError(t, err)
+	assignment, err := env.ConfigAssignmentStore.Get(ctx, "agent-enrolled")
+	require.NoError(t, err)
+	assert.Equal(t, configID, assignment.GetConfigId())
+
+	// enrollment URLs are single-use
+	_, err = env.TokenStore.Get(ctx, resp.Msg.GetTokenID())
+	assert.True(t, grpcutil.IsErrorNotFound(err))
+	_, err = client.BootstrapAgent(ctx, &testIdentity{id: "agent-reused"}, "Reused", bootstrap.EnrollmentAuthorization(signed))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	exists, err := env.AgentRepo.Exists(ctx, "agent-reused")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestBootstrap_LabelRules(t *testing.T) {
+	t.Parallel()
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	_, err := env.BootstrapServer.CreateToken(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateTokenRequest{
+		TTL:    defaultTTL(),
+		Labels: map[string]string{"otelfleet.agent.id": "spoofed"},
+	}))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.ErrorContains(t, err, "reserved prefix")
+
+	tokenResp, err := env.BootstrapServer.CreateToken(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateTokenRequest{
+		TTL:    defaultTTL(),
+		Labels: map[string]string{"env": "prod"},
+	}))
+	require.NoError(t, err)
+
+	// tokens are checked against the rules in effect when agents bootstrap
+	env.BootstrapServer.SetLabelRules(labels.Rules{AllowedPrefixes: []string{"team."}})
+	_, err = env.BootstrapServer.CreateEnrollmentURL(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateEnrollmentURLRequest{
+		Labels:  map[string]string{"env": "prod"},
+		BaseURL: env.BaseURL,
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	client := bootstrapclient.NewInsecure(bootstrapclient.Config{
+		Logger:     env.Logger,
+		ServerURL:  env.BaseURL,
+		HTTPClient: env.HTTPServer.Client(),
+	})
+	_, err = client.BootstrapAgent(ctx, &testIdentity{id: "agent-labels"}, "Labels", tokenResp.Msg.GetID())
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	assert.ErrorContains(t, err, `label "env" does not start with an allowed prefix`)
+}
+
+func mustGetToken(t *testing.T, env *testutil.TestEnv, id string) *bootstrapv1alpha1.BootstrapToken {
+	t.Helper()
+	token, err := env.TokenStore.Get(context.Background(), id)
+	require.NoError(t, err)
+	return token
+}
+
 // ============================================================================
 // Config Management Tests
 // ============================================================================
 
 func TestConfig_CRUD_Operations(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -214,7 +365,7 @@ func TestConfig_CRUD_Operations(t *testing.T) {
 	assert.Equal(t, configYAML, string(getResp.Msg.GetConfig()))
 
 	// List configs - should contain our config
-	listResp, err := env.ConfigServer.ListConfigs(ctx, connect.NewRequest(&emptypb.Empty{}))
+	listResp, err := env.ConfigServer.ListConfigs(ctx, connect.NewRequest(&configv1alpha1.ListConfigsRequest{}))
 	require.NoError(t, err)
 	found := false
 	for _, ref := range listResp.Msg.GetConfigs() {
@@ -259,6 +410,7 @@ func TestConfig_CRUD_Operations(t *testing.T) {
 }
 
 func TestConfig_DefaultConfig_FallbackBehavior(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -284,6 +436,7 @@ exporters:
 // ============================================================================
 
 func TestConfigAssignment_ManualAssignment(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -318,6 +471,7 @@ func TestConfigAssignment_ManualAssignment(t *testing.T) {
 }
 
 func TestConfigAssignment_AgentReceivesAssignedConfig(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -361,6 +515,7 @@ exporters:
 }
 
 func TestConfigAssignment_Unassign(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -395,6 +550,7 @@ func TestConfigAssignment_Unassign(t *testing.T) {
 }
 
 func TestConfigAssignment_BatchAssign(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -432,6 +588,7 @@ func TestConfigAssignment_BatchAssign(t *testing.T) {
 }
 
 func TestConfigAssignment_AssignByLabels(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -454,8 +611,13 @@ func TestConfigAssignment_AssignByLabels(t *testing.T) {
 	require.NoError(t, prodEUAgent.Start())
 	require.NoError(t, stagingAgent.Start())
 
-	// Allow time for agents to connect and send their descriptions
-	time.Sleep(200 * time.Millisecond)
+	// Wait for the agents to connect and send their descriptions
+	for _, agent := range []*testutil.TestAgent{prodUSAgent, prodEUAgent, stagingAgent} {
+		env.WaitFor(t, 5*time.Second, func() bool {
+			a, err := env.AgentRepo.Get(ctx, agent.ID)
+			return err == nil && len(a.Labels()) > 0
+		}, "agent %s did not report its labels", agent.ID)
+	}
 
 	// Assign by labels - only prod agents in us-east
 	resp, err := env.ConfigServer.AssignConfigByLabels(ctx, connect.NewRequest(&configv1alpha1.AssignConfigByLabelsRequest{
@@ -477,6 +639,7 @@ func TestConfigAssignment_AssignByLabels(t *testing.T) {
 // ============================================================================
 
 func TestAgentStatus_ReportsEffectiveConfig(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -504,8 +667,11 @@ func TestAgentStatus_ReportsEffectiveConfig(t *testing.T) {
 	require.NoError(t, agent.Start())
 	agent.WaitForConfig(t, 5*time.Second)
 
-	// Allow time for status to propagate
-	time.Sleep(500 * time.Millisecond)
+	// Wait for the agent to report the config it applied
+	env.WaitFor(t, 5*time.Second, func() bool {
+		_, err := env.EffectiveConfigStore.Get(ctx, agent.ID)
+		return err == nil
+	}, "agent did not report its effective config")
 
 	// Check agent status via API
 	statusResp, err := env.AgentServer.Status(ctx, connect.NewRequest(&agentsv1alpha1.GetAgentStatusRequest{
@@ -526,6 +692,7 @@ func TestAgentStatus_ReportsEffectiveConfig(t *testing.T) {
 }
 
 func TestAgentStatus_ConfigInSync(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -550,8 +717,11 @@ func TestAgentStatus_ConfigInSync(t *testing.T) {
 	require.NoError(t, agent.Start())
 	agent.WaitForConfig(t, 5*time.Second)
 
-	// Allow status to propagate
-	time.Sleep(500 * time.Millisecond)
+	// Wait for the agent to report applying the config
+	env.WaitFor(t, 5*time.Second, func() bool {
+		status, err := env.RemoteStatusStore.Get(ctx, agent.ID)
+		return err == nil && status.GetStatus() == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED
+	}, "agent did not report applying the config")
 
 	// Check config status
 	configStatusResp, err := env.ConfigServer.GetConfigStatus(ctx, connect.NewRequest(&configv1alpha1.GetConfigStatusRequest{
@@ -571,6 +741,7 @@ func TestAgentStatus_ConfigInSync(t *testing.T) {
 // ============================================================================
 
 func TestConfigUpdate_PropagatedToConnectedAgent(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -630,6 +801,7 @@ exporters:
 }
 
 func TestConfigReassignment_NewConfigDelivered(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -685,6 +857,7 @@ func TestConfigReassignment_NewConfigDelivered(t *testing.T) {
 // ============================================================================
 
 func TestMultipleAgents_DifferentConfigs(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -741,6 +914,7 @@ func TestMultipleAgents_DifferentConfigs(t *testing.T) {
 }
 
 func TestMultipleAgents_ListAgents(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -753,7 +927,7 @@ func TestMultipleAgents_ListAgents(t *testing.T) {
 	// Start one agent to test status filtering
 	agent1, _ := env.GetAgent("list-agent-1")
 	require.NoError(t, agent1.Start())
-	time.Sleep(200 * time.Millisecond) // Allow connection
+	env.WaitForAgentState(t, agent1.ID, agentsv1alpha1.AgentState_AGENT_STATE_CONNECTED, 5*time.Second)
 
 	// List all agents
 	listResp, err := env.AgentServer.ListAgents(ctx, connect.NewRequest(&agentsv1alpha1.ListAgentsRequest{}))
@@ -778,6 +952,7 @@ func TestMultipleAgents_ListAgents(t *testing.T) {
 // ============================================================================
 
 func TestError_AssignConfigToNonexistentAgent(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -797,6 +972,7 @@ func TestError_AssignConfigToNonexistentAgent(t *testing.T) {
 }
 
 func TestError_AssignNonexistentConfig(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -811,6 +987,7 @@ func TestError_AssignNonexistentConfig(t *testing.T) {
 }
 
 func TestError_GetNonexistentConfig(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -821,6 +998,7 @@ func TestError_GetNonexistentConfig(t *testing.T) {
 }
 
 func TestError_EmptyLabelsForAssignByLabels(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -843,6 +1021,7 @@ func TestError_EmptyLabelsForAssignByLabels(t *testing.T) {
 // ============================================================================
 
 func TestToken_CreateAndList(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -860,7 +1039,7 @@ func TestToken_CreateAndList(t *testing.T) {
 	require.NoError(t, err)
 
 	// List tokens
-	listResp, err := env.BootstrapServer.ListTokens(ctx, connect.NewRequest(&emptypb.Empty{}))
+	listResp, err := env.BootstrapServer.ListTokens(ctx, connect.NewRequest(&bootstrapv1alpha1.ListTokensRequest{}))
 	require.NoError(t, err)
 
 	// Should contain our tokens
@@ -872,7 +1051,35 @@ func TestToken_CreateAndList(t *testing.T) {
 	assert.True(t, foundTokens[token2.Msg.GetID()])
 }
 
+func TestToken_TTL(t *testing.T) {
+	t.Parallel()
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	token, err := env.BootstrapServer.CreateToken(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateTokenRequest{
+		TTL: durationpb.New(24 * time.Hour),
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, token.Msg.GetTTL().AsDuration())
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), token.Msg.GetExpiry().AsTime(), time.Minute)
+
+	token, err = env.BootstrapServer.CreateToken(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateTokenRequest{}))
+	require.NoError(t, err)
+	assert.Equal(t, bootstrapsvc.DefaultTokenTTL, token.Msg.GetTTL().AsDuration(), "tokens created without a TTL get the default TTL")
+	assert.WithinDuration(t, time.Now().Add(bootstrapsvc.DefaultTokenTTL), token.Msg.GetExpiry().AsTime(), time.Minute)
+
+	_, err = env.BootstrapServer.CreateToken(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateTokenRequest{
+		TTL: durationpb.New(bootstrapsvc.DefaultMaxTokenTTL + time.Hour),
+	}))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = env.BootstrapServer.CreateToken(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateTokenRequest{
+		TTL: durationpb.New(-time.Hour),
+	}))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestToken_Delete(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
@@ -890,7 +1097,7 @@ func TestToken_Delete(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify deletion via list
-	listResp, err := env.BootstrapServer.ListTokens(ctx, connect.NewRequest(&emptypb.Empty{}))
+	listResp, err := env.BootstrapServer.ListTokens(ctx, connect.NewRequest(&bootstrapv1alpha1.ListTokensRequest{}))
 	require.NoError(t, err)
 
 	for _, tok := range listResp.Msg.GetTokens() {
@@ -898,11 +1105,206 @@ func TestToken_Delete(t *testing.T) {
 	}
 }
 
+func TestToken_DeleteWithRecentBootstrapRequiresForce(t *testing.T) {
+	t.Parallel()
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	tokenResp, err := env.BootstrapServer.CreateToken(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateTokenRequest{
+		TTL: defaultTTL(),
+	}))
+	require.NoError(t, err)
+	tokenID := tokenResp.Msg.GetID()
+
+	client := bootstrapclient.NewInsecure(bootstrapclient.Config{
+		Logger:     env.Logger,
+		ServerURL:  env.BaseURL,
+		HTTPClient: env.HTTPServer.Client(),
+	})
+	_, err = client.BootstrapAgent(ctx, &testIdentity{id: "in-flight-agent"}, "In-flight Agent", tokenID)
+	require.NoError(t, err)
+
+	// Deleting a token with a recent bootstrap attempt is refused and reports who used it
+	_, err = env.BootstrapServer.DeleteToken(ctx, connect.NewRequest(&bootstrapv1alpha1.DeleteTokenRequest{
+		ID: tokenID,
+	}))
+	require.Error(t, err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Contains(t, err.Error(), "in-flight-agent")
+
+	// Waiting does not help while the attempt is still within the tracking window
+	_, err = env.BootstrapServer.DeleteToken(ctx, connect.NewRequest(&bootstrapv1alpha1.DeleteTokenRequest{
+		ID:   tokenID,
+		Wait: durationpb.New(10 * time.Millisecond),
+	}))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = env.BootstrapServer.DeleteToken(ctx, connect.NewRequest(&bootstrapv1alpha1.DeleteTokenRequest{
+		ID:    tokenID,
+		Force: true,
+	}))
+	require.NoError(t, err)
+
+	_, err = env.TokenStore.Get(ctx, tokenID)
+	assert.True(t, grpcutil.IsErrorNotFound(err))
+}
+
+func TestToken_CheckDoesNotConsumeToken(t *testing.T) {
+	t.Parallel()
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	configID := "check-config"
+	_, err := env.ConfigServer.PutConfig(ctx, connect.NewRequest(&configv1alpha1.PutConfigRequest{
+		Ref:    &configv1alpha1.ConfigReference{Id: configID},
+		Config: &configv1alpha1.Config{Config: []byte("receivers: {}")},
+	}))
+	require.NoError(t, err)
+	tokenResp, err := env.BootstrapServer.CreateToken(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateTokenRequest{
+		TTL:             defaultTTL(),
+		ConfigReference: &configID,
+		Labels:          map[string]string{"env": "prod"},
+	}))
+	require.NoError(t, err)
+	enrollment, err := env.BootstrapServer.CreateEnrollmentURL(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateEnrollmentURLRequest{
+		BaseURL: env.BaseURL,
+	}))
+	require.NoError(t, err)
+	_, signed, err := bootstrap.ParseEnrollmentURL(enrollment.Msg.GetUrl())
+	require.NoError(t, err)
+
+	client := bootstrapclient.NewInsecure(bootstrapclient.Config{
+		Logger:     env.Logger,
+		ServerURL:  env.BaseURL,
+		HTTPClient: env.HTTPServer.Client(),
+	})
+	check, err := client.CheckToken(ctx, tokenResp.Msg.GetID())
+	require.NoError(t, err)
+	assert.True(t, check.GetValid())
+	assert.Equal(t, tokenResp.Msg.GetID(), check.GetTokenID())
+	assert.Equal(t, configID, check.GetConfigReference())
+	assert.Equal(t, map[string]string{"env": "prod"}, check.GetLabels())
+	assert.Nil(t, check.RemainingUses, "multi-use tokens are usable until they expire")
+	assert.Positive(t, check.GetRemainingTTL().AsDuration())
+
+	check, err = client.CheckToken(ctx, "nonexistent-token-id")
+	require.NoError(t, err)
+	assert.False(t, check.GetValid())
+	assert.Equal(t, "token not found", check.GetReason())
+	assert.Empty(t, check.GetTokenID())
+
+	// checking a single-use token doesn't consume it
+	for range 2 {
+		check, err = client.CheckToken(ctx, bootstrap.EnrollmentAuthorization(signed))
+		require.NoError(t, err)
+		assert.True(t, check.GetValid())
+		assert.Equal(t, int32(1), check.GetRemainingUses())
+	}
+	_, err = client.BootstrapAgent(ctx, &testIdentity{id: "checked-agent"}, "Checked Agent", bootstrap.EnrollmentAuthorization(signed))
+	require.NoError(t, err)
+	check, err = client.CheckToken(ctx, bootstrap.EnrollmentAuthorization(signed))
+	require.NoError(t, err)
+	assert.False(t, check.GetValid())
+
+	// checks are rate limited
+	var limited bool
+	for range 20 {
+		if _, err := client.CheckToken(ctx, tokenResp.Msg.GetID()); err != nil {
+			require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+			limited = true
+			break
+		}
+	}
+	assert.True(t, limited)
+}
+
 // ============================================================================
 // List Assignments Tests
 // ============================================================================
 
+func TestToken_GenerateInstallScript(t *testing.T) {
+	t.Parallel()
+	env := testutil.NewTestEnv(t)
+	ctx := context.Background()
+
+	tokenResp, err := env.BootstrapServer.CreateToken(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateTokenRequest{
+		TTL:    defaultTTL(),
+		Labels: map[string]string{"env": "prod", "team": "o'brien, ops"},
+	}))
+	require.NoError(t, err)
+	token := tokenResp.Msg
+
+	generate := func(platform bootstrapv1alpha1.InstallPlatform, settings map[string]string) (*bootstrapv1alpha1.InstallScript, error) {
+		resp, err := env.BootstrapServer.GenerateInstallScript(ctx, connect.NewRequest(&bootstrapv1alpha1.GenerateInstallScriptRequest{
+			TokenID:  token.GetID(),
+			Platform: platform,
+			BaseURL:  env.BaseURL,
+			Settings: settings,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	script, err := generate(bootstrapv1alpha1.InstallPlatform_INSTALL_PLATFORM_SHELL, map[string]string{"WATCHDOG_THRESHOLD": "1m"})
+	require.NoError(t, err)
+	assert.Equal(t, "install-agent.sh", script.GetFilename())
+	assert.True(t, script.GetExpiry().AsTime().Equal(token.GetExpiry().AsTime()))
+
+	// the agent environment written by the script evaluates to the server's settings
+	envFile := script.GetScript()
+	envFile = envFile[strings.Index(envFile, "<<'EOF'\n")+len("<<'EOF'\n") : strings.Index(envFile, "EOF\nchmod")]
+	out, err := exec.Command("sh", "-c", envFile+`printf '%s\n' "$SERVER_URL" "$BOOTSTRAP_TOKEN" "$AGENT_NAME" "$AGENT_LABELS" "$WATCHDOG_THRESHOLD"`).Output()
+	require.NoError(t, err)
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	require.Len(t, lines, 5)
+	assert.Equal(t, env.BaseURL, lines[0])
+	assert.Equal(t, token.GetID()+"."+token.GetSecret(), lines[1])
+	assert.Equal(t, hostname, lines[2])
+	labels, err := bootstrap.ParseLabels(lines[3])
+	require.NoError(t, err)
+	assert.Equal(t, token.GetLabels(), labels)
+	assert.Equal(t, "1m", lines[4])
+	require.NoError(t, exec.Command("sh", "-n", "-c", script.GetScript()).Run())
+
+	script, err = generate(bootstrapv1alpha1.InstallPlatform_INSTALL_PLATFORM_CLOUD_INIT, nil)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(script.GetScript(), "#cloud-config\n"))
+	assert.Contains(t, script.GetScript(), "      export BOOTSTRAP_TOKEN='"+token.GetID()+"."+token.GetSecret()+"'\n")
+	script, err = generate(bootstrapv1alpha1.InstallPlatform_INSTALL_PLATFORM_POWERSHELL, nil)
+	require.NoError(t, err)
+	assert.Contains(t, script.GetScript(), "$env:AGENT_LABELS = 'env=prod,team=o%27brien%2C+ops'\n")
+	assert.Contains(t, script.GetScript(), "$env:AGENT_NAME = $env:COMPUTERNAME\n")
+
+	// single-use tokens are embedded as enrollment URLs
+	enrollment, err := env.BootstrapServer.CreateEnrollmentURL(ctx, connect.NewRequest(&bootstrapv1alpha1.CreateEnrollmentURLRequest{BaseURL: env.BaseURL}))
+	require.NoError(t, err)
+	resp, err := env.BootstrapServer.GenerateInstallScript(ctx, connect.NewRequest(&bootstrapv1alpha1.GenerateInstallScriptRequest{
+		TokenID:   enrollment.Msg.GetTokenID(),
+		Platform:  bootstrapv1alpha1.InstallPlatform_INSTALL_PLATFORM_SHELL,
+		BaseURL:   env.BaseURL,
+		AgentName: "web-1",
+	}))
+	require.NoError(t, err)
+	assert.Contains(t, resp.Msg.GetScript(), "export ENROLLMENT_URL='"+enrollment.Msg.GetUrl()+"'\n")
+	assert.Contains(t, resp.Msg.GetScript(), "export AGENT_NAME='web-1'\n")
+	assert.NotContains(t, resp.Msg.GetScript(), "BOOTSTRAP_TOKEN")
+
+	_, err = generate(bootstrapv1alpha1.InstallPlatform_INSTALL_PLATFORM_UNSPECIFIED, nil)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = generate(bootstrapv1alpha1.InstallPlatform_INSTALL_PLATFORM_SHELL, map[string]string{"PATH": "/tmp"})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = env.BootstrapServer.DeleteToken(ctx, connect.NewRequest(&bootstrapv1alpha1.DeleteTokenRequest{ID: token.GetID()}))
+	require.NoError(t, err)
+	_, err = generate(bootstrapv1alpha1.InstallPlatform_INSTALL_PLATFORM_SHELL, nil)
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
 func TestListConfigAssignments_FilterByConfig(t *testing.T) {
+	t.Parallel()
 	env := testutil.NewTestEnv(t)
 	ctx := context.Background()
 
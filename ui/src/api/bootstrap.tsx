@@ -0,0 +1,41 @@
+import { useEffect, useState } from "react";
+import { baseUrl } from ".";
+
+// Mirrors ui.BootstrapData served by the server at /api/ui/bootstrap
+export interface UIBootstrap {
+  user?: {
+    subject: string;
+    roles: string[];
+    admin: boolean;
+  };
+  features: Record<string, boolean>;
+  external_url?: string;
+  fleet: {
+    agents: number;
+    states: Record<string, number>;
+    config_sync: Record<string, number>;
+  };
+}
+
+/**
+* Load the data the UI needs at startup, undefined until loaded or if the server is unreachable.
+*/
+export function useUIBootstrap(): UIBootstrap | undefined {
+  const [data, setData] = useState<UIBootstrap>();
+  useEffect(() => {
+    const controller = new AbortController();
+    fetch(`${baseUrl}/api/ui/bootstrap`, { signal: controller.signal, credentials: "include" })
+      .then((resp) => (resp.ok ? resp.json() : undefined))
+      .then(setData)
+      .catch(() => setData(undefined));
+    return () => controller.abort();
+  }, []);
+  return data;
+}
+
+/**
+* Returns false for features the server reported as unsupported.
+*/
+export function hasFeature(data: UIBootstrap | undefined, feature: string): boolean {
+  return data?.features[feature] ?? false;
+}
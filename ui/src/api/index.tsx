@@ -3,9 +3,13 @@ import { type DescService } from "@bufbuild/protobuf";
 import { createConnectTransport } from "@connectrpc/connect-web";
 import { createClient, type Client } from "@connectrpc/connect";
 
+// In development the UI is served by vite and calls the API on its own port, built it is
+// served by the API itself.
+export const baseUrl = import.meta.env.DEV ? "http://localhost:16587" : window.location.origin;
+
 // This transport is going to be used throughout the app
 const transport = createConnectTransport({
-  baseUrl: "http://localhost:16587",
+  baseUrl,
 });
 
 /**
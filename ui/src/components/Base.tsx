@@ -3,6 +3,7 @@ import { Link, Outlet } from '@tanstack/react-router'
 import ColorSchemeContext, { useColorScheme } from '../contexts/ColorSchemeContext';
 import { Notifications } from '@mantine/notifications';
 import { elevationShadows, elevationStylesOverrides } from '../theme/elevation';
+import { useUIBootstrap } from '../api/bootstrap';
 
 
 import {
@@ -14,6 +15,7 @@ import {
     NavLink,
     Group,
     ActionIcon,
+    Text,
     useComputedColorScheme,
     type MantineColorScheme,
 } from '@mantine/core';
@@ -121,6 +123,7 @@ const ColorSchemeToggle: FC = () => {
 const Base: FC = () => {
     const [opened, { toggle }] = useDisclosure();
     const [active, setActive] = useState<string | null>(null);
+    const bootstrap = useUIBootstrap();
     const [colorScheme, setColorScheme] = useLocalStorage<MantineColorScheme>({
         key: 'mantine-color-scheme',
         defaultValue: 'auto',
@@ -162,6 +165,11 @@ const Base: FC = () => {
                             style={{ height: '90%', maxHeight: '100%', objectFit: 'contain' }}
                         />
                         <Group gap="sm">
+                            {bootstrap?.user && (
+                                <Text size="sm" c="dimmed">
+                                    {bootstrap.user.subject}{bootstrap.user.admin ? ' (admin)' : ''}
+                                </Text>
+                            )}
                             <ColorSchemeToggle />
                             <a href="https://github.com/otelfleet" target="_blank" rel="noopener noreferrer" style={{ display: 'flex', alignItems: 'center', color: 'inherit' }}>
                                 <GitHubLogoIcon style={{ height: '90%', maxHeight: '100%' }} />
@@ -198,7 +206,7 @@ const Base: FC = () => {
 
                         <NavLink
                             label="Agents"
-                            description="Deployed collectors"
+                            description={bootstrap ? `${bootstrap.fleet.agents} deployed collectors` : "Deployed collectors"}
                             opened={active === 'agents'}
                             active={active === 'agents'}
                             onClick={() => setActive(active === 'agents' ? null : 'agents')}
@@ -1,9 +1,9 @@
-import { useState } from "react";
+import { useEffect, useState } from "react";
 import MonacoEditor, { type OnChange, type OnMount } from "@monaco-editor/react";
-import { Box, Button, Group, TextInput, Paper, SegmentedControl } from '@mantine/core';
+import { Alert, Box, Button, Code, Group, TextInput, Paper, SegmentedControl, Stack, Text } from '@mantine/core';
 import { useForm } from '@mantine/form'
 import { useClient } from "../api";
-import { ConfigService } from '../gen/api/pkg/api/config/v1alpha1/config_pb';
+import { ConfigService, type ConfigMergeConflict } from '../gen/api/pkg/api/config/v1alpha1/config_pb';
 import { notifications } from "@mantine/notifications";
 import { notifyGRPCError } from "../api/notifications";
 import { useNavigate } from '@tanstack/react-router';
@@ -27,6 +27,9 @@ export function Editor({ defaultConfig, configId, readOnly = false, height }: Ed
     const actualConfig = defaultConfig ? defaultConfig : ""
     const [configData, setConfig] = useState(actualConfig)
     const [viewMode, setViewMode] = useState<ViewMode>('split');
+    // edit session merging the changes others make while editing, unset if unsupported
+    const [sessionId, setSessionId] = useState<string>();
+    const [conflicts, setConflicts] = useState<ConfigMergeConflict[]>([]);
 
     const handleEditorChange: OnChange = (value) => {
         if (value !== undefined && !readOnly) {
@@ -53,18 +56,54 @@ export function Editor({ defaultConfig, configId, readOnly = false, height }: Ed
     const otelConfigClient = useClient(ConfigService)
     const navigate = useNavigate();
 
+    useEffect(() => {
+        if (!configId || readOnly) return;
+        otelConfigClient.beginConfigEdit({ id: configId })
+            .then((session) => setSessionId(session.id))
+            .catch(() => setSessionId(undefined));
+    }, [configId, readOnly]);
+
+    const saveEdit = async (configName: string, bytes: Uint8Array) => {
+        const result = await otelConfigClient.saveConfigEdit({
+            ref: { id: configName },
+            sessionId: sessionId,
+            config: { config: bytes },
+        })
+        if (!result.saved) {
+            setConflicts(result.conflicts);
+            notifications.show({
+                title: "Config changed concurrently",
+                message: `${result.conflicts.length} of your changes conflict with changes saved since you started editing`,
+                color: "yellow",
+            })
+            return false;
+        }
+        setConflicts([]);
+        if (result.merged) {
+            notifications.show({
+                title: "Merged concurrent changes",
+                message: `Changes saved to ${configName} since you started editing were kept`,
+            })
+        }
+        return true;
+    }
+
     const handleSubmit = async (values: { configName: string }) => {
         if (readOnly) return;
         try {
             const bytes = new TextEncoder().encode(configData);
-            await otelConfigClient.putConfig({
-                ref: {
-                    id: values.configName,
-                },
-                config: {
-                    config: bytes,
-                },
-            })
+            if (sessionId) {
+                if (!await saveEdit(values.configName, bytes)) return;
+            } else {
+                await otelConfigClient.putConfig({
+                    ref: {
+                        id: values.configName,
+                    },
+                    config: {
+                        config: bytes,
+                    },
+                })
+            }
             notifications.show({
                 title: isEditMode ? "Config updated" : "Config created",
                 message: isEditMode
@@ -120,6 +159,24 @@ export function Editor({ defaultConfig, configId, readOnly = false, height }: Ed
                 </form>
             )}
 
+            {conflicts.length > 0 && (
+                <Alert color="yellow" title="Resolve the conflicting changes, then save again">
+                    <Stack gap="xs">
+                        {conflicts.map((conflict) => (
+                            <Box key={conflict.path}>
+                                <Text size="sm" fw={600}>{conflict.path || "(whole config)"}</Text>
+                                <Group gap="xs" align="flex-start">
+                                    <Text size="xs">Yours:</Text>
+                                    <Code block>{conflict.ours || "(removed)"}</Code>
+                                    <Text size="xs">Saved:</Text>
+                                    <Code block>{conflict.theirs || "(removed)"}</Code>
+                                </Group>
+                            </Box>
+                        ))}
+                    </Stack>
+                </Alert>
+            )}
+
             {readOnly && (
                 <Group>
                     <SegmentedControl
@@ -0,0 +1,348 @@
+// @generated by protoc-gen-es v2.10.2 with parameter "target=ts"
+// @generated from file pkg/api/admin/v1alpha1/admin.proto (package admin.v1alpha1, syntax proto3)
+/* eslint-disable */
+
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file pkg/api/admin/v1alpha1/admin.proto.
+ */
+export const file_pkg_api_admin_v1alpha1_admin: GenFile = /*@__PURE__*/
+  fileDesc("CiJwa2cvYXBpL2FkbWluL3YxYWxwaGExL2FkbWluLnByb3RvEg5hZG1pbi52MWFscGhhMSKRAQoGTW9kdWxlEgwKBG5hbWUYASABKAkSFAoMdXNlcl92aXNpYmxlGAIgASgIEhAKCGluY2x1ZGVkGAMgASgIEhQKDGRlcGVuZGVuY2llcxgEIAMoCRIqCgVzdGF0ZRgFIAEoDjIbLmFkbWluLnYxYWxwaGExLk1vZHVsZVN0YXRlEg8KB2ZhaWx1cmUYBiABKAkiEwoRR2V0TW9kdWxlc1JlcXVlc3QiTQoSR2V0TW9kdWxlc1Jlc3BvbnNlEg4KBnRhcmdldBgBIAEoCRInCgdtb2R1bGVzGAIgAygLMhYuYWRtaW4udjFhbHBoYTEuTW9kdWxlIoICCgxPcEFNUFNlc3Npb24SCgoCaWQYASABKAkSEAoIYWdlbnRfaWQYAiABKAkSEQoJdHJhbnNwb3J0GAMgASgJEhMKC3JlbW90ZV9hZGRyGAQgASgJEhUKDWZvcndhcmRlZF9mb3IYBSABKAkSEgoKdXNlcl9hZ2VudBgGIAEoCRInCgN0bHMYByABKAsyGi5hZG1pbi52MWFscGhhMS5TZXNzaW9uVExTEhIKCmdhdGV3YXlfaWQYCCABKAkSFAoMY2FwYWJpbGl0aWVzGAkgAygJEi4KCnN0YXJ0ZWRfYXQYCiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIpIBCgpTZXNzaW9uVExTEg8KB3ZlcnNpb24YASABKAkSFAoMY2lwaGVyX3N1aXRlGAIgASgJEhMKC3NlcnZlcl9uYW1lGAMgASgJEhsKE25lZ290aWF0ZWRfcHJvdG9jb2wYBCABKAkSFgoOY2xpZW50X3N1YmplY3QYBSABKAkSEwoLY2xpZW50X3VyaXMYBiADKAkiJwoTTGlzdFNlc3Npb25zUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCSJGChRMaXN0U2Vzc2lvbnNSZXNwb25zZRIuCghzZXNzaW9ucxgBIAMoCzIcLmFkbWluLnYxYWxwaGExLk9wQU1QU2Vzc2lvbirHAQoLTW9kdWxlU3RhdGUSHAoYTU9EVUxFX1NUQVRFX1VOU1BFQ0lGSUVEEAASFAoQTU9EVUxFX1NUQVRFX05FVxABEhkKFU1PRFVMRV9TVEFURV9TVEFSVElORxACEhgKFE1PRFVMRV9TVEFURV9SVU5OSU5HEAMSGQoVTU9EVUxFX1NUQVRFX1NUT1BQSU5HEAQSGwoXTU9EVUxFX1NUQVRFX1RFUk1JTkFURUQQBRIXChNNT0RVTEVfU1RBVEVfRkFJTEVEEAYyvgEKDEFkbWluU2VydmljZRJTCgpHZXRNb2R1bGVzEiEuYWRtaW4udjFhbHBoYTEuR2V0TW9kdWxlc1JlcXVlc3QaIi5hZG1pbi52MWFscGhhMS5HZXRNb2R1bGVzUmVzcG9uc2USWQoMTGlzdFNlc3Npb25zEiMuYWRtaW4udjFhbHBoYTEuTGlzdFNlc3Npb25zUmVxdWVzdBokLmFkbWluLnYxYWxwaGExLkxpc3RTZXNzaW9uc1Jlc3BvbnNlQjdaNWdpdGh1Yi5jb20vb3RlbGZsZWV0L290ZWxmbGVldC9wa2cvYXBpL2FkbWluL3YxYWxwaGExYgZwcm90bzM", [file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message admin.v1alpha1.Module
+ */
+export type Module = Message<"admin.v1alpha1.Module"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * user visible modules can be targeted
+   *
+   * @generated from field: bool user_visible = 2;
+   */
+  userVisible: boolean;
+
+  /**
+   * included modules are run by the server
+   *
+   * @generated from field: bool included = 3;
+   */
+  included: boolean;
+
+  /**
+   * the modules this module directly depends on
+   *
+   * @generated from field: repeated string dependencies = 4;
+   */
+  dependencies: string[];
+
+  /**
+   * @generated from field: admin.v1alpha1.ModuleState state = 5;
+   */
+  state: ModuleState;
+
+  /**
+   * why the module failed, if it did
+   *
+   * @generated from field: string failure = 6;
+   */
+  failure: string;
+};
+
+/**
+ * Describes the message admin.v1alpha1.Module.
+ * Use `create(ModuleSchema)` to create a new message.
+ */
+export const ModuleSchema: GenMessage<Module> = /*@__PURE__*/
+  messageDesc(file_pkg_api_admin_v1alpha1_admin, 0);
+
+/**
+ * @generated from message admin.v1alpha1.GetModulesRequest
+ */
+export type GetModulesRequest = Message<"admin.v1alpha1.GetModulesRequest"> & {
+};
+
+/**
+ * Describes the message admin.v1alpha1.GetModulesRequest.
+ * Use `create(GetModulesRequestSchema)` to create a new message.
+ */
+export const GetModulesRequestSchema: GenMessage<GetModulesRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_admin_v1alpha1_admin, 1);
+
+/**
+ * @generated from message admin.v1alpha1.GetModulesResponse
+ */
+export type GetModulesResponse = Message<"admin.v1alpha1.GetModulesResponse"> & {
+  /**
+   * the module run by the server, including its dependencies
+   *
+   * @generated from field: string target = 1;
+   */
+  target: string;
+
+  /**
+   * modules sorted by name
+   *
+   * @generated from field: repeated admin.v1alpha1.Module modules = 2;
+   */
+  modules: Module[];
+};
+
+/**
+ * Describes the message admin.v1alpha1.GetModulesResponse.
+ * Use `create(GetModulesResponseSchema)` to create a new message.
+ */
+export const GetModulesResponseSchema: GenMessage<GetModulesResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_admin_v1alpha1_admin, 2);
+
+/**
+ * OpAMPSession is a live OpAMP connection of an agent
+ *
+ * @generated from message admin.v1alpha1.OpAMPSession
+ */
+export type OpAMPSession = Message<"admin.v1alpha1.OpAMPSession"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * the agent identified on the session, empty until it identifies itself
+   *
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * "websocket", "http" or "gateway"
+   *
+   * @generated from field: string transport = 3;
+   */
+  transport: string;
+
+  /**
+   * address the session was opened from, as seen by the server
+   *
+   * @generated from field: string remote_addr = 4;
+   */
+  remoteAddr: string;
+
+  /**
+   * X-Forwarded-For header of the request opening the session, if any
+   *
+   * @generated from field: string forwarded_for = 5;
+   */
+  forwardedFor: string;
+
+  /**
+   * @generated from field: string user_agent = 6;
+   */
+  userAgent: string;
+
+  /**
+   * unset for sessions not opened over TLS
+   *
+   * @generated from field: admin.v1alpha1.SessionTLS tls = 7;
+   */
+  tls?: SessionTLS;
+
+  /**
+   * the gateway relaying the session, for the gateway transport
+   *
+   * @generated from field: string gateway_id = 8;
+   */
+  gatewayId: string;
+
+  /**
+   * capabilities the agent declared on the session, e.g. "AcceptsRemoteConfig"
+   *
+   * @generated from field: repeated string capabilities = 9;
+   */
+  capabilities: string[];
+
+  /**
+   * @generated from field: google.protobuf.Timestamp started_at = 10;
+   */
+  startedAt?: Timestamp;
+};
+
+/**
+ * Describes the message admin.v1alpha1.OpAMPSession.
+ * Use `create(OpAMPSessionSchema)` to create a new message.
+ */
+export const OpAMPSessionSchema: GenMessage<OpAMPSession> = /*@__PURE__*/
+  messageDesc(file_pkg_api_admin_v1alpha1_admin, 3);
+
+/**
+ * @generated from message admin.v1alpha1.SessionTLS
+ */
+export type SessionTLS = Message<"admin.v1alpha1.SessionTLS"> & {
+  /**
+   * e.g. "TLS 1.3"
+   *
+   * @generated from field: string version = 1;
+   */
+  version: string;
+
+  /**
+   * @generated from field: string cipher_suite = 2;
+   */
+  cipherSuite: string;
+
+  /**
+   * the server name the agent requested
+   *
+   * @generated from field: string server_name = 3;
+   */
+  serverName: string;
+
+  /**
+   * the ALPN protocol, if one was negotiated
+   *
+   * @generated from field: string negotiated_protocol = 4;
+   */
+  negotiatedProtocol: string;
+
+  /**
+   * subject of the client certificate, empty unless the agent presented one
+   *
+   * @generated from field: string client_subject = 5;
+   */
+  clientSubject: string;
+
+  /**
+   * URI SANs of the client certificate, e.g. SPIFFE IDs
+   *
+   * @generated from field: repeated string client_uris = 6;
+   */
+  clientUris: string[];
+};
+
+/**
+ * Describes the message admin.v1alpha1.SessionTLS.
+ * Use `create(SessionTLSSchema)` to create a new message.
+ */
+export const SessionTLSSchema: GenMessage<SessionTLS> = /*@__PURE__*/
+  messageDesc(file_pkg_api_admin_v1alpha1_admin, 4);
+
+/**
+ * @generated from message admin.v1alpha1.ListSessionsRequest
+ */
+export type ListSessionsRequest = Message<"admin.v1alpha1.ListSessionsRequest"> & {
+  /**
+   * only list the sessions of this agent
+   *
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message admin.v1alpha1.ListSessionsRequest.
+ * Use `create(ListSessionsRequestSchema)` to create a new message.
+ */
+export const ListSessionsRequestSchema: GenMessage<ListSessionsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_admin_v1alpha1_admin, 5);
+
+/**
+ * @generated from message admin.v1alpha1.ListSessionsResponse
+ */
+export type ListSessionsResponse = Message<"admin.v1alpha1.ListSessionsResponse"> & {
+  /**
+   * sessions sorted by start time
+   *
+   * @generated from field: repeated admin.v1alpha1.OpAMPSession sessions = 1;
+   */
+  sessions: OpAMPSession[];
+};
+
+/**
+ * Describes the message admin.v1alpha1.ListSessionsResponse.
+ * Use `create(ListSessionsResponseSchema)` to create a new message.
+ */
+export const ListSessionsResponseSchema: GenMessage<ListSessionsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_admin_v1alpha1_admin, 6);
+
+/**
+ * @generated from enum admin.v1alpha1.ModuleState
+ */
+export enum ModuleState {
+  /**
+   * the module has no service, e.g. it only registers routes, or it is not initialized
+   *
+   * @generated from enum value: MODULE_STATE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: MODULE_STATE_NEW = 1;
+   */
+  NEW = 1,
+
+  /**
+   * @generated from enum value: MODULE_STATE_STARTING = 2;
+   */
+  STARTING = 2,
+
+  /**
+   * @generated from enum value: MODULE_STATE_RUNNING = 3;
+   */
+  RUNNING = 3,
+
+  /**
+   * @generated from enum value: MODULE_STATE_STOPPING = 4;
+   */
+  STOPPING = 4,
+
+  /**
+   * @generated from enum value: MODULE_STATE_TERMINATED = 5;
+   */
+  TERMINATED = 5,
+
+  /**
+   * @generated from enum value: MODULE_STATE_FAILED = 6;
+   */
+  FAILED = 6,
+}
+
+/**
+ * Describes the enum admin.v1alpha1.ModuleState.
+ */
+export const ModuleStateSchema: GenEnum<ModuleState> = /*@__PURE__*/
+  enumDesc(file_pkg_api_admin_v1alpha1_admin, 0);
+
+/**
+ * @generated from service admin.v1alpha1.AdminService
+ */
+export const AdminService: GenService<{
+  /**
+   * GetModules lists the modules of the server, their dependencies and state
+   *
+   * @generated from rpc admin.v1alpha1.AdminService.GetModules
+   */
+  getModules: {
+    methodKind: "unary";
+    input: typeof GetModulesRequestSchema;
+    output: typeof GetModulesResponseSchema;
+  },
+  /**
+   * ListSessions lists the live OpAMP sessions of agents and where they were opened from
+   *
+   * @generated from rpc admin.v1alpha1.AdminService.ListSessions
+   */
+  listSessions: {
+    methodKind: "unary";
+    input: typeof ListSessionsRequestSchema;
+    output: typeof ListSessionsResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_pkg_api_admin_v1alpha1_admin, 0);
+
@@ -4,15 +4,142 @@
 
 import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
 import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
-import type { EmptySchema, Timestamp } from "@bufbuild/protobuf/wkt";
-import { file_google_protobuf_empty, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Duration, EmptySchema, Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_duration, file_google_protobuf_empty, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Event } from "../../events/v1alpha1/events_pb";
+import { file_pkg_api_events_v1alpha1_events } from "../../events/v1alpha1/events_pb";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file pkg/api/agents/v1alpha1/agents.proto.
  */
 export const file_pkg_api_agents_v1alpha1_agents: GenFile = /*@__PURE__*/
-  fileDesc("CiRwa2cvYXBpL2FnZW50cy92MWFscGhhMS9hZ2VudHMucHJvdG8SD2NvbmZpZy52MWFscGhhMSIoChFMaXN0QWdlbnRzUmVxdWVzdBITCgt3aXRoX3N0YXR1cxgBIAEoCCJQChJMaXN0QWdlbnRzUmVzcG9uc2USOgoGYWdlbnRzGAEgAygLMiouY29uZmlnLnYxYWxwaGExLkFnZW50RGVzY3JpcHRpb25BbmRTdGF0dXMicwoJQWdlbnRWaWV3EjgKDHJlZ2lzdHJhdGlvbhgBIAEoCzIiLmNvbmZpZy52MWFscGhhMS5BZ2VudFJlZ2lzdHJhdGlvbhIsCgZzdGF0dXMYAiABKAsyHC5jb25maWcudjFhbHBoYTEuQWdlbnRTdGF0dXMiewoZQWdlbnREZXNjcmlwdGlvbkFuZFN0YXR1cxIwCgVhZ2VudBgBIAEoCzIhLmNvbmZpZy52MWFscGhhMS5BZ2VudERlc2NyaXB0aW9uEiwKBnN0YXR1cxgCIAEoCzIcLmNvbmZpZy52MWFscGhhMS5BZ2VudFN0YXR1cyIjCg9HZXRBZ2VudFJlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkiRAoQR2V0QWdlbnRSZXNwb25zZRIwCgVhZ2VudBgBIAEoCzIhLmNvbmZpZy52MWFscGhhMS5BZ2VudERlc2NyaXB0aW9uIikKFUdldEFnZW50U3RhdHVzUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCSJGChZHZXRBZ2VudFN0YXR1c1Jlc3BvbnNlEiwKBnN0YXR1cxgBIAEoCzIcLmNvbmZpZy52MWFscGhhMS5BZ2VudFN0YXR1cyImChJEZWxldGVBZ2VudFJlcXVlc3QSEAoIYWdlbnRfaWQYASABKAki2wMKC0FnZW50U3RhdHVzEioKBXN0YXRlGAEgASgOMhsuY29uZmlnLnYxYWxwaGExLkFnZW50U3RhdGUSMAoGaGVhbHRoGAIgASgLMiAuY29uZmlnLnYxYWxwaGExLkNvbXBvbmVudEhlYWx0aBI6ChBlZmZlY3RpdmVfY29uZmlnGAMgASgLMiAuY29uZmlnLnYxYWxwaGExLkVmZmVjdGl2ZUNvbmZpZxJBChRyZW1vdGVfY29uZmlnX3N0YXR1cxgEIAEoCzIjLmNvbmZpZy52MWFscGhhMS5SZW1vdGVDb25maWdTdGF0dXMSLQoJbGFzdF9zZWVuGAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBI9ChJjb25maWdfc3luY19zdGF0dXMYBiABKA4yIS5jb25maWcudjFhbHBoYTEuQ29uZmlnU3luY1N0YXR1cxIaChJjb25maWdfc3luY19yZWFzb24YByABKAkSMAoMY29ubmVjdGVkX2F0GAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIzCg9kaXNjb25uZWN0ZWRfYXQYCSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIsYBChFBZ2VudFJlZ2lzdHJhdGlvbhIKCgJpZBgBIAEoCRIVCg1mcmllbmRseV9uYW1lGAIgASgJEjkKFmlkZW50aWZ5aW5nX2F0dHJpYnV0ZXMYAyADKAsyGS5jb25maWcudjFhbHBoYTEuS2V5VmFsdWUSPQoabm9uX2lkZW50aWZ5aW5nX2F0dHJpYnV0ZXMYBCADKAsyGS5jb25maWcudjFhbHBoYTEuS2V5VmFsdWUSFAoMY2FwYWJpbGl0aWVzGAUgAygJIsUBChBBZ2VudERlc2NyaXB0aW9uEgoKAmlkGAEgASgJEhUKDWZyaWVuZGx5X25hbWUYAiABKAkSOQoWaWRlbnRpZnlpbmdfYXR0cmlidXRlcxgDIAMoCzIZLmNvbmZpZy52MWFscGhhMS5LZXlWYWx1ZRI9Chpub25faWRlbnRpZnlpbmdfYXR0cmlidXRlcxgEIAMoCzIZLmNvbmZpZy52MWFscGhhMS5LZXlWYWx1ZRIUCgxjYXBhYmlsaXRpZXMYBSADKAkiQQoIS2V5VmFsdWUSCwoDa2V5GAEgASgJEigKBXZhbHVlGAIgASgLMhkuY29uZmlnLnYxYWxwaGExLkFueVZhbHVlIvABCghBbnlWYWx1ZRIWCgxzdHJpbmdfdmFsdWUYASABKAlIABIUCgpib29sX3ZhbHVlGAIgASgISAASEwoJaW50X3ZhbHVlGAMgASgDSAASFgoMZG91YmxlX3ZhbHVlGAQgASgBSAASFQoLYnl0ZXNfdmFsdWUYBSABKAxIABIyCgthcnJheV92YWx1ZRgGIAEoCzIbLmNvbmZpZy52MWFscGhhMS5BcnJheVZhbHVlSAASNQoMa3ZsaXN0X3ZhbHVlGAcgASgLMh0uY29uZmlnLnYxYWxwaGExLktleVZhbHVlTGlzdEgAQgcKBXZhbHVlIjcKCkFycmF5VmFsdWUSKQoGdmFsdWVzGAEgAygLMhkuY29uZmlnLnYxYWxwaGExLkFueVZhbHVlIjkKDEtleVZhbHVlTGlzdBIpCgZ2YWx1ZXMYASADKAsyGS5jb25maWcudjFhbHBoYTEuS2V5VmFsdWUirAIKFEFnZW50Q29ubmVjdGlvblN0YXRlEhAKCGFnZW50X2lkGAEgASgJEioKBXN0YXRlGAIgASgOMhsuY29uZmlnLnYxYWxwaGExLkFnZW50U3RhdGUSLQoJbGFzdF9zZWVuGAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIwCgxjb25uZWN0ZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjMKD2Rpc2Nvbm5lY3RlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASFAoMaW5zdGFuY2VfdWlkGAYgASgMEhQKDGNhcGFiaWxpdGllcxgHIAEoBBIUCgxzZXF1ZW5jZV9udW0YCCABKAQiuAIKD0NvbXBvbmVudEhlYWx0aBIPCgdoZWFsdGh5GAEgASgIEhwKFHN0YXJ0X3RpbWVfdW5peF9uYW5vGAIgASgEEhIKCmxhc3RfZXJyb3IYAyABKAkSDgoGc3RhdHVzGAQgASgJEh0KFXN0YXR1c190aW1lX3VuaXhfbmFubxgFIAEoBBJWChRjb21wb25lbnRfaGVhbHRoX21hcBgGIAMoCzI4LmNvbmZpZy52MWFscGhhMS5Db21wb25lbnRIZWFsdGguQ29tcG9uZW50SGVhbHRoTWFwRW50cnkaWwoXQ29tcG9uZW50SGVhbHRoTWFwRW50cnkSCwoDa2V5GAEgASgJEi8KBXZhbHVlGAIgASgLMiAuY29uZmlnLnYxYWxwaGExLkNvbXBvbmVudEhlYWx0aDoCOAEiRgoPRWZmZWN0aXZlQ29uZmlnEjMKCmNvbmZpZ19tYXAYASABKAsyHy5jb25maWcudjFhbHBoYTEuQWdlbnRDb25maWdNYXAiqAEKDkFnZW50Q29uZmlnTWFwEkIKCmNvbmZpZ19tYXAYASADKAsyLi5jb25maWcudjFhbHBoYTEuQWdlbnRDb25maWdNYXAuQ29uZmlnTWFwRW50cnkaUgoOQ29uZmlnTWFwRW50cnkSCwoDa2V5GAEgASgJEi8KBXZhbHVlGAIgASgLMiAuY29uZmlnLnYxYWxwaGExLkFnZW50Q29uZmlnRmlsZToCOAEiNQoPQWdlbnRDb25maWdGaWxlEgwKBGJvZHkYASABKAwSFAoMY29udGVudF90eXBlGAIgASgJIoMBChJSZW1vdGVDb25maWdTdGF0dXMSHwoXbGFzdF9yZW1vdGVfY29uZmlnX2hhc2gYASABKAwSNQoGc3RhdHVzGAIgASgOMiUuY29uZmlnLnYxYWxwaGExLlJlbW90ZUNvbmZpZ1N0YXR1c2VzEhUKDWVycm9yX21lc3NhZ2UYAyABKAkqXgoKQWdlbnRTdGF0ZRIXChNBR0VOVF9TVEFURV9VTktOT1dOEAASGQoVQUdFTlRfU1RBVEVfQ09OTkVDVEVEEAESHAoYQUdFTlRfU1RBVEVfRElTQ09OTkVDVEVEEAIqtQEKEENvbmZpZ1N5bmNTdGF0dXMSHgoaQ09ORklHX1NZTkNfU1RBVFVTX1VOS05PV04QABIeChpDT05GSUdfU1lOQ19TVEFUVVNfSU5fU1lOQxABEiIKHkNPTkZJR19TWU5DX1NUQVRVU19PVVRfT0ZfU1lOQxACEh8KG0NPTkZJR19TWU5DX1NUQVRVU19BUFBMWUlORxADEhwKGENPTkZJR19TWU5DX1NUQVRVU19FUlJPUhAEKqQBChRSZW1vdGVDb25maWdTdGF0dXNlcxIgChxSRU1PVEVfQ09ORklHX1NUQVRVU0VTX1VOU0VUEAASIgoeUkVNT1RFX0NPTkZJR19TVEFUVVNFU19BUFBMSUVEEAESIwofUkVNT1RFX0NPTkZJR19TVEFUVVNFU19BUFBMWUlORxACEiEKHVJFTU9URV9DT05GSUdfU1RBVFVTRVNfRkFJTEVEEAMy3QIKDEFnZW50U2VydmljZRJVCgpMaXN0QWdlbnRzEiIuY29uZmlnLnYxYWxwaGExLkxpc3RBZ2VudHNSZXF1ZXN0GiMuY29uZmlnLnYxYWxwaGExLkxpc3RBZ2VudHNSZXNwb25zZRJPCghHZXRBZ2VudBIgLmNvbmZpZy52MWFscGhhMS5HZXRBZ2VudFJlcXVlc3QaIS5jb25maWcudjFhbHBoYTEuR2V0QWdlbnRSZXNwb25zZRJZCgZTdGF0dXMSJi5jb25maWcudjFhbHBoYTEuR2V0QWdlbnRTdGF0dXNSZXF1ZXN0GicuY29uZmlnLnYxYWxwaGExLkdldEFnZW50U3RhdHVzUmVzcG9uc2USSgoLRGVsZXRlQWdlbnQSIy5jb25maWcudjFhbHBoYTEuRGVsZXRlQWdlbnRSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5QjhaNmdpdGh1Yi5jb20vb3RlbGZsZWV0L290ZWxmbGVldC9wa2cvYXBpL2FnZW50cy92MWFscGhhMWIGcHJvdG8z", [file_google_protobuf_empty, file_google_protobuf_timestamp]);
+  fileDesc("CiRwa2cvYXBpL2FnZW50cy92MWFscGhhMS9hZ2VudHMucHJvdG8SD2NvbmZpZy52MWFscGhhMSJkCgxSZWxheVJlcXVlc3QSEgoKZ2F0ZXdheV9pZBgBIAEoCRIVCg1jb25uZWN0aW9uX2lkGAIgASgJEhAKCGFnZW50X2lkGAMgASgJEhcKD2FnZW50X3RvX3NlcnZlchgEIAEoDCIoCg1SZWxheVJlc3BvbnNlEhcKD3NlcnZlcl90b19hZ2VudBgBIAMoDCIpChNXYXRjaEdhdGV3YXlSZXF1ZXN0EhIKCmdhdGV3YXlfaWQYASABKAkiUgoOUmVsYXllZE1lc3NhZ2USFQoNY29ubmVjdGlvbl9pZBgBIAEoCRIQCghhZ2VudF9pZBgCIAEoCRIXCg9zZXJ2ZXJfdG9fYWdlbnQYAyABKAwiSgodRGlzY29ubmVjdFJlbGF5ZWRBZ2VudFJlcXVlc3QSEgoKZ2F0ZXdheV9pZBgBIAEoCRIVCg1jb25uZWN0aW9uX2lkGAIgASgJIp8EChFMaXN0QWdlbnRzUmVxdWVzdBITCgt3aXRoX3N0YXR1cxgBIAEoCBIuCgR2aWV3GAIgASgOMiAuY29uZmlnLnYxYWxwaGExLkFnZW50U3RhdHVzVmlldxI/ChRjb25maWdfc3luY19zdGF0dXNlcxgDIAMoDjIhLmNvbmZpZy52MWFscGhhMS5Db25maWdTeW5jU3RhdHVzEhcKD2luY2x1ZGVfZGVsZXRlZBgEIAEoCBIRCglwYWdlX3NpemUYBSABKAUSEgoKcGFnZV90b2tlbhgGIAEoCRIwCgdzb3J0X2J5GAcgASgOMh8uY29uZmlnLnYxYWxwaGExLkFnZW50U29ydEZpZWxkEhIKCmRlc2NlbmRpbmcYCCABKAgSKwoGc3RhdGVzGAkgAygOMhsuY29uZmlnLnYxYWxwaGExLkFnZW50U3RhdGUSTQoObGFiZWxfc2VsZWN0b3IYCiADKAsyNS5jb25maWcudjFhbHBoYTEuTGlzdEFnZW50c1JlcXVlc3QuTGFiZWxTZWxlY3RvckVudHJ5EhIKCmNvbmZpZ19pZHMYCyADKAkSOAoNaGVhbHRoX3N0YXRlcxgMIAMoDjIhLmNvbmZpZy52MWFscGhhMS5BZ2VudEhlYWx0aFN0YXRlGjQKEkxhYmVsU2VsZWN0b3JFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIsYBChJMaXN0QWdlbnRzUmVzcG9uc2USOgoGYWdlbnRzGAEgAygLMiouY29uZmlnLnYxYWxwaGExLkFnZW50RGVzY3JpcHRpb25BbmRTdGF0dXMSLwoGZXJyb3JzGAIgAygLMh8uY29uZmlnLnYxYWxwaGExLkFnZW50TG9hZEVycm9yEhMKC3RvdGFsX2NvdW50GAMgASgFEhcKD25leHRfcGFnZV90b2tlbhgEIAEoCRIVCg1tYXRjaGVkX2NvdW50GAUgASgFIjMKDkFnZW50TG9hZEVycm9yEhAKCGFnZW50X2lkGAEgASgJEg8KB21lc3NhZ2UYAiABKAkicwoJQWdlbnRWaWV3EjgKDHJlZ2lzdHJhdGlvbhgBIAEoCzIiLmNvbmZpZy52MWFscGhhMS5BZ2VudFJlZ2lzdHJhdGlvbhIsCgZzdGF0dXMYAiABKAsyHC5jb25maWcudjFhbHBoYTEuQWdlbnRTdGF0dXMiewoZQWdlbnREZXNjcmlwdGlvbkFuZFN0YXR1cxIwCgVhZ2VudBgBIAEoCzIhLmNvbmZpZy52MWFscGhhMS5BZ2VudERlc2NyaXB0aW9uEiwKBnN0YXR1cxgCIAEoCzIcLmNvbmZpZy52MWFscGhhMS5BZ2VudFN0YXR1cyIjCg9HZXRBZ2VudFJlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkiRAoQR2V0QWdlbnRSZXNwb25zZRIwCgVhZ2VudBgBIAEoCzIhLmNvbmZpZy52MWFscGhhMS5BZ2VudERlc2NyaXB0aW9uIlkKFUdldEFnZW50U3RhdHVzUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCRIuCgR2aWV3GAIgASgOMiAuY29uZmlnLnYxYWxwaGExLkFnZW50U3RhdHVzVmlldyJGChZHZXRBZ2VudFN0YXR1c1Jlc3BvbnNlEiwKBnN0YXR1cxgBIAEoCzIcLmNvbmZpZy52MWFscGhhMS5BZ2VudFN0YXR1cyKlAQocV2FpdEZvckFnZW50Q29uZGl0aW9uUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCRIyCgljb25kaXRpb24YAiABKA4yHy5jb25maWcudjFhbHBoYTEuQWdlbnRDb25kaXRpb24SEwoLY29uZmlnX2hhc2gYAyABKAwSKgoHdGltZW91dBgEIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbiJgCh1XYWl0Rm9yQWdlbnRDb25kaXRpb25SZXNwb25zZRIRCglzYXRpc2ZpZWQYASABKAgSLAoGc3RhdHVzGAIgASgLMhwuY29uZmlnLnYxYWxwaGExLkFnZW50U3RhdHVzIjUKEkRlbGV0ZUFnZW50UmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCRINCgVwdXJnZRgCIAEoCCJCChtDYXB0dXJlQWdlbnRTbmFwc2hvdFJlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkSEQoJbWF4X2J5dGVzGAIgASgDIlAKHENhcHR1cmVBZ2VudFNuYXBzaG90UmVzcG9uc2USMAoIc25hcHNob3QYASABKAsyHi5jb25maWcudjFhbHBoYTEuQWdlbnRTbmFwc2hvdCIuChdHZXRBZ2VudFNuYXBzaG90UmVxdWVzdBITCgtzbmFwc2hvdF9pZBgBIAEoCSJMChhHZXRBZ2VudFNuYXBzaG90UmVzcG9uc2USMAoIc25hcHNob3QYASABKAsyHi5jb25maWcudjFhbHBoYTEuQWdlbnRTbmFwc2hvdCItChlMaXN0QWdlbnRTbmFwc2hvdHNSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJIk8KGkxpc3RBZ2VudFNuYXBzaG90c1Jlc3BvbnNlEjEKCXNuYXBzaG90cxgBIAMoCzIeLmNvbmZpZy52MWFscGhhMS5BZ2VudFNuYXBzaG90IjwKF0xpc3RDb25maWdQdXNoZXNSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJEg8KB3B1c2hfaWQYAiABKAkiRwoYTGlzdENvbmZpZ1B1c2hlc1Jlc3BvbnNlEisKBnB1c2hlcxgBIAMoCzIbLmNvbmZpZy52MWFscGhhMS5Db25maWdQdXNoIh0KG0NhcHR1cmVGbGVldFNuYXBzaG90UmVxdWVzdCIbChlMaXN0RmxlZXRTbmFwc2hvdHNSZXF1ZXN0Ik8KGkxpc3RGbGVldFNuYXBzaG90c1Jlc3BvbnNlEjEKCXNuYXBzaG90cxgBIAMoCzIeLmNvbmZpZy52MWFscGhhMS5GbGVldFNuYXBzaG90IkkKFURpZmZGbGVldFN0YXRlUmVxdWVzdBIYChBmcm9tX3NuYXBzaG90X2lkGAEgASgJEhYKDnRvX3NuYXBzaG90X2lkGAIgASgJIpYBCg1GbGVldFNuYXBzaG90EgoKAmlkGAEgASgJEi8KC2NhcHR1cmVkX2F0GAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBITCgthZ2VudF9jb3VudBgDIAEoBRIzCgZhZ2VudHMYBCADKAsyIy5jb25maWcudjFhbHBoYTEuRmxlZXRTbmFwc2hvdEFnZW50IuwBChJGbGVldFNuYXBzaG90QWdlbnQSEAoIYWdlbnRfaWQYASABKAkSDAoEbmFtZRgCIAEoCRIPCgd2ZXJzaW9uGAMgASgJEhEKCWNvbmZpZ19pZBgEIAEoCRITCgtjb25maWdfaGFzaBgFIAEoCRINCgVzdGF0ZRgGIAEoCRI/CgZsYWJlbHMYByADKAsyLy5jb25maWcudjFhbHBoYTEuRmxlZXRTbmFwc2hvdEFnZW50LkxhYmVsc0VudHJ5Gi0KC0xhYmVsc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEiiAIKDkZsZWV0U3RhdGVEaWZmEiwKBGZyb20YASABKAsyHi5jb25maWcudjFhbHBoYTEuRmxlZXRTbmFwc2hvdBIqCgJ0bxgCIAEoCzIeLmNvbmZpZy52MWFscGhhMS5GbGVldFNuYXBzaG90EjIKBWFkZGVkGAMgAygLMiMuY29uZmlnLnYxYWxwaGExLkZsZWV0U25hcHNob3RBZ2VudBI0CgdyZW1vdmVkGAQgAygLMiMuY29uZmlnLnYxYWxwaGExLkZsZWV0U25hcHNob3RBZ2VudBIyCgdjaGFuZ2VkGAUgAygLMiEuY29uZmlnLnYxYWxwaGExLkFnZW50U3RhdGVDaGFuZ2UiUwoQQWdlbnRTdGF0ZUNoYW5nZRIQCghhZ2VudF9pZBgBIAEoCRItCgdjaGFuZ2VzGAIgAygLMhwuY29uZmlnLnYxYWxwaGExLkZpZWxkQ2hhbmdlIjYKC0ZpZWxkQ2hhbmdlEg0KBWZpZWxkGAEgASgJEgwKBGZyb20YAiABKAkSCgoCdG8YAyABKAkizwIKDUFnZW50U25hcHNob3QSCgoCaWQYASABKAkSEAoIYWdlbnRfaWQYAiABKAkSMgoFc3RhdGUYAyABKA4yIy5jb25maWcudjFhbHBoYTEuQWdlbnRTbmFwc2hvdFN0YXRlEjAKDHJlcXVlc3RlZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASMAoMY29tcGxldGVkX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgpleHBpcmVzX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIRCgltYXhfYnl0ZXMYByABKAMSEgoKc2l6ZV9ieXRlcxgIIAEoAxIRCgl0cnVuY2F0ZWQYCSABKAgSDQoFZXJyb3IYCiABKAkSDwoHYXJjaGl2ZRgLIAEoDCJRCg9TbmFwc2hvdFJlcXVlc3QSEwoLc25hcHNob3RfaWQYASABKAkSFgoOc2VydmVyX3B1Yl9rZXkYAiABKAwSEQoJbWF4X2J5dGVzGAMgASgDInMKDlNuYXBzaG90VXBsb2FkEhMKC3NuYXBzaG90X2lkGAEgASgJEhYKDmNsaWVudF9wdWJfa2V5GAIgASgMEhIKCmNpcGhlcnRleHQYAyABKAwSEQoJdHJ1bmNhdGVkGAQgASgIEg0KBWVycm9yGAUgASgJIpYFCgtBZ2VudFN0YXR1cxIqCgVzdGF0ZRgBIAEoDjIbLmNvbmZpZy52MWFscGhhMS5BZ2VudFN0YXRlEjAKBmhlYWx0aBgCIAEoCzIgLmNvbmZpZy52MWFscGhhMS5Db21wb25lbnRIZWFsdGgSOgoQZWZmZWN0aXZlX2NvbmZpZxgDIAEoCzIgLmNvbmZpZy52MWFscGhhMS5FZmZlY3RpdmVDb25maWcSQQoUcmVtb3RlX2NvbmZpZ19zdGF0dXMYBCABKAsyIy5jb25maWcudjFhbHBoYTEuUmVtb3RlQ29uZmlnU3RhdHVzEi0KCWxhc3Rfc2VlbhgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASPQoSY29uZmlnX3N5bmNfc3RhdHVzGAYgASgOMiEuY29uZmlnLnYxYWxwaGExLkNvbmZpZ1N5bmNTdGF0dXMSGgoSY29uZmlnX3N5bmNfcmVhc29uGAcgASgJEjAKDGNvbm5lY3RlZF9hdBgIIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASMwoPZGlzY29ubmVjdGVkX2F0GAkgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIUCgxpbnN0YW5jZV91aWQYCiABKAwSOAoQaW5zdGFuY2VfaGlzdG9yeRgLIAMoCzIeLmNvbmZpZy52MWFscGhhMS5BZ2VudEluc3RhbmNlEjkKDGxhc3RfY29tbWFuZBgMIAEoCzIjLmNvbmZpZy52MWFscGhhMS5BZ2VudENvbW1hbmRTdGF0dXMSLgoHbWV0cmljcxgNIAEoCzIdLmNvbmZpZy52MWFscGhhMS5BZ2VudE1ldHJpY3MihQIKDEFnZW50TWV0cmljcxIvCgtyZWNlaXZlZF9hdBgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEwoLY3B1X3NlY29uZHMYAiABKAESFwoPY3B1X3V0aWxpemF0aW9uGAMgASgBEhgKEG1lbW9yeV9yc3NfYnl0ZXMYBCABKAMSGAoQaGVhcF9hbGxvY19ieXRlcxgFIAEoAxIpCgZ1cHRpbWUYBiABKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24SNwoPZXhwb3J0ZXJfcXVldWVzGAcgAygLMh4uY29uZmlnLnYxYWxwaGExLkV4cG9ydGVyUXVldWUiQQoNRXhwb3J0ZXJRdWV1ZRIQCghleHBvcnRlchgBIAEoCRIMCgRzaXplGAIgASgDEhAKCGNhcGFjaXR5GAMgASgDIsYBChFBZ2VudFJlZ2lzdHJhdGlvbhIKCgJpZBgBIAEoCRIVCg1mcmllbmRseV9uYW1lGAIgASgJEjkKFmlkZW50aWZ5aW5nX2F0dHJpYnV0ZXMYAyADKAsyGS5jb25maWcudjFhbHBoYTEuS2V5VmFsdWUSPQoabm9uX2lkZW50aWZ5aW5nX2F0dHJpYnV0ZXMYBCADKAsyGS5jb25maWcudjFhbHBoYTEuS2V5VmFsdWUSFAoMY2FwYWJpbGl0aWVzGAUgAygJIrUCChBBZ2VudERlc2NyaXB0aW9uEgoKAmlkGAEgASgJEhUKDWZyaWVuZGx5X25hbWUYAiABKAkSOQoWaWRlbnRpZnlpbmdfYXR0cmlidXRlcxgDIAMoCzIZLmNvbmZpZy52MWFscGhhMS5LZXlWYWx1ZRI9Chpub25faWRlbnRpZnlpbmdfYXR0cmlidXRlcxgEIAMoCzIZLmNvbmZpZy52MWFscGhhMS5LZXlWYWx1ZRIUCgxjYXBhYmlsaXRpZXMYBSADKAkSLgoKZGVsZXRlZF9hdBgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKcmV2b2tlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDgoGdGVuYW50GAggASgJIkEKCEtleVZhbHVlEgsKA2tleRgBIAEoCRIoCgV2YWx1ZRgCIAEoCzIZLmNvbmZpZy52MWFscGhhMS5BbnlWYWx1ZSLwAQoIQW55VmFsdWUSFgoMc3RyaW5nX3ZhbHVlGAEgASgJSAASFAoKYm9vbF92YWx1ZRgCIAEoCEgAEhMKCWludF92YWx1ZRgDIAEoA0gAEhYKDGRvdWJsZV92YWx1ZRgEIAEoAUgAEhUKC2J5dGVzX3ZhbHVlGAUgASgMSAASMgoLYXJyYXlfdmFsdWUYBiABKAsyGy5jb25maWcudjFhbHBoYTEuQXJyYXlWYWx1ZUgAEjUKDGt2bGlzdF92YWx1ZRgHIAEoCzIdLmNvbmZpZy52MWFscGhhMS5LZXlWYWx1ZUxpc3RIAEIHCgV2YWx1ZSI3CgpBcnJheVZhbHVlEikKBnZhbHVlcxgBIAMoCzIZLmNvbmZpZy52MWFscGhhMS5BbnlWYWx1ZSI5CgxLZXlWYWx1ZUxpc3QSKQoGdmFsdWVzGAEgAygLMhkuY29uZmlnLnYxYWxwaGExLktleVZhbHVlIuYCChRBZ2VudENvbm5lY3Rpb25TdGF0ZRIQCghhZ2VudF9pZBgBIAEoCRIqCgVzdGF0ZRgCIAEoDjIbLmNvbmZpZy52MWFscGhhMS5BZ2VudFN0YXRlEi0KCWxhc3Rfc2VlbhgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASMAoMY29ubmVjdGVkX2F0GAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIzCg9kaXNjb25uZWN0ZWRfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhQKDGluc3RhbmNlX3VpZBgGIAEoDBIUCgxjYXBhYmlsaXRpZXMYByABKAQSFAoMc2VxdWVuY2VfbnVtGAggASgEEjgKEGluc3RhbmNlX2hpc3RvcnkYCSADKAsyHi5jb25maWcudjFhbHBoYTEuQWdlbnRJbnN0YW5jZSKEAQoNQWdlbnRJbnN0YW5jZRIUCgxpbnN0YW5jZV91aWQYASABKAwSLgoKZmlyc3Rfc2VlbhgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLQoJbGFzdF9zZWVuGAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCK4AgoPQ29tcG9uZW50SGVhbHRoEg8KB2hlYWx0aHkYASABKAgSHAoUc3RhcnRfdGltZV91bml4X25hbm8YAiABKAQSEgoKbGFzdF9lcnJvchgDIAEoCRIOCgZzdGF0dXMYBCABKAkSHQoVc3RhdHVzX3RpbWVfdW5peF9uYW5vGAUgASgEElYKFGNvbXBvbmVudF9oZWFsdGhfbWFwGAYgAygLMjguY29uZmlnLnYxYWxwaGExLkNvbXBvbmVudEhlYWx0aC5Db21wb25lbnRIZWFsdGhNYXBFbnRyeRpbChdDb21wb25lbnRIZWFsdGhNYXBFbnRyeRILCgNrZXkYASABKAkSLwoFdmFsdWUYAiABKAsyIC5jb25maWcudjFhbHBoYTEuQ29tcG9uZW50SGVhbHRoOgI4ASJGCg9FZmZlY3RpdmVDb25maWcSMwoKY29uZmlnX21hcBgBIAEoCzIfLmNvbmZpZy52MWFscGhhMS5BZ2VudENvbmZpZ01hcCKoAQoOQWdlbnRDb25maWdNYXASQgoKY29uZmlnX21hcBgBIAMoCzIuLmNvbmZpZy52MWFscGhhMS5BZ2VudENvbmZpZ01hcC5Db25maWdNYXBFbnRyeRpSCg5Db25maWdNYXBFbnRyeRILCgNrZXkYASABKAkSLwoFdmFsdWUYAiABKAsyIC5jb25maWcudjFhbHBoYTEuQWdlbnRDb25maWdGaWxlOgI4ASI1Cg9BZ2VudENvbmZpZ0ZpbGUSDAoEYm9keRgBIAEoDBIUCgxjb250ZW50X3R5cGUYAiABKAkigwEKElJlbW90ZUNvbmZpZ1N0YXR1cxIfChdsYXN0X3JlbW90ZV9jb25maWdfaGFzaBgBIAEoDBI1CgZzdGF0dXMYAiABKA4yJS5jb25maWcudjFhbHBoYTEuUmVtb3RlQ29uZmlnU3RhdHVzZXMSFQoNZXJyb3JfbWVzc2FnZRgDIAEoCSKyAgoKQ29uZmlnUHVzaBIPCgdwdXNoX2lkGAEgASgJEhAKCGFnZW50X2lkGAIgASgJEhMKC2NvbmZpZ19oYXNoGAMgASgMEi8KBXN0YXRlGAQgASgOMiAuY29uZmlnLnYxYWxwaGExLkNvbmZpZ1B1c2hTdGF0ZRIuCgpvZmZlcmVkX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIzCg9hY2tub3dsZWRnZWRfYXQYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCmFwcGxpZWRfYXQYByABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhUKDWVycm9yX21lc3NhZ2UYCCABKAkSDwoHYXR0ZW1wdBgJIAEoBSJAChFDb25maWdQdXNoSGlzdG9yeRIrCgZwdXNoZXMYASADKAsyGy5jb25maWcudjFhbHBoYTEuQ29uZmlnUHVzaCIiCg9Db25maWdQdXNoT2ZmZXISDwoHcHVzaF9pZBgBIAEoCSJMChFDb25maWdQdXNoUmVjZWlwdBIPCgdwdXNoX2lkGAEgASgJEg8KB2FwcGxpZWQYAiABKAgSFQoNZXJyb3JfbWVzc2FnZRgDIAEoCSJLChNBdmFpbGFiaWxpdHlIaXN0b3J5EjQKB3BlcmlvZHMYASADKAsyIy5jb25maWcudjFhbHBoYTEuQXZhaWxhYmlsaXR5UGVyaW9kIokBChJBdmFpbGFiaWxpdHlQZXJpb2QSKQoFc3RhcnQYASABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEicKA2VuZBgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDwoHaGVhbHRoeRgDIAEoCBIOCgZjbG9zZWQYBCABKAgiWwobR2V0QWdlbnRBdmFpbGFiaWxpdHlSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJEioKB3dpbmRvd3MYAiADKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24iYwoSV2luZG93QXZhaWxhYmlsaXR5EikKBndpbmRvdxgBIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhIRCgljb25uZWN0ZWQYAiABKAESDwoHaGVhbHRoeRgDIAEoASJbChFBZ2VudEF2YWlsYWJpbGl0eRIQCghhZ2VudF9pZBgBIAEoCRI0Cgd3aW5kb3dzGAIgAygLMiMuY29uZmlnLnYxYWxwaGExLldpbmRvd0F2YWlsYWJpbGl0eSLaAQobR2V0RmxlZXRBdmFpbGFiaWxpdHlSZXF1ZXN0EhAKCGdyb3VwX2J5GAEgASgJEkwKCHNlbGVjdG9yGAIgAygLMjouY29uZmlnLnYxYWxwaGExLkdldEZsZWV0QXZhaWxhYmlsaXR5UmVxdWVzdC5TZWxlY3RvckVudHJ5EioKB3dpbmRvd3MYAyADKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24aLwoNU2VsZWN0b3JFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBInMKEUF2YWlsYWJpbGl0eUdyb3VwEhMKC2xhYmVsX3ZhbHVlGAEgASgJEhMKC2FnZW50X2NvdW50GAIgASgFEjQKB3dpbmRvd3MYAyADKAsyIy5jb25maWcudjFhbHBoYTEuV2luZG93QXZhaWxhYmlsaXR5IkcKEUZsZWV0QXZhaWxhYmlsaXR5EjIKBmdyb3VwcxgBIAMoCzIiLmNvbmZpZy52MWFscGhhMS5BdmFpbGFiaWxpdHlHcm91cCLyAQoSQWdlbnRDb21tYW5kU3RhdHVzEgoKAmlkGAEgASgJEgwKBHR5cGUYAiABKAkSMQoFc3RhdGUYAyABKA4yIi5jb25maWcudjFhbHBoYTEuQWdlbnRDb21tYW5kU3RhdGUSFAoMcmVxdWVzdGVkX2J5GAQgASgJEjAKDHJlcXVlc3RlZF9hdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASMAoMY29tcGxldGVkX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIVCg1lcnJvcl9tZXNzYWdlGAcgASgJIkwKEkFnZW50Q29tbWFuZFJlc3VsdBIMCgR0eXBlGAEgASgJEhEKCXN1Y2NlZWRlZBgCIAEoCBIVCg1lcnJvcl9tZXNzYWdlGAMgASgJIicKE1Jlc3RhcnRBZ2VudFJlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkiTAoUUmVzdGFydEFnZW50UmVzcG9uc2USNAoHY29tbWFuZBgBIAEoCzIjLmNvbmZpZy52MWFscGhhMS5BZ2VudENvbW1hbmRTdGF0dXMiKAoUVW5kZWxldGVBZ2VudFJlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkiJgoSUmV2b2tlQWdlbnRSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJIq8BChVHZXRBZ2VudEV2ZW50c1JlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkSKQoFc3RhcnQYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEicKA2VuZBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDQoFdHlwZXMYBCADKAkSDQoFbGltaXQYBSABKAUSEgoKcGFnZV90b2tlbhgGIAEoCSJZChZHZXRBZ2VudEV2ZW50c1Jlc3BvbnNlEiYKBmV2ZW50cxgBIAMoCzIWLmV2ZW50cy52MWFscGhhMS5FdmVudBIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAki3gEKEkFnZW50UHJvYmxlbVJlcG9ydBIvCgR0eXBlGAEgASgOMiEuY29uZmlnLnYxYWxwaGExLkFnZW50UHJvYmxlbVR5cGUSDwoHbWVzc2FnZRgCIAEoCRITCgtvY2N1cnJlbmNlcxgDIAEoBRJBCgdkZXRhaWxzGAQgAygLMjAuY29uZmlnLnYxYWxwaGExLkFnZW50UHJvYmxlbVJlcG9ydC5EZXRhaWxzRW50cnkaLgoMRGV0YWlsc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEi4gIKDEFnZW50UHJvYmxlbRIQCghhZ2VudF9pZBgBIAEoCRIvCgR0eXBlGAIgASgOMiEuY29uZmlnLnYxYWxwaGExLkFnZW50UHJvYmxlbVR5cGUSDwoHbWVzc2FnZRgDIAEoCRITCgtvY2N1cnJlbmNlcxgEIAEoBRI7CgdkZXRhaWxzGAUgAygLMiouY29uZmlnLnYxYWxwaGExLkFnZW50UHJvYmxlbS5EZXRhaWxzRW50cnkSDwoHcmVwb3J0cxgGIAEoBRI1ChFmaXJzdF9yZXBvcnRlZF9hdBgHIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASNAoQbGFzdF9yZXBvcnRlZF9hdBgIIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAaLgoMRGV0YWlsc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEiXgoYR2V0UHJvYmxlbXNSZXBvcnRSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJEjAKBXR5cGVzGAIgAygOMiEuY29uZmlnLnYxYWxwaGExLkFnZW50UHJvYmxlbVR5cGUiTAoZR2V0UHJvYmxlbXNSZXBvcnRSZXNwb25zZRIvCghwcm9ibGVtcxgBIAMoCzIdLmNvbmZpZy52MWFscGhhMS5BZ2VudFByb2JsZW0iXgoZQWNrbm93bGVkZ2VQcm9ibGVtUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCRIvCgR0eXBlGAIgASgOMiEuY29uZmlnLnYxYWxwaGExLkFnZW50UHJvYmxlbVR5cGUqiQEKDkFnZW50U29ydEZpZWxkEiAKHEFHRU5UX1NPUlRfRklFTERfVU5TUEVDSUZJRUQQABIZChVBR0VOVF9TT1JUX0ZJRUxEX05BTUUQARIeChpBR0VOVF9TT1JUX0ZJRUxEX0xBU1RfU0VFThACEhoKFkFHRU5UX1NPUlRfRklFTERfU1RBVEUQAyp0ChBBZ2VudEhlYWx0aFN0YXRlEh4KGkFHRU5UX0hFQUxUSF9TVEFURV9VTktOT1dOEAASHgoaQUdFTlRfSEVBTFRIX1NUQVRFX0hFQUxUSFkQARIgChxBR0VOVF9IRUFMVEhfU1RBVEVfVU5IRUFMVEhZEAIqiwEKD0FnZW50U3RhdHVzVmlldxIhCh1BR0VOVF9TVEFUVVNfVklFV19VTlNQRUNJRklFRBAAEhsKF0FHRU5UX1NUQVRVU19WSUVXX0JBU0lDEAESHAoYQUdFTlRfU1RBVFVTX1ZJRVdfSEVBTFRIEAISGgoWQUdFTlRfU1RBVFVTX1ZJRVdfRlVMTBADKrMBCg5BZ2VudENvbmRpdGlvbhIfChtBR0VOVF9DT05ESVRJT05fVU5TUEVDSUZJRUQQABIdChlBR0VOVF9DT05ESVRJT05fQ09OTkVDVEVEEAESIgoeQUdFTlRfQ09ORElUSU9OX0NPTkZJR19BUFBMSUVEEAISGwoXQUdFTlRfQ09ORElUSU9OX0hFQUxUSFkQAxIgChxBR0VOVF9DT05ESVRJT05fRElTQ09OTkVDVEVEEAQqnQEKEkFnZW50U25hcHNob3RTdGF0ZRIkCiBBR0VOVF9TTkFQU0hPVF9TVEFURV9VTlNQRUNJRklFRBAAEiAKHEFHRU5UX1NOQVBTSE9UX1NUQVRFX1BFTkRJTkcQARIeChpBR0VOVF9TTkFQU0hPVF9TVEFURV9SRUFEWRACEh8KG0FHRU5UX1NOQVBTSE9UX1NUQVRFX0ZBSUxFRBADKl4KCkFnZW50U3RhdGUSFwoTQUdFTlRfU1RBVEVfVU5LTk9XThAAEhkKFUFHRU5UX1NUQVRFX0NPTk5FQ1RFRBABEhwKGEFHRU5UX1NUQVRFX0RJU0NPTk5FQ1RFRBACKtkBChBDb25maWdTeW5jU3RhdHVzEh4KGkNPTkZJR19TWU5DX1NUQVRVU19VTktOT1dOEAASHgoaQ09ORklHX1NZTkNfU1RBVFVTX0lOX1NZTkMQARIiCh5DT05GSUdfU1lOQ19TVEFUVVNfT1VUX09GX1NZTkMQAhIfChtDT05GSUdfU1lOQ19TVEFUVVNfQVBQTFlJTkcQAxIcChhDT05GSUdfU1lOQ19TVEFUVVNfRVJST1IQBBIiCh5DT05GSUdfU1lOQ19TVEFUVVNfVU5TVVBQT1JURUQQBSqkAQoUUmVtb3RlQ29uZmlnU3RhdHVzZXMSIAocUkVNT1RFX0NPTkZJR19TVEFUVVNFU19VTlNFVBAAEiIKHlJFTU9URV9DT05GSUdfU1RBVFVTRVNfQVBQTElFRBABEiMKH1JFTU9URV9DT05GSUdfU1RBVFVTRVNfQVBQTFlJTkcQAhIhCh1SRU1PVEVfQ09ORklHX1NUQVRVU0VTX0ZBSUxFRBADKrQBCg9Db25maWdQdXNoU3RhdGUSIQodQ09ORklHX1BVU0hfU1RBVEVfVU5TUEVDSUZJRUQQABIdChlDT05GSUdfUFVTSF9TVEFURV9PRkZFUkVEEAESIgoeQ09ORklHX1BVU0hfU1RBVEVfQUNLTk9XTEVER0VEEAISHQoZQ09ORklHX1BVU0hfU1RBVEVfQVBQTElFRBADEhwKGENPTkZJR19QVVNIX1NUQVRFX0ZBSUxFRBAEKpwBChFBZ2VudENvbW1hbmRTdGF0ZRIjCh9BR0VOVF9DT01NQU5EX1NUQVRFX1VOU1BFQ0lGSUVEEAASHwobQUdFTlRfQ09NTUFORF9TVEFURV9QRU5ESU5HEAESIQodQUdFTlRfQ09NTUFORF9TVEFURV9TVUNDRUVERUQQAhIeChpBR0VOVF9DT01NQU5EX1NUQVRFX0ZBSUxFRBADKrkBChBBZ2VudFByb2JsZW1UeXBlEiIKHkFHRU5UX1BST0JMRU1fVFlQRV9VTlNQRUNJRklFRBAAEisKJ0FHRU5UX1BST0JMRU1fVFlQRV9DT05GSUdfQVBQTFlfRkFJTElORxABEisKJ0FHRU5UX1BST0JMRU1fVFlQRV9DT0xMRUNUT1JfQ1JBU0hfTE9PUBACEicKI0FHRU5UX1BST0JMRU1fVFlQRV9ESVNLX05FQVJMWV9GVUxMEAMysQ8KDEFnZW50U2VydmljZRJVCgpMaXN0QWdlbnRzEiIuY29uZmlnLnYxYWxwaGExLkxpc3RBZ2VudHNSZXF1ZXN0GiMuY29uZmlnLnYxYWxwaGExLkxpc3RBZ2VudHNSZXNwb25zZRJPCghHZXRBZ2VudBIgLmNvbmZpZy52MWFscGhhMS5HZXRBZ2VudFJlcXVlc3QaIS5jb25maWcudjFhbHBoYTEuR2V0QWdlbnRSZXNwb25zZRJZCgZTdGF0dXMSJi5jb25maWcudjFhbHBoYTEuR2V0QWdlbnRTdGF0dXNSZXF1ZXN0GicuY29uZmlnLnYxYWxwaGExLkdldEFnZW50U3RhdHVzUmVzcG9uc2USSgoLRGVsZXRlQWdlbnQSIy5jb25maWcudjFhbHBoYTEuRGVsZXRlQWdlbnRSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5Ek4KDVVuZGVsZXRlQWdlbnQSJS5jb25maWcudjFhbHBoYTEuVW5kZWxldGVBZ2VudFJlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkSSgoLUmV2b2tlQWdlbnQSIy5jb25maWcudjFhbHBoYTEuUmV2b2tlQWdlbnRSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5EnMKFENhcHR1cmVBZ2VudFNuYXBzaG90EiwuY29uZmlnLnYxYWxwaGExLkNhcHR1cmVBZ2VudFNuYXBzaG90UmVxdWVzdBotLmNvbmZpZy52MWFscGhhMS5DYXB0dXJlQWdlbnRTbmFwc2hvdFJlc3BvbnNlEmcKEEdldEFnZW50U25hcHNob3QSKC5jb25maWcudjFhbHBoYTEuR2V0QWdlbnRTbmFwc2hvdFJlcXVlc3QaKS5jb25maWcudjFhbHBoYTEuR2V0QWdlbnRTbmFwc2hvdFJlc3BvbnNlEm0KEkxpc3RBZ2VudFNuYXBzaG90cxIqLmNvbmZpZy52MWFscGhhMS5MaXN0QWdlbnRTbmFwc2hvdHNSZXF1ZXN0GisuY29uZmlnLnYxYWxwaGExLkxpc3RBZ2VudFNuYXBzaG90c1Jlc3BvbnNlEmcKEExpc3RDb25maWdQdXNoZXMSKC5jb25maWcudjFhbHBoYTEuTGlzdENvbmZpZ1B1c2hlc1JlcXVlc3QaKS5jb25maWcudjFhbHBoYTEuTGlzdENvbmZpZ1B1c2hlc1Jlc3BvbnNlEmQKFENhcHR1cmVGbGVldFNuYXBzaG90EiwuY29uZmlnLnYxYWxwaGExLkNhcHR1cmVGbGVldFNuYXBzaG90UmVxdWVzdBoeLmNvbmZpZy52MWFscGhhMS5GbGVldFNuYXBzaG90Em0KEkxpc3RGbGVldFNuYXBzaG90cxIqLmNvbmZpZy52MWFscGhhMS5MaXN0RmxlZXRTbmFwc2hvdHNSZXF1ZXN0GisuY29uZmlnLnYxYWxwaGExLkxpc3RGbGVldFNuYXBzaG90c1Jlc3BvbnNlElkKDkRpZmZGbGVldFN0YXRlEiYuY29uZmlnLnYxYWxwaGExLkRpZmZGbGVldFN0YXRlUmVxdWVzdBofLmNvbmZpZy52MWFscGhhMS5GbGVldFN0YXRlRGlmZhJoChRHZXRBZ2VudEF2YWlsYWJpbGl0eRIsLmNvbmZpZy52MWFscGhhMS5HZXRBZ2VudEF2YWlsYWJpbGl0eVJlcXVlc3QaIi5jb25maWcudjFhbHBoYTEuQWdlbnRBdmFpbGFiaWxpdHkSaAoUR2V0RmxlZXRBdmFpbGFiaWxpdHkSLC5jb25maWcudjFhbHBoYTEuR2V0RmxlZXRBdmFpbGFiaWxpdHlSZXF1ZXN0GiIuY29uZmlnLnYxYWxwaGExLkZsZWV0QXZhaWxhYmlsaXR5EnYKFVdhaXRGb3JBZ2VudENvbmRpdGlvbhItLmNvbmZpZy52MWFscGhhMS5XYWl0Rm9yQWdlbnRDb25kaXRpb25SZXF1ZXN0Gi4uY29uZmlnLnYxYWxwaGExLldhaXRGb3JBZ2VudENvbmRpdGlvblJlc3BvbnNlElsKDFJlc3RhcnRBZ2VudBIkLmNvbmZpZy52MWFscGhhMS5SZXN0YXJ0QWdlbnRSZXF1ZXN0GiUuY29uZmlnLnYxYWxwaGExLlJlc3RhcnRBZ2VudFJlc3BvbnNlEmEKDkdldEFnZW50RXZlbnRzEiYuY29uZmlnLnYxYWxwaGExLkdldEFnZW50RXZlbnRzUmVxdWVzdBonLmNvbmZpZy52MWFscGhhMS5HZXRBZ2VudEV2ZW50c1Jlc3BvbnNlEmoKEUdldFByb2JsZW1zUmVwb3J0EikuY29uZmlnLnYxYWxwaGExLkdldFByb2JsZW1zUmVwb3J0UmVxdWVzdBoqLmNvbmZpZy52MWFscGhhMS5HZXRQcm9ibGVtc1JlcG9ydFJlc3BvbnNlElgKEkFja25vd2xlZGdlUHJvYmxlbRIqLmNvbmZpZy52MWFscGhhMS5BY2tub3dsZWRnZVByb2JsZW1SZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5MoACCg5HYXRld2F5U2VydmljZRJGCgVSZWxheRIdLmNvbmZpZy52MWFscGhhMS5SZWxheVJlcXVlc3QaHi5jb25maWcudjFhbHBoYTEuUmVsYXlSZXNwb25zZRJQCgVXYXRjaBIkLmNvbmZpZy52MWFscGhhMS5XYXRjaEdhdGV3YXlSZXF1ZXN0Gh8uY29uZmlnLnYxYWxwaGExLlJlbGF5ZWRNZXNzYWdlMAESVAoKRGlzY29ubmVjdBIuLmNvbmZpZy52MWFscGhhMS5EaXNjb25uZWN0UmVsYXllZEFnZW50UmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eUI4WjZnaXRodWIuY29tL290ZWxmbGVldC9vdGVsZmxlZXQvcGtnL2FwaS9hZ2VudHMvdjFhbHBoYTFiBnByb3RvMw", [file_google_protobuf_duration, file_google_protobuf_empty, file_google_protobuf_timestamp, file_pkg_api_events_v1alpha1_events]);
+
+/**
+ * @generated from message config.v1alpha1.RelayRequest
+ */
+export type RelayRequest = Message<"config.v1alpha1.RelayRequest"> & {
+  /**
+   * @generated from field: string gateway_id = 1;
+   */
+  gatewayId: string;
+
+  /**
+   * identifies the agent connection on the gateway
+   *
+   * @generated from field: string connection_id = 2;
+   */
+  connectionId: string;
+
+  /**
+   * the agent identified on the connection, if the gateway knows it
+   *
+   * @generated from field: string agent_id = 3;
+   */
+  agentId: string;
+
+  /**
+   * serialized opamp AgentToServer message
+   *
+   * @generated from field: bytes agent_to_server = 4;
+   */
+  agentToServer: Uint8Array;
+};
+
+/**
+ * Describes the message config.v1alpha1.RelayRequest.
+ * Use `create(RelayRequestSchema)` to create a new message.
+ */
+export const RelayRequestSchema: GenMessage<RelayRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 0);
+
+/**
+ * @generated from message config.v1alpha1.RelayResponse
+ */
+export type RelayResponse = Message<"config.v1alpha1.RelayResponse"> & {
+  /**
+   * serialized opamp ServerToAgent messages
+   *
+   * @generated from field: repeated bytes server_to_agent = 1;
+   */
+  serverToAgent: Uint8Array[];
+};
+
+/**
+ * Describes the message config.v1alpha1.RelayResponse.
+ * Use `create(RelayResponseSchema)` to create a new message.
+ */
+export const RelayResponseSchema: GenMessage<RelayResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 1);
+
+/**
+ * @generated from message config.v1alpha1.WatchGatewayRequest
+ */
+export type WatchGatewayRequest = Message<"config.v1alpha1.WatchGatewayRequest"> & {
+  /**
+   * @generated from field: string gateway_id = 1;
+   */
+  gatewayId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.WatchGatewayRequest.
+ * Use `create(WatchGatewayRequestSchema)` to create a new message.
+ */
+export const WatchGatewayRequestSchema: GenMessage<WatchGatewayRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 2);
+
+/**
+ * @generated from message config.v1alpha1.RelayedMessage
+ */
+export type RelayedMessage = Message<"config.v1alpha1.RelayedMessage"> & {
+  /**
+   * @generated from field: string connection_id = 1;
+   */
+  connectionId: string;
+
+  /**
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * serialized opamp ServerToAgent message
+   *
+   * @generated from field: bytes server_to_agent = 3;
+   */
+  serverToAgent: Uint8Array;
+};
+
+/**
+ * Describes the message config.v1alpha1.RelayedMessage.
+ * Use `create(RelayedMessageSchema)` to create a new message.
+ */
+export const RelayedMessageSchema: GenMessage<RelayedMessage> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 3);
+
+/**
+ * @generated from message config.v1alpha1.DisconnectRelayedAgentRequest
+ */
+export type DisconnectRelayedAgentRequest = Message<"config.v1alpha1.DisconnectRelayedAgentRequest"> & {
+  /**
+   * @generated from field: string gateway_id = 1;
+   */
+  gatewayId: string;
+
+  /**
+   * @generated from field: string connection_id = 2;
+   */
+  connectionId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.DisconnectRelayedAgentRequest.
+ * Use `create(DisconnectRelayedAgentRequestSchema)` to create a new message.
+ */
+export const DisconnectRelayedAgentRequestSchema: GenMessage<DisconnectRelayedAgentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 4);
 
 /**
  * @generated from message config.v1alpha1.ListAgentsRequest
@@ -22,6 +149,80 @@ export type ListAgentsRequest = Message<"config.v1alpha1.ListAgentsRequest"> & {
    * @generated from field: bool with_status = 1;
    */
   withStatus: boolean;
+
+  /**
+   * parts of the status to include when with_status is set
+   *
+   * @generated from field: config.v1alpha1.AgentStatusView view = 2;
+   */
+  view: AgentStatusView;
+
+  /**
+   * only return agents in one of these config sync statuses, all agents when empty
+   *
+   * @generated from field: repeated config.v1alpha1.ConfigSyncStatus config_sync_statuses = 3;
+   */
+  configSyncStatuses: ConfigSyncStatus[];
+
+  /**
+   * also return the agents deleted within the grace period
+   *
+   * @generated from field: bool include_deleted = 4;
+   */
+  includeDeleted: boolean;
+
+  /**
+   * maximum number of agents returned, all the matching agents when 0. The next page is
+   * requested with the next_page_token of the response, and the same filters and order.
+   *
+   * @generated from field: int32 page_size = 5;
+   */
+  pageSize: number;
+
+  /**
+   * @generated from field: string page_token = 6;
+   */
+  pageToken: string;
+
+  /**
+   * order of the agents, ties are broken by agent ID
+   *
+   * @generated from field: config.v1alpha1.AgentSortField sort_by = 7;
+   */
+  sortBy: AgentSortField;
+
+  /**
+   * @generated from field: bool descending = 8;
+   */
+  descending: boolean;
+
+  /**
+   * only return agents in one of these connection states, all agents when empty
+   *
+   * @generated from field: repeated config.v1alpha1.AgentState states = 9;
+   */
+  states: AgentState[];
+
+  /**
+   * only return agents whose labels include all of these
+   *
+   * @generated from field: map<string, string> label_selector = 10;
+   */
+  labelSelector: { [key: string]: string };
+
+  /**
+   * only return agents assigned one of these configs, all agents when empty
+   *
+   * @generated from field: repeated string config_ids = 11;
+   */
+  configIds: string[];
+
+  /**
+   * only return agents in one of these health states, all agents when empty
+   *
+   * @generated from field: repeated config.v1alpha1.AgentHealthState health_states = 12;
+   */
+  healthStates: AgentHealthState[];
 };
 
 /**
@@ -29,7 +230,7 @@ export type ListAgentsRequest = Message<"config.v1alpha1.ListAgentsRequest"> & {
  * Use `create(ListAgentsRequestSchema)` to create a new message.
  */
 export const ListAgentsRequestSchema: GenMessage<ListAgentsRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 0);
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 5);
 
 /**
  * @generated from message config.v1alpha1.ListAgentsResponse
@@ -39,6 +240,34 @@ export type ListAgentsResponse = Message<"config.v1alpha1.ListAgentsResponse"> &
    * @generated from field: repeated config.v1alpha1.AgentDescriptionAndStatus agents = 1;
    */
   agents: AgentDescriptionAndStatus[];
+
+  /**
+   * agents that are registered but could not be loaded, and are missing from agents
+   *
+   * @generated from field: repeated config.v1alpha1.AgentLoadError errors = 2;
+   */
+  errors: AgentLoadError[];
+
+  /**
+   * number of registered agents, before filtering
+   *
+   * @generated from field: int32 total_count = 3;
+   */
+  totalCount: number;
+
+  /**
+   * token of the next page, empty on the last page
+   *
+   * @generated from field: string next_page_token = 4;
+   */
+  nextPageToken: string;
+
+  /**
+   * number of agents matching the filters, across all pages
+   *
+   * @generated from field: int32 matched_count = 5;
+   */
+  matchedCount: number;
 };
 
 /**
@@ -46,7 +275,29 @@ export type ListAgentsResponse = Message<"config.v1alpha1.ListAgentsResponse"> &
  * Use `create(ListAgentsResponseSchema)` to create a new message.
  */
 export const ListAgentsResponseSchema: GenMessage<ListAgentsResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 1);
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 6);
+
+/**
+ * @generated from message config.v1alpha1.AgentLoadError
+ */
+export type AgentLoadError = Message<"config.v1alpha1.AgentLoadError"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string message = 2;
+   */
+  message: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentLoadError.
+ * Use `create(AgentLoadErrorSchema)` to create a new message.
+ */
+export const AgentLoadErrorSchema: GenMessage<AgentLoadError> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 7);
 
 /**
  * AgentView combines registration and status for list/get responses.
@@ -71,7 +322,7 @@ export type AgentView = Message<"config.v1alpha1.AgentView"> & {
  * Use `create(AgentViewSchema)` to create a new message.
  */
 export const AgentViewSchema: GenMessage<AgentView> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 2);
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 8);
 
 /**
  * AgentDescriptionAndStatus is kept for backward compatibility.
@@ -96,7 +347,7 @@ export type AgentDescriptionAndStatus = Message<"config.v1alpha1.AgentDescriptio
  * Use `create(AgentDescriptionAndStatusSchema)` to create a new message.
  */
 export const AgentDescriptionAndStatusSchema: GenMessage<AgentDescriptionAndStatus> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 3);
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 9);
 
 /**
  * @generated from message config.v1alpha1.GetAgentRequest
@@ -113,7 +364,7 @@ export type GetAgentRequest = Message<"config.v1alpha1.GetAgentRequest"> & {
  * Use `create(GetAgentRequestSchema)` to create a new message.
  */
 export const GetAgentRequestSchema: GenMessage<GetAgentRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 4);
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 10);
 
 /**
  * @generated from message config.v1alpha1.GetAgentResponse
@@ -130,7 +381,7 @@ export type GetAgentResponse = Message<"config.v1alpha1.GetAgentResponse"> & {
  * Use `create(GetAgentResponseSchema)` to create a new message.
  */
 export const GetAgentResponseSchema: GenMessage<GetAgentResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 5);
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 11);
 
 /**
  * @generated from message config.v1alpha1.GetAgentStatusRequest
@@ -140,6 +391,11 @@ export type GetAgentStatusRequest = Message<"config.v1alpha1.GetAgentStatusReque
    * @generated from field: string agent_id = 1;
    */
   agentId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.AgentStatusView view = 2;
+   */
+  view: AgentStatusView;
 };
 
 /**
@@ -147,7 +403,7 @@ export type GetAgentStatusRequest = Message<"config.v1alpha1.GetAgentStatusReque
  * Use `create(GetAgentStatusRequestSchema)` to create a new message.
  */
 export const GetAgentStatusRequestSchema: GenMessage<GetAgentStatusRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 6);
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 12);
 
 /**
  * @generated from message config.v1alpha1.GetAgentStatusResponse
@@ -164,486 +420,2206 @@ export type GetAgentStatusResponse = Message<"config.v1alpha1.GetAgentStatusResp
  * Use `create(GetAgentStatusResponseSchema)` to create a new message.
  */
 export const GetAgentStatusResponseSchema: GenMessage<GetAgentStatusResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 7);
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 13);
 
 /**
- * @generated from message config.v1alpha1.DeleteAgentRequest
+ * @generated from message config.v1alpha1.WaitForAgentConditionRequest
  */
-export type DeleteAgentRequest = Message<"config.v1alpha1.DeleteAgentRequest"> & {
+export type WaitForAgentConditionRequest = Message<"config.v1alpha1.WaitForAgentConditionRequest"> & {
   /**
    * @generated from field: string agent_id = 1;
    */
   agentId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.AgentCondition condition = 2;
+   */
+  condition: AgentCondition;
+
+  /**
+   * for AGENT_CONDITION_CONFIG_APPLIED, the hash of the config that must be applied, as
+   * reported in the remote config status
+   *
+   * @generated from field: bytes config_hash = 3;
+   */
+  configHash: Uint8Array;
+
+  /**
+   * how long to wait, defaults to 30s and is capped at 5m
+   *
+   * @generated from field: google.protobuf.Duration timeout = 4;
+   */
+  timeout?: Duration;
 };
 
 /**
- * Describes the message config.v1alpha1.DeleteAgentRequest.
- * Use `create(DeleteAgentRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.WaitForAgentConditionRequest.
+ * Use `create(WaitForAgentConditionRequestSchema)` to create a new message.
  */
-export const DeleteAgentRequestSchema: GenMessage<DeleteAgentRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 8);
+export const WaitForAgentConditionRequestSchema: GenMessage<WaitForAgentConditionRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 14);
 
 /**
- * @generated from message config.v1alpha1.AgentStatus
+ * @generated from message config.v1alpha1.WaitForAgentConditionResponse
  */
-export type AgentStatus = Message<"config.v1alpha1.AgentStatus"> & {
+export type WaitForAgentConditionResponse = Message<"config.v1alpha1.WaitForAgentConditionResponse"> & {
   /**
-   * @generated from field: config.v1alpha1.AgentState state = 1;
+   * false if the timeout expired first
+   *
+   * @generated from field: bool satisfied = 1;
    */
-  state: AgentState;
+  satisfied: boolean;
 
   /**
-   * @generated from field: config.v1alpha1.ComponentHealth health = 2;
+   * @generated from field: config.v1alpha1.AgentStatus status = 2;
    */
-  health?: ComponentHealth;
+  status?: AgentStatus;
+};
 
-  /**
-   * @generated from field: config.v1alpha1.EffectiveConfig effective_config = 3;
-   */
-  effectiveConfig?: EffectiveConfig;
+/**
+ * Describes the message config.v1alpha1.WaitForAgentConditionResponse.
+ * Use `create(WaitForAgentConditionResponseSchema)` to create a new message.
+ */
+export const WaitForAgentConditionResponseSchema: GenMessage<WaitForAgentConditionResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 15);
 
+/**
+ * @generated from message config.v1alpha1.DeleteAgentRequest
+ */
+export type DeleteAgentRequest = Message<"config.v1alpha1.DeleteAgentRequest"> & {
   /**
-   * @generated from field: config.v1alpha1.RemoteConfigStatus remote_config_status = 4;
+   * @generated from field: string agent_id = 1;
    */
-  remoteConfigStatus?: RemoteConfigStatus;
+  agentId: string;
 
   /**
-   * New fields for unified status
+   * delete the agent permanently, without a grace period
    *
-   * @generated from field: google.protobuf.Timestamp last_seen = 5;
+   * @generated from field: bool purge = 2;
    */
-  lastSeen?: Timestamp;
+  purge: boolean;
+};
 
-  /**
-   * @generated from field: config.v1alpha1.ConfigSyncStatus config_sync_status = 6;
-   */
-  configSyncStatus: ConfigSyncStatus;
+/**
+ * Describes the message config.v1alpha1.DeleteAgentRequest.
+ * Use `create(DeleteAgentRequestSchema)` to create a new message.
+ */
+export const DeleteAgentRequestSchema: GenMessage<DeleteAgentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 16);
 
+/**
+ * @generated from message config.v1alpha1.CaptureAgentSnapshotRequest
+ */
+export type CaptureAgentSnapshotRequest = Message<"config.v1alpha1.CaptureAgentSnapshotRequest"> & {
   /**
-   * @generated from field: string config_sync_reason = 7;
+   * @generated from field: string agent_id = 1;
    */
-  configSyncReason: string;
+  agentId: string;
 
   /**
-   * @generated from field: google.protobuf.Timestamp connected_at = 8;
+   * max_bytes caps the size of the snapshot archive, defaults to 4MiB
+   *
+   * @generated from field: int64 max_bytes = 2;
    */
-  connectedAt?: Timestamp;
+  maxBytes: bigint;
+};
+
+/**
+ * Describes the message config.v1alpha1.CaptureAgentSnapshotRequest.
+ * Use `create(CaptureAgentSnapshotRequestSchema)` to create a new message.
+ */
+export const CaptureAgentSnapshotRequestSchema: GenMessage<CaptureAgentSnapshotRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 17);
 
+/**
+ * @generated from message config.v1alpha1.CaptureAgentSnapshotResponse
+ */
+export type CaptureAgentSnapshotResponse = Message<"config.v1alpha1.CaptureAgentSnapshotResponse"> & {
   /**
-   * @generated from field: google.protobuf.Timestamp disconnected_at = 9;
+   * @generated from field: config.v1alpha1.AgentSnapshot snapshot = 1;
    */
-  disconnectedAt?: Timestamp;
+  snapshot?: AgentSnapshot;
 };
 
 /**
- * Describes the message config.v1alpha1.AgentStatus.
- * Use `create(AgentStatusSchema)` to create a new message.
+ * Describes the message config.v1alpha1.CaptureAgentSnapshotResponse.
+ * Use `create(CaptureAgentSnapshotResponseSchema)` to create a new message.
  */
-export const AgentStatusSchema: GenMessage<AgentStatus> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 9);
+export const CaptureAgentSnapshotResponseSchema: GenMessage<CaptureAgentSnapshotResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 18);
 
 /**
- * AgentRegistration represents the core agent identity and attributes.
- * This is the preferred type name for agent registration data.
- *
- * @generated from message config.v1alpha1.AgentRegistration
+ * @generated from message config.v1alpha1.GetAgentSnapshotRequest
  */
-export type AgentRegistration = Message<"config.v1alpha1.AgentRegistration"> & {
+export type GetAgentSnapshotRequest = Message<"config.v1alpha1.GetAgentSnapshotRequest"> & {
   /**
-   * @generated from field: string id = 1;
+   * @generated from field: string snapshot_id = 1;
    */
-  id: string;
+  snapshotId: string;
+};
 
-  /**
+/**
+ * Describes the message config.v1alpha1.GetAgentSnapshotRequest.
+ * Use `create(GetAgentSnapshotRequestSchema)` to create a new message.
+ */
+export const GetAgentSnapshotRequestSchema: GenMessage<GetAgentSnapshotRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 19);
+
+/**
+ * @generated from message config.v1alpha1.GetAgentSnapshotResponse
+ */
+export type GetAgentSnapshotResponse = Message<"config.v1alpha1.GetAgentSnapshotResponse"> & {
+  /**
+   * @generated from field: config.v1alpha1.AgentSnapshot snapshot = 1;
+   */
+  snapshot?: AgentSnapshot;
+};
+
+/**
+ * Describes the message config.v1alpha1.GetAgentSnapshotResponse.
+ * Use `create(GetAgentSnapshotResponseSchema)` to create a new message.
+ */
+export const GetAgentSnapshotResponseSchema: GenMessage<GetAgentSnapshotResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 20);
+
+/**
+ * @generated from message config.v1alpha1.ListAgentSnapshotsRequest
+ */
+export type ListAgentSnapshotsRequest = Message<"config.v1alpha1.ListAgentSnapshotsRequest"> & {
+  /**
+   * agent_id optionally restricts the snapshots to a single agent
+   *
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.ListAgentSnapshotsRequest.
+ * Use `create(ListAgentSnapshotsRequestSchema)` to create a new message.
+ */
+export const ListAgentSnapshotsRequestSchema: GenMessage<ListAgentSnapshotsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 21);
+
+/**
+ * @generated from message config.v1alpha1.ListAgentSnapshotsResponse
+ */
+export type ListAgentSnapshotsResponse = Message<"config.v1alpha1.ListAgentSnapshotsResponse"> & {
+  /**
+   * snapshots are listed without their archive
+   *
+   * @generated from field: repeated config.v1alpha1.AgentSnapshot snapshots = 1;
+   */
+  snapshots: AgentSnapshot[];
+};
+
+/**
+ * Describes the message config.v1alpha1.ListAgentSnapshotsResponse.
+ * Use `create(ListAgentSnapshotsResponseSchema)` to create a new message.
+ */
+export const ListAgentSnapshotsResponseSchema: GenMessage<ListAgentSnapshotsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 22);
+
+/**
+ * @generated from message config.v1alpha1.ListConfigPushesRequest
+ */
+export type ListConfigPushesRequest = Message<"config.v1alpha1.ListConfigPushesRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * push_id optionally restricts the response to a single push
+   *
+   * @generated from field: string push_id = 2;
+   */
+  pushId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.ListConfigPushesRequest.
+ * Use `create(ListConfigPushesRequestSchema)` to create a new message.
+ */
+export const ListConfigPushesRequestSchema: GenMessage<ListConfigPushesRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 23);
+
+/**
+ * @generated from message config.v1alpha1.ListConfigPushesResponse
+ */
+export type ListConfigPushesResponse = Message<"config.v1alpha1.ListConfigPushesResponse"> & {
+  /**
+   * pushes are listed oldest first
+   *
+   * @generated from field: repeated config.v1alpha1.ConfigPush pushes = 1;
+   */
+  pushes: ConfigPush[];
+};
+
+/**
+ * Describes the message config.v1alpha1.ListConfigPushesResponse.
+ * Use `create(ListConfigPushesResponseSchema)` to create a new message.
+ */
+export const ListConfigPushesResponseSchema: GenMessage<ListConfigPushesResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 24);
+
+/**
+ * @generated from message config.v1alpha1.CaptureFleetSnapshotRequest
+ */
+export type CaptureFleetSnapshotRequest = Message<"config.v1alpha1.CaptureFleetSnapshotRequest"> & {
+};
+
+/**
+ * Describes the message config.v1alpha1.CaptureFleetSnapshotRequest.
+ * Use `create(CaptureFleetSnapshotRequestSchema)` to create a new message.
+ */
+export const CaptureFleetSnapshotRequestSchema: GenMessage<CaptureFleetSnapshotRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 25);
+
+/**
+ * @generated from message config.v1alpha1.ListFleetSnapshotsRequest
+ */
+export type ListFleetSnapshotsRequest = Message<"config.v1alpha1.ListFleetSnapshotsRequest"> & {
+};
+
+/**
+ * Describes the message config.v1alpha1.ListFleetSnapshotsRequest.
+ * Use `create(ListFleetSnapshotsRequestSchema)` to create a new message.
+ */
+export const ListFleetSnapshotsRequestSchema: GenMessage<ListFleetSnapshotsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 26);
+
+/**
+ * @generated from message config.v1alpha1.ListFleetSnapshotsResponse
+ */
+export type ListFleetSnapshotsResponse = Message<"config.v1alpha1.ListFleetSnapshotsResponse"> & {
+  /**
+   * snapshots are listed oldest first, without their agents
+   *
+   * @generated from field: repeated config.v1alpha1.FleetSnapshot snapshots = 1;
+   */
+  snapshots: FleetSnapshot[];
+};
+
+/**
+ * Describes the message config.v1alpha1.ListFleetSnapshotsResponse.
+ * Use `create(ListFleetSnapshotsResponseSchema)` to create a new message.
+ */
+export const ListFleetSnapshotsResponseSchema: GenMessage<ListFleetSnapshotsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 27);
+
+/**
+ * @generated from message config.v1alpha1.DiffFleetStateRequest
+ */
+export type DiffFleetStateRequest = Message<"config.v1alpha1.DiffFleetStateRequest"> & {
+  /**
+   * @generated from field: string from_snapshot_id = 1;
+   */
+  fromSnapshotId: string;
+
+  /**
+   * to_snapshot_id defaults to the current state of the fleet
+   *
+   * @generated from field: string to_snapshot_id = 2;
+   */
+  toSnapshotId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.DiffFleetStateRequest.
+ * Use `create(DiffFleetStateRequestSchema)` to create a new message.
+ */
+export const DiffFleetStateRequestSchema: GenMessage<DiffFleetStateRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 28);
+
+/**
+ * FleetSnapshot records the state of every agent at a point in time
+ *
+ * @generated from message config.v1alpha1.FleetSnapshot
+ */
+export type FleetSnapshot = Message<"config.v1alpha1.FleetSnapshot"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp captured_at = 2;
+   */
+  capturedAt?: Timestamp;
+
+  /**
+   * @generated from field: int32 agent_count = 3;
+   */
+  agentCount: number;
+
+  /**
+   * agents are sorted by ID
+   *
+   * @generated from field: repeated config.v1alpha1.FleetSnapshotAgent agents = 4;
+   */
+  agents: FleetSnapshotAgent[];
+};
+
+/**
+ * Describes the message config.v1alpha1.FleetSnapshot.
+ * Use `create(FleetSnapshotSchema)` to create a new message.
+ */
+export const FleetSnapshotSchema: GenMessage<FleetSnapshot> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 29);
+
+/**
+ * @generated from message config.v1alpha1.FleetSnapshotAgent
+ */
+export type FleetSnapshotAgent = Message<"config.v1alpha1.FleetSnapshotAgent"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * version is the agent's reported service.version
+   *
+   * @generated from field: string version = 3;
+   */
+  version: string;
+
+  /**
+   * @generated from field: string config_id = 4;
+   */
+  configId: string;
+
+  /**
+   * config_hash is the hex encoded hash of the remote config last reported by the agent
+   *
+   * @generated from field: string config_hash = 5;
+   */
+  configHash: string;
+
+  /**
+   * @generated from field: string state = 6;
+   */
+  state: string;
+
+  /**
+   * @generated from field: map<string, string> labels = 7;
+   */
+  labels: { [key: string]: string };
+};
+
+/**
+ * Describes the message config.v1alpha1.FleetSnapshotAgent.
+ * Use `create(FleetSnapshotAgentSchema)` to create a new message.
+ */
+export const FleetSnapshotAgentSchema: GenMessage<FleetSnapshotAgent> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 30);
+
+/**
+ * @generated from message config.v1alpha1.FleetStateDiff
+ */
+export type FleetStateDiff = Message<"config.v1alpha1.FleetStateDiff"> & {
+  /**
+   * from and to are the compared snapshots, without their agents.
+   * to has no ID when compared against the current state of the fleet.
+   *
+   * @generated from field: config.v1alpha1.FleetSnapshot from = 1;
+   */
+  from?: FleetSnapshot;
+
+  /**
+   * @generated from field: config.v1alpha1.FleetSnapshot to = 2;
+   */
+  to?: FleetSnapshot;
+
+  /**
+   * @generated from field: repeated config.v1alpha1.FleetSnapshotAgent added = 3;
+   */
+  added: FleetSnapshotAgent[];
+
+  /**
+   * @generated from field: repeated config.v1alpha1.FleetSnapshotAgent removed = 4;
+   */
+  removed: FleetSnapshotAgent[];
+
+  /**
+   * @generated from field: repeated config.v1alpha1.AgentStateChange changed = 5;
+   */
+  changed: AgentStateChange[];
+};
+
+/**
+ * Describes the message config.v1alpha1.FleetStateDiff.
+ * Use `create(FleetStateDiffSchema)` to create a new message.
+ */
+export const FleetStateDiffSchema: GenMessage<FleetStateDiff> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 31);
+
+/**
+ * AgentStateChange lists the fields of an agent that changed between two fleet snapshots
+ *
+ * @generated from message config.v1alpha1.AgentStateChange
+ */
+export type AgentStateChange = Message<"config.v1alpha1.AgentStateChange"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: repeated config.v1alpha1.FieldChange changes = 2;
+   */
+  changes: FieldChange[];
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentStateChange.
+ * Use `create(AgentStateChangeSchema)` to create a new message.
+ */
+export const AgentStateChangeSchema: GenMessage<AgentStateChange> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 32);
+
+/**
+ * @generated from message config.v1alpha1.FieldChange
+ */
+export type FieldChange = Message<"config.v1alpha1.FieldChange"> & {
+  /**
+   * field is config_id, config_hash, version, name, state or label.<key>
+   *
+   * @generated from field: string field = 1;
+   */
+  field: string;
+
+  /**
+   * @generated from field: string from = 2;
+   */
+  from: string;
+
+  /**
+   * @generated from field: string to = 3;
+   */
+  to: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.FieldChange.
+ * Use `create(FieldChangeSchema)` to create a new message.
+ */
+export const FieldChangeSchema: GenMessage<FieldChange> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 33);
+
+/**
+ * AgentSnapshot is a support bundle captured by an agent's supervisor, containing
+ * its config directory, applied config hash, recent supervisor logs and process list.
+ *
+ * @generated from message config.v1alpha1.AgentSnapshot
+ */
+export type AgentSnapshot = Message<"config.v1alpha1.AgentSnapshot"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.AgentSnapshotState state = 3;
+   */
+  state: AgentSnapshotState;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp requested_at = 4;
+   */
+  requestedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp completed_at = 5;
+   */
+  completedAt?: Timestamp;
+
+  /**
+   * expires_at is when the snapshot is deleted from the server
+   *
+   * @generated from field: google.protobuf.Timestamp expires_at = 6;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * @generated from field: int64 max_bytes = 7;
+   */
+  maxBytes: bigint;
+
+  /**
+   * @generated from field: int64 size_bytes = 8;
+   */
+  sizeBytes: bigint;
+
+  /**
+   * truncated is set if content was left out to respect max_bytes
+   *
+   * @generated from field: bool truncated = 9;
+   */
+  truncated: boolean;
+
+  /**
+   * @generated from field: string error = 10;
+   */
+  error: string;
+
+  /**
+   * archive is a gzipped tarball of the snapshot contents
+   *
+   * @generated from field: bytes archive = 11;
+   */
+  archive: Uint8Array;
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentSnapshot.
+ * Use `create(AgentSnapshotSchema)` to create a new message.
+ */
+export const AgentSnapshotSchema: GenMessage<AgentSnapshot> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 34);
+
+/**
+ * SnapshotRequest is sent to supervisors in an OpAMP custom message to request a snapshot.
+ *
+ * @generated from message config.v1alpha1.SnapshotRequest
+ */
+export type SnapshotRequest = Message<"config.v1alpha1.SnapshotRequest"> & {
+  /**
+   * @generated from field: string snapshot_id = 1;
+   */
+  snapshotId: string;
+
+  /**
+   * server_pub_key is the server's ephemeral x25519 public key used to encrypt the upload
+   *
+   * @generated from field: bytes server_pub_key = 2;
+   */
+  serverPubKey: Uint8Array;
+
+  /**
+   * @generated from field: int64 max_bytes = 3;
+   */
+  maxBytes: bigint;
+};
+
+/**
+ * Describes the message config.v1alpha1.SnapshotRequest.
+ * Use `create(SnapshotRequestSchema)` to create a new message.
+ */
+export const SnapshotRequestSchema: GenMessage<SnapshotRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 35);
+
+/**
+ * SnapshotUpload is sent by supervisors in an OpAMP custom message in reply to a SnapshotRequest.
+ *
+ * @generated from message config.v1alpha1.SnapshotUpload
+ */
+export type SnapshotUpload = Message<"config.v1alpha1.SnapshotUpload"> & {
+  /**
+   * @generated from field: string snapshot_id = 1;
+   */
+  snapshotId: string;
+
+  /**
+   * client_pub_key is the supervisor's ephemeral x25519 public key
+   *
+   * @generated from field: bytes client_pub_key = 2;
+   */
+  clientPubKey: Uint8Array;
+
+  /**
+   * ciphertext is the AES-GCM sealed archive, prefixed with its nonce
+   *
+   * @generated from field: bytes ciphertext = 3;
+   */
+  ciphertext: Uint8Array;
+
+  /**
+   * @generated from field: bool truncated = 4;
+   */
+  truncated: boolean;
+
+  /**
+   * error is set if the supervisor failed to capture the snapshot
+   *
+   * @generated from field: string error = 5;
+   */
+  error: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.SnapshotUpload.
+ * Use `create(SnapshotUploadSchema)` to create a new message.
+ */
+export const SnapshotUploadSchema: GenMessage<SnapshotUpload> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 36);
+
+/**
+ * @generated from message config.v1alpha1.AgentStatus
+ */
+export type AgentStatus = Message<"config.v1alpha1.AgentStatus"> & {
+  /**
+   * @generated from field: config.v1alpha1.AgentState state = 1;
+   */
+  state: AgentState;
+
+  /**
+   * @generated from field: config.v1alpha1.ComponentHealth health = 2;
+   */
+  health?: ComponentHealth;
+
+  /**
+   * @generated from field: config.v1alpha1.EffectiveConfig effective_config = 3;
+   */
+  effectiveConfig?: EffectiveConfig;
+
+  /**
+   * @generated from field: config.v1alpha1.RemoteConfigStatus remote_config_status = 4;
+   */
+  remoteConfigStatus?: RemoteConfigStatus;
+
+  /**
+   * New fields for unified status
+   *
+   * @generated from field: google.protobuf.Timestamp last_seen = 5;
+   */
+  lastSeen?: Timestamp;
+
+  /**
+   * @generated from field: config.v1alpha1.ConfigSyncStatus config_sync_status = 6;
+   */
+  configSyncStatus: ConfigSyncStatus;
+
+  /**
+   * @generated from field: string config_sync_reason = 7;
+   */
+  configSyncReason: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp connected_at = 8;
+   */
+  connectedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp disconnected_at = 9;
+   */
+  disconnectedAt?: Timestamp;
+
+  /**
+   * instance_uid is the OpAMP instance UID of the agent's current process
+   *
+   * @generated from field: bytes instance_uid = 10;
+   */
+  instanceUid: Uint8Array;
+
+  /**
+   * instance_history lists the instance UIDs the agent has connected with, oldest first
+   *
+   * @generated from field: repeated config.v1alpha1.AgentInstance instance_history = 11;
+   */
+  instanceHistory: AgentInstance[];
+
+  /**
+   * the last command sent to the agent, unset if it was never sent one
+   *
+   * @generated from field: config.v1alpha1.AgentCommandStatus last_command = 12;
+   */
+  lastCommand?: AgentCommandStatus;
+
+  /**
+   * the latest own metrics of the agent's collector, in the health and full views. Unset
+   * unless the server receives the own metrics of agents.
+   *
+   * @generated from field: config.v1alpha1.AgentMetrics metrics = 13;
+   */
+  metrics?: AgentMetrics;
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentStatus.
+ * Use `create(AgentStatusSchema)` to create a new message.
+ */
+export const AgentStatusSchema: GenMessage<AgentStatus> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 37);
+
+/**
+ * AgentMetrics are the own metrics of an agent's collector, as last reported to the server
+ *
+ * @generated from message config.v1alpha1.AgentMetrics
+ */
+export type AgentMetrics = Message<"config.v1alpha1.AgentMetrics"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp received_at = 1;
+   */
+  receivedAt?: Timestamp;
+
+  /**
+   * CPU time used by the collector process since it started
+   *
+   * @generated from field: double cpu_seconds = 2;
+   */
+  cpuSeconds: number;
+
+  /**
+   * CPUs used by the collector process since the previous report, e.g. 0.5 for half a CPU
+   *
+   * @generated from field: double cpu_utilization = 3;
+   */
+  cpuUtilization: number;
+
+  /**
+   * resident memory of the collector process
+   *
+   * @generated from field: int64 memory_rss_bytes = 4;
+   */
+  memoryRssBytes: bigint;
+
+  /**
+   * bytes of heap objects allocated by the collector
+   *
+   * @generated from field: int64 heap_alloc_bytes = 5;
+   */
+  heapAllocBytes: bigint;
+
+  /**
+   * @generated from field: google.protobuf.Duration uptime = 6;
+   */
+  uptime?: Duration;
+
+  /**
+   * the sending queues of the exporters of the collector
+   *
+   * @generated from field: repeated config.v1alpha1.ExporterQueue exporter_queues = 7;
+   */
+  exporterQueues: ExporterQueue[];
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentMetrics.
+ * Use `create(AgentMetricsSchema)` to create a new message.
+ */
+export const AgentMetricsSchema: GenMessage<AgentMetrics> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 38);
+
+/**
+ * @generated from message config.v1alpha1.ExporterQueue
+ */
+export type ExporterQueue = Message<"config.v1alpha1.ExporterQueue"> & {
+  /**
+   * ID of the exporter, e.g. otlp/backend
+   *
+   * @generated from field: string exporter = 1;
+   */
+  exporter: string;
+
+  /**
+   * number of batches in the queue
+   *
+   * @generated from field: int64 size = 2;
+   */
+  size: bigint;
+
+  /**
+   * @generated from field: int64 capacity = 3;
+   */
+  capacity: bigint;
+};
+
+/**
+ * Describes the message config.v1alpha1.ExporterQueue.
+ * Use `create(ExporterQueueSchema)` to create a new message.
+ */
+export const ExporterQueueSchema: GenMessage<ExporterQueue> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 39);
+
+/**
+ * AgentRegistration represents the core agent identity and attributes.
+ * This is the preferred type name for agent registration data.
+ *
+ * @generated from message config.v1alpha1.AgentRegistration
+ */
+export type AgentRegistration = Message<"config.v1alpha1.AgentRegistration"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string friendly_name = 2;
+   */
+  friendlyName: string;
+
+  /**
+   * Attributes that identify the Agent (e.g., service.name, service.version, service.instance.id).
+   *
+   * @generated from field: repeated config.v1alpha1.KeyValue identifying_attributes = 3;
+   */
+  identifyingAttributes: KeyValue[];
+
+  /**
+   * Attributes that do not necessarily identify the Agent but help describe where it runs.
+   *
+   * @generated from field: repeated config.v1alpha1.KeyValue non_identifying_attributes = 4;
+   */
+  nonIdentifyingAttributes: KeyValue[];
+
+  /**
+   * @generated from field: repeated string capabilities = 5;
+   */
+  capabilities: string[];
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentRegistration.
+ * Use `create(AgentRegistrationSchema)` to create a new message.
+ */
+export const AgentRegistrationSchema: GenMessage<AgentRegistration> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 40);
+
+/**
+ * AgentDescription is kept for backward compatibility.
+ * Use AgentRegistration for new code.
+ *
+ * @generated from message config.v1alpha1.AgentDescription
+ */
+export type AgentDescription = Message<"config.v1alpha1.AgentDescription"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
    * @generated from field: string friendly_name = 2;
    */
-  friendlyName: string;
+  friendlyName: string;
+
+  /**
+   * Attributes that identify the Agent (e.g., service.name, service.version, service.instance.id).
+   * These attributes uniquely identify the agent and should be used for its own telemetry.
+   *
+   * @generated from field: repeated config.v1alpha1.KeyValue identifying_attributes = 3;
+   */
+  identifyingAttributes: KeyValue[];
+
+  /**
+   * Attributes that do not necessarily identify the Agent but help describe where it runs
+   * (e.g., os.type, os.version, host.*, cloud.*).
+   *
+   * @generated from field: repeated config.v1alpha1.KeyValue non_identifying_attributes = 4;
+   */
+  nonIdentifyingAttributes: KeyValue[];
+
+  /**
+   * @generated from field: repeated string capabilities = 5;
+   */
+  capabilities: string[];
+
+  /**
+   * when the agent was deleted, it is purged once the deletion grace period expires
+   *
+   * @generated from field: google.protobuf.Timestamp deleted_at = 6;
+   */
+  deletedAt?: Timestamp;
+
+  /**
+   * when the agent was revoked, its OpAMP connections are rejected
+   *
+   * @generated from field: google.protobuf.Timestamp revoked_at = 7;
+   */
+  revokedAt?: Timestamp;
+
+  /**
+   * tenant the agent joined when it bootstrapped, empty for the default tenant
+   *
+   * @generated from field: string tenant = 8;
+   */
+  tenant: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentDescription.
+ * Use `create(AgentDescriptionSchema)` to create a new message.
+ */
+export const AgentDescriptionSchema: GenMessage<AgentDescription> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 41);
+
+/**
+ * KeyValue represents a key-value pair with support for various value types.
+ *
+ * @generated from message config.v1alpha1.KeyValue
+ */
+export type KeyValue = Message<"config.v1alpha1.KeyValue"> & {
+  /**
+   * @generated from field: string key = 1;
+   */
+  key: string;
+
+  /**
+   * @generated from field: config.v1alpha1.AnyValue value = 2;
+   */
+  value?: AnyValue;
+};
+
+/**
+ * Describes the message config.v1alpha1.KeyValue.
+ * Use `create(KeyValueSchema)` to create a new message.
+ */
+export const KeyValueSchema: GenMessage<KeyValue> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 42);
+
+/**
+ * AnyValue represents a value that can be one of several types.
+ *
+ * @generated from message config.v1alpha1.AnyValue
+ */
+export type AnyValue = Message<"config.v1alpha1.AnyValue"> & {
+  /**
+   * @generated from oneof config.v1alpha1.AnyValue.value
+   */
+  value: {
+    /**
+     * @generated from field: string string_value = 1;
+     */
+    value: string;
+    case: "stringValue";
+  } | {
+    /**
+     * @generated from field: bool bool_value = 2;
+     */
+    value: boolean;
+    case: "boolValue";
+  } | {
+    /**
+     * @generated from field: int64 int_value = 3;
+     */
+    value: bigint;
+    case: "intValue";
+  } | {
+    /**
+     * @generated from field: double double_value = 4;
+     */
+    value: number;
+    case: "doubleValue";
+  } | {
+    /**
+     * @generated from field: bytes bytes_value = 5;
+     */
+    value: Uint8Array;
+    case: "bytesValue";
+  } | {
+    /**
+     * @generated from field: config.v1alpha1.ArrayValue array_value = 6;
+     */
+    value: ArrayValue;
+    case: "arrayValue";
+  } | {
+    /**
+     * @generated from field: config.v1alpha1.KeyValueList kvlist_value = 7;
+     */
+    value: KeyValueList;
+    case: "kvlistValue";
+  } | { case: undefined; value?: undefined };
+};
+
+/**
+ * Describes the message config.v1alpha1.AnyValue.
+ * Use `create(AnyValueSchema)` to create a new message.
+ */
+export const AnyValueSchema: GenMessage<AnyValue> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 43);
+
+/**
+ * ArrayValue holds an array of AnyValue.
+ *
+ * @generated from message config.v1alpha1.ArrayValue
+ */
+export type ArrayValue = Message<"config.v1alpha1.ArrayValue"> & {
+  /**
+   * @generated from field: repeated config.v1alpha1.AnyValue values = 1;
+   */
+  values: AnyValue[];
+};
+
+/**
+ * Describes the message config.v1alpha1.ArrayValue.
+ * Use `create(ArrayValueSchema)` to create a new message.
+ */
+export const ArrayValueSchema: GenMessage<ArrayValue> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 44);
+
+/**
+ * KeyValueList holds a list of KeyValue pairs.
+ *
+ * @generated from message config.v1alpha1.KeyValueList
+ */
+export type KeyValueList = Message<"config.v1alpha1.KeyValueList"> & {
+  /**
+   * @generated from field: repeated config.v1alpha1.KeyValue values = 1;
+   */
+  values: KeyValue[];
+};
+
+/**
+ * Describes the message config.v1alpha1.KeyValueList.
+ * Use `create(KeyValueListSchema)` to create a new message.
+ */
+export const KeyValueListSchema: GenMessage<KeyValueList> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 45);
+
+/**
+ * AgentConnectionState represents the persisted connection state of an agent.
+ * This replaces the in-memory AgentTracker state.
+ *
+ * @generated from message config.v1alpha1.AgentConnectionState
+ */
+export type AgentConnectionState = Message<"config.v1alpha1.AgentConnectionState"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.AgentState state = 2;
+   */
+  state: AgentState;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_seen = 3;
+   */
+  lastSeen?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp connected_at = 4;
+   */
+  connectedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp disconnected_at = 5;
+   */
+  disconnectedAt?: Timestamp;
+
+  /**
+   * @generated from field: bytes instance_uid = 6;
+   */
+  instanceUid: Uint8Array;
+
+  /**
+   * @generated from field: uint64 capabilities = 7;
+   */
+  capabilities: bigint;
+
+  /**
+   * @generated from field: uint64 sequence_num = 8;
+   */
+  sequenceNum: bigint;
+
+  /**
+   * instance_history lists the instance UIDs the agent has connected with, oldest first.
+   * The last entry is the current instance.
+   *
+   * @generated from field: repeated config.v1alpha1.AgentInstance instance_history = 9;
+   */
+  instanceHistory: AgentInstance[];
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentConnectionState.
+ * Use `create(AgentConnectionStateSchema)` to create a new message.
+ */
+export const AgentConnectionStateSchema: GenMessage<AgentConnectionState> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 46);
+
+/**
+ * AgentInstance records an OpAMP instance UID an agent has connected with.
+ * An agent's instance UID changes when its supervisor regenerates it, e.g. on reinstall.
+ *
+ * @generated from message config.v1alpha1.AgentInstance
+ */
+export type AgentInstance = Message<"config.v1alpha1.AgentInstance"> & {
+  /**
+   * @generated from field: bytes instance_uid = 1;
+   */
+  instanceUid: Uint8Array;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp first_seen = 2;
+   */
+  firstSeen?: Timestamp;
+
+  /**
+   * last_seen is unset for the current instance
+   *
+   * @generated from field: google.protobuf.Timestamp last_seen = 3;
+   */
+  lastSeen?: Timestamp;
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentInstance.
+ * Use `create(AgentInstanceSchema)` to create a new message.
+ */
+export const AgentInstanceSchema: GenMessage<AgentInstance> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 47);
+
+/**
+ * ComponentHealth represents the health status of an agent and its components.
+ *
+ * @generated from message config.v1alpha1.ComponentHealth
+ */
+export type ComponentHealth = Message<"config.v1alpha1.ComponentHealth"> & {
+  /**
+   * @generated from field: bool healthy = 1;
+   */
+  healthy: boolean;
+
+  /**
+   * @generated from field: uint64 start_time_unix_nano = 2;
+   */
+  startTimeUnixNano: bigint;
+
+  /**
+   * @generated from field: string last_error = 3;
+   */
+  lastError: string;
+
+  /**
+   * @generated from field: string status = 4;
+   */
+  status: string;
+
+  /**
+   * @generated from field: uint64 status_time_unix_nano = 5;
+   */
+  statusTimeUnixNano: bigint;
+
+  /**
+   * @generated from field: map<string, config.v1alpha1.ComponentHealth> component_health_map = 6;
+   */
+  componentHealthMap: { [key: string]: ComponentHealth };
+};
+
+/**
+ * Describes the message config.v1alpha1.ComponentHealth.
+ * Use `create(ComponentHealthSchema)` to create a new message.
+ */
+export const ComponentHealthSchema: GenMessage<ComponentHealth> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 48);
+
+/**
+ * EffectiveConfig represents the current effective configuration of an agent.
+ *
+ * @generated from message config.v1alpha1.EffectiveConfig
+ */
+export type EffectiveConfig = Message<"config.v1alpha1.EffectiveConfig"> & {
+  /**
+   * @generated from field: config.v1alpha1.AgentConfigMap config_map = 1;
+   */
+  configMap?: AgentConfigMap;
+};
+
+/**
+ * Describes the message config.v1alpha1.EffectiveConfig.
+ * Use `create(EffectiveConfigSchema)` to create a new message.
+ */
+export const EffectiveConfigSchema: GenMessage<EffectiveConfig> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 49);
+
+/**
+ * AgentConfigMap holds a map of config file names to their content.
+ *
+ * @generated from message config.v1alpha1.AgentConfigMap
+ */
+export type AgentConfigMap = Message<"config.v1alpha1.AgentConfigMap"> & {
+  /**
+   * @generated from field: map<string, config.v1alpha1.AgentConfigFile> config_map = 1;
+   */
+  configMap: { [key: string]: AgentConfigFile };
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentConfigMap.
+ * Use `create(AgentConfigMapSchema)` to create a new message.
+ */
+export const AgentConfigMapSchema: GenMessage<AgentConfigMap> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 50);
+
+/**
+ * AgentConfigFile represents a single configuration file.
+ *
+ * @generated from message config.v1alpha1.AgentConfigFile
+ */
+export type AgentConfigFile = Message<"config.v1alpha1.AgentConfigFile"> & {
+  /**
+   * @generated from field: bytes body = 1;
+   */
+  body: Uint8Array;
+
+  /**
+   * @generated from field: string content_type = 2;
+   */
+  contentType: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentConfigFile.
+ * Use `create(AgentConfigFileSchema)` to create a new message.
+ */
+export const AgentConfigFileSchema: GenMessage<AgentConfigFile> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 51);
+
+/**
+ * RemoteConfigStatus represents the status of a remote configuration on an agent.
+ *
+ * @generated from message config.v1alpha1.RemoteConfigStatus
+ */
+export type RemoteConfigStatus = Message<"config.v1alpha1.RemoteConfigStatus"> & {
+  /**
+   * @generated from field: bytes last_remote_config_hash = 1;
+   */
+  lastRemoteConfigHash: Uint8Array;
+
+  /**
+   * @generated from field: config.v1alpha1.RemoteConfigStatuses status = 2;
+   */
+  status: RemoteConfigStatuses;
+
+  /**
+   * @generated from field: string error_message = 3;
+   */
+  errorMessage: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.RemoteConfigStatus.
+ * Use `create(RemoteConfigStatusSchema)` to create a new message.
+ */
+export const RemoteConfigStatusSchema: GenMessage<RemoteConfigStatus> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 52);
+
+/**
+ * ConfigPush tracks the delivery of a single remote config offer to an agent.
+ *
+ * @generated from message config.v1alpha1.ConfigPush
+ */
+export type ConfigPush = Message<"config.v1alpha1.ConfigPush"> & {
+  /**
+   * @generated from field: string push_id = 1;
+   */
+  pushId: string;
+
+  /**
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: bytes config_hash = 3;
+   */
+  configHash: Uint8Array;
+
+  /**
+   * @generated from field: config.v1alpha1.ConfigPushState state = 4;
+   */
+  state: ConfigPushState;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp offered_at = 5;
+   */
+  offeredAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp acknowledged_at = 6;
+   */
+  acknowledgedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp applied_at = 7;
+   */
+  appliedAt?: Timestamp;
+
+  /**
+   * @generated from field: string error_message = 8;
+   */
+  errorMessage: string;
+
+  /**
+   * attempt counts consecutive pushes of the same config hash, starting at 1,
+   * so that retries are visible
+   *
+   * @generated from field: int32 attempt = 9;
+   */
+  attempt: number;
+};
+
+/**
+ * Describes the message config.v1alpha1.ConfigPush.
+ * Use `create(ConfigPushSchema)` to create a new message.
+ */
+export const ConfigPushSchema: GenMessage<ConfigPush> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 53);
+
+/**
+ * ConfigPushHistory holds the most recent pushes to an agent, oldest first.
+ *
+ * @generated from message config.v1alpha1.ConfigPushHistory
+ */
+export type ConfigPushHistory = Message<"config.v1alpha1.ConfigPushHistory"> & {
+  /**
+   * @generated from field: repeated config.v1alpha1.ConfigPush pushes = 1;
+   */
+  pushes: ConfigPush[];
+};
+
+/**
+ * Describes the message config.v1alpha1.ConfigPushHistory.
+ * Use `create(ConfigPushHistorySchema)` to create a new message.
+ */
+export const ConfigPushHistorySchema: GenMessage<ConfigPushHistory> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 54);
+
+/**
+ * ConfigPushOffer is sent to supervisors in an OpAMP custom message alongside a remote config.
+ *
+ * @generated from message config.v1alpha1.ConfigPushOffer
+ */
+export type ConfigPushOffer = Message<"config.v1alpha1.ConfigPushOffer"> & {
+  /**
+   * @generated from field: string push_id = 1;
+   */
+  pushId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.ConfigPushOffer.
+ * Use `create(ConfigPushOfferSchema)` to create a new message.
+ */
+export const ConfigPushOfferSchema: GenMessage<ConfigPushOffer> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 55);
+
+/**
+ * ConfigPushReceipt is sent by supervisors in an OpAMP custom message once they
+ * processed the remote config of a ConfigPushOffer.
+ *
+ * @generated from message config.v1alpha1.ConfigPushReceipt
+ */
+export type ConfigPushReceipt = Message<"config.v1alpha1.ConfigPushReceipt"> & {
+  /**
+   * @generated from field: string push_id = 1;
+   */
+  pushId: string;
+
+  /**
+   * @generated from field: bool applied = 2;
+   */
+  applied: boolean;
+
+  /**
+   * @generated from field: string error_message = 3;
+   */
+  errorMessage: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.ConfigPushReceipt.
+ * Use `create(ConfigPushReceiptSchema)` to create a new message.
+ */
+export const ConfigPushReceiptSchema: GenMessage<ConfigPushReceipt> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 56);
+
+/**
+ * AvailabilityHistory records the periods an agent was heard from, oldest first
+ *
+ * @generated from message config.v1alpha1.AvailabilityHistory
+ */
+export type AvailabilityHistory = Message<"config.v1alpha1.AvailabilityHistory"> & {
+  /**
+   * @generated from field: repeated config.v1alpha1.AvailabilityPeriod periods = 1;
+   */
+  periods: AvailabilityPeriod[];
+};
+
+/**
+ * Describes the message config.v1alpha1.AvailabilityHistory.
+ * Use `create(AvailabilityHistorySchema)` to create a new message.
+ */
+export const AvailabilityHistorySchema: GenMessage<AvailabilityHistory> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 57);
+
+/**
+ * AvailabilityPeriod is a stretch of time the agent sent heartbeats no further apart than
+ * the heartbeat timeout, with the same health
+ *
+ * @generated from message config.v1alpha1.AvailabilityPeriod
+ */
+export type AvailabilityPeriod = Message<"config.v1alpha1.AvailabilityPeriod"> & {
+  /**
+   * @generated from field: google.protobuf.Timestamp start = 1;
+   */
+  start?: Timestamp;
+
+  /**
+   * time of the last heartbeat of the period
+   *
+   * @generated from field: google.protobuf.Timestamp end = 2;
+   */
+  end?: Timestamp;
+
+  /**
+   * @generated from field: bool healthy = 3;
+   */
+  healthy: boolean;
+
+  /**
+   * set when the period ended with the agent disconnecting, open periods are assumed to last
+   * until the heartbeat timeout expires
+   *
+   * @generated from field: bool closed = 4;
+   */
+  closed: boolean;
+};
+
+/**
+ * Describes the message config.v1alpha1.AvailabilityPeriod.
+ * Use `create(AvailabilityPeriodSchema)` to create a new message.
+ */
+export const AvailabilityPeriodSchema: GenMessage<AvailabilityPeriod> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 58);
+
+/**
+ * @generated from message config.v1alpha1.GetAgentAvailabilityRequest
+ */
+export type GetAgentAvailabilityRequest = Message<"config.v1alpha1.GetAgentAvailabilityRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * defaults to 1h, 24h, 7d and 30d, windows longer than 30d are not supported
+   *
+   * @generated from field: repeated google.protobuf.Duration windows = 2;
+   */
+  windows: Duration[];
+};
+
+/**
+ * Describes the message config.v1alpha1.GetAgentAvailabilityRequest.
+ * Use `create(GetAgentAvailabilityRequestSchema)` to create a new message.
+ */
+export const GetAgentAvailabilityRequestSchema: GenMessage<GetAgentAvailabilityRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 59);
+
+/**
+ * WindowAvailability is the availability over the window ending now. Windows start no
+ * earlier than the first heartbeat of the agent.
+ *
+ * @generated from message config.v1alpha1.WindowAvailability
+ */
+export type WindowAvailability = Message<"config.v1alpha1.WindowAvailability"> & {
+  /**
+   * @generated from field: google.protobuf.Duration window = 1;
+   */
+  window?: Duration;
+
+  /**
+   * share of the window the agent was connected, between 0 and 1
+   *
+   * @generated from field: double connected = 2;
+   */
+  connected: number;
+
+  /**
+   * share of the window the agent was connected and healthy, between 0 and 1
+   *
+   * @generated from field: double healthy = 3;
+   */
+  healthy: number;
+};
+
+/**
+ * Describes the message config.v1alpha1.WindowAvailability.
+ * Use `create(WindowAvailabilitySchema)` to create a new message.
+ */
+export const WindowAvailabilitySchema: GenMessage<WindowAvailability> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 60);
+
+/**
+ * @generated from message config.v1alpha1.AgentAvailability
+ */
+export type AgentAvailability = Message<"config.v1alpha1.AgentAvailability"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: repeated config.v1alpha1.WindowAvailability windows = 2;
+   */
+  windows: WindowAvailability[];
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentAvailability.
+ * Use `create(AgentAvailabilitySchema)` to create a new message.
+ */
+export const AgentAvailabilitySchema: GenMessage<AgentAvailability> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 61);
+
+/**
+ * @generated from message config.v1alpha1.GetFleetAvailabilityRequest
+ */
+export type GetFleetAvailabilityRequest = Message<"config.v1alpha1.GetFleetAvailabilityRequest"> & {
+  /**
+   * label to group agents by, all agents are in a single group if empty
+   *
+   * @generated from field: string group_by = 1;
+   */
+  groupBy: string;
+
+  /**
+   * only agents matching every label are included
+   *
+   * @generated from field: map<string, string> selector = 2;
+   */
+  selector: { [key: string]: string };
+
+  /**
+   * @generated from field: repeated google.protobuf.Duration windows = 3;
+   */
+  windows: Duration[];
+};
+
+/**
+ * Describes the message config.v1alpha1.GetFleetAvailabilityRequest.
+ * Use `create(GetFleetAvailabilityRequestSchema)` to create a new message.
+ */
+export const GetFleetAvailabilityRequestSchema: GenMessage<GetFleetAvailabilityRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 62);
+
+/**
+ * AvailabilityGroup is the mean availability of the agents sharing a label value
+ *
+ * @generated from message config.v1alpha1.AvailabilityGroup
+ */
+export type AvailabilityGroup = Message<"config.v1alpha1.AvailabilityGroup"> & {
+  /**
+   * empty for agents without the label
+   *
+   * @generated from field: string label_value = 1;
+   */
+  labelValue: string;
+
+  /**
+   * @generated from field: int32 agent_count = 2;
+   */
+  agentCount: number;
+
+  /**
+   * @generated from field: repeated config.v1alpha1.WindowAvailability windows = 3;
+   */
+  windows: WindowAvailability[];
+};
+
+/**
+ * Describes the message config.v1alpha1.AvailabilityGroup.
+ * Use `create(AvailabilityGroupSchema)` to create a new message.
+ */
+export const AvailabilityGroupSchema: GenMessage<AvailabilityGroup> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 63);
+
+/**
+ * @generated from message config.v1alpha1.FleetAvailability
+ */
+export type FleetAvailability = Message<"config.v1alpha1.FleetAvailability"> & {
+  /**
+   * sorted by label value
+   *
+   * @generated from field: repeated config.v1alpha1.AvailabilityGroup groups = 1;
+   */
+  groups: AvailabilityGroup[];
+};
+
+/**
+ * Describes the message config.v1alpha1.FleetAvailability.
+ * Use `create(FleetAvailabilitySchema)` to create a new message.
+ */
+export const FleetAvailabilitySchema: GenMessage<FleetAvailability> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 64);
+
+/**
+ * AgentCommandStatus tracks a command sent to an agent
+ *
+ * @generated from message config.v1alpha1.AgentCommandStatus
+ */
+export type AgentCommandStatus = Message<"config.v1alpha1.AgentCommandStatus"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * the command, e.g. "restart"
+   *
+   * @generated from field: string type = 2;
+   */
+  type: string;
+
+  /**
+   * @generated from field: config.v1alpha1.AgentCommandState state = 3;
+   */
+  state: AgentCommandState;
+
+  /**
+   * principal that requested the command, empty for anonymous callers
+   *
+   * @generated from field: string requested_by = 4;
+   */
+  requestedBy: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp requested_at = 5;
+   */
+  requestedAt?: Timestamp;
+
+  /**
+   * when the agent reported the result
+   *
+   * @generated from field: google.protobuf.Timestamp completed_at = 6;
+   */
+  completedAt?: Timestamp;
+
+  /**
+   * @generated from field: string error_message = 7;
+   */
+  errorMessage: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentCommandStatus.
+ * Use `create(AgentCommandStatusSchema)` to create a new message.
+ */
+export const AgentCommandStatusSchema: GenMessage<AgentCommandStatus> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 65);
+
+/**
+ * AgentCommandResult is sent by supervisors once they carried out a command. OpAMP commands
+ * carry no ID, the result is for the agent's last command of the same type.
+ *
+ * @generated from message config.v1alpha1.AgentCommandResult
+ */
+export type AgentCommandResult = Message<"config.v1alpha1.AgentCommandResult"> & {
+  /**
+   * @generated from field: string type = 1;
+   */
+  type: string;
+
+  /**
+   * @generated from field: bool succeeded = 2;
+   */
+  succeeded: boolean;
+
+  /**
+   * @generated from field: string error_message = 3;
+   */
+  errorMessage: string;
+};
 
+/**
+ * Describes the message config.v1alpha1.AgentCommandResult.
+ * Use `create(AgentCommandResultSchema)` to create a new message.
+ */
+export const AgentCommandResultSchema: GenMessage<AgentCommandResult> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 66);
+
+/**
+ * @generated from message config.v1alpha1.RestartAgentRequest
+ */
+export type RestartAgentRequest = Message<"config.v1alpha1.RestartAgentRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.RestartAgentRequest.
+ * Use `create(RestartAgentRequestSchema)` to create a new message.
+ */
+export const RestartAgentRequestSchema: GenMessage<RestartAgentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 67);
+
+/**
+ * @generated from message config.v1alpha1.RestartAgentResponse
+ */
+export type RestartAgentResponse = Message<"config.v1alpha1.RestartAgentResponse"> & {
   /**
-   * Attributes that identify the Agent (e.g., service.name, service.version, service.instance.id).
-   *
-   * @generated from field: repeated config.v1alpha1.KeyValue identifying_attributes = 3;
+   * @generated from field: config.v1alpha1.AgentCommandStatus command = 1;
    */
-  identifyingAttributes: KeyValue[];
+  command?: AgentCommandStatus;
+};
+
+/**
+ * Describes the message config.v1alpha1.RestartAgentResponse.
+ * Use `create(RestartAgentResponseSchema)` to create a new message.
+ */
+export const RestartAgentResponseSchema: GenMessage<RestartAgentResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 68);
 
+/**
+ * @generated from message config.v1alpha1.UndeleteAgentRequest
+ */
+export type UndeleteAgentRequest = Message<"config.v1alpha1.UndeleteAgentRequest"> & {
   /**
-   * Attributes that do not necessarily identify the Agent but help describe where it runs.
-   *
-   * @generated from field: repeated config.v1alpha1.KeyValue non_identifying_attributes = 4;
+   * @generated from field: string agent_id = 1;
    */
-  nonIdentifyingAttributes: KeyValue[];
+  agentId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.UndeleteAgentRequest.
+ * Use `create(UndeleteAgentRequestSchema)` to create a new message.
+ */
+export const UndeleteAgentRequestSchema: GenMessage<UndeleteAgentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 69);
 
+/**
+ * @generated from message config.v1alpha1.RevokeAgentRequest
+ */
+export type RevokeAgentRequest = Message<"config.v1alpha1.RevokeAgentRequest"> & {
   /**
-   * @generated from field: repeated string capabilities = 5;
+   * @generated from field: string agent_id = 1;
    */
-  capabilities: string[];
+  agentId: string;
 };
 
 /**
- * Describes the message config.v1alpha1.AgentRegistration.
- * Use `create(AgentRegistrationSchema)` to create a new message.
+ * Describes the message config.v1alpha1.RevokeAgentRequest.
+ * Use `create(RevokeAgentRequestSchema)` to create a new message.
  */
-export const AgentRegistrationSchema: GenMessage<AgentRegistration> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 10);
+export const RevokeAgentRequestSchema: GenMessage<RevokeAgentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 70);
 
 /**
- * AgentDescription is kept for backward compatibility.
- * Use AgentRegistration for new code.
- *
- * @generated from message config.v1alpha1.AgentDescription
+ * @generated from message config.v1alpha1.GetAgentEventsRequest
  */
-export type AgentDescription = Message<"config.v1alpha1.AgentDescription"> & {
+export type GetAgentEventsRequest = Message<"config.v1alpha1.GetAgentEventsRequest"> & {
   /**
-   * @generated from field: string id = 1;
+   * @generated from field: string agent_id = 1;
    */
-  id: string;
+  agentId: string;
 
   /**
-   * @generated from field: string friendly_name = 2;
+   * inclusive lower bound on the event time
+   *
+   * @generated from field: google.protobuf.Timestamp start = 2;
    */
-  friendlyName: string;
+  start?: Timestamp;
 
   /**
-   * Attributes that identify the Agent (e.g., service.name, service.version, service.instance.id).
-   * These attributes uniquely identify the agent and should be used for its own telemetry.
+   * exclusive upper bound on the event time
    *
-   * @generated from field: repeated config.v1alpha1.KeyValue identifying_attributes = 3;
+   * @generated from field: google.protobuf.Timestamp end = 3;
    */
-  identifyingAttributes: KeyValue[];
+  end?: Timestamp;
 
   /**
-   * Attributes that do not necessarily identify the Agent but help describe where it runs
-   * (e.g., os.type, os.version, host.*, cloud.*).
+   * event types to return, all if empty
    *
-   * @generated from field: repeated config.v1alpha1.KeyValue non_identifying_attributes = 4;
+   * @generated from field: repeated string types = 4;
    */
-  nonIdentifyingAttributes: KeyValue[];
+  types: string[];
 
   /**
-   * @generated from field: repeated string capabilities = 5;
+   * maximum number of events to return, 0 for the server default
+   *
+   * @generated from field: int32 limit = 5;
    */
-  capabilities: string[];
+  limit: number;
+
+  /**
+   * next_page_token from a previous response
+   *
+   * @generated from field: string page_token = 6;
+   */
+  pageToken: string;
 };
 
 /**
- * Describes the message config.v1alpha1.AgentDescription.
- * Use `create(AgentDescriptionSchema)` to create a new message.
+ * Describes the message config.v1alpha1.GetAgentEventsRequest.
+ * Use `create(GetAgentEventsRequestSchema)` to create a new message.
  */
-export const AgentDescriptionSchema: GenMessage<AgentDescription> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 11);
+export const GetAgentEventsRequestSchema: GenMessage<GetAgentEventsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 71);
 
 /**
- * KeyValue represents a key-value pair with support for various value types.
- *
- * @generated from message config.v1alpha1.KeyValue
+ * @generated from message config.v1alpha1.GetAgentEventsResponse
  */
-export type KeyValue = Message<"config.v1alpha1.KeyValue"> & {
+export type GetAgentEventsResponse = Message<"config.v1alpha1.GetAgentEventsResponse"> & {
   /**
-   * @generated from field: string key = 1;
+   * @generated from field: repeated events.v1alpha1.Event events = 1;
    */
-  key: string;
+  events: Event[];
 
   /**
-   * @generated from field: config.v1alpha1.AnyValue value = 2;
+   * @generated from field: string next_page_token = 2;
    */
-  value?: AnyValue;
+  nextPageToken: string;
 };
 
 /**
- * Describes the message config.v1alpha1.KeyValue.
- * Use `create(KeyValueSchema)` to create a new message.
+ * Describes the message config.v1alpha1.GetAgentEventsResponse.
+ * Use `create(GetAgentEventsResponseSchema)` to create a new message.
  */
-export const KeyValueSchema: GenMessage<KeyValue> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 12);
+export const GetAgentEventsResponseSchema: GenMessage<GetAgentEventsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 72);
 
 /**
- * AnyValue represents a value that can be one of several types.
+ * AgentProblemReport is sent by supervisors when they detect a problem they can't recover
+ * from by themselves. A problem is reported once when it starts, and again if it recurs
+ * after it cleared.
  *
- * @generated from message config.v1alpha1.AnyValue
+ * @generated from message config.v1alpha1.AgentProblemReport
  */
-export type AnyValue = Message<"config.v1alpha1.AnyValue"> & {
+export type AgentProblemReport = Message<"config.v1alpha1.AgentProblemReport"> & {
   /**
-   * @generated from oneof config.v1alpha1.AnyValue.value
+   * @generated from field: config.v1alpha1.AgentProblemType type = 1;
    */
-  value: {
-    /**
-     * @generated from field: string string_value = 1;
-     */
-    value: string;
-    case: "stringValue";
-  } | {
-    /**
-     * @generated from field: bool bool_value = 2;
-     */
-    value: boolean;
-    case: "boolValue";
-  } | {
-    /**
-     * @generated from field: int64 int_value = 3;
-     */
-    value: bigint;
-    case: "intValue";
-  } | {
-    /**
-     * @generated from field: double double_value = 4;
-     */
-    value: number;
-    case: "doubleValue";
-  } | {
-    /**
-     * @generated from field: bytes bytes_value = 5;
-     */
-    value: Uint8Array;
-    case: "bytesValue";
-  } | {
-    /**
-     * @generated from field: config.v1alpha1.ArrayValue array_value = 6;
-     */
-    value: ArrayValue;
-    case: "arrayValue";
-  } | {
-    /**
-     * @generated from field: config.v1alpha1.KeyValueList kvlist_value = 7;
-     */
-    value: KeyValueList;
-    case: "kvlistValue";
-  } | { case: undefined; value?: undefined };
+  type: AgentProblemType;
+
+  /**
+   * @generated from field: string message = 2;
+   */
+  message: string;
+
+  /**
+   * how many times the problem occurred, e.g. the consecutive failed config applies
+   *
+   * @generated from field: int32 occurrences = 3;
+   */
+  occurrences: number;
+
+  /**
+   * @generated from field: map<string, string> details = 4;
+   */
+  details: { [key: string]: string };
 };
 
 /**
- * Describes the message config.v1alpha1.AnyValue.
- * Use `create(AnyValueSchema)` to create a new message.
+ * Describes the message config.v1alpha1.AgentProblemReport.
+ * Use `create(AgentProblemReportSchema)` to create a new message.
  */
-export const AnyValueSchema: GenMessage<AnyValue> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 13);
+export const AgentProblemReportSchema: GenMessage<AgentProblemReport> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 73);
 
 /**
- * ArrayValue holds an array of AnyValue.
+ * AgentProblem is a problem reported by an agent, open until it is acknowledged
  *
- * @generated from message config.v1alpha1.ArrayValue
+ * @generated from message config.v1alpha1.AgentProblem
  */
-export type ArrayValue = Message<"config.v1alpha1.ArrayValue"> & {
+export type AgentProblem = Message<"config.v1alpha1.AgentProblem"> & {
   /**
-   * @generated from field: repeated config.v1alpha1.AnyValue values = 1;
+   * @generated from field: string agent_id = 1;
    */
-  values: AnyValue[];
+  agentId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.AgentProblemType type = 2;
+   */
+  type: AgentProblemType;
+
+  /**
+   * from the last report of the problem
+   *
+   * @generated from field: string message = 3;
+   */
+  message: string;
+
+  /**
+   * @generated from field: int32 occurrences = 4;
+   */
+  occurrences: number;
+
+  /**
+   * @generated from field: map<string, string> details = 5;
+   */
+  details: { [key: string]: string };
+
+  /**
+   * reports received since the problem was opened
+   *
+   * @generated from field: int32 reports = 6;
+   */
+  reports: number;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp first_reported_at = 7;
+   */
+  firstReportedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_reported_at = 8;
+   */
+  lastReportedAt?: Timestamp;
 };
 
 /**
- * Describes the message config.v1alpha1.ArrayValue.
- * Use `create(ArrayValueSchema)` to create a new message.
+ * Describes the message config.v1alpha1.AgentProblem.
+ * Use `create(AgentProblemSchema)` to create a new message.
  */
-export const ArrayValueSchema: GenMessage<ArrayValue> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 14);
+export const AgentProblemSchema: GenMessage<AgentProblem> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 74);
 
 /**
- * KeyValueList holds a list of KeyValue pairs.
- *
- * @generated from message config.v1alpha1.KeyValueList
+ * @generated from message config.v1alpha1.GetProblemsReportRequest
  */
-export type KeyValueList = Message<"config.v1alpha1.KeyValueList"> & {
+export type GetProblemsReportRequest = Message<"config.v1alpha1.GetProblemsReportRequest"> & {
   /**
-   * @generated from field: repeated config.v1alpha1.KeyValue values = 1;
+   * agent whose problems to return, all agents if empty
+   *
+   * @generated from field: string agent_id = 1;
    */
-  values: KeyValue[];
+  agentId: string;
+
+  /**
+   * problem types to return, all if empty
+   *
+   * @generated from field: repeated config.v1alpha1.AgentProblemType types = 2;
+   */
+  types: AgentProblemType[];
 };
 
 /**
- * Describes the message config.v1alpha1.KeyValueList.
- * Use `create(KeyValueListSchema)` to create a new message.
+ * Describes the message config.v1alpha1.GetProblemsReportRequest.
+ * Use `create(GetProblemsReportRequestSchema)` to create a new message.
  */
-export const KeyValueListSchema: GenMessage<KeyValueList> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 15);
+export const GetProblemsReportRequestSchema: GenMessage<GetProblemsReportRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 75);
 
 /**
- * AgentConnectionState represents the persisted connection state of an agent.
- * This replaces the in-memory AgentTracker state.
- *
- * @generated from message config.v1alpha1.AgentConnectionState
+ * @generated from message config.v1alpha1.GetProblemsReportResponse
  */
-export type AgentConnectionState = Message<"config.v1alpha1.AgentConnectionState"> & {
+export type GetProblemsReportResponse = Message<"config.v1alpha1.GetProblemsReportResponse"> & {
   /**
-   * @generated from field: string agent_id = 1;
+   * ordered by agent ID, then problem type
+   *
+   * @generated from field: repeated config.v1alpha1.AgentProblem problems = 1;
    */
-  agentId: string;
+  problems: AgentProblem[];
+};
 
-  /**
-   * @generated from field: config.v1alpha1.AgentState state = 2;
-   */
-  state: AgentState;
+/**
+ * Describes the message config.v1alpha1.GetProblemsReportResponse.
+ * Use `create(GetProblemsReportResponseSchema)` to create a new message.
+ */
+export const GetProblemsReportResponseSchema: GenMessage<GetProblemsReportResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 76);
 
+/**
+ * @generated from message config.v1alpha1.AcknowledgeProblemRequest
+ */
+export type AcknowledgeProblemRequest = Message<"config.v1alpha1.AcknowledgeProblemRequest"> & {
   /**
-   * @generated from field: google.protobuf.Timestamp last_seen = 3;
+   * @generated from field: string agent_id = 1;
    */
-  lastSeen?: Timestamp;
+  agentId: string;
 
   /**
-   * @generated from field: google.protobuf.Timestamp connected_at = 4;
+   * @generated from field: config.v1alpha1.AgentProblemType type = 2;
    */
-  connectedAt?: Timestamp;
+  type: AgentProblemType;
+};
+
+/**
+ * Describes the message config.v1alpha1.AcknowledgeProblemRequest.
+ * Use `create(AcknowledgeProblemRequestSchema)` to create a new message.
+ */
+export const AcknowledgeProblemRequestSchema: GenMessage<AcknowledgeProblemRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1alpha1_agents, 77);
 
+/**
+ * @generated from enum config.v1alpha1.AgentSortField
+ */
+export enum AgentSortField {
   /**
-   * @generated from field: google.protobuf.Timestamp disconnected_at = 5;
+   * by agent ID
+   *
+   * @generated from enum value: AGENT_SORT_FIELD_UNSPECIFIED = 0;
    */
-  disconnectedAt?: Timestamp;
+  UNSPECIFIED = 0,
 
   /**
-   * @generated from field: bytes instance_uid = 6;
+   * @generated from enum value: AGENT_SORT_FIELD_NAME = 1;
    */
-  instanceUid: Uint8Array;
+  NAME = 1,
 
   /**
-   * @generated from field: uint64 capabilities = 7;
+   * agents that were never seen first
+   *
+   * @generated from enum value: AGENT_SORT_FIELD_LAST_SEEN = 2;
    */
-  capabilities: bigint;
+  LAST_SEEN = 2,
 
   /**
-   * @generated from field: uint64 sequence_num = 8;
+   * @generated from enum value: AGENT_SORT_FIELD_STATE = 3;
    */
-  sequenceNum: bigint;
-};
+  STATE = 3,
+}
 
 /**
- * Describes the message config.v1alpha1.AgentConnectionState.
- * Use `create(AgentConnectionStateSchema)` to create a new message.
+ * Describes the enum config.v1alpha1.AgentSortField.
  */
-export const AgentConnectionStateSchema: GenMessage<AgentConnectionState> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 16);
+export const AgentSortFieldSchema: GenEnum<AgentSortField> = /*@__PURE__*/
+  enumDesc(file_pkg_api_agents_v1alpha1_agents, 0);
 
 /**
- * ComponentHealth represents the health status of an agent and its components.
- *
- * @generated from message config.v1alpha1.ComponentHealth
+ * @generated from enum config.v1alpha1.AgentHealthState
  */
-export type ComponentHealth = Message<"config.v1alpha1.ComponentHealth"> & {
+export enum AgentHealthState {
   /**
-   * @generated from field: bool healthy = 1;
+   * the agent hasn't reported its health
+   *
+   * @generated from enum value: AGENT_HEALTH_STATE_UNKNOWN = 0;
    */
-  healthy: boolean;
+  UNKNOWN = 0,
 
   /**
-   * @generated from field: uint64 start_time_unix_nano = 2;
+   * @generated from enum value: AGENT_HEALTH_STATE_HEALTHY = 1;
    */
-  startTimeUnixNano: bigint;
+  HEALTHY = 1,
 
   /**
-   * @generated from field: string last_error = 3;
+   * @generated from enum value: AGENT_HEALTH_STATE_UNHEALTHY = 2;
    */
-  lastError: string;
+  UNHEALTHY = 2,
+}
+
+/**
+ * Describes the enum config.v1alpha1.AgentHealthState.
+ */
+export const AgentHealthStateSchema: GenEnum<AgentHealthState> = /*@__PURE__*/
+  enumDesc(file_pkg_api_agents_v1alpha1_agents, 1);
 
+/**
+ * AgentStatusView selects the parts of an agent's status to assemble, cheaper views skip
+ * reading the stores holding the omitted parts
+ *
+ * @generated from enum config.v1alpha1.AgentStatusView
+ */
+export enum AgentStatusView {
   /**
-   * @generated from field: string status = 4;
+   * same as AGENT_STATUS_VIEW_FULL
+   *
+   * @generated from enum value: AGENT_STATUS_VIEW_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * state, connection times, instance history and config sync status
+   *
+   * @generated from enum value: AGENT_STATUS_VIEW_BASIC = 1;
    */
-  status: string;
+  BASIC = 1,
 
   /**
-   * @generated from field: uint64 status_time_unix_nano = 5;
+   * the basic view plus component health and the remote config status
+   *
+   * @generated from enum value: AGENT_STATUS_VIEW_HEALTH = 2;
    */
-  statusTimeUnixNano: bigint;
+  HEALTH = 2,
 
   /**
-   * @generated from field: map<string, config.v1alpha1.ComponentHealth> component_health_map = 6;
+   * everything, including the effective config bodies
+   *
+   * @generated from enum value: AGENT_STATUS_VIEW_FULL = 3;
    */
-  componentHealthMap: { [key: string]: ComponentHealth };
-};
+  FULL = 3,
+}
 
 /**
- * Describes the message config.v1alpha1.ComponentHealth.
- * Use `create(ComponentHealthSchema)` to create a new message.
+ * Describes the enum config.v1alpha1.AgentStatusView.
  */
-export const ComponentHealthSchema: GenMessage<ComponentHealth> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 17);
+export const AgentStatusViewSchema: GenEnum<AgentStatusView> = /*@__PURE__*/
+  enumDesc(file_pkg_api_agents_v1alpha1_agents, 2);
 
 /**
- * EffectiveConfig represents the current effective configuration of an agent.
- *
- * @generated from message config.v1alpha1.EffectiveConfig
+ * @generated from enum config.v1alpha1.AgentCondition
  */
-export type EffectiveConfig = Message<"config.v1alpha1.EffectiveConfig"> & {
+export enum AgentCondition {
   /**
-   * @generated from field: config.v1alpha1.AgentConfigMap config_map = 1;
+   * @generated from enum value: AGENT_CONDITION_UNSPECIFIED = 0;
    */
-  configMap?: AgentConfigMap;
-};
-
-/**
- * Describes the message config.v1alpha1.EffectiveConfig.
- * Use `create(EffectiveConfigSchema)` to create a new message.
- */
-export const EffectiveConfigSchema: GenMessage<EffectiveConfig> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 18);
+  UNSPECIFIED = 0,
 
-/**
- * AgentConfigMap holds a map of config file names to their content.
- *
- * @generated from message config.v1alpha1.AgentConfigMap
- */
-export type AgentConfigMap = Message<"config.v1alpha1.AgentConfigMap"> & {
   /**
-   * @generated from field: map<string, config.v1alpha1.AgentConfigFile> config_map = 1;
+   * @generated from enum value: AGENT_CONDITION_CONNECTED = 1;
    */
-  configMap: { [key: string]: AgentConfigFile };
-};
+  CONNECTED = 1,
 
-/**
- * Describes the message config.v1alpha1.AgentConfigMap.
- * Use `create(AgentConfigMapSchema)` to create a new message.
- */
-export const AgentConfigMapSchema: GenMessage<AgentConfigMap> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 19);
+  /**
+   * the agent applied its assigned config, the built-in config when nothing is assigned, or
+   * the config with config_hash if set
+   *
+   * @generated from enum value: AGENT_CONDITION_CONFIG_APPLIED = 2;
+   */
+  CONFIG_APPLIED = 2,
 
-/**
- * AgentConfigFile represents a single configuration file.
- *
- * @generated from message config.v1alpha1.AgentConfigFile
- */
-export type AgentConfigFile = Message<"config.v1alpha1.AgentConfigFile"> & {
   /**
-   * @generated from field: bytes body = 1;
+   * @generated from enum value: AGENT_CONDITION_HEALTHY = 3;
    */
-  body: Uint8Array;
+  HEALTHY = 3,
 
   /**
-   * @generated from field: string content_type = 2;
+   * @generated from enum value: AGENT_CONDITION_DISCONNECTED = 4;
    */
-  contentType: string;
-};
+  DISCONNECTED = 4,
+}
 
 /**
- * Describes the message config.v1alpha1.AgentConfigFile.
- * Use `create(AgentConfigFileSchema)` to create a new message.
+ * Describes the enum config.v1alpha1.AgentCondition.
  */
-export const AgentConfigFileSchema: GenMessage<AgentConfigFile> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 20);
+export const AgentConditionSchema: GenEnum<AgentCondition> = /*@__PURE__*/
+  enumDesc(file_pkg_api_agents_v1alpha1_agents, 3);
 
 /**
- * RemoteConfigStatus represents the status of a remote configuration on an agent.
- *
- * @generated from message config.v1alpha1.RemoteConfigStatus
+ * @generated from enum config.v1alpha1.AgentSnapshotState
  */
-export type RemoteConfigStatus = Message<"config.v1alpha1.RemoteConfigStatus"> & {
+export enum AgentSnapshotState {
   /**
-   * @generated from field: bytes last_remote_config_hash = 1;
+   * @generated from enum value: AGENT_SNAPSHOT_STATE_UNSPECIFIED = 0;
    */
-  lastRemoteConfigHash: Uint8Array;
+  UNSPECIFIED = 0,
 
   /**
-   * @generated from field: config.v1alpha1.RemoteConfigStatuses status = 2;
+   * @generated from enum value: AGENT_SNAPSHOT_STATE_PENDING = 1;
    */
-  status: RemoteConfigStatuses;
+  PENDING = 1,
 
   /**
-   * @generated from field: string error_message = 3;
+   * @generated from enum value: AGENT_SNAPSHOT_STATE_READY = 2;
    */
-  errorMessage: string;
-};
+  READY = 2,
+
+  /**
+   * @generated from enum value: AGENT_SNAPSHOT_STATE_FAILED = 3;
+   */
+  FAILED = 3,
+}
 
 /**
- * Describes the message config.v1alpha1.RemoteConfigStatus.
- * Use `create(RemoteConfigStatusSchema)` to create a new message.
+ * Describes the enum config.v1alpha1.AgentSnapshotState.
  */
-export const RemoteConfigStatusSchema: GenMessage<RemoteConfigStatus> = /*@__PURE__*/
-  messageDesc(file_pkg_api_agents_v1alpha1_agents, 21);
+export const AgentSnapshotStateSchema: GenEnum<AgentSnapshotState> = /*@__PURE__*/
+  enumDesc(file_pkg_api_agents_v1alpha1_agents, 4);
 
 /**
  * @generated from enum config.v1alpha1.AgentState
@@ -669,7 +2645,7 @@ export enum AgentState {
  * Describes the enum config.v1alpha1.AgentState.
  */
 export const AgentStateSchema: GenEnum<AgentState> = /*@__PURE__*/
-  enumDesc(file_pkg_api_agents_v1alpha1_agents, 0);
+  enumDesc(file_pkg_api_agents_v1alpha1_agents, 5);
 
 /**
  * ConfigSyncStatus represents the unified config synchronization status.
@@ -712,13 +2688,20 @@ export enum ConfigSyncStatus {
    * @generated from enum value: CONFIG_SYNC_STATUS_ERROR = 4;
    */
   ERROR = 4,
+
+  /**
+   * Config assigned but the agent does not accept remote config
+   *
+   * @generated from enum value: CONFIG_SYNC_STATUS_UNSUPPORTED = 5;
+   */
+  UNSUPPORTED = 5,
 }
 
 /**
  * Describes the enum config.v1alpha1.ConfigSyncStatus.
  */
 export const ConfigSyncStatusSchema: GenEnum<ConfigSyncStatus> = /*@__PURE__*/
-  enumDesc(file_pkg_api_agents_v1alpha1_agents, 1);
+  enumDesc(file_pkg_api_agents_v1alpha1_agents, 6);
 
 /**
  * @generated from enum config.v1alpha1.RemoteConfigStatuses
@@ -749,7 +2732,117 @@ export enum RemoteConfigStatuses {
  * Describes the enum config.v1alpha1.RemoteConfigStatuses.
  */
 export const RemoteConfigStatusesSchema: GenEnum<RemoteConfigStatuses> = /*@__PURE__*/
-  enumDesc(file_pkg_api_agents_v1alpha1_agents, 2);
+  enumDesc(file_pkg_api_agents_v1alpha1_agents, 7);
+
+/**
+ * @generated from enum config.v1alpha1.ConfigPushState
+ */
+export enum ConfigPushState {
+  /**
+   * @generated from enum value: CONFIG_PUSH_STATE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * the config was sent to the agent
+   *
+   * @generated from enum value: CONFIG_PUSH_STATE_OFFERED = 1;
+   */
+  OFFERED = 1,
+
+  /**
+   * the agent reported receiving the config
+   *
+   * @generated from enum value: CONFIG_PUSH_STATE_ACKNOWLEDGED = 2;
+   */
+  ACKNOWLEDGED = 2,
+
+  /**
+   * @generated from enum value: CONFIG_PUSH_STATE_APPLIED = 3;
+   */
+  APPLIED = 3,
+
+  /**
+   * @generated from enum value: CONFIG_PUSH_STATE_FAILED = 4;
+   */
+  FAILED = 4,
+}
+
+/**
+ * Describes the enum config.v1alpha1.ConfigPushState.
+ */
+export const ConfigPushStateSchema: GenEnum<ConfigPushState> = /*@__PURE__*/
+  enumDesc(file_pkg_api_agents_v1alpha1_agents, 8);
+
+/**
+ * @generated from enum config.v1alpha1.AgentCommandState
+ */
+export enum AgentCommandState {
+  /**
+   * @generated from enum value: AGENT_COMMAND_STATE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * the command was sent, the agent hasn't reported its result yet
+   *
+   * @generated from enum value: AGENT_COMMAND_STATE_PENDING = 1;
+   */
+  PENDING = 1,
+
+  /**
+   * @generated from enum value: AGENT_COMMAND_STATE_SUCCEEDED = 2;
+   */
+  SUCCEEDED = 2,
+
+  /**
+   * @generated from enum value: AGENT_COMMAND_STATE_FAILED = 3;
+   */
+  FAILED = 3,
+}
+
+/**
+ * Describes the enum config.v1alpha1.AgentCommandState.
+ */
+export const AgentCommandStateSchema: GenEnum<AgentCommandState> = /*@__PURE__*/
+  enumDesc(file_pkg_api_agents_v1alpha1_agents, 9);
+
+/**
+ * @generated from enum config.v1alpha1.AgentProblemType
+ */
+export enum AgentProblemType {
+  /**
+   * @generated from enum value: AGENT_PROBLEM_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * the agent failed to apply its remote config several times in a row
+   *
+   * @generated from enum value: AGENT_PROBLEM_TYPE_CONFIG_APPLY_FAILING = 1;
+   */
+  CONFIG_APPLY_FAILING = 1,
+
+  /**
+   * the collector keeps exiting shortly after being started
+   *
+   * @generated from enum value: AGENT_PROBLEM_TYPE_COLLECTOR_CRASH_LOOP = 2;
+   */
+  COLLECTOR_CRASH_LOOP = 2,
+
+  /**
+   * the filesystem of the collector's config is nearly full
+   *
+   * @generated from enum value: AGENT_PROBLEM_TYPE_DISK_NEARLY_FULL = 3;
+   */
+  DISK_NEARLY_FULL = 3,
+}
+
+/**
+ * Describes the enum config.v1alpha1.AgentProblemType.
+ */
+export const AgentProblemTypeSchema: GenEnum<AgentProblemType> = /*@__PURE__*/
+  enumDesc(file_pkg_api_agents_v1alpha1_agents, 10);
 
 /**
  * @generated from service config.v1alpha1.AgentService
@@ -780,6 +2873,9 @@ export const AgentService: GenService<{
     output: typeof GetAgentStatusResponseSchema;
   },
   /**
+   * DeleteAgent hides the agent and withholds its config. It is purged once the deletion
+   * grace period expires, unless it reconnects or is undeleted in the meantime.
+   *
    * @generated from rpc config.v1alpha1.AgentService.DeleteAgent
    */
   deleteAgent: {
@@ -787,6 +2883,217 @@ export const AgentService: GenService<{
     input: typeof DeleteAgentRequestSchema;
     output: typeof EmptySchema;
   },
+  /**
+   * UndeleteAgent restores an agent deleted within the grace period
+   *
+   * @generated from rpc config.v1alpha1.AgentService.UndeleteAgent
+   */
+  undeleteAgent: {
+    methodKind: "unary";
+    input: typeof UndeleteAgentRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * RevokeAgent disconnects the agent and rejects its OpAMP connections from then on, e.g. once
+   * its host is decommissioned or compromised. Revoked agents can't bootstrap again under the
+   * same ID, they stay listed until they are deleted.
+   *
+   * @generated from rpc config.v1alpha1.AgentService.RevokeAgent
+   */
+  revokeAgent: {
+    methodKind: "unary";
+    input: typeof RevokeAgentRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * CaptureAgentSnapshot asks the agent's supervisor to upload a support snapshot.
+   * The snapshot is captured asynchronously, poll GetAgentSnapshot until it is ready.
+   *
+   * @generated from rpc config.v1alpha1.AgentService.CaptureAgentSnapshot
+   */
+  captureAgentSnapshot: {
+    methodKind: "unary";
+    input: typeof CaptureAgentSnapshotRequestSchema;
+    output: typeof CaptureAgentSnapshotResponseSchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.AgentService.GetAgentSnapshot
+   */
+  getAgentSnapshot: {
+    methodKind: "unary";
+    input: typeof GetAgentSnapshotRequestSchema;
+    output: typeof GetAgentSnapshotResponseSchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.AgentService.ListAgentSnapshots
+   */
+  listAgentSnapshots: {
+    methodKind: "unary";
+    input: typeof ListAgentSnapshotsRequestSchema;
+    output: typeof ListAgentSnapshotsResponseSchema;
+  },
+  /**
+   * ListConfigPushes lists the remote configs recently pushed to an agent and how far
+   * each got, from being offered to being applied.
+   *
+   * @generated from rpc config.v1alpha1.AgentService.ListConfigPushes
+   */
+  listConfigPushes: {
+    methodKind: "unary";
+    input: typeof ListConfigPushesRequestSchema;
+    output: typeof ListConfigPushesResponseSchema;
+  },
+  /**
+   * Fleet snapshots record the state of every agent, they are captured daily and on demand.
+   *
+   * @generated from rpc config.v1alpha1.AgentService.CaptureFleetSnapshot
+   */
+  captureFleetSnapshot: {
+    methodKind: "unary";
+    input: typeof CaptureFleetSnapshotRequestSchema;
+    output: typeof FleetSnapshotSchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.AgentService.ListFleetSnapshots
+   */
+  listFleetSnapshots: {
+    methodKind: "unary";
+    input: typeof ListFleetSnapshotsRequestSchema;
+    output: typeof ListFleetSnapshotsResponseSchema;
+  },
+  /**
+   * DiffFleetState reports the agents added, removed and changed between two fleet snapshots,
+   * or between a snapshot and the current state of the fleet.
+   *
+   * @generated from rpc config.v1alpha1.AgentService.DiffFleetState
+   */
+  diffFleetState: {
+    methodKind: "unary";
+    input: typeof DiffFleetStateRequestSchema;
+    output: typeof FleetStateDiffSchema;
+  },
+  /**
+   * Availability is the share of time agents were connected and healthy over rolling windows,
+   * computed from their heartbeats. An agent missing heartbeats for longer than the configured
+   * timeout counts as unavailable until it is heard from again.
+   *
+   * @generated from rpc config.v1alpha1.AgentService.GetAgentAvailability
+   */
+  getAgentAvailability: {
+    methodKind: "unary";
+    input: typeof GetAgentAvailabilityRequestSchema;
+    output: typeof AgentAvailabilitySchema;
+  },
+  /**
+   * GetFleetAvailability averages the availability of agents, grouped by the value of a label
+   *
+   * @generated from rpc config.v1alpha1.AgentService.GetFleetAvailability
+   */
+  getFleetAvailability: {
+    methodKind: "unary";
+    input: typeof GetFleetAvailabilityRequestSchema;
+    output: typeof FleetAvailabilitySchema;
+  },
+  /**
+   * WaitForAgentCondition returns as soon as the agent satisfies the condition, or once the
+   * timeout expires, with the status of the agent either way
+   *
+   * @generated from rpc config.v1alpha1.AgentService.WaitForAgentCondition
+   */
+  waitForAgentCondition: {
+    methodKind: "unary";
+    input: typeof WaitForAgentConditionRequestSchema;
+    output: typeof WaitForAgentConditionResponseSchema;
+  },
+  /**
+   * RestartAgent asks a connected agent to restart its collector. The agent reports the
+   * result asynchronously, it is the last_command of the agent's status.
+   *
+   * @generated from rpc config.v1alpha1.AgentService.RestartAgent
+   */
+  restartAgent: {
+    methodKind: "unary";
+    input: typeof RestartAgentRequestSchema;
+    output: typeof RestartAgentResponseSchema;
+  },
+  /**
+   * GetAgentEvents returns the timeline of an agent, oldest first: the events recorded about
+   * it, e.g. connections, config assignments and outcomes, bootstrap and deletion. Timelines
+   * are kept longer than fleet events, and outlive purged agents until they expire.
+   *
+   * @generated from rpc config.v1alpha1.AgentService.GetAgentEvents
+   */
+  getAgentEvents: {
+    methodKind: "unary";
+    input: typeof GetAgentEventsRequestSchema;
+    output: typeof GetAgentEventsResponseSchema;
+  },
+  /**
+   * GetProblemsReport returns the open problems reported by agents' supervisors, e.g. a
+   * config failing to apply or a crash-looping collector. Problems stay open until they are
+   * acknowledged, even if the agent recovered in the meantime.
+   *
+   * @generated from rpc config.v1alpha1.AgentService.GetProblemsReport
+   */
+  getProblemsReport: {
+    methodKind: "unary";
+    input: typeof GetProblemsReportRequestSchema;
+    output: typeof GetProblemsReportResponseSchema;
+  },
+  /**
+   * AcknowledgeProblem closes an open problem of an agent. It is opened again if the agent
+   * reports it again.
+   *
+   * @generated from rpc config.v1alpha1.AgentService.AcknowledgeProblem
+   */
+  acknowledgeProblem: {
+    methodKind: "unary";
+    input: typeof AcknowledgeProblemRequestSchema;
+    output: typeof EmptySchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_pkg_api_agents_v1alpha1_agents, 0);
 
+/**
+ * GatewayService relays the OpAMP traffic of agents connected to regional gateways. Gateways
+ * terminate the OpAMP connections of the agents of their region and forward their messages,
+ * so that the server tracks and configures them as if they were connected directly.
+ *
+ * @generated from service config.v1alpha1.GatewayService
+ */
+export const GatewayService: GenService<{
+  /**
+   * Relay handles a message an agent sent to the gateway and returns the messages to send
+   * back to it, the reply last
+   *
+   * @generated from rpc config.v1alpha1.GatewayService.Relay
+   */
+  relay: {
+    methodKind: "unary";
+    input: typeof RelayRequestSchema;
+    output: typeof RelayResponseSchema;
+  },
+  /**
+   * Watch streams the messages sent to the agents of the gateway outside of replies, such as
+   * config pushes. Agents relayed by a gateway are disconnected when its watch ends.
+   *
+   * @generated from rpc config.v1alpha1.GatewayService.Watch
+   */
+  watch: {
+    methodKind: "server_streaming";
+    input: typeof WatchGatewayRequestSchema;
+    output: typeof RelayedMessageSchema;
+  },
+  /**
+   * Disconnect reports that an agent connection to the gateway closed
+   *
+   * @generated from rpc config.v1alpha1.GatewayService.Disconnect
+   */
+  disconnect: {
+    methodKind: "unary";
+    input: typeof DisconnectRelayedAgentRequestSchema;
+    output: typeof EmptySchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_pkg_api_agents_v1alpha1_agents, 1);
+
@@ -0,0 +1,458 @@
+// @generated by protoc-gen-es v2.10.2 with parameter "target=ts"
+// @generated from file pkg/api/agents/v1beta1/agents.proto (package agents.v1beta1, syntax proto3)
+/* eslint-disable */
+
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { EmptySchema, Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_empty, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file pkg/api/agents/v1beta1/agents.proto.
+ */
+export const file_pkg_api_agents_v1beta1_agents: GenFile = /*@__PURE__*/
+  fileDesc("CiNwa2cvYXBpL2FnZW50cy92MWJldGExL2FnZW50cy5wcm90bxIOYWdlbnRzLnYxYmV0YTEisAIKEUxpc3RBZ2VudHNSZXF1ZXN0EhcKD2luY2x1ZGVfZGVsZXRlZBgEIAEoCBIRCglwYWdlX3NpemUYBSABKAUSEgoKcGFnZV90b2tlbhgGIAEoCRIvCgdzb3J0X2J5GAcgASgOMh4uYWdlbnRzLnYxYmV0YTEuQWdlbnRTb3J0RmllbGQSEgoKZGVzY2VuZGluZxgIIAEoCBJMCg5sYWJlbF9zZWxlY3RvchgKIAMoCzI0LmFnZW50cy52MWJldGExLkxpc3RBZ2VudHNSZXF1ZXN0LkxhYmVsU2VsZWN0b3JFbnRyeRISCgpjb25maWdfaWRzGAsgAygJGjQKEkxhYmVsU2VsZWN0b3JFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIokBChJMaXN0QWdlbnRzUmVzcG9uc2USLgoGYWdlbnRzGAEgAygLMh4uYWdlbnRzLnYxYmV0YTEuQWdlbnRMaXN0RW50cnkSEwoLdG90YWxfY291bnQYAyABKAUSFwoPbmV4dF9wYWdlX3Rva2VuGAQgASgJEhUKDW1hdGNoZWRfY291bnQYBSABKAUiNgoOQWdlbnRMaXN0RW50cnkSJAoFYWdlbnQYASABKAsyFS5hZ2VudHMudjFiZXRhMS5BZ2VudCIjCg9HZXRBZ2VudFJlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkiOAoQR2V0QWdlbnRSZXNwb25zZRIkCgVhZ2VudBgBIAEoCzIVLmFnZW50cy52MWJldGExLkFnZW50IjUKEkRlbGV0ZUFnZW50UmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCRINCgVwdXJnZRgCIAEoCCIoChRVbmRlbGV0ZUFnZW50UmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCSLoAQoFQWdlbnQSCgoCaWQYASABKAkSFQoNZnJpZW5kbHlfbmFtZRgCIAEoCRI4ChZpZGVudGlmeWluZ19hdHRyaWJ1dGVzGAMgAygLMhguYWdlbnRzLnYxYmV0YTEuS2V5VmFsdWUSPAoabm9uX2lkZW50aWZ5aW5nX2F0dHJpYnV0ZXMYBCADKAsyGC5hZ2VudHMudjFiZXRhMS5LZXlWYWx1ZRIUCgxjYXBhYmlsaXRpZXMYBSADKAkSLgoKZGVsZXRlZF9hdBgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiQAoIS2V5VmFsdWUSCwoDa2V5GAEgASgJEicKBXZhbHVlGAIgASgLMhguYWdlbnRzLnYxYmV0YTEuQW55VmFsdWUi7gEKCEFueVZhbHVlEhYKDHN0cmluZ192YWx1ZRgBIAEoCUgAEhQKCmJvb2xfdmFsdWUYAiABKAhIABITCglpbnRfdmFsdWUYAyABKANIABIWCgxkb3VibGVfdmFsdWUYBCABKAFIABIVCgtieXRlc192YWx1ZRgFIAEoDEgAEjEKC2FycmF5X3ZhbHVlGAYgASgLMhouYWdlbnRzLnYxYmV0YTEuQXJyYXlWYWx1ZUgAEjQKDGt2bGlzdF92YWx1ZRgHIAEoCzIcLmFnZW50cy52MWJldGExLktleVZhbHVlTGlzdEgAQgcKBXZhbHVlIjYKCkFycmF5VmFsdWUSKAoGdmFsdWVzGAEgAygLMhguYWdlbnRzLnYxYmV0YTEuQW55VmFsdWUiOAoMS2V5VmFsdWVMaXN0EigKBnZhbHVlcxgBIAMoCzIYLmFnZW50cy52MWJldGExLktleVZhbHVlKokBCg5BZ2VudFNvcnRGaWVsZBIgChxBR0VOVF9TT1JUX0ZJRUxEX1VOU1BFQ0lGSUVEEAASGQoVQUdFTlRfU09SVF9GSUVMRF9OQU1FEAESHgoaQUdFTlRfU09SVF9GSUVMRF9MQVNUX1NFRU4QAhIaChZBR0VOVF9TT1JUX0ZJRUxEX1NUQVRFEAMyzAIKDEFnZW50U2VydmljZRJTCgpMaXN0QWdlbnRzEiEuYWdlbnRzLnYxYmV0YTEuTGlzdEFnZW50c1JlcXVlc3QaIi5hZ2VudHMudjFiZXRhMS5MaXN0QWdlbnRzUmVzcG9uc2USTQoIR2V0QWdlbnQSHy5hZ2VudHMudjFiZXRhMS5HZXRBZ2VudFJlcXVlc3QaIC5hZ2VudHMudjFiZXRhMS5HZXRBZ2VudFJlc3BvbnNlEkkKC0RlbGV0ZUFnZW50EiIuYWdlbnRzLnYxYmV0YTEuRGVsZXRlQWdlbnRSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5Ek0KDVVuZGVsZXRlQWdlbnQSJC5hZ2VudHMudjFiZXRhMS5VbmRlbGV0ZUFnZW50UmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eUI3WjVnaXRodWIuY29tL290ZWxmbGVldC9vdGVsZmxlZXQvcGtnL2FwaS9hZ2VudHMvdjFiZXRhMWIGcHJvdG8z", [file_google_protobuf_empty, file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message agents.v1beta1.ListAgentsRequest
+ */
+export type ListAgentsRequest = Message<"agents.v1beta1.ListAgentsRequest"> & {
+  /**
+   * also return the agents deleted within the grace period
+   *
+   * @generated from field: bool include_deleted = 4;
+   */
+  includeDeleted: boolean;
+
+  /**
+   * maximum number of agents returned, all the matching agents when 0. The next page is
+   * requested with the next_page_token of the response, and the same filters and order.
+   *
+   * @generated from field: int32 page_size = 5;
+   */
+  pageSize: number;
+
+  /**
+   * @generated from field: string page_token = 6;
+   */
+  pageToken: string;
+
+  /**
+   * order of the agents, ties are broken by agent ID
+   *
+   * @generated from field: agents.v1beta1.AgentSortField sort_by = 7;
+   */
+  sortBy: AgentSortField;
+
+  /**
+   * @generated from field: bool descending = 8;
+   */
+  descending: boolean;
+
+  /**
+   * only return agents whose labels include all of these
+   *
+   * @generated from field: map<string, string> label_selector = 10;
+   */
+  labelSelector: { [key: string]: string };
+
+  /**
+   * only return agents assigned one of these configs, all agents when empty
+   *
+   * @generated from field: repeated string config_ids = 11;
+   */
+  configIds: string[];
+};
+
+/**
+ * Describes the message agents.v1beta1.ListAgentsRequest.
+ * Use `create(ListAgentsRequestSchema)` to create a new message.
+ */
+export const ListAgentsRequestSchema: GenMessage<ListAgentsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1beta1_agents, 0);
+
+/**
+ * @generated from message agents.v1beta1.ListAgentsResponse
+ */
+export type ListAgentsResponse = Message<"agents.v1beta1.ListAgentsResponse"> & {
+  /**
+   * @generated from field: repeated agents.v1beta1.AgentListEntry agents = 1;
+   */
+  agents: AgentListEntry[];
+
+  /**
+   * number of registered agents, before filtering
+   *
+   * @generated from field: int32 total_count = 3;
+   */
+  totalCount: number;
+
+  /**
+   * token of the next page, empty on the last page
+   *
+   * @generated from field: string next_page_token = 4;
+   */
+  nextPageToken: string;
+
+  /**
+   * number of agents matching the filters, across all pages
+   *
+   * @generated from field: int32 matched_count = 5;
+   */
+  matchedCount: number;
+};
+
+/**
+ * Describes the message agents.v1beta1.ListAgentsResponse.
+ * Use `create(ListAgentsResponseSchema)` to create a new message.
+ */
+export const ListAgentsResponseSchema: GenMessage<ListAgentsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1beta1_agents, 1);
+
+/**
+ * @generated from message agents.v1beta1.AgentListEntry
+ */
+export type AgentListEntry = Message<"agents.v1beta1.AgentListEntry"> & {
+  /**
+   * @generated from field: agents.v1beta1.Agent agent = 1;
+   */
+  agent?: Agent;
+};
+
+/**
+ * Describes the message agents.v1beta1.AgentListEntry.
+ * Use `create(AgentListEntrySchema)` to create a new message.
+ */
+export const AgentListEntrySchema: GenMessage<AgentListEntry> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1beta1_agents, 2);
+
+/**
+ * @generated from message agents.v1beta1.GetAgentRequest
+ */
+export type GetAgentRequest = Message<"agents.v1beta1.GetAgentRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message agents.v1beta1.GetAgentRequest.
+ * Use `create(GetAgentRequestSchema)` to create a new message.
+ */
+export const GetAgentRequestSchema: GenMessage<GetAgentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1beta1_agents, 3);
+
+/**
+ * @generated from message agents.v1beta1.GetAgentResponse
+ */
+export type GetAgentResponse = Message<"agents.v1beta1.GetAgentResponse"> & {
+  /**
+   * @generated from field: agents.v1beta1.Agent agent = 1;
+   */
+  agent?: Agent;
+};
+
+/**
+ * Describes the message agents.v1beta1.GetAgentResponse.
+ * Use `create(GetAgentResponseSchema)` to create a new message.
+ */
+export const GetAgentResponseSchema: GenMessage<GetAgentResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1beta1_agents, 4);
+
+/**
+ * @generated from message agents.v1beta1.DeleteAgentRequest
+ */
+export type DeleteAgentRequest = Message<"agents.v1beta1.DeleteAgentRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * delete the agent permanently, without a grace period
+   *
+   * @generated from field: bool purge = 2;
+   */
+  purge: boolean;
+};
+
+/**
+ * Describes the message agents.v1beta1.DeleteAgentRequest.
+ * Use `create(DeleteAgentRequestSchema)` to create a new message.
+ */
+export const DeleteAgentRequestSchema: GenMessage<DeleteAgentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1beta1_agents, 5);
+
+/**
+ * @generated from message agents.v1beta1.UndeleteAgentRequest
+ */
+export type UndeleteAgentRequest = Message<"agents.v1beta1.UndeleteAgentRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message agents.v1beta1.UndeleteAgentRequest.
+ * Use `create(UndeleteAgentRequestSchema)` to create a new message.
+ */
+export const UndeleteAgentRequestSchema: GenMessage<UndeleteAgentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1beta1_agents, 6);
+
+/**
+ * @generated from message agents.v1beta1.Agent
+ */
+export type Agent = Message<"agents.v1beta1.Agent"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string friendly_name = 2;
+   */
+  friendlyName: string;
+
+  /**
+   * Attributes that identify the Agent (e.g., service.name, service.version, service.instance.id).
+   *
+   * @generated from field: repeated agents.v1beta1.KeyValue identifying_attributes = 3;
+   */
+  identifyingAttributes: KeyValue[];
+
+  /**
+   * Attributes that do not necessarily identify the Agent but help describe where it runs
+   * (e.g., os.type, os.version, host.*, cloud.*).
+   *
+   * @generated from field: repeated agents.v1beta1.KeyValue non_identifying_attributes = 4;
+   */
+  nonIdentifyingAttributes: KeyValue[];
+
+  /**
+   * @generated from field: repeated string capabilities = 5;
+   */
+  capabilities: string[];
+
+  /**
+   * when the agent was deleted, it is purged once the deletion grace period expires
+   *
+   * @generated from field: google.protobuf.Timestamp deleted_at = 6;
+   */
+  deletedAt?: Timestamp;
+};
+
+/**
+ * Describes the message agents.v1beta1.Agent.
+ * Use `create(AgentSchema)` to create a new message.
+ */
+export const AgentSchema: GenMessage<Agent> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1beta1_agents, 7);
+
+/**
+ * KeyValue represents a key-value pair with support for various value types.
+ *
+ * @generated from message agents.v1beta1.KeyValue
+ */
+export type KeyValue = Message<"agents.v1beta1.KeyValue"> & {
+  /**
+   * @generated from field: string key = 1;
+   */
+  key: string;
+
+  /**
+   * @generated from field: agents.v1beta1.AnyValue value = 2;
+   */
+  value?: AnyValue;
+};
+
+/**
+ * Describes the message agents.v1beta1.KeyValue.
+ * Use `create(KeyValueSchema)` to create a new message.
+ */
+export const KeyValueSchema: GenMessage<KeyValue> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1beta1_agents, 8);
+
+/**
+ * AnyValue represents a value that can be one of several types.
+ *
+ * @generated from message agents.v1beta1.AnyValue
+ */
+export type AnyValue = Message<"agents.v1beta1.AnyValue"> & {
+  /**
+   * @generated from oneof agents.v1beta1.AnyValue.value
+   */
+  value: {
+    /**
+     * @generated from field: string string_value = 1;
+     */
+    value: string;
+    case: "stringValue";
+  } | {
+    /**
+     * @generated from field: bool bool_value = 2;
+     */
+    value: boolean;
+    case: "boolValue";
+  } | {
+    /**
+     * @generated from field: int64 int_value = 3;
+     */
+    value: bigint;
+    case: "intValue";
+  } | {
+    /**
+     * @generated from field: double double_value = 4;
+     */
+    value: number;
+    case: "doubleValue";
+  } | {
+    /**
+     * @generated from field: bytes bytes_value = 5;
+     */
+    value: Uint8Array;
+    case: "bytesValue";
+  } | {
+    /**
+     * @generated from field: agents.v1beta1.ArrayValue array_value = 6;
+     */
+    value: ArrayValue;
+    case: "arrayValue";
+  } | {
+    /**
+     * @generated from field: agents.v1beta1.KeyValueList kvlist_value = 7;
+     */
+    value: KeyValueList;
+    case: "kvlistValue";
+  } | { case: undefined; value?: undefined };
+};
+
+/**
+ * Describes the message agents.v1beta1.AnyValue.
+ * Use `create(AnyValueSchema)` to create a new message.
+ */
+export const AnyValueSchema: GenMessage<AnyValue> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1beta1_agents, 9);
+
+/**
+ * ArrayValue holds an array of AnyValue.
+ *
+ * @generated from message agents.v1beta1.ArrayValue
+ */
+export type ArrayValue = Message<"agents.v1beta1.ArrayValue"> & {
+  /**
+   * @generated from field: repeated agents.v1beta1.AnyValue values = 1;
+   */
+  values: AnyValue[];
+};
+
+/**
+ * Describes the message agents.v1beta1.ArrayValue.
+ * Use `create(ArrayValueSchema)` to create a new message.
+ */
+export const ArrayValueSchema: GenMessage<ArrayValue> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1beta1_agents, 10);
+
+/**
+ * KeyValueList holds a list of KeyValue pairs.
+ *
+ * @generated from message agents.v1beta1.KeyValueList
+ */
+export type KeyValueList = Message<"agents.v1beta1.KeyValueList"> & {
+  /**
+   * @generated from field: repeated agents.v1beta1.KeyValue values = 1;
+   */
+  values: KeyValue[];
+};
+
+/**
+ * Describes the message agents.v1beta1.KeyValueList.
+ * Use `create(KeyValueListSchema)` to create a new message.
+ */
+export const KeyValueListSchema: GenMessage<KeyValueList> = /*@__PURE__*/
+  messageDesc(file_pkg_api_agents_v1beta1_agents, 11);
+
+/**
+ * @generated from enum agents.v1beta1.AgentSortField
+ */
+export enum AgentSortField {
+  /**
+   * by agent ID
+   *
+   * @generated from enum value: AGENT_SORT_FIELD_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: AGENT_SORT_FIELD_NAME = 1;
+   */
+  NAME = 1,
+
+  /**
+   * agents that were never seen first
+   *
+   * @generated from enum value: AGENT_SORT_FIELD_LAST_SEEN = 2;
+   */
+  LAST_SEEN = 2,
+
+  /**
+   * @generated from enum value: AGENT_SORT_FIELD_STATE = 3;
+   */
+  STATE = 3,
+}
+
+/**
+ * Describes the enum agents.v1beta1.AgentSortField.
+ */
+export const AgentSortFieldSchema: GenEnum<AgentSortField> = /*@__PURE__*/
+  enumDesc(file_pkg_api_agents_v1beta1_agents, 0);
+
+/**
+ * AgentService is the beta version of config.v1alpha1.AgentService. Its messages are
+ * wire-compatible subsets of the v1alpha1 messages, calls are served by the v1alpha1 handlers.
+ *
+ * @generated from service agents.v1beta1.AgentService
+ */
+export const AgentService: GenService<{
+  /**
+   * @generated from rpc agents.v1beta1.AgentService.ListAgents
+   */
+  listAgents: {
+    methodKind: "unary";
+    input: typeof ListAgentsRequestSchema;
+    output: typeof ListAgentsResponseSchema;
+  },
+  /**
+   * @generated from rpc agents.v1beta1.AgentService.GetAgent
+   */
+  getAgent: {
+    methodKind: "unary";
+    input: typeof GetAgentRequestSchema;
+    output: typeof GetAgentResponseSchema;
+  },
+  /**
+   * DeleteAgent hides the agent and withholds its config. It is purged once the deletion
+   * grace period expires, unless it reconnects or is undeleted in the meantime.
+   *
+   * @generated from rpc agents.v1beta1.AgentService.DeleteAgent
+   */
+  deleteAgent: {
+    methodKind: "unary";
+    input: typeof DeleteAgentRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * UndeleteAgent restores an agent deleted within the grace period
+   *
+   * @generated from rpc agents.v1beta1.AgentService.UndeleteAgent
+   */
+  undeleteAgent: {
+    methodKind: "unary";
+    input: typeof UndeleteAgentRequestSchema;
+    output: typeof EmptySchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_pkg_api_agents_v1beta1_agents, 0);
+
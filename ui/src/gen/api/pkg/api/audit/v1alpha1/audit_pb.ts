@@ -0,0 +1,259 @@
+// @generated by protoc-gen-es v2.10.2 with parameter "target=ts"
+// @generated from file pkg/api/audit/v1alpha1/audit.proto (package audit.v1alpha1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file pkg/api/audit/v1alpha1/audit.proto.
+ */
+export const file_pkg_api_audit_v1alpha1_audit: GenFile = /*@__PURE__*/
+  fileDesc("CiJwa2cvYXBpL2F1ZGl0L3YxYWxwaGExL2F1ZGl0LnByb3RvEg5hdWRpdC52MWFscGhhMSKTAgoKQXVkaXRFbnRyeRIQCghzZXF1ZW5jZRgBIAEoBBIoCgR0aW1lGAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIRCglwcmluY2lwYWwYAyABKAkSEQoJcHJvY2VkdXJlGAQgASgJEhUKDXJlc291cmNlX3R5cGUYBSABKAkSEwoLcmVzb3VyY2VfaWQYBiABKAkSEgoKcmVxdWVzdF9pZBgHIAEoCRIRCglwZWVyX2FkZHIYCCABKAkSDAoEY29kZRgJIAEoCRINCgVlcnJvchgKIAEoCRIzCg1jb25maWdfY2hhbmdlGAsgASgLMhwuYXVkaXQudjFhbHBoYTEuQ29uZmlnQ2hhbmdlImkKDENvbmZpZ0NoYW5nZRIPCgdzdWJqZWN0GAEgASgJEhAKCG9sZF9oYXNoGAIgASgJEhAKCG5ld19oYXNoGAMgASgJEgwKBGRpZmYYBCABKAkSFgoOZGlmZl90cnVuY2F0ZWQYBSABKAgijQEKC0VudHJ5RmlsdGVyEikKBXN0YXJ0GAEgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBInCgNlbmQYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhYKDnJlc291cmNlX3R5cGVzGAMgAygJEhIKCnByaW5jaXBhbHMYBCADKAkiZAoSTGlzdEVudHJpZXNSZXF1ZXN0EisKBmZpbHRlchgBIAEoCzIbLmF1ZGl0LnYxYWxwaGExLkVudHJ5RmlsdGVyEg0KBWxpbWl0GAIgASgFEhIKCnBhZ2VfdG9rZW4YAyABKAkiWwoTTGlzdEVudHJpZXNSZXNwb25zZRIrCgdlbnRyaWVzGAEgAygLMhouYXVkaXQudjFhbHBoYTEuQXVkaXRFbnRyeRIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkyZgoMQXVkaXRTZXJ2aWNlElYKC0xpc3RFbnRyaWVzEiIuYXVkaXQudjFhbHBoYTEuTGlzdEVudHJpZXNSZXF1ZXN0GiMuYXVkaXQudjFhbHBoYTEuTGlzdEVudHJpZXNSZXNwb25zZUI3WjVnaXRodWIuY29tL290ZWxmbGVldC9vdGVsZmxlZXQvcGtnL2FwaS9hdWRpdC92MWFscGhhMWIGcHJvdG8z", [file_google_protobuf_timestamp]);
+
+/**
+ * AuditEntry records a call to a management API mutation, whether it succeeded or not
+ *
+ * @generated from message audit.v1alpha1.AuditEntry
+ */
+export type AuditEntry = Message<"audit.v1alpha1.AuditEntry"> & {
+  /**
+   * monotonically increasing sequence number
+   *
+   * @generated from field: uint64 sequence = 1;
+   */
+  sequence: bigint;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp time = 2;
+   */
+  time?: Timestamp;
+
+  /**
+   * authenticated principal that made the call, empty for anonymous callers
+   *
+   * @generated from field: string principal = 3;
+   */
+  principal: string;
+
+  /**
+   * full name of the procedure called, e.g. /config.v1alpha1.ConfigService/PutConfig
+   *
+   * @generated from field: string procedure = 4;
+   */
+  procedure: string;
+
+  /**
+   * snake cased type of the resource the procedure changes, e.g. "config" or "agent"
+   *
+   * @generated from field: string resource_type = 5;
+   */
+  resourceType: string;
+
+  /**
+   * ID of the resource, if the request names one
+   *
+   * @generated from field: string resource_id = 6;
+   */
+  resourceId: string;
+
+  /**
+   * ID of the API request, see the X-Request-ID header
+   *
+   * @generated from field: string request_id = 7;
+   */
+  requestId: string;
+
+  /**
+   * address of the caller
+   *
+   * @generated from field: string peer_addr = 8;
+   */
+  peerAddr: string;
+
+  /**
+   * connect code of the failure, e.g. "permission_denied", empty if the call succeeded
+   *
+   * @generated from field: string code = 9;
+   */
+  code: string;
+
+  /**
+   * error message of the failure
+   *
+   * @generated from field: string error = 10;
+   */
+  error: string;
+
+  /**
+   * config changed by config-affecting operations, such as PutConfig or AssignConfig
+   *
+   * @generated from field: audit.v1alpha1.ConfigChange config_change = 11;
+   */
+  configChange?: ConfigChange;
+};
+
+/**
+ * Describes the message audit.v1alpha1.AuditEntry.
+ * Use `create(AuditEntrySchema)` to create a new message.
+ */
+export const AuditEntrySchema: GenMessage<AuditEntry> = /*@__PURE__*/
+  messageDesc(file_pkg_api_audit_v1alpha1_audit, 0);
+
+/**
+ * ConfigChange describes the change of a config made by an operation
+ *
+ * @generated from message audit.v1alpha1.ConfigChange
+ */
+export type ConfigChange = Message<"audit.v1alpha1.ConfigChange"> & {
+  /**
+   * what the config is of, e.g. "config/logs", "agent/agent-1" or "default_config"
+   *
+   * @generated from field: string subject = 1;
+   */
+  subject: string;
+
+  /**
+   * hex encoded SHA-256 of the config before and after the operation, empty if there was none
+   *
+   * @generated from field: string old_hash = 2;
+   */
+  oldHash: string;
+
+  /**
+   * @generated from field: string new_hash = 3;
+   */
+  newHash: string;
+
+  /**
+   * unified diff of the config, with secret references redacted. It is empty for rollouts,
+   * whose previous config varies per agent.
+   *
+   * @generated from field: string diff = 4;
+   */
+  diff: string;
+
+  /**
+   * the diff was truncated to keep entries compact
+   *
+   * @generated from field: bool diff_truncated = 5;
+   */
+  diffTruncated: boolean;
+};
+
+/**
+ * Describes the message audit.v1alpha1.ConfigChange.
+ * Use `create(ConfigChangeSchema)` to create a new message.
+ */
+export const ConfigChangeSchema: GenMessage<ConfigChange> = /*@__PURE__*/
+  messageDesc(file_pkg_api_audit_v1alpha1_audit, 1);
+
+/**
+ * @generated from message audit.v1alpha1.EntryFilter
+ */
+export type EntryFilter = Message<"audit.v1alpha1.EntryFilter"> & {
+  /**
+   * inclusive lower bound on the entry time
+   *
+   * @generated from field: google.protobuf.Timestamp start = 1;
+   */
+  start?: Timestamp;
+
+  /**
+   * exclusive upper bound on the entry time
+   *
+   * @generated from field: google.protobuf.Timestamp end = 2;
+   */
+  end?: Timestamp;
+
+  /**
+   * @generated from field: repeated string resource_types = 3;
+   */
+  resourceTypes: string[];
+
+  /**
+   * @generated from field: repeated string principals = 4;
+   */
+  principals: string[];
+};
+
+/**
+ * Describes the message audit.v1alpha1.EntryFilter.
+ * Use `create(EntryFilterSchema)` to create a new message.
+ */
+export const EntryFilterSchema: GenMessage<EntryFilter> = /*@__PURE__*/
+  messageDesc(file_pkg_api_audit_v1alpha1_audit, 2);
+
+/**
+ * @generated from message audit.v1alpha1.ListEntriesRequest
+ */
+export type ListEntriesRequest = Message<"audit.v1alpha1.ListEntriesRequest"> & {
+  /**
+   * @generated from field: audit.v1alpha1.EntryFilter filter = 1;
+   */
+  filter?: EntryFilter;
+
+  /**
+   * maximum number of entries to return, 0 for the server default
+   *
+   * @generated from field: int32 limit = 2;
+   */
+  limit: number;
+
+  /**
+   * next_page_token from a previous response
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken: string;
+};
+
+/**
+ * Describes the message audit.v1alpha1.ListEntriesRequest.
+ * Use `create(ListEntriesRequestSchema)` to create a new message.
+ */
+export const ListEntriesRequestSchema: GenMessage<ListEntriesRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_audit_v1alpha1_audit, 3);
+
+/**
+ * @generated from message audit.v1alpha1.ListEntriesResponse
+ */
+export type ListEntriesResponse = Message<"audit.v1alpha1.ListEntriesResponse"> & {
+  /**
+   * @generated from field: repeated audit.v1alpha1.AuditEntry entries = 1;
+   */
+  entries: AuditEntry[];
+
+  /**
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message audit.v1alpha1.ListEntriesResponse.
+ * Use `create(ListEntriesResponseSchema)` to create a new message.
+ */
+export const ListEntriesResponseSchema: GenMessage<ListEntriesResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_audit_v1alpha1_audit, 4);
+
+/**
+ * @generated from service audit.v1alpha1.AuditService
+ */
+export const AuditService: GenService<{
+  /**
+   * ListEntries returns the audit entries matching the filter, oldest first
+   *
+   * @generated from rpc audit.v1alpha1.AuditService.ListEntries
+   */
+  listEntries: {
+    methodKind: "unary";
+    input: typeof ListEntriesRequestSchema;
+    output: typeof ListEntriesResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_pkg_api_audit_v1alpha1_audit, 0);
+
@@ -2,11 +2,11 @@
 // @generated from file pkg/api/bootstrap/v1alpha1/bootstrap.proto (package bootstrap.v1alpha1, syntax proto3)
 /* eslint-disable */
 
-import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
-import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
 import type { Duration, EmptySchema, Timestamp } from "@bufbuild/protobuf/wkt";
 import { file_google_protobuf_duration, file_google_protobuf_empty, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
-import type { Config } from "../../config/v1alpha1/config_pb";
+import type { AuditFilter, AuditInfo, Config } from "../../config/v1alpha1/config_pb";
 import { file_pkg_api_config_v1alpha1_config } from "../../config/v1alpha1/config_pb";
 import type { Message } from "@bufbuild/protobuf";
 
@@ -14,7 +14,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file pkg/api/bootstrap/v1alpha1/bootstrap.proto.
  */
 export const file_pkg_api_bootstrap_v1alpha1_bootstrap: GenFile = /*@__PURE__*/
-  fileDesc("Cipwa2cvYXBpL2Jvb3RzdHJhcC92MWFscGhhMS9ib290c3RyYXAucHJvdG8SEmJvb3RzdHJhcC52MWFscGhhMSIjChBHZXRDb25maWdSZXF1ZXN0Eg8KB3Rva2VuSUQYASABKAkiPAoRR2V0Q29uZmlnUmVzcG9uc2USJwoGY29uZmlnGAEgASgLMhcuY29uZmlnLnYxYWxwaGExLkNvbmZpZyJMChRCb290c3RyYXBBdXRoUmVxdWVzdBIQCghjbGllbnRJZBgBIAEoCRIMCgRuYW1lGAIgASgJEhQKDGNsaWVudFB1YktleRgDIAEoDCItChVCb290c3RyYXBBdXRoUmVzcG9uc2USFAoMc2VydmVyUHViS2V5GAEgASgMIrECCg5Cb290c3RyYXBUb2tlbhIKCgJJRBgBIAEoCRIOCgZTZWNyZXQYAiABKAkSJgoDVFRMGAMgASgLMhkuZ29vZ2xlLnByb3RvYnVmLkR1cmF0aW9uEi8KBkV4cGlyeRgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIAIgBARIcCg9jb25maWdSZWZlcmVuY2UYBSABKAlIAYgBARI+CgZsYWJlbHMYBiADKAsyLi5ib290c3RyYXAudjFhbHBoYTEuQm9vdHN0cmFwVG9rZW4uTGFiZWxzRW50cnkaLQoLTGFiZWxzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4AUIJCgdfRXhwaXJ5QhIKEF9jb25maWdSZWZlcmVuY2UiRgoQTGlzdFRva2VuUmVwb25zZRIyCgZ0b2tlbnMYASADKAsyIi5ib290c3RyYXAudjFhbHBoYTEuQm9vdHN0cmFwVG9rZW4i4QEKEkNyZWF0ZVRva2VuUmVxdWVzdBImCgNUVEwYASABKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24SHAoPY29uZmlnUmVmZXJlbmNlGAIgASgJSACIAQESQgoGbGFiZWxzGAMgAygLMjIuYm9vdHN0cmFwLnYxYWxwaGExLkNyZWF0ZVRva2VuUmVxdWVzdC5MYWJlbHNFbnRyeRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBQhIKEF9jb25maWdSZWZlcmVuY2UiIAoSRGVsZXRlVG9rZW5SZXF1ZXN0EgoKAklEGAEgASgJIpEBChFTaWduYXR1cmVSZXNwb25zZRJJCgpzaWduYXR1cmVzGAEgAygLMjUuYm9vdHN0cmFwLnYxYWxwaGExLlNpZ25hdHVyZVJlc3BvbnNlLlNpZ25hdHVyZXNFbnRyeRoxCg9TaWduYXR1cmVzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgMOgI4ASJCChBCb290c3RyYXBSZXF1ZXN0EgoKAklEGAEgASgJEgwKBG5hbWUYAiABKAkSFAoMY2xpZW50UHViS2V5GAMgASgMMrQDCgxUb2tlblNlcnZpY2USWQoLQ3JlYXRlVG9rZW4SJi5ib290c3RyYXAudjFhbHBoYTEuQ3JlYXRlVG9rZW5SZXF1ZXN0GiIuYm9vdHN0cmFwLnYxYWxwaGExLkJvb3RzdHJhcFRva2VuEkoKCkxpc3RUb2tlbnMSFi5nb29nbGUucHJvdG9idWYuRW1wdHkaJC5ib290c3RyYXAudjFhbHBoYTEuTGlzdFRva2VuUmVwb25zZRJNCgtEZWxldGVUb2tlbhImLmJvb3RzdHJhcC52MWFscGhhMS5EZWxldGVUb2tlblJlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkSSwoKU2lnbmF0dXJlcxIWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRolLmJvb3RzdHJhcC52MWFscGhhMS5TaWduYXR1cmVSZXNwb25zZRJhChJHZXRCb290c3RyYXBDb25maWcSJC5ib290c3RyYXAudjFhbHBoYTEuR2V0Q29uZmlnUmVxdWVzdBolLmJvb3RzdHJhcC52MWFscGhhMS5HZXRDb25maWdSZXNwb25zZTJ0ChBCb290c3RyYXBTZXJ2aWNlEmAKCUJvb3RzdHJhcBIoLmJvb3RzdHJhcC52MWFscGhhMS5Cb290c3RyYXBBdXRoUmVxdWVzdBopLmJvb3RzdHJhcC52MWFscGhhMS5Cb290c3RyYXBBdXRoUmVzcG9uc2VCRFpCZ2l0aHViLmNvbS9vdGVsZmxlZXQvb3RlbGZsZWV0L3BrZy9hcGkvYm9vdHN0cmFwL3YxYWxwaGExO3YxYWxwaGExYgZwcm90bzM", [file_google_protobuf_duration, file_google_protobuf_empty, file_google_protobuf_timestamp, file_pkg_api_config_v1alpha1_config]);
+  fileDesc("Cipwa2cvYXBpL2Jvb3RzdHJhcC92MWFscGhhMS9ib290c3RyYXAucHJvdG8SEmJvb3RzdHJhcC52MWFscGhhMSIjChBHZXRDb25maWdSZXF1ZXN0Eg8KB3Rva2VuSUQYASABKAkiPAoRR2V0Q29uZmlnUmVzcG9uc2USJwoGY29uZmlnGAEgASgLMhcuY29uZmlnLnYxYWxwaGExLkNvbmZpZyITChFDaGVja1Rva2VuUmVxdWVzdCL0AgoSQ2hlY2tUb2tlblJlc3BvbnNlEg0KBXZhbGlkGAEgASgIEg4KBnJlYXNvbhgCIAEoCRIPCgd0b2tlbklEGAMgASgJEhoKDXJlbWFpbmluZ1VzZXMYBCABKAVIAIgBARIqCgZleHBpcnkYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi8KDHJlbWFpbmluZ1RUTBgGIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhIcCg9jb25maWdSZWZlcmVuY2UYByABKAlIAYgBARJCCgZsYWJlbHMYCCADKAsyMi5ib290c3RyYXAudjFhbHBoYTEuQ2hlY2tUb2tlblJlc3BvbnNlLkxhYmVsc0VudHJ5Gi0KC0xhYmVsc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAFCEAoOX3JlbWFpbmluZ1VzZXNCEgoQX2NvbmZpZ1JlZmVyZW5jZSJoChRCb290c3RyYXBBdXRoUmVxdWVzdBIQCghjbGllbnRJZBgBIAEoCRIMCgRuYW1lGAIgASgJEhQKDGNsaWVudFB1YktleRgDIAEoDBIaChJjZXJ0aWZpY2F0ZVJlcXVlc3QYBCABKAwiQgoVQm9vdHN0cmFwQXV0aFJlc3BvbnNlEhQKDHNlcnZlclB1YktleRgBIAEoDBITCgtjcmVkZW50aWFscxgCIAEoDCJCChRCb290c3RyYXBDcmVkZW50aWFscxITCgtjZXJ0aWZpY2F0ZRgBIAEoDBIVCg1jYUNlcnRpZmljYXRlGAIgASgMIjMKDUVucm9sbFJlcXVlc3QSDAoEbmFtZRgBIAEoCRIUCgxjbGllbnRQdWJLZXkYAiABKAwiSQoORW5yb2xsUmVzcG9uc2USDwoHYWdlbnRJZBgBIAEoCRIQCghzcGlmZmVJZBgCIAEoCRIUCgxzZXJ2ZXJQdWJLZXkYAyABKAwi/wIKDkJvb3RzdHJhcFRva2VuEgoKAklEGAEgASgJEg4KBlNlY3JldBgCIAEoCRImCgNUVEwYAyABKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24SLwoGRXhwaXJ5GAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcEgAiAEBEhwKD2NvbmZpZ1JlZmVyZW5jZRgFIAEoCUgBiAEBEj4KBmxhYmVscxgGIAMoCzIuLmJvb3RzdHJhcC52MWFscGhhMS5Cb290c3RyYXBUb2tlbi5MYWJlbHNFbnRyeRIpCgVhdWRpdBgHIAEoCzIaLmNvbmZpZy52MWFscGhhMS5BdWRpdEluZm8SEQoJc2luZ2xlVXNlGAggASgIEg4KBnRlbmFudBgJIAEoCRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBQgkKB19FeHBpcnlCEgoQX2NvbmZpZ1JlZmVyZW5jZSJBChFMaXN0VG9rZW5zUmVxdWVzdBIsCgZmaWx0ZXIYASABKAsyHC5jb25maWcudjFhbHBoYTEuQXVkaXRGaWx0ZXIiRgoQTGlzdFRva2VuUmVwb25zZRIyCgZ0b2tlbnMYASADKAsyIi5ib290c3RyYXAudjFhbHBoYTEuQm9vdHN0cmFwVG9rZW4i4QEKEkNyZWF0ZVRva2VuUmVxdWVzdBImCgNUVEwYASABKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24SHAoPY29uZmlnUmVmZXJlbmNlGAIgASgJSACIAQESQgoGbGFiZWxzGAMgAygLMjIuYm9vdHN0cmFwLnYxYWxwaGExLkNyZWF0ZVRva2VuUmVxdWVzdC5MYWJlbHNFbnRyeRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBQhIKEF9jb25maWdSZWZlcmVuY2UiggIKGkNyZWF0ZUVucm9sbG1lbnRVUkxSZXF1ZXN0EiYKA1RUTBgBIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhIcCg9jb25maWdSZWZlcmVuY2UYAiABKAlIAIgBARJKCgZsYWJlbHMYAyADKAsyOi5ib290c3RyYXAudjFhbHBoYTEuQ3JlYXRlRW5yb2xsbWVudFVSTFJlcXVlc3QuTGFiZWxzRW50cnkSDwoHYmFzZVVSTBgEIAEoCRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBQhIKEF9jb25maWdSZWZlcmVuY2UiWQoNRW5yb2xsbWVudFVSTBILCgN1cmwYASABKAkSDwoHdG9rZW5JRBgCIAEoCRIqCgZleHBpcnkYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIo0CChxHZW5lcmF0ZUluc3RhbGxTY3JpcHRSZXF1ZXN0Eg8KB3Rva2VuSUQYASABKAkSNQoIcGxhdGZvcm0YAiABKA4yIy5ib290c3RyYXAudjFhbHBoYTEuSW5zdGFsbFBsYXRmb3JtEg8KB2Jhc2VVUkwYAyABKAkSEQoJYWdlbnROYW1lGAQgASgJElAKCHNldHRpbmdzGAUgAygLMj4uYm9vdHN0cmFwLnYxYWxwaGExLkdlbmVyYXRlSW5zdGFsbFNjcmlwdFJlcXVlc3QuU2V0dGluZ3NFbnRyeRovCg1TZXR0aW5nc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEiXQoNSW5zdGFsbFNjcmlwdBIOCgZzY3JpcHQYASABKAkSEAoIZmlsZW5hbWUYAiABKAkSKgoGZXhwaXJ5GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCJYChJEZWxldGVUb2tlblJlcXVlc3QSCgoCSUQYASABKAkSDQoFZm9yY2UYAiABKAgSJwoEd2FpdBgDIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbiKRAQoRU2lnbmF0dXJlUmVzcG9uc2USSQoKc2lnbmF0dXJlcxgBIAMoCzI1LmJvb3RzdHJhcC52MWFscGhhMS5TaWduYXR1cmVSZXNwb25zZS5TaWduYXR1cmVzRW50cnkaMQoPU2lnbmF0dXJlc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoDDoCOAEiQgoQQm9vdHN0cmFwUmVxdWVzdBIKCgJJRBgBIAEoCRIMCgRuYW1lGAIgASgJEhQKDGNsaWVudFB1YktleRgDIAEoDCqRAQoPSW5zdGFsbFBsYXRmb3JtEiAKHElOU1RBTExfUExBVEZPUk1fVU5TUEVDSUZJRUQQABIaChZJTlNUQUxMX1BMQVRGT1JNX1NIRUxMEAESHwobSU5TVEFMTF9QTEFURk9STV9DTE9VRF9JTklUEAISHwobSU5TVEFMTF9QTEFURk9STV9QT1dFUlNIRUxMEAMymwUKDFRva2VuU2VydmljZRJZCgtDcmVhdGVUb2tlbhImLmJvb3RzdHJhcC52MWFscGhhMS5DcmVhdGVUb2tlblJlcXVlc3QaIi5ib290c3RyYXAudjFhbHBoYTEuQm9vdHN0cmFwVG9rZW4SWQoKTGlzdFRva2VucxIlLmJvb3RzdHJhcC52MWFscGhhMS5MaXN0VG9rZW5zUmVxdWVzdBokLmJvb3RzdHJhcC52MWFscGhhMS5MaXN0VG9rZW5SZXBvbnNlEk0KC0RlbGV0ZVRva2VuEiYuYm9vdHN0cmFwLnYxYWxwaGExLkRlbGV0ZVRva2VuUmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRJLCgpTaWduYXR1cmVzEhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5GiUuYm9vdHN0cmFwLnYxYWxwaGExLlNpZ25hdHVyZVJlc3BvbnNlEmgKE0NyZWF0ZUVucm9sbG1lbnRVUkwSLi5ib290c3RyYXAudjFhbHBoYTEuQ3JlYXRlRW5yb2xsbWVudFVSTFJlcXVlc3QaIS5ib290c3RyYXAudjFhbHBoYTEuRW5yb2xsbWVudFVSTBJsChVHZW5lcmF0ZUluc3RhbGxTY3JpcHQSMC5ib290c3RyYXAudjFhbHBoYTEuR2VuZXJhdGVJbnN0YWxsU2NyaXB0UmVxdWVzdBohLmJvb3RzdHJhcC52MWFscGhhMS5JbnN0YWxsU2NyaXB0EmEKEkdldEJvb3RzdHJhcENvbmZpZxIkLmJvb3RzdHJhcC52MWFscGhhMS5HZXRDb25maWdSZXF1ZXN0GiUuYm9vdHN0cmFwLnYxYWxwaGExLkdldENvbmZpZ1Jlc3BvbnNlMqICChBCb290c3RyYXBTZXJ2aWNlEmAKCUJvb3RzdHJhcBIoLmJvb3RzdHJhcC52MWFscGhhMS5Cb290c3RyYXBBdXRoUmVxdWVzdBopLmJvb3RzdHJhcC52MWFscGhhMS5Cb290c3RyYXBBdXRoUmVzcG9uc2USTwoGRW5yb2xsEiEuYm9vdHN0cmFwLnYxYWxwaGExLkVucm9sbFJlcXVlc3QaIi5ib290c3RyYXAudjFhbHBoYTEuRW5yb2xsUmVzcG9uc2USWwoKQ2hlY2tUb2tlbhIlLmJvb3RzdHJhcC52MWFscGhhMS5DaGVja1Rva2VuUmVxdWVzdBomLmJvb3RzdHJhcC52MWFscGhhMS5DaGVja1Rva2VuUmVzcG9uc2VCRFpCZ2l0aHViLmNvbS9vdGVsZmxlZXQvb3RlbGZsZWV0L3BrZy9hcGkvYm9vdHN0cmFwL3YxYWxwaGExO3YxYWxwaGExYgZwcm90bzM", [file_google_protobuf_duration, file_google_protobuf_empty, file_google_protobuf_timestamp, file_pkg_api_config_v1alpha1_config]);
 
 /**
  * @generated from message bootstrap.v1alpha1.GetConfigRequest
@@ -50,6 +50,77 @@ export type GetConfigResponse = Message<"bootstrap.v1alpha1.GetConfigResponse">
 export const GetConfigResponseSchema: GenMessage<GetConfigResponse> = /*@__PURE__*/
   messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 1);
 
+/**
+ * @generated from message bootstrap.v1alpha1.CheckTokenRequest
+ */
+export type CheckTokenRequest = Message<"bootstrap.v1alpha1.CheckTokenRequest"> & {
+};
+
+/**
+ * Describes the message bootstrap.v1alpha1.CheckTokenRequest.
+ * Use `create(CheckTokenRequestSchema)` to create a new message.
+ */
+export const CheckTokenRequestSchema: GenMessage<CheckTokenRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 2);
+
+/**
+ * @generated from message bootstrap.v1alpha1.CheckTokenResponse
+ */
+export type CheckTokenResponse = Message<"bootstrap.v1alpha1.CheckTokenResponse"> & {
+  /**
+   * @generated from field: bool valid = 1;
+   */
+  valid: boolean;
+
+  /**
+   * why agents can't bootstrap with the token, for invalid tokens
+   *
+   * @generated from field: string reason = 2;
+   */
+  reason: string;
+
+  /**
+   * the remaining fields are only set for valid tokens
+   *
+   * @generated from field: string tokenID = 3;
+   */
+  tokenID: string;
+
+  /**
+   * bootstraps left before the token is consumed, unset for tokens usable until they expire
+   *
+   * @generated from field: optional int32 remainingUses = 4;
+   */
+  remainingUses?: number;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expiry = 5;
+   */
+  expiry?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Duration remainingTTL = 6;
+   */
+  remainingTTL?: Duration;
+
+  /**
+   * @generated from field: optional string configReference = 7;
+   */
+  configReference?: string;
+
+  /**
+   * @generated from field: map<string, string> labels = 8;
+   */
+  labels: { [key: string]: string };
+};
+
+/**
+ * Describes the message bootstrap.v1alpha1.CheckTokenResponse.
+ * Use `create(CheckTokenResponseSchema)` to create a new message.
+ */
+export const CheckTokenResponseSchema: GenMessage<CheckTokenResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 3);
+
 /**
  * @generated from message bootstrap.v1alpha1.BootstrapAuthRequest
  */
@@ -68,6 +139,14 @@ export type BootstrapAuthRequest = Message<"bootstrap.v1alpha1.BootstrapAuthRequ
    * @generated from field: bytes clientPubKey = 3;
    */
   clientPubKey: Uint8Array;
+
+  /**
+   * optional DER encoded certificate request of the key the agent authenticates its
+   * OpAMP connection with, signed by the agent CA if the server has one
+   *
+   * @generated from field: bytes certificateRequest = 4;
+   */
+  certificateRequest: Uint8Array;
 };
 
 /**
@@ -75,7 +154,7 @@ export type BootstrapAuthRequest = Message<"bootstrap.v1alpha1.BootstrapAuthRequ
  * Use `create(BootstrapAuthRequestSchema)` to create a new message.
  */
 export const BootstrapAuthRequestSchema: GenMessage<BootstrapAuthRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 2);
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 4);
 
 /**
  * @generated from message bootstrap.v1alpha1.BootstrapAuthResponse
@@ -85,6 +164,14 @@ export type BootstrapAuthResponse = Message<"bootstrap.v1alpha1.BootstrapAuthRes
    * @generated from field: bytes serverPubKey = 1;
    */
   serverPubKey: Uint8Array;
+
+  /**
+   * BootstrapCredentials sealed with the shared secret of the key agreement, set if a
+   * client certificate was issued
+   *
+   * @generated from field: bytes credentials = 2;
+   */
+  credentials: Uint8Array;
 };
 
 /**
@@ -92,7 +179,86 @@ export type BootstrapAuthResponse = Message<"bootstrap.v1alpha1.BootstrapAuthRes
  * Use `create(BootstrapAuthResponseSchema)` to create a new message.
  */
 export const BootstrapAuthResponseSchema: GenMessage<BootstrapAuthResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 3);
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 5);
+
+/**
+ * @generated from message bootstrap.v1alpha1.BootstrapCredentials
+ */
+export type BootstrapCredentials = Message<"bootstrap.v1alpha1.BootstrapCredentials"> & {
+  /**
+   * DER encoded client certificate issued to the agent
+   *
+   * @generated from field: bytes certificate = 1;
+   */
+  certificate: Uint8Array;
+
+  /**
+   * DER encoded certificate of the agent CA
+   *
+   * @generated from field: bytes caCertificate = 2;
+   */
+  caCertificate: Uint8Array;
+};
+
+/**
+ * Describes the message bootstrap.v1alpha1.BootstrapCredentials.
+ * Use `create(BootstrapCredentialsSchema)` to create a new message.
+ */
+export const BootstrapCredentialsSchema: GenMessage<BootstrapCredentials> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 6);
+
+/**
+ * @generated from message bootstrap.v1alpha1.EnrollRequest
+ */
+export type EnrollRequest = Message<"bootstrap.v1alpha1.EnrollRequest"> & {
+  /**
+   * optional friendly name, defaults to the SPIFFE ID of the agent
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: bytes clientPubKey = 2;
+   */
+  clientPubKey: Uint8Array;
+};
+
+/**
+ * Describes the message bootstrap.v1alpha1.EnrollRequest.
+ * Use `create(EnrollRequestSchema)` to create a new message.
+ */
+export const EnrollRequestSchema: GenMessage<EnrollRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 7);
+
+/**
+ * @generated from message bootstrap.v1alpha1.EnrollResponse
+ */
+export type EnrollResponse = Message<"bootstrap.v1alpha1.EnrollResponse"> & {
+  /**
+   * agent ID derived from the SPIFFE ID of the presented SVID
+   *
+   * @generated from field: string agentId = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string spiffeId = 2;
+   */
+  spiffeId: string;
+
+  /**
+   * @generated from field: bytes serverPubKey = 3;
+   */
+  serverPubKey: Uint8Array;
+};
+
+/**
+ * Describes the message bootstrap.v1alpha1.EnrollResponse.
+ * Use `create(EnrollResponseSchema)` to create a new message.
+ */
+export const EnrollResponseSchema: GenMessage<EnrollResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 8);
 
 /**
  * @generated from message bootstrap.v1alpha1.BootstrapToken
@@ -129,6 +295,25 @@ export type BootstrapToken = Message<"bootstrap.v1alpha1.BootstrapToken"> & {
    * @generated from field: map<string, string> labels = 6;
    */
   labels: { [key: string]: string };
+
+  /**
+   * @generated from field: config.v1alpha1.AuditInfo audit = 7;
+   */
+  audit?: AuditInfo;
+
+  /**
+   * single-use tokens are deleted once an agent bootstrapped with them
+   *
+   * @generated from field: bool singleUse = 8;
+   */
+  singleUse: boolean;
+
+  /**
+   * tenant the token was created in, agents bootstrapped with it join it
+   *
+   * @generated from field: string tenant = 9;
+   */
+  tenant: string;
 };
 
 /**
@@ -136,7 +321,24 @@ export type BootstrapToken = Message<"bootstrap.v1alpha1.BootstrapToken"> & {
  * Use `create(BootstrapTokenSchema)` to create a new message.
  */
 export const BootstrapTokenSchema: GenMessage<BootstrapToken> = /*@__PURE__*/
-  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 4);
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 9);
+
+/**
+ * @generated from message bootstrap.v1alpha1.ListTokensRequest
+ */
+export type ListTokensRequest = Message<"bootstrap.v1alpha1.ListTokensRequest"> & {
+  /**
+   * @generated from field: config.v1alpha1.AuditFilter filter = 1;
+   */
+  filter?: AuditFilter;
+};
+
+/**
+ * Describes the message bootstrap.v1alpha1.ListTokensRequest.
+ * Use `create(ListTokensRequestSchema)` to create a new message.
+ */
+export const ListTokensRequestSchema: GenMessage<ListTokensRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 10);
 
 /**
  * @generated from message bootstrap.v1alpha1.ListTokenReponse
@@ -153,7 +355,7 @@ export type ListTokenReponse = Message<"bootstrap.v1alpha1.ListTokenReponse"> &
  * Use `create(ListTokenReponseSchema)` to create a new message.
  */
 export const ListTokenReponseSchema: GenMessage<ListTokenReponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 5);
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 11);
 
 /**
  * @generated from message bootstrap.v1alpha1.CreateTokenRequest
@@ -180,7 +382,144 @@ export type CreateTokenRequest = Message<"bootstrap.v1alpha1.CreateTokenRequest"
  * Use `create(CreateTokenRequestSchema)` to create a new message.
  */
 export const CreateTokenRequestSchema: GenMessage<CreateTokenRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 6);
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 12);
+
+/**
+ * @generated from message bootstrap.v1alpha1.CreateEnrollmentURLRequest
+ */
+export type CreateEnrollmentURLRequest = Message<"bootstrap.v1alpha1.CreateEnrollmentURLRequest"> & {
+  /**
+   * how long the URL is valid, defaults to an hour
+   *
+   * @generated from field: google.protobuf.Duration TTL = 1;
+   */
+  TTL?: Duration;
+
+  /**
+   * @generated from field: optional string configReference = 2;
+   */
+  configReference?: string;
+
+  /**
+   * @generated from field: map<string, string> labels = 3;
+   */
+  labels: { [key: string]: string };
+
+  /**
+   * base URL of the server agents bootstrap against, defaults to the server's external URL
+   *
+   * @generated from field: string baseURL = 4;
+   */
+  baseURL: string;
+};
+
+/**
+ * Describes the message bootstrap.v1alpha1.CreateEnrollmentURLRequest.
+ * Use `create(CreateEnrollmentURLRequestSchema)` to create a new message.
+ */
+export const CreateEnrollmentURLRequestSchema: GenMessage<CreateEnrollmentURLRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 13);
+
+/**
+ * @generated from message bootstrap.v1alpha1.EnrollmentURL
+ */
+export type EnrollmentURL = Message<"bootstrap.v1alpha1.EnrollmentURL"> & {
+  /**
+   * @generated from field: string url = 1;
+   */
+  url: string;
+
+  /**
+   * @generated from field: string tokenID = 2;
+   */
+  tokenID: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expiry = 3;
+   */
+  expiry?: Timestamp;
+};
+
+/**
+ * Describes the message bootstrap.v1alpha1.EnrollmentURL.
+ * Use `create(EnrollmentURLSchema)` to create a new message.
+ */
+export const EnrollmentURLSchema: GenMessage<EnrollmentURL> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 14);
+
+/**
+ * @generated from message bootstrap.v1alpha1.GenerateInstallScriptRequest
+ */
+export type GenerateInstallScriptRequest = Message<"bootstrap.v1alpha1.GenerateInstallScriptRequest"> & {
+  /**
+   * @generated from field: string tokenID = 1;
+   */
+  tokenID: string;
+
+  /**
+   * @generated from field: bootstrap.v1alpha1.InstallPlatform platform = 2;
+   */
+  platform: InstallPlatform;
+
+  /**
+   * base URL of the server agents bootstrap against, defaults to the server's external URL
+   *
+   * @generated from field: string baseURL = 3;
+   */
+  baseURL: string;
+
+  /**
+   * friendly name of the agent, defaults to the host name
+   *
+   * @generated from field: string agentName = 4;
+   */
+  agentName: string;
+
+  /**
+   * additional agent settings, e.g. WATCHDOG_THRESHOLD or HEALTHZ_ADDR
+   *
+   * @generated from field: map<string, string> settings = 5;
+   */
+  settings: { [key: string]: string };
+};
+
+/**
+ * Describes the message bootstrap.v1alpha1.GenerateInstallScriptRequest.
+ * Use `create(GenerateInstallScriptRequestSchema)` to create a new message.
+ */
+export const GenerateInstallScriptRequestSchema: GenMessage<GenerateInstallScriptRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 15);
+
+/**
+ * @generated from message bootstrap.v1alpha1.InstallScript
+ */
+export type InstallScript = Message<"bootstrap.v1alpha1.InstallScript"> & {
+  /**
+   * @generated from field: string script = 1;
+   */
+  script: string;
+
+  /**
+   * suggested file name of the script, e.g. install-agent.sh
+   *
+   * @generated from field: string filename = 2;
+   */
+  filename: string;
+
+  /**
+   * the script stops working once the token expires
+   *
+   * @generated from field: google.protobuf.Timestamp expiry = 3;
+   */
+  expiry?: Timestamp;
+};
+
+/**
+ * Describes the message bootstrap.v1alpha1.InstallScript.
+ * Use `create(InstallScriptSchema)` to create a new message.
+ */
+export const InstallScriptSchema: GenMessage<InstallScript> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 16);
 
 /**
  * @generated from message bootstrap.v1alpha1.DeleteTokenRequest
@@ -190,6 +529,21 @@ export type DeleteTokenRequest = Message<"bootstrap.v1alpha1.DeleteTokenRequest"
    * @generated from field: string ID = 1;
    */
   ID: string;
+
+  /**
+   * delete the token even if it was recently used by bootstrap attempts
+   *
+   * @generated from field: bool force = 2;
+   */
+  force: boolean;
+
+  /**
+   * optionally wait up to this long for recent bootstrap attempts to settle
+   * before deciding whether the token can be deleted
+   *
+   * @generated from field: google.protobuf.Duration wait = 3;
+   */
+  wait?: Duration;
 };
 
 /**
@@ -197,7 +551,7 @@ export type DeleteTokenRequest = Message<"bootstrap.v1alpha1.DeleteTokenRequest"
  * Use `create(DeleteTokenRequestSchema)` to create a new message.
  */
 export const DeleteTokenRequestSchema: GenMessage<DeleteTokenRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 7);
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 17);
 
 /**
  * @generated from message bootstrap.v1alpha1.SignatureResponse
@@ -214,7 +568,7 @@ export type SignatureResponse = Message<"bootstrap.v1alpha1.SignatureResponse">
  * Use `create(SignatureResponseSchema)` to create a new message.
  */
 export const SignatureResponseSchema: GenMessage<SignatureResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 8);
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 18);
 
 /**
  * @generated from message bootstrap.v1alpha1.BootstrapRequest
@@ -241,7 +595,38 @@ export type BootstrapRequest = Message<"bootstrap.v1alpha1.BootstrapRequest"> &
  * Use `create(BootstrapRequestSchema)` to create a new message.
  */
 export const BootstrapRequestSchema: GenMessage<BootstrapRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 9);
+  messageDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 19);
+
+/**
+ * @generated from enum bootstrap.v1alpha1.InstallPlatform
+ */
+export enum InstallPlatform {
+  /**
+   * @generated from enum value: INSTALL_PLATFORM_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: INSTALL_PLATFORM_SHELL = 1;
+   */
+  SHELL = 1,
+
+  /**
+   * @generated from enum value: INSTALL_PLATFORM_CLOUD_INIT = 2;
+   */
+  CLOUD_INIT = 2,
+
+  /**
+   * @generated from enum value: INSTALL_PLATFORM_POWERSHELL = 3;
+   */
+  POWERSHELL = 3,
+}
+
+/**
+ * Describes the enum bootstrap.v1alpha1.InstallPlatform.
+ */
+export const InstallPlatformSchema: GenEnum<InstallPlatform> = /*@__PURE__*/
+  enumDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 0);
 
 /**
  * @generated from service bootstrap.v1alpha1.TokenService
@@ -260,7 +645,7 @@ export const TokenService: GenService<{
    */
   listTokens: {
     methodKind: "unary";
-    input: typeof EmptySchema;
+    input: typeof ListTokensRequestSchema;
     output: typeof ListTokenReponseSchema;
   },
   /**
@@ -279,6 +664,28 @@ export const TokenService: GenService<{
     input: typeof EmptySchema;
     output: typeof SignatureResponseSchema;
   },
+  /**
+   * CreateEnrollmentURL creates a single-use token and returns a pre-signed URL
+   * agents can bootstrap with, for provisioning tools that can't pass bearer tokens
+   *
+   * @generated from rpc bootstrap.v1alpha1.TokenService.CreateEnrollmentURL
+   */
+  createEnrollmentURL: {
+    methodKind: "unary";
+    input: typeof CreateEnrollmentURLRequestSchema;
+    output: typeof EnrollmentURLSchema;
+  },
+  /**
+   * GenerateInstallScript returns a snippet installing an agent bootstrapped with the token,
+   * embedding the server URL, the token, its labels and the agent settings
+   *
+   * @generated from rpc bootstrap.v1alpha1.TokenService.GenerateInstallScript
+   */
+  generateInstallScript: {
+    methodKind: "unary";
+    input: typeof GenerateInstallScriptRequestSchema;
+    output: typeof InstallScriptSchema;
+  },
   /**
    * @generated from rpc bootstrap.v1alpha1.TokenService.GetBootstrapConfig
    */
@@ -302,6 +709,29 @@ export const BootstrapService: GenService<{
     input: typeof BootstrapAuthRequestSchema;
     output: typeof BootstrapAuthResponseSchema;
   },
+  /**
+   * Enroll registers an agent authenticated by an X.509 SVID presented
+   * on the TLS connection, skipping token bootstrap entirely.
+   *
+   * @generated from rpc bootstrap.v1alpha1.BootstrapService.Enroll
+   */
+  enroll: {
+    methodKind: "unary";
+    input: typeof EnrollRequestSchema;
+    output: typeof EnrollResponseSchema;
+  },
+  /**
+   * CheckToken verifies the token presented in the Authorization header like Bootstrap,
+   * without consuming a use or registering an agent, so that provisioning pipelines can
+   * validate tokens before installing agents
+   *
+   * @generated from rpc bootstrap.v1alpha1.BootstrapService.CheckToken
+   */
+  checkToken: {
+    methodKind: "unary";
+    input: typeof CheckTokenRequestSchema;
+    output: typeof CheckTokenResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_pkg_api_bootstrap_v1alpha1_bootstrap, 1);
 
@@ -0,0 +1,195 @@
+// @generated by protoc-gen-es v2.10.2 with parameter "target=ts"
+// @generated from file pkg/api/bootstrap/v1beta1/bootstrap.proto (package bootstrap.v1beta1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Duration, EmptySchema, Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_duration, file_google_protobuf_empty, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { AuditInfo } from "../../config/v1beta1/config_pb";
+import { file_pkg_api_config_v1beta1_config } from "../../config/v1beta1/config_pb";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file pkg/api/bootstrap/v1beta1/bootstrap.proto.
+ */
+export const file_pkg_api_bootstrap_v1beta1_bootstrap: GenFile = /*@__PURE__*/
+  fileDesc("Cilwa2cvYXBpL2Jvb3RzdHJhcC92MWJldGExL2Jvb3RzdHJhcC5wcm90bxIRYm9vdHN0cmFwLnYxYmV0YTEi4gEKEkNyZWF0ZVRva2VuUmVxdWVzdBImCgN0dGwYASABKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24SHQoQY29uZmlnX3JlZmVyZW5jZRgCIAEoCUgAiAEBEkEKBmxhYmVscxgDIAMoCzIxLmJvb3RzdHJhcC52MWJldGExLkNyZWF0ZVRva2VuUmVxdWVzdC5MYWJlbHNFbnRyeRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBQhMKEV9jb25maWdfcmVmZXJlbmNlIvACCg5Cb290c3RyYXBUb2tlbhIKCgJpZBgBIAEoCRIOCgZzZWNyZXQYAiABKAkSJgoDdHRsGAMgASgLMhkuZ29vZ2xlLnByb3RvYnVmLkR1cmF0aW9uEi8KBmV4cGlyeRgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBIAIgBARIdChBjb25maWdfcmVmZXJlbmNlGAUgASgJSAGIAQESPQoGbGFiZWxzGAYgAygLMi0uYm9vdHN0cmFwLnYxYmV0YTEuQm9vdHN0cmFwVG9rZW4uTGFiZWxzRW50cnkSKAoFYXVkaXQYByABKAsyGS5jb25maWcudjFiZXRhMS5BdWRpdEluZm8SEgoKc2luZ2xlX3VzZRgIIAEoCBotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBQgkKB19leHBpcnlCEwoRX2NvbmZpZ19yZWZlcmVuY2UiEwoRTGlzdFRva2Vuc1JlcXVlc3QiRwoSTGlzdFRva2Vuc1Jlc3BvbnNlEjEKBnRva2VucxgBIAMoCzIhLmJvb3RzdHJhcC52MWJldGExLkJvb3RzdHJhcFRva2VuIlgKEkRlbGV0ZVRva2VuUmVxdWVzdBIKCgJpZBgBIAEoCRINCgVmb3JjZRgCIAEoCBInCgR3YWl0GAMgASgLMhkuZ29vZ2xlLnByb3RvYnVmLkR1cmF0aW9uMpACCgxUb2tlblNlcnZpY2USVwoLQ3JlYXRlVG9rZW4SJS5ib290c3RyYXAudjFiZXRhMS5DcmVhdGVUb2tlblJlcXVlc3QaIS5ib290c3RyYXAudjFiZXRhMS5Cb290c3RyYXBUb2tlbhJZCgpMaXN0VG9rZW5zEiQuYm9vdHN0cmFwLnYxYmV0YTEuTGlzdFRva2Vuc1JlcXVlc3QaJS5ib290c3RyYXAudjFiZXRhMS5MaXN0VG9rZW5zUmVzcG9uc2USTAoLRGVsZXRlVG9rZW4SJS5ib290c3RyYXAudjFiZXRhMS5EZWxldGVUb2tlblJlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHlCOlo4Z2l0aHViLmNvbS9vdGVsZmxlZXQvb3RlbGZsZWV0L3BrZy9hcGkvYm9vdHN0cmFwL3YxYmV0YTFiBnByb3RvMw", [file_google_protobuf_duration, file_google_protobuf_empty, file_google_protobuf_timestamp, file_pkg_api_config_v1beta1_config]);
+
+/**
+ * @generated from message bootstrap.v1beta1.CreateTokenRequest
+ */
+export type CreateTokenRequest = Message<"bootstrap.v1beta1.CreateTokenRequest"> & {
+  /**
+   * @generated from field: google.protobuf.Duration ttl = 1;
+   */
+  ttl?: Duration;
+
+  /**
+   * @generated from field: optional string config_reference = 2;
+   */
+  configReference?: string;
+
+  /**
+   * @generated from field: map<string, string> labels = 3;
+   */
+  labels: { [key: string]: string };
+};
+
+/**
+ * Describes the message bootstrap.v1beta1.CreateTokenRequest.
+ * Use `create(CreateTokenRequestSchema)` to create a new message.
+ */
+export const CreateTokenRequestSchema: GenMessage<CreateTokenRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1beta1_bootstrap, 0);
+
+/**
+ * @generated from message bootstrap.v1beta1.BootstrapToken
+ */
+export type BootstrapToken = Message<"bootstrap.v1beta1.BootstrapToken"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string secret = 2;
+   */
+  secret: string;
+
+  /**
+   * @generated from field: google.protobuf.Duration ttl = 3;
+   */
+  ttl?: Duration;
+
+  /**
+   * @generated from field: optional google.protobuf.Timestamp expiry = 4;
+   */
+  expiry?: Timestamp;
+
+  /**
+   * @generated from field: optional string config_reference = 5;
+   */
+  configReference?: string;
+
+  /**
+   * @generated from field: map<string, string> labels = 6;
+   */
+  labels: { [key: string]: string };
+
+  /**
+   * @generated from field: config.v1beta1.AuditInfo audit = 7;
+   */
+  audit?: AuditInfo;
+
+  /**
+   * single-use tokens are deleted once an agent bootstrapped with them
+   *
+   * @generated from field: bool single_use = 8;
+   */
+  singleUse: boolean;
+};
+
+/**
+ * Describes the message bootstrap.v1beta1.BootstrapToken.
+ * Use `create(BootstrapTokenSchema)` to create a new message.
+ */
+export const BootstrapTokenSchema: GenMessage<BootstrapToken> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1beta1_bootstrap, 1);
+
+/**
+ * @generated from message bootstrap.v1beta1.ListTokensRequest
+ */
+export type ListTokensRequest = Message<"bootstrap.v1beta1.ListTokensRequest"> & {
+};
+
+/**
+ * Describes the message bootstrap.v1beta1.ListTokensRequest.
+ * Use `create(ListTokensRequestSchema)` to create a new message.
+ */
+export const ListTokensRequestSchema: GenMessage<ListTokensRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1beta1_bootstrap, 2);
+
+/**
+ * @generated from message bootstrap.v1beta1.ListTokensResponse
+ */
+export type ListTokensResponse = Message<"bootstrap.v1beta1.ListTokensResponse"> & {
+  /**
+   * @generated from field: repeated bootstrap.v1beta1.BootstrapToken tokens = 1;
+   */
+  tokens: BootstrapToken[];
+};
+
+/**
+ * Describes the message bootstrap.v1beta1.ListTokensResponse.
+ * Use `create(ListTokensResponseSchema)` to create a new message.
+ */
+export const ListTokensResponseSchema: GenMessage<ListTokensResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1beta1_bootstrap, 3);
+
+/**
+ * @generated from message bootstrap.v1beta1.DeleteTokenRequest
+ */
+export type DeleteTokenRequest = Message<"bootstrap.v1beta1.DeleteTokenRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * delete the token even if it was recently used by bootstrap attempts
+   *
+   * @generated from field: bool force = 2;
+   */
+  force: boolean;
+
+  /**
+   * optionally wait up to this long for recent bootstrap attempts to settle
+   * before deciding whether the token can be deleted
+   *
+   * @generated from field: google.protobuf.Duration wait = 3;
+   */
+  wait?: Duration;
+};
+
+/**
+ * Describes the message bootstrap.v1beta1.DeleteTokenRequest.
+ * Use `create(DeleteTokenRequestSchema)` to create a new message.
+ */
+export const DeleteTokenRequestSchema: GenMessage<DeleteTokenRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_bootstrap_v1beta1_bootstrap, 4);
+
+/**
+ * TokenService is the beta version of bootstrap.v1alpha1.TokenService. Its messages are
+ * wire-compatible subsets of the v1alpha1 messages, calls are served by the v1alpha1 handlers.
+ *
+ * @generated from service bootstrap.v1beta1.TokenService
+ */
+export const TokenService: GenService<{
+  /**
+   * @generated from rpc bootstrap.v1beta1.TokenService.CreateToken
+   */
+  createToken: {
+    methodKind: "unary";
+    input: typeof CreateTokenRequestSchema;
+    output: typeof BootstrapTokenSchema;
+  },
+  /**
+   * @generated from rpc bootstrap.v1beta1.TokenService.ListTokens
+   */
+  listTokens: {
+    methodKind: "unary";
+    input: typeof ListTokensRequestSchema;
+    output: typeof ListTokensResponseSchema;
+  },
+  /**
+   * @generated from rpc bootstrap.v1beta1.TokenService.DeleteToken
+   */
+  deleteToken: {
+    methodKind: "unary";
+    input: typeof DeleteTokenRequestSchema;
+    output: typeof EmptySchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_pkg_api_bootstrap_v1beta1_bootstrap, 0);
+
@@ -4,15 +4,15 @@
 
 import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
 import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
-import type { EmptySchema, Timestamp } from "@bufbuild/protobuf/wkt";
-import { file_google_protobuf_empty, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Duration, EmptySchema, Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_duration, file_google_protobuf_empty, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file pkg/api/config/v1alpha1/config.proto.
  */
 export const file_pkg_api_config_v1alpha1_config: GenFile = /*@__PURE__*/
-  fileDesc("CiRwa2cvYXBpL2NvbmZpZy92MWFscGhhMS9jb25maWcucHJvdG8SD2NvbmZpZy52MWFscGhhMSJqChBQdXRDb25maWdSZXF1ZXN0Ei0KA3JlZhgBIAEoCzIgLmNvbmZpZy52MWFscGhhMS5Db25maWdSZWZlcmVuY2USJwoGY29uZmlnGAIgASgLMhcuY29uZmlnLnYxYWxwaGExLkNvbmZpZyJAChVWYWxpZGF0ZUNvbmZpZ1JlcXVlc3QSJwoGY29uZmlnGAEgASgLMhcuY29uZmlnLnYxYWxwaGExLkNvbmZpZyJGChFMaXN0Q29uZmlnUmVwb25zZRIxCgdjb25maWdzGAEgAygLMiAuY29uZmlnLnYxYWxwaGExLkNvbmZpZ1JlZmVyZW5jZSIdCg9Db25maWdSZWZlcmVuY2USCgoCaWQYASABKAkiGAoGQ29uZmlnEg4KBmNvbmZpZxgBIAEoDCI3CgtDb25maWdSYW5nZRIUCgxzdGFydFZlcnNpb24YASABKAkSEgoKZW5kVmVyc2lvbhgCIAEoCSJsCgZMYWJlbHMSMwoGbGFiZWxzGAEgAygLMiMuY29uZmlnLnYxYWxwaGExLkxhYmVscy5MYWJlbHNFbnRyeRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIgkKB01hdGNoZXIirAEKEENvbmZpZ0Fzc2lnbm1lbnQSEAoIYWdlbnRfaWQYASABKAkSEQoJY29uZmlnX2lkGAIgASgJEi0KBnNvdXJjZRgDIAEoDjIdLmNvbmZpZy52MWFscGhhMS5Db25maWdTb3VyY2USLwoLYXNzaWduZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhMKC2NvbmZpZ19oYXNoGAUgASgMIjoKE0Fzc2lnbkNvbmZpZ1JlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkSEQoJY29uZmlnX2lkGAIgASgJIjgKFEFzc2lnbkNvbmZpZ1Jlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgSDwoHbWVzc2FnZRgCIAEoCSIpChVHZXRBZ2VudENvbmZpZ1JlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkiiwEKFkdldEFnZW50Q29uZmlnUmVzcG9uc2USEQoJY29uZmlnX2lkGAEgASgJEi0KBnNvdXJjZRgCIAEoDjIdLmNvbmZpZy52MWFscGhhMS5Db25maWdTb3VyY2USLwoLYXNzaWduZWRfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIikKFVVuYXNzaWduQ29uZmlnUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCSIpChZVbmFzc2lnbkNvbmZpZ1Jlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgiRAocTGlzdENvbmZpZ0Fzc2lnbm1lbnRzUmVxdWVzdBIWCgljb25maWdfaWQYASABKAlIAIgBAUIMCgpfY29uZmlnX2lkIuwBChRDb25maWdBc3NpZ25tZW50SW5mbxIQCghhZ2VudF9pZBgBIAEoCRIRCgljb25maWdfaWQYAiABKAkSLQoGc291cmNlGAMgASgOMh0uY29uZmlnLnYxYWxwaGExLkNvbmZpZ1NvdXJjZRIvCgthc3NpZ25lZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASOAoGc3RhdHVzGAUgASgOMiguY29uZmlnLnYxYWxwaGExLkNvbmZpZ0FwcGxpY2F0aW9uU3RhdHVzEhUKDWVycm9yX21lc3NhZ2UYBiABKAkiWwodTGlzdENvbmZpZ0Fzc2lnbm1lbnRzUmVzcG9uc2USOgoLYXNzaWdubWVudHMYASADKAsyJS5jb25maWcudjFhbHBoYTEuQ29uZmlnQXNzaWdubWVudEluZm8iKgoWR2V0Q29uZmlnU3RhdHVzUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCSKiAQoXR2V0Q29uZmlnU3RhdHVzUmVzcG9uc2USOQoKYXNzaWdubWVudBgBIAEoCzIlLmNvbmZpZy52MWFscGhhMS5Db25maWdBc3NpZ25tZW50SW5mbxIdChVlZmZlY3RpdmVfY29uZmlnX2hhc2gYAiABKAwSHAoUYXNzaWduZWRfY29uZmlnX2hhc2gYAyABKAwSDwoHaW5fc3luYxgEIAEoCCJAChhCYXRjaEFzc2lnbkNvbmZpZ1JlcXVlc3QSEQoJYWdlbnRfaWRzGAEgAygJEhEKCWNvbmZpZ19pZBgCIAEoCSJxChlCYXRjaEFzc2lnbkNvbmZpZ1Jlc3BvbnNlEhIKCnN1Y2Nlc3NmdWwYASABKAUSDgoGZmFpbGVkGAIgASgFEhgKEGZhaWxlZF9hZ2VudF9pZHMYAyADKAkSFgoOZXJyb3JfbWVzc2FnZXMYBCADKAkiqQEKG0Fzc2lnbkNvbmZpZ0J5TGFiZWxzUmVxdWVzdBJICgZsYWJlbHMYASADKAsyOC5jb25maWcudjFhbHBoYTEuQXNzaWduQ29uZmlnQnlMYWJlbHNSZXF1ZXN0LkxhYmVsc0VudHJ5EhEKCWNvbmZpZ19pZBgCIAEoCRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIl0KHEFzc2lnbkNvbmZpZ0J5TGFiZWxzUmVzcG9uc2USGQoRbWF0Y2hlZF9hZ2VudF9pZHMYASADKAkSEgoKc3VjY2Vzc2Z1bBgCIAEoBRIOCgZmYWlsZWQYAyABKAUijQIKGFJvbGxpbmdEZXBsb3ltZW50UmVxdWVzdBIRCgljb25maWdfaWQYASABKAkSEQoJYWdlbnRfaWRzGAIgAygJElAKDGFnZW50X2xhYmVscxgDIAMoCzI6LmNvbmZpZy52MWFscGhhMS5Sb2xsaW5nRGVwbG95bWVudFJlcXVlc3QuQWdlbnRMYWJlbHNFbnRyeRISCgpiYXRjaF9zaXplGAQgASgFEhsKE2JhdGNoX2RlbGF5X3NlY29uZHMYBSABKAUSFAoMbWF4X2ZhaWx1cmVzGAYgASgFGjIKEEFnZW50TGFiZWxzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASIyChlSb2xsaW5nRGVwbG95bWVudFJlc3BvbnNlEhUKDWRlcGxveW1lbnRfaWQYASABKAkipgEKFUFnZW50RGVwbG95bWVudFN0YXR1cxIQCghhZ2VudF9pZBgBIAEoCRI0CgVzdGF0ZRgCIAEoDjIlLmNvbmZpZy52MWFscGhhMS5BZ2VudERlcGxveW1lbnRTdGF0ZRIVCg1lcnJvcl9tZXNzYWdlGAMgASgJEi4KCmFwcGxpZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIoUDChBEZXBsb3ltZW50U3RhdHVzEhUKDWRlcGxveW1lbnRfaWQYASABKAkSEQoJY29uZmlnX2lkGAIgASgJEi8KBXN0YXRlGAMgASgOMiAuY29uZmlnLnYxYWxwaGExLkRlcGxveW1lbnRTdGF0ZRIUCgx0b3RhbF9hZ2VudHMYBCABKAUSGAoQY29tcGxldGVkX2FnZW50cxgFIAEoBRIVCg1mYWlsZWRfYWdlbnRzGAYgASgFEhYKDnBlbmRpbmdfYWdlbnRzGAcgASgFEhUKDWN1cnJlbnRfYmF0Y2gYCCABKAUSPgoOYWdlbnRfc3RhdHVzZXMYCSADKAsyJi5jb25maWcudjFhbHBoYTEuQWdlbnREZXBsb3ltZW50U3RhdHVzEi4KCnN0YXJ0ZWRfYXQYCiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjAKDGNvbXBsZXRlZF9hdBgLIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiMwoaR2V0RGVwbG95bWVudFN0YXR1c1JlcXVlc3QSFQoNZGVwbG95bWVudF9pZBgBIAEoCSJQChtHZXREZXBsb3ltZW50U3RhdHVzUmVzcG9uc2USMQoGc3RhdHVzGAEgASgLMiEuY29uZmlnLnYxYWxwaGExLkRlcGxveW1lbnRTdGF0dXMiLwoWUGF1c2VEZXBsb3ltZW50UmVxdWVzdBIVCg1kZXBsb3ltZW50X2lkGAEgASgJIjAKF1Jlc3VtZURlcGxveW1lbnRSZXF1ZXN0EhUKDWRlcGxveW1lbnRfaWQYASABKAkiMAoXQ2FuY2VsRGVwbG95bWVudFJlcXVlc3QSFQoNZGVwbG95bWVudF9pZBgBIAEoCSI8ChhEZXBsb3ltZW50QWN0aW9uUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCBIPCgdtZXNzYWdlGAIgASgJImYKFkxpc3REZXBsb3ltZW50c1JlcXVlc3QSOwoMc3RhdGVfZmlsdGVyGAEgASgOMiAuY29uZmlnLnYxYWxwaGExLkRlcGxveW1lbnRTdGF0ZUgAiAEBQg8KDV9zdGF0ZV9maWx0ZXIiUQoXTGlzdERlcGxveW1lbnRzUmVzcG9uc2USNgoLZGVwbG95bWVudHMYASADKAsyIS5jb25maWcudjFhbHBoYTEuRGVwbG95bWVudFN0YXR1cyp/CgxDb25maWdTb3VyY2USHQoZQ09ORklHX1NPVVJDRV9VTlNQRUNJRklFRBAAEhkKFUNPTkZJR19TT1VSQ0VfREVGQVVMVBABEhsKF0NPTkZJR19TT1VSQ0VfQk9PVFNUUkFQEAISGAoUQ09ORklHX1NPVVJDRV9NQU5VQUwQAyq4AQoXQ29uZmlnQXBwbGljYXRpb25TdGF0dXMSKQolQ09ORklHX0FQUExJQ0FUSU9OX1NUQVRVU19VTlNQRUNJRklFRBAAEiUKIUNPTkZJR19BUFBMSUNBVElPTl9TVEFUVVNfUEVORElORxABEiUKIUNPTkZJR19BUFBMSUNBVElPTl9TVEFUVVNfQVBQTElFRBACEiQKIENPTkZJR19BUFBMSUNBVElPTl9TVEFUVVNfRkFJTEVEEAMq7QEKD0RlcGxveW1lbnRTdGF0ZRIgChxERVBMT1lNRU5UX1NUQVRFX1VOU1BFQ0lGSUVEEAASHAoYREVQTE9ZTUVOVF9TVEFURV9QRU5ESU5HEAESIAocREVQTE9ZTUVOVF9TVEFURV9JTl9QUk9HUkVTUxACEhsKF0RFUExPWU1FTlRfU1RBVEVfUEFVU0VEEAMSHgoaREVQTE9ZTUVOVF9TVEFURV9DT01QTEVURUQQBBIbChdERVBMT1lNRU5UX1NUQVRFX0ZBSUxFRBAFEh4KGkRFUExPWU1FTlRfU1RBVEVfQ0FOQ0VMTEVEEAYqzgEKFEFnZW50RGVwbG95bWVudFN0YXRlEiYKIkFHRU5UX0RFUExPWU1FTlRfU1RBVEVfVU5TUEVDSUZJRUQQABIiCh5BR0VOVF9ERVBMT1lNRU5UX1NUQVRFX1BFTkRJTkcQARIjCh9BR0VOVF9ERVBMT1lNRU5UX1NUQVRFX0FQUExZSU5HEAISIgoeQUdFTlRfREVQTE9ZTUVOVF9TVEFURV9BUFBMSUVEEAMSIQodQUdFTlRfREVQTE9ZTUVOVF9TVEFURV9GQUlMRUQQBDL7DgoNQ29uZmlnU2VydmljZRJNCgtWYWxpZENvbmZpZxImLmNvbmZpZy52MWFscGhhMS5WYWxpZGF0ZUNvbmZpZ1JlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkSRgoJUHV0Q29uZmlnEiEuY29uZmlnLnYxYWxwaGExLlB1dENvbmZpZ1JlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkSRgoJR2V0Q29uZmlnEiAuY29uZmlnLnYxYWxwaGExLkNvbmZpZ1JlZmVyZW5jZRoXLmNvbmZpZy52MWFscGhhMS5Db25maWcSSAoMRGVsZXRlQ29uZmlnEiAuY29uZmlnLnYxYWxwaGExLkNvbmZpZ1JlZmVyZW5jZRoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRJJCgtMaXN0Q29uZmlncxIWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRoiLmNvbmZpZy52MWFscGhhMS5MaXN0Q29uZmlnUmVwb25zZRJDChBHZXREZWZhdWx0Q29uZmlnEhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5GhcuY29uZmlnLnYxYWxwaGExLkNvbmZpZxJNChBTZXREZWZhdWx0Q29uZmlnEiEuY29uZmlnLnYxYWxwaGExLlB1dENvbmZpZ1JlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkSWwoMQXNzaWduQ29uZmlnEiQuY29uZmlnLnYxYWxwaGExLkFzc2lnbkNvbmZpZ1JlcXVlc3QaJS5jb25maWcudjFhbHBoYTEuQXNzaWduQ29uZmlnUmVzcG9uc2USYQoOR2V0QWdlbnRDb25maWcSJi5jb25maWcudjFhbHBoYTEuR2V0QWdlbnRDb25maWdSZXF1ZXN0GicuY29uZmlnLnYxYWxwaGExLkdldEFnZW50Q29uZmlnUmVzcG9uc2USYQoOVW5hc3NpZ25Db25maWcSJi5jb25maWcudjFhbHBoYTEuVW5hc3NpZ25Db25maWdSZXF1ZXN0GicuY29uZmlnLnYxYWxwaGExLlVuYXNzaWduQ29uZmlnUmVzcG9uc2USdgoVTGlzdENvbmZpZ0Fzc2lnbm1lbnRzEi0uY29uZmlnLnYxYWxwaGExLkxpc3RDb25maWdBc3NpZ25tZW50c1JlcXVlc3QaLi5jb25maWcudjFhbHBoYTEuTGlzdENvbmZpZ0Fzc2lnbm1lbnRzUmVzcG9uc2USZAoPR2V0Q29uZmlnU3RhdHVzEicuY29uZmlnLnYxYWxwaGExLkdldENvbmZpZ1N0YXR1c1JlcXVlc3QaKC5jb25maWcudjFhbHBoYTEuR2V0Q29uZmlnU3RhdHVzUmVzcG9uc2USagoRQmF0Y2hBc3NpZ25Db25maWcSKS5jb25maWcudjFhbHBoYTEuQmF0Y2hBc3NpZ25Db25maWdSZXF1ZXN0GiouY29uZmlnLnYxYWxwaGExLkJhdGNoQXNzaWduQ29uZmlnUmVzcG9uc2UScwoUQXNzaWduQ29uZmlnQnlMYWJlbHMSLC5jb25maWcudjFhbHBoYTEuQXNzaWduQ29uZmlnQnlMYWJlbHNSZXF1ZXN0Gi0uY29uZmlnLnYxYWxwaGExLkFzc2lnbkNvbmZpZ0J5TGFiZWxzUmVzcG9uc2USbwoWU3RhcnRSb2xsaW5nRGVwbG95bWVudBIpLmNvbmZpZy52MWFscGhhMS5Sb2xsaW5nRGVwbG95bWVudFJlcXVlc3QaKi5jb25maWcudjFhbHBoYTEuUm9sbGluZ0RlcGxveW1lbnRSZXNwb25zZRJwChNHZXREZXBsb3ltZW50U3RhdHVzEisuY29uZmlnLnYxYWxwaGExLkdldERlcGxveW1lbnRTdGF0dXNSZXF1ZXN0GiwuY29uZmlnLnYxYWxwaGExLkdldERlcGxveW1lbnRTdGF0dXNSZXNwb25zZRJlCg9QYXVzZURlcGxveW1lbnQSJy5jb25maWcudjFhbHBoYTEuUGF1c2VEZXBsb3ltZW50UmVxdWVzdBopLmNvbmZpZy52MWFscGhhMS5EZXBsb3ltZW50QWN0aW9uUmVzcG9uc2USZwoQUmVzdW1lRGVwbG95bWVudBIoLmNvbmZpZy52MWFscGhhMS5SZXN1bWVEZXBsb3ltZW50UmVxdWVzdBopLmNvbmZpZy52MWFscGhhMS5EZXBsb3ltZW50QWN0aW9uUmVzcG9uc2USZwoQQ2FuY2VsRGVwbG95bWVudBIoLmNvbmZpZy52MWFscGhhMS5DYW5jZWxEZXBsb3ltZW50UmVxdWVzdBopLmNvbmZpZy52MWFscGhhMS5EZXBsb3ltZW50QWN0aW9uUmVzcG9uc2USZAoPTGlzdERlcGxveW1lbnRzEicuY29uZmlnLnYxYWxwaGExLkxpc3REZXBsb3ltZW50c1JlcXVlc3QaKC5jb25maWcudjFhbHBoYTEuTGlzdERlcGxveW1lbnRzUmVzcG9uc2VCOFo2Z2l0aHViLmNvbS9vdGVsZmxlZXQvb3RlbGZsZWV0L3BrZy9hcGkvY29uZmlnL3YxYWxwaGExYgZwcm90bzM", [file_google_protobuf_empty, file_google_protobuf_timestamp]);
+  fileDesc("CiRwa2cvYXBpL2NvbmZpZy92MWFscGhhMS9jb25maWcucHJvdG8SD2NvbmZpZy52MWFscGhhMSJ8ChBQdXRDb25maWdSZXF1ZXN0Ei0KA3JlZhgBIAEoCzIgLmNvbmZpZy52MWFscGhhMS5Db25maWdSZWZlcmVuY2USJwoGY29uZmlnGAIgASgLMhcuY29uZmlnLnYxYWxwaGExLkNvbmZpZxIQCgh2YWxpZGF0ZRgDIAEoCCJAChVWYWxpZGF0ZUNvbmZpZ1JlcXVlc3QSJwoGY29uZmlnGAEgASgLMhcuY29uZmlnLnYxYWxwaGExLkNvbmZpZyJaCh1WYWxpZGF0ZUNvbmZpZ0RldGFpbGVkUmVxdWVzdBInCgZjb25maWcYASABKAsyFy5jb25maWcudjFhbHBoYTEuQ29uZmlnEhAKCGFnZW50X2lkGAIgASgJIoMBCg1Db25maWdGaW5kaW5nEjIKCHNldmVyaXR5GAEgASgOMiAuY29uZmlnLnYxYWxwaGExLkZpbmRpbmdTZXZlcml0eRIPCgdydWxlX2lkGAIgASgJEg8KB21lc3NhZ2UYAyABKAkSDAoEbGluZRgEIAEoDRIOCgZjb2x1bW4YBSABKA0iWQoWQ29uZmlnVmFsaWRhdGlvblJlc3VsdBINCgV2YWxpZBgBIAEoCBIwCghmaW5kaW5ncxgCIAMoCzIeLmNvbmZpZy52MWFscGhhMS5Db25maWdGaW5kaW5nIkIKEkxpc3RDb25maWdzUmVxdWVzdBIsCgZmaWx0ZXIYASABKAsyHC5jb25maWcudjFhbHBoYTEuQXVkaXRGaWx0ZXIi3AEKEUxpc3RDb25maWdSZXBvbnNlEjEKB2NvbmZpZ3MYASADKAsyIC5jb25maWcudjFhbHBoYTEuQ29uZmlnUmVmZXJlbmNlEkIKCG1ldGFkYXRhGAIgAygLMjAuY29uZmlnLnYxYWxwaGExLkxpc3RDb25maWdSZXBvbnNlLk1ldGFkYXRhRW50cnkaUAoNTWV0YWRhdGFFbnRyeRILCgNrZXkYASABKAkSLgoFdmFsdWUYAiABKAsyHy5jb25maWcudjFhbHBoYTEuQ29uZmlnTWV0YWRhdGE6AjgBIh0KD0NvbmZpZ1JlZmVyZW5jZRIKCgJpZBgBIAEoCSJLCgZDb25maWcSDgoGY29uZmlnGAEgASgMEjEKCG1ldGFkYXRhGAIgASgLMh8uY29uZmlnLnYxYWxwaGExLkNvbmZpZ01ldGFkYXRhIp0CCg5Db25maWdNZXRhZGF0YRINCgVvd25lchgBIAEoCRIMCgR0YWdzGAIgAygJEikKBWF1ZGl0GAMgASgLMhouY29uZmlnLnYxYWxwaGExLkF1ZGl0SW5mbxJFCgthbm5vdGF0aW9ucxgEIAMoCzIwLmNvbmZpZy52MWFscGhhMS5Db25maWdNZXRhZGF0YS5Bbm5vdGF0aW9uc0VudHJ5EjgKCXJlc291cmNlcxgFIAEoCzIlLmNvbmZpZy52MWFscGhhMS5SZXNvdXJjZVJlcXVpcmVtZW50cxIOCgZ0ZW5hbnQYBiABKAkaMgoQQW5ub3RhdGlvbnNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIkwKFFJlc291cmNlUmVxdWlyZW1lbnRzEhgKEG1pbl9tZW1vcnlfYnl0ZXMYASABKAQSGgoSZXhwZWN0ZWRfY3B1X2NvcmVzGAIgASgBIpUBCglBdWRpdEluZm8SEgoKY3JlYXRlZF9ieRgBIAEoCRIuCgpjcmVhdGVkX2F0GAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBITCgttb2RpZmllZF9ieRgDIAEoCRIvCgttb2RpZmllZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAinwEKC0F1ZGl0RmlsdGVyEhIKCmNyZWF0ZWRfYnkYASABKAkSEwoLbW9kaWZpZWRfYnkYAiABKAkSMgoObW9kaWZpZWRfYWZ0ZXIYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjMKD21vZGlmaWVkX2JlZm9yZRgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiiQEKEUNvbmZpZ0VkaXRTZXNzaW9uEgoKAmlkGAEgASgJEhEKCWNvbmZpZ19pZBgCIAEoCRIlCgRiYXNlGAMgASgLMhcuY29uZmlnLnYxYWxwaGExLkNvbmZpZxIuCgpleHBpcmVzX2F0GAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCKDAQoVU2F2ZUNvbmZpZ0VkaXRSZXF1ZXN0Ei0KA3JlZhgBIAEoCzIgLmNvbmZpZy52MWFscGhhMS5Db25maWdSZWZlcmVuY2USEgoKc2Vzc2lvbl9pZBgCIAEoCRInCgZjb25maWcYAyABKAsyFy5jb25maWcudjFhbHBoYTEuQ29uZmlnIk8KE0NvbmZpZ01lcmdlQ29uZmxpY3QSDAoEcGF0aBgBIAEoCRIMCgRiYXNlGAIgASgJEgwKBG91cnMYAyABKAkSDgoGdGhlaXJzGAQgASgJIpcBChRTYXZlQ29uZmlnRWRpdFJlc3VsdBINCgVzYXZlZBgBIAEoCBIOCgZtZXJnZWQYAiABKAgSJwoGY29uZmlnGAMgASgLMhcuY29uZmlnLnYxYWxwaGExLkNvbmZpZxI3Cgljb25mbGljdHMYBCADKAsyJC5jb25maWcudjFhbHBoYTEuQ29uZmlnTWVyZ2VDb25mbGljdCI3CgtDb25maWdSYW5nZRIUCgxzdGFydFZlcnNpb24YASABKAkSEgoKZW5kVmVyc2lvbhgCIAEoCSJsCgZMYWJlbHMSMwoGbGFiZWxzGAEgAygLMiMuY29uZmlnLnYxYWxwaGExLkxhYmVscy5MYWJlbHNFbnRyeRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIgkKB01hdGNoZXIitAIKEENvbmZpZ0Fzc2lnbm1lbnQSEAoIYWdlbnRfaWQYASABKAkSEQoJY29uZmlnX2lkGAIgASgJEi0KBnNvdXJjZRgDIAEoDjIdLmNvbmZpZy52MWFscGhhMS5Db25maWdTb3VyY2USLwoLYXNzaWduZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhMKC2NvbmZpZ19oYXNoGAUgASgMEikKBWF1ZGl0GAYgASgLMhouY29uZmlnLnYxYWxwaGExLkF1ZGl0SW5mbxIRCglwb2xpY3lfaWQYByABKAkSFQoNYXV0b19yb2xsYmFjaxgIIAEoCBIxCghyb2xsYmFjaxgJIAEoCzIfLmNvbmZpZy52MWFscGhhMS5Db25maWdSb2xsYmFjayKRAQoOQ29uZmlnUm9sbGJhY2sSGAoQZmFpbGVkX2NvbmZpZ19pZBgBIAEoCRIaChJmYWlsZWRfY29uZmlnX2hhc2gYAiABKAwSFQoNZXJyb3JfbWVzc2FnZRgDIAEoCRIyCg5yb2xsZWRfYmFja19hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAioQEKD0tub3duR29vZENvbmZpZxI1Cgphc3NpZ25tZW50GAEgASgLMiEuY29uZmlnLnYxYWxwaGExLkNvbmZpZ0Fzc2lnbm1lbnQSJwoGY29uZmlnGAIgASgLMhcuY29uZmlnLnYxYWxwaGExLkNvbmZpZxIuCgphcHBsaWVkX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCKAAQoTQXNzaWduQ29uZmlnUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCRIRCgljb25maWdfaWQYAiABKAkSLQoGc291cmNlGAMgASgOMh0uY29uZmlnLnYxYWxwaGExLkNvbmZpZ1NvdXJjZRIVCg1hdXRvX3JvbGxiYWNrGAQgASgIIkoKFEFzc2lnbkNvbmZpZ1Jlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgSDwoHbWVzc2FnZRgCIAEoCRIQCgh3YXJuaW5ncxgDIAMoCSIpChVHZXRBZ2VudENvbmZpZ1JlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkiiwEKFkdldEFnZW50Q29uZmlnUmVzcG9uc2USEQoJY29uZmlnX2lkGAEgASgJEi0KBnNvdXJjZRgCIAEoDjIdLmNvbmZpZy52MWFscGhhMS5Db25maWdTb3VyY2USLwoLYXNzaWduZWRfYXQYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIikKFVVuYXNzaWduQ29uZmlnUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCSIpChZVbmFzc2lnbkNvbmZpZ1Jlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgieAocTGlzdENvbmZpZ0Fzc2lnbm1lbnRzUmVxdWVzdBIWCgljb25maWdfaWQYASABKAlIAIgBARIyCgxhdWRpdF9maWx0ZXIYAiABKAsyHC5jb25maWcudjFhbHBoYTEuQXVkaXRGaWx0ZXJCDAoKX2NvbmZpZ19pZCLKAgoUQ29uZmlnQXNzaWdubWVudEluZm8SEAoIYWdlbnRfaWQYASABKAkSEQoJY29uZmlnX2lkGAIgASgJEi0KBnNvdXJjZRgDIAEoDjIdLmNvbmZpZy52MWFscGhhMS5Db25maWdTb3VyY2USLwoLYXNzaWduZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjgKBnN0YXR1cxgFIAEoDjIoLmNvbmZpZy52MWFscGhhMS5Db25maWdBcHBsaWNhdGlvblN0YXR1cxIVCg1lcnJvcl9tZXNzYWdlGAYgASgJEikKBWF1ZGl0GAcgASgLMhouY29uZmlnLnYxYWxwaGExLkF1ZGl0SW5mbxIxCghyb2xsYmFjaxgIIAEoCzIfLmNvbmZpZy52MWFscGhhMS5Db25maWdSb2xsYmFjayJbCh1MaXN0Q29uZmlnQXNzaWdubWVudHNSZXNwb25zZRI6Cgthc3NpZ25tZW50cxgBIAMoCzIlLmNvbmZpZy52MWFscGhhMS5Db25maWdBc3NpZ25tZW50SW5mbyIqChZHZXRDb25maWdTdGF0dXNSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJIqIBChdHZXRDb25maWdTdGF0dXNSZXNwb25zZRI5Cgphc3NpZ25tZW50GAEgASgLMiUuY29uZmlnLnYxYWxwaGExLkNvbmZpZ0Fzc2lnbm1lbnRJbmZvEh0KFWVmZmVjdGl2ZV9jb25maWdfaGFzaBgCIAEoDBIcChRhc3NpZ25lZF9jb25maWdfaGFzaBgDIAEoDBIPCgdpbl9zeW5jGAQgASgIIk8KGEJhdGNoQXNzaWduQ29uZmlnUmVxdWVzdBIRCglhZ2VudF9pZHMYASADKAkSEQoJY29uZmlnX2lkGAIgASgJEg0KBWFzeW5jGAMgASgIIoEBChlCYXRjaEFzc2lnbkNvbmZpZ1Jlc3BvbnNlEhIKCnN1Y2Nlc3NmdWwYASABKAUSDgoGZmFpbGVkGAIgASgFEhgKEGZhaWxlZF9hZ2VudF9pZHMYAyADKAkSFgoOZXJyb3JfbWVzc2FnZXMYBCADKAkSDgoGam9iX2lkGAUgASgJIsYBChtBc3NpZ25Db25maWdCeUxhYmVsc1JlcXVlc3QSSAoGbGFiZWxzGAEgAygLMjguY29uZmlnLnYxYWxwaGExLkFzc2lnbkNvbmZpZ0J5TGFiZWxzUmVxdWVzdC5MYWJlbHNFbnRyeRIRCgljb25maWdfaWQYAiABKAkSGwoTc2VsZWN0b3JfZXhwcmVzc2lvbhgDIAEoCRotCgtMYWJlbHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIl0KHEFzc2lnbkNvbmZpZ0J5TGFiZWxzUmVzcG9uc2USGQoRbWF0Y2hlZF9hZ2VudF9pZHMYASADKAkSEgoKc3VjY2Vzc2Z1bBgCIAEoBRIOCgZmYWlsZWQYAyABKAUikgIKEEFzc2lnbm1lbnRQb2xpY3kSCgoCaWQYASABKAkSQQoIc2VsZWN0b3IYAiADKAsyLy5jb25maWcudjFhbHBoYTEuQXNzaWdubWVudFBvbGljeS5TZWxlY3RvckVudHJ5EhEKCWNvbmZpZ19pZBgDIAEoCRIQCghwcmlvcml0eRgEIAEoBRIpCgVhdWRpdBgFIAEoCzIaLmNvbmZpZy52MWFscGhhMS5BdWRpdEluZm8SEQoJYWdlbnRfaWRzGAYgAygJEhsKE3NlbGVjdG9yX2V4cHJlc3Npb24YByABKAkaLwoNU2VsZWN0b3JFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIk8KGlB1dEFzc2lnbm1lbnRQb2xpY3lSZXF1ZXN0EjEKBnBvbGljeRgBIAEoCzIhLmNvbmZpZy52MWFscGhhMS5Bc3NpZ25tZW50UG9saWN5IicKGUFzc2lnbm1lbnRQb2xpY3lSZWZlcmVuY2USCgoCaWQYASABKAkiHwodTGlzdEFzc2lnbm1lbnRQb2xpY2llc1JlcXVlc3QimwIKCkFnZW50R3JvdXASCgoCaWQYASABKAkSEwoLZGVzY3JpcHRpb24YAiABKAkSOwoIc2VsZWN0b3IYAyADKAsyKS5jb25maWcudjFhbHBoYTEuQWdlbnRHcm91cC5TZWxlY3RvckVudHJ5EhEKCWFnZW50X2lkcxgEIAMoCRIRCgljb25maWdfaWQYBSABKAkSEAoIcHJpb3JpdHkYBiABKAUSKQoFYXVkaXQYByABKAsyGi5jb25maWcudjFhbHBoYTEuQXVkaXRJbmZvEhsKE3NlbGVjdG9yX2V4cHJlc3Npb24YCCABKAkaLwoNU2VsZWN0b3JFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIkAKEkNyZWF0ZUdyb3VwUmVxdWVzdBIqCgVncm91cBgBIAEoCzIbLmNvbmZpZy52MWFscGhhMS5BZ2VudEdyb3VwIkAKElVwZGF0ZUdyb3VwUmVxdWVzdBIqCgVncm91cBgBIAEoCzIbLmNvbmZpZy52MWFscGhhMS5BZ2VudEdyb3VwIiEKE0FnZW50R3JvdXBSZWZlcmVuY2USCgoCaWQYASABKAkiEwoRTGlzdEdyb3Vwc1JlcXVlc3QiwQEKEkxpc3RHcm91cHNSZXNwb25zZRIrCgZncm91cHMYASADKAsyGy5jb25maWcudjFhbHBoYTEuQWdlbnRHcm91cBJKCgxhZ2VudF9jb3VudHMYAiADKAsyNC5jb25maWcudjFhbHBoYTEuTGlzdEdyb3Vwc1Jlc3BvbnNlLkFnZW50Q291bnRzRW50cnkaMgoQQWdlbnRDb3VudHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAU6AjgBIm4KGEFzc2lnbm1lbnRQb2xpY3lDb25mbGljdBIQCghhZ2VudF9pZBgBIAEoCRISCgpwb2xpY3lfaWRzGAIgAygJEhkKEWFwcGxpZWRfcG9saWN5X2lkGAMgASgJEhEKCWFtYmlndW91cxgEIAEoCCKlAgoeTGlzdEFzc2lnbm1lbnRQb2xpY2llc1Jlc3BvbnNlEjMKCHBvbGljaWVzGAEgAygLMiEuY29uZmlnLnYxYWxwaGExLkFzc2lnbm1lbnRQb2xpY3kSPAoJY29uZmxpY3RzGAIgAygLMikuY29uZmlnLnYxYWxwaGExLkFzc2lnbm1lbnRQb2xpY3lDb25mbGljdBJaCg5hcHBsaWVkX2FnZW50cxgDIAMoCzJCLmNvbmZpZy52MWFscGhhMS5MaXN0QXNzaWdubWVudFBvbGljaWVzUmVzcG9uc2UuQXBwbGllZEFnZW50c0VudHJ5GjQKEkFwcGxpZWRBZ2VudHNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAU6AjgBIjMKH0dldEFzc2lnbm1lbnRFeHBsYW5hdGlvblJlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkijQEKE0Fzc2lnbm1lbnRDYW5kaWRhdGUSLQoGc291cmNlGAEgASgOMh0uY29uZmlnLnYxYWxwaGExLkNvbmZpZ1NvdXJjZRIRCgljb25maWdfaWQYAiABKAkSEQoJcG9saWN5X2lkGAMgASgJEhEKCWVmZmVjdGl2ZRgEIAEoCBIOCgZyZWFzb24YBSABKAki7AEKFUFzc2lnbm1lbnRFeHBsYW5hdGlvbhIQCghhZ2VudF9pZBgBIAEoCRI3ChBlZmZlY3RpdmVfc291cmNlGAIgASgOMh0uY29uZmlnLnYxYWxwaGExLkNvbmZpZ1NvdXJjZRIbChNlZmZlY3RpdmVfY29uZmlnX2lkGAMgASgJEjgKCmNhbmRpZGF0ZXMYBCADKAsyJC5jb25maWcudjFhbHBoYTEuQXNzaWdubWVudENhbmRpZGF0ZRIxCgpwcmVjZWRlbmNlGAUgAygOMh0uY29uZmlnLnYxYWxwaGExLkNvbmZpZ1NvdXJjZSLcAQoPQ29tcG9uZW50UG9saWN5EgoKAmlkGAEgASgJEkAKCHNlbGVjdG9yGAIgAygLMi4uY29uZmlnLnYxYWxwaGExLkNvbXBvbmVudFBvbGljeS5TZWxlY3RvckVudHJ5Eg4KBmRlbmllZBgDIAMoCRIPCgdhbGxvd2VkGAQgAygJEikKBWF1ZGl0GAUgASgLMhouY29uZmlnLnYxYWxwaGExLkF1ZGl0SW5mbxovCg1TZWxlY3RvckVudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEiTQoZUHV0Q29tcG9uZW50UG9saWN5UmVxdWVzdBIwCgZwb2xpY3kYASABKAsyIC5jb25maWcudjFhbHBoYTEuQ29tcG9uZW50UG9saWN5IiYKGENvbXBvbmVudFBvbGljeVJlZmVyZW5jZRIKCgJpZBgBIAEoCSIeChxMaXN0Q29tcG9uZW50UG9saWNpZXNSZXF1ZXN0InUKGENvbXBvbmVudFBvbGljeVZpb2xhdGlvbhIRCglwb2xpY3lfaWQYASABKAkSEAoIYWdlbnRfaWQYAiABKAkSEQoJY29uZmlnX2lkGAMgASgJEhEKCWNvbXBvbmVudBgEIAEoCRIOCgZyZWFzb24YBSABKAkikgEKHUxpc3RDb21wb25lbnRQb2xpY2llc1Jlc3BvbnNlEjIKCHBvbGljaWVzGAEgAygLMiAuY29uZmlnLnYxYWxwaGExLkNvbXBvbmVudFBvbGljeRI9Cgp2aW9sYXRpb25zGAIgAygLMikuY29uZmlnLnYxYWxwaGExLkNvbXBvbmVudFBvbGljeVZpb2xhdGlvbiKvAQoVQ29sbGVjdG9yRGlzdHJpYnV0aW9uEgwKBG5hbWUYASABKAkSDwoHdmVyc2lvbhgCIAEoCRISCgpjb21wb25lbnRzGAMgAygJEjgKCWFydGlmYWN0cxgEIAMoCzIlLmNvbmZpZy52MWFscGhhMS5EaXN0cmlidXRpb25BcnRpZmFjdBIpCgVhdWRpdBgFIAEoCzIaLmNvbmZpZy52MWFscGhhMS5BdWRpdEluZm8iWAoURGlzdHJpYnV0aW9uQXJ0aWZhY3QSEAoIcGxhdGZvcm0YASABKAkSCwoDdXJsGAIgASgJEg4KBnNoYTI1NhgDIAEoCRIRCglzaWduYXR1cmUYBCABKAwiXwofUHV0Q29sbGVjdG9yRGlzdHJpYnV0aW9uUmVxdWVzdBI8CgxkaXN0cmlidXRpb24YASABKAsyJi5jb25maWcudjFhbHBoYTEuQ29sbGVjdG9yRGlzdHJpYnV0aW9uIj8KHkNvbGxlY3RvckRpc3RyaWJ1dGlvblJlZmVyZW5jZRIMCgRuYW1lGAEgASgJEg8KB3ZlcnNpb24YAiABKAkiMQohTGlzdENvbGxlY3RvckRpc3RyaWJ1dGlvbnNSZXF1ZXN0EgwKBG5hbWUYASABKAkiYwoiTGlzdENvbGxlY3RvckRpc3RyaWJ1dGlvbnNSZXNwb25zZRI9Cg1kaXN0cmlidXRpb25zGAEgAygLMiYuY29uZmlnLnYxYWxwaGExLkNvbGxlY3RvckRpc3RyaWJ1dGlvbiJ7Ch9DaGVja0NvbmZpZ0NvbXBhdGliaWxpdHlSZXF1ZXN0EhEKCWNvbmZpZ19pZBgBIAEoCRJFCgxkaXN0cmlidXRpb24YAiABKAsyLy5jb25maWcudjFhbHBoYTEuQ29sbGVjdG9yRGlzdHJpYnV0aW9uUmVmZXJlbmNlIkUKE0NvbmZpZ0NvbXBhdGliaWxpdHkSEgoKY29tcGF0aWJsZRgBIAEoCBIaChJtaXNzaW5nX2NvbXBvbmVudHMYAiADKAkiiAMKGFJvbGxpbmdEZXBsb3ltZW50UmVxdWVzdBIRCgljb25maWdfaWQYASABKAkSEQoJYWdlbnRfaWRzGAIgAygJElAKDGFnZW50X2xhYmVscxgDIAMoCzI6LmNvbmZpZy52MWFscGhhMS5Sb2xsaW5nRGVwbG95bWVudFJlcXVlc3QuQWdlbnRMYWJlbHNFbnRyeRISCgpiYXRjaF9zaXplGAQgASgFEhsKE2JhdGNoX2RlbGF5X3NlY29uZHMYBSABKAUSFAoMbWF4X2ZhaWx1cmVzGAYgASgFEiAKGG1heF9hcHBseV9qaXR0ZXJfc2Vjb25kcxgHIAEoBRIVCg1hdXRvX3JvbGxiYWNrGAggASgIEh0KFWFwcGx5X3RpbWVvdXRfc2Vjb25kcxgJIAEoBRIhChlhZ2VudF9zZWxlY3Rvcl9leHByZXNzaW9uGAogASgJGjIKEEFnZW50TGFiZWxzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASKfAQoNRGVwbG95bWVudEpvYhIVCg1kZXBsb3ltZW50X2lkGAEgASgJEhEKCWFnZW50X2lkcxgCIAMoCRI6CgdyZXF1ZXN0GAMgASgLMikuY29uZmlnLnYxYWxwaGExLlJvbGxpbmdEZXBsb3ltZW50UmVxdWVzdBITCgtyb2xsYmFja19vZhgEIAEoCRITCgtiYXRjaF9zaXplcxgFIAMoBSIyChlSb2xsaW5nRGVwbG95bWVudFJlc3BvbnNlEhUKDWRlcGxveW1lbnRfaWQYASABKAki1wIKFUFnZW50RGVwbG95bWVudFN0YXR1cxIQCghhZ2VudF9pZBgBIAEoCRI0CgVzdGF0ZRgCIAEoDjIlLmNvbmZpZy52MWFscGhhMS5BZ2VudERlcGxveW1lbnRTdGF0ZRIVCg1lcnJvcl9tZXNzYWdlGAMgASgJEi4KCmFwcGxpZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCnN0YXJ0ZWRfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEg0KBWJhdGNoGAYgASgFEj4KE3ByZXZpb3VzX2Fzc2lnbm1lbnQYByABKAsyIS5jb25maWcudjFhbHBoYTEuQ29uZmlnQXNzaWdubWVudBIwCg9wcmV2aW91c19jb25maWcYCCABKAsyFy5jb25maWcudjFhbHBoYTEuQ29uZmlnIqoEChBEZXBsb3ltZW50U3RhdHVzEhUKDWRlcGxveW1lbnRfaWQYASABKAkSEQoJY29uZmlnX2lkGAIgASgJEi8KBXN0YXRlGAMgASgOMiAuY29uZmlnLnYxYWxwaGExLkRlcGxveW1lbnRTdGF0ZRIUCgx0b3RhbF9hZ2VudHMYBCABKAUSGAoQY29tcGxldGVkX2FnZW50cxgFIAEoBRIVCg1mYWlsZWRfYWdlbnRzGAYgASgFEhYKDnBlbmRpbmdfYWdlbnRzGAcgASgFEhUKDWN1cnJlbnRfYmF0Y2gYCCABKAUSPgoOYWdlbnRfc3RhdHVzZXMYCSADKAsyJi5jb25maWcudjFhbHBoYTEuQWdlbnREZXBsb3ltZW50U3RhdHVzEi4KCnN0YXJ0ZWRfYXQYCiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjAKDGNvbXBsZXRlZF9hdBgLIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASOwoXcHJvamVjdGVkX2NvbXBsZXRpb25fYXQYDCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEikKBWF1ZGl0GA0gASgLMhouY29uZmlnLnYxYWxwaGExLkF1ZGl0SW5mbxITCgtyb2xsYmFja19vZhgOIAEoCRIWCg5yb2xsZWRfYmFja19ieRgPIAEoCRIOCgZ0ZW5hbnQYECABKAkiMwoaR2V0RGVwbG95bWVudFN0YXR1c1JlcXVlc3QSFQoNZGVwbG95bWVudF9pZBgBIAEoCSJQChtHZXREZXBsb3ltZW50U3RhdHVzUmVzcG9uc2USMQoGc3RhdHVzGAEgASgLMiEuY29uZmlnLnYxYWxwaGExLkRlcGxveW1lbnRTdGF0dXMiLwoWUGF1c2VEZXBsb3ltZW50UmVxdWVzdBIVCg1kZXBsb3ltZW50X2lkGAEgASgJIjAKF1Jlc3VtZURlcGxveW1lbnRSZXF1ZXN0EhUKDWRlcGxveW1lbnRfaWQYASABKAkiMAoXQ2FuY2VsRGVwbG95bWVudFJlcXVlc3QSFQoNZGVwbG95bWVudF9pZBgBIAEoCSIvChZQdXJnZURlcGxveW1lbnRSZXF1ZXN0EhUKDWRlcGxveW1lbnRfaWQYASABKAkibgoZUm9sbGJhY2tEZXBsb3ltZW50UmVxdWVzdBIVCg1kZXBsb3ltZW50X2lkGAEgASgJEhsKE2JhdGNoX2RlbGF5X3NlY29uZHMYAiABKAUSHQoVYXBwbHlfdGltZW91dF9zZWNvbmRzGAMgASgFIjwKGERlcGxveW1lbnRBY3Rpb25SZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIEg8KB21lc3NhZ2UYAiABKAkimgEKFkxpc3REZXBsb3ltZW50c1JlcXVlc3QSOwoMc3RhdGVfZmlsdGVyGAEgASgOMiAuY29uZmlnLnYxYWxwaGExLkRlcGxveW1lbnRTdGF0ZUgAiAEBEjIKDGF1ZGl0X2ZpbHRlchgCIAEoCzIcLmNvbmZpZy52MWFscGhhMS5BdWRpdEZpbHRlckIPCg1fc3RhdGVfZmlsdGVyIlEKF0xpc3REZXBsb3ltZW50c1Jlc3BvbnNlEjYKC2RlcGxveW1lbnRzGAEgAygLMiEuY29uZmlnLnYxYWxwaGExLkRlcGxveW1lbnRTdGF0dXMiZwoMU2tpcHBlZEFnZW50EhAKCGFnZW50X2lkGAEgASgJEjUKBnJlYXNvbhgCIAEoDjIlLmNvbmZpZy52MWFscGhhMS5EZXBsb3ltZW50U2tpcFJlYXNvbhIOCgZkZXRhaWwYAyABKAkiNQoPQ2FwYWNpdHlXYXJuaW5nEhAKCGFnZW50X2lkGAEgASgJEhAKCHdhcm5pbmdzGAIgAygJIqEBChNEZXBsb3ltZW50UGxhbkJhdGNoEg4KBm51bWJlchgBIAEoBRIRCglhZ2VudF9pZHMYAiADKAkSMQoOZXhwZWN0ZWRfc3RhcnQYAyABKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24SNAoRZXhwZWN0ZWRfZHVyYXRpb24YBCABKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24izgIKDkRlcGxveW1lbnRQbGFuEhEKCWNvbmZpZ19pZBgBIAEoCRIUCgx0b3RhbF9hZ2VudHMYAiABKAUSNQoHYmF0Y2hlcxgDIAMoCzIkLmNvbmZpZy52MWFscGhhMS5EZXBsb3ltZW50UGxhbkJhdGNoEjUKDnNraXBwZWRfYWdlbnRzGAQgAygLMh0uY29uZmlnLnYxYWxwaGExLlNraXBwZWRBZ2VudBIZChFwb2xpY3lfdmlvbGF0aW9ucxgFIAMoCRI0ChFleHBlY3RlZF9kdXJhdGlvbhgGIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhIXCg9sYXRlbmN5X3NhbXBsZXMYByABKAUSOwoRY2FwYWNpdHlfd2FybmluZ3MYCCADKAsyIC5jb25maWcudjFhbHBoYTEuQ2FwYWNpdHlXYXJuaW5nIhoKGEdldENvbmZpZ0NvdmVyYWdlUmVxdWVzdCJDCg5TaWduYWxDb3ZlcmFnZRIOCgZzaWduYWwYASABKAkSDgoGYWdlbnRzGAIgASgFEhEKCXBpcGVsaW5lcxgDIAEoBSIuCg5Db21wb25lbnRVc2FnZRIMCgR0eXBlGAEgASgJEg4KBmFnZW50cxgCIAEoBSLsAQoUQ29uZmlnQ292ZXJhZ2VSZXBvcnQSFAoMdG90YWxfYWdlbnRzGAEgASgFEhgKEHJlcG9ydGluZ19hZ2VudHMYAiABKAUSMAoHc2lnbmFscxgDIAMoCzIfLmNvbmZpZy52MWFscGhhMS5TaWduYWxDb3ZlcmFnZRIyCglleHBvcnRlcnMYBCADKAsyHy5jb25maWcudjFhbHBoYTEuQ29tcG9uZW50VXNhZ2USIAoYYWdlbnRzX3dpdGhvdXRfcGlwZWxpbmVzGAUgAygJEhwKFGFnZW50c19ub3RfcmVwb3J0aW5nGAYgAygJImIKEkFnZW50Q29uZmlnSGlzdG9yeRI5CgdlbnRyaWVzGAEgAygLMiguY29uZmlnLnYxYWxwaGExLkFnZW50Q29uZmlnSGlzdG9yeUVudHJ5EhEKCXRydW5jYXRlZBgCIAEoCCKDAgoXQWdlbnRDb25maWdIaXN0b3J5RW50cnkSKAoEdGltZRgBIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASMgoGY2hhbmdlGAIgASgOMiIuY29uZmlnLnYxYWxwaGExLkFnZW50Q29uZmlnQ2hhbmdlEjUKCmFzc2lnbm1lbnQYAyABKAsyIS5jb25maWcudjFhbHBoYTEuQ29uZmlnQXNzaWdubWVudBInCgZjb25maWcYBCABKAsyFy5jb25maWcudjFhbHBoYTEuQ29uZmlnEhMKC2NvbmZpZ19oYXNoGAUgASgMEhUKDWVycm9yX21lc3NhZ2UYBiABKAkiWQobR2V0QWdlbnRDb25maWdBdFRpbWVSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJEigKBHRpbWUYAiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIvcBChFBZ2VudENvbmZpZ0F0VGltZRIQCghhZ2VudF9pZBgBIAEoCRIoCgR0aW1lGAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBI1Cgphc3NpZ25tZW50GAMgASgLMiEuY29uZmlnLnYxYWxwaGExLkNvbmZpZ0Fzc2lnbm1lbnQSJwoGY29uZmlnGAQgASgLMhcuY29uZmlnLnYxYWxwaGExLkNvbmZpZxI0CgdhcHBsaWVkGAUgASgLMiMuY29uZmlnLnYxYWxwaGExLkFwcGxpZWRBZ2VudENvbmZpZxIQCghjb21wbGV0ZRgGIAEoCCJsChJBcHBsaWVkQWdlbnRDb25maWcSEwoLY29uZmlnX2hhc2gYASABKAwSLgoKYXBwbGllZF9hdBgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEQoJY29uZmlnX2lkGAMgASgJIqUBCg1QYWNrYWdlVGFyZ2V0EhAKCGFnZW50X2lkGAEgASgJEhAKCGdyb3VwX2lkGAIgASgJEkUKDGRpc3RyaWJ1dGlvbhgDIAEoCzIvLmNvbmZpZy52MWFscGhhMS5Db2xsZWN0b3JEaXN0cmlidXRpb25SZWZlcmVuY2USKQoFYXVkaXQYBCABKAsyGi5jb25maWcudjFhbHBoYTEuQXVkaXRJbmZvIkkKF1NldFBhY2thZ2VUYXJnZXRSZXF1ZXN0Ei4KBnRhcmdldBgBIAEoCzIeLmNvbmZpZy52MWFscGhhMS5QYWNrYWdlVGFyZ2V0IjwKFlBhY2thZ2VUYXJnZXRSZWZlcmVuY2USEAoIYWdlbnRfaWQYASABKAkSEAoIZ3JvdXBfaWQYAiABKAkiGwoZTGlzdFBhY2thZ2VUYXJnZXRzUmVxdWVzdCJNChpMaXN0UGFja2FnZVRhcmdldHNSZXNwb25zZRIvCgd0YXJnZXRzGAEgAygLMh4uY29uZmlnLnYxYWxwaGExLlBhY2thZ2VUYXJnZXQiMAocR2V0QWdlbnRQYWNrYWdlU3RhdHVzUmVxdWVzdBIQCghhZ2VudF9pZBgBIAEoCSKmAgoSQWdlbnRQYWNrYWdlU3RhdHVzEhAKCGFnZW50X2lkGAEgASgJEi4KBnRhcmdldBgCIAEoCzIeLmNvbmZpZy52MWFscGhhMS5QYWNrYWdlVGFyZ2V0EjcKCGFydGlmYWN0GAMgASgLMiUuY29uZmlnLnYxYWxwaGExLkRpc3RyaWJ1dGlvbkFydGlmYWN0EhkKEWluc3RhbGxlZF92ZXJzaW9uGAQgASgJEjMKBXN0YXRlGAUgASgOMiQuY29uZmlnLnYxYWxwaGExLlBhY2thZ2VJbnN0YWxsU3RhdGUSFQoNZXJyb3JfbWVzc2FnZRgGIAEoCRIuCgp1cGRhdGVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCptCg9GaW5kaW5nU2V2ZXJpdHkSIAocRklORElOR19TRVZFUklUWV9VTlNQRUNJRklFRBAAEhoKFkZJTkRJTkdfU0VWRVJJVFlfRVJST1IQARIcChhGSU5ESU5HX1NFVkVSSVRZX1dBUk5JTkcQAiq1AQoMQ29uZmlnU291cmNlEh0KGUNPTkZJR19TT1VSQ0VfVU5TUEVDSUZJRUQQABIZChVDT05GSUdfU09VUkNFX0RFRkFVTFQQARIbChdDT05GSUdfU09VUkNFX0JPT1RTVFJBUBACEhgKFENPTkZJR19TT1VSQ0VfTUFOVUFMEAMSGAoUQ09ORklHX1NPVVJDRV9QT0xJQ1kQBBIaChZDT05GSUdfU09VUkNFX0ZBTExCQUNLEAUq4wEKF0NvbmZpZ0FwcGxpY2F0aW9uU3RhdHVzEikKJUNPTkZJR19BUFBMSUNBVElPTl9TVEFUVVNfVU5TUEVDSUZJRUQQABIlCiFDT05GSUdfQVBQTElDQVRJT05fU1RBVFVTX1BFTkRJTkcQARIlCiFDT05GSUdfQVBQTElDQVRJT05fU1RBVFVTX0FQUExJRUQQAhIkCiBDT05GSUdfQVBQTElDQVRJT05fU1RBVFVTX0ZBSUxFRBADEikKJUNPTkZJR19BUFBMSUNBVElPTl9TVEFUVVNfVU5TVVBQT1JURUQQBCrtAQoPRGVwbG95bWVudFN0YXRlEiAKHERFUExPWU1FTlRfU1RBVEVfVU5TUEVDSUZJRUQQABIcChhERVBMT1lNRU5UX1NUQVRFX1BFTkRJTkcQARIgChxERVBMT1lNRU5UX1NUQVRFX0lOX1BST0dSRVNTEAISGwoXREVQTE9ZTUVOVF9TVEFURV9QQVVTRUQQAxIeChpERVBMT1lNRU5UX1NUQVRFX0NPTVBMRVRFRBAEEhsKF0RFUExPWU1FTlRfU1RBVEVfRkFJTEVEEAUSHgoaREVQTE9ZTUVOVF9TVEFURV9DQU5DRUxMRUQQBiryAQoUQWdlbnREZXBsb3ltZW50U3RhdGUSJgoiQUdFTlRfREVQTE9ZTUVOVF9TVEFURV9VTlNQRUNJRklFRBAAEiIKHkFHRU5UX0RFUExPWU1FTlRfU1RBVEVfUEVORElORxABEiMKH0FHRU5UX0RFUExPWU1FTlRfU1RBVEVfQVBQTFlJTkcQAhIiCh5BR0VOVF9ERVBMT1lNRU5UX1NUQVRFX0FQUExJRUQQAxIhCh1BR0VOVF9ERVBMT1lNRU5UX1NUQVRFX0ZBSUxFRBAEEiIKHkFHRU5UX0RFUExPWU1FTlRfU1RBVEVfU0tJUFBFRBAFKrEBChREZXBsb3ltZW50U2tpcFJlYXNvbhImCiJERVBMT1lNRU5UX1NLSVBfUkVBU09OX1VOU1BFQ0lGSUVEEAASJAogREVQTE9ZTUVOVF9TS0lQX1JFQVNPTl9OT1RfRk9VTkQQARIiCh5ERVBMT1lNRU5UX1NLSVBfUkVBU09OX09GRkxJTkUQAhInCiNERVBMT1lNRU5UX1NLSVBfUkVBU09OX0lOQ09NUEFUSUJMRRADKr8BChFBZ2VudENvbmZpZ0NoYW5nZRIjCh9BR0VOVF9DT05GSUdfQ0hBTkdFX1VOU1BFQ0lGSUVEEAASIAocQUdFTlRfQ09ORklHX0NIQU5HRV9BU1NJR05FRBABEiIKHkFHRU5UX0NPTkZJR19DSEFOR0VfVU5BU1NJR05FRBACEh8KG0FHRU5UX0NPTkZJR19DSEFOR0VfQVBQTElFRBADEh4KGkFHRU5UX0NPTkZJR19DSEFOR0VfRkFJTEVEEAQqgwIKE1BhY2thZ2VJbnN0YWxsU3RhdGUSJQohUEFDS0FHRV9JTlNUQUxMX1NUQVRFX1VOU1BFQ0lGSUVEEAASIwofUEFDS0FHRV9JTlNUQUxMX1NUQVRFX0lOU1RBTExFRBABEikKJVBBQ0tBR0VfSU5TVEFMTF9TVEFURV9JTlNUQUxMX1BFTkRJTkcQAhIkCiBQQUNLQUdFX0lOU1RBTExfU1RBVEVfSU5TVEFMTElORxADEigKJFBBQ0tBR0VfSU5TVEFMTF9TVEFURV9JTlNUQUxMX0ZBSUxFRBAEEiUKIVBBQ0tBR0VfSU5TVEFMTF9TVEFURV9ET1dOTE9BRElORxAFMtgkCg1Db25maWdTZXJ2aWNlEk0KC1ZhbGlkQ29uZmlnEiYuY29uZmlnLnYxYWxwaGExLlZhbGlkYXRlQ29uZmlnUmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRJxChZWYWxpZGF0ZUNvbmZpZ0RldGFpbGVkEi4uY29uZmlnLnYxYWxwaGExLlZhbGlkYXRlQ29uZmlnRGV0YWlsZWRSZXF1ZXN0GicuY29uZmlnLnYxYWxwaGExLkNvbmZpZ1ZhbGlkYXRpb25SZXN1bHQSRgoJUHV0Q29uZmlnEiEuY29uZmlnLnYxYWxwaGExLlB1dENvbmZpZ1JlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHkSRgoJR2V0Q29uZmlnEiAuY29uZmlnLnYxYWxwaGExLkNvbmZpZ1JlZmVyZW5jZRoXLmNvbmZpZy52MWFscGhhMS5Db25maWcSSAoMRGVsZXRlQ29uZmlnEiAuY29uZmlnLnYxYWxwaGExLkNvbmZpZ1JlZmVyZW5jZRoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRJWCgtMaXN0Q29uZmlncxIjLmNvbmZpZy52MWFscGhhMS5MaXN0Q29uZmlnc1JlcXVlc3QaIi5jb25maWcudjFhbHBoYTEuTGlzdENvbmZpZ1JlcG9uc2USQwoQR2V0RGVmYXVsdENvbmZpZxIWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRoXLmNvbmZpZy52MWFscGhhMS5Db25maWcSVwoPQmVnaW5Db25maWdFZGl0EiAuY29uZmlnLnYxYWxwaGExLkNvbmZpZ1JlZmVyZW5jZRoiLmNvbmZpZy52MWFscGhhMS5Db25maWdFZGl0U2Vzc2lvbhJfCg5TYXZlQ29uZmlnRWRpdBImLmNvbmZpZy52MWFscGhhMS5TYXZlQ29uZmlnRWRpdFJlcXVlc3QaJS5jb25maWcudjFhbHBoYTEuU2F2ZUNvbmZpZ0VkaXRSZXN1bHQSTQoQU2V0RGVmYXVsdENvbmZpZxIhLmNvbmZpZy52MWFscGhhMS5QdXRDb25maWdSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5ElsKDEFzc2lnbkNvbmZpZxIkLmNvbmZpZy52MWFscGhhMS5Bc3NpZ25Db25maWdSZXF1ZXN0GiUuY29uZmlnLnYxYWxwaGExLkFzc2lnbkNvbmZpZ1Jlc3BvbnNlEmEKDkdldEFnZW50Q29uZmlnEiYuY29uZmlnLnYxYWxwaGExLkdldEFnZW50Q29uZmlnUmVxdWVzdBonLmNvbmZpZy52MWFscGhhMS5HZXRBZ2VudENvbmZpZ1Jlc3BvbnNlEmEKDlVuYXNzaWduQ29uZmlnEiYuY29uZmlnLnYxYWxwaGExLlVuYXNzaWduQ29uZmlnUmVxdWVzdBonLmNvbmZpZy52MWFscGhhMS5VbmFzc2lnbkNvbmZpZ1Jlc3BvbnNlEnYKFUxpc3RDb25maWdBc3NpZ25tZW50cxItLmNvbmZpZy52MWFscGhhMS5MaXN0Q29uZmlnQXNzaWdubWVudHNSZXF1ZXN0Gi4uY29uZmlnLnYxYWxwaGExLkxpc3RDb25maWdBc3NpZ25tZW50c1Jlc3BvbnNlEmQKD0dldENvbmZpZ1N0YXR1cxInLmNvbmZpZy52MWFscGhhMS5HZXRDb25maWdTdGF0dXNSZXF1ZXN0GiguY29uZmlnLnYxYWxwaGExLkdldENvbmZpZ1N0YXR1c1Jlc3BvbnNlEmgKFEdldEFnZW50Q29uZmlnQXRUaW1lEiwuY29uZmlnLnYxYWxwaGExLkdldEFnZW50Q29uZmlnQXRUaW1lUmVxdWVzdBoiLmNvbmZpZy52MWFscGhhMS5BZ2VudENvbmZpZ0F0VGltZRJqChFCYXRjaEFzc2lnbkNvbmZpZxIpLmNvbmZpZy52MWFscGhhMS5CYXRjaEFzc2lnbkNvbmZpZ1JlcXVlc3QaKi5jb25maWcudjFhbHBoYTEuQmF0Y2hBc3NpZ25Db25maWdSZXNwb25zZRJzChRBc3NpZ25Db25maWdCeUxhYmVscxIsLmNvbmZpZy52MWFscGhhMS5Bc3NpZ25Db25maWdCeUxhYmVsc1JlcXVlc3QaLS5jb25maWcudjFhbHBoYTEuQXNzaWduQ29uZmlnQnlMYWJlbHNSZXNwb25zZRJvChZTdGFydFJvbGxpbmdEZXBsb3ltZW50EikuY29uZmlnLnYxYWxwaGExLlJvbGxpbmdEZXBsb3ltZW50UmVxdWVzdBoqLmNvbmZpZy52MWFscGhhMS5Sb2xsaW5nRGVwbG95bWVudFJlc3BvbnNlEnAKE0dldERlcGxveW1lbnRTdGF0dXMSKy5jb25maWcudjFhbHBoYTEuR2V0RGVwbG95bWVudFN0YXR1c1JlcXVlc3QaLC5jb25maWcudjFhbHBoYTEuR2V0RGVwbG95bWVudFN0YXR1c1Jlc3BvbnNlEmUKD1BhdXNlRGVwbG95bWVudBInLmNvbmZpZy52MWFscGhhMS5QYXVzZURlcGxveW1lbnRSZXF1ZXN0GikuY29uZmlnLnYxYWxwaGExLkRlcGxveW1lbnRBY3Rpb25SZXNwb25zZRJnChBSZXN1bWVEZXBsb3ltZW50EiguY29uZmlnLnYxYWxwaGExLlJlc3VtZURlcGxveW1lbnRSZXF1ZXN0GikuY29uZmlnLnYxYWxwaGExLkRlcGxveW1lbnRBY3Rpb25SZXNwb25zZRJnChBDYW5jZWxEZXBsb3ltZW50EiguY29uZmlnLnYxYWxwaGExLkNhbmNlbERlcGxveW1lbnRSZXF1ZXN0GikuY29uZmlnLnYxYWxwaGExLkRlcGxveW1lbnRBY3Rpb25SZXNwb25zZRJkCg9MaXN0RGVwbG95bWVudHMSJy5jb25maWcudjFhbHBoYTEuTGlzdERlcGxveW1lbnRzUmVxdWVzdBooLmNvbmZpZy52MWFscGhhMS5MaXN0RGVwbG95bWVudHNSZXNwb25zZRJlCg9QdXJnZURlcGxveW1lbnQSJy5jb25maWcudjFhbHBoYTEuUHVyZ2VEZXBsb3ltZW50UmVxdWVzdBopLmNvbmZpZy52MWFscGhhMS5EZXBsb3ltZW50QWN0aW9uUmVzcG9uc2USbAoSUm9sbGJhY2tEZXBsb3ltZW50EiouY29uZmlnLnYxYWxwaGExLlJvbGxiYWNrRGVwbG95bWVudFJlcXVlc3QaKi5jb25maWcudjFhbHBoYTEuUm9sbGluZ0RlcGxveW1lbnRSZXNwb25zZRJgChJTaW11bGF0ZURlcGxveW1lbnQSKS5jb25maWcudjFhbHBoYTEuUm9sbGluZ0RlcGxveW1lbnRSZXF1ZXN0Gh8uY29uZmlnLnYxYWxwaGExLkRlcGxveW1lbnRQbGFuEmUKE1B1dEFzc2lnbm1lbnRQb2xpY3kSKy5jb25maWcudjFhbHBoYTEuUHV0QXNzaWdubWVudFBvbGljeVJlcXVlc3QaIS5jb25maWcudjFhbHBoYTEuQXNzaWdubWVudFBvbGljeRJkChNHZXRBc3NpZ25tZW50UG9saWN5EiouY29uZmlnLnYxYWxwaGExLkFzc2lnbm1lbnRQb2xpY3lSZWZlcmVuY2UaIS5jb25maWcudjFhbHBoYTEuQXNzaWdubWVudFBvbGljeRJcChZEZWxldGVBc3NpZ25tZW50UG9saWN5EiouY29uZmlnLnYxYWxwaGExLkFzc2lnbm1lbnRQb2xpY3lSZWZlcmVuY2UaFi5nb29nbGUucHJvdG9idWYuRW1wdHkSeQoWTGlzdEFzc2lnbm1lbnRQb2xpY2llcxIuLmNvbmZpZy52MWFscGhhMS5MaXN0QXNzaWdubWVudFBvbGljaWVzUmVxdWVzdBovLmNvbmZpZy52MWFscGhhMS5MaXN0QXNzaWdubWVudFBvbGljaWVzUmVzcG9uc2USdAoYR2V0QXNzaWdubWVudEV4cGxhbmF0aW9uEjAuY29uZmlnLnYxYWxwaGExLkdldEFzc2lnbm1lbnRFeHBsYW5hdGlvblJlcXVlc3QaJi5jb25maWcudjFhbHBoYTEuQXNzaWdubWVudEV4cGxhbmF0aW9uEk8KC0NyZWF0ZUdyb3VwEiMuY29uZmlnLnYxYWxwaGExLkNyZWF0ZUdyb3VwUmVxdWVzdBobLmNvbmZpZy52MWFscGhhMS5BZ2VudEdyb3VwEk8KC1VwZGF0ZUdyb3VwEiMuY29uZmlnLnYxYWxwaGExLlVwZGF0ZUdyb3VwUmVxdWVzdBobLmNvbmZpZy52MWFscGhhMS5BZ2VudEdyb3VwEk0KCEdldEdyb3VwEiQuY29uZmlnLnYxYWxwaGExLkFnZW50R3JvdXBSZWZlcmVuY2UaGy5jb25maWcudjFhbHBoYTEuQWdlbnRHcm91cBJLCgtEZWxldGVHcm91cBIkLmNvbmZpZy52MWFscGhhMS5BZ2VudEdyb3VwUmVmZXJlbmNlGhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5ElUKCkxpc3RHcm91cHMSIi5jb25maWcudjFhbHBoYTEuTGlzdEdyb3Vwc1JlcXVlc3QaIy5jb25maWcudjFhbHBoYTEuTGlzdEdyb3Vwc1Jlc3BvbnNlEmIKElB1dENvbXBvbmVudFBvbGljeRIqLmNvbmZpZy52MWFscGhhMS5QdXRDb21wb25lbnRQb2xpY3lSZXF1ZXN0GiAuY29uZmlnLnYxYWxwaGExLkNvbXBvbmVudFBvbGljeRJhChJHZXRDb21wb25lbnRQb2xpY3kSKS5jb25maWcudjFhbHBoYTEuQ29tcG9uZW50UG9saWN5UmVmZXJlbmNlGiAuY29uZmlnLnYxYWxwaGExLkNvbXBvbmVudFBvbGljeRJaChVEZWxldGVDb21wb25lbnRQb2xpY3kSKS5jb25maWcudjFhbHBoYTEuQ29tcG9uZW50UG9saWN5UmVmZXJlbmNlGhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5EnYKFUxpc3RDb21wb25lbnRQb2xpY2llcxItLmNvbmZpZy52MWFscGhhMS5MaXN0Q29tcG9uZW50UG9saWNpZXNSZXF1ZXN0Gi4uY29uZmlnLnYxYWxwaGExLkxpc3RDb21wb25lbnRQb2xpY2llc1Jlc3BvbnNlEnQKGFB1dENvbGxlY3RvckRpc3RyaWJ1dGlvbhIwLmNvbmZpZy52MWFscGhhMS5QdXRDb2xsZWN0b3JEaXN0cmlidXRpb25SZXF1ZXN0GiYuY29uZmlnLnYxYWxwaGExLkNvbGxlY3RvckRpc3RyaWJ1dGlvbhJzChhHZXRDb2xsZWN0b3JEaXN0cmlidXRpb24SLy5jb25maWcudjFhbHBoYTEuQ29sbGVjdG9yRGlzdHJpYnV0aW9uUmVmZXJlbmNlGiYuY29uZmlnLnYxYWxwaGExLkNvbGxlY3RvckRpc3RyaWJ1dGlvbhJmChtEZWxldGVDb2xsZWN0b3JEaXN0cmlidXRpb24SLy5jb25maWcudjFhbHBoYTEuQ29sbGVjdG9yRGlzdHJpYnV0aW9uUmVmZXJlbmNlGhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5EoUBChpMaXN0Q29sbGVjdG9yRGlzdHJpYnV0aW9ucxIyLmNvbmZpZy52MWFscGhhMS5MaXN0Q29sbGVjdG9yRGlzdHJpYnV0aW9uc1JlcXVlc3QaMy5jb25maWcudjFhbHBoYTEuTGlzdENvbGxlY3RvckRpc3RyaWJ1dGlvbnNSZXNwb25zZRJyChhDaGVja0NvbmZpZ0NvbXBhdGliaWxpdHkSMC5jb25maWcudjFhbHBoYTEuQ2hlY2tDb25maWdDb21wYXRpYmlsaXR5UmVxdWVzdBokLmNvbmZpZy52MWFscGhhMS5Db25maWdDb21wYXRpYmlsaXR5EmUKEUdldENvbmZpZ0NvdmVyYWdlEikuY29uZmlnLnYxYWxwaGExLkdldENvbmZpZ0NvdmVyYWdlUmVxdWVzdBolLmNvbmZpZy52MWFscGhhMS5Db25maWdDb3ZlcmFnZVJlcG9ydDKiAwoOUGFja2FnZVNlcnZpY2USXAoQU2V0UGFja2FnZVRhcmdldBIoLmNvbmZpZy52MWFscGhhMS5TZXRQYWNrYWdlVGFyZ2V0UmVxdWVzdBoeLmNvbmZpZy52MWFscGhhMS5QYWNrYWdlVGFyZ2V0ElYKE0RlbGV0ZVBhY2thZ2VUYXJnZXQSJy5jb25maWcudjFhbHBoYTEuUGFja2FnZVRhcmdldFJlZmVyZW5jZRoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRJtChJMaXN0UGFja2FnZVRhcmdldHMSKi5jb25maWcudjFhbHBoYTEuTGlzdFBhY2thZ2VUYXJnZXRzUmVxdWVzdBorLmNvbmZpZy52MWFscGhhMS5MaXN0UGFja2FnZVRhcmdldHNSZXNwb25zZRJrChVHZXRBZ2VudFBhY2thZ2VTdGF0dXMSLS5jb25maWcudjFhbHBoYTEuR2V0QWdlbnRQYWNrYWdlU3RhdHVzUmVxdWVzdBojLmNvbmZpZy52MWFscGhhMS5BZ2VudFBhY2thZ2VTdGF0dXNCOFo2Z2l0aHViLmNvbS9vdGVsZmxlZXQvb3RlbGZsZWV0L3BrZy9hcGkvY29uZmlnL3YxYWxwaGExYgZwcm90bzM", [file_google_protobuf_duration, file_google_protobuf_empty, file_google_protobuf_timestamp]);
 
 /**
  * @generated from message config.v1alpha1.PutConfigRequest
@@ -27,6 +27,13 @@ export type PutConfigRequest = Message<"config.v1alpha1.PutConfigRequest"> & {
    * @generated from field: config.v1alpha1.Config config = 2;
    */
   config?: Config;
+
+  /**
+   * reject the config if it is invalid, with the findings of ValidConfig
+   *
+   * @generated from field: bool validate = 3;
+   */
+  validate: boolean;
 };
 
 /**
@@ -54,813 +61,3052 @@ export const ValidateConfigRequestSchema: GenMessage<ValidateConfigRequest> = /*
   messageDesc(file_pkg_api_config_v1alpha1_config, 1);
 
 /**
- * @generated from message config.v1alpha1.ListConfigReponse
+ * @generated from message config.v1alpha1.ValidateConfigDetailedRequest
  */
-export type ListConfigReponse = Message<"config.v1alpha1.ListConfigReponse"> & {
+export type ValidateConfigDetailedRequest = Message<"config.v1alpha1.ValidateConfigDetailedRequest"> & {
   /**
-   * @generated from field: repeated config.v1alpha1.ConfigReference configs = 1;
+   * @generated from field: config.v1alpha1.Config config = 1;
    */
-  configs: ConfigReference[];
+  config?: Config;
+
+  /**
+   * also check the component policies applying to this agent, only fleet-wide ones when empty
+   *
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
 };
 
 /**
- * Describes the message config.v1alpha1.ListConfigReponse.
- * Use `create(ListConfigReponseSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ValidateConfigDetailedRequest.
+ * Use `create(ValidateConfigDetailedRequestSchema)` to create a new message.
  */
-export const ListConfigReponseSchema: GenMessage<ListConfigReponse> = /*@__PURE__*/
+export const ValidateConfigDetailedRequestSchema: GenMessage<ValidateConfigDetailedRequest> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 2);
 
 /**
- * @generated from message config.v1alpha1.ConfigReference
+ * ConfigFinding is a problem found in a config
+ *
+ * @generated from message config.v1alpha1.ConfigFinding
  */
-export type ConfigReference = Message<"config.v1alpha1.ConfigReference"> & {
+export type ConfigFinding = Message<"config.v1alpha1.ConfigFinding"> & {
   /**
-   * @generated from field: string id = 1;
+   * @generated from field: config.v1alpha1.FindingSeverity severity = 1;
    */
-  id: string;
+  severity: FindingSeverity;
+
+  /**
+   * stable identifier of the check producing the finding, e.g. yaml-syntax
+   *
+   * @generated from field: string rule_id = 2;
+   */
+  ruleId: string;
+
+  /**
+   * @generated from field: string message = 3;
+   */
+  message: string;
+
+  /**
+   * 1-based position in the config, 0 when unknown
+   *
+   * @generated from field: uint32 line = 4;
+   */
+  line: number;
+
+  /**
+   * @generated from field: uint32 column = 5;
+   */
+  column: number;
 };
 
 /**
- * Describes the message config.v1alpha1.ConfigReference.
- * Use `create(ConfigReferenceSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ConfigFinding.
+ * Use `create(ConfigFindingSchema)` to create a new message.
  */
-export const ConfigReferenceSchema: GenMessage<ConfigReference> = /*@__PURE__*/
+export const ConfigFindingSchema: GenMessage<ConfigFinding> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 3);
 
 /**
- * @generated from message config.v1alpha1.Config
+ * @generated from message config.v1alpha1.ConfigValidationResult
  */
-export type Config = Message<"config.v1alpha1.Config"> & {
+export type ConfigValidationResult = Message<"config.v1alpha1.ConfigValidationResult"> & {
   /**
-   * @generated from field: bytes config = 1;
+   * false if any finding is an error
+   *
+   * @generated from field: bool valid = 1;
    */
-  config: Uint8Array;
+  valid: boolean;
+
+  /**
+   * findings ordered by position
+   *
+   * @generated from field: repeated config.v1alpha1.ConfigFinding findings = 2;
+   */
+  findings: ConfigFinding[];
 };
 
 /**
- * Describes the message config.v1alpha1.Config.
- * Use `create(ConfigSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ConfigValidationResult.
+ * Use `create(ConfigValidationResultSchema)` to create a new message.
  */
-export const ConfigSchema: GenMessage<Config> = /*@__PURE__*/
+export const ConfigValidationResultSchema: GenMessage<ConfigValidationResult> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 4);
 
 /**
- * @generated from message config.v1alpha1.ConfigRange
+ * @generated from message config.v1alpha1.ListConfigsRequest
  */
-export type ConfigRange = Message<"config.v1alpha1.ConfigRange"> & {
-  /**
-   * @generated from field: string startVersion = 1;
-   */
-  startVersion: string;
-
+export type ListConfigsRequest = Message<"config.v1alpha1.ListConfigsRequest"> & {
   /**
-   * @generated from field: string endVersion = 2;
+   * @generated from field: config.v1alpha1.AuditFilter filter = 1;
    */
-  endVersion: string;
+  filter?: AuditFilter;
 };
 
 /**
- * Describes the message config.v1alpha1.ConfigRange.
- * Use `create(ConfigRangeSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ListConfigsRequest.
+ * Use `create(ListConfigsRequestSchema)` to create a new message.
  */
-export const ConfigRangeSchema: GenMessage<ConfigRange> = /*@__PURE__*/
+export const ListConfigsRequestSchema: GenMessage<ListConfigsRequest> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 5);
 
 /**
- * @generated from message config.v1alpha1.Labels
+ * @generated from message config.v1alpha1.ListConfigReponse
  */
-export type Labels = Message<"config.v1alpha1.Labels"> & {
+export type ListConfigReponse = Message<"config.v1alpha1.ListConfigReponse"> & {
   /**
-   * @generated from field: map<string, string> labels = 1;
+   * @generated from field: repeated config.v1alpha1.ConfigReference configs = 1;
    */
-  labels: { [key: string]: string };
+  configs: ConfigReference[];
+
+  /**
+   * metadata of the listed configs, by config ID
+   *
+   * @generated from field: map<string, config.v1alpha1.ConfigMetadata> metadata = 2;
+   */
+  metadata: { [key: string]: ConfigMetadata };
 };
 
 /**
- * Describes the message config.v1alpha1.Labels.
- * Use `create(LabelsSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ListConfigReponse.
+ * Use `create(ListConfigReponseSchema)` to create a new message.
  */
-export const LabelsSchema: GenMessage<Labels> = /*@__PURE__*/
+export const ListConfigReponseSchema: GenMessage<ListConfigReponse> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 6);
 
 /**
- * TODO:
- *
- * @generated from message config.v1alpha1.Matcher
+ * @generated from message config.v1alpha1.ConfigReference
  */
-export type Matcher = Message<"config.v1alpha1.Matcher"> & {
+export type ConfigReference = Message<"config.v1alpha1.ConfigReference"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
 };
 
 /**
- * Describes the message config.v1alpha1.Matcher.
- * Use `create(MatcherSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ConfigReference.
+ * Use `create(ConfigReferenceSchema)` to create a new message.
  */
-export const MatcherSchema: GenMessage<Matcher> = /*@__PURE__*/
+export const ConfigReferenceSchema: GenMessage<ConfigReference> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 7);
 
 /**
- * ConfigAssignment tracks metadata about a config assignment to an agent
- *
- * @generated from message config.v1alpha1.ConfigAssignment
+ * @generated from message config.v1alpha1.Config
  */
-export type ConfigAssignment = Message<"config.v1alpha1.ConfigAssignment"> & {
+export type Config = Message<"config.v1alpha1.Config"> & {
   /**
-   * @generated from field: string agent_id = 1;
+   * @generated from field: bytes config = 1;
    */
-  agentId: string;
+  config: Uint8Array;
 
   /**
-   * @generated from field: string config_id = 2;
+   * @generated from field: config.v1alpha1.ConfigMetadata metadata = 2;
    */
-  configId: string;
+  metadata?: ConfigMetadata;
+};
 
+/**
+ * Describes the message config.v1alpha1.Config.
+ * Use `create(ConfigSchema)` to create a new message.
+ */
+export const ConfigSchema: GenMessage<Config> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 8);
+
+/**
+ * ConfigMetadata holds ownership information used to scope config management
+ *
+ * @generated from message config.v1alpha1.ConfigMetadata
+ */
+export type ConfigMetadata = Message<"config.v1alpha1.ConfigMetadata"> & {
   /**
-   * @generated from field: config.v1alpha1.ConfigSource source = 3;
+   * principal that created the config
+   *
+   * @generated from field: string owner = 1;
    */
-  source: ConfigSource;
+  owner: string;
 
   /**
-   * @generated from field: google.protobuf.Timestamp assigned_at = 4;
+   * @generated from field: repeated string tags = 2;
    */
-  assignedAt?: Timestamp;
+  tags: string[];
 
   /**
-   * @generated from field: bytes config_hash = 5;
+   * set by the server on every change
+   *
+   * @generated from field: config.v1alpha1.AuditInfo audit = 3;
    */
-  configHash: Uint8Array;
-};
+  audit?: AuditInfo;
 
-/**
- * Describes the message config.v1alpha1.ConfigAssignment.
- * Use `create(ConfigAssignmentSchema)` to create a new message.
- */
-export const ConfigAssignmentSchema: GenMessage<ConfigAssignment> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 8);
+  /**
+   * free-form annotations, e.g. the lineage of configs copied from another server
+   *
+   * @generated from field: map<string, string> annotations = 4;
+   */
+  annotations: { [key: string]: string };
 
-/**
- * @generated from message config.v1alpha1.AssignConfigRequest
- */
-export type AssignConfigRequest = Message<"config.v1alpha1.AssignConfigRequest"> & {
   /**
-   * @generated from field: string agent_id = 1;
+   * resources the config needs on the agent's host, checked against the capacity agents report
+   *
+   * @generated from field: config.v1alpha1.ResourceRequirements resources = 5;
    */
-  agentId: string;
+  resources?: ResourceRequirements;
 
   /**
-   * @generated from field: string config_id = 2;
+   * tenant the config was created in, set by the server
+   *
+   * @generated from field: string tenant = 6;
    */
-  configId: string;
+  tenant: string;
 };
 
 /**
- * Describes the message config.v1alpha1.AssignConfigRequest.
- * Use `create(AssignConfigRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ConfigMetadata.
+ * Use `create(ConfigMetadataSchema)` to create a new message.
  */
-export const AssignConfigRequestSchema: GenMessage<AssignConfigRequest> = /*@__PURE__*/
+export const ConfigMetadataSchema: GenMessage<ConfigMetadata> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 9);
 
 /**
- * @generated from message config.v1alpha1.AssignConfigResponse
+ * ResourceRequirements are resource hints of a config. Unset fields are not checked.
+ *
+ * @generated from message config.v1alpha1.ResourceRequirements
  */
-export type AssignConfigResponse = Message<"config.v1alpha1.AssignConfigResponse"> & {
+export type ResourceRequirements = Message<"config.v1alpha1.ResourceRequirements"> & {
   /**
-   * @generated from field: bool success = 1;
+   * @generated from field: uint64 min_memory_bytes = 1;
    */
-  success: boolean;
+  minMemoryBytes: bigint;
 
   /**
-   * @generated from field: string message = 2;
+   * @generated from field: double expected_cpu_cores = 2;
    */
-  message: string;
+  expectedCpuCores: number;
 };
 
 /**
- * Describes the message config.v1alpha1.AssignConfigResponse.
- * Use `create(AssignConfigResponseSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ResourceRequirements.
+ * Use `create(ResourceRequirementsSchema)` to create a new message.
  */
-export const AssignConfigResponseSchema: GenMessage<AssignConfigResponse> = /*@__PURE__*/
+export const ResourceRequirementsSchema: GenMessage<ResourceRequirements> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 10);
 
 /**
- * @generated from message config.v1alpha1.GetAgentConfigRequest
+ * AuditInfo records which principals created and last modified a resource.
+ * Principals are empty for changes made by anonymous callers.
+ *
+ * @generated from message config.v1alpha1.AuditInfo
  */
-export type GetAgentConfigRequest = Message<"config.v1alpha1.GetAgentConfigRequest"> & {
+export type AuditInfo = Message<"config.v1alpha1.AuditInfo"> & {
   /**
-   * @generated from field: string agent_id = 1;
+   * @generated from field: string created_by = 1;
    */
-  agentId: string;
+  createdBy: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 2;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: string modified_by = 3;
+   */
+  modifiedBy: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp modified_at = 4;
+   */
+  modifiedAt?: Timestamp;
 };
 
 /**
- * Describes the message config.v1alpha1.GetAgentConfigRequest.
- * Use `create(GetAgentConfigRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.AuditInfo.
+ * Use `create(AuditInfoSchema)` to create a new message.
  */
-export const GetAgentConfigRequestSchema: GenMessage<GetAgentConfigRequest> = /*@__PURE__*/
+export const AuditInfoSchema: GenMessage<AuditInfo> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 11);
 
 /**
- * @generated from message config.v1alpha1.GetAgentConfigResponse
+ * AuditFilter selects resources by their AuditInfo. Unset fields match everything.
+ *
+ * @generated from message config.v1alpha1.AuditFilter
  */
-export type GetAgentConfigResponse = Message<"config.v1alpha1.GetAgentConfigResponse"> & {
+export type AuditFilter = Message<"config.v1alpha1.AuditFilter"> & {
   /**
-   * @generated from field: string config_id = 1;
+   * @generated from field: string created_by = 1;
    */
-  configId: string;
+  createdBy: string;
 
   /**
-   * @generated from field: config.v1alpha1.ConfigSource source = 2;
+   * @generated from field: string modified_by = 2;
    */
-  source: ConfigSource;
+  modifiedBy: string;
 
   /**
-   * @generated from field: google.protobuf.Timestamp assigned_at = 3;
+   * inclusive lower bound on the modification time
+   *
+   * @generated from field: google.protobuf.Timestamp modified_after = 3;
    */
-  assignedAt?: Timestamp;
+  modifiedAfter?: Timestamp;
+
+  /**
+   * exclusive upper bound on the modification time
+   *
+   * @generated from field: google.protobuf.Timestamp modified_before = 4;
+   */
+  modifiedBefore?: Timestamp;
 };
 
 /**
- * Describes the message config.v1alpha1.GetAgentConfigResponse.
- * Use `create(GetAgentConfigResponseSchema)` to create a new message.
+ * Describes the message config.v1alpha1.AuditFilter.
+ * Use `create(AuditFilterSchema)` to create a new message.
  */
-export const GetAgentConfigResponseSchema: GenMessage<GetAgentConfigResponse> = /*@__PURE__*/
+export const AuditFilterSchema: GenMessage<AuditFilter> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 12);
 
 /**
- * @generated from message config.v1alpha1.UnassignConfigRequest
+ * ConfigEditSession records the revision of a config an editor started from
+ *
+ * @generated from message config.v1alpha1.ConfigEditSession
  */
-export type UnassignConfigRequest = Message<"config.v1alpha1.UnassignConfigRequest"> & {
+export type ConfigEditSession = Message<"config.v1alpha1.ConfigEditSession"> & {
   /**
-   * @generated from field: string agent_id = 1;
+   * @generated from field: string id = 1;
    */
-  agentId: string;
+  id: string;
+
+  /**
+   * @generated from field: string config_id = 2;
+   */
+  configId: string;
+
+  /**
+   * the config when the session began or was last saved, empty for new configs
+   *
+   * @generated from field: config.v1alpha1.Config base = 3;
+   */
+  base?: Config;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp expires_at = 4;
+   */
+  expiresAt?: Timestamp;
 };
 
 /**
- * Describes the message config.v1alpha1.UnassignConfigRequest.
- * Use `create(UnassignConfigRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ConfigEditSession.
+ * Use `create(ConfigEditSessionSchema)` to create a new message.
  */
-export const UnassignConfigRequestSchema: GenMessage<UnassignConfigRequest> = /*@__PURE__*/
+export const ConfigEditSessionSchema: GenMessage<ConfigEditSession> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 13);
 
 /**
- * @generated from message config.v1alpha1.UnassignConfigResponse
+ * @generated from message config.v1alpha1.SaveConfigEditRequest
  */
-export type UnassignConfigResponse = Message<"config.v1alpha1.UnassignConfigResponse"> & {
+export type SaveConfigEditRequest = Message<"config.v1alpha1.SaveConfigEditRequest"> & {
   /**
-   * @generated from field: bool success = 1;
+   * @generated from field: config.v1alpha1.ConfigReference ref = 1;
    */
-  success: boolean;
-};
+  ref?: ConfigReference;
 
-/**
- * Describes the message config.v1alpha1.UnassignConfigResponse.
- * Use `create(UnassignConfigResponseSchema)` to create a new message.
- */
-export const UnassignConfigResponseSchema: GenMessage<UnassignConfigResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 14);
+  /**
+   * @generated from field: string session_id = 2;
+   */
+  sessionId: string;
 
-/**
- * @generated from message config.v1alpha1.ListConfigAssignmentsRequest
- */
-export type ListConfigAssignmentsRequest = Message<"config.v1alpha1.ListConfigAssignmentsRequest"> & {
   /**
-   * Filter by config
+   * the edited config, its metadata replaces the current one when set
    *
-   * @generated from field: optional string config_id = 1;
+   * @generated from field: config.v1alpha1.Config config = 3;
    */
-  configId?: string;
+  config?: Config;
 };
 
 /**
- * Describes the message config.v1alpha1.ListConfigAssignmentsRequest.
- * Use `create(ListConfigAssignmentsRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.SaveConfigEditRequest.
+ * Use `create(SaveConfigEditRequestSchema)` to create a new message.
  */
-export const ListConfigAssignmentsRequestSchema: GenMessage<ListConfigAssignmentsRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 15);
+export const SaveConfigEditRequestSchema: GenMessage<SaveConfigEditRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 14);
 
 /**
- * @generated from message config.v1alpha1.ConfigAssignmentInfo
+ * ConfigMergeConflict is a value changed differently by the editor and concurrently by
+ * someone else. Values are YAML, empty when absent.
+ *
+ * @generated from message config.v1alpha1.ConfigMergeConflict
  */
-export type ConfigAssignmentInfo = Message<"config.v1alpha1.ConfigAssignmentInfo"> & {
+export type ConfigMergeConflict = Message<"config.v1alpha1.ConfigMergeConflict"> & {
   /**
-   * @generated from field: string agent_id = 1;
+   * dot separated path of the value, e.g. exporters.otlp.endpoint
+   *
+   * @generated from field: string path = 1;
    */
-  agentId: string;
+  path: string;
 
   /**
-   * @generated from field: string config_id = 2;
+   * @generated from field: string base = 2;
    */
-  configId: string;
+  base: string;
 
   /**
-   * @generated from field: config.v1alpha1.ConfigSource source = 3;
+   * @generated from field: string ours = 3;
    */
-  source: ConfigSource;
+  ours: string;
 
   /**
-   * @generated from field: google.protobuf.Timestamp assigned_at = 4;
+   * @generated from field: string theirs = 4;
    */
-  assignedAt?: Timestamp;
+  theirs: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.ConfigMergeConflict.
+ * Use `create(ConfigMergeConflictSchema)` to create a new message.
+ */
+export const ConfigMergeConflictSchema: GenMessage<ConfigMergeConflict> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 15);
 
+/**
+ * @generated from message config.v1alpha1.SaveConfigEditResult
+ */
+export type SaveConfigEditResult = Message<"config.v1alpha1.SaveConfigEditResult"> & {
   /**
-   * @generated from field: config.v1alpha1.ConfigApplicationStatus status = 5;
+   * false if the changes conflict with concurrent ones, in which case nothing was saved
+   *
+   * @generated from field: bool saved = 1;
    */
-  status: ConfigApplicationStatus;
+  saved: boolean;
 
   /**
-   * @generated from field: string error_message = 6;
+   * true if concurrent changes were merged into the saved config
+   *
+   * @generated from field: bool merged = 2;
    */
-  errorMessage: string;
+  merged: boolean;
+
+  /**
+   * the saved config, or the current one when not saved. The session continues from it,
+   * so that a resolution of the conflicts can be saved.
+   *
+   * @generated from field: config.v1alpha1.Config config = 3;
+   */
+  config?: Config;
+
+  /**
+   * @generated from field: repeated config.v1alpha1.ConfigMergeConflict conflicts = 4;
+   */
+  conflicts: ConfigMergeConflict[];
 };
 
 /**
- * Describes the message config.v1alpha1.ConfigAssignmentInfo.
- * Use `create(ConfigAssignmentInfoSchema)` to create a new message.
+ * Describes the message config.v1alpha1.SaveConfigEditResult.
+ * Use `create(SaveConfigEditResultSchema)` to create a new message.
  */
-export const ConfigAssignmentInfoSchema: GenMessage<ConfigAssignmentInfo> = /*@__PURE__*/
+export const SaveConfigEditResultSchema: GenMessage<SaveConfigEditResult> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 16);
 
 /**
- * @generated from message config.v1alpha1.ListConfigAssignmentsResponse
+ * @generated from message config.v1alpha1.ConfigRange
  */
-export type ListConfigAssignmentsResponse = Message<"config.v1alpha1.ListConfigAssignmentsResponse"> & {
+export type ConfigRange = Message<"config.v1alpha1.ConfigRange"> & {
   /**
-   * @generated from field: repeated config.v1alpha1.ConfigAssignmentInfo assignments = 1;
+   * @generated from field: string startVersion = 1;
    */
-  assignments: ConfigAssignmentInfo[];
+  startVersion: string;
+
+  /**
+   * @generated from field: string endVersion = 2;
+   */
+  endVersion: string;
 };
 
 /**
- * Describes the message config.v1alpha1.ListConfigAssignmentsResponse.
- * Use `create(ListConfigAssignmentsResponseSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ConfigRange.
+ * Use `create(ConfigRangeSchema)` to create a new message.
  */
-export const ListConfigAssignmentsResponseSchema: GenMessage<ListConfigAssignmentsResponse> = /*@__PURE__*/
+export const ConfigRangeSchema: GenMessage<ConfigRange> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 17);
 
 /**
- * @generated from message config.v1alpha1.GetConfigStatusRequest
+ * @generated from message config.v1alpha1.Labels
  */
-export type GetConfigStatusRequest = Message<"config.v1alpha1.GetConfigStatusRequest"> & {
+export type Labels = Message<"config.v1alpha1.Labels"> & {
   /**
-   * @generated from field: string agent_id = 1;
+   * @generated from field: map<string, string> labels = 1;
    */
-  agentId: string;
+  labels: { [key: string]: string };
 };
 
 /**
- * Describes the message config.v1alpha1.GetConfigStatusRequest.
- * Use `create(GetConfigStatusRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.Labels.
+ * Use `create(LabelsSchema)` to create a new message.
  */
-export const GetConfigStatusRequestSchema: GenMessage<GetConfigStatusRequest> = /*@__PURE__*/
+export const LabelsSchema: GenMessage<Labels> = /*@__PURE__*/
   messageDesc(file_pkg_api_config_v1alpha1_config, 18);
 
 /**
- * @generated from message config.v1alpha1.GetConfigStatusResponse
+ * TODO:
+ *
+ * @generated from message config.v1alpha1.Matcher
  */
-export type GetConfigStatusResponse = Message<"config.v1alpha1.GetConfigStatusResponse"> & {
+export type Matcher = Message<"config.v1alpha1.Matcher"> & {
+};
+
+/**
+ * Describes the message config.v1alpha1.Matcher.
+ * Use `create(MatcherSchema)` to create a new message.
+ */
+export const MatcherSchema: GenMessage<Matcher> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 19);
+
+/**
+ * ConfigAssignment tracks metadata about a config assignment to an agent
+ *
+ * @generated from message config.v1alpha1.ConfigAssignment
+ */
+export type ConfigAssignment = Message<"config.v1alpha1.ConfigAssignment"> & {
   /**
-   * @generated from field: config.v1alpha1.ConfigAssignmentInfo assignment = 1;
+   * @generated from field: string agent_id = 1;
    */
-  assignment?: ConfigAssignmentInfo;
+  agentId: string;
 
   /**
-   * What agent reports
+   * @generated from field: string config_id = 2;
+   */
+  configId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.ConfigSource source = 3;
+   */
+  source: ConfigSource;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp assigned_at = 4;
+   */
+  assignedAt?: Timestamp;
+
+  /**
+   * @generated from field: bytes config_hash = 5;
+   */
+  configHash: Uint8Array;
+
+  /**
+   * @generated from field: config.v1alpha1.AuditInfo audit = 6;
+   */
+  audit?: AuditInfo;
+
+  /**
+   * the policy that made the assignment, for CONFIG_SOURCE_POLICY
    *
-   * @generated from field: bytes effective_config_hash = 2;
+   * @generated from field: string policy_id = 7;
    */
-  effectiveConfigHash: Uint8Array;
+  policyId: string;
 
   /**
-   * What we assigned
+   * roll the agent back to its last known-good config if it fails to apply this one
    *
-   * @generated from field: bytes assigned_config_hash = 3;
+   * @generated from field: bool auto_rollback = 8;
    */
-  assignedConfigHash: Uint8Array;
+  autoRollback: boolean;
 
   /**
-   * @generated from field: bool in_sync = 4;
+   * set when the assignment was restored by a rollback
+   *
+   * @generated from field: config.v1alpha1.ConfigRollback rollback = 9;
    */
-  inSync: boolean;
+  rollback?: ConfigRollback;
 };
 
 /**
- * Describes the message config.v1alpha1.GetConfigStatusResponse.
- * Use `create(GetConfigStatusResponseSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ConfigAssignment.
+ * Use `create(ConfigAssignmentSchema)` to create a new message.
  */
-export const GetConfigStatusResponseSchema: GenMessage<GetConfigStatusResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 19);
+export const ConfigAssignmentSchema: GenMessage<ConfigAssignment> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 20);
 
 /**
- * @generated from message config.v1alpha1.BatchAssignConfigRequest
+ * ConfigRollback records that an agent was rolled back to its last known-good config
+ * after failing to apply its assigned config
+ *
+ * @generated from message config.v1alpha1.ConfigRollback
  */
-export type BatchAssignConfigRequest = Message<"config.v1alpha1.BatchAssignConfigRequest"> & {
+export type ConfigRollback = Message<"config.v1alpha1.ConfigRollback"> & {
   /**
-   * @generated from field: repeated string agent_ids = 1;
+   * the config the agent failed to apply
+   *
+   * @generated from field: string failed_config_id = 1;
    */
-  agentIds: string[];
+  failedConfigId: string;
+
+  /**
+   * @generated from field: bytes failed_config_hash = 2;
+   */
+  failedConfigHash: Uint8Array;
+
+  /**
+   * @generated from field: string error_message = 3;
+   */
+  errorMessage: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp rolled_back_at = 4;
+   */
+  rolledBackAt?: Timestamp;
+};
+
+/**
+ * Describes the message config.v1alpha1.ConfigRollback.
+ * Use `create(ConfigRollbackSchema)` to create a new message.
+ */
+export const ConfigRollbackSchema: GenMessage<ConfigRollback> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 21);
+
+/**
+ * KnownGoodConfig is the last assigned config an agent reported applying
+ *
+ * @generated from message config.v1alpha1.KnownGoodConfig
+ */
+export type KnownGoodConfig = Message<"config.v1alpha1.KnownGoodConfig"> & {
+  /**
+   * @generated from field: config.v1alpha1.ConfigAssignment assignment = 1;
+   */
+  assignment?: ConfigAssignment;
+
+  /**
+   * @generated from field: config.v1alpha1.Config config = 2;
+   */
+  config?: Config;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp applied_at = 3;
+   */
+  appliedAt?: Timestamp;
+};
+
+/**
+ * Describes the message config.v1alpha1.KnownGoodConfig.
+ * Use `create(KnownGoodConfigSchema)` to create a new message.
+ */
+export const KnownGoodConfigSchema: GenMessage<KnownGoodConfig> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 22);
+
+/**
+ * @generated from message config.v1alpha1.AssignConfigRequest
+ */
+export type AssignConfigRequest = Message<"config.v1alpha1.AssignConfigRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
 
   /**
    * @generated from field: string config_id = 2;
    */
   configId: string;
+
+  /**
+   * source is MANUAL (the default) to assign config_id, or DEFAULT to have the agent
+   * intentionally follow the global default config, in which case config_id must be empty
+   *
+   * @generated from field: config.v1alpha1.ConfigSource source = 3;
+   */
+  source: ConfigSource;
+
+  /**
+   * roll the agent back to its last known-good config if it fails to apply the config
+   *
+   * @generated from field: bool auto_rollback = 4;
+   */
+  autoRollback: boolean;
 };
 
 /**
- * Describes the message config.v1alpha1.BatchAssignConfigRequest.
- * Use `create(BatchAssignConfigRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.AssignConfigRequest.
+ * Use `create(AssignConfigRequestSchema)` to create a new message.
  */
-export const BatchAssignConfigRequestSchema: GenMessage<BatchAssignConfigRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 20);
+export const AssignConfigRequestSchema: GenMessage<AssignConfigRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 23);
 
 /**
- * @generated from message config.v1alpha1.BatchAssignConfigResponse
+ * @generated from message config.v1alpha1.AssignConfigResponse
  */
-export type BatchAssignConfigResponse = Message<"config.v1alpha1.BatchAssignConfigResponse"> & {
+export type AssignConfigResponse = Message<"config.v1alpha1.AssignConfigResponse"> & {
   /**
-   * @generated from field: int32 successful = 1;
+   * @generated from field: bool success = 1;
    */
-  successful: number;
+  success: boolean;
 
   /**
-   * @generated from field: int32 failed = 2;
+   * @generated from field: string message = 2;
    */
-  failed: number;
+  message: string;
 
   /**
-   * @generated from field: repeated string failed_agent_ids = 3;
+   * the config's resource requirements exceeding the capacity reported by the agent
+   *
+   * @generated from field: repeated string warnings = 3;
    */
-  failedAgentIds: string[];
+  warnings: string[];
+};
+
+/**
+ * Describes the message config.v1alpha1.AssignConfigResponse.
+ * Use `create(AssignConfigResponseSchema)` to create a new message.
+ */
+export const AssignConfigResponseSchema: GenMessage<AssignConfigResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 24);
 
+/**
+ * @generated from message config.v1alpha1.GetAgentConfigRequest
+ */
+export type GetAgentConfigRequest = Message<"config.v1alpha1.GetAgentConfigRequest"> & {
   /**
-   * @generated from field: repeated string error_messages = 4;
+   * @generated from field: string agent_id = 1;
    */
-  errorMessages: string[];
+  agentId: string;
 };
 
 /**
- * Describes the message config.v1alpha1.BatchAssignConfigResponse.
- * Use `create(BatchAssignConfigResponseSchema)` to create a new message.
+ * Describes the message config.v1alpha1.GetAgentConfigRequest.
+ * Use `create(GetAgentConfigRequestSchema)` to create a new message.
  */
-export const BatchAssignConfigResponseSchema: GenMessage<BatchAssignConfigResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 21);
+export const GetAgentConfigRequestSchema: GenMessage<GetAgentConfigRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 25);
+
+/**
+ * @generated from message config.v1alpha1.GetAgentConfigResponse
+ */
+export type GetAgentConfigResponse = Message<"config.v1alpha1.GetAgentConfigResponse"> & {
+  /**
+   * @generated from field: string config_id = 1;
+   */
+  configId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.ConfigSource source = 2;
+   */
+  source: ConfigSource;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp assigned_at = 3;
+   */
+  assignedAt?: Timestamp;
+};
+
+/**
+ * Describes the message config.v1alpha1.GetAgentConfigResponse.
+ * Use `create(GetAgentConfigResponseSchema)` to create a new message.
+ */
+export const GetAgentConfigResponseSchema: GenMessage<GetAgentConfigResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 26);
+
+/**
+ * @generated from message config.v1alpha1.UnassignConfigRequest
+ */
+export type UnassignConfigRequest = Message<"config.v1alpha1.UnassignConfigRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.UnassignConfigRequest.
+ * Use `create(UnassignConfigRequestSchema)` to create a new message.
+ */
+export const UnassignConfigRequestSchema: GenMessage<UnassignConfigRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 27);
+
+/**
+ * @generated from message config.v1alpha1.UnassignConfigResponse
+ */
+export type UnassignConfigResponse = Message<"config.v1alpha1.UnassignConfigResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message config.v1alpha1.UnassignConfigResponse.
+ * Use `create(UnassignConfigResponseSchema)` to create a new message.
+ */
+export const UnassignConfigResponseSchema: GenMessage<UnassignConfigResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 28);
+
+/**
+ * @generated from message config.v1alpha1.ListConfigAssignmentsRequest
+ */
+export type ListConfigAssignmentsRequest = Message<"config.v1alpha1.ListConfigAssignmentsRequest"> & {
+  /**
+   * Filter by config
+   *
+   * @generated from field: optional string config_id = 1;
+   */
+  configId?: string;
+
+  /**
+   * @generated from field: config.v1alpha1.AuditFilter audit_filter = 2;
+   */
+  auditFilter?: AuditFilter;
+};
+
+/**
+ * Describes the message config.v1alpha1.ListConfigAssignmentsRequest.
+ * Use `create(ListConfigAssignmentsRequestSchema)` to create a new message.
+ */
+export const ListConfigAssignmentsRequestSchema: GenMessage<ListConfigAssignmentsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 29);
+
+/**
+ * @generated from message config.v1alpha1.ConfigAssignmentInfo
+ */
+export type ConfigAssignmentInfo = Message<"config.v1alpha1.ConfigAssignmentInfo"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string config_id = 2;
+   */
+  configId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.ConfigSource source = 3;
+   */
+  source: ConfigSource;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp assigned_at = 4;
+   */
+  assignedAt?: Timestamp;
+
+  /**
+   * @generated from field: config.v1alpha1.ConfigApplicationStatus status = 5;
+   */
+  status: ConfigApplicationStatus;
+
+  /**
+   * @generated from field: string error_message = 6;
+   */
+  errorMessage: string;
+
+  /**
+   * @generated from field: config.v1alpha1.AuditInfo audit = 7;
+   */
+  audit?: AuditInfo;
+
+  /**
+   * @generated from field: config.v1alpha1.ConfigRollback rollback = 8;
+   */
+  rollback?: ConfigRollback;
+};
+
+/**
+ * Describes the message config.v1alpha1.ConfigAssignmentInfo.
+ * Use `create(ConfigAssignmentInfoSchema)` to create a new message.
+ */
+export const ConfigAssignmentInfoSchema: GenMessage<ConfigAssignmentInfo> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 30);
+
+/**
+ * @generated from message config.v1alpha1.ListConfigAssignmentsResponse
+ */
+export type ListConfigAssignmentsResponse = Message<"config.v1alpha1.ListConfigAssignmentsResponse"> & {
+  /**
+   * @generated from field: repeated config.v1alpha1.ConfigAssignmentInfo assignments = 1;
+   */
+  assignments: ConfigAssignmentInfo[];
+};
+
+/**
+ * Describes the message config.v1alpha1.ListConfigAssignmentsResponse.
+ * Use `create(ListConfigAssignmentsResponseSchema)` to create a new message.
+ */
+export const ListConfigAssignmentsResponseSchema: GenMessage<ListConfigAssignmentsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 31);
+
+/**
+ * @generated from message config.v1alpha1.GetConfigStatusRequest
+ */
+export type GetConfigStatusRequest = Message<"config.v1alpha1.GetConfigStatusRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.GetConfigStatusRequest.
+ * Use `create(GetConfigStatusRequestSchema)` to create a new message.
+ */
+export const GetConfigStatusRequestSchema: GenMessage<GetConfigStatusRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 32);
+
+/**
+ * @generated from message config.v1alpha1.GetConfigStatusResponse
+ */
+export type GetConfigStatusResponse = Message<"config.v1alpha1.GetConfigStatusResponse"> & {
+  /**
+   * @generated from field: config.v1alpha1.ConfigAssignmentInfo assignment = 1;
+   */
+  assignment?: ConfigAssignmentInfo;
+
+  /**
+   * What agent reports
+   *
+   * @generated from field: bytes effective_config_hash = 2;
+   */
+  effectiveConfigHash: Uint8Array;
+
+  /**
+   * What we assigned
+   *
+   * @generated from field: bytes assigned_config_hash = 3;
+   */
+  assignedConfigHash: Uint8Array;
+
+  /**
+   * @generated from field: bool in_sync = 4;
+   */
+  inSync: boolean;
+};
+
+/**
+ * Describes the message config.v1alpha1.GetConfigStatusResponse.
+ * Use `create(GetConfigStatusResponseSchema)` to create a new message.
+ */
+export const GetConfigStatusResponseSchema: GenMessage<GetConfigStatusResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 33);
+
+/**
+ * @generated from message config.v1alpha1.BatchAssignConfigRequest
+ */
+export type BatchAssignConfigRequest = Message<"config.v1alpha1.BatchAssignConfigRequest"> & {
+  /**
+   * @generated from field: repeated string agent_ids = 1;
+   */
+  agentIds: string[];
+
+  /**
+   * @generated from field: string config_id = 2;
+   */
+  configId: string;
+
+  /**
+   * Runs the assignment as a background job: the response only carries the job ID,
+   * and the job result is the BatchAssignConfigResponse
+   *
+   * @generated from field: bool async = 3;
+   */
+  async: boolean;
+};
+
+/**
+ * Describes the message config.v1alpha1.BatchAssignConfigRequest.
+ * Use `create(BatchAssignConfigRequestSchema)` to create a new message.
+ */
+export const BatchAssignConfigRequestSchema: GenMessage<BatchAssignConfigRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 34);
+
+/**
+ * @generated from message config.v1alpha1.BatchAssignConfigResponse
+ */
+export type BatchAssignConfigResponse = Message<"config.v1alpha1.BatchAssignConfigResponse"> & {
+  /**
+   * @generated from field: int32 successful = 1;
+   */
+  successful: number;
+
+  /**
+   * @generated from field: int32 failed = 2;
+   */
+  failed: number;
+
+  /**
+   * @generated from field: repeated string failed_agent_ids = 3;
+   */
+  failedAgentIds: string[];
+
+  /**
+   * @generated from field: repeated string error_messages = 4;
+   */
+  errorMessages: string[];
+
+  /**
+   * ID of the background job, for async requests
+   *
+   * @generated from field: string job_id = 5;
+   */
+  jobId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.BatchAssignConfigResponse.
+ * Use `create(BatchAssignConfigResponseSchema)` to create a new message.
+ */
+export const BatchAssignConfigResponseSchema: GenMessage<BatchAssignConfigResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 35);
+
+/**
+ * @generated from message config.v1alpha1.AssignConfigByLabelsRequest
+ */
+export type AssignConfigByLabelsRequest = Message<"config.v1alpha1.AssignConfigByLabelsRequest"> & {
+  /**
+   * Agent labels to match
+   *
+   * @generated from field: map<string, string> labels = 1;
+   */
+  labels: { [key: string]: string };
+
+  /**
+   * @generated from field: string config_id = 2;
+   */
+  configId: string;
+
+  /**
+   * label selector expression agents must also match, e.g. "env in (prod,staging),!legacy".
+   * Groups of comma separated requirements are separated by ||, agents matching any group
+   * are selected. labels or selector_expression must be non-empty.
+   *
+   * @generated from field: string selector_expression = 3;
+   */
+  selectorExpression: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.AssignConfigByLabelsRequest.
+ * Use `create(AssignConfigByLabelsRequestSchema)` to create a new message.
+ */
+export const AssignConfigByLabelsRequestSchema: GenMessage<AssignConfigByLabelsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 36);
+
+/**
+ * @generated from message config.v1alpha1.AssignConfigByLabelsResponse
+ */
+export type AssignConfigByLabelsResponse = Message<"config.v1alpha1.AssignConfigByLabelsResponse"> & {
+  /**
+   * @generated from field: repeated string matched_agent_ids = 1;
+   */
+  matchedAgentIds: string[];
+
+  /**
+   * @generated from field: int32 successful = 2;
+   */
+  successful: number;
+
+  /**
+   * @generated from field: int32 failed = 3;
+   */
+  failed: number;
+};
+
+/**
+ * Describes the message config.v1alpha1.AssignConfigByLabelsResponse.
+ * Use `create(AssignConfigByLabelsResponseSchema)` to create a new message.
+ */
+export const AssignConfigByLabelsResponseSchema: GenMessage<AssignConfigByLabelsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 37);
+
+/**
+ * AssignmentPolicy assigns config_id to every agent matching selector, unless the agent
+ * has a manual or explicit default assignment. When several policies match an agent the
+ * one with the highest priority applies, ties are broken by policy ID.
+ *
+ * @generated from message config.v1alpha1.AssignmentPolicy
+ */
+export type AssignmentPolicy = Message<"config.v1alpha1.AssignmentPolicy"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * agent labels that must all match. One of selector, selector_expression and agent_ids
+   * must be non-empty.
+   *
+   * @generated from field: map<string, string> selector = 2;
+   */
+  selector: { [key: string]: string };
+
+  /**
+   * @generated from field: string config_id = 3;
+   */
+  configId: string;
+
+  /**
+   * @generated from field: int32 priority = 4;
+   */
+  priority: number;
+
+  /**
+   * set by the server on every change
+   *
+   * @generated from field: config.v1alpha1.AuditInfo audit = 5;
+   */
+  audit?: AuditInfo;
+
+  /**
+   * agents the policy applies to regardless of their labels
+   *
+   * @generated from field: repeated string agent_ids = 6;
+   */
+  agentIds: string[];
+
+  /**
+   * label selector expression agents must also match, see AssignConfigByLabelsRequest
+   *
+   * @generated from field: string selector_expression = 7;
+   */
+  selectorExpression: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.AssignmentPolicy.
+ * Use `create(AssignmentPolicySchema)` to create a new message.
+ */
+export const AssignmentPolicySchema: GenMessage<AssignmentPolicy> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 38);
+
+/**
+ * @generated from message config.v1alpha1.PutAssignmentPolicyRequest
+ */
+export type PutAssignmentPolicyRequest = Message<"config.v1alpha1.PutAssignmentPolicyRequest"> & {
+  /**
+   * @generated from field: config.v1alpha1.AssignmentPolicy policy = 1;
+   */
+  policy?: AssignmentPolicy;
+};
+
+/**
+ * Describes the message config.v1alpha1.PutAssignmentPolicyRequest.
+ * Use `create(PutAssignmentPolicyRequestSchema)` to create a new message.
+ */
+export const PutAssignmentPolicyRequestSchema: GenMessage<PutAssignmentPolicyRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 39);
+
+/**
+ * @generated from message config.v1alpha1.AssignmentPolicyReference
+ */
+export type AssignmentPolicyReference = Message<"config.v1alpha1.AssignmentPolicyReference"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.AssignmentPolicyReference.
+ * Use `create(AssignmentPolicyReferenceSchema)` to create a new message.
+ */
+export const AssignmentPolicyReferenceSchema: GenMessage<AssignmentPolicyReference> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 40);
+
+/**
+ * @generated from message config.v1alpha1.ListAssignmentPoliciesRequest
+ */
+export type ListAssignmentPoliciesRequest = Message<"config.v1alpha1.ListAssignmentPoliciesRequest"> & {
+};
+
+/**
+ * Describes the message config.v1alpha1.ListAssignmentPoliciesRequest.
+ * Use `create(ListAssignmentPoliciesRequestSchema)` to create a new message.
+ */
+export const ListAssignmentPoliciesRequestSchema: GenMessage<ListAssignmentPoliciesRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 41);
+
+/**
+ * AgentGroup is a named set of agents : the agents matching its selector, and its explicit
+ * members. The config of a group is assigned to its agents like the config of an assignment
+ * policy with the group's priority, including to the agents joining the group later.
+ *
+ * @generated from message config.v1alpha1.AgentGroup
+ */
+export type AgentGroup = Message<"config.v1alpha1.AgentGroup"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string description = 2;
+   */
+  description: string;
+
+  /**
+   * agent labels that must all match
+   *
+   * @generated from field: map<string, string> selector = 3;
+   */
+  selector: { [key: string]: string };
+
+  /**
+   * agents in the group regardless of their labels
+   *
+   * @generated from field: repeated string agent_ids = 4;
+   */
+  agentIds: string[];
+
+  /**
+   * optional, the config assigned to the agents of the group
+   *
+   * @generated from field: string config_id = 5;
+   */
+  configId: string;
+
+  /**
+   * @generated from field: int32 priority = 6;
+   */
+  priority: number;
+
+  /**
+   * set by the server on every change
+   *
+   * @generated from field: config.v1alpha1.AuditInfo audit = 7;
+   */
+  audit?: AuditInfo;
+
+  /**
+   * label selector expression agents must also match, see AssignConfigByLabelsRequest
+   *
+   * @generated from field: string selector_expression = 8;
+   */
+  selectorExpression: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentGroup.
+ * Use `create(AgentGroupSchema)` to create a new message.
+ */
+export const AgentGroupSchema: GenMessage<AgentGroup> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 42);
+
+/**
+ * @generated from message config.v1alpha1.CreateGroupRequest
+ */
+export type CreateGroupRequest = Message<"config.v1alpha1.CreateGroupRequest"> & {
+  /**
+   * @generated from field: config.v1alpha1.AgentGroup group = 1;
+   */
+  group?: AgentGroup;
+};
+
+/**
+ * Describes the message config.v1alpha1.CreateGroupRequest.
+ * Use `create(CreateGroupRequestSchema)` to create a new message.
+ */
+export const CreateGroupRequestSchema: GenMessage<CreateGroupRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 43);
+
+/**
+ * @generated from message config.v1alpha1.UpdateGroupRequest
+ */
+export type UpdateGroupRequest = Message<"config.v1alpha1.UpdateGroupRequest"> & {
+  /**
+   * @generated from field: config.v1alpha1.AgentGroup group = 1;
+   */
+  group?: AgentGroup;
+};
+
+/**
+ * Describes the message config.v1alpha1.UpdateGroupRequest.
+ * Use `create(UpdateGroupRequestSchema)` to create a new message.
+ */
+export const UpdateGroupRequestSchema: GenMessage<UpdateGroupRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 44);
+
+/**
+ * @generated from message config.v1alpha1.AgentGroupReference
+ */
+export type AgentGroupReference = Message<"config.v1alpha1.AgentGroupReference"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentGroupReference.
+ * Use `create(AgentGroupReferenceSchema)` to create a new message.
+ */
+export const AgentGroupReferenceSchema: GenMessage<AgentGroupReference> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 45);
+
+/**
+ * @generated from message config.v1alpha1.ListGroupsRequest
+ */
+export type ListGroupsRequest = Message<"config.v1alpha1.ListGroupsRequest"> & {
+};
+
+/**
+ * Describes the message config.v1alpha1.ListGroupsRequest.
+ * Use `create(ListGroupsRequestSchema)` to create a new message.
+ */
+export const ListGroupsRequestSchema: GenMessage<ListGroupsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 46);
+
+/**
+ * @generated from message config.v1alpha1.ListGroupsResponse
+ */
+export type ListGroupsResponse = Message<"config.v1alpha1.ListGroupsResponse"> & {
+  /**
+   * @generated from field: repeated config.v1alpha1.AgentGroup groups = 1;
+   */
+  groups: AgentGroup[];
+
+  /**
+   * number of agents in each group, by group ID
+   *
+   * @generated from field: map<string, int32> agent_counts = 2;
+   */
+  agentCounts: { [key: string]: number };
+};
+
+/**
+ * Describes the message config.v1alpha1.ListGroupsResponse.
+ * Use `create(ListGroupsResponseSchema)` to create a new message.
+ */
+export const ListGroupsResponseSchema: GenMessage<ListGroupsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 47);
+
+/**
+ * AssignmentPolicyConflict reports an agent matched by policies assigning different configs
+ *
+ * @generated from message config.v1alpha1.AssignmentPolicyConflict
+ */
+export type AssignmentPolicyConflict = Message<"config.v1alpha1.AssignmentPolicyConflict"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * the matching policies, in order of precedence
+   *
+   * @generated from field: repeated string policy_ids = 2;
+   */
+  policyIds: string[];
+
+  /**
+   * the policy that applies, empty if the agent is manually overridden
+   *
+   * @generated from field: string applied_policy_id = 3;
+   */
+  appliedPolicyId: string;
+
+  /**
+   * set when several of policy_ids share the highest priority, which is usually unintended
+   *
+   * @generated from field: bool ambiguous = 4;
+   */
+  ambiguous: boolean;
+};
+
+/**
+ * Describes the message config.v1alpha1.AssignmentPolicyConflict.
+ * Use `create(AssignmentPolicyConflictSchema)` to create a new message.
+ */
+export const AssignmentPolicyConflictSchema: GenMessage<AssignmentPolicyConflict> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 48);
+
+/**
+ * @generated from message config.v1alpha1.ListAssignmentPoliciesResponse
+ */
+export type ListAssignmentPoliciesResponse = Message<"config.v1alpha1.ListAssignmentPoliciesResponse"> & {
+  /**
+   * in order of precedence
+   *
+   * @generated from field: repeated config.v1alpha1.AssignmentPolicy policies = 1;
+   */
+  policies: AssignmentPolicy[];
+
+  /**
+   * @generated from field: repeated config.v1alpha1.AssignmentPolicyConflict conflicts = 2;
+   */
+  conflicts: AssignmentPolicyConflict[];
+
+  /**
+   * number of agents each policy currently applies to, by policy ID
+   *
+   * @generated from field: map<string, int32> applied_agents = 3;
+   */
+  appliedAgents: { [key: string]: number };
+};
+
+/**
+ * Describes the message config.v1alpha1.ListAssignmentPoliciesResponse.
+ * Use `create(ListAssignmentPoliciesResponseSchema)` to create a new message.
+ */
+export const ListAssignmentPoliciesResponseSchema: GenMessage<ListAssignmentPoliciesResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 49);
+
+/**
+ * @generated from message config.v1alpha1.GetAssignmentExplanationRequest
+ */
+export type GetAssignmentExplanationRequest = Message<"config.v1alpha1.GetAssignmentExplanationRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.GetAssignmentExplanationRequest.
+ * Use `create(GetAssignmentExplanationRequestSchema)` to create a new message.
+ */
+export const GetAssignmentExplanationRequestSchema: GenMessage<GetAssignmentExplanationRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 50);
+
+/**
+ * AssignmentCandidate is a source that could assign a config to an agent
+ *
+ * @generated from message config.v1alpha1.AssignmentCandidate
+ */
+export type AssignmentCandidate = Message<"config.v1alpha1.AssignmentCandidate"> & {
+  /**
+   * @generated from field: config.v1alpha1.ConfigSource source = 1;
+   */
+  source: ConfigSource;
+
+  /**
+   * empty for the DEFAULT and FALLBACK sources
+   *
+   * @generated from field: string config_id = 2;
+   */
+  configId: string;
+
+  /**
+   * for CONFIG_SOURCE_POLICY
+   *
+   * @generated from field: string policy_id = 3;
+   */
+  policyId: string;
+
+  /**
+   * set on the candidate whose config is assigned to the agent
+   *
+   * @generated from field: bool effective = 4;
+   */
+  effective: boolean;
+
+  /**
+   * why the candidate applies or is overridden
+   *
+   * @generated from field: string reason = 5;
+   */
+  reason: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.AssignmentCandidate.
+ * Use `create(AssignmentCandidateSchema)` to create a new message.
+ */
+export const AssignmentCandidateSchema: GenMessage<AssignmentCandidate> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 51);
+
+/**
+ * AssignmentExplanation lists the candidate config sources of an agent in order of
+ * precedence, by default manual assignments, then assignment policies, then the config of
+ * the bootstrap token, then the built-in fallback config.
+ *
+ * @generated from message config.v1alpha1.AssignmentExplanation
+ */
+export type AssignmentExplanation = Message<"config.v1alpha1.AssignmentExplanation"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.ConfigSource effective_source = 2;
+   */
+  effectiveSource: ConfigSource;
+
+  /**
+   * @generated from field: string effective_config_id = 3;
+   */
+  effectiveConfigId: string;
+
+  /**
+   * @generated from field: repeated config.v1alpha1.AssignmentCandidate candidates = 4;
+   */
+  candidates: AssignmentCandidate[];
+
+  /**
+   * the precedence of the MANUAL, POLICY and BOOTSTRAP sources configured on the server,
+   * highest first. DEFAULT ranks as MANUAL, and FALLBACK always comes last.
+   *
+   * @generated from field: repeated config.v1alpha1.ConfigSource precedence = 5;
+   */
+  precedence: ConfigSource[];
+};
+
+/**
+ * Describes the message config.v1alpha1.AssignmentExplanation.
+ * Use `create(AssignmentExplanationSchema)` to create a new message.
+ */
+export const AssignmentExplanationSchema: GenMessage<AssignmentExplanation> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 52);
+
+/**
+ * ComponentPolicy restricts the collector components that may be used in the configs of the
+ * agents matching selector. Components are given as kind/type, e.g. exporters/debug, or as a
+ * bare type matching components of any kind. Component names after the type are ignored.
+ * Policies without a selector apply fleet-wide and are also enforced when configs are saved,
+ * other policies are enforced when configs are assigned.
+ *
+ * @generated from message config.v1alpha1.ComponentPolicy
+ */
+export type ComponentPolicy = Message<"config.v1alpha1.ComponentPolicy"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * agent labels that must all match, empty applies to every agent
+   *
+   * @generated from field: map<string, string> selector = 2;
+   */
+  selector: { [key: string]: string };
+
+  /**
+   * components that must not be used
+   *
+   * @generated from field: repeated string denied = 3;
+   */
+  denied: string[];
+
+  /**
+   * if set, the only components that may be used
+   *
+   * @generated from field: repeated string allowed = 4;
+   */
+  allowed: string[];
+
+  /**
+   * set by the server on every change
+   *
+   * @generated from field: config.v1alpha1.AuditInfo audit = 5;
+   */
+  audit?: AuditInfo;
+};
+
+/**
+ * Describes the message config.v1alpha1.ComponentPolicy.
+ * Use `create(ComponentPolicySchema)` to create a new message.
+ */
+export const ComponentPolicySchema: GenMessage<ComponentPolicy> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 53);
+
+/**
+ * @generated from message config.v1alpha1.PutComponentPolicyRequest
+ */
+export type PutComponentPolicyRequest = Message<"config.v1alpha1.PutComponentPolicyRequest"> & {
+  /**
+   * @generated from field: config.v1alpha1.ComponentPolicy policy = 1;
+   */
+  policy?: ComponentPolicy;
+};
+
+/**
+ * Describes the message config.v1alpha1.PutComponentPolicyRequest.
+ * Use `create(PutComponentPolicyRequestSchema)` to create a new message.
+ */
+export const PutComponentPolicyRequestSchema: GenMessage<PutComponentPolicyRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 54);
+
+/**
+ * @generated from message config.v1alpha1.ComponentPolicyReference
+ */
+export type ComponentPolicyReference = Message<"config.v1alpha1.ComponentPolicyReference"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.ComponentPolicyReference.
+ * Use `create(ComponentPolicyReferenceSchema)` to create a new message.
+ */
+export const ComponentPolicyReferenceSchema: GenMessage<ComponentPolicyReference> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 55);
+
+/**
+ * @generated from message config.v1alpha1.ListComponentPoliciesRequest
+ */
+export type ListComponentPoliciesRequest = Message<"config.v1alpha1.ListComponentPoliciesRequest"> & {
+};
+
+/**
+ * Describes the message config.v1alpha1.ListComponentPoliciesRequest.
+ * Use `create(ListComponentPoliciesRequestSchema)` to create a new message.
+ */
+export const ListComponentPoliciesRequestSchema: GenMessage<ListComponentPoliciesRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 56);
+
+/**
+ * ComponentPolicyViolation reports a component of an agent's assigned config forbidden by a policy
+ *
+ * @generated from message config.v1alpha1.ComponentPolicyViolation
+ */
+export type ComponentPolicyViolation = Message<"config.v1alpha1.ComponentPolicyViolation"> & {
+  /**
+   * @generated from field: string policy_id = 1;
+   */
+  policyId: string;
+
+  /**
+   * @generated from field: string agent_id = 2;
+   */
+  agentId: string;
+
+  /**
+   * empty if the agent follows the default config
+   *
+   * @generated from field: string config_id = 3;
+   */
+  configId: string;
+
+  /**
+   * the offending component ID, e.g. exporters/debug
+   *
+   * @generated from field: string component = 4;
+   */
+  component: string;
+
+  /**
+   * @generated from field: string reason = 5;
+   */
+  reason: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.ComponentPolicyViolation.
+ * Use `create(ComponentPolicyViolationSchema)` to create a new message.
+ */
+export const ComponentPolicyViolationSchema: GenMessage<ComponentPolicyViolation> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 57);
+
+/**
+ * @generated from message config.v1alpha1.ListComponentPoliciesResponse
+ */
+export type ListComponentPoliciesResponse = Message<"config.v1alpha1.ListComponentPoliciesResponse"> & {
+  /**
+   * sorted by ID
+   *
+   * @generated from field: repeated config.v1alpha1.ComponentPolicy policies = 1;
+   */
+  policies: ComponentPolicy[];
+
+  /**
+   * violations of configs assigned before the policies were put in place, sorted by agent ID
+   *
+   * @generated from field: repeated config.v1alpha1.ComponentPolicyViolation violations = 2;
+   */
+  violations: ComponentPolicyViolation[];
+};
+
+/**
+ * Describes the message config.v1alpha1.ListComponentPoliciesResponse.
+ * Use `create(ListComponentPoliciesResponseSchema)` to create a new message.
+ */
+export const ListComponentPoliciesResponseSchema: GenMessage<ListComponentPoliciesResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 58);
+
+/**
+ * CollectorDistribution describes a collector build: the components it includes and where to
+ * download it. Agents run the distribution whose name and version match the service.name and
+ * service.version they report.
+ *
+ * @generated from message config.v1alpha1.CollectorDistribution
+ */
+export type CollectorDistribution = Message<"config.v1alpha1.CollectorDistribution"> & {
+  /**
+   * e.g. otelcol-contrib
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string version = 2;
+   */
+  version: string;
+
+  /**
+   * components included in the distribution as kind/type, e.g. receivers/otlp
+   *
+   * @generated from field: repeated string components = 3;
+   */
+  components: string[];
+
+  /**
+   * @generated from field: repeated config.v1alpha1.DistributionArtifact artifacts = 4;
+   */
+  artifacts: DistributionArtifact[];
+
+  /**
+   * set by the server on every change
+   *
+   * @generated from field: config.v1alpha1.AuditInfo audit = 5;
+   */
+  audit?: AuditInfo;
+};
+
+/**
+ * Describes the message config.v1alpha1.CollectorDistribution.
+ * Use `create(CollectorDistributionSchema)` to create a new message.
+ */
+export const CollectorDistributionSchema: GenMessage<CollectorDistribution> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 59);
+
+/**
+ * DistributionArtifact is a downloadable build of a distribution for a platform
+ *
+ * @generated from message config.v1alpha1.DistributionArtifact
+ */
+export type DistributionArtifact = Message<"config.v1alpha1.DistributionArtifact"> & {
+  /**
+   * os/arch, e.g. linux/amd64
+   *
+   * @generated from field: string platform = 1;
+   */
+  platform: string;
+
+  /**
+   * @generated from field: string url = 2;
+   */
+  url: string;
+
+  /**
+   * hex encoded SHA-256 of the artifact
+   *
+   * @generated from field: string sha256 = 3;
+   */
+  sha256: string;
+
+  /**
+   * optional, ed25519 signature of the SHA-256 digest of the artifact, verified by supervisors
+   * configured with a package verification key
+   *
+   * @generated from field: bytes signature = 4;
+   */
+  signature: Uint8Array;
+};
+
+/**
+ * Describes the message config.v1alpha1.DistributionArtifact.
+ * Use `create(DistributionArtifactSchema)` to create a new message.
+ */
+export const DistributionArtifactSchema: GenMessage<DistributionArtifact> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 60);
+
+/**
+ * @generated from message config.v1alpha1.PutCollectorDistributionRequest
+ */
+export type PutCollectorDistributionRequest = Message<"config.v1alpha1.PutCollectorDistributionRequest"> & {
+  /**
+   * @generated from field: config.v1alpha1.CollectorDistribution distribution = 1;
+   */
+  distribution?: CollectorDistribution;
+};
+
+/**
+ * Describes the message config.v1alpha1.PutCollectorDistributionRequest.
+ * Use `create(PutCollectorDistributionRequestSchema)` to create a new message.
+ */
+export const PutCollectorDistributionRequestSchema: GenMessage<PutCollectorDistributionRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 61);
+
+/**
+ * @generated from message config.v1alpha1.CollectorDistributionReference
+ */
+export type CollectorDistributionReference = Message<"config.v1alpha1.CollectorDistributionReference"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: string version = 2;
+   */
+  version: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.CollectorDistributionReference.
+ * Use `create(CollectorDistributionReferenceSchema)` to create a new message.
+ */
+export const CollectorDistributionReferenceSchema: GenMessage<CollectorDistributionReference> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 62);
+
+/**
+ * @generated from message config.v1alpha1.ListCollectorDistributionsRequest
+ */
+export type ListCollectorDistributionsRequest = Message<"config.v1alpha1.ListCollectorDistributionsRequest"> & {
+  /**
+   * if set, only lists the versions of the named distribution
+   *
+   * @generated from field: string name = 1;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.ListCollectorDistributionsRequest.
+ * Use `create(ListCollectorDistributionsRequestSchema)` to create a new message.
+ */
+export const ListCollectorDistributionsRequestSchema: GenMessage<ListCollectorDistributionsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 63);
+
+/**
+ * @generated from message config.v1alpha1.ListCollectorDistributionsResponse
+ */
+export type ListCollectorDistributionsResponse = Message<"config.v1alpha1.ListCollectorDistributionsResponse"> & {
+  /**
+   * sorted by name, then version
+   *
+   * @generated from field: repeated config.v1alpha1.CollectorDistribution distributions = 1;
+   */
+  distributions: CollectorDistribution[];
+};
+
+/**
+ * Describes the message config.v1alpha1.ListCollectorDistributionsResponse.
+ * Use `create(ListCollectorDistributionsResponseSchema)` to create a new message.
+ */
+export const ListCollectorDistributionsResponseSchema: GenMessage<ListCollectorDistributionsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 64);
+
+/**
+ * @generated from message config.v1alpha1.CheckConfigCompatibilityRequest
+ */
+export type CheckConfigCompatibilityRequest = Message<"config.v1alpha1.CheckConfigCompatibilityRequest"> & {
+  /**
+   * @generated from field: string config_id = 1;
+   */
+  configId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.CollectorDistributionReference distribution = 2;
+   */
+  distribution?: CollectorDistributionReference;
+};
+
+/**
+ * Describes the message config.v1alpha1.CheckConfigCompatibilityRequest.
+ * Use `create(CheckConfigCompatibilityRequestSchema)` to create a new message.
+ */
+export const CheckConfigCompatibilityRequestSchema: GenMessage<CheckConfigCompatibilityRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 65);
+
+/**
+ * @generated from message config.v1alpha1.ConfigCompatibility
+ */
+export type ConfigCompatibility = Message<"config.v1alpha1.ConfigCompatibility"> & {
+  /**
+   * @generated from field: bool compatible = 1;
+   */
+  compatible: boolean;
+
+  /**
+   * components used by the config the distribution doesn't include, as kind/type
+   *
+   * @generated from field: repeated string missing_components = 2;
+   */
+  missingComponents: string[];
+};
+
+/**
+ * Describes the message config.v1alpha1.ConfigCompatibility.
+ * Use `create(ConfigCompatibilitySchema)` to create a new message.
+ */
+export const ConfigCompatibilitySchema: GenMessage<ConfigCompatibility> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 66);
+
+/**
+ * @generated from message config.v1alpha1.RollingDeploymentRequest
+ */
+export type RollingDeploymentRequest = Message<"config.v1alpha1.RollingDeploymentRequest"> & {
+  /**
+   * @generated from field: string config_id = 1;
+   */
+  configId: string;
+
+  /**
+   * @generated from field: repeated string agent_ids = 2;
+   */
+  agentIds: string[];
+
+  /**
+   * Alternative to agent_ids
+   *
+   * @generated from field: map<string, string> agent_labels = 3;
+   */
+  agentLabels: { [key: string]: string };
+
+  /**
+   * Agents per batch (default: 1)
+   *
+   * @generated from field: int32 batch_size = 4;
+   */
+  batchSize: number;
+
+  /**
+   * Delay between batches (default: 0)
+   *
+   * @generated from field: int32 batch_delay_seconds = 5;
+   */
+  batchDelaySeconds: number;
+
+  /**
+   * Stop after N failures (default: 0 = no limit)
+   *
+   * @generated from field: int32 max_failures = 6;
+   */
+  maxFailures: number;
+
+  /**
+   * Smears config application within each batch: every agent is assigned the config
+   * after a per-agent delay of up to this many seconds (default: 0 = no jitter)
+   *
+   * @generated from field: int32 max_apply_jitter_seconds = 7;
+   */
+  maxApplyJitterSeconds: number;
+
+  /**
+   * roll agents back to their last known-good config if they fail to apply the config
+   *
+   * @generated from field: bool auto_rollback = 8;
+   */
+  autoRollback: boolean;
+
+  /**
+   * Waits up to this many seconds for each agent of a batch to report applying the config
+   * before moving to the next batch, agents failing to apply it or not applying it in time
+   * count as failed. Agents not reporting remote config statuses are waited on to report
+   * being healthy instead. (default: 0 = agents count as applied once they are assigned)
+   *
+   * @generated from field: int32 apply_timeout_seconds = 9;
+   */
+  applyTimeoutSeconds: number;
+
+  /**
+   * label selector expression agents must also match, see AssignConfigByLabelsRequest.
+   * Alternative to agent_ids, along with agent_labels.
+   *
+   * @generated from field: string agent_selector_expression = 10;
+   */
+  agentSelectorExpression: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.RollingDeploymentRequest.
+ * Use `create(RollingDeploymentRequestSchema)` to create a new message.
+ */
+export const RollingDeploymentRequestSchema: GenMessage<RollingDeploymentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 67);
+
+/**
+ * DeploymentJob is the payload of the background job executing a rolling deployment
+ *
+ * @generated from message config.v1alpha1.DeploymentJob
+ */
+export type DeploymentJob = Message<"config.v1alpha1.DeploymentJob"> & {
+  /**
+   * @generated from field: string deployment_id = 1;
+   */
+  deploymentId: string;
+
+  /**
+   * agents targeted by the deployment, in rollout order
+   *
+   * @generated from field: repeated string agent_ids = 2;
+   */
+  agentIds: string[];
+
+  /**
+   * @generated from field: config.v1alpha1.RollingDeploymentRequest request = 3;
+   */
+  request?: RollingDeploymentRequest;
+
+  /**
+   * the deployment rolled back, for rollbacks
+   *
+   * @generated from field: string rollback_of = 4;
+   */
+  rollbackOf: string;
+
+  /**
+   * sizes of the batches agent_ids are split in, if they differ from request.batch_size
+   *
+   * @generated from field: repeated int32 batch_sizes = 5;
+   */
+  batchSizes: number[];
+};
+
+/**
+ * Describes the message config.v1alpha1.DeploymentJob.
+ * Use `create(DeploymentJobSchema)` to create a new message.
+ */
+export const DeploymentJobSchema: GenMessage<DeploymentJob> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 68);
+
+/**
+ * @generated from message config.v1alpha1.RollingDeploymentResponse
+ */
+export type RollingDeploymentResponse = Message<"config.v1alpha1.RollingDeploymentResponse"> & {
+  /**
+   * @generated from field: string deployment_id = 1;
+   */
+  deploymentId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.RollingDeploymentResponse.
+ * Use `create(RollingDeploymentResponseSchema)` to create a new message.
+ */
+export const RollingDeploymentResponseSchema: GenMessage<RollingDeploymentResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 69);
+
+/**
+ * @generated from message config.v1alpha1.AgentDeploymentStatus
+ */
+export type AgentDeploymentStatus = Message<"config.v1alpha1.AgentDeploymentStatus"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.AgentDeploymentState state = 2;
+   */
+  state: AgentDeploymentState;
+
+  /**
+   * @generated from field: string error_message = 3;
+   */
+  errorMessage: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp applied_at = 4;
+   */
+  appliedAt?: Timestamp;
+
+  /**
+   * When the agent started applying the config, used with applied_at to
+   * estimate apply latency for future deployments
+   *
+   * @generated from field: google.protobuf.Timestamp started_at = 5;
+   */
+  startedAt?: Timestamp;
+
+  /**
+   * the batch the agent is part of, starting at 1
+   *
+   * @generated from field: int32 batch = 6;
+   */
+  batch: number;
+
+  /**
+   * the assignment of the agent when the deployment started, restored by rolling the
+   * deployment back; unset if the agent had none
+   *
+   * @generated from field: config.v1alpha1.ConfigAssignment previous_assignment = 7;
+   */
+  previousAssignment?: ConfigAssignment;
+
+  /**
+   * the version of the previously assigned config
+   *
+   * @generated from field: config.v1alpha1.Config previous_config = 8;
+   */
+  previousConfig?: Config;
+};
+
+/**
+ * Describes the message config.v1alpha1.AgentDeploymentStatus.
+ * Use `create(AgentDeploymentStatusSchema)` to create a new message.
+ */
+export const AgentDeploymentStatusSchema: GenMessage<AgentDeploymentStatus> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 70);
+
+/**
+ * @generated from message config.v1alpha1.DeploymentStatus
+ */
+export type DeploymentStatus = Message<"config.v1alpha1.DeploymentStatus"> & {
+  /**
+   * @generated from field: string deployment_id = 1;
+   */
+  deploymentId: string;
+
+  /**
+   * @generated from field: string config_id = 2;
+   */
+  configId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.DeploymentState state = 3;
+   */
+  state: DeploymentState;
+
+  /**
+   * @generated from field: int32 total_agents = 4;
+   */
+  totalAgents: number;
+
+  /**
+   * @generated from field: int32 completed_agents = 5;
+   */
+  completedAgents: number;
+
+  /**
+   * @generated from field: int32 failed_agents = 6;
+   */
+  failedAgents: number;
+
+  /**
+   * @generated from field: int32 pending_agents = 7;
+   */
+  pendingAgents: number;
+
+  /**
+   * @generated from field: int32 current_batch = 8;
+   */
+  currentBatch: number;
+
+  /**
+   * @generated from field: repeated config.v1alpha1.AgentDeploymentStatus agent_statuses = 9;
+   */
+  agentStatuses: AgentDeploymentStatus[];
+
+  /**
+   * @generated from field: google.protobuf.Timestamp started_at = 10;
+   */
+  startedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp completed_at = 11;
+   */
+  completedAt?: Timestamp;
+
+  /**
+   * Estimated completion time, accounting for batch delays and apply jitter
+   *
+   * @generated from field: google.protobuf.Timestamp projected_completion_at = 12;
+   */
+  projectedCompletionAt?: Timestamp;
+
+  /**
+   * @generated from field: config.v1alpha1.AuditInfo audit = 13;
+   */
+  audit?: AuditInfo;
+
+  /**
+   * the deployment this one rolls back, restoring the previous config of each agent
+   * rather than assigning config_id
+   *
+   * @generated from field: string rollback_of = 14;
+   */
+  rollbackOf: string;
+
+  /**
+   * the deployment that rolled this one back
+   *
+   * @generated from field: string rolled_back_by = 15;
+   */
+  rolledBackBy: string;
+
+  /**
+   * tenant the deployment was started in
+   *
+   * @generated from field: string tenant = 16;
+   */
+  tenant: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.DeploymentStatus.
+ * Use `create(DeploymentStatusSchema)` to create a new message.
+ */
+export const DeploymentStatusSchema: GenMessage<DeploymentStatus> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 71);
+
+/**
+ * @generated from message config.v1alpha1.GetDeploymentStatusRequest
+ */
+export type GetDeploymentStatusRequest = Message<"config.v1alpha1.GetDeploymentStatusRequest"> & {
+  /**
+   * @generated from field: string deployment_id = 1;
+   */
+  deploymentId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.GetDeploymentStatusRequest.
+ * Use `create(GetDeploymentStatusRequestSchema)` to create a new message.
+ */
+export const GetDeploymentStatusRequestSchema: GenMessage<GetDeploymentStatusRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 72);
+
+/**
+ * @generated from message config.v1alpha1.GetDeploymentStatusResponse
+ */
+export type GetDeploymentStatusResponse = Message<"config.v1alpha1.GetDeploymentStatusResponse"> & {
+  /**
+   * @generated from field: config.v1alpha1.DeploymentStatus status = 1;
+   */
+  status?: DeploymentStatus;
+};
+
+/**
+ * Describes the message config.v1alpha1.GetDeploymentStatusResponse.
+ * Use `create(GetDeploymentStatusResponseSchema)` to create a new message.
+ */
+export const GetDeploymentStatusResponseSchema: GenMessage<GetDeploymentStatusResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 73);
+
+/**
+ * @generated from message config.v1alpha1.PauseDeploymentRequest
+ */
+export type PauseDeploymentRequest = Message<"config.v1alpha1.PauseDeploymentRequest"> & {
+  /**
+   * @generated from field: string deployment_id = 1;
+   */
+  deploymentId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.PauseDeploymentRequest.
+ * Use `create(PauseDeploymentRequestSchema)` to create a new message.
+ */
+export const PauseDeploymentRequestSchema: GenMessage<PauseDeploymentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 74);
+
+/**
+ * @generated from message config.v1alpha1.ResumeDeploymentRequest
+ */
+export type ResumeDeploymentRequest = Message<"config.v1alpha1.ResumeDeploymentRequest"> & {
+  /**
+   * @generated from field: string deployment_id = 1;
+   */
+  deploymentId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.ResumeDeploymentRequest.
+ * Use `create(ResumeDeploymentRequestSchema)` to create a new message.
+ */
+export const ResumeDeploymentRequestSchema: GenMessage<ResumeDeploymentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 75);
+
+/**
+ * @generated from message config.v1alpha1.CancelDeploymentRequest
+ */
+export type CancelDeploymentRequest = Message<"config.v1alpha1.CancelDeploymentRequest"> & {
+  /**
+   * @generated from field: string deployment_id = 1;
+   */
+  deploymentId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.CancelDeploymentRequest.
+ * Use `create(CancelDeploymentRequestSchema)` to create a new message.
+ */
+export const CancelDeploymentRequestSchema: GenMessage<CancelDeploymentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 76);
+
+/**
+ * @generated from message config.v1alpha1.PurgeDeploymentRequest
+ */
+export type PurgeDeploymentRequest = Message<"config.v1alpha1.PurgeDeploymentRequest"> & {
+  /**
+   * @generated from field: string deployment_id = 1;
+   */
+  deploymentId: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.PurgeDeploymentRequest.
+ * Use `create(PurgeDeploymentRequestSchema)` to create a new message.
+ */
+export const PurgeDeploymentRequestSchema: GenMessage<PurgeDeploymentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 77);
+
+/**
+ * @generated from message config.v1alpha1.RollbackDeploymentRequest
+ */
+export type RollbackDeploymentRequest = Message<"config.v1alpha1.RollbackDeploymentRequest"> & {
+  /**
+   * @generated from field: string deployment_id = 1;
+   */
+  deploymentId: string;
+
+  /**
+   * Delay between batches (default: 0)
+   *
+   * @generated from field: int32 batch_delay_seconds = 2;
+   */
+  batchDelaySeconds: number;
+
+  /**
+   * Waits up to this many seconds for each agent to report applying its restored config
+   * before moving to the next batch (default: 0 = agents count as restored once assigned)
+   *
+   * @generated from field: int32 apply_timeout_seconds = 3;
+   */
+  applyTimeoutSeconds: number;
+};
+
+/**
+ * Describes the message config.v1alpha1.RollbackDeploymentRequest.
+ * Use `create(RollbackDeploymentRequestSchema)` to create a new message.
+ */
+export const RollbackDeploymentRequestSchema: GenMessage<RollbackDeploymentRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 78);
+
+/**
+ * @generated from message config.v1alpha1.DeploymentActionResponse
+ */
+export type DeploymentActionResponse = Message<"config.v1alpha1.DeploymentActionResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+
+  /**
+   * @generated from field: string message = 2;
+   */
+  message: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.DeploymentActionResponse.
+ * Use `create(DeploymentActionResponseSchema)` to create a new message.
+ */
+export const DeploymentActionResponseSchema: GenMessage<DeploymentActionResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 79);
+
+/**
+ * @generated from message config.v1alpha1.ListDeploymentsRequest
+ */
+export type ListDeploymentsRequest = Message<"config.v1alpha1.ListDeploymentsRequest"> & {
+  /**
+   * @generated from field: optional config.v1alpha1.DeploymentState state_filter = 1;
+   */
+  stateFilter?: DeploymentState;
+
+  /**
+   * @generated from field: config.v1alpha1.AuditFilter audit_filter = 2;
+   */
+  auditFilter?: AuditFilter;
+};
+
+/**
+ * Describes the message config.v1alpha1.ListDeploymentsRequest.
+ * Use `create(ListDeploymentsRequestSchema)` to create a new message.
+ */
+export const ListDeploymentsRequestSchema: GenMessage<ListDeploymentsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 80);
+
+/**
+ * @generated from message config.v1alpha1.ListDeploymentsResponse
+ */
+export type ListDeploymentsResponse = Message<"config.v1alpha1.ListDeploymentsResponse"> & {
+  /**
+   * @generated from field: repeated config.v1alpha1.DeploymentStatus deployments = 1;
+   */
+  deployments: DeploymentStatus[];
+};
+
+/**
+ * Describes the message config.v1alpha1.ListDeploymentsResponse.
+ * Use `create(ListDeploymentsResponseSchema)` to create a new message.
+ */
+export const ListDeploymentsResponseSchema: GenMessage<ListDeploymentsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 81);
+
+/**
+ * @generated from message config.v1alpha1.SkippedAgent
+ */
+export type SkippedAgent = Message<"config.v1alpha1.SkippedAgent"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: config.v1alpha1.DeploymentSkipReason reason = 2;
+   */
+  reason: DeploymentSkipReason;
+
+  /**
+   * details on the reason, e.g. the components missing from the agent's distribution
+   *
+   * @generated from field: string detail = 3;
+   */
+  detail: string;
+};
+
+/**
+ * Describes the message config.v1alpha1.SkippedAgent.
+ * Use `create(SkippedAgentSchema)` to create a new message.
+ */
+export const SkippedAgentSchema: GenMessage<SkippedAgent> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 82);
+
+/**
+ * CapacityWarning reports a targeted agent whose host lacks resources the config requires
+ *
+ * @generated from message config.v1alpha1.CapacityWarning
+ */
+export type CapacityWarning = Message<"config.v1alpha1.CapacityWarning"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: repeated string warnings = 2;
+   */
+  warnings: string[];
+};
+
+/**
+ * Describes the message config.v1alpha1.CapacityWarning.
+ * Use `create(CapacityWarningSchema)` to create a new message.
+ */
+export const CapacityWarningSchema: GenMessage<CapacityWarning> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 83);
+
+/**
+ * @generated from message config.v1alpha1.DeploymentPlanBatch
+ */
+export type DeploymentPlanBatch = Message<"config.v1alpha1.DeploymentPlanBatch"> & {
+  /**
+   * @generated from field: int32 number = 1;
+   */
+  number: number;
+
+  /**
+   * @generated from field: repeated string agent_ids = 2;
+   */
+  agentIds: string[];
+
+  /**
+   * Offset from the start of the deployment the batch is expected to start at
+   *
+   * @generated from field: google.protobuf.Duration expected_start = 3;
+   */
+  expectedStart?: Duration;
+
+  /**
+   * @generated from field: google.protobuf.Duration expected_duration = 4;
+   */
+  expectedDuration?: Duration;
+};
+
+/**
+ * Describes the message config.v1alpha1.DeploymentPlanBatch.
+ * Use `create(DeploymentPlanBatchSchema)` to create a new message.
+ */
+export const DeploymentPlanBatchSchema: GenMessage<DeploymentPlanBatch> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 84);
+
+/**
+ * DeploymentPlan describes what a rolling deployment would do if it was started
+ *
+ * @generated from message config.v1alpha1.DeploymentPlan
+ */
+export type DeploymentPlan = Message<"config.v1alpha1.DeploymentPlan"> & {
+  /**
+   * @generated from field: string config_id = 1;
+   */
+  configId: string;
+
+  /**
+   * @generated from field: int32 total_agents = 2;
+   */
+  totalAgents: number;
+
+  /**
+   * @generated from field: repeated config.v1alpha1.DeploymentPlanBatch batches = 3;
+   */
+  batches: DeploymentPlanBatch[];
+
+  /**
+   * @generated from field: repeated config.v1alpha1.SkippedAgent skipped_agents = 4;
+   */
+  skippedAgents: SkippedAgent[];
+
+  /**
+   * Reasons the deployment would be rejected or is unlikely to succeed
+   *
+   * @generated from field: repeated string policy_violations = 5;
+   */
+  policyViolations: string[];
+
+  /**
+   * @generated from field: google.protobuf.Duration expected_duration = 6;
+   */
+  expectedDuration?: Duration;
+
+  /**
+   * Number of recorded apply latencies the duration estimates are based on
+   *
+   * @generated from field: int32 latency_samples = 7;
+   */
+  latencySamples: number;
+
+  /**
+   * @generated from field: repeated config.v1alpha1.CapacityWarning capacity_warnings = 8;
+   */
+  capacityWarnings: CapacityWarning[];
+};
+
+/**
+ * Describes the message config.v1alpha1.DeploymentPlan.
+ * Use `create(DeploymentPlanSchema)` to create a new message.
+ */
+export const DeploymentPlanSchema: GenMessage<DeploymentPlan> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 85);
+
+/**
+ * @generated from message config.v1alpha1.GetConfigCoverageRequest
+ */
+export type GetConfigCoverageRequest = Message<"config.v1alpha1.GetConfigCoverageRequest"> & {
+};
+
+/**
+ * Describes the message config.v1alpha1.GetConfigCoverageRequest.
+ * Use `create(GetConfigCoverageRequestSchema)` to create a new message.
+ */
+export const GetConfigCoverageRequestSchema: GenMessage<GetConfigCoverageRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 86);
+
+/**
+ * SignalCoverage counts the agents running pipelines for a telemetry signal
+ *
+ * @generated from message config.v1alpha1.SignalCoverage
+ */
+export type SignalCoverage = Message<"config.v1alpha1.SignalCoverage"> & {
+  /**
+   * traces, metrics, logs or profiles
+   *
+   * @generated from field: string signal = 1;
+   */
+  signal: string;
+
+  /**
+   * number of agents with at least one pipeline for the signal
+   *
+   * @generated from field: int32 agents = 2;
+   */
+  agents: number;
+
+  /**
+   * number of pipelines for the signal across the fleet
+   *
+   * @generated from field: int32 pipelines = 3;
+   */
+  pipelines: number;
+};
+
+/**
+ * Describes the message config.v1alpha1.SignalCoverage.
+ * Use `create(SignalCoverageSchema)` to create a new message.
+ */
+export const SignalCoverageSchema: GenMessage<SignalCoverage> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 87);
+
+/**
+ * ComponentUsage counts the agents using a component type in their pipelines
+ *
+ * @generated from message config.v1alpha1.ComponentUsage
+ */
+export type ComponentUsage = Message<"config.v1alpha1.ComponentUsage"> & {
+  /**
+   * component type, without the optional "/name" suffix
+   *
+   * @generated from field: string type = 1;
+   */
+  type: string;
+
+  /**
+   * @generated from field: int32 agents = 2;
+   */
+  agents: number;
+};
+
+/**
+ * Describes the message config.v1alpha1.ComponentUsage.
+ * Use `create(ComponentUsageSchema)` to create a new message.
+ */
+export const ComponentUsageSchema: GenMessage<ComponentUsage> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 88);
+
+/**
+ * ConfigCoverageReport summarizes the pipelines in the effective configs reported by agents
+ *
+ * @generated from message config.v1alpha1.ConfigCoverageReport
+ */
+export type ConfigCoverageReport = Message<"config.v1alpha1.ConfigCoverageReport"> & {
+  /**
+   * @generated from field: int32 total_agents = 1;
+   */
+  totalAgents: number;
+
+  /**
+   * agents that have reported an effective config
+   *
+   * @generated from field: int32 reporting_agents = 2;
+   */
+  reportingAgents: number;
+
+  /**
+   * sorted by signal
+   *
+   * @generated from field: repeated config.v1alpha1.SignalCoverage signals = 3;
+   */
+  signals: SignalCoverage[];
+
+  /**
+   * exporters used in pipelines, most used first
+   *
+   * @generated from field: repeated config.v1alpha1.ComponentUsage exporters = 4;
+   */
+  exporters: ComponentUsage[];
 
-/**
- * @generated from message config.v1alpha1.AssignConfigByLabelsRequest
- */
-export type AssignConfigByLabelsRequest = Message<"config.v1alpha1.AssignConfigByLabelsRequest"> & {
   /**
-   * Agent labels to match
+   * reporting agents whose effective config has no pipelines
    *
-   * @generated from field: map<string, string> labels = 1;
+   * @generated from field: repeated string agents_without_pipelines = 5;
    */
-  labels: { [key: string]: string };
+  agentsWithoutPipelines: string[];
 
   /**
-   * @generated from field: string config_id = 2;
+   * agents that have not reported an effective config yet
+   *
+   * @generated from field: repeated string agents_not_reporting = 6;
    */
-  configId: string;
+  agentsNotReporting: string[];
 };
 
 /**
- * Describes the message config.v1alpha1.AssignConfigByLabelsRequest.
- * Use `create(AssignConfigByLabelsRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ConfigCoverageReport.
+ * Use `create(ConfigCoverageReportSchema)` to create a new message.
  */
-export const AssignConfigByLabelsRequestSchema: GenMessage<AssignConfigByLabelsRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 22);
+export const ConfigCoverageReportSchema: GenMessage<ConfigCoverageReport> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 89);
 
 /**
- * @generated from message config.v1alpha1.AssignConfigByLabelsResponse
+ * AgentConfigHistory records the config changes of an agent, oldest first
+ *
+ * @generated from message config.v1alpha1.AgentConfigHistory
  */
-export type AssignConfigByLabelsResponse = Message<"config.v1alpha1.AssignConfigByLabelsResponse"> & {
-  /**
-   * @generated from field: repeated string matched_agent_ids = 1;
-   */
-  matchedAgentIds: string[];
-
+export type AgentConfigHistory = Message<"config.v1alpha1.AgentConfigHistory"> & {
   /**
-   * @generated from field: int32 successful = 2;
+   * @generated from field: repeated config.v1alpha1.AgentConfigHistoryEntry entries = 1;
    */
-  successful: number;
+  entries: AgentConfigHistoryEntry[];
 
   /**
-   * @generated from field: int32 failed = 3;
+   * set once the oldest entries were dropped to bound the history
+   *
+   * @generated from field: bool truncated = 2;
    */
-  failed: number;
+  truncated: boolean;
 };
 
 /**
- * Describes the message config.v1alpha1.AssignConfigByLabelsResponse.
- * Use `create(AssignConfigByLabelsResponseSchema)` to create a new message.
+ * Describes the message config.v1alpha1.AgentConfigHistory.
+ * Use `create(AgentConfigHistorySchema)` to create a new message.
  */
-export const AssignConfigByLabelsResponseSchema: GenMessage<AssignConfigByLabelsResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 23);
+export const AgentConfigHistorySchema: GenMessage<AgentConfigHistory> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 90);
 
 /**
- * @generated from message config.v1alpha1.RollingDeploymentRequest
+ * @generated from message config.v1alpha1.AgentConfigHistoryEntry
  */
-export type RollingDeploymentRequest = Message<"config.v1alpha1.RollingDeploymentRequest"> & {
+export type AgentConfigHistoryEntry = Message<"config.v1alpha1.AgentConfigHistoryEntry"> & {
   /**
-   * @generated from field: string config_id = 1;
+   * @generated from field: google.protobuf.Timestamp time = 1;
    */
-  configId: string;
+  time?: Timestamp;
 
   /**
-   * @generated from field: repeated string agent_ids = 2;
+   * @generated from field: config.v1alpha1.AgentConfigChange change = 2;
    */
-  agentIds: string[];
+  change: AgentConfigChange;
 
   /**
-   * Alternative to agent_ids
+   * the assignment, for ASSIGNED
    *
-   * @generated from field: map<string, string> agent_labels = 3;
+   * @generated from field: config.v1alpha1.ConfigAssignment assignment = 3;
    */
-  agentLabels: { [key: string]: string };
+  assignment?: ConfigAssignment;
 
   /**
-   * Agents per batch (default: 1)
+   * the version of the config that was assigned, for ASSIGNED
    *
-   * @generated from field: int32 batch_size = 4;
+   * @generated from field: config.v1alpha1.Config config = 4;
    */
-  batchSize: number;
+  config?: Config;
 
   /**
-   * Delay between batches (default: 0)
+   * the config hash the agent reported, for APPLIED and FAILED
    *
-   * @generated from field: int32 batch_delay_seconds = 5;
+   * @generated from field: bytes config_hash = 5;
    */
-  batchDelaySeconds: number;
+  configHash: Uint8Array;
 
   /**
-   * Stop after N failures (default: 0 = no limit)
-   *
-   * @generated from field: int32 max_failures = 6;
+   * @generated from field: string error_message = 6;
    */
-  maxFailures: number;
+  errorMessage: string;
 };
 
 /**
- * Describes the message config.v1alpha1.RollingDeploymentRequest.
- * Use `create(RollingDeploymentRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.AgentConfigHistoryEntry.
+ * Use `create(AgentConfigHistoryEntrySchema)` to create a new message.
  */
-export const RollingDeploymentRequestSchema: GenMessage<RollingDeploymentRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 24);
+export const AgentConfigHistoryEntrySchema: GenMessage<AgentConfigHistoryEntry> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 91);
 
 /**
- * @generated from message config.v1alpha1.RollingDeploymentResponse
+ * @generated from message config.v1alpha1.GetAgentConfigAtTimeRequest
  */
-export type RollingDeploymentResponse = Message<"config.v1alpha1.RollingDeploymentResponse"> & {
+export type GetAgentConfigAtTimeRequest = Message<"config.v1alpha1.GetAgentConfigAtTimeRequest"> & {
   /**
-   * @generated from field: string deployment_id = 1;
+   * @generated from field: string agent_id = 1;
    */
-  deploymentId: string;
+  agentId: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp time = 2;
+   */
+  time?: Timestamp;
 };
 
 /**
- * Describes the message config.v1alpha1.RollingDeploymentResponse.
- * Use `create(RollingDeploymentResponseSchema)` to create a new message.
+ * Describes the message config.v1alpha1.GetAgentConfigAtTimeRequest.
+ * Use `create(GetAgentConfigAtTimeRequestSchema)` to create a new message.
  */
-export const RollingDeploymentResponseSchema: GenMessage<RollingDeploymentResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 25);
+export const GetAgentConfigAtTimeRequestSchema: GenMessage<GetAgentConfigAtTimeRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 92);
 
 /**
- * @generated from message config.v1alpha1.AgentDeploymentStatus
+ * AgentConfigAtTime is the config of an agent at a past time, reconstructed from its config
+ * history
+ *
+ * @generated from message config.v1alpha1.AgentConfigAtTime
  */
-export type AgentDeploymentStatus = Message<"config.v1alpha1.AgentDeploymentStatus"> & {
+export type AgentConfigAtTime = Message<"config.v1alpha1.AgentConfigAtTime"> & {
   /**
    * @generated from field: string agent_id = 1;
    */
   agentId: string;
 
   /**
-   * @generated from field: config.v1alpha1.AgentDeploymentState state = 2;
+   * @generated from field: google.protobuf.Timestamp time = 2;
    */
-  state: AgentDeploymentState;
+  time?: Timestamp;
 
   /**
-   * @generated from field: string error_message = 3;
+   * the assignment in effect at time, unset if the agent had none
+   *
+   * @generated from field: config.v1alpha1.ConfigAssignment assignment = 3;
    */
-  errorMessage: string;
+  assignment?: ConfigAssignment;
 
   /**
-   * @generated from field: google.protobuf.Timestamp applied_at = 4;
+   * the version of the assigned config in effect at time
+   *
+   * @generated from field: config.v1alpha1.Config config = 4;
    */
-  appliedAt?: Timestamp;
-};
-
-/**
- * Describes the message config.v1alpha1.AgentDeploymentStatus.
- * Use `create(AgentDeploymentStatusSchema)` to create a new message.
- */
-export const AgentDeploymentStatusSchema: GenMessage<AgentDeploymentStatus> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 26);
+  config?: Config;
 
-/**
- * @generated from message config.v1alpha1.DeploymentStatus
- */
-export type DeploymentStatus = Message<"config.v1alpha1.DeploymentStatus"> & {
   /**
-   * @generated from field: string deployment_id = 1;
+   * the last config the agent reported applying before time, unset if unknown
+   *
+   * @generated from field: config.v1alpha1.AppliedAgentConfig applied = 5;
    */
-  deploymentId: string;
+  applied?: AppliedAgentConfig;
 
   /**
-   * @generated from field: string config_id = 2;
+   * false if the history does not reach back to time, in which case the assignment is unknown
+   *
+   * @generated from field: bool complete = 6;
    */
-  configId: string;
+  complete: boolean;
+};
 
-  /**
-   * @generated from field: config.v1alpha1.DeploymentState state = 3;
-   */
-  state: DeploymentState;
+/**
+ * Describes the message config.v1alpha1.AgentConfigAtTime.
+ * Use `create(AgentConfigAtTimeSchema)` to create a new message.
+ */
+export const AgentConfigAtTimeSchema: GenMessage<AgentConfigAtTime> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 93);
 
+/**
+ * @generated from message config.v1alpha1.AppliedAgentConfig
+ */
+export type AppliedAgentConfig = Message<"config.v1alpha1.AppliedAgentConfig"> & {
   /**
-   * @generated from field: int32 total_agents = 4;
+   * @generated from field: bytes config_hash = 1;
    */
-  totalAgents: number;
+  configHash: Uint8Array;
 
   /**
-   * @generated from field: int32 completed_agents = 5;
+   * @generated from field: google.protobuf.Timestamp applied_at = 2;
    */
-  completedAgents: number;
+  appliedAt?: Timestamp;
 
   /**
-   * @generated from field: int32 failed_agents = 6;
+   * the config assigned with this hash, empty if the history has no such assignment
+   *
+   * @generated from field: string config_id = 3;
    */
-  failedAgents: number;
+  configId: string;
+};
 
-  /**
-   * @generated from field: int32 pending_agents = 7;
-   */
-  pendingAgents: number;
+/**
+ * Describes the message config.v1alpha1.AppliedAgentConfig.
+ * Use `create(AppliedAgentConfigSchema)` to create a new message.
+ */
+export const AppliedAgentConfigSchema: GenMessage<AppliedAgentConfig> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 94);
 
+/**
+ * PackageTarget is the distribution installed on an agent, or on the agents of a group.
+ * Exactly one of agent_id and group_id is set.
+ *
+ * @generated from message config.v1alpha1.PackageTarget
+ */
+export type PackageTarget = Message<"config.v1alpha1.PackageTarget"> & {
   /**
-   * @generated from field: int32 current_batch = 8;
+   * @generated from field: string agent_id = 1;
    */
-  currentBatch: number;
+  agentId: string;
 
   /**
-   * @generated from field: repeated config.v1alpha1.AgentDeploymentStatus agent_statuses = 9;
+   * @generated from field: string group_id = 2;
    */
-  agentStatuses: AgentDeploymentStatus[];
+  groupId: string;
 
   /**
-   * @generated from field: google.protobuf.Timestamp started_at = 10;
+   * @generated from field: config.v1alpha1.CollectorDistributionReference distribution = 3;
    */
-  startedAt?: Timestamp;
+  distribution?: CollectorDistributionReference;
 
   /**
-   * @generated from field: google.protobuf.Timestamp completed_at = 11;
+   * set by the server on every change
+   *
+   * @generated from field: config.v1alpha1.AuditInfo audit = 4;
    */
-  completedAt?: Timestamp;
+  audit?: AuditInfo;
 };
 
 /**
- * Describes the message config.v1alpha1.DeploymentStatus.
- * Use `create(DeploymentStatusSchema)` to create a new message.
+ * Describes the message config.v1alpha1.PackageTarget.
+ * Use `create(PackageTargetSchema)` to create a new message.
  */
-export const DeploymentStatusSchema: GenMessage<DeploymentStatus> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 27);
+export const PackageTargetSchema: GenMessage<PackageTarget> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 95);
 
 /**
- * @generated from message config.v1alpha1.GetDeploymentStatusRequest
+ * @generated from message config.v1alpha1.SetPackageTargetRequest
  */
-export type GetDeploymentStatusRequest = Message<"config.v1alpha1.GetDeploymentStatusRequest"> & {
+export type SetPackageTargetRequest = Message<"config.v1alpha1.SetPackageTargetRequest"> & {
   /**
-   * @generated from field: string deployment_id = 1;
+   * @generated from field: config.v1alpha1.PackageTarget target = 1;
    */
-  deploymentId: string;
+  target?: PackageTarget;
 };
 
 /**
- * Describes the message config.v1alpha1.GetDeploymentStatusRequest.
- * Use `create(GetDeploymentStatusRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.SetPackageTargetRequest.
+ * Use `create(SetPackageTargetRequestSchema)` to create a new message.
  */
-export const GetDeploymentStatusRequestSchema: GenMessage<GetDeploymentStatusRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 28);
+export const SetPackageTargetRequestSchema: GenMessage<SetPackageTargetRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 96);
 
 /**
- * @generated from message config.v1alpha1.GetDeploymentStatusResponse
+ * @generated from message config.v1alpha1.PackageTargetReference
  */
-export type GetDeploymentStatusResponse = Message<"config.v1alpha1.GetDeploymentStatusResponse"> & {
+export type PackageTargetReference = Message<"config.v1alpha1.PackageTargetReference"> & {
   /**
-   * @generated from field: config.v1alpha1.DeploymentStatus status = 1;
+   * @generated from field: string agent_id = 1;
    */
-  status?: DeploymentStatus;
+  agentId: string;
+
+  /**
+   * @generated from field: string group_id = 2;
+   */
+  groupId: string;
 };
 
 /**
- * Describes the message config.v1alpha1.GetDeploymentStatusResponse.
- * Use `create(GetDeploymentStatusResponseSchema)` to create a new message.
+ * Describes the message config.v1alpha1.PackageTargetReference.
+ * Use `create(PackageTargetReferenceSchema)` to create a new message.
  */
-export const GetDeploymentStatusResponseSchema: GenMessage<GetDeploymentStatusResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 29);
+export const PackageTargetReferenceSchema: GenMessage<PackageTargetReference> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 97);
 
 /**
- * @generated from message config.v1alpha1.PauseDeploymentRequest
+ * @generated from message config.v1alpha1.ListPackageTargetsRequest
  */
-export type PauseDeploymentRequest = Message<"config.v1alpha1.PauseDeploymentRequest"> & {
-  /**
-   * @generated from field: string deployment_id = 1;
-   */
-  deploymentId: string;
+export type ListPackageTargetsRequest = Message<"config.v1alpha1.ListPackageTargetsRequest"> & {
 };
 
 /**
- * Describes the message config.v1alpha1.PauseDeploymentRequest.
- * Use `create(PauseDeploymentRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ListPackageTargetsRequest.
+ * Use `create(ListPackageTargetsRequestSchema)` to create a new message.
  */
-export const PauseDeploymentRequestSchema: GenMessage<PauseDeploymentRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 30);
+export const ListPackageTargetsRequestSchema: GenMessage<ListPackageTargetsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 98);
 
 /**
- * @generated from message config.v1alpha1.ResumeDeploymentRequest
+ * @generated from message config.v1alpha1.ListPackageTargetsResponse
  */
-export type ResumeDeploymentRequest = Message<"config.v1alpha1.ResumeDeploymentRequest"> & {
+export type ListPackageTargetsResponse = Message<"config.v1alpha1.ListPackageTargetsResponse"> & {
   /**
-   * @generated from field: string deployment_id = 1;
+   * agent targets sorted by agent ID, then group targets sorted by group ID
+   *
+   * @generated from field: repeated config.v1alpha1.PackageTarget targets = 1;
    */
-  deploymentId: string;
+  targets: PackageTarget[];
 };
 
 /**
- * Describes the message config.v1alpha1.ResumeDeploymentRequest.
- * Use `create(ResumeDeploymentRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.ListPackageTargetsResponse.
+ * Use `create(ListPackageTargetsResponseSchema)` to create a new message.
  */
-export const ResumeDeploymentRequestSchema: GenMessage<ResumeDeploymentRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 31);
+export const ListPackageTargetsResponseSchema: GenMessage<ListPackageTargetsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 99);
 
 /**
- * @generated from message config.v1alpha1.CancelDeploymentRequest
+ * @generated from message config.v1alpha1.GetAgentPackageStatusRequest
  */
-export type CancelDeploymentRequest = Message<"config.v1alpha1.CancelDeploymentRequest"> & {
+export type GetAgentPackageStatusRequest = Message<"config.v1alpha1.GetAgentPackageStatusRequest"> & {
   /**
-   * @generated from field: string deployment_id = 1;
+   * @generated from field: string agent_id = 1;
    */
-  deploymentId: string;
+  agentId: string;
 };
 
 /**
- * Describes the message config.v1alpha1.CancelDeploymentRequest.
- * Use `create(CancelDeploymentRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.GetAgentPackageStatusRequest.
+ * Use `create(GetAgentPackageStatusRequestSchema)` to create a new message.
  */
-export const CancelDeploymentRequestSchema: GenMessage<CancelDeploymentRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 32);
+export const GetAgentPackageStatusRequestSchema: GenMessage<GetAgentPackageStatusRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 100);
 
 /**
- * @generated from message config.v1alpha1.DeploymentActionResponse
+ * @generated from message config.v1alpha1.AgentPackageStatus
  */
-export type DeploymentActionResponse = Message<"config.v1alpha1.DeploymentActionResponse"> & {
+export type AgentPackageStatus = Message<"config.v1alpha1.AgentPackageStatus"> & {
   /**
-   * @generated from field: bool success = 1;
+   * @generated from field: string agent_id = 1;
    */
-  success: boolean;
+  agentId: string;
 
   /**
-   * @generated from field: string message = 2;
+   * the target applying to the agent, unset if none does
+   *
+   * @generated from field: config.v1alpha1.PackageTarget target = 2;
    */
-  message: string;
-};
+  target?: PackageTarget;
 
-/**
- * Describes the message config.v1alpha1.DeploymentActionResponse.
- * Use `create(DeploymentActionResponseSchema)` to create a new message.
- */
-export const DeploymentActionResponseSchema: GenMessage<DeploymentActionResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 33);
+  /**
+   * the artifact of the target distribution offered to the agent, unset if the distribution
+   * has no artifact for the agent's platform
+   *
+   * @generated from field: config.v1alpha1.DistributionArtifact artifact = 3;
+   */
+  artifact?: DistributionArtifact;
 
-/**
- * @generated from message config.v1alpha1.ListDeploymentsRequest
- */
-export type ListDeploymentsRequest = Message<"config.v1alpha1.ListDeploymentsRequest"> & {
   /**
-   * @generated from field: optional config.v1alpha1.DeploymentState state_filter = 1;
+   * the version of the collector package the agent reported installing, empty if unknown
+   *
+   * @generated from field: string installed_version = 4;
    */
-  stateFilter?: DeploymentState;
+  installedVersion: string;
+
+  /**
+   * @generated from field: config.v1alpha1.PackageInstallState state = 5;
+   */
+  state: PackageInstallState;
+
+  /**
+   * @generated from field: string error_message = 6;
+   */
+  errorMessage: string;
+
+  /**
+   * when the agent last reported its package statuses
+   *
+   * @generated from field: google.protobuf.Timestamp updated_at = 7;
+   */
+  updatedAt?: Timestamp;
 };
 
 /**
- * Describes the message config.v1alpha1.ListDeploymentsRequest.
- * Use `create(ListDeploymentsRequestSchema)` to create a new message.
+ * Describes the message config.v1alpha1.AgentPackageStatus.
+ * Use `create(AgentPackageStatusSchema)` to create a new message.
  */
-export const ListDeploymentsRequestSchema: GenMessage<ListDeploymentsRequest> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 34);
+export const AgentPackageStatusSchema: GenMessage<AgentPackageStatus> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1alpha1_config, 101);
 
 /**
- * @generated from message config.v1alpha1.ListDeploymentsResponse
+ * @generated from enum config.v1alpha1.FindingSeverity
  */
-export type ListDeploymentsResponse = Message<"config.v1alpha1.ListDeploymentsResponse"> & {
+export enum FindingSeverity {
   /**
-   * @generated from field: repeated config.v1alpha1.DeploymentStatus deployments = 1;
+   * @generated from enum value: FINDING_SEVERITY_UNSPECIFIED = 0;
    */
-  deployments: DeploymentStatus[];
-};
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: FINDING_SEVERITY_ERROR = 1;
+   */
+  ERROR = 1,
+
+  /**
+   * @generated from enum value: FINDING_SEVERITY_WARNING = 2;
+   */
+  WARNING = 2,
+}
 
 /**
- * Describes the message config.v1alpha1.ListDeploymentsResponse.
- * Use `create(ListDeploymentsResponseSchema)` to create a new message.
- */
-export const ListDeploymentsResponseSchema: GenMessage<ListDeploymentsResponse> = /*@__PURE__*/
-  messageDesc(file_pkg_api_config_v1alpha1_config, 35);
+ * Describes the enum config.v1alpha1.FindingSeverity.
+ */
+export const FindingSeveritySchema: GenEnum<FindingSeverity> = /*@__PURE__*/
+  enumDesc(file_pkg_api_config_v1alpha1_config, 0);
 
 /**
  * ConfigSource indicates how a config was assigned to an agent
@@ -887,13 +3133,27 @@ export enum ConfigSource {
    * @generated from enum value: CONFIG_SOURCE_MANUAL = 3;
    */
   MANUAL = 3,
+
+  /**
+   * assigned by an AssignmentPolicy
+   *
+   * @generated from enum value: CONFIG_SOURCE_POLICY = 4;
+   */
+  POLICY = 4,
+
+  /**
+   * the built-in config sent to agents with nothing assigned, only used in explanations
+   *
+   * @generated from enum value: CONFIG_SOURCE_FALLBACK = 5;
+   */
+  FALLBACK = 5,
 }
 
 /**
  * Describes the enum config.v1alpha1.ConfigSource.
  */
 export const ConfigSourceSchema: GenEnum<ConfigSource> = /*@__PURE__*/
-  enumDesc(file_pkg_api_config_v1alpha1_config, 0);
+  enumDesc(file_pkg_api_config_v1alpha1_config, 1);
 
 /**
  * ConfigApplicationStatus indicates whether the agent has applied the config
@@ -920,13 +3180,20 @@ export enum ConfigApplicationStatus {
    * @generated from enum value: CONFIG_APPLICATION_STATUS_FAILED = 3;
    */
   FAILED = 3,
+
+  /**
+   * the agent does not accept remote config, so the assignment is never applied
+   *
+   * @generated from enum value: CONFIG_APPLICATION_STATUS_UNSUPPORTED = 4;
+   */
+  UNSUPPORTED = 4,
 }
 
 /**
  * Describes the enum config.v1alpha1.ConfigApplicationStatus.
  */
 export const ConfigApplicationStatusSchema: GenEnum<ConfigApplicationStatus> = /*@__PURE__*/
-  enumDesc(file_pkg_api_config_v1alpha1_config, 1);
+  enumDesc(file_pkg_api_config_v1alpha1_config, 2);
 
 /**
  * DeploymentState represents the overall state of a deployment
@@ -974,7 +3241,7 @@ export enum DeploymentState {
  * Describes the enum config.v1alpha1.DeploymentState.
  */
 export const DeploymentStateSchema: GenEnum<DeploymentState> = /*@__PURE__*/
-  enumDesc(file_pkg_api_config_v1alpha1_config, 2);
+  enumDesc(file_pkg_api_config_v1alpha1_config, 3);
 
 /**
  * AgentDeploymentState represents the state of deployment for a single agent
@@ -1006,13 +3273,146 @@ export enum AgentDeploymentState {
    * @generated from enum value: AGENT_DEPLOYMENT_STATE_FAILED = 4;
    */
   FAILED = 4,
+
+  /**
+   * the agent was left as is, e.g. by a rollback because its assignment changed since the
+   * rolled back deployment
+   *
+   * @generated from enum value: AGENT_DEPLOYMENT_STATE_SKIPPED = 5;
+   */
+  SKIPPED = 5,
 }
 
 /**
  * Describes the enum config.v1alpha1.AgentDeploymentState.
  */
 export const AgentDeploymentStateSchema: GenEnum<AgentDeploymentState> = /*@__PURE__*/
-  enumDesc(file_pkg_api_config_v1alpha1_config, 3);
+  enumDesc(file_pkg_api_config_v1alpha1_config, 4);
+
+/**
+ * DeploymentSkipReason explains why an agent targeted by a deployment would not apply the config
+ *
+ * @generated from enum config.v1alpha1.DeploymentSkipReason
+ */
+export enum DeploymentSkipReason {
+  /**
+   * @generated from enum value: DEPLOYMENT_SKIP_REASON_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * The agent is not registered, assigning the config to it fails
+   *
+   * @generated from enum value: DEPLOYMENT_SKIP_REASON_NOT_FOUND = 1;
+   */
+  NOT_FOUND = 1,
+
+  /**
+   * The agent is not connected, it applies the config once it reconnects
+   *
+   * @generated from enum value: DEPLOYMENT_SKIP_REASON_OFFLINE = 2;
+   */
+  OFFLINE = 2,
+
+  /**
+   * The agent does not accept remote config, or its collector distribution lacks
+   * components used by the config
+   *
+   * @generated from enum value: DEPLOYMENT_SKIP_REASON_INCOMPATIBLE = 3;
+   */
+  INCOMPATIBLE = 3,
+}
+
+/**
+ * Describes the enum config.v1alpha1.DeploymentSkipReason.
+ */
+export const DeploymentSkipReasonSchema: GenEnum<DeploymentSkipReason> = /*@__PURE__*/
+  enumDesc(file_pkg_api_config_v1alpha1_config, 5);
+
+/**
+ * @generated from enum config.v1alpha1.AgentConfigChange
+ */
+export enum AgentConfigChange {
+  /**
+   * @generated from enum value: AGENT_CONFIG_CHANGE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * a config was assigned to the agent
+   *
+   * @generated from enum value: AGENT_CONFIG_CHANGE_ASSIGNED = 1;
+   */
+  ASSIGNED = 1,
+
+  /**
+   * the agent's assignment was removed
+   *
+   * @generated from enum value: AGENT_CONFIG_CHANGE_UNASSIGNED = 2;
+   */
+  UNASSIGNED = 2,
+
+  /**
+   * the agent reported applying a config
+   *
+   * @generated from enum value: AGENT_CONFIG_CHANGE_APPLIED = 3;
+   */
+  APPLIED = 3,
+
+  /**
+   * the agent reported failing to apply a config
+   *
+   * @generated from enum value: AGENT_CONFIG_CHANGE_FAILED = 4;
+   */
+  FAILED = 4,
+}
+
+/**
+ * Describes the enum config.v1alpha1.AgentConfigChange.
+ */
+export const AgentConfigChangeSchema: GenEnum<AgentConfigChange> = /*@__PURE__*/
+  enumDesc(file_pkg_api_config_v1alpha1_config, 6);
+
+/**
+ * @generated from enum config.v1alpha1.PackageInstallState
+ */
+export enum PackageInstallState {
+  /**
+   * @generated from enum value: PACKAGE_INSTALL_STATE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: PACKAGE_INSTALL_STATE_INSTALLED = 1;
+   */
+  INSTALLED = 1,
+
+  /**
+   * @generated from enum value: PACKAGE_INSTALL_STATE_INSTALL_PENDING = 2;
+   */
+  INSTALL_PENDING = 2,
+
+  /**
+   * @generated from enum value: PACKAGE_INSTALL_STATE_INSTALLING = 3;
+   */
+  INSTALLING = 3,
+
+  /**
+   * @generated from enum value: PACKAGE_INSTALL_STATE_INSTALL_FAILED = 4;
+   */
+  INSTALL_FAILED = 4,
+
+  /**
+   * @generated from enum value: PACKAGE_INSTALL_STATE_DOWNLOADING = 5;
+   */
+  DOWNLOADING = 5,
+}
+
+/**
+ * Describes the enum config.v1alpha1.PackageInstallState.
+ */
+export const PackageInstallStateSchema: GenEnum<PackageInstallState> = /*@__PURE__*/
+  enumDesc(file_pkg_api_config_v1alpha1_config, 7);
 
 /**
  * @generated from service config.v1alpha1.ConfigService
@@ -1020,6 +3420,7 @@ export const AgentDeploymentStateSchema: GenEnum<AgentDeploymentState> = /*@__PU
 export const ConfigService: GenService<{
   /**
    * Config CRUD
+   * Fails with INVALID_ARGUMENT if the config is invalid, with a ConfigValidationResult detail
    *
    * @generated from rpc config.v1alpha1.ConfigService.ValidConfig
    */
@@ -1028,6 +3429,16 @@ export const ConfigService: GenService<{
     input: typeof ValidateConfigRequestSchema;
     output: typeof EmptySchema;
   },
+  /**
+   * Returns every finding about a config with its position, for editors
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.ValidateConfigDetailed
+   */
+  validateConfigDetailed: {
+    methodKind: "unary";
+    input: typeof ValidateConfigDetailedRequestSchema;
+    output: typeof ConfigValidationResultSchema;
+  },
   /**
    * @generated from rpc config.v1alpha1.ConfigService.PutConfig
    */
@@ -1057,7 +3468,7 @@ export const ConfigService: GenService<{
    */
   listConfigs: {
     methodKind: "unary";
-    input: typeof EmptySchema;
+    input: typeof ListConfigsRequestSchema;
     output: typeof ListConfigReponseSchema;
   },
   /**
@@ -1068,6 +3479,24 @@ export const ConfigService: GenService<{
     input: typeof EmptySchema;
     output: typeof ConfigSchema;
   },
+  /**
+   * Concurrent edits: saves merge the changes made to the config since the session began
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.BeginConfigEdit
+   */
+  beginConfigEdit: {
+    methodKind: "unary";
+    input: typeof ConfigReferenceSchema;
+    output: typeof ConfigEditSessionSchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.SaveConfigEdit
+   */
+  saveConfigEdit: {
+    methodKind: "unary";
+    input: typeof SaveConfigEditRequestSchema;
+    output: typeof SaveConfigEditResultSchema;
+  },
   /**
    * @generated from rpc config.v1alpha1.ConfigService.SetDefaultConfig
    */
@@ -1120,6 +3549,16 @@ export const ConfigService: GenService<{
     input: typeof GetConfigStatusRequestSchema;
     output: typeof GetConfigStatusResponseSchema;
   },
+  /**
+   * Reconstructs the config assigned to an agent, and the one it applied, at a past time
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.GetAgentConfigAtTime
+   */
+  getAgentConfigAtTime: {
+    methodKind: "unary";
+    input: typeof GetAgentConfigAtTimeRequestSchema;
+    output: typeof AgentConfigAtTimeSchema;
+  },
   /**
    * Phase 3: Batch Assignment
    *
@@ -1188,6 +3627,261 @@ export const ConfigService: GenService<{
     input: typeof ListDeploymentsRequestSchema;
     output: typeof ListDeploymentsResponseSchema;
   },
+  /**
+   * Deletes a finished deployment and its per-agent statuses
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.PurgeDeployment
+   */
+  purgeDeployment: {
+    methodKind: "unary";
+    input: typeof PurgeDeploymentRequestSchema;
+    output: typeof DeploymentActionResponseSchema;
+  },
+  /**
+   * Restores the configs agents had before a finished deployment, in reverse batch order,
+   * as a new deployment
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.RollbackDeployment
+   */
+  rollbackDeployment: {
+    methodKind: "unary";
+    input: typeof RollbackDeploymentRequestSchema;
+    output: typeof RollingDeploymentResponseSchema;
+  },
+  /**
+   * Plans a rolling deployment without executing it
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.SimulateDeployment
+   */
+  simulateDeployment: {
+    methodKind: "unary";
+    input: typeof RollingDeploymentRequestSchema;
+    output: typeof DeploymentPlanSchema;
+  },
+  /**
+   * Assignment policies: steady-state config assignment by label selector
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.PutAssignmentPolicy
+   */
+  putAssignmentPolicy: {
+    methodKind: "unary";
+    input: typeof PutAssignmentPolicyRequestSchema;
+    output: typeof AssignmentPolicySchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.GetAssignmentPolicy
+   */
+  getAssignmentPolicy: {
+    methodKind: "unary";
+    input: typeof AssignmentPolicyReferenceSchema;
+    output: typeof AssignmentPolicySchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.DeleteAssignmentPolicy
+   */
+  deleteAssignmentPolicy: {
+    methodKind: "unary";
+    input: typeof AssignmentPolicyReferenceSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.ListAssignmentPolicies
+   */
+  listAssignmentPolicies: {
+    methodKind: "unary";
+    input: typeof ListAssignmentPoliciesRequestSchema;
+    output: typeof ListAssignmentPoliciesResponseSchema;
+  },
+  /**
+   * Lists every source that could assign a config to an agent, and which one applies
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.GetAssignmentExplanation
+   */
+  getAssignmentExplanation: {
+    methodKind: "unary";
+    input: typeof GetAssignmentExplanationRequestSchema;
+    output: typeof AssignmentExplanationSchema;
+  },
+  /**
+   * Agent groups: named sets of agents, selected by labels or listed explicitly, whose config
+   * is assigned to every agent in the group
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.CreateGroup
+   */
+  createGroup: {
+    methodKind: "unary";
+    input: typeof CreateGroupRequestSchema;
+    output: typeof AgentGroupSchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.UpdateGroup
+   */
+  updateGroup: {
+    methodKind: "unary";
+    input: typeof UpdateGroupRequestSchema;
+    output: typeof AgentGroupSchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.GetGroup
+   */
+  getGroup: {
+    methodKind: "unary";
+    input: typeof AgentGroupReferenceSchema;
+    output: typeof AgentGroupSchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.DeleteGroup
+   */
+  deleteGroup: {
+    methodKind: "unary";
+    input: typeof AgentGroupReferenceSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.ListGroups
+   */
+  listGroups: {
+    methodKind: "unary";
+    input: typeof ListGroupsRequestSchema;
+    output: typeof ListGroupsResponseSchema;
+  },
+  /**
+   * Component policies forbid collector components in the configs of the agents they apply to
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.PutComponentPolicy
+   */
+  putComponentPolicy: {
+    methodKind: "unary";
+    input: typeof PutComponentPolicyRequestSchema;
+    output: typeof ComponentPolicySchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.GetComponentPolicy
+   */
+  getComponentPolicy: {
+    methodKind: "unary";
+    input: typeof ComponentPolicyReferenceSchema;
+    output: typeof ComponentPolicySchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.DeleteComponentPolicy
+   */
+  deleteComponentPolicy: {
+    methodKind: "unary";
+    input: typeof ComponentPolicyReferenceSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * Lists the component policies along with the assigned configs violating them
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.ListComponentPolicies
+   */
+  listComponentPolicies: {
+    methodKind: "unary";
+    input: typeof ListComponentPoliciesRequestSchema;
+    output: typeof ListComponentPoliciesResponseSchema;
+  },
+  /**
+   * Collector distributions describe the collector builds agents run
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.PutCollectorDistribution
+   */
+  putCollectorDistribution: {
+    methodKind: "unary";
+    input: typeof PutCollectorDistributionRequestSchema;
+    output: typeof CollectorDistributionSchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.GetCollectorDistribution
+   */
+  getCollectorDistribution: {
+    methodKind: "unary";
+    input: typeof CollectorDistributionReferenceSchema;
+    output: typeof CollectorDistributionSchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.DeleteCollectorDistribution
+   */
+  deleteCollectorDistribution: {
+    methodKind: "unary";
+    input: typeof CollectorDistributionReferenceSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.ConfigService.ListCollectorDistributions
+   */
+  listCollectorDistributions: {
+    methodKind: "unary";
+    input: typeof ListCollectorDistributionsRequestSchema;
+    output: typeof ListCollectorDistributionsResponseSchema;
+  },
+  /**
+   * Checks that a distribution includes every component used by a config
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.CheckConfigCompatibility
+   */
+  checkConfigCompatibility: {
+    methodKind: "unary";
+    input: typeof CheckConfigCompatibilityRequestSchema;
+    output: typeof ConfigCompatibilitySchema;
+  },
+  /**
+   * Fleet-wide analysis of effective configs
+   *
+   * @generated from rpc config.v1alpha1.ConfigService.GetConfigCoverage
+   */
+  getConfigCoverage: {
+    methodKind: "unary";
+    input: typeof GetConfigCoverageRequestSchema;
+    output: typeof ConfigCoverageReportSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_pkg_api_config_v1alpha1_config, 0);
 
+/**
+ * PackageService upgrades the collectors of agents to registered collector distributions,
+ * offered to their supervisors as OpAMP packages
+ *
+ * @generated from service config.v1alpha1.PackageService
+ */
+export const PackageService: GenService<{
+  /**
+   * Sets the distribution installed on an agent, or on the agents of a group. The target of an
+   * agent outranks the targets of its groups.
+   *
+   * @generated from rpc config.v1alpha1.PackageService.SetPackageTarget
+   */
+  setPackageTarget: {
+    methodKind: "unary";
+    input: typeof SetPackageTargetRequestSchema;
+    output: typeof PackageTargetSchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.PackageService.DeletePackageTarget
+   */
+  deletePackageTarget: {
+    methodKind: "unary";
+    input: typeof PackageTargetReferenceSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * @generated from rpc config.v1alpha1.PackageService.ListPackageTargets
+   */
+  listPackageTargets: {
+    methodKind: "unary";
+    input: typeof ListPackageTargetsRequestSchema;
+    output: typeof ListPackageTargetsResponseSchema;
+  },
+  /**
+   * Returns the distribution offered to an agent and the installation status it reported
+   *
+   * @generated from rpc config.v1alpha1.PackageService.GetAgentPackageStatus
+   */
+  getAgentPackageStatus: {
+    methodKind: "unary";
+    input: typeof GetAgentPackageStatusRequestSchema;
+    output: typeof AgentPackageStatusSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_pkg_api_config_v1alpha1_config, 1);
+
@@ -0,0 +1,568 @@
+// @generated by protoc-gen-es v2.10.2 with parameter "target=ts"
+// @generated from file pkg/api/config/v1beta1/config.proto (package config.v1beta1, syntax proto3)
+/* eslint-disable */
+
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { EmptySchema, Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_empty, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file pkg/api/config/v1beta1/config.proto.
+ */
+export const file_pkg_api_config_v1beta1_config: GenFile = /*@__PURE__*/
+  fileDesc("CiNwa2cvYXBpL2NvbmZpZy92MWJldGExL2NvbmZpZy5wcm90bxIOY29uZmlnLnYxYmV0YTEiegoQUHV0Q29uZmlnUmVxdWVzdBIsCgNyZWYYASABKAsyHy5jb25maWcudjFiZXRhMS5Db25maWdSZWZlcmVuY2USJgoGY29uZmlnGAIgASgLMhYuY29uZmlnLnYxYmV0YTEuQ29uZmlnEhAKCHZhbGlkYXRlGAMgASgIIh0KD0NvbmZpZ1JlZmVyZW5jZRIKCgJpZBgBIAEoCSJKCgZDb25maWcSDgoGY29uZmlnGAEgASgMEjAKCG1ldGFkYXRhGAIgASgLMh4uY29uZmlnLnYxYmV0YTEuQ29uZmlnTWV0YWRhdGEi0QEKDkNvbmZpZ01ldGFkYXRhEg0KBW93bmVyGAEgASgJEgwKBHRhZ3MYAiADKAkSKAoFYXVkaXQYAyABKAsyGS5jb25maWcudjFiZXRhMS5BdWRpdEluZm8SRAoLYW5ub3RhdGlvbnMYBCADKAsyLy5jb25maWcudjFiZXRhMS5Db25maWdNZXRhZGF0YS5Bbm5vdGF0aW9uc0VudHJ5GjIKEEFubm90YXRpb25zRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASKVAQoJQXVkaXRJbmZvEhIKCmNyZWF0ZWRfYnkYASABKAkSLgoKY3JlYXRlZF9hdBgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEwoLbW9kaWZpZWRfYnkYAyABKAkSLwoLbW9kaWZpZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIhQKEkxpc3RDb25maWdzUmVxdWVzdCLdAQoTTGlzdENvbmZpZ3NSZXNwb25zZRIwCgdjb25maWdzGAEgAygLMh8uY29uZmlnLnYxYmV0YTEuQ29uZmlnUmVmZXJlbmNlEkMKCG1ldGFkYXRhGAIgAygLMjEuY29uZmlnLnYxYmV0YTEuTGlzdENvbmZpZ3NSZXNwb25zZS5NZXRhZGF0YUVudHJ5Gk8KDU1ldGFkYXRhRW50cnkSCwoDa2V5GAEgASgJEi0KBXZhbHVlGAIgASgLMh4uY29uZmlnLnYxYmV0YTEuQ29uZmlnTWV0YWRhdGE6AjgBIn8KE0Fzc2lnbkNvbmZpZ1JlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkSEQoJY29uZmlnX2lkGAIgASgJEiwKBnNvdXJjZRgDIAEoDjIcLmNvbmZpZy52MWJldGExLkNvbmZpZ1NvdXJjZRIVCg1hdXRvX3JvbGxiYWNrGAQgASgIIkoKFEFzc2lnbkNvbmZpZ1Jlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgSDwoHbWVzc2FnZRgCIAEoCRIQCgh3YXJuaW5ncxgDIAMoCSIpChVHZXRBZ2VudENvbmZpZ1JlcXVlc3QSEAoIYWdlbnRfaWQYASABKAkiigEKFkdldEFnZW50Q29uZmlnUmVzcG9uc2USEQoJY29uZmlnX2lkGAEgASgJEiwKBnNvdXJjZRgCIAEoDjIcLmNvbmZpZy52MWJldGExLkNvbmZpZ1NvdXJjZRIvCgthc3NpZ25lZF9hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAiKQoVVW5hc3NpZ25Db25maWdSZXF1ZXN0EhAKCGFnZW50X2lkGAEgASgJIikKFlVuYXNzaWduQ29uZmlnUmVzcG9uc2USDwoHc3VjY2VzcxgBIAEoCCJEChxMaXN0Q29uZmlnQXNzaWdubWVudHNSZXF1ZXN0EhYKCWNvbmZpZ19pZBgBIAEoCUgAiAEBQgwKCl9jb25maWdfaWQiVgodTGlzdENvbmZpZ0Fzc2lnbm1lbnRzUmVzcG9uc2USNQoLYXNzaWdubWVudHMYASADKAsyIC5jb25maWcudjFiZXRhMS5Db25maWdBc3NpZ25tZW50IpACChBDb25maWdBc3NpZ25tZW50EhAKCGFnZW50X2lkGAEgASgJEhEKCWNvbmZpZ19pZBgCIAEoCRIsCgZzb3VyY2UYAyABKA4yHC5jb25maWcudjFiZXRhMS5Db25maWdTb3VyY2USLwoLYXNzaWduZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjcKBnN0YXR1cxgFIAEoDjInLmNvbmZpZy52MWJldGExLkNvbmZpZ0FwcGxpY2F0aW9uU3RhdHVzEhUKDWVycm9yX21lc3NhZ2UYBiABKAkSKAoFYXVkaXQYByABKAsyGS5jb25maWcudjFiZXRhMS5BdWRpdEluZm8qmQEKDENvbmZpZ1NvdXJjZRIdChlDT05GSUdfU09VUkNFX1VOU1BFQ0lGSUVEEAASGQoVQ09ORklHX1NPVVJDRV9ERUZBVUxUEAESGwoXQ09ORklHX1NPVVJDRV9CT09UU1RSQVAQAhIYChRDT05GSUdfU09VUkNFX01BTlVBTBADEhgKFENPTkZJR19TT1VSQ0VfUE9MSUNZEAQq4wEKF0NvbmZpZ0FwcGxpY2F0aW9uU3RhdHVzEikKJUNPTkZJR19BUFBMSUNBVElPTl9TVEFUVVNfVU5TUEVDSUZJRUQQABIlCiFDT05GSUdfQVBQTElDQVRJT05fU1RBVFVTX1BFTkRJTkcQARIlCiFDT05GSUdfQVBQTElDQVRJT05fU1RBVFVTX0FQUExJRUQQAhIkCiBDT05GSUdfQVBQTElDQVRJT05fU1RBVFVTX0ZBSUxFRBADEikKJUNPTkZJR19BUFBMSUNBVElPTl9TVEFUVVNfVU5TVVBQT1JURUQQBDLQBQoNQ29uZmlnU2VydmljZRJFCglQdXRDb25maWcSIC5jb25maWcudjFiZXRhMS5QdXRDb25maWdSZXF1ZXN0GhYuZ29vZ2xlLnByb3RvYnVmLkVtcHR5EkQKCUdldENvbmZpZxIfLmNvbmZpZy52MWJldGExLkNvbmZpZ1JlZmVyZW5jZRoWLmNvbmZpZy52MWJldGExLkNvbmZpZxJHCgxEZWxldGVDb25maWcSHy5jb25maWcudjFiZXRhMS5Db25maWdSZWZlcmVuY2UaFi5nb29nbGUucHJvdG9idWYuRW1wdHkSVgoLTGlzdENvbmZpZ3MSIi5jb25maWcudjFiZXRhMS5MaXN0Q29uZmlnc1JlcXVlc3QaIy5jb25maWcudjFiZXRhMS5MaXN0Q29uZmlnc1Jlc3BvbnNlElkKDEFzc2lnbkNvbmZpZxIjLmNvbmZpZy52MWJldGExLkFzc2lnbkNvbmZpZ1JlcXVlc3QaJC5jb25maWcudjFiZXRhMS5Bc3NpZ25Db25maWdSZXNwb25zZRJfCg5HZXRBZ2VudENvbmZpZxIlLmNvbmZpZy52MWJldGExLkdldEFnZW50Q29uZmlnUmVxdWVzdBomLmNvbmZpZy52MWJldGExLkdldEFnZW50Q29uZmlnUmVzcG9uc2USXwoOVW5hc3NpZ25Db25maWcSJS5jb25maWcudjFiZXRhMS5VbmFzc2lnbkNvbmZpZ1JlcXVlc3QaJi5jb25maWcudjFiZXRhMS5VbmFzc2lnbkNvbmZpZ1Jlc3BvbnNlEnQKFUxpc3RDb25maWdBc3NpZ25tZW50cxIsLmNvbmZpZy52MWJldGExLkxpc3RDb25maWdBc3NpZ25tZW50c1JlcXVlc3QaLS5jb25maWcudjFiZXRhMS5MaXN0Q29uZmlnQXNzaWdubWVudHNSZXNwb25zZUI3WjVnaXRodWIuY29tL290ZWxmbGVldC9vdGVsZmxlZXQvcGtnL2FwaS9jb25maWcvdjFiZXRhMWIGcHJvdG8z", [file_google_protobuf_empty, file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message config.v1beta1.PutConfigRequest
+ */
+export type PutConfigRequest = Message<"config.v1beta1.PutConfigRequest"> & {
+  /**
+   * @generated from field: config.v1beta1.ConfigReference ref = 1;
+   */
+  ref?: ConfigReference;
+
+  /**
+   * @generated from field: config.v1beta1.Config config = 2;
+   */
+  config?: Config;
+
+  /**
+   * reject the config if it is invalid
+   *
+   * @generated from field: bool validate = 3;
+   */
+  validate: boolean;
+};
+
+/**
+ * Describes the message config.v1beta1.PutConfigRequest.
+ * Use `create(PutConfigRequestSchema)` to create a new message.
+ */
+export const PutConfigRequestSchema: GenMessage<PutConfigRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 0);
+
+/**
+ * @generated from message config.v1beta1.ConfigReference
+ */
+export type ConfigReference = Message<"config.v1beta1.ConfigReference"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message config.v1beta1.ConfigReference.
+ * Use `create(ConfigReferenceSchema)` to create a new message.
+ */
+export const ConfigReferenceSchema: GenMessage<ConfigReference> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 1);
+
+/**
+ * @generated from message config.v1beta1.Config
+ */
+export type Config = Message<"config.v1beta1.Config"> & {
+  /**
+   * @generated from field: bytes config = 1;
+   */
+  config: Uint8Array;
+
+  /**
+   * @generated from field: config.v1beta1.ConfigMetadata metadata = 2;
+   */
+  metadata?: ConfigMetadata;
+};
+
+/**
+ * Describes the message config.v1beta1.Config.
+ * Use `create(ConfigSchema)` to create a new message.
+ */
+export const ConfigSchema: GenMessage<Config> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 2);
+
+/**
+ * @generated from message config.v1beta1.ConfigMetadata
+ */
+export type ConfigMetadata = Message<"config.v1beta1.ConfigMetadata"> & {
+  /**
+   * principal that created the config
+   *
+   * @generated from field: string owner = 1;
+   */
+  owner: string;
+
+  /**
+   * @generated from field: repeated string tags = 2;
+   */
+  tags: string[];
+
+  /**
+   * set by the server on every change
+   *
+   * @generated from field: config.v1beta1.AuditInfo audit = 3;
+   */
+  audit?: AuditInfo;
+
+  /**
+   * free-form annotations, e.g. the lineage of configs copied from another server
+   *
+   * @generated from field: map<string, string> annotations = 4;
+   */
+  annotations: { [key: string]: string };
+};
+
+/**
+ * Describes the message config.v1beta1.ConfigMetadata.
+ * Use `create(ConfigMetadataSchema)` to create a new message.
+ */
+export const ConfigMetadataSchema: GenMessage<ConfigMetadata> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 3);
+
+/**
+ * @generated from message config.v1beta1.AuditInfo
+ */
+export type AuditInfo = Message<"config.v1beta1.AuditInfo"> & {
+  /**
+   * @generated from field: string created_by = 1;
+   */
+  createdBy: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 2;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: string modified_by = 3;
+   */
+  modifiedBy: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp modified_at = 4;
+   */
+  modifiedAt?: Timestamp;
+};
+
+/**
+ * Describes the message config.v1beta1.AuditInfo.
+ * Use `create(AuditInfoSchema)` to create a new message.
+ */
+export const AuditInfoSchema: GenMessage<AuditInfo> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 4);
+
+/**
+ * @generated from message config.v1beta1.ListConfigsRequest
+ */
+export type ListConfigsRequest = Message<"config.v1beta1.ListConfigsRequest"> & {
+};
+
+/**
+ * Describes the message config.v1beta1.ListConfigsRequest.
+ * Use `create(ListConfigsRequestSchema)` to create a new message.
+ */
+export const ListConfigsRequestSchema: GenMessage<ListConfigsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 5);
+
+/**
+ * @generated from message config.v1beta1.ListConfigsResponse
+ */
+export type ListConfigsResponse = Message<"config.v1beta1.ListConfigsResponse"> & {
+  /**
+   * @generated from field: repeated config.v1beta1.ConfigReference configs = 1;
+   */
+  configs: ConfigReference[];
+
+  /**
+   * metadata of the listed configs, by config ID
+   *
+   * @generated from field: map<string, config.v1beta1.ConfigMetadata> metadata = 2;
+   */
+  metadata: { [key: string]: ConfigMetadata };
+};
+
+/**
+ * Describes the message config.v1beta1.ListConfigsResponse.
+ * Use `create(ListConfigsResponseSchema)` to create a new message.
+ */
+export const ListConfigsResponseSchema: GenMessage<ListConfigsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 6);
+
+/**
+ * @generated from message config.v1beta1.AssignConfigRequest
+ */
+export type AssignConfigRequest = Message<"config.v1beta1.AssignConfigRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string config_id = 2;
+   */
+  configId: string;
+
+  /**
+   * source is MANUAL (the default) to assign config_id, or DEFAULT to have the agent
+   * intentionally follow the global default config, in which case config_id must be empty
+   *
+   * @generated from field: config.v1beta1.ConfigSource source = 3;
+   */
+  source: ConfigSource;
+
+  /**
+   * roll the agent back to its last known-good config if it fails to apply the config
+   *
+   * @generated from field: bool auto_rollback = 4;
+   */
+  autoRollback: boolean;
+};
+
+/**
+ * Describes the message config.v1beta1.AssignConfigRequest.
+ * Use `create(AssignConfigRequestSchema)` to create a new message.
+ */
+export const AssignConfigRequestSchema: GenMessage<AssignConfigRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 7);
+
+/**
+ * @generated from message config.v1beta1.AssignConfigResponse
+ */
+export type AssignConfigResponse = Message<"config.v1beta1.AssignConfigResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+
+  /**
+   * @generated from field: string message = 2;
+   */
+  message: string;
+
+  /**
+   * the config's resource requirements exceeding the capacity reported by the agent
+   *
+   * @generated from field: repeated string warnings = 3;
+   */
+  warnings: string[];
+};
+
+/**
+ * Describes the message config.v1beta1.AssignConfigResponse.
+ * Use `create(AssignConfigResponseSchema)` to create a new message.
+ */
+export const AssignConfigResponseSchema: GenMessage<AssignConfigResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 8);
+
+/**
+ * @generated from message config.v1beta1.GetAgentConfigRequest
+ */
+export type GetAgentConfigRequest = Message<"config.v1beta1.GetAgentConfigRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message config.v1beta1.GetAgentConfigRequest.
+ * Use `create(GetAgentConfigRequestSchema)` to create a new message.
+ */
+export const GetAgentConfigRequestSchema: GenMessage<GetAgentConfigRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 9);
+
+/**
+ * @generated from message config.v1beta1.GetAgentConfigResponse
+ */
+export type GetAgentConfigResponse = Message<"config.v1beta1.GetAgentConfigResponse"> & {
+  /**
+   * @generated from field: string config_id = 1;
+   */
+  configId: string;
+
+  /**
+   * @generated from field: config.v1beta1.ConfigSource source = 2;
+   */
+  source: ConfigSource;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp assigned_at = 3;
+   */
+  assignedAt?: Timestamp;
+};
+
+/**
+ * Describes the message config.v1beta1.GetAgentConfigResponse.
+ * Use `create(GetAgentConfigResponseSchema)` to create a new message.
+ */
+export const GetAgentConfigResponseSchema: GenMessage<GetAgentConfigResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 10);
+
+/**
+ * @generated from message config.v1beta1.UnassignConfigRequest
+ */
+export type UnassignConfigRequest = Message<"config.v1beta1.UnassignConfigRequest"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+};
+
+/**
+ * Describes the message config.v1beta1.UnassignConfigRequest.
+ * Use `create(UnassignConfigRequestSchema)` to create a new message.
+ */
+export const UnassignConfigRequestSchema: GenMessage<UnassignConfigRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 11);
+
+/**
+ * @generated from message config.v1beta1.UnassignConfigResponse
+ */
+export type UnassignConfigResponse = Message<"config.v1beta1.UnassignConfigResponse"> & {
+  /**
+   * @generated from field: bool success = 1;
+   */
+  success: boolean;
+};
+
+/**
+ * Describes the message config.v1beta1.UnassignConfigResponse.
+ * Use `create(UnassignConfigResponseSchema)` to create a new message.
+ */
+export const UnassignConfigResponseSchema: GenMessage<UnassignConfigResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 12);
+
+/**
+ * @generated from message config.v1beta1.ListConfigAssignmentsRequest
+ */
+export type ListConfigAssignmentsRequest = Message<"config.v1beta1.ListConfigAssignmentsRequest"> & {
+  /**
+   * only list the assignments of this config
+   *
+   * @generated from field: optional string config_id = 1;
+   */
+  configId?: string;
+};
+
+/**
+ * Describes the message config.v1beta1.ListConfigAssignmentsRequest.
+ * Use `create(ListConfigAssignmentsRequestSchema)` to create a new message.
+ */
+export const ListConfigAssignmentsRequestSchema: GenMessage<ListConfigAssignmentsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 13);
+
+/**
+ * @generated from message config.v1beta1.ListConfigAssignmentsResponse
+ */
+export type ListConfigAssignmentsResponse = Message<"config.v1beta1.ListConfigAssignmentsResponse"> & {
+  /**
+   * @generated from field: repeated config.v1beta1.ConfigAssignment assignments = 1;
+   */
+  assignments: ConfigAssignment[];
+};
+
+/**
+ * Describes the message config.v1beta1.ListConfigAssignmentsResponse.
+ * Use `create(ListConfigAssignmentsResponseSchema)` to create a new message.
+ */
+export const ListConfigAssignmentsResponseSchema: GenMessage<ListConfigAssignmentsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 14);
+
+/**
+ * @generated from message config.v1beta1.ConfigAssignment
+ */
+export type ConfigAssignment = Message<"config.v1beta1.ConfigAssignment"> & {
+  /**
+   * @generated from field: string agent_id = 1;
+   */
+  agentId: string;
+
+  /**
+   * @generated from field: string config_id = 2;
+   */
+  configId: string;
+
+  /**
+   * @generated from field: config.v1beta1.ConfigSource source = 3;
+   */
+  source: ConfigSource;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp assigned_at = 4;
+   */
+  assignedAt?: Timestamp;
+
+  /**
+   * @generated from field: config.v1beta1.ConfigApplicationStatus status = 5;
+   */
+  status: ConfigApplicationStatus;
+
+  /**
+   * @generated from field: string error_message = 6;
+   */
+  errorMessage: string;
+
+  /**
+   * @generated from field: config.v1beta1.AuditInfo audit = 7;
+   */
+  audit?: AuditInfo;
+};
+
+/**
+ * Describes the message config.v1beta1.ConfigAssignment.
+ * Use `create(ConfigAssignmentSchema)` to create a new message.
+ */
+export const ConfigAssignmentSchema: GenMessage<ConfigAssignment> = /*@__PURE__*/
+  messageDesc(file_pkg_api_config_v1beta1_config, 15);
+
+/**
+ * @generated from enum config.v1beta1.ConfigSource
+ */
+export enum ConfigSource {
+  /**
+   * @generated from enum value: CONFIG_SOURCE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: CONFIG_SOURCE_DEFAULT = 1;
+   */
+  DEFAULT = 1,
+
+  /**
+   * @generated from enum value: CONFIG_SOURCE_BOOTSTRAP = 2;
+   */
+  BOOTSTRAP = 2,
+
+  /**
+   * @generated from enum value: CONFIG_SOURCE_MANUAL = 3;
+   */
+  MANUAL = 3,
+
+  /**
+   * assigned by an assignment policy
+   *
+   * @generated from enum value: CONFIG_SOURCE_POLICY = 4;
+   */
+  POLICY = 4,
+}
+
+/**
+ * Describes the enum config.v1beta1.ConfigSource.
+ */
+export const ConfigSourceSchema: GenEnum<ConfigSource> = /*@__PURE__*/
+  enumDesc(file_pkg_api_config_v1beta1_config, 0);
+
+/**
+ * @generated from enum config.v1beta1.ConfigApplicationStatus
+ */
+export enum ConfigApplicationStatus {
+  /**
+   * @generated from enum value: CONFIG_APPLICATION_STATUS_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * @generated from enum value: CONFIG_APPLICATION_STATUS_PENDING = 1;
+   */
+  PENDING = 1,
+
+  /**
+   * @generated from enum value: CONFIG_APPLICATION_STATUS_APPLIED = 2;
+   */
+  APPLIED = 2,
+
+  /**
+   * @generated from enum value: CONFIG_APPLICATION_STATUS_FAILED = 3;
+   */
+  FAILED = 3,
+
+  /**
+   * the agent does not accept remote config, so the assignment is never applied
+   *
+   * @generated from enum value: CONFIG_APPLICATION_STATUS_UNSUPPORTED = 4;
+   */
+  UNSUPPORTED = 4,
+}
+
+/**
+ * Describes the enum config.v1beta1.ConfigApplicationStatus.
+ */
+export const ConfigApplicationStatusSchema: GenEnum<ConfigApplicationStatus> = /*@__PURE__*/
+  enumDesc(file_pkg_api_config_v1beta1_config, 1);
+
+/**
+ * ConfigService is the beta version of config.v1alpha1.ConfigService. Its messages are
+ * wire-compatible subsets of the v1alpha1 messages, calls are served by the v1alpha1 handlers.
+ *
+ * @generated from service config.v1beta1.ConfigService
+ */
+export const ConfigService: GenService<{
+  /**
+   * @generated from rpc config.v1beta1.ConfigService.PutConfig
+   */
+  putConfig: {
+    methodKind: "unary";
+    input: typeof PutConfigRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * @generated from rpc config.v1beta1.ConfigService.GetConfig
+   */
+  getConfig: {
+    methodKind: "unary";
+    input: typeof ConfigReferenceSchema;
+    output: typeof ConfigSchema;
+  },
+  /**
+   * @generated from rpc config.v1beta1.ConfigService.DeleteConfig
+   */
+  deleteConfig: {
+    methodKind: "unary";
+    input: typeof ConfigReferenceSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * @generated from rpc config.v1beta1.ConfigService.ListConfigs
+   */
+  listConfigs: {
+    methodKind: "unary";
+    input: typeof ListConfigsRequestSchema;
+    output: typeof ListConfigsResponseSchema;
+  },
+  /**
+   * @generated from rpc config.v1beta1.ConfigService.AssignConfig
+   */
+  assignConfig: {
+    methodKind: "unary";
+    input: typeof AssignConfigRequestSchema;
+    output: typeof AssignConfigResponseSchema;
+  },
+  /**
+   * @generated from rpc config.v1beta1.ConfigService.GetAgentConfig
+   */
+  getAgentConfig: {
+    methodKind: "unary";
+    input: typeof GetAgentConfigRequestSchema;
+    output: typeof GetAgentConfigResponseSchema;
+  },
+  /**
+   * @generated from rpc config.v1beta1.ConfigService.UnassignConfig
+   */
+  unassignConfig: {
+    methodKind: "unary";
+    input: typeof UnassignConfigRequestSchema;
+    output: typeof UnassignConfigResponseSchema;
+  },
+  /**
+   * @generated from rpc config.v1beta1.ConfigService.ListConfigAssignments
+   */
+  listConfigAssignments: {
+    methodKind: "unary";
+    input: typeof ListConfigAssignmentsRequestSchema;
+    output: typeof ListConfigAssignmentsResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_pkg_api_config_v1beta1_config, 0);
+
@@ -0,0 +1,265 @@
+// @generated by protoc-gen-es v2.10.2 with parameter "target=ts"
+// @generated from file pkg/api/events/v1alpha1/events.proto (package events.v1alpha1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file pkg/api/events/v1alpha1/events.proto.
+ */
+export const file_pkg_api_events_v1alpha1_events: GenFile = /*@__PURE__*/
+  fileDesc("CiRwa2cvYXBpL2V2ZW50cy92MWFscGhhMS9ldmVudHMucHJvdG8SD2V2ZW50cy52MWFscGhhMSKOAgoFRXZlbnQSEAoIc2VxdWVuY2UYASABKAQSKAoEdGltZRgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDAoEdHlwZRgDIAEoCRIQCghhZ2VudF9pZBgEIAEoCRIyCgZsYWJlbHMYBSADKAsyIi5ldmVudHMudjFhbHBoYTEuRXZlbnQuTGFiZWxzRW50cnkSDwoHbWVzc2FnZRgGIAEoCRIRCglwcmluY2lwYWwYByABKAkSEgoKcmVxdWVzdF9pZBgIIAEoCRIOCgZ0ZW5hbnQYCSABKAkaLQoLTGFiZWxzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASKAAgoLRXZlbnRGaWx0ZXISKQoFc3RhcnQYASABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEicKA2VuZBgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEQoJYWdlbnRfaWRzGAMgAygJEg0KBXR5cGVzGAQgAygJEjgKBmxhYmVscxgFIAMoCzIoLmV2ZW50cy52MWFscGhhMS5FdmVudEZpbHRlci5MYWJlbHNFbnRyeRISCgpwcmluY2lwYWxzGAYgAygJGi0KC0xhYmVsc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEiZAoRTGlzdEV2ZW50c1JlcXVlc3QSLAoGZmlsdGVyGAEgASgLMhwuZXZlbnRzLnYxYWxwaGExLkV2ZW50RmlsdGVyEg0KBWxpbWl0GAIgASgFEhIKCnBhZ2VfdG9rZW4YAyABKAkiVQoSTGlzdEV2ZW50c1Jlc3BvbnNlEiYKBmV2ZW50cxgBIAMoCzIWLmV2ZW50cy52MWFscGhhMS5FdmVudBIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkiWAoSV2F0Y2hFdmVudHNSZXF1ZXN0EiwKBmZpbHRlchgBIAEoCzIcLmV2ZW50cy52MWFscGhhMS5FdmVudEZpbHRlchIUCgxyZXN1bWVfdG9rZW4YAiABKAkiUgoTV2F0Y2hFdmVudHNSZXNwb25zZRIlCgVldmVudBgBIAEoCzIWLmV2ZW50cy52MWFscGhhMS5FdmVudBIUCgxyZXN1bWVfdG9rZW4YAiABKAkywQEKDEV2ZW50U2VydmljZRJVCgpMaXN0RXZlbnRzEiIuZXZlbnRzLnYxYWxwaGExLkxpc3RFdmVudHNSZXF1ZXN0GiMuZXZlbnRzLnYxYWxwaGExLkxpc3RFdmVudHNSZXNwb25zZRJaCgtXYXRjaEV2ZW50cxIjLmV2ZW50cy52MWFscGhhMS5XYXRjaEV2ZW50c1JlcXVlc3QaJC5ldmVudHMudjFhbHBoYTEuV2F0Y2hFdmVudHNSZXNwb25zZTABQjhaNmdpdGh1Yi5jb20vb3RlbGZsZWV0L290ZWxmbGVldC9wa2cvYXBpL2V2ZW50cy92MWFscGhhMWIGcHJvdG8z", [file_google_protobuf_timestamp]);
+
+/**
+ * Event is a notable change in the fleet, e.g. an agent connecting.
+ *
+ * @generated from message events.v1alpha1.Event
+ */
+export type Event = Message<"events.v1alpha1.Event"> & {
+  /**
+   * monotonically increasing sequence number
+   *
+   * @generated from field: uint64 sequence = 1;
+   */
+  sequence: bigint;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp time = 2;
+   */
+  time?: Timestamp;
+
+  /**
+   * dot separated event type, e.g. "agent.connected"
+   *
+   * @generated from field: string type = 3;
+   */
+  type: string;
+
+  /**
+   * @generated from field: string agent_id = 4;
+   */
+  agentId: string;
+
+  /**
+   * labels of the agent, or of the object the event relates to
+   *
+   * @generated from field: map<string, string> labels = 5;
+   */
+  labels: { [key: string]: string };
+
+  /**
+   * @generated from field: string message = 6;
+   */
+  message: string;
+
+  /**
+   * authenticated principal that made the change, empty for changes made by
+   * agents or anonymous callers
+   *
+   * @generated from field: string principal = 7;
+   */
+  principal: string;
+
+  /**
+   * ID of the API request that made the change, see the X-Request-ID header
+   *
+   * @generated from field: string request_id = 8;
+   */
+  requestId: string;
+
+  /**
+   * tenant of the agent, or of the request that made the change, the events of a
+   * tenant are only visible to its requests
+   *
+   * @generated from field: string tenant = 9;
+   */
+  tenant: string;
+};
+
+/**
+ * Describes the message events.v1alpha1.Event.
+ * Use `create(EventSchema)` to create a new message.
+ */
+export const EventSchema: GenMessage<Event> = /*@__PURE__*/
+  messageDesc(file_pkg_api_events_v1alpha1_events, 0);
+
+/**
+ * @generated from message events.v1alpha1.EventFilter
+ */
+export type EventFilter = Message<"events.v1alpha1.EventFilter"> & {
+  /**
+   * inclusive lower bound on the event time
+   *
+   * @generated from field: google.protobuf.Timestamp start = 1;
+   */
+  start?: Timestamp;
+
+  /**
+   * exclusive upper bound on the event time
+   *
+   * @generated from field: google.protobuf.Timestamp end = 2;
+   */
+  end?: Timestamp;
+
+  /**
+   * @generated from field: repeated string agent_ids = 3;
+   */
+  agentIds: string[];
+
+  /**
+   * @generated from field: repeated string types = 4;
+   */
+  types: string[];
+
+  /**
+   * events must have all of these labels
+   *
+   * @generated from field: map<string, string> labels = 5;
+   */
+  labels: { [key: string]: string };
+
+  /**
+   * @generated from field: repeated string principals = 6;
+   */
+  principals: string[];
+};
+
+/**
+ * Describes the message events.v1alpha1.EventFilter.
+ * Use `create(EventFilterSchema)` to create a new message.
+ */
+export const EventFilterSchema: GenMessage<EventFilter> = /*@__PURE__*/
+  messageDesc(file_pkg_api_events_v1alpha1_events, 1);
+
+/**
+ * @generated from message events.v1alpha1.ListEventsRequest
+ */
+export type ListEventsRequest = Message<"events.v1alpha1.ListEventsRequest"> & {
+  /**
+   * @generated from field: events.v1alpha1.EventFilter filter = 1;
+   */
+  filter?: EventFilter;
+
+  /**
+   * maximum number of events to return, 0 for the server default
+   *
+   * @generated from field: int32 limit = 2;
+   */
+  limit: number;
+
+  /**
+   * next_page_token from a previous response
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken: string;
+};
+
+/**
+ * Describes the message events.v1alpha1.ListEventsRequest.
+ * Use `create(ListEventsRequestSchema)` to create a new message.
+ */
+export const ListEventsRequestSchema: GenMessage<ListEventsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_events_v1alpha1_events, 2);
+
+/**
+ * @generated from message events.v1alpha1.ListEventsResponse
+ */
+export type ListEventsResponse = Message<"events.v1alpha1.ListEventsResponse"> & {
+  /**
+   * @generated from field: repeated events.v1alpha1.Event events = 1;
+   */
+  events: Event[];
+
+  /**
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken: string;
+};
+
+/**
+ * Describes the message events.v1alpha1.ListEventsResponse.
+ * Use `create(ListEventsResponseSchema)` to create a new message.
+ */
+export const ListEventsResponseSchema: GenMessage<ListEventsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_events_v1alpha1_events, 3);
+
+/**
+ * @generated from message events.v1alpha1.WatchEventsRequest
+ */
+export type WatchEventsRequest = Message<"events.v1alpha1.WatchEventsRequest"> & {
+  /**
+   * @generated from field: events.v1alpha1.EventFilter filter = 1;
+   */
+  filter?: EventFilter;
+
+  /**
+   * resume_token from a previously received event; if unset, only new events are streamed
+   *
+   * @generated from field: string resume_token = 2;
+   */
+  resumeToken: string;
+};
+
+/**
+ * Describes the message events.v1alpha1.WatchEventsRequest.
+ * Use `create(WatchEventsRequestSchema)` to create a new message.
+ */
+export const WatchEventsRequestSchema: GenMessage<WatchEventsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_events_v1alpha1_events, 4);
+
+/**
+ * @generated from message events.v1alpha1.WatchEventsResponse
+ */
+export type WatchEventsResponse = Message<"events.v1alpha1.WatchEventsResponse"> & {
+  /**
+   * @generated from field: events.v1alpha1.Event event = 1;
+   */
+  event?: Event;
+
+  /**
+   * @generated from field: string resume_token = 2;
+   */
+  resumeToken: string;
+};
+
+/**
+ * Describes the message events.v1alpha1.WatchEventsResponse.
+ * Use `create(WatchEventsResponseSchema)` to create a new message.
+ */
+export const WatchEventsResponseSchema: GenMessage<WatchEventsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_events_v1alpha1_events, 5);
+
+/**
+ * @generated from service events.v1alpha1.EventService
+ */
+export const EventService: GenService<{
+  /**
+   * ListEvents returns retained events matching the filter, oldest first
+   *
+   * @generated from rpc events.v1alpha1.EventService.ListEvents
+   */
+  listEvents: {
+    methodKind: "unary";
+    input: typeof ListEventsRequestSchema;
+    output: typeof ListEventsResponseSchema;
+  },
+  /**
+   * WatchEvents streams events matching the filter as they happen.
+   * Consumers pass the last received resume token to catch up on events
+   * emitted while they were disconnected.
+   *
+   * @generated from rpc events.v1alpha1.EventService.WatchEvents
+   */
+  watchEvents: {
+    methodKind: "server_streaming";
+    input: typeof WatchEventsRequestSchema;
+    output: typeof WatchEventsResponseSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_pkg_api_events_v1alpha1_events, 0);
+
@@ -0,0 +1,273 @@
+// @generated by protoc-gen-es v2.10.2 with parameter "target=ts"
+// @generated from file pkg/api/jobs/v1alpha1/jobs.proto (package jobs.v1alpha1, syntax proto3)
+/* eslint-disable */
+
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Any, Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_any, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file pkg/api/jobs/v1alpha1/jobs.proto.
+ */
+export const file_pkg_api_jobs_v1alpha1_jobs: GenFile = /*@__PURE__*/
+  fileDesc("CiBwa2cvYXBpL2pvYnMvdjFhbHBoYTEvam9icy5wcm90bxINam9icy52MWFscGhhMSK1AwoDSm9iEgoKAmlkGAEgASgJEgwKBHR5cGUYAiABKAkSJgoFc3RhdGUYAyABKA4yFy5qb2JzLnYxYWxwaGExLkpvYlN0YXRlEiUKB3BheWxvYWQYBCABKAsyFC5nb29nbGUucHJvdG9idWYuQW55EiQKBnJlc3VsdBgFIAEoCzIULmdvb2dsZS5wcm90b2J1Zi5BbnkSEAoIYXR0ZW1wdHMYBiABKAUSFAoMbWF4X2F0dGVtcHRzGAcgASgFEhIKCmxhc3RfZXJyb3IYCCABKAkSLgoKY3JlYXRlZF9hdBgJIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLgoKc3RhcnRlZF9hdBgKIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASMAoMY29tcGxldGVkX2F0GAsgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIvCgtuZXh0X3J1bl9hdBgMIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEQoJcHJpbmNpcGFsGA0gASgJEg0KBXJvbGVzGA4gAygJIhsKDUdldEpvYlJlcXVlc3QSCgoCaWQYASABKAkiWAoPTGlzdEpvYnNSZXF1ZXN0Eg0KBXR5cGVzGAEgAygJEicKBnN0YXRlcxgCIAMoDjIXLmpvYnMudjFhbHBoYTEuSm9iU3RhdGUSDQoFbGltaXQYAyABKAUiNAoQTGlzdEpvYnNSZXNwb25zZRIgCgRqb2JzGAEgAygLMhIuam9icy52MWFscGhhMS5Kb2IiHgoQQ2FuY2VsSm9iUmVxdWVzdBIKCgJpZBgBIAEoCSqbAQoISm9iU3RhdGUSGQoVSk9CX1NUQVRFX1VOU1BFQ0lGSUVEEAASFQoRSk9CX1NUQVRFX1BFTkRJTkcQARIVChFKT0JfU1RBVEVfUlVOTklORxACEhcKE0pPQl9TVEFURV9TVUNDRUVERUQQAxIUChBKT0JfU1RBVEVfRkFJTEVEEAQSFwoTSk9CX1NUQVRFX0NBTkNFTExFRBAFMtcBCgpKb2JTZXJ2aWNlEjoKBkdldEpvYhIcLmpvYnMudjFhbHBoYTEuR2V0Sm9iUmVxdWVzdBoSLmpvYnMudjFhbHBoYTEuSm9iEksKCExpc3RKb2JzEh4uam9icy52MWFscGhhMS5MaXN0Sm9ic1JlcXVlc3QaHy5qb2JzLnYxYWxwaGExLkxpc3RKb2JzUmVzcG9uc2USQAoJQ2FuY2VsSm9iEh8uam9icy52MWFscGhhMS5DYW5jZWxKb2JSZXF1ZXN0GhIuam9icy52MWFscGhhMS5Kb2JCNlo0Z2l0aHViLmNvbS9vdGVsZmxlZXQvb3RlbGZsZWV0L3BrZy9hcGkvam9icy92MWFscGhhMWIGcHJvdG8z", [file_google_protobuf_any, file_google_protobuf_timestamp]);
+
+/**
+ * Job is an operation executed in the background by the server, persisted so that it
+ * survives restarts
+ *
+ * @generated from message jobs.v1alpha1.Job
+ */
+export type Job = Message<"jobs.v1alpha1.Job"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * dot separated job type, e.g. "deployment.rollout"
+   *
+   * @generated from field: string type = 2;
+   */
+  type: string;
+
+  /**
+   * @generated from field: jobs.v1alpha1.JobState state = 3;
+   */
+  state: JobState;
+
+  /**
+   * input of the job, specific to its type
+   *
+   * @generated from field: google.protobuf.Any payload = 4;
+   */
+  payload?: Any;
+
+  /**
+   * output of the job once it succeeded, specific to its type
+   *
+   * @generated from field: google.protobuf.Any result = 5;
+   */
+  result?: Any;
+
+  /**
+   * number of times the job was started, including the current run
+   *
+   * @generated from field: int32 attempts = 6;
+   */
+  attempts: number;
+
+  /**
+   * @generated from field: int32 max_attempts = 7;
+   */
+  maxAttempts: number;
+
+  /**
+   * @generated from field: string last_error = 8;
+   */
+  lastError: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 9;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp started_at = 10;
+   */
+  startedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp completed_at = 11;
+   */
+  completedAt?: Timestamp;
+
+  /**
+   * when a pending job may next run, set for retries
+   *
+   * @generated from field: google.protobuf.Timestamp next_run_at = 12;
+   */
+  nextRunAt?: Timestamp;
+
+  /**
+   * principal that enqueued the job, the job runs on its behalf
+   *
+   * @generated from field: string principal = 13;
+   */
+  principal: string;
+
+  /**
+   * @generated from field: repeated string roles = 14;
+   */
+  roles: string[];
+};
+
+/**
+ * Describes the message jobs.v1alpha1.Job.
+ * Use `create(JobSchema)` to create a new message.
+ */
+export const JobSchema: GenMessage<Job> = /*@__PURE__*/
+  messageDesc(file_pkg_api_jobs_v1alpha1_jobs, 0);
+
+/**
+ * @generated from message jobs.v1alpha1.GetJobRequest
+ */
+export type GetJobRequest = Message<"jobs.v1alpha1.GetJobRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message jobs.v1alpha1.GetJobRequest.
+ * Use `create(GetJobRequestSchema)` to create a new message.
+ */
+export const GetJobRequestSchema: GenMessage<GetJobRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_jobs_v1alpha1_jobs, 1);
+
+/**
+ * @generated from message jobs.v1alpha1.ListJobsRequest
+ */
+export type ListJobsRequest = Message<"jobs.v1alpha1.ListJobsRequest"> & {
+  /**
+   * @generated from field: repeated string types = 1;
+   */
+  types: string[];
+
+  /**
+   * @generated from field: repeated jobs.v1alpha1.JobState states = 2;
+   */
+  states: JobState[];
+
+  /**
+   * maximum number of jobs to return, 0 for the server default
+   *
+   * @generated from field: int32 limit = 3;
+   */
+  limit: number;
+};
+
+/**
+ * Describes the message jobs.v1alpha1.ListJobsRequest.
+ * Use `create(ListJobsRequestSchema)` to create a new message.
+ */
+export const ListJobsRequestSchema: GenMessage<ListJobsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_jobs_v1alpha1_jobs, 2);
+
+/**
+ * @generated from message jobs.v1alpha1.ListJobsResponse
+ */
+export type ListJobsResponse = Message<"jobs.v1alpha1.ListJobsResponse"> & {
+  /**
+   * @generated from field: repeated jobs.v1alpha1.Job jobs = 1;
+   */
+  jobs: Job[];
+};
+
+/**
+ * Describes the message jobs.v1alpha1.ListJobsResponse.
+ * Use `create(ListJobsResponseSchema)` to create a new message.
+ */
+export const ListJobsResponseSchema: GenMessage<ListJobsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_jobs_v1alpha1_jobs, 3);
+
+/**
+ * @generated from message jobs.v1alpha1.CancelJobRequest
+ */
+export type CancelJobRequest = Message<"jobs.v1alpha1.CancelJobRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message jobs.v1alpha1.CancelJobRequest.
+ * Use `create(CancelJobRequestSchema)` to create a new message.
+ */
+export const CancelJobRequestSchema: GenMessage<CancelJobRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_jobs_v1alpha1_jobs, 4);
+
+/**
+ * @generated from enum jobs.v1alpha1.JobState
+ */
+export enum JobState {
+  /**
+   * @generated from enum value: JOB_STATE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * waiting for a worker, either for the first time or to be retried
+   *
+   * @generated from enum value: JOB_STATE_PENDING = 1;
+   */
+  PENDING = 1,
+
+  /**
+   * @generated from enum value: JOB_STATE_RUNNING = 2;
+   */
+  RUNNING = 2,
+
+  /**
+   * @generated from enum value: JOB_STATE_SUCCEEDED = 3;
+   */
+  SUCCEEDED = 3,
+
+  /**
+   * failed with a permanent error, or on its last attempt
+   *
+   * @generated from enum value: JOB_STATE_FAILED = 4;
+   */
+  FAILED = 4,
+
+  /**
+   * @generated from enum value: JOB_STATE_CANCELLED = 5;
+   */
+  CANCELLED = 5,
+}
+
+/**
+ * Describes the enum jobs.v1alpha1.JobState.
+ */
+export const JobStateSchema: GenEnum<JobState> = /*@__PURE__*/
+  enumDesc(file_pkg_api_jobs_v1alpha1_jobs, 0);
+
+/**
+ * @generated from service jobs.v1alpha1.JobService
+ */
+export const JobService: GenService<{
+  /**
+   * @generated from rpc jobs.v1alpha1.JobService.GetJob
+   */
+  getJob: {
+    methodKind: "unary";
+    input: typeof GetJobRequestSchema;
+    output: typeof JobSchema;
+  },
+  /**
+   * ListJobs returns jobs matching the filter, most recently created first
+   *
+   * @generated from rpc jobs.v1alpha1.JobService.ListJobs
+   */
+  listJobs: {
+    methodKind: "unary";
+    input: typeof ListJobsRequestSchema;
+    output: typeof ListJobsResponseSchema;
+  },
+  /**
+   * CancelJob cancels a pending or running job, returning once it stopped
+   *
+   * @generated from rpc jobs.v1alpha1.JobService.CancelJob
+   */
+  cancelJob: {
+    methodKind: "unary";
+    input: typeof CancelJobRequestSchema;
+    output: typeof JobSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_pkg_api_jobs_v1alpha1_jobs, 0);
+
@@ -0,0 +1,391 @@
+// @generated by protoc-gen-es v2.10.2 with parameter "target=ts"
+// @generated from file pkg/api/rbac/v1alpha1/rbac.proto (package rbac.v1alpha1, syntax proto3)
+/* eslint-disable */
+
+import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Duration, EmptySchema, Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_duration, file_google_protobuf_empty, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file pkg/api/rbac/v1alpha1/rbac.proto.
+ */
+export const file_pkg_api_rbac_v1alpha1_rbac: GenFile = /*@__PURE__*/
+  fileDesc("CiBwa2cvYXBpL3JiYWMvdjFhbHBoYTEvcmJhYy5wcm90bxINcmJhYy52MWFscGhhMSISChBHZXRDYWxsZXJSZXF1ZXN0IigKBkNhbGxlchIPCgdzdWJqZWN0GAEgASgJEg0KBXJvbGVzGAIgAygJIrwBCgZBUElLZXkSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRIPCgdzdWJqZWN0GAMgASgJEhMKC3NlY3JldF9oYXNoGAQgASgMEi4KCmNyZWF0ZWRfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCmV4cGlyZXNfYXQYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhIKCmNyZWF0ZWRfYnkYByABKAkicQoLUm9sZUJpbmRpbmcSDwoHc3ViamVjdBgBIAEoCRINCgVyb2xlcxgCIAMoCRIuCgp1cGRhdGVkX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBISCgp1cGRhdGVkX2J5GAQgASgJIloKE0NyZWF0ZUFQSUtleVJlcXVlc3QSDAoEbmFtZRgBIAEoCRINCgVyb2xlcxgCIAMoCRImCgN0dGwYAyABKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24iSQoUQ3JlYXRlQVBJS2V5UmVzcG9uc2USIgoDa2V5GAEgASgLMhUucmJhYy52MWFscGhhMS5BUElLZXkSDQoFdG9rZW4YAiABKAkiFAoSTGlzdEFQSUtleXNSZXF1ZXN0IjoKE0xpc3RBUElLZXlzUmVzcG9uc2USIwoEa2V5cxgBIAMoCzIVLnJiYWMudjFhbHBoYTEuQVBJS2V5IiEKE0RlbGV0ZUFQSUtleVJlcXVlc3QSCgoCaWQYASABKAkiNwoVUHV0Um9sZUJpbmRpbmdSZXF1ZXN0Eg8KB3N1YmplY3QYASABKAkSDQoFcm9sZXMYAiADKAkiGQoXTGlzdFJvbGVCaW5kaW5nc1JlcXVlc3QiSAoYTGlzdFJvbGVCaW5kaW5nc1Jlc3BvbnNlEiwKCGJpbmRpbmdzGAEgAygLMhoucmJhYy52MWFscGhhMS5Sb2xlQmluZGluZyIrChhEZWxldGVSb2xlQmluZGluZ1JlcXVlc3QSDwoHc3ViamVjdBgBIAEoCTLcBAoLUkJBQ1NlcnZpY2USQwoJR2V0Q2FsbGVyEh8ucmJhYy52MWFscGhhMS5HZXRDYWxsZXJSZXF1ZXN0GhUucmJhYy52MWFscGhhMS5DYWxsZXISVwoMQ3JlYXRlQVBJS2V5EiIucmJhYy52MWFscGhhMS5DcmVhdGVBUElLZXlSZXF1ZXN0GiMucmJhYy52MWFscGhhMS5DcmVhdGVBUElLZXlSZXNwb25zZRJUCgtMaXN0QVBJS2V5cxIhLnJiYWMudjFhbHBoYTEuTGlzdEFQSUtleXNSZXF1ZXN0GiIucmJhYy52MWFscGhhMS5MaXN0QVBJS2V5c1Jlc3BvbnNlEkoKDERlbGV0ZUFQSUtleRIiLnJiYWMudjFhbHBoYTEuRGVsZXRlQVBJS2V5UmVxdWVzdBoWLmdvb2dsZS5wcm90b2J1Zi5FbXB0eRJSCg5QdXRSb2xlQmluZGluZxIkLnJiYWMudjFhbHBoYTEuUHV0Um9sZUJpbmRpbmdSZXF1ZXN0GhoucmJhYy52MWFscGhhMS5Sb2xlQmluZGluZxJjChBMaXN0Um9sZUJpbmRpbmdzEiYucmJhYy52MWFscGhhMS5MaXN0Um9sZUJpbmRpbmdzUmVxdWVzdBonLnJiYWMudjFhbHBoYTEuTGlzdFJvbGVCaW5kaW5nc1Jlc3BvbnNlElQKEURlbGV0ZVJvbGVCaW5kaW5nEicucmJhYy52MWFscGhhMS5EZWxldGVSb2xlQmluZGluZ1JlcXVlc3QaFi5nb29nbGUucHJvdG9idWYuRW1wdHlCNlo0Z2l0aHViLmNvbS9vdGVsZmxlZXQvb3RlbGZsZWV0L3BrZy9hcGkvcmJhYy92MWFscGhhMWIGcHJvdG8z", [file_google_protobuf_duration, file_google_protobuf_empty, file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message rbac.v1alpha1.GetCallerRequest
+ */
+export type GetCallerRequest = Message<"rbac.v1alpha1.GetCallerRequest"> & {
+};
+
+/**
+ * Describes the message rbac.v1alpha1.GetCallerRequest.
+ * Use `create(GetCallerRequestSchema)` to create a new message.
+ */
+export const GetCallerRequestSchema: GenMessage<GetCallerRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 0);
+
+/**
+ * @generated from message rbac.v1alpha1.Caller
+ */
+export type Caller = Message<"rbac.v1alpha1.Caller"> & {
+  /**
+   * e.g. the subject claim of an OIDC token, or apikey:<id> for API keys
+   *
+   * @generated from field: string subject = 1;
+   */
+  subject: string;
+
+  /**
+   * @generated from field: repeated string roles = 2;
+   */
+  roles: string[];
+};
+
+/**
+ * Describes the message rbac.v1alpha1.Caller.
+ * Use `create(CallerSchema)` to create a new message.
+ */
+export const CallerSchema: GenMessage<Caller> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 1);
+
+/**
+ * APIKey authenticates callers presenting its token as a bearer token
+ *
+ * @generated from message rbac.v1alpha1.APIKey
+ */
+export type APIKey = Message<"rbac.v1alpha1.APIKey"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * subject of the callers presenting the key, its roles are bound to it
+   *
+   * @generated from field: string subject = 3;
+   */
+  subject: string;
+
+  /**
+   * SHA-256 of the secret of the token, never returned
+   *
+   * @generated from field: bytes secret_hash = 4;
+   */
+  secretHash: Uint8Array;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 5;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * unset for keys that don't expire
+   *
+   * @generated from field: google.protobuf.Timestamp expires_at = 6;
+   */
+  expiresAt?: Timestamp;
+
+  /**
+   * subject of the caller that created the key
+   *
+   * @generated from field: string created_by = 7;
+   */
+  createdBy: string;
+};
+
+/**
+ * Describes the message rbac.v1alpha1.APIKey.
+ * Use `create(APIKeySchema)` to create a new message.
+ */
+export const APIKeySchema: GenMessage<APIKey> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 2);
+
+/**
+ * RoleBinding grants roles to the callers authenticated as a subject
+ *
+ * @generated from message rbac.v1alpha1.RoleBinding
+ */
+export type RoleBinding = Message<"rbac.v1alpha1.RoleBinding"> & {
+  /**
+   * @generated from field: string subject = 1;
+   */
+  subject: string;
+
+  /**
+   * @generated from field: repeated string roles = 2;
+   */
+  roles: string[];
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 3;
+   */
+  updatedAt?: Timestamp;
+
+  /**
+   * subject of the caller that last changed the binding
+   *
+   * @generated from field: string updated_by = 4;
+   */
+  updatedBy: string;
+};
+
+/**
+ * Describes the message rbac.v1alpha1.RoleBinding.
+ * Use `create(RoleBindingSchema)` to create a new message.
+ */
+export const RoleBindingSchema: GenMessage<RoleBinding> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 3);
+
+/**
+ * @generated from message rbac.v1alpha1.CreateAPIKeyRequest
+ */
+export type CreateAPIKeyRequest = Message<"rbac.v1alpha1.CreateAPIKeyRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: repeated string roles = 2;
+   */
+  roles: string[];
+
+  /**
+   * the key doesn't expire if unset
+   *
+   * @generated from field: google.protobuf.Duration ttl = 3;
+   */
+  ttl?: Duration;
+};
+
+/**
+ * Describes the message rbac.v1alpha1.CreateAPIKeyRequest.
+ * Use `create(CreateAPIKeyRequestSchema)` to create a new message.
+ */
+export const CreateAPIKeyRequestSchema: GenMessage<CreateAPIKeyRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 4);
+
+/**
+ * @generated from message rbac.v1alpha1.CreateAPIKeyResponse
+ */
+export type CreateAPIKeyResponse = Message<"rbac.v1alpha1.CreateAPIKeyResponse"> & {
+  /**
+   * @generated from field: rbac.v1alpha1.APIKey key = 1;
+   */
+  key?: APIKey;
+
+  /**
+   * presented as "Authorization: Bearer <token>"
+   *
+   * @generated from field: string token = 2;
+   */
+  token: string;
+};
+
+/**
+ * Describes the message rbac.v1alpha1.CreateAPIKeyResponse.
+ * Use `create(CreateAPIKeyResponseSchema)` to create a new message.
+ */
+export const CreateAPIKeyResponseSchema: GenMessage<CreateAPIKeyResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 5);
+
+/**
+ * @generated from message rbac.v1alpha1.ListAPIKeysRequest
+ */
+export type ListAPIKeysRequest = Message<"rbac.v1alpha1.ListAPIKeysRequest"> & {
+};
+
+/**
+ * Describes the message rbac.v1alpha1.ListAPIKeysRequest.
+ * Use `create(ListAPIKeysRequestSchema)` to create a new message.
+ */
+export const ListAPIKeysRequestSchema: GenMessage<ListAPIKeysRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 6);
+
+/**
+ * @generated from message rbac.v1alpha1.ListAPIKeysResponse
+ */
+export type ListAPIKeysResponse = Message<"rbac.v1alpha1.ListAPIKeysResponse"> & {
+  /**
+   * @generated from field: repeated rbac.v1alpha1.APIKey keys = 1;
+   */
+  keys: APIKey[];
+};
+
+/**
+ * Describes the message rbac.v1alpha1.ListAPIKeysResponse.
+ * Use `create(ListAPIKeysResponseSchema)` to create a new message.
+ */
+export const ListAPIKeysResponseSchema: GenMessage<ListAPIKeysResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 7);
+
+/**
+ * @generated from message rbac.v1alpha1.DeleteAPIKeyRequest
+ */
+export type DeleteAPIKeyRequest = Message<"rbac.v1alpha1.DeleteAPIKeyRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message rbac.v1alpha1.DeleteAPIKeyRequest.
+ * Use `create(DeleteAPIKeyRequestSchema)` to create a new message.
+ */
+export const DeleteAPIKeyRequestSchema: GenMessage<DeleteAPIKeyRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 8);
+
+/**
+ * @generated from message rbac.v1alpha1.PutRoleBindingRequest
+ */
+export type PutRoleBindingRequest = Message<"rbac.v1alpha1.PutRoleBindingRequest"> & {
+  /**
+   * @generated from field: string subject = 1;
+   */
+  subject: string;
+
+  /**
+   * @generated from field: repeated string roles = 2;
+   */
+  roles: string[];
+};
+
+/**
+ * Describes the message rbac.v1alpha1.PutRoleBindingRequest.
+ * Use `create(PutRoleBindingRequestSchema)` to create a new message.
+ */
+export const PutRoleBindingRequestSchema: GenMessage<PutRoleBindingRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 9);
+
+/**
+ * @generated from message rbac.v1alpha1.ListRoleBindingsRequest
+ */
+export type ListRoleBindingsRequest = Message<"rbac.v1alpha1.ListRoleBindingsRequest"> & {
+};
+
+/**
+ * Describes the message rbac.v1alpha1.ListRoleBindingsRequest.
+ * Use `create(ListRoleBindingsRequestSchema)` to create a new message.
+ */
+export const ListRoleBindingsRequestSchema: GenMessage<ListRoleBindingsRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 10);
+
+/**
+ * @generated from message rbac.v1alpha1.ListRoleBindingsResponse
+ */
+export type ListRoleBindingsResponse = Message<"rbac.v1alpha1.ListRoleBindingsResponse"> & {
+  /**
+   * @generated from field: repeated rbac.v1alpha1.RoleBinding bindings = 1;
+   */
+  bindings: RoleBinding[];
+};
+
+/**
+ * Describes the message rbac.v1alpha1.ListRoleBindingsResponse.
+ * Use `create(ListRoleBindingsResponseSchema)` to create a new message.
+ */
+export const ListRoleBindingsResponseSchema: GenMessage<ListRoleBindingsResponse> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 11);
+
+/**
+ * @generated from message rbac.v1alpha1.DeleteRoleBindingRequest
+ */
+export type DeleteRoleBindingRequest = Message<"rbac.v1alpha1.DeleteRoleBindingRequest"> & {
+  /**
+   * @generated from field: string subject = 1;
+   */
+  subject: string;
+};
+
+/**
+ * Describes the message rbac.v1alpha1.DeleteRoleBindingRequest.
+ * Use `create(DeleteRoleBindingRequestSchema)` to create a new message.
+ */
+export const DeleteRoleBindingRequestSchema: GenMessage<DeleteRoleBindingRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_rbac_v1alpha1_rbac, 12);
+
+/**
+ * RBACService manages the API keys and role bindings of the management API callers. Callers
+ * authenticate with an API key or an OIDC bearer token, and are granted the roles bound to
+ * their subject: viewer, operator or admin.
+ *
+ * @generated from service rbac.v1alpha1.RBACService
+ */
+export const RBACService: GenService<{
+  /**
+   * GetCaller returns the authenticated caller and the roles it is granted
+   *
+   * @generated from rpc rbac.v1alpha1.RBACService.GetCaller
+   */
+  getCaller: {
+    methodKind: "unary";
+    input: typeof GetCallerRequestSchema;
+    output: typeof CallerSchema;
+  },
+  /**
+   * CreateAPIKey creates an API key granted the given roles. The token presenting it is only
+   * returned once.
+   *
+   * @generated from rpc rbac.v1alpha1.RBACService.CreateAPIKey
+   */
+  createAPIKey: {
+    methodKind: "unary";
+    input: typeof CreateAPIKeyRequestSchema;
+    output: typeof CreateAPIKeyResponseSchema;
+  },
+  /**
+   * @generated from rpc rbac.v1alpha1.RBACService.ListAPIKeys
+   */
+  listAPIKeys: {
+    methodKind: "unary";
+    input: typeof ListAPIKeysRequestSchema;
+    output: typeof ListAPIKeysResponseSchema;
+  },
+  /**
+   * DeleteAPIKey deletes an API key along with its role binding
+   *
+   * @generated from rpc rbac.v1alpha1.RBACService.DeleteAPIKey
+   */
+  deleteAPIKey: {
+    methodKind: "unary";
+    input: typeof DeleteAPIKeyRequestSchema;
+    output: typeof EmptySchema;
+  },
+  /**
+   * PutRoleBinding grants roles to a subject, replacing the roles it was granted
+   *
+   * @generated from rpc rbac.v1alpha1.RBACService.PutRoleBinding
+   */
+  putRoleBinding: {
+    methodKind: "unary";
+    input: typeof PutRoleBindingRequestSchema;
+    output: typeof RoleBindingSchema;
+  },
+  /**
+   * @generated from rpc rbac.v1alpha1.RBACService.ListRoleBindings
+   */
+  listRoleBindings: {
+    methodKind: "unary";
+    input: typeof ListRoleBindingsRequestSchema;
+    output: typeof ListRoleBindingsResponseSchema;
+  },
+  /**
+   * @generated from rpc rbac.v1alpha1.RBACService.DeleteRoleBinding
+   */
+  deleteRoleBinding: {
+    methodKind: "unary";
+    input: typeof DeleteRoleBindingRequestSchema;
+    output: typeof EmptySchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_pkg_api_rbac_v1alpha1_rbac, 0);
+
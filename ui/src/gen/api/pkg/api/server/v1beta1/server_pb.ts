@@ -0,0 +1,196 @@
+// @generated by protoc-gen-es v2.10.2 with parameter "target=ts"
+// @generated from file pkg/api/server/v1beta1/server.proto (package server.v1beta1, syntax proto3)
+/* eslint-disable */
+
+import type { GenEnum, GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
+import { enumDesc, fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
+import type { Duration, Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_duration, file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Message } from "@bufbuild/protobuf";
+
+/**
+ * Describes the file pkg/api/server/v1beta1/server.proto.
+ */
+export const file_pkg_api_server_v1beta1_server: GenFile = /*@__PURE__*/
+  fileDesc("CiNwa2cvYXBpL3NlcnZlci92MWJldGExL3NlcnZlci5wcm90bxIOc2VydmVyLnYxYmV0YTEiHgocR2V0U2VydmVyQ2FwYWJpbGl0aWVzUmVxdWVzdCJ9ChJTZXJ2ZXJDYXBhYmlsaXRpZXMSMAoMYXBpX3ZlcnNpb25zGAEgAygLMhouc2VydmVyLnYxYmV0YTEuQVBJVmVyc2lvbhI1ChJkZXByZWNhdGlvbl93aW5kb3cYAiABKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24iuAEKCkFQSVZlcnNpb24SCwoDYXBpGAEgASgJEg8KB3ZlcnNpb24YAiABKAkSDwoHc2VydmljZRgDIAEoCRIsCglzdGFiaWxpdHkYBCABKA4yGS5zZXJ2ZXIudjFiZXRhMS5TdGFiaWxpdHkSDwoHbWV0aG9kcxgFIAMoCRI8ChJkZXByZWNhdGVkX21ldGhvZHMYBiADKAsyIC5zZXJ2ZXIudjFiZXRhMS5EZXByZWNhdGVkTWV0aG9kIpcBChBEZXByZWNhdGVkTWV0aG9kEg4KBm1ldGhvZBgBIAEoCRIRCglzdWNjZXNzb3IYAiABKAkSMQoNZGVwcmVjYXRlZF9hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLQoJc3Vuc2V0X2F0GAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCplCglTdGFiaWxpdHkSGQoVU1RBQklMSVRZX1VOU1BFQ0lGSUVEEAASEwoPU1RBQklMSVRZX0FMUEhBEAESEgoOU1RBQklMSVRZX0JFVEEQAhIUChBTVEFCSUxJVFlfU1RBQkxFEAMyegoNU2VydmVyU2VydmljZRJpChVHZXRTZXJ2ZXJDYXBhYmlsaXRpZXMSLC5zZXJ2ZXIudjFiZXRhMS5HZXRTZXJ2ZXJDYXBhYmlsaXRpZXNSZXF1ZXN0GiIuc2VydmVyLnYxYmV0YTEuU2VydmVyQ2FwYWJpbGl0aWVzQjdaNWdpdGh1Yi5jb20vb3RlbGZsZWV0L290ZWxmbGVldC9wa2cvYXBpL3NlcnZlci92MWJldGExYgZwcm90bzM", [file_google_protobuf_duration, file_google_protobuf_timestamp]);
+
+/**
+ * @generated from message server.v1beta1.GetServerCapabilitiesRequest
+ */
+export type GetServerCapabilitiesRequest = Message<"server.v1beta1.GetServerCapabilitiesRequest"> & {
+};
+
+/**
+ * Describes the message server.v1beta1.GetServerCapabilitiesRequest.
+ * Use `create(GetServerCapabilitiesRequestSchema)` to create a new message.
+ */
+export const GetServerCapabilitiesRequestSchema: GenMessage<GetServerCapabilitiesRequest> = /*@__PURE__*/
+  messageDesc(file_pkg_api_server_v1beta1_server, 0);
+
+/**
+ * @generated from message server.v1beta1.ServerCapabilities
+ */
+export type ServerCapabilities = Message<"server.v1beta1.ServerCapabilities"> & {
+  /**
+   * @generated from field: repeated server.v1beta1.APIVersion api_versions = 1;
+   */
+  apiVersions: APIVersion[];
+
+  /**
+   * how long deprecated methods are still served once a more stable version supersedes them
+   *
+   * @generated from field: google.protobuf.Duration deprecation_window = 2;
+   */
+  deprecationWindow?: Duration;
+};
+
+/**
+ * Describes the message server.v1beta1.ServerCapabilities.
+ * Use `create(ServerCapabilitiesSchema)` to create a new message.
+ */
+export const ServerCapabilitiesSchema: GenMessage<ServerCapabilities> = /*@__PURE__*/
+  messageDesc(file_pkg_api_server_v1beta1_server, 1);
+
+/**
+ * @generated from message server.v1beta1.APIVersion
+ */
+export type APIVersion = Message<"server.v1beta1.APIVersion"> & {
+  /**
+   * name of the API, e.g. agents
+   *
+   * @generated from field: string api = 1;
+   */
+  api: string;
+
+  /**
+   * e.g. v1beta1
+   *
+   * @generated from field: string version = 2;
+   */
+  version: string;
+
+  /**
+   * fully qualified name of the service, e.g. agents.v1beta1.AgentService
+   *
+   * @generated from field: string service = 3;
+   */
+  service: string;
+
+  /**
+   * @generated from field: server.v1beta1.Stability stability = 4;
+   */
+  stability: Stability;
+
+  /**
+   * names of the methods of the service
+   *
+   * @generated from field: repeated string methods = 5;
+   */
+  methods: string[];
+
+  /**
+   * the methods superseded by a more stable version, they are served until their sunset
+   *
+   * @generated from field: repeated server.v1beta1.DeprecatedMethod deprecated_methods = 6;
+   */
+  deprecatedMethods: DeprecatedMethod[];
+};
+
+/**
+ * Describes the message server.v1beta1.APIVersion.
+ * Use `create(APIVersionSchema)` to create a new message.
+ */
+export const APIVersionSchema: GenMessage<APIVersion> = /*@__PURE__*/
+  messageDesc(file_pkg_api_server_v1beta1_server, 2);
+
+/**
+ * @generated from message server.v1beta1.DeprecatedMethod
+ */
+export type DeprecatedMethod = Message<"server.v1beta1.DeprecatedMethod"> & {
+  /**
+   * @generated from field: string method = 1;
+   */
+  method: string;
+
+  /**
+   * procedure superseding the method, e.g. /agents.v1beta1.AgentService/ListAgents
+   *
+   * @generated from field: string successor = 2;
+   */
+  successor: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp deprecated_at = 3;
+   */
+  deprecatedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp sunset_at = 4;
+   */
+  sunsetAt?: Timestamp;
+};
+
+/**
+ * Describes the message server.v1beta1.DeprecatedMethod.
+ * Use `create(DeprecatedMethodSchema)` to create a new message.
+ */
+export const DeprecatedMethodSchema: GenMessage<DeprecatedMethod> = /*@__PURE__*/
+  messageDesc(file_pkg_api_server_v1beta1_server, 3);
+
+/**
+ * Stability is the compatibility promise of an API version
+ *
+ * @generated from enum server.v1beta1.Stability
+ */
+export enum Stability {
+  /**
+   * @generated from enum value: STABILITY_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * may change incompatibly between releases
+   *
+   * @generated from enum value: STABILITY_ALPHA = 1;
+   */
+  ALPHA = 1,
+
+  /**
+   * only changes compatibly, fields, values and methods are only added
+   *
+   * @generated from enum value: STABILITY_BETA = 2;
+   */
+  BETA = 2,
+
+  /**
+   * only changes compatibly, and is served until the next major version
+   *
+   * @generated from enum value: STABILITY_STABLE = 3;
+   */
+  STABLE = 3,
+}
+
+/**
+ * Describes the enum server.v1beta1.Stability.
+ */
+export const StabilitySchema: GenEnum<Stability> = /*@__PURE__*/
+  enumDesc(file_pkg_api_server_v1beta1_server, 0);
+
+/**
+ * @generated from service server.v1beta1.ServerService
+ */
+export const ServerService: GenService<{
+  /**
+   * GetServerCapabilities returns the API versions served by the server, so that clients can
+   * pick the most stable version of each API they use
+   *
+   * @generated from rpc server.v1beta1.ServerService.GetServerCapabilities
+   */
+  getServerCapabilities: {
+    methodKind: "unary";
+    input: typeof GetServerCapabilitiesRequestSchema;
+    output: typeof ServerCapabilitiesSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_pkg_api_server_v1beta1_server, 0);
+
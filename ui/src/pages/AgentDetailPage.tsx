@@ -8,6 +8,7 @@ import {
 } from '../gen/api/pkg/api/agents/v1alpha1/agents_pb';
 import type {
     AgentDescription,
+    AgentMetrics,
     AgentStatus,
     ComponentHealth,
     KeyValue,
@@ -94,6 +95,13 @@ export function AgentDetailPage({ agentId }: AgentDetailPageProps) {
                     message: `Successfully assigned config "${selectedConfig}" to agent`,
                     icon: <CheckCircledIcon />,
                 });
+                if (response.warnings.length > 0) {
+                    notifications.show({
+                        title: 'Agent may lack resources for this config',
+                        message: response.warnings.join('; '),
+                        color: 'yellow',
+                    });
+                }
                 fetchConfigAssignment();
             } else {
                 notifications.show({
@@ -191,7 +199,7 @@ export function AgentDetailPage({ agentId }: AgentDetailPageProps) {
                     </Tabs.List>
 
                     <Tabs.Panel value="health" pt="md" style={{ flex: 1 }}>
-                        <HealthTab health={status?.health} />
+                        <HealthTab health={status?.health} metrics={status?.metrics} />
                     </Tabs.Panel>
 
                     <Tabs.Panel value="details" pt="md" style={{ flex: 1 }}>
@@ -254,6 +262,7 @@ function AgentHeader({ agent, status }: { agent: AgentDescription | null; status
         [ConfigSyncStatusEnum.OUT_OF_SYNC]: { color: 'yellow', label: 'Out of Sync' },
         [ConfigSyncStatusEnum.APPLYING]: { color: 'blue', label: 'Applying' },
         [ConfigSyncStatusEnum.ERROR]: { color: 'red', label: 'Error' },
+        [ConfigSyncStatusEnum.UNSUPPORTED]: { color: 'gray', label: 'Unsupported' },
     };
 
     const configStatus = configSyncStatusMap[status?.configSyncStatus ?? 0] ?? { color: 'gray', label: 'Unknown' };
@@ -350,8 +359,8 @@ function ConfigAssignmentSection({
     );
 }
 
-function HealthTab({ health }: { health?: ComponentHealth }) {
-    if (!health) {
+function HealthTab({ health, metrics }: { health?: ComponentHealth; metrics?: AgentMetrics }) {
+    if (!health && !metrics) {
         return (
             <Alert color="gray" title="No Health Data">
                 No health information available for this agent.
@@ -361,12 +370,84 @@ function HealthTab({ health }: { health?: ComponentHealth }) {
 
     return (
         <Stack gap="md">
-            <HealthOverview health={health} />
-            <ComponentHealthTable health={health} />
+            {health && <HealthOverview health={health} />}
+            {metrics && <CollectorMetrics metrics={metrics} />}
+            {health && <ComponentHealthTable health={health} />}
         </Stack>
     );
 }
 
+function formatBytes(bytes: bigint): string {
+    const units = ['B', 'KiB', 'MiB', 'GiB'];
+    let value = Number(bytes);
+    let unit = 0;
+    while (value >= 1024 && unit < units.length - 1) {
+        value /= 1024;
+        unit++;
+    }
+    return `${value.toFixed(unit === 0 ? 0 : 1)} ${units[unit]}`;
+}
+
+function CollectorMetrics({ metrics }: { metrics: AgentMetrics }) {
+    const receivedAt = metrics.receivedAt
+        ? new Date(Number(metrics.receivedAt.seconds) * 1000).toLocaleString()
+        : 'N/A';
+    const uptime = metrics.uptime ? `${Math.floor(Number(metrics.uptime.seconds) / 60)} minutes` : 'N/A';
+
+    return (
+        <Paper p="md" withBorder>
+            <Title order={4} mb="md">Collector Metrics</Title>
+            <Group gap="xl">
+                <Stack gap="xs">
+                    <Text size="sm" c="dimmed">CPU</Text>
+                    <Text>{(metrics.cpuUtilization * 100).toFixed(1)}%</Text>
+                </Stack>
+                <Stack gap="xs">
+                    <Text size="sm" c="dimmed">Memory (RSS)</Text>
+                    <Text>{formatBytes(metrics.memoryRssBytes)}</Text>
+                </Stack>
+                <Stack gap="xs">
+                    <Text size="sm" c="dimmed">Heap</Text>
+                    <Text>{formatBytes(metrics.heapAllocBytes)}</Text>
+                </Stack>
+                <Stack gap="xs">
+                    <Text size="sm" c="dimmed">Uptime</Text>
+                    <Text>{uptime}</Text>
+                </Stack>
+                <Stack gap="xs">
+                    <Text size="sm" c="dimmed">Last Report</Text>
+                    <Text>{receivedAt}</Text>
+                </Stack>
+            </Group>
+            {metrics.exporterQueues.length > 0 && (
+                <Table mt="md">
+                    <Table.Thead>
+                        <Table.Tr>
+                            <Table.Th>Exporter</Table.Th>
+                            <Table.Th>Queue Size</Table.Th>
+                            <Table.Th>Queue Capacity</Table.Th>
+                        </Table.Tr>
+                    </Table.Thead>
+                    <Table.Tbody>
+                        {metrics.exporterQueues.map((queue) => {
+                            const full = queue.capacity > 0n && queue.size * 10n >= queue.capacity * 9n;
+                            return (
+                                <Table.Tr key={queue.exporter}>
+                                    <Table.Td>{queue.exporter}</Table.Td>
+                                    <Table.Td>
+                                        <Text c={full ? 'red' : undefined}>{queue.size.toString()}</Text>
+                                    </Table.Td>
+                                    <Table.Td>{queue.capacity.toString()}</Table.Td>
+                                </Table.Tr>
+                            );
+                        })}
+                    </Table.Tbody>
+                </Table>
+            )}
+        </Paper>
+    );
+}
+
 function HealthOverview({ health }: { health: ComponentHealth }) {
     const formatTime = (nanos: bigint) => {
         if (!nanos) return 'N/A';
@@ -1,11 +1,11 @@
-import { AgentService, AgentState as AgentStateEnum, ConfigSyncStatus as ConfigSyncStatusEnum } from '../gen/api/pkg/api/agents/v1alpha1/agents_pb';
-import type { AgentDescriptionAndStatus, AgentState, ComponentHealth, ConfigSyncStatus } from '../gen/api/pkg/api/agents/v1alpha1/agents_pb';
+import { AgentService, AgentState as AgentStateEnum, AgentStatusView, ConfigSyncStatus as ConfigSyncStatusEnum } from '../gen/api/pkg/api/agents/v1alpha1/agents_pb';
+import type { AgentDescriptionAndStatus, AgentLoadError, AgentState, ComponentHealth, ConfigSyncStatus } from '../gen/api/pkg/api/agents/v1alpha1/agents_pb';
 import { ConfigService, ConfigApplicationStatus } from '../gen/api/pkg/api/config/v1alpha1/config_pb';
 import type { ConfigReference, ConfigAssignmentInfo } from '../gen/api/pkg/api/config/v1alpha1/config_pb';
 import { useClient } from '../api';
 import { useEffect, useState, useCallback, useMemo } from 'react';
 import { notifyGRPCError } from '../api/notifications';
-import { Badge, Tooltip, Text, Button, Group, Modal, Select, Stack, Paper, ActionIcon } from '@mantine/core';
+import { Alert, Badge, Tooltip, Text, Button, Group, Modal, Select, Stack, Paper, ActionIcon } from '@mantine/core';
 import { useDisclosure } from '@mantine/hooks';
 import { notifications } from '@mantine/notifications';
 import { Link } from '@tanstack/react-router';
@@ -52,6 +52,7 @@ function ConfigSyncStatusBadge({ status, reason }: { status?: ConfigSyncStatus;
         [ConfigSyncStatusEnum.OUT_OF_SYNC]: { color: 'yellow', label: 'Out of Sync' },
         [ConfigSyncStatusEnum.APPLYING]: { color: 'blue', label: 'Applying' },
         [ConfigSyncStatusEnum.ERROR]: { color: 'red', label: 'Error' },
+        [ConfigSyncStatusEnum.UNSUPPORTED]: { color: 'gray', label: 'Unsupported' },
     };
 
     const { color, label } = statusMap[status ?? 0] ?? { color: 'gray', label: 'Unknown' };
@@ -75,6 +76,7 @@ function AssignedConfigBadge({ assignment }: { assignment?: ConfigAssignmentInfo
         [ConfigApplicationStatus.PENDING]: { color: 'yellow', label: 'Pending' },
         [ConfigApplicationStatus.APPLIED]: { color: 'green', label: 'Applied' },
         [ConfigApplicationStatus.FAILED]: { color: 'red', label: 'Failed' },
+        [ConfigApplicationStatus.UNSUPPORTED]: { color: 'gray', label: 'Unsupported' },
     };
 
     const { color, label } = statusMap[assignment.status] ?? { color: 'gray', label: '' };
@@ -94,6 +96,7 @@ export const AgentPage = () => {
     const configClient = useClient(ConfigService);
 
     const [agentsState, setAgentsState] = useState<AgentDescriptionAndStatus[]>([]);
+    const [loadErrors, setLoadErrors] = useState<AgentLoadError[]>([]);
     const [assignments, setAssignments] = useState<Map<string, ConfigAssignmentInfo>>(new Map());
     const [availableConfigs, setAvailableConfigs] = useState<ConfigReference[]>([]);
     const [selectedAgents, setSelectedAgents] = useState<Set<string | number>>(new Set());
@@ -108,8 +111,11 @@ export const AgentPage = () => {
         try {
             const response = await agentClient.listAgents({
                 withStatus: true,
+                // the list doesn't show effective configs
+                view: AgentStatusView.HEALTH,
             });
             setAgentsState(response.agents);
+            setLoadErrors(response.errors);
         } catch (error) {
             notifyGRPCError("Failed to list agents", error);
         }
@@ -291,6 +297,16 @@ export const AgentPage = () => {
                 </Paper>
             )}
 
+            {loadErrors.length > 0 && (
+                <Alert color="yellow" mb="md" title={`${loadErrors.length} agent${loadErrors.length > 1 ? 's' : ''} could not be loaded`}>
+                    {loadErrors.map((e) => (
+                        <Text key={e.agentId} size="sm">
+                            {e.agentId}: {e.message}
+                        </Text>
+                    ))}
+                </Alert>
+            )}
+
             <Table<AgentDescriptionAndStatus>
                 title="OpenTelemetry Collector agents"
                 data={agentsState}
@@ -340,8 +356,9 @@ export const AgentPage = () => {
                         Are you sure you want to delete agent <Text span fw={700}>{agentToDelete?.name}</Text>?
                     </Text>
                     <Text size="sm" c="dimmed">
-                        This will permanently remove the agent and all its associated data including
-                        health status, configuration state, and connection history. This action cannot be undone.
+                        This will disconnect the agent if it is connected and hide it. The agent is restored
+                        if it reconnects within the deletion grace period, after which its config assignment
+                        is revoked and it is permanently removed along with all its associated data.
                     </Text>
                     <Group justify="flex-end" mt="md">
                         <Button variant="default" onClick={closeDeleteModal}>Cancel</Button>
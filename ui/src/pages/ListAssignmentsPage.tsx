@@ -45,6 +45,7 @@ function ApplicationStatusBadge({ status, errorMessage }: { status: ConfigApplic
         [ConfigApplicationStatus.PENDING]: { color: 'yellow', label: 'Pending' },
         [ConfigApplicationStatus.APPLIED]: { color: 'green', label: 'Applied' },
         [ConfigApplicationStatus.FAILED]: { color: 'red', label: 'Failed' },
+        [ConfigApplicationStatus.UNSUPPORTED]: { color: 'gray', label: 'Unsupported' },
     }[status] ?? { color: 'gray', label: 'Unknown' };
 
     return (
@@ -206,7 +207,14 @@ export function ListAssignmentsPage() {
             label: 'Status',
             visible: true,
             render: (value: unknown, row: ConfigAssignmentInfo) => (
-                <ApplicationStatusBadge status={value as ConfigApplicationStatus} errorMessage={row.errorMessage} />
+                <Group gap="xs" justify="center">
+                    <ApplicationStatusBadge status={value as ConfigApplicationStatus} errorMessage={row.errorMessage} />
+                    {row.rollback && (
+                        <Tooltip label={`${row.rollback.failedConfigId || 'default config'} failed to apply: ${row.rollback.errorMessage}`}>
+                            <Badge color="orange" variant="light">Rolled back</Badge>
+                        </Tooltip>
+                    )}
+                </Group>
             ),
         },
         {
@@ -238,6 +246,7 @@ export function ListAssignmentsPage() {
         { value: String(ConfigApplicationStatus.PENDING), label: 'Pending' },
         { value: String(ConfigApplicationStatus.APPLIED), label: 'Applied' },
         { value: String(ConfigApplicationStatus.FAILED), label: 'Failed' },
+        { value: String(ConfigApplicationStatus.UNSUPPORTED), label: 'Unsupported' },
     ];
 
     const sourceOptions = [